@@ -21,7 +21,16 @@ type Access interface {
 
 type access struct {
 	*jwt.Token
-	action string
+	action       string
+	pipelineName string
+}
+
+// apiTokenClaims are the claims of tokens minted for an atc.APIToken, which
+// are authorized by their scopes rather than by team roles.
+type apiTokenClaims struct {
+	ID     int                 `mapstructure:"id"`
+	Team   string              `mapstructure:"team"`
+	Scopes []atc.APITokenScope `mapstructure:"scopes"`
 }
 
 func (a *access) HasToken() bool {
@@ -41,7 +50,37 @@ func (a *access) Claims() jwt.MapClaims {
 	return jwt.MapClaims{}
 }
 
+func (a *access) apiToken() (apiTokenClaims, bool) {
+	var claims apiTokenClaims
+
+	apiTokenClaim, ok := a.Claims()["api_token"]
+	if !ok {
+		return claims, false
+	}
+
+	err := mapstructure.Decode(apiTokenClaim, &claims)
+	if err != nil {
+		return claims, false
+	}
+
+	return claims, true
+}
+
 func (a *access) IsAuthorized(team string) bool {
+	if apiToken, ok := a.apiToken(); ok {
+		if apiToken.Team != team {
+			return false
+		}
+
+		for _, scope := range apiToken.Scopes {
+			if a.inScope(scope) {
+				return true
+			}
+		}
+
+		return false
+	}
+
 	if a.IsAdmin() {
 		return true
 	}
@@ -73,6 +112,22 @@ func (a *access) hasPermission(role string) bool {
 	}
 }
 
+func (a *access) inScope(scope atc.APITokenScope) bool {
+	if scope.Pipeline != "" && scope.Pipeline != a.pipelineName {
+		return false
+	}
+
+	if scope.Action == atc.APITokenReadOnly {
+		return requiredRoles[a.action] == "viewer"
+	}
+
+	if scope.Action == atc.APITokenEmbed {
+		return atc.EmbedActions[a.action]
+	}
+
+	return scope.Action == a.action
+}
+
 func (a *access) IsAdmin() bool {
 	if isAdminClaim, ok := a.Claims()["is_admin"]; ok {
 		isAdmin, ok := isAdminClaim.(bool)
@@ -90,6 +145,16 @@ func (a *access) IsSystem() bool {
 }
 
 func (a *access) TeamNames() []string {
+	// api tokens only see the whole of their team if they may read all of it
+	if apiToken, ok := a.apiToken(); ok {
+		for _, scope := range apiToken.Scopes {
+			if scope.Action == atc.APITokenReadOnly && scope.Pipeline == "" {
+				return []string{apiToken.Team}
+			}
+		}
+
+		return []string{}
+	}
 
 	teams := []string{}
 	for teamName := range a.TeamRoles() {
@@ -148,24 +213,38 @@ var requiredRoles = map[string]string{
 	atc.GetBuild:                      "viewer",
 	atc.GetCheck:                      "viewer",
 	atc.GetBuildPlan:                  "viewer",
+	atc.GetBuildArtifactRegistry:      "viewer",
 	atc.CreateBuild:                   "member",
 	atc.ListBuilds:                    "viewer",
 	atc.BuildEvents:                   "viewer",
+	atc.MultiplexBuildEvents:          "viewer",
 	atc.BuildResources:                "viewer",
 	atc.AbortBuild:                    "pipeline-operator",
+	atc.PauseBuild:                    "pipeline-operator",
+	atc.ResumeBuild:                   "pipeline-operator",
+	atc.RerunBuild:                    "pipeline-operator",
+	atc.AnnotateBuild:                 "pipeline-operator",
 	atc.GetBuildPreparation:           "viewer",
+	atc.ExplainBuild:                  "viewer",
+	atc.GetBuildSnapshot:              "viewer",
+	atc.GetBuildTimeline:              "viewer",
+	atc.GetBuildPlanGraph:             "viewer",
 	atc.GetJob:                        "viewer",
 	atc.CreateJobBuild:                "pipeline-operator",
 	atc.ListAllJobs:                   "viewer",
 	atc.ListJobs:                      "viewer",
 	atc.ListJobBuilds:                 "viewer",
 	atc.ListJobInputs:                 "viewer",
+	atc.DryRunJobSchedule:             "viewer",
+	atc.GetJobPlanGraph:               "viewer",
+	atc.GetJobStatistics:              "viewer",
 	atc.GetJobBuild:                   "viewer",
 	atc.PauseJob:                      "pipeline-operator",
 	atc.UnpauseJob:                    "pipeline-operator",
 	atc.GetVersionsDB:                 "viewer",
 	atc.JobBadge:                      "viewer",
 	atc.MainJobBadge:                  "viewer",
+	atc.JobStatus:                     "viewer",
 	atc.ClearTaskCache:                "pipeline-operator",
 	atc.ListAllResources:              "viewer",
 	atc.ListResources:                 "viewer",
@@ -176,27 +255,35 @@ var requiredRoles = map[string]string{
 	atc.CheckResource:                 "pipeline-operator",
 	atc.CheckResourceWebHook:          "pipeline-operator",
 	atc.CheckResourceType:             "pipeline-operator",
+	atc.ListResourceChecks:            "viewer",
 	atc.ListResourceVersions:          "viewer",
 	atc.GetResourceVersion:            "viewer",
 	atc.EnableResourceVersion:         "pipeline-operator",
 	atc.DisableResourceVersion:        "pipeline-operator",
 	atc.PinResourceVersion:            "pipeline-operator",
+	atc.UpdateResourceVersionMetadata: "pipeline-operator",
 	atc.ListBuildsWithVersionAsInput:  "viewer",
 	atc.ListBuildsWithVersionAsOutput: "viewer",
 	atc.GetResourceCausality:          "viewer",
+	atc.ListResourceVersionSpan:       "viewer",
 	atc.ListAllPipelines:              "viewer",
 	atc.ListPipelines:                 "viewer",
 	atc.GetPipeline:                   "viewer",
+	atc.GetPipelineGraph:              "viewer",
+	atc.GetPipelineScheduling:         "viewer",
 	atc.DeletePipeline:                "member",
 	atc.OrderPipelines:                "member",
 	atc.PausePipeline:                 "pipeline-operator",
 	atc.UnpausePipeline:               "pipeline-operator",
 	atc.ExposePipeline:                "member",
+	atc.SharePipeline:                 "member",
+	atc.UnsharePipeline:               "member",
 	atc.HidePipeline:                  "member",
 	atc.RenamePipeline:                "member",
 	atc.ListPipelineBuilds:            "viewer",
 	atc.CreatePipelineBuild:           "member",
 	atc.PipelineBadge:                 "viewer",
+	atc.PipelineStatus:                "viewer",
 	atc.RegisterWorker:                "member",
 	atc.LandWorker:                    "member",
 	atc.RetireWorker:                  "member",
@@ -204,6 +291,8 @@ var requiredRoles = map[string]string{
 	atc.HeartbeatWorker:               "member",
 	atc.ListWorkers:                   "viewer",
 	atc.DeleteWorker:                  "member",
+	atc.ListWorkerDiscrepancies:       "viewer",
+	atc.ResolveWorkerDiscrepancies:    "member",
 	atc.SetLogLevel:                   "member",
 	atc.GetLogLevel:                   "viewer",
 	atc.DownloadCLI:                   "viewer",
@@ -212,6 +301,7 @@ var requiredRoles = map[string]string{
 	atc.ListContainers:                "viewer",
 	atc.GetContainer:                  "viewer",
 	atc.HijackContainer:               "member",
+	atc.HijackCheckContainer:          "member",
 	atc.ListDestroyingContainers:      "viewer",
 	atc.ReportWorkerContainers:        "member",
 	atc.ListVolumes:                   "viewer",
@@ -222,8 +312,16 @@ var requiredRoles = map[string]string{
 	atc.SetTeam:                       "owner",
 	atc.RenameTeam:                    "owner",
 	atc.DestroyTeam:                   "owner",
+	atc.RestoreTeam:                   "owner",
 	atc.ListTeamBuilds:                "viewer",
+	atc.GetPipelinesRepoStatus:        "viewer",
+	atc.GetResourceTypeMappings:       "viewer",
+	atc.GetResourceTypeHealth:         "viewer",
+	atc.ListAPITokens:                 "owner",
+	atc.CreateAPIToken:                "owner",
+	atc.RevokeAPIToken:                "owner",
 	atc.CreateArtifact:                "member",
 	atc.GetArtifact:                   "member",
 	atc.ListBuildArtifacts:            "viewer",
+	atc.DownloadBuildArtifact:         "viewer",
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/concourse/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -15,33 +16,70 @@ type AccessFactory interface {
 	Create(*http.Request, string) Access
 }
 
+//go:generate counterfeiter . TokenRevocations
+
+// TokenRevocations is checked for each request made with an API token, so
+// that revoked tokens stop working before they expire.
+type TokenRevocations interface {
+	IsRevoked(tokenID int) (bool, error)
+}
+
 type accessFactory struct {
-	publicKey *rsa.PublicKey
+	publicKey   *rsa.PublicKey
+	revocations TokenRevocations
 }
 
-func NewAccessFactory(key *rsa.PublicKey) AccessFactory {
+func NewAccessFactory(key *rsa.PublicKey, revocations TokenRevocations) AccessFactory {
 	return &accessFactory{
-		publicKey: key,
+		publicKey:   key,
+		revocations: revocations,
 	}
 }
 
 func (a *accessFactory) Create(r *http.Request, action string) Access {
 
+	pipelineName := r.URL.Query().Get(":pipeline_name")
+
 	header := r.Header.Get("Authorization")
+
+	embedded := false
+	if header == "" && atc.EmbedActions[action] {
+		if token := r.URL.Query().Get(atc.EmbedTokenQuery); token != "" {
+			header = "Bearer " + token
+			embedded = true
+		}
+	}
+
 	if header == "" {
-		return &access{nil, action}
+		return &access{nil, action, pipelineName}
 	}
 
 	if len(header) < 7 || strings.ToUpper(header[0:6]) != "BEARER" {
-		return &access{&jwt.Token{}, action}
+		return &access{&jwt.Token{}, action, pipelineName}
 	}
 
 	token, err := jwt.Parse(header[7:], a.validate)
 	if err != nil {
-		return &access{&jwt.Token{}, action}
+		return &access{&jwt.Token{}, action, pipelineName}
+	}
+
+	acc := &access{token, action, pipelineName}
+
+	apiToken, ok := acc.apiToken()
+	if ok {
+		revoked, err := a.revocations.IsRevoked(apiToken.ID)
+		if err != nil || revoked {
+			return &access{&jwt.Token{}, action, pipelineName}
+		}
+	}
+
+	// only API tokens may be given in the query, so that the tokens of users
+	// don't end up in pages and logs
+	if embedded && !ok {
+		return &access{&jwt.Token{}, action, pipelineName}
 	}
 
-	return &access{token, action}
+	return acc
 }
 
 func (a *accessFactory) validate(token *jwt.Token) (interface{}, error) {
@@ -3,11 +3,13 @@ package accessor_test
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	jwt "github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -18,6 +20,7 @@ var _ = Describe("Accessor", func() {
 	var (
 		req             *http.Request
 		key             *rsa.PrivateKey
+		fakeRevocations *accessorfakes.FakeTokenRevocations
 		accessorFactory accessor.AccessFactory
 		claims          *jwt.MapClaims
 		access          accessor.Access
@@ -35,7 +38,8 @@ var _ = Describe("Accessor", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		publicKey := &key.PublicKey
-		accessorFactory = accessor.NewAccessFactory(publicKey)
+		fakeRevocations = new(accessorfakes.FakeTokenRevocations)
+		accessorFactory = accessor.NewAccessFactory(publicKey, fakeRevocations)
 
 	})
 
@@ -276,6 +280,200 @@ var _ = Describe("Accessor", func() {
 		})
 	})
 
+	Describe("API tokens", func() {
+		var action string
+
+		BeforeEach(func() {
+			action = atc.CreateJobBuild
+			claims = &jwt.MapClaims{
+				"user_name": "api-token:deployer",
+				"api_token": map[string]interface{}{
+					"id":   42,
+					"team": "some-team",
+					"scopes": []atc.APITokenScope{
+						{Action: atc.CreateJobBuild, Pipeline: "some-pipeline"},
+						{Action: atc.APITokenReadOnly, Pipeline: "other-pipeline"},
+					},
+				},
+			}
+
+			req.URL.RawQuery = ":pipeline_name=some-pipeline"
+		})
+
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req, action)
+		})
+
+		It("checks whether the token was revoked", func() {
+			Expect(fakeRevocations.IsRevokedCallCount()).To(Equal(1))
+			Expect(fakeRevocations.IsRevokedArgsForCall(0)).To(Equal(42))
+		})
+
+		It("allows the actions of its scopes", func() {
+			Expect(access.IsAuthenticated()).To(BeTrue())
+			Expect(access.IsAuthorized("some-team")).To(BeTrue())
+			Expect(access.UserName()).To(Equal("api-token:deployer"))
+		})
+
+		It("does not allow them for other teams", func() {
+			Expect(access.IsAuthorized("other-team")).To(BeFalse())
+		})
+
+		It("only shows its team when it may read all of it", func() {
+			Expect(access.TeamNames()).To(BeEmpty())
+		})
+
+		Context("when the action is not in its scopes", func() {
+			BeforeEach(func() {
+				action = atc.PausePipeline
+			})
+
+			It("is not authorized", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+		})
+
+		Context("when the request is for another pipeline", func() {
+			BeforeEach(func() {
+				req.URL.RawQuery = ":pipeline_name=other-pipeline"
+			})
+
+			It("is not authorized", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+
+			Context("for a read-only action", func() {
+				BeforeEach(func() {
+					action = atc.GetPipeline
+				})
+
+				It("is authorized by the read-only scope", func() {
+					Expect(access.IsAuthorized("some-team")).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the token claims admin", func() {
+			BeforeEach(func() {
+				(*claims)["is_admin"] = true
+				action = atc.PausePipeline
+			})
+
+			It("is still limited to its scopes", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+		})
+
+		Context("when the token has been revoked", func() {
+			BeforeEach(func() {
+				fakeRevocations.IsRevokedReturns(true, nil)
+			})
+
+			It("is not authenticated", func() {
+				Expect(access.HasToken()).To(BeTrue())
+				Expect(access.IsAuthenticated()).To(BeFalse())
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+		})
+
+		Context("when checking for revocation fails", func() {
+			BeforeEach(func() {
+				fakeRevocations.IsRevokedReturns(false, errors.New("nope"))
+			})
+
+			It("is not authenticated", func() {
+				Expect(access.IsAuthenticated()).To(BeFalse())
+			})
+		})
+
+		Context("when the token has the embed scope", func() {
+			BeforeEach(func() {
+				(*claims)["api_token"] = map[string]interface{}{
+					"id":   42,
+					"team": "some-team",
+					"scopes": []atc.APITokenScope{
+						{Action: atc.APITokenEmbed, Pipeline: "some-pipeline"},
+					},
+				}
+
+				action = atc.JobBadge
+			})
+
+			It("allows badges and statuses", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeTrue())
+			})
+
+			Context("for another read-only action", func() {
+				BeforeEach(func() {
+					action = atc.GetPipeline
+				})
+
+				It("is not authorized", func() {
+					Expect(access.IsAuthorized("some-team")).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("tokens given in the query", func() {
+		var action string
+
+		BeforeEach(func() {
+			action = atc.PipelineStatus
+			claims = &jwt.MapClaims{
+				"api_token": map[string]interface{}{
+					"id":   42,
+					"team": "some-team",
+					"scopes": []atc.APITokenScope{
+						{Action: atc.APITokenEmbed, Pipeline: "some-pipeline"},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.URL.RawQuery = ":pipeline_name=some-pipeline&" + atc.EmbedTokenQuery + "=" + tokenString
+			access = accessorFactory.Create(req, action)
+		})
+
+		It("authorizes badges and statuses with api tokens", func() {
+			Expect(access.IsAuthenticated()).To(BeTrue())
+			Expect(access.IsAuthorized("some-team")).To(BeTrue())
+		})
+
+		Context("for other actions", func() {
+			BeforeEach(func() {
+				action = atc.GetPipeline
+			})
+
+			It("ignores the token", func() {
+				Expect(access.HasToken()).To(BeFalse())
+			})
+		})
+
+		Context("when the token is not an api token", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{
+					"teams": map[string][]string{"some-team": {"owner"}},
+				}
+			})
+
+			It("is not authenticated", func() {
+				Expect(access.IsAuthenticated()).To(BeFalse())
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Get CSRF Token", func() {
 		JustBeforeEach(func() {
 			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
@@ -477,6 +675,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetBuildPlan, atc.GetBuildPlan, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetBuildPlan, atc.GetBuildPlan, "viewer", true),
 
+		Entry("owner :: "+atc.GetBuildArtifactRegistry, atc.GetBuildArtifactRegistry, "owner", true),
+		Entry("member :: "+atc.GetBuildArtifactRegistry, atc.GetBuildArtifactRegistry, "member", true),
+		Entry("pipeline-operator :: "+atc.GetBuildArtifactRegistry, atc.GetBuildArtifactRegistry, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetBuildArtifactRegistry, atc.GetBuildArtifactRegistry, "viewer", true),
+
 		Entry("owner :: "+atc.CreateBuild, atc.CreateBuild, "owner", true),
 		Entry("member :: "+atc.CreateBuild, atc.CreateBuild, "member", true),
 		Entry("pipeline-operator :: "+atc.CreateBuild, atc.CreateBuild, "pipeline-operator", false),
@@ -492,6 +695,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.BuildEvents, atc.BuildEvents, "pipeline-operator", true),
 		Entry("viewer :: "+atc.BuildEvents, atc.BuildEvents, "viewer", true),
 
+		Entry("owner :: "+atc.MultiplexBuildEvents, atc.MultiplexBuildEvents, "owner", true),
+		Entry("member :: "+atc.MultiplexBuildEvents, atc.MultiplexBuildEvents, "member", true),
+		Entry("pipeline-operator :: "+atc.MultiplexBuildEvents, atc.MultiplexBuildEvents, "pipeline-operator", true),
+		Entry("viewer :: "+atc.MultiplexBuildEvents, atc.MultiplexBuildEvents, "viewer", true),
+
 		Entry("owner :: "+atc.BuildResources, atc.BuildResources, "owner", true),
 		Entry("member :: "+atc.BuildResources, atc.BuildResources, "member", true),
 		Entry("pipeline-operator :: "+atc.BuildResources, atc.BuildResources, "pipeline-operator", true),
@@ -502,11 +710,51 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.AbortBuild, atc.AbortBuild, "pipeline-operator", true),
 		Entry("viewer :: "+atc.AbortBuild, atc.AbortBuild, "viewer", false),
 
+		Entry("owner :: "+atc.PauseBuild, atc.PauseBuild, "owner", true),
+		Entry("member :: "+atc.PauseBuild, atc.PauseBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.PauseBuild, atc.PauseBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.PauseBuild, atc.PauseBuild, "viewer", false),
+
+		Entry("owner :: "+atc.ResumeBuild, atc.ResumeBuild, "owner", true),
+		Entry("member :: "+atc.ResumeBuild, atc.ResumeBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.ResumeBuild, atc.ResumeBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ResumeBuild, atc.ResumeBuild, "viewer", false),
+
+		Entry("owner :: "+atc.RerunBuild, atc.RerunBuild, "owner", true),
+		Entry("member :: "+atc.RerunBuild, atc.RerunBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.RerunBuild, atc.RerunBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.RerunBuild, atc.RerunBuild, "viewer", false),
+
+		Entry("owner :: "+atc.AnnotateBuild, atc.AnnotateBuild, "owner", true),
+		Entry("member :: "+atc.AnnotateBuild, atc.AnnotateBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.AnnotateBuild, atc.AnnotateBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.AnnotateBuild, atc.AnnotateBuild, "viewer", false),
+
 		Entry("owner :: "+atc.GetBuildPreparation, atc.GetBuildPreparation, "owner", true),
 		Entry("member :: "+atc.GetBuildPreparation, atc.GetBuildPreparation, "member", true),
 		Entry("pipeline-operator :: "+atc.GetBuildPreparation, atc.GetBuildPreparation, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetBuildPreparation, atc.GetBuildPreparation, "viewer", true),
 
+		Entry("owner :: "+atc.ExplainBuild, atc.ExplainBuild, "owner", true),
+		Entry("member :: "+atc.ExplainBuild, atc.ExplainBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.ExplainBuild, atc.ExplainBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ExplainBuild, atc.ExplainBuild, "viewer", true),
+
+		Entry("owner :: "+atc.GetBuildSnapshot, atc.GetBuildSnapshot, "owner", true),
+		Entry("member :: "+atc.GetBuildSnapshot, atc.GetBuildSnapshot, "member", true),
+		Entry("pipeline-operator :: "+atc.GetBuildSnapshot, atc.GetBuildSnapshot, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetBuildSnapshot, atc.GetBuildSnapshot, "viewer", true),
+
+		Entry("owner :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "owner", true),
+		Entry("member :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "member", true),
+		Entry("pipeline-operator :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "viewer", true),
+
+		Entry("owner :: "+atc.GetBuildPlanGraph, atc.GetBuildPlanGraph, "owner", true),
+		Entry("member :: "+atc.GetBuildPlanGraph, atc.GetBuildPlanGraph, "member", true),
+		Entry("pipeline-operator :: "+atc.GetBuildPlanGraph, atc.GetBuildPlanGraph, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetBuildPlanGraph, atc.GetBuildPlanGraph, "viewer", true),
+
 		Entry("owner :: "+atc.GetJob, atc.GetJob, "owner", true),
 		Entry("member :: "+atc.GetJob, atc.GetJob, "member", true),
 		Entry("pipeline-operator :: "+atc.GetJob, atc.GetJob, "pipeline-operator", true),
@@ -537,6 +785,21 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.ListJobInputs, atc.ListJobInputs, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListJobInputs, atc.ListJobInputs, "viewer", true),
 
+		Entry("owner :: "+atc.DryRunJobSchedule, atc.DryRunJobSchedule, "owner", true),
+		Entry("member :: "+atc.DryRunJobSchedule, atc.DryRunJobSchedule, "member", true),
+		Entry("pipeline-operator :: "+atc.DryRunJobSchedule, atc.DryRunJobSchedule, "pipeline-operator", true),
+		Entry("viewer :: "+atc.DryRunJobSchedule, atc.DryRunJobSchedule, "viewer", true),
+
+		Entry("owner :: "+atc.GetJobPlanGraph, atc.GetJobPlanGraph, "owner", true),
+		Entry("member :: "+atc.GetJobPlanGraph, atc.GetJobPlanGraph, "member", true),
+		Entry("pipeline-operator :: "+atc.GetJobPlanGraph, atc.GetJobPlanGraph, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetJobPlanGraph, atc.GetJobPlanGraph, "viewer", true),
+
+		Entry("owner :: "+atc.GetJobStatistics, atc.GetJobStatistics, "owner", true),
+		Entry("member :: "+atc.GetJobStatistics, atc.GetJobStatistics, "member", true),
+		Entry("pipeline-operator :: "+atc.GetJobStatistics, atc.GetJobStatistics, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetJobStatistics, atc.GetJobStatistics, "viewer", true),
+
 		Entry("owner :: "+atc.GetJobBuild, atc.GetJobBuild, "owner", true),
 		Entry("member :: "+atc.GetJobBuild, atc.GetJobBuild, "member", true),
 		Entry("pipeline-operator :: "+atc.GetJobBuild, atc.GetJobBuild, "pipeline-operator", true),
@@ -567,6 +830,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.MainJobBadge, atc.MainJobBadge, "pipeline-operator", true),
 		Entry("viewer :: "+atc.MainJobBadge, atc.MainJobBadge, "viewer", true),
 
+		Entry("owner :: "+atc.JobStatus, atc.JobStatus, "owner", true),
+		Entry("member :: "+atc.JobStatus, atc.JobStatus, "member", true),
+		Entry("pipeline-operator :: "+atc.JobStatus, atc.JobStatus, "pipeline-operator", true),
+		Entry("viewer :: "+atc.JobStatus, atc.JobStatus, "viewer", true),
+
 		Entry("owner :: "+atc.ClearTaskCache, atc.ClearTaskCache, "owner", true),
 		Entry("member :: "+atc.ClearTaskCache, atc.ClearTaskCache, "member", true),
 		Entry("pipeline-operator :: "+atc.ClearTaskCache, atc.ClearTaskCache, "pipeline-operator", true),
@@ -607,6 +875,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.CheckResourceType, atc.CheckResourceType, "pipeline-operator", true),
 		Entry("viewer :: "+atc.CheckResourceType, atc.CheckResourceType, "viewer", false),
 
+		Entry("owner :: "+atc.ListResourceChecks, atc.ListResourceChecks, "owner", true),
+		Entry("member :: "+atc.ListResourceChecks, atc.ListResourceChecks, "member", true),
+		Entry("pipeline-operator :: "+atc.ListResourceChecks, atc.ListResourceChecks, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListResourceChecks, atc.ListResourceChecks, "viewer", true),
+
 		Entry("owner :: "+atc.ListResourceVersions, atc.ListResourceVersions, "owner", true),
 		Entry("member :: "+atc.ListResourceVersions, atc.ListResourceVersions, "member", true),
 		Entry("pipeline-operator :: "+atc.ListResourceVersions, atc.ListResourceVersions, "pipeline-operator", true),
@@ -642,6 +915,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetResourceCausality, atc.GetResourceCausality, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetResourceCausality, atc.GetResourceCausality, "viewer", true),
 
+		Entry("owner :: "+atc.ListResourceVersionSpan, atc.ListResourceVersionSpan, "owner", true),
+		Entry("member :: "+atc.ListResourceVersionSpan, atc.ListResourceVersionSpan, "member", true),
+		Entry("pipeline-operator :: "+atc.ListResourceVersionSpan, atc.ListResourceVersionSpan, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListResourceVersionSpan, atc.ListResourceVersionSpan, "viewer", true),
+
 		Entry("owner :: "+atc.ListAllPipelines, atc.ListAllPipelines, "owner", true),
 		Entry("member :: "+atc.ListAllPipelines, atc.ListAllPipelines, "member", true),
 		Entry("pipeline-operator :: "+atc.ListAllPipelines, atc.ListAllPipelines, "pipeline-operator", true),
@@ -657,6 +935,16 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetPipeline, atc.GetPipeline, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetPipeline, atc.GetPipeline, "viewer", true),
 
+		Entry("owner :: "+atc.GetPipelineGraph, atc.GetPipelineGraph, "owner", true),
+		Entry("member :: "+atc.GetPipelineGraph, atc.GetPipelineGraph, "member", true),
+		Entry("pipeline-operator :: "+atc.GetPipelineGraph, atc.GetPipelineGraph, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetPipelineGraph, atc.GetPipelineGraph, "viewer", true),
+
+		Entry("owner :: "+atc.GetPipelineScheduling, atc.GetPipelineScheduling, "owner", true),
+		Entry("member :: "+atc.GetPipelineScheduling, atc.GetPipelineScheduling, "member", true),
+		Entry("pipeline-operator :: "+atc.GetPipelineScheduling, atc.GetPipelineScheduling, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetPipelineScheduling, atc.GetPipelineScheduling, "viewer", true),
+
 		Entry("owner :: "+atc.DeletePipeline, atc.DeletePipeline, "owner", true),
 		Entry("member :: "+atc.DeletePipeline, atc.DeletePipeline, "member", true),
 		Entry("pipeline-operator :: "+atc.DeletePipeline, atc.DeletePipeline, "pipeline-operator", false),
@@ -687,6 +975,16 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.HidePipeline, atc.HidePipeline, "pipeline-operator", false),
 		Entry("viewer :: "+atc.HidePipeline, atc.HidePipeline, "viewer", false),
 
+		Entry("owner :: "+atc.SharePipeline, atc.SharePipeline, "owner", true),
+		Entry("member :: "+atc.SharePipeline, atc.SharePipeline, "member", true),
+		Entry("pipeline-operator :: "+atc.SharePipeline, atc.SharePipeline, "pipeline-operator", false),
+		Entry("viewer :: "+atc.SharePipeline, atc.SharePipeline, "viewer", false),
+
+		Entry("owner :: "+atc.UnsharePipeline, atc.UnsharePipeline, "owner", true),
+		Entry("member :: "+atc.UnsharePipeline, atc.UnsharePipeline, "member", true),
+		Entry("pipeline-operator :: "+atc.UnsharePipeline, atc.UnsharePipeline, "pipeline-operator", false),
+		Entry("viewer :: "+atc.UnsharePipeline, atc.UnsharePipeline, "viewer", false),
+
 		Entry("owner :: "+atc.RenamePipeline, atc.RenamePipeline, "owner", true),
 		Entry("member :: "+atc.RenamePipeline, atc.RenamePipeline, "member", true),
 		Entry("pipeline-operator :: "+atc.RenamePipeline, atc.RenamePipeline, "pipeline-operator", false),
@@ -707,6 +1005,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.PipelineBadge, atc.PipelineBadge, "pipeline-operator", true),
 		Entry("viewer :: "+atc.PipelineBadge, atc.PipelineBadge, "viewer", true),
 
+		Entry("owner :: "+atc.PipelineStatus, atc.PipelineStatus, "owner", true),
+		Entry("member :: "+atc.PipelineStatus, atc.PipelineStatus, "member", true),
+		Entry("pipeline-operator :: "+atc.PipelineStatus, atc.PipelineStatus, "pipeline-operator", true),
+		Entry("viewer :: "+atc.PipelineStatus, atc.PipelineStatus, "viewer", true),
+
 		Entry("owner :: "+atc.RegisterWorker, atc.RegisterWorker, "owner", true),
 		Entry("member :: "+atc.RegisterWorker, atc.RegisterWorker, "member", true),
 		Entry("pipeline-operator :: "+atc.RegisterWorker, atc.RegisterWorker, "pipeline-operator", false),
@@ -742,6 +1045,16 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.DeleteWorker, atc.DeleteWorker, "pipeline-operator", false),
 		Entry("viewer :: "+atc.DeleteWorker, atc.DeleteWorker, "viewer", false),
 
+		Entry("owner :: "+atc.ListWorkerDiscrepancies, atc.ListWorkerDiscrepancies, "owner", true),
+		Entry("member :: "+atc.ListWorkerDiscrepancies, atc.ListWorkerDiscrepancies, "member", true),
+		Entry("pipeline-operator :: "+atc.ListWorkerDiscrepancies, atc.ListWorkerDiscrepancies, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListWorkerDiscrepancies, atc.ListWorkerDiscrepancies, "viewer", true),
+
+		Entry("owner :: "+atc.ResolveWorkerDiscrepancies, atc.ResolveWorkerDiscrepancies, "owner", true),
+		Entry("member :: "+atc.ResolveWorkerDiscrepancies, atc.ResolveWorkerDiscrepancies, "member", true),
+		Entry("pipeline-operator :: "+atc.ResolveWorkerDiscrepancies, atc.ResolveWorkerDiscrepancies, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ResolveWorkerDiscrepancies, atc.ResolveWorkerDiscrepancies, "viewer", false),
+
 		Entry("owner :: "+atc.SetLogLevel, atc.SetLogLevel, "owner", true),
 		Entry("member :: "+atc.SetLogLevel, atc.SetLogLevel, "member", true),
 		Entry("pipeline-operator :: "+atc.SetLogLevel, atc.SetLogLevel, "pipeline-operator", false),
@@ -782,6 +1095,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.HijackContainer, atc.HijackContainer, "pipeline-operator", false),
 		Entry("viewer :: "+atc.HijackContainer, atc.HijackContainer, "viewer", false),
 
+		Entry("owner :: "+atc.HijackCheckContainer, atc.HijackCheckContainer, "owner", true),
+		Entry("member :: "+atc.HijackCheckContainer, atc.HijackCheckContainer, "member", true),
+		Entry("pipeline-operator :: "+atc.HijackCheckContainer, atc.HijackCheckContainer, "pipeline-operator", false),
+		Entry("viewer :: "+atc.HijackCheckContainer, atc.HijackCheckContainer, "viewer", false),
+
 		Entry("owner :: "+atc.ListDestroyingContainers, atc.ListDestroyingContainers, "owner", true),
 		Entry("member :: "+atc.ListDestroyingContainers, atc.ListDestroyingContainers, "member", true),
 		Entry("pipeline-operator :: "+atc.ListDestroyingContainers, atc.ListDestroyingContainers, "pipeline-operator", true),
@@ -831,11 +1149,46 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.DestroyTeam, atc.DestroyTeam, "pipeline-operator", false),
 		Entry("viewer :: "+atc.DestroyTeam, atc.DestroyTeam, "viewer", false),
 
+		Entry("owner :: "+atc.RestoreTeam, atc.RestoreTeam, "owner", true),
+		Entry("member :: "+atc.RestoreTeam, atc.RestoreTeam, "member", false),
+		Entry("pipeline-operator :: "+atc.RestoreTeam, atc.RestoreTeam, "pipeline-operator", false),
+		Entry("viewer :: "+atc.RestoreTeam, atc.RestoreTeam, "viewer", false),
+
 		Entry("owner :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "owner", true),
 		Entry("member :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "member", true),
 		Entry("pipeline-operator :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "viewer", true),
 
+		Entry("owner :: "+atc.GetPipelinesRepoStatus, atc.GetPipelinesRepoStatus, "owner", true),
+		Entry("member :: "+atc.GetPipelinesRepoStatus, atc.GetPipelinesRepoStatus, "member", true),
+		Entry("pipeline-operator :: "+atc.GetPipelinesRepoStatus, atc.GetPipelinesRepoStatus, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetPipelinesRepoStatus, atc.GetPipelinesRepoStatus, "viewer", true),
+
+		Entry("owner :: "+atc.GetResourceTypeMappings, atc.GetResourceTypeMappings, "owner", true),
+		Entry("member :: "+atc.GetResourceTypeMappings, atc.GetResourceTypeMappings, "member", true),
+		Entry("pipeline-operator :: "+atc.GetResourceTypeMappings, atc.GetResourceTypeMappings, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetResourceTypeMappings, atc.GetResourceTypeMappings, "viewer", true),
+
+		Entry("owner :: "+atc.GetResourceTypeHealth, atc.GetResourceTypeHealth, "owner", true),
+		Entry("member :: "+atc.GetResourceTypeHealth, atc.GetResourceTypeHealth, "member", true),
+		Entry("pipeline-operator :: "+atc.GetResourceTypeHealth, atc.GetResourceTypeHealth, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetResourceTypeHealth, atc.GetResourceTypeHealth, "viewer", true),
+
+		Entry("owner :: "+atc.ListAPITokens, atc.ListAPITokens, "owner", true),
+		Entry("member :: "+atc.ListAPITokens, atc.ListAPITokens, "member", false),
+		Entry("pipeline-operator :: "+atc.ListAPITokens, atc.ListAPITokens, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ListAPITokens, atc.ListAPITokens, "viewer", false),
+
+		Entry("owner :: "+atc.CreateAPIToken, atc.CreateAPIToken, "owner", true),
+		Entry("member :: "+atc.CreateAPIToken, atc.CreateAPIToken, "member", false),
+		Entry("pipeline-operator :: "+atc.CreateAPIToken, atc.CreateAPIToken, "pipeline-operator", false),
+		Entry("viewer :: "+atc.CreateAPIToken, atc.CreateAPIToken, "viewer", false),
+
+		Entry("owner :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "owner", true),
+		Entry("member :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "member", false),
+		Entry("pipeline-operator :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "pipeline-operator", false),
+		Entry("viewer :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "viewer", false),
+
 		Entry("owner :: "+atc.CreateArtifact, atc.CreateArtifact, "owner", true),
 		Entry("member :: "+atc.CreateArtifact, atc.CreateArtifact, "member", true),
 		Entry("pipeline-operator :: "+atc.CreateArtifact, atc.CreateArtifact, "pipeline-operator", false),
@@ -850,5 +1203,10 @@ var _ = Describe("Accessor", func() {
 		Entry("member :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "member", true),
 		Entry("pipeline-operator :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "viewer", true),
+
+		Entry("owner :: "+atc.DownloadBuildArtifact, atc.DownloadBuildArtifact, "owner", true),
+		Entry("member :: "+atc.DownloadBuildArtifact, atc.DownloadBuildArtifact, "member", true),
+		Entry("pipeline-operator :: "+atc.DownloadBuildArtifact, atc.DownloadBuildArtifact, "pipeline-operator", true),
+		Entry("viewer :: "+atc.DownloadBuildArtifact, atc.DownloadBuildArtifact, "viewer", true),
 	)
 })
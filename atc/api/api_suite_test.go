@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -10,19 +11,25 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/api/auth"
+	"github.com/concourse/concourse/atc/api/configserver/configserverfakes"
 	"github.com/concourse/concourse/atc/api/containerserver/containerserverfakes"
+	"github.com/concourse/concourse/atc/api/migrationserver/migrationserverfakes"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/gc/gcfakes"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/skymarshal/token/tokenfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -50,18 +57,27 @@ var (
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
 	dbUserFactory           *dbfakes.FakeUserFactory
+	dbLockRepository        *dbfakes.FakeLockRepository
+	fakeLockFactory         *lockfakes.FakeLockFactory
+	resourceCaches          *metric.CacheUsage
+	fakeMigrationVersions   *migrationserverfakes.FakeVersions
+	dbBackfillRepository    *dbfakes.FakeBackfillRepository
+	dbDBHealthRepository    *dbfakes.FakeDBHealthRepository
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
+	fakeTokenGenerator      *tokenfakes.FakeGenerator
 	fakeSecretManager       *credsfakes.FakeSecrets
 	credsManagers           creds.Managers
 	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
+	fakeConfigValidator     *configserverfakes.FakeConfigValidator
 	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
 	expire                  time.Duration
 	isTLSEnabled            bool
 	cliDownloadsDir         string
 	logger                  *lagertest.TestLogger
 
-	constructedEventHandler *fakeEventHandlerFactory
+	constructedEventHandler          *fakeEventHandlerFactory
+	constructedMultiplexEventHandler *fakeMultiplexEventHandlerFactory
 
 	server *httptest.Server
 	client *http.Client
@@ -87,6 +103,26 @@ func (f *fakeEventHandlerFactory) Construct(
 	})
 }
 
+type fakeMultiplexEventHandlerFactory struct {
+	builds []db.Build
+
+	lock sync.Mutex
+}
+
+func (f *fakeMultiplexEventHandlerFactory) Construct(
+	logger lager.Logger,
+	builds []db.Build,
+) http.Handler {
+	f.lock.Lock()
+	f.builds = builds
+	f.lock.Unlock()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("fake multiplex event handler factory was here"))
+	})
+}
+
 var _ = BeforeEach(func() {
 	dbTeamFactory = new(dbfakes.FakeTeamFactory)
 	dbPipelineFactory = new(dbfakes.FakePipelineFactory)
@@ -95,12 +131,24 @@ var _ = BeforeEach(func() {
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 	dbUserFactory = new(dbfakes.FakeUserFactory)
+	dbLockRepository = new(dbfakes.FakeLockRepository)
+	fakeLockFactory = new(lockfakes.FakeLockFactory)
+	resourceCaches = new(metric.CacheUsage)
+	fakeMigrationVersions = new(migrationserverfakes.FakeVersions)
+	dbBackfillRepository = new(dbfakes.FakeBackfillRepository)
+	dbDBHealthRepository = new(dbfakes.FakeDBHealthRepository)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
 	interceptTimeoutFactory.NewInterceptTimeoutReturns(interceptTimeout)
 
+	fakeConfigValidator = new(configserverfakes.FakeConfigValidator)
+	fakeConfigValidator.ValidateStub = func(_ context.Context, _ lager.Logger, validate func()) error {
+		validate()
+		return nil
+	}
+
 	dbTeam = new(dbfakes.FakeTeam)
 	dbTeam.IDReturns(734)
 	dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
@@ -124,12 +172,15 @@ var _ = BeforeEach(func() {
 
 	fakeSecretManager = new(credsfakes.FakeSecrets)
 	credsManagers = make(creds.Managers)
+
+	fakeTokenGenerator = new(tokenfakes.FakeGenerator)
 	var err error
 
 	cliDownloadsDir, err = ioutil.TempDir("", "cli-downloads")
 	Expect(err).NotTo(HaveOccurred())
 
 	constructedEventHandler = &fakeEventHandlerFactory{}
+	constructedMultiplexEventHandler = &fakeMultiplexEventHandlerFactory{}
 
 	logger = lagertest.NewTestLogger("api")
 
@@ -174,8 +225,15 @@ var _ = BeforeEach(func() {
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		dbUserFactory,
+		dbLockRepository,
+		fakeLockFactory,
+		resourceCaches,
+		fakeMigrationVersions,
+		dbBackfillRepository,
+		dbDBHealthRepository,
 
 		constructedEventHandler.Construct,
+		constructedMultiplexEventHandler.Construct,
 
 		fakeWorkerClient,
 
@@ -189,6 +247,13 @@ var _ = BeforeEach(func() {
 		fakeSecretManager,
 		credsManagers,
 		interceptTimeoutFactory,
+		atc.DeprecatedResourceTypes{"some-deprecated-type": "use some-type instead"},
+		fakeTokenGenerator,
+		atc.ResourceTypeMappings{
+			"git": {Type: "registry-image", Source: atc.Source{"repository": "installation/git-resource"}},
+			"s3":  {Type: "registry-image", Source: atc.Source{"repository": "installation/s3-resource"}},
+		},
+		fakeConfigValidator,
 	)
 
 	Expect(err).NotTo(HaveOccurred())
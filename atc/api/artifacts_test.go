@@ -105,7 +105,7 @@ var _ = Describe("Artifacts API", func() {
 				It("creates the volume using the worker client", func() {
 					Expect(fakeWorkerClient.CreateVolumeCallCount()).To(Equal(1))
 
-					_, volumeSpec, workerSpec, volumeType := fakeWorkerClient.CreateVolumeArgsForCall(0)
+					_, _, volumeSpec, workerSpec, volumeType := fakeWorkerClient.CreateVolumeArgsForCall(0)
 					Expect(volumeSpec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
 					Expect(workerSpec).To(Equal(worker.WorkerSpec{
 						TeamID:   734,
@@ -202,9 +202,14 @@ var _ = Describe("Artifacts API", func() {
 	})
 
 	Describe("GET /api/v1/teams/:team_name/artifacts/:artifact_id", func() {
-		var response *http.Response
+		var (
+			response    *http.Response
+			rangeHeader string
+		)
 
 		BeforeEach(func() {
+			rangeHeader = ""
+
 			fakeaccess = new(accessorfakes.FakeAccess)
 			fakeaccess.IsAuthenticatedReturns(true)
 
@@ -212,8 +217,14 @@ var _ = Describe("Artifacts API", func() {
 		})
 
 		JustBeforeEach(func() {
-			var err error
-			response, err = http.Get(server.URL + "/api/v1/teams/some-team/artifacts/18")
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/artifacts/18", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if rangeHeader != "" {
+				req.Header.Set("Range", rangeHeader)
+			}
+
+			response, err = http.DefaultClient.Do(req)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -353,6 +364,17 @@ var _ = Describe("Artifacts API", func() {
 						It("returns the contents of the volume", func() {
 							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-content")))
 						})
+
+						Context("when resuming from an offset", func() {
+							BeforeEach(func() {
+								rangeHeader = "bytes=5-"
+							})
+
+							It("returns 206 with the contents from the offset", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusPartialContent))
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("content")))
+							})
+						})
 					})
 				})
 			})
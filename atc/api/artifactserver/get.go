@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 func (s *Server) GetArtifact(team db.Team) http.Handler {
@@ -15,6 +16,12 @@ func (s *Server) GetArtifact(team db.Team) http.Handler {
 
 		w.Header().Set("Content-Type", "application/octet-stream")
 
+		offset, err := worker.ParseStreamRange(r.Header.Get("Range"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		artifactID, err := strconv.Atoi(r.FormValue(":artifact_id"))
 		if err != nil {
 			logger.Error("failed-to-get-artifact-id", err)
@@ -48,7 +55,12 @@ func (s *Server) GetArtifact(team db.Team) http.Handler {
 			return
 		}
 
-		reader, err := workerVolume.StreamOut(r.Context(), "/")
+		reader, err := worker.StreamOutFrom(r.Context(), workerVolume, "/", offset)
+		if err == worker.ErrStreamChanged {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-stream-volume-contents", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -57,6 +69,12 @@ func (s *Server) GetArtifact(team db.Team) http.Handler {
 
 		defer reader.Close()
 
+		w.Header().Set("Accept-Ranges", "bytes")
+
+		if offset > 0 {
+			w.WriteHeader(http.StatusPartialContent)
+		}
+
 		_, err = io.Copy(w, reader)
 		if err != nil {
 			logger.Error("failed-to-encode-artifact", err)
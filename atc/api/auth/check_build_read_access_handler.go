@@ -90,7 +90,7 @@ func (h checkBuildReadAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 			return
 		}
 
-		if !pipeline.Public() {
+		if !pipeline.Public() && !isSharedWith(r, pipeline) {
 			if acc.IsAuthenticated() {
 				h.rejector.Forbidden(w, r)
 				return
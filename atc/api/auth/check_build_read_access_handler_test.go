@@ -27,6 +27,7 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 		fakeaccess     *accessorfakes.FakeAccess
 		build          *dbfakes.FakeBuild
 		pipeline       *dbfakes.FakePipeline
+		shareToken     string
 	)
 
 	BeforeEach(func() {
@@ -42,13 +43,14 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 		build.PipelineReturns(pipeline, true, nil)
 		build.TeamNameReturns("some-team")
 		build.JobNameReturns("some-job")
+		shareToken = ""
 	})
 
 	JustBeforeEach(func() {
 		fakeAccessor.CreateReturns(fakeaccess)
 		server = httptest.NewServer(handler)
 
-		request, err := http.NewRequest("POST", server.URL+"?:build_id=55", nil)
+		request, err := http.NewRequest("POST", server.URL+"?:build_id=55&share_token="+shareToken, nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		response, err = new(http.Client).Do(request)
@@ -189,6 +191,17 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 					It("returns 401", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 					})
+
+					Context("when the request has the pipeline's share token", func() {
+						BeforeEach(func() {
+							shareToken = "some-share-token"
+							pipeline.IsSharedWithStub = func(token string) bool {
+								return token == "some-share-token"
+							}
+						})
+
+						ItReturnsTheBuild()
+					})
 				})
 				Context("when fetching pipeline throws error", func() {
 					BeforeEach(func() {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -68,7 +69,7 @@ func (h checkPipelineAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 
 	acc := accessor.GetAccessor(r)
 
-	if acc.IsAuthorized(teamName) || pipeline.Public() {
+	if acc.IsAuthorized(teamName) || pipeline.Public() || isSharedWith(r, pipeline) {
 		ctx := context.WithValue(r.Context(), PipelineContextKey, pipeline)
 		h.delegateHandler.ServeHTTP(w, r.WithContext(ctx))
 		return
@@ -81,3 +82,10 @@ func (h checkPipelineAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 
 	h.rejector.Forbidden(w, r)
 }
+
+// isSharedWith returns true if the request carries the pipeline's share
+// token, in which case it may see whatever it could if the pipeline were
+// public.
+func isSharedWith(r *http.Request, pipeline db.Pipeline) bool {
+	return pipeline.IsSharedWith(r.URL.Query().Get(atc.ShareTokenQuery))
+}
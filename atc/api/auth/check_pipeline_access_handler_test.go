@@ -28,6 +28,8 @@ var _ = Describe("CheckPipelineAccessHandler", func() {
 
 		fakeAccessor *accessorfakes.FakeAccessFactory
 		fakeaccess   *accessorfakes.FakeAccess
+
+		shareToken string
 	)
 
 	BeforeEach(func() {
@@ -36,6 +38,7 @@ var _ = Describe("CheckPipelineAccessHandler", func() {
 		teamFactory.FindTeamReturns(team, true, nil)
 
 		pipeline = new(dbfakes.FakePipeline)
+		shareToken = ""
 
 		handlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
 		fakeAccessor = new(accessorfakes.FakeAccessFactory)
@@ -50,7 +53,7 @@ var _ = Describe("CheckPipelineAccessHandler", func() {
 		fakeAccessor.CreateReturns(fakeaccess)
 		server = httptest.NewServer(handler)
 
-		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline&share_token="+shareToken, nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		response, err = new(http.Client).Do(request)
@@ -147,6 +150,34 @@ var _ = Describe("CheckPipelineAccessHandler", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 					})
 				})
+
+				Context("and the request has the pipeline's share token", func() {
+					BeforeEach(func() {
+						shareToken = "some-share-token"
+						pipeline.IsSharedWithStub = func(token string) bool {
+							return token == "some-share-token"
+						}
+					})
+
+					It("calls pipelineScopedHandler with pipelineDB in context", func() {
+						Expect(delegate.IsCalled).To(BeTrue())
+						Expect(delegate.ContextPipelineDB).To(BeIdenticalTo(pipeline))
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("and the request has some other share token", func() {
+					BeforeEach(func() {
+						shareToken = "some-other-token"
+					})
+
+					It("returns 401 Unauthorized", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
 			})
 		})
 	})
@@ -10,8 +10,11 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -81,6 +84,34 @@ var _ = Describe("Builds API", func() {
 					fakeAccess.IsAuthorizedReturns(true)
 				})
 
+				Context("when a step uses an artifact no earlier step registers", func() {
+					BeforeEach(func() {
+						plan = atc.Plan{
+							ID: "1",
+							Task: &atc.TaskPlan{
+								Config: &atc.TaskConfig{
+									Run:    atc.TaskRunConfig{Path: "ls"},
+									Inputs: []atc.TaskInputConfig{{Name: "missing"}},
+								},
+							},
+						}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("explains why", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("step 1 uses artifact 'missing', which no earlier step registers"))
+					})
+
+					It("does not create a build", func() {
+						Expect(dbTeam.CreateStartedBuildCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when creating a started build fails", func() {
 					BeforeEach(func() {
 						dbTeam.CreateStartedBuildReturns(nil, errors.New("oh no!"))
@@ -585,6 +616,38 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 						})
+
+						Context("when the build errored with an error code", func() {
+							BeforeEach(func() {
+								build.StatusReturns(db.BuildStatusErrored)
+								build.ErrorCodeReturns(atc.ErrorCodeNoWorkersMatchingTags)
+							})
+
+							It("returns the error code", func() {
+								var returned atc.Build
+								err := json.NewDecoder(response.Body).Decode(&returned)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(returned.Status).To(Equal("errored"))
+								Expect(returned.ErrorCode).To(Equal(atc.ErrorCodeNoWorkersMatchingTags))
+							})
+						})
+
+						Context("when the build is an automatic retry", func() {
+							BeforeEach(func() {
+								build.RerunOfReturns(41)
+								build.AutoRetryAttemptReturns(2)
+							})
+
+							It("returns which retry it is", func() {
+								var returned atc.Build
+								err := json.NewDecoder(response.Body).Decode(&returned)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(returned.RerunOf).To(Equal(41))
+								Expect(returned.AutoRetryAttempt).To(Equal(2))
+							})
+						})
 					})
 				})
 			})
@@ -645,6 +708,46 @@ var _ = Describe("Builds API", func() {
 					It("returns 200", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
 					})
+
+					Context("when the build inputs/outputs have metadata", func() {
+						BeforeEach(func() {
+							build.ResourcesReturns([]db.BuildInput{
+								{
+									Name:     "input1",
+									Version:  atc.Version{"version": "value1"},
+									Metadata: db.ResourceConfigMetadataFields{{Name: "some", Value: "metadata"}},
+								},
+							}, []db.BuildOutput{
+								{
+									Name:     "output1",
+									Version:  atc.Version{"version": "value2"},
+									Metadata: db.ResourceConfigMetadataFields{{Name: "other", Value: "metadata"}},
+								},
+							}, nil)
+						})
+
+						It("hides the metadata", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"inputs": [
+									{
+										"name": "input1",
+										"version": {"version": "value1"},
+										"pipeline_id": 42,
+										"first_occurrence": false
+									}
+								],
+								"outputs": [
+									{
+										"name": "output1",
+										"version": {"version": "value2"}
+									}
+								]
+							}`))
+						})
+					})
 				})
 			})
 
@@ -676,6 +779,7 @@ var _ = Describe("Builds API", func() {
 								Name:            "input1",
 								Version:         atc.Version{"version": "value1"},
 								ResourceID:      1,
+								Metadata:        db.ResourceConfigMetadataFields{{Name: "some", Value: "metadata"}},
 								FirstOccurrence: true,
 							},
 							{
@@ -687,8 +791,9 @@ var _ = Describe("Builds API", func() {
 						},
 							[]db.BuildOutput{
 								{
-									Name:    "myresource3",
-									Version: atc.Version{"version": "value3"},
+									Name:     "myresource3",
+									Version:  atc.Version{"version": "value3"},
+									Metadata: db.ResourceConfigMetadataFields{{Name: "other", Value: "metadata"}},
 								},
 								{
 									Name:    "myresource4",
@@ -710,6 +815,7 @@ var _ = Describe("Builds API", func() {
 								{
 									"name": "input1",
 									"version": {"version": "value1"},
+									"metadata": [{"name": "some", "value": "metadata"}],
 									"pipeline_id": 42,
 									"first_occurrence": true
 								},
@@ -723,7 +829,8 @@ var _ = Describe("Builds API", func() {
 							"outputs": [
 								{
 									"name": "myresource3",
-									"version": {"version": "value3"}
+									"version": {"version": "value3"},
+									"metadata": [{"name": "other", "value": "metadata"}]
 								},
 								{
 									"name": "myresource4",
@@ -961,137 +1068,137 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
-	Describe("PUT /api/v1/builds/:build_id/abort", func() {
+	Describe("GET /api/v1/builds/events", func() {
 		var (
+			query    string
 			response *http.Response
+
+			otherBuild *dbfakes.FakeBuild
 		)
 
+		BeforeEach(func() {
+			query = "?build_id=128&build_id=129"
+
+			build.IDReturns(128)
+			build.JobNameReturns("some-job")
+			build.TeamNameReturns("some-team")
+			build.PipelineReturns(fakePipeline, true, nil)
+
+			otherBuild = new(dbfakes.FakeBuild)
+			otherBuild.IDReturns(129)
+			otherBuild.JobNameReturns("other-job")
+			otherBuild.TeamNameReturns("other-team")
+			otherBuild.PipelineReturns(fakePipeline, true, nil)
+
+			dbBuildFactory.BuildStub = func(id int) (db.Build, bool, error) {
+				switch id {
+				case 128:
+					return build, true, nil
+				case 129:
+					return otherBuild, true, nil
+				default:
+					return nil, false, nil
+				}
+			}
+		})
+
 		JustBeforeEach(func() {
 			var err error
 
-			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort", nil)
-			Expect(err).NotTo(HaveOccurred())
-
-			response, err = client.Do(req)
+			response, err = client.Get(server.URL + "/api/v1/builds/events" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		Context("when not authenticated", func() {
+		Context("when authorized for every build's team", func() {
 			BeforeEach(func() {
-				fakeAccess.IsAuthenticatedReturns(false)
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
 			})
 
-			It("returns 401", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-			})
-		})
+			It("serves the request via the multiplex event handler", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
 
-		Context("when authenticated", func() {
-			BeforeEach(func() {
-				fakeAccess.IsAuthenticatedReturns(true)
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(body)).To(Equal("fake multiplex event handler factory was here"))
+				Expect(constructedMultiplexEventHandler.builds).To(Equal([]db.Build{build, otherBuild}))
 			})
 
-			Context("when looking up the build fails", func() {
+			Context("when a build is given more than once", func() {
 				BeforeEach(func() {
-					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+					query = "?build_id=128&build_id=128"
 				})
 
-				It("returns 500", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				It("streams it once", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(constructedMultiplexEventHandler.builds).To(Equal([]db.Build{build}))
 				})
 			})
 
-			Context("when the build can not be found", func() {
+			Context("when a build cannot be found", func() {
 				BeforeEach(func() {
-					dbBuildFactory.BuildReturns(nil, false, nil)
-				})
-
-				It("returns 404", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					query = "?build_id=128&build_id=130"
 				})
-			})
 
-			Context("when the build is found", func() {
-				BeforeEach(func() {
-					build.TeamNameReturns("some-team")
-					dbBuildFactory.BuildReturns(build, true, nil)
+				It("returns 403 rather than giving away that it doesn't exist", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
 				})
 
-				Context("when not authorized", func() {
+				Context("when the user is an admin", func() {
 					BeforeEach(func() {
-						fakeAccess.IsAuthorizedReturns(false)
+						fakeAccess.IsAdminReturns(true)
 					})
 
-					It("returns 403", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					It("returns Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 					})
 				})
+			})
 
-				Context("when authorized", func() {
-					BeforeEach(func() {
-						fakeAccess.IsAuthorizedReturns(true)
-					})
-
-					Context("when aborting the build fails", func() {
-						BeforeEach(func() {
-							build.MarkAsAbortedReturns(errors.New("nope"))
-						})
+			Context("when looking up a build fails", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildStub = nil
+					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+				})
 
-						It("returns 500", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-						})
-					})
+				It("returns Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
 
-					Context("when aborting succeeds", func() {
-						BeforeEach(func() {
-							build.MarkAsAbortedReturns(nil)
-						})
+			Context("when no builds are given", func() {
+				BeforeEach(func() {
+					query = ""
+				})
 
-						It("returns 204", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
-						})
-					})
+				It("returns Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
 				})
 			})
-		})
-	})
 
-	Describe("GET /api/v1/builds/:build_id/preparation", func() {
-		var response *http.Response
+			Context("when a build id is invalid", func() {
+				BeforeEach(func() {
+					query = "?build_id=nope"
+				})
 
-		JustBeforeEach(func() {
-			var err error
-			response, err = http.Get(server.URL + "/api/v1/builds/42/preparation")
-			Expect(err).NotTo(HaveOccurred())
+				It("returns Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
 		})
 
-		Context("when the build is found", func() {
-			var buildPrep db.BuildPreparation
-
+		Context("when authorized for only some of the builds' teams", func() {
 			BeforeEach(func() {
-				buildPrep = db.BuildPreparation{
-					BuildID:          42,
-					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
-					PausedJob:        db.BuildPreparationStatusNotBlocking,
-					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
-					Inputs: map[string]db.BuildPreparationStatus{
-						"foo": db.BuildPreparationStatusUnknown,
-						"bar": db.BuildPreparationStatusBlocking,
-					},
-					InputsSatisfied:     db.BuildPreparationStatusBlocking,
-					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedStub = func(team string) bool {
+					return team == "some-team"
 				}
-				dbBuildFactory.BuildReturns(build, true, nil)
-				build.JobNameReturns("job1")
-				build.TeamNameReturns("some-team")
-				build.PreparationReturns(buildPrep, true, nil)
 			})
 
-			Context("when authenticated, but not authorized", func() {
+			Context("and the other build's pipeline is private", func() {
 				BeforeEach(func() {
-					fakeAccess.IsAuthenticatedReturns(true)
-					fakeAccess.IsAuthorizedReturns(false)
-					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
 				})
 
 				It("returns 403", func() {
@@ -1099,80 +1206,1327 @@ var _ = Describe("Builds API", func() {
 				})
 			})
 
-			Context("when not authenticated", func() {
+			Context("and the other build's pipeline and job are public", func() {
 				BeforeEach(func() {
-					fakeAccess.IsAuthenticatedReturns(false)
+					fakePipeline.PublicReturns(true)
+
+					fakeJob := new(dbfakes.FakeJob)
+					fakeJob.PublicReturns(true)
+					fakePipeline.JobReturns(fakeJob, true, nil)
 				})
 
-				Context("and build is one off", func() {
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			Context("and a build cannot be found", func() {
+				BeforeEach(func() {
+					query = "?build_id=130"
+				})
+
+				It("returns 401 rather than giving away that it doesn't exist", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and a job is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+
+					fakeJob := new(dbfakes.FakeJob)
+					fakeJob.PublicReturns(false)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+					fakePipeline.IsSharedWithStub = func(token string) bool {
+						return token == "some-share-token"
+					}
+
+					fakeJob := new(dbfakes.FakeJob)
+					fakeJob.PublicReturns(true)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				Context("when the pipeline is shared with the request's share token", func() {
 					BeforeEach(func() {
-						build.PipelineReturns(nil, false, nil)
+						query += "&share_token=some-share-token"
 					})
 
-					It("returns 401", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(constructedMultiplexEventHandler.builds).To(Equal([]db.Build{build, otherBuild}))
 					})
 				})
 
-				Context("and the pipeline is private", func() {
+				Context("when the request has some other share token", func() {
 					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(false)
+						query += "&share_token=some-other-token"
 					})
 
 					It("returns 401", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 					})
 				})
+			})
+		})
 
-				Context("and the pipeline is public", func() {
-					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(true)
-					})
+		Context("when selecting the builds of a job", func() {
+			var fakeJob *dbfakes.FakeJob
 
-					Context("when the job is found", func() {
-						var fakeJob *dbfakes.FakeJob
-						BeforeEach(func() {
-							fakeJob = new(dbfakes.FakeJob)
-							fakePipeline.JobReturns(fakeJob, true, nil)
-						})
+			BeforeEach(func() {
+				query = "?team_name=some-team&pipeline_name=some-pipeline&job_name=some-job"
 
-						Context("when job is private", func() {
-							BeforeEach(func() {
-								fakeJob.PublicReturns(false)
-							})
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.BuildsReturns([]db.Build{otherBuild, build}, db.Pagination{}, nil)
+				fakePipeline.JobReturns(fakeJob, true, nil)
 
-							It("returns 401", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-							})
-						})
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
 
-						Context("when job is public", func() {
-							BeforeEach(func() {
-								fakeJob.PublicReturns(true)
-							})
+			It("streams the job's latest builds", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(constructedMultiplexEventHandler.builds).To(Equal([]db.Build{otherBuild, build}))
 
-							It("returns 200", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusOK))
-							})
-						})
-					})
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+				Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+				Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+				Expect(fakeJob.BuildsArgsForCall(0)).To(Equal(db.Page{Limit: buildserver.MaxMultiplexedBuilds}))
+			})
 
-					Context("when finding the job fails", func() {
-						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, errors.New("nope"))
-						})
+			Context("when the job cannot be found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
 
-						It("returns Internal Server Error", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-						})
-					})
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
 
-					Context("when the job cannot be found", func() {
-						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, nil)
-						})
+			Context("when the pipeline cannot be found", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the job's builds fails", func() {
+				BeforeEach(func() {
+					fakeJob.BuildsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the pipeline is not given", func() {
+				BeforeEach(func() {
+					query = "?team_name=some-team&job_name=some-job"
+				})
+
+				It("returns Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when builds are also given by id", func() {
+				BeforeEach(func() {
+					query += "&build_id=128"
+				})
+
+				It("returns Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when not authorized for the team", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("doesn't look up the job", func() {
+					Expect(fakePipeline.JobCallCount()).To(BeZero())
+				})
+
+				Context("when the job cannot be found", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 403 rather than giving away that it doesn't exist", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when the pipeline cannot be found", func() {
+					BeforeEach(func() {
+						dbTeam.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 403 rather than giving away that it doesn't exist", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when the pipeline is shared with the request's share token", func() {
+					BeforeEach(func() {
+						query += "&share_token=some-share-token"
+						fakePipeline.IsSharedWithStub = func(token string) bool {
+							return token == "some-share-token"
+						}
+						fakeJob.PublicReturns(true)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+			})
+		})
+
+		Context("when selecting the builds with a version as an input", func() {
+			var fakeResource *dbfakes.FakeResource
+
+			BeforeEach(func() {
+				query = "?team_name=some-team&pipeline_name=some-pipeline&resource_name=some-resource&resource_version_id=42"
+
+				fakeResource = new(dbfakes.FakeResource)
+				fakeResource.IDReturns(7)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+				fakePipeline.GetBuildsWithVersionAsInputReturns([]db.Build{build, otherBuild}, nil)
+
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("streams the builds, latest first", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(constructedMultiplexEventHandler.builds).To(Equal([]db.Build{otherBuild, build}))
+
+				Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("some-resource"))
+				resourceID, versionID := fakePipeline.GetBuildsWithVersionAsInputArgsForCall(0)
+				Expect(resourceID).To(Equal(7))
+				Expect(versionID).To(Equal(42))
+			})
+
+			Context("when the resource cannot be found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the builds fails", func() {
+				BeforeEach(func() {
+					fakePipeline.GetBuildsWithVersionAsInputReturns(nil, errors.New("nope"))
+				})
+
+				It("returns Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the version id is invalid", func() {
+				BeforeEach(func() {
+					query = "?team_name=some-team&pipeline_name=some-pipeline&resource_name=some-resource&resource_version_id=nope"
+				})
+
+				It("returns Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when a job is also given", func() {
+				BeforeEach(func() {
+					query += "&job_name=some-job"
+				})
+
+				It("returns Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/abort", func() {
+		var (
+			response *http.Response
+		)
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when looking up the build fails", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when aborting the build fails", func() {
+						BeforeEach(func() {
+							build.MarkAsAbortedReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when aborting succeeds", func() {
+						BeforeEach(func() {
+							build.MarkAsAbortedReturns(nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/pause", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/pause", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					build.IsRunningReturns(true)
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					It("pauses the build and returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+						Expect(build.SetPausedCallCount()).To(Equal(1))
+						Expect(build.SetPausedArgsForCall(0)).To(BeTrue())
+					})
+
+					Context("when the build has finished", func() {
+						BeforeEach(func() {
+							build.IsRunningReturns(false)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							Expect(build.SetPausedCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when pausing the build fails", func() {
+						BeforeEach(func() {
+							build.SetPausedReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/resume", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+
+			build.TeamNameReturns("some-team")
+			build.IsRunningReturns(true)
+			dbBuildFactory.BuildReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/resume", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("resumes the build and returns 204", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(build.SetPausedCallCount()).To(Equal(1))
+			Expect(build.SetPausedArgsForCall(0)).To(BeFalse())
+		})
+
+		Context("when the build has finished", func() {
+			BeforeEach(func() {
+				build.IsRunningReturns(false)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/builds/:build_id/rerun", func() {
+		var (
+			response *http.Response
+
+			fakePipeline *dbfakes.FakePipeline
+			fakeJob      *dbfakes.FakeJob
+			rerunBuild   *dbfakes.FakeBuild
+			plan         atc.Plan
+		)
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+
+			plan = atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					{ID: "2", Task: &atc.TaskPlan{Name: "build"}},
+					{ID: "3", Task: &atc.TaskPlan{Name: "test"}},
+				},
+			}
+
+			build.IDReturns(128)
+			build.TeamNameReturns("some-team")
+			build.JobIDReturns(1)
+			build.JobNameReturns("some-job")
+			build.StatusReturns(db.BuildStatusFailed)
+			build.PrivatePlanReturns(plan)
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakePipeline = new(dbfakes.FakePipeline)
+			fakePipeline.JobReturns(fakeJob, true, nil)
+			build.PipelineReturns(fakePipeline, true, nil)
+
+			rerunBuild = new(dbfakes.FakeBuild)
+			rerunBuild.IDReturns(129)
+			rerunBuild.NameReturns("2")
+			rerunBuild.JobNameReturns("some-job")
+			rerunBuild.PipelineNameReturns("some-pipeline")
+			rerunBuild.TeamNameReturns("some-team")
+			rerunBuild.StatusReturns(db.BuildStatusStarted)
+			rerunBuild.RerunOfReturns(128)
+			fakeJob.RerunBuildReturns(rerunBuild, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/rerun", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("looks up the build's job", func() {
+			Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+		})
+
+		It("returns the created build", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(`{
+				"id": 129,
+				"name": "2",
+				"job_name": "some-job",
+				"pipeline_name": "some-pipeline",
+				"team_name": "some-team",
+				"status": "started",
+				"api_url": "/api/v1/builds/129",
+				"rerun_of": 128
+			}`))
+		})
+
+		Context("when the build has a checkpoint", func() {
+			BeforeEach(func() {
+				build.CheckpointReturns(atc.BuildCheckpoint{
+					PlanID: "2",
+					Artifacts: []atc.CheckpointArtifact{
+						{Name: "binary", ArtifactID: 42},
+					},
+				}, true, nil)
+			})
+
+			It("re-runs the build from the step after it", func() {
+				Expect(fakeJob.RerunBuildCallCount()).To(Equal(1))
+
+				original, rerunPlan := fakeJob.RerunBuildArgsForCall(0)
+				Expect(original).To(Equal(build))
+
+				Expect(*rerunPlan.Do).To(HaveLen(2))
+				Expect((*rerunPlan.Do)[0].InParallel.Steps[0].ArtifactInput).To(Equal(&atc.ArtifactInputPlan{
+					ArtifactID: 42,
+					Name:       "binary",
+				}))
+				Expect((*rerunPlan.Do)[1]).To(Equal((*plan.Do)[1]))
+			})
+		})
+
+		Context("when the build has no checkpoint", func() {
+			BeforeEach(func() {
+				build.CheckpointReturns(atc.BuildCheckpoint{}, false, nil)
+			})
+
+			It("re-runs the whole build", func() {
+				_, rerunPlan := fakeJob.RerunBuildArgsForCall(0)
+				Expect(rerunPlan).To(Equal(plan))
+			})
+		})
+
+		Context("when the build succeeded", func() {
+			BeforeEach(func() {
+				build.StatusReturns(db.BuildStatusSucceeded)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the build's plan was not kept", func() {
+			BeforeEach(func() {
+				build.PrivatePlanReturns(atc.Plan{})
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the build is a one-off build", func() {
+			BeforeEach(func() {
+				build.JobIDReturns(0)
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when creating the build fails", func() {
+			BeforeEach(func() {
+				fakeJob.RerunBuildReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/snapshot", func() {
+		var (
+			response *http.Response
+
+			resourceTypes atc.VersionedResourceTypes
+		)
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+
+			build.IDReturns(128)
+			build.NameReturns("3")
+			build.TeamNameReturns("some-team")
+			build.PipelineNameReturns("some-pipeline")
+			build.JobIDReturns(1)
+			build.JobNameReturns("some-job")
+			build.StatusReturns(db.BuildStatusFailed)
+			build.ImageOverridesReturns(atc.TaskImageOverrides{
+				"unit": {Version: atc.Version{"digest": "sha256:other"}},
+			})
+			build.ResourcesReturns([]db.BuildInput{
+				{Name: "source", Version: atc.Version{"ref": "abc"}, ResourceID: 1},
+			}, nil, nil)
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			resourceTypes = atc.VersionedResourceTypes{
+				{
+					ResourceType: atc.ResourceType{Name: "git", Type: "registry-image"},
+					Version:      atc.Version{"digest": "sha256:git"},
+				},
+			}
+
+			planFactory := atc.NewPlanFactory(0)
+			build.PrivatePlanReturns(planFactory.NewPlan(atc.DoPlan{
+				planFactory.NewPlan(atc.GetPlan{
+					Name:                   "source",
+					Resource:               "some-repo",
+					Type:                   "git",
+					Source:                 atc.Source{"uri": "https://example.com/repo.git", "private_key": "((key))"},
+					Params:                 atc.Params{"depth": 1},
+					Version:                &atc.Version{"ref": "abc"},
+					VersionedResourceTypes: resourceTypes,
+				}),
+				planFactory.NewPlan(atc.TaskPlan{
+					Name:       "unit",
+					Privileged: true,
+					Config: &atc.TaskConfig{
+						Platform: "linux",
+						ImageResource: &atc.ImageResource{
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "golang"},
+						},
+						Run: atc.TaskRunConfig{Path: "go"},
+					},
+					Params:                 atc.Params{"TOKEN": "((token))"},
+					VersionedResourceTypes: resourceTypes,
+				}),
+				planFactory.NewPlan(atc.TaskPlan{
+					Name:                   "integration",
+					ConfigPath:             "source/ci/integration.yml",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			}))
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/128/snapshot")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the build's inputs and tasks as they were planned", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			var snapshot atc.BuildSnapshot
+			Expect(json.Unmarshal(body, &snapshot)).To(Succeed())
+			Expect(snapshot.ResourceTypes).To(Equal(resourceTypes))
+
+			snapshot.ResourceTypes = nil
+			body, err = json.Marshal(snapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(`{
+				"build": {
+					"id": 128,
+					"name": "3",
+					"job_name": "some-job",
+					"pipeline_name": "some-pipeline",
+					"team_name": "some-team",
+					"status": "failed",
+					"api_url": "/api/v1/builds/128",
+					"image_overrides": {"unit": {"version": {"digest": "sha256:other"}}}
+				},
+				"inputs": [
+					{
+						"name": "source",
+						"resource": "some-repo",
+						"type": "git",
+						"source": {"uri": "https://example.com/repo.git", "private_key": "((key))"},
+						"params": {"depth": 1},
+						"version": {"ref": "abc"}
+					}
+				],
+				"tasks": [
+					{
+						"name": "unit",
+						"config": {
+							"platform": "linux",
+							"image_resource": {
+								"type": "registry-image",
+								"source": {"repository": "golang"}
+							},
+							"container_limits": {},
+							"run": {"path": "go"}
+						},
+						"params": {"TOKEN": "((token))"},
+						"privileged": true,
+						"image_override": {"version": {"digest": "sha256:other"}}
+					},
+					{
+						"name": "integration",
+						"config_path": "source/ci/integration.yml"
+					}
+				]
+			}`))
+		})
+
+		Context("when the build is a one-off build", func() {
+			BeforeEach(func() {
+				build.JobIDReturns(0)
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the build's plan was not kept", func() {
+			BeforeEach(func() {
+				build.PrivatePlanReturns(atc.Plan{})
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the requester is not authorized for the build's team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts/:artifact_name", func() {
+		var (
+			response *http.Response
+
+			olderArtifact    *dbfakes.FakeWorkerArtifact
+			artifact         *dbfakes.FakeWorkerArtifact
+			fakeVolume       *dbfakes.FakeCreatedVolume
+			fakeWorkerVolume *workerfakes.FakeVolume
+
+			rangeHeader string
+		)
+
+		BeforeEach(func() {
+			rangeHeader = ""
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+
+			build.IDReturns(128)
+			build.TeamIDReturns(734)
+			build.TeamNameReturns("some-team")
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			olderArtifact = new(dbfakes.FakeWorkerArtifact)
+			olderArtifact.IDReturns(1)
+			olderArtifact.NameReturns("binary")
+
+			otherArtifact := new(dbfakes.FakeWorkerArtifact)
+			otherArtifact.IDReturns(3)
+			otherArtifact.NameReturns("other")
+
+			artifact = new(dbfakes.FakeWorkerArtifact)
+			artifact.IDReturns(2)
+			artifact.NameReturns("binary")
+
+			build.ArtifactsReturns([]db.WorkerArtifact{olderArtifact, artifact, otherArtifact}, nil)
+
+			fakeVolume = new(dbfakes.FakeCreatedVolume)
+			fakeVolume.HandleReturns("some-handle")
+			artifact.VolumeReturns(fakeVolume, true, nil)
+
+			fakeWorkerVolume = new(workerfakes.FakeVolume)
+			fakeWorkerVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-tarball")), nil)
+			fakeWorkerClient.FindVolumeReturns(fakeWorkerVolume, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/builds/128/artifacts/binary", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if rangeHeader != "" {
+				req.Header.Set("Range", rangeHeader)
+			}
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("streams out the latest artifact with the name", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/octet-stream"))
+			Expect(response.Header.Get("Content-Disposition")).To(Equal(`attachment; filename="binary.tar.zst"`))
+			Expect(response.Header.Get("Accept-Ranges")).To(Equal("bytes"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("some-tarball"))
+
+			Expect(artifact.VolumeArgsForCall(0)).To(Equal(734))
+			Expect(olderArtifact.VolumeCallCount()).To(BeZero())
+
+			_, teamID, handle := fakeWorkerClient.FindVolumeArgsForCall(0)
+			Expect(teamID).To(Equal(734))
+			Expect(handle).To(Equal("some-handle"))
+
+			_, path := fakeWorkerVolume.StreamOutArgsForCall(0)
+			Expect(path).To(Equal("/"))
+		})
+
+		Context("when resuming the download from an offset", func() {
+			BeforeEach(func() {
+				rangeHeader = "bytes=5-"
+			})
+
+			It("streams out the rest of the artifact from the offset", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusPartialContent))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(Equal("tarball"))
+			})
+
+			Context("when the artifact is shorter than the offset", func() {
+				BeforeEach(func() {
+					rangeHeader = "bytes=50-"
+				})
+
+				It("returns 416", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusRequestedRangeNotSatisfiable))
+				})
+			})
+		})
+
+		Context("when the range isn't from an offset onwards", func() {
+			BeforeEach(func() {
+				rangeHeader = "bytes=0-5"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the build is running", func() {
+			BeforeEach(func() {
+				build.IsRunningReturns(true)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the build has no artifact with the name", func() {
+			BeforeEach(func() {
+				build.ArtifactsReturns([]db.WorkerArtifact{}, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the artifact's volume is gone", func() {
+			BeforeEach(func() {
+				artifact.VolumeReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the worker volume can't be found", func() {
+			BeforeEach(func() {
+				fakeWorkerClient.FindVolumeReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when streaming the volume fails", func() {
+			BeforeEach(func() {
+				fakeWorkerVolume.StreamOutReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/annotations", func() {
+		var (
+			token    string
+			body     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			token = ""
+			body = `{"coverage":"87%","ticket":"https://tracker.example.com/123"}`
+
+			build.IDReturns(128)
+			build.NameReturns("some-build")
+			build.TeamNameReturns("some-team")
+			build.TokenReturns("some-token")
+			build.IsRunningReturns(true)
+			build.AnnotationsReturns(atc.BuildAnnotations{"coverage": "87%"})
+			dbBuildFactory.BuildReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/annotations", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			if token != "" {
+				req.Header.Set(atc.BuildTokenHeader, token)
+			}
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(build.AnnotateCallCount()).To(BeZero())
+			})
+
+			Context("when the build's token is given", func() {
+				BeforeEach(func() {
+					token = "some-token"
+				})
+
+				It("annotates the build", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					Expect(build.AnnotateCallCount()).To(Equal(1))
+					Expect(build.AnnotateArgsForCall(0)).To(Equal(atc.BuildAnnotations{
+						"coverage": "87%",
+						"ticket":   "https://tracker.example.com/123",
+					}))
+				})
+
+				It("returns the build with its annotations", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					var presented atc.Build
+					err := json.NewDecoder(response.Body).Decode(&presented)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(presented.ID).To(Equal(128))
+					Expect(presented.Annotations).To(Equal(atc.BuildAnnotations{"coverage": "87%"}))
+				})
+
+				Context("when the build has finished", func() {
+					BeforeEach(func() {
+						build.IsRunningReturns(false)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						Expect(build.AnnotateCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the wrong token is given", func() {
+				BeforeEach(func() {
+					token = "some-other-token"
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(build.AnnotateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the build has no token", func() {
+				BeforeEach(func() {
+					token = "some-token"
+					build.TokenReturns("")
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(build.AnnotateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				It("checks the build's team", func() {
+					Expect(fakeAccess.IsAuthorizedArgsForCall(0)).To(Equal("some-team"))
+				})
+
+				It("annotates the build, even once it has finished", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(build.AnnotateCallCount()).To(Equal(1))
+				})
+
+				Context("when the build can not be found", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when looking up the build fails", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						body = `{"coverage":`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(build.AnnotateCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when an annotation is invalid", func() {
+					BeforeEach(func() {
+						body = `{"not a key":"value"}`
+					})
+
+					It("returns 400 with the error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+						errBody, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(errBody)).To(ContainSubstring("not a key"))
+
+						Expect(build.AnnotateCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the build would have too many annotations", func() {
+					BeforeEach(func() {
+						build.AnnotateReturns(db.ErrTooManyBuildAnnotations)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("returns the quota_exceeded error code", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"code": "quota_exceeded",
+							"message": "builds may have at most 50 annotations"
+						}`))
+					})
+				})
+
+				Context("when annotating the build fails", func() {
+					BeforeEach(func() {
+						build.AnnotateReturns(errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/preparation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/preparation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			var buildPrep db.BuildPreparation
+
+			BeforeEach(func() {
+				buildPrep = db.BuildPreparation{
+					BuildID:          42,
+					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
+					PausedJob:        db.BuildPreparationStatusNotBlocking,
+					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					Inputs: map[string]db.BuildPreparationStatus{
+						"foo": db.BuildPreparationStatusUnknown,
+						"bar": db.BuildPreparationStatusBlocking,
+					},
+					InputsSatisfied:     db.BuildPreparationStatusBlocking,
+					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+				}
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.PreparationReturns(buildPrep, true, nil)
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+				})
+
+				Context("and build is one off", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is private", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(false)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is public", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(true)
+					})
+
+					Context("when the job is found", func() {
+						var fakeJob *dbfakes.FakeJob
+						BeforeEach(func() {
+							fakeJob = new(dbfakes.FakeJob)
+							fakePipeline.JobReturns(fakeJob, true, nil)
+						})
+
+						Context("when job is private", func() {
+							BeforeEach(func() {
+								fakeJob.PublicReturns(false)
+							})
+
+							It("returns 401", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							})
+						})
+
+						Context("when job is public", func() {
+							BeforeEach(func() {
+								fakeJob.PublicReturns(true)
+							})
+
+							It("returns 200", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+						})
+					})
+
+					Context("when finding the job fails", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, errors.New("nope"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the job cannot be found", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, nil)
+						})
 
 						It("returns Not Found", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
@@ -1187,11 +2541,468 @@ var _ = Describe("Builds API", func() {
 					fakeAccess.IsAuthorizedReturns(true)
 				})
 
-				It("fetches data from the db", func() {
-					Expect(build.PreparationCallCount()).To(Equal(1))
+				It("fetches data from the db", func() {
+					Expect(build.PreparationCallCount()).To(Equal(1))
+				})
+
+				It("returns OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the build preparation", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+					"build_id": 42,
+					"paused_pipeline": "not_blocking",
+					"paused_job": "not_blocking",
+					"max_running_builds": "blocking",
+					"inputs": {
+						"foo": "unknown",
+						"bar": "blocking"
+					},
+					"inputs_satisfied": "blocking",
+					"missing_input_reasons": {
+						"some-input": "some-reason"
+					}
+				}`))
+				})
+
+				Context("when the build preparation is not found", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(build, true, nil)
+						build.PreparationReturns(db.BuildPreparation{}, false, nil)
+					})
+
+					It("returns Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when looking up the build preparation fails", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(build, true, nil)
+						build.PreparationReturns(db.BuildPreparation{}, false, errors.New("ho ho ho merry festivus"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when looking up the build fails", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, errors.New("ho ho ho merry festivus"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/plan", func() {
+		var plan *json.RawMessage
+
+		var response *http.Response
+
+		BeforeEach(func() {
+			data := []byte(`{"some":"plan"}`)
+			plan = (*json.RawMessage)(&data)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/plan")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+
+					build.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+				})
+
+				Context("and build is one off", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is private", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(false)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is public", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(true)
+					})
+
+					Context("when finding the job fails", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, errors.New("nope"))
+						})
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the job does not exist", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, nil)
+						})
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when the job exists", func() {
+						var fakeJob *dbfakes.FakeJob
+
+						BeforeEach(func() {
+							fakeJob = new(dbfakes.FakeJob)
+							fakePipeline.JobReturns(fakeJob, true, nil)
+						})
+
+						Context("and the job is public", func() {
+							BeforeEach(func() {
+								fakeJob.PublicReturns(true)
+							})
+							Context("and the build has a plan", func() {
+								BeforeEach(func() {
+									build.HasPlanReturns(true)
+								})
+								It("returns 200", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+							})
+							Context("and the build has no plan", func() {
+								BeforeEach(func() {
+									build.HasPlanReturns(false)
+								})
+								It("returns 404", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+								})
+							})
+						})
+
+						Context("and the job is private", func() {
+							BeforeEach(func() {
+								fakeJob.PublicReturns(false)
+							})
+							It("returns 401", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							})
+						})
+					})
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build returns a plan", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(true)
+						build.PublicPlanReturns(plan)
+						build.SchemaReturns("some-schema")
+					})
+
+					It("returns OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns Content-Type 'application/json'", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					})
+
+					It("returns the plan", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+						"schema": "some-schema",
+						"plan": {"some":"plan"}
+					}`))
+					})
 				})
 
-				It("returns OK", func() {
+				Context("when the build has no plan", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(false)
+					})
+
+					It("returns no Content-Type header", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal(""))
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when looking up the build fails", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, errors.New("oh no!"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifact-registry", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifact-registry")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build has a plan", func() {
+					BeforeEach(func() {
+						plan := atc.Plan{
+							ID: "1",
+							Do: &atc.DoPlan{
+								{ID: "2", Get: &atc.GetPlan{Name: "repo", Source: atc.Source{"private": "key"}}},
+								{ID: "3", Task: &atc.TaskPlan{
+									Name: "build",
+									Config: &atc.TaskConfig{
+										Inputs: []atc.TaskInputConfig{{Name: "repo"}, {Name: "missing"}},
+									},
+								}},
+							},
+						}
+
+						build.HasPlanReturns(true)
+						build.PublicPlanReturns(plan.Public())
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns Content-Type 'application/json'", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					})
+
+					It("returns the artifacts registered by the plan", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"artifacts": [
+								{"name": "repo", "plan_id": "2", "step": "get", "used_by": ["3"]}
+							],
+							"unresolved": [
+								{"name": "missing", "plan_id": "3"}
+							]
+						}`))
+					})
+				})
+
+				Context("when the build has no plan", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(false)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+	Describe("GET /api/v1/builds/:build_id/explain", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/explain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.NameReturns("7")
+				build.JobNameReturns("job1")
+				build.PipelineNameReturns("some-pipeline")
+				build.TeamNameReturns("some-team")
+				build.StatusReturns(db.BuildStatusFailed)
+				build.StartTimeReturns(time.Unix(100, 0))
+				build.EndTimeReturns(time.Unix(200, 0))
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				var fakeEventSource *dbfakes.FakeEventSource
+
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+
+					build.ResourcesReturns([]db.BuildInput{
+						{Name: "repo", Version: atc.Version{"ref": "abc"}, FirstOccurrence: true},
+						{Name: "image", Version: atc.Version{"digest": "old"}, FallbackFrom: atc.Version{"digest": "new"}},
+						{Name: "config", Version: atc.Version{"ref": "pinned"}},
+					}, nil, nil)
+					build.InputOverridesReturns(atc.InputVersionOverrides{"config": atc.Version{"ref": "pinned"}})
+
+					plan := atc.Plan{
+						ID: "1",
+						Do: &atc.DoPlan{
+							{ID: "2", Get: &atc.GetPlan{Name: "repo"}},
+							{ID: "3", Ensure: &atc.EnsurePlan{
+								Step: atc.Plan{ID: "4", Task: &atc.TaskPlan{Name: "unit"}},
+								Next: atc.Plan{ID: "5", Put: &atc.PutPlan{Name: "status"}},
+							}},
+						},
+					}
+
+					build.HasPlanReturns(true)
+					build.SchemaReturns("exec.v2")
+					build.PublicPlanReturns(plan.Public())
+
+					returnedEvents := []event.Envelope{
+						envelope(event.InitializeGet{Origin: event.Origin{ID: "2"}, Time: 101}),
+						envelope(event.StartGet{Origin: event.Origin{ID: "2"}, Time: 103, Worker: "worker-a", Cached: true}),
+						envelope(event.FinishGet{Origin: event.Origin{ID: "2"}, Time: 104}),
+						envelope(event.InitializeTask{Origin: event.Origin{ID: "4"}, Time: 105}),
+						envelope(event.StartTask{Origin: event.Origin{ID: "4"}, Time: 115, Worker: "worker-b"}),
+						envelope(event.Log{Origin: event.Origin{ID: "4"}, Time: 116, Payload: "FAIL"}),
+						envelope(event.FinishTask{Origin: event.Origin{ID: "4"}, Time: 175, ExitStatus: 1}),
+						envelope(event.InitializePut{Origin: event.Origin{ID: "5"}, Time: 176}),
+						envelope(event.Error{Origin: event.Origin{ID: "5"}, Time: 177, Message: "no workers"}),
+						envelope(event.Status{Status: atc.StatusFailed, Time: 200}),
+					}
+
+					fakeEventSource = new(dbfakes.FakeEventSource)
+					fakeEventSource.NextStub = func() (event.Envelope, error) {
+						i := fakeEventSource.NextCallCount() - 1
+						if i >= len(returnedEvents) {
+							return event.Envelope{}, db.ErrEndOfBuildEventStream
+						}
+
+						return returnedEvents[i], nil
+					}
+
+					build.EventsReturns(fakeEventSource, nil)
+				})
+
+				It("returns 200", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
 
@@ -1199,242 +3010,459 @@ var _ = Describe("Builds API", func() {
 					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
 				})
 
-				It("returns the build preparation", func() {
+				It("explains the inputs, plan, and steps of the build", func() {
 					body, err := ioutil.ReadAll(response.Body)
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(body).To(MatchJSON(`{
-					"build_id": 42,
-					"paused_pipeline": "not_blocking",
-					"paused_job": "not_blocking",
-					"max_running_builds": "blocking",
-					"inputs": {
-						"foo": "unknown",
-						"bar": "blocking"
-					},
-					"inputs_satisfied": "blocking",
-					"missing_input_reasons": {
-						"some-input": "some-reason"
-					}
-				}`))
+						"build": {
+							"id": 42,
+							"name": "7",
+							"status": "failed",
+							"job_name": "job1",
+							"pipeline_name": "some-pipeline",
+							"team_name": "some-team",
+							"api_url": "/api/v1/builds/42",
+							"start_time": 100,
+							"end_time": 200,
+							"input_overrides": {"config": {"ref": "pinned"}}
+						},
+						"inputs": [
+							{"name": "repo", "version": {"ref": "abc"}, "reason": "latest", "first_occurrence": true},
+							{"name": "image", "version": {"digest": "old"}, "reason": "fallback", "fallback_from": {"digest": "new"}, "first_occurrence": false},
+							{"name": "config", "version": {"ref": "pinned"}, "reason": "override", "first_occurrence": false}
+						],
+						"plan": {
+							"schema": "exec.v2",
+							"plan": {
+								"id": "1",
+								"do": [
+									{"id": "2", "get": {"type": "", "name": "repo", "resource": ""}},
+									{"id": "3", "ensure": {
+										"step": {"id": "4", "task": {"name": "unit", "privileged": false}},
+										"ensure": {"id": "5", "put": {"type": "", "name": "status", "resource": ""}}
+									}}
+								]
+							}
+						},
+						"steps": [
+							{
+								"plan_id": "2",
+								"type": "get",
+								"name": "repo",
+								"worker": "worker-a",
+								"cached": true,
+								"exit_status": 0,
+								"initializing_duration": 2,
+								"running_duration": 1
+							},
+							{
+								"plan_id": "4",
+								"type": "task",
+								"name": "unit",
+								"worker": "worker-b",
+								"exit_status": 1,
+								"initializing_duration": 10,
+								"running_duration": 60
+							},
+							{
+								"plan_id": "5",
+								"type": "put",
+								"name": "status",
+								"error": "no workers"
+							}
+						]
+					}`))
 				})
 
-				Context("when the build preparation is not found", func() {
+				It("closes the event source", func() {
+					Eventually(fakeEventSource.CloseCallCount).Should(Equal(1))
+				})
+
+				Context("when the build failed before running any steps", func() {
 					BeforeEach(func() {
-						dbBuildFactory.BuildReturns(build, true, nil)
-						build.PreparationReturns(db.BuildPreparation{}, false, nil)
+						fakeEventSource.NextStub = nil
+						fakeEventSource.NextReturnsOnCall(0, envelope(event.Error{Message: "failed to interpolate"}), nil)
+						fakeEventSource.NextReturnsOnCall(1, event.Envelope{}, db.ErrEndOfBuildEventStream)
 					})
 
-					It("returns Not Found", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					It("returns the errors of the build", func() {
+						var explanation atc.BuildExplanation
+						err := json.NewDecoder(response.Body).Decode(&explanation)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(explanation.Steps).To(BeEmpty())
+						Expect(explanation.Errors).To(Equal([]string{"failed to interpolate"}))
 					})
 				})
 
-				Context("when looking up the build preparation fails", func() {
+				Context("when the build is still running", func() {
 					BeforeEach(func() {
-						dbBuildFactory.BuildReturns(build, true, nil)
-						build.PreparationReturns(db.BuildPreparation{}, false, errors.New("ho ho ho merry festivus"))
+						build.IsRunningReturns(true)
 					})
 
-					It("returns 500 Internal Server Error", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not read the events", func() {
+						Expect(build.EventsCallCount()).To(BeZero())
 					})
 				})
-			})
-		})
 
-		Context("when looking up the build fails", func() {
-			BeforeEach(func() {
-				dbBuildFactory.BuildReturns(nil, false, errors.New("ho ho ho merry festivus"))
-			})
+				Context("when reading the events fails", func() {
+					BeforeEach(func() {
+						build.EventsReturns(nil, errors.New("nope"))
+					})
 
-			It("returns 500 Internal Server Error", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
 			})
 		})
 
-		Context("when build is not found", func() {
+		Context("when the build is not found", func() {
 			BeforeEach(func() {
 				dbBuildFactory.BuildReturns(nil, false, nil)
 			})
 
-			It("returns 404", func() {
+			It("returns Not Found", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 			})
 		})
 	})
 
-	Describe("GET /api/v1/builds/:build_id/plan", func() {
-		var plan *json.RawMessage
-
-		var response *http.Response
+	Describe("GET /api/v1/builds/:build_id/plan/graph", func() {
+		var (
+			response *http.Response
+			query    string
+		)
 
 		BeforeEach(func() {
-			data := []byte(`{"some":"plan"}`)
-			plan = (*json.RawMessage)(&data)
+			query = ""
 		})
 
 		JustBeforeEach(func() {
 			var err error
-			response, err = http.Get(server.URL + "/api/v1/builds/42/plan")
+			response, err = http.Get(server.URL + "/api/v1/builds/42/plan/graph" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
 		Context("when the build is found", func() {
 			BeforeEach(func() {
+				build.IDReturns(42)
 				build.JobNameReturns("job1")
+				build.PipelineNameReturns("some-pipeline")
 				build.TeamNameReturns("some-team")
 				dbBuildFactory.BuildReturns(build, true, nil)
 			})
 
-			Context("when authenticated, but not authorized", func() {
+			Context("when not authenticated and the pipeline is private", func() {
 				BeforeEach(func() {
-					fakeAccess.IsAuthenticatedReturns(true)
-					fakeAccess.IsAuthorizedReturns(false)
-
+					fakeAccess.IsAuthenticatedReturns(false)
 					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
 				})
 
-				It("returns 403", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 				})
 			})
 
-			Context("when not authenticated", func() {
+			Context("when authenticated", func() {
 				BeforeEach(func() {
-					fakeAccess.IsAuthenticatedReturns(false)
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
 				})
 
-				Context("and build is one off", func() {
+				Context("when the build has a plan", func() {
 					BeforeEach(func() {
-						build.PipelineReturns(nil, false, nil)
-					})
+						plan := atc.Plan{
+							ID: "1",
+							Ensure: &atc.EnsurePlan{
+								Step: atc.Plan{ID: "2", Task: &atc.TaskPlan{Name: "unit"}},
+								Next: atc.Plan{ID: "3", Put: &atc.PutPlan{Name: "status", Resource: "status"}},
+							},
+						}
 
-					It("returns 401", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						build.HasPlanReturns(true)
+						build.PublicPlanReturns(plan.Public())
 					})
-				})
 
-				Context("and the pipeline is private", func() {
-					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(false)
-					})
+					It("returns the plan as a graph", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
 
-					It("returns 401", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-					})
-				})
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
 
-				Context("and the pipeline is public", func() {
-					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(true)
+						Expect(body).To(MatchJSON(`{
+							"nodes": [
+								{"id": "1", "type": "ensure"},
+								{"id": "2", "type": "task", "name": "unit"},
+								{"id": "3", "type": "put", "name": "status"}
+							],
+							"edges": [
+								{"from": "2", "to": "3", "type": "ensure"},
+								{"from": "1", "to": "2", "type": "contains"},
+								{"from": "1", "to": "3", "type": "contains"}
+							]
+						}`))
 					})
 
-					Context("when finding the job fails", func() {
+					Context("when asked for DOT", func() {
 						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, errors.New("nope"))
+							query = "?format=dot"
 						})
-						It("returns 500", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+
+						It("returns the graph in DOT", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(ContainSubstring(`"2" -> "3" [label="ensure", style=solid];`))
 						})
 					})
+				})
 
-					Context("when the job does not exist", func() {
-						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, nil)
-						})
-						It("returns 404", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
-						})
+				Context("when the build has no plan", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(false)
 					})
 
-					Context("when the job exists", func() {
-						var fakeJob *dbfakes.FakeJob
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+		})
+	})
 
-						BeforeEach(func() {
-							fakeJob = new(dbfakes.FakeJob)
-							fakePipeline.JobReturns(fakeJob, true, nil)
-						})
+	Describe("GET /api/v1/builds/:build_id/timeline", func() {
+		var response *http.Response
 
-						Context("and the job is public", func() {
-							BeforeEach(func() {
-								fakeJob.PublicReturns(true)
-							})
-							Context("and the build has a plan", func() {
-								BeforeEach(func() {
-									build.HasPlanReturns(true)
-								})
-								It("returns 200", func() {
-									Expect(response.StatusCode).To(Equal(http.StatusOK))
-								})
-							})
-							Context("and the build has no plan", func() {
-								BeforeEach(func() {
-									build.HasPlanReturns(false)
-								})
-								It("returns 404", func() {
-									Expect(response.StatusCode).To(Equal(http.StatusNotFound))
-								})
-							})
-						})
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/timeline")
+			Expect(err).NotTo(HaveOccurred())
+		})
 
-						Context("and the job is private", func() {
-							BeforeEach(func() {
-								fakeJob.PublicReturns(false)
-							})
-							It("returns 401", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-							})
-						})
-					})
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.NameReturns("7")
+				build.JobNameReturns("job1")
+				build.PipelineNameReturns("some-pipeline")
+				build.TeamNameReturns("some-team")
+				build.StatusReturns(db.BuildStatusSucceeded)
+				build.CreateTimeReturns(time.Unix(90, 0))
+				build.StartTimeReturns(time.Unix(100, 0))
+				build.EndTimeReturns(time.Unix(160, 0))
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 				})
 			})
 
 			Context("when authenticated", func() {
+				var fakeEventSource *dbfakes.FakeEventSource
+
 				BeforeEach(func() {
 					fakeAccess.IsAuthenticatedReturns(true)
 					fakeAccess.IsAuthorizedReturns(true)
+
+					plan := atc.Plan{
+						ID: "1",
+						Do: &atc.DoPlan{
+							{ID: "2", InParallel: &atc.InParallelPlan{
+								Steps: []atc.Plan{
+									{ID: "3", Get: &atc.GetPlan{Name: "repo", Resource: "repo"}},
+									{ID: "4", Get: &atc.GetPlan{Name: "image", Resource: "image"}},
+								},
+							}},
+							{ID: "5", Ensure: &atc.EnsurePlan{
+								Step: atc.Plan{ID: "6", Task: &atc.TaskPlan{Name: "unit"}},
+								Next: atc.Plan{ID: "7", Put: &atc.PutPlan{Name: "status", Resource: "status"}},
+							}},
+						},
+					}
+
+					build.HasPlanReturns(true)
+					build.PublicPlanReturns(plan.Public())
+
+					returnedEvents := []event.Envelope{
+						envelope(event.InitializeGet{Origin: event.Origin{ID: "3"}, Time: 101}),
+						envelope(event.InitializeGet{Origin: event.Origin{ID: "4"}, Time: 101}),
+						envelope(event.StartGet{Origin: event.Origin{ID: "4"}, Time: 102, Worker: "worker-a"}),
+						envelope(event.StartGet{Origin: event.Origin{ID: "3"}, Time: 103, Worker: "worker-b"}),
+						envelope(event.FinishGet{Origin: event.Origin{ID: "3"}, Time: 104}),
+						envelope(event.FinishGet{Origin: event.Origin{ID: "4"}, Time: 110}),
+						envelope(event.InitializeTask{Origin: event.Origin{ID: "6"}, Time: 110}),
+						envelope(event.ContainerPrepared{Origin: event.Origin{ID: "6"}, Time: 116, ImageFetchDuration: 4, InputStreamDuration: 2}),
+						envelope(event.StartTask{Origin: event.Origin{ID: "6"}, Time: 118, Worker: "worker-a"}),
+						envelope(event.FinishTask{Origin: event.Origin{ID: "6"}, Time: 150}),
+						envelope(event.InitializePut{Origin: event.Origin{ID: "7"}, Time: 150}),
+						envelope(event.StartPut{Origin: event.Origin{ID: "7"}, Time: 151, Worker: "worker-a"}),
+						envelope(event.FinishPut{Origin: event.Origin{ID: "7"}, Time: 160}),
+						envelope(event.Status{Status: atc.StatusSucceeded, Time: 160}),
+					}
+
+					fakeEventSource = new(dbfakes.FakeEventSource)
+					fakeEventSource.NextStub = func() (event.Envelope, error) {
+						i := fakeEventSource.NextCallCount() - 1
+						if i >= len(returnedEvents) {
+							return event.Envelope{}, db.ErrEndOfBuildEventStream
+						}
+
+						return returnedEvents[i], nil
+					}
+
+					build.EventsReturns(fakeEventSource, nil)
 				})
 
-				Context("when the build returns a plan", func() {
-					BeforeEach(func() {
-						build.HasPlanReturns(true)
-						build.PublicPlanReturns(plan)
-						build.SchemaReturns("some-schema")
-					})
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
 
-					It("returns OK", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusOK))
-					})
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
 
-					It("returns Content-Type 'application/json'", func() {
-						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				It("returns the timeline of the build with its critical path", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"build": {
+							"id": 42,
+							"name": "7",
+							"status": "succeeded",
+							"job_name": "job1",
+							"pipeline_name": "some-pipeline",
+							"team_name": "some-team",
+							"api_url": "/api/v1/builds/42",
+							"start_time": 100,
+							"end_time": 160
+						},
+						"queued_duration": 10,
+						"steps": [
+							{
+								"plan_id": "3",
+								"type": "get",
+								"name": "repo",
+								"worker": "worker-b",
+								"initialized_at": 101,
+								"started_at": 103,
+								"finished_at": 104,
+								"queued_duration": 2,
+								"image_fetch_duration": 0,
+								"input_stream_duration": 0,
+								"running_duration": 1,
+								"on_critical_path": false
+							},
+							{
+								"plan_id": "4",
+								"type": "get",
+								"name": "image",
+								"worker": "worker-a",
+								"initialized_at": 101,
+								"started_at": 102,
+								"finished_at": 110,
+								"queued_duration": 1,
+								"image_fetch_duration": 0,
+								"input_stream_duration": 0,
+								"running_duration": 8,
+								"on_critical_path": true
+							},
+							{
+								"plan_id": "6",
+								"type": "task",
+								"name": "unit",
+								"worker": "worker-a",
+								"initialized_at": 110,
+								"started_at": 118,
+								"finished_at": 150,
+								"queued_duration": 2,
+								"image_fetch_duration": 4,
+								"input_stream_duration": 2,
+								"running_duration": 32,
+								"on_critical_path": true
+							},
+							{
+								"plan_id": "7",
+								"type": "put",
+								"name": "status",
+								"worker": "worker-a",
+								"initialized_at": 150,
+								"started_at": 151,
+								"finished_at": 160,
+								"queued_duration": 1,
+								"image_fetch_duration": 0,
+								"input_stream_duration": 0,
+								"running_duration": 9,
+								"on_critical_path": true
+							}
+						],
+						"critical_path": ["4", "6", "7"],
+						"critical_path_duration": 59
+					}`))
+				})
+
+				It("closes the event source", func() {
+					Eventually(fakeEventSource.CloseCallCount).Should(Equal(1))
+				})
+
+				Context("when a step did not finish", func() {
+					BeforeEach(func() {
+						fakeEventSource.NextStub = nil
+						fakeEventSource.NextReturnsOnCall(0, envelope(event.InitializeGet{Origin: event.Origin{ID: "3"}, Time: 101}), nil)
+						fakeEventSource.NextReturnsOnCall(1, envelope(event.Error{Origin: event.Origin{ID: "3"}, Time: 102, Message: "no workers"}), nil)
+						fakeEventSource.NextReturnsOnCall(2, event.Envelope{}, db.ErrEndOfBuildEventStream)
 					})
 
-					It("returns the plan", func() {
-						body, err := ioutil.ReadAll(response.Body)
+					It("leaves it off the critical path", func() {
+						var timeline atc.BuildTimeline
+						err := json.NewDecoder(response.Body).Decode(&timeline)
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(body).To(MatchJSON(`{
-						"schema": "some-schema",
-						"plan": {"some":"plan"}
-					}`))
+						Expect(timeline.Steps).To(HaveLen(1))
+						Expect(timeline.CriticalPath).To(BeEmpty())
+						Expect(timeline.CriticalPathDuration).To(BeZero())
 					})
 				})
 
-				Context("when the build has no plan", func() {
+				Context("when the build is still running", func() {
 					BeforeEach(func() {
-						build.HasPlanReturns(false)
+						build.IsRunningReturns(true)
 					})
 
-					It("returns no Content-Type header", func() {
-						Expect(response.Header.Get("Content-Type")).To(Equal(""))
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
 					})
 
-					It("returns not found", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					It("does not read the events", func() {
+						Expect(build.EventsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when reading the events fails", func() {
+					BeforeEach(func() {
+						build.EventsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
 			})
@@ -1449,15 +3477,17 @@ var _ = Describe("Builds API", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 			})
 		})
-
-		Context("when looking up the build fails", func() {
-			BeforeEach(func() {
-				dbBuildFactory.BuildReturns(nil, false, errors.New("oh no!"))
-			})
-
-			It("returns 500 Internal Server Error", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-			})
-		})
 	})
 })
+
+func envelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+	}
+}
@@ -0,0 +1,109 @@
+package buildserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// AnnotateBuild merges the given annotations into the build's annotations.
+//
+// Besides members of the build's team, the build's own tasks may annotate it
+// while it is running by presenting the build's token.
+func (s *Server) AnnotateBuild(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("annotate-build")
+
+	buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	logger = logger.WithData(lager.Data{"build": buildID})
+
+	build, found, err := s.buildFactory.Build(buildID)
+	if err != nil {
+		logger.Error("failed-to-get-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+	if !canAnnotate(acc, build, r.Header.Get(atc.BuildTokenHeader)) {
+		if acc.IsAuthenticated() {
+			s.rejector.Forbidden(w, r)
+			return
+		}
+
+		s.rejector.Unauthorized(w, r)
+		return
+	}
+
+	var annotations atc.BuildAnnotations
+	err = json.NewDecoder(r.Body).Decode(&annotations)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = annotations.Validate()
+	if err != nil {
+		logger.Info("invalid-annotations", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	err = build.Annotate(annotations)
+	if err == db.ErrTooManyBuildAnnotations {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+
+		err = json.NewEncoder(w).Encode(atc.ErrorResponse{
+			Code:    atc.ErrorCodeQuotaExceeded,
+			Message: err.Error(),
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-error", err)
+		}
+
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-annotate-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(present.Build(build))
+	if err != nil {
+		logger.Error("failed-to-encode-build", err)
+	}
+}
+
+func canAnnotate(acc accessor.Access, build db.Build, token string) bool {
+	if token != "" && build.Token() != "" && build.IsRunning() {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(build.Token())) == 1 {
+			return true
+		}
+	}
+
+	return acc.IsAuthenticated() && acc.IsAuthorized(build.TeamName())
+}
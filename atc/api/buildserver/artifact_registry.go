@@ -0,0 +1,38 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildArtifactRegistry(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-artifact-registry")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !build.HasPlan() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// the public plan keeps the names of the artifacts each step uses
+		// and registers, and is kept once the build has finished
+		var plan atc.Plan
+		err := json.Unmarshal(*build.PublicPlan(), &plan)
+		if err != nil {
+			hLog.Error("failed-to-unmarshal-public-plan", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(atc.NewArtifactRegistry(plan))
+		if err != nil {
+			hLog.Error("failed-to-encode-artifact-registry", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+}
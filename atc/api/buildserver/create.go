@@ -3,6 +3,7 @@ package buildserver
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -22,6 +23,14 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 			return
 		}
 
+		problems := atc.NewArtifactRegistry(plan).Errors()
+		if len(problems) > 0 {
+			hLog.Info("invalid-artifacts", lager.Data{"problems": problems})
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(strings.Join(problems, "\n")))
+			return
+		}
+
 		build, err := team.CreateStartedBuild(plan)
 		if err != nil {
 			hLog.Error("failed-to-create-one-off-build", err)
@@ -0,0 +1,118 @@
+package buildserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// DownloadBuildArtifact streams the contents of an artifact kept by a
+// finished build as a zstd-compressed tarball, straight from the volume on
+// its worker. Where the build kept more than one artifact with the name, the
+// latest one is downloaded. A download which was interrupted is resumed by
+// asking for the range of bytes from where it left off.
+func (s *Server) DownloadBuildArtifact(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":artifact_name")
+
+		logger := s.logger.Session("download-build-artifact", lager.Data{
+			"build":    build.ID(),
+			"artifact": name,
+		})
+
+		offset, err := worker.ParseStreamRange(r.Header.Get("Range"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("the build has not finished"))
+			return
+		}
+
+		artifacts, err := build.Artifacts()
+		if err != nil {
+			logger.Error("failed-to-get-build-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var artifact db.WorkerArtifact
+		for _, candidate := range artifacts {
+			if candidate.Name() != name {
+				continue
+			}
+
+			if artifact == nil || candidate.ID() > artifact.ID() {
+				artifact = candidate
+			}
+		}
+
+		if artifact == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		artifactVolume, found, err := artifact.Volume(build.TeamID())
+		if err != nil {
+			logger.Error("failed-to-get-artifact-volume", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("artifact-volume-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		workerVolume, found, err := s.workerClient.FindVolume(logger, build.TeamID(), artifactVolume.Handle())
+		if err != nil {
+			logger.Error("failed-to-get-worker-volume", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("worker-volume-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		reader, err := worker.StreamOutFrom(r.Context(), workerVolume, "/", offset)
+		if err == worker.ErrStreamChanged {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-stream-volume-contents", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		defer reader.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.tar.zst"`, name))
+		w.Header().Set("Accept-Ranges", "bytes")
+
+		if offset > 0 {
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		_, err = io.Copy(w, reader)
+		if err != nil {
+			logger.Error("failed-to-stream-artifact", err)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package buildserver
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+const (
+	// BlockOnFullBuffer stops reading a build's events while the consumer's
+	// buffer is full, until the consumer catches up.
+	BlockOnFullBuffer = "block"
+
+	// DropOldestEvents makes room for each event read into a full buffer by
+	// dropping the oldest one the consumer hasn't been sent yet.
+	DropOldestEvents = "drop-oldest"
+
+	// DisconnectOnFullBuffer ends the stream once the consumer's buffer is
+	// full. The consumer is sent the events already buffered first, so it
+	// can reconnect from the last one it saw.
+	DisconnectOnFullBuffer = "disconnect"
+)
+
+var ErrEventBufferOverflowed = errors.New("build event buffer overflowed")
+
+// EventBufferConfig bounds the events buffered for each consumer of a build
+// event stream, so that a slow consumer doesn't hold up reading the events.
+// A Size of zero leaves the stream unbuffered.
+type EventBufferConfig struct {
+	Size   int
+	Policy string
+}
+
+// EventBuffer reads a build's events into a bounded buffer as quickly as it
+// can, handling a full buffer according to its policy.
+type EventBuffer struct {
+	source  db.EventSource
+	config  EventBufferConfig
+	dropped func()
+
+	lock   sync.Mutex
+	cond   *sync.Cond
+	events []bufferedEvent
+	err    error
+	closed bool
+
+	nextID uint
+}
+
+type bufferedEvent struct {
+	id       uint
+	envelope event.Envelope
+}
+
+// NewEventBuffer buffers the events from the source, which starts at the
+// event with the given id. The dropped func is called for each event
+// dropped under the DropOldestEvents policy.
+func NewEventBuffer(source db.EventSource, from uint, config EventBufferConfig, dropped func()) *EventBuffer {
+	buffer := &EventBuffer{
+		source:  source,
+		config:  config,
+		dropped: dropped,
+
+		nextID: from,
+	}
+
+	buffer.cond = sync.NewCond(&buffer.lock)
+
+	if config.Size > 0 {
+		go buffer.fill(from)
+	}
+
+	return buffer
+}
+
+// Next returns the id of the next event along with the event.
+func (buffer *EventBuffer) Next() (uint, event.Envelope, error) {
+	if buffer.config.Size <= 0 {
+		ev, err := buffer.source.Next()
+		if err != nil {
+			return 0, event.Envelope{}, err
+		}
+
+		buffer.nextID++
+
+		return buffer.nextID - 1, ev, nil
+	}
+
+	buffer.lock.Lock()
+	defer buffer.lock.Unlock()
+
+	for len(buffer.events) == 0 && buffer.err == nil {
+		buffer.cond.Wait()
+	}
+
+	if len(buffer.events) == 0 {
+		return 0, event.Envelope{}, buffer.err
+	}
+
+	next := buffer.events[0]
+	buffer.events = buffer.events[1:]
+
+	buffer.cond.Broadcast()
+
+	return next.id, next.envelope, nil
+}
+
+func (buffer *EventBuffer) Close() error {
+	buffer.lock.Lock()
+	buffer.closed = true
+	buffer.cond.Broadcast()
+	buffer.lock.Unlock()
+
+	return buffer.source.Close()
+}
+
+func (buffer *EventBuffer) fill(id uint) {
+	for ; ; id++ {
+		ev, err := buffer.source.Next()
+
+		buffer.lock.Lock()
+
+		if err != nil {
+			buffer.err = err
+			buffer.cond.Broadcast()
+			buffer.lock.Unlock()
+			return
+		}
+
+		if buffer.config.Policy == BlockOnFullBuffer {
+			for len(buffer.events) >= buffer.config.Size && !buffer.closed {
+				buffer.cond.Wait()
+			}
+		}
+
+		if buffer.closed {
+			buffer.lock.Unlock()
+			return
+		}
+
+		if len(buffer.events) >= buffer.config.Size {
+			if buffer.config.Policy == DisconnectOnFullBuffer {
+				buffer.err = ErrEventBufferOverflowed
+				buffer.cond.Broadcast()
+				buffer.lock.Unlock()
+				return
+			}
+
+			buffer.events = buffer.events[1:]
+			buffer.dropped()
+		}
+
+		buffer.events = append(buffer.events, bufferedEvent{id: id, envelope: ev})
+		buffer.cond.Broadcast()
+
+		buffer.lock.Unlock()
+	}
+}
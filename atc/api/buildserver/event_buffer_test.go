@@ -0,0 +1,166 @@
+package buildserver_test
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	. "github.com/concourse/concourse/atc/api/buildserver"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventBuffer", func() {
+	var (
+		fakeEventSource *dbfakes.FakeEventSource
+		sourceEvents    chan event.Envelope
+		read            chan struct{}
+
+		config  EventBufferConfig
+		dropped int32
+
+		buffer *EventBuffer
+	)
+
+	BeforeEach(func() {
+		sourceEvents = make(chan event.Envelope, 10)
+		read = make(chan struct{}, 10)
+
+		// captured so that a buffer left reading from an earlier test's
+		// source doesn't see this test's channels
+		events, reads := sourceEvents, read
+
+		fakeEventSource = new(dbfakes.FakeEventSource)
+		fakeEventSource.NextStub = func() (event.Envelope, error) {
+			ev, ok := <-events
+			if !ok {
+				return event.Envelope{}, db.ErrEndOfBuildEventStream
+			}
+
+			reads <- struct{}{}
+
+			return ev, nil
+		}
+
+		atomic.StoreInt32(&dropped, 0)
+	})
+
+	JustBeforeEach(func() {
+		buffer = NewEventBuffer(fakeEventSource, 5, config, func() { atomic.AddInt32(&dropped, 1) })
+	})
+
+	AfterEach(func() {
+		Expect(buffer.Close()).To(Succeed())
+		Expect(fakeEventSource.CloseCallCount()).To(Equal(1))
+	})
+
+	publish := func(n int) {
+		for i := 0; i < n; i++ {
+			sourceEvents <- fakeEvent(fmt.Sprintf(`{"event":%d}`, i))
+		}
+
+		for i := 0; i < n; i++ {
+			Eventually(read).Should(Receive())
+		}
+	}
+
+	next := func(id uint, payload string) {
+		nextID, ev, err := buffer.Next()
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		ExpectWithOffset(1, nextID).To(Equal(id))
+		ExpectWithOffset(1, ev).To(Equal(fakeEvent(payload)))
+	}
+
+	Context("when the size is zero", func() {
+		BeforeEach(func() {
+			config = EventBufferConfig{}
+		})
+
+		It("reads the events straight from the source", func() {
+			sourceEvents <- fakeEvent(`{"event":0}`)
+			sourceEvents <- fakeEvent(`{"event":1}`)
+			close(sourceEvents)
+
+			next(5, `{"event":0}`)
+			next(6, `{"event":1}`)
+
+			_, _, err := buffer.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
+	Context("with the block policy", func() {
+		BeforeEach(func() {
+			config = EventBufferConfig{Size: 2, Policy: BlockOnFullBuffer}
+		})
+
+		It("stops reading events while the buffer is full", func() {
+			publish(3)
+
+			sourceEvents <- fakeEvent(`{"event":3}`)
+			Consistently(read).ShouldNot(Receive())
+
+			next(5, `{"event":0}`)
+			Eventually(read).Should(Receive())
+
+			next(6, `{"event":1}`)
+			next(7, `{"event":2}`)
+			next(8, `{"event":3}`)
+
+			Expect(atomic.LoadInt32(&dropped)).To(BeZero())
+		})
+	})
+
+	Context("with the drop-oldest policy", func() {
+		BeforeEach(func() {
+			config = EventBufferConfig{Size: 2, Policy: DropOldestEvents}
+		})
+
+		It("drops the oldest buffered events, keeping their ids", func() {
+			publish(4)
+
+			Eventually(func() int32 {
+				return atomic.LoadInt32(&dropped)
+			}).Should(Equal(int32(2)))
+
+			next(7, `{"event":2}`)
+			next(8, `{"event":3}`)
+		})
+	})
+
+	Context("with the disconnect policy", func() {
+		BeforeEach(func() {
+			config = EventBufferConfig{Size: 2, Policy: DisconnectOnFullBuffer}
+		})
+
+		It("ends the stream after the buffered events once the buffer overflows", func() {
+			publish(3)
+
+			next(5, `{"event":0}`)
+			next(6, `{"event":1}`)
+
+			_, _, err := buffer.Next()
+			Expect(err).To(Equal(ErrEventBufferOverflowed))
+			Expect(atomic.LoadInt32(&dropped)).To(BeZero())
+		})
+	})
+
+	Context("when the source ends", func() {
+		BeforeEach(func() {
+			config = EventBufferConfig{Size: 2, Policy: DropOldestEvents}
+		})
+
+		It("returns the buffered events before the error", func() {
+			publish(1)
+			close(sourceEvents)
+
+			next(5, `{"event":0}`)
+
+			_, _, err := buffer.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+})
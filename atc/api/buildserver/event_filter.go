@@ -0,0 +1,190 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// eventFilter limits the events streamed for a build to those matching the
+// query parameters of the request, so that tooling tailing a few steps of a
+// large build does not have to receive (and discard) everything else.
+//
+// Filtered events still consume their event ID, so that Last-Event-ID
+// continues to work when reconnecting with the same filters.
+type eventFilter struct {
+	eventTypes map[atc.EventType]bool
+	originIDs  map[event.OriginID]bool
+	sources    map[event.OriginSource]bool
+
+	// step names are resolved to origin IDs using the build's plan. A pending
+	// build has no plan yet, so the build is reloaded and the names resolved
+	// again for each event from a step which hasn't been seen before.
+	build     db.Build
+	stepNames map[string]bool
+	unmatched map[event.OriginID]bool
+}
+
+// stepNameKeys are the keys in a public plan whose values name a step.
+var stepNameKeys = []string{"get", "put", "task", "check", "dependent_get"}
+
+func newEventFilter(query url.Values, build db.Build) *eventFilter {
+	filter := &eventFilter{
+		build: build,
+	}
+
+	for _, eventType := range query[atc.BuildEventsTypeQuery] {
+		if filter.eventTypes == nil {
+			filter.eventTypes = map[atc.EventType]bool{}
+		}
+
+		filter.eventTypes[atc.EventType(eventType)] = true
+	}
+
+	for _, source := range query[atc.BuildEventsSourceQuery] {
+		if filter.sources == nil {
+			filter.sources = map[event.OriginSource]bool{}
+		}
+
+		filter.sources[event.OriginSource(source)] = true
+	}
+
+	stepIDs := query[atc.BuildEventsStepIDQuery]
+	stepNames := query[atc.BuildEventsStepNameQuery]
+	if len(stepIDs) == 0 && len(stepNames) == 0 {
+		return filter
+	}
+
+	filter.originIDs = map[event.OriginID]bool{}
+
+	for _, id := range stepIDs {
+		filter.originIDs[event.OriginID(id)] = true
+	}
+
+	if len(stepNames) > 0 {
+		filter.stepNames = map[string]bool{}
+		for _, name := range stepNames {
+			filter.stepNames[name] = true
+		}
+
+		filter.unmatched = map[event.OriginID]bool{}
+
+		filter.resolveStepNames(build.PublicPlan())
+	}
+
+	return filter
+}
+
+func (filter *eventFilter) resolveStepNames(publicPlan *json.RawMessage) {
+	if publicPlan == nil {
+		return
+	}
+
+	var plan interface{}
+	err := json.Unmarshal(*publicPlan, &plan)
+	if err != nil {
+		return
+	}
+
+	collectStepIDs(plan, filter.stepNames, filter.originIDs)
+}
+
+func (filter *eventFilter) matchesStep(id event.OriginID) bool {
+	if filter.originIDs[id] {
+		return true
+	}
+
+	if filter.stepNames == nil || filter.unmatched[id] {
+		return false
+	}
+
+	found, err := filter.build.Reload()
+	if err != nil || !found {
+		return false
+	}
+
+	filter.resolveStepNames(filter.build.PublicPlan())
+
+	if filter.originIDs[id] {
+		return true
+	}
+
+	filter.unmatched[id] = true
+
+	return false
+}
+
+// Allows returns whether the event should be sent to the client. Events with
+// no origin (e.g. build status changes) belong to the build rather than any
+// step, so they are not subject to step or source filters.
+func (filter *eventFilter) Allows(envelope event.Envelope) bool {
+	if filter.eventTypes != nil && !filter.eventTypes[envelope.Event] {
+		return false
+	}
+
+	if filter.originIDs == nil && filter.sources == nil {
+		return true
+	}
+
+	origin, found := envelopeOrigin(envelope)
+	if !found {
+		return true
+	}
+
+	if filter.originIDs != nil && !filter.matchesStep(origin.ID) {
+		return false
+	}
+
+	if filter.sources != nil && !filter.sources[origin.Source] {
+		return false
+	}
+
+	return true
+}
+
+func envelopeOrigin(envelope event.Envelope) (event.Origin, bool) {
+	if envelope.Data == nil {
+		return event.Origin{}, false
+	}
+
+	var payload struct {
+		Origin *event.Origin `json:"origin"`
+	}
+
+	err := json.Unmarshal(*envelope.Data, &payload)
+	if err != nil || payload.Origin == nil {
+		return event.Origin{}, false
+	}
+
+	return *payload.Origin, true
+}
+
+func collectStepIDs(node interface{}, names map[string]bool, ids map[event.OriginID]bool) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, child := range n {
+			collectStepIDs(child, names, ids)
+		}
+
+	case map[string]interface{}:
+		if id, ok := n["id"].(string); ok {
+			for _, key := range stepNameKeys {
+				step, ok := n[key].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				if name, ok := step["name"].(string); ok && names[name] {
+					ids[event.OriginID(id)] = true
+				}
+			}
+		}
+
+		for _, child := range n {
+			collectStepIDs(child, names, ids)
+		}
+	}
+}
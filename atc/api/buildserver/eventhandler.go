@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/vito/go-sse/sse"
 )
 
@@ -17,6 +18,18 @@ const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+	return newEventHandler(logger, build, EventBufferConfig{})
+}
+
+// NewBufferedEventHandlerFactory constructs event handlers which buffer each
+// consumer's events according to the config.
+func NewBufferedEventHandlerFactory(config EventBufferConfig) EventHandlerFactory {
+	return func(logger lager.Logger, build db.Build) http.Handler {
+		return newEventHandler(logger, build, config)
+	}
+}
+
+func newEventHandler(logger lager.Logger, build db.Build, config EventBufferConfig) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientNotifier := w.(http.CloseNotifier)
 
@@ -55,19 +68,28 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			writer.writeFlusher = gz
 		}
 
-		events, err := build.Events(eventID)
+		source, err := build.Events(eventID)
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		events := NewEventBuffer(source, eventID, config, func() {
+			metric.BuildEventsDropped{
+				Consumer: "build-events",
+				BuildID:  build.ID(),
+			}.Emit(logger)
+		})
+
 		defer db.Close(events)
 
+		filter := newEventFilter(r.URL.Query(), build)
+
 		for {
 			logger = logger.WithData(lager.Data{"id": eventID})
 
-			ev, err := events.Next()
+			id, ev, err := events.Next()
 			if err != nil {
 				if err == db.ErrEndOfBuildEventStream {
 					err := writer.WriteEnd(eventID)
@@ -77,6 +99,8 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 					}
 
 					<-clientNotifier.CloseNotify()
+				} else if err == ErrEventBufferOverflowed {
+					logger.Info("disconnecting-slow-consumer", lager.Data{"remote-addr": r.RemoteAddr})
 				} else {
 					logger.Error("failed-to-get-next-build-event", err)
 					return
@@ -85,10 +109,14 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 				return
 			}
 
-			err = writer.WriteEvent(eventID, ev)
-			if err != nil {
-				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
-				return
+			eventID = id
+
+			if filter.Allows(ev) {
+				err = writer.WriteEvent(eventID, ev)
+				if err != nil {
+					logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+					return
+				}
 			}
 
 			eventID++
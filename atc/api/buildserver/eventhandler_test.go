@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -28,6 +29,18 @@ func fakeEvent(payload string) event.Envelope {
 	}
 }
 
+func envelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+	}
+}
+
 var _ = Describe("Handler", func() {
 	var (
 		build *dbfakes.FakeBuild
@@ -166,6 +179,114 @@ var _ = Describe("Handler", func() {
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when filters are given", func() {
+				var plan json.RawMessage
+
+				BeforeEach(func() {
+					returnedEvents = []event.Envelope{
+						envelope(event.Log{Origin: event.Origin{ID: "get-id", Source: event.OriginSourceStdout}, Payload: "get stdout"}),
+						envelope(event.Log{Origin: event.Origin{ID: "task-id", Source: event.OriginSourceStdout}, Payload: "task stdout"}),
+						envelope(event.Log{Origin: event.Origin{ID: "task-id", Source: event.OriginSourceStderr}, Payload: "task stderr"}),
+						envelope(event.FinishTask{Origin: event.Origin{ID: "task-id"}, ExitStatus: 1}),
+						envelope(event.Status{Status: "failed"}),
+					}
+
+					plan = json.RawMessage(`{
+						"id": "do-id",
+						"do": [
+							{"id": "get-id", "get": {"name": "some-input", "type": "git"}},
+							{"id": "task-id", "task": {"name": "some-task"}}
+						]
+					}`)
+					build.PublicPlanReturns(&plan)
+				})
+
+				readEvents := func() []sse.Event {
+					defer db.Close(response.Body)
+					reader := sse.NewReadCloser(response.Body)
+
+					var events []sse.Event
+					for {
+						ev, err := reader.Next()
+						Expect(err).ToNot(HaveOccurred())
+
+						if ev.Name == "end" {
+							return events
+						}
+
+						events = append(events, ev)
+					}
+				}
+
+				eventIDs := func(events []sse.Event) []string {
+					var ids []string
+					for _, ev := range events {
+						ids = append(ids, ev.ID)
+					}
+					return ids
+				}
+
+				Context("by step id", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "step_id=task-id"
+					})
+
+					It("only emits the step's events and build-level events, keeping their ids", func() {
+						Expect(eventIDs(readEvents())).To(Equal([]string{"1", "2", "3", "4"}))
+					})
+				})
+
+				Context("by step name", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "step_name=some-input"
+					})
+
+					It("resolves the name to the step's id using the build plan", func() {
+						Expect(eventIDs(readEvents())).To(Equal([]string{"0", "4"}))
+					})
+
+					Context("when the build is pending when subscribing", func() {
+						BeforeEach(func() {
+							build.PublicPlanReturns(nil)
+
+							build.ReloadStub = func() (bool, error) {
+								build.PublicPlanReturns(&plan)
+								return true, nil
+							}
+						})
+
+						It("resolves the name once the build has been planned", func() {
+							Expect(eventIDs(readEvents())).To(Equal([]string{"0", "4"}))
+						})
+
+						It("reloads the build for each step it hasn't seen", func() {
+							readEvents()
+							Expect(build.ReloadCallCount()).To(Equal(2))
+						})
+					})
+				})
+
+				Context("by event type", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "event=status&event=finish-task"
+					})
+
+					It("only emits events of the given types", func() {
+						Expect(eventIDs(readEvents())).To(Equal([]string{"3", "4"}))
+					})
+				})
+
+				Context("by source", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "event=log&source=stderr"
+					})
+
+					It("only emits output from the given source", func() {
+						Expect(eventIDs(readEvents())).To(Equal([]string{"2"}))
+					})
+				})
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
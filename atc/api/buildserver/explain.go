@@ -0,0 +1,261 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+func (s *Server) ExplainBuild(build db.Build) http.Handler {
+	logger := s.logger.Session("explain-build", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the steps are worked out from the build's events, which can only be
+		// read in full once the build has finished
+		if build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		inputs, _, err := build.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-build-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		explanation := atc.BuildExplanation{
+			Build:  present.Build(build),
+			Inputs: explainInputs(build, inputs),
+			Plan: atc.PublicBuildPlan{
+				Schema: build.Schema(),
+				Plan:   build.PublicPlan(),
+			},
+			Steps: []atc.ExplainedStep{},
+		}
+
+		err = explainSteps(build, &explanation)
+		if err != nil {
+			logger.Error("failed-to-read-build-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(explanation)
+		if err != nil {
+			logger.Error("failed-to-encode-build-explanation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func explainInputs(build db.Build, inputs []db.BuildInput) []atc.ExplainedInput {
+	overrides := build.InputOverrides()
+
+	explained := make([]atc.ExplainedInput, 0, len(inputs))
+	for _, input := range inputs {
+		reason := atc.InputReasonLatest
+		if _, found := overrides[input.Name]; found {
+			reason = atc.InputReasonOverride
+		} else if input.FallbackFrom != nil {
+			reason = atc.InputReasonFallback
+		}
+
+		explained = append(explained, atc.ExplainedInput{
+			Name:            input.Name,
+			Version:         input.Version,
+			Reason:          reason,
+			FallbackFrom:    input.FallbackFrom,
+			FirstOccurrence: input.FirstOccurrence,
+		})
+	}
+
+	return explained
+}
+
+// stepTimes are the times at which a step reached each phase, as recorded by
+// its events.
+type stepTimes struct {
+	initialized, started, finished int64
+}
+
+func explainSteps(build db.Build, explanation *atc.BuildExplanation) error {
+	names := map[atc.PlanID]string{}
+	if build.HasPlan() {
+		var plan atc.Plan
+		err := json.Unmarshal(*build.PublicPlan(), &plan)
+		if err != nil {
+			return err
+		}
+
+		planStepNames(plan, names)
+	}
+
+	events, err := build.Events(0)
+	if err != nil {
+		return err
+	}
+
+	defer events.Close()
+
+	var steps []*atc.ExplainedStep
+	stepsByID := map[atc.PlanID]*atc.ExplainedStep{}
+	times := map[atc.PlanID]*stepTimes{}
+
+	step := func(origin event.Origin, stepType string) (*atc.ExplainedStep, *stepTimes) {
+		id := atc.PlanID(origin.ID)
+
+		explained, found := stepsByID[id]
+		if !found {
+			explained = &atc.ExplainedStep{
+				PlanID: id,
+				Type:   stepType,
+				Name:   names[id],
+			}
+
+			steps = append(steps, explained)
+			stepsByID[id] = explained
+			times[id] = &stepTimes{}
+		}
+
+		return explained, times[id]
+	}
+
+	for {
+		envelope, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		parsed, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			// events of versions which are no longer known are skipped, as
+			// older builds are still worth explaining
+			continue
+		}
+
+		switch e := parsed.(type) {
+		case event.InitializeGet:
+			_, t := step(e.Origin, "get")
+			t.initialized = e.Time
+
+		case event.StartGet:
+			explained, t := step(e.Origin, "get")
+			explained.Worker = e.Worker
+			cached := e.Cached
+			explained.Cached = &cached
+			t.started = e.Time
+
+		case event.FinishGet:
+			explained, t := step(e.Origin, "get")
+			exitStatus := e.ExitStatus
+			explained.ExitStatus = &exitStatus
+			t.finished = e.Time
+
+		case event.InitializePut:
+			_, t := step(e.Origin, "put")
+			t.initialized = e.Time
+
+		case event.StartPut:
+			explained, t := step(e.Origin, "put")
+			explained.Worker = e.Worker
+			t.started = e.Time
+
+		case event.FinishPut:
+			explained, t := step(e.Origin, "put")
+			exitStatus := e.ExitStatus
+			explained.ExitStatus = &exitStatus
+			t.finished = e.Time
+
+		case event.InitializeTask:
+			_, t := step(e.Origin, "task")
+			t.initialized = e.Time
+
+		case event.StartTask:
+			explained, t := step(e.Origin, "task")
+			explained.Worker = e.Worker
+			t.started = e.Time
+
+		case event.FinishTask:
+			explained, t := step(e.Origin, "task")
+			exitStatus := e.ExitStatus
+			explained.ExitStatus = &exitStatus
+			t.finished = e.Time
+
+		case event.Error:
+			explained, found := stepsByID[atc.PlanID(e.Origin.ID)]
+			if !found {
+				explanation.Errors = append(explanation.Errors, e.Message)
+				continue
+			}
+
+			explained.Error = e.Message
+		}
+	}
+
+	for _, explained := range steps {
+		t := times[explained.PlanID]
+
+		if t.initialized != 0 && t.started != 0 {
+			explained.InitializingDuration = t.started - t.initialized
+		}
+
+		if t.started != 0 && t.finished != 0 {
+			explained.RunningDuration = t.finished - t.started
+		}
+
+		explanation.Steps = append(explanation.Steps, *explained)
+	}
+
+	return nil
+}
+
+// planStepNames records the names of the get, put, and task steps of a plan.
+func planStepNames(plan atc.Plan, names map[atc.PlanID]string) {
+	var children []atc.Plan
+
+	switch {
+	case plan.Get != nil:
+		names[plan.ID] = plan.Get.Name
+	case plan.Put != nil:
+		names[plan.ID] = plan.Put.Name
+	case plan.Task != nil:
+		names[plan.ID] = plan.Task.Name
+	case plan.Do != nil:
+		children = *plan.Do
+	case plan.Aggregate != nil:
+		children = *plan.Aggregate
+	case plan.InParallel != nil:
+		children = plan.InParallel.Steps
+	case plan.Retry != nil:
+		children = *plan.Retry
+	case plan.Try != nil:
+		children = []atc.Plan{plan.Try.Step}
+	case plan.Timeout != nil:
+		children = []atc.Plan{plan.Timeout.Step}
+	case plan.OnAbort != nil:
+		children = []atc.Plan{plan.OnAbort.Step, plan.OnAbort.Next}
+	case plan.OnError != nil:
+		children = []atc.Plan{plan.OnError.Step, plan.OnError.Next}
+	case plan.OnFailure != nil:
+		children = []atc.Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.OnSuccess != nil:
+		children = []atc.Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.Ensure != nil:
+		children = []atc.Plan{plan.Ensure.Step, plan.Ensure.Next}
+	}
+
+	for _, child := range children {
+		planStepNames(child, names)
+	}
+}
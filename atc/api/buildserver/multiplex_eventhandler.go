@@ -0,0 +1,236 @@
+package buildserver
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/vito/go-sse/sse"
+)
+
+// MultiplexedEvent is the payload of each event sent by the multiplexed
+// event stream. Event is omitted from the "end" event sent once a build's
+// stream is exhausted.
+type MultiplexedEvent struct {
+	BuildID int             `json:"build_id"`
+	Event   *event.Envelope `json:"event,omitempty"`
+}
+
+type multiplexedFrame struct {
+	buildID  int
+	eventID  uint
+	envelope *event.Envelope
+
+	// overflowed is set when the build's buffer filled up under the
+	// DisconnectOnFullBuffer policy.
+	overflowed bool
+}
+
+// NewMultiplexEventHandler streams the events of several builds over a single
+// connection. Each SSE event's ID is "<build id>:<event id>" and its data is a
+// MultiplexedEvent. An "end" event is sent for each build once its stream is
+// exhausted, and a final "end-all" event once every build has ended.
+//
+// Streams always start from each build's first event.
+func NewMultiplexEventHandler(logger lager.Logger, builds []db.Build) http.Handler {
+	return newMultiplexEventHandler(logger, builds, EventBufferConfig{})
+}
+
+// NewBufferedMultiplexEventHandlerFactory constructs multiplexed event
+// handlers which buffer the events of each build according to the config.
+// The configured size is shared between the builds streamed over each
+// connection, rather than given to each of them.
+func NewBufferedMultiplexEventHandlerFactory(config EventBufferConfig) MultiplexEventHandlerFactory {
+	return func(logger lager.Logger, builds []db.Build) http.Handler {
+		return newMultiplexEventHandler(logger, builds, config)
+	}
+}
+
+func newMultiplexEventHandler(logger lager.Logger, builds []db.Build, config EventBufferConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientNotifier := w.(http.CloseNotifier)
+
+		sources := make([]*EventBuffer, 0, len(builds))
+		defer func() {
+			for _, source := range sources {
+				db.Close(source)
+			}
+		}()
+
+		// watching a job's history streams hundreds of builds at once, so a
+		// buffer per build would multiply the events held for one consumer
+		buildConfig := config
+		if len(builds) > 0 {
+			buildConfig.Size = config.Size / len(builds)
+		}
+
+		for _, build := range builds {
+			events, err := build.Events(0)
+			if err != nil {
+				logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			buildID := build.ID()
+			sources = append(sources, NewEventBuffer(events, 0, buildConfig, func() {
+				metric.BuildEventsDropped{
+					Consumer: "multiplex-build-events",
+					BuildID:  buildID,
+				}.Emit(logger)
+			}))
+		}
+
+		w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+		w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+		w.Header().Add("X-Accel-Buffering", "no")
+		w.Header().Add(ProtocolVersionHeader, CurrentProtocolVersion)
+
+		writer := eventWriter{
+			responseWriter:  w,
+			writeFlusher:    nil,
+			responseFlusher: w.(http.Flusher),
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+
+			gz := gzip.NewWriter(w)
+			defer db.Close(gz)
+
+			writer.responseWriter = gz
+			writer.writeFlusher = gz
+		}
+
+		frames := make(chan multiplexedFrame)
+		stop := make(chan struct{})
+		defer close(stop)
+
+		for i, build := range builds {
+			go readBuildEvents(
+				logger.Session("read", lager.Data{"build-id": build.ID()}),
+				build.ID(),
+				sources[i],
+				newEventFilter(r.URL.Query(), build),
+				frames,
+				stop,
+			)
+		}
+
+		remaining := len(builds)
+		for remaining > 0 {
+			var frame multiplexedFrame
+
+			select {
+			case frame = <-frames:
+			case <-clientNotifier.CloseNotify():
+				return
+			}
+
+			if frame.overflowed {
+				logger.Info("disconnecting-slow-consumer", lager.Data{"build-id": frame.buildID, "remote-addr": r.RemoteAddr})
+				return
+			}
+
+			name := "event"
+			if frame.envelope == nil {
+				name = "end"
+				remaining--
+			}
+
+			err := writer.WriteMultiplexed(name, frame)
+			if err != nil {
+				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+				return
+			}
+		}
+
+		err := sse.Event{Name: "end-all"}.Write(writer.responseWriter)
+		if err == nil {
+			err = writer.flush()
+		}
+
+		if err != nil {
+			logger.Info("failed-to-write-end", lager.Data{"error": err.Error()})
+			return
+		}
+
+		<-clientNotifier.CloseNotify()
+	})
+}
+
+// readBuildEvents sends the build's allowed events to frames, followed by a
+// frame with no envelope once the stream ends or fails.
+func readBuildEvents(
+	logger lager.Logger,
+	buildID int,
+	events *EventBuffer,
+	filter *eventFilter,
+	frames chan<- multiplexedFrame,
+	stop <-chan struct{},
+) {
+	var eventID uint
+
+	send := func(frame multiplexedFrame) bool {
+		select {
+		case frames <- frame:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+
+	for {
+		id, ev, err := events.Next()
+		if err != nil {
+			if err != db.ErrEndOfBuildEventStream && err != db.ErrBuildEventStreamClosed && err != ErrEventBufferOverflowed {
+				logger.Error("failed-to-get-next-build-event", err)
+			}
+
+			send(multiplexedFrame{
+				buildID:    buildID,
+				eventID:    eventID,
+				overflowed: err == ErrEventBufferOverflowed,
+			})
+			return
+		}
+
+		eventID = id
+
+		if filter.Allows(ev) {
+			if !send(multiplexedFrame{buildID: buildID, eventID: eventID, envelope: &ev}) {
+				return
+			}
+		}
+
+		eventID++
+	}
+}
+
+func (writer eventWriter) WriteMultiplexed(name string, frame multiplexedFrame) error {
+	payload, err := json.Marshal(MultiplexedEvent{
+		BuildID: frame.buildID,
+		Event:   frame.envelope,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = sse.Event{
+		ID:   fmt.Sprintf("%d:%d", frame.buildID, frame.eventID),
+		Name: name,
+		Data: payload,
+	}.Write(writer.responseWriter)
+	if err != nil {
+		return err
+	}
+
+	return writer.flush()
+}
@@ -0,0 +1,144 @@
+package buildserver_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/concourse/atc/api/buildserver"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/vito/go-sse/sse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Multiplex Handler", func() {
+	var (
+		build1 *dbfakes.FakeBuild
+		build2 *dbfakes.FakeBuild
+
+		source1 *dbfakes.FakeEventSource
+		source2 *dbfakes.FakeEventSource
+
+		server   *httptest.Server
+		response *http.Response
+	)
+
+	eventSource := func(events ...event.Envelope) *dbfakes.FakeEventSource {
+		source := new(dbfakes.FakeEventSource)
+
+		next := 0
+		source.NextStub = func() (event.Envelope, error) {
+			if next >= len(events) {
+				return event.Envelope{}, db.ErrEndOfBuildEventStream
+			}
+
+			next++
+
+			return events[next-1], nil
+		}
+
+		return source
+	}
+
+	BeforeEach(func() {
+		build1 = new(dbfakes.FakeBuild)
+		build1.IDReturns(1)
+		source1 = eventSource(fakeEvent(`{"event":"1a"}`), fakeEvent(`{"event":"1b"}`))
+		build1.EventsReturns(source1, nil)
+
+		build2 = new(dbfakes.FakeBuild)
+		build2.IDReturns(2)
+		source2 = eventSource(fakeEvent(`{"event":"2a"}`))
+		build2.EventsReturns(source2, nil)
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(NewMultiplexEventHandler(
+			lagertest.NewTestLogger("test"),
+			[]db.Build{build1, build2},
+		))
+
+		client := &http.Client{
+			Transport: &http.Transport{},
+		}
+
+		var err error
+		response, err = client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when subscribing to the builds succeeds", func() {
+		AfterEach(func() {
+			Eventually(source1.CloseCallCount, 30*time.Second).Should(Equal(1))
+			Eventually(source2.CloseCallCount, 30*time.Second).Should(Equal(1))
+		})
+
+		It("subscribes to every build from the start", func() {
+			_ = response.Body.Close()
+
+			Expect(build1.EventsCallCount()).To(Equal(1))
+			Expect(build1.EventsArgsForCall(0)).To(BeZero())
+			Expect(build2.EventsCallCount()).To(Equal(1))
+			Expect(build2.EventsArgsForCall(0)).To(BeZero())
+		})
+
+		It("returns an event stream", func() {
+			_ = response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("text/event-stream; charset=utf-8"))
+			Expect(response.Header.Get("X-ATC-Stream-Version")).To(Equal("2.0"))
+		})
+
+		It("emits each build's events framed with the build id, followed by the end events", func() {
+			defer db.Close(response.Body)
+			reader := sse.NewReadCloser(response.Body)
+
+			perBuild := map[string][]sse.Event{}
+			for {
+				ev, err := reader.Next()
+				Expect(err).ToNot(HaveOccurred())
+
+				if ev.Name == "end-all" {
+					break
+				}
+
+				perBuild[ev.ID[:1]] = append(perBuild[ev.ID[:1]], ev)
+			}
+
+			Expect(perBuild["1"]).To(Equal([]sse.Event{
+				{ID: "1:0", Name: "event", Data: []byte(`{"build_id":1,"event":{"data":{"event":"1a"},"event":"fake","version":"42.0"}}`)},
+				{ID: "1:1", Name: "event", Data: []byte(`{"build_id":1,"event":{"data":{"event":"1b"},"event":"fake","version":"42.0"}}`)},
+				{ID: "1:2", Name: "end", Data: []byte(`{"build_id":1}`)},
+			}))
+
+			Expect(perBuild["2"]).To(Equal([]sse.Event{
+				{ID: "2:0", Name: "event", Data: []byte(`{"build_id":2,"event":{"data":{"event":"2a"},"event":"fake","version":"42.0"}}`)},
+				{ID: "2:1", Name: "end", Data: []byte(`{"build_id":2}`)},
+			}))
+		})
+	})
+
+	Context("when subscribing to a build fails", func() {
+		BeforeEach(func() {
+			build2.EventsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns 500 and closes the subscriptions already made", func() {
+			_ = response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			Eventually(source1.CloseCallCount).Should(Equal(1))
+		})
+	})
+})
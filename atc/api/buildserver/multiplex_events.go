@@ -0,0 +1,322 @@
+package buildserver
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// MaxMultiplexedBuilds is the number of builds whose events may be streamed
+// over a single connection. Only the latest builds of a job or version are
+// streamed if it has more.
+const MaxMultiplexedBuilds = 500
+
+var (
+	errMultiplexBadRequest = errors.New("bad request")
+	errMultiplexNotFound   = errors.New("not found")
+	errMultiplexNotVisible = errors.New("not visible")
+)
+
+func (s *Server) MultiplexBuildEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("multiplex-build-events")
+
+	acc := accessor.GetAccessor(r)
+	visibility := newBuildEventsVisibility(acc, r.URL.Query().Get(atc.ShareTokenQuery))
+
+	builds, err := s.multiplexedBuilds(logger, visibility, r.URL.Query())
+	if err == nil {
+		err = visibility.allVisible(builds)
+		if err != nil && err != errMultiplexNotVisible {
+			logger.Error("failed-to-check-build-visibility", err)
+		}
+	}
+
+	switch err {
+	case nil:
+	case errMultiplexBadRequest:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	case errMultiplexNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case errMultiplexNotVisible:
+		if acc.IsAuthenticated() {
+			s.rejector.Forbidden(w, r)
+			return
+		}
+
+		s.rejector.Unauthorized(w, r)
+		return
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	s.multiplexFactory(logger, builds).ServeHTTP(w, r)
+}
+
+// multiplexedBuilds returns the builds selected by the query, which must use
+// exactly one of the selectors. Where whatever the builds are selected by
+// isn't visible to the request, errMultiplexNotVisible is returned whether or
+// not it exists, so as not to give away private pipelines, jobs and
+// resources.
+func (s *Server) multiplexedBuilds(logger lager.Logger, visibility *buildEventsVisibility, query url.Values) ([]db.Build, error) {
+	byID := len(query[atc.MultiplexBuildEventsBuildIDQuery]) > 0
+	byJob := query.Get(atc.MultiplexBuildEventsJobQuery) != ""
+	byVersion := query.Get(atc.MultiplexBuildEventsResourceQuery) != ""
+
+	switch {
+	case byID && !byJob && !byVersion:
+		return s.buildsByID(logger, visibility, query[atc.MultiplexBuildEventsBuildIDQuery])
+	case byJob && !byID && !byVersion:
+		return s.buildsOfJob(logger, visibility, query)
+	case byVersion && !byID && !byJob:
+		return s.buildsWithVersionAsInput(logger, visibility, query)
+	default:
+		return nil, errMultiplexBadRequest
+	}
+}
+
+func (s *Server) buildsByID(logger lager.Logger, visibility *buildEventsVisibility, buildIDs []string) ([]db.Build, error) {
+	if len(buildIDs) > MaxMultiplexedBuilds {
+		return nil, errMultiplexBadRequest
+	}
+
+	builds := make([]db.Build, 0, len(buildIDs))
+	seen := map[int]bool{}
+	for _, idStr := range buildIDs {
+		buildID, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, errMultiplexBadRequest
+		}
+
+		if seen[buildID] {
+			continue
+		}
+
+		seen[buildID] = true
+
+		build, found, err := s.buildFactory.Build(buildID)
+		if err != nil {
+			logger.Error("failed-to-get-build", err, lager.Data{"build-id": buildID})
+			return nil, err
+		}
+
+		if !found {
+			if !visibility.acc.IsAdmin() {
+				return nil, errMultiplexNotVisible
+			}
+
+			return nil, errMultiplexNotFound
+		}
+
+		builds = append(builds, build)
+	}
+
+	return builds, nil
+}
+
+func (s *Server) buildsOfJob(logger lager.Logger, visibility *buildEventsVisibility, query url.Values) ([]db.Build, error) {
+	pipeline, err := s.multiplexedPipeline(logger, visibility, query)
+	if err != nil {
+		return nil, err
+	}
+
+	jobName := query.Get(atc.MultiplexBuildEventsJobQuery)
+
+	job, found, err := pipeline.Job(jobName)
+	if err != nil {
+		logger.Error("failed-to-get-job", err, lager.Data{"job": jobName})
+		return nil, err
+	}
+
+	if !found {
+		return nil, errMultiplexNotFound
+	}
+
+	builds, _, err := job.Builds(db.Page{Limit: MaxMultiplexedBuilds})
+	if err != nil {
+		logger.Error("failed-to-get-job-builds", err, lager.Data{"job": jobName})
+		return nil, err
+	}
+
+	return builds, nil
+}
+
+func (s *Server) buildsWithVersionAsInput(logger lager.Logger, visibility *buildEventsVisibility, query url.Values) ([]db.Build, error) {
+	versionID, err := strconv.Atoi(query.Get(atc.MultiplexBuildEventsVersionQuery))
+	if err != nil {
+		return nil, errMultiplexBadRequest
+	}
+
+	pipeline, err := s.multiplexedPipeline(logger, visibility, query)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceName := query.Get(atc.MultiplexBuildEventsResourceQuery)
+
+	resource, found, err := pipeline.Resource(resourceName)
+	if err != nil {
+		logger.Error("failed-to-get-resource", err, lager.Data{"resource": resourceName})
+		return nil, err
+	}
+
+	if !found {
+		return nil, errMultiplexNotFound
+	}
+
+	builds, err := pipeline.GetBuildsWithVersionAsInput(resource.ID(), versionID)
+	if err != nil {
+		logger.Error("failed-to-get-builds-with-version-as-input", err, lager.Data{"resource": resourceName})
+		return nil, err
+	}
+
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].ID() > builds[j].ID()
+	})
+
+	if len(builds) > MaxMultiplexedBuilds {
+		builds = builds[:MaxMultiplexedBuilds]
+	}
+
+	return builds, nil
+}
+
+// multiplexedPipeline returns the pipeline the query selects builds from. It
+// must be visible to the request before its jobs and resources are looked
+// up; a team or pipeline which can't be found is only reported as such to
+// requests authorized for the team.
+func (s *Server) multiplexedPipeline(logger lager.Logger, visibility *buildEventsVisibility, query url.Values) (db.Pipeline, error) {
+	teamName := query.Get(atc.MultiplexBuildEventsTeamQuery)
+	pipelineName := query.Get(atc.MultiplexBuildEventsPipelineQuery)
+	if teamName == "" || pipelineName == "" {
+		return nil, errMultiplexBadRequest
+	}
+
+	authorized := visibility.authorized(teamName)
+
+	notFound := errMultiplexNotFound
+	if !authorized {
+		notFound = errMultiplexNotVisible
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-get-team", err, lager.Data{"team": teamName})
+		return nil, err
+	}
+
+	if !found {
+		return nil, notFound
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-pipeline", err, lager.Data{"team": teamName, "pipeline": pipelineName})
+		return nil, err
+	}
+
+	if !found {
+		return nil, notFound
+	}
+
+	if !authorized && !visibility.pipelineVisible(pipeline) {
+		return nil, errMultiplexNotVisible
+	}
+
+	return pipeline, nil
+}
+
+// buildEventsVisibility applies the same rules as the single build event
+// stream: the build's team must be authorized, or its pipeline must be
+// public or shared with the request's share token, and its job public.
+// Pipelines and jobs are looked up once for all the builds.
+type buildEventsVisibility struct {
+	acc        accessor.Access
+	shareToken string
+
+	pipelines  map[int]db.Pipeline
+	publicJobs map[int]map[string]bool
+}
+
+func newBuildEventsVisibility(acc accessor.Access, shareToken string) *buildEventsVisibility {
+	return &buildEventsVisibility{
+		acc:        acc,
+		shareToken: shareToken,
+
+		pipelines:  map[int]db.Pipeline{},
+		publicJobs: map[int]map[string]bool{},
+	}
+}
+
+func (v *buildEventsVisibility) authorized(teamName string) bool {
+	return v.acc.IsAuthenticated() && v.acc.IsAuthorized(teamName)
+}
+
+func (v *buildEventsVisibility) pipelineVisible(pipeline db.Pipeline) bool {
+	return pipeline.Public() || pipeline.IsSharedWith(v.shareToken)
+}
+
+// allVisible returns errMultiplexNotVisible unless every build is visible.
+func (v *buildEventsVisibility) allVisible(builds []db.Build) error {
+	for _, build := range builds {
+		visible, err := v.visible(build)
+		if err != nil {
+			return err
+		}
+
+		if !visible {
+			return errMultiplexNotVisible
+		}
+	}
+
+	return nil
+}
+
+func (v *buildEventsVisibility) visible(build db.Build) (bool, error) {
+	if v.authorized(build.TeamName()) {
+		return true, nil
+	}
+
+	pipeline, cached := v.pipelines[build.PipelineID()]
+	if !cached {
+		var found bool
+		var err error
+		pipeline, found, err = build.Pipeline()
+		if err != nil {
+			return false, err
+		}
+
+		if !found {
+			pipeline = nil
+		}
+
+		v.pipelines[build.PipelineID()] = pipeline
+		v.publicJobs[build.PipelineID()] = map[string]bool{}
+	}
+
+	if pipeline == nil || !v.pipelineVisible(pipeline) {
+		return false, nil
+	}
+
+	public, cached := v.publicJobs[build.PipelineID()][build.JobName()]
+	if !cached {
+		job, found, err := pipeline.Job(build.JobName())
+		if err != nil {
+			return false, err
+		}
+
+		public = found && job.Public()
+		v.publicJobs[build.PipelineID()][build.JobName()] = public
+	}
+
+	return public, nil
+}
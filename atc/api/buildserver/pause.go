@@ -0,0 +1,38 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) PauseBuild(build db.Build) http.Handler {
+	return s.setBuildPaused(build, "pause", true)
+}
+
+func (s *Server) ResumeBuild(build db.Build) http.Handler {
+	return s.setBuildPaused(build, "resume", false)
+}
+
+func (s *Server) setBuildPaused(build db.Build, action string, paused bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session(action, lager.Data{
+			"build": build.ID(),
+		})
+
+		if !build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		err := build.SetPaused(paused)
+		if err != nil {
+			logger.Error("failed-to-"+action+"-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,47 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetBuildPlanGraph returns the build's public plan laid out as a graph, as
+// JSON or, given format=dot, in Graphviz's DOT language.
+func (s *Server) GetBuildPlanGraph(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-plan-graph", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !build.HasPlan() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var plan atc.Plan
+		err := json.Unmarshal(*build.PublicPlan(), &plan)
+		if err != nil {
+			logger.Error("failed-to-unmarshal-public-plan", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		graph := atc.NewPlanGraph(plan)
+
+		if r.URL.Query().Get("format") == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			_, err = io.WriteString(w, graph.DOT())
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			err = json.NewEncoder(w).Encode(graph)
+		}
+
+		if err != nil {
+			logger.Error("failed-to-write-plan-graph", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
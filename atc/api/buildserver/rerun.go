@@ -0,0 +1,101 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// RerunBuild creates a build re-running a failed job build from the step it
+// failed at. The steps which succeeded before then aren't run again; their
+// artifacts are given to the later steps from where the failed build left
+// them. A build which failed at its first step is re-run in full.
+func (s *Server) RerunBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("rerun-build", lager.Data{
+			"build": build.ID(),
+		})
+
+		if build.JobID() == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("only builds of jobs can be re-run"))
+			return
+		}
+
+		if build.Status() != db.BuildStatusFailed && build.Status() != db.BuildStatusErrored {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("only failed or errored builds can be re-run"))
+			return
+		}
+
+		// builds whose plans weren't kept, or were cleared once a later build
+		// of the job succeeded, can't be re-run
+		plan := build.PrivatePlan()
+		if plan.ID == "" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("the build's plan was not kept, so it can't be re-run"))
+			return
+		}
+
+		pipeline, found, err := build.Pipeline()
+		if err != nil {
+			logger.Error("failed-to-get-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		job, found, err := pipeline.Job(build.JobName())
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		checkpoint, found, err := build.Checkpoint()
+		if err != nil {
+			logger.Error("failed-to-get-checkpoint", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if found {
+			resumed, ok := atc.RerunPlan(plan, checkpoint, atc.NewPlanFactory(time.Now().Unix()))
+			if ok {
+				plan = resumed
+			} else {
+				logger.Info("checkpoint-not-in-plan", lager.Data{"plan-id": checkpoint.PlanID})
+			}
+		}
+
+		rerun, err := job.RerunBuild(build, plan)
+		if err != nil {
+			logger.Error("failed-to-create-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(present.Build(rerun))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -21,14 +22,17 @@ func (s *Server) BuildResources(build db.Build) http.Handler {
 			return
 		}
 
+		acc := accessor.GetAccessor(r)
+		hideMetadata := !acc.IsAuthorized(build.TeamName())
+
 		atcInputs := make([]atc.PublicBuildInput, 0, len(inputs))
 		for _, input := range inputs {
-			atcInputs = append(atcInputs, present.PublicBuildInput(input, build.PipelineID()))
+			atcInputs = append(atcInputs, present.PublicBuildInput(input, build.PipelineID(), hideMetadata))
 		}
 
 		atcOutputs := make([]atc.PublicBuildOutput, 0, len(outputs))
 		for _, output := range outputs {
-			atcOutputs = append(atcOutputs, present.PublicBuildOutput(output))
+			atcOutputs = append(atcOutputs, present.PublicBuildOutput(output, hideMetadata))
 		}
 
 		output := atc.BuildInputsOutputs{
@@ -6,10 +6,13 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 type EventHandlerFactory func(lager.Logger, db.Build) http.Handler
 
+type MultiplexEventHandlerFactory func(lager.Logger, []db.Build) http.Handler
+
 type Server struct {
 	logger lager.Logger
 
@@ -17,7 +20,9 @@ type Server struct {
 
 	teamFactory         db.TeamFactory
 	buildFactory        db.BuildFactory
+	workerClient        worker.Client
 	eventHandlerFactory EventHandlerFactory
+	multiplexFactory    MultiplexEventHandlerFactory
 	rejector            auth.Rejector
 }
 
@@ -26,7 +31,9 @@ func NewServer(
 	externalURL string,
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
+	workerClient worker.Client,
 	eventHandlerFactory EventHandlerFactory,
+	multiplexFactory MultiplexEventHandlerFactory,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -35,7 +42,9 @@ func NewServer(
 
 		teamFactory:         teamFactory,
 		buildFactory:        buildFactory,
+		workerClient:        workerClient,
 		eventHandlerFactory: eventHandlerFactory,
+		multiplexFactory:    multiplexFactory,
 
 		rejector: auth.UnauthorizedRejector{},
 	}
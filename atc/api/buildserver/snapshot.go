@@ -0,0 +1,145 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetBuildSnapshot exports the resolved inputs, tasks and images of a job
+// build as a manifest, so that the build's context can be reproduced locally.
+//
+// The resources, tasks and resource types are taken from the plan the build
+// ran, which job builds keep once they finish, rather than from the job's
+// current config.
+func (s *Server) GetBuildSnapshot(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-build-snapshot", lager.Data{
+			"build": build.ID(),
+		})
+
+		if build.JobID() == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("only builds of jobs can be exported"))
+			return
+		}
+
+		// builds which finished before their plans were kept can't be exported
+		plan := build.PrivatePlan()
+		if plan.ID == "" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("the build's plan was not kept, so it can't be exported"))
+			return
+		}
+
+		inputs, _, err := build.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-build-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		snapshot := atc.BuildSnapshot{
+			Build:         present.Build(build),
+			Inputs:        snapshotInputs(plan, inputs),
+			Tasks:         snapshotTasks(plan, build.ImageOverrides()),
+			ResourceTypes: snapshotResourceTypes(plan),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(snapshot)
+		if err != nil {
+			logger.Error("failed-to-encode-build-snapshot", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func snapshotInputs(plan atc.Plan, inputs []db.BuildInput) []atc.SnapshotInput {
+	gets := map[string]atc.GetPlan{}
+	plan.Each(func(p atc.Plan) {
+		if p.Get == nil {
+			return
+		}
+
+		if _, found := gets[p.Get.Name]; !found {
+			gets[p.Get.Name] = *p.Get
+		}
+	})
+
+	snapshotted := make([]atc.SnapshotInput, 0, len(inputs))
+	for _, input := range inputs {
+		snapshot := atc.SnapshotInput{
+			Name:    input.Name,
+			Version: input.Version,
+		}
+
+		get, found := gets[input.Name]
+		if found {
+			snapshot.Resource = get.Resource
+			snapshot.Type = get.Type
+			snapshot.Source = get.Source
+			snapshot.Params = get.Params
+		}
+
+		snapshotted = append(snapshotted, snapshot)
+	}
+
+	return snapshotted
+}
+
+func snapshotTasks(plan atc.Plan, overrides atc.TaskImageOverrides) []atc.SnapshotTask {
+	tasks := []atc.SnapshotTask{}
+	plan.Each(func(p atc.Plan) {
+		if p.Task == nil {
+			return
+		}
+
+		task := atc.SnapshotTask{
+			Name:              p.Task.Name,
+			ConfigPath:        p.Task.ConfigPath,
+			Config:            p.Task.Config,
+			Vars:              p.Task.Vars,
+			Params:            p.Task.Params,
+			Privileged:        p.Task.Privileged,
+			InputMapping:      p.Task.InputMapping,
+			OutputMapping:     p.Task.OutputMapping,
+			ImageArtifactName: p.Task.ImageArtifactName,
+		}
+
+		if override, found := overrides[p.Task.Name]; found {
+			task.ImageOverride = &override
+		}
+
+		tasks = append(tasks, task)
+	})
+
+	return tasks
+}
+
+// snapshotResourceTypes returns the resource types the build's steps were
+// planned with. Every step is given all of the pipeline's resource types, so
+// the first step with any has them all.
+func snapshotResourceTypes(plan atc.Plan) atc.VersionedResourceTypes {
+	var types atc.VersionedResourceTypes
+	plan.Each(func(p atc.Plan) {
+		if types != nil {
+			return
+		}
+
+		switch {
+		case p.Get != nil:
+			types = p.Get.VersionedResourceTypes
+		case p.Put != nil:
+			types = p.Put.VersionedResourceTypes
+		case p.Task != nil:
+			types = p.Task.VersionedResourceTypes
+		}
+	})
+
+	return types
+}
@@ -0,0 +1,261 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+func (s *Server) GetBuildTimeline(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-timeline", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// as with explaining a build, the timeline is worked out from the
+		// build's events, which are only complete once it has finished
+		if build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		// a finished build only has its public plan left, which still has
+		// the shape and step names of the plan it ran
+		var plan atc.Plan
+		if build.HasPlan() {
+			err := json.Unmarshal(*build.PublicPlan(), &plan)
+			if err != nil {
+				logger.Error("failed-to-unmarshal-public-plan", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		steps, err := timelineSteps(build, plan)
+		if err != nil {
+			logger.Error("failed-to-read-build-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		timeline := atc.BuildTimeline{
+			Build:        present.Build(build),
+			Steps:        []atc.TimelineStep{},
+			CriticalPath: []atc.PlanID{},
+		}
+
+		if !build.StartTime().IsZero() && !build.CreateTime().IsZero() {
+			timeline.QueuedDuration = int64(build.StartTime().Sub(build.CreateTime()).Seconds())
+		}
+
+		stepsByID := map[atc.PlanID]*atc.TimelineStep{}
+		for i := range steps {
+			stepsByID[steps[i].PlanID] = &steps[i]
+		}
+
+		for _, id := range criticalPath(plan, stepsByID) {
+			step := stepsByID[id]
+			step.OnCriticalPath = true
+
+			timeline.CriticalPath = append(timeline.CriticalPath, id)
+			timeline.CriticalPathDuration += step.Duration()
+		}
+
+		timeline.Steps = append(timeline.Steps, steps...)
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(timeline)
+		if err != nil {
+			logger.Error("failed-to-encode-build-timeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func timelineSteps(build db.Build, plan atc.Plan) ([]atc.TimelineStep, error) {
+	names := map[atc.PlanID]string{}
+	planStepNames(plan, names)
+
+	events, err := build.Events(0)
+	if err != nil {
+		return nil, err
+	}
+
+	defer events.Close()
+
+	var order []atc.PlanID
+	stepsByID := map[atc.PlanID]*atc.TimelineStep{}
+
+	step := func(origin event.Origin, stepType string) *atc.TimelineStep {
+		id := atc.PlanID(origin.ID)
+
+		timelineStep, found := stepsByID[id]
+		if !found {
+			timelineStep = &atc.TimelineStep{
+				PlanID: id,
+				Type:   stepType,
+				Name:   names[id],
+			}
+
+			order = append(order, id)
+			stepsByID[id] = timelineStep
+		}
+
+		return timelineStep
+	}
+
+	for {
+		envelope, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			continue
+		}
+
+		switch e := parsed.(type) {
+		case event.InitializeGet:
+			step(e.Origin, "get").InitializedAt = e.Time
+
+		case event.StartGet:
+			s := step(e.Origin, "get")
+			s.Worker = e.Worker
+			s.StartedAt = e.Time
+
+		case event.FinishGet:
+			step(e.Origin, "get").FinishedAt = e.Time
+
+		case event.InitializePut:
+			step(e.Origin, "put").InitializedAt = e.Time
+
+		case event.StartPut:
+			s := step(e.Origin, "put")
+			s.Worker = e.Worker
+			s.StartedAt = e.Time
+
+		case event.FinishPut:
+			step(e.Origin, "put").FinishedAt = e.Time
+
+		case event.InitializeTask:
+			step(e.Origin, "task").InitializedAt = e.Time
+
+		case event.StartTask:
+			s := step(e.Origin, "task")
+			s.Worker = e.Worker
+			s.StartedAt = e.Time
+
+		case event.FinishTask:
+			step(e.Origin, "task").FinishedAt = e.Time
+
+		case event.ContainerPrepared:
+			// containers for fetching the step's image are prepared too, but
+			// the step's own container is prepared last
+			s, found := stepsByID[atc.PlanID(e.Origin.ID)]
+			if !found {
+				continue
+			}
+
+			s.ImageFetchDuration = e.ImageFetchDuration
+			s.InputStreamDuration = e.InputStreamDuration
+		}
+	}
+
+	steps := make([]atc.TimelineStep, 0, len(order))
+	for _, id := range order {
+		s := stepsByID[id]
+
+		if s.InitializedAt != 0 && s.StartedAt != 0 {
+			queued := s.StartedAt - s.InitializedAt - s.ImageFetchDuration - s.InputStreamDuration
+			if queued > 0 {
+				s.QueuedDuration = queued
+			}
+		}
+
+		if s.StartedAt != 0 && s.FinishedAt != 0 {
+			s.RunningDuration = s.FinishedAt - s.StartedAt
+		}
+
+		steps = append(steps, *s)
+	}
+
+	return steps, nil
+}
+
+// criticalPath returns the steps of the plan which ran one after another and
+// determined when it finished. Of steps run in parallel, only the path which
+// finished last is followed.
+func criticalPath(plan atc.Plan, steps map[atc.PlanID]*atc.TimelineStep) []atc.PlanID {
+	var sequence []atc.Plan
+
+	switch {
+	case plan.Get != nil, plan.Put != nil, plan.Task != nil:
+		step, found := steps[plan.ID]
+		if !found || step.FinishedAt == 0 {
+			return nil
+		}
+
+		return []atc.PlanID{plan.ID}
+
+	case plan.Aggregate != nil:
+		return latestPath(*plan.Aggregate, steps)
+	case plan.InParallel != nil:
+		return latestPath(plan.InParallel.Steps, steps)
+
+	case plan.Do != nil:
+		sequence = *plan.Do
+	case plan.Retry != nil:
+		sequence = *plan.Retry
+	case plan.Try != nil:
+		sequence = []atc.Plan{plan.Try.Step}
+	case plan.Timeout != nil:
+		sequence = []atc.Plan{plan.Timeout.Step}
+	case plan.OnAbort != nil:
+		sequence = []atc.Plan{plan.OnAbort.Step, plan.OnAbort.Next}
+	case plan.OnError != nil:
+		sequence = []atc.Plan{plan.OnError.Step, plan.OnError.Next}
+	case plan.OnFailure != nil:
+		sequence = []atc.Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.OnSuccess != nil:
+		sequence = []atc.Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.Ensure != nil:
+		sequence = []atc.Plan{plan.Ensure.Step, plan.Ensure.Next}
+	}
+
+	var path []atc.PlanID
+	for _, child := range sequence {
+		path = append(path, criticalPath(child, steps)...)
+	}
+
+	return path
+}
+
+// latestPath returns the critical path of whichever of the plans finished
+// last.
+func latestPath(plans []atc.Plan, steps map[atc.PlanID]*atc.TimelineStep) []atc.PlanID {
+	var latest []atc.PlanID
+	var latestFinish int64
+
+	for _, plan := range plans {
+		path := criticalPath(plan, steps)
+		if len(path) == 0 {
+			continue
+		}
+
+		finish := steps[path[len(path)-1]].FinishedAt
+		if latest == nil || finish > latestFinish {
+			latest = path
+			latestFinish = finish
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,97 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache Efficiency API", func() {
+	Describe("GET /api/v1/cache-efficiency", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/cache-efficiency")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			Context("when nothing has been fetched yet", func() {
+				It("returns empty totals", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"hits": 0,
+						"misses": 0,
+						"hit_ratio": 0,
+						"resource_types": [],
+						"workers": []
+					}`))
+				})
+			})
+
+			Context("when resources have been fetched", func() {
+				BeforeEach(func() {
+					resourceCaches.Hit("git", "worker-a")
+					resourceCaches.Hit("git", "worker-a")
+					resourceCaches.Miss("git", "worker-b")
+					resourceCaches.Miss("s3", "worker-a")
+				})
+
+				It("returns the totals overall and by resource type and worker", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"hits": 2,
+						"misses": 2,
+						"hit_ratio": 0.5,
+						"resource_types": [
+							{"name": "git", "hits": 2, "misses": 1, "hit_ratio": 0.6666666666666666},
+							{"name": "s3", "hits": 0, "misses": 1, "hit_ratio": 0}
+						],
+						"workers": [
+							{"name": "worker-a", "hits": 2, "misses": 1, "hit_ratio": 0.6666666666666666},
+							{"name": "worker-b", "hits": 0, "misses": 1, "hit_ratio": 0}
+						]
+					}`))
+				})
+			})
+		})
+	})
+})
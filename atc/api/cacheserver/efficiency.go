@@ -0,0 +1,76 @@
+package cacheserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// GetCacheEfficiency totals the resource cache hits and misses counted by
+// this ATC, overall and by resource type and worker.
+func (s *Server) GetCacheEfficiency(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-cache-efficiency")
+
+	var total metric.CacheUsageCount
+	byResourceType := map[string]metric.CacheUsageCount{}
+	byWorker := map[string]metric.CacheUsageCount{}
+
+	for _, stat := range s.resourceCaches.Totals() {
+		total = add(total, stat.CacheUsageCount)
+		byResourceType[stat.ResourceType] = add(byResourceType[stat.ResourceType], stat.CacheUsageCount)
+		byWorker[stat.Worker] = add(byWorker[stat.Worker], stat.CacheUsageCount)
+	}
+
+	efficiency := atc.CacheEfficiency{
+		CacheUsage:    present(total),
+		ResourceTypes: presentNamed(byResourceType),
+		Workers:       presentNamed(byWorker),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(efficiency)
+	if err != nil {
+		logger.Error("failed-to-encode-cache-efficiency", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func add(a metric.CacheUsageCount, b metric.CacheUsageCount) metric.CacheUsageCount {
+	return metric.CacheUsageCount{
+		Hits:   a.Hits + b.Hits,
+		Misses: a.Misses + b.Misses,
+	}
+}
+
+func present(count metric.CacheUsageCount) atc.CacheUsage {
+	usage := atc.CacheUsage{
+		Hits:   count.Hits,
+		Misses: count.Misses,
+	}
+
+	if count.Hits+count.Misses > 0 {
+		usage.HitRatio = float64(count.Hits) / float64(count.Hits+count.Misses)
+	}
+
+	return usage
+}
+
+func presentNamed(counts map[string]metric.CacheUsageCount) []atc.NamedCacheUsage {
+	named := []atc.NamedCacheUsage{}
+	for name, count := range counts {
+		named = append(named, atc.NamedCacheUsage{
+			Name:       name,
+			CacheUsage: present(count),
+		})
+	}
+
+	sort.Slice(named, func(i, j int) bool {
+		return named[i].Name < named[j].Name
+	})
+
+	return named
+}
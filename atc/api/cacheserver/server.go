@@ -0,0 +1,20 @@
+package cacheserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	resourceCaches *metric.CacheUsage
+}
+
+func NewServer(logger lager.Logger, resourceCaches *metric.CacheUsage) *Server {
+	return &Server{
+		logger: logger,
+
+		resourceCaches: resourceCaches,
+	}
+}
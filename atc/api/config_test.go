@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -141,6 +143,10 @@ var _ = Describe("Config API", func() {
 								Resources: []string{"some-resource"},
 							},
 						})
+						pipelineConfig.ResourceDefaults = atc.ResourceDefaults{
+							"some-type": atc.Source{"default-config": "some-default"},
+						}
+						fakePipeline.ResourceDefaultsReturns(pipelineConfig.ResourceDefaults)
 						fakeTeam.PipelineReturns(fakePipeline, true, nil)
 					})
 
@@ -186,9 +192,13 @@ var _ = Describe("Config API", func() {
 								fakeResource = new(dbfakes.FakeResource)
 								fakeResource.NameReturns("some-resource")
 								fakeResource.TypeReturns("some-type")
-								fakeResource.SourceReturns(atc.Source{
+								fakeResource.ConfigSourceReturns(atc.Source{
 									"source-config": "some-value",
 								})
+								fakeResource.SourceReturns(atc.Source{
+									"source-config":  "some-value",
+									"default-config": "some-default",
+								})
 
 								fakePipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
 							})
@@ -226,6 +236,45 @@ var _ = Describe("Config API", func() {
 										Config: pipelineConfig,
 									}))
 								})
+
+								Context("when the pipeline uses deprecated constructs", func() {
+									BeforeEach(func() {
+										fakePipeline.DeprecationsReturns([]atc.ConfigDeprecation{
+											{
+												Location:  "jobs.some-job",
+												Construct: "aggregate",
+												Message:   "aggregate is deprecated",
+											},
+										})
+									})
+
+									It("returns them alongside the config", func() {
+										var actualConfigResponse atc.ConfigResponse
+										err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+										Expect(err).NotTo(HaveOccurred())
+
+										Expect(actualConfigResponse.Deprecations).To(Equal([]atc.ConfigDeprecation{
+											{
+												Location:  "jobs.some-job",
+												Construct: "aggregate",
+												Message:   "aggregate is deprecated",
+											},
+										}))
+									})
+								})
+
+								It("returns the resources' own source alongside the pipeline's resource defaults", func() {
+									var actualConfigResponse atc.ConfigResponse
+									err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(actualConfigResponse.Config.Resources[0].Source).To(Equal(atc.Source{
+										"source-config": "some-value",
+									}))
+									Expect(actualConfigResponse.Config.ResourceDefaults).To(Equal(atc.ResourceDefaults{
+										"some-type": atc.Source{"default-config": "some-default"},
+									}))
+								})
 							})
 
 							Context("when finding the resource types fails", func() {
@@ -339,6 +388,8 @@ var _ = Describe("Config API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+
+				dbTeam.SavePipelineReturns(fakePipeline, false, nil)
 			})
 
 			Context("when a config version is specified", func() {
@@ -433,6 +484,181 @@ var _ = Describe("Config API", func() {
 							Expect(initiallyPaused).To(BeTrue())
 						})
 
+						It("saves that it uses no deprecated constructs", func() {
+							Expect(fakePipeline.UpdateDeprecationsCallCount()).To(Equal(1))
+							Expect(fakePipeline.UpdateDeprecationsArgsForCall(0)).To(BeEmpty())
+						})
+
+						Context("when the config uses deprecated constructs", func() {
+							BeforeEach(func() {
+								pipelineConfig.Resources[0].Type = "some-deprecated-type"
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("saves the deprecations", func() {
+								Expect(fakePipeline.UpdateDeprecationsCallCount()).To(Equal(1))
+								Expect(fakePipeline.UpdateDeprecationsArgsForCall(0)).To(Equal([]atc.ConfigDeprecation{
+									{
+										Location:  "resources.some-resource",
+										Construct: "type: some-deprecated-type",
+										Message:   "use some-type instead",
+									},
+								}))
+							})
+
+							It("returns the deprecations", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+									"deprecations": [
+										{
+											"location": "resources.some-resource",
+											"construct": "type: some-deprecated-type",
+											"message": "use some-type instead"
+										}
+									]
+								}`))
+							})
+						})
+
+						Context("when the team requires image digests", func() {
+							BeforeEach(func() {
+								dbTeam.RequireImageDigestsReturns(true)
+							})
+
+							It("saves configs whose tasks configure no image_resource", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							})
+
+							Context("when a task's image_resource is not pinned by digest", func() {
+								BeforeEach(func() {
+									pipelineConfig.Jobs[0].Plan[1].TaskConfig.RootfsURI = ""
+									pipelineConfig.Jobs[0].Plan[1].TaskConfig.ImageResource = &atc.ImageResource{
+										Type:   "registry-image",
+										Source: atc.Source{"repository": "some/image"},
+									}
+
+									payload, err := json.Marshal(pipelineConfig)
+									Expect(err).NotTo(HaveOccurred())
+
+									request.Body = gbytes.BufferWithBytes(payload)
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns an error for the task", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"errors": [
+											"jobs.some-job.plan.task(some-task).config.image_resource must be pinned by digest"
+										]
+									}`))
+								})
+
+								It("does not save anything", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+						})
+
+						Context("when the team has a pipelines repo", func() {
+							BeforeEach(func() {
+								dbTeam.PipelinesRepoReturns(&atc.PipelinesRepo{URI: "https://example.com/pipelines.git"})
+								dbTeam.PipelineReturns(fakePipeline, true, nil)
+							})
+
+							It("saves pipelines which are not managed by the repo", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							})
+
+							Context("when the pipeline is managed by the repo", func() {
+								BeforeEach(func() {
+									fakePipeline.ReconciledDigestReturns("some-digest")
+								})
+
+								It("returns 409", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusConflict))
+								})
+
+								It("returns an error pointing at the repo", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"errors": [
+											"pipeline is managed by the team's pipelines repo; change it in https://example.com/pipelines.git instead"
+										]
+									}`))
+								})
+
+								It("does not save anything", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+						})
+
+						It("validates it with the config validator", func() {
+							Expect(fakeConfigValidator.ValidateCallCount()).To(Equal(1))
+						})
+
+						Context("when the validation queue is full", func() {
+							BeforeEach(func() {
+								fakeConfigValidator.ValidateReturns(configserver.ErrValidationQueueFull)
+							})
+
+							It("returns 503", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+							})
+
+							It("returns the error", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors":["too many pipeline configs are waiting to be validated; try again later"]}`))
+							})
+
+							It("does not save anything", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when validating it times out", func() {
+							BeforeEach(func() {
+								fakeConfigValidator.ValidateReturns(configserver.ErrValidationTimedOut)
+							})
+
+							It("returns 503", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+							})
+
+							It("does not save anything", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when validating it panics", func() {
+							BeforeEach(func() {
+								fakeConfigValidator.ValidateReturns(configserver.ErrValidationPanicked)
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not save anything", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when saving the deprecations fails", func() {
+							BeforeEach(func() {
+								fakePipeline.UpdateDeprecationsReturns(errors.New("nope"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
 								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
@@ -447,6 +673,62 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when the config changed since the given version", func() {
+							BeforeEach(func() {
+								dbTeam.SavePipelineReturns(nil, false, db.ErrConfigComparisonFailed)
+
+								currentConfig := pipelineConfig
+								currentConfig.Groups = nil
+
+								fakePipeline.ConfigReturns(currentConfig, nil)
+								fakePipeline.ConfigVersionReturns(43)
+								dbTeam.PipelineReturns(fakePipeline, true, nil)
+							})
+
+							It("returns 409", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusConflict))
+								Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+							})
+
+							It("returns the current version and a diff of the config", func() {
+								var saveResponse atc.SaveConfigResponse
+								Expect(json.NewDecoder(response.Body).Decode(&saveResponse)).To(Succeed())
+
+								Expect(saveResponse.Errors).To(Equal([]string{"pipeline config has changed since version 42; fetch the current config and try again"}))
+								Expect(saveResponse.Conflict).ToNot(BeNil())
+								Expect(saveResponse.Conflict.Version).To(Equal("43"))
+								Expect(saveResponse.Conflict.Diff).To(HavePrefix("+ groups:\n"))
+								Expect(saveResponse.Conflict.Diff).ToNot(MatchRegexp(`(?m)^- `))
+							})
+
+							Context("when the configs are too large to diff line by line", func() {
+								BeforeEach(func() {
+									currentConfig := pipelineConfig
+									currentConfig.Groups = nil
+									for i := 0; i < 2000; i++ {
+										pipelineConfig.Groups = append(pipelineConfig.Groups, atc.GroupConfig{Name: fmt.Sprintf("new-group-%d", i), Jobs: []string{"some-job"}})
+										currentConfig.Groups = append(currentConfig.Groups, atc.GroupConfig{Name: fmt.Sprintf("old-group-%d", i), Jobs: []string{"some-job"}})
+									}
+
+									fakePipeline.ConfigReturns(currentConfig, nil)
+
+									payload, err := json.Marshal(pipelineConfig)
+									Expect(err).NotTo(HaveOccurred())
+
+									request.Body = gbytes.BufferWithBytes(payload)
+								})
+
+								It("shows the lines which differ as removed and added", func() {
+									var saveResponse atc.SaveConfigResponse
+									Expect(json.NewDecoder(response.Body).Decode(&saveResponse)).To(Succeed())
+
+									Expect(saveResponse.Conflict).ToNot(BeNil())
+									Expect(saveResponse.Conflict.Diff).To(ContainSubstring("-   name: old-group-1999\n"))
+									Expect(saveResponse.Conflict.Diff).To(ContainSubstring("+   name: new-group-0\n"))
+								})
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
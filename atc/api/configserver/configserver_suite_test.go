@@ -0,0 +1,13 @@
+package configserver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestConfigserver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Configserver Suite")
+}
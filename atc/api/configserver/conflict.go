@@ -0,0 +1,115 @@
+package configserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/aryann/difflib"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"sigs.k8s.io/yaml"
+)
+
+// handleConflict rejects a config which was based on an older version of
+// the pipeline's config, showing what it would have overwritten.
+func (s *Server) handleConflict(logger lager.Logger, w http.ResponseWriter, team db.Team, pipelineName string, version db.ConfigVersion, config atc.Config) {
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	current, err := pipeline.Config()
+	if err != nil {
+		logger.Error("failed-to-get-current-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	diff, err := configDiff(current, config)
+	if err != nil {
+		logger.Error("failed-to-diff-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	message := fmt.Sprintf("pipeline config has changed since version %d; fetch the current config and try again", version)
+	if version == 0 {
+		message = "pipeline already exists; the version of its config which the new config is based on must be given"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{
+		Errors: []string{message},
+		Conflict: &atc.ConfigConflict{
+			Version: fmt.Sprintf("%d", pipeline.ConfigVersion()),
+			Diff:    diff,
+		},
+	})
+}
+
+// maxConfigDiffCells caps the size of the table which difflib fills in to
+// diff configs, which grows with the product of their lengths in lines. Past
+// it, the lines which differ are shown as removed and added wholesale rather
+// than diffed line by line.
+const maxConfigDiffCells = 1 << 20
+
+func configDiff(before atc.Config, after atc.Config) (string, error) {
+	payloadBefore, err := yaml.Marshal(before)
+	if err != nil {
+		return "", err
+	}
+
+	payloadAfter, err := yaml.Marshal(after)
+	if err != nil {
+		return "", err
+	}
+
+	linesBefore := strings.Split(string(payloadBefore), "\n")
+	linesAfter := strings.Split(string(payloadAfter), "\n")
+
+	// most of a config is usually unchanged, so leave the lines the two have
+	// in common at either end out of the diff
+	for len(linesBefore) > 0 && len(linesAfter) > 0 && linesBefore[0] == linesAfter[0] {
+		linesBefore, linesAfter = linesBefore[1:], linesAfter[1:]
+	}
+
+	for len(linesBefore) > 0 && len(linesAfter) > 0 && linesBefore[len(linesBefore)-1] == linesAfter[len(linesAfter)-1] {
+		linesBefore, linesAfter = linesBefore[:len(linesBefore)-1], linesAfter[:len(linesAfter)-1]
+	}
+
+	var diff strings.Builder
+
+	if len(linesBefore)*len(linesAfter) > maxConfigDiffCells {
+		for _, line := range linesBefore {
+			diff.WriteString("- " + line + "\n")
+		}
+
+		for _, line := range linesAfter {
+			diff.WriteString("+ " + line + "\n")
+		}
+
+		return diff.String(), nil
+	}
+
+	for _, line := range difflib.Diff(linesBefore, linesAfter) {
+		switch line.Delta {
+		case difflib.LeftOnly:
+			diff.WriteString("- " + line.Payload + "\n")
+		case difflib.RightOnly:
+			diff.WriteString("+ " + line.Payload + "\n")
+		}
+	}
+
+	return diff.String(), nil
+}
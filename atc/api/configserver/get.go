@@ -68,13 +68,24 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		Resources:     resources.Configs(),
 		ResourceTypes: resourceTypes.Configs(),
 		Jobs:          jobs.Configs(),
+
+		ResourceDefaults:       pipeline.ResourceDefaults(),
+		IgnoreTeamContainerEnv: pipeline.IgnoreTeamContainerEnv(),
+		ContainerDNS:           pipeline.ContainerDNS(),
+		Branches:               pipeline.Branches(),
+		SchedulingWindows:      pipeline.SchedulingWindows(),
+		Labels:                 pipeline.Labels(),
+		Redactions:             pipeline.Redactions(),
+		TimeZone:               pipeline.TimeZone(),
+		Locale:                 pipeline.Locale(),
 	}
 
 	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", pipeline.ConfigVersion()))
 	w.Header().Set("Content-Type", "application/json")
 
 	err = json.NewEncoder(w).Encode(atc.ConfigResponse{
-		Config: config,
+		Config:       config,
+		Deprecations: pipeline.Deprecations(),
 	})
 	if err != nil {
 		logger.Error("failed-to-encode-config", err)
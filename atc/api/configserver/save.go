@@ -58,7 +58,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		for k := range ignoredUnknownToplevels {
 			switch k {
-			case "groups", "jobs", "resources", "resource_types":
+			case "groups", "jobs", "resources", "resource_types", "branches", "scheduling_windows", "labels", "redactions":
 			default:
 				delete(ignoredUnknownToplevels, k)
 			}
@@ -84,24 +84,50 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	warnings, errorMessages := config.Validate()
-	if len(errorMessages) > 0 {
-		session.Info("ignoring-invalid-config")
-		s.handleBadRequest(w, errorMessages...)
-		return
-	}
-
 	pipelineName := rata.Param(r, "pipeline_name")
 	teamName := rata.Param(r, "team_name")
 
-	if checkCredentials {
-		variables := creds.NewVariables(s.secretManager, teamName, pipelineName)
+	var warnings []atc.ConfigWarning
+	var errorMessages []string
+	var credErrs error
 
-		errs := validateCredParams(variables, config, session)
-		if errs != nil {
-			s.handleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
+	err := s.configValidator.Validate(r.Context(), session, func() {
+		warnings, errorMessages = config.Validate()
+		if len(errorMessages) > 0 || !checkCredentials {
 			return
 		}
+
+		variables := creds.NewVariables(s.secretManager, teamName, pipelineName)
+		credErrs = validateCredParams(variables, config, session)
+	})
+	if err == ErrValidationQueueFull || err == ErrValidationTimedOut {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		s.writeSaveConfigResponse(w, atc.SaveConfigResponse{
+			Errors: []string{err.Error()},
+		})
+		return
+	}
+
+	if err == ErrValidationPanicked {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err != nil {
+		session.Info("gave-up-validating", lager.Data{"error": err.Error()})
+		return
+	}
+
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config")
+		s.handleBadRequest(w, errorMessages...)
+		return
+	}
+
+	if credErrs != nil {
+		s.handleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", credErrs))
+		return
 	}
 
 	session.Info("saving")
@@ -119,7 +145,41 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, true)
+	if team.RequireImageDigests() {
+		messages := config.ImagesNotPinnedByDigest()
+		if len(messages) > 0 {
+			session.Info("images-not-pinned-by-digest")
+			s.handleBadRequest(w, messages...)
+			return
+		}
+	}
+
+	if repo := team.PipelinesRepo(); repo != nil {
+		existing, found, err := team.Pipeline(pipelineName)
+		if err != nil {
+			session.Error("failed-to-find-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if found && existing.ReconciledDigest() != "" {
+			session.Info("pipeline-managed-by-repo")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			s.writeSaveConfigResponse(w, atc.SaveConfigResponse{
+				Errors: []string{fmt.Sprintf("pipeline is managed by the team's pipelines repo; change it in %s instead", repo.URI)},
+			})
+			return
+		}
+	}
+
+	pipeline, created, err := team.SavePipeline(pipelineName, config, version, true)
+	if err == db.ErrConfigComparisonFailed {
+		session.Info("config-conflict", lager.Data{"version": version})
+		s.handleConflict(session, w, team, pipelineName, version, config)
+		return
+	}
+
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -127,6 +187,15 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	deprecations := config.Deprecations(s.deprecatedResourceTypes)
+
+	err = pipeline.UpdateDeprecations(deprecations)
+	if err != nil {
+		session.Error("failed-to-save-deprecations", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	session.Info("saved")
 
 	w.Header().Set("Content-Type", "application/json")
@@ -137,7 +206,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}
 
-	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
+	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{
+		Warnings:     warnings,
+		Deprecations: deprecations,
+	})
 }
 
 // Simply validate that the credentials exist; don't do anything with the actual secrets
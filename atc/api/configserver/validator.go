@@ -0,0 +1,125 @@
+package configserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// ErrValidationQueueFull is returned when every validation worker is busy and
+// the queue of configs waiting for one is full.
+var ErrValidationQueueFull = errors.New("too many pipeline configs are waiting to be validated; try again later")
+
+// ErrValidationTimedOut is returned when a config was not validated within the
+// validator's timeout.
+var ErrValidationTimedOut = errors.New("timed out validating pipeline config")
+
+// ErrValidationPanicked is returned when validating a config panicked.
+var ErrValidationPanicked = errors.New("validating pipeline config panicked")
+
+//go:generate counterfeiter . ConfigValidator
+
+// ConfigValidator runs the validation of pipeline configs being saved.
+// Validating a huge config (interpolating it, resolving its resource types and
+// looking for cycles between its jobs) can take a while, so it is done by a
+// bounded number of workers rather than by every request at once.
+//
+// Validate runs the given func on a worker and waits for it to return, the
+// timeout to elapse, or the context to be done. A panic in the func is
+// recovered by the worker and returned as ErrValidationPanicked.
+type ConfigValidator interface {
+	Validate(ctx context.Context, logger lager.Logger, validate func()) error
+}
+
+type validation struct {
+	ctx      context.Context
+	logger   lager.Logger
+	validate func()
+	done     chan struct{}
+	err      error
+}
+
+type configValidator struct {
+	timeout time.Duration
+	queue   chan *validation
+}
+
+// NewConfigValidator starts the given number of workers, which validate
+// configs from a queue holding up to queueSize of them. A timeout of 0 means
+// requests wait as long as they're connected.
+func NewConfigValidator(workers int, queueSize int, timeout time.Duration) ConfigValidator {
+	validator := &configValidator{
+		timeout: timeout,
+		queue:   make(chan *validation, queueSize),
+	}
+
+	for i := 0; i < workers; i++ {
+		go validator.work()
+	}
+
+	return validator
+}
+
+func (validator *configValidator) Validate(ctx context.Context, logger lager.Logger, validate func()) error {
+	if validator.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, validator.timeout)
+		defer cancel()
+	}
+
+	v := &validation{
+		ctx:      ctx,
+		logger:   logger,
+		validate: validate,
+		done:     make(chan struct{}),
+	}
+
+	select {
+	case validator.queue <- v:
+	default:
+		logger.Info("validation-queue-full")
+		return ErrValidationQueueFull
+	}
+
+	select {
+	case <-v.done:
+		return v.err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			logger.Info("validation-timed-out", lager.Data{"timeout": validator.timeout.String()})
+			return ErrValidationTimedOut
+		}
+
+		return ctx.Err()
+	}
+}
+
+func (validator *configValidator) work() {
+	for v := range validator.queue {
+		// the request gave up while this was queued; don't bother
+		if v.ctx.Err() != nil {
+			continue
+		}
+
+		validator.run(v)
+	}
+}
+
+// run runs the validation, recovering if it panics so that the worker keeps
+// going and the request is told what happened.
+func (validator *configValidator) run(v *validation) {
+	defer close(v.done)
+
+	defer func() {
+		if r := recover(); r != nil {
+			v.logger.Error("validation-panicked", fmt.Errorf("%v", r), lager.Data{"stack": string(debug.Stack())})
+			v.err = ErrValidationPanicked
+		}
+	}()
+
+	v.validate()
+}
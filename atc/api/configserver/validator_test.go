@@ -0,0 +1,136 @@
+package configserver_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/api/configserver"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigValidator", func() {
+	var (
+		logger    *lagertest.TestLogger
+		validator configserver.ConfigValidator
+		timeout   time.Duration
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		timeout = 0
+	})
+
+	JustBeforeEach(func() {
+		validator = configserver.NewConfigValidator(1, 1, timeout)
+	})
+
+	It("runs the validation and waits for it", func() {
+		validated := false
+
+		err := validator.Validate(context.Background(), logger, func() {
+			validated = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validated).To(BeTrue())
+	})
+
+	Context("when the validation panics", func() {
+		It("returns an error", func() {
+			err := validator.Validate(context.Background(), logger, func() {
+				panic("oh no")
+			})
+			Expect(err).To(Equal(configserver.ErrValidationPanicked))
+		})
+
+		It("keeps validating further configs", func() {
+			validator.Validate(context.Background(), logger, func() {
+				panic("oh no")
+			})
+
+			validated := false
+
+			err := validator.Validate(context.Background(), logger, func() {
+				validated = true
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validated).To(BeTrue())
+		})
+	})
+
+	Context("when the worker is busy and the queue is full", func() {
+		var release chan struct{}
+
+		JustBeforeEach(func() {
+			release = make(chan struct{})
+			started := make(chan struct{})
+
+			go validator.Validate(context.Background(), logger, func() {
+				close(started)
+				<-release
+			})
+
+			Eventually(started).Should(BeClosed())
+
+			// fill the queue with a validation which gives up waiting, rather
+			// than one which blocks until the worker is released
+			Eventually(func() error {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+
+				return validator.Validate(ctx, logger, func() {})
+			}).Should(Equal(configserver.ErrValidationQueueFull))
+		})
+
+		AfterEach(func() {
+			close(release)
+		})
+
+		It("rejects further validations without running them", func() {
+			validated := false
+
+			err := validator.Validate(context.Background(), logger, func() {
+				validated = true
+			})
+			Expect(err).To(Equal(configserver.ErrValidationQueueFull))
+			Expect(validated).To(BeFalse())
+		})
+	})
+
+	Context("when the validation takes longer than the timeout", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			timeout = 10 * time.Millisecond
+			release = make(chan struct{})
+		})
+
+		AfterEach(func() {
+			close(release)
+		})
+
+		It("gives up waiting", func() {
+			err := validator.Validate(context.Background(), logger, func() {
+				<-release
+			})
+			Expect(err).To(Equal(configserver.ErrValidationTimedOut))
+		})
+	})
+
+	Context("when the request is cancelled while queued", func() {
+		It("does not run the validation", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			validated := false
+
+			err := validator.Validate(ctx, logger, func() {
+				validated = true
+			})
+			Expect(err).To(Equal(context.Canceled))
+			Expect(validated).To(BeFalse())
+		})
+	})
+})
@@ -3,6 +3,7 @@ package api_test
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -349,6 +350,188 @@ var _ = Describe("Containers API", func() {
 				})
 			})
 
+			Describe("filtering by state and age", func() {
+				BeforeEach(func() {
+					fakeContainer1.StateReturns(atc.ContainerStateCreated)
+					fakeContainer1.CreatedAtReturns(time.Now().Add(-2 * time.Hour))
+
+					fakeContainer2.StateReturns(atc.ContainerStateFailed)
+					fakeContainer2.CreatedAtReturns(time.Now().Add(-time.Minute))
+
+					dbTeam.ContainersReturns([]db.Container{fakeContainer1, fakeContainer2}, nil)
+				})
+
+				handles := func(response *http.Response) []string {
+					var containers []atc.Container
+					err := json.NewDecoder(response.Body).Decode(&containers)
+					Expect(err).NotTo(HaveOccurred())
+
+					handles := []string{}
+					for _, container := range containers {
+						handles = append(handles, container.ID)
+					}
+
+					return handles
+				}
+
+				Context("by state", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"state": []string{"failed"},
+						}.Encode()
+					})
+
+					It("still looks up all of the team's containers", func() {
+						_, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(dbTeam.ContainersCallCount()).To(Equal(1))
+						Expect(dbTeam.FindContainersByMetadataCallCount()).To(BeZero())
+					})
+
+					It("returns only the containers in that state", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(handles(response)).To(Equal([]string{"some-other-handle"}))
+					})
+				})
+
+				Context("by age", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"older_than": []string{"1h"},
+						}.Encode()
+					})
+
+					It("returns only the containers older than the given age", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(handles(response)).To(Equal([]string{"some-handle"}))
+					})
+				})
+
+				Context("combined with metadata", func() {
+					BeforeEach(func() {
+						dbTeam.FindContainersByMetadataReturns([]db.Container{fakeContainer1, fakeContainer2}, nil)
+
+						req.URL.RawQuery = url.Values{
+							"pipeline_id": []string{strconv.Itoa(pipelineID)},
+							"newer_than":  []string{"1h"},
+						}.Encode()
+					})
+
+					It("queries with the metadata and filters the result", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(dbTeam.FindContainersByMetadataArgsForCall(0)).To(Equal(db.ContainerMetadata{
+							PipelineID: pipelineID,
+						}))
+						Expect(handles(response)).To(Equal([]string{"some-other-handle"}))
+					})
+				})
+
+				Context("when the state is unknown", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"state": []string{"sleepy"},
+						}.Encode()
+					})
+
+					It("returns 400 Bad Request", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the age is not a duration", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"older_than": []string{"ages"},
+						}.Encode()
+					})
+
+					It("returns 400 Bad Request", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("querying with details", func() {
+				var fakeWorkerContainer *workerfakes.FakeContainer
+
+				BeforeEach(func() {
+					fakeContainer1.StateReturns(atc.ContainerStateCreated)
+					fakeContainer2.StateReturns(atc.ContainerStateDestroying)
+					dbTeam.ContainersReturns([]db.Container{fakeContainer1, fakeContainer2}, nil)
+					dbTeam.IDReturns(42)
+
+					fakeWorkerContainer = new(workerfakes.FakeContainer)
+					fakeWorkerContainer.MetricsReturns(garden.Metrics{
+						PidStat:  garden.ContainerPidStat{Current: 12},
+						DiskStat: garden.ContainerDiskStat{TotalBytesUsed: 1024},
+					}, nil)
+					fakeWorkerClient.FindContainerReturns(fakeWorkerContainer, true, nil)
+
+					req.URL.RawQuery = url.Values{
+						"details": []string{"true"},
+					}.Encode()
+				})
+
+				It("asks the worker about created containers only", func() {
+					_, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeWorkerClient.FindContainerCallCount()).To(Equal(1))
+					_, teamID, handle := fakeWorkerClient.FindContainerArgsForCall(0)
+					Expect(teamID).To(Equal(42))
+					Expect(handle).To(Equal("some-handle"))
+				})
+
+				It("includes the worker-reported details", func() {
+					response, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+
+					var containers []atc.Container
+					err = json.NewDecoder(response.Body).Decode(&containers)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(containers).To(HaveLen(2))
+					Expect(containers[0].Details).To(Equal(&atc.ContainerDetails{
+						ProcessCount:   12,
+						DiskUsageBytes: 1024,
+					}))
+					Expect(containers[1].Details).To(BeNil())
+				})
+
+				Context("when the worker fails to report metrics", func() {
+					BeforeEach(func() {
+						fakeWorkerContainer.MetricsReturns(garden.Metrics{}, errors.New("nope"))
+					})
+
+					It("still lists the container without details", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						var containers []atc.Container
+						err = json.NewDecoder(response.Body).Decode(&containers)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(containers).To(HaveLen(2))
+						Expect(containers[0].Details).To(BeNil())
+					})
+				})
+			})
+
 			Describe("querying with type 'check'", func() {
 				BeforeEach(func() {
 					req.URL.RawQuery = url.Values{
@@ -977,6 +1160,183 @@ var _ = Describe("Containers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/hijack", func() {
+		var (
+			conn     *websocket.Conn
+			response *http.Response
+
+			expectBadHandshake bool
+		)
+
+		BeforeEach(func() {
+			expectBadHandshake = false
+		})
+
+		JustBeforeEach(func() {
+			wsURL, err := url.Parse(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			wsURL.Scheme = "ws"
+			wsURL.Path = "/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/check/hijack"
+
+			dialer := websocket.Dialer{}
+			conn, response, err = dialer.Dial(wsURL.String(), nil)
+			if !expectBadHandshake {
+				Expect(err).NotTo(HaveOccurred())
+
+				err = conn.WriteJSON(atc.HijackProcessSpec{Path: "ls", User: "snoopy"})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			if !expectBadHandshake {
+				_ = conn.Close()
+			}
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the user is not admin", func() {
+				BeforeEach(func() {
+					expectBadHandshake = true
+
+					fakeaccess.IsAdminReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not look for the check container", func() {
+					Expect(dbTeam.FindCheckContainersCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the user is an admin", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAdminReturns(true)
+				})
+
+				Context("when the resource has check containers", func() {
+					var (
+						fakeContainer *workerfakes.FakeContainer
+						processExit   chan int
+					)
+
+					BeforeEach(func() {
+						rotatedContainer := new(dbfakes.FakeContainer)
+						rotatedContainer.IDReturns(1)
+						rotatedContainer.HandleReturns("rotated-handle")
+						rotatedContainer.StateReturns(atc.ContainerStateCreated)
+
+						latestContainer := new(dbfakes.FakeContainer)
+						latestContainer.IDReturns(2)
+						latestContainer.HandleReturns("latest-handle")
+						latestContainer.StateReturns(atc.ContainerStateCreated)
+
+						creatingContainer := new(dbfakes.FakeContainer)
+						creatingContainer.IDReturns(3)
+						creatingContainer.HandleReturns("creating-handle")
+						creatingContainer.StateReturns(atc.ContainerStateCreating)
+
+						dbTeam.FindCheckContainersReturns([]db.Container{rotatedContainer, creatingContainer, latestContainer}, nil, nil)
+
+						exit := make(chan int)
+						processExit = exit
+
+						fakeProcess := new(gfakes.FakeProcess)
+						fakeProcess.WaitStub = func() (int, error) {
+							return <-exit, nil
+						}
+
+						fakeContainer = new(workerfakes.FakeContainer)
+						fakeContainer.RunReturns(fakeProcess, nil)
+						fakeWorkerClient.FindContainerReturns(fakeContainer, true, nil)
+					})
+
+					AfterEach(func() {
+						close(processExit)
+					})
+
+					It("looks up the resource's check containers", func() {
+						Expect(dbTeam.FindCheckContainersCallCount()).To(Equal(1))
+
+						pipelineName, resourceName, _ := dbTeam.FindCheckContainersArgsForCall(0)
+						Expect(pipelineName).To(Equal("some-pipeline"))
+						Expect(resourceName).To(Equal("some-resource"))
+					})
+
+					It("hijacks the most recently created check container", func() {
+						Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+						_, lookedUpTeamID, lookedUpHandle := fakeWorkerClient.FindContainerArgsForCall(0)
+						Expect(lookedUpTeamID).To(Equal(734))
+						Expect(lookedUpHandle).To(Equal("latest-handle"))
+
+						_, spec, _ := fakeContainer.RunArgsForCall(0)
+						Expect(spec).To(Equal(garden.ProcessSpec{
+							Path: "ls",
+							User: "snoopy",
+						}))
+					})
+
+					Context("when the container could not be found on the worker client", func() {
+						BeforeEach(func() {
+							expectBadHandshake = true
+
+							fakeWorkerClient.FindContainerReturns(nil, false, nil)
+						})
+
+						It("returns 404 Not Found", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+				})
+
+				Context("when the resource has no created check containers", func() {
+					BeforeEach(func() {
+						expectBadHandshake = true
+
+						dbTeam.FindCheckContainersReturns(nil, nil, nil)
+					})
+
+					It("returns 404 Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when finding the check containers fails", func() {
+					BeforeEach(func() {
+						expectBadHandshake = true
+
+						dbTeam.FindCheckContainersReturns(nil, nil, errors.New("nope"))
+					})
+
+					It("returns 500 internal error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				expectBadHandshake = true
+
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/containers/destroying", func() {
 		BeforeEach(func() {
 			var err error
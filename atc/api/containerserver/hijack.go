@@ -133,31 +133,101 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 
 		hLog.Debug("found-container")
 
-		conn, err := upgrader.Upgrade(w, r, nil)
+		s.upgradeAndHijack(hLog, w, r, container)
+	})
+}
+
+// HijackCheckContainer hijacks the most recently created check container of
+// a resource, so that a failing check can be debugged without first looking
+// up its container's handle. Containers of check sessions which have expired
+// and been replaced are still hijackable until they are garbage collected.
+func (s *Server) HijackCheckContainer(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pipelineName := r.FormValue(":pipeline_name")
+		resourceName := r.FormValue(":resource_name")
+
+		hLog := s.logger.Session("hijack-check-container", lager.Data{
+			"pipeline": pipelineName,
+			"resource": resourceName,
+		})
+
+		acc := accessor.GetAccessor(r)
+		if !acc.IsAdmin() {
+			hLog.Info("user-not-authorized-to-hijack-check-container")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		checkContainers, _, err := team.FindCheckContainers(pipelineName, resourceName, s.secretManager)
 		if err != nil {
-			hLog.Error("unable-to-upgrade-connection-for-websockets", err)
+			hLog.Error("failed-to-find-check-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		defer db.Close(conn)
+		var latest db.Container
+		for _, checkContainer := range checkContainers {
+			if checkContainer.State() != atc.ContainerStateCreated {
+				continue
+			}
+
+			if latest == nil || checkContainer.ID() > latest.ID() {
+				latest = checkContainer
+			}
+		}
+
+		if latest == nil {
+			hLog.Info("check-container-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 
-		var processSpec atc.HijackProcessSpec
-		err = conn.ReadJSON(&processSpec)
+		hLog = hLog.WithData(lager.Data{"handle": latest.Handle()})
+
+		container, found, err := s.workerClient.FindContainer(hLog, team.ID(), latest.Handle())
 		if err != nil {
-			hLog.Error("malformed-process-spec", err)
-			closeWithErr(hLog, conn, websocket.CloseUnsupportedData, fmt.Sprintf("malformed process spec"))
+			hLog.Error("failed-to-find-container", err)
+			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		hijackRequest := hijackRequest{
-			Container: container,
-			Process:   processSpec,
+		if !found {
+			hLog.Info("container-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
 		}
 
-		s.hijack(hLog, conn, hijackRequest)
+		hLog.Debug("found-container")
+
+		s.upgradeAndHijack(hLog, w, r, container)
 	})
 }
 
+func (s *Server) upgradeAndHijack(hLog lager.Logger, w http.ResponseWriter, r *http.Request, container worker.Container) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		hLog.Error("unable-to-upgrade-connection-for-websockets", err)
+		return
+	}
+
+	defer db.Close(conn)
+
+	var processSpec atc.HijackProcessSpec
+	err = conn.ReadJSON(&processSpec)
+	if err != nil {
+		hLog.Error("malformed-process-spec", err)
+		closeWithErr(hLog, conn, websocket.CloseUnsupportedData, fmt.Sprintf("malformed process spec"))
+		return
+	}
+
+	hijackRequest := hijackRequest{
+		Container: container,
+		Process:   processSpec,
+	}
+
+	s.hijack(hLog, conn, hijackRequest)
+}
+
 type hijackRequest struct {
 	Container worker.Container
 	Process   atc.HijackProcessSpec
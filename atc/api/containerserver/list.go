@@ -21,6 +21,13 @@ func (s *Server) ListContainers(team db.Team) http.Handler {
 			"params": params,
 		})
 
+		filter, err := createContainerFilterFromRequest(r)
+		if err != nil {
+			hLog.Error("failed-to-parse-request", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		containerLocator, err := createContainerLocatorFromRequest(team, r, s.secretManager)
 		if err != nil {
 			hLog.Error("failed-to-parse-request", err)
@@ -41,10 +48,21 @@ func (s *Server) ListContainers(team db.Team) http.Handler {
 
 		hLog.Debug("listed", lager.Data{"container-count": len(containers)})
 
-		presentedContainers := make([]atc.Container, len(containers))
-		for i := 0; i < len(containers); i++ {
-			container := containers[i]
-			presentedContainers[i] = present.Container(container, checkContainersExpiresAt[container.ID()])
+		now := time.Now()
+
+		presentedContainers := []atc.Container{}
+		for _, container := range containers {
+			if !filter.Matches(container, now) {
+				continue
+			}
+
+			presentedContainer := present.Container(container, checkContainersExpiresAt[container.ID()])
+
+			if filter.details && container.State() == atc.ContainerStateCreated {
+				presentedContainer.Details = s.containerDetails(hLog, team, container)
+			}
+
+			presentedContainers = append(presentedContainers, presentedContainer)
 		}
 
 		err = json.NewEncoder(w).Encode(presentedContainers)
@@ -55,6 +73,96 @@ func (s *Server) ListContainers(team db.Team) http.Handler {
 	})
 }
 
+// containerDetails asks the container's worker for its process count and
+// disk usage. Workers which cannot be reached are logged and skipped, so that
+// one stalled worker does not fail the whole listing.
+func (s *Server) containerDetails(logger lager.Logger, team db.Team, container db.Container) *atc.ContainerDetails {
+	logger = logger.WithData(lager.Data{"handle": container.Handle()})
+
+	workerContainer, found, err := s.workerClient.FindContainer(logger, team.ID(), container.Handle())
+	if err != nil {
+		logger.Error("failed-to-find-worker-container", err)
+		return nil
+	}
+
+	if !found {
+		logger.Info("worker-container-not-found")
+		return nil
+	}
+
+	metrics, err := workerContainer.Metrics()
+	if err != nil {
+		logger.Error("failed-to-get-container-metrics", err)
+		return nil
+	}
+
+	return &atc.ContainerDetails{
+		ProcessCount:   metrics.PidStat.Current,
+		DiskUsageBytes: metrics.DiskStat.TotalBytesUsed,
+	}
+}
+
+// containerFilter narrows down the located containers by the properties which
+// the locators do not query on.
+type containerFilter struct {
+	state     string
+	olderThan time.Duration
+	newerThan time.Duration
+	details   bool
+}
+
+var containerFilterParams = []string{"state", "older_than", "newer_than", "details"}
+
+func createContainerFilterFromRequest(r *http.Request) (containerFilter, error) {
+	query := r.URL.Query()
+
+	filter := containerFilter{
+		state:   query.Get("state"),
+		details: query.Get("details") == "true",
+	}
+
+	switch filter.state {
+	case "",
+		atc.ContainerStateCreating,
+		atc.ContainerStateCreated,
+		atc.ContainerStateDestroying,
+		atc.ContainerStateFailed:
+	default:
+		return containerFilter{}, fmt.Errorf("unknown 'state' param (%s)", filter.state)
+	}
+
+	var err error
+	filter.olderThan, err = parseDurationParam(r, "older_than")
+	if err != nil {
+		return containerFilter{}, err
+	}
+
+	filter.newerThan, err = parseDurationParam(r, "newer_than")
+	if err != nil {
+		return containerFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func (f containerFilter) Matches(container db.Container, now time.Time) bool {
+	if f.state != "" && container.State() != f.state {
+		return false
+	}
+
+	age := now.Sub(container.CreatedAt())
+
+	if f.olderThan != 0 && age < f.olderThan {
+		return false
+	}
+
+	if f.newerThan != 0 && age > f.newerThan {
+		return false
+	}
+
+	return true
+}
+
 type containerLocator interface {
 	Locate() ([]db.Container, map[int]time.Time, error)
 }
@@ -63,6 +171,10 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretMana
 	query := r.URL.Query()
 	delete(query, ":team_name")
 
+	for _, param := range containerFilterParams {
+		delete(query, param)
+	}
+
 	if len(query) == 0 {
 		return &allContainersLocator{
 			team: team,
@@ -165,3 +277,17 @@ func parseIntParam(r *http.Request, name string) (int, error) {
 
 	return val, nil
 }
+
+func parseDurationParam(r *http.Request, name string) (time.Duration, error) {
+	var val time.Duration
+	param := r.URL.Query().Get(name)
+	if len(param) != 0 {
+		var err error
+		val, err = time.ParseDuration(param)
+		if err != nil {
+			return 0, fmt.Errorf("invalid '%s' param (%s): %s", name, param, err)
+		}
+	}
+
+	return val, nil
+}
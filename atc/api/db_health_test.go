@@ -0,0 +1,117 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DB Health API", func() {
+	Describe("GET /api/v1/db-health", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/db-health")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				dbDBHealthRepository.HealthReturns(atc.DBHealth{
+					Tables: []atc.TableHealth{
+						{
+							Name:           "builds",
+							LiveTuples:     30000,
+							DeadTuples:     20000,
+							DeadTupleRatio: 0.4,
+							TableBytes:     1024,
+							IndexBytes:     512,
+							LastAnalyzed:   100,
+							Indexes: []atc.IndexHealth{
+								{
+									Name:        "builds_pkey",
+									Bytes:       512,
+									Scans:       10,
+									Valid:       true,
+									Suggestions: []string{},
+								},
+							},
+							Suggestions: []string{"VACUUM ANALYZE builds"},
+						},
+					},
+				}, nil)
+			})
+
+			It("returns the health of the tables", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"tables": [
+						{
+							"name": "builds",
+							"live_tuples": 30000,
+							"dead_tuples": 20000,
+							"dead_tuple_ratio": 0.4,
+							"table_bytes": 1024,
+							"index_bytes": 512,
+							"last_analyzed": 100,
+							"indexes": [
+								{
+									"name": "builds_pkey",
+									"bytes": 512,
+									"scans": 10,
+									"valid": true,
+									"suggestions": []
+								}
+							],
+							"suggestions": ["VACUUM ANALYZE builds"]
+						}
+					]
+				}`))
+			})
+
+			Context("when getting the health fails", func() {
+				BeforeEach(func() {
+					dbDBHealthRepository.HealthReturns(atc.DBHealth{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
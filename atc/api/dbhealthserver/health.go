@@ -0,0 +1,27 @@
+package dbhealthserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetDBHealth reports how bloated the database's busiest tables and their
+// indexes are, and the maintenance suggested for them.
+func (s *Server) GetDBHealth(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-db-health")
+
+	health, err := s.repository.Health()
+	if err != nil {
+		logger.Error("failed-to-get-db-health", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(health)
+	if err != nil {
+		logger.Error("failed-to-encode-db-health", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,20 @@
+package dbhealthserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	repository db.DBHealthRepository
+}
+
+func NewServer(logger lager.Logger, repository db.DBHealthRepository) *Server {
+	return &Server{
+		logger: logger,
+
+		repository: repository,
+	}
+}
@@ -10,14 +10,18 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/artifactserver"
 	"github.com/concourse/concourse/atc/api/buildserver"
+	"github.com/concourse/concourse/atc/api/cacheserver"
 	"github.com/concourse/concourse/atc/api/ccserver"
 	"github.com/concourse/concourse/atc/api/checkserver"
 	"github.com/concourse/concourse/atc/api/cliserver"
 	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/dbhealthserver"
 	"github.com/concourse/concourse/atc/api/infoserver"
 	"github.com/concourse/concourse/atc/api/jobserver"
+	"github.com/concourse/concourse/atc/api/lockserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
+	"github.com/concourse/concourse/atc/api/migrationserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
@@ -26,10 +30,13 @@ import (
 	"github.com/concourse/concourse/atc/api/workerserver"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/mainredirect"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/skymarshal/token"
 	"github.com/tedsuo/rata"
 )
 
@@ -53,8 +60,15 @@ func NewHandler(
 	dbCheckFactory db.CheckFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	lockRepository db.LockRepository,
+	lockFactory lock.LockFactory,
+	resourceCaches *metric.CacheUsage,
+	migrationVersions migrationserver.Versions,
+	backfillRepository db.BackfillRepository,
+	dbHealthRepository db.DBHealthRepository,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
+	multiplexEventHandlerFactory buildserver.MultiplexEventHandlerFactory,
 
 	workerClient worker.Client,
 
@@ -68,6 +82,10 @@ func NewHandler(
 	secretManager creds.Secrets,
 	credsManagers creds.Managers,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
+	deprecatedResourceTypes atc.DeprecatedResourceTypes,
+	apiTokenGenerator token.Generator,
+	resourceTypeMappings atc.ResourceTypeMappings,
+	configValidator configserver.ConfigValidator,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -79,24 +97,28 @@ func NewHandler(
 	buildHandlerFactory := buildserver.NewScopedHandlerFactory(logger)
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
-	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
+	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, workerClient, eventHandlerFactory, multiplexEventHandlerFactory)
 	checkServer := checkserver.NewServer(logger, dbCheckFactory)
-	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
+	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory, dbWorkerFactory)
 	resourceServer := resourceserver.NewServer(logger, secretManager, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
 
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager, deprecatedResourceTypes, configValidator)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
 	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 	containerServer := containerserver.NewServer(logger, workerClient, secretManager, interceptTimeoutFactory, containerRepository, destroyer)
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL, apiTokenGenerator, resourceTypeMappings)
 	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
 	artifactServer := artifactserver.NewServer(logger, workerClient)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
+	lockServer := lockserver.NewServer(logger, lockRepository, lockFactory)
+	cacheServer := cacheserver.NewServer(logger, resourceCaches)
+	migrationServer := migrationserver.NewServer(logger, migrationVersions, backfillRepository)
+	dbHealthServer := dbhealthserver.NewServer(logger, dbHealthRepository)
 
 	handlers := map[string]http.Handler{
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
@@ -104,28 +126,43 @@ func NewHandler(
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
-		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
-		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
-		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
-		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
-		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
-		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
-		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
-		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
-		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
+		atc.ListBuilds:               http.HandlerFunc(buildServer.ListBuilds),
+		atc.CreateBuild:              teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
+		atc.GetBuild:                 buildHandlerFactory.HandlerFor(buildServer.GetBuild),
+		atc.BuildResources:           buildHandlerFactory.HandlerFor(buildServer.BuildResources),
+		atc.AbortBuild:               buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.PauseBuild:               buildHandlerFactory.HandlerFor(buildServer.PauseBuild),
+		atc.ResumeBuild:              buildHandlerFactory.HandlerFor(buildServer.ResumeBuild),
+		atc.RerunBuild:               buildHandlerFactory.HandlerFor(buildServer.RerunBuild),
+		atc.GetBuildPlan:             buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildArtifactRegistry: buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifactRegistry),
+		atc.GetBuildPreparation:      buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
+		atc.ExplainBuild:             buildHandlerFactory.HandlerFor(buildServer.ExplainBuild),
+		atc.GetBuildSnapshot:         buildHandlerFactory.HandlerFor(buildServer.GetBuildSnapshot),
+		atc.GetBuildTimeline:         buildHandlerFactory.HandlerFor(buildServer.GetBuildTimeline),
+		atc.GetBuildPlanGraph:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlanGraph),
+		atc.BuildEvents:              buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
+		atc.MultiplexBuildEvents:     http.HandlerFunc(buildServer.MultiplexBuildEvents),
+		atc.ListBuildArtifacts:       buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
+		atc.DownloadBuildArtifact:    buildHandlerFactory.HandlerFor(buildServer.DownloadBuildArtifact),
+		atc.AnnotateBuild:            http.HandlerFunc(buildServer.AnnotateBuild),
 
 		atc.GetCheck: http.HandlerFunc(checkServer.GetCheck),
 
-		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.ListAllJobs:       http.HandlerFunc(jobServer.ListAllJobs),
+		atc.ListJobs:          pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:            pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:     pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:     pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.GetJobPlanGraph:   pipelineHandlerFactory.HandlerFor(jobServer.GetJobPlanGraph),
+		atc.GetJobStatistics:  pipelineHandlerFactory.HandlerFor(jobServer.GetJobStatistics),
+		atc.DryRunJobSchedule: pipelineHandlerFactory.HandlerFor(jobServer.DryRunJobSchedule),
+		atc.GetJobBuild:       pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.CreateJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:          pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:        pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.JobBadge:          pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.JobStatus:         pipelineHandlerFactory.HandlerFor(jobServer.JobStatus),
 		atc.MainJobBadge: mainredirect.Handler{
 			Routes: atc.Routes,
 			Route:  atc.JobBadge,
@@ -133,20 +170,25 @@ func NewHandler(
 
 		atc.ClearTaskCache: pipelineHandlerFactory.HandlerFor(jobServer.ClearTaskCache),
 
-		atc.ListAllPipelines:    http.HandlerFunc(pipelineServer.ListAllPipelines),
-		atc.ListPipelines:       http.HandlerFunc(pipelineServer.ListPipelines),
-		atc.GetPipeline:         pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
-		atc.DeletePipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
-		atc.OrderPipelines:      http.HandlerFunc(pipelineServer.OrderPipelines),
-		atc.PausePipeline:       pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
-		atc.UnpausePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
-		atc.ExposePipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
-		atc.HidePipeline:        pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
-		atc.GetVersionsDB:       pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
-		atc.RenamePipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
-		atc.ListPipelineBuilds:  pipelineHandlerFactory.HandlerFor(pipelineServer.ListPipelineBuilds),
-		atc.CreatePipelineBuild: pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
-		atc.PipelineBadge:       pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
+		atc.ListAllPipelines:      http.HandlerFunc(pipelineServer.ListAllPipelines),
+		atc.ListPipelines:         http.HandlerFunc(pipelineServer.ListPipelines),
+		atc.GetPipeline:           pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
+		atc.GetPipelineGraph:      pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipelineGraph),
+		atc.GetPipelineScheduling: pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipelineScheduling),
+		atc.DeletePipeline:        pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
+		atc.OrderPipelines:        http.HandlerFunc(pipelineServer.OrderPipelines),
+		atc.PausePipeline:         pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
+		atc.UnpausePipeline:       pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
+		atc.ExposePipeline:        pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
+		atc.SharePipeline:         pipelineHandlerFactory.HandlerFor(pipelineServer.SharePipeline),
+		atc.UnsharePipeline:       pipelineHandlerFactory.HandlerFor(pipelineServer.UnsharePipeline),
+		atc.HidePipeline:          pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
+		atc.GetVersionsDB:         pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
+		atc.RenamePipeline:        pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
+		atc.ListPipelineBuilds:    pipelineHandlerFactory.HandlerFor(pipelineServer.ListPipelineBuilds),
+		atc.CreatePipelineBuild:   pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
+		atc.PipelineBadge:         pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
+		atc.PipelineStatus:        pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineStatus),
 
 		atc.ListAllResources:        http.HandlerFunc(resourceServer.ListAllResources),
 		atc.ListResources:           pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
@@ -157,15 +199,18 @@ func NewHandler(
 		atc.CheckResource:           pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook:    pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 		atc.CheckResourceType:       pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
+		atc.ListResourceChecks:      pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceChecks),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
 		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
+		atc.UpdateResourceVersionMetadata: pipelineHandlerFactory.HandlerFor(versionServer.UpdateResourceVersionMetadata),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
+		atc.ListResourceVersionSpan:       pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersionSpan),
 
 		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
 		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
@@ -175,6 +220,9 @@ func NewHandler(
 		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
 		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
 
+		atc.ListWorkerDiscrepancies:    http.HandlerFunc(workerServer.ListWorkerDiscrepancies),
+		atc.ResolveWorkerDiscrepancies: http.HandlerFunc(workerServer.ResolveWorkerDiscrepancies),
+
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
 
@@ -184,9 +232,18 @@ func NewHandler(
 
 		atc.ListActiveUsersSince: http.HandlerFunc(usersServer.GetUsersSince),
 
+		atc.ListLocks: http.HandlerFunc(lockServer.ListLocks),
+
+		atc.GetCacheEfficiency: http.HandlerFunc(cacheServer.GetCacheEfficiency),
+
+		atc.GetMigrationStatus: http.HandlerFunc(migrationServer.GetMigrationStatus),
+
+		atc.GetDBHealth: http.HandlerFunc(dbHealthServer.GetDBHealth),
+
 		atc.ListContainers:           teamHandlerFactory.HandlerFor(containerServer.ListContainers),
 		atc.GetContainer:             teamHandlerFactory.HandlerFor(containerServer.GetContainer),
 		atc.HijackContainer:          teamHandlerFactory.HandlerFor(containerServer.HijackContainer),
+		atc.HijackCheckContainer:     teamHandlerFactory.HandlerFor(containerServer.HijackCheckContainer),
 		atc.ListDestroyingContainers: http.HandlerFunc(containerServer.ListDestroyingContainers),
 		atc.ReportWorkerContainers:   http.HandlerFunc(containerServer.ReportWorkerContainers),
 
@@ -199,8 +256,17 @@ func NewHandler(
 		atc.SetTeam:        http.HandlerFunc(teamServer.SetTeam),
 		atc.RenameTeam:     http.HandlerFunc(teamServer.RenameTeam),
 		atc.DestroyTeam:    http.HandlerFunc(teamServer.DestroyTeam),
+		atc.RestoreTeam:    http.HandlerFunc(teamServer.RestoreTeam),
 		atc.ListTeamBuilds: http.HandlerFunc(teamServer.ListTeamBuilds),
 
+		atc.GetPipelinesRepoStatus:  teamHandlerFactory.HandlerFor(teamServer.GetPipelinesRepoStatus),
+		atc.GetResourceTypeMappings: teamHandlerFactory.HandlerFor(teamServer.GetResourceTypeMappings),
+		atc.GetResourceTypeHealth:   teamHandlerFactory.HandlerFor(teamServer.GetResourceTypeHealth),
+
+		atc.ListAPITokens:  teamHandlerFactory.HandlerFor(teamServer.ListAPITokens),
+		atc.CreateAPIToken: teamHandlerFactory.HandlerFor(teamServer.CreateAPIToken),
+		atc.RevokeAPIToken: teamHandlerFactory.HandlerFor(teamServer.RevokeAPIToken),
+
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
 	}
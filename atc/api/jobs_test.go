@@ -1,16 +1,20 @@
 package api_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -843,14 +847,330 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/status", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/status")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				fakePipeline.JobReturns(fakeJob, true, nil)
+			})
+
+			It("returns Content-Type as application/json and disables caching", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(response.Header.Get("Cache-Control")).To(Equal("no-cache, no-store, must-revalidate"))
+				Expect(response.Header.Get("Expires")).To(Equal("0"))
+			})
+
+			Context("when the finished build is failed", func() {
+				BeforeEach(func() {
+					build := new(dbfakes.FakeBuild)
+					build.NameReturns("7")
+					build.JobNameReturns("some-job")
+					build.EndTimeReturns(time.Unix(100, 0))
+					build.StatusReturns(db.BuildStatusFailed)
+
+					fakeJob.FinishedAndNextBuildReturns(build, nil, nil)
+				})
+
+				It("returns the status shown by its badge", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"status": "failing",
+						"job": "some-job",
+						"build": "7",
+						"end_time": 100
+					}`))
+				})
+			})
+
+			Context("when there are no running or finished builds", func() {
+				BeforeEach(func() {
+					fakeJob.FinishedAndNextBuildReturns(nil, nil, nil)
+				})
+
+				It("returns an unknown status", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{"status": "unknown"}`))
+				})
+			})
+
+			Context("when getting the job's builds fails", func() {
+				BeforeEach(func() {
+					fakeJob.FinishedAndNextBuildReturns(nil, nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the job is not present", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/statistics", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/statistics" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				fakePipeline.JobReturns(fakeJob, true, nil)
+
+				fakeJob.IDReturns(1)
+				fakeJob.BuildStatisticsStub = func(from time.Time, to time.Time) (atc.BuildStatistics, error) {
+					if to.Sub(from) == 24*time.Hour && time.Since(to) < time.Minute {
+						return atc.BuildStatistics{
+							Builds:         4,
+							Succeeded:      2,
+							Failed:         1,
+							Aborted:        1,
+							SuccessRate:    2.0 / 3.0,
+							MeanDuration:   15,
+							MedianDuration: 15,
+							P95Duration:    19.5,
+						}, nil
+					}
+
+					return atc.BuildStatistics{
+						Builds:         2,
+						Succeeded:      1,
+						Failed:         1,
+						SuccessRate:    0.5,
+						MeanDuration:   10,
+						MedianDuration: 10,
+						P95Duration:    10,
+					}, nil
+				}
+			})
+
+			It("returns 200 OK with JSON", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("covers the last day, week and 30 days", func() {
+				var stats atc.JobStatistics
+				err := json.NewDecoder(response.Body).Decode(&stats)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stats.Windows).To(HaveLen(3))
+				Expect(stats.Windows[0].Window).To(Equal("24h0m0s"))
+				Expect(stats.Windows[1].Window).To(Equal("168h0m0s"))
+				Expect(stats.Windows[2].Window).To(Equal("720h0m0s"))
+
+				Expect(fakeJob.BuildStatisticsCallCount()).To(Equal(6))
+			})
+
+			It("compares each window to the one before it", func() {
+				var stats atc.JobStatistics
+				err := json.NewDecoder(response.Body).Decode(&stats)
+				Expect(err).NotTo(HaveOccurred())
+
+				day := stats.Windows[0]
+				Expect(day.Current.Builds).To(Equal(4))
+				Expect(day.Previous.Builds).To(Equal(2))
+				Expect(*day.Trend.SuccessRateChange).To(BeNumerically("~", 2.0/3.0-0.5))
+				Expect(*day.Trend.MedianDurationChange).To(BeNumerically("~", 5))
+				Expect(*day.Trend.P95DurationChange).To(BeNumerically("~", 9.5))
+
+				from, to := fakeJob.BuildStatisticsArgsForCall(0)
+				prevFrom, prevTo := fakeJob.BuildStatisticsArgsForCall(1)
+				Expect(prevTo).To(Equal(from))
+				Expect(to.Sub(from)).To(Equal(24 * time.Hour))
+				Expect(prevTo.Sub(prevFrom)).To(Equal(24 * time.Hour))
+			})
+
+			Context("when the previous window has no builds", func() {
+				BeforeEach(func() {
+					fakeJob.BuildStatisticsStub = nil
+					fakeJob.BuildStatisticsReturnsOnCall(0, atc.BuildStatistics{Builds: 1, Succeeded: 1, SuccessRate: 1}, nil)
+					fakeJob.BuildStatisticsReturnsOnCall(1, atc.BuildStatistics{}, nil)
+				})
+
+				It("leaves out the trend", func() {
+					var stats atc.JobStatistics
+					err := json.NewDecoder(response.Body).Decode(&stats)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(stats.Windows[0].Trend).To(Equal(atc.JobStatisticsTrend{}))
+				})
+			})
+
+			Context("when windows are given", func() {
+				BeforeEach(func() {
+					query = "?window=1h&window=48h"
+				})
+
+				It("covers them", func() {
+					var stats atc.JobStatistics
+					err := json.NewDecoder(response.Body).Decode(&stats)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(stats.Windows).To(HaveLen(2))
+					Expect(stats.Windows[0].Window).To(Equal("1h0m0s"))
+					Expect(stats.Windows[1].Window).To(Equal("48h0m0s"))
+				})
+			})
+
+			Context("when a window is invalid", func() {
+				BeforeEach(func() {
+					query = "?window=bogus"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeJob.BuildStatisticsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a window is not positive", func() {
+				BeforeEach(func() {
+					query = "?window=-1h"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when asked for again before any build finishes", func() {
+				It("reuses the statistics", func() {
+					response, err := client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/statistics")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					Expect(fakeJob.BuildStatisticsCallCount()).To(Equal(6))
+				})
+			})
+
+			Context("when asked for again after a build finishes", func() {
+				It("computes them again", func() {
+					build := new(dbfakes.FakeBuild)
+					build.IDReturns(42)
+					fakeJob.FinishedAndNextBuildReturns(build, nil, nil)
+
+					response, err := client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/statistics")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					Expect(fakeJob.BuildStatisticsCallCount()).To(Equal(12))
+				})
+			})
+
+			Context("when getting the statistics fails", func() {
+				BeforeEach(func() {
+					fakeJob.BuildStatisticsStub = nil
+					fakeJob.BuildStatisticsReturns(atc.BuildStatistics{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the job is not present", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", func() {
 		var response *http.Response
 		var dashboardResponse db.Dashboard
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1081,6 +1401,27 @@ var _ = Describe("Jobs API", func() {
 						]`))
 				})
 
+				Context("when filtering by label", func() {
+					BeforeEach(func() {
+						job1.ConfigReturns(atc.JobConfig{
+							Name:   "job-1",
+							Labels: atc.Labels{"tier": "1"},
+						})
+
+						query = "?label=tier:1"
+					})
+
+					It("returns only the jobs with matching labels", func() {
+						var jobs []atc.Job
+						err := json.NewDecoder(response.Body).Decode(&jobs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(jobs).To(HaveLen(1))
+						Expect(jobs[0].Name).To(Equal("job-1"))
+						Expect(jobs[0].Labels).To(Equal(atc.Labels{"tier": "1"}))
+					})
+				})
+
 				Context("when there are no jobs in dashboard", func() {
 					BeforeEach(func() {
 						dashboardResponse = db.Dashboard{}
@@ -1492,47 +1833,486 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
-					Context("when triggering the build succeeds", func() {
+					Context("when the request overrides input versions", func() {
+						var fakeResource *dbfakes.FakeResource
+
 						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+								"input_overrides": {"some-input": {"ref": "v1"}}
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							fakeResource = new(dbfakes.FakeResource)
+							fakeResource.NameReturns("some-input")
+							fakeResource.ResourceConfigVersionIDReturns(7, true, nil)
+							fakePipeline.ResourcesReturns([]db.Resource{fakeResource}, nil)
+
 							build := new(dbfakes.FakeBuild)
 							build.IDReturns(42)
 							build.NameReturns("1")
 							build.JobNameReturns("some-job")
 							build.PipelineNameReturns("a-pipeline")
 							build.TeamNameReturns("some-team")
-							build.StatusReturns(db.BuildStatusStarted)
-							build.StartTimeReturns(time.Unix(1, 0))
-							build.EndTimeReturns(time.Unix(100, 0))
+							build.StatusReturns(db.BuildStatusPending)
+							build.InputOverridesReturns(atc.InputVersionOverrides{"some-input": atc.Version{"ref": "v1"}})
+							fakeJob.CreateBuildWithOverridesReturns(build, nil)
+						})
 
-							fakeJob.CreateBuildReturns(build, nil)
+						It("looks up the overridden version", func() {
+							Expect(fakeResource.ResourceConfigVersionIDCallCount()).To(Equal(1))
+							Expect(fakeResource.ResourceConfigVersionIDArgsForCall(0)).To(Equal(atc.Version{"ref": "v1"}))
 						})
 
-						It("triggers the build", func() {
-							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
+						It("triggers the build with the overrides", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(BeZero())
+							Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+							inputOverrides, imageOverrides, _, _ := fakeJob.CreateBuildWithOverridesArgsForCall(0)
+							Expect(inputOverrides).To(Equal(atc.InputVersionOverrides{
+								"some-input": atc.Version{"ref": "v1"},
+							}))
+							Expect(imageOverrides).To(BeNil())
 						})
 
-						Context("when finding the pipeline resources fails", func() {
+						It("does not check the overridden inputs", func() {
+							Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+						})
+
+						It("returns the build with its overrides", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"input_overrides": {"some-input": {"ref": "v1"}}
+							}`))
+						})
+
+						Context("when the version does not exist", func() {
 							BeforeEach(func() {
-								fakePipeline.ResourcesReturns(nil, errors.New("nope"))
+								fakeResource.ResourceConfigVersionIDReturns(0, false, nil)
 							})
 
-							It("returns a 500", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							It("returns 400 without triggering the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(Equal("version of input 'some-input' not found"))
+
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(BeZero())
 							})
 						})
 
-						Context("when finding the pipeline resources succeeds", func() {
-							var fakeResource *dbfakes.FakeResource
-
+						Context("when looking up the version fails", func() {
 							BeforeEach(func() {
-								fakeResource = new(dbfakes.FakeResource)
-								fakeResource.NameReturns("some-input")
-								fakeResource.CurrentPinnedVersionReturns(atc.Version{"some": "version"})
-
-								fakePipeline.ResourcesReturns([]db.Resource{fakeResource}, nil)
+								fakeResource.ResourceConfigVersionIDReturns(0, false, errors.New("nope"))
 							})
 
-							Context("when finding the pipeline resource types fails", func() {
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+
+						Context("when the job has no such input", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+									"input_overrides": {"bogus": {"ref": "v1"}}
+								}`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 400 without triggering the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(Equal("job has no input named 'bogus'"))
+
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the body is malformed", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+						})
+					})
+
+					Context("when the request overrides task images", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name: "some-job",
+								Plan: atc.PlanSequence{
+									{Get: "some-input"},
+									{Task: "some-task", TaskConfigPath: "some-input/task.yml"},
+									{Task: "artifact-task", TaskConfigPath: "some-input/task.yml", ImageArtifactName: "some-input"},
+								},
+							})
+
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+								"image_overrides": {"some-task": {"version": {"digest": "sha256:fixed"}}}
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							build.ImageOverridesReturns(atc.TaskImageOverrides{
+								"some-task": {Version: atc.Version{"digest": "sha256:fixed"}},
+							})
+							fakeJob.CreateBuildWithOverridesReturns(build, nil)
+						})
+
+						It("triggers the build with the overrides", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(BeZero())
+							Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+
+							inputOverrides, imageOverrides, _, _ := fakeJob.CreateBuildWithOverridesArgsForCall(0)
+							Expect(inputOverrides).To(BeNil())
+							Expect(imageOverrides).To(Equal(atc.TaskImageOverrides{
+								"some-task": {Version: atc.Version{"digest": "sha256:fixed"}},
+							}))
+						})
+
+						It("returns the build with its overrides", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"image_overrides": {"some-task": {"version": {"digest": "sha256:fixed"}}}
+							}`))
+						})
+
+						Context("when the overrides are invalid", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+									"image_overrides": {
+										"bogus": {"version": {"digest": "sha256:fixed"}},
+										"some-task": {},
+										"artifact-task": {"source": {"repository": "some/image"}}
+									}
+								}`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 400 without triggering the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(Equal(strings.Join([]string{
+									"task 'artifact-task' uses an image artifact, which cannot be overridden",
+									"job has no task named 'bogus'",
+									"image override of task 'some-task' must set a version or source",
+								}, "\n")))
+
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					Context("when the request gives artifacts for inputs", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name: "some-job",
+								Plan: atc.PlanSequence{
+									{Get: "some-input"},
+									{Get: "other-input"},
+								},
+							})
+
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+								"input_artifacts": {"some-input": 17}
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							someResource := new(dbfakes.FakeResource)
+							someResource.NameReturns("some-input")
+							otherResource := new(dbfakes.FakeResource)
+							otherResource.NameReturns("other-input")
+							fakePipeline.ResourcesReturns([]db.Resource{someResource, otherResource}, nil)
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							build.InputArtifactsReturns(atc.InputArtifacts{"some-input": 17})
+							fakeJob.CreateBuildWithOverridesReturns(build, nil)
+						})
+
+						It("triggers the build with the artifacts", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(BeZero())
+							Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+
+							inputOverrides, imageOverrides, inputArtifacts, _ := fakeJob.CreateBuildWithOverridesArgsForCall(0)
+							Expect(inputOverrides).To(BeNil())
+							Expect(imageOverrides).To(BeNil())
+							Expect(inputArtifacts).To(Equal(atc.InputArtifacts{"some-input": 17}))
+						})
+
+						It("only checks the inputs which are fetched", func() {
+							Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+
+							resource, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+							Expect(resource.Name()).To(Equal("other-input"))
+						})
+
+						It("returns the build with its artifacts", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"input_artifacts": {"some-input": 17}
+							}`))
+						})
+
+						Context("when the artifacts are invalid", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+									"input_overrides": {"other-input": {"ref": "v1"}},
+									"input_artifacts": {"bogus": 17, "other-input": 18, "some-input": 0}
+								}`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 400 without triggering the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(ContainSubstring(strings.Join([]string{
+									"job has no input named 'bogus'",
+									"input 'other-input' cannot have both a version override and an artifact",
+									"artifact of input 'some-input' must be set",
+								}, "\n")))
+
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					Context("when the request pins steps to workers", func() {
+						BeforeEach(func() {
+							fakePipeline.TeamIDReturns(734)
+
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name: "some-job",
+								Plan: atc.PlanSequence{
+									{Get: "some-input"},
+									{Task: "some-task"},
+									{Put: "some-output"},
+								},
+							})
+
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+								"worker_overrides": {"some-task": "some-worker"}
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							someWorker := new(dbfakes.FakeWorker)
+							someWorker.TeamIDReturns(734)
+
+							globalWorker := new(dbfakes.FakeWorker)
+
+							otherTeamWorker := new(dbfakes.FakeWorker)
+							otherTeamWorker.TeamIDReturns(735)
+
+							dbWorkerFactory.GetWorkerStub = func(name string) (db.Worker, bool, error) {
+								switch name {
+								case "some-worker":
+									return someWorker, true, nil
+								case "global-worker":
+									return globalWorker, true, nil
+								case "other-team-worker":
+									return otherTeamWorker, true, nil
+								default:
+									return nil, false, nil
+								}
+							}
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							build.WorkerOverridesReturns(atc.StepWorkerOverrides{"some-task": "some-worker"})
+							fakeJob.CreateBuildWithOverridesReturns(build, nil)
+						})
+
+						It("triggers the build with the steps pinned", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(BeZero())
+							Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+
+							_, _, _, workerOverrides := fakeJob.CreateBuildWithOverridesArgsForCall(0)
+							Expect(workerOverrides).To(Equal(atc.StepWorkerOverrides{"some-task": "some-worker"}))
+						})
+
+						It("returns the build with its worker overrides", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"worker_overrides": {"some-task": "some-worker"}
+							}`))
+						})
+
+						Context("when a step is pinned to a worker shared by all teams", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+									"worker_overrides": {"some-input": "global-worker"}
+								}`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("triggers the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when the worker overrides are invalid", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+									"worker_overrides": {
+										"bogus": "some-worker",
+										"some-input": "",
+										"some-output": "other-team-worker",
+										"some-task": "missing-worker"
+									}
+								}`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 400 without triggering the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(Equal(strings.Join([]string{
+									"job has no step named 'bogus'",
+									"worker of step 'some-input' must not be empty",
+									"worker 'other-team-worker' of step 'some-output' not found",
+									"worker 'missing-worker' of step 'some-task' not found",
+								}, "\n")))
+
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when looking up a worker fails", func() {
+							BeforeEach(func() {
+								dbWorkerFactory.GetWorkerStub = nil
+								dbWorkerFactory.GetWorkerReturns(nil, false, errors.New("nope"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					Context("when triggering the build succeeds", func() {
+						BeforeEach(func() {
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusStarted)
+							build.StartTimeReturns(time.Unix(1, 0))
+							build.EndTimeReturns(time.Unix(100, 0))
+
+							fakeJob.CreateBuildReturns(build, nil)
+						})
+
+						It("triggers the build", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
+						})
+
+						Context("when finding the pipeline resources fails", func() {
+							BeforeEach(func() {
+								fakePipeline.ResourcesReturns(nil, errors.New("nope"))
+							})
+
+							It("returns a 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+
+						Context("when finding the pipeline resources succeeds", func() {
+							var fakeResource *dbfakes.FakeResource
+
+							BeforeEach(func() {
+								fakeResource = new(dbfakes.FakeResource)
+								fakeResource.NameReturns("some-input")
+								fakeResource.CurrentPinnedVersionReturns(atc.Version{"some": "version"})
+
+								fakePipeline.ResourcesReturns([]db.Resource{fakeResource}, nil)
+							})
+
+							Context("when finding the pipeline resource types fails", func() {
 								BeforeEach(func() {
 									fakePipeline.ResourceTypesReturns(nil, errors.New("nope"))
 								})
@@ -1785,6 +2565,293 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule/dry-run", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/schedule/dry-run", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the job is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the job is found", func() {
+					var versionsDB *algorithm.VersionsDB
+
+					BeforeEach(func() {
+						fakeJob.NameReturns("some-job")
+						fakeJob.ConfigReturns(atc.JobConfig{
+							Name: "some-job",
+							Plan: atc.PlanSequence{
+								{
+									Get:      "some-input",
+									Resource: "some-resource",
+									Params:   atc.Params{"some": "params"},
+								},
+								{
+									Get:      "some-other-input",
+									Resource: "some-other-resource",
+								},
+							},
+						})
+
+						fakePipeline.JobReturns(fakeJob, true, nil)
+
+						resource1 := new(dbfakes.FakeResource)
+						resource1.NameReturns("some-resource")
+						resource1.TypeReturns("some-type")
+						resource1.SourceReturns(atc.Source{"some": "source"})
+
+						resource2 := new(dbfakes.FakeResource)
+						resource2.NameReturns("some-other-resource")
+						resource2.TypeReturns("some-other-type")
+						resource2.SourceReturns(atc.Source{"some": "other-source"})
+
+						fakePipeline.ResourcesReturns([]db.Resource{resource1, resource2}, nil)
+
+						versionsDB = &algorithm.VersionsDB{
+							JobIDs:      map[string]int{"some-job": 1},
+							ResourceIDs: map[string]int{"some-resource": 11, "some-other-resource": 12},
+							ResourceVersions: []algorithm.ResourceVersion{
+								{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+								{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+							},
+						}
+
+						fakePipeline.LoadVersionsDBReturns(versionsDB, nil)
+
+						fakePipeline.ResourceVersionStub = func(id int) (atc.ResourceVersion, bool, error) {
+							return atc.ResourceVersion{ID: id, Version: atc.Version{"id": strconv.Itoa(id)}}, true, nil
+						}
+					})
+
+					Context("when loading the versions db fails", func() {
+						BeforeEach(func() {
+							fakePipeline.LoadVersionsDBReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when every input resolves", func() {
+						It("returns the versions a build would use", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"resolved": true,
+								"inputs": [
+									{
+										"name": "some-input",
+										"resource": "some-resource",
+										"type": "some-type",
+										"source": {"some": "source"},
+										"version": {"id": "1"},
+										"params": {"some": "params"}
+									},
+									{
+										"name": "some-other-input",
+										"resource": "some-other-resource",
+										"type": "some-other-type",
+										"source": {"some": "other-source"},
+										"version": {"id": "2"}
+									}
+								]
+							}`))
+						})
+
+						It("does not save the input mapping", func() {
+							Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+							Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+							Expect(fakeJob.DeleteNextInputMappingCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when an input has no versions", func() {
+						BeforeEach(func() {
+							versionsDB.ResourceVersions = versionsDB.ResourceVersions[:1]
+						})
+
+						It("returns the unsatisfied inputs", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"resolved": false,
+								"unsatisfied_inputs": ["some-other-input"],
+								"reason": "no versions satisfy inputs: some-other-input",
+								"error_code": "input_resolution_failed"
+							}`))
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/plan/graph", func() {
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/plan/graph" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the job is found", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{Get: "some-input", Resource: "some-resource"},
+							{Task: "some-task"},
+						},
+					})
+
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					resource := new(dbfakes.FakeResource)
+					resource.NameReturns("some-resource")
+					resource.TypeReturns("git")
+
+					fakePipeline.ResourcesReturns(db.Resources{resource}, nil)
+				})
+
+				It("returns the plan a build would run as a graph", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					var graph atc.PlanGraph
+					err := json.NewDecoder(response.Body).Decode(&graph)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(graph.Nodes).To(HaveLen(3))
+					Expect(graph.Nodes[0].Type).To(Equal("do"))
+					Expect(graph.Nodes[1].Type).To(Equal("get"))
+					Expect(graph.Nodes[1].Name).To(Equal("some-input"))
+					Expect(graph.Nodes[2].Type).To(Equal("task"))
+					Expect(graph.Nodes[2].Name).To(Equal("some-task"))
+
+					Expect(graph.Edges).To(ContainElement(atc.PlanGraphEdge{
+						From: graph.Nodes[1].ID,
+						To:   graph.Nodes[2].ID,
+						Type: atc.PlanGraphEdgeNext,
+					}))
+				})
+
+				Context("when asked for DOT", func() {
+					BeforeEach(func() {
+						query = "?format=dot"
+					})
+
+					It("returns the graph in DOT", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(HavePrefix("digraph plan {"))
+						Expect(string(body)).To(ContainSubstring(`label="task: some-task"`))
+					})
+				})
+
+				Context("when the job uses a resource which doesn't exist", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourcesReturns(db.Resources{}, nil)
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
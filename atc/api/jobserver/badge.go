@@ -23,6 +23,10 @@ type Badge struct {
 	status    string
 }
 
+func (b *Badge) Status() string {
+	return b.status
+}
+
 func (b *Badge) statusWidth() int {
 	return b.width - 37
 }
@@ -2,8 +2,14 @@ package jobserver
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
+	"strings"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -32,10 +38,11 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, err := job.CreateBuild()
-		if err != nil {
-			logger.Error("failed-to-create-job-build", err)
-			w.WriteHeader(http.StatusInternalServerError)
+		var request atc.CreateJobBuildRequest
+		err = json.NewDecoder(r.Body).Decode(&request)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
@@ -46,6 +53,45 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		problems, err := validateInputOverrides(job.Config(), resources, request.InputOverrides)
+		if err != nil {
+			logger.Error("failed-to-validate-input-overrides", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		problems = append(problems, validateImageOverrides(job.Config(), request.ImageOverrides)...)
+		problems = append(problems, validateInputArtifacts(job.Config(), request)...)
+
+		workerProblems, err := s.validateWorkerOverrides(job.Config(), pipeline.TeamID(), request.WorkerOverrides)
+		if err != nil {
+			logger.Error("failed-to-validate-worker-overrides", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		problems = append(problems, workerProblems...)
+
+		if len(problems) > 0 {
+			logger.Info("invalid-overrides", lager.Data{"problems": problems})
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(strings.Join(problems, "\n")))
+			return
+		}
+
+		var build db.Build
+		if len(request.InputOverrides) > 0 || len(request.ImageOverrides) > 0 || len(request.InputArtifacts) > 0 || len(request.WorkerOverrides) > 0 {
+			build, err = job.CreateBuildWithOverrides(request.InputOverrides, request.ImageOverrides, request.InputArtifacts, request.WorkerOverrides)
+		} else {
+			build, err = job.CreateBuild()
+		}
+
+		if err != nil {
+			logger.Error("failed-to-create-job-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		resourceTypes, err := pipeline.ResourceTypes()
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
@@ -54,6 +100,14 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 		}
 
 		for _, input := range job.Config().Inputs() {
+			if _, overridden := request.InputOverrides[input.Name]; overridden {
+				continue
+			}
+
+			if _, uploaded := request.InputArtifacts[input.Name]; uploaded {
+				continue
+			}
+
 			resource, found := resources.Lookup(input.Resource)
 			if found {
 				version := resource.CurrentPinnedVersion()
@@ -76,3 +130,174 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+// validateInputOverrides returns a problem for each override which does not
+// name one of the job's inputs or a version of the input's resource.
+func validateInputOverrides(config atc.JobConfig, resources db.Resources, overrides atc.InputVersionOverrides) ([]string, error) {
+	inputs := map[string]atc.JobInput{}
+	for _, input := range config.Inputs() {
+		inputs[input.Name] = input
+	}
+
+	names := []string{}
+	for name := range overrides {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	problems := []string{}
+	for _, name := range names {
+		input, found := inputs[name]
+		if !found {
+			problems = append(problems, fmt.Sprintf("job has no input named '%s'", name))
+			continue
+		}
+
+		if len(overrides[name]) == 0 {
+			problems = append(problems, fmt.Sprintf("version of input '%s' must not be empty", name))
+			continue
+		}
+
+		resource, found := resources.Lookup(input.Resource)
+		if !found {
+			problems = append(problems, fmt.Sprintf("input '%s' has no resource", name))
+			continue
+		}
+
+		_, found, err := resource.ResourceConfigVersionID(overrides[name])
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			problems = append(problems, fmt.Sprintf("version of input '%s' not found", name))
+		}
+	}
+
+	return problems, nil
+}
+
+// validateImageOverrides returns a problem for each override which does not
+// name one of the job's tasks, overrides nothing, or names a task whose
+// image is an artifact rather than an image_resource.
+func validateImageOverrides(config atc.JobConfig, overrides atc.TaskImageOverrides) []string {
+	tasks := map[string]atc.PlanConfig{}
+	for _, plan := range config.Plans() {
+		if plan.Task != "" {
+			tasks[plan.Task] = plan
+		}
+	}
+
+	names := []string{}
+	for name := range overrides {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	problems := []string{}
+	for _, name := range names {
+		task, found := tasks[name]
+		if !found {
+			problems = append(problems, fmt.Sprintf("job has no task named '%s'", name))
+			continue
+		}
+
+		if len(overrides[name].Version) == 0 && len(overrides[name].Source) == 0 {
+			problems = append(problems, fmt.Sprintf("image override of task '%s' must set a version or source", name))
+			continue
+		}
+
+		if task.ImageArtifactName != "" {
+			problems = append(problems, fmt.Sprintf("task '%s' uses an image artifact, which cannot be overridden", name))
+		}
+	}
+
+	return problems
+}
+
+// validateInputArtifacts returns a problem for each uploaded artifact which
+// does not name one of the job's inputs, or names an input whose version is
+// also overridden.
+func validateInputArtifacts(config atc.JobConfig, request atc.CreateJobBuildRequest) []string {
+	inputs := map[string]bool{}
+	for _, input := range config.Inputs() {
+		inputs[input.Name] = true
+	}
+
+	names := []string{}
+	for name := range request.InputArtifacts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	problems := []string{}
+	for _, name := range names {
+		if !inputs[name] {
+			problems = append(problems, fmt.Sprintf("job has no input named '%s'", name))
+			continue
+		}
+
+		if request.InputArtifacts[name] <= 0 {
+			problems = append(problems, fmt.Sprintf("artifact of input '%s' must be set", name))
+			continue
+		}
+
+		if _, overridden := request.InputOverrides[name]; overridden {
+			problems = append(problems, fmt.Sprintf("input '%s' cannot have both a version override and an artifact", name))
+		}
+	}
+
+	return problems
+}
+
+// validateWorkerOverrides returns a problem for each override which does not
+// name one of the job's get, put, or task steps, or names a worker which
+// doesn't exist or belongs to another team.
+func (s *Server) validateWorkerOverrides(config atc.JobConfig, teamID int, overrides atc.StepWorkerOverrides) ([]string, error) {
+	steps := map[string]bool{}
+	for _, plan := range config.Plans() {
+		switch {
+		case plan.Get != "", plan.Put != "":
+			steps[plan.Name()] = true
+		case plan.Task != "":
+			steps[plan.Task] = true
+		}
+	}
+
+	names := []string{}
+	for name := range overrides {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	problems := []string{}
+	for _, name := range names {
+		if !steps[name] {
+			problems = append(problems, fmt.Sprintf("job has no step named '%s'", name))
+			continue
+		}
+
+		workerName := overrides[name]
+		if workerName == "" {
+			problems = append(problems, fmt.Sprintf("worker of step '%s' must not be empty", name))
+			continue
+		}
+
+		worker, found, err := s.workerFactory.GetWorker(workerName)
+		if err != nil {
+			return nil, err
+		}
+
+		// workers of other teams are reported as not found so as to not leak
+		// their names
+		if !found || (worker.TeamID() != 0 && worker.TeamID() != teamID) {
+			problems = append(problems, fmt.Sprintf("worker '%s' of step '%s' not found", workerName, name))
+		}
+	}
+
+	return problems, nil
+}
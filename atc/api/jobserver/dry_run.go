@@ -0,0 +1,131 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputconfig"
+)
+
+// DryRunJobSchedule runs the same input determination as the scheduler and
+// reports the versions a new build would use, without saving the result or
+// creating a build.
+func (s *Server) DryRunJobSchedule(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("dry-run-job-schedule")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		versions, err := pipeline.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		mapper := inputmapper.NewInputMapper(pipeline, inputconfig.NewTransformer(pipeline), 0)
+
+		resolution, err := mapper.ResolveNextInputMapping(logger, versions, job, resources)
+		if err != nil {
+			logger.Error("failed-to-resolve-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		dryRun := atc.ScheduleDryRun{}
+
+		switch {
+		case len(resolution.Unsatisfied) > 0:
+			dryRun.UnsatisfiedInputs = resolution.Unsatisfied
+			dryRun.Reason = "no versions satisfy inputs: " + strings.Join(resolution.Unsatisfied, ", ")
+			dryRun.ErrorCode = atc.ErrorCodeInputResolutionFailed
+
+		case resolution.Mapping == nil:
+			dryRun.Reason = "no combination of versions satisfies every input's passed constraints"
+			dryRun.ErrorCode = atc.ErrorCodeInputResolutionFailed
+
+		default:
+			dryRun.Resolved = true
+
+			inputs, err := s.presentDryRunInputs(logger, pipeline, job, resources, resolution.Mapping)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			dryRun.Inputs = inputs
+		}
+
+		err = json.NewEncoder(w).Encode(dryRun)
+		if err != nil {
+			logger.Error("failed-to-encode-dry-run", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) presentDryRunInputs(
+	logger lager.Logger,
+	pipeline db.Pipeline,
+	job db.Job,
+	resources db.Resources,
+	mapping algorithm.InputMapping,
+) ([]atc.BuildInput, error) {
+	jobInputs := job.Config().Inputs()
+
+	inputs := []atc.BuildInput{}
+	for _, config := range jobInputs {
+		inputVersion, found := mapping[config.Name]
+		if !found {
+			continue
+		}
+
+		version, found, err := pipeline.ResourceVersion(inputVersion.VersionID)
+		if err != nil {
+			logger.Error("failed-to-get-resource-version", err, lager.Data{"input": config.Name})
+			return nil, err
+		}
+
+		if !found {
+			err := fmt.Errorf("resource version %d not found", inputVersion.VersionID)
+			logger.Error("failed-to-find-resource-version", err, lager.Data{"input": config.Name})
+			return nil, err
+		}
+
+		resource, _ := resources.Lookup(config.Resource)
+
+		inputs = append(inputs, present.BuildInput(db.BuildInput{
+			Name:    config.Name,
+			Version: version.Version,
+		}, config, resource))
+	}
+
+	return inputs, nil
+}
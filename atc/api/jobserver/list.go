@@ -2,6 +2,7 @@ package jobserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
@@ -13,6 +14,13 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("list-jobs")
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+
 		jobs := []atc.Job{}
 
 		dashboard, err := pipeline.Dashboard()
@@ -26,6 +34,10 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 		teamName := r.FormValue(":team_name")
 
 		for _, job := range dashboard {
+			if !selector.Matches(job.Job.Config().Labels) {
+				continue
+			}
+
 			jobs = append(
 				jobs,
 				present.Job(
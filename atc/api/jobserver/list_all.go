@@ -2,6 +2,7 @@ package jobserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
@@ -13,10 +14,16 @@ import (
 func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("list-all-jobs")
 
+	selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, err.Error())
+		return
+	}
+
 	acc := accessor.GetAccessor(r)
 
 	var dashboard db.Dashboard
-	var err error
 
 	if acc.IsAdmin() {
 		dashboard, err = s.jobFactory.AllActiveJobs()
@@ -33,6 +40,10 @@ func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 	jobs := []atc.Job{}
 
 	for _, job := range dashboard {
+		if !selector.Matches(job.Job.Config().Labels) {
+			continue
+		}
+
 		jobs = append(
 			jobs,
 			present.Job(
@@ -0,0 +1,69 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+)
+
+// GetJobPlanGraph returns the plan a new build of the job would run, laid out
+// as a graph, as JSON or, given format=dot, in Graphviz's DOT language. Its
+// step ids are only meaningful within the graph, as they'll differ from the
+// ids of any build's plan.
+func (s *Server) GetJobPlanGraph(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-job-plan-graph")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		logger := logger.WithData(lager.Data{"job": jobName})
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		buildFactory := factory.NewBuildFactory(atc.NewPlanFactory(0))
+
+		plan, err := buildFactory.Create(job.Config(), resources.Configs(), atc.VersionedResourceTypes{}, nil)
+		if err != nil {
+			logger.Error("failed-to-create-plan", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		graph := atc.NewPlanGraph(plan)
+
+		if r.URL.Query().Get("format") == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			_, err = io.WriteString(w, graph.DOT())
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			err = json.NewEncoder(w).Encode(graph)
+		}
+
+		if err != nil {
+			logger.Error("failed-to-write-plan-graph", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
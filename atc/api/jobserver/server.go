@@ -15,6 +15,9 @@ type Server struct {
 	secretManager creds.Secrets
 	jobFactory    db.JobFactory
 	checkFactory  db.CheckFactory
+	workerFactory db.WorkerFactory
+
+	statisticsCache *statisticsCache
 }
 
 func NewServer(
@@ -23,6 +26,7 @@ func NewServer(
 	secretManager creds.Secrets,
 	jobFactory db.JobFactory,
 	checkFactory db.CheckFactory,
+	workerFactory db.WorkerFactory,
 ) *Server {
 	return &Server{
 		logger:        logger,
@@ -31,5 +35,8 @@ func NewServer(
 		secretManager: secretManager,
 		jobFactory:    jobFactory,
 		checkFactory:  checkFactory,
+		workerFactory: workerFactory,
+
+		statisticsCache: newStatisticsCache(),
 	}
 }
@@ -0,0 +1,222 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// DefaultStatisticsWindows are the windows a job's statistics cover when none
+// are asked for: the last day, week and 30 days.
+var DefaultStatisticsWindows = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+const (
+	maxStatisticsWindows = 5
+	maxStatisticsWindow  = 366 * 24 * time.Hour
+)
+
+// statisticsCacheTTL is how long a job's statistics are reused for, as long
+// as none of its builds finish in the meantime. It bounds how far behind the
+// windows, which end when the statistics are computed, can get.
+const statisticsCacheTTL = time.Minute
+
+// GetJobStatistics summarizes the job's builds over the windows given by
+// each window param, e.g. window=24h&window=168h.
+func (s *Server) GetJobStatistics(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-job-statistics")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		logger := logger.WithData(lager.Data{"job": jobName})
+
+		windows, err := parseStatisticsWindows(r.URL.Query()["window"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		finished, _, err := job.FinishedAndNextBuild()
+		if err != nil {
+			logger.Error("failed-to-get-finished-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		finishedID := 0
+		if finished != nil {
+			finishedID = finished.ID()
+		}
+
+		key := statisticsCacheKey{jobID: job.ID(), windows: fmt.Sprint(windows)}
+
+		stats, found := s.statisticsCache.get(key, finishedID)
+		if !found {
+			stats, err = jobStatistics(job, windows, time.Now())
+			if err != nil {
+				logger.Error("failed-to-get-statistics", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			s.statisticsCache.set(key, finishedID, stats)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(stats)
+		if err != nil {
+			logger.Error("failed-to-encode-statistics", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func parseStatisticsWindows(params []string) ([]time.Duration, error) {
+	if len(params) == 0 {
+		return DefaultStatisticsWindows, nil
+	}
+
+	if len(params) > maxStatisticsWindows {
+		return nil, fmt.Errorf("at most %d windows may be given", maxStatisticsWindows)
+	}
+
+	windows := []time.Duration{}
+	for _, param := range params {
+		window, err := time.ParseDuration(strings.TrimSpace(param))
+		if err != nil {
+			return nil, fmt.Errorf("invalid window '%s': %s", param, err)
+		}
+
+		if window <= 0 || window > maxStatisticsWindow {
+			return nil, fmt.Errorf("window '%s' must be positive and at most %s", param, maxStatisticsWindow)
+		}
+
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+func jobStatistics(job db.Job, windows []time.Duration, now time.Time) (atc.JobStatistics, error) {
+	stats := atc.JobStatistics{
+		Windows: []atc.JobStatisticsWindow{},
+	}
+
+	for _, window := range windows {
+		current, err := job.BuildStatistics(now.Add(-window), now)
+		if err != nil {
+			return atc.JobStatistics{}, err
+		}
+
+		previous, err := job.BuildStatistics(now.Add(-2*window), now.Add(-window))
+		if err != nil {
+			return atc.JobStatistics{}, err
+		}
+
+		stats.Windows = append(stats.Windows, atc.JobStatisticsWindow{
+			Window:   window.String(),
+			Current:  current,
+			Previous: previous,
+			Trend:    statisticsTrend(current, previous),
+		})
+	}
+
+	return stats, nil
+}
+
+func statisticsTrend(current atc.BuildStatistics, previous atc.BuildStatistics) atc.JobStatisticsTrend {
+	var trend atc.JobStatisticsTrend
+
+	if current.Builds > current.Aborted && previous.Builds > previous.Aborted {
+		change := current.SuccessRate - previous.SuccessRate
+		trend.SuccessRateChange = &change
+	}
+
+	if current.Succeeded > 0 && previous.Succeeded > 0 {
+		median := current.MedianDuration - previous.MedianDuration
+		trend.MedianDurationChange = &median
+
+		p95 := current.P95Duration - previous.P95Duration
+		trend.P95DurationChange = &p95
+	}
+
+	return trend
+}
+
+type statisticsCacheKey struct {
+	jobID   int
+	windows string
+}
+
+type statisticsCacheEntry struct {
+	finishedBuildID int
+	expiresAt       time.Time
+	statistics      atc.JobStatistics
+}
+
+// statisticsCache holds on to jobs' statistics until they expire or another
+// of the job's builds finishes.
+type statisticsCache struct {
+	lock    sync.Mutex
+	entries map[statisticsCacheKey]statisticsCacheEntry
+}
+
+func newStatisticsCache() *statisticsCache {
+	return &statisticsCache{
+		entries: map[statisticsCacheKey]statisticsCacheEntry{},
+	}
+}
+
+func (cache *statisticsCache) get(key statisticsCacheKey, finishedBuildID int) (atc.JobStatistics, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	entry, found := cache.entries[key]
+	if !found || entry.finishedBuildID != finishedBuildID || time.Now().After(entry.expiresAt) {
+		return atc.JobStatistics{}, false
+	}
+
+	return entry.statistics, true
+}
+
+func (cache *statisticsCache) set(key statisticsCacheKey, finishedBuildID int, statistics atc.JobStatistics) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	now := time.Now()
+
+	// drop expired entries so that jobs which are no longer looked at don't
+	// stay cached
+	for k, entry := range cache.entries {
+		if now.After(entry.expiresAt) {
+			delete(cache.entries, k)
+		}
+	}
+
+	cache.entries[key] = statisticsCacheEntry{
+		finishedBuildID: finishedBuildID,
+		expiresAt:       now.Add(statisticsCacheTTL),
+		statistics:      statistics,
+	}
+}
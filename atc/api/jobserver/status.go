@@ -0,0 +1,64 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func StatusForBuild(build db.Build) atc.EmbeddedStatus {
+	status := atc.EmbeddedStatus{
+		Status: BadgeForBuild(build).Status(),
+	}
+
+	if build != nil {
+		status.Job = build.JobName()
+		status.Build = build.Name()
+
+		if !build.EndTime().IsZero() {
+			status.EndTime = build.EndTime().Unix()
+		}
+	}
+
+	return status
+}
+
+func (s *Server) JobStatus(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("job-status")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("error-finding-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		build, _, err := job.FinishedAndNextBuild()
+		if err != nil {
+			logger.Error("could-not-get-job-finished-and-next-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		w.Header().Set("Expires", "0")
+
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(StatusForBuild(build))
+		if err != nil {
+			logger.Error("failed-to-encode-status", err)
+		}
+	})
+}
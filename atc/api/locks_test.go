@@ -0,0 +1,127 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Locks API", func() {
+	Describe("GET /api/v1/locks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/locks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				connectedAt := time.Unix(1500000000, 0)
+
+				dbLockRepository.AdvisoryLocksReturns([]db.AdvisoryLock{
+					{
+						ID:              lock.NewPipelineSchedulingLockLockID(42),
+						Granted:         true,
+						PID:             100,
+						ApplicationName: "some-app",
+						ClientAddr:      "10.0.0.1",
+						BackendStart:    connectedAt,
+					},
+					{
+						ID:         lock.NewPipelineSchedulingLockLockID(42),
+						Granted:    false,
+						PID:        101,
+						ClientAddr: "10.0.0.2",
+					},
+					{
+						ID:      lock.NewBuildTrackingLockID(7),
+						Granted: true,
+						PID:     102,
+					},
+					{
+						ID:      lock.LockID{12345, 6789},
+						Granted: true,
+						PID:     103,
+					},
+				}, nil)
+
+				fakeLockFactory.HeldReturns([]lock.HeldLock{
+					{
+						ID:         lock.NewBuildTrackingLockID(7),
+						AcquiredAt: time.Unix(1600000000, 0),
+					},
+				})
+			})
+
+			It("returns each lock with its holder and waiters", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"type": "PipelineScheduling",
+						"id": [2, 42],
+						"holder": {"pid": 100, "application_name": "some-app", "client_addr": "10.0.0.1", "connected_at": 1500000000},
+						"waiting": [{"pid": 101, "client_addr": "10.0.0.2"}]
+					},
+					{
+						"type": "BuildTracking",
+						"id": [1, 7],
+						"holder": {"pid": 102},
+						"acquired_at": 1600000000
+					},
+					{
+						"type": "Unknown",
+						"id": [12345, 6789],
+						"holder": {"pid": 103}
+					}
+				]`))
+			})
+
+			Context("when getting the locks fails", func() {
+				BeforeEach(func() {
+					dbLockRepository.AdvisoryLocksReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
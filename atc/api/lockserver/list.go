@@ -0,0 +1,91 @@
+package lockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+// ListLocks lists the advisory locks held in the database, with the sessions
+// holding and waiting for each.
+func (s *Server) ListLocks(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-locks")
+
+	advisoryLocks, err := s.lockRepository.AdvisoryLocks()
+	if err != nil {
+		logger.Error("failed-to-get-advisory-locks", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	acquired := map[string]int64{}
+	for _, held := range s.lockFactory.Held() {
+		acquired[lockKey(held.ID)] = held.AcquiredAt.Unix()
+	}
+
+	locks := []atc.Lock{}
+	indices := map[string]int{}
+	for _, advisoryLock := range advisoryLocks {
+		key := lockKey(advisoryLock.ID)
+
+		i, found := indices[key]
+		if !found {
+			i = len(locks)
+			indices[key] = i
+
+			locks = append(locks, atc.Lock{
+				Type: lockType(advisoryLock.ID),
+				ID:   advisoryLock.ID,
+			})
+		}
+
+		holder := presentHolder(advisoryLock)
+
+		if advisoryLock.Granted {
+			locks[i].Holder = &holder
+			locks[i].AcquiredAt = acquired[key]
+		} else {
+			locks[i].Waiting = append(locks[i].Waiting, holder)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(locks)
+	if err != nil {
+		logger.Error("failed-to-encode-locks", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func lockKey(id lock.LockID) string {
+	return fmt.Sprint([]int(id))
+}
+
+func lockType(id lock.LockID) string {
+	if len(id) > 0 {
+		if name, found := lock.LockTypeNames[id[0]]; found {
+			return name
+		}
+	}
+
+	return "Unknown"
+}
+
+func presentHolder(advisoryLock db.AdvisoryLock) atc.LockHolder {
+	holder := atc.LockHolder{
+		PID:             advisoryLock.PID,
+		ApplicationName: advisoryLock.ApplicationName,
+		ClientAddr:      advisoryLock.ClientAddr,
+	}
+
+	if !advisoryLock.BackendStart.IsZero() {
+		holder.ConnectedAt = advisoryLock.BackendStart.Unix()
+	}
+
+	return holder
+}
@@ -0,0 +1,23 @@
+package lockserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	lockRepository db.LockRepository
+	lockFactory    lock.LockFactory
+}
+
+func NewServer(logger lager.Logger, lockRepository db.LockRepository, lockFactory lock.LockFactory) *Server {
+	return &Server{
+		logger: logger,
+
+		lockRepository: lockRepository,
+		lockFactory:    lockFactory,
+	}
+}
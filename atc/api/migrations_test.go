@@ -0,0 +1,144 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrations API", func() {
+	Describe("GET /api/v1/migrations", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/migrations")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				fakeMigrationVersions.CurrentVersionReturns(1572531600, nil)
+				fakeMigrationVersions.SupportedVersionReturns(1572531600, nil)
+			})
+
+			Context("when there are no backfills", func() {
+				It("returns the versions", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"current_version": 1572531600,
+						"supported_version": 1572531600,
+						"backfills": []
+					}`))
+				})
+			})
+
+			Context("when there are backfills", func() {
+				BeforeEach(func() {
+					dbBackfillRepository.ProgressReturns([]db.BackfillProgress{
+						{
+							Name:      "running",
+							TargetID:  200,
+							LastID:    50,
+							Error:     "disaster",
+							StartedAt: time.Unix(100, 0),
+							UpdatedAt: time.Unix(200, 0),
+						},
+						{
+							Name:       "empty",
+							StartedAt:  time.Unix(100, 0),
+							UpdatedAt:  time.Unix(100, 0),
+							FinishedAt: time.Unix(100, 0),
+						},
+					}, nil)
+				})
+
+				It("returns their progress", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"current_version": 1572531600,
+						"supported_version": 1572531600,
+						"backfills": [
+							{
+								"name": "running",
+								"target_id": 200,
+								"last_id": 50,
+								"percent": 25,
+								"error": "disaster",
+								"started_at": 100,
+								"updated_at": 200
+							},
+							{
+								"name": "empty",
+								"target_id": 0,
+								"last_id": 0,
+								"percent": 100,
+								"started_at": 100,
+								"updated_at": 100,
+								"finished_at": 100
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when getting the current version fails", func() {
+				BeforeEach(func() {
+					fakeMigrationVersions.CurrentVersionReturns(0, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the backfill progress fails", func() {
+				BeforeEach(func() {
+					dbBackfillRepository.ProgressReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
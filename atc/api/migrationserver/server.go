@@ -0,0 +1,31 @@
+package migrationserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Versions
+
+// Versions reports the version the database schema is at and the latest
+// version this ATC can migrate it to.
+type Versions interface {
+	CurrentVersion() (int, error)
+	SupportedVersion() (int, error)
+}
+
+type Server struct {
+	logger lager.Logger
+
+	versions           Versions
+	backfillRepository db.BackfillRepository
+}
+
+func NewServer(logger lager.Logger, versions Versions, backfillRepository db.BackfillRepository) *Server {
+	return &Server{
+		logger: logger,
+
+		versions:           versions,
+		backfillRepository: backfillRepository,
+	}
+}
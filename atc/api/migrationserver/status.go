@@ -0,0 +1,77 @@
+package migrationserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetMigrationStatus reports the database's schema version and the progress
+// of its backfills.
+func (s *Server) GetMigrationStatus(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-migration-status")
+
+	currentVersion, err := s.versions.CurrentVersion()
+	if err != nil {
+		logger.Error("failed-to-get-current-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	supportedVersion, err := s.versions.SupportedVersion()
+	if err != nil {
+		logger.Error("failed-to-get-supported-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	progress, err := s.backfillRepository.Progress()
+	if err != nil {
+		logger.Error("failed-to-get-backfill-progress", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	status := atc.MigrationStatus{
+		CurrentVersion:   currentVersion,
+		SupportedVersion: supportedVersion,
+		Backfills:        []atc.BackfillProgress{},
+	}
+
+	for _, p := range progress {
+		status.Backfills = append(status.Backfills, presentBackfillProgress(p))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(status)
+	if err != nil {
+		logger.Error("failed-to-encode-migration-status", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func presentBackfillProgress(progress db.BackfillProgress) atc.BackfillProgress {
+	percent := 100.0
+	if progress.TargetID > 0 {
+		percent = float64(progress.LastID) / float64(progress.TargetID) * 100
+	}
+
+	presented := atc.BackfillProgress{
+		Name:      progress.Name,
+		TargetID:  progress.TargetID,
+		LastID:    progress.LastID,
+		Percent:   percent,
+		Error:     progress.Error,
+		StartedAt: progress.StartedAt.Unix(),
+		UpdatedAt: progress.UpdatedAt.Unix(),
+	}
+
+	if progress.Finished() {
+		presented.FinishedAt = progress.FinishedAt.Unix()
+	}
+
+	return presented
+}
@@ -93,9 +93,14 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("GET /api/v1/pipelines", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/pipelines", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/pipelines"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -229,6 +234,47 @@ var _ = Describe("Pipelines API", func() {
 				})
 			})
 
+			Context("when filtering by label", func() {
+				BeforeEach(func() {
+					privatePipeline.LabelsReturns(atc.Labels{"service": "web", "tier": "1"})
+					publicPipeline.LabelsReturns(atc.Labels{"service": "api", "tier": "1"})
+
+					query = "?label=tier&label=service:web"
+				})
+
+				It("returns only the pipelines with matching labels", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+					{
+						"id": 3,
+						"name": "private-pipeline",
+						"paused": false,
+						"public": false,
+						"team_name": "main",
+						"groups": [
+							{
+								"name": "group1",
+								"jobs": ["job1", "job2"],
+								"resources": ["resource1", "resource2"]
+							}
+						],
+						"labels": {"service": "web", "tier": "1"}
+					}]`))
+				})
+
+				Context("when the selector is malformed", func() {
+					BeforeEach(func() {
+						query = "?label=:web"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Context("when the call to get active pipelines fails", func() {
 				BeforeEach(func() {
 					dbPipelineFactory.VisiblePipelinesReturns(nil, errors.New("disaster"))
@@ -461,6 +507,22 @@ var _ = Describe("Pipelines API", func() {
 						]
 					}`))
 			})
+
+			Context("when the pipeline has a timezone and locale", func() {
+				BeforeEach(func() {
+					fakePipeline.TimeZoneReturns("Europe/London")
+					fakePipeline.LocaleReturns("en-GB")
+				})
+
+				It("includes them in the pipeline JSON", func() {
+					var pipeline atc.Pipeline
+					err := json.NewDecoder(response.Body).Decode(&pipeline)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(pipeline.TimeZone).To(Equal(atc.TimeZone("Europe/London")))
+					Expect(pipeline.Locale).To(Equal(atc.Locale("en-GB")))
+				})
+			})
 		})
 
 		Context("when authenticated as another team", func() {
@@ -785,6 +847,89 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/status", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			fakeTeam.PipelineReturns(dbPipeline, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/status")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.IsAuthenticatedReturns(false)
+				dbPipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the pipeline has a failed and a successful build", func() {
+				BeforeEach(func() {
+					succeededJob := new(dbfakes.FakeJob)
+					succeededBuild := new(dbfakes.FakeBuild)
+					succeededBuild.NameReturns("3")
+					succeededBuild.JobNameReturns("succeeding-job")
+					succeededBuild.StatusReturns(db.BuildStatusSucceeded)
+					succeededJob.FinishedAndNextBuildReturns(succeededBuild, nil, nil)
+
+					failedJob := new(dbfakes.FakeJob)
+					failedBuild := new(dbfakes.FakeBuild)
+					failedBuild.NameReturns("5")
+					failedBuild.JobNameReturns("failing-job")
+					failedBuild.EndTimeReturns(time.Unix(100, 0))
+					failedBuild.StatusReturns(db.BuildStatusFailed)
+					failedJob.FinishedAndNextBuildReturns(failedBuild, nil, nil)
+
+					dbPipeline.JobsReturns([]db.Job{succeededJob, failedJob}, nil)
+				})
+
+				It("returns 200 OK as json", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the status of the failed build", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"status": "failing",
+						"job": "failing-job",
+						"build": "5",
+						"end_time": 100
+					}`))
+				})
+			})
+
+			Context("when getting the jobs fails", func() {
+				BeforeEach(func() {
+					dbPipeline.JobsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name", func() {
 		var response *http.Response
 
@@ -1105,6 +1250,143 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/share", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/share", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when requester belongs to the team", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					fakeTeam.PipelineReturns(dbPipeline, true, nil)
+				})
+
+				Context("when sharing the pipeline succeeds", func() {
+					BeforeEach(func() {
+						dbPipeline.ShareReturns("some-share-token", nil)
+					})
+
+					It("returns 200 with the share token", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"token":"some-share-token"}`))
+					})
+				})
+
+				Context("when sharing the pipeline fails", func() {
+					BeforeEach(func() {
+						dbPipeline.ShareReturns("", errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when requester does not belong to the team", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not share the pipeline", func() {
+					Expect(dbPipeline.ShareCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/unshare", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/unshare", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when requester belongs to the team", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					fakeTeam.PipelineReturns(dbPipeline, true, nil)
+				})
+
+				It("unshares the pipeline", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbPipeline.UnshareCallCount()).To(Equal(1))
+				})
+
+				Context("when unsharing the pipeline fails", func() {
+					BeforeEach(func() {
+						dbPipeline.UnshareReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when requester does not belong to the team", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/hide", func() {
 		var response *http.Response
 
@@ -1383,6 +1665,7 @@ var _ = Describe("Pipelines API", func() {
 						"CheckOrder": 123
 			    }
 				],
+				"DeletedVersions": null,
 				"BuildOutputs": [
 					{
 						"VersionID": 73,
@@ -1399,7 +1682,9 @@ var _ = Describe("Pipelines API", func() {
 						"BuildID": 66,
 						"JobID": 13,
 						"CheckOrder": 88,
-						"InputName": "some-input-name"
+						"InputName": "some-input-name",
+						"Succeeded": false,
+						"Failed": false
 					}
 				],
 				"JobIDs": {
@@ -1423,6 +1708,219 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/scheduling", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			fakeTeam.PipelineReturns(dbPipeline, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/scheduling")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the pipeline has been scheduled", func() {
+			BeforeEach(func() {
+				dbPipeline.SchedulingTickReturns(atc.SchedulingTick{
+					StartTime:     1573741200,
+					Duration:      1.5,
+					JobsEvaluated: 4,
+					BuildsCreated: 2,
+					Error:         "failed to load versions",
+				}, true, nil)
+			})
+
+			It("returns how the last tick went", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"start_time": 1573741200,
+					"duration": 1.5,
+					"jobs_evaluated": 4,
+					"builds_created": 2,
+					"error": "failed to load versions"
+				}`))
+			})
+		})
+
+		Context("when the pipeline hasn't been scheduled yet", func() {
+			BeforeEach(func() {
+				dbPipeline.SchedulingTickReturns(atc.SchedulingTick{}, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the tick fails", func() {
+			BeforeEach(func() {
+				dbPipeline.SchedulingTickReturns(atc.SchedulingTick{}, false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/graph", func() {
+		var (
+			query    string
+			etag     string
+			response *http.Response
+		)
+
+		getGraph := func() *http.Response {
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/graph"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if etag != "" {
+				request.Header.Set("If-None-Match", etag)
+			}
+
+			response, err := client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			return response
+		}
+
+		BeforeEach(func() {
+			query = ""
+			etag = ""
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			fakeTeam.PipelineReturns(dbPipeline, true, nil)
+
+			dbPipeline.IDReturns(42)
+			dbPipeline.ConfigVersionReturns(3)
+			dbPipeline.GroupsReturns(atc.GroupConfigs{
+				{Name: "tests", Jobs: []string{"unit"}},
+			})
+
+			unitJob := new(dbfakes.FakeJob)
+			unitJob.ConfigReturns(atc.JobConfig{
+				Name: "unit",
+				Plan: atc.PlanSequence{{Get: "repo"}},
+			})
+
+			shipJob := new(dbfakes.FakeJob)
+			shipJob.ConfigReturns(atc.JobConfig{
+				Name: "ship",
+				Plan: atc.PlanSequence{{Get: "repo", Passed: []string{"unit"}}},
+			})
+
+			dbPipeline.JobsReturns(db.Jobs{unitJob, shipJob}, nil)
+		})
+
+		JustBeforeEach(func() {
+			response = getGraph()
+		})
+
+		It("returns the laid out graph", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(`{
+				"ranks": 4,
+				"nodes": [
+					{"id": "job:unit", "type": "job", "name": "unit", "job": "unit", "rank": 1, "order": 0},
+					{"id": "input:unit:repo", "type": "input", "name": "repo", "job": "unit", "resource": "repo", "rank": 0, "order": 0},
+					{"id": "job:ship", "type": "job", "name": "ship", "job": "ship", "rank": 3, "order": 0}
+				],
+				"edges": [
+					{"source": "input:unit:repo", "target": "job:unit"},
+					{"source": "job:unit", "target": "job:ship", "resource": "repo", "waypoints": [{"rank": 2, "order": 0}]}
+				]
+			}`))
+		})
+
+		It("returns the pipeline's config version as the ETag", func() {
+			Expect(response.Header.Get("ETag")).To(Equal(`"42-3"`))
+		})
+
+		It("caches the layout until the config changes", func() {
+			Expect(getGraph().StatusCode).To(Equal(http.StatusOK))
+			Expect(dbPipeline.JobsCallCount()).To(Equal(1))
+
+			dbPipeline.ConfigVersionReturns(4)
+
+			Expect(getGraph().StatusCode).To(Equal(http.StatusOK))
+			Expect(dbPipeline.JobsCallCount()).To(Equal(2))
+		})
+
+		Context("when the client has the current layout", func() {
+			BeforeEach(func() {
+				etag = `"42-3"`
+			})
+
+			It("returns 304", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotModified))
+				Expect(dbPipeline.JobsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a group is given", func() {
+			BeforeEach(func() {
+				query = "?group=tests"
+			})
+
+			It("lays out only the group's jobs", func() {
+				var graph atc.PipelineGraph
+				err := json.NewDecoder(response.Body).Decode(&graph)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(graph.Nodes).To(HaveLen(2))
+				Expect(graph.Edges).To(HaveLen(1))
+			})
+		})
+
+		Context("when the group does not exist", func() {
+			BeforeEach(func() {
+				query = "?group=bogus"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the jobs fails", func() {
+			BeforeEach(func() {
+				dbPipeline.JobsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 
@@ -1,6 +1,7 @@
 package pipelineserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/concourse/concourse/atc/db"
 )
 
-func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (*jobserver.Badge, error) {
+// statusBuildForPipeline returns the latest finished build of the pipeline's
+// job in the worst state, which determines the pipeline's badge and status.
+func statusBuildForPipeline(pipeline db.Pipeline, logger lager.Logger) (db.Build, error) {
 	var build db.Build
 
 	jobStatusPrecedence := map[db.BuildStatus]int{
@@ -42,7 +45,7 @@ func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (*jobserver.Bad
 		}
 	}
 
-	return jobserver.BadgeForBuild(build), nil
+	return build, nil
 }
 
 func (s *Server) PipelineBadge(pipeline db.Pipeline) http.Handler {
@@ -55,12 +58,35 @@ func (s *Server) PipelineBadge(pipeline db.Pipeline) http.Handler {
 
 		w.WriteHeader(http.StatusOK)
 
-		badge, err := badgeForPipeline(pipeline, logger)
+		build, err := statusBuildForPipeline(pipeline, logger)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		fmt.Fprint(w, badge)
+		fmt.Fprint(w, jobserver.BadgeForBuild(build))
+	})
+}
+
+func (s *Server) PipelineStatus(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("pipeline-status")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		build, err := statusBuildForPipeline(pipeline, logger)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		w.Header().Set("Expires", "0")
+
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(jobserver.StatusForBuild(build))
+		if err != nil {
+			logger.Error("failed-to-encode-status", err)
+		}
 	})
 }
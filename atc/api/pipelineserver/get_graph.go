@@ -0,0 +1,130 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/pipelinegraph"
+)
+
+// GetPipelineGraph returns the laid out graph of the pipeline's jobs,
+// optionally limited to the jobs in the groups given by the 'group' query
+// parameter.
+//
+// Layouts are cached until the pipeline's config changes, and the config
+// version is given as the ETag so that clients can cache them too.
+func (s *Server) GetPipelineGraph(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-pipeline-graph")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groups := r.URL.Query()["group"]
+		for _, group := range groups {
+			if _, _, found := pipeline.Groups().Lookup(group); !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		}
+
+		etag := fmt.Sprintf(`"%d-%d"`, pipeline.ID(), pipeline.ConfigVersion())
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		graph, found := s.graphs.get(pipeline, groups)
+		if !found {
+			jobs, err := pipeline.Jobs()
+			if err != nil {
+				logger.Error("failed-to-get-jobs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			graph = pipelinegraph.Layout(groupJobs(jobs.Configs(), pipeline.Groups(), groups))
+			s.graphs.set(pipeline, groups, graph)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", etag)
+
+		err := json.NewEncoder(w).Encode(graph)
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-graph", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func groupJobs(jobs atc.JobConfigs, groupConfigs atc.GroupConfigs, groups []string) atc.JobConfigs {
+	if len(groups) == 0 {
+		return jobs
+	}
+
+	inGroups := map[string]bool{}
+	for _, group := range groups {
+		config, _, _ := groupConfigs.Lookup(group)
+		for _, job := range config.Jobs {
+			inGroups[job] = true
+		}
+	}
+
+	filtered := atc.JobConfigs{}
+	for _, job := range jobs {
+		if inGroups[job.Name] {
+			filtered = append(filtered, job)
+		}
+	}
+
+	return filtered
+}
+
+type graphCache struct {
+	lock   sync.Mutex
+	graphs map[string]cachedGraph
+}
+
+type cachedGraph struct {
+	configVersion db.ConfigVersion
+	graph         atc.PipelineGraph
+}
+
+func newGraphCache() *graphCache {
+	return &graphCache{
+		graphs: map[string]cachedGraph{},
+	}
+}
+
+func (cache *graphCache) get(pipeline db.Pipeline, groups []string) (atc.PipelineGraph, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cached, found := cache.graphs[graphCacheKey(pipeline, groups)]
+	if !found || cached.configVersion != pipeline.ConfigVersion() {
+		return atc.PipelineGraph{}, false
+	}
+
+	return cached.graph, true
+}
+
+func (cache *graphCache) set(pipeline db.Pipeline, groups []string, graph atc.PipelineGraph) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.graphs[graphCacheKey(pipeline, groups)] = cachedGraph{
+		configVersion: pipeline.ConfigVersion(),
+		graph:         graph,
+	}
+}
+
+func graphCacheKey(pipeline db.Pipeline, groups []string) string {
+	sorted := append([]string{}, groups...)
+	sort.Strings(sorted)
+
+	return fmt.Sprintf("%d:%s", pipeline.ID(), strings.Join(sorted, ","))
+}
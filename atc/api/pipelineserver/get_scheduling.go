@@ -0,0 +1,36 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetPipelineScheduling returns how the scheduler's last tick of the
+// pipeline went, or 404 if it hasn't been scheduled yet.
+func (s *Server) GetPipelineScheduling(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-pipeline-scheduling")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tick, found, err := pipeline.SchedulingTick()
+		if err != nil {
+			logger.Error("failed-to-get-scheduling-tick", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(tick)
+		if err != nil {
+			logger.Error("failed-to-encode-scheduling-tick", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
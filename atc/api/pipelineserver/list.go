@@ -2,8 +2,10 @@ package pipelineserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -11,6 +13,13 @@ import (
 
 func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("list-pipelines")
+
+	selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, err.Error())
+		return
+	}
 	requestTeamName := r.FormValue(":team_name")
 	team, found, err := s.teamFactory.FindTeam(requestTeamName)
 	if err != nil {
@@ -42,9 +51,21 @@ func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 
-	err = json.NewEncoder(w).Encode(present.Pipelines(pipelines))
+	err = json.NewEncoder(w).Encode(present.Pipelines(filterPipelines(pipelines, selector)))
 	if err != nil {
 		logger.Error("failed-to-encode-pipelines", err)
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+// filterPipelines returns the pipelines whose labels match the selector.
+func filterPipelines(pipelines []db.Pipeline, selector atc.LabelSelector) []db.Pipeline {
+	filtered := []db.Pipeline{}
+	for _, pipeline := range pipelines {
+		if selector.Matches(pipeline.Labels()) {
+			filtered = append(filtered, pipeline)
+		}
+	}
+
+	return filtered
+}
@@ -12,6 +12,7 @@ type Server struct {
 	rejector        auth.Rejector
 	pipelineFactory db.PipelineFactory
 	externalURL     string
+	graphs          *graphCache
 }
 
 func NewServer(
@@ -26,5 +27,6 @@ func NewServer(
 		rejector:        auth.UnauthorizedRejector{},
 		pipelineFactory: pipelineFactory,
 		externalURL:     externalURL,
+		graphs:          newGraphCache(),
 	}
 }
@@ -0,0 +1,44 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) SharePipeline(pipelineDB db.Pipeline) http.Handler {
+	logger := s.logger.Session("share-pipeline")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := pipelineDB.Share()
+		if err != nil {
+			logger.Error("failed-to-share-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(atc.SharedPipeline{Token: token})
+		if err != nil {
+			logger.Error("failed-to-encode-share-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) UnsharePipeline(pipelineDB db.Pipeline) http.Handler {
+	logger := s.logger.Session("unshare-pipeline")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := pipelineDB.Unshare()
+		if err != nil {
+			logger.Error("failed-to-unshare-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
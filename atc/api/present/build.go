@@ -19,13 +19,23 @@ func Build(build db.Build) atc.Build {
 	}
 
 	atcBuild := atc.Build{
-		ID:           build.ID(),
-		Name:         build.Name(),
-		JobName:      build.JobName(),
-		PipelineName: build.PipelineName(),
-		TeamName:     build.TeamName(),
-		Status:       string(build.Status()),
-		APIURL:       apiURL,
+		ID:               build.ID(),
+		Name:             build.Name(),
+		JobName:          build.JobName(),
+		PipelineName:     build.PipelineName(),
+		TeamName:         build.TeamName(),
+		Status:           string(build.Status()),
+		APIURL:           apiURL,
+		Paused:           build.IsPaused(),
+		RerunOf:          build.RerunOf(),
+		AutoRetryAttempt: build.AutoRetryAttempt(),
+		ErrorCode:        build.ErrorCode(),
+		Annotations:      build.Annotations(),
+		InputOverrides:   build.InputOverrides(),
+		ImageOverrides:   build.ImageOverrides(),
+		WorkerOverrides:  build.WorkerOverrides(),
+		InputArtifacts:   build.InputArtifacts(),
+		InputFallbacks:   build.InputFallbacks(),
 	}
 
 	if !build.StartTime().IsZero() {
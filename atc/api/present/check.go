@@ -8,8 +8,10 @@ import (
 func Check(check db.Check) atc.Check {
 
 	atcCheck := atc.Check{
-		ID:     check.ID(),
-		Status: string(check.Status()),
+		ID:         check.ID(),
+		Status:     string(check.Status()),
+		WorkerName: check.WorkerName(),
+		Output:     check.Output(),
 	}
 
 	if !check.CreateTime().IsZero() {
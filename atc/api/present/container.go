@@ -32,6 +32,10 @@ func Container(container db.Container, expiresAt time.Time) atc.Container {
 		User:             meta.User,
 	}
 
+	if !container.CreatedAt().IsZero() {
+		atcContainer.CreatedAt = container.CreatedAt().Unix()
+	}
+
 	if !expiresAt.IsZero() {
 		atcContainer.ExpiresIn = expiresAt.Sub(time.Now()).Round(time.Second).String()
 	}
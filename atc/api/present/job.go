@@ -61,6 +61,9 @@ func Job(
 		TransitionBuild:      presentedTransitionBuild,
 		HasNewInputs:         job.HasNewInputs(),
 
+		SchedulingWindows: job.Config().SchedulingWindows,
+		Labels:            job.Config().Labels,
+
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
 
@@ -12,6 +12,14 @@ func Pipeline(savedPipeline db.Pipeline) atc.Pipeline {
 		TeamName: savedPipeline.TeamName(),
 		Paused:   savedPipeline.Paused(),
 		Public:   savedPipeline.Public(),
+		Shared:   savedPipeline.Shared(),
 		Groups:   savedPipeline.Groups(),
+
+		Deprecations:      savedPipeline.Deprecations(),
+		SchedulingWindows: savedPipeline.SchedulingWindows(),
+		Labels:            savedPipeline.Labels(),
+
+		TimeZone: savedPipeline.TimeZone(),
+		Locale:   savedPipeline.Locale(),
 	}
 }
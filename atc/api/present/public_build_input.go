@@ -5,18 +5,30 @@ import (
 	"github.com/concourse/concourse/atc/db"
 )
 
-func PublicBuildInput(input db.BuildInput, pipelineID int) atc.PublicBuildInput {
-	return atc.PublicBuildInput{
+func PublicBuildInput(input db.BuildInput, pipelineID int, hideMetadata bool) atc.PublicBuildInput {
+	publicInput := atc.PublicBuildInput{
 		Name:            input.Name,
 		Version:         atc.Version(input.Version),
 		PipelineID:      pipelineID,
 		FirstOccurrence: input.FirstOccurrence,
 	}
+
+	if !hideMetadata {
+		publicInput.Metadata = input.Metadata.ToATCMetadata()
+	}
+
+	return publicInput
 }
 
-func PublicBuildOutput(output db.BuildOutput) atc.PublicBuildOutput {
-	return atc.PublicBuildOutput{
+func PublicBuildOutput(output db.BuildOutput, hideMetadata bool) atc.PublicBuildOutput {
+	publicOutput := atc.PublicBuildOutput{
 		Name:    output.Name,
 		Version: atc.Version(output.Version),
 	}
+
+	if !hideMetadata {
+		publicOutput.Metadata = output.Metadata.ToATCMetadata()
+	}
+
+	return publicOutput
 }
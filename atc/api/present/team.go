@@ -10,5 +10,20 @@ func Team(team db.Team) atc.Team {
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
+
+		MaxBuildLogSize:         team.MaxBuildLogSize(),
+		MaxBuildStartsPerMinute: team.MaxBuildStartsPerMinute(),
+		ResourceDefaults:        team.ResourceDefaults(),
+		ContainerEnv:            team.ContainerEnv(),
+		CACerts:                 team.CACerts(),
+		ContainerDNS:            team.ContainerDNS(),
+		CheckPolicy:             team.CheckPolicy(),
+		DefaultTaskImage:        team.DefaultTaskImage(),
+		PipelinesRepo:           team.PipelinesRepo(),
+		ConcurrencyPools:        team.ConcurrencyPools(),
+		ContentScanPolicy:       team.ContentScanPolicy(),
+		ResourceTypeMappings:    team.ResourceTypeMappings(),
+		PrivilegedPolicy:        team.PrivilegedPolicy(),
+		RequireImageDigests:     team.RequireImageDigests(),
 	}
 }
@@ -5,10 +5,12 @@ import (
 	"github.com/concourse/concourse/atc/db"
 )
 
-func VersionedResourceTypes(showCheckError bool, savedResourceTypes db.ResourceTypes) atc.VersionedResourceTypes {
+func VersionedResourceTypes(showCheckError bool, savedResourceTypes db.ResourceTypes, redactions *atc.Redactions) atc.VersionedResourceTypes {
 	versionedResourceTypes := savedResourceTypes.Deserialize()
 
 	for i, resourceType := range savedResourceTypes {
+		versionedResourceTypes[i].Source = redactions.Source(versionedResourceTypes[i].Source)
+
 		if resourceType.CheckSetupError() != nil && showCheckError {
 			versionedResourceTypes[i].CheckSetupError = resourceType.CheckSetupError().Error()
 		} else {
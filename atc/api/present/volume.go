@@ -21,6 +21,11 @@ func Volume(volume db.CreatedVolume) (atc.Volume, error) {
 		return atc.Volume{}, err
 	}
 
+	var createdAt int64
+	if !volume.CreatedAt().IsZero() {
+		createdAt = volume.CreatedAt().Unix()
+	}
+
 	return atc.Volume{
 		ID:               volume.Handle(),
 		Type:             string(volume.Type()),
@@ -33,6 +38,7 @@ func Volume(volume db.CreatedVolume) (atc.Volume, error) {
 		StepName:         stepName,
 		ResourceType:     toVolumeResourceType(resourceType),
 		BaseResourceType: toVolumeBaseResourceType(baseResourceType),
+		CreatedAt:        createdAt,
 	}, nil
 }
 
@@ -35,6 +35,11 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
 		Tags:             workerInfo.Tags(),
+		Labels:           workerInfo.Labels(),
+		GPUs:             workerInfo.GPUs(),
+		StreamEncoding:   workerInfo.StreamEncoding(),
+		ProtocolVersion:  workerInfo.ProtocolVersion(),
+		Capabilities:     workerInfo.Capabilities(),
 		Name:             workerInfo.Name(),
 		Team:             workerInfo.TeamName(),
 		State:            string(workerInfo.State()),
@@ -277,6 +277,26 @@ var _ = Describe("Resources API", func() {
 				})
 			})
 
+			Context("when authenticated for another team and the public pipeline redacts check errors", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(true)
+					fakeaccess.IsAuthorizedReturns(false)
+					fakePipeline.PublicReturns(true)
+					fakePipeline.RedactionsReturns(&atc.Redactions{CheckErrors: true})
+				})
+
+				It("excludes the check errors", func() {
+					var resources []atc.Resource
+					err := json.NewDecoder(response.Body).Decode(&resources)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(resources).To(HaveLen(3))
+					Expect(resources[1].FailingToCheck).To(BeTrue())
+					Expect(resources[1].CheckError).To(BeEmpty())
+					Expect(resources[2].CheckSetupError).To(BeEmpty())
+				})
+			})
+
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
@@ -719,6 +739,122 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when it finds the resource", func() {
+				var fakeResource *dbfakes.FakeResource
+
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+					fakeCheck := new(dbfakes.FakeCheck)
+					fakeCheck.IDReturns(2)
+					fakeCheck.StatusReturns(db.CheckStatusErrored)
+					fakeCheck.StartTimeReturns(time.Unix(100, 0))
+					fakeCheck.EndTimeReturns(time.Unix(110, 0))
+					fakeCheck.CheckErrorReturns(errors.New("connection refused"))
+					fakeCheck.WorkerNameReturns("some-worker")
+					fakeCheck.OutputReturns("dialing...")
+
+					otherCheck := new(dbfakes.FakeCheck)
+					otherCheck.IDReturns(1)
+					otherCheck.StatusReturns(db.CheckStatusSucceeded)
+					otherCheck.WorkerNameReturns("other-worker")
+
+					fakeResource.ChecksReturns([]db.Check{fakeCheck, otherCheck}, nil)
+				})
+
+				It("returns the checks of the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"status": "errored",
+							"start_time": 100,
+							"end_time": 110,
+							"check_error": "connection refused",
+							"worker_name": "some-worker",
+							"output": "dialing..."
+						},
+						{
+							"id": 1,
+							"status": "succeeded",
+							"worker_name": "other-worker"
+						}
+					]`))
+				})
+
+				It("lists the default number of checks", func() {
+					Expect(fakeResource.ChecksArgsForCall(0)).To(Equal(atc.PaginationAPIDefaultLimit))
+				})
+
+				Context("when given a limit", func() {
+					BeforeEach(func() {
+						query = "?limit=5"
+					})
+
+					It("lists that many checks", func() {
+						Expect(fakeResource.ChecksArgsForCall(0)).To(Equal(5))
+					})
+				})
+
+				Context("when listing the checks fails", func() {
+					BeforeEach(func() {
+						fakeResource.ChecksReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types", func() {
 		var response *http.Response
 
@@ -827,6 +963,53 @@ var _ = Describe("Resources API", func() {
 				}
 			]`))
 					})
+
+					Context("and the pipeline redacts sources", func() {
+						BeforeEach(func() {
+							fakePipeline.RedactionsReturns(&atc.Redactions{Sources: true})
+						})
+
+						It("returns the resource types without their sources", func() {
+							var resourceTypes []map[string]interface{}
+							err := json.NewDecoder(response.Body).Decode(&resourceTypes)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(resourceTypes).To(HaveLen(2))
+							Expect(resourceTypes[0]["source"]).To(BeNil())
+							Expect(resourceTypes[1]["source"]).To(BeNil())
+						})
+					})
+
+					Context("and the pipeline redacts source fields", func() {
+						BeforeEach(func() {
+							fakePipeline.RedactionsReturns(&atc.Redactions{SourceFields: []string{"source-key-1"}})
+						})
+
+						It("returns the resource types with the fields redacted", func() {
+							var resourceTypes []atc.VersionedResourceType
+							err := json.NewDecoder(response.Body).Decode(&resourceTypes)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(resourceTypes[0].Source).To(Equal(atc.Source{"source-key-1": atc.RedactedValue}))
+							Expect(resourceTypes[1].Source).To(Equal(atc.Source{"source-key-2": "source-value-2"}))
+						})
+					})
+
+					Context("and the requester is authenticated but the pipeline redacts check errors", func() {
+						BeforeEach(func() {
+							fakeaccess.IsAuthenticatedReturns(true)
+							fakePipeline.RedactionsReturns(&atc.Redactions{CheckErrors: true})
+						})
+
+						It("excludes the check errors", func() {
+							var resourceTypes []atc.VersionedResourceType
+							err := json.NewDecoder(response.Body).Decode(&resourceTypes)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(resourceTypes[1].CheckError).To(BeEmpty())
+							Expect(resourceTypes[1].CheckSetupError).To(BeEmpty())
+						})
+					})
 				})
 			})
 
@@ -878,6 +1061,21 @@ var _ = Describe("Resources API", func() {
 		]`))
 				})
 
+				Context("when the pipeline has redactions", func() {
+					BeforeEach(func() {
+						fakePipeline.RedactionsReturns(&atc.Redactions{Sources: true, CheckErrors: true})
+					})
+
+					It("does not apply them", func() {
+						var resourceTypes []atc.VersionedResourceType
+						err := json.NewDecoder(response.Body).Decode(&resourceTypes)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(resourceTypes[0].Source).To(Equal(atc.Source{"source-key-1": "source-value-1"}))
+						Expect(resourceTypes[1].CheckError).To(Equal("sup"))
+					})
+				})
+
 				Context("when getting the resource type fails", func() {
 					Context("when the resource type are not found", func() {
 						BeforeEach(func() {
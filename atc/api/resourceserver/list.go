@@ -21,8 +21,9 @@ func (s *Server) ListResources(pipeline db.Pipeline) http.Handler {
 		}
 
 		acc := accessor.GetAccessor(r)
-		showCheckErr := acc.IsAuthenticated()
 		teamName := r.FormValue(":team_name")
+		showCheckErr := acc.IsAuthenticated() &&
+			(acc.IsAuthorized(teamName) || !pipeline.Redactions().HidesCheckErrors())
 
 		var presentedResources []atc.Resource
 		for _, resource := range resources {
@@ -37,7 +37,7 @@ func (s *Server) ListAllResources(w http.ResponseWriter, r *http.Request) {
 			resources,
 			present.Resource(
 				resource,
-				true,
+				acc.IsAuthorized(resource.TeamName()) || !resource.Redactions().HidesCheckErrors(),
 				resource.TeamName(),
 			),
 		)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -21,7 +22,14 @@ func (s *Server) ListVersionedResourceTypes(pipeline db.Pipeline) http.Handler {
 
 		acc := accessor.GetAccessor(r)
 		showCheckErr := acc.IsAuthenticated()
-		versionedResourceTypes := present.VersionedResourceTypes(showCheckErr, resourceTypes)
+
+		var redactions *atc.Redactions
+		if !acc.IsAuthorized(pipeline.TeamName()) {
+			redactions = pipeline.Redactions()
+			showCheckErr = showCheckErr && !redactions.HidesCheckErrors()
+		}
+
+		versionedResourceTypes := present.VersionedResourceTypes(showCheckErr, resourceTypes, redactions)
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -30,7 +30,14 @@ func (s *Server) DisableResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = resource.DisableVersion(resourceConfigVersionID)
+		// builds which haven't started yet resolve their inputs again unless
+		// they're asked to be aborted instead
+		if r.URL.Query().Get("abort_pending_builds") == "true" {
+			err = resource.DisableVersionAndAbortPendingBuilds(resourceConfigVersionID)
+		} else {
+			err = resource.DisableVersion(resourceConfigVersionID)
+		}
+
 		if err != nil {
 			logger.Error("failed-to-disable-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -31,6 +32,11 @@ func (s *Server) GetResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		acc := accessor.GetAccessor(r)
+		if !acc.IsAuthorized(pipeline.TeamName()) && pipeline.Redactions().HidesVersionMetadata() {
+			version.Metadata = nil
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 
 		w.WriteHeader(http.StatusOK)
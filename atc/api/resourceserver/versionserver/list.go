@@ -109,7 +109,8 @@ func (s *Server) ListResourceVersions(pipeline db.Pipeline) http.Handler {
 		w.WriteHeader(http.StatusOK)
 
 		acc := accessor.GetAccessor(r)
-		hideMetadata := !resource.Public() && !acc.IsAuthorized(teamName)
+		hideMetadata := !acc.IsAuthorized(teamName) &&
+			(!resource.Public() || pipeline.Redactions().HidesVersionMetadata())
 
 		versions = present.ResourceVersions(hideMetadata, versions)
 
@@ -0,0 +1,102 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListResourceVersionSpan lists the versions of a resource between two
+// versions along with the builds which used each one as an input, e.g. to
+// generate release notes for everything shipped since the last deploy.
+//
+// The lower bound is given by either since (exclusive) or from (inclusive),
+// and the optional upper bound by either until (exclusive) or to (inclusive).
+func (s *Server) ListResourceVersionSpan(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-resource-version-span")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+		teamName := r.FormValue(":team_name")
+
+		var span db.VersionSpan
+		span.Since, _ = strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+		span.From, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryFrom))
+		span.Until, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+		span.To, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryTo))
+
+		if span.Since == 0 && span.From == 0 {
+			logger.Info("missing-lower-bound")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found", lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		versions, found, err := resource.VersionsInSpan(span)
+		if err != nil {
+			logger.Error("failed-to-get-versions-in-span", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("span-bound-not-found", lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+		hideMetadata := !acc.IsAuthorized(teamName) &&
+			(!resource.Public() || pipeline.Redactions().HidesVersionMetadata())
+
+		versions = present.ResourceVersions(hideMetadata, versions)
+
+		spanned := []atc.SpannedResourceVersion{}
+		for _, version := range versions {
+			builds, err := pipeline.GetBuildsWithVersionAsInput(resource.ID(), version.ID)
+			if err != nil {
+				logger.Error("failed-to-get-builds-with-version-as-input", err, lager.Data{"version-id": version.ID})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			presentedBuilds := []atc.Build{}
+			for _, build := range builds {
+				presentedBuilds = append(presentedBuilds, present.Build(build))
+			}
+
+			spanned = append(spanned, atc.SpannedResourceVersion{
+				ResourceVersion: version,
+				InputTo:         presentedBuilds,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(spanned)
+		if err != nil {
+			logger.Error("failed-to-encode-resource-version-span", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
 )
 
 func jsonEncode(object interface{}) *bytes.Buffer {
@@ -291,6 +292,22 @@ var _ = Describe("Teams API", func() {
 			atcTeam  atc.Team
 		)
 
+		updatedFields := func() []string {
+			if fakeTeam.UpdateSettingsCallCount() == 0 {
+				return nil
+			}
+
+			_, fields := fakeTeam.UpdateSettingsArgsForCall(fakeTeam.UpdateSettingsCallCount() - 1)
+			return fields
+		}
+
+		updatedSettings := func() atc.Team {
+			Expect(fakeTeam.UpdateSettingsCallCount()).ToNot(BeZero())
+
+			settings, _ := fakeTeam.UpdateSettingsArgsForCall(fakeTeam.UpdateSettingsCallCount() - 1)
+			return settings
+		}
+
 		BeforeEach(func() {
 			fakeTeam.IDReturns(5)
 			fakeTeam.NameReturns("some-team")
@@ -324,264 +341,1102 @@ var _ = Describe("Teams API", func() {
 
 				It("updates provider auth", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
-					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+					Expect(updatedFields()).To(ContainElement("auth"))
 
-					updatedProviderAuth := fakeTeam.UpdateProviderAuthArgsForCall(0)
+					updatedProviderAuth := updatedSettings().Auth
 					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
 				})
 
+				It("updates the given settings together", func() {
+					Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
+					Expect(updatedFields()).To(Equal([]string{"auth"}))
+				})
+
+				Context("when auth is left out", func() {
+					It("leaves it as it is", func() {
+						request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team", bytes.NewBufferString(`{
+							"container_env": {"SOME_VAR": "some-value"}
+						}`))
+						Expect(err).NotTo(HaveOccurred())
+
+						response, err := client.Do(request)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						Expect(updatedFields()).To(Equal([]string{"container_env"}))
+					})
+				})
+
 				Context("when updating provider auth fails", func() {
 					BeforeEach(func() {
-						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
+						fakeTeam.UpdateSettingsReturns(errors.New("stop trying to make fetch happen"))
 					})
 
 					It("returns 500 Internal Server error", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
-			})
-		}
-
-		Context("when the requester team is authorized as an admin team", func() {
-			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAdminReturns(true)
-			})
 
-			authorizedTeamTests()
+				Context("when settings are left out", func() {
+					It("leaves them as they are", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_log_size"))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_starts_per_minute"))
+						Expect(updatedFields()).ToNot(ContainElement("resource_defaults"))
+						Expect(updatedFields()).ToNot(ContainElement("container_env"))
+						Expect(updatedFields()).ToNot(ContainElement("ca_certs"))
+						Expect(updatedFields()).ToNot(ContainElement("container_dns"))
+						Expect(updatedFields()).ToNot(ContainElement("default_task_image"))
+						Expect(updatedFields()).ToNot(ContainElement("pipelines_repo"))
+						Expect(updatedFields()).ToNot(ContainElement("concurrency_pools"))
+						Expect(updatedFields()).ToNot(ContainElement("check_policy"))
+						Expect(updatedFields()).ToNot(ContainElement("content_scan_policy"))
+						Expect(updatedFields()).ToNot(ContainElement("resource_type_mappings"))
+						Expect(updatedFields()).ToNot(ContainElement("privileged_policy"))
+						Expect(updatedFields()).ToNot(ContainElement("require_image_digests"))
+					})
 
-			Context("when the team is not found", func() {
-				BeforeEach(func() {
-					dbTeamFactory.FindTeamReturns(nil, false, nil)
-					dbTeamFactory.CreateTeamReturns(fakeTeam, nil)
-				})
+					It("resets settings which are given as empty", func() {
+						request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team", bytes.NewBufferString(`{
+							"auth": {"owner": {"users": ["local:username"]}},
+							"ca_certs": ""
+						}`))
+						Expect(err).NotTo(HaveOccurred())
 
-				It("creates the team", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusCreated))
-					Expect(dbTeamFactory.CreateTeamCallCount()).To(Equal(1))
+						response, err := client.Do(request)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
 
-					createdTeam := dbTeamFactory.CreateTeamArgsForCall(0)
-					Expect(createdTeam).To(Equal(atc.Team{
-						Name: "some-team",
-					}))
+						Expect(updatedFields()).To(ContainElement("ca_certs"))
+						Expect(updatedSettings().CACerts).To(BeEmpty())
+					})
 				})
 
-				Context("when it fails to create team", func() {
+				Context("when settings are cleared", func() {
 					BeforeEach(func() {
-						dbTeamFactory.CreateTeamReturns(nil, errors.New("it is never going to happen"))
+						atcTeam.Clear = []string{"container_env", "ca_certs"}
 					})
 
-					It("returns a 500 Internal Server error", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					It("resets them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("container_env"))
+						Expect(updatedSettings().ContainerEnv).To(BeEmpty())
+						Expect(updatedFields()).To(ContainElement("ca_certs"))
+						Expect(updatedSettings().CACerts).To(BeEmpty())
 					})
-				})
-			})
-		})
-
-		Context("when the requester team is authorized as the team being set", func() {
-			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAuthorizedReturns(true)
-			})
-
-			authorizedTeamTests()
 
-			Context("when the team is not found", func() {
-				BeforeEach(func() {
-					dbTeamFactory.FindTeamReturns(nil, false, nil)
-					dbTeamFactory.CreateTeamReturns(fakeTeam, nil)
-				})
+					It("leaves the other settings as they are", func() {
+						Expect(updatedFields()).ToNot(ContainElement("max_build_log_size"))
+						Expect(updatedFields()).ToNot(ContainElement("resource_defaults"))
+					})
 
-				It("does not create the team", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
-					Expect(dbTeamFactory.CreateTeamCallCount()).To(Equal(0))
-				})
-			})
-		})
-	})
+					Context("when a setting is also given", func() {
+						BeforeEach(func() {
+							atcTeam.ContainerEnv = map[string]string{"SOME_VAR": "some-value"}
+						})
 
-	Describe("DELETE /api/v1/teams/:team_name", func() {
-		var request *http.Request
-		var response *http.Response
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(updatedFields()).ToNot(ContainElement("auth"))
+							Expect(updatedFields()).ToNot(ContainElement("container_env"))
+						})
+					})
 
-		var teamName string
+					Context("when a setting is unknown", func() {
+						BeforeEach(func() {
+							atcTeam.Clear = []string{"container_env", "bogus"}
+						})
 
-		BeforeEach(func() {
-			teamName = "team venture"
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(updatedFields()).ToNot(ContainElement("auth"))
+							Expect(updatedFields()).ToNot(ContainElement("container_env"))
+						})
+					})
+				})
 
-			fakeTeam.IDReturns(2)
-			fakeTeam.NameReturns(teamName)
-		})
+				Context("when resource defaults are given", func() {
+					BeforeEach(func() {
+						atcTeam.ResourceDefaults = atc.ResourceDefaults{
+							"registry-image": atc.Source{"registry_mirror": "https://mirror.example.com"},
+						}
+					})
 
-		Context("when the requester is authenticated for some admin team", func() {
-			JustBeforeEach(func() {
-				path := fmt.Sprintf("%s/api/v1/teams/%s", server.URL, teamName)
+					It("updates the resource defaults", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("resource_defaults"))
+						Expect(updatedSettings().ResourceDefaults).To(Equal(atc.ResourceDefaults{
+							"registry-image": atc.Source{"registry_mirror": "https://mirror.example.com"},
+						}))
+					})
+				})
 
-				var err error
-				request, err = http.NewRequest("DELETE", path, nil)
-				Expect(err).NotTo(HaveOccurred())
+				Context("when updating the resource defaults fails", func() {
+					BeforeEach(func() {
+						atcTeam.ResourceDefaults = atc.ResourceDefaults{"git": atc.Source{"depth": 1}}
+						fakeTeam.UpdateSettingsReturns(errors.New("nope"))
+					})
 
-				response, err = client.Do(request)
-				Expect(err).NotTo(HaveOccurred())
-			})
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
 
-			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAdminReturns(true)
-			})
+				Context("when container env is given", func() {
+					BeforeEach(func() {
+						atcTeam.ContainerEnv = map[string]string{"HTTP_PROXY": "http://proxy.example.com"}
+					})
 
-			Context("when there's a problem finding teams", func() {
-				BeforeEach(func() {
-					dbTeamFactory.FindTeamReturns(nil, false, errors.New("a dingo ate my baby!"))
+					It("updates the container env", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("container_env"))
+						Expect(updatedSettings().ContainerEnv).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy.example.com"}))
+					})
 				})
 
-				It("returns 500 Internal Server Error", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-				})
-			})
+				Context("when updating the container env fails", func() {
+					BeforeEach(func() {
+						atcTeam.ContainerEnv = map[string]string{"FOO": "bar"}
+						fakeTeam.UpdateSettingsReturns(errors.New("nope"))
+					})
 
-			Context("when team exists", func() {
-				BeforeEach(func() {
-					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
 				})
 
-				It("returns 204 No Content", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
-				})
+				Context("when CA certs are given", func() {
+					BeforeEach(func() {
+						atcTeam.CACerts = "some-ca-cert"
+					})
 
-				It("receives the correct team name", func() {
-					Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
-					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal(teamName))
-				})
-				It("deletes the team from the DB", func() {
-					Expect(fakeTeam.DeleteCallCount()).To(Equal(1))
-					//TODO delete the build events via a table drop rather
+					It("updates the CA certs", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("ca_certs"))
+						Expect(updatedSettings().CACerts).To(Equal("some-ca-cert"))
+					})
 				})
 
-				Context("when trying to delete the admin team", func() {
+				Context("when container dns is given", func() {
 					BeforeEach(func() {
-						teamName = atc.DefaultTeamName
-						fakeTeam.AdminReturns(true)
-						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
-						dbTeamFactory.GetTeamsReturns([]db.Team{fakeTeam}, nil)
+						atcTeam.ContainerDNS = &atc.ContainerDNS{Servers: []string{"10.0.0.2"}}
 					})
 
-					It("returns 403 Forbidden and backs off", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
-						Expect(fakeTeam.DeleteCallCount()).To(Equal(0))
+					It("updates the container dns", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("container_dns"))
+						Expect(updatedSettings().ContainerDNS).To(Equal(&atc.ContainerDNS{Servers: []string{"10.0.0.2"}}))
+					})
+
+					Context("when the container dns is invalid", func() {
+						BeforeEach(func() {
+							atcTeam.ContainerDNS = &atc.ContainerDNS{Servers: []string{"dns.example.com"}}
+						})
+
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(updatedFields()).ToNot(ContainElement("container_dns"))
+						})
 					})
 				})
 
-				Context("when there's a problem deleting the team", func() {
+				Context("when a default task image is given", func() {
 					BeforeEach(func() {
-						fakeTeam.DeleteReturns(errors.New("disaster"))
+						atcTeam.DefaultTaskImage = &atc.ImageResource{
+							Type:   "docker-image",
+							Source: atc.Source{"repository": "busybox"},
+						}
 					})
 
-					It("returns 500 Internal Server Error", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					It("updates the default task image", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("default_task_image"))
+						Expect(updatedSettings().DefaultTaskImage).To(Equal(&atc.ImageResource{
+							Type:   "docker-image",
+							Source: atc.Source{"repository": "busybox"},
+						}))
 					})
-				})
-			})
 
-			Context("when team does not exist", func() {
-				BeforeEach(func() {
-					dbTeamFactory.FindTeamReturns(nil, false, nil)
-				})
+					Context("when the default task image has no type", func() {
+						BeforeEach(func() {
+							atcTeam.DefaultTaskImage.Type = ""
+						})
 
-				It("returns 404 Not Found", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(updatedFields()).ToNot(ContainElement("default_task_image"))
+						})
+					})
 				})
-			})
-		})
 
-		Context("when the requester belongs to a non-admin team", func() {
-			JustBeforeEach(func() {
-				path := fmt.Sprintf("%s/api/v1/teams/%s", server.URL, "non-admin-team")
+				Context("when a pipelines repo is given", func() {
+					BeforeEach(func() {
+						atcTeam.PipelinesRepo = &atc.PipelinesRepo{
+							URI:  "https://github.com/example/pipelines.git",
+							Path: "ci",
+						}
+					})
 
-				var err error
-				request, err = http.NewRequest("DELETE", path, nil)
-				Expect(err).NotTo(HaveOccurred())
+					It("updates the pipelines repo", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("pipelines_repo"))
+						Expect(updatedSettings().PipelinesRepo).To(Equal(&atc.PipelinesRepo{
+							URI:  "https://github.com/example/pipelines.git",
+							Path: "ci",
+						}))
+					})
 
-				response, err = client.Do(request)
-				Expect(err).NotTo(HaveOccurred())
+					Context("when the pipelines repo is invalid", func() {
+						BeforeEach(func() {
+							atcTeam.PipelinesRepo.Path = "../elsewhere"
+						})
 
-			})
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(updatedFields()).ToNot(ContainElement("pipelines_repo"))
+						})
+					})
+				})
 
-			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAdminReturns(false)
-			})
+				Context("when concurrency pools are given", func() {
+					BeforeEach(func() {
+						atcTeam.ConcurrencyPools = atc.ConcurrencyPools{"integration-db": 3}
+					})
 
-			It("returns 403 forbidden", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
-			})
-		})
-	})
+					It("updates the concurrency pools", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("concurrency_pools"))
+						Expect(updatedSettings().ConcurrencyPools).To(Equal(atc.ConcurrencyPools{"integration-db": 3}))
+					})
 
-	Describe("PUT /api/v1/teams/:team_name/rename", func() {
-		var response *http.Response
-		var teamName string
+					Context("when a limit is not positive", func() {
+						BeforeEach(func() {
+							atcTeam.ConcurrencyPools["integration-db"] = 0
+						})
 
-		JustBeforeEach(func() {
-			request, err := http.NewRequest(
-				"PUT",
-				server.URL+"/api/v1/teams/"+teamName+"/rename",
-				bytes.NewBufferString(`{"name":"some-new-name"}`),
-			)
-			Expect(err).NotTo(HaveOccurred())
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(updatedFields()).ToNot(ContainElement("concurrency_pools"))
+						})
+					})
+				})
 
-			response, err = client.Do(request)
-			Expect(err).NotTo(HaveOccurred())
-		})
+				Context("when updating the CA certs fails", func() {
+					BeforeEach(func() {
+						atcTeam.CACerts = "some-ca-cert"
+						fakeTeam.UpdateSettingsReturns(errors.New("nope"))
+					})
 
-		BeforeEach(func() {
-			fakeTeam.IDReturns(2)
-		})
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		}
 
-		Context("when authenticated", func() {
+		Context("when the requester team is authorized as an admin team", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
 			})
-			Context("when requester belongs to an admin team", func() {
+
+			authorizedTeamTests()
+
+			Context("when the team exists and an admin-only setting is cleared", func() {
 				BeforeEach(func() {
-					teamName = "a-team"
-					fakeTeam.NameReturns(teamName)
-					fakeaccess.IsAdminReturns(true)
+					fakeTeam.CheckPolicyReturns(&atc.CheckPolicy{MinEvery: "5m"})
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					atcTeam.Clear = []string{"check_policy"}
 				})
 
-				It("constructs teamDB with provided team name", func() {
-					Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
-					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+				It("resets it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("check_policy"))
+					Expect(updatedSettings().CheckPolicy).To(BeNil())
 				})
+			})
 
-				It("renames the team to the name provided", func() {
-					Expect(fakeTeam.RenameCallCount()).To(Equal(1))
-					Expect(fakeTeam.RenameArgsForCall(0)).To(Equal("some-new-name"))
+			Context("when a max build log size is given", func() {
+				BeforeEach(func() {
+					atcTeam.MaxBuildLogSize = 1024 * 1024
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
-				It("returns 204 no content", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				It("updates the max build log size", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("max_build_log_size"))
+					Expect(updatedSettings().MaxBuildLogSize).To(Equal(int64(1024 * 1024)))
+				})
+
+				Context("when updating the max build log size fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateSettingsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
 				})
 			})
 
-			Context("when requester belongs to the team", func() {
+			Context("when the max build log size is cleared", func() {
 				BeforeEach(func() {
-					teamName = "a-team"
-					fakeTeam.NameReturns(teamName)
-					fakeaccess.IsAuthorizedReturns(true)
+					fakeTeam.MaxBuildLogSizeReturns(1024)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					atcTeam.Clear = []string{"max_build_log_size"}
 				})
 
-				It("constructs teamDB with provided team name", func() {
-					Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
-					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+				It("lifts the limit", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("max_build_log_size"))
+					Expect(updatedSettings().MaxBuildLogSize).To(BeZero())
 				})
+			})
 
-				It("renames the team to the name provided", func() {
-					Expect(fakeTeam.RenameCallCount()).To(Equal(1))
-					Expect(fakeTeam.RenameArgsForCall(0)).To(Equal("some-new-name"))
+			Context("when a max build starts per minute is given", func() {
+				BeforeEach(func() {
+					atcTeam.MaxBuildStartsPerMinute = 10
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the max build starts per minute", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("max_build_starts_per_minute"))
+					Expect(updatedSettings().MaxBuildStartsPerMinute).To(Equal(10))
+				})
+
+				Context("when updating the max build starts per minute fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateSettingsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when a check policy is given", func() {
+				BeforeEach(func() {
+					atcTeam.CheckPolicy = &atc.CheckPolicy{MinEvery: "5m", MaxConcurrent: 10}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the check policy", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("check_policy"))
+					Expect(updatedSettings().CheckPolicy).To(Equal(&atc.CheckPolicy{MinEvery: "5m", MaxConcurrent: 10}))
+				})
+
+				Context("when the check policy is invalid", func() {
+					BeforeEach(func() {
+						atcTeam.CheckPolicy = &atc.CheckPolicy{MinEvery: "1h", MaxEvery: "1m"}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(updatedFields()).ToNot(ContainElement("check_policy"))
+					})
+				})
+
+				Context("when updating the check policy fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateSettingsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when a content scan policy is given", func() {
+				BeforeEach(func() {
+					atcTeam.ContentScanPolicy = atc.ContentScanPolicyBlock
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the content scan policy", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("content_scan_policy"))
+					Expect(updatedSettings().ContentScanPolicy).To(Equal(atc.ContentScanPolicyBlock))
+				})
+
+				Context("when the content scan policy is unknown", func() {
+					BeforeEach(func() {
+						atcTeam.ContentScanPolicy = "ignore"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(updatedFields()).ToNot(ContainElement("content_scan_policy"))
+					})
+				})
+			})
+
+			Context("when resource type mappings are given", func() {
+				BeforeEach(func() {
+					atcTeam.ResourceTypeMappings = atc.ResourceTypeMappings{
+						"git": {Type: "registry-image", Source: atc.Source{"repository": "internal/git-resource"}},
+					}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the resource type mappings", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("resource_type_mappings"))
+					Expect(updatedSettings().ResourceTypeMappings).To(Equal(atc.ResourceTypeMappings{
+						"git": {Type: "registry-image", Source: atc.Source{"repository": "internal/git-resource"}},
+					}))
+				})
+
+				Context("when a mapping is missing its type", func() {
+					BeforeEach(func() {
+						atcTeam.ResourceTypeMappings = atc.ResourceTypeMappings{"git": {}}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(updatedFields()).ToNot(ContainElement("resource_type_mappings"))
+					})
+				})
+			})
+
+			Context("when a privileged policy is given", func() {
+				BeforeEach(func() {
+					atcTeam.PrivilegedPolicy = &atc.PrivilegedPolicy{Allow: []string{"infra/*"}}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the privileged policy", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("privileged_policy"))
+					Expect(updatedSettings().PrivilegedPolicy).To(Equal(&atc.PrivilegedPolicy{Allow: []string{"infra/*"}}))
+				})
+
+				Context("when an entry is not a valid glob", func() {
+					BeforeEach(func() {
+						atcTeam.PrivilegedPolicy = &atc.PrivilegedPolicy{Allow: []string{"infra/["}}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(updatedFields()).ToNot(ContainElement("privileged_policy"))
+					})
+				})
+			})
+
+			Context("when image digests are required", func() {
+				BeforeEach(func() {
+					atcTeam.RequireImageDigests = true
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the team to require image digests", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(updatedFields()).To(ContainElement("require_image_digests"))
+					Expect(updatedSettings().RequireImageDigests).To(BeTrue())
+				})
+
+				Context("when the default task image is not pinned by digest", func() {
+					BeforeEach(func() {
+						atcTeam.DefaultTaskImage = &atc.ImageResource{
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "some/image"},
+						}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(updatedFields()).ToNot(ContainElement("require_image_digests"))
+					})
+				})
+
+				Context("when the default task image is pinned by digest", func() {
+					BeforeEach(func() {
+						atcTeam.DefaultTaskImage = &atc.ImageResource{
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "some/image@sha256:abc123"},
+						}
+					})
+
+					It("updates the team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).To(ContainElement("require_image_digests"))
+					})
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+					dbTeamFactory.CreateTeamReturns(fakeTeam, nil)
+				})
+
+				It("creates the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(dbTeamFactory.CreateTeamCallCount()).To(Equal(1))
+
+					createdTeam := dbTeamFactory.CreateTeamArgsForCall(0)
+					Expect(createdTeam).To(Equal(atc.Team{
+						Name: "some-team",
+					}))
+				})
+
+				Context("when it fails to create team", func() {
+					BeforeEach(func() {
+						dbTeamFactory.CreateTeamReturns(nil, errors.New("it is never going to happen"))
+					})
+
+					It("returns a 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when a deleted team has the name", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindDeletedTeamReturns(fakeTeam, true, nil)
+					})
+
+					It("returns 409 Conflict and does not create the team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(dbTeamFactory.CreateTeamCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
+		Context("when the requester team is authorized as the team being set", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			authorizedTeamTests()
+
+			Context("when the team has a max build log size", func() {
+				BeforeEach(func() {
+					fakeTeam.MaxBuildLogSizeReturns(1024)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the request gives the same max build log size", func() {
+					BeforeEach(func() {
+						atcTeam.MaxBuildLogSize = 1024
+					})
+
+					It("leaves the max build log size unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_log_size"))
+					})
+				})
+
+				Context("when the request changes the max build log size", func() {
+					BeforeEach(func() {
+						atcTeam.MaxBuildLogSize = 1024 * 1024
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_log_size"))
+					})
+				})
+
+				Context("when the request lifts the max build log size", func() {
+					BeforeEach(func() {
+						atcTeam.Clear = []string{"max_build_log_size"}
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_log_size"))
+					})
+				})
+			})
+
+			Context("when the team has a max build starts per minute", func() {
+				BeforeEach(func() {
+					fakeTeam.MaxBuildStartsPerMinuteReturns(10)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the request gives the same max build starts per minute", func() {
+					BeforeEach(func() {
+						atcTeam.MaxBuildStartsPerMinute = 10
+					})
+
+					It("leaves the max build starts per minute unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_starts_per_minute"))
+					})
+				})
+
+				Context("when the request changes the max build starts per minute", func() {
+					BeforeEach(func() {
+						atcTeam.MaxBuildStartsPerMinute = 100
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_starts_per_minute"))
+					})
+				})
+
+				Context("when the request lifts the max build starts per minute", func() {
+					BeforeEach(func() {
+						atcTeam.Clear = []string{"max_build_starts_per_minute"}
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("max_build_starts_per_minute"))
+					})
+				})
+			})
+
+			Context("when the team has a check policy", func() {
+				BeforeEach(func() {
+					fakeTeam.CheckPolicyReturns(&atc.CheckPolicy{MinEvery: "5m"})
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the request leaves out the check policy", func() {
+					It("leaves the check policy unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("check_policy"))
+					})
+				})
+
+				Context("when the request gives the same check policy", func() {
+					BeforeEach(func() {
+						atcTeam.CheckPolicy = &atc.CheckPolicy{MinEvery: "5m"}
+					})
+
+					It("leaves the check policy unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("check_policy"))
+					})
+				})
+
+				Context("when the request changes the check policy", func() {
+					BeforeEach(func() {
+						atcTeam.CheckPolicy = &atc.CheckPolicy{MinEvery: "10s"}
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("check_policy"))
+					})
+				})
+
+				Context("when the request clears the check policy", func() {
+					BeforeEach(func() {
+						atcTeam.Clear = []string{"check_policy"}
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("check_policy"))
+					})
+				})
+			})
+
+			Context("when the team has a content scan policy", func() {
+				BeforeEach(func() {
+					fakeTeam.ContentScanPolicyReturns(atc.ContentScanPolicyBlock)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the request leaves out the content scan policy", func() {
+					It("leaves the content scan policy unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("content_scan_policy"))
+					})
+				})
+
+				Context("when the request changes the content scan policy", func() {
+					BeforeEach(func() {
+						atcTeam.ContentScanPolicy = atc.ContentScanPolicyWarn
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("content_scan_policy"))
+					})
+				})
+			})
+
+			Context("when the team has resource type mappings", func() {
+				BeforeEach(func() {
+					fakeTeam.ResourceTypeMappingsReturns(atc.ResourceTypeMappings{
+						"git": {Type: "registry-image", Source: atc.Source{"repository": "internal/git-resource"}},
+					})
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the request leaves out the resource type mappings", func() {
+					It("leaves the resource type mappings unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("resource_type_mappings"))
+					})
+				})
+
+				Context("when the request changes the resource type mappings", func() {
+					BeforeEach(func() {
+						atcTeam.ResourceTypeMappings = atc.ResourceTypeMappings{
+							"git": {Type: "registry-image", Source: atc.Source{"repository": "someone-else/git-resource"}},
+						}
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("resource_type_mappings"))
+					})
+				})
+			})
+
+			Context("when the team has a privileged policy", func() {
+				BeforeEach(func() {
+					fakeTeam.PrivilegedPolicyReturns(&atc.PrivilegedPolicy{Allow: []string{"infra"}})
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the request leaves out the privileged policy", func() {
+					It("leaves the privileged policy unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("privileged_policy"))
+					})
+				})
+
+				Context("when the request changes the privileged policy", func() {
+					BeforeEach(func() {
+						atcTeam.PrivilegedPolicy = &atc.PrivilegedPolicy{Allow: []string{"*"}}
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(updatedFields()).ToNot(ContainElement("auth"))
+						Expect(updatedFields()).ToNot(ContainElement("privileged_policy"))
+					})
+				})
+			})
+
+			Context("when the request requires image digests", func() {
+				BeforeEach(func() {
+					atcTeam.RequireImageDigests = true
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(updatedFields()).ToNot(ContainElement("auth"))
+					Expect(updatedFields()).ToNot(ContainElement("require_image_digests"))
+				})
+
+				Context("when the team already requires image digests", func() {
+					BeforeEach(func() {
+						fakeTeam.RequireImageDigestsReturns(true)
+					})
+
+					It("leaves the requirement unchanged", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(updatedFields()).ToNot(ContainElement("require_image_digests"))
+					})
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+					dbTeamFactory.CreateTeamReturns(fakeTeam, nil)
+				})
+
+				It("does not create the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbTeamFactory.CreateTeamCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name", func() {
+		var request *http.Request
+		var response *http.Response
+
+		var teamName string
+
+		BeforeEach(func() {
+			teamName = "team venture"
+
+			fakeTeam.IDReturns(2)
+			fakeTeam.NameReturns(teamName)
+		})
+
+		Context("when the requester is authenticated for some admin team", func() {
+			JustBeforeEach(func() {
+				path := fmt.Sprintf("%s/api/v1/teams/%s", server.URL, teamName)
+
+				var err error
+				request, err = http.NewRequest("DELETE", path, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+			})
+
+			Context("when there's a problem finding teams", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, errors.New("a dingo ate my baby!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when team exists", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				It("receives the correct team name", func() {
+					Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal(teamName))
+				})
+				It("soft deletes the team, keeping it around to be restored", func() {
+					Expect(fakeTeam.SoftDeleteCallCount()).To(Equal(1))
+					Expect(fakeTeam.DeleteCallCount()).To(Equal(0))
+				})
+
+				Context("when trying to delete the admin team", func() {
+					BeforeEach(func() {
+						teamName = atc.DefaultTeamName
+						fakeTeam.AdminReturns(true)
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						dbTeamFactory.GetTeamsReturns([]db.Team{fakeTeam}, nil)
+					})
+
+					It("returns 403 Forbidden and backs off", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(fakeTeam.SoftDeleteCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when there's a problem deleting the team", func() {
+					BeforeEach(func() {
+						fakeTeam.SoftDeleteReturns(errors.New("disaster"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the requester belongs to a non-admin team", func() {
+			JustBeforeEach(func() {
+				path := fmt.Sprintf("%s/api/v1/teams/%s", server.URL, "non-admin-team")
+
+				var err error
+				request, err = http.NewRequest("DELETE", path, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+
+			})
+
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(false)
+			})
+
+			It("returns 403 forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/restore", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/restore", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the requester is an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+			})
+
+			Context("when the team has been deleted", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindDeletedTeamReturns(fakeTeam, true, nil)
+					fakeTeam.RestoreReturns(true, nil)
+				})
+
+				It("looks up the deleted team by name", func() {
+					Expect(dbTeamFactory.FindDeletedTeamCallCount()).To(Equal(1))
+					Expect(dbTeamFactory.FindDeletedTeamArgsForCall(0)).To(Equal("some-team"))
+				})
+
+				It("restores it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(fakeTeam.RestoreCallCount()).To(Equal(1))
+				})
+
+				Context("when it is no longer deleted by the time it is restored", func() {
+					BeforeEach(func() {
+						fakeTeam.RestoreReturns(false, nil)
+					})
+
+					It("returns 404 Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when restoring it fails", func() {
+					BeforeEach(func() {
+						fakeTeam.RestoreReturns(false, errors.New("disaster"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team has not been deleted", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindDeletedTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the deleted team fails", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindDeletedTeamReturns(nil, false, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the requester is not an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeamFactory.FindDeletedTeamCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/rename", func() {
+		var response *http.Response
+		var teamName string
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/"+teamName+"/rename",
+				bytes.NewBufferString(`{"name":"some-new-name"}`),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeTeam.IDReturns(2)
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+			Context("when requester belongs to an admin team", func() {
+				BeforeEach(func() {
+					teamName = "a-team"
+					fakeTeam.NameReturns(teamName)
+					fakeaccess.IsAdminReturns(true)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("constructs teamDB with provided team name", func() {
+					Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+				})
+
+				It("renames the team to the name provided", func() {
+					Expect(fakeTeam.RenameCallCount()).To(Equal(1))
+					Expect(fakeTeam.RenameArgsForCall(0)).To(Equal("some-new-name"))
+				})
+
+				It("returns 204 no content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+			})
+
+			Context("when requester belongs to the team", func() {
+				BeforeEach(func() {
+					teamName = "a-team"
+					fakeTeam.NameReturns(teamName)
+					fakeaccess.IsAuthorizedReturns(true)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("constructs teamDB with provided team name", func() {
+					Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+				})
+
+				It("renames the team to the name provided", func() {
+					Expect(fakeTeam.RenameCallCount()).To(Equal(1))
+					Expect(fakeTeam.RenameArgsForCall(0)).To(Equal("some-new-name"))
 				})
 
 				It("returns 204 no content", func() {
@@ -589,190 +1444,645 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
-			Context("when requester does not belong to the team", func() {
+			Context("when requester does not belong to the team", func() {
+				BeforeEach(func() {
+					teamName = "a-team"
+					fakeTeam.NameReturns(teamName)
+					fakeaccess.IsAuthorizedReturns(false)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.RenameCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.RenameCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/builds", func() {
+		var (
+			response    *http.Response
+			queryParams string
+			teamName    string
+		)
+
+		BeforeEach(func() {
+			teamName = "some-team"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/" + teamName + "/builds" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.BuildsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when no params are passed", func() {
+				It("does not set defaults for since and until", func() {
+					Expect(fakeTeam.BuildsCallCount()).To(Equal(1))
+
+					page := fakeTeam.BuildsArgsForCall(0)
+					Expect(page).To(Equal(db.Page{
+						Since: 0,
+						Until: 0,
+						Limit: 100,
+					}))
+				})
+			})
+
+			Context("when all the params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?since=2&until=3&limit=8"
+				})
+
+				It("passes them through", func() {
+					Expect(fakeTeam.BuildsCallCount()).To(Equal(1))
+
+					page := fakeTeam.BuildsArgsForCall(0)
+					Expect(page).To(Equal(db.Page{
+						Since: 2,
+						Until: 3,
+						Limit: 8,
+					}))
+				})
+			})
+
+			Context("when getting the builds succeeds", func() {
+				var returnedBuilds []db.Build
+
+				BeforeEach(func() {
+					queryParams = "?since=5&limit=2"
+
+					build1 := new(dbfakes.FakeBuild)
+					build1.IDReturns(4)
+					build1.NameReturns("2")
+					build1.JobNameReturns("some-job")
+					build1.PipelineNameReturns("some-pipeline")
+					build1.TeamNameReturns("some-team")
+					build1.StatusReturns(db.BuildStatusStarted)
+					build1.StartTimeReturns(time.Unix(1, 0))
+					build1.EndTimeReturns(time.Unix(100, 0))
+
+					build2 := new(dbfakes.FakeBuild)
+					build2.IDReturns(2)
+					build2.NameReturns("1")
+					build2.JobNameReturns("some-job")
+					build2.PipelineNameReturns("some-pipeline")
+					build2.TeamNameReturns("some-team")
+					build2.StatusReturns(db.BuildStatusSucceeded)
+					build2.StartTimeReturns(time.Unix(101, 0))
+					build2.EndTimeReturns(time.Unix(200, 0))
+
+					returnedBuilds = []db.Build{build1, build2}
+					fakeTeam.BuildsReturns(returnedBuilds, db.Pagination{}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the builds", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+					{
+						"id": 4,
+						"name": "2",
+						"job_name": "some-job",
+						"status": "started",
+						"api_url": "/api/v1/builds/4",
+						"pipeline_name":"some-pipeline",
+						"team_name": "some-team",
+						"start_time": 1,
+						"end_time": 100
+					},
+					{
+						"id": 2,
+						"name": "1",
+						"job_name": "some-job",
+						"status": "succeeded",
+						"api_url": "/api/v1/builds/2",
+						"pipeline_name": "some-pipeline",
+						"team_name": "some-team",
+						"start_time": 101,
+						"end_time": 200
+					}
+				]`))
+				})
+
+				Context("when next/previous pages are available", func() {
+					BeforeEach(func() {
+						fakeTeam.BuildsReturns(returnedBuilds, db.Pagination{
+							Previous: &db.Page{Until: 4, Limit: 2},
+							Next:     &db.Page{Since: 2, Limit: 2},
+						}, nil)
+					})
+
+					It("returns Link headers per rfc5988", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/teams/some-team/builds?until=4&limit=2>; rel="previous"`, externalURL),
+							fmt.Sprintf(`<%s/api/v1/teams/some-team/builds?since=2&limit=2>; rel="next"`, externalURL),
+						}))
+					})
+				})
+			})
+
+			Context("when getting the build fails", func() {
+				BeforeEach(func() {
+					fakeTeam.BuildsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines-repo/status", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines-repo/status")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when the team has no pipelines repo", func() {
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the team has a pipelines repo", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelinesRepoReturns(&atc.PipelinesRepo{URI: "https://example.com/pipelines.git"})
+					fakeTeam.PipelinesRepoStatusReturns(&atc.PipelinesRepoStatus{
+						Revision:     "abc123",
+						ReconciledAt: 42,
+						Pipelines: []atc.PipelineReconciliation{
+							{Name: "some-pipeline", Status: atc.PipelineInSync},
+							{Name: "other-pipeline", Status: atc.PipelineInvalid, Errors: []string{"malformed config"}},
+						},
+					})
+				})
+
+				It("returns the status of the last reconciliation", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"revision": "abc123",
+						"reconciled_at": 42,
+						"pipelines": [
+							{"name": "some-pipeline", "status": "in-sync"},
+							{"name": "other-pipeline", "status": "invalid", "errors": ["malformed config"]}
+						]
+					}`))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/resource-type-mappings", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/resource-type-mappings")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when the team has no mappings of its own", func() {
+				It("returns the installation's mappings", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"git": {"type": "registry-image", "source": {"repository": "installation/git-resource"}},
+						"s3": {"type": "registry-image", "source": {"repository": "installation/s3-resource"}}
+					}`))
+				})
+			})
+
+			Context("when the team overrides a mapping", func() {
 				BeforeEach(func() {
-					teamName = "a-team"
-					fakeTeam.NameReturns(teamName)
-					fakeaccess.IsAuthorizedReturns(false)
-					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					fakeTeam.ResourceTypeMappingsReturns(atc.ResourceTypeMappings{
+						"git": {Type: "registry-image", Source: atc.Source{"repository": "team/git-resource"}, Privileged: true},
+					})
 				})
 
-				It("returns 403 Forbidden", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
-					Expect(fakeTeam.RenameCallCount()).To(Equal(0))
+				It("returns the team's mapping in place of the installation's", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"git": {"type": "registry-image", "source": {"repository": "team/git-resource"}, "privileged": true},
+						"s3": {"type": "registry-image", "source": {"repository": "installation/s3-resource"}}
+					}`))
 				})
 			})
 		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/resource-type-health", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/resource-type-health")
+			Expect(err).NotTo(HaveOccurred())
+		})
 
-		Context("when not authenticated", func() {
+		Context("when not authorized", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(false)
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
 			})
 
-			It("returns 401 Unauthorized", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-				Expect(fakeTeam.RenameCallCount()).To(Equal(0))
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.ResourceTypeHealthReturns([]atc.ResourceTypeHealth{
+					{
+						Type:                 "slack-notification",
+						Pipelines:            12,
+						Resources:            14,
+						Checks:               40,
+						FailedChecks:         30,
+						CheckFailureRate:     0.75,
+						AverageCheckDuration: 12.5,
+						Fetches:              4,
+						FailedFetches:        1,
+						FetchFailureRate:     0.25,
+						AverageFetchDuration: 3,
+						MaxFetchDuration:     6,
+					},
+				}, nil)
+			})
+
+			It("returns the health of each resource type", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{
+						"type": "slack-notification",
+						"pipelines": 12,
+						"resources": 14,
+						"checks": 40,
+						"failed_checks": 30,
+						"check_failure_rate": 0.75,
+						"average_check_duration": 12.5,
+						"fetches": 4,
+						"failed_fetches": 1,
+						"fetch_failure_rate": 0.25,
+						"average_fetch_duration": 3,
+						"max_fetch_duration": 6
+					}
+				]`))
+			})
+
+			Context("when getting the health fails", func() {
+				BeforeEach(func() {
+					fakeTeam.ResourceTypeHealthReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
 			})
 		})
 	})
 
-	Describe("GET /api/v1/teams/:team_name/builds", func() {
+	Describe("GET /api/v1/teams/:team_name/api-tokens", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/api-tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.APITokensReturns([]atc.APIToken{
+					{
+						ID:        1,
+						Name:      "deployer",
+						TeamName:  "some-team",
+						Scopes:    []atc.APITokenScope{{Action: atc.CreateJobBuild, Pipeline: "some-pipeline"}},
+						ExpiresAt: 2000,
+						CreatedBy: "some-user",
+						CreatedAt: 1000,
+						RevokedAt: 1500,
+					},
+				}, nil)
+			})
+
+			It("returns the team's tokens without the tokens themselves", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[{
+					"id": 1,
+					"name": "deployer",
+					"team_name": "some-team",
+					"scopes": [{"action": "CreateJobBuild", "pipeline": "some-pipeline"}],
+					"expires_at": 2000,
+					"created_by": "some-user",
+					"created_at": 1000,
+					"revoked_at": 1500
+				}]`))
+			})
+
+			Context("when getting the tokens fails", func() {
+				BeforeEach(func() {
+					fakeTeam.APITokensReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/api-tokens", func() {
 		var (
-			response    *http.Response
-			queryParams string
-			teamName    string
+			request  atc.APITokenRequest
+			response *http.Response
 		)
 
 		BeforeEach(func() {
-			teamName = "some-team"
+			request = atc.APITokenRequest{
+				Name:      "deployer",
+				Scopes:    []atc.APITokenScope{{Action: atc.CreateJobBuild, Pipeline: "some-pipeline"}},
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			}
 		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/teams/" + teamName + "/builds" + queryParams)
+			response, err = client.Post(server.URL+"/api/v1/teams/some-team/api-tokens", "application/json", jsonEncode(request))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		Context("when not authenticated", func() {
+		Context("when not authorized", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(false)
-				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
 			})
 
-			It("returns 401", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-				Expect(fakeTeam.BuildsCallCount()).To(Equal(0))
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.CreateAPITokenCallCount()).To(BeZero())
 			})
 		})
 
-		Context("when authenticated", func() {
+		Context("when authorized", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.UserNameReturns("some-user")
 				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.NameReturns("some-team")
+				fakeTeam.CreateAPITokenReturns(atc.APIToken{
+					ID:        7,
+					Name:      "deployer",
+					TeamName:  "some-team",
+					Scopes:    request.Scopes,
+					ExpiresAt: request.ExpiresAt,
+					CreatedBy: "some-user",
+					CreatedAt: 1000,
+				}, nil)
+
+				fakeTokenGenerator.GenerateReturns(&oauth2.Token{AccessToken: "some-token"}, nil)
 			})
 
-			Context("when no params are passed", func() {
-				It("does not set defaults for since and until", func() {
-					Expect(fakeTeam.BuildsCallCount()).To(Equal(1))
+			It("saves the token as created by the user", func() {
+				Expect(fakeTeam.CreateAPITokenCallCount()).To(Equal(1))
 
-					page := fakeTeam.BuildsArgsForCall(0)
-					Expect(page).To(Equal(db.Page{
-						Since: 0,
-						Until: 0,
-						Limit: 100,
-					}))
-				})
+				saved, createdBy := fakeTeam.CreateAPITokenArgsForCall(0)
+				Expect(saved).To(Equal(request))
+				Expect(createdBy).To(Equal("some-user"))
 			})
 
-			Context("when all the params are passed", func() {
-				BeforeEach(func() {
-					queryParams = "?since=2&until=3&limit=8"
-				})
+			It("mints a token expiring with it and limited to its scopes", func() {
+				Expect(fakeTokenGenerator.GenerateCallCount()).To(Equal(1))
+				Expect(fakeTokenGenerator.GenerateArgsForCall(0)).To(Equal(map[string]interface{}{
+					"exp":       request.ExpiresAt,
+					"user_name": "api-token:deployer",
+					"api_token": map[string]interface{}{
+						"id":     7,
+						"team":   "some-team",
+						"scopes": request.Scopes,
+					},
+				}))
+			})
 
-				It("passes them through", func() {
-					Expect(fakeTeam.BuildsCallCount()).To(Equal(1))
+			It("returns 201 with the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
 
-					page := fakeTeam.BuildsArgsForCall(0)
-					Expect(page).To(Equal(db.Page{
-						Since: 2,
-						Until: 3,
-						Limit: 8,
-					}))
-				})
-			})
+				var created atc.CreatedAPIToken
+				err := json.NewDecoder(response.Body).Decode(&created)
+				Expect(err).NotTo(HaveOccurred())
 
-			Context("when getting the builds succeeds", func() {
-				var returnedBuilds []db.Build
+				Expect(created.ID).To(Equal(7))
+				Expect(created.Token).To(Equal("some-token"))
+			})
 
+			Context("when the request is invalid", func() {
 				BeforeEach(func() {
-					queryParams = "?since=5&limit=2"
+					request.Scopes = []atc.APITokenScope{{Action: atc.CreateAPIToken}}
+				})
 
-					build1 := new(dbfakes.FakeBuild)
-					build1.IDReturns(4)
-					build1.NameReturns("2")
-					build1.JobNameReturns("some-job")
-					build1.PipelineNameReturns("some-pipeline")
-					build1.TeamNameReturns("some-team")
-					build1.StatusReturns(db.BuildStatusStarted)
-					build1.StartTimeReturns(time.Unix(1, 0))
-					build1.EndTimeReturns(time.Unix(100, 0))
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
 
-					build2 := new(dbfakes.FakeBuild)
-					build2.IDReturns(2)
-					build2.NameReturns("1")
-					build2.JobNameReturns("some-job")
-					build2.PipelineNameReturns("some-pipeline")
-					build2.TeamNameReturns("some-team")
-					build2.StatusReturns(db.BuildStatusSucceeded)
-					build2.StartTimeReturns(time.Unix(101, 0))
-					build2.EndTimeReturns(time.Unix(200, 0))
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("scopes[0] cannot allow managing api tokens"))
 
-					returnedBuilds = []db.Build{build1, build2}
-					fakeTeam.BuildsReturns(returnedBuilds, db.Pagination{}, nil)
+					Expect(fakeTeam.CreateAPITokenCallCount()).To(BeZero())
 				})
+			})
 
-				It("returns 200 OK", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Context("when a token with the same name exists", func() {
+				BeforeEach(func() {
+					fakeTeam.CreateAPITokenReturns(atc.APIToken{}, db.ErrAPITokenExists)
 				})
 
-				It("returns Content-Type 'application/json'", func() {
-					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(fakeTokenGenerator.GenerateCallCount()).To(BeZero())
 				})
+			})
 
-				It("returns the builds", func() {
-					body, err := ioutil.ReadAll(response.Body)
-					Expect(err).NotTo(HaveOccurred())
+			Context("when minting the token fails", func() {
+				BeforeEach(func() {
+					fakeTokenGenerator.GenerateReturns(nil, errors.New("nope"))
+				})
 
-					Expect(body).To(MatchJSON(`[
-					{
-						"id": 4,
-						"name": "2",
-						"job_name": "some-job",
-						"status": "started",
-						"api_url": "/api/v1/builds/4",
-						"pipeline_name":"some-pipeline",
-						"team_name": "some-team",
-						"start_time": 1,
-						"end_time": 100
-					},
-					{
-						"id": 2,
-						"name": "1",
-						"job_name": "some-job",
-						"status": "succeeded",
-						"api_url": "/api/v1/builds/2",
-						"pipeline_name": "some-pipeline",
-						"team_name": "some-team",
-						"start_time": 101,
-						"end_time": 200
-					}
-				]`))
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 				})
+			})
+		})
+	})
 
-				Context("when next/previous pages are available", func() {
-					BeforeEach(func() {
-						fakeTeam.BuildsReturns(returnedBuilds, db.Pagination{
-							Previous: &db.Page{Until: 4, Limit: 2},
-							Next:     &db.Page{Since: 2, Limit: 2},
-						}, nil)
-					})
+	Describe("DELETE /api/v1/teams/:team_name/api-tokens/:api_token_name", func() {
+		var response *http.Response
 
-					It("returns Link headers per rfc5988", func() {
-						Expect(response.Header["Link"]).To(ConsistOf([]string{
-							fmt.Sprintf(`<%s/api/v1/teams/some-team/builds?until=4&limit=2>; rel="previous"`, externalURL),
-							fmt.Sprintf(`<%s/api/v1/teams/some-team/builds?since=2&limit=2>; rel="next"`, externalURL),
-						}))
-					})
-				})
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/api-tokens/deployer", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
 			})
 
-			Context("when getting the build fails", func() {
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.RevokeAPITokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.RevokeAPITokenReturns(true, nil)
+			})
+
+			It("revokes the token and returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeTeam.RevokeAPITokenCallCount()).To(Equal(1))
+				Expect(fakeTeam.RevokeAPITokenArgsForCall(0)).To(Equal("deployer"))
+			})
+
+			Context("when there is no such token", func() {
 				BeforeEach(func() {
-					fakeTeam.BuildsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+					fakeTeam.RevokeAPITokenReturns(false, nil)
 				})
 
-				It("returns 404 Not Found", func() {
+				It("returns 404", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 				})
 			})
@@ -0,0 +1,119 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListAPITokens(team db.Team) http.Handler {
+	logger := s.logger.Session("list-api-tokens")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens, err := team.APITokens()
+		if err != nil {
+			logger.Error("failed-to-get-api-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(tokens)
+		if err != nil {
+			logger.Error("failed-to-encode-api-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) CreateAPIToken(team db.Team) http.Handler {
+	logger := s.logger.Session("create-api-token")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request atc.APITokenRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		errorMessages := request.Validate(time.Now())
+		if len(errorMessages) > 0 {
+			logger.Info("invalid-api-token", lager.Data{"errors": errorMessages})
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(strings.Join(errorMessages, "\n")))
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		apiToken, err := team.CreateAPIToken(request, acc.UserName())
+		if err != nil {
+			if err == db.ErrAPITokenExists {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+
+			logger.Error("failed-to-create-api-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		token, err := s.tokenGenerator.Generate(map[string]interface{}{
+			"exp":       apiToken.ExpiresAt,
+			"user_name": fmt.Sprintf("api-token:%s", apiToken.Name),
+			"api_token": map[string]interface{}{
+				"id":     apiToken.ID,
+				"team":   team.Name(),
+				"scopes": apiToken.Scopes,
+			},
+		})
+		if err != nil {
+			logger.Error("failed-to-generate-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(atc.CreatedAPIToken{
+			APIToken: apiToken,
+			Token:    token.AccessToken,
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-api-token", err)
+		}
+	})
+}
+
+func (s *Server) RevokeAPIToken(team db.Team) http.Handler {
+	logger := s.logger.Session("revoke-api-token")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":api_token_name")
+
+		revoked, err := team.RevokeAPIToken(name)
+		if err != nil {
+			logger.Error("failed-to-revoke-api-token", err, lager.Data{"name": name})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !revoked {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
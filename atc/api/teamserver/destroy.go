@@ -54,7 +54,9 @@ func (s *Server) DestroyTeam(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	err = team.Delete()
+	// the team is only soft deleted, so that it can be restored until it is
+	// purged after the grace period
+	err = team.SoftDelete()
 	if err != nil {
 		hLog.Error("failed-to-delete-team", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,32 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetPipelinesRepoStatus(team db.Team) http.Handler {
+	hLog := s.logger.Session("get-pipelines-repo-status")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if team.PipelinesRepo() == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		status := atc.PipelinesRepoStatus{}
+		if team.PipelinesRepoStatus() != nil {
+			status = *team.PipelinesRepoStatus()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(status)
+		if err != nil {
+			hLog.Error("failed-to-encode-pipelines-repo-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
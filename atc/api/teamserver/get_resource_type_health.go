@@ -0,0 +1,31 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetResourceTypeHealth responds with how the checks and fetches of each type
+// of resource in the team's pipelines have been faring, so that a type which
+// is misbehaving across many pipelines stands out.
+func (s *Server) GetResourceTypeHealth(team db.Team) http.Handler {
+	hLog := s.logger.Session("get-resource-type-health")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health, err := team.ResourceTypeHealth()
+		if err != nil {
+			hLog.Error("failed-to-get-resource-type-health", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(health)
+		if err != nil {
+			hLog.Error("failed-to-encode-resource-type-health", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
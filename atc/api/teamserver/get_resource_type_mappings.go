@@ -0,0 +1,29 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetResourceTypeMappings responds with the mappings applied to the team's
+// resources: the installation's, overridden by the team's own.
+func (s *Server) GetResourceTypeMappings(team db.Team) http.Handler {
+	hLog := s.logger.Session("get-resource-type-mappings")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mappings := s.resourceTypeMappings.Override(team.ResourceTypeMappings())
+		if mappings == nil {
+			mappings = atc.ResourceTypeMappings{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(mappings)
+		if err != nil {
+			hLog.Error("failed-to-encode-resource-type-mappings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package teamserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+func (s *Server) RestoreTeam(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("restore-team")
+	hLog.Debug("restoring-team")
+
+	teamName := r.FormValue(":team_name")
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsAdmin() {
+		hLog.Info("requesting-team-is-not-admin")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindDeletedTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-deleted-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("deleted-team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	restored, err := team.Restore()
+	if err != nil {
+		hLog.Error("failed-to-restore-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// purged or restored by someone else in the meantime
+	if !restored {
+		hLog.Info("team-no-longer-deleted")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,23 +2,33 @@ package teamserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/skymarshal/token"
 )
 
 type Server struct {
-	logger      lager.Logger
-	teamFactory db.TeamFactory
-	externalURL string
+	logger         lager.Logger
+	teamFactory    db.TeamFactory
+	externalURL    string
+	tokenGenerator token.Generator
+
+	resourceTypeMappings atc.ResourceTypeMappings
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	externalURL string,
+	tokenGenerator token.Generator,
+	resourceTypeMappings atc.ResourceTypeMappings,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		teamFactory: teamFactory,
-		externalURL: externalURL,
+		logger:         logger,
+		teamFactory:    teamFactory,
+		externalURL:    externalURL,
+		tokenGenerator: tokenGenerator,
+
+		resourceTypeMappings: resourceTypeMappings,
 	}
 }
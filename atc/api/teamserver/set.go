@@ -2,7 +2,9 @@ package teamserver
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"reflect"
 
 	"code.cloudfoundry.org/lager"
 
@@ -20,13 +22,39 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	teamName := r.FormValue(":team_name")
 
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		hLog.Error("failed-to-read-body", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	var atcTeam atc.Team
-	err := json.NewDecoder(r.Body).Decode(&atcTeam)
+	err = json.Unmarshal(payload, &atcTeam)
+	if err != nil {
+		hLog.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// settings left out of the request are left as they are, rather than
+	// being reset, so that they can be set one at a time
+	var given map[string]json.RawMessage
+	err = json.Unmarshal(payload, &given)
 	if err != nil {
 		hLog.Error("malformed-request", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+
+	for _, field := range atcTeam.Clear {
+		if _, found := given[field]; found {
+			hLog.Info("setting-both-given-and-cleared", lager.Data{"field": field})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
 	atcTeam.Name = teamName
 	if !acc.IsAdmin() && !acc.IsAuthorized(teamName) {
 		hLog.Debug("not-allowed")
@@ -34,6 +62,81 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if atcTeam.ContainerDNS != nil {
+		err = atcTeam.ContainerDNS.Validate()
+		if err != nil {
+			hLog.Info("invalid-container-dns", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if atcTeam.CheckPolicy != nil {
+		err = atcTeam.CheckPolicy.Validate()
+		if err != nil {
+			hLog.Info("invalid-check-policy", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if atcTeam.DefaultTaskImage != nil {
+		err = atcTeam.DefaultTaskImage.Validate()
+		if err != nil {
+			hLog.Info("invalid-default-task-image", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if atcTeam.PipelinesRepo != nil {
+		err = atcTeam.PipelinesRepo.Validate()
+		if err != nil {
+			hLog.Info("invalid-pipelines-repo", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if atcTeam.ContentScanPolicy != "" {
+		err = atcTeam.ContentScanPolicy.Validate()
+		if err != nil {
+			hLog.Info("invalid-content-scan-policy", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if atcTeam.PrivilegedPolicy != nil {
+		err = atcTeam.PrivilegedPolicy.Validate()
+		if err != nil {
+			hLog.Info("invalid-privileged-policy", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	// the team's default task image is run by its tasks like any other
+	if atcTeam.RequireImageDigests && atcTeam.DefaultTaskImage != nil && !atcTeam.DefaultTaskImage.PinnedByDigest() {
+		hLog.Info("default-task-image-not-pinned-by-digest")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = atcTeam.ConcurrencyPools.Validate()
+	if err != nil {
+		hLog.Info("invalid-concurrency-pools", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = atcTeam.ResourceTypeMappings.Validate()
+	if err != nil {
+		hLog.Info("invalid-resource-type-mappings", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		hLog.Error("failed-to-lookup-team", err, lager.Data{"teamName": teamName})
@@ -42,8 +145,98 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if found {
-		hLog.Debug("updating-credentials")
-		err = team.UpdateProviderAuth(atcTeam.Auth)
+		// the check policy is set by admins to protect the installation, so
+		// team members may leave it out but not change it
+		if !acc.IsAdmin() && atcTeam.CheckPolicy != nil && !reflect.DeepEqual(atcTeam.CheckPolicy, team.CheckPolicy()) {
+			hLog.Debug("not-allowed-to-change-check-policy")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// likewise for the max build log size, which protects the database
+		// from the team's build output
+		if !acc.IsAdmin() && atcTeam.MaxBuildLogSize != 0 && atcTeam.MaxBuildLogSize != team.MaxBuildLogSize() {
+			hLog.Debug("not-allowed-to-change-max-build-log-size")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// and for the max build starts per minute, which protects the workers
+		// from bursts of the team's builds
+		if !acc.IsAdmin() && atcTeam.MaxBuildStartsPerMinute != 0 && atcTeam.MaxBuildStartsPerMinute != team.MaxBuildStartsPerMinute() {
+			hLog.Debug("not-allowed-to-change-max-build-starts-per-minute")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// and for the content scan policy, so that a team cannot let
+		// through content which the installation blocks
+		if !acc.IsAdmin() && atcTeam.ContentScanPolicy != "" && atcTeam.ContentScanPolicy != team.ContentScanPolicy() {
+			hLog.Debug("not-allowed-to-change-content-scan-policy")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// and for the resource type mappings, which decide the images run
+		// for the team's resources
+		if !acc.IsAdmin() && len(atcTeam.ResourceTypeMappings) != 0 && !reflect.DeepEqual(atcTeam.ResourceTypeMappings, team.ResourceTypeMappings()) {
+			hLog.Debug("not-allowed-to-change-resource-type-mappings")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// and for the privileged policy, which protects the workers from the
+		// team's own pipelines
+		if !acc.IsAdmin() && atcTeam.PrivilegedPolicy != nil && !reflect.DeepEqual(atcTeam.PrivilegedPolicy, team.PrivilegedPolicy()) {
+			hLog.Debug("not-allowed-to-change-privileged-policy")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// and for requiring image digests
+		if !acc.IsAdmin() && atcTeam.RequireImageDigests && !team.RequireImageDigests() {
+			hLog.Debug("not-allowed-to-change-require-image-digests")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// a cleared setting is updated like one given as empty, which is
+		// what it is left as when it isn't given
+		cleared := map[string]bool{}
+		for _, field := range atcTeam.Clear {
+			if containsSetting(adminTeamSettings, field) {
+				if !acc.IsAdmin() {
+					hLog.Debug("not-allowed-to-clear", lager.Data{"field": field})
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+			} else if !containsSetting(teamSettings, field) {
+				hLog.Info("unknown-setting-to-clear", lager.Data{"field": field})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			cleared[field] = true
+		}
+
+		settings := teamSettings
+		if acc.IsAdmin() {
+			settings = append(append([]string{}, teamSettings...), adminTeamSettings...)
+		}
+
+		fields := []string{}
+		if _, found := given["auth"]; found {
+			fields = append(fields, "auth")
+		}
+
+		for _, field := range settings {
+			if _, found := given[field]; found || cleared[field] {
+				fields = append(fields, field)
+			}
+		}
+
+		hLog.Debug("updating-team", lager.Data{"fields": fields})
+		err = team.UpdateSettings(atcTeam, fields)
 		if err != nil {
 			hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -53,6 +246,19 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
+		_, deleted, err := s.teamFactory.FindDeletedTeam(teamName)
+		if err != nil {
+			hLog.Error("failed-to-lookup-deleted-team", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if deleted {
+			hLog.Info("team-is-deleted", lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		hLog.Debug("creating team")
 
 		team, err = s.teamFactory.CreateTeam(atcTeam)
@@ -74,3 +280,36 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+// teamSettings are the settings, named by their fields in the request, which
+// a team's members may update.
+var teamSettings = []string{
+	"resource_defaults",
+	"container_env",
+	"ca_certs",
+	"container_dns",
+	"default_task_image",
+	"pipelines_repo",
+	"concurrency_pools",
+}
+
+// adminTeamSettings are the settings which only admins may update.
+var adminTeamSettings = []string{
+	"max_build_log_size",
+	"max_build_starts_per_minute",
+	"check_policy",
+	"content_scan_policy",
+	"resource_type_mappings",
+	"privileged_policy",
+	"require_image_digests",
+}
+
+func containsSetting(settings []string, field string) bool {
+	for _, setting := range settings {
+		if setting == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -570,11 +571,16 @@ var _ = Describe("Versions API", func() {
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", func() {
 		var response *http.Response
 		var fakeResource *dbfakes.FakeResource
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/disable", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/disable"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -608,6 +614,38 @@ var _ = Describe("Versions API", func() {
 						Expect(resourceConfigVersionID).To(Equal(42))
 					})
 
+					It("does not abort pending builds", func() {
+						Expect(fakeResource.DisableVersionAndAbortPendingBuildsCallCount()).To(BeZero())
+					})
+
+					Context("when asked to abort pending builds using the version", func() {
+						BeforeEach(func() {
+							query = "?abort_pending_builds=true"
+						})
+
+						It("disables the version and aborts them", func() {
+							Expect(fakeResource.DisableVersionCallCount()).To(BeZero())
+							Expect(fakeResource.DisableVersionAndAbortPendingBuildsCallCount()).To(Equal(1))
+
+							resourceConfigVersionID := fakeResource.DisableVersionAndAbortPendingBuildsArgsForCall(0)
+							Expect(resourceConfigVersionID).To(Equal(42))
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						Context("when disabling the resource version fails", func() {
+							BeforeEach(func() {
+								fakeResource.DisableVersionAndAbortPendingBuildsReturns(errors.New("welp"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
 					Context("when disabling the resource version succeeds", func() {
 						BeforeEach(func() {
 							fakeResource.DisableVersionReturns(nil)
@@ -784,6 +822,132 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/metadata", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+		var body string
+
+		BeforeEach(func() {
+			body = `[{"name":"some-name","value":"some-value"}]`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/metadata", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				It("tries to find the resource", func() {
+					resourceName := fakePipeline.ResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+				})
+
+				Context("when finding the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.IDReturns(1)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					It("updates the metadata of the right resource config version", func() {
+						resourceConfigVersionID, metadata := fakeResource.UpdateVersionMetadataArgsForCall(0)
+						Expect(resourceConfigVersionID).To(Equal(42))
+						Expect(metadata).To(Equal(db.ResourceConfigMetadataFields{
+							{Name: "some-name", Value: "some-value"},
+						}))
+					})
+
+					Context("when updating the metadata succeeds", func() {
+						BeforeEach(func() {
+							fakeResource.UpdateVersionMetadataReturns(true, nil)
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the version is not found", func() {
+						BeforeEach(func() {
+							fakeResource.UpdateVersionMetadataReturns(false, nil)
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when updating the metadata fails", func() {
+						BeforeEach(func() {
+							fakeResource.UpdateVersionMetadataReturns(false, errors.New("welp"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							body = `{`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not update the metadata", func() {
+							Expect(fakeResource.UpdateVersionMetadataCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
@@ -1177,4 +1341,220 @@ var _ = Describe("Versions API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/span", func() {
+		var response *http.Response
+		var queryParams string
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			queryParams = "?since=122&to=125"
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.IDReturns(1)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/span"+queryParams, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+					fakeaccess.IsAuthenticatedReturns(true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+					fakeResource.VersionsInSpanReturns([]atc.ResourceVersion{
+						{
+							ID:       124,
+							Version:  atc.Version{"ref": "v2"},
+							Metadata: []atc.MetadataField{{Name: "message", Value: "secret"}},
+							Enabled:  true,
+						},
+					}, true, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when the resource is not public", func() {
+					It("hides the version metadata", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"id": 124,
+								"version": {"ref": "v2"},
+								"enabled": true,
+								"input_to": []
+							}
+						]`))
+					})
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when no lower bound is given", func() {
+				BeforeEach(func() {
+					queryParams = "?to=125"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when not finding the resource", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when resource retrieval succeeds", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("looks up the versions in the given span", func() {
+					Expect(fakeResource.VersionsInSpanCallCount()).To(Equal(1))
+					Expect(fakeResource.VersionsInSpanArgsForCall(0)).To(Equal(db.VersionSpan{
+						Since: 122,
+						To:    125,
+					}))
+				})
+
+				Context("when a bound of the span is not found", func() {
+					BeforeEach(func() {
+						fakeResource.VersionsInSpanReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the versions fails", func() {
+					BeforeEach(func() {
+						fakeResource.VersionsInSpanReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when getting the versions succeeds", func() {
+					BeforeEach(func() {
+						fakeResource.VersionsInSpanReturns([]atc.ResourceVersion{
+							{ID: 125, Version: atc.Version{"ref": "v3"}, Enabled: true},
+							{ID: 124, Version: atc.Version{"ref": "v2"}, Enabled: false},
+						}, true, nil)
+
+						build := new(dbfakes.FakeBuild)
+						build.IDReturns(1024)
+						build.NameReturns("5")
+						build.JobNameReturns("some-job")
+						build.PipelineNameReturns("a-pipeline")
+						build.TeamNameReturns("a-team")
+						build.StatusReturns(db.BuildStatusSucceeded)
+						build.StartTimeReturns(time.Unix(1, 0))
+						build.EndTimeReturns(time.Unix(100, 0))
+
+						fakePipeline.GetBuildsWithVersionAsInputReturnsOnCall(0, []db.Build{build}, nil)
+						fakePipeline.GetBuildsWithVersionAsInputReturnsOnCall(1, []db.Build{}, nil)
+					})
+
+					It("looks up the builds which used each version as an input", func() {
+						Expect(fakePipeline.GetBuildsWithVersionAsInputCallCount()).To(Equal(2))
+
+						resourceID, versionID := fakePipeline.GetBuildsWithVersionAsInputArgsForCall(0)
+						Expect(resourceID).To(Equal(1))
+						Expect(versionID).To(Equal(125))
+
+						resourceID, versionID = fakePipeline.GetBuildsWithVersionAsInputArgsForCall(1)
+						Expect(resourceID).To(Equal(1))
+						Expect(versionID).To(Equal(124))
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					})
+
+					It("returns the versions with their builds", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"id": 125,
+								"version": {"ref": "v3"},
+								"enabled": true,
+								"input_to": [
+									{
+										"id": 1024,
+										"team_name": "a-team",
+										"name": "5",
+										"status": "succeeded",
+										"job_name": "some-job",
+										"api_url": "/api/v1/builds/1024",
+										"pipeline_name": "a-pipeline",
+										"start_time": 1,
+										"end_time": 100
+									}
+								]
+							},
+							{
+								"id": 124,
+								"version": {"ref": "v2"},
+								"enabled": false,
+								"input_to": []
+							}
+						]`))
+					})
+
+					Context("when getting the builds fails", func() {
+						BeforeEach(func() {
+							fakePipeline.GetBuildsWithVersionAsInputReturnsOnCall(1, nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+		})
+	})
 })
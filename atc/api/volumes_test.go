@@ -2,11 +2,13 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
@@ -29,13 +31,20 @@ var _ = Describe("Volumes API", func() {
 	})
 
 	Describe("GET /api/v1//teams/a-team/volumes", func() {
-		var response *http.Response
+		var (
+			query    url.Values
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = url.Values{}
+		})
 
 		JustBeforeEach(func() {
 			fakeAccessor.CreateReturns(fakeaccess)
 
 			var err error
-			response, err = client.Get(server.URL + "/api/v1/teams/a-team/volumes")
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/volumes?" + query.Encode())
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -118,6 +127,7 @@ var _ = Describe("Volumes API", func() {
 							volume5.WorkerNameReturns(fakeWorker.Name())
 							volume5.TypeReturns(db.VolumeTypeTaskCache)
 							volume5.TaskIdentifierReturns("some-pipeline", "some-job", "some-task", nil)
+							volume5.CreatedAtReturns(time.Unix(1571000000, 0))
 							return []db.CreatedVolume{
 								volume1,
 								volume2,
@@ -218,13 +228,114 @@ var _ = Describe("Volumes API", func() {
 		 						"base_resource_type": null,
 		 						"pipeline_name": "some-pipeline",
 		 						"job_name": "some-job",
-		 						"step_name": "some-task"
+		 						"step_name": "some-task",
+		 						"created_at": 1571000000
 		 					}
 		 				]`,
 						))
 					})
 				})
 
+				Context("when filtering the volumes", func() {
+					BeforeEach(func() {
+						fakeVolumeRepository.GetTeamVolumesStub = func(teamID int) ([]db.CreatedVolume, error) {
+							resourceVolume := new(dbfakes.FakeCreatedVolume)
+							resourceVolume.HandleReturns("some-resource-handle")
+							resourceVolume.WorkerNameReturns("some-worker")
+							resourceVolume.TypeReturns(db.VolumeTypeResource)
+							resourceVolume.CreatedAtReturns(time.Now().Add(-time.Minute))
+
+							taskCacheVolume := new(dbfakes.FakeCreatedVolume)
+							taskCacheVolume.HandleReturns("some-task-cache-handle")
+							taskCacheVolume.WorkerNameReturns("some-other-worker")
+							taskCacheVolume.TypeReturns(db.VolumeTypeTaskCache)
+							taskCacheVolume.TaskIdentifierReturns("some-pipeline", "some-job", "some-task", nil)
+							taskCacheVolume.CreatedAtReturns(time.Now().Add(-2 * time.Hour))
+
+							return []db.CreatedVolume{resourceVolume, taskCacheVolume}, nil
+						}
+					})
+
+					handles := func() []string {
+						var volumes []atc.Volume
+						err := json.NewDecoder(response.Body).Decode(&volumes)
+						Expect(err).NotTo(HaveOccurred())
+
+						handles := []string{}
+						for _, volume := range volumes {
+							handles = append(handles, volume.ID)
+						}
+
+						return handles
+					}
+
+					Context("by type", func() {
+						BeforeEach(func() {
+							query.Set("type", "task-cache")
+						})
+
+						It("returns only the volumes of that type", func() {
+							Expect(handles()).To(Equal([]string{"some-task-cache-handle"}))
+						})
+					})
+
+					Context("by worker", func() {
+						BeforeEach(func() {
+							query.Set("worker_name", "some-worker")
+						})
+
+						It("returns only the volumes on that worker", func() {
+							Expect(handles()).To(Equal([]string{"some-resource-handle"}))
+						})
+					})
+
+					Context("by pipeline and job", func() {
+						BeforeEach(func() {
+							query.Set("pipeline_name", "some-pipeline")
+							query.Set("job_name", "some-job")
+						})
+
+						It("returns only the volumes of that job", func() {
+							Expect(handles()).To(Equal([]string{"some-task-cache-handle"}))
+						})
+					})
+
+					Context("by age", func() {
+						BeforeEach(func() {
+							query.Set("older_than", "1h")
+						})
+
+						It("returns only the volumes older than the given age", func() {
+							Expect(handles()).To(Equal([]string{"some-task-cache-handle"}))
+						})
+
+						Context("with an upper bound", func() {
+							BeforeEach(func() {
+								query.Del("older_than")
+								query.Set("newer_than", "1h")
+							})
+
+							It("returns only the volumes newer than the given age", func() {
+								Expect(handles()).To(Equal([]string{"some-resource-handle"}))
+							})
+						})
+					})
+
+					Context("when the age is not a duration", func() {
+						BeforeEach(func() {
+							query.Set("older_than", "yesterday")
+						})
+
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not look up the volumes", func() {
+							Expect(fakeVolumeRepository.GetTeamVolumesCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("when getting all volumes fails", func() {
 					BeforeEach(func() {
 						fakeVolumeRepository.GetTeamVolumesReturns([]db.CreatedVolume{}, errors.New("oh no!"))
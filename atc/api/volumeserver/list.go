@@ -2,7 +2,9 @@ package volumeserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -14,6 +16,13 @@ func (s *Server) ListVolumes(team db.Team) http.Handler {
 	hLog := s.logger.Session("list-volumes")
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, err := createVolumeFilterFromRequest(r)
+		if err != nil {
+			hLog.Error("failed-to-parse-request", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		hLog.Debug("listing")
 
 		volumes, err := s.repository.GetTeamVolumes(team.ID())
@@ -25,12 +34,14 @@ func (s *Server) ListVolumes(team db.Team) http.Handler {
 
 		hLog.Debug("listed", lager.Data{"volume-count": len(volumes)})
 
+		now := time.Now()
+
 		presentedVolumes := []atc.Volume{}
 		for i := 0; i < len(volumes); i++ {
 			volume := volumes[i]
 			if vol, err := present.Volume(volume); err != nil {
 				hLog.Error("failed-to-present-volume", err)
-			} else {
+			} else if filter.Matches(vol, volume.CreatedAt(), now) {
 				presentedVolumes = append(presentedVolumes, vol)
 			}
 		}
@@ -43,3 +54,82 @@ func (s *Server) ListVolumes(team db.Team) http.Handler {
 		}
 	})
 }
+
+// volumeFilter narrows down the team's volumes. Pipeline and job names are
+// only known for task cache volumes.
+type volumeFilter struct {
+	typ          string
+	workerName   string
+	pipelineName string
+	jobName      string
+	olderThan    time.Duration
+	newerThan    time.Duration
+}
+
+func createVolumeFilterFromRequest(r *http.Request) (volumeFilter, error) {
+	query := r.URL.Query()
+
+	filter := volumeFilter{
+		typ:          query.Get("type"),
+		workerName:   query.Get("worker_name"),
+		pipelineName: query.Get("pipeline_name"),
+		jobName:      query.Get("job_name"),
+	}
+
+	var err error
+	filter.olderThan, err = parseDurationParam(r, "older_than")
+	if err != nil {
+		return volumeFilter{}, err
+	}
+
+	filter.newerThan, err = parseDurationParam(r, "newer_than")
+	if err != nil {
+		return volumeFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func (f volumeFilter) Matches(volume atc.Volume, createdAt time.Time, now time.Time) bool {
+	if f.typ != "" && volume.Type != f.typ {
+		return false
+	}
+
+	if f.workerName != "" && volume.WorkerName != f.workerName {
+		return false
+	}
+
+	if f.pipelineName != "" && volume.PipelineName != f.pipelineName {
+		return false
+	}
+
+	if f.jobName != "" && volume.JobName != f.jobName {
+		return false
+	}
+
+	age := now.Sub(createdAt)
+
+	if f.olderThan != 0 && age < f.olderThan {
+		return false
+	}
+
+	if f.newerThan != 0 && age > f.newerThan {
+		return false
+	}
+
+	return true
+}
+
+func parseDurationParam(r *http.Request, name string) (time.Duration, error) {
+	var val time.Duration
+	param := r.URL.Query().Get(name)
+	if len(param) != 0 {
+		var err error
+		val, err = time.ParseDuration(param)
+		if err != nil {
+			return 0, fmt.Errorf("invalid '%s' param (%s): %s", name, param, err)
+		}
+	}
+
+	return val, nil
+}
@@ -28,10 +28,17 @@ var _ = Describe("Workers API", func() {
 	})
 
 	Describe("GET /api/v1/workers", func() {
-		var response *http.Response
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(req)
@@ -139,6 +146,94 @@ var _ = Describe("Workers API", func() {
 				})
 			})
 
+			Context("when a worker negotiated its protocol", func() {
+				BeforeEach(func() {
+					teamWorker1.ProtocolVersionReturns(2)
+					teamWorker1.CapabilitiesReturns([]atc.WorkerCapability{atc.WorkerCapabilityGPUs})
+
+					dbWorkerFactory.VisibleWorkersReturns([]db.Worker{teamWorker1}, nil)
+				})
+
+				It("returns its protocol version and capabilities", func() {
+					var returnedWorkers []atc.Worker
+					err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(returnedWorkers).To(HaveLen(1))
+					Expect(returnedWorkers[0].ProtocolVersion).To(Equal(2))
+					Expect(returnedWorkers[0].Capabilities).To(Equal([]atc.WorkerCapability{atc.WorkerCapabilityGPUs}))
+				})
+			})
+
+			Context("when filtering by labels", func() {
+				BeforeEach(func() {
+					teamWorker1.LabelsReturns(atc.Labels{"zone": "us-east-1", "gpu": "true"})
+					teamWorker2.LabelsReturns(atc.Labels{"zone": "us-west-1"})
+
+					dbWorkerFactory.VisibleWorkersReturns([]db.Worker{
+						teamWorker1,
+						teamWorker2,
+					}, nil)
+				})
+
+				Context("with a value", func() {
+					BeforeEach(func() {
+						query = "?label=zone:us-west-1"
+					})
+
+					It("returns the workers with the label set to the value", func() {
+						var returnedWorkers []atc.Worker
+						err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(returnedWorkers).To(Equal([]atc.Worker{
+							{
+								GardenAddr:      "5.6.7.8:7777",
+								BaggageclaimURL: "5.6.7.8:8888",
+								Labels:          atc.Labels{"zone": "us-west-1"},
+							},
+						}))
+					})
+				})
+
+				Context("without a value", func() {
+					BeforeEach(func() {
+						query = "?label=gpu&label=zone:us-east-1"
+					})
+
+					It("returns the workers with the label set", func() {
+						var returnedWorkers []atc.Worker
+						err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(returnedWorkers).To(HaveLen(1))
+						Expect(returnedWorkers[0].GardenAddr).To(Equal("1.2.3.4:7777"))
+					})
+				})
+
+				Context("when no workers match", func() {
+					BeforeEach(func() {
+						query = "?label=zone:eu-central-1"
+					})
+
+					It("returns an empty list", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON("[]"))
+					})
+				})
+
+				Context("when the selector is malformed", func() {
+					BeforeEach(func() {
+						query = "?label=:us-east-1"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Context("when getting the workers fails", func() {
 				BeforeEach(func() {
 					dbWorkerFactory.VisibleWorkersReturns(nil, errors.New("error!"))
@@ -232,11 +327,48 @@ var _ = Describe("Workers API", func() {
 					Platform: "haiku",
 					Tags:     []string{"not", "a", "limerick"},
 					Version:  "1.2.3",
+
+					ProtocolVersion: atc.LegacyWorkerProtocolVersion,
 				}))
 
 				Expect(savedTTL.String()).To(Equal(ttl))
 			})
 
+			Context("when the worker offers a protocol version and capabilities", func() {
+				BeforeEach(func() {
+					worker.ProtocolVersion = atc.WorkerProtocolVersion + 1
+					worker.Capabilities = []atc.WorkerCapability{
+						"some-future-capability",
+						atc.WorkerCapabilityZstdStreaming,
+					}
+				})
+
+				It("saves the protocol negotiated with the worker", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					savedWorker, _ := dbWorkerFactory.SaveWorkerArgsForCall(0)
+					Expect(savedWorker.ProtocolVersion).To(Equal(atc.WorkerProtocolVersion))
+					Expect(savedWorker.Capabilities).To(Equal([]atc.WorkerCapability{
+						atc.WorkerCapabilityZstdStreaming,
+					}))
+				})
+			})
+
+			Context("when the worker's protocol version is no longer supported", func() {
+				BeforeEach(func() {
+					worker.ProtocolVersion = -1
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("worker protocol version -1 is not supported"))
+				})
+
+				It("does not save it", func() {
+					Expect(dbWorkerFactory.SaveWorkerCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when request is not from tsa", func() {
 				Context("when system claim is false", func() {
 					BeforeEach(func() {
@@ -324,6 +456,8 @@ var _ = Describe("Workers API", func() {
 						Platform: "haiku",
 						Tags:     []string{"not", "a", "limerick"},
 						Version:  "1.2.3",
+
+						ProtocolVersion: atc.LegacyWorkerProtocolVersion,
 					}))
 
 					Expect(savedTTL.String()).To(Equal(ttl))
@@ -356,6 +490,8 @@ var _ = Describe("Workers API", func() {
 						Platform: "haiku",
 						Tags:     []string{"not", "a", "limerick"},
 						Version:  "1.2.3",
+
+						ProtocolVersion: atc.LegacyWorkerProtocolVersion,
 					}))
 
 					Expect(savedTTL.String()).To(Equal(ttl))
@@ -389,6 +525,8 @@ var _ = Describe("Workers API", func() {
 						Platform: "haiku",
 						Tags:     []string{"not", "a", "limerick"},
 						Version:  "1.2.3",
+
+						ProtocolVersion: atc.LegacyWorkerProtocolVersion,
 					}))
 
 					Expect(savedTTL.String()).To(Equal(ttl))
@@ -774,6 +912,150 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/workers/:worker_name/discrepancies", func() {
+		var (
+			response   *http.Response
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/workers/some-worker/discrepancies")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			fakeWorker.NameReturns("some-worker")
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.DiscrepanciesReturns([]atc.WorkerDiscrepancy{
+				{
+					Type:       "container",
+					Handle:     "some-handle",
+					Problem:    atc.DiscrepancyMissingOnWorker,
+					BuildID:    42,
+					Since:      1234,
+					Heartbeats: 3,
+				},
+			}, nil)
+
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+		})
+
+		It("returns the worker's discrepancies", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[{
+				"type": "container",
+				"handle": "some-handle",
+				"problem": "missing_on_worker",
+				"build_id": 42,
+				"since": 1234,
+				"heartbeats": 3
+			}]`))
+		})
+
+		Context("when getting the discrepancies fails", func() {
+			BeforeEach(func() {
+				fakeWorker.DiscrepanciesReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			BeforeEach(func() {
+				dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/workers/:worker_name/discrepancies/resolve", func() {
+		var (
+			response   *http.Response
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/some-worker/discrepancies/resolve", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			fakeWorker.NameReturns("some-worker")
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.ResolveDiscrepanciesReturns(2, nil)
+
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+		})
+
+		It("resolves the worker's discrepancies", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeWorker.ResolveDiscrepanciesCallCount()).To(Equal(1))
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"resolved": 2}`))
+		})
+
+		Context("when resolving the discrepancies fails", func() {
+			BeforeEach(func() {
+				fakeWorker.ResolveDiscrepanciesReturns(0, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			BeforeEach(func() {
+				dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not resolve anything", func() {
+				Expect(fakeWorker.ResolveDiscrepanciesCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/heartbeat", func() {
 		var (
 			response   *http.Response
@@ -860,6 +1142,34 @@ var _ = Describe("Workers API", func() {
 			Expect(t).To(Equal(ttl))
 		})
 
+		It("does not reconcile a census", func() {
+			Expect(fakeWorker.ReconcileCensusCallCount()).To(BeZero())
+		})
+
+		Context("when the heartbeat includes a census", func() {
+			BeforeEach(func() {
+				worker.Census = &atc.WorkerCensus{
+					Containers: []atc.CensusContainer{{Handle: "some-handle", BuildID: 42}},
+					Volumes:    []string{"some-volume"},
+				}
+			})
+
+			It("reconciles it against the saved worker", func() {
+				Expect(fakeWorker.ReconcileCensusCallCount()).To(Equal(1))
+				Expect(fakeWorker.ReconcileCensusArgsForCall(0)).To(Equal(*worker.Census))
+			})
+
+			Context("when reconciling the census fails", func() {
+				BeforeEach(func() {
+					fakeWorker.ReconcileCensusReturns(nil, errors.New("nope"))
+				})
+
+				It("still returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
 		Context("when the TTL is invalid", func() {
 			BeforeEach(func() {
 				ttlStr = "invalid-duration"
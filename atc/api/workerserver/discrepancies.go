@@ -0,0 +1,80 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func (s *Server) ListWorkerDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-worker-discrepancies")
+	workerName := r.FormValue(":worker_name")
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	discrepancies, err := worker.Discrepancies()
+	if err != nil {
+		logger.Error("failed-to-get-discrepancies", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(discrepancies)
+	if err != nil {
+		logger.Error("failed-to-encode-discrepancies", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) ResolveWorkerDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("resolve-worker-discrepancies")
+	workerName := r.FormValue(":worker_name")
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	resolved, err := worker.ResolveDiscrepancies()
+	if err != nil {
+		logger.Error("failed-to-resolve-discrepancies", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("resolved", lager.Data{"discrepancies": resolved})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(atc.ResolveWorkerDiscrepanciesResponseBody{
+		Resolved: resolved,
+	})
+	if err != nil {
+		logger.Error("failed-to-encode-response", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
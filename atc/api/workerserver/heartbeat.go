@@ -82,6 +82,18 @@ func (s *Server) HeartbeatWorker(w http.ResponseWriter, r *http.Request) {
 		Platform:   registration.Platform,
 	}.Emit(s.logger)
 
+	if registration.Census != nil {
+		discrepancies, err := savedWorker.ReconcileCensus(*registration.Census)
+		if err != nil {
+			logger.Error("failed-to-reconcile-census", err)
+		} else {
+			metric.WorkerDiscrepancies{
+				WorkerName:    registration.Name,
+				Discrepancies: len(discrepancies),
+			}.Emit(s.logger)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(present.Worker(savedWorker))
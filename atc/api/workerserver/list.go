@@ -2,6 +2,7 @@ package workerserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
@@ -18,6 +19,13 @@ func (s *Server) ListWorkers(w http.ResponseWriter, r *http.Request) {
 		err     error
 	)
 
+	selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, err.Error())
+		return
+	}
+
 	acc := accessor.GetAccessor(r)
 
 	if acc.IsAdmin() {
@@ -32,9 +40,13 @@ func (s *Server) ListWorkers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	atcWorkers := make([]atc.Worker, len(workers))
-	for i, savedWorker := range workers {
-		atcWorkers[i] = present.Worker(savedWorker)
+	atcWorkers := []atc.Worker{}
+	for _, savedWorker := range workers {
+		if !selector.Matches(savedWorker.Labels()) {
+			continue
+		}
+
+		atcWorkers = append(atcWorkers, present.Worker(savedWorker))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -43,6 +43,14 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = registration.NegotiateProtocol()
+	if err != nil {
+		logger.Info("unsupported-protocol", lager.Data{"worker-name": registration.Name, "error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, err.Error())
+		return
+	}
+
 	var ttl time.Duration
 
 	ttlStr := r.URL.Query().Get("ttl")
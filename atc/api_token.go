@@ -0,0 +1,108 @@
+package atc
+
+import (
+	"fmt"
+	"time"
+)
+
+// APITokenReadOnly is the action of a scope allowing every action which a
+// team's viewers may perform.
+const APITokenReadOnly = "read-only"
+
+// APITokenEmbed is the action of a scope allowing every action in
+// EmbedActions, so that a pipeline's badges and statuses can be embedded
+// without the token allowing anything else.
+const APITokenEmbed = "embed"
+
+// EmbedActions are the badge and status actions, which are the only actions
+// that may be authorized by an API token given in the EmbedTokenQuery.
+var EmbedActions = map[string]bool{
+	JobBadge:       true,
+	JobStatus:      true,
+	PipelineBadge:  true,
+	PipelineStatus: true,
+}
+
+// APIToken is a long-lived token for automation to use the API of a single
+// team, limited to the actions of its scopes until it expires or is revoked.
+type APIToken struct {
+	ID        int             `json:"id"`
+	Name      string          `json:"name"`
+	TeamName  string          `json:"team_name"`
+	Scopes    []APITokenScope `json:"scopes"`
+	ExpiresAt int64           `json:"expires_at"`
+	CreatedBy string          `json:"created_by,omitempty"`
+	CreatedAt int64           `json:"created_at"`
+	RevokedAt int64           `json:"revoked_at,omitempty"`
+}
+
+// APITokenScope allows an action, such as CreateJobBuild, or every read-only
+// action with APITokenReadOnly. If Pipeline is set, the action is only
+// allowed through the pipeline's endpoints.
+type APITokenScope struct {
+	Action   string `json:"action"`
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+// APITokenRequest is the body of a request to create an APIToken.
+type APITokenRequest struct {
+	Name      string          `json:"name"`
+	Scopes    []APITokenScope `json:"scopes"`
+	ExpiresAt int64           `json:"expires_at"`
+}
+
+// CreatedAPIToken is the response to creating an APIToken. The token itself
+// is only ever returned here.
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// apiTokenActions are the actions which API tokens can never be scoped to,
+// so that a token can't be used to create more tokens.
+var apiTokenActions = map[string]bool{
+	ListAPITokens:  true,
+	CreateAPIToken: true,
+	RevokeAPIToken: true,
+}
+
+func (request APITokenRequest) Validate(now time.Time) []string {
+	errorMessages := []string{}
+
+	if request.Name == "" {
+		errorMessages = append(errorMessages, "name must be specified")
+	}
+
+	if request.ExpiresAt == 0 {
+		errorMessages = append(errorMessages, "expires_at must be specified")
+	} else if request.ExpiresAt <= now.Unix() {
+		errorMessages = append(errorMessages, "expires_at must be in the future")
+	}
+
+	if len(request.Scopes) == 0 {
+		errorMessages = append(errorMessages, "at least one scope must be specified")
+	}
+
+	for i, scope := range request.Scopes {
+		switch {
+		case scope.Action == "":
+			errorMessages = append(errorMessages, fmt.Sprintf("scopes[%d] has no action", i))
+		case apiTokenActions[scope.Action]:
+			errorMessages = append(errorMessages, fmt.Sprintf("scopes[%d] cannot allow managing api tokens", i))
+		case scope.Action != APITokenReadOnly && scope.Action != APITokenEmbed && !isRoute(scope.Action):
+			errorMessages = append(errorMessages, fmt.Sprintf("scopes[%d] has unknown action '%s'", i, scope.Action))
+		}
+	}
+
+	return errorMessages
+}
+
+func isRoute(name string) bool {
+	for _, route := range Routes {
+		if route.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITokenRequest", func() {
+	var (
+		now     time.Time
+		request atc.APITokenRequest
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1000, 0)
+
+		request = atc.APITokenRequest{
+			Name: "deployer",
+			Scopes: []atc.APITokenScope{
+				{Action: atc.CreateJobBuild, Pipeline: "some-pipeline"},
+				{Action: atc.APITokenReadOnly},
+			},
+			ExpiresAt: 2000,
+		}
+	})
+
+	Describe("Validate", func() {
+		It("accepts a request for known actions expiring in the future", func() {
+			Expect(request.Validate(now)).To(BeEmpty())
+		})
+
+		It("requires a name", func() {
+			request.Name = ""
+			Expect(request.Validate(now)).To(ConsistOf("name must be specified"))
+		})
+
+		It("requires an expiry in the future", func() {
+			request.ExpiresAt = 0
+			Expect(request.Validate(now)).To(ConsistOf("expires_at must be specified"))
+
+			request.ExpiresAt = 1000
+			Expect(request.Validate(now)).To(ConsistOf("expires_at must be in the future"))
+		})
+
+		It("requires scopes", func() {
+			request.Scopes = nil
+			Expect(request.Validate(now)).To(ConsistOf("at least one scope must be specified"))
+		})
+
+		It("rejects unknown actions", func() {
+			request.Scopes = []atc.APITokenScope{{}, {Action: "DoAnything"}}
+			Expect(request.Validate(now)).To(ConsistOf(
+				"scopes[0] has no action",
+				"scopes[1] has unknown action 'DoAnything'",
+			))
+		})
+
+		It("accepts the embed action", func() {
+			request.Scopes = []atc.APITokenScope{{Action: atc.APITokenEmbed, Pipeline: "some-pipeline"}}
+			Expect(request.Validate(now)).To(BeEmpty())
+		})
+
+		It("does not allow tokens to manage tokens", func() {
+			request.Scopes = []atc.APITokenScope{{Action: atc.CreateAPIToken}}
+			Expect(request.Validate(now)).To(ConsistOf("scopes[0] cannot allow managing api tokens"))
+		})
+	})
+})
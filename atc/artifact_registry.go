@@ -0,0 +1,314 @@
+package atc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ArtifactRegistry is the set of artifacts which the steps of a build's plan
+// register for later steps to use. It is worked out from the plan alone, so
+// that steps using artifacts which won't be there show up before any step
+// runs rather than once the step gets to them.
+type ArtifactRegistry struct {
+	Artifacts  []ArtifactRegistration `json:"artifacts"`
+	Conflicts  []ArtifactConflict     `json:"conflicts,omitempty"`
+	Unresolved []ArtifactReference    `json:"unresolved,omitempty"`
+}
+
+// ArtifactRegistration is an artifact registered under a name by a step. The
+// name and the step's plan ID together address the artifact within the
+// build, even when later steps register other artifacts under the same name.
+type ArtifactRegistration struct {
+	Name   string   `json:"name"`
+	PlanID PlanID   `json:"plan_id"`
+	Step   string   `json:"step"`
+	UsedBy []PlanID `json:"used_by,omitempty"`
+}
+
+// ArtifactConflict is a name registered by more than one of the steps run in
+// parallel by an in_parallel or aggregate step, leaving which artifact later
+// steps get up to whichever of them finishes last.
+type ArtifactConflict struct {
+	Name    string   `json:"name"`
+	PlanIDs []PlanID `json:"plan_ids"`
+}
+
+// ArtifactReference is a step's use of a name which no step before it
+// registers.
+type ArtifactReference struct {
+	Name   string `json:"name"`
+	PlanID PlanID `json:"plan_id"`
+}
+
+func NewArtifactRegistry(plan Plan) ArtifactRegistry {
+	registry := ArtifactRegistry{
+		Artifacts: []ArtifactRegistration{},
+	}
+
+	registry.walk(plan, artifactScope{})
+
+	return registry
+}
+
+// Errors describes the conflicts and unresolved artifacts in the registry.
+func (registry ArtifactRegistry) Errors() []string {
+	errorMessages := []string{}
+
+	for _, conflict := range registry.Conflicts {
+		ids := []string{}
+		for _, id := range conflict.PlanIDs {
+			ids = append(ids, string(id))
+		}
+
+		errorMessages = append(errorMessages, fmt.Sprintf(
+			"steps %s run in parallel and register the same artifact name '%s'",
+			strings.Join(ids, ", "),
+			conflict.Name,
+		))
+	}
+
+	for _, reference := range registry.Unresolved {
+		errorMessages = append(errorMessages, fmt.Sprintf(
+			"step %s uses artifact '%s', which no earlier step registers",
+			reference.PlanID,
+			reference.Name,
+		))
+	}
+
+	return errorMessages
+}
+
+// artifactScope maps each artifact name to the indexes of the registrations
+// which a step may get when using the name.
+type artifactScope map[string][]int
+
+// override returns the scope after running steps which registered the given
+// artifacts, replacing any registered earlier under the same names.
+func (scope artifactScope) override(registered artifactScope) artifactScope {
+	result := artifactScope{}
+	for name, indexes := range scope {
+		result[name] = indexes
+	}
+
+	for name, indexes := range registered {
+		result[name] = indexes
+	}
+
+	return result
+}
+
+// merge returns the scope after running either these steps or steps which
+// registered the given artifacts.
+func (scope artifactScope) merge(registered artifactScope) artifactScope {
+	result := artifactScope{}
+	for name, indexes := range scope {
+		result[name] = indexes
+	}
+
+	for name, indexes := range registered {
+		result[name] = append(append([]int{}, result[name]...), indexes...)
+	}
+
+	return result
+}
+
+func (scope artifactScope) names() []string {
+	names := []string{}
+	for name := range scope {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// walk records the artifacts used and registered by the plan, given those
+// registered before it runs, and returns the artifacts it registers.
+func (registry *ArtifactRegistry) walk(plan Plan, scope artifactScope) artifactScope {
+	registered := artifactScope{}
+
+	use := func(name string, optional bool) {
+		indexes, found := scope[name]
+		if !found {
+			if !optional {
+				registry.Unresolved = append(registry.Unresolved, ArtifactReference{
+					Name:   name,
+					PlanID: plan.ID,
+				})
+			}
+
+			return
+		}
+
+		for _, i := range indexes {
+			registry.Artifacts[i].UsedBy = append(registry.Artifacts[i].UsedBy, plan.ID)
+		}
+	}
+
+	register := func(name string, step string) {
+		registry.Artifacts = append(registry.Artifacts, ArtifactRegistration{
+			Name:   name,
+			PlanID: plan.ID,
+			Step:   step,
+		})
+
+		registered[name] = []int{len(registry.Artifacts) - 1}
+	}
+
+	switch {
+	case plan.Do != nil:
+		inner := scope
+		for _, step := range *plan.Do {
+			stepRegistered := registry.walk(step, inner)
+			inner = inner.override(stepRegistered)
+			registered = registered.override(stepRegistered)
+		}
+
+	case plan.Aggregate != nil:
+		registered = registry.walkParallel(*plan.Aggregate, scope)
+
+	case plan.InParallel != nil:
+		registered = registry.walkParallel(plan.InParallel.Steps, scope)
+
+	case plan.Retry != nil:
+		for _, attempt := range *plan.Retry {
+			registered = registered.merge(registry.walk(attempt, scope))
+		}
+
+	case plan.Try != nil:
+		registered = registry.walk(plan.Try.Step, scope)
+
+	case plan.Timeout != nil:
+		registered = registry.walk(plan.Timeout.Step, scope)
+
+	case plan.OnAbort != nil:
+		registered = registry.walkHook(plan.OnAbort.Step, plan.OnAbort.Next, scope)
+
+	case plan.OnError != nil:
+		registered = registry.walkHook(plan.OnError.Step, plan.OnError.Next, scope)
+
+	case plan.OnFailure != nil:
+		registered = registry.walkHook(plan.OnFailure.Step, plan.OnFailure.Next, scope)
+
+	case plan.OnSuccess != nil:
+		registered = registry.walkHook(plan.OnSuccess.Step, plan.OnSuccess.Next, scope)
+
+	case plan.Ensure != nil:
+		registered = registry.walkHook(plan.Ensure.Step, plan.Ensure.Next, scope)
+
+	case plan.Get != nil:
+		register(plan.Get.Name, "get")
+
+	case plan.Put != nil:
+		if plan.Put.Inputs != nil {
+			for _, input := range plan.Put.Inputs.Specified {
+				use(input, false)
+			}
+		}
+
+	case plan.Task != nil:
+		task := plan.Task
+
+		if segs := strings.SplitN(task.ConfigPath, "/", 2); len(segs) == 2 {
+			use(segs[0], false)
+		}
+
+		if task.ImageArtifactName != "" {
+			use(task.ImageArtifactName, false)
+		}
+
+		if task.Config != nil {
+			for _, input := range task.Config.Inputs {
+				name := input.Name
+				if mapped, found := task.InputMapping[name]; found {
+					name = mapped
+				}
+
+				use(name, input.Optional)
+			}
+
+			for _, output := range task.Config.Outputs {
+				name := output.Name
+				if mapped, found := task.OutputMapping[name]; found {
+					name = mapped
+				}
+
+				register(name, "task")
+			}
+		} else {
+			// the outputs of tasks configured by file aren't known until the
+			// file is read, apart from the ones which are mapped
+			names := []string{}
+			for _, name := range task.OutputMapping {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				register(name, "task")
+			}
+		}
+
+	case plan.ArtifactInput != nil:
+		register(plan.ArtifactInput.Name, "artifact_input")
+
+	case plan.ArtifactOutput != nil:
+		use(plan.ArtifactOutput.Name, false)
+	}
+
+	return registered
+}
+
+func (registry *ArtifactRegistry) walkHook(step Plan, next Plan, scope artifactScope) artifactScope {
+	stepRegistered := registry.walk(step, scope)
+	nextRegistered := registry.walk(next, scope.override(stepRegistered))
+	return stepRegistered.override(nextRegistered)
+}
+
+func (registry *ArtifactRegistry) walkParallel(steps []Plan, scope artifactScope) artifactScope {
+	registered := artifactScope{}
+
+	conflicts := map[string][]PlanID{}
+	conflicting := []string{}
+
+	for _, step := range steps {
+		stepRegistered := registry.walk(step, scope)
+
+		for _, name := range stepRegistered.names() {
+			earlier, found := registered[name]
+			if !found {
+				continue
+			}
+
+			if _, found := conflicts[name]; !found {
+				conflicting = append(conflicting, name)
+				conflicts[name] = registry.planIDs(earlier)
+			}
+
+			conflicts[name] = append(conflicts[name], registry.planIDs(stepRegistered[name])...)
+		}
+
+		registered = registered.merge(stepRegistered)
+	}
+
+	for _, name := range conflicting {
+		registry.Conflicts = append(registry.Conflicts, ArtifactConflict{
+			Name:    name,
+			PlanIDs: conflicts[name],
+		})
+	}
+
+	return registered
+}
+
+func (registry *ArtifactRegistry) planIDs(indexes []int) []PlanID {
+	ids := []PlanID{}
+	for _, i := range indexes {
+		ids = append(ids, registry.Artifacts[i].PlanID)
+	}
+
+	return ids
+}
@@ -0,0 +1,187 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ArtifactRegistry", func() {
+	var (
+		plan     atc.Plan
+		registry atc.ArtifactRegistry
+	)
+
+	task := func(id atc.PlanID, inputs []string, outputs []string) atc.Plan {
+		config := &atc.TaskConfig{}
+		for _, input := range inputs {
+			config.Inputs = append(config.Inputs, atc.TaskInputConfig{Name: input})
+		}
+		for _, output := range outputs {
+			config.Outputs = append(config.Outputs, atc.TaskOutputConfig{Name: output})
+		}
+
+		return atc.Plan{ID: id, Task: &atc.TaskPlan{Name: string(id), Config: config}}
+	}
+
+	get := func(id atc.PlanID, name string) atc.Plan {
+		return atc.Plan{ID: id, Get: &atc.GetPlan{Name: name}}
+	}
+
+	JustBeforeEach(func() {
+		registry = atc.NewArtifactRegistry(plan)
+	})
+
+	Context("when steps run one after another", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					get("2", "repo"),
+					task("3", []string{"repo"}, []string{"repo"}),
+					task("4", []string{"repo"}, nil),
+				},
+			}
+		})
+
+		It("addresses each artifact by the step registering it", func() {
+			Expect(registry.Artifacts).To(Equal([]atc.ArtifactRegistration{
+				{Name: "repo", PlanID: "2", Step: "get", UsedBy: []atc.PlanID{"3"}},
+				{Name: "repo", PlanID: "3", Step: "task", UsedBy: []atc.PlanID{"4"}},
+			}))
+		})
+
+		It("has no errors", func() {
+			Expect(registry.Errors()).To(BeEmpty())
+		})
+	})
+
+	Context("when a step uses an artifact no earlier step registers", func() {
+		BeforeEach(func() {
+			mapped := task("3", []string{"in", "missing"}, nil)
+			mapped.Task.InputMapping = map[string]string{"in": "repo"}
+			mapped.Task.Config.Inputs = append(mapped.Task.Config.Inputs, atc.TaskInputConfig{Name: "maybe", Optional: true})
+
+			plan = atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					get("2", "repo"),
+					mapped,
+					{ID: "4", Task: &atc.TaskPlan{ConfigPath: "other/task.yml", ImageArtifactName: "image"}},
+				},
+			}
+		})
+
+		It("records it as unresolved", func() {
+			Expect(registry.Artifacts[0].UsedBy).To(Equal([]atc.PlanID{"3"}))
+			Expect(registry.Unresolved).To(Equal([]atc.ArtifactReference{
+				{Name: "missing", PlanID: "3"},
+				{Name: "other", PlanID: "4"},
+				{Name: "image", PlanID: "4"},
+			}))
+			Expect(registry.Errors()).To(ContainElement("step 3 uses artifact 'missing', which no earlier step registers"))
+		})
+	})
+
+	Context("when steps run in parallel", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					{
+						ID: "2",
+						InParallel: &atc.InParallelPlan{
+							Steps: []atc.Plan{
+								get("3", "repo"),
+								task("4", []string{"repo"}, []string{"repo"}),
+								{ID: "5", Aggregate: &atc.AggregatePlan{get("6", "other")}},
+							},
+						},
+					},
+					task("7", []string{"repo", "other"}, nil),
+				},
+			}
+		})
+
+		It("does not let them use each other's artifacts", func() {
+			Expect(registry.Unresolved).To(Equal([]atc.ArtifactReference{
+				{Name: "repo", PlanID: "4"},
+			}))
+		})
+
+		It("records names registered by more than one of them as conflicts", func() {
+			Expect(registry.Conflicts).To(Equal([]atc.ArtifactConflict{
+				{Name: "repo", PlanIDs: []atc.PlanID{"3", "4"}},
+			}))
+			Expect(registry.Errors()).To(ContainElement("steps 3, 4 run in parallel and register the same artifact name 'repo'"))
+		})
+
+		It("lets later steps use either of the conflicting artifacts", func() {
+			Expect(registry.Artifacts).To(Equal([]atc.ArtifactRegistration{
+				{Name: "repo", PlanID: "3", Step: "get", UsedBy: []atc.PlanID{"7"}},
+				{Name: "repo", PlanID: "4", Step: "task", UsedBy: []atc.PlanID{"7"}},
+				{Name: "other", PlanID: "6", Step: "get", UsedBy: []atc.PlanID{"7"}},
+			}))
+		})
+	})
+
+	Context("when a step is retried", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					{ID: "2", Retry: &atc.RetryPlan{get("3", "repo"), get("4", "repo")}},
+					task("5", []string{"repo"}, nil),
+				},
+			}
+		})
+
+		It("does not treat the attempts as conflicting", func() {
+			Expect(registry.Conflicts).To(BeEmpty())
+			Expect(registry.Artifacts[0].UsedBy).To(Equal([]atc.PlanID{"5"}))
+			Expect(registry.Artifacts[1].UsedBy).To(Equal([]atc.PlanID{"5"}))
+		})
+	})
+
+	Context("with hooks", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "1",
+				OnSuccess: &atc.OnSuccessPlan{
+					Step: atc.Plan{ID: "2", Put: &atc.PutPlan{Name: "repo", Inputs: &atc.InputsConfig{Specified: []string{"built"}}}},
+					Next: get("3", "repo"),
+				},
+			}
+		})
+
+		It("registers the artifacts of the hook", func() {
+			Expect(registry.Artifacts).To(Equal([]atc.ArtifactRegistration{
+				{Name: "repo", PlanID: "3", Step: "get"},
+			}))
+			Expect(registry.Unresolved).To(Equal([]atc.ArtifactReference{
+				{Name: "built", PlanID: "2"},
+			}))
+		})
+	})
+
+	Context("for a one-off build", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					{ID: "2", ArtifactInput: &atc.ArtifactInputPlan{ArtifactID: 42, Name: "src"}},
+					task("3", []string{"src"}, []string{"out"}),
+					{ID: "4", ArtifactOutput: &atc.ArtifactOutputPlan{Name: "out"}},
+				},
+			}
+		})
+
+		It("registers the uploaded inputs", func() {
+			Expect(registry.Artifacts).To(Equal([]atc.ArtifactRegistration{
+				{Name: "src", PlanID: "2", Step: "artifact_input", UsedBy: []atc.PlanID{"3"}},
+				{Name: "out", PlanID: "3", Step: "task", UsedBy: []atc.PlanID{"4"}},
+			}))
+			Expect(registry.Errors()).To(BeEmpty())
+		})
+	})
+})
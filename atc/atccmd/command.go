@@ -6,11 +6,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,15 +24,21 @@ import (
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/buildserver"
+	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/backfill"
+	"github.com/concourse/concourse/atc/buildretry"
 	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/checkpolicy"
+	"github.com/concourse/concourse/atc/contentscan"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
+	"github.com/concourse/concourse/atc/dbhealth"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/fetcher"
@@ -38,17 +46,25 @@ import (
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/lockrunner"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/pipelineinstances"
 	"github.com/concourse/concourse/atc/pipelines"
+	"github.com/concourse/concourse/atc/pipelinesrepo"
+	"github.com/concourse/concourse/atc/prewarm"
 	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
+	"github.com/concourse/concourse/atc/scheduler/gate"
+	"github.com/concourse/concourse/atc/scheduler/startlimit"
 	"github.com/concourse/concourse/atc/syslog"
+	"github.com/concourse/concourse/atc/versionhook"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
+	"github.com/concourse/concourse/atc/worker/transport"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/concourse/concourse/skymarshal"
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/concourse/concourse/skymarshal/storage"
+	"github.com/concourse/concourse/skymarshal/token"
 	"github.com/concourse/concourse/web"
 	"github.com/concourse/flag"
 	"github.com/concourse/retryhttp"
@@ -61,6 +77,7 @@ import (
 	"github.com/tedsuo/ifrit/sigmon"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
+	"sigs.k8s.io/yaml"
 
 	// dynamically registered metric emitters
 	_ "github.com/concourse/concourse/atc/metric/emitter"
@@ -76,6 +93,10 @@ import (
 var defaultDriverName = "postgres"
 var retryingDriverName = "too-many-connections-retrying"
 
+// imagePrefetchQueueSize is how many fetched images may wait to be prefetched
+// onto other workers before any more are dropped.
+const imagePrefetchQueueSize = 100
+
 type ATCCommand struct {
 	RunCommand RunCommand `command:"run"`
 	Migration  Migration  `command:"migrate"`
@@ -113,19 +134,85 @@ type RunCommand struct {
 
 	InterceptIdleTimeout time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
 
-	EnableGlobalResources bool          `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
-	EnableLidar           bool          `long:"enable-lidar" description:"The Future™ of resource checking."`
-	LidarScannerInterval  time.Duration `long:"lidar-scanner-interval" default:"1m" description:"Interval on which the resource scanner will run to see if new checks need to be scheduled"`
-	LidarCheckerInterval  time.Duration `long:"lidar-checker-interval" default:"10s" description:"Interval on which the resource checker runs any scheduled checks"`
+	ConfigValidationWorkers   int           `long:"config-validation-workers"    default:"4"   description:"Number of pipeline configs being set which are validated at once."`
+	ConfigValidationQueueSize int           `long:"config-validation-queue-size" default:"32"  description:"Number of pipeline configs which may wait for validation. Further attempts to set a pipeline are rejected until the queue drains."`
+	ConfigValidationTimeout   time.Duration `long:"config-validation-timeout"    default:"30s" description:"Length of time to wait for a pipeline config to be validated before giving up. 0 means no timeout."`
+
+	EnableGlobalResources       bool          `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
+	EnableGlobalPublicResources bool          `long:"enable-global-public-resources" description:"Enable equivalent resources of base resource types whose source references no credentials to share a single version history across pipelines and teams, so they are only checked once. Implied by --enable-global-resources."`
+	EnableLidar                 bool          `long:"enable-lidar" description:"The Future™ of resource checking."`
+	LidarScannerInterval        time.Duration `long:"lidar-scanner-interval" default:"1m" description:"Interval on which the resource scanner will run to see if new checks need to be scheduled"`
+	LidarCheckerInterval        time.Duration `long:"lidar-checker-interval" default:"10s" description:"Interval on which the resource checker runs any scheduled checks"`
+
+	EnableImageLayerCaching  bool `long:"enable-image-layer-caching" description:"Assemble registry-image images from layers cached on workers, downloading missing layers through the ATC, so that images sharing layers don't download them again."`
+	EnableImagePrefetch      bool `long:"enable-image-prefetch" description:"Stream each image fetched for a step onto every other worker which could run the same step, so that builds scheduled on them don't download the image again."`
+	ImagePrefetchMaxInFlight int  `long:"image-prefetch-max-in-flight" default:"4" description:"Maximum number of workers an image is prefetched onto at once."`
 
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
+	ResourceCheckKillGracePeriod time.Duration `long:"resource-check-kill-grace-period" default:"1m" description:"How long a check which has timed out is given to exit after being interrupted, before its processes are killed and the check is abandoned."`
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceTypeCheckingInterval time.Duration `long:"resource-type-checking-interval" default:"1m" description:"Interval on which to check for new versions of resource types."`
 
+	InputResolutionVersionLimit int `long:"input-resolution-version-limit" default:"0" description:"Maximum number of versions of each input explored when resolving a job's inputs together before giving up until the next scheduling tick, which resumes from where it left off. 0 means no limit."`
+
+	MaxBuildStartsPerMinute int `long:"max-build-starts-per-minute" default:"0" description:"Maximum number of builds started per minute, across all teams. The limit, like teams' own limits, is shared by all ATCs. Builds past this stay pending until the limit allows them. 0 means no limit."`
+
+	GateMetricProviders map[string]string `long:"gate-metric-provider" description:"A Prometheus server which job gates can query for metrics, referred to by name. Can be specified multiple times." value-name:"NAME:URL"`
+
 	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" description:"Method by which a worker is selected during container placement."`
 	MaxActiveTasksPerWorker           int           `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 
+	MaxConcurrentCreationsPerWorker int `long:"max-concurrent-creations-per-worker" default:"0" description:"Maximum number of containers and volumes this ATC will create on each worker at once. Further creations wait for a slot. 0 means no limit."`
+
+	WorkerMaxIdleConnections      int           `long:"worker-max-idle-connections" default:"4" description:"Number of idle connections to keep open to each of a worker's Garden and Baggageclaim servers for later requests to reuse. 0 opens a new connection for every request."`
+	WorkerIdleConnectionTimeout   time.Duration `long:"worker-idle-connection-timeout" default:"90s" description:"How long an idle connection to a worker is kept open."`
+	WorkerTCPKeepAlive            time.Duration `long:"worker-tcp-keepalive" default:"30s" description:"Interval between TCP keepalive probes on connections to workers."`
+	WorkerCircuitBreakerThreshold int           `long:"worker-circuit-breaker-threshold" default:"0" description:"Number of requests in a row to one of a worker's servers which fail to get a response before requests to it fail fast. 0 disables the circuit breaker."`
+	WorkerCircuitBreakerCooldown  time.Duration `long:"worker-circuit-breaker-cooldown" default:"30s" description:"How long requests to a worker fail fast for once its circuit breaker opens, before one is let through to try it again."`
+
+	ContainerCACerts []flag.File `long:"container-ca-cert" description:"Path to a PEM-encoded CA cert to trust in every task and resource container. Can be specified multiple times."`
+
+	ContainerDNSServers []string `long:"container-dns-server" description:"DNS server for build containers to use in place of the worker's. Can be specified multiple times."`
+	ContainerDNSSearch  []string `long:"container-dns-search" description:"Search domain for build containers to use in place of the worker's. Can be specified multiple times."`
+
+	DefaultTaskImageType   string            `long:"default-task-image-type" description:"Resource type of the image used by tasks which configure neither an image nor an image_resource."`
+	DefaultTaskImageSource map[string]string `long:"default-task-image-source" description:"Source of the default task image. Can be specified multiple times." value-name:"KEY:VALUE"`
+
+	ContentScannerURL flag.URL `long:"content-scanner-url" description:"URL to POST the content fetched by get steps and passed to put steps to, for scanning before the build carries on. Content is not scanned if not set."`
+	ContentScanPolicy string   `long:"content-scan-policy" default:"warn" choice:"warn" choice:"block" description:"Whether the content scanner's findings, or a failure to scan, only warn or error the step. Teams may be given their own policy."`
+
+	NewVersionWebhookAllowedHosts []string `long:"new-version-webhook-allowed-host" description:"Host which resources' new version webhooks may be sent to, e.g. 'hooks.example.com', or '*.example.com' for any of its subdomains. If any are given, webhooks are only sent to them. Otherwise they may be sent to any host other than those at loopback, private or link-local addresses. Can be specified multiple times."`
+
+	EnableArtifactChecksums bool `long:"enable-artifact-checksums" description:"Checksum the content fetched by get steps and produced by task steps, saving an event with each checksum. The content is streamed through the ATC to do so."`
+
+	ResourceTypeMappings flag.File `long:"resource-type-mappings" description:"Path to a YAML file mapping base resource types to images which implement them in their place, e.g. pointing git at an internally patched resource. Teams may override the mapping of each type."`
+
+	DeprecatedResourceTypes map[string]string `long:"deprecated-resource-type" description:"Resource type which pipelines should migrate away from, with a message saying what to use instead. Reported as a deprecation by pipelines using it. Can be specified multiple times." value-name:"TYPE:MESSAGE"`
+
+	PipelinesRepoInterval         time.Duration `long:"pipelines-repo-interval" default:"1m" description:"Interval on which teams' pipelines are reconciled with their pipelines repos."`
+	PipelinesRepoAllowedProtocols string        `long:"pipelines-repo-allowed-protocols" default:"https:ssh:git" description:"Colon-separated git protocols which pipelines repo URIs may use."`
+
+	PipelineInstancesInterval time.Duration `long:"pipeline-instances-interval" default:"1m" description:"Interval on which instances of pipelines configured with branches are spawned and archived."`
+
+	BackfillBatchSize int           `long:"backfill-batch-size" default:"1000" description:"Maximum number of rows each batch of an online migration's backfill visits."`
+	BackfillInterval  time.Duration `long:"backfill-interval" default:"1s" description:"Interval on which the next batch of an online migration's backfill is run."`
+
+	DBHealthInterval time.Duration `long:"db-health-interval" default:"5m" description:"Interval on which the bloat of the busiest database tables is emitted as metrics, and maintenance for them suggested in the logs."`
+
+	BuildEventBufferSize   int    `long:"build-event-buffer-size" default:"0" description:"Maximum number of build events buffered for each consumer of a build's event stream, shared between the builds of a multiplexed stream. Set to 0 to not buffer events."`
+	BuildEventBufferPolicy string `long:"build-event-buffer-policy" default:"block" choice:"block" choice:"drop-oldest" choice:"disconnect" description:"What to do when a consumer's build event buffer is full: stop reading events until it catches up, drop its oldest buffered event, or disconnect it."`
+
+	ResourceCacheStoreDir flag.Dir `long:"resource-cache-store-dir" description:"Directory (e.g. a mounted object store bucket) in which to persist initialized resource caches, so they can be hydrated onto other workers and survive worker recreation."`
+
+	ResourceCachePrewarmLimit    int           `long:"resource-cache-prewarm-limit" default:"0" description:"Number of the most used resource caches to create volumes for on each newly registered worker, from the resource cache store or other workers. 0 disables prewarming."`
+	ResourceCachePrewarmInterval time.Duration `long:"resource-cache-prewarm-interval" default:"30s" description:"Interval on which to look for newly registered workers to prewarm. Workers which started within the last interval are prewarmed."`
+
+	VolumeStreamingSource string `long:"volume-streaming-source" default:"arbitrary" choice:"arbitrary" choice:"nearest" description:"How to choose which of the workers which have a volume to stream it from. 'nearest' prefers workers in the same zone, then those running the fewest build containers."`
+	WorkerZoneLabel       string `long:"worker-zone-label" default:"zone" description:"Worker label whose value is the zone (e.g. availability zone) the worker runs in."`
+
+	KeepBuildOutputs bool `long:"keep-build-outputs" description:"Keep the artifacts produced by each job build as worker artifacts, so that they can be downloaded once the build has finished. They expire along with other worker artifacts."`
+
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -143,6 +230,7 @@ type RunCommand struct {
 		Attributes          map[string]string `long:"metrics-attribute" description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
 		BufferSize          uint32            `long:"metrics-buffer-size" default:"1000" description:"The size of the buffer used in emitting event metrics."`
 		CaptureErrorMetrics bool              `long:"capture-error-metrics" description:"Enable capturing of error log metrics"`
+		EmitterFamilies     map[string]string `long:"metrics-emitter-families" description:"Comma-separated families of metrics to emit to a metric emitter, such as build,worker. Metrics are in the family of the first word of their name. Can be specified once for each emitter, which otherwise emits every metric." value-name:"EMITTER:FAMILIES"`
 	} `group:"Metrics & Diagnostics"`
 
 	Server struct {
@@ -159,10 +247,15 @@ type RunCommand struct {
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
 		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"6h" description:"Period after which to reap checks that are completed."`
+		MaxBuildPendingPeriod  time.Duration `long:"max-build-pending-period" description:"Period after which pending builds that could be scheduled but still haven't started are errored, e.g. when no workers match the tags of their resources. Builds held by serial groups, max-in-flight, concurrency pools and the like are not errored. 0 means builds may be pending forever."`
+		DeletedTeamGracePeriod time.Duration `long:"deleted-team-grace-period" default:"72h" description:"Period after which deleted teams are purged, along with their pipelines and build history. Until then they can be restored."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
+	InfrastructureErrorRetries      int           `long:"infrastructure-error-retries" default:"0" description:"Number of times to automatically re-run a job build which errored because of a failure of its workers, e.g. a worker disappearing. 0 disables automatic retries."`
+	InfrastructureErrorRetryBackoff time.Duration `long:"infrastructure-error-retry-backoff" default:"1m" description:"Period to wait before the first automatic retry of a build. The period doubles with each retry."`
+
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 
 	DefaultBuildLogsToRetain uint64 `long:"default-build-logs-to-retain" description:"Default build logs to retain, 0 means all"`
@@ -174,6 +267,9 @@ type RunCommand struct {
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
 
+	CheckCpuLimits    map[string]string `long:"check-cpu-limit" description:"Max number of cpu shares for the check containers of a resource type. Can be specified multiple times." value-name:"TYPE:SHARES"`
+	CheckMemoryLimits map[string]string `long:"check-memory-limit" description:"Maximum memory for the check containers of a resource type. Can be specified multiple times." value-name:"TYPE:LIMIT"`
+
 	Auditor struct {
 		EnableBuildAuditLog     bool `long:"enable-build-auditing" description:"Enable auditing for all api requests connected to builds."`
 		EnableContainerAuditLog bool `long:"enable-container-auditing" description:"Enable auditing for all api requests connected to containers."`
@@ -202,13 +298,14 @@ type RunCommand struct {
 	EnableRedactSecrets bool `long:"enable-redact-secrets" description:"Enable redacting secrets in build logs."`
 }
 
-var HelpError = errors.New("must specify one of `--current-db-version`, `--supported-db-version`, or `--migrate-db-to-version`")
+var HelpError = errors.New("must specify one of `--current-db-version`, `--supported-db-version`, `--pending-migrations`, or `--migrate-db-to-version`")
 
 type Migration struct {
 	Postgres           flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 	EncryptionKey      flag.Cipher         `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
 	CurrentDBVersion   bool                `long:"current-db-version" description:"Print the current database version and exit"`
 	SupportedDBVersion bool                `long:"supported-db-version" description:"Print the max supported database version and exit"`
+	PendingMigrations  bool                `long:"pending-migrations" description:"Print the migrations which would be run to reach the max supported database version, with the table locks they take, and exit"`
 	MigrateDBToVersion int                 `long:"migrate-db-to-version" description:"Migrate to the specified database version and exit"`
 }
 
@@ -219,6 +316,9 @@ func (m *Migration) Execute(args []string) error {
 	if m.SupportedDBVersion {
 		return m.supportedDBVersion()
 	}
+	if m.PendingMigrations {
+		return m.pendingMigrations()
+	}
 	if m.MigrateDBToVersion > 0 {
 		return m.migrateDBToVersion()
 	}
@@ -259,6 +359,49 @@ func (cmd *Migration) supportedDBVersion() error {
 	return nil
 }
 
+func (cmd *Migration) pendingMigrations() error {
+	helper := migration.NewOpenHelper(
+		defaultDriverName,
+		cmd.Postgres.ConnectionString(),
+		nil,
+		encryption.NewNoEncryption(),
+	)
+
+	version, err := helper.SupportedVersion()
+	if err != nil {
+		return err
+	}
+
+	pending, err := helper.PendingMigrations(version)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("no pending migrations")
+		return nil
+	}
+
+	for _, m := range pending {
+		fmt.Printf("%d %s %s\n", m.Version, m.Direction, m.Name)
+
+		if m.Go {
+			fmt.Println("  runs Go code; locks cannot be estimated")
+			continue
+		}
+
+		if m.Transactional {
+			fmt.Println("  runs in a transaction; locks are held until it finishes")
+		}
+
+		for _, lock := range m.Locks {
+			fmt.Printf("  %s lock on %s (~%d rows)\n", lock.Mode, lock.Table, lock.EstimatedRows)
+		}
+	}
+
+	return nil
+}
+
 func (cmd *Migration) migrateDBToVersion() error {
 	version := cmd.MigrateDBToVersion
 
@@ -385,6 +528,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	})
 
 	atc.EnableGlobalResources = cmd.EnableGlobalResources
+	atc.EnableGlobalPublicResources = cmd.EnableGlobalPublicResources
 
 	radar.GlobalResourceCheckTimeout = cmd.GlobalResourceCheckTimeout
 	//FIXME: These only need to run once for the entire binary. At the moment,
@@ -416,7 +560,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
-	lockFactory := lock.NewLockFactory(lockConn, metric.LogLockAcquired, metric.LogLockReleased)
+	lockFactory := lock.NewLockFactory(lockConn, metric.LogLockAcquired, metric.LogLockReleased, metric.LogLockWaited)
 
 	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, cmd.MaxOpenConnections, "api", lockFactory)
 	if err != nil {
@@ -492,12 +636,16 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, storage, lockFactory, secretManager)
+	// shared by the API's and the backend's workers, so that together they
+	// stay within the per-worker limit
+	creationLimiter := cmd.creationLimiter()
+
+	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, storage, lockFactory, secretManager, creationLimiter)
 	if err != nil {
 		return nil, err
 	}
 
-	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager)
+	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager, creationLimiter)
 	if err != nil {
 		return nil, err
 	}
@@ -512,6 +660,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	storage storage.Storage,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	creationLimiter worker.CreationLimiter,
 ) ([]grouper.Member, error) {
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 	userFactory := db.NewUserFactory(dbConn)
@@ -553,6 +702,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbResourceConfigFactory,
 		resourceFetcher,
 		resourceFactory,
+		cmd.imageRegistryClient(),
+		nil,
 	)
 
 	dbWorkerBaseResourceTypeFactory := db.NewWorkerBaseResourceTypeFactory(dbConn)
@@ -565,10 +716,18 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	resourceTypeMappings, err := cmd.resourceTypeMappings()
+	if err != nil {
+		return nil, err
+	}
+
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
-		image.NewImageFactory(imageResourceFetcherFactory),
+		image.NewImageFactory(
+			imageResourceFetcherFactory,
+			image.NewResourceTypeMappings(resourceTypeMappings, teamFactory),
+		),
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
 		dbWorkerBaseResourceTypeFactory,
@@ -578,7 +737,9 @@ func (cmd *RunCommand) constructAPIMembers(
 		teamFactory,
 		dbWorkerFactory,
 		workerVersion,
-		cmd.BaggageclaimResponseHeaderTimeout,
+		cmd.workerConnections(logger),
+		cmd.resourceCacheStore(),
+		creationLimiter,
 	)
 
 	pool := worker.NewPool(workerProvider)
@@ -591,8 +752,8 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
-	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.GlobalResourceCheckTimeout)
-	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey())
+	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.GlobalResourceCheckTimeout, cmd.ResourceCheckKillGracePeriod)
+	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey(), db.NewAPITokenRepository(dbConn))
 
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
@@ -609,10 +770,16 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		userFactory,
+		db.NewLockRepository(dbConn),
+		db.NewBackfillRepository(dbConn),
+		db.NewDBHealthRepository(dbConn),
+		lockFactory,
 		workerClient,
 		secretManager,
 		credsManagers,
 		accessFactory,
+		token.NewGenerator(authHandler.PrivateKey),
+		resourceTypeMappings,
 	)
 
 	if err != nil {
@@ -697,6 +864,7 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbConn db.Conn,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	creationLimiter worker.CreationLimiter,
 ) ([]grouper.Member, error) {
 
 	if cmd.Syslog.Address != "" && cmd.Syslog.Transport == "" {
@@ -715,11 +883,14 @@ func (cmd *RunCommand) constructBackendMembers(
 	fetchSourceFactory := fetcher.NewFetchSourceFactory(dbResourceCacheFactory, resourceFactory)
 	resourceFetcher := fetcher.NewFetcher(clock.NewClock(), lockFactory, fetchSourceFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
+	imagePrefetchQueue := cmd.imagePrefetchQueue()
 	imageResourceFetcherFactory := image.NewImageResourceFetcherFactory(
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
 		resourceFetcher,
 		resourceFactory,
+		cmd.imageRegistryClient(),
+		imagePrefetchQueue,
 	)
 
 	dbWorkerBaseResourceTypeFactory := db.NewWorkerBaseResourceTypeFactory(dbConn)
@@ -732,10 +903,18 @@ func (cmd *RunCommand) constructBackendMembers(
 		return nil, err
 	}
 
+	resourceTypeMappings, err := cmd.resourceTypeMappings()
+	if err != nil {
+		return nil, err
+	}
+
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
-		image.NewImageFactory(imageResourceFetcherFactory),
+		image.NewImageFactory(
+			imageResourceFetcherFactory,
+			image.NewResourceTypeMappings(resourceTypeMappings, teamFactory),
+		),
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
 		dbWorkerBaseResourceTypeFactory,
@@ -745,7 +924,9 @@ func (cmd *RunCommand) constructBackendMembers(
 		teamFactory,
 		dbWorkerFactory,
 		workerVersion,
-		cmd.BaggageclaimResponseHeaderTimeout,
+		cmd.workerConnections(logger),
+		cmd.resourceCacheStore(),
+		creationLimiter,
 	)
 
 	pool := worker.NewPool(workerProvider)
@@ -756,12 +937,38 @@ func (cmd *RunCommand) constructBackendMembers(
 		return nil, err
 	}
 
+	checkLimits, err := cmd.parseCheckLimits()
+	if err != nil {
+		return nil, err
+	}
+
 	buildContainerStrategy, err := cmd.chooseBuildContainerStrategy()
 	if err != nil {
 		return nil, err
 	}
 	checkContainerStrategy := worker.NewRandomPlacementStrategy()
 
+	containerCACerts, err := cmd.loadContainerCACerts()
+	if err != nil {
+		return nil, err
+	}
+
+	containerDNS, err := cmd.containerDNS()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultTaskImage, err := cmd.defaultTaskImage()
+	if err != nil {
+		return nil, err
+	}
+
+	versionNotifier := versionhook.NewNotifier(
+		versionhook.NewClient(cmd.NewVersionWebhookAllowedHosts),
+		cmd.ExternalURL.String(),
+		cmd.NewVersionWebhookAllowedHosts,
+	)
+
 	engine := cmd.constructEngine(
 		pool,
 		workerClient,
@@ -770,11 +977,20 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbResourceConfigFactory,
 		secretManager,
 		defaultLimits,
+		checkLimits,
 		buildContainerStrategy,
 		resourceFactory,
 		lockFactory,
+		teamFactory,
+		containerCACerts,
+		containerDNS,
+		defaultTaskImage,
+		versionNotifier,
+		cmd.constructAuditor(logger),
 	)
 
+	checkPolicy := checkpolicy.NewEnforcer(teamFactory)
+
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		pool,
 		resourceFactory,
@@ -782,16 +998,26 @@ func (cmd *RunCommand) constructBackendMembers(
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
 		checkContainerStrategy,
+		startlimit.NewLimiter(clock.NewClock(), teamFactory, db.NewBuildStartBuckets(dbConn), cmd.MaxBuildStartsPerMinute),
+		checkPolicy,
+		versionNotifier,
+		cmd.constructGateEvaluator(),
+		cmd.InputResolutionVersionLimit,
+		teamFactory,
+		containerCACerts,
+		checkLimits,
+		cmd.ResourceCheckKillGracePeriod,
 	)
 
 	dbWorkerLifecycle := db.NewWorkerLifecycle(dbConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(dbConn)
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbArtifactLifecycle := db.NewArtifactLifecycle(dbConn)
+	dbImageLayerLifecycle := db.NewImageLayerLifecycle(dbConn)
 	dbCheckLifecycle := db.NewCheckLifecycle(dbConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(dbConn)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
-	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.GlobalResourceCheckTimeout)
+	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.GlobalResourceCheckTimeout, cmd.ResourceCheckKillGracePeriod)
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
 
 	bus := dbConn.Bus()
@@ -823,11 +1049,20 @@ func (cmd *RunCommand) constructBackendMembers(
 			logger.Session("collector"),
 			gc.NewCollector(
 				gc.NewBuildCollector(dbBuildFactory),
+				gc.NewPendingBuildCollector(
+					dbBuildFactory,
+					cmd.GC.MaxBuildPendingPeriod,
+				),
+				gc.NewTeamCollector(
+					teamFactory,
+					cmd.GC.DeletedTeamGracePeriod,
+				),
 				gc.NewWorkerCollector(dbWorkerLifecycle),
 				gc.NewResourceCacheUseCollector(dbResourceCacheLifecycle),
 				gc.NewResourceConfigCollector(dbResourceConfigFactory),
 				gc.NewResourceCacheCollector(dbResourceCacheLifecycle),
 				gc.NewArtifactCollector(dbArtifactLifecycle),
+				gc.NewImageLayerCollector(dbImageLayerLifecycle),
 				gc.NewCheckCollector(
 					dbCheckLifecycle,
 					cmd.GC.CheckRecyclePeriod,
@@ -875,6 +1110,67 @@ func (cmd *RunCommand) constructBackendMembers(
 		)},
 	}
 
+	if resourceCacheStore := cmd.resourceCacheStore(); resourceCacheStore != nil {
+		// run separately as it walks a store which may be slow to list
+		members = append(members, grouper.Member{
+			Name: "stored-resource-cache-collector", Runner: lockrunner.NewRunner(
+				logger.Session("stored-resource-cache-collector"),
+				gc.NewStoredResourceCacheCollector(dbResourceCacheLifecycle, resourceCacheStore),
+				"stored-resource-cache-collector",
+				lockFactory,
+				clock.NewClock(),
+				cmd.GC.Interval,
+			)})
+	}
+
+	members = append(members, grouper.Member{
+		Name: "pipelines-repo-reconciler", Runner: lockrunner.NewRunner(
+			logger.Session("pipelines-repo-reconciler"),
+			pipelinesrepo.NewReconciler(
+				clock.NewClock(),
+				teamFactory,
+				pipelinesrepo.NewGitFetcher(cmd.PipelinesRepoAllowedProtocols),
+				atc.DeprecatedResourceTypes(cmd.DeprecatedResourceTypes),
+			),
+			"pipelines-repo-reconciler",
+			lockFactory,
+			clock.NewClock(),
+			cmd.PipelinesRepoInterval,
+		)},
+		grouper.Member{Name: "pipeline-instances", Runner: lockrunner.NewRunner(
+			logger.Session("pipeline-instances"),
+			pipelineinstances.NewSpawner(
+				dbPipelineFactory,
+				teamFactory,
+				atc.DeprecatedResourceTypes(cmd.DeprecatedResourceTypes),
+			),
+			"pipeline-instances",
+			lockFactory,
+			clock.NewClock(),
+			cmd.PipelineInstancesInterval,
+		)},
+		grouper.Member{Name: "backfiller", Runner: lockrunner.NewRunner(
+			logger.Session("backfiller"),
+			backfill.NewBackfiller(
+				db.NewBackfillRepository(dbConn),
+				db.Backfills,
+				cmd.BackfillBatchSize,
+			),
+			"backfiller",
+			lockFactory,
+			clock.NewClock(),
+			cmd.BackfillInterval,
+		)},
+		grouper.Member{Name: "db-health", Runner: lockrunner.NewRunner(
+			logger.Session("db-health"),
+			dbhealth.NewMonitor(db.NewDBHealthRepository(dbConn)),
+			"db-health",
+			lockFactory,
+			clock.NewClock(),
+			cmd.DBHealthInterval,
+		)},
+	)
+
 	var lidarRunner ifrit.Runner
 
 	if cmd.EnableLidar {
@@ -887,12 +1183,14 @@ func (cmd *RunCommand) constructBackendMembers(
 				secretManager,
 				cmd.GlobalResourceCheckTimeout,
 				cmd.ResourceCheckingInterval,
+				checkPolicy,
 			),
 			cmd.LidarScannerInterval,
 			lidar.NewChecker(
 				logger.Session("lidar-checker"),
 				dbCheckFactory,
 				engine,
+				checkPolicy,
 			),
 			cmd.LidarCheckerInterval,
 			bus,
@@ -905,6 +1203,7 @@ func (cmd *RunCommand) constructBackendMembers(
 				logger.Session("lidar-checker"),
 				dbCheckFactory,
 				engine,
+				checkPolicy,
 			),
 			cmd.LidarCheckerInterval,
 			bus,
@@ -915,6 +1214,55 @@ func (cmd *RunCommand) constructBackendMembers(
 		Name: "lidar", Runner: lidarRunner,
 	})
 
+	if cmd.InfrastructureErrorRetries > 0 {
+		members = append(members, grouper.Member{
+			Name: "build-retrier", Runner: lockrunner.NewRunner(
+				logger.Session("build-retrier"),
+				buildretry.NewRetrier(
+					clock.NewClock(),
+					dbBuildFactory,
+					cmd.InfrastructureErrorRetries,
+					cmd.InfrastructureErrorRetryBackoff,
+				),
+				"build-retrier",
+				lockFactory,
+				clock.NewClock(),
+				10*time.Second,
+			)},
+		)
+	}
+
+	if imagePrefetchQueue != nil {
+		members = append(members, grouper.Member{
+			Name: "image-prefetcher", Runner: image.NewImagePrefetcher(
+				logger.Session("image-prefetcher"),
+				imagePrefetchQueue,
+				workerProvider,
+				cmd.ImagePrefetchMaxInFlight,
+			)},
+		)
+	}
+
+	if cmd.ResourceCachePrewarmLimit > 0 {
+		members = append(members, grouper.Member{
+			Name: "resource-cache-prewarmer", Runner: lockrunner.NewRunner(
+				logger.Session("resource-cache-prewarmer"),
+				prewarm.NewPrewarmer(
+					workerProvider,
+					dbResourceCacheFactory,
+					cmd.streamSourceStrategy(),
+					cmd.WorkerZoneLabel,
+					cmd.ResourceCachePrewarmLimit,
+					cmd.ResourceCachePrewarmInterval,
+				),
+				"resource-cache-prewarmer",
+				lockFactory,
+				clock.NewClock(),
+				cmd.ResourceCachePrewarmInterval,
+			)},
+		)
+	}
+
 	if syslogDrainConfigured {
 		members = append(members, grouper.Member{
 			Name: "syslog", Runner: lockrunner.NewRunner(
@@ -1105,6 +1453,37 @@ func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
 	})
 }
 
+func (cmd *RunCommand) parseCheckLimits() (map[string]atc.ContainerLimits, error) {
+	checkLimits := map[string]atc.ContainerLimits{}
+
+	for resourceType, value := range cmd.CheckCpuLimits {
+		cpu, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu limit for checks of '%s': %s", resourceType, err)
+		}
+
+		limits, err := atc.ParseContainerLimits(map[string]interface{}{"cpu": cpu})
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu limit for checks of '%s': %s", resourceType, err)
+		}
+
+		limits.Memory = checkLimits[resourceType].Memory
+		checkLimits[resourceType] = limits
+	}
+
+	for resourceType, value := range cmd.CheckMemoryLimits {
+		limits, err := atc.ParseContainerLimits(map[string]interface{}{"memory": value})
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory limit for checks of '%s': %s", resourceType, err)
+		}
+
+		limits.CPU = checkLimits[resourceType].CPU
+		checkLimits[resourceType] = limits
+	}
+
+	return checkLimits, nil
+}
+
 func (cmd *RunCommand) defaultBindIP() net.IP {
 	URL := cmd.BindIP.String()
 	if URL == "0.0.0.0" {
@@ -1146,6 +1525,21 @@ func run(runner ifrit.Runner, onReady func(), onExit func()) ifrit.Runner {
 	})
 }
 
+func (cmd *RunCommand) constructGateEvaluator() gate.Evaluator {
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}
+
+	providers := map[string]gate.Provider{}
+	for name, providerURL := range cmd.GateMetricProviders {
+		providers[name] = gate.NewPrometheusProvider(client, providerURL)
+	}
+
+	return gate.NewEvaluator(clock.NewClock(), client, providers)
+}
+
 func (cmd *RunCommand) validate() error {
 	var errs *multierror.Error
 
@@ -1178,6 +1572,16 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	for name, providerURL := range cmd.GateMetricProviders {
+		u, err := url.Parse(providerURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			errs = multierror.Append(
+				errs,
+				fmt.Errorf("invalid URL for --gate-metric-provider '%s': %s", name, providerURL),
+			)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1199,7 +1603,7 @@ func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 		host, _ = os.Hostname()
 	}
 
-	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes, cmd.Metrics.BufferSize)
+	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes, cmd.Metrics.BufferSize, cmd.Metrics.EmitterFamilies)
 }
 
 func (cmd *RunCommand) constructDBConn(
@@ -1269,6 +1673,127 @@ func (cmd *RunCommand) chooseBuildContainerStrategy() (worker.ContainerPlacement
 	return strategy, nil
 }
 
+func (cmd *RunCommand) streamSourceStrategy() worker.StreamSourceStrategy {
+	if cmd.VolumeStreamingSource == "nearest" {
+		return worker.NewNearestStreamSourceStrategy(cmd.WorkerZoneLabel)
+	}
+
+	return worker.NewArbitraryStreamSourceStrategy()
+}
+
+func (cmd *RunCommand) resourceCacheStore() worker.ResourceCacheStore {
+	if cmd.ResourceCacheStoreDir == "" {
+		return nil
+	}
+
+	return worker.NewDirResourceCacheStore(cmd.ResourceCacheStoreDir.Path())
+}
+
+func (cmd *RunCommand) creationLimiter() worker.CreationLimiter {
+	if cmd.MaxConcurrentCreationsPerWorker <= 0 {
+		return nil
+	}
+
+	return worker.NewCreationLimiter(cmd.MaxConcurrentCreationsPerWorker)
+}
+
+func (cmd *RunCommand) loadContainerCACerts() (string, error) {
+	var certs []string
+	for _, path := range cmd.ContainerCACerts {
+		content, err := ioutil.ReadFile(path.Path())
+		if err != nil {
+			return "", fmt.Errorf("failed to read container CA cert: %s", err)
+		}
+
+		certs = append(certs, strings.TrimSpace(string(content)))
+	}
+
+	if len(certs) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(certs, "\n") + "\n", nil
+}
+
+func (cmd *RunCommand) containerDNS() (*atc.ContainerDNS, error) {
+	if len(cmd.ContainerDNSServers) == 0 && len(cmd.ContainerDNSSearch) == 0 {
+		return nil, nil
+	}
+
+	dns := &atc.ContainerDNS{
+		Servers: cmd.ContainerDNSServers,
+		Search:  cmd.ContainerDNSSearch,
+	}
+
+	err := dns.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid container dns: %s", err)
+	}
+
+	return dns, nil
+}
+
+func (cmd *RunCommand) defaultTaskImage() (*atc.ImageResource, error) {
+	if cmd.DefaultTaskImageType == "" && len(cmd.DefaultTaskImageSource) == 0 {
+		return nil, nil
+	}
+
+	image := &atc.ImageResource{
+		Type:   cmd.DefaultTaskImageType,
+		Source: atc.Source{},
+	}
+
+	for key, value := range cmd.DefaultTaskImageSource {
+		image.Source[key] = value
+	}
+
+	err := image.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid default task image: %s", err)
+	}
+
+	return image, nil
+}
+
+func (cmd *RunCommand) resourceTypeMappings() (atc.ResourceTypeMappings, error) {
+	if cmd.ResourceTypeMappings == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(cmd.ResourceTypeMappings.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource type mappings: %s", err)
+	}
+
+	var mappings atc.ResourceTypeMappings
+	err = yaml.Unmarshal(content, &mappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resource type mappings: %s", err)
+	}
+
+	err = mappings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource type mappings: %s", err)
+	}
+
+	return mappings, nil
+}
+
+func (cmd *RunCommand) workerConnections(logger lager.Logger) *transport.WorkerConnections {
+	return transport.NewWorkerConnections(
+		logger.Session("worker-connections"),
+		clock.NewClock(),
+		transport.WorkerConnectionsConfig{
+			MaxIdleConns:                      cmd.WorkerMaxIdleConnections,
+			IdleConnTimeout:                   cmd.WorkerIdleConnectionTimeout,
+			TCPKeepAlive:                      cmd.WorkerTCPKeepAlive,
+			BaggageclaimResponseHeaderTimeout: cmd.BaggageclaimResponseHeaderTimeout,
+			CircuitBreakerThreshold:           cmd.WorkerCircuitBreakerThreshold,
+			CircuitBreakerCooldown:            cmd.WorkerCircuitBreakerCooldown,
+		},
+	)
+}
+
 func (cmd *RunCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) error {
 	team, found, err := teamFactory.FindTeam(atc.DefaultTeamName)
 	if err != nil {
@@ -1300,9 +1825,16 @@ func (cmd *RunCommand) constructEngine(
 	resourceConfigFactory db.ResourceConfigFactory,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
+	checkLimits map[string]atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
 	lockFactory lock.LockFactory,
+	teamFactory db.TeamFactory,
+	containerCACerts string,
+	containerDNS *atc.ContainerDNS,
+	defaultTaskImage *atc.ImageResource,
+	versionNotifier versionhook.Notifier,
+	aud auditor.Auditor,
 ) engine.Engine {
 
 	stepFactory := builder.NewStepFactory(
@@ -1312,20 +1844,43 @@ func (cmd *RunCommand) constructEngine(
 		resourceCacheFactory,
 		resourceConfigFactory,
 		defaultLimits,
+		checkLimits,
 		strategy,
 		resourceFactory,
 		lockFactory,
+		cmd.contentScanner(),
 	)
 
 	stepBuilder := builder.NewStepBuilder(
 		stepFactory,
-		builder.NewDelegateFactory(),
+		builder.NewDelegateFactory(versionNotifier),
 		cmd.ExternalURL.String(),
 		secretManager,
 		cmd.EnableRedactSecrets,
+		teamFactory,
+		containerCACerts,
+		containerDNS,
+		defaultTaskImage,
+		atc.ContentScanPolicy(cmd.ContentScanPolicy),
+		cmd.EnableArtifactChecksums,
 	)
 
-	return engine.NewEngine(stepBuilder)
+	return engine.NewEngine(stepBuilder, teamFactory, aud, cmd.KeepBuildOutputs)
+}
+
+func (cmd *RunCommand) constructAuditor(logger lager.Logger) auditor.Auditor {
+	return auditor.NewAuditor(
+		cmd.Auditor.EnableBuildAuditLog,
+		cmd.Auditor.EnableContainerAuditLog,
+		cmd.Auditor.EnableJobAuditLog,
+		cmd.Auditor.EnablePipelineAuditLog,
+		cmd.Auditor.EnableResourceAuditLog,
+		cmd.Auditor.EnableSystemAuditLog,
+		cmd.Auditor.EnableTeamAuditLog,
+		cmd.Auditor.EnableWorkerAuditLog,
+		cmd.Auditor.EnableVolumeAuditLog,
+		logger,
+	)
 }
 
 func (cmd *RunCommand) constructHTTPHandler(
@@ -1359,6 +1914,13 @@ func (cmd *RunCommand) constructHTTPHandler(
 	return httpHandler
 }
 
+func (cmd *RunCommand) buildEventBufferConfig() buildserver.EventBufferConfig {
+	return buildserver.EventBufferConfig{
+		Size:   cmd.BuildEventBufferSize,
+		Policy: cmd.BuildEventBufferPolicy,
+	}
+}
+
 func (cmd *RunCommand) constructAPIHandler(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
@@ -1374,10 +1936,16 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbCheckFactory db.CheckFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	lockRepository db.LockRepository,
+	backfillRepository db.BackfillRepository,
+	dbHealthRepository db.DBHealthRepository,
+	lockFactory lock.LockFactory,
 	workerClient worker.Client,
 	secretManager creds.Secrets,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
+	apiTokenGenerator token.Generator,
+	resourceTypeMappings atc.ResourceTypeMappings,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1385,18 +1953,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	checkBuildWriteAccessHandlerFactory := auth.NewCheckBuildWriteAccessHandlerFactory(dbBuildFactory)
 	checkWorkerTeamAccessHandlerFactory := auth.NewCheckWorkerTeamAccessHandlerFactory(dbWorkerFactory)
 
-	aud := auditor.NewAuditor(
-		cmd.Auditor.EnableBuildAuditLog,
-		cmd.Auditor.EnableContainerAuditLog,
-		cmd.Auditor.EnableJobAuditLog,
-		cmd.Auditor.EnablePipelineAuditLog,
-		cmd.Auditor.EnableResourceAuditLog,
-		cmd.Auditor.EnableSystemAuditLog,
-		cmd.Auditor.EnableTeamAuditLog,
-		cmd.Auditor.EnableWorkerAuditLog,
-		cmd.Auditor.EnableVolumeAuditLog,
-		logger,
-	)
+	aud := cmd.constructAuditor(logger)
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
 		wrappa.NewAPIAuthWrappa(
@@ -1427,8 +1984,20 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbCheckFactory,
 		resourceConfigFactory,
 		dbUserFactory,
+		lockRepository,
+		lockFactory,
+		&metric.ResourceCaches,
+		migration.NewOpenHelper(
+			defaultDriverName,
+			cmd.Postgres.ConnectionString(),
+			nil,
+			encryption.NewNoEncryption(),
+		),
+		backfillRepository,
+		dbHealthRepository,
 
-		buildserver.NewEventHandler,
+		buildserver.NewBufferedEventHandlerFactory(cmd.buildEventBufferConfig()),
+		buildserver.NewBufferedMultiplexEventHandlerFactory(cmd.buildEventBufferConfig()),
 
 		workerClient,
 
@@ -1442,6 +2011,10 @@ func (cmd *RunCommand) constructAPIHandler(
 		secretManager,
 		credsManagers,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
+		atc.DeprecatedResourceTypes(cmd.DeprecatedResourceTypes),
+		apiTokenGenerator,
+		resourceTypeMappings,
+		configserver.NewConfigValidator(cmd.ConfigValidationWorkers, cmd.ConfigValidationQueueSize, cmd.ConfigValidationTimeout),
 	)
 }
 
@@ -1500,7 +2073,7 @@ func (cmd *RunCommand) constructPipelineSyncer(
 							"pipeline": pipeline.Name(),
 						}),
 						Pipeline:  pipeline,
-						Scheduler: radarSchedulerFactory.BuildScheduler(pipeline),
+						Scheduler: radarSchedulerFactory.BuildScheduler(pipeline, variables),
 						Noop:      cmd.Developer.Noop,
 						Interval:  10 * time.Second,
 					},
@@ -1538,6 +2111,45 @@ func (cmd *RunCommand) appendStaticWorker(
 	)
 }
 
+// contentScanner returns the scanner which the content of get and put steps
+// is passed through, or nil if content scanning is disabled.
+func (cmd *RunCommand) contentScanner() contentscan.Scanner {
+	if cmd.ContentScannerURL.URL == nil {
+		return nil
+	}
+
+	return contentscan.NewHTTPScanner(&http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}, cmd.ContentScannerURL.String())
+}
+
+// imagePrefetchQueue returns the queue images are prefetched from, or nil if
+// image prefetching is disabled.
+func (cmd *RunCommand) imagePrefetchQueue() image.ImagePrefetchQueue {
+	if !cmd.EnableImagePrefetch {
+		return nil
+	}
+
+	return image.NewImagePrefetchQueue(imagePrefetchQueueSize)
+}
+
+// imageRegistryClient returns the client used to download image layers from
+// registries, or nil if image layer caching is disabled.
+func (cmd *RunCommand) imageRegistryClient() *http.Client {
+	if !cmd.EnableImageLayerCaching {
+		return nil
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
+
 func (cmd *RunCommand) isTLSEnabled() bool {
 	return cmd.TLSBindPort != 0
 }
@@ -37,8 +37,16 @@ func NewAuditor(
 
 type Auditor interface {
 	Audit(action string, userName string, r *http.Request)
+
+	// AuditEvent records an action taken by the ATC itself, rather than one
+	// requested through the API.
+	AuditEvent(action string, data lager.Data)
 }
 
+// PrivilegedNotAllowed is audited when a build is refused a privileged
+// container by its team's privileged policy.
+const PrivilegedNotAllowed = "PrivilegedNotAllowed"
+
 type auditor struct {
 	EnableBuildAuditLog     bool
 	EnableContainerAuditLog bool
@@ -84,30 +92,45 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 	}
 }
 
+func (a *auditor) AuditEvent(action string, data lager.Data) {
+	if a.ValidateAction(action) {
+		a.logger.Info("audit", lager.Data{"action": action, "parameters": data})
+	}
+}
+
 var loggingLevels = map[string]string{
+	PrivilegedNotAllowed:              "EnableTeamAuditLog",
 	atc.SaveConfig:                    "EnableSystemAuditLog",
 	atc.GetConfig:                     "EnableSystemAuditLog",
 	atc.GetCC:                         "EnableSystemAuditLog",
 	atc.GetBuild:                      "EnableBuildAuditLog",
 	atc.GetBuildPlan:                  "EnableBuildAuditLog",
+	atc.GetBuildArtifactRegistry:      "EnableBuildAuditLog",
 	atc.CreateBuild:                   "EnableBuildAuditLog",
 	atc.ListBuilds:                    "EnableBuildAuditLog",
 	atc.BuildEvents:                   "EnableBuildAuditLog",
 	atc.BuildResources:                "EnableBuildAuditLog",
 	atc.AbortBuild:                    "EnableBuildAuditLog",
 	atc.GetBuildPreparation:           "EnableBuildAuditLog",
+	atc.ExplainBuild:                  "EnableBuildAuditLog",
+	atc.GetBuildSnapshot:              "EnableBuildAuditLog",
+	atc.GetBuildTimeline:              "EnableBuildAuditLog",
+	atc.GetBuildPlanGraph:             "EnableBuildAuditLog",
 	atc.GetJob:                        "EnableJobAuditLog",
 	atc.CreateJobBuild:                "EnableJobAuditLog",
 	atc.ListAllJobs:                   "EnableJobAuditLog",
 	atc.ListJobs:                      "EnableJobAuditLog",
 	atc.ListJobBuilds:                 "EnableJobAuditLog",
 	atc.ListJobInputs:                 "EnableJobAuditLog",
+	atc.GetJobPlanGraph:               "EnableJobAuditLog",
+	atc.GetJobStatistics:              "EnableJobAuditLog",
 	atc.GetJobBuild:                   "EnableJobAuditLog",
 	atc.PauseJob:                      "EnableJobAuditLog",
 	atc.UnpauseJob:                    "EnableJobAuditLog",
 	atc.GetVersionsDB:                 "EnableSystemAuditLog",
 	atc.JobBadge:                      "EnableJobAuditLog",
 	atc.MainJobBadge:                  "EnableJobAuditLog",
+	atc.JobStatus:                     "EnableJobAuditLog",
 	atc.ClearTaskCache:                "EnableSystemAuditLog",
 	atc.ListAllResources:              "EnableResourceAuditLog",
 	atc.ListResources:                 "EnableResourceAuditLog",
@@ -126,19 +149,24 @@ var loggingLevels = map[string]string{
 	atc.ListBuildsWithVersionAsInput:  "EnableBuildAuditLog",
 	atc.ListBuildsWithVersionAsOutput: "EnableBuildAuditLog",
 	atc.GetResourceCausality:          "EnableResourceAuditLog",
+	atc.ListResourceVersionSpan:       "EnableResourceAuditLog",
 	atc.ListAllPipelines:              "EnablePipelineAuditLog",
 	atc.ListPipelines:                 "EnablePipelineAuditLog",
 	atc.GetPipeline:                   "EnablePipelineAuditLog",
+	atc.GetPipelineScheduling:         "EnablePipelineAuditLog",
 	atc.DeletePipeline:                "EnablePipelineAuditLog",
 	atc.OrderPipelines:                "EnablePipelineAuditLog",
 	atc.PausePipeline:                 "EnablePipelineAuditLog",
 	atc.UnpausePipeline:               "EnablePipelineAuditLog",
 	atc.ExposePipeline:                "EnablePipelineAuditLog",
+	atc.SharePipeline:                 "EnablePipelineAuditLog",
+	atc.UnsharePipeline:               "EnablePipelineAuditLog",
 	atc.HidePipeline:                  "EnablePipelineAuditLog",
 	atc.RenamePipeline:                "EnablePipelineAuditLog",
 	atc.ListPipelineBuilds:            "EnablePipelineAuditLog",
 	atc.CreatePipelineBuild:           "EnablePipelineAuditLog",
 	atc.PipelineBadge:                 "EnablePipelineAuditLog",
+	atc.PipelineStatus:                "EnablePipelineAuditLog",
 	atc.RegisterWorker:                "EnableWorkerAuditLog",
 	atc.LandWorker:                    "EnableWorkerAuditLog",
 	atc.RetireWorker:                  "EnableWorkerAuditLog",
@@ -146,6 +174,8 @@ var loggingLevels = map[string]string{
 	atc.HeartbeatWorker:               "EnableWorkerAuditLog",
 	atc.ListWorkers:                   "EnableWorkerAuditLog",
 	atc.DeleteWorker:                  "EnableWorkerAuditLog",
+	atc.ListWorkerDiscrepancies:       "EnableWorkerAuditLog",
+	atc.ResolveWorkerDiscrepancies:    "EnableWorkerAuditLog",
 	atc.SetLogLevel:                   "EnableSystemAuditLog",
 	atc.GetLogLevel:                   "EnableSystemAuditLog",
 	atc.DownloadCLI:                   "EnableSystemAuditLog",
@@ -154,6 +184,7 @@ var loggingLevels = map[string]string{
 	atc.ListContainers:                "EnableContainerAuditLog",
 	atc.GetContainer:                  "EnableContainerAuditLog",
 	atc.HijackContainer:               "EnableContainerAuditLog",
+	atc.HijackCheckContainer:          "EnableContainerAuditLog",
 	atc.ListDestroyingContainers:      "EnableContainerAuditLog",
 	atc.ReportWorkerContainers:        "EnableContainerAuditLog",
 	atc.ListVolumes:                   "EnableVolumeAuditLog",
@@ -163,7 +194,12 @@ var loggingLevels = map[string]string{
 	atc.SetTeam:                       "EnableTeamAuditLog",
 	atc.RenameTeam:                    "EnableTeamAuditLog",
 	atc.DestroyTeam:                   "EnableTeamAuditLog",
+	atc.RestoreTeam:                   "EnableTeamAuditLog",
 	atc.ListTeamBuilds:                "EnableTeamAuditLog",
+	atc.GetResourceTypeHealth:         "EnableTeamAuditLog",
+	atc.ListAPITokens:                 "EnableTeamAuditLog",
+	atc.CreateAPIToken:                "EnableTeamAuditLog",
+	atc.RevokeAPIToken:                "EnableTeamAuditLog",
 	atc.CreateArtifact:                "EnableBuildAuditLog",
 	atc.GetArtifact:                   "EnableBuildAuditLog",
 	atc.ListBuildArtifacts:            "EnableBuildAuditLog",
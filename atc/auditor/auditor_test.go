@@ -3,6 +3,7 @@ package auditor_test
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc/auditor"
@@ -569,4 +570,28 @@ var _ = Describe("Audit", func() {
 			})
 		})
 	})
+
+	Describe("AuditEvent", func() {
+		Context("When EnableTeamAuditLog is true with a PrivilegedNotAllowed event", func() {
+			BeforeEach(func() {
+				EnableTeamAuditLog = true
+			})
+
+			It("Create a log including the action and its data", func() {
+				aud.AuditEvent(auditor.PrivilegedNotAllowed, lager.Data{"team": "some-team"})
+				logs := logger.Logs()
+				Expect(logs).To(HaveLen(1))
+				Expect(logs[0].Data["action"]).To(Equal(auditor.PrivilegedNotAllowed))
+				Expect(logs[0].Data["parameters"]).To(Equal(map[string]interface{}{"team": "some-team"}))
+			})
+		})
+
+		Context("When EnableTeamAuditLog is false with a PrivilegedNotAllowed event", func() {
+			It("Doesn't create a log", func() {
+				aud.AuditEvent(auditor.PrivilegedNotAllowed, lager.Data{"team": "some-team"})
+				logs := logger.Logs()
+				Expect(len(logs)).To(Equal(0))
+			})
+		})
+	})
 })
@@ -0,0 +1,48 @@
+package backfill
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Backfiller runs a batch of the first unfinished backfill each time it
+// runs, so that backfills make steady progress without loading the database
+// any more than a batch at a time.
+type Backfiller struct {
+	repository db.BackfillRepository
+	backfills  []db.Backfill
+	batchSize  int
+}
+
+func NewBackfiller(repository db.BackfillRepository, backfills []db.Backfill, batchSize int) *Backfiller {
+	return &Backfiller{
+		repository: repository,
+		backfills:  backfills,
+		batchSize:  batchSize,
+	}
+}
+
+func (b *Backfiller) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("backfill")
+
+	for _, backfill := range b.backfills {
+		logger := logger.WithData(lager.Data{"backfill": backfill.Name})
+
+		finished, err := b.repository.RunBatch(backfill, b.batchSize)
+		if err != nil {
+			logger.Error("failed-to-run-batch", err)
+			return err
+		}
+
+		if !finished {
+			return nil
+		}
+
+		logger.Debug("finished")
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package backfill_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/backfill"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backfiller", func() {
+	var (
+		fakeRepository *dbfakes.FakeBackfillRepository
+		backfills      []db.Backfill
+
+		runErr error
+	)
+
+	batchesRun := func() []string {
+		names := []string{}
+		for i := 0; i < fakeRepository.RunBatchCallCount(); i++ {
+			backfill, size := fakeRepository.RunBatchArgsForCall(i)
+			Expect(size).To(Equal(500))
+			names = append(names, backfill.Name)
+		}
+
+		return names
+	}
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeBackfillRepository)
+
+		backfills = []db.Backfill{
+			{Name: "first", Table: "builds"},
+			{Name: "second", Table: "teams"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = backfill.NewBackfiller(fakeRepository, backfills, 500).Run(ctx)
+	})
+
+	Context("when the first backfill is unfinished", func() {
+		BeforeEach(func() {
+			fakeRepository.RunBatchReturns(false, nil)
+		})
+
+		It("runs a batch of it alone", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(batchesRun()).To(Equal([]string{"first"}))
+		})
+	})
+
+	Context("when the first backfill has finished", func() {
+		BeforeEach(func() {
+			fakeRepository.RunBatchReturnsOnCall(0, true, nil)
+			fakeRepository.RunBatchReturnsOnCall(1, false, nil)
+		})
+
+		It("moves on to the next", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(batchesRun()).To(Equal([]string{"first", "second"}))
+		})
+	})
+
+	Context("when a batch fails", func() {
+		BeforeEach(func() {
+			fakeRepository.RunBatchReturns(false, errors.New("nope"))
+		})
+
+		It("returns the error without moving on", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(batchesRun()).To(Equal([]string{"first"}))
+		})
+	})
+})
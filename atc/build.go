@@ -1,5 +1,11 @@
 package atc
 
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
 type BuildStatus string
 
 const (
@@ -22,6 +28,116 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+
+	// Paused is true when the build has been paused, holding it before its
+	// next step until it's resumed.
+	Paused bool `json:"paused,omitempty"`
+
+	// RerunOf is the ID of the failed build which this build re-runs.
+	RerunOf int `json:"rerun_of,omitempty"`
+
+	// AutoRetryAttempt counts which automatic retry of a build that errored
+	// because of an infrastructure failure this build is. It is 0 for builds
+	// which aren't automatic retries.
+	AutoRetryAttempt int `json:"auto_retry_attempt,omitempty"`
+
+	// ErrorCode is the kind of failure which errored the build, if it's one
+	// with a code.
+	ErrorCode ErrorCode `json:"error_code,omitempty"`
+
+	Annotations BuildAnnotations `json:"annotations,omitempty"`
+
+	InputOverrides InputVersionOverrides `json:"input_overrides,omitempty"`
+	InputFallbacks InputFallbacks        `json:"input_fallbacks,omitempty"`
+	ImageOverrides TaskImageOverrides    `json:"image_overrides,omitempty"`
+	InputArtifacts InputArtifacts        `json:"input_artifacts,omitempty"`
+
+	WorkerOverrides StepWorkerOverrides `json:"worker_overrides,omitempty"`
+}
+
+// InputVersionOverrides maps the names of a job's inputs to the versions a
+// manually triggered build uses for them, regardless of the inputs' version
+// and passed constraints.
+type InputVersionOverrides map[string]Version
+
+// TaskImageOverrides maps the names of a job's task steps to overrides of
+// their image_resource for a manually triggered build, for trying out a
+// different image without changing the pipeline.
+type TaskImageOverrides map[string]TaskImageOverride
+
+// TaskImageOverride replaces the version and/or the source of a task's
+// image_resource.
+type TaskImageOverride struct {
+	Version Version `json:"version,omitempty"`
+	Source  Source  `json:"source,omitempty"`
+}
+
+// InputArtifacts maps the names of a job's inputs to the IDs of artifacts
+// uploaded for a manually triggered build to use in place of fetching them,
+// e.g. for a hotfix which isn't in any resource yet. The artifacts expire
+// along with other uploaded artifacts.
+type InputArtifacts map[string]int
+
+// StepWorkerOverrides maps the names of a job's get, put, and task steps to
+// the workers a manually triggered build runs them on, for reproducing
+// failures which only happen on a particular worker. The workers must be
+// shared by all teams or belong to the build's team.
+type StepWorkerOverrides map[string]string
+
+// InputFallbacks maps the names of the inputs of a build which fell back to
+// the latest version the job has passed with to the latest versions they
+// fell back from.
+type InputFallbacks map[string]Version
+
+// CreateJobBuildRequest is the optional body of a request to trigger a job.
+type CreateJobBuildRequest struct {
+	InputOverrides InputVersionOverrides `json:"input_overrides,omitempty"`
+	ImageOverrides TaskImageOverrides    `json:"image_overrides,omitempty"`
+	InputArtifacts InputArtifacts        `json:"input_artifacts,omitempty"`
+
+	WorkerOverrides StepWorkerOverrides `json:"worker_overrides,omitempty"`
+}
+
+// BuildAnnotations are short results attached to a build by its tasks or by
+// external systems, e.g. a coverage percentage or a link to a ticket. Values
+// may contain markdown.
+type BuildAnnotations map[string]string
+
+const (
+	MaxBuildAnnotations           = 50
+	MaxBuildAnnotationKeyLength   = 64
+	MaxBuildAnnotationValueLength = 4096
+)
+
+var buildAnnotationKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// Validate checks annotations given to be saved. An empty value removes the
+// annotation.
+func (annotations BuildAnnotations) Validate() error {
+	errorMessages := []string{}
+
+	if len(annotations) > MaxBuildAnnotations {
+		errorMessages = append(errorMessages, fmt.Sprintf("at most %d annotations may be given", MaxBuildAnnotations))
+	}
+
+	keys := []string{}
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if len(key) > MaxBuildAnnotationKeyLength || !buildAnnotationKeyRegex.MatchString(key) {
+			errorMessages = append(errorMessages, fmt.Sprintf("invalid key '%s': must be at most %d letters, digits, '_', '.' or '-'", key, MaxBuildAnnotationKeyLength))
+		}
+
+		if len(annotations[key]) > MaxBuildAnnotationValueLength {
+			errorMessages = append(errorMessages, fmt.Sprintf("value of '%s' is longer than %d bytes", key, MaxBuildAnnotationValueLength))
+		}
+	}
+
+	return compositeErr(errorMessages)
 }
 
 func (b Build) IsRunning() bool {
@@ -0,0 +1,205 @@
+package atc
+
+import "sort"
+
+// BuildCheckpoint is the last step of a job build's sequence of steps to
+// have succeeded, along with the artifacts registered by then. A build which
+// fails can be re-run from the step after its checkpoint.
+type BuildCheckpoint struct {
+	PlanID    PlanID               `json:"plan_id"`
+	Artifacts []CheckpointArtifact `json:"artifacts"`
+}
+
+// CheckpointArtifact is an artifact registered by the time a build reached
+// its checkpoint. Artifacts fetched by get steps are kept as the version
+// fetched, so that a re-run finds them in the resource's cache again, and
+// others as the worker artifact holding their volume.
+type CheckpointArtifact struct {
+	Name string `json:"name"`
+
+	ArtifactID int `json:"artifact_id,omitempty"`
+
+	GetPlanID PlanID  `json:"get_plan_id,omitempty"`
+	Version   Version `json:"version,omitempty"`
+}
+
+// CheckpointSteps returns the IDs of the steps in a job build's sequence of
+// steps, i.e. those which a re-run of the build can start after.
+func CheckpointSteps(plan Plan) []PlanID {
+	sequence := stepSequence(plan)
+	if sequence == nil {
+		return nil
+	}
+
+	ids := []PlanID{}
+	for _, step := range *sequence {
+		ids = append(ids, step.ID)
+	}
+
+	return ids
+}
+
+// RerunPlan returns the plan for re-running a job build from the step after
+// its checkpoint. The steps up to and including the checkpoint are replaced
+// by ones giving the later steps the artifacts registered by then. It
+// returns false when the checkpoint isn't in the plan's sequence of steps.
+func RerunPlan(plan Plan, checkpoint BuildCheckpoint, factory PlanFactory) (Plan, bool) {
+	artifacts := append([]CheckpointArtifact{}, checkpoint.Artifacts...)
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+
+	inputs := []Plan{}
+	for _, artifact := range artifacts {
+		if artifact.ArtifactID != 0 {
+			inputs = append(inputs, factory.NewPlan(ArtifactInputPlan{
+				ArtifactID: artifact.ArtifactID,
+				Name:       artifact.Name,
+			}))
+
+			continue
+		}
+
+		get, found := findPlan(plan, artifact.GetPlanID)
+		if !found || get.Get == nil {
+			return Plan{}, false
+		}
+
+		version := artifact.Version
+
+		getPlan := *get.Get
+		getPlan.Name = artifact.Name
+		getPlan.Version = &version
+		getPlan.VersionFrom = nil
+
+		inputs = append(inputs, factory.NewPlan(getPlan))
+	}
+
+	var resumed []Plan
+	if len(inputs) > 0 {
+		resumed = append(resumed, factory.NewPlan(InParallelPlan{
+			Steps:    inputs,
+			FailFast: true,
+		}))
+	}
+
+	return resumeSequence(plan, checkpoint.PlanID, resumed)
+}
+
+// stepSequence returns the sequence of steps of a job build's plan, which is
+// wrapped in the job's hooks.
+func stepSequence(plan Plan) *DoPlan {
+	switch {
+	case plan.Do != nil:
+		return plan.Do
+	case plan.OnAbort != nil:
+		return stepSequence(plan.OnAbort.Step)
+	case plan.OnError != nil:
+		return stepSequence(plan.OnError.Step)
+	case plan.OnFailure != nil:
+		return stepSequence(plan.OnFailure.Step)
+	case plan.OnSuccess != nil:
+		return stepSequence(plan.OnSuccess.Step)
+	case plan.Ensure != nil:
+		return stepSequence(plan.Ensure.Step)
+	}
+
+	return nil
+}
+
+// resumeSequence returns a copy of the plan with the steps of its sequence
+// of steps up to and including the given one replaced.
+func resumeSequence(plan Plan, from PlanID, replacements []Plan) (Plan, bool) {
+	switch {
+	case plan.Do != nil:
+		for i, step := range *plan.Do {
+			if step.ID != from {
+				continue
+			}
+
+			do := append(DoPlan{}, replacements...)
+			do = append(do, (*plan.Do)[i+1:]...)
+			plan.Do = &do
+
+			return plan, true
+		}
+
+	case plan.OnAbort != nil:
+		hook := *plan.OnAbort
+		step, found := resumeSequence(hook.Step, from, replacements)
+		hook.Step = step
+		plan.OnAbort = &hook
+		return plan, found
+
+	case plan.OnError != nil:
+		hook := *plan.OnError
+		step, found := resumeSequence(hook.Step, from, replacements)
+		hook.Step = step
+		plan.OnError = &hook
+		return plan, found
+
+	case plan.OnFailure != nil:
+		hook := *plan.OnFailure
+		step, found := resumeSequence(hook.Step, from, replacements)
+		hook.Step = step
+		plan.OnFailure = &hook
+		return plan, found
+
+	case plan.OnSuccess != nil:
+		hook := *plan.OnSuccess
+		step, found := resumeSequence(hook.Step, from, replacements)
+		hook.Step = step
+		plan.OnSuccess = &hook
+		return plan, found
+
+	case plan.Ensure != nil:
+		hook := *plan.Ensure
+		step, found := resumeSequence(hook.Step, from, replacements)
+		hook.Step = step
+		plan.Ensure = &hook
+		return plan, found
+	}
+
+	return plan, false
+}
+
+func findPlan(plan Plan, id PlanID) (Plan, bool) {
+	if plan.ID == id {
+		return plan, true
+	}
+
+	children := []Plan{}
+
+	switch {
+	case plan.Do != nil:
+		children = *plan.Do
+	case plan.Aggregate != nil:
+		children = *plan.Aggregate
+	case plan.InParallel != nil:
+		children = plan.InParallel.Steps
+	case plan.Retry != nil:
+		children = *plan.Retry
+	case plan.Try != nil:
+		children = []Plan{plan.Try.Step}
+	case plan.Timeout != nil:
+		children = []Plan{plan.Timeout.Step}
+	case plan.OnAbort != nil:
+		children = []Plan{plan.OnAbort.Step, plan.OnAbort.Next}
+	case plan.OnError != nil:
+		children = []Plan{plan.OnError.Step, plan.OnError.Next}
+	case plan.OnFailure != nil:
+		children = []Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.OnSuccess != nil:
+		children = []Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.Ensure != nil:
+		children = []Plan{plan.Ensure.Step, plan.Ensure.Next}
+	}
+
+	for _, child := range children {
+		if found, ok := findPlan(child, id); ok {
+			return found, true
+		}
+	}
+
+	return Plan{}, false
+}
@@ -0,0 +1,133 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildCheckpoint", func() {
+	var (
+		source   atc.Plan
+		sequence atc.Plan
+		plan     atc.Plan
+	)
+
+	task := func(id atc.PlanID) atc.Plan {
+		return atc.Plan{ID: id, Task: &atc.TaskPlan{Name: string(id)}}
+	}
+
+	BeforeEach(func() {
+		source = atc.Plan{
+			ID: "2",
+			Get: &atc.GetPlan{
+				Name:     "repo",
+				Resource: "some-resource",
+				Type:     "git",
+			},
+		}
+
+		sequence = atc.Plan{
+			ID: "1",
+			Do: &atc.DoPlan{
+				source,
+				task("3"),
+				task("4"),
+			},
+		}
+
+		plan = atc.Plan{
+			ID: "5",
+			Ensure: &atc.EnsurePlan{
+				Step: sequence,
+				Next: task("6"),
+			},
+		}
+	})
+
+	Describe("CheckpointSteps", func() {
+		It("returns the steps of the sequence within the job's hooks", func() {
+			Expect(atc.CheckpointSteps(plan)).To(Equal([]atc.PlanID{"2", "3", "4"}))
+		})
+
+		It("returns nothing for a plan of a single step", func() {
+			Expect(atc.CheckpointSteps(task("7"))).To(BeEmpty())
+		})
+	})
+
+	Describe("RerunPlan", func() {
+		var (
+			checkpoint atc.BuildCheckpoint
+			rerun      atc.Plan
+			ok         bool
+		)
+
+		BeforeEach(func() {
+			checkpoint = atc.BuildCheckpoint{
+				PlanID: "3",
+				Artifacts: []atc.CheckpointArtifact{
+					{Name: "repo", GetPlanID: "2", Version: atc.Version{"ref": "abc"}},
+					{Name: "output", ArtifactID: 42},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			rerun, ok = atc.RerunPlan(plan, checkpoint, atc.NewPlanFactory(0))
+		})
+
+		It("replaces the steps up to the checkpoint with ones giving the same artifacts", func() {
+			Expect(ok).To(BeTrue())
+
+			pinnedGet := *source.Get
+			pinnedGet.Version = &atc.Version{"ref": "abc"}
+
+			Expect(rerun).To(Equal(atc.Plan{
+				ID: "5",
+				Ensure: &atc.EnsurePlan{
+					Step: atc.Plan{
+						ID: "1",
+						Do: &atc.DoPlan{
+							{
+								ID: "3",
+								InParallel: &atc.InParallelPlan{
+									Steps: []atc.Plan{
+										{ID: "1", ArtifactInput: &atc.ArtifactInputPlan{ArtifactID: 42, Name: "output"}},
+										{ID: "2", Get: &pinnedGet},
+									},
+									FailFast: true,
+								},
+							},
+							task("4"),
+						},
+					},
+					Next: task("6"),
+				},
+			}))
+		})
+
+		It("leaves the original plan alone", func() {
+			Expect(*plan.Ensure.Step.Do).To(HaveLen(3))
+		})
+
+		Context("when the get step which fetched an artifact isn't in the plan", func() {
+			BeforeEach(func() {
+				checkpoint.Artifacts[0].GetPlanID = "missing"
+			})
+
+			It("can't be re-run", func() {
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		Context("when the checkpoint isn't in the sequence of steps", func() {
+			BeforeEach(func() {
+				checkpoint.PlanID = "6"
+			})
+
+			It("can't be re-run", func() {
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+})
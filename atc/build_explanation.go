@@ -0,0 +1,58 @@
+package atc
+
+// BuildExplanation brings together how a finished build came to run the way
+// it did: why each input version was chosen, the plan it ran, and where and
+// for how long each of its steps ran.
+type BuildExplanation struct {
+	Build  Build            `json:"build"`
+	Inputs []ExplainedInput `json:"inputs"`
+	Plan   PublicBuildPlan  `json:"plan"`
+	Steps  []ExplainedStep  `json:"steps"`
+
+	// Errors stopped the build outside of any step, e.g. while building its
+	// plan.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// InputReason is why a build used the version of an input that it did.
+type InputReason string
+
+const (
+	// InputReasonLatest is the latest version satisfying the input's
+	// constraints when the build was scheduled.
+	InputReasonLatest InputReason = "latest"
+
+	// InputReasonOverride is a version chosen by whoever triggered the build.
+	InputReasonOverride InputReason = "override"
+
+	// InputReasonFallback is the latest version the job has passed with, used
+	// in place of the latest version after the job kept failing with it.
+	InputReasonFallback InputReason = "fallback"
+)
+
+type ExplainedInput struct {
+	Name            string      `json:"name"`
+	Version         Version     `json:"version"`
+	Reason          InputReason `json:"reason"`
+	FallbackFrom    Version     `json:"fallback_from,omitempty"`
+	FirstOccurrence bool        `json:"first_occurrence"`
+}
+
+// ExplainedStep is a get, put, or task step of the plan which started
+// running. Durations are in seconds.
+type ExplainedStep struct {
+	PlanID PlanID `json:"plan_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name,omitempty"`
+	Worker string `json:"worker,omitempty"`
+
+	// Cached is only set for get steps, and is true if the version was
+	// already on the worker rather than being fetched.
+	Cached *bool `json:"cached,omitempty"`
+
+	ExitStatus *int   `json:"exit_status,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	InitializingDuration int64 `json:"initializing_duration,omitempty"`
+	RunningDuration      int64 `json:"running_duration,omitempty"`
+}
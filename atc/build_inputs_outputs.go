@@ -6,15 +6,17 @@ type BuildInputsOutputs struct {
 }
 
 type PublicBuildInput struct {
-	Name            string  `json:"name"`
-	Version         Version `json:"version"`
-	PipelineID      int     `json:"pipeline_id"`
-	FirstOccurrence bool    `json:"first_occurrence"`
+	Name            string          `json:"name"`
+	Version         Version         `json:"version"`
+	Metadata        []MetadataField `json:"metadata,omitempty"`
+	PipelineID      int             `json:"pipeline_id"`
+	FirstOccurrence bool            `json:"first_occurrence"`
 }
 
 type PublicBuildOutput struct {
-	Name    string  `json:"name"`
-	Version Version `json:"version"`
+	Name     string          `json:"name"`
+	Version  Version         `json:"version"`
+	Metadata []MetadataField `json:"metadata,omitempty"`
 }
 
 type ResourceVersion struct {
@@ -23,3 +25,10 @@ type ResourceVersion struct {
 	Version  Version         `json:"version"`
 	Enabled  bool            `json:"enabled"`
 }
+
+// SpannedResourceVersion is a version within a span of a resource's versions,
+// along with the builds which used it as an input.
+type SpannedResourceVersion struct {
+	ResourceVersion
+	InputTo []Build `json:"input_to"`
+}
@@ -0,0 +1,44 @@
+package atc
+
+// BuildSnapshot is what a local runner needs to reproduce the context of a
+// job build: the exact versions of its inputs, the config of the resources
+// they came from, and the tasks it ran along with their images.
+//
+// Sources and params are given as configured in the pipeline, so credentials
+// appear as ((var)) references rather than their values.
+type BuildSnapshot struct {
+	Build         Build                  `json:"build"`
+	Inputs        []SnapshotInput        `json:"inputs"`
+	Tasks         []SnapshotTask         `json:"tasks"`
+	ResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
+}
+
+// SnapshotInput is an input of a build and the version of it the build used.
+type SnapshotInput struct {
+	Name     string  `json:"name"`
+	Resource string  `json:"resource"`
+	Type     string  `json:"type"`
+	Source   Source  `json:"source"`
+	Params   Params  `json:"params,omitempty"`
+	Version  Version `json:"version"`
+}
+
+// SnapshotTask is a task step of a build. Tasks configured by file have only
+// a ConfigPath, as the config itself is in one of the build's inputs.
+type SnapshotTask struct {
+	Name          string            `json:"name"`
+	ConfigPath    string            `json:"config_path,omitempty"`
+	Config        *TaskConfig       `json:"config,omitempty"`
+	Vars          Params            `json:"vars,omitempty"`
+	Params        Params            `json:"params,omitempty"`
+	Privileged    bool              `json:"privileged,omitempty"`
+	InputMapping  map[string]string `json:"input_mapping,omitempty"`
+	OutputMapping map[string]string `json:"output_mapping,omitempty"`
+
+	// ImageArtifactName is the name of the artifact the task runs in, if it
+	// doesn't use the image_resource of its config.
+	ImageArtifactName string `json:"image,omitempty"`
+
+	// ImageOverride is set if the build overrode the task's image_resource.
+	ImageOverride *TaskImageOverride `json:"image_override,omitempty"`
+}
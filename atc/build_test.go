@@ -1,6 +1,9 @@
 package atc_test
 
 import (
+	"fmt"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -88,4 +91,37 @@ var _ = Describe("Build", func() {
 			}
 		})
 	})
+
+	Describe("BuildAnnotations", func() {
+		Describe("Validate", func() {
+			It("accepts valid annotations", func() {
+				Expect(atc.BuildAnnotations{
+					"coverage":       "87%",
+					"ticket.url":     "https://tracker.example.com/123",
+					"notes_markdown": "**all good**",
+				}.Validate()).To(Succeed())
+			})
+
+			It("rejects keys with invalid characters", func() {
+				err := atc.BuildAnnotations{"not a key": "value"}.Validate()
+				Expect(err).To(MatchError(ContainSubstring("invalid key 'not a key'")))
+			})
+
+			It("rejects values that are too long", func() {
+				err := atc.BuildAnnotations{
+					"notes": strings.Repeat("x", atc.MaxBuildAnnotationValueLength+1),
+				}.Validate()
+				Expect(err).To(MatchError(ContainSubstring("value of 'notes' is longer than")))
+			})
+
+			It("rejects too many annotations", func() {
+				annotations := atc.BuildAnnotations{}
+				for i := 0; i <= atc.MaxBuildAnnotations; i++ {
+					annotations[fmt.Sprintf("key-%d", i)] = "value"
+				}
+
+				Expect(annotations.Validate()).To(MatchError(ContainSubstring("at most 50 annotations")))
+			})
+		})
+	})
 })
@@ -0,0 +1,52 @@
+package atc
+
+// BuildTimeline breaks down where the time went in a finished build: how long
+// it waited to start, how long each of its steps spent queued, fetching its
+// image, streaming its inputs and running, and which of the steps lay on the
+// build's critical path. Times are unix timestamps and durations are in
+// seconds.
+type BuildTimeline struct {
+	Build Build `json:"build"`
+
+	// QueuedDuration is how long the build waited between being created and
+	// starting.
+	QueuedDuration int64 `json:"queued_duration"`
+
+	Steps []TimelineStep `json:"steps"`
+
+	// CriticalPath is the chain of steps, one after another, which determined
+	// how long the build took. Shortening any other step does not shorten the
+	// build.
+	CriticalPath         []PlanID `json:"critical_path"`
+	CriticalPathDuration int64    `json:"critical_path_duration"`
+}
+
+// TimelineStep is a get, put, or task step of the plan which started running.
+type TimelineStep struct {
+	PlanID PlanID `json:"plan_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name,omitempty"`
+	Worker string `json:"worker,omitempty"`
+
+	InitializedAt int64 `json:"initialized_at,omitempty"`
+	StartedAt     int64 `json:"started_at,omitempty"`
+	FinishedAt    int64 `json:"finished_at,omitempty"`
+
+	// QueuedDuration is the time spent initializing which wasn't spent
+	// fetching the image or streaming inputs, e.g. waiting for a worker.
+	QueuedDuration      int64 `json:"queued_duration"`
+	ImageFetchDuration  int64 `json:"image_fetch_duration"`
+	InputStreamDuration int64 `json:"input_stream_duration"`
+	RunningDuration     int64 `json:"running_duration"`
+
+	OnCriticalPath bool `json:"on_critical_path"`
+}
+
+// Duration is how long the step took from initializing to finishing.
+func (step TimelineStep) Duration() int64 {
+	if step.InitializedAt == 0 || step.FinishedAt == 0 {
+		return 0
+	}
+
+	return step.FinishedAt - step.InitializedAt
+}
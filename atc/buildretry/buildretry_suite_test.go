@@ -0,0 +1,13 @@
+package buildretry_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBuildRetry(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Build Retry Suite")
+}
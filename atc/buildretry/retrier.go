@@ -0,0 +1,136 @@
+package buildretry
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// Retrier re-runs job builds which errored because of a failure of the
+// infrastructure, such as their worker disappearing, rather than of the
+// build itself. Each build is retried up to a maximum number of times, waiting
+// twice as long before each retry as before the last one.
+//
+// A retry resumes from where the errored build left off, as a re-run does,
+// and records which automatic retry it is so that it can be told apart from
+// builds triggered by the scheduler or by users. Like a re-run, it's created
+// pending, so that the scheduler only starts it once the job's serial groups,
+// max in flight, concurrency pools, gates and start limits allow it to.
+type Retrier struct {
+	clock        clock.Clock
+	buildFactory db.BuildFactory
+	maxAttempts  int
+	backoff      time.Duration
+}
+
+// NewRetrier returns a Retrier which retries a build up to maxAttempts times,
+// waiting backoff after it errored before the first retry.
+func NewRetrier(
+	clock clock.Clock,
+	buildFactory db.BuildFactory,
+	maxAttempts int,
+	backoff time.Duration,
+) *Retrier {
+	return &Retrier{
+		clock:        clock,
+		buildFactory: buildFactory,
+		maxAttempts:  maxAttempts,
+		backoff:      backoff,
+	}
+}
+
+func (r *Retrier) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-retrier")
+
+	builds, err := r.buildFactory.GetAutoRetryableBuilds(atc.InfrastructureErrorCodes, r.maxAttempts)
+	if err != nil {
+		logger.Error("failed-to-get-retryable-builds", err)
+		return err
+	}
+
+	for _, build := range builds {
+		// don't hammer workers which are struggling; each retry waits twice as
+		// long as the one before it
+		due := build.EndTime().Add(r.backoff << uint(build.AutoRetryAttempt()))
+		if r.clock.Now().Before(due) {
+			continue
+		}
+
+		r.retry(logger.Session("retry", lager.Data{"build": build.ID()}), build)
+	}
+
+	return nil
+}
+
+func (r *Retrier) retry(logger lager.Logger, build db.Build) {
+	// builds which finished before their plans were kept have nothing to
+	// retry from
+	if build.PrivatePlan().ID == "" {
+		logger.Info("build-has-no-plan")
+		return
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-get-pipeline", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	job, found, err := pipeline.Job(build.JobName())
+	if err != nil {
+		logger.Error("failed-to-get-job", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	checkpoint, found, err := build.Checkpoint()
+	if err != nil {
+		logger.Error("failed-to-get-checkpoint", err)
+		return
+	}
+
+	plan := build.PrivatePlan()
+	if found {
+		resumed, ok := atc.RerunPlan(plan, checkpoint, atc.NewPlanFactory(r.clock.Now().Unix()))
+		if ok {
+			plan = resumed
+		} else {
+			logger.Info("checkpoint-not-in-plan", lager.Data{"plan-id": checkpoint.PlanID})
+		}
+	}
+
+	retry, err := job.AutoRetryBuild(build, plan)
+	if err != nil {
+		logger.Error("failed-to-create-build", err)
+		return
+	}
+
+	logger.Info("retried", lager.Data{
+		"retry":      retry.ID(),
+		"attempt":    retry.AutoRetryAttempt(),
+		"error-code": build.ErrorCode(),
+	})
+
+	metric.BuildAutoRetried{
+		PipelineName: build.PipelineName(),
+		JobName:      build.JobName(),
+		BuildName:    build.Name(),
+		BuildID:      build.ID(),
+		TeamName:     build.TeamName(),
+		ErrorCode:    build.ErrorCode(),
+		Attempt:      retry.AutoRetryAttempt(),
+	}.Emit(logger)
+}
@@ -0,0 +1,159 @@
+package buildretry_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/buildretry"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retrier", func() {
+	var (
+		fakeClock        *fakeclock.FakeClock
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild        *dbfakes.FakeBuild
+		fakePipeline     *dbfakes.FakePipeline
+		fakeJob          *dbfakes.FakeJob
+		fakeRetry        *dbfakes.FakeBuild
+
+		plan atc.Plan
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1000, 0))
+
+		plan = atc.Plan{
+			ID:   "some-plan",
+			Task: &atc.TaskPlan{Name: "some-task"},
+		}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.ErrorCodeReturns(atc.ErrorCodeWorkerUnavailable)
+		fakeBuild.EndTimeReturns(fakeClock.Now().Add(-2 * time.Minute))
+		fakeBuild.PrivatePlanReturns(plan)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakePipeline.JobReturns(fakeJob, true, nil)
+
+		fakeRetry = new(dbfakes.FakeBuild)
+		fakeRetry.AutoRetryAttemptReturns(1)
+		fakeJob.AutoRetryBuildReturns(fakeRetry, nil)
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.GetAutoRetryableBuildsReturns([]db.Build{fakeBuild}, nil)
+	})
+
+	JustBeforeEach(func() {
+		err = buildretry.NewRetrier(fakeClock, fakeBuildFactory, 3, time.Minute).Run(context.TODO())
+	})
+
+	It("looks for builds which errored with infrastructure failures", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeBuildFactory.GetAutoRetryableBuildsCallCount()).To(Equal(1))
+
+		codes, maxAttempts := fakeBuildFactory.GetAutoRetryableBuildsArgsForCall(0)
+		Expect(codes).To(Equal(atc.InfrastructureErrorCodes))
+		Expect(maxAttempts).To(Equal(3))
+	})
+
+	It("retries them with their plan", func() {
+		Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+		Expect(fakeJob.AutoRetryBuildCallCount()).To(Equal(1))
+
+		original, retryPlan := fakeJob.AutoRetryBuildArgsForCall(0)
+		Expect(original).To(Equal(fakeBuild))
+		Expect(retryPlan).To(Equal(plan))
+	})
+
+	Context("when the build hasn't waited out the backoff", func() {
+		BeforeEach(func() {
+			fakeBuild.EndTimeReturns(fakeClock.Now().Add(-30 * time.Second))
+		})
+
+		It("leaves it for now", func() {
+			Expect(fakeJob.AutoRetryBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the build is itself a retry", func() {
+		BeforeEach(func() {
+			fakeBuild.AutoRetryAttemptReturns(1)
+			fakeBuild.EndTimeReturns(fakeClock.Now().Add(-90 * time.Second))
+		})
+
+		It("waits twice as long before retrying it again", func() {
+			Expect(fakeJob.AutoRetryBuildCallCount()).To(BeZero())
+
+			fakeBuild.EndTimeReturns(fakeClock.Now().Add(-3 * time.Minute))
+			err = buildretry.NewRetrier(fakeClock, fakeBuildFactory, 3, time.Minute).Run(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeJob.AutoRetryBuildCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the build's plan was not kept", func() {
+		BeforeEach(func() {
+			fakeBuild.PrivatePlanReturns(atc.Plan{})
+		})
+
+		It("does not retry it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeJob.AutoRetryBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the build has a checkpoint", func() {
+		BeforeEach(func() {
+			fakeBuild.CheckpointReturns(atc.BuildCheckpoint{PlanID: "not-in-the-plan"}, true, nil)
+		})
+
+		It("retries it with its plan if the checkpoint isn't in it", func() {
+			_, retryPlan := fakeJob.AutoRetryBuildArgsForCall(0)
+			Expect(retryPlan).To(Equal(plan))
+		})
+	})
+
+	Context("when retrying a build fails", func() {
+		var otherBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			otherBuild = new(dbfakes.FakeBuild)
+			otherBuild.JobNameReturns("some-job")
+			otherBuild.PrivatePlanReturns(plan)
+			otherBuild.PipelineReturns(fakePipeline, true, nil)
+
+			fakeBuildFactory.GetAutoRetryableBuildsReturns([]db.Build{fakeBuild, otherBuild}, nil)
+			fakeJob.AutoRetryBuildReturnsOnCall(0, nil, errors.New("disaster"))
+			fakeJob.AutoRetryBuildReturnsOnCall(1, fakeRetry, nil)
+		})
+
+		It("carries on retrying the others", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeJob.AutoRetryBuildCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the builds can't be found", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetAutoRetryableBuildsReturns(nil, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError("disaster"))
+		})
+	})
+})
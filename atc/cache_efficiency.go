@@ -0,0 +1,26 @@
+package atc
+
+// CacheEfficiency is how often fetching resources found their cache already
+// initialized on the chosen worker rather than running the resource, since
+// the ATC serving it started.
+type CacheEfficiency struct {
+	CacheUsage
+
+	ResourceTypes []NamedCacheUsage `json:"resource_types"`
+	Workers       []NamedCacheUsage `json:"workers"`
+}
+
+type CacheUsage struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+
+	// HitRatio is the share of fetches which were cache hits, from 0 to 1.
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// NamedCacheUsage is the cache usage of a single resource type or worker.
+type NamedCacheUsage struct {
+	Name string `json:"name"`
+
+	CacheUsage
+}
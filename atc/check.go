@@ -7,4 +7,6 @@ type Check struct {
 	StartTime  int64  `json:"start_time,omitempty"`
 	EndTime    int64  `json:"end_time,omitempty"`
 	CheckError string `json:"check_error,omitempty"`
+	WorkerName string `json:"worker_name,omitempty"`
+	Output     string `json:"output,omitempty"`
 }
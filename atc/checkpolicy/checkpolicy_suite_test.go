@@ -0,0 +1,13 @@
+package checkpolicy_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCheckpolicy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Checkpolicy Suite")
+}
@@ -0,0 +1,103 @@
+package checkpolicy
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Enforcer
+
+// Enforcer applies each team's CheckPolicy to the checking of the team's
+// resources and resource types, so that one team's configuration can't
+// overload the installation with checks.
+type Enforcer interface {
+	// Interval returns the interval at which a resource of the team
+	// configured with the given check_every should be checked.
+	Interval(logger lager.Logger, teamName string, checkEvery string, defaultInterval time.Duration) (time.Duration, error)
+
+	// Acquire reserves one of the team's concurrent checks, returning false
+	// if the team already has as many checks running as its policy allows.
+	// Once acquired, the returned func must be called when the check has
+	// finished.
+	Acquire(logger lager.Logger, teamName string) (func(), bool, error)
+}
+
+// NewEnforcer returns an Enforcer which looks up each team's policy as it
+// is needed, so changes take effect on the next check.
+//
+// Running checks are counted in memory, so each ATC enforces the team's
+// limit independently.
+func NewEnforcer(teamFactory db.TeamFactory) Enforcer {
+	return &enforcer{
+		teamFactory: teamFactory,
+		running:     map[string]int{},
+	}
+}
+
+type enforcer struct {
+	teamFactory db.TeamFactory
+
+	lock    sync.Mutex
+	running map[string]int
+}
+
+func (e *enforcer) Interval(logger lager.Logger, teamName string, checkEvery string, defaultInterval time.Duration) (time.Duration, error) {
+	policy, err := e.policy(logger, teamName)
+	if err != nil {
+		return 0, err
+	}
+
+	return policy.Interval(checkEvery, defaultInterval)
+}
+
+func (e *enforcer) Acquire(logger lager.Logger, teamName string) (func(), bool, error) {
+	policy, err := e.policy(logger, teamName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if policy == nil || policy.MaxConcurrent == 0 {
+		return func() {}, true, nil
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.running[teamName] >= policy.MaxConcurrent {
+		logger.Debug("team-check-concurrency-limit-reached", lager.Data{"limit": policy.MaxConcurrent})
+		return nil, false, nil
+	}
+
+	e.running[teamName]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			e.lock.Lock()
+			defer e.lock.Unlock()
+
+			e.running[teamName]--
+			if e.running[teamName] == 0 {
+				delete(e.running, teamName)
+			}
+		})
+	}, true, nil
+}
+
+func (e *enforcer) policy(logger lager.Logger, teamName string) (*atc.CheckPolicy, error) {
+	team, found, err := e.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return team.CheckPolicy(), nil
+}
@@ -0,0 +1,137 @@
+package checkpolicy_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Enforcer", func() {
+	var (
+		logger          *lagertest.TestLogger
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+
+		enforcer checkpolicy.Enforcer
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		enforcer = checkpolicy.NewEnforcer(fakeTeamFactory)
+	})
+
+	Describe("Interval", func() {
+		It("applies the team's policy", func() {
+			fakeTeam.CheckPolicyReturns(&atc.CheckPolicy{MinEvery: "5m"})
+
+			interval, err := enforcer.Interval(logger, "some-team", "10s", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(interval).To(Equal(5 * time.Minute))
+
+			Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+		})
+
+		It("uses the resource's check_every when the team has no policy", func() {
+			interval, err := enforcer.Interval(logger, "some-team", "10s", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(interval).To(Equal(10 * time.Second))
+		})
+
+		It("uses the resource's check_every when the team is not found", func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, nil)
+
+			interval, err := enforcer.Interval(logger, "some-team", "", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(interval).To(Equal(time.Minute))
+		})
+
+		It("errors when finding the team fails", func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+
+			_, err := enforcer.Interval(logger, "some-team", "", time.Minute)
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+
+	Describe("Acquire", func() {
+		Context("when the team has no concurrency limit", func() {
+			It("always acquires", func() {
+				for i := 0; i < 100; i++ {
+					_, acquired, err := enforcer.Acquire(logger, "some-team")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+				}
+			})
+		})
+
+		Context("when the team has a concurrency limit", func() {
+			BeforeEach(func() {
+				fakeTeam.CheckPolicyReturns(&atc.CheckPolicy{MaxConcurrent: 2})
+			})
+
+			It("acquires until the limit is reached", func() {
+				_, acquired, err := enforcer.Acquire(logger, "some-team")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				release, acquired, err := enforcer.Acquire(logger, "some-team")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				_, acquired, err = enforcer.Acquire(logger, "some-team")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				By("releasing a check")
+				release()
+
+				_, acquired, err = enforcer.Acquire(logger, "some-team")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("only releases once per acquire", func() {
+				release, _, _ := enforcer.Acquire(logger, "some-team")
+				_, _, _ = enforcer.Acquire(logger, "some-team")
+
+				release()
+				release()
+
+				_, acquired, _ := enforcer.Acquire(logger, "some-team")
+				Expect(acquired).To(BeTrue())
+
+				_, acquired, _ = enforcer.Acquire(logger, "some-team")
+				Expect(acquired).To(BeFalse())
+			})
+
+			It("counts each team separately", func() {
+				_, _, _ = enforcer.Acquire(logger, "some-team")
+				_, _, _ = enforcer.Acquire(logger, "some-team")
+
+				_, acquired, err := enforcer.Acquire(logger, "other-team")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+		})
+
+		It("errors when finding the team fails", func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+
+			_, acquired, err := enforcer.Acquire(logger, "some-team")
+			Expect(err).To(MatchError("nope"))
+			Expect(acquired).To(BeFalse())
+		})
+	})
+})
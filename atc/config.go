@@ -21,6 +21,29 @@ type Config struct {
 	Resources     ResourceConfigs `json:"resources,omitempty"`
 	ResourceTypes ResourceTypes   `json:"resource_types,omitempty"`
 	Jobs          JobConfigs      `json:"jobs,omitempty"`
+
+	ResourceDefaults ResourceDefaults `json:"resource_defaults,omitempty"`
+
+	IgnoreTeamContainerEnv bool `json:"ignore_team_container_env,omitempty"`
+
+	ContainerDNS *ContainerDNS `json:"container_dns,omitempty"`
+
+	Branches *BranchesConfig `json:"branches,omitempty"`
+
+	// SchedulingWindows apply to every job which doesn't configure its own.
+	SchedulingWindows *SchedulingWindows `json:"scheduling_windows,omitempty"`
+
+	Labels Labels `json:"labels,omitempty"`
+
+	// Redactions apply to what the public can see of the pipeline when it is
+	// exposed.
+	Redactions *Redactions `json:"redactions,omitempty"`
+
+	// TimeZone applies to scheduling windows which don't configure a
+	// location, and along with Locale tells clients how to display the
+	// pipeline's times.
+	TimeZone TimeZone `json:"timezone,omitempty"`
+	Locale   Locale   `json:"locale,omitempty"`
 }
 
 type GroupConfig struct {
@@ -52,6 +75,70 @@ type ResourceConfig struct {
 	Tags         Tags    `json:"tags,omitempty"`
 	Version      Version `json:"version,omitempty"`
 	Icon         string  `json:"icon,omitempty"`
+
+	// Mode restricts whether the resource is checked, fetched and pushed to.
+	Mode ResourceMode `json:"mode,omitempty"`
+
+	// NewVersionWebhooks are notified whenever checking the resource finds
+	// new versions.
+	NewVersionWebhooks []NewVersionWebhookConfig `json:"new_version_webhooks,omitempty"`
+}
+
+// NewVersionWebhookConfig is a URL which is sent a POST request describing
+// the new versions of a resource. Header values may use credentials.
+type NewVersionWebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Timeout string            `json:"timeout,omitempty"`
+}
+
+// ResourceDefaults maps a resource type name to source fields that every
+// resource of that type inherits unless it sets them itself.
+type ResourceDefaults map[string]Source
+
+// Apply returns source with the defaults for the given resource type filled
+// in underneath it. Fields already present in source are left untouched.
+func (defaults ResourceDefaults) Apply(resourceType string, source Source) Source {
+	defaultSource, found := defaults[resourceType]
+	if !found || len(defaultSource) == 0 {
+		return source
+	}
+
+	merged := Source{}
+	for key, value := range defaultSource {
+		merged[key] = value
+	}
+
+	for key, value := range source {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// ContainerDNS configures name resolution in build containers, replacing
+// whatever the worker would otherwise configure. If only search domains are
+// given, the worker's name servers are kept.
+type ContainerDNS struct {
+	Servers []string `json:"servers,omitempty"`
+	Search  []string `json:"search,omitempty"`
+}
+
+// DefaultBranchesField is the field of a git-branches resource's versions
+// listing its branches.
+const DefaultBranchesField = "branches"
+
+// BranchesConfig spawns an instance of the pipeline for each branch listed
+// by the latest version of one of its resources, e.g. a git-branches
+// resource. Each instance is set to the pipeline's config with ((branch))
+// replaced by its branch, and is archived once its branch is no longer
+// listed.
+type BranchesConfig struct {
+	Resource string `json:"resource"`
+
+	// Field is the field of the resource's versions holding the
+	// comma-separated branches. Defaults to DefaultBranchesField.
+	Field string `json:"field,omitempty"`
 }
 
 type ResourceType struct {
@@ -104,10 +191,16 @@ type PlanSequence []PlanConfig
 
 // A VersionConfig represents the choice to include every version of a
 // resource, the latest version of a resource, or a pinned (specific) one.
+// Deleted selects every version the resource has reported as deleted, for
+// jobs that should run when a version disappears. LatestPassingOrNewest
+// selects the latest version, unless builds of the job keep failing with it,
+// in which case it falls back to the latest version the job has passed with.
 type VersionConfig struct {
-	Every  bool
-	Latest bool
-	Pinned Version
+	Every                 bool
+	Latest                bool
+	Deleted               bool
+	LatestPassingOrNewest bool
+	Pinned                Version
 }
 
 func (c *VersionConfig) UnmarshalJSON(version []byte) error {
@@ -122,6 +215,8 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 	case string:
 		c.Every = actual == "every"
 		c.Latest = actual == "latest"
+		c.Deleted = actual == "deleted"
+		c.LatestPassingOrNewest = actual == "latest-passing-or-newest"
 	case map[string]interface{}:
 		version := Version{}
 
@@ -144,6 +239,13 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 
 const VersionLatest = "latest"
 const VersionEvery = "every"
+const VersionDeleted = "deleted"
+const VersionLatestPassingOrNewest = "latest-passing-or-newest"
+
+// DefaultFallbackAfter is the number of failed builds with the latest version
+// of an input using latest-passing-or-newest versions after which the input
+// falls back, if it doesn't configure fallback_after.
+const DefaultFallbackAfter = 3
 
 func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	if c.Latest {
@@ -154,6 +256,14 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 		return json.Marshal(VersionEvery)
 	}
 
+	if c.Deleted {
+		return json.Marshal(VersionDeleted)
+	}
+
+	if c.LatestPassingOrNewest {
+		return json.Marshal(VersionLatestPassingOrNewest)
+	}
+
 	if c.Pinned != nil {
 		return json.Marshal(c.Pinned)
 	}
@@ -289,17 +399,26 @@ type PlanConfig struct {
 	Task string `json:"task,omitempty"`
 	// run task privileged
 	Privileged bool `json:"privileged,omitempty"`
+	// number of GPUs to allocate to the task's container
+	GPUs int `json:"gpus,omitempty"`
 	// task config path, e.g. foo/build.yml
 	TaskConfigPath string `json:"file,omitempty"`
 	// task variables, if task is specified as external file via TaskConfigPath
 	TaskVars Params `json:"vars,omitempty"`
 	// inlined task config
 	TaskConfig *TaskConfig `json:"config,omitempty"`
+	// file in one of the task's outputs, e.g. out/vars.yml, whose vars are
+	// given to the file task configs of later steps under the task's name
+	TaskOutputVars string `json:"output_vars,omitempty"`
 
 	// used by Get and Put for specifying params to the resource
 	// used by Task for passing params to external task config
 	Params Params `json:"params,omitempty"`
 
+	// params given to the task as files at the given paths, relative to its
+	// working directory, instead of as environment variables
+	FileParams map[string]string `json:"file_params,omitempty"`
+
 	// used to pass specific inputs/outputs as generic inputs/outputs in task config
 	InputMapping  map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping map[string]string `json:"output_mapping,omitempty"`
@@ -313,6 +432,14 @@ type PlanConfig struct {
 	// used by any step to specify which workers are eligible to run the step
 	Tags Tags `json:"tags,omitempty"`
 
+	// used by get, put and task steps to specify the labels a worker must
+	// have to run the step
+	Labels Labels `json:"labels,omitempty"`
+
+	// used by any step to join the team's concurrency pools; the step is a
+	// member of them while it runs, waiting to start until it may join them
+	ConcurrencyPools []string `json:"concurrency_pools,omitempty"`
+
 	// used by any step to run something when the build is aborted during execution of the step
 	Abort *PlanConfig `json:"on_abort,omitempty"`
 
@@ -341,6 +468,10 @@ type PlanConfig struct {
 	Attempts int `json:"attempts,omitempty"`
 
 	Version *VersionConfig `json:"version,omitempty"`
+
+	// used by Get with latest-passing-or-newest versions to configure how
+	// many builds must fail with the latest version before falling back
+	FallbackAfter int `json:"fallback_after,omitempty"`
 }
 
 func (config PlanConfig) Name() string {
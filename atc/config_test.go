@@ -39,5 +39,75 @@ var _ = Describe("Config", func() {
 				})
 			})
 		})
+
+		Context("when unmarshaling deleted versions from JSON", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`"deleted"`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{Deleted: true}))
+			})
+
+			It("marshals back to the same JSON", func() {
+				payload, err := json.Marshal(&VersionConfig{Deleted: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(payload).To(MatchJSON(`"deleted"`))
+			})
+		})
+
+		Context("when unmarshaling latest-passing-or-newest versions from JSON", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`"latest-passing-or-newest"`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{LatestPassingOrNewest: true}))
+			})
+
+			It("marshals back to the same JSON", func() {
+				payload, err := json.Marshal(&VersionConfig{LatestPassingOrNewest: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(payload).To(MatchJSON(`"latest-passing-or-newest"`))
+			})
+		})
+	})
+
+	Describe("ResourceDefaults", func() {
+		var defaults ResourceDefaults
+
+		BeforeEach(func() {
+			defaults = ResourceDefaults{
+				"git": Source{
+					"branch":     "master",
+					"ssh_config": "some-config",
+				},
+			}
+		})
+
+		It("fills in fields the source does not set", func() {
+			Expect(defaults.Apply("git", Source{"uri": "some-uri", "branch": "develop"})).To(Equal(Source{
+				"uri":        "some-uri",
+				"branch":     "develop",
+				"ssh_config": "some-config",
+			}))
+		})
+
+		It("does not modify the defaults", func() {
+			defaults.Apply("git", Source{"uri": "some-uri"})
+			Expect(defaults["git"]).To(Equal(Source{
+				"branch":     "master",
+				"ssh_config": "some-config",
+			}))
+		})
+
+		Context("when there are no defaults for the type", func() {
+			It("returns the source as-is", func() {
+				Expect(defaults.Apply("s3", Source{"bucket": "some-bucket"})).To(Equal(Source{"bucket": "some-bucket"}))
+				Expect(defaults.Apply("s3", nil)).To(BeNil())
+			})
+		})
 	})
 })
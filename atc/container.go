@@ -26,6 +26,16 @@ type Container struct {
 	WorkingDirectory string `json:"working_directory,omitempty"`
 
 	ExpiresIn string `json:"expires_in,omitempty"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+
+	// Details are reported by the container's worker, and are only included
+	// when requested.
+	Details *ContainerDetails `json:"details,omitempty"`
+}
+
+type ContainerDetails struct {
+	ProcessCount   uint64 `json:"process_count"`
+	DiskUsageBytes uint64 `json:"disk_usage_bytes"`
 }
 
 const (
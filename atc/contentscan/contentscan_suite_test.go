@@ -0,0 +1,13 @@
+package contentscan_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestContentscan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Contentscan Suite")
+}
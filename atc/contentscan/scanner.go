@@ -0,0 +1,96 @@
+package contentscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+//go:generate counterfeiter . Scanner
+
+// Scanner inspects the content fetched by get steps and passed to put steps,
+// e.g. for malware or disallowed licenses.
+type Scanner interface {
+	Scan(ctx context.Context, subject Subject, content io.Reader) (Result, error)
+}
+
+// Subject describes the content being scanned.
+type Subject struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+	BuildID      int
+	BuildName    string
+
+	// Step is the type of the step, i.e. get or put.
+	Step string
+
+	// Artifact is the name of the artifact being scanned; the name of the
+	// get step, or the name of an input to the put step.
+	Artifact string
+}
+
+// Result lists what the scanner found. Content without findings is clean.
+type Result struct {
+	Findings []string `json:"findings,omitempty"`
+}
+
+func (result Result) Clean() bool {
+	return len(result.Findings) == 0
+}
+
+// NewHTTPScanner returns a Scanner which POSTs content to the given URL.
+//
+// The content is sent as a zstd-compressed tarball, as it is streamed out of
+// the worker, and the subject is described by X-Concourse-* headers. The
+// scanner must respond with 200 OK and a JSON result, listing any findings.
+func NewHTTPScanner(client *http.Client, url string) Scanner {
+	return &httpScanner{
+		client: client,
+		url:    url,
+	}
+}
+
+type httpScanner struct {
+	client *http.Client
+	url    string
+}
+
+func (s *httpScanner) Scan(ctx context.Context, subject Subject, content io.Reader) (Result, error) {
+	req, err := http.NewRequest("POST", s.url, content)
+	if err != nil {
+		return Result{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-tar")
+	req.Header.Set("Content-Encoding", "zstd")
+	req.Header.Set("X-Concourse-Team", subject.TeamName)
+	req.Header.Set("X-Concourse-Pipeline", subject.PipelineName)
+	req.Header.Set("X-Concourse-Job", subject.JobName)
+	req.Header.Set("X-Concourse-Build-ID", strconv.Itoa(subject.BuildID))
+	req.Header.Set("X-Concourse-Build-Name", subject.BuildName)
+	req.Header.Set("X-Concourse-Step", subject.Step)
+	req.Header.Set("X-Concourse-Artifact", subject.Artifact)
+
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return Result{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("content scanner responded with %s", resp.Status)
+	}
+
+	var result Result
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return Result{}, fmt.Errorf("malformed content scanner response: %s", err)
+	}
+
+	return result, nil
+}
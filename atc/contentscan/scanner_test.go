@@ -0,0 +1,110 @@
+package contentscan_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc/contentscan"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("HTTPScanner", func() {
+	var (
+		server  *ghttp.Server
+		scanner contentscan.Scanner
+
+		result  contentscan.Result
+		scanErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		scanner = contentscan.NewHTTPScanner(http.DefaultClient, server.URL()+"/scan")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		result, scanErr = scanner.Scan(context.Background(), contentscan.Subject{
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildID:      42,
+			BuildName:    "7",
+			Step:         "get",
+			Artifact:     "some-repo",
+		}, strings.NewReader("some-content"))
+	})
+
+	Context("when the content is clean", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/scan"),
+					ghttp.VerifyHeader(http.Header{
+						"Content-Type":           {"application/x-tar"},
+						"Content-Encoding":       {"zstd"},
+						"X-Concourse-Team":       {"some-team"},
+						"X-Concourse-Pipeline":   {"some-pipeline"},
+						"X-Concourse-Job":        {"some-job"},
+						"X-Concourse-Build-Id":   {"42"},
+						"X-Concourse-Build-Name": {"7"},
+						"X-Concourse-Step":       {"get"},
+						"X-Concourse-Artifact":   {"some-repo"},
+					}),
+					ghttp.VerifyBody([]byte("some-content")),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+		})
+
+		It("sends the content and reports it as clean", func() {
+			Expect(scanErr).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+			Expect(result.Clean()).To(BeTrue())
+		})
+	})
+
+	Context("when the scanner finds something", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"findings":["EICAR-Test-File in eicar.com"]}`),
+			)
+		})
+
+		It("returns the findings", func() {
+			Expect(scanErr).NotTo(HaveOccurred())
+			Expect(result.Clean()).To(BeFalse())
+			Expect(result.Findings).To(Equal([]string{"EICAR-Test-File in eicar.com"}))
+		})
+	})
+
+	Context("when the scanner responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, ""),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(scanErr).To(MatchError("content scanner responded with 503 Service Unavailable"))
+		})
+	})
+
+	Context("when the response is malformed", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, "nope"),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(scanErr).To(MatchError(ContainSubstring("malformed content scanner response")))
+		})
+	})
+})
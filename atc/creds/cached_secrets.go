@@ -21,6 +21,7 @@ type CachedSecrets struct {
 
 type CacheEntry struct {
 	value      interface{}
+	version    string
 	expiration *time.Time
 	found      bool
 }
@@ -37,24 +38,31 @@ func NewCachedSecrets(secrets Secrets, cacheConfig SecretCacheConfig) *CachedSec
 }
 
 func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, _, expiration, found, err := cs.GetVersioned(secretPath)
+	return value, expiration, found, err
+}
+
+// GetVersioned is like Get, but also returns the secret's version if the
+// underlying secret manager versions its secrets
+func (cs *CachedSecrets) GetVersioned(secretPath string) (interface{}, string, *time.Time, bool, error) {
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
 		result := entry.(CacheEntry)
-		return result.value, result.expiration, result.found, nil
+		return result.value, result.version, result.expiration, result.found, nil
 	}
 
 	// otherwise, let's make a request to the underlying secret manager
-	value, expiration, found, err := cs.secrets.Get(secretPath)
+	value, version, expiration, found, err := getVersioned(cs.secrets, secretPath)
 
 	// we don't want to cache errors, let the errors be retried the next time around
 	if err != nil {
-		return nil, nil, false, err
+		return nil, "", nil, false, err
 	}
 
-	// here we want to cache secret value, expiration, and found flag too
+	// here we want to cache secret value, version, expiration, and found flag too
 	// meaning that "secret not found" responses will be cached too!
-	entry = CacheEntry{value: value, expiration: expiration, found: found}
+	entry = CacheEntry{value: value, version: version, expiration: expiration, found: found}
 
 	if found {
 		// take default cache ttl
@@ -71,7 +79,7 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 		cs.cache.Set(secretPath, entry, cs.cacheConfig.DurationNotFound)
 	}
 
-	return value, expiration, found, nil
+	return value, version, expiration, found, nil
 }
 
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []SecretLookupPath {
@@ -162,4 +162,26 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	Context("when the underlying secret manager versions secrets", func() {
+		BeforeEach(func() {
+			cachedSecretManager = creds.NewCachedSecrets(versionedSecrets{secretManager, "some-version"}, cacheConfig)
+		})
+
+		It("caches the secret's version with its value", func() {
+			secretManager.GetStub = makeGetStub("foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			value, version, _, found, err := cachedSecretManager.GetVersioned("foo")
+			Expect(value).To(BeIdenticalTo("value"))
+			Expect(version).To(Equal("some-version"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+
+			value, version, _, found, err = cachedSecretManager.GetVersioned("foo")
+			Expect(value).To(BeIdenticalTo("value"))
+			Expect(version).To(Equal("some-version"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+			Expect(underlyingReads).To(BeIdenticalTo(1))
+		})
+	})
 })
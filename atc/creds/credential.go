@@ -0,0 +1,98 @@
+package creds
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/concourse/concourse/vars"
+)
+
+// Credential identifies a secret which was interpolated into a config, so
+// that things configured with one version of a secret can be told apart from
+// those configured with another.
+type Credential struct {
+	// Path is the path of the secret in the credential manager, or the var's
+	// name if the var wasn't read from one.
+	Path string `json:"path"`
+
+	// Version is the version of the secret reported by the credential
+	// manager, or a digest of its value if the manager doesn't version
+	// secrets.
+	Version string `json:"version"`
+}
+
+// credentialRecorder records the credentials of the vars read through it.
+type credentialRecorder struct {
+	variables vars.Variables
+
+	lock        sync.Mutex
+	credentials map[string]Credential
+}
+
+func newCredentialRecorder(variables vars.Variables) *credentialRecorder {
+	return &credentialRecorder{
+		variables:   variables,
+		credentials: map[string]Credential{},
+	}
+}
+
+func (r *credentialRecorder) Get(varDef vars.VariableDefinition) (interface{}, bool, error) {
+	var (
+		val      interface{}
+		identity vars.SecretIdentity
+		found    bool
+		err      error
+	)
+
+	if secrets, ok := r.variables.(vars.SecretVariables); ok {
+		val, identity, found, err = secrets.GetSecret(varDef)
+	} else {
+		identity = vars.SecretIdentity{Path: varDef.Name}
+		val, found, err = r.variables.Get(varDef)
+	}
+
+	if err != nil || !found {
+		return val, found, err
+	}
+
+	version := identity.Version
+	if version == "" {
+		version = digestCredential(val)
+	}
+
+	r.lock.Lock()
+	r.credentials[identity.Path] = Credential{Path: identity.Path, Version: version}
+	r.lock.Unlock()
+
+	return val, true, nil
+}
+
+func (r *credentialRecorder) List() ([]vars.VariableDefinition, error) {
+	return r.variables.List()
+}
+
+// Credentials returns the recorded credentials, ordered by path.
+func (r *credentialRecorder) Credentials() []Credential {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	credentials := []Credential{}
+	for _, credential := range r.credentials {
+		credentials = append(credentials, credential)
+	}
+
+	sort.Slice(credentials, func(i, j int) bool {
+		return credentials[i].Path < credentials[j].Path
+	})
+
+	return credentials
+}
+
+// digestCredential digests the value as printed with its types, as values read
+// from YAML can't always be marshalled to JSON. Maps are printed in key order,
+// so equal values always have the same digest.
+func digestCredential(val interface{}) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%#v", val))))
+}
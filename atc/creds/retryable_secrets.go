@@ -23,20 +23,27 @@ func NewRetryableSecrets(secrets Secrets, retryConfig SecretRetryConfig) Secrets
 
 // Get retrieves the value and expiration of an individual secret
 func (rs RetryableSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	result, _, expiration, exists, err := rs.GetVersioned(secretPath)
+	return result, expiration, exists, err
+}
+
+// GetVersioned is like Get, but also returns the secret's version if the
+// underlying secret manager versions its secrets
+func (rs RetryableSecrets) GetVersioned(secretPath string) (interface{}, string, *time.Time, bool, error) {
 	r := &retryhttp.DefaultRetryer{}
 	for i := 0; i < rs.retryConfig.Attempts-1; i++ {
-		result, expiration, exists, err := rs.secrets.Get(secretPath)
+		result, version, expiration, exists, err := getVersioned(rs.secrets, secretPath)
 		if err != nil && r.IsRetryable(err) {
 			time.Sleep(rs.retryConfig.Interval)
 			continue
 		}
-		return result, expiration, exists, err
+		return result, version, expiration, exists, err
 	}
-	result, expiration, exists, err := rs.secrets.Get(secretPath)
+	result, version, expiration, exists, err := getVersioned(rs.secrets, secretPath)
 	if err != nil {
 		err = fmt.Errorf("%s (after %d retries)", err, rs.retryConfig.Attempts)
 	}
-	return result, expiration, exists, err
+	return result, version, expiration, exists, err
 }
 
 // NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
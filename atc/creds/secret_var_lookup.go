@@ -41,6 +41,31 @@ func (sl VariableLookupFromSecrets) Get(varDef vars.VariableDefinition) (interfa
 	}
 }
 
+// GetSecret is like Get, but also identifies the secret the var was read from
+// by its path and, if the credential manager versions secrets, its version.
+func (sl VariableLookupFromSecrets) GetSecret(varDef vars.VariableDefinition) (interface{}, vars.SecretIdentity, bool, error) {
+	if len(sl.LookupPaths) > 0 {
+		for _, rule := range sl.LookupPaths {
+			secretId, err := rule.VariableToSecretPath(varDef.Name)
+			if err != nil {
+				return nil, vars.SecretIdentity{}, false, err
+			}
+			result, version, _, found, err := getVersioned(sl.Secrets, secretId)
+			if err != nil {
+				return nil, vars.SecretIdentity{}, false, err
+			}
+			if !found {
+				continue
+			}
+			return result, vars.SecretIdentity{Path: secretId, Version: version}, true, nil
+		}
+		return nil, vars.SecretIdentity{}, false, nil
+	} else {
+		result, version, _, found, err := getVersioned(sl.Secrets, varDef.Name)
+		return result, vars.SecretIdentity{Path: varDef.Name, Version: version}, found, err
+	}
+}
+
 func (sl VariableLookupFromSecrets) List() ([]vars.VariableDefinition, error) {
 	return nil, nil
 }
@@ -20,3 +20,24 @@ type Secrets interface {
 	// NewSecretLookupPaths returns an instance of lookup policy, which can transform pipeline ((var)) into one or more secret paths, based on team name and pipeline name
 	NewSecretLookupPaths(string, string) []SecretLookupPath
 }
+
+// VersionedSecrets are Secrets from credential managers which version their
+// secrets, e.g. so that rotating a secret can be told apart from reading the
+// same one again.
+type VersionedSecrets interface {
+	Secrets
+
+	// GetVersioned is like Get, but also returns the secret's version
+	GetVersioned(string) (interface{}, string, *time.Time, bool, error)
+}
+
+// getVersioned reads the secret with its version if the credential manager
+// versions its secrets, and otherwise with no version.
+func getVersioned(secrets Secrets, secretPath string) (interface{}, string, *time.Time, bool, error) {
+	if versioned, ok := secrets.(VersionedSecrets); ok {
+		return versioned.GetVersioned(secretPath)
+	}
+
+	value, expiration, found, err := secrets.Get(secretPath)
+	return value, "", expiration, found, err
+}
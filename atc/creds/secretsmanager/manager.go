@@ -69,7 +69,7 @@ func (manager *Manager) Health() (*creds.HealthResponse, error) {
 		Method: "GetSecretValue",
 	}
 
-	_, _, _, err := manager.SecretManager.getSecretById("__concourse-health-check")
+	_, _, _, _, err := manager.SecretManager.getSecretById("__concourse-health-check")
 	if err != nil {
 		health.Error = err.Error()
 		return health, nil
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
@@ -47,17 +48,24 @@ func (s *SecretsManager) NewSecretLookupPaths(teamName string, pipelineName stri
 
 // Get retrieves the value and expiration of an individual secret
 func (s *SecretsManager) Get(secretPath string) (interface{}, *time.Time, bool, error) {
-	value, expiration, found, err := s.getSecretById(secretPath)
+	value, _, expiration, found, err := s.GetVersioned(secretPath)
+	return value, expiration, found, err
+}
+
+// GetVersioned retrieves the value, version and expiration of an individual
+// secret
+func (s *SecretsManager) GetVersioned(secretPath string) (interface{}, string, *time.Time, bool, error) {
+	value, version, expiration, found, err := s.getSecretById(secretPath)
 	if err != nil {
 		s.log.Error("failed-to-fetch-aws-secret", err, lager.Data{
 			"secret-path": secretPath,
 		})
-		return nil, nil, false, err
+		return nil, "", nil, false, err
 	}
 	if found {
-		return value, expiration, true, nil
+		return value, version, expiration, true, nil
 	}
-	return nil, nil, false, nil
+	return nil, "", nil, false, nil
 }
 
 /*
@@ -66,26 +74,27 @@ func (s *SecretsManager) Get(secretPath string) (interface{}, *time.Time, bool,
 
 	In case SecretBinary is set, it is expected to be a valid JSON object or it will error.
 */
-func (s *SecretsManager) getSecretById(name string) (interface{}, *time.Time, bool, error) {
+func (s *SecretsManager) getSecretById(name string) (interface{}, string, *time.Time, bool, error) {
 	value, err := s.api.GetSecretValue(&secretsmanager.GetSecretValueInput{
 		SecretId: &name,
 	})
 	if err == nil {
+		version := aws.StringValue(value.VersionId)
 		switch {
 		case value.SecretString != nil:
-			return *value.SecretString, nil, true, nil
+			return *value.SecretString, version, nil, true, nil
 		case value.SecretBinary != nil:
 			values, err := decodeJsonValue(value.SecretBinary)
 			if err != nil {
-				return nil, nil, true, err
+				return nil, "", nil, true, err
 			}
-			return values, nil, true, nil
+			return values, version, nil, true, nil
 		}
 	} else if errObj, ok := err.(awserr.Error); ok && errObj.Code() == secretsmanager.ErrCodeResourceNotFoundException {
-		return nil, nil, false, nil
+		return nil, "", nil, false, nil
 	}
 
-	return nil, nil, false, err
+	return nil, "", nil, false, err
 }
 
 func decodeJsonValue(data []byte) (map[string]interface{}, error) {
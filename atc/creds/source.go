@@ -26,3 +26,17 @@ func (s Source) Evaluate() (atc.Source, error) {
 
 	return source, nil
 }
+
+// EvaluateWithCredentials evaluates the source, and also returns the
+// credentials interpolated into it.
+func (s Source) EvaluateWithCredentials() (atc.Source, []Credential, error) {
+	recorder := newCredentialRecorder(s.variablesResolver)
+
+	var source atc.Source
+	err := evaluate(recorder, s.rawSource, &source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return source, recorder.Credentials(), nil
+}
@@ -1,8 +1,11 @@
 package creds_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/vars"
 
 	. "github.com/onsi/ginkgo"
@@ -35,4 +38,118 @@ var _ = Describe("Evaluate", func() {
 			}))
 		})
 	})
+
+	Describe("EvaluateWithCredentials", func() {
+		It("parses variables", func() {
+			result, _, err := source.EvaluateWithCredentials()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.Source{
+				"some": map[string]interface{}{
+					"source-key": "lol",
+				},
+			}))
+		})
+
+		It("returns the credentials interpolated into the source", func() {
+			_, credentials, err := source.EvaluateWithCredentials()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(credentials).To(HaveLen(1))
+			Expect(credentials[0].Path).To(Equal("some-param"))
+			Expect(credentials[0].Version).ToNot(BeEmpty())
+		})
+
+		Context("when the source is read from a credential manager", func() {
+			var fakeSecrets *credsfakes.FakeSecrets
+
+			BeforeEach(func() {
+				fakeSecrets = new(credsfakes.FakeSecrets)
+				fakeSecrets.NewSecretLookupPathsReturns([]creds.SecretLookupPath{
+					creds.NewSecretLookupWithPrefix("/concourse/some-team/some-pipeline/"),
+					creds.NewSecretLookupWithPrefix("/concourse/some-team/"),
+				})
+				fakeSecrets.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+					if path == "/concourse/some-team/some-param" {
+						return "lol", nil, true, nil
+					}
+
+					return nil, nil, false, nil
+				}
+
+				source = creds.NewSource(
+					vars.NewCredVarsTracker(creds.NewVariables(fakeSecrets, "some-team", "some-pipeline"), true),
+					atc.Source{"source-key": "((some-param))"},
+				)
+			})
+
+			It("identifies the credentials by the secret they were read from", func() {
+				_, credentials, err := source.EvaluateWithCredentials()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(credentials).To(HaveLen(1))
+				Expect(credentials[0].Path).To(Equal("/concourse/some-team/some-param"))
+			})
+
+			It("gives a secret whose value changes a new version", func() {
+				_, before, err := source.EvaluateWithCredentials()
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeSecrets.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+					if path == "/concourse/some-team/some-param" {
+						return "rofl", nil, true, nil
+					}
+
+					return nil, nil, false, nil
+				}
+
+				_, after, err := source.EvaluateWithCredentials()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(after[0].Path).To(Equal(before[0].Path))
+				Expect(after[0].Version).ToNot(Equal(before[0].Version))
+			})
+
+			Context("when the credential manager versions secrets", func() {
+				BeforeEach(func() {
+					source = creds.NewSource(
+						vars.NewCredVarsTracker(creds.NewVariables(versionedSecrets{fakeSecrets, "3"}, "some-team", "some-pipeline"), true),
+						atc.Source{"source-key": "((some-param))"},
+					)
+				})
+
+				It("uses the secret's version", func() {
+					_, credentials, err := source.EvaluateWithCredentials()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(credentials).To(Equal([]creds.Credential{
+						{Path: "/concourse/some-team/some-param", Version: "3"},
+					}))
+				})
+			})
+		})
+
+		Context("when the source has no vars", func() {
+			BeforeEach(func() {
+				source = creds.NewSource(vars.StaticVariables{}, atc.Source{"some": "source"})
+			})
+
+			It("returns no credentials", func() {
+				_, credentials, err := source.EvaluateWithCredentials()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(credentials).To(BeEmpty())
+			})
+		})
+	})
 })
+
+type versionedSecrets struct {
+	*credsfakes.FakeSecrets
+
+	version string
+}
+
+func (s versionedSecrets) GetVersioned(path string) (interface{}, string, *time.Time, bool, error) {
+	value, expiration, found, err := s.Get(path)
+	return value, s.version, expiration, found, err
+}
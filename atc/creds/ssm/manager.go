@@ -93,7 +93,7 @@ func (manager *SsmManager) Health() (*creds.HealthResponse, error) {
 		Method: "GetParameter",
 	}
 
-	_, _, _, err := manager.Ssm.getParameterByName("__concourse-health-check")
+	_, _, _, _, err := manager.Ssm.getParameterByName("__concourse-health-check")
 	if err != nil {
 		if errObj, ok := err.(awserr.Error); ok && strings.Contains(errObj.Code(), "AccessDenied") {
 			health.Response = map[string]string{
@@ -2,6 +2,7 @@ package ssm
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -47,16 +48,23 @@ func (s *Ssm) NewSecretLookupPaths(teamName string, pipelineName string) []creds
 
 // Get retrieves the value and expiration of an individual secret
 func (s *Ssm) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, _, expiration, found, err := s.GetVersioned(secretPath)
+	return value, expiration, found, err
+}
+
+// GetVersioned retrieves the value, version and expiration of an individual
+// secret. Complex values read by path have no version.
+func (s *Ssm) GetVersioned(secretPath string) (interface{}, string, *time.Time, bool, error) {
 	// Try to get the parameter as string value, by name
-	value, expiration, found, err := s.getParameterByName(secretPath)
+	value, version, expiration, found, err := s.getParameterByName(secretPath)
 	if err != nil {
 		s.log.Error("unable to retrieve aws ssm secret by name", err, lager.Data{
 			"secretPath": secretPath,
 		})
-		return nil, nil, false, err
+		return nil, "", nil, false, err
 	}
 	if found {
-		return value, expiration, true, nil
+		return value, version, expiration, true, nil
 	}
 	// Parameter may exist as a complex value so try again using parameter name as root path
 	value, expiration, found, err = s.getParameterByPath(secretPath)
@@ -64,26 +72,30 @@ func (s *Ssm) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 		s.log.Error("unable to retrieve aws ssm secret by path", err, lager.Data{
 			"secretPath": secretPath,
 		})
-		return nil, nil, false, err
+		return nil, "", nil, false, err
 	}
 	if found {
-		return value, expiration, true, nil
+		return value, "", expiration, true, nil
 	}
-	return nil, nil, false, nil
+	return nil, "", nil, false, nil
 }
 
-func (s *Ssm) getParameterByName(name string) (interface{}, *time.Time, bool, error) {
+func (s *Ssm) getParameterByName(name string) (interface{}, string, *time.Time, bool, error) {
 	param, err := s.api.GetParameter(&ssm.GetParameterInput{
 		Name:           &name,
 		WithDecryption: aws.Bool(true),
 	})
 	if err == nil {
-		return *param.Parameter.Value, nil, true, nil
+		var version string
+		if param.Parameter.Version != nil {
+			version = strconv.FormatInt(*param.Parameter.Version, 10)
+		}
+		return *param.Parameter.Value, version, nil, true, nil
 
 	} else if errObj, ok := err.(awserr.Error); ok && errObj.Code() == ssm.ErrCodeParameterNotFound {
-		return nil, nil, false, nil
+		return nil, "", nil, false, nil
 	}
-	return nil, nil, false, err
+	return nil, "", nil, false, err
 }
 
 func (s *Ssm) getParameterByPath(path string) (interface{}, *time.Time, bool, error) {
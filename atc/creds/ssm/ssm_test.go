@@ -57,7 +57,7 @@ func (mock *MockSsmService) GetParameter(input *ssm.GetParameterInput) (*ssm.Get
 	if err != nil {
 		return nil, err
 	}
-	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: &value}}, nil
+	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: &value, Version: aws.Int64(1)}}, nil
 }
 
 func (mock *MockSsmService) GetParametersByPathPages(input *ssm.GetParametersByPathInput, fn func(*ssm.GetParametersByPathOutput, bool) bool) error {
@@ -120,6 +120,14 @@ var _ = Describe("Ssm", func() {
 			Expect(err).To(BeNil())
 		})
 
+		It("should identify the parameter by its name and version", func() {
+			value, identity, found, err := variables.(vars.SecretVariables).GetSecret(varDef)
+			Expect(value).To(BeEquivalentTo("ssm decrypted value"))
+			Expect(identity).To(Equal(vars.SecretIdentity{Path: "/concourse/alpha/bogus/cheery", Version: "1"}))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
 		It("should get complex paramter", func() {
 			mockService.stubGetParametersByPathPages = func(path string) []mockPathResultPage {
 				return []mockPathResultPage{
@@ -319,6 +319,238 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("finds the last deleted version for inputs that use deleted versions when there are no builds for that resource", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			Deleted: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Deleted: true},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("finds the next deleted version for inputs that use deleted versions when there is a build for that resource", Example{
+		DB: DB{
+			BuildInputs: []DBRow{
+				{Job: CurrentJobName, BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv4", CheckOrder: 4},
+			},
+
+			Deleted: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Deleted: true},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("does not resolve inputs that use deleted versions when nothing has been deleted", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Deleted: true},
+			},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
+
+	Entry("does not use deleted versions for inputs that use the latest version", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+
+			Deleted: []DBRow{
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
+	Entry("falls back to the latest passing version once builds keep failing with the latest version", Example{
+		DB: DB{
+			BuildInputs: []DBRow{
+				{Job: CurrentJobName, BuildID: 1, InputName: "resource-x", Resource: "resource-x", Version: "rxv1", CheckOrder: 1, Status: "succeeded"},
+				{Job: CurrentJobName, BuildID: 2, InputName: "resource-x", Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Status: "succeeded"},
+				{Job: CurrentJobName, BuildID: 3, InputName: "resource-x", Resource: "resource-x", Version: "rxv3", CheckOrder: 3, Status: "failed"},
+				{Job: CurrentJobName, BuildID: 4, InputName: "resource-x", Resource: "resource-x", Version: "rxv3", CheckOrder: 3, Status: "failed"},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:          "resource-x",
+				Resource:      "resource-x",
+				FallbackAfter: 2,
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+			Fallbacks: map[string]string{
+				"resource-x": "rxv3",
+			},
+		},
+	}),
+
+	Entry("keeps trying the latest version until builds have failed with it enough times", Example{
+		DB: DB{
+			BuildInputs: []DBRow{
+				{Job: CurrentJobName, BuildID: 1, InputName: "resource-x", Resource: "resource-x", Version: "rxv1", CheckOrder: 1, Status: "succeeded"},
+				{Job: CurrentJobName, BuildID: 2, InputName: "resource-x", Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Status: "failed"},
+				{Job: CurrentJobName, BuildID: 3, InputName: "resource-x", Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Status: "errored"},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:          "resource-x",
+				Resource:      "resource-x",
+				FallbackAfter: 2,
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("does not fall back from a latest version which a build has passed with", Example{
+		DB: DB{
+			BuildInputs: []DBRow{
+				{Job: CurrentJobName, BuildID: 1, InputName: "resource-x", Resource: "resource-x", Version: "rxv1", CheckOrder: 1, Status: "succeeded"},
+				{Job: CurrentJobName, BuildID: 2, InputName: "resource-x", Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Status: "failed"},
+				{Job: CurrentJobName, BuildID: 3, InputName: "resource-x", Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Status: "succeeded"},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:          "resource-x",
+				Resource:      "resource-x",
+				FallbackAfter: 1,
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("keeps the latest version when the job has never passed", Example{
+		DB: DB{
+			BuildInputs: []DBRow{
+				{Job: CurrentJobName, BuildID: 1, InputName: "resource-x", Resource: "resource-x", Version: "rxv1", CheckOrder: 1, Status: "failed"},
+				{Job: CurrentJobName, BuildID: 2, InputName: "resource-x", Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Status: "failed"},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:          "resource-x",
+				Resource:      "resource-x",
+				FallbackAfter: 1,
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
 	Entry("finds next version for inputs that use every version when there is a build for that resource", Example{
 		DB: DB{
 			BuildInputs: []DBRow{
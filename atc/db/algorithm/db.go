@@ -2,6 +2,7 @@ package algorithm
 
 type VersionsDB struct {
 	ResourceVersions []ResourceVersion
+	DeletedVersions  []ResourceVersion
 	BuildOutputs     []BuildOutput
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
@@ -25,6 +26,9 @@ type BuildInput struct {
 	BuildID   int
 	JobID     int
 	InputName string
+
+	Succeeded bool
+	Failed    bool
 }
 
 func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputName string) bool {
@@ -38,6 +42,54 @@ func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputNam
 	return true
 }
 
+// FailedBuildsWithVersion returns how many of the job's builds have failed
+// with the version as the named input, or 0 if any has succeeded with it.
+func (db VersionsDB) FailedBuildsWithVersion(versionID int, jobID int, inputName string) int {
+	failed := 0
+	for _, buildInput := range db.BuildInputs {
+		if buildInput.VersionID != versionID ||
+			buildInput.JobID != jobID ||
+			buildInput.InputName != inputName {
+			continue
+		}
+
+		if buildInput.Succeeded {
+			return 0
+		}
+
+		if buildInput.Failed {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// LatestVersionPassedByJob returns the latest version of the resource which
+// one of the job's builds has succeeded with as the named input.
+func (db VersionsDB) LatestVersionPassedByJob(resourceID int, jobID int, inputName string) (VersionCandidate, bool) {
+	var candidate VersionCandidate
+	var found bool
+
+	for _, buildInput := range db.BuildInputs {
+		if !buildInput.Succeeded ||
+			buildInput.ResourceID != resourceID ||
+			buildInput.JobID != jobID ||
+			buildInput.InputName != inputName {
+			continue
+		}
+
+		// the version may since have been deleted or disabled
+		version, exists := db.FindVersionOfResource(resourceID, buildInput.VersionID)
+		if exists && version.CheckOrder > candidate.CheckOrder {
+			candidate = version
+			found = true
+		}
+	}
+
+	return candidate, found
+}
+
 func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	candidates := VersionCandidates{}
 	for _, output := range db.ResourceVersions {
@@ -52,6 +104,20 @@ func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	return candidates
 }
 
+func (db VersionsDB) AllDeletedVersionsOfResource(resourceID int) VersionCandidates {
+	candidates := VersionCandidates{}
+	for _, output := range db.DeletedVersions {
+		if output.ResourceID == resourceID {
+			candidates.Add(VersionCandidate{
+				VersionID:  output.VersionID,
+				CheckOrder: output.CheckOrder,
+			})
+		}
+	}
+
+	return candidates
+}
+
 func (db VersionsDB) LatestVersionOfResource(resourceID int) (VersionCandidate, bool) {
 	var candidate VersionCandidate
 	var found bool
@@ -67,7 +67,68 @@ func (candidates InputCandidates) String() string {
 }
 
 func (candidates InputCandidates) Reduce(depth int, jobs JobSet) (ResolvedInputs, bool) {
-	newInputCandidates := candidates.pruneToCommonBuilds(jobs)
+	mapping, _, ok := candidates.ReduceWithLimit(jobs, 0, nil)
+	return mapping, ok
+}
+
+// ReduceCheckpoint records the version each input was pinned to when a
+// search gave up, so that the next search can resume from there rather than
+// explore the same versions again.
+type ReduceCheckpoint map[string]int
+
+// ReduceWithLimit reduces the candidates as Reduce does, but gives up once it
+// has explored more than limit versions of any one input, returning a
+// checkpoint to resume from instead. A limit of 0 means there is no limit.
+//
+// A search resuming from a checkpoint skips the versions of each input newer
+// than the one it was pinned to, including any which appeared since, until
+// it moves on from them. Searches which resume from a checkpoint which no
+// longer matches the candidates start from the beginning.
+func (candidates InputCandidates) ReduceWithLimit(jobs JobSet, limit int, checkpoint ReduceCheckpoint) (ResolvedInputs, ReduceCheckpoint, bool) {
+	reduction := &reduction{
+		jobs:     jobs,
+		limit:    limit,
+		resume:   ReduceCheckpoint{},
+		explored: map[string]int{},
+	}
+
+	for input, version := range checkpoint {
+		reduction.resume[input] = version
+	}
+
+	mapping, ok := reduction.reduce(candidates)
+	if reduction.gaveUp {
+		checkpoint := ReduceCheckpoint{}
+		for _, pin := range reduction.pinned {
+			checkpoint[pin.input] = pin.versionID
+		}
+
+		return nil, checkpoint, false
+	}
+
+	return mapping, nil, ok
+}
+
+type reduction struct {
+	jobs  JobSet
+	limit int
+
+	resume   ReduceCheckpoint
+	explored map[string]int
+
+	// pinned is the version each input being explored is pinned to,
+	// outermost first
+	pinned []pin
+	gaveUp bool
+}
+
+type pin struct {
+	input     string
+	versionID int
+}
+
+func (reduction *reduction) reduce(candidates InputCandidates) (ResolvedInputs, bool) {
+	newInputCandidates := candidates.pruneToCommonBuilds(reduction.jobs)
 
 	for i, inputVersionCandidates := range newInputCandidates {
 		if inputVersionCandidates.Len() == 1 {
@@ -80,8 +141,11 @@ func (candidates InputCandidates) Reduce(depth int, jobs JobSet) (ResolvedInputs
 			continue
 		}
 
+		input := inputVersionCandidates.Input
 		versionIDs := inputVersionCandidates.VersionIDs()
 
+		reduction.resumeFrom(input, versionIDs)
+
 		iteration := 0
 
 		for {
@@ -93,9 +157,25 @@ func (candidates InputCandidates) Reduce(depth int, jobs JobSet) (ResolvedInputs
 
 			iteration++
 
+			reduction.pinned = append(reduction.pinned, pin{input, id})
+
+			reduction.explored[input]++
+			if reduction.limit > 0 && reduction.explored[input] > reduction.limit {
+				// the versions pinned so far, including this one which is
+				// yet to be explored, are where the next search resumes
+				reduction.gaveUp = true
+				return nil, false
+			}
+
 			newInputCandidates.Pin(i, id)
 
-			mapping, ok := newInputCandidates.Reduce(depth+1, jobs)
+			mapping, ok := reduction.reduce(newInputCandidates)
+			if reduction.gaveUp {
+				return nil, false
+			}
+
+			reduction.pinned = reduction.pinned[:len(reduction.pinned)-1]
+
 			if ok && inputVersionCandidates.IsNext(id, versionIDs) {
 				return mapping, true
 			}
@@ -120,6 +200,35 @@ func (candidates InputCandidates) Reduce(depth int, jobs JobSet) (ResolvedInputs
 	return resolved, true
 }
 
+// resumeFrom skips the versions of the input which were explored before the
+// checkpoint being resumed from, if the version it was pinned to is still a
+// candidate. Otherwise, the rest of the checkpoint is of no use, as the
+// search has gone somewhere else.
+func (reduction *reduction) resumeFrom(input string, versionIDs *VersionsIter) {
+	resumeID, found := reduction.resume[input]
+	if !found {
+		return
+	}
+
+	delete(reduction.resume, input)
+
+	probe := *versionIDs
+	for {
+		id, ok := probe.Peek()
+		if !ok {
+			reduction.resume = ReduceCheckpoint{}
+			return
+		}
+
+		if id == resumeID {
+			*versionIDs = probe
+			return
+		}
+
+		probe.Next()
+	}
+}
+
 func (candidates InputCandidates) Pin(input int, version int) {
 	limitedToVersion := candidates[input].ForVersion(version)
 
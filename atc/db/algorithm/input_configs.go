@@ -3,24 +3,42 @@ package algorithm
 type InputConfigs []InputConfig
 
 type InputConfig struct {
-	Name            string
-	JobName         string
-	Passed          JobSet
-	UseEveryVersion bool
-	PinnedVersionID int
-	ResourceID      int
-	JobID           int
+	Name               string
+	JobName            string
+	Passed             JobSet
+	UseEveryVersion    bool
+	UseDeletedVersions bool
+	PinnedVersionID    int
+	ResourceID         int
+	JobID              int
+
+	// FallbackAfter is the number of builds which must fail with the latest
+	// version before falling back to the latest version the job has passed
+	// with. Inputs which don't fall back leave it as 0.
+	FallbackAfter int
 }
 
 func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
+	mapping, _, ok := configs.ResolveWithLimit(db, 0, nil)
+	return mapping, ok
+}
+
+// ResolveWithLimit resolves the inputs as Resolve does, but gives up once it
+// has explored more than limit versions of any one input, returning a
+// checkpoint for the next attempt to resume from. See
+// InputCandidates.ReduceWithLimit.
+func (configs InputConfigs) ResolveWithLimit(db *VersionsDB, limit int, checkpoint ReduceCheckpoint) (InputMapping, ReduceCheckpoint, bool) {
 	jobs := JobSet{}
 	inputCandidates := InputCandidates{}
+	fallbacks := map[string]int{}
 
 	for _, inputConfig := range configs {
 		versionCandidates := VersionCandidates{}
 
 		if len(inputConfig.Passed) == 0 {
-			if inputConfig.UseEveryVersion {
+			if inputConfig.UseDeletedVersions {
+				versionCandidates = db.AllDeletedVersionsOfResource(inputConfig.ResourceID)
+			} else if inputConfig.UseEveryVersion {
 				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
 			} else {
 				var versionCandidate VersionCandidate
@@ -30,6 +48,15 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
 				} else {
 					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
+
+					if found && inputConfig.FallbackAfter > 0 &&
+						db.FailedBuildsWithVersion(versionCandidate.VersionID, inputConfig.JobID, inputConfig.Name) >= inputConfig.FallbackAfter {
+						passing, passed := db.LatestVersionPassedByJob(inputConfig.ResourceID, inputConfig.JobID, inputConfig.Name)
+						if passed && passing.VersionID != versionCandidate.VersionID {
+							fallbacks[inputConfig.Name] = versionCandidate.VersionID
+							versionCandidate = passing
+						}
+					}
 				}
 
 				if found {
@@ -38,7 +65,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			}
 
 			if versionCandidates.IsEmpty() {
-				return nil, false
+				return nil, nil, false
 			}
 		} else {
 			jobs = jobs.Union(inputConfig.Passed)
@@ -49,7 +76,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			)
 
 			if versionCandidates.IsEmpty() {
-				return nil, false
+				return nil, nil, false
 			}
 		}
 
@@ -62,16 +89,16 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		inputCandidates = append(inputCandidates, InputVersionCandidates{
 			Input:                 inputConfig.Name,
 			Passed:                inputConfig.Passed,
-			UseEveryVersion:       inputConfig.UseEveryVersion,
+			UseEveryVersion:       inputConfig.UseEveryVersion || inputConfig.UseDeletedVersions,
 			PinnedVersionID:       inputConfig.PinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
 		})
 	}
 
-	basicMapping, ok := inputCandidates.Reduce(0, jobs)
+	basicMapping, checkpoint, ok := inputCandidates.ReduceWithLimit(jobs, limit, checkpoint)
 	if !ok {
-		return nil, false
+		return nil, checkpoint, false
 	}
 
 	mapping := InputMapping{}
@@ -80,11 +107,12 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		inputVersionID := basicMapping[inputName]
 		firstOccurrence := db.IsVersionFirstOccurrence(inputVersionID, inputConfig.JobID, inputName)
 		mapping[inputName] = InputVersion{
-			ResourceID:      inputConfig.ResourceID,
-			VersionID:       inputVersionID,
-			FirstOccurrence: firstOccurrence,
+			ResourceID:            inputConfig.ResourceID,
+			VersionID:             inputVersionID,
+			FirstOccurrence:       firstOccurrence,
+			FallbackFromVersionID: fallbacks[inputName],
 		}
 	}
 
-	return mapping, true
+	return mapping, nil, true
 }
@@ -6,4 +6,8 @@ type InputVersion struct {
 	ResourceID      int
 	VersionID       int
 	FirstOccurrence bool
+
+	// FallbackFromVersionID is the latest version of the input, if the input
+	// fell back from it to the latest version the job has passed with.
+	FallbackFromVersionID int
 }
@@ -0,0 +1,87 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResolveWithLimit", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+	)
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 21, CheckOrder: 3},
+				{VersionID: 4, ResourceID: 21, CheckOrder: 4},
+			},
+			BuildOutputs: []algorithm.BuildOutput{},
+			BuildInputs: []algorithm.BuildInput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+					InputName:       "some-input",
+				},
+			},
+			JobIDs:      map[string]int{"j1": 11},
+			ResourceIDs: map[string]int{"r1": 21},
+		}
+
+		// every version is used in turn, so versions 4 and 3 are explored
+		// before settling on version 2, the one after the last one used
+		inputConfigs = algorithm.InputConfigs{
+			{
+				Name:            "some-input",
+				JobName:         "j1",
+				Passed:          algorithm.JobSet{},
+				UseEveryVersion: true,
+				ResourceID:      21,
+				JobID:           11,
+			},
+		}
+	})
+
+	It("resolves the inputs when there's no limit", func() {
+		mapping, checkpoint, ok := inputConfigs.ResolveWithLimit(versionsDB, 0, nil)
+		Expect(ok).To(BeTrue())
+		Expect(checkpoint).To(BeNil())
+		Expect(mapping["some-input"].VersionID).To(Equal(2))
+	})
+
+	It("resolves the inputs when they're found within the limit", func() {
+		mapping, checkpoint, ok := inputConfigs.ResolveWithLimit(versionsDB, 3, nil)
+		Expect(ok).To(BeTrue())
+		Expect(checkpoint).To(BeNil())
+		Expect(mapping["some-input"].VersionID).To(Equal(2))
+	})
+
+	It("gives up after exploring the limit, resuming from where it left off", func() {
+		_, checkpoint, ok := inputConfigs.ResolveWithLimit(versionsDB, 1, nil)
+		Expect(ok).To(BeFalse())
+		Expect(checkpoint).To(Equal(algorithm.ReduceCheckpoint{"some-input": 3}))
+
+		_, checkpoint, ok = inputConfigs.ResolveWithLimit(versionsDB, 1, checkpoint)
+		Expect(ok).To(BeFalse())
+		Expect(checkpoint).To(Equal(algorithm.ReduceCheckpoint{"some-input": 2}))
+
+		mapping, checkpoint, ok := inputConfigs.ResolveWithLimit(versionsDB, 1, checkpoint)
+		Expect(ok).To(BeTrue())
+		Expect(checkpoint).To(BeNil())
+		Expect(mapping["some-input"].VersionID).To(Equal(2))
+	})
+
+	Context("when the checkpoint's version is no longer a candidate", func() {
+		It("starts from the beginning", func() {
+			mapping, checkpoint, ok := inputConfigs.ResolveWithLimit(versionsDB, 0, algorithm.ReduceCheckpoint{"some-input": 99})
+			Expect(ok).To(BeTrue())
+			Expect(checkpoint).To(BeNil())
+			Expect(mapping["some-input"].VersionID).To(Equal(2))
+		})
+	})
+})
@@ -14,6 +14,7 @@ type DB struct {
 	BuildInputs  []DBRow
 	BuildOutputs []DBRow
 	Resources    []DBRow
+	Deleted      []DBRow
 }
 
 type DBRow struct {
@@ -23,6 +24,8 @@ type DBRow struct {
 	Version    string
 	CheckOrder int
 	VersionID  int
+	InputName  string
+	Status     string
 }
 
 type Example struct {
@@ -35,21 +38,24 @@ type Example struct {
 type Inputs []Input
 
 type Input struct {
-	Name     string
-	Resource string
-	Passed   []string
-	Version  Version
+	Name          string
+	Resource      string
+	Passed        []string
+	Version       Version
+	FallbackAfter int
 }
 
 type Version struct {
-	Every  bool
-	Latest bool
-	Pinned string
+	Every   bool
+	Latest  bool
+	Deleted bool
+	Pinned  string
 }
 
 type Result struct {
-	OK     bool
-	Values map[string]string
+	OK        bool
+	Values    map[string]string
+	Fallbacks map[string]string
 }
 
 type StringMapping map[string]int
@@ -114,6 +120,14 @@ func (example Example) Run() {
 			}
 			db.ResourceVersions = append(db.ResourceVersions, version)
 		}
+		for _, row := range example.DB.Deleted {
+			version := algorithm.ResourceVersion{
+				VersionID:  versionIDs.ID(row.Version),
+				ResourceID: resourceIDs.ID(row.Resource),
+				CheckOrder: row.CheckOrder,
+			}
+			db.DeletedVersions = append(db.DeletedVersions, version)
+		}
 		for _, row := range example.DB.BuildInputs {
 			version := algorithm.ResourceVersion{
 				VersionID:  versionIDs.ID(row.Version),
@@ -124,6 +138,9 @@ func (example Example) Run() {
 				ResourceVersion: version,
 				BuildID:         row.BuildID,
 				JobID:           jobIDs.ID(row.Job),
+				InputName:       row.InputName,
+				Succeeded:       row.Status == "succeeded",
+				Failed:          row.Status == "failed",
 			})
 		}
 		for _, row := range example.DB.BuildOutputs {
@@ -153,23 +170,34 @@ func (example Example) Run() {
 		}
 
 		inputConfigs[i] = algorithm.InputConfig{
-			Name:            input.Name,
-			Passed:          passed,
-			ResourceID:      resourceIDs.ID(input.Resource),
-			UseEveryVersion: input.Version.Every,
-			PinnedVersionID: versionID,
-			JobID:           jobIDs.ID(CurrentJobName),
+			Name:               input.Name,
+			Passed:             passed,
+			ResourceID:         resourceIDs.ID(input.Resource),
+			UseEveryVersion:    input.Version.Every,
+			UseDeletedVersions: input.Version.Deleted,
+			PinnedVersionID:    versionID,
+			JobID:              jobIDs.ID(CurrentJobName),
+			FallbackAfter:      input.FallbackAfter,
 		}
 	}
 
 	resolved, ok := inputConfigs.Resolve(db)
 
 	prettyValues := map[string]string{}
+	var prettyFallbacks map[string]string
 	for name, inputVersion := range resolved {
 		prettyValues[name] = versionIDs.Name(inputVersion.VersionID)
+
+		if inputVersion.FallbackFromVersionID != 0 {
+			if prettyFallbacks == nil {
+				prettyFallbacks = map[string]string{}
+			}
+
+			prettyFallbacks[name] = versionIDs.Name(inputVersion.FallbackFromVersionID)
+		}
 	}
 
-	actualResult := Result{OK: ok, Values: prettyValues}
+	actualResult := Result{OK: ok, Values: prettyValues, Fallbacks: prettyFallbacks}
 
 	Expect(actualResult).To(Equal(example.Result))
 }
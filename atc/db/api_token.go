@@ -0,0 +1,89 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+var ErrAPITokenExists = errors.New("an api token with the same name already exists")
+
+var apiTokensQuery = psql.Select("a.id, a.name, t.name, a.scopes, a.expires_at, a.created_by, a.created_at, a.revoked_at").
+	From("api_tokens a").
+	Join("teams t ON t.id = a.team_id")
+
+//go:generate counterfeiter . APITokenRepository
+
+// APITokenRepository is used when authenticating requests made with API
+// tokens, which stay valid until they expire unless they are revoked.
+type APITokenRepository interface {
+	IsRevoked(tokenID int) (bool, error)
+}
+
+type apiTokenRepository struct {
+	conn Conn
+}
+
+func NewAPITokenRepository(conn Conn) APITokenRepository {
+	return &apiTokenRepository{
+		conn: conn,
+	}
+}
+
+// IsRevoked returns true if the token was revoked, or its team was deleted
+// or destroyed.
+func (repository *apiTokenRepository) IsRevoked(tokenID int) (bool, error) {
+	var revoked bool
+	err := psql.Select("a.revoked_at IS NOT NULL OR t.deleted_at IS NOT NULL").
+		From("api_tokens a").
+		Join("teams t ON t.id = a.team_id").
+		Where(sq.Eq{"a.id": tokenID}).
+		RunWith(repository.conn).
+		QueryRow().
+		Scan(&revoked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+func scanAPIToken(row scannable) (atc.APIToken, error) {
+	var (
+		token     atc.APIToken
+		scopes    []byte
+		expiresAt time.Time
+		createdBy sql.NullString
+		createdAt time.Time
+		revokedAt pq.NullTime
+	)
+
+	err := row.Scan(&token.ID, &token.Name, &token.TeamName, &scopes, &expiresAt, &createdBy, &createdAt, &revokedAt)
+	if err != nil {
+		return atc.APIToken{}, err
+	}
+
+	err = json.Unmarshal(scopes, &token.Scopes)
+	if err != nil {
+		return atc.APIToken{}, err
+	}
+
+	token.ExpiresAt = expiresAt.Unix()
+	token.CreatedBy = createdBy.String
+	token.CreatedAt = createdAt.Unix()
+
+	if revokedAt.Valid {
+		token.RevokedAt = revokedAt.Time.Unix()
+	}
+
+	return token, nil
+}
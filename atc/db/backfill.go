@@ -0,0 +1,211 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// A Backfill fills in data for a schema change a batch of rows at a time
+// while the ATC is running, rather than in the migration making the change,
+// so that a hot table isn't locked for as long as it takes to rewrite it.
+//
+// Only the rows which exist when the backfill first runs are visited, so
+// whatever writes rows must already write them in their new form by then.
+type Backfill struct {
+	Name string
+
+	// Table is the table whose rows are visited, in order of their ids.
+	Table string
+
+	// Batch fills in the rows with ids after fromID, up to and including
+	// toID. It is run in the same transaction as the progress is saved in.
+	Batch func(tx Tx, fromID int, toID int) error
+}
+
+// Backfills are the backfills run by the ATC, in order. A backfill should
+// be kept until a later migration no longer needs the data it fills in.
+var Backfills = []Backfill{
+	compressBuildEventsBackfill,
+}
+
+// BackfillProgress is how far a backfill has got through the rows it
+// visits. Error is set when its last batch failed, and is retried.
+type BackfillProgress struct {
+	Name     string
+	TargetID int
+	LastID   int
+	Error    string
+
+	StartedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt time.Time
+}
+
+func (progress BackfillProgress) Finished() bool {
+	return !progress.FinishedAt.IsZero()
+}
+
+//go:generate counterfeiter . BackfillRepository
+
+type BackfillRepository interface {
+	Progress() ([]BackfillProgress, error)
+	RunBatch(backfill Backfill, size int) (bool, error)
+}
+
+type backfillRepository struct {
+	conn Conn
+}
+
+func NewBackfillRepository(conn Conn) BackfillRepository {
+	return &backfillRepository{
+		conn: conn,
+	}
+}
+
+var backfillsQuery = psql.Select(
+	"name",
+	"target_id",
+	"last_id",
+	"error",
+	"started_at",
+	"updated_at",
+	"finished_at",
+).From("backfills")
+
+// Progress returns the progress of every backfill which has started.
+func (repository *backfillRepository) Progress() ([]BackfillProgress, error) {
+	rows, err := backfillsQuery.
+		OrderBy("started_at", "name").
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	progress := []BackfillProgress{}
+	for rows.Next() {
+		p, err := scanBackfillProgress(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		progress = append(progress, p)
+	}
+
+	return progress, nil
+}
+
+// RunBatch runs the next batch of up to size rows of the backfill,
+// returning true once it has visited all of its rows.
+func (repository *backfillRepository) RunBatch(backfill Backfill, size int) (bool, error) {
+	// the rows to visit are the ones there are when the backfill first runs
+	_, err := repository.conn.Exec(`
+		INSERT INTO backfills (name, target_id)
+		SELECT $1, COALESCE(MAX(id), 0) FROM `+pq.QuoteIdentifier(backfill.Table)+`
+		ON CONFLICT (name) DO NOTHING
+	`, backfill.Name)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := repository.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	progress, err := scanBackfillProgress(backfillsQuery.
+		Where(sq.Eq{"name": backfill.Name}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow())
+	if err != nil {
+		return false, err
+	}
+
+	if progress.Finished() {
+		return true, nil
+	}
+
+	toID := progress.LastID + size
+	if toID > progress.TargetID {
+		toID = progress.TargetID
+	}
+
+	err = backfill.Batch(tx, progress.LastID, toID)
+	if err != nil {
+		Rollback(tx)
+
+		_, updateErr := psql.Update("backfills").
+			Set("error", err.Error()).
+			Set("updated_at", sq.Expr("now()")).
+			Where(sq.Eq{"name": backfill.Name}).
+			RunWith(repository.conn).
+			Exec()
+		if updateErr != nil {
+			return false, fmt.Errorf("%s (failed to save error: %s)", err, updateErr)
+		}
+
+		return false, err
+	}
+
+	finished := toID >= progress.TargetID
+
+	update := psql.Update("backfills").
+		Set("last_id", toID).
+		Set("error", nil).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"name": backfill.Name})
+
+	if finished {
+		update = update.Set("finished_at", sq.Expr("now()"))
+	}
+
+	_, err = update.RunWith(tx).Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return finished, nil
+}
+
+func scanBackfillProgress(row scannable) (BackfillProgress, error) {
+	var (
+		progress    BackfillProgress
+		backfillErr sql.NullString
+		finishedAt  pq.NullTime
+	)
+
+	err := row.Scan(
+		&progress.Name,
+		&progress.TargetID,
+		&progress.LastID,
+		&backfillErr,
+		&progress.StartedAt,
+		&progress.UpdatedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return BackfillProgress{}, err
+	}
+
+	progress.Error = backfillErr.String
+
+	if finishedAt.Valid {
+		progress.FinishedAt = finishedAt.Time
+	}
+
+	return progress, nil
+}
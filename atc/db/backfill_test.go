@@ -0,0 +1,104 @@
+package db_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BackfillRepository", func() {
+	var (
+		repository db.BackfillRepository
+		backfill   db.Backfill
+
+		batches  [][2]int
+		batchErr error
+		maxID    int
+	)
+
+	BeforeEach(func() {
+		repository = db.NewBackfillRepository(dbConn)
+
+		batches = nil
+		batchErr = nil
+
+		for _, name := range []string{"backfill-a", "backfill-b", "backfill-c"} {
+			_, err := teamFactory.CreateTeam(atc.Team{Name: name})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		err := dbConn.QueryRow(`SELECT MAX(id) FROM teams`).Scan(&maxID)
+		Expect(err).NotTo(HaveOccurred())
+
+		backfill = db.Backfill{
+			Name:  "some-backfill",
+			Table: "teams",
+			Batch: func(tx db.Tx, fromID int, toID int) error {
+				batches = append(batches, [2]int{fromID, toID})
+				return batchErr
+			},
+		}
+	})
+
+	It("visits the rows there are when it starts, a batch at a time", func() {
+		finished, err := repository.RunBatch(backfill, maxID-1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finished).To(BeFalse())
+
+		_, err = teamFactory.CreateTeam(atc.Team{Name: "backfill-d"})
+		Expect(err).NotTo(HaveOccurred())
+
+		finished, err = repository.RunBatch(backfill, maxID-1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finished).To(BeTrue())
+
+		Expect(batches).To(Equal([][2]int{{0, maxID - 1}, {maxID - 1, maxID}}))
+
+		finished, err = repository.RunBatch(backfill, maxID-1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finished).To(BeTrue())
+		Expect(batches).To(HaveLen(2))
+	})
+
+	It("reports its progress", func() {
+		_, err := repository.RunBatch(backfill, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		progress, err := repository.Progress()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress).To(HaveLen(1))
+		Expect(progress[0].Name).To(Equal("some-backfill"))
+		Expect(progress[0].TargetID).To(Equal(maxID))
+		Expect(progress[0].LastID).To(Equal(1))
+		Expect(progress[0].Finished()).To(BeFalse())
+	})
+
+	Context("when a batch fails", func() {
+		BeforeEach(func() {
+			batchErr = errors.New("disaster")
+		})
+
+		It("records the error and retries the batch next time", func() {
+			_, err := repository.RunBatch(backfill, 1)
+			Expect(err).To(MatchError("disaster"))
+
+			progress, err := repository.Progress()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress[0].LastID).To(Equal(0))
+			Expect(progress[0].Error).To(Equal("disaster"))
+
+			batchErr = nil
+
+			_, err = repository.RunBatch(backfill, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(batches).To(Equal([][2]int{{0, 1}, {0, 1}}))
+
+			progress, err = repository.Progress()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress[0].Error).To(BeEmpty())
+		})
+	})
+})
@@ -1,7 +1,9 @@
 package db
 
 import (
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,17 +21,30 @@ import (
 
 const schema = "exec.v2"
 
+// SavedEvent is one of a build's saved events, along with the ID it can be
+// replaced by.
+type SavedEvent struct {
+	ID    int
+	Event atc.Event
+}
+
 type BuildInput struct {
 	Name       string
 	Version    atc.Version
 	ResourceID int
+	Metadata   ResourceConfigMetadataFields
 
 	FirstOccurrence bool
+
+	// FallbackFrom is the latest version of the input, if the input fell back
+	// from it to the latest version the job has passed with.
+	FallbackFrom atc.Version
 }
 
 type BuildOutput struct {
-	Name    string
-	Version atc.Version
+	Name     string
+	Version  atc.Version
+	Metadata ResourceConfigMetadataFields
 }
 
 type BuildStatus string
@@ -43,7 +58,7 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed, b.token, b.annotations, b.input_overrides, b.input_fallbacks, b.image_overrides, b.input_artifacts, b.worker_overrides, b.paused, b.rerun_of, b.auto_retry_attempt, b.error_code, b.schedulable_time").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -68,14 +83,23 @@ type Build interface {
 	PublicPlan() *json.RawMessage
 	HasPlan() bool
 	Status() BuildStatus
+	CreateTime() time.Time
 	StartTime() time.Time
 	IsNewerThanLastCheckOf(input Resource) bool
 	EndTime() time.Time
 	ReapTime() time.Time
+	SchedulableTime() time.Time
 	IsManuallyTriggered() bool
 	IsScheduled() bool
 	IsRunning() bool
 	IsCompleted() bool
+	Token() string
+	Annotations() atc.BuildAnnotations
+	InputOverrides() atc.InputVersionOverrides
+	InputFallbacks() atc.InputFallbacks
+	ImageOverrides() atc.TaskImageOverrides
+	InputArtifacts() atc.InputArtifacts
+	WorkerOverrides() atc.StepWorkerOverrides
 
 	Reload() (bool, error)
 
@@ -86,12 +110,17 @@ type Build interface {
 
 	Start(atc.Plan) (bool, error)
 	Finish(BuildStatus) error
+	ErrorPending(atc.ErrorCode, string) (bool, error)
 
 	SetInterceptible(bool) error
 
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
 
+	// SavedEvents returns the build's saved events of the given types along
+	// with their IDs, in the order they were saved.
+	SavedEvents(types ...atc.EventType) ([]SavedEvent, error)
+
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
@@ -100,6 +129,7 @@ type Build interface {
 
 	Resources() ([]BuildInput, []BuildOutput, error)
 	SaveImageResourceVersion(UsedResourceCache) error
+	SaveResourceFetch(resourceType string, duration time.Duration, succeeded bool) error
 
 	Pipeline() (Pipeline, bool, error)
 
@@ -108,9 +138,29 @@ type Build interface {
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
+	SetSchedulable(bool) error
+	ClaimConcurrencyPools(pools []string) (bool, error)
+	ReleaseConcurrencyPools() error
+	ClaimStepConcurrencyPools(planID atc.PlanID, pools []string) (bool, error)
+	ReleaseStepConcurrencyPools(planID atc.PlanID) error
 
 	IsDrained() bool
 	SetDrained(bool) error
+
+	IsPaused() bool
+	Paused() (bool, error)
+	SetPaused(bool) error
+	ResumeNotifier() (Notifier, error)
+
+	RerunOf() int
+	AutoRetryAttempt() int
+	Checkpoint() (atc.BuildCheckpoint, bool, error)
+	SaveCheckpoint(atc.BuildCheckpoint) error
+
+	Annotate(atc.BuildAnnotations) error
+
+	ErrorCode() atc.ErrorCode
+	SaveErrorCode(atc.ErrorCode) error
 }
 
 type build struct {
@@ -138,16 +188,32 @@ type build struct {
 	endTime    time.Time
 	reapTime   time.Time
 
+	schedulableTime time.Time
+
 	conn        Conn
 	lockFactory lock.LockFactory
 	drained     bool
 	aborted     bool
 	completed   bool
+	paused      bool
+	rerunOf     int
+	autoRetry   int
+	errorCode   atc.ErrorCode
+
+	token          string
+	annotations    atc.BuildAnnotations
+	inputOverrides atc.InputVersionOverrides
+	inputFallbacks atc.InputFallbacks
+	imageOverrides atc.TaskImageOverrides
+	inputArtifacts atc.InputArtifacts
+
+	workerOverrides atc.StepWorkerOverrides
 }
 
 var ErrBuildDisappeared = errors.New("build disappeared from db")
 var ErrBuildHasNoPipeline = errors.New("build has no pipeline")
 var ErrBuildArtifactNotFound = errors.New("build artifact not found")
+var ErrTooManyBuildAnnotations = fmt.Errorf("builds may have at most %d annotations", atc.MaxBuildAnnotations)
 
 type ResourceNotFoundInPipeline struct {
 	Resource string
@@ -174,15 +240,41 @@ func (b *build) HasPlan() bool                { return string(*b.publicPlan) !=
 func (b *build) IsNewerThanLastCheckOf(input Resource) bool {
 	return b.createTime.After(input.LastCheckEndTime())
 }
-func (b *build) StartTime() time.Time { return b.startTime }
-func (b *build) EndTime() time.Time   { return b.endTime }
-func (b *build) ReapTime() time.Time  { return b.reapTime }
-func (b *build) Status() BuildStatus  { return b.status }
-func (b *build) IsScheduled() bool    { return b.scheduled }
-func (b *build) IsDrained() bool      { return b.drained }
-func (b *build) IsRunning() bool      { return !b.completed }
-func (b *build) IsAborted() bool      { return b.aborted }
-func (b *build) IsCompleted() bool    { return b.completed }
+func (b *build) CreateTime() time.Time { return b.createTime }
+func (b *build) StartTime() time.Time  { return b.startTime }
+func (b *build) EndTime() time.Time    { return b.endTime }
+func (b *build) ReapTime() time.Time   { return b.reapTime }
+func (b *build) Status() BuildStatus   { return b.status }
+func (b *build) IsScheduled() bool     { return b.scheduled }
+func (b *build) IsDrained() bool       { return b.drained }
+func (b *build) IsRunning() bool       { return !b.completed }
+func (b *build) IsAborted() bool       { return b.aborted }
+func (b *build) IsPaused() bool        { return b.paused }
+func (b *build) RerunOf() int          { return b.rerunOf }
+func (b *build) AutoRetryAttempt() int { return b.autoRetry }
+func (b *build) IsCompleted() bool     { return b.completed }
+
+func (b *build) ErrorCode() atc.ErrorCode { return b.errorCode }
+
+func (b *build) SchedulableTime() time.Time { return b.schedulableTime }
+
+func (b *build) Token() string                     { return b.token }
+func (b *build) Annotations() atc.BuildAnnotations { return b.annotations }
+func (b *build) InputOverrides() atc.InputVersionOverrides {
+	return b.inputOverrides
+}
+func (b *build) InputFallbacks() atc.InputFallbacks {
+	return b.inputFallbacks
+}
+func (b *build) ImageOverrides() atc.TaskImageOverrides {
+	return b.imageOverrides
+}
+func (b *build) InputArtifacts() atc.InputArtifacts {
+	return b.inputArtifacts
+}
+func (b *build) WorkerOverrides() atc.StepWorkerOverrides {
+	return b.workerOverrides
+}
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -323,12 +415,22 @@ func (b *build) Finish(status BuildStatus) error {
 
 	var endTime time.Time
 
+	// the plans of job builds which fail or error are kept once they finish,
+	// as they are re-run and retried from them, until a later build of the
+	// job succeeds (see ClearSupersededPlans)
+	keptPlan, keptNonce := sq.Expr("NULL"), sq.Expr("NULL")
+	if status == BuildStatusFailed || status == BuildStatusErrored {
+		keptPlan = sq.Expr("CASE WHEN job_id IS NULL THEN NULL ELSE private_plan END")
+		keptNonce = sq.Expr("CASE WHEN job_id IS NULL THEN NULL ELSE nonce END")
+	}
+
 	err = psql.Update("builds").
 		Set("status", status).
 		Set("end_time", sq.Expr("now()")).
 		Set("completed", true).
-		Set("private_plan", nil).
-		Set("nonce", nil).
+		Set("paused", false).
+		Set("private_plan", keptPlan).
+		Set("nonce", keptNonce).
 		Where(sq.Eq{"id": b.id}).
 		Suffix("RETURNING end_time").
 		RunWith(tx).
@@ -338,7 +440,92 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	err = b.saveEvent(tx, event.Status{
+	// any steps still in pools are no longer members once the build is done
+	_, err = psql.Delete("build_concurrency_pool_claims").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = b.finish(tx, status, endTime)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
+// ErrorPending errors the build with the given code, saving an error event
+// explaining why, unless it has started or finished in the meantime. It
+// returns whether the build was errored.
+func (b *build) ErrorPending(code atc.ErrorCode, message string) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var endTime time.Time
+
+	err = psql.Update("builds").
+		Set("status", BuildStatusErrored).
+		Set("error_code", code).
+		Set("end_time", sq.Expr("now()")).
+		Set("completed", true).
+		Set("paused", false).
+		Set("private_plan", nil).
+		Set("nonce", nil).
+		Where(sq.Eq{
+			"id":     b.id,
+			"status": BuildStatusPending,
+		}).
+		Suffix("RETURNING end_time").
+		RunWith(tx).
+		QueryRow().
+		Scan(&endTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = b.saveEvent(tx, event.Error{
+		Message: message,
+		Time:    endTime.Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = b.finish(tx, BuildStatusErrored, endTime)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	b.status = BuildStatusErrored
+	b.errorCode = code
+
+	return true, b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
+// finish does the bookkeeping for the build having been marked as completed
+// with the given status: its final event, and its job's builds.
+func (b *build) finish(tx Tx, status BuildStatus, endTime time.Time) error {
+	err := b.saveEvent(tx, event.Status{
 		Status: atc.BuildStatus(status),
 		Time:   endTime.Unix(),
 	})
@@ -387,32 +574,205 @@ func (b *build) Finish(status BuildStatus) error {
 		}
 	}
 
-	err = tx.Commit()
+	return nil
+}
+
+func (b *build) SetDrained(drained bool) error {
+	_, err := psql.Update("builds").
+		Set("drained", drained).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+
+	if err == nil {
+		b.drained = drained
+	}
+	return err
+}
+
+// SetPaused pauses or resumes the build. A paused build finishes the step it
+// is running, then holds before running the next one until it's resumed.
+func (b *build) SetPaused(paused bool) error {
+	_, err := psql.Update("builds").
+		Set("paused", paused).
+		Where(sq.Eq{
+			"id":        b.id,
+			"completed": false,
+		}).
+		RunWith(b.conn).
+		Exec()
 	if err != nil {
 		return err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	b.paused = paused
+
+	return b.conn.Bus().Notify(buildPauseChannel(b.id))
+}
+
+// Paused returns whether the build is currently paused, rather than whether
+// it was when it was loaded.
+func (b *build) Paused() (bool, error) {
+	var paused bool
+	err := psql.Select("paused").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&paused)
 	if err != nil {
-		return err
+		if err == sql.ErrNoRows {
+			return false, ErrBuildDisappeared
+		}
+
+		return false, err
 	}
 
-	return nil
+	return paused, nil
 }
 
-func (b *build) SetDrained(drained bool) error {
+// SaveErrorCode records the kind of failure which errored the build.
+func (b *build) SaveErrorCode(code atc.ErrorCode) error {
 	_, err := psql.Update("builds").
-		Set("drained", drained).
+		Set("error_code", code).
 		Where(sq.Eq{"id": b.id}).
 		RunWith(b.conn).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	if err == nil {
-		b.drained = drained
+	b.errorCode = code
+
+	return nil
+}
+
+// ResumeNotifier returns a Notifier that can be watched for when the build
+// may have been resumed. It's also notified when the build is paused, so
+// whether it's paused should be checked again on each notification.
+func (b *build) ResumeNotifier() (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildPauseChannel(b.id), func() (bool, error) {
+		paused, err := b.Paused()
+		return !paused, err
+	})
+}
+
+// SaveCheckpoint saves how far the build got before failing, for re-running
+// it from there.
+func (b *build) SaveCheckpoint(checkpoint atc.BuildCheckpoint) error {
+	payload, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
 	}
+
+	_, err = psql.Update("builds").
+		Set("checkpoint", string(payload)).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
 	return err
 }
 
+// Checkpoint returns the checkpoint saved when the build failed, if it got
+// past any of its steps.
+func (b *build) Checkpoint() (atc.BuildCheckpoint, bool, error) {
+	var payload sql.NullString
+	err := psql.Select("checkpoint").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.BuildCheckpoint{}, false, ErrBuildDisappeared
+		}
+
+		return atc.BuildCheckpoint{}, false, err
+	}
+
+	if !payload.Valid {
+		return atc.BuildCheckpoint{}, false, nil
+	}
+
+	var checkpoint atc.BuildCheckpoint
+	err = json.Unmarshal([]byte(payload.String), &checkpoint)
+	if err != nil {
+		return atc.BuildCheckpoint{}, false, err
+	}
+
+	return checkpoint, true, nil
+}
+
+// Annotate merges the given annotations into the build's, removing those
+// given with an empty value.
+func (b *build) Annotate(annotations atc.BuildAnnotations) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var payload sql.NullString
+	err = psql.Select("annotations").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBuildDisappeared
+		}
+		return err
+	}
+
+	merged := atc.BuildAnnotations{}
+	if payload.Valid {
+		err = json.Unmarshal([]byte(payload.String), &merged)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key, value := range annotations {
+		if value == "" {
+			delete(merged, key)
+		} else {
+			merged[key] = value
+		}
+	}
+
+	if len(merged) > atc.MaxBuildAnnotations {
+		return ErrTooManyBuildAnnotations
+	}
+
+	mergedPayload, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("annotations", mergedPayload).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.annotations = merged
+
+	return nil
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -472,6 +832,43 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// SetSchedulable records whether the pending build is held only by something
+// other than the scheduler's own limits, e.g. inputs which can't be resolved
+// because no worker can check their resources. The build keeps the time it
+// first became schedulable until it is held by a limit again.
+func (b *build) SetSchedulable(schedulable bool) error {
+	query := psql.Update("builds").
+		Where(sq.Eq{"id": b.id})
+
+	if schedulable {
+		query = query.
+			Set("schedulable_time", sq.Expr("now()")).
+			Where(sq.Eq{"schedulable_time": nil}).
+			Suffix("RETURNING schedulable_time")
+	} else {
+		query = query.
+			Set("schedulable_time", nil).
+			Suffix("RETURNING schedulable_time")
+	}
+
+	var schedulableTime pq.NullTime
+	err := query.
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&schedulableTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		return err
+	}
+
+	b.schedulableTime = schedulableTime.Time
+
+	return nil
+}
+
 func (b *build) Schedule() (bool, error) {
 	result, err := psql.Update("builds").
 		Set("scheduled", true).
@@ -490,6 +887,218 @@ func (b *build) Schedule() (bool, error) {
 	return rows == 1, nil
 }
 
+// ClaimConcurrencyPools makes the build a member of the given pools, unless
+// any of them already has as many running members among the team's builds
+// and steps as the team allows. Pools which the team has not configured are
+// not limited. A build stays a member of its pools until it completes, or
+// until it releases them.
+func (b *build) ClaimConcurrencyPools(pools []string) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	limits, err := lockConcurrencyPools(tx, b.teamID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, pool := range pools {
+		limit, found := limits[pool]
+		if !found {
+			continue
+		}
+
+		members, err := concurrencyPoolMembers(tx, b.teamID, pool, b.id, "")
+		if err != nil {
+			return false, err
+		}
+
+		if members >= limit {
+			return false, nil
+		}
+	}
+
+	_, err = psql.Update("builds").
+		Set("concurrency_pools", pq.Array(pools)).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseConcurrencyPools gives up the build's membership of its pools, for
+// builds which claimed them but did not go on to be scheduled.
+func (b *build) ReleaseConcurrencyPools() error {
+	_, err := psql.Update("builds").
+		Set("concurrency_pools", nil).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// ClaimStepConcurrencyPools makes the build's step with the given plan ID a
+// member of the given pools, limited like ClaimConcurrencyPools. Pools which
+// the build is already a member of are left out, as the step runs as part
+// of the build's membership. The step stays a member until it releases them
+// or the build completes.
+func (b *build) ClaimStepConcurrencyPools(planID atc.PlanID, pools []string) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	limits, err := lockConcurrencyPools(tx, b.teamID)
+	if err != nil {
+		return false, err
+	}
+
+	var buildPools []string
+	err = psql.Select("concurrency_pools").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		QueryRow().
+		Scan(pq.Array(&buildPools))
+	if err != nil {
+		return false, err
+	}
+
+	member := map[string]bool{}
+	for _, pool := range buildPools {
+		member[pool] = true
+	}
+
+	for _, pool := range pools {
+		if member[pool] {
+			continue
+		}
+
+		limit, found := limits[pool]
+		if found {
+			members, err := concurrencyPoolMembers(tx, b.teamID, pool, b.id, planID)
+			if err != nil {
+				return false, err
+			}
+
+			if members >= limit {
+				return false, nil
+			}
+		}
+
+		_, err = psql.Insert("build_concurrency_pool_claims").
+			Columns("build_id", "plan_id", "pool").
+			Values(b.id, string(planID), pool).
+			Suffix("ON CONFLICT DO NOTHING").
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseStepConcurrencyPools gives up the membership of the pools claimed
+// for the build's step with the given plan ID.
+func (b *build) ReleaseStepConcurrencyPools(planID atc.PlanID) error {
+	_, err := psql.Delete("build_concurrency_pool_claims").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+		}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// lockConcurrencyPools returns the limits of the team's pools, locking the
+// team so that claims are serialized across its pipelines.
+func lockConcurrencyPools(tx Tx, teamID int) (map[string]int, error) {
+	var payload sql.NullString
+	err := psql.Select("concurrency_pools").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits map[string]int
+	if payload.Valid {
+		err = json.Unmarshal([]byte(payload.String), &limits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return limits, nil
+}
+
+// concurrencyPoolMembers counts the running members of the team's pool: its
+// builds which are members for as long as they run, and the steps which are
+// members while they run. The given build, or the given step of it, is not
+// counted, so that claims may be repeated when a build is resumed.
+func concurrencyPoolMembers(tx Tx, teamID int, pool string, buildID int, planID atc.PlanID) (int, error) {
+	var builds int
+	err := psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.And{
+			sq.Eq{"team_id": teamID, "completed": false},
+			sq.NotEq{"id": buildID},
+			sq.Expr("? = ANY(concurrency_pools)", pool),
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&builds)
+	if err != nil {
+		return 0, err
+	}
+
+	var steps int
+	err = psql.Select("COUNT(*)").
+		From("build_concurrency_pool_claims c").
+		Join("builds b ON b.id = c.build_id").
+		Where(sq.And{
+			sq.Eq{"b.team_id": teamID, "b.completed": false, "c.pool": pool},
+			sq.Or{
+				sq.NotEq{"c.build_id": buildID},
+				sq.NotEq{"c.plan_id": string(planID)},
+			},
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&steps)
+	if err != nil {
+		return 0, err
+	}
+
+	return builds + steps, nil
+}
+
 func (b *build) Pipeline() (Pipeline, bool, error) {
 	if b.pipelineID == 0 {
 		return nil, false, nil
@@ -529,6 +1138,23 @@ func (b *build) SaveImageResourceVersion(rc UsedResourceCache) error {
 	return nil
 }
 
+// SaveResourceFetch records how long a get step of the build took to fetch
+// a resource of the given type, for gauging the health of the type.
+func (b *build) SaveResourceFetch(resourceType string, duration time.Duration, succeeded bool) error {
+	var pipelineID sql.NullInt64
+	if b.pipelineID != 0 {
+		pipelineID = sql.NullInt64{Int64: int64(b.pipelineID), Valid: true}
+	}
+
+	_, err := psql.Insert("resource_fetches").
+		Columns("team_id", "pipeline_id", "type", "duration_ms", "succeeded").
+		Values(b.teamID, pipelineID, resourceType, duration.Milliseconds(), succeeded).
+		RunWith(b.conn).
+		Exec()
+
+	return err
+}
+
 func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
 	lock, acquired, err := b.lockFactory.Acquire(
 		logger.Session("lock", lager.Data{
@@ -745,14 +1371,9 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newBuildEventSource(
 		b.id,
-		table,
+		b.eventsTable(),
 		b.conn,
 		notifier,
 		from,
@@ -780,6 +1401,57 @@ func (b *build) SaveEvent(event atc.Event) error {
 	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
+func (b *build) SavedEvents(types ...atc.EventType) ([]SavedEvent, error) {
+	typeNames := make([]string, len(types))
+	for i, t := range types {
+		typeNames[i] = string(t)
+	}
+
+	rows, err := psql.Select("event_id", "type", "version", "payload", "compressed_payload").
+		From(b.eventsTable()).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"type":     typeNames,
+		}).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	savedEvents := []SavedEvent{}
+	for rows.Next() {
+		var (
+			id         int
+			t, v       string
+			p          sql.NullString
+			compressed []byte
+		)
+
+		err = rows.Scan(&id, &t, &v, &p, &compressed)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := buildEventPayload(p, compressed)
+		if err != nil {
+			return nil, err
+		}
+
+		ev, err := event.ParseEvent(atc.EventVersion(v), atc.EventType(t), payload)
+		if err != nil {
+			return nil, err
+		}
+
+		savedEvents = append(savedEvents, SavedEvent{ID: id, Event: ev})
+	}
+
+	return savedEvents, nil
+}
+
 func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 
 	artifact := artifact{
@@ -950,11 +1622,35 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 		return err
 	}
 
+	fallbacks := atc.InputFallbacks{}
 	for _, input := range inputs {
 		err = b.saveInputTx(tx, b.id, input)
 		if err != nil {
 			return err
 		}
+
+		if input.FallbackFrom != nil {
+			fallbacks[input.Name] = input.FallbackFrom
+		}
+	}
+
+	var fallbacksPayload interface{}
+	if len(fallbacks) > 0 {
+		payload, err := json.Marshal(fallbacks)
+		if err != nil {
+			return err
+		}
+
+		fallbacksPayload = string(payload)
+	}
+
+	_, err = psql.Update("builds").
+		Set("input_fallbacks", fallbacksPayload).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
 	}
 
 	if b.pipelineID != 0 {
@@ -983,7 +1679,7 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			AND i.build_id < builds.id
 		)`
 
-	rows, err := psql.Select("inputs.name", "resources.id", "versions.version", firstOccurrence).
+	rows, err := psql.Select("inputs.name", "resources.id", "versions.version", "versions.metadata", firstOccurrence).
 		From("resource_config_versions versions, build_resource_config_version_inputs inputs, builds, resources").
 		Where(sq.Eq{"builds.id": b.id}).
 		Where(sq.NotEq{"versions.check_order": 0}).
@@ -1013,10 +1709,12 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			firstOccurrence bool
 			versionBlob     string
 			version         atc.Version
+			metadataBlob    sql.NullString
+			metadata        ResourceConfigMetadataFields
 			resourceID      int
 		)
 
-		err = rows.Scan(&inputName, &resourceID, &versionBlob, &firstOccurrence)
+		err = rows.Scan(&inputName, &resourceID, &versionBlob, &metadataBlob, &firstOccurrence)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1026,15 +1724,23 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			return nil, nil, err
 		}
 
+		if metadataBlob.Valid {
+			err = json.Unmarshal([]byte(metadataBlob.String), &metadata)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		inputs = append(inputs, BuildInput{
 			Name:            inputName,
 			Version:         version,
 			ResourceID:      resourceID,
+			Metadata:        metadata,
 			FirstOccurrence: firstOccurrence,
 		})
 	}
 
-	rows, err = psql.Select("outputs.name", "versions.version").
+	rows, err = psql.Select("outputs.name", "versions.version", "versions.metadata").
 		From("resource_config_versions versions, build_resource_config_version_outputs outputs, builds, resources").
 		Where(sq.Eq{"builds.id": b.id}).
 		Where(sq.NotEq{"versions.check_order": 0}).
@@ -1053,12 +1759,14 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 
 	for rows.Next() {
 		var (
-			outputName  string
-			versionBlob string
-			version     atc.Version
+			outputName   string
+			versionBlob  string
+			version      atc.Version
+			metadataBlob sql.NullString
+			metadata     ResourceConfigMetadataFields
 		)
 
-		err := rows.Scan(&outputName, &versionBlob)
+		err := rows.Scan(&outputName, &versionBlob, &metadataBlob)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1068,9 +1776,17 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			return nil, nil, err
 		}
 
+		if metadataBlob.Valid {
+			err = json.Unmarshal([]byte(metadataBlob.String), &metadata)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		outputs = append(outputs, BuildOutput{
-			Name:    outputName,
-			Version: version,
+			Name:     outputName,
+			Version:  version,
+			Metadata: metadata,
 		})
 	}
 
@@ -1109,12 +1825,16 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		jobID, pipelineID                                      sql.NullInt64
 		schema, privatePlan, jobName, pipelineName, publicPlan sql.NullString
 		createTime, startTime, endTime, reapTime               pq.NullTime
-		nonce                                                  sql.NullString
-		drained, aborted, completed                            bool
+		schedulableTime                                        pq.NullTime
+		nonce, token, annotations, inputOverrides, errorCode   sql.NullString
+		inputFallbacks, imageOverrides, inputArtifacts         sql.NullString
+		workerOverrides                                        sql.NullString
+		rerunOf                                                sql.NullInt64
+		drained, aborted, completed, paused                    bool
 		status                                                 string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed, &token, &annotations, &inputOverrides, &inputFallbacks, &imageOverrides, &inputArtifacts, &workerOverrides, &paused, &rerunOf, &b.autoRetry, &errorCode, &schedulableTime)
 	if err != nil {
 		return err
 	}
@@ -1129,9 +1849,62 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.startTime = startTime.Time
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
+	b.schedulableTime = schedulableTime.Time
 	b.drained = drained
 	b.aborted = aborted
 	b.completed = completed
+	b.paused = paused
+	b.rerunOf = int(rerunOf.Int64)
+	b.errorCode = atc.ErrorCode(errorCode.String)
+	b.token = token.String
+
+	b.annotations = nil
+	if annotations.Valid {
+		err = json.Unmarshal([]byte(annotations.String), &b.annotations)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.inputOverrides = nil
+	if inputOverrides.Valid {
+		err = json.Unmarshal([]byte(inputOverrides.String), &b.inputOverrides)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.inputFallbacks = nil
+	if inputFallbacks.Valid {
+		err = json.Unmarshal([]byte(inputFallbacks.String), &b.inputFallbacks)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.imageOverrides = nil
+	if imageOverrides.Valid {
+		err = json.Unmarshal([]byte(imageOverrides.String), &b.imageOverrides)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.inputArtifacts = nil
+	if inputArtifacts.Valid {
+		err = json.Unmarshal([]byte(inputArtifacts.String), &b.inputArtifacts)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.workerOverrides = nil
+	if workerOverrides.Valid {
+		err = json.Unmarshal([]byte(workerOverrides.String), &b.workerOverrides)
+		if err != nil {
+			return err
+		}
+	}
 
 	var (
 		noncense      *string
@@ -1171,21 +1944,37 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 		return err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	compressed, err := compressBuildEventPayload(payload)
+	if err != nil {
+		return err
 	}
-	_, err = psql.Insert(table).
-		Columns("event_id", "build_id", "type", "version", "payload").
-		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
+
+	_, err = psql.Insert(b.eventsTable()).
+		Columns("event_id", "build_id", "type", "version", "compressed_payload").
+		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), compressed).
 		RunWith(tx).
 		Exec()
 	return err
 }
 
+func (b *build) eventsTable() string {
+	if b.pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
+}
+
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
+	token, err := newBuildToken()
+	if err != nil {
+		return err
+	}
+
+	vals["token"] = token
+
 	var buildID int
-	err := psql.Insert("builds").
+	err = psql.Insert("builds").
 		SetMap(vals).
 		Suffix("RETURNING id").
 		RunWith(tx).
@@ -1208,6 +1997,19 @@ func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 	return createBuildEventSeq(tx, buildID)
 }
 
+// newBuildToken generates the token given to a build's tasks for calling
+// back to the API on the build's behalf.
+func newBuildToken() (string, error) {
+	token := make([]byte, 32)
+
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
 func buildStartedChannel() string {
 	return fmt.Sprintf("build_started")
 }
@@ -1220,6 +2022,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildPauseChannel(buildID int) string {
+	return fmt.Sprintf("build_pause_%d", buildID)
+}
+
 func updateNextBuildForJob(tx Tx, jobID int) error {
 	_, err := tx.Exec(`
 		UPDATE jobs AS j
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/DataDog/zstd"
+	"github.com/lib/pq"
+)
+
+// Build events are stored with their payloads compressed with zstd, as the
+// build event tables are typically the largest in the database. Events saved
+// before payloads were compressed are compressed by the compress-build-events
+// backfill; until then their payload is read as it is.
+
+func compressBuildEventPayload(payload []byte) ([]byte, error) {
+	return zstd.Compress(nil, payload)
+}
+
+// buildEventPayload returns the payload of an event stored either compressed
+// or as it is.
+func buildEventPayload(payload sql.NullString, compressed []byte) ([]byte, error) {
+	if compressed == nil {
+		return []byte(payload.String), nil
+	}
+
+	return zstd.Decompress(nil, compressed)
+}
+
+// compressBuildEventsBackfill compresses the payloads of the events of the
+// builds it visits which were saved before payloads were compressed.
+var compressBuildEventsBackfill = Backfill{
+	Name:  "compress-build-events",
+	Table: "builds",
+	Batch: compressBuildEvents,
+}
+
+type uncompressedBuildEvent struct {
+	table   string
+	buildID int
+	eventID int
+	payload string
+}
+
+func compressBuildEvents(tx Tx, fromID int, toID int) error {
+	rows, err := tx.Query(`
+		SELECT tableoid::regclass::text, build_id, event_id, payload
+		FROM build_events
+		WHERE build_id > $1
+		AND build_id <= $2
+		AND compressed_payload IS NULL
+	`, fromID, toID)
+	if err != nil {
+		return err
+	}
+
+	var events []uncompressedBuildEvent
+	for rows.Next() {
+		var event uncompressedBuildEvent
+		err = rows.Scan(&event.table, &event.buildID, &event.eventID, &event.payload)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		events = append(events, event)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		compressed, err := compressBuildEventPayload([]byte(event.payload))
+		if err != nil {
+			return err
+		}
+
+		// update the table the event is in rather than build_events so that
+		// only its indexes are used
+		_, err = tx.Exec(`
+			UPDATE `+pq.QuoteIdentifier(event.table)+`
+			SET compressed_payload = $3, payload = NULL
+			WHERE build_id = $1
+			AND event_id = $2
+		`, event.buildID, event.eventID, compressed)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"sync"
@@ -110,7 +111,7 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 
 		rows, err := source.conn.Query(`
-			SELECT type, version, payload
+			SELECT type, version, payload, compressed_payload
 			FROM `+source.table+`
 			WHERE build_id = $1
 			ORDER BY event_id ASC
@@ -130,8 +131,24 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 
 			cursor++
 
-			var t, v, p string
-			err := rows.Scan(&t, &v, &p)
+			var (
+				t, v       string
+				p          sql.NullString
+				compressed []byte
+			)
+
+			err := rows.Scan(&t, &v, &p, &compressed)
+			if err != nil {
+				_ = rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			// payloads are only decompressed as they're read, a batch at a
+			// time
+			payload, err := buildEventPayload(p, compressed)
 			if err != nil {
 				_ = rows.Close()
 
@@ -140,7 +157,7 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 				return
 			}
 
-			data := json.RawMessage(p)
+			data := json.RawMessage(payload)
 
 			ev := event.Envelope{
 				Data:    &data,
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
@@ -19,8 +20,11 @@ type BuildFactory interface {
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	GetStalePendingBuilds(maxPending time.Duration) ([]Build, error)
+	GetAutoRetryableBuilds(codes []atc.ErrorCode, maxAttempts int) ([]Build, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
+	ClearSupersededPlans() error
 }
 
 type buildFactory struct {
@@ -64,7 +68,8 @@ func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pa
 		Where(sq.Or{
 			sq.Eq{"p.public": true},
 			sq.Eq{"t.name": teamNames},
-		})
+		}).
+		Where(sq.Eq{"t.deleted_at": nil})
 
 	if page.UseDate {
 		return getBuildsWithDates(newBuildsQuery, minMaxIdQuery, page, f.conn,
@@ -75,17 +80,23 @@ func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pa
 }
 
 func (f *buildFactory) AllBuilds(page Page) ([]Build, Pagination, error) {
+	newBuildsQuery := buildsQuery.
+		Where(sq.Eq{"t.deleted_at": nil})
+
 	if page.UseDate {
-		return getBuildsWithDates(buildsQuery, minMaxIdQuery, page, f.conn,
+		return getBuildsWithDates(newBuildsQuery, minMaxIdQuery, page, f.conn,
 			f.lockFactory)
 	}
-	return getBuildsWithPagination(buildsQuery, minMaxIdQuery,
+	return getBuildsWithPagination(newBuildsQuery, minMaxIdQuery,
 		page, f.conn, f.lockFactory)
 }
 
 func (f *buildFactory) PublicBuilds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(
-		buildsQuery.Where(sq.Eq{"p.public": true}), minMaxIdQuery,
+		buildsQuery.Where(sq.Eq{
+			"p.public":     true,
+			"t.deleted_at": nil,
+		}), minMaxIdQuery,
 		page, f.conn, f.lockFactory)
 }
 
@@ -109,6 +120,26 @@ func (f *buildFactory) MarkNonInterceptibleBuilds() error {
 	return err
 }
 
+// ClearSupersededPlans forgets the kept plans of failed and errored job
+// builds once a later build of the job has succeeded, as there's no longer
+// any point in re-running them.
+func (f *buildFactory) ClearSupersededPlans() error {
+	_, err := psql.Update("builds b").
+		Set("private_plan", nil).
+		Set("nonce", nil).
+		Where(sq.Eq{
+			"completed": true,
+		}).
+		Where(sq.NotEq{
+			"job_id":       nil,
+			"private_plan": nil,
+		}).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM builds later WHERE later.job_id = b.job_id AND later.id > b.id AND later.status = ?)", string(BuildStatusSucceeded))).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
 func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
 		"b.completed": true,
@@ -126,6 +157,45 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// GetStalePendingBuilds returns the pending builds which have been
+// schedulable for longer than the given duration without being started.
+// Builds held by the scheduler's limits, e.g. serial groups or concurrency
+// pools, are not schedulable, and so are not returned.
+func (f *buildFactory) GetStalePendingBuilds(maxPending time.Duration) ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{
+			"b.status": BuildStatusPending,
+		}).
+		Where(sq.NotEq{
+			"b.schedulable_time": nil,
+		}).
+		Where(sq.Expr(fmt.Sprintf("now() - b.schedulable_time > '%d seconds'::interval", int(maxPending.Seconds()))))
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
+// GetAutoRetryableBuilds returns the job builds which errored with one of
+// the given codes and may be retried automatically: those which haven't been
+// retried maxAttempts times already, and which are still the latest build of
+// their job in an unpaused pipeline.
+func (f *buildFactory) GetAutoRetryableBuilds(codes []atc.ErrorCode, maxAttempts int) ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{
+			"b.status":     BuildStatusErrored,
+			"b.error_code": codes,
+			"p.paused":     false,
+		}).
+		Where(sq.NotEq{
+			"b.job_id": nil,
+		}).
+		Where(sq.Lt{
+			"b.auto_retry_attempt": maxAttempts,
+		}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM builds later WHERE later.job_id = b.job_id AND later.id > b.id)"))
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
 func getBuilds(buildsQuery sq.SelectBuilder, conn Conn, lockFactory lock.LockFactory) ([]Build, error) {
 	rows, err := buildsQuery.RunWith(conn).Query()
 	if err != nil {
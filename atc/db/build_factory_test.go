@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -40,6 +42,51 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("ClearSupersededPlans", func() {
+		var failed db.Build
+		var plan atc.Plan
+
+		BeforeEach(func() {
+			var err error
+			failed, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			plan = atc.Plan{ID: "1", Task: &atc.TaskPlan{Name: "some-task"}}
+
+			started, err := failed.Start(plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			Expect(failed.Finish(db.BuildStatusFailed)).To(Succeed())
+		})
+
+		It("keeps the plans of builds which may still be re-run", func() {
+			later, err := defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(later.Finish(db.BuildStatusErrored)).To(Succeed())
+
+			Expect(buildFactory.ClearSupersededPlans()).To(Succeed())
+
+			found, err := failed.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(failed.PrivatePlan()).To(Equal(plan))
+		})
+
+		It("clears the plans of builds followed by a successful build of the job", func() {
+			later, err := defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(later.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			Expect(buildFactory.ClearSupersededPlans()).To(Succeed())
+
+			found, err := failed.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(failed.PrivatePlan()).To(Equal(atc.Plan{}))
+		})
+	})
+
 	Describe("MarkNonInterceptibleBuilds", func() {
 		Context("one-off builds", func() {
 			DescribeTable("completed and within grace period",
@@ -452,4 +499,127 @@ var _ = Describe("BuildFactory", func() {
 			Expect(builds).To(ConsistOf(build1DB, build2DB))
 		})
 	})
+
+	Describe("GetStalePendingBuilds", func() {
+		var pendingBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			pendingBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pendingBuild.SetSchedulable(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			heldBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = heldBuild.SetSchedulable(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = heldBuild.SetSchedulable(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			startedBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = startedBuild.SetSchedulable(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = dbConn.Exec(`UPDATE builds SET create_time = now() - '2 hours'::interval, schedulable_time = schedulable_time - '2 hours'::interval`)
+			Expect(err).NotTo(HaveOccurred())
+
+			recentBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = recentBuild.SetSchedulable(true)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the builds schedulable for longer than the given duration", func() {
+			builds, err := buildFactory.GetStalePendingBuilds(time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(pendingBuild.ID()))
+			Expect(builds[0].SchedulableTime()).To(BeTemporally("~", time.Now().Add(-2*time.Hour), time.Minute))
+		})
+	})
+
+	Describe("GetAutoRetryableBuilds", func() {
+		var erroredBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			erroredBuild, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := erroredBuild.Start(atc.Plan{ID: "some-plan"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			Expect(erroredBuild.SaveErrorCode(atc.ErrorCodeWorkerUnavailable)).To(Succeed())
+			Expect(erroredBuild.Finish(db.BuildStatusErrored)).To(Succeed())
+		})
+
+		It("returns the job builds which errored with one of the codes", func() {
+			builds, err := buildFactory.GetAutoRetryableBuilds(atc.InfrastructureErrorCodes, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(erroredBuild.ID()))
+		})
+
+		It("returns them with the plan they ran", func() {
+			builds, err := buildFactory.GetAutoRetryableBuilds(atc.InfrastructureErrorCodes, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].PrivatePlan()).To(Equal(atc.Plan{ID: "some-plan"}))
+		})
+
+		It("does not return builds which errored with other codes", func() {
+			builds, err := buildFactory.GetAutoRetryableBuilds([]atc.ErrorCode{atc.ErrorCodeImageUnavailable}, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when the build has been retried", func() {
+			var retry db.Build
+
+			BeforeEach(func() {
+				var err error
+				retry, err = defaultJob.AutoRetryBuild(erroredBuild, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return it", func() {
+				builds, err := buildFactory.GetAutoRetryableBuilds(atc.InfrastructureErrorCodes, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+
+			Context("when the retry errors too", func() {
+				BeforeEach(func() {
+					Expect(retry.SaveErrorCode(atc.ErrorCodeVolumeMissing)).To(Succeed())
+					Expect(retry.Finish(db.BuildStatusErrored)).To(Succeed())
+				})
+
+				It("returns the retry until it has been retried the most times", func() {
+					builds, err := buildFactory.GetAutoRetryableBuilds(atc.InfrastructureErrorCodes, 2)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(HaveLen(1))
+					Expect(builds[0].ID()).To(Equal(retry.ID()))
+
+					builds, err = buildFactory.GetAutoRetryableBuilds(atc.InfrastructureErrorCodes, 1)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(BeEmpty())
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . BuildStartBuckets
+
+// BuildStartBuckets are leaky buckets counting build starts across the whole
+// installation and per team. They are kept in the database, so that every
+// ATC counts against the same limits.
+type BuildStartBuckets interface {
+	// Reserve counts a build start of the team, unless either the global or
+	// the team's bucket already holds as many starts as its limit per
+	// minute. A limit of 0 means unlimited.
+	Reserve(teamID int, globalPerMinute int, teamPerMinute int) (bool, error)
+
+	// Refund takes back a build start of the team counted by Reserve.
+	Refund(teamID int) error
+}
+
+type buildStartBuckets struct {
+	conn Conn
+}
+
+func NewBuildStartBuckets(conn Conn) BuildStartBuckets {
+	return &buildStartBuckets{
+		conn: conn,
+	}
+}
+
+func (b *buildStartBuckets) Reserve(teamID int, globalPerMinute int, teamPerMinute int) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	// the global bucket is always locked before the team's, so that
+	// concurrent reservations can't deadlock
+	globalLevel, err := leakBuildStartBucket(tx, sql.NullInt64{}, globalPerMinute)
+	if err != nil {
+		return false, err
+	}
+
+	teamLevel, err := leakBuildStartBucket(tx, sql.NullInt64{Int64: int64(teamID), Valid: true}, teamPerMinute)
+	if err != nil {
+		return false, err
+	}
+
+	if buildStartBucketFull(globalLevel, globalPerMinute) || buildStartBucketFull(teamLevel, teamPerMinute) {
+		// keep what has leaked
+		return false, tx.Commit()
+	}
+
+	_, err = psql.Update("build_start_buckets").
+		Set("level", sq.Expr("level + 1")).
+		Where(sq.Or{
+			sq.Eq{"team_id": nil},
+			sq.Eq{"team_id": teamID},
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *buildStartBuckets) Refund(teamID int) error {
+	_, err := psql.Update("build_start_buckets").
+		Set("level", sq.Expr("GREATEST(0, level - 1)")).
+		Where(sq.Or{
+			sq.Eq{"team_id": nil},
+			sq.Eq{"team_id": teamID},
+		}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// leakBuildStartBucket drains the bucket of the given team, or the global
+// bucket if no team is given, by as many starts as the limit allows for the
+// time since it was last drained, and returns its level. The bucket is
+// created if it doesn't exist yet, and stays locked until the transaction
+// ends.
+func leakBuildStartBucket(tx Tx, teamID sql.NullInt64, perMinute int) (float64, error) {
+	var level float64
+	err := psql.Insert("build_start_buckets").
+		Columns("team_id").
+		Values(teamID).
+		Suffix(`
+			ON CONFLICT ((COALESCE(team_id, 0))) DO UPDATE SET
+				level = CASE
+					WHEN ?::integer = 0 THEN 0
+					ELSE GREATEST(0, build_start_buckets.level - EXTRACT(EPOCH FROM now() - build_start_buckets.last_leak) / 60 * ?::integer)
+				END,
+				last_leak = now()
+			RETURNING level
+		`, perMinute, perMinute).
+		RunWith(tx).
+		QueryRow().
+		Scan(&level)
+	if err != nil {
+		return 0, err
+	}
+
+	return level, nil
+}
+
+func buildStartBucketFull(level float64, perMinute int) bool {
+	return perMinute > 0 && level+1 > float64(perMinute)
+}
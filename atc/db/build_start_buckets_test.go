@@ -0,0 +1,99 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildStartBuckets", func() {
+	var (
+		buckets   db.BuildStartBuckets
+		otherTeam db.Team
+	)
+
+	BeforeEach(func() {
+		buckets = db.NewBuildStartBuckets(dbConn)
+
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	reserve := func(teamID int, globalPerMinute int, teamPerMinute int) bool {
+		reserved, err := buckets.Reserve(teamID, globalPerMinute, teamPerMinute)
+		Expect(err).ToNot(HaveOccurred())
+		return reserved
+	}
+
+	// backdate pretends the buckets were last drained the given number of
+	// seconds ago
+	backdate := func(seconds int) {
+		_, err := dbConn.Exec(`UPDATE build_start_buckets SET last_leak = now() - $1 * interval '1 second'`, seconds)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Context("with a global limit", func() {
+		It("reserves build starts of any team up to the limit", func() {
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(otherTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeFalse())
+			Expect(reserve(otherTeam.ID(), 2, 0)).To(BeFalse())
+		})
+
+		It("reserves more build starts as time passes", func() {
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeFalse())
+
+			backdate(30)
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeFalse())
+
+			backdate(60)
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeFalse())
+		})
+
+		It("reserves a refunded build start again", func() {
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 0)).To(BeTrue())
+
+			Expect(buckets.Refund(defaultTeam.ID())).To(Succeed())
+			Expect(reserve(otherTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(otherTeam.ID(), 2, 0)).To(BeFalse())
+		})
+	})
+
+	Context("with a team limit", func() {
+		It("limits only that team's build starts", func() {
+			Expect(reserve(defaultTeam.ID(), 0, 1)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 0, 1)).To(BeFalse())
+
+			Expect(reserve(otherTeam.ID(), 0, 0)).To(BeTrue())
+			Expect(reserve(otherTeam.ID(), 0, 0)).To(BeTrue())
+
+			backdate(60)
+			Expect(reserve(defaultTeam.ID(), 0, 1)).To(BeTrue())
+		})
+
+		It("reserves a refunded build start of the team again", func() {
+			Expect(reserve(defaultTeam.ID(), 0, 1)).To(BeTrue())
+
+			Expect(buckets.Refund(defaultTeam.ID())).To(Succeed())
+			Expect(reserve(defaultTeam.ID(), 0, 1)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 0, 1)).To(BeFalse())
+		})
+
+		It("does not count build starts refused by the team limit against the global limit", func() {
+			Expect(reserve(defaultTeam.ID(), 2, 1)).To(BeTrue())
+			Expect(reserve(defaultTeam.ID(), 2, 1)).To(BeFalse())
+			Expect(reserve(defaultTeam.ID(), 2, 1)).To(BeFalse())
+
+			Expect(reserve(otherTeam.ID(), 2, 0)).To(BeTrue())
+			Expect(reserve(otherTeam.ID(), 2, 0)).To(BeFalse())
+		})
+	})
+})
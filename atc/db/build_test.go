@@ -1,8 +1,10 @@
 package db_test
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -71,6 +73,88 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Pause", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is not paused in the beginning", func() {
+			Expect(build.IsPaused()).To(BeFalse())
+
+			paused, err := build.Paused()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paused).To(BeFalse())
+		})
+
+		It("notifies when the build is resumed", func() {
+			Expect(build.SetPaused(true)).To(Succeed())
+
+			paused, err := build.Paused()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paused).To(BeTrue())
+
+			notifier, err := build.ResumeNotifier()
+			Expect(err).NotTo(HaveOccurred())
+			defer notifier.Close()
+
+			Consistently(notifier.Notify()).ShouldNot(Receive())
+
+			Expect(build.SetPaused(false)).To(Succeed())
+
+			Eventually(notifier.Notify()).Should(Receive())
+
+			_, err = build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.IsPaused()).To(BeFalse())
+		})
+
+		It("is no longer paused once the build finishes", func() {
+			Expect(build.SetPaused(true)).To(Succeed())
+			Expect(build.Finish(db.BuildStatusAborted)).To(Succeed())
+
+			_, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.IsPaused()).To(BeFalse())
+		})
+	})
+
+	Describe("Checkpoint", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has no checkpoint in the beginning", func() {
+			_, found, err := build.Checkpoint()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the saved checkpoint", func() {
+			checkpoint := atc.BuildCheckpoint{
+				PlanID: "some-plan-id",
+				Artifacts: []atc.CheckpointArtifact{
+					{Name: "some-output", ArtifactID: 42},
+					{Name: "some-input", GetPlanID: "some-get", Version: atc.Version{"ref": "abc"}},
+				},
+			}
+
+			Expect(build.SaveCheckpoint(checkpoint)).To(Succeed())
+
+			saved, found, err := build.Checkpoint()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(saved).To(Equal(checkpoint))
+		})
+	})
+
 	Describe("Start", func() {
 		var err error
 		var started bool
@@ -176,6 +260,10 @@ var _ = Describe("Build", func() {
 			build, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
+			started, err := build.Start(atc.Plan{ID: "1", Task: &atc.TaskPlan{Name: "some-task"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
 			err = build.Finish(db.BuildStatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -211,6 +299,54 @@ var _ = Describe("Build", func() {
 			Expect(build.PrivatePlan()).To(Equal(atc.Plan{}))
 		})
 
+		Context("when the build is of a job", func() {
+			var plan atc.Plan
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				plan = atc.Plan{ID: "1", Task: &atc.TaskPlan{Name: "some-task"}}
+
+				started, err := build.Start(plan)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				err = build.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the private plan, so that the build can be rerun", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.PrivatePlan()).To(Equal(plan))
+			})
+		})
+
+		Context("when a build of a job succeeds", func() {
+			BeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := build.Start(atc.Plan{ID: "1", Task: &atc.TaskPlan{Name: "some-task"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("clears out the private plan, as it won't be rerun", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.PrivatePlan()).To(Equal(atc.Plan{}))
+			})
+		})
+
 		It("sets completed to true", func() {
 			Expect(build.IsCompleted()).To(BeFalse())
 			Expect(build.IsRunning()).To(BeTrue())
@@ -223,6 +359,64 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("ErrorPending", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("errors the build with the code, explaining why", func() {
+			errored, err := build.ErrorPending(atc.ErrorCodePendingTimedOut, "pending for too long")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errored).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusErrored))
+			Expect(build.ErrorCode()).To(Equal(atc.ErrorCodePendingTimedOut))
+			Expect(build.IsCompleted()).To(BeTrue())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Error{
+				Message: "pending for too long",
+				Time:    build.EndTime().Unix(),
+			})))
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusErrored,
+				Time:   build.EndTime().Unix(),
+			})))
+		})
+
+		Context("when the build has started", func() {
+			BeforeEach(func() {
+				started, err := build.Start(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+
+			It("leaves it be", func() {
+				errored, err := build.ErrorPending(atc.ErrorCodePendingTimedOut, "pending for too long")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(errored).To(BeFalse())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusStarted))
+				Expect(build.ErrorCode()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Abort", func() {
 		var build db.Build
 		BeforeEach(func() {
@@ -242,6 +436,77 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Token", func() {
+		It("is generated uniquely on creation", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.Token()).NotTo(BeEmpty())
+			Expect(build.Token()).NotTo(Equal(otherBuild.Token()))
+		})
+	})
+
+	Describe("SaveErrorCode", func() {
+		It("saves the code of the failure which errored the build", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.ErrorCode()).To(BeEmpty())
+
+			err = build.SaveErrorCode(atc.ErrorCodeImageUnavailable)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.ErrorCode()).To(Equal(atc.ErrorCodeImageUnavailable))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ErrorCode()).To(Equal(atc.ErrorCodeImageUnavailable))
+		})
+	})
+
+	Describe("Annotate", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Annotate(atc.BuildAnnotations{"coverage": "80%", "ticket": "ABC-1"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the annotations", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Annotations()).To(Equal(atc.BuildAnnotations{"coverage": "80%", "ticket": "ABC-1"}))
+		})
+
+		It("merges with the existing annotations, removing those given an empty value", func() {
+			err := build.Annotate(atc.BuildAnnotations{"coverage": "87%", "ticket": ""})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Annotations()).To(Equal(atc.BuildAnnotations{"coverage": "87%"}))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Annotations()).To(Equal(atc.BuildAnnotations{"coverage": "87%"}))
+		})
+
+		It("refuses to exceed the maximum number of annotations", func() {
+			annotations := atc.BuildAnnotations{}
+			for i := 0; i < atc.MaxBuildAnnotations; i++ {
+				annotations[fmt.Sprintf("key-%d", i)] = "value"
+			}
+
+			err := build.Annotate(annotations)
+			Expect(err).To(Equal(db.ErrTooManyBuildAnnotations))
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			build, err := team.CreateOneOffBuild()
@@ -365,6 +630,104 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SavedEvents", func() {
+		It("returns the events of the given types with their IDs, in order", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "head"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Status{Status: atc.StatusStarted})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.LogTruncated{Size: 6})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "tail"})
+			Expect(err).NotTo(HaveOccurred())
+
+			saved, err := build.SavedEvents(event.EventTypeLog, event.EventTypeLogTruncated)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(HaveLen(3))
+			Expect(saved[0].Event).To(Equal(event.Log{Payload: "head"}))
+			Expect(saved[1].Event).To(Equal(event.LogTruncated{Size: 6}))
+			Expect(saved[2].Event).To(Equal(event.Log{Payload: "tail"}))
+			Expect(saved[0].ID).To(BeNumerically("<", saved[1].ID))
+			Expect(saved[1].ID).To(BeNumerically("<", saved[2].ID))
+		})
+	})
+
+	Describe("event compression", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves events with their payloads compressed", func() {
+			err := build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			var payload sql.NullString
+			var compressed []byte
+			err = dbConn.QueryRow(`SELECT payload, compressed_payload FROM build_events WHERE build_id = $1`, build.ID()).Scan(&payload, &compressed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.Valid).To(BeFalse())
+			Expect(compressed).NotTo(BeEmpty())
+		})
+
+		Context("when events were saved before payloads were compressed", func() {
+			BeforeEach(func() {
+				oldLog := event.Log{Payload: "old log"}
+				payload, err := json.Marshal(oldLog)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = dbConn.Exec(fmt.Sprintf(`
+					INSERT INTO team_build_events_%d (event_id, build_id, type, version, payload)
+					VALUES (nextval('build_event_id_seq_%d'), $1, $2, $3, $4)
+				`, build.TeamID(), build.ID()), build.ID(), string(oldLog.EventType()), string(oldLog.Version()), string(payload))
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: "new log"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reads them along with the compressed events", func() {
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "old log"})))
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "new log"})))
+			})
+
+			It("compresses them when backfilled", func() {
+				repository := db.NewBackfillRepository(dbConn)
+
+				Eventually(func() (bool, error) {
+					return repository.RunBatch(db.Backfills[0], 1000)
+				}).Should(BeTrue())
+
+				var uncompressed int
+				err := dbConn.QueryRow(`SELECT COUNT(*) FROM build_events WHERE build_id = $1 AND compressed_payload IS NULL`, build.ID()).Scan(&uncompressed)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(uncompressed).To(BeZero())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "old log"})))
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "new log"})))
+			})
+		})
+	})
+
 	Describe("SaveOutput", func() {
 		var pipeline db.Pipeline
 		var job db.Job
@@ -1132,6 +1495,238 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SetSchedulable", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.SchedulableTime()).To(BeZero())
+		})
+
+		It("records when the build became schedulable", func() {
+			err := build.SetSchedulable(true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.SchedulableTime()).To(BeTemporally("~", time.Now(), time.Minute))
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.SchedulableTime()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("keeps the time while the build stays schedulable", func() {
+			_, err := dbConn.Exec(`UPDATE builds SET schedulable_time = now() - '1 hour'::interval WHERE id = $1`, build.ID())
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SetSchedulable(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.SchedulableTime()).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+		})
+
+		It("forgets the time once the build is held again", func() {
+			err := build.SetSchedulable(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SetSchedulable(false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.SchedulableTime()).To(BeZero())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.SchedulableTime()).To(BeZero())
+		})
+	})
+
+	Describe("ClaimConcurrencyPools", func() {
+		var (
+			build   db.Build
+			claimed bool
+			err     error
+		)
+
+		BeforeEach(func() {
+			err := team.UpdateConcurrencyPools(atc.ConcurrencyPools{"integration-db": 1})
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			claimed, err = build.ClaimConcurrencyPools([]string{"integration-db", "unlimited"})
+		})
+
+		It("claims the pools", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		It("can claim them again", func() {
+			claimed, err = build.ClaimConcurrencyPools([]string{"integration-db"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		Context("when another of the team's builds is running in a full pool", func() {
+			var otherBuild db.Build
+
+			BeforeEach(func() {
+				otherBuild, err = team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				claimed, err := otherBuild.ClaimConcurrencyPools([]string{"integration-db"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+
+			It("does not claim the pools", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeFalse())
+			})
+
+			Context("once the other build has completed", func() {
+				BeforeEach(func() {
+					err := otherBuild.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("claims the pools", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(claimed).To(BeTrue())
+				})
+			})
+
+			Context("once the other build has released them", func() {
+				BeforeEach(func() {
+					err := otherBuild.ReleaseConcurrencyPools()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("claims the pools", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(claimed).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when another team's build is running in a pool of the same name", func() {
+			BeforeEach(func() {
+				otherTeam, err := teamFactory.CreateTeam(atc.Team{
+					Name:             "some-other-team",
+					ConcurrencyPools: atc.ConcurrencyPools{"integration-db": 1},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				otherBuild, err := otherTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				claimed, err := otherBuild.ClaimConcurrencyPools([]string{"integration-db"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+
+			It("claims the pools", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("ClaimStepConcurrencyPools", func() {
+		var (
+			build   db.Build
+			claimed bool
+			err     error
+		)
+
+		BeforeEach(func() {
+			err := team.UpdateConcurrencyPools(atc.ConcurrencyPools{"integration-db": 1})
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			claimed, err = build.ClaimStepConcurrencyPools("some-plan", []string{"integration-db", "unlimited"})
+		})
+
+		It("claims the pools", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		It("can claim them again for the same step", func() {
+			claimed, err = build.ClaimStepConcurrencyPools("some-plan", []string{"integration-db"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+
+		It("does not claim them for another of the build's steps", func() {
+			claimed, err = build.ClaimStepConcurrencyPools("some-other-plan", []string{"integration-db"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeFalse())
+		})
+
+		It("does not let another build claim them for the whole build", func() {
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			claimed, err = otherBuild.ClaimConcurrencyPools([]string{"integration-db"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeFalse())
+		})
+
+		Context("once the step has released them", func() {
+			JustBeforeEach(func() {
+				err := build.ReleaseStepConcurrencyPools("some-plan")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("lets another step claim them", func() {
+				claimed, err = build.ClaimStepConcurrencyPools("some-other-plan", []string{"integration-db"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+		})
+
+		Context("once the build has finished", func() {
+			JustBeforeEach(func() {
+				err := build.Finish(db.BuildStatusErrored)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("lets another build claim them", func() {
+				otherBuild, err := team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				claimed, err = otherBuild.ClaimStepConcurrencyPools("some-plan", []string{"integration-db"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+		})
+
+		Context("when the build is already a member of the pool for the whole build", func() {
+			BeforeEach(func() {
+				claimed, err := build.ClaimConcurrencyPools([]string{"integration-db"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+
+			It("claims the pools", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("UseInputs", func() {
 		var build db.Build
 		var pipeline db.Pipeline
@@ -38,12 +38,17 @@ type Check interface {
 	EndTime() time.Time
 	Status() CheckStatus
 	CheckError() error
+	WorkerName() string
+	Output() string
 
 	Start() error
 	Finish() error
 	FinishWithError(err error) error
 
 	SaveVersions([]atc.Version) error
+	SaveDeletedVersions([]atc.Version) error
+	SaveWorker(workerName string) error
+	SaveOutput(output string) error
 	AllCheckables() ([]Checkable, error)
 	AcquireTrackingLock(lager.Logger) (lock.Lock, bool, error)
 	Reload() (bool, error)
@@ -61,6 +66,8 @@ var checksQuery = psql.Select(
 	"c.nonce",
 	"c.check_error",
 	"c.metadata",
+	"c.worker_name",
+	"c.output",
 ).
 	From("checks c")
 
@@ -73,6 +80,8 @@ type check struct {
 	schema     string
 	plan       atc.Plan
 	checkError error
+	workerName string
+	output     string
 
 	createTime time.Time
 	startTime  time.Time
@@ -99,6 +108,8 @@ func (c *check) CreateTime() time.Time      { return c.createTime }
 func (c *check) StartTime() time.Time       { return c.startTime }
 func (c *check) EndTime() time.Time         { return c.endTime }
 func (c *check) CheckError() error          { return c.checkError }
+func (c *check) WorkerName() string         { return c.workerName }
+func (c *check) Output() string             { return c.output }
 
 func (c *check) TeamID() int {
 	return c.metadata.TeamID
@@ -235,6 +246,40 @@ func (c *check) finish(status CheckStatus, checkError error) error {
 	return nil
 }
 
+func (c *check) SaveWorker(workerName string) error {
+	_, err := psql.Update("checks").
+		Set("worker_name", workerName).
+		Where(sq.Eq{
+			"id": c.id,
+		}).
+		RunWith(c.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	c.workerName = workerName
+
+	return nil
+}
+
+func (c *check) SaveOutput(output string) error {
+	_, err := psql.Update("checks").
+		Set("output", output).
+		Where(sq.Eq{
+			"id": c.id,
+		}).
+		RunWith(c.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	c.output = output
+
+	return nil
+}
+
 func (c *check) AcquireTrackingLock(logger lager.Logger) (lock.Lock, bool, error) {
 	return c.lockFactory.Acquire(
 		logger,
@@ -306,12 +351,17 @@ func (c *check) SaveVersions(versions []atc.Version) error {
 	return saveVersions(c.conn, c.resourceConfigScopeID, versions)
 }
 
+func (c *check) SaveDeletedVersions(versions []atc.Version) error {
+	return saveDeletedVersions(c.conn, c.resourceConfigScopeID, versions)
+}
+
 func scanCheck(c *check, row scannable) error {
 	var (
 		createTime, startTime, endTime  pq.NullTime
 		schema, plan, nonce, checkError sql.NullString
 		status                          string
 		metadata                        sql.NullString
+		workerName, output              sql.NullString
 	)
 
 	err := row.Scan(
@@ -326,6 +376,8 @@ func scanCheck(c *check, row scannable) error {
 		&nonce,
 		&checkError,
 		&metadata,
+		&workerName,
+		&output,
 	)
 	if err != nil {
 		return err
@@ -336,17 +388,20 @@ func scanCheck(c *check, row scannable) error {
 		noncense = &nonce.String
 	}
 
-	es := c.conn.EncryptionStrategy()
-	decryptedPlan, err := es.Decrypt(string(plan.String), noncense)
-	if err != nil {
-		return err
-	}
-
-	if len(decryptedPlan) > 0 {
-		err = json.Unmarshal(decryptedPlan, &c.plan)
+	// checks recorded after being run by radar have no plan
+	if plan.Valid {
+		es := c.conn.EncryptionStrategy()
+		decryptedPlan, err := es.Decrypt(string(plan.String), noncense)
 		if err != nil {
 			return err
 		}
+
+		if len(decryptedPlan) > 0 {
+			err = json.Unmarshal(decryptedPlan, &c.plan)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if len(metadata.String) > 0 {
@@ -364,6 +419,8 @@ func scanCheck(c *check, row scannable) error {
 
 	c.status = CheckStatus(status)
 	c.schema = schema.String
+	c.workerName = workerName.String
+	c.output = output.String
 	c.createTime = createTime.Time
 	c.startTime = startTime.Time
 	c.endTime = endTime.Time
@@ -54,22 +54,28 @@ type checkFactory struct {
 	conn        Conn
 	lockFactory lock.LockFactory
 
-	secrets             creds.Secrets
-	defaultCheckTimeout time.Duration
+	secrets              creds.Secrets
+	defaultCheckTimeout  time.Duration
+	checkKillGracePeriod time.Duration
 }
 
+// NewCheckFactory returns a CheckFactory whose checks time out after the
+// checkable's check_timeout, or defaultCheckTimeout if it has none. Checks
+// which are still running checkKillGracePeriod after timing out are killed.
 func NewCheckFactory(
 	conn Conn,
 	lockFactory lock.LockFactory,
 	secrets creds.Secrets,
 	defaultCheckTimeout time.Duration,
+	checkKillGracePeriod time.Duration,
 ) CheckFactory {
 	return &checkFactory{
 		conn:        conn,
 		lockFactory: lockFactory,
 
-		secrets:             secrets,
-		defaultCheckTimeout: defaultCheckTimeout,
+		secrets:              secrets,
+		defaultCheckTimeout:  defaultCheckTimeout,
+		checkKillGracePeriod: checkKillGracePeriod,
 	}
 }
 
@@ -158,7 +164,7 @@ func (c *checkFactory) TryCreateCheck(checkable Checkable, resourceTypes Resourc
 		checkable.PipelineName(),
 	)
 
-	source, err := creds.NewSource(variables, checkable.Source()).Evaluate()
+	source, credentials, err := creds.NewSource(variables, checkable.Source()).EvaluateWithCredentials()
 	if err != nil {
 		return nil, false, err
 	}
@@ -175,6 +181,11 @@ func (c *checkFactory) TryCreateCheck(checkable Checkable, resourceTypes Resourc
 		return nil, false, err
 	}
 
+	_, err = resourceConfigScope.UpdateCredentials(credentials)
+	if err != nil {
+		return nil, false, err
+	}
+
 	if fromVersion == nil {
 		rcv, found, err := resourceConfigScope.LatestVersion()
 		if err != nil {
@@ -194,6 +205,7 @@ func (c *checkFactory) TryCreateCheck(checkable Checkable, resourceTypes Resourc
 			Tags:        checkable.Tags(),
 			Timeout:     timeout.String(),
 			FromVersion: fromVersion,
+			HardTimeout: (timeout + c.checkKillGracePeriod).String(),
 
 			VersionedResourceTypes: filteredTypes,
 		},
@@ -194,6 +194,7 @@ var _ = Describe("CheckFactory", func() {
 									Expect(check.Plan().Check.Source).To(Equal(atc.Source{"some": "((secret))"}))
 									Expect(check.Plan().Check.Tags).To(ConsistOf("tag-a", "tag-b"))
 									Expect(check.Plan().Check.Timeout).To(Equal("10s"))
+									Expect(check.Plan().Check.HardTimeout).To(Equal("1m10s"))
 								})
 							})
 
@@ -298,6 +299,7 @@ var _ = Describe("CheckFactory", func() {
 						Expect(check.Plan().Check.Source).To(Equal(atc.Source{"some": "source"}))
 						Expect(check.Plan().Check.Tags).To(ConsistOf("tag-a", "tag-b"))
 						Expect(check.Plan().Check.Timeout).To(Equal("1m0s"))
+						Expect(check.Plan().Check.HardTimeout).To(Equal("2m0s"))
 					})
 				})
 			})
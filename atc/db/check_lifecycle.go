@@ -11,6 +11,7 @@ import (
 
 type CheckLifecycle interface {
 	RemoveExpiredChecks(time.Duration) error
+	RemoveExpiredResourceFetches(time.Duration) error
 }
 
 type checkLifecycle struct {
@@ -39,3 +40,17 @@ func (lifecycle *checkLifecycle) RemoveExpiredChecks(recyclePeriod time.Duration
 
 	return err
 }
+
+// RemoveExpiredResourceFetches removes the records of resource fetches older
+// than the recycle period, so that fetches are reported on over the same
+// period as checks.
+func (lifecycle *checkLifecycle) RemoveExpiredResourceFetches(recyclePeriod time.Duration) error {
+	_, err := psql.Delete("resource_fetches").
+		Where(sq.Gt{
+			"Now() - create_time": fmt.Sprintf("%.0f seconds", recyclePeriod.Seconds()),
+		}).
+		RunWith(lifecycle.conn).
+		Exec()
+
+	return err
+}
@@ -69,4 +69,26 @@ var _ = Describe("CheckLifecycle", func() {
 			})
 		})
 	})
+
+	Describe("RemoveExpiredResourceFetches", func() {
+		BeforeEach(func() {
+			_, err := dbConn.Exec("INSERT INTO resource_fetches(team_id, type, duration_ms, succeeded, create_time) VALUES($1, 'git', 1000, true, NOW() - '25 hours'::interval)", defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec("INSERT INTO resource_fetches(team_id, type, duration_ms, succeeded, create_time) VALUES($1, 'git', 1000, true, NOW() - '23 hours'::interval)", defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			err := checkLifecycle.RemoveExpiredResourceFetches(time.Hour * 24)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes the fetches recorded more than 24 hours ago", func() {
+			var count int
+			err := dbConn.QueryRow("SELECT count(*) from resource_fetches").Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+	})
 })
@@ -133,6 +133,63 @@ var _ = Describe("Check", func() {
 		})
 	})
 
+	Describe("SaveWorker", func() {
+		It("saves the worker running the check", func() {
+			Expect(check.SaveWorker("some-worker")).To(Succeed())
+			Expect(check.WorkerName()).To(Equal("some-worker"))
+
+			check.Reload()
+			Expect(check.WorkerName()).To(Equal("some-worker"))
+		})
+	})
+
+	Describe("SaveOutput", func() {
+		It("saves the output of the check", func() {
+			Expect(check.SaveOutput("some-output")).To(Succeed())
+			Expect(check.Output()).To(Equal("some-output"))
+
+			check.Reload()
+			Expect(check.Output()).To(Equal("some-output"))
+		})
+	})
+
+	Describe("a resource's checks", func() {
+		var otherCheck db.Check
+
+		BeforeEach(func() {
+			err = check.FinishWithError(errors.New("nope"))
+			Expect(err).NotTo(HaveOccurred())
+
+			otherCheck, created, err = checkFactory.CreateCheck(
+				resourceConfigScope.ID(),
+				false,
+				atc.Plan{},
+				db.CheckMetadata{},
+			)
+			Expect(created).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultResource.Reload()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the checks of the resource, newest first", func() {
+			checks, err := defaultResource.Checks(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(2))
+			Expect(checks[0].ID()).To(Equal(otherCheck.ID()))
+			Expect(checks[1].ID()).To(Equal(check.ID()))
+			Expect(checks[1].CheckError()).To(MatchError("nope"))
+		})
+
+		It("lists at most the limit", func() {
+			checks, err := defaultResource.Checks(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(1))
+			Expect(checks[0].ID()).To(Equal(otherCheck.ID()))
+		})
+	})
+
 	Describe("AllCheckables", func() {
 		var checkables []db.Checkable
 
@@ -3,12 +3,15 @@ package db
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
 )
 
 var ErrContainerDisappeared = errors.New("container disappeared from db")
+var ErrNotEnoughGPUs = errors.New("not enough gpus available on worker")
 
 type ContainerState string
 
@@ -20,6 +23,7 @@ type Container interface {
 	Handle() string
 	WorkerName() string
 	Metadata() ContainerMetadata
+	CreatedAt() time.Time
 }
 
 //go:generate counterfeiter . CreatingContainer
@@ -29,6 +33,8 @@ type CreatingContainer interface {
 
 	Created() (CreatedContainer, error)
 	Failed() (FailedContainer, error)
+
+	AllocateGPUs(count int) ([]int, error)
 }
 
 type creatingContainer struct {
@@ -36,6 +42,7 @@ type creatingContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 	conn       Conn
 }
 
@@ -44,6 +51,7 @@ func newCreatingContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	conn Conn,
 ) *creatingContainer {
 	return &creatingContainer{
@@ -51,6 +59,7 @@ func newCreatingContainer(
 		handle:     handle,
 		workerName: workerName,
 		metadata:   metadata,
+		createdAt:  createdAt,
 		conn:       conn,
 	}
 }
@@ -60,6 +69,7 @@ func (container *creatingContainer) State() string               { return atc.Co
 func (container *creatingContainer) Handle() string              { return container.handle }
 func (container *creatingContainer) WorkerName() string          { return container.workerName }
 func (container *creatingContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *creatingContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *creatingContainer) Created() (CreatedContainer, error) {
 	rows, err := psql.Update("containers").
@@ -91,6 +101,7 @@ func (container *creatingContainer) Created() (CreatedContainer, error) {
 		container.handle,
 		container.workerName,
 		container.metadata,
+		container.createdAt,
 		false,
 		container.conn,
 	), nil
@@ -126,10 +137,111 @@ func (container *creatingContainer) Failed() (FailedContainer, error) {
 		container.handle,
 		container.workerName,
 		container.metadata,
+		container.createdAt,
 		container.conn,
 	), nil
 }
 
+// AllocateGPUs allocates GPUs on the container's worker which are not
+// allocated to any other container, returning their indices. GPUs are
+// released once their container's task exits, or the container fails or is
+// destroyed. Allocating again returns the GPUs already allocated to the
+// container.
+func (container *creatingContainer) AllocateGPUs(count int) ([]int, error) {
+	tx, err := container.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	// locking the worker serializes allocations on it
+	var workerGPUs int
+	err = psql.Select("gpus").
+		From("workers").
+		Where(sq.Eq{"name": container.workerName}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&workerGPUs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := psql.Select("id", "gpus").
+		From("containers").
+		Where(sq.And{
+			sq.Eq{"worker_name": container.workerName},
+			sq.NotEq{"gpus": nil},
+			sq.NotEq{"state": []string{atc.ContainerStateFailed, atc.ContainerStateDestroying}},
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	allocated := map[int]bool{}
+	var existing []int
+
+	for rows.Next() {
+		var id int
+		var gpus []int64
+		err = rows.Scan(&id, pq.Array(&gpus))
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		for _, gpu := range gpus {
+			allocated[int(gpu)] = true
+
+			if id == container.id {
+				existing = append(existing, int(gpu))
+			}
+		}
+	}
+
+	err = rows.Err()
+	Close(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) > 0 {
+		return existing, nil
+	}
+
+	var gpus []int
+	var gpuIDs []int64
+	for gpu := 0; gpu < workerGPUs && len(gpus) < count; gpu++ {
+		if !allocated[gpu] {
+			gpus = append(gpus, gpu)
+			gpuIDs = append(gpuIDs, int64(gpu))
+		}
+	}
+
+	if len(gpus) < count {
+		return nil, ErrNotEnoughGPUs
+	}
+
+	_, err = psql.Update("containers").
+		Set("gpus", pq.Array(gpuIDs)).
+		Where(sq.Eq{"id": container.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return gpus, nil
+}
+
 //go:generate counterfeiter . CreatedContainer
 
 type CreatedContainer interface {
@@ -139,6 +251,7 @@ type CreatedContainer interface {
 	Destroying() (DestroyingContainer, error)
 	IsHijacked() bool
 	MarkAsHijacked() error
+	ReleaseGPUs() error
 }
 
 type createdContainer struct {
@@ -146,6 +259,7 @@ type createdContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 
 	hijacked bool
 
@@ -157,6 +271,7 @@ func newCreatedContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	hijacked bool,
 	conn Conn,
 ) *createdContainer {
@@ -165,6 +280,7 @@ func newCreatedContainer(
 		handle:     handle,
 		workerName: workerName,
 		metadata:   metadata,
+		createdAt:  createdAt,
 		hijacked:   hijacked,
 		conn:       conn,
 	}
@@ -175,6 +291,7 @@ func (container *createdContainer) State() string               { return atc.Con
 func (container *createdContainer) Handle() string              { return container.handle }
 func (container *createdContainer) WorkerName() string          { return container.workerName }
 func (container *createdContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *createdContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *createdContainer) IsHijacked() bool { return container.hijacked }
 
@@ -207,6 +324,7 @@ func (container *createdContainer) Destroying() (DestroyingContainer, error) {
 		container.handle,
 		container.workerName,
 		container.metadata,
+		container.createdAt,
 		isDiscontinued,
 		container.conn,
 	), nil
@@ -243,6 +361,7 @@ func (container *createdContainer) Discontinue() (DestroyingContainer, error) {
 		container.handle,
 		container.workerName,
 		container.metadata,
+		container.createdAt,
 		true,
 		container.conn,
 	), nil
@@ -277,6 +396,18 @@ func (container *createdContainer) MarkAsHijacked() error {
 	return nil
 }
 
+// ReleaseGPUs releases the GPUs allocated to the container, once its task has
+// exited, so that they can be allocated to other containers while it's kept
+// around for hijacking.
+func (container *createdContainer) ReleaseGPUs() error {
+	_, err := psql.Update("containers").
+		Set("gpus", nil).
+		Where(sq.Eq{"id": container.id}).
+		RunWith(container.conn).
+		Exec()
+	return err
+}
+
 //go:generate counterfeiter . DestroyingContainer
 
 type DestroyingContainer interface {
@@ -291,6 +422,7 @@ type destroyingContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 
 	isDiscontinued bool
 
@@ -302,6 +434,7 @@ func newDestroyingContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	isDiscontinued bool,
 	conn Conn,
 ) *destroyingContainer {
@@ -310,6 +443,7 @@ func newDestroyingContainer(
 		handle:         handle,
 		workerName:     workerName,
 		metadata:       metadata,
+		createdAt:      createdAt,
 		isDiscontinued: isDiscontinued,
 		conn:           conn,
 	}
@@ -320,6 +454,7 @@ func (container *destroyingContainer) State() string               { return atc.
 func (container *destroyingContainer) Handle() string              { return container.handle }
 func (container *destroyingContainer) WorkerName() string          { return container.workerName }
 func (container *destroyingContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *destroyingContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *destroyingContainer) IsDiscontinued() bool { return container.isDiscontinued }
 
@@ -360,6 +495,7 @@ type failedContainer struct {
 	handle     string
 	workerName string
 	metadata   ContainerMetadata
+	createdAt  time.Time
 	conn       Conn
 }
 
@@ -368,6 +504,7 @@ func newFailedContainer(
 	handle string,
 	workerName string,
 	metadata ContainerMetadata,
+	createdAt time.Time,
 	conn Conn,
 ) *failedContainer {
 	return &failedContainer{
@@ -375,6 +512,7 @@ func newFailedContainer(
 		handle:     handle,
 		workerName: workerName,
 		metadata:   metadata,
+		createdAt:  createdAt,
 		conn:       conn,
 	}
 }
@@ -384,6 +522,7 @@ func (container *failedContainer) State() string               { return atc.Cont
 func (container *failedContainer) Handle() string              { return container.handle }
 func (container *failedContainer) WorkerName() string          { return container.workerName }
 func (container *failedContainer) Metadata() ContainerMetadata { return container.metadata }
+func (container *failedContainer) CreatedAt() time.Time        { return container.createdAt }
 
 func (container *failedContainer) Destroy() (bool, error) {
 	rows, err := psql.Delete("containers").
@@ -124,14 +124,20 @@ func (c buildStepContainerOwner) sqlMap() map[string]interface{} {
 // worker base resource type, with an expiry. When the resource config or
 // worker base resource type disappear, or the expiry is reached, the container
 // can be removed.
+//
+// Check containers are given the team's environment and CA certificates, so
+// a team's checks don't share containers with other teams' checks of the same
+// resource config.
 func NewResourceConfigCheckSessionContainerOwner(
 	resourceConfigID int,
 	baseResourceTypeID int,
+	teamID int,
 	expiries ContainerOwnerExpiries,
 ) ContainerOwner {
 	return resourceConfigCheckSessionContainerOwner{
 		resourceConfigID:   resourceConfigID,
 		baseResourceTypeID: baseResourceTypeID,
+		teamID:             teamID,
 		expiries:           expiries,
 	}
 }
@@ -139,6 +145,7 @@ func NewResourceConfigCheckSessionContainerOwner(
 type resourceConfigCheckSessionContainerOwner struct {
 	resourceConfigID   int
 	baseResourceTypeID int
+	teamID             int
 	expiries           ContainerOwnerExpiries
 }
 
@@ -177,6 +184,7 @@ func (c resourceConfigCheckSessionContainerOwner) Find(conn Conn) (sq.Eq, bool,
 
 	return sq.Eq{
 		"resource_config_check_session_id": ids,
+		"team_id":                          c.teamID,
 	}, true, nil
 }
 
@@ -224,5 +232,6 @@ func (c resourceConfigCheckSessionContainerOwner) Create(tx Tx, workerName strin
 
 	return map[string]interface{}{
 		"resource_config_check_session_id": rccsID,
+		"team_id":                          c.teamID,
 	}, nil
 }
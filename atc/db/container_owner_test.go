@@ -54,6 +54,7 @@ var _ = Describe("ContainerOwner", func() {
 			owner = db.NewResourceConfigCheckSessionContainerOwner(
 				resourceConfig.ID(),
 				resourceConfig.OriginBaseResourceType().ID,
+				defaultTeam.ID(),
 				ownerExpiries,
 			)
 		})
@@ -74,6 +75,7 @@ var _ = Describe("ContainerOwner", func() {
 					existingOwner := db.NewResourceConfigCheckSessionContainerOwner(
 						resourceConfig.ID(),
 						resourceConfig.OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						ownerExpiries,
 					)
 
@@ -88,8 +90,9 @@ var _ = Describe("ContainerOwner", func() {
 				})
 
 				It("finds the resource config check session", func() {
-					Expect(foundColumns).To(HaveLen(1))
+					Expect(foundColumns).To(HaveLen(2))
 					Expect(foundColumns["resource_config_check_session_id"]).To(ConsistOf(createdColumns["resource_config_check_session_id"]))
+					Expect(foundColumns["team_id"]).To(Equal(defaultTeam.ID()))
 					Expect(found).To(BeTrue())
 				})
 			})
@@ -101,6 +104,7 @@ var _ = Describe("ContainerOwner", func() {
 					existingOwner := db.NewResourceConfigCheckSessionContainerOwner(
 						resourceConfig.ID(),
 						resourceConfig.OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						ownerExpiries,
 					)
 
@@ -119,7 +123,7 @@ var _ = Describe("ContainerOwner", func() {
 				})
 
 				It("finds both resource config check sessions", func() {
-					Expect(foundColumns).To(HaveLen(1))
+					Expect(foundColumns).To(HaveLen(2))
 					Expect(foundColumns["resource_config_check_session_id"]).To(ConsistOf(createdColumns["resource_config_check_session_id"], createdColumns2["resource_config_check_session_id"]))
 					Expect(found).To(BeTrue())
 				})
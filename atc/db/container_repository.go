@@ -269,7 +269,7 @@ func (repository *containerRepository) FindOrphanedContainers() ([]CreatingConta
 }
 
 func selectContainers(asOptional ...string) sq.SelectBuilder {
-	columns := []string{"id", "handle", "worker_name", "hijacked", "discontinued", "state"}
+	columns := []string{"id", "handle", "worker_name", "hijacked", "discontinued", "state", "created_at"}
 	columns = append(columns, containerMetadataColumns...)
 
 	table := "containers"
@@ -293,11 +293,12 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 		isDiscontinued bool
 		isHijacked     bool
 		state          string
+		createdAt      time.Time
 
 		metadata ContainerMetadata
 	)
 
-	columns := []interface{}{&id, &handle, &workerName, &isHijacked, &isDiscontinued, &state}
+	columns := []interface{}{&id, &handle, &workerName, &isHijacked, &isDiscontinued, &state, &createdAt}
 	columns = append(columns, metadata.ScanTargets()...)
 
 	err := row.Scan(columns...)
@@ -312,6 +313,7 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			conn,
 		), nil, nil, nil, nil
 	case atc.ContainerStateCreated:
@@ -320,6 +322,7 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			isHijacked,
 			conn,
 		), nil, nil, nil
@@ -329,6 +332,7 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			isDiscontinued,
 			conn,
 		), nil, nil
@@ -338,6 +342,7 @@ func scanContainer(row sq.RowScanner, conn Conn) (CreatingContainer, CreatedCont
 			handle,
 			workerName,
 			metadata,
+			createdAt,
 			conn,
 		), nil
 	}
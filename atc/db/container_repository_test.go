@@ -38,6 +38,7 @@ var _ = Describe("ContainerRepository", func() {
 					db.NewResourceConfigCheckSessionContainerOwner(
 						resourceConfig.ID(),
 						resourceConfig.OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						expiries,
 					),
 					fullMetadata,
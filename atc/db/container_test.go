@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -42,6 +44,23 @@ var _ = Describe("Container", func() {
 		})
 	})
 
+	Describe("CreatedAt", func() {
+		It("returns when the container was created", func() {
+			Expect(creatingContainer.CreatedAt()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("is kept when the container is created", func() {
+			createdContainer, err := creatingContainer.Created()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdContainer.CreatedAt()).To(Equal(creatingContainer.CreatedAt()))
+
+			containers, err := defaultTeam.FindContainersByMetadata(fullMetadata)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].CreatedAt()).To(BeTemporally("==", creatingContainer.CreatedAt()))
+		})
+	})
+
 	Describe("Created", func() {
 		Context("when the container is already created", func() {
 			var createdContainer db.CreatedContainer
@@ -117,6 +136,72 @@ var _ = Describe("Container", func() {
 		})
 	})
 
+	Describe("AllocateGPUs", func() {
+		var otherContainer db.CreatingContainer
+
+		BeforeEach(func() {
+			_, err := psql.Update("workers").
+				Set("gpus", 2).
+				Where(sq.Eq{"name": defaultWorker.Name()}).
+				RunWith(dbConn).
+				Exec()
+			Expect(err).NotTo(HaveOccurred())
+
+			otherContainer, err = defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(build.ID(), "some-other-plan", defaultTeam.ID()),
+				fullMetadata,
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("allocates gpus which are not allocated to other containers", func() {
+			gpus, err := creatingContainer.AllocateGPUs(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gpus).To(Equal([]int{0}))
+
+			gpus, err = otherContainer.AllocateGPUs(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gpus).To(Equal([]int{1}))
+		})
+
+		It("returns the gpus already allocated to the container", func() {
+			_, err := creatingContainer.AllocateGPUs(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			gpus, err := creatingContainer.AllocateGPUs(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gpus).To(Equal([]int{0}))
+		})
+
+		It("fails when not enough gpus are available", func() {
+			_, err := creatingContainer.AllocateGPUs(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherContainer.AllocateGPUs(2)
+			Expect(err).To(Equal(db.ErrNotEnoughGPUs))
+		})
+
+		It("releases the gpus once the container fails", func() {
+			_, err := creatingContainer.AllocateGPUs(2)
+			Expect(err).NotTo(HaveOccurred())
+
+			available, err := defaultWorker.AvailableGPUs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(available).To(BeZero())
+
+			_, err = creatingContainer.Failed()
+			Expect(err).NotTo(HaveOccurred())
+
+			available, err = defaultWorker.AvailableGPUs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(available).To(Equal(2))
+
+			gpus, err := otherContainer.AllocateGPUs(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gpus).To(Equal([]int{0, 1}))
+		})
+	})
+
 	Describe("Failed", func() {
 		var failedContainer db.FailedContainer
 		var failedErr error
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// HotTables are the tables whose health is monitored: those which builds and
+// checks insert into and delete from the most. Build events are kept in a
+// table for each team and pipeline, which are counted together as
+// build_events.
+var HotTables = []string{
+	"build_events",
+	"build_image_resource_caches",
+	"build_resource_config_version_inputs",
+	"build_resource_config_version_outputs",
+	"builds",
+	"checks",
+	"containers",
+	"resource_cache_uses",
+	"resource_config_versions",
+	"volumes",
+	"worker_resource_caches",
+}
+
+const (
+	// tables with more dead tuples than this, making up more than the dead
+	// tuple ratio of the table, are suggested to be vacuumed
+	deadTuplesThreshold     = 10000
+	deadTupleRatioThreshold = 0.2
+
+	// tables with live tuples which haven't been analyzed for longer than
+	// this are suggested to be analyzed
+	staleStatisticsThreshold = 7 * 24 * time.Hour
+
+	// tables bigger than this whose indexes are this many times bigger than
+	// them are suggested to be reindexed
+	indexBloatTableBytes = 100 * 1024 * 1024
+	indexBloatFactor     = 2
+)
+
+//go:generate counterfeiter . DBHealthRepository
+
+type DBHealthRepository interface {
+	Health() (atc.DBHealth, error)
+}
+
+type dbHealthRepository struct {
+	conn Conn
+}
+
+func NewDBHealthRepository(conn Conn) DBHealthRepository {
+	return &dbHealthRepository{
+		conn: conn,
+	}
+}
+
+// Health reports the health of each of the hot tables which exist, from the
+// statistics postgres collects. Partitions are summed up, and their vacuum
+// and analyze times are those of the partition done longest ago.
+func (repository *dbHealthRepository) Health() (atc.DBHealth, error) {
+	rows, err := repository.conn.Query(`
+		SELECT COALESCE(p.relname, s.relname),
+			sum(s.n_live_tup)::bigint,
+			sum(s.n_dead_tup)::bigint,
+			sum(pg_table_size(s.relid))::bigint,
+			sum(pg_indexes_size(s.relid))::bigint,
+			EXTRACT(EPOCH FROM min(GREATEST(s.last_vacuum, s.last_autovacuum)))::bigint,
+			EXTRACT(EPOCH FROM min(GREATEST(s.last_analyze, s.last_autoanalyze)))::bigint
+		FROM pg_stat_user_tables s
+		LEFT JOIN pg_inherits i ON i.inhrelid = s.relid
+		LEFT JOIN pg_class p ON p.oid = i.inhparent
+		WHERE COALESCE(p.relname, s.relname) = ANY($1)
+		GROUP BY 1
+		ORDER BY 1
+	`, pq.Array(HotTables))
+	if err != nil {
+		return atc.DBHealth{}, err
+	}
+
+	tables := []atc.TableHealth{}
+	indexByTable := map[string]int{}
+
+	for rows.Next() {
+		var table atc.TableHealth
+		var lastVacuumed, lastAnalyzed sql.NullInt64
+		err = rows.Scan(
+			&table.Name,
+			&table.LiveTuples,
+			&table.DeadTuples,
+			&table.TableBytes,
+			&table.IndexBytes,
+			&lastVacuumed,
+			&lastAnalyzed,
+		)
+		if err != nil {
+			Close(rows)
+			return atc.DBHealth{}, err
+		}
+
+		if total := table.LiveTuples + table.DeadTuples; total > 0 {
+			table.DeadTupleRatio = float64(table.DeadTuples) / float64(total)
+		}
+
+		table.LastVacuumed = lastVacuumed.Int64
+		table.LastAnalyzed = lastAnalyzed.Int64
+		table.Indexes = []atc.IndexHealth{}
+
+		indexByTable[table.Name] = len(tables)
+		tables = append(tables, table)
+	}
+
+	Close(rows)
+
+	rows, err = repository.conn.Query(`
+		SELECT s.relname, s.indexrelname, pg_relation_size(s.indexrelid), s.idx_scan, i.indisvalid
+		FROM pg_stat_user_indexes s
+		JOIN pg_index i ON i.indexrelid = s.indexrelid
+		WHERE s.relname = ANY($1)
+		ORDER BY s.relname, s.indexrelname
+	`, pq.Array(HotTables))
+	if err != nil {
+		return atc.DBHealth{}, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var tableName string
+		var index atc.IndexHealth
+		err = rows.Scan(&tableName, &index.Name, &index.Bytes, &index.Scans, &index.Valid)
+		if err != nil {
+			return atc.DBHealth{}, err
+		}
+
+		i, found := indexByTable[tableName]
+		if !found {
+			continue
+		}
+
+		index.Suggestions = suggestIndexMaintenance(index)
+
+		tables[i].Indexes = append(tables[i].Indexes, index)
+	}
+
+	now := time.Now()
+	for i := range tables {
+		tables[i].Suggestions = suggestTableMaintenance(tables[i], now)
+	}
+
+	return atc.DBHealth{Tables: tables}, nil
+}
+
+func suggestTableMaintenance(table atc.TableHealth, now time.Time) []string {
+	suggestions := []string{}
+
+	if table.DeadTuples > deadTuplesThreshold && table.DeadTupleRatio > deadTupleRatioThreshold {
+		suggestions = append(suggestions, fmt.Sprintf(
+			"VACUUM ANALYZE %s: %.0f%% of its tuples are dead, so autovacuum is not keeping up with it",
+			table.Name,
+			table.DeadTupleRatio*100,
+		))
+	} else if table.LiveTuples > 0 && now.Sub(time.Unix(table.LastAnalyzed, 0)) > staleStatisticsThreshold {
+		suggestions = append(suggestions, fmt.Sprintf(
+			"ANALYZE %s: its statistics are stale, so the query planner may choose poor plans for it",
+			table.Name,
+		))
+	}
+
+	if table.TableBytes > indexBloatTableBytes && table.IndexBytes > table.TableBytes*indexBloatFactor {
+		suggestions = append(suggestions, fmt.Sprintf(
+			"REINDEX TABLE %s: its indexes are %d times the size of the table, so are likely bloated",
+			table.Name,
+			table.IndexBytes/table.TableBytes,
+		))
+	}
+
+	return suggestions
+}
+
+func suggestIndexMaintenance(index atc.IndexHealth) []string {
+	suggestions := []string{}
+
+	if !index.Valid {
+		suggestions = append(suggestions, fmt.Sprintf(
+			"DROP INDEX %s and create it again: it was left invalid by a failed build, so is kept up to date but never used",
+			index.Name,
+		))
+	}
+
+	return suggestions
+}
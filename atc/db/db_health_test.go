@@ -0,0 +1,98 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DBHealthRepository", func() {
+	var repository db.DBHealthRepository
+
+	BeforeEach(func() {
+		repository = db.NewDBHealthRepository(dbConn)
+	})
+
+	tableNamed := func(health atc.DBHealth, name string) (atc.TableHealth, bool) {
+		for _, table := range health.Tables {
+			if table.Name == name {
+				return table, true
+			}
+		}
+
+		return atc.TableHealth{}, false
+	}
+
+	It("reports each of the hot tables in order", func() {
+		health, err := repository.Health()
+		Expect(err).NotTo(HaveOccurred())
+
+		names := []string{}
+		for _, table := range health.Tables {
+			names = append(names, table.Name)
+		}
+
+		Expect(names).To(Equal(db.HotTables))
+	})
+
+	It("counts the partitions of build_events as one table", func() {
+		build, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveEvent(event.Log{Payload: "hello"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dbConn.Exec(`ANALYZE`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() int64 {
+			health, err := repository.Health()
+			Expect(err).NotTo(HaveOccurred())
+
+			table, found := tableNamed(health, "build_events")
+			Expect(found).To(BeTrue())
+
+			return table.LiveTuples
+		}).Should(BeNumerically(">", 0))
+	})
+
+	It("reports the tables' indexes", func() {
+		health, err := repository.Health()
+		Expect(err).NotTo(HaveOccurred())
+
+		builds, found := tableNamed(health, "builds")
+		Expect(found).To(BeTrue())
+
+		var pkey atc.IndexHealth
+		for _, index := range builds.Indexes {
+			if index.Name == "builds_pkey" {
+				pkey = index
+			}
+		}
+
+		Expect(pkey.Name).To(Equal("builds_pkey"))
+		Expect(pkey.Bytes).To(BeNumerically(">", 0))
+		Expect(pkey.Valid).To(BeTrue())
+		Expect(pkey.Suggestions).To(BeEmpty())
+	})
+
+	It("counts the dead tuples left by deleted rows", func() {
+		build, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dbConn.Exec(`DELETE FROM builds WHERE id = $1`, build.ID())
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() int64 {
+			health, err := repository.Health()
+			Expect(err).NotTo(HaveOccurred())
+
+			table, found := tableNamed(health, "builds")
+			Expect(found).To(BeTrue())
+
+			return table.DeadTuples
+		}).Should(BeNumerically(">", 0))
+	})
+})
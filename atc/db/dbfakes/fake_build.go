@@ -41,6 +41,27 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	AnnotateStub        func(atc.BuildAnnotations) error
+	annotateMutex       sync.RWMutex
+	annotateArgsForCall []struct {
+		arg1 atc.BuildAnnotations
+	}
+	annotateReturns struct {
+		result1 error
+	}
+	annotateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AnnotationsStub        func() atc.BuildAnnotations
+	annotationsMutex       sync.RWMutex
+	annotationsArgsForCall []struct {
+	}
+	annotationsReturns struct {
+		result1 atc.BuildAnnotations
+	}
+	annotationsReturnsOnCall map[int]struct {
+		result1 atc.BuildAnnotations
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -66,6 +87,67 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	AutoRetryAttemptStub        func() int
+	autoRetryAttemptMutex       sync.RWMutex
+	autoRetryAttemptArgsForCall []struct {
+	}
+	autoRetryAttemptReturns struct {
+		result1 int
+	}
+	autoRetryAttemptReturnsOnCall map[int]struct {
+		result1 int
+	}
+	CheckpointStub        func() (atc.BuildCheckpoint, bool, error)
+	checkpointMutex       sync.RWMutex
+	checkpointArgsForCall []struct {
+	}
+	checkpointReturns struct {
+		result1 atc.BuildCheckpoint
+		result2 bool
+		result3 error
+	}
+	checkpointReturnsOnCall map[int]struct {
+		result1 atc.BuildCheckpoint
+		result2 bool
+		result3 error
+	}
+	ClaimConcurrencyPoolsStub        func([]string) (bool, error)
+	claimConcurrencyPoolsMutex       sync.RWMutex
+	claimConcurrencyPoolsArgsForCall []struct {
+		arg1 []string
+	}
+	claimConcurrencyPoolsReturns struct {
+		result1 bool
+		result2 error
+	}
+	claimConcurrencyPoolsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ClaimStepConcurrencyPoolsStub        func(atc.PlanID, []string) (bool, error)
+	claimStepConcurrencyPoolsMutex       sync.RWMutex
+	claimStepConcurrencyPoolsArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 []string
+	}
+	claimStepConcurrencyPoolsReturns struct {
+		result1 bool
+		result2 error
+	}
+	claimStepConcurrencyPoolsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CreateTimeStub        func() time.Time
+	createTimeMutex       sync.RWMutex
+	createTimeArgsForCall []struct {
+	}
+	createTimeReturns struct {
+		result1 time.Time
+	}
+	createTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	DeleteStub        func() (bool, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -88,6 +170,30 @@ type FakeBuild struct {
 	endTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	ErrorCodeStub        func() atc.ErrorCode
+	errorCodeMutex       sync.RWMutex
+	errorCodeArgsForCall []struct {
+	}
+	errorCodeReturns struct {
+		result1 atc.ErrorCode
+	}
+	errorCodeReturnsOnCall map[int]struct {
+		result1 atc.ErrorCode
+	}
+	ErrorPendingStub        func(atc.ErrorCode, string) (bool, error)
+	errorPendingMutex       sync.RWMutex
+	errorPendingArgsForCall []struct {
+		arg1 atc.ErrorCode
+		arg2 string
+	}
+	errorPendingReturns struct {
+		result1 bool
+		result2 error
+	}
+	errorPendingReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	EventsStub        func(uint) (db.EventSource, error)
 	eventsMutex       sync.RWMutex
 	eventsArgsForCall []struct {
@@ -132,6 +238,46 @@ type FakeBuild struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ImageOverridesStub        func() atc.TaskImageOverrides
+	imageOverridesMutex       sync.RWMutex
+	imageOverridesArgsForCall []struct {
+	}
+	imageOverridesReturns struct {
+		result1 atc.TaskImageOverrides
+	}
+	imageOverridesReturnsOnCall map[int]struct {
+		result1 atc.TaskImageOverrides
+	}
+	InputArtifactsStub        func() atc.InputArtifacts
+	inputArtifactsMutex       sync.RWMutex
+	inputArtifactsArgsForCall []struct {
+	}
+	inputArtifactsReturns struct {
+		result1 atc.InputArtifacts
+	}
+	inputArtifactsReturnsOnCall map[int]struct {
+		result1 atc.InputArtifacts
+	}
+	InputFallbacksStub        func() atc.InputFallbacks
+	inputFallbacksMutex       sync.RWMutex
+	inputFallbacksArgsForCall []struct {
+	}
+	inputFallbacksReturns struct {
+		result1 atc.InputFallbacks
+	}
+	inputFallbacksReturnsOnCall map[int]struct {
+		result1 atc.InputFallbacks
+	}
+	InputOverridesStub        func() atc.InputVersionOverrides
+	inputOverridesMutex       sync.RWMutex
+	inputOverridesArgsForCall []struct {
+	}
+	inputOverridesReturns struct {
+		result1 atc.InputVersionOverrides
+	}
+	inputOverridesReturnsOnCall map[int]struct {
+		result1 atc.InputVersionOverrides
+	}
 	InterceptibleStub        func() (bool, error)
 	interceptibleMutex       sync.RWMutex
 	interceptibleArgsForCall []struct {
@@ -195,6 +341,16 @@ type FakeBuild struct {
 	isNewerThanLastCheckOfReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsPausedStub        func() bool
+	isPausedMutex       sync.RWMutex
+	isPausedArgsForCall []struct {
+	}
+	isPausedReturns struct {
+		result1 bool
+	}
+	isPausedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsRunningStub        func() bool
 	isRunningMutex       sync.RWMutex
 	isRunningArgsForCall []struct {
@@ -255,6 +411,18 @@ type FakeBuild struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PausedStub        func() (bool, error)
+	pausedMutex       sync.RWMutex
+	pausedArgsForCall []struct {
+	}
+	pausedReturns struct {
+		result1 bool
+		result2 error
+	}
+	pausedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -333,6 +501,27 @@ type FakeBuild struct {
 	reapTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	ReleaseConcurrencyPoolsStub        func() error
+	releaseConcurrencyPoolsMutex       sync.RWMutex
+	releaseConcurrencyPoolsArgsForCall []struct {
+	}
+	releaseConcurrencyPoolsReturns struct {
+		result1 error
+	}
+	releaseConcurrencyPoolsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseStepConcurrencyPoolsStub        func(atc.PlanID) error
+	releaseStepConcurrencyPoolsMutex       sync.RWMutex
+	releaseStepConcurrencyPoolsArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	releaseStepConcurrencyPoolsReturns struct {
+		result1 error
+	}
+	releaseStepConcurrencyPoolsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -345,6 +534,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct {
+	}
+	rerunOfReturns struct {
+		result1 int
+	}
+	rerunOfReturnsOnCall map[int]struct {
+		result1 int
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct {
@@ -359,6 +558,40 @@ type FakeBuild struct {
 		result2 []db.BuildOutput
 		result3 error
 	}
+	ResumeNotifierStub        func() (db.Notifier, error)
+	resumeNotifierMutex       sync.RWMutex
+	resumeNotifierArgsForCall []struct {
+	}
+	resumeNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	resumeNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	SaveCheckpointStub        func(atc.BuildCheckpoint) error
+	saveCheckpointMutex       sync.RWMutex
+	saveCheckpointArgsForCall []struct {
+		arg1 atc.BuildCheckpoint
+	}
+	saveCheckpointReturns struct {
+		result1 error
+	}
+	saveCheckpointReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveErrorCodeStub        func(atc.ErrorCode) error
+	saveErrorCodeMutex       sync.RWMutex
+	saveErrorCodeArgsForCall []struct {
+		arg1 atc.ErrorCode
+	}
+	saveErrorCodeReturns struct {
+		result1 error
+	}
+	saveErrorCodeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -398,6 +631,42 @@ type FakeBuild struct {
 	saveOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveResourceFetchStub        func(string, time.Duration, bool) error
+	saveResourceFetchMutex       sync.RWMutex
+	saveResourceFetchArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+		arg3 bool
+	}
+	saveResourceFetchReturns struct {
+		result1 error
+	}
+	saveResourceFetchReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SavedEventsStub        func(...atc.EventType) ([]db.SavedEvent, error)
+	savedEventsMutex       sync.RWMutex
+	savedEventsArgsForCall []struct {
+		arg1 []atc.EventType
+	}
+	savedEventsReturns struct {
+		result1 []db.SavedEvent
+		result2 error
+	}
+	savedEventsReturnsOnCall map[int]struct {
+		result1 []db.SavedEvent
+		result2 error
+	}
+	SchedulableTimeStub        func() time.Time
+	schedulableTimeMutex       sync.RWMutex
+	schedulableTimeArgsForCall []struct {
+	}
+	schedulableTimeReturns struct {
+		result1 time.Time
+	}
+	schedulableTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	ScheduleStub        func() (bool, error)
 	scheduleMutex       sync.RWMutex
 	scheduleArgsForCall []struct {
@@ -442,6 +711,28 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetPausedStub        func(bool) error
+	setPausedMutex       sync.RWMutex
+	setPausedArgsForCall []struct {
+		arg1 bool
+	}
+	setPausedReturns struct {
+		result1 error
+	}
+	setPausedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetSchedulableStub        func(bool) error
+	setSchedulableMutex       sync.RWMutex
+	setSchedulableArgsForCall []struct {
+		arg1 bool
+	}
+	setSchedulableReturns struct {
+		result1 error
+	}
+	setSchedulableReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartStub        func(atc.Plan) (bool, error)
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
@@ -495,6 +786,16 @@ type FakeBuild struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TokenStub        func() string
+	tokenMutex       sync.RWMutex
+	tokenArgsForCall []struct {
+	}
+	tokenReturns struct {
+		result1 string
+	}
+	tokenReturnsOnCall map[int]struct {
+		result1 string
+	}
 	UseInputsStub        func([]db.BuildInput) error
 	useInputsMutex       sync.RWMutex
 	useInputsArgsForCall []struct {
@@ -506,6 +807,16 @@ type FakeBuild struct {
 	useInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WorkerOverridesStub        func() atc.StepWorkerOverrides
+	workerOverridesMutex       sync.RWMutex
+	workerOverridesArgsForCall []struct {
+	}
+	workerOverridesReturns struct {
+		result1 atc.StepWorkerOverrides
+	}
+	workerOverridesReturnsOnCall map[int]struct {
+		result1 atc.StepWorkerOverrides
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -632,6 +943,118 @@ func (fake *FakeBuild) AcquireTrackingLockReturnsOnCall(i int, result1 lock.Lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Annotate(arg1 atc.BuildAnnotations) error {
+	fake.annotateMutex.Lock()
+	ret, specificReturn := fake.annotateReturnsOnCall[len(fake.annotateArgsForCall)]
+	fake.annotateArgsForCall = append(fake.annotateArgsForCall, struct {
+		arg1 atc.BuildAnnotations
+	}{arg1})
+	fake.recordInvocation("Annotate", []interface{}{arg1})
+	fake.annotateMutex.Unlock()
+	if fake.AnnotateStub != nil {
+		return fake.AnnotateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.annotateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AnnotateCallCount() int {
+	fake.annotateMutex.RLock()
+	defer fake.annotateMutex.RUnlock()
+	return len(fake.annotateArgsForCall)
+}
+
+func (fake *FakeBuild) AnnotateCalls(stub func(atc.BuildAnnotations) error) {
+	fake.annotateMutex.Lock()
+	defer fake.annotateMutex.Unlock()
+	fake.AnnotateStub = stub
+}
+
+func (fake *FakeBuild) AnnotateArgsForCall(i int) atc.BuildAnnotations {
+	fake.annotateMutex.RLock()
+	defer fake.annotateMutex.RUnlock()
+	argsForCall := fake.annotateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) AnnotateReturns(result1 error) {
+	fake.annotateMutex.Lock()
+	defer fake.annotateMutex.Unlock()
+	fake.AnnotateStub = nil
+	fake.annotateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) AnnotateReturnsOnCall(i int, result1 error) {
+	fake.annotateMutex.Lock()
+	defer fake.annotateMutex.Unlock()
+	fake.AnnotateStub = nil
+	if fake.annotateReturnsOnCall == nil {
+		fake.annotateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.annotateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) Annotations() atc.BuildAnnotations {
+	fake.annotationsMutex.Lock()
+	ret, specificReturn := fake.annotationsReturnsOnCall[len(fake.annotationsArgsForCall)]
+	fake.annotationsArgsForCall = append(fake.annotationsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Annotations", []interface{}{})
+	fake.annotationsMutex.Unlock()
+	if fake.AnnotationsStub != nil {
+		return fake.AnnotationsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.annotationsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AnnotationsCallCount() int {
+	fake.annotationsMutex.RLock()
+	defer fake.annotationsMutex.RUnlock()
+	return len(fake.annotationsArgsForCall)
+}
+
+func (fake *FakeBuild) AnnotationsCalls(stub func() atc.BuildAnnotations) {
+	fake.annotationsMutex.Lock()
+	defer fake.annotationsMutex.Unlock()
+	fake.AnnotationsStub = stub
+}
+
+func (fake *FakeBuild) AnnotationsReturns(result1 atc.BuildAnnotations) {
+	fake.annotationsMutex.Lock()
+	defer fake.annotationsMutex.Unlock()
+	fake.AnnotationsStub = nil
+	fake.annotationsReturns = struct {
+		result1 atc.BuildAnnotations
+	}{result1}
+}
+
+func (fake *FakeBuild) AnnotationsReturnsOnCall(i int, result1 atc.BuildAnnotations) {
+	fake.annotationsMutex.Lock()
+	defer fake.annotationsMutex.Unlock()
+	fake.AnnotationsStub = nil
+	if fake.annotationsReturnsOnCall == nil {
+		fake.annotationsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildAnnotations
+		})
+	}
+	fake.annotationsReturnsOnCall[i] = struct {
+		result1 atc.BuildAnnotations
+	}{result1}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -744,10 +1167,309 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 			result2 error
 		})
 	}
-	fake.artifactsReturnsOnCall[i] = struct {
-		result1 []db.WorkerArtifact
-		result2 error
-	}{result1, result2}
+	fake.artifactsReturnsOnCall[i] = struct {
+		result1 []db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) AutoRetryAttempt() int {
+	fake.autoRetryAttemptMutex.Lock()
+	ret, specificReturn := fake.autoRetryAttemptReturnsOnCall[len(fake.autoRetryAttemptArgsForCall)]
+	fake.autoRetryAttemptArgsForCall = append(fake.autoRetryAttemptArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AutoRetryAttempt", []interface{}{})
+	fake.autoRetryAttemptMutex.Unlock()
+	if fake.AutoRetryAttemptStub != nil {
+		return fake.AutoRetryAttemptStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.autoRetryAttemptReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AutoRetryAttemptCallCount() int {
+	fake.autoRetryAttemptMutex.RLock()
+	defer fake.autoRetryAttemptMutex.RUnlock()
+	return len(fake.autoRetryAttemptArgsForCall)
+}
+
+func (fake *FakeBuild) AutoRetryAttemptCalls(stub func() int) {
+	fake.autoRetryAttemptMutex.Lock()
+	defer fake.autoRetryAttemptMutex.Unlock()
+	fake.AutoRetryAttemptStub = stub
+}
+
+func (fake *FakeBuild) AutoRetryAttemptReturns(result1 int) {
+	fake.autoRetryAttemptMutex.Lock()
+	defer fake.autoRetryAttemptMutex.Unlock()
+	fake.AutoRetryAttemptStub = nil
+	fake.autoRetryAttemptReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) AutoRetryAttemptReturnsOnCall(i int, result1 int) {
+	fake.autoRetryAttemptMutex.Lock()
+	defer fake.autoRetryAttemptMutex.Unlock()
+	fake.AutoRetryAttemptStub = nil
+	if fake.autoRetryAttemptReturnsOnCall == nil {
+		fake.autoRetryAttemptReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.autoRetryAttemptReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) Checkpoint() (atc.BuildCheckpoint, bool, error) {
+	fake.checkpointMutex.Lock()
+	ret, specificReturn := fake.checkpointReturnsOnCall[len(fake.checkpointArgsForCall)]
+	fake.checkpointArgsForCall = append(fake.checkpointArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Checkpoint", []interface{}{})
+	fake.checkpointMutex.Unlock()
+	if fake.CheckpointStub != nil {
+		return fake.CheckpointStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.checkpointReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) CheckpointCallCount() int {
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	return len(fake.checkpointArgsForCall)
+}
+
+func (fake *FakeBuild) CheckpointCalls(stub func() (atc.BuildCheckpoint, bool, error)) {
+	fake.checkpointMutex.Lock()
+	defer fake.checkpointMutex.Unlock()
+	fake.CheckpointStub = stub
+}
+
+func (fake *FakeBuild) CheckpointReturns(result1 atc.BuildCheckpoint, result2 bool, result3 error) {
+	fake.checkpointMutex.Lock()
+	defer fake.checkpointMutex.Unlock()
+	fake.CheckpointStub = nil
+	fake.checkpointReturns = struct {
+		result1 atc.BuildCheckpoint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) CheckpointReturnsOnCall(i int, result1 atc.BuildCheckpoint, result2 bool, result3 error) {
+	fake.checkpointMutex.Lock()
+	defer fake.checkpointMutex.Unlock()
+	fake.CheckpointStub = nil
+	if fake.checkpointReturnsOnCall == nil {
+		fake.checkpointReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildCheckpoint
+			result2 bool
+			result3 error
+		})
+	}
+	fake.checkpointReturnsOnCall[i] = struct {
+		result1 atc.BuildCheckpoint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ClaimConcurrencyPools(arg1 []string) (bool, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.claimConcurrencyPoolsMutex.Lock()
+	ret, specificReturn := fake.claimConcurrencyPoolsReturnsOnCall[len(fake.claimConcurrencyPoolsArgsForCall)]
+	fake.claimConcurrencyPoolsArgsForCall = append(fake.claimConcurrencyPoolsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("ClaimConcurrencyPools", []interface{}{arg1Copy})
+	fake.claimConcurrencyPoolsMutex.Unlock()
+	if fake.ClaimConcurrencyPoolsStub != nil {
+		return fake.ClaimConcurrencyPoolsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.claimConcurrencyPoolsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ClaimConcurrencyPoolsCallCount() int {
+	fake.claimConcurrencyPoolsMutex.RLock()
+	defer fake.claimConcurrencyPoolsMutex.RUnlock()
+	return len(fake.claimConcurrencyPoolsArgsForCall)
+}
+
+func (fake *FakeBuild) ClaimConcurrencyPoolsCalls(stub func([]string) (bool, error)) {
+	fake.claimConcurrencyPoolsMutex.Lock()
+	defer fake.claimConcurrencyPoolsMutex.Unlock()
+	fake.ClaimConcurrencyPoolsStub = stub
+}
+
+func (fake *FakeBuild) ClaimConcurrencyPoolsArgsForCall(i int) []string {
+	fake.claimConcurrencyPoolsMutex.RLock()
+	defer fake.claimConcurrencyPoolsMutex.RUnlock()
+	argsForCall := fake.claimConcurrencyPoolsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ClaimConcurrencyPoolsReturns(result1 bool, result2 error) {
+	fake.claimConcurrencyPoolsMutex.Lock()
+	defer fake.claimConcurrencyPoolsMutex.Unlock()
+	fake.ClaimConcurrencyPoolsStub = nil
+	fake.claimConcurrencyPoolsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ClaimConcurrencyPoolsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.claimConcurrencyPoolsMutex.Lock()
+	defer fake.claimConcurrencyPoolsMutex.Unlock()
+	fake.ClaimConcurrencyPoolsStub = nil
+	if fake.claimConcurrencyPoolsReturnsOnCall == nil {
+		fake.claimConcurrencyPoolsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.claimConcurrencyPoolsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ClaimStepConcurrencyPools(arg1 atc.PlanID, arg2 []string) (bool, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.claimStepConcurrencyPoolsMutex.Lock()
+	ret, specificReturn := fake.claimStepConcurrencyPoolsReturnsOnCall[len(fake.claimStepConcurrencyPoolsArgsForCall)]
+	fake.claimStepConcurrencyPoolsArgsForCall = append(fake.claimStepConcurrencyPoolsArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("ClaimStepConcurrencyPools", []interface{}{arg1, arg2Copy})
+	fake.claimStepConcurrencyPoolsMutex.Unlock()
+	if fake.ClaimStepConcurrencyPoolsStub != nil {
+		return fake.ClaimStepConcurrencyPoolsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.claimStepConcurrencyPoolsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ClaimStepConcurrencyPoolsCallCount() int {
+	fake.claimStepConcurrencyPoolsMutex.RLock()
+	defer fake.claimStepConcurrencyPoolsMutex.RUnlock()
+	return len(fake.claimStepConcurrencyPoolsArgsForCall)
+}
+
+func (fake *FakeBuild) ClaimStepConcurrencyPoolsCalls(stub func(atc.PlanID, []string) (bool, error)) {
+	fake.claimStepConcurrencyPoolsMutex.Lock()
+	defer fake.claimStepConcurrencyPoolsMutex.Unlock()
+	fake.ClaimStepConcurrencyPoolsStub = stub
+}
+
+func (fake *FakeBuild) ClaimStepConcurrencyPoolsArgsForCall(i int) (atc.PlanID, []string) {
+	fake.claimStepConcurrencyPoolsMutex.RLock()
+	defer fake.claimStepConcurrencyPoolsMutex.RUnlock()
+	argsForCall := fake.claimStepConcurrencyPoolsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) ClaimStepConcurrencyPoolsReturns(result1 bool, result2 error) {
+	fake.claimStepConcurrencyPoolsMutex.Lock()
+	defer fake.claimStepConcurrencyPoolsMutex.Unlock()
+	fake.ClaimStepConcurrencyPoolsStub = nil
+	fake.claimStepConcurrencyPoolsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ClaimStepConcurrencyPoolsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.claimStepConcurrencyPoolsMutex.Lock()
+	defer fake.claimStepConcurrencyPoolsMutex.Unlock()
+	fake.ClaimStepConcurrencyPoolsStub = nil
+	if fake.claimStepConcurrencyPoolsReturnsOnCall == nil {
+		fake.claimStepConcurrencyPoolsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.claimStepConcurrencyPoolsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) CreateTime() time.Time {
+	fake.createTimeMutex.Lock()
+	ret, specificReturn := fake.createTimeReturnsOnCall[len(fake.createTimeArgsForCall)]
+	fake.createTimeArgsForCall = append(fake.createTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreateTime", []interface{}{})
+	fake.createTimeMutex.Unlock()
+	if fake.CreateTimeStub != nil {
+		return fake.CreateTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) CreateTimeCallCount() int {
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
+	return len(fake.createTimeArgsForCall)
+}
+
+func (fake *FakeBuild) CreateTimeCalls(stub func() time.Time) {
+	fake.createTimeMutex.Lock()
+	defer fake.createTimeMutex.Unlock()
+	fake.CreateTimeStub = stub
+}
+
+func (fake *FakeBuild) CreateTimeReturns(result1 time.Time) {
+	fake.createTimeMutex.Lock()
+	defer fake.createTimeMutex.Unlock()
+	fake.CreateTimeStub = nil
+	fake.createTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) CreateTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.createTimeMutex.Lock()
+	defer fake.createTimeMutex.Unlock()
+	fake.CreateTimeStub = nil
+	if fake.createTimeReturnsOnCall == nil {
+		fake.createTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
 }
 
 func (fake *FakeBuild) Delete() (bool, error) {
@@ -857,6 +1579,122 @@ func (fake *FakeBuild) EndTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ErrorCode() atc.ErrorCode {
+	fake.errorCodeMutex.Lock()
+	ret, specificReturn := fake.errorCodeReturnsOnCall[len(fake.errorCodeArgsForCall)]
+	fake.errorCodeArgsForCall = append(fake.errorCodeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ErrorCode", []interface{}{})
+	fake.errorCodeMutex.Unlock()
+	if fake.ErrorCodeStub != nil {
+		return fake.ErrorCodeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.errorCodeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ErrorCodeCallCount() int {
+	fake.errorCodeMutex.RLock()
+	defer fake.errorCodeMutex.RUnlock()
+	return len(fake.errorCodeArgsForCall)
+}
+
+func (fake *FakeBuild) ErrorCodeCalls(stub func() atc.ErrorCode) {
+	fake.errorCodeMutex.Lock()
+	defer fake.errorCodeMutex.Unlock()
+	fake.ErrorCodeStub = stub
+}
+
+func (fake *FakeBuild) ErrorCodeReturns(result1 atc.ErrorCode) {
+	fake.errorCodeMutex.Lock()
+	defer fake.errorCodeMutex.Unlock()
+	fake.ErrorCodeStub = nil
+	fake.errorCodeReturns = struct {
+		result1 atc.ErrorCode
+	}{result1}
+}
+
+func (fake *FakeBuild) ErrorCodeReturnsOnCall(i int, result1 atc.ErrorCode) {
+	fake.errorCodeMutex.Lock()
+	defer fake.errorCodeMutex.Unlock()
+	fake.ErrorCodeStub = nil
+	if fake.errorCodeReturnsOnCall == nil {
+		fake.errorCodeReturnsOnCall = make(map[int]struct {
+			result1 atc.ErrorCode
+		})
+	}
+	fake.errorCodeReturnsOnCall[i] = struct {
+		result1 atc.ErrorCode
+	}{result1}
+}
+
+func (fake *FakeBuild) ErrorPending(arg1 atc.ErrorCode, arg2 string) (bool, error) {
+	fake.errorPendingMutex.Lock()
+	ret, specificReturn := fake.errorPendingReturnsOnCall[len(fake.errorPendingArgsForCall)]
+	fake.errorPendingArgsForCall = append(fake.errorPendingArgsForCall, struct {
+		arg1 atc.ErrorCode
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ErrorPending", []interface{}{arg1, arg2})
+	fake.errorPendingMutex.Unlock()
+	if fake.ErrorPendingStub != nil {
+		return fake.ErrorPendingStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.errorPendingReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ErrorPendingCallCount() int {
+	fake.errorPendingMutex.RLock()
+	defer fake.errorPendingMutex.RUnlock()
+	return len(fake.errorPendingArgsForCall)
+}
+
+func (fake *FakeBuild) ErrorPendingCalls(stub func(atc.ErrorCode, string) (bool, error)) {
+	fake.errorPendingMutex.Lock()
+	defer fake.errorPendingMutex.Unlock()
+	fake.ErrorPendingStub = stub
+}
+
+func (fake *FakeBuild) ErrorPendingArgsForCall(i int) (atc.ErrorCode, string) {
+	fake.errorPendingMutex.RLock()
+	defer fake.errorPendingMutex.RUnlock()
+	argsForCall := fake.errorPendingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) ErrorPendingReturns(result1 bool, result2 error) {
+	fake.errorPendingMutex.Lock()
+	defer fake.errorPendingMutex.Unlock()
+	fake.ErrorPendingStub = nil
+	fake.errorPendingReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ErrorPendingReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.errorPendingMutex.Lock()
+	defer fake.errorPendingMutex.Unlock()
+	fake.ErrorPendingStub = nil
+	if fake.errorPendingReturnsOnCall == nil {
+		fake.errorPendingReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.errorPendingReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
 	fake.eventsMutex.Lock()
 	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
@@ -1084,6 +1922,214 @@ func (fake *FakeBuild) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ImageOverrides() atc.TaskImageOverrides {
+	fake.imageOverridesMutex.Lock()
+	ret, specificReturn := fake.imageOverridesReturnsOnCall[len(fake.imageOverridesArgsForCall)]
+	fake.imageOverridesArgsForCall = append(fake.imageOverridesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageOverrides", []interface{}{})
+	fake.imageOverridesMutex.Unlock()
+	if fake.ImageOverridesStub != nil {
+		return fake.ImageOverridesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageOverridesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ImageOverridesCallCount() int {
+	fake.imageOverridesMutex.RLock()
+	defer fake.imageOverridesMutex.RUnlock()
+	return len(fake.imageOverridesArgsForCall)
+}
+
+func (fake *FakeBuild) ImageOverridesCalls(stub func() atc.TaskImageOverrides) {
+	fake.imageOverridesMutex.Lock()
+	defer fake.imageOverridesMutex.Unlock()
+	fake.ImageOverridesStub = stub
+}
+
+func (fake *FakeBuild) ImageOverridesReturns(result1 atc.TaskImageOverrides) {
+	fake.imageOverridesMutex.Lock()
+	defer fake.imageOverridesMutex.Unlock()
+	fake.ImageOverridesStub = nil
+	fake.imageOverridesReturns = struct {
+		result1 atc.TaskImageOverrides
+	}{result1}
+}
+
+func (fake *FakeBuild) ImageOverridesReturnsOnCall(i int, result1 atc.TaskImageOverrides) {
+	fake.imageOverridesMutex.Lock()
+	defer fake.imageOverridesMutex.Unlock()
+	fake.ImageOverridesStub = nil
+	if fake.imageOverridesReturnsOnCall == nil {
+		fake.imageOverridesReturnsOnCall = make(map[int]struct {
+			result1 atc.TaskImageOverrides
+		})
+	}
+	fake.imageOverridesReturnsOnCall[i] = struct {
+		result1 atc.TaskImageOverrides
+	}{result1}
+}
+
+func (fake *FakeBuild) InputArtifacts() atc.InputArtifacts {
+	fake.inputArtifactsMutex.Lock()
+	ret, specificReturn := fake.inputArtifactsReturnsOnCall[len(fake.inputArtifactsArgsForCall)]
+	fake.inputArtifactsArgsForCall = append(fake.inputArtifactsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("InputArtifacts", []interface{}{})
+	fake.inputArtifactsMutex.Unlock()
+	if fake.InputArtifactsStub != nil {
+		return fake.InputArtifactsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.inputArtifactsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) InputArtifactsCallCount() int {
+	fake.inputArtifactsMutex.RLock()
+	defer fake.inputArtifactsMutex.RUnlock()
+	return len(fake.inputArtifactsArgsForCall)
+}
+
+func (fake *FakeBuild) InputArtifactsCalls(stub func() atc.InputArtifacts) {
+	fake.inputArtifactsMutex.Lock()
+	defer fake.inputArtifactsMutex.Unlock()
+	fake.InputArtifactsStub = stub
+}
+
+func (fake *FakeBuild) InputArtifactsReturns(result1 atc.InputArtifacts) {
+	fake.inputArtifactsMutex.Lock()
+	defer fake.inputArtifactsMutex.Unlock()
+	fake.InputArtifactsStub = nil
+	fake.inputArtifactsReturns = struct {
+		result1 atc.InputArtifacts
+	}{result1}
+}
+
+func (fake *FakeBuild) InputArtifactsReturnsOnCall(i int, result1 atc.InputArtifacts) {
+	fake.inputArtifactsMutex.Lock()
+	defer fake.inputArtifactsMutex.Unlock()
+	fake.InputArtifactsStub = nil
+	if fake.inputArtifactsReturnsOnCall == nil {
+		fake.inputArtifactsReturnsOnCall = make(map[int]struct {
+			result1 atc.InputArtifacts
+		})
+	}
+	fake.inputArtifactsReturnsOnCall[i] = struct {
+		result1 atc.InputArtifacts
+	}{result1}
+}
+
+func (fake *FakeBuild) InputFallbacks() atc.InputFallbacks {
+	fake.inputFallbacksMutex.Lock()
+	ret, specificReturn := fake.inputFallbacksReturnsOnCall[len(fake.inputFallbacksArgsForCall)]
+	fake.inputFallbacksArgsForCall = append(fake.inputFallbacksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("InputFallbacks", []interface{}{})
+	fake.inputFallbacksMutex.Unlock()
+	if fake.InputFallbacksStub != nil {
+		return fake.InputFallbacksStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.inputFallbacksReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) InputFallbacksCallCount() int {
+	fake.inputFallbacksMutex.RLock()
+	defer fake.inputFallbacksMutex.RUnlock()
+	return len(fake.inputFallbacksArgsForCall)
+}
+
+func (fake *FakeBuild) InputFallbacksCalls(stub func() atc.InputFallbacks) {
+	fake.inputFallbacksMutex.Lock()
+	defer fake.inputFallbacksMutex.Unlock()
+	fake.InputFallbacksStub = stub
+}
+
+func (fake *FakeBuild) InputFallbacksReturns(result1 atc.InputFallbacks) {
+	fake.inputFallbacksMutex.Lock()
+	defer fake.inputFallbacksMutex.Unlock()
+	fake.InputFallbacksStub = nil
+	fake.inputFallbacksReturns = struct {
+		result1 atc.InputFallbacks
+	}{result1}
+}
+
+func (fake *FakeBuild) InputFallbacksReturnsOnCall(i int, result1 atc.InputFallbacks) {
+	fake.inputFallbacksMutex.Lock()
+	defer fake.inputFallbacksMutex.Unlock()
+	fake.InputFallbacksStub = nil
+	if fake.inputFallbacksReturnsOnCall == nil {
+		fake.inputFallbacksReturnsOnCall = make(map[int]struct {
+			result1 atc.InputFallbacks
+		})
+	}
+	fake.inputFallbacksReturnsOnCall[i] = struct {
+		result1 atc.InputFallbacks
+	}{result1}
+}
+
+func (fake *FakeBuild) InputOverrides() atc.InputVersionOverrides {
+	fake.inputOverridesMutex.Lock()
+	ret, specificReturn := fake.inputOverridesReturnsOnCall[len(fake.inputOverridesArgsForCall)]
+	fake.inputOverridesArgsForCall = append(fake.inputOverridesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("InputOverrides", []interface{}{})
+	fake.inputOverridesMutex.Unlock()
+	if fake.InputOverridesStub != nil {
+		return fake.InputOverridesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.inputOverridesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) InputOverridesCallCount() int {
+	fake.inputOverridesMutex.RLock()
+	defer fake.inputOverridesMutex.RUnlock()
+	return len(fake.inputOverridesArgsForCall)
+}
+
+func (fake *FakeBuild) InputOverridesCalls(stub func() atc.InputVersionOverrides) {
+	fake.inputOverridesMutex.Lock()
+	defer fake.inputOverridesMutex.Unlock()
+	fake.InputOverridesStub = stub
+}
+
+func (fake *FakeBuild) InputOverridesReturns(result1 atc.InputVersionOverrides) {
+	fake.inputOverridesMutex.Lock()
+	defer fake.inputOverridesMutex.Unlock()
+	fake.InputOverridesStub = nil
+	fake.inputOverridesReturns = struct {
+		result1 atc.InputVersionOverrides
+	}{result1}
+}
+
+func (fake *FakeBuild) InputOverridesReturnsOnCall(i int, result1 atc.InputVersionOverrides) {
+	fake.inputOverridesMutex.Lock()
+	defer fake.inputOverridesMutex.Unlock()
+	fake.InputOverridesStub = nil
+	if fake.inputOverridesReturnsOnCall == nil {
+		fake.inputOverridesReturnsOnCall = make(map[int]struct {
+			result1 atc.InputVersionOverrides
+		})
+	}
+	fake.inputOverridesReturnsOnCall[i] = struct {
+		result1 atc.InputVersionOverrides
+	}{result1}
+}
+
 func (fake *FakeBuild) Interceptible() (bool, error) {
 	fake.interceptibleMutex.Lock()
 	ret, specificReturn := fake.interceptibleReturnsOnCall[len(fake.interceptibleArgsForCall)]
@@ -1407,6 +2453,58 @@ func (fake *FakeBuild) IsNewerThanLastCheckOfReturnsOnCall(i int, result1 bool)
 	}{result1}
 }
 
+func (fake *FakeBuild) IsPaused() bool {
+	fake.isPausedMutex.Lock()
+	ret, specificReturn := fake.isPausedReturnsOnCall[len(fake.isPausedArgsForCall)]
+	fake.isPausedArgsForCall = append(fake.isPausedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IsPaused", []interface{}{})
+	fake.isPausedMutex.Unlock()
+	if fake.IsPausedStub != nil {
+		return fake.IsPausedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isPausedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsPausedCallCount() int {
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
+	return len(fake.isPausedArgsForCall)
+}
+
+func (fake *FakeBuild) IsPausedCalls(stub func() bool) {
+	fake.isPausedMutex.Lock()
+	defer fake.isPausedMutex.Unlock()
+	fake.IsPausedStub = stub
+}
+
+func (fake *FakeBuild) IsPausedReturns(result1 bool) {
+	fake.isPausedMutex.Lock()
+	defer fake.isPausedMutex.Unlock()
+	fake.IsPausedStub = nil
+	fake.isPausedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsPausedReturnsOnCall(i int, result1 bool) {
+	fake.isPausedMutex.Lock()
+	defer fake.isPausedMutex.Unlock()
+	fake.IsPausedStub = nil
+	if fake.isPausedReturnsOnCall == nil {
+		fake.isPausedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isPausedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsRunning() bool {
 	fake.isRunningMutex.Lock()
 	ret, specificReturn := fake.isRunningReturnsOnCall[len(fake.isRunningArgsForCall)]
@@ -1719,6 +2817,61 @@ func (fake *FakeBuild) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Paused() (bool, error) {
+	fake.pausedMutex.Lock()
+	ret, specificReturn := fake.pausedReturnsOnCall[len(fake.pausedArgsForCall)]
+	fake.pausedArgsForCall = append(fake.pausedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Paused", []interface{}{})
+	fake.pausedMutex.Unlock()
+	if fake.PausedStub != nil {
+		return fake.PausedStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pausedReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) PausedCallCount() int {
+	fake.pausedMutex.RLock()
+	defer fake.pausedMutex.RUnlock()
+	return len(fake.pausedArgsForCall)
+}
+
+func (fake *FakeBuild) PausedCalls(stub func() (bool, error)) {
+	fake.pausedMutex.Lock()
+	defer fake.pausedMutex.Unlock()
+	fake.PausedStub = stub
+}
+
+func (fake *FakeBuild) PausedReturns(result1 bool, result2 error) {
+	fake.pausedMutex.Lock()
+	defer fake.pausedMutex.Unlock()
+	fake.PausedStub = nil
+	fake.pausedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PausedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pausedMutex.Lock()
+	defer fake.pausedMutex.Unlock()
+	fake.PausedStub = nil
+	if fake.pausedReturnsOnCall == nil {
+		fake.pausedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pausedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2095,6 +3248,118 @@ func (fake *FakeBuild) ReapTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ReleaseConcurrencyPools() error {
+	fake.releaseConcurrencyPoolsMutex.Lock()
+	ret, specificReturn := fake.releaseConcurrencyPoolsReturnsOnCall[len(fake.releaseConcurrencyPoolsArgsForCall)]
+	fake.releaseConcurrencyPoolsArgsForCall = append(fake.releaseConcurrencyPoolsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReleaseConcurrencyPools", []interface{}{})
+	fake.releaseConcurrencyPoolsMutex.Unlock()
+	if fake.ReleaseConcurrencyPoolsStub != nil {
+		return fake.ReleaseConcurrencyPoolsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.releaseConcurrencyPoolsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ReleaseConcurrencyPoolsCallCount() int {
+	fake.releaseConcurrencyPoolsMutex.RLock()
+	defer fake.releaseConcurrencyPoolsMutex.RUnlock()
+	return len(fake.releaseConcurrencyPoolsArgsForCall)
+}
+
+func (fake *FakeBuild) ReleaseConcurrencyPoolsCalls(stub func() error) {
+	fake.releaseConcurrencyPoolsMutex.Lock()
+	defer fake.releaseConcurrencyPoolsMutex.Unlock()
+	fake.ReleaseConcurrencyPoolsStub = stub
+}
+
+func (fake *FakeBuild) ReleaseConcurrencyPoolsReturns(result1 error) {
+	fake.releaseConcurrencyPoolsMutex.Lock()
+	defer fake.releaseConcurrencyPoolsMutex.Unlock()
+	fake.ReleaseConcurrencyPoolsStub = nil
+	fake.releaseConcurrencyPoolsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReleaseConcurrencyPoolsReturnsOnCall(i int, result1 error) {
+	fake.releaseConcurrencyPoolsMutex.Lock()
+	defer fake.releaseConcurrencyPoolsMutex.Unlock()
+	fake.ReleaseConcurrencyPoolsStub = nil
+	if fake.releaseConcurrencyPoolsReturnsOnCall == nil {
+		fake.releaseConcurrencyPoolsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseConcurrencyPoolsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReleaseStepConcurrencyPools(arg1 atc.PlanID) error {
+	fake.releaseStepConcurrencyPoolsMutex.Lock()
+	ret, specificReturn := fake.releaseStepConcurrencyPoolsReturnsOnCall[len(fake.releaseStepConcurrencyPoolsArgsForCall)]
+	fake.releaseStepConcurrencyPoolsArgsForCall = append(fake.releaseStepConcurrencyPoolsArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("ReleaseStepConcurrencyPools", []interface{}{arg1})
+	fake.releaseStepConcurrencyPoolsMutex.Unlock()
+	if fake.ReleaseStepConcurrencyPoolsStub != nil {
+		return fake.ReleaseStepConcurrencyPoolsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.releaseStepConcurrencyPoolsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ReleaseStepConcurrencyPoolsCallCount() int {
+	fake.releaseStepConcurrencyPoolsMutex.RLock()
+	defer fake.releaseStepConcurrencyPoolsMutex.RUnlock()
+	return len(fake.releaseStepConcurrencyPoolsArgsForCall)
+}
+
+func (fake *FakeBuild) ReleaseStepConcurrencyPoolsCalls(stub func(atc.PlanID) error) {
+	fake.releaseStepConcurrencyPoolsMutex.Lock()
+	defer fake.releaseStepConcurrencyPoolsMutex.Unlock()
+	fake.ReleaseStepConcurrencyPoolsStub = stub
+}
+
+func (fake *FakeBuild) ReleaseStepConcurrencyPoolsArgsForCall(i int) atc.PlanID {
+	fake.releaseStepConcurrencyPoolsMutex.RLock()
+	defer fake.releaseStepConcurrencyPoolsMutex.RUnlock()
+	argsForCall := fake.releaseStepConcurrencyPoolsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ReleaseStepConcurrencyPoolsReturns(result1 error) {
+	fake.releaseStepConcurrencyPoolsMutex.Lock()
+	defer fake.releaseStepConcurrencyPoolsMutex.Unlock()
+	fake.ReleaseStepConcurrencyPoolsStub = nil
+	fake.releaseStepConcurrencyPoolsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReleaseStepConcurrencyPoolsReturnsOnCall(i int, result1 error) {
+	fake.releaseStepConcurrencyPoolsMutex.Lock()
+	defer fake.releaseStepConcurrencyPoolsMutex.Unlock()
+	fake.ReleaseStepConcurrencyPoolsStub = nil
+	if fake.releaseStepConcurrencyPoolsReturnsOnCall == nil {
+		fake.releaseStepConcurrencyPoolsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseStepConcurrencyPoolsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -2150,6 +3415,58 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	ret, specificReturn := fake.rerunOfReturnsOnCall[len(fake.rerunOfArgsForCall)]
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rerunOfReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfCalls(stub func() int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = stub
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfReturnsOnCall(i int, result1 int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = nil
+	if fake.rerunOfReturnsOnCall == nil {
+		fake.rerunOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.rerunOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -2208,6 +3525,181 @@ func (fake *FakeBuild) ResourcesReturnsOnCall(i int, result1 []db.BuildInput, re
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) ResumeNotifier() (db.Notifier, error) {
+	fake.resumeNotifierMutex.Lock()
+	ret, specificReturn := fake.resumeNotifierReturnsOnCall[len(fake.resumeNotifierArgsForCall)]
+	fake.resumeNotifierArgsForCall = append(fake.resumeNotifierArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResumeNotifier", []interface{}{})
+	fake.resumeNotifierMutex.Unlock()
+	if fake.ResumeNotifierStub != nil {
+		return fake.ResumeNotifierStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resumeNotifierReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ResumeNotifierCallCount() int {
+	fake.resumeNotifierMutex.RLock()
+	defer fake.resumeNotifierMutex.RUnlock()
+	return len(fake.resumeNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ResumeNotifierCalls(stub func() (db.Notifier, error)) {
+	fake.resumeNotifierMutex.Lock()
+	defer fake.resumeNotifierMutex.Unlock()
+	fake.ResumeNotifierStub = stub
+}
+
+func (fake *FakeBuild) ResumeNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.resumeNotifierMutex.Lock()
+	defer fake.resumeNotifierMutex.Unlock()
+	fake.ResumeNotifierStub = nil
+	fake.resumeNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResumeNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.resumeNotifierMutex.Lock()
+	defer fake.resumeNotifierMutex.Unlock()
+	fake.ResumeNotifierStub = nil
+	if fake.resumeNotifierReturnsOnCall == nil {
+		fake.resumeNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.resumeNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveCheckpoint(arg1 atc.BuildCheckpoint) error {
+	fake.saveCheckpointMutex.Lock()
+	ret, specificReturn := fake.saveCheckpointReturnsOnCall[len(fake.saveCheckpointArgsForCall)]
+	fake.saveCheckpointArgsForCall = append(fake.saveCheckpointArgsForCall, struct {
+		arg1 atc.BuildCheckpoint
+	}{arg1})
+	fake.recordInvocation("SaveCheckpoint", []interface{}{arg1})
+	fake.saveCheckpointMutex.Unlock()
+	if fake.SaveCheckpointStub != nil {
+		return fake.SaveCheckpointStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveCheckpointReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveCheckpointCallCount() int {
+	fake.saveCheckpointMutex.RLock()
+	defer fake.saveCheckpointMutex.RUnlock()
+	return len(fake.saveCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) SaveCheckpointCalls(stub func(atc.BuildCheckpoint) error) {
+	fake.saveCheckpointMutex.Lock()
+	defer fake.saveCheckpointMutex.Unlock()
+	fake.SaveCheckpointStub = stub
+}
+
+func (fake *FakeBuild) SaveCheckpointArgsForCall(i int) atc.BuildCheckpoint {
+	fake.saveCheckpointMutex.RLock()
+	defer fake.saveCheckpointMutex.RUnlock()
+	argsForCall := fake.saveCheckpointArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveCheckpointReturns(result1 error) {
+	fake.saveCheckpointMutex.Lock()
+	defer fake.saveCheckpointMutex.Unlock()
+	fake.SaveCheckpointStub = nil
+	fake.saveCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveCheckpointReturnsOnCall(i int, result1 error) {
+	fake.saveCheckpointMutex.Lock()
+	defer fake.saveCheckpointMutex.Unlock()
+	fake.SaveCheckpointStub = nil
+	if fake.saveCheckpointReturnsOnCall == nil {
+		fake.saveCheckpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveCheckpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveErrorCode(arg1 atc.ErrorCode) error {
+	fake.saveErrorCodeMutex.Lock()
+	ret, specificReturn := fake.saveErrorCodeReturnsOnCall[len(fake.saveErrorCodeArgsForCall)]
+	fake.saveErrorCodeArgsForCall = append(fake.saveErrorCodeArgsForCall, struct {
+		arg1 atc.ErrorCode
+	}{arg1})
+	fake.recordInvocation("SaveErrorCode", []interface{}{arg1})
+	fake.saveErrorCodeMutex.Unlock()
+	if fake.SaveErrorCodeStub != nil {
+		return fake.SaveErrorCodeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveErrorCodeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveErrorCodeCallCount() int {
+	fake.saveErrorCodeMutex.RLock()
+	defer fake.saveErrorCodeMutex.RUnlock()
+	return len(fake.saveErrorCodeArgsForCall)
+}
+
+func (fake *FakeBuild) SaveErrorCodeCalls(stub func(atc.ErrorCode) error) {
+	fake.saveErrorCodeMutex.Lock()
+	defer fake.saveErrorCodeMutex.Unlock()
+	fake.SaveErrorCodeStub = stub
+}
+
+func (fake *FakeBuild) SaveErrorCodeArgsForCall(i int) atc.ErrorCode {
+	fake.saveErrorCodeMutex.RLock()
+	defer fake.saveErrorCodeMutex.RUnlock()
+	argsForCall := fake.saveErrorCodeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveErrorCodeReturns(result1 error) {
+	fake.saveErrorCodeMutex.Lock()
+	defer fake.saveErrorCodeMutex.Unlock()
+	fake.SaveErrorCodeStub = nil
+	fake.saveErrorCodeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveErrorCodeReturnsOnCall(i int, result1 error) {
+	fake.saveErrorCodeMutex.Lock()
+	defer fake.saveErrorCodeMutex.Unlock()
+	fake.SaveErrorCodeStub = nil
+	if fake.saveErrorCodeReturnsOnCall == nil {
+		fake.saveErrorCodeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveErrorCodeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -2394,6 +3886,188 @@ func (fake *FakeBuild) SaveOutputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SaveResourceFetch(arg1 string, arg2 time.Duration, arg3 bool) error {
+	fake.saveResourceFetchMutex.Lock()
+	ret, specificReturn := fake.saveResourceFetchReturnsOnCall[len(fake.saveResourceFetchArgsForCall)]
+	fake.saveResourceFetchArgsForCall = append(fake.saveResourceFetchArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SaveResourceFetch", []interface{}{arg1, arg2, arg3})
+	fake.saveResourceFetchMutex.Unlock()
+	if fake.SaveResourceFetchStub != nil {
+		return fake.SaveResourceFetchStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveResourceFetchReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveResourceFetchCallCount() int {
+	fake.saveResourceFetchMutex.RLock()
+	defer fake.saveResourceFetchMutex.RUnlock()
+	return len(fake.saveResourceFetchArgsForCall)
+}
+
+func (fake *FakeBuild) SaveResourceFetchCalls(stub func(string, time.Duration, bool) error) {
+	fake.saveResourceFetchMutex.Lock()
+	defer fake.saveResourceFetchMutex.Unlock()
+	fake.SaveResourceFetchStub = stub
+}
+
+func (fake *FakeBuild) SaveResourceFetchArgsForCall(i int) (string, time.Duration, bool) {
+	fake.saveResourceFetchMutex.RLock()
+	defer fake.saveResourceFetchMutex.RUnlock()
+	argsForCall := fake.saveResourceFetchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) SaveResourceFetchReturns(result1 error) {
+	fake.saveResourceFetchMutex.Lock()
+	defer fake.saveResourceFetchMutex.Unlock()
+	fake.SaveResourceFetchStub = nil
+	fake.saveResourceFetchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveResourceFetchReturnsOnCall(i int, result1 error) {
+	fake.saveResourceFetchMutex.Lock()
+	defer fake.saveResourceFetchMutex.Unlock()
+	fake.SaveResourceFetchStub = nil
+	if fake.saveResourceFetchReturnsOnCall == nil {
+		fake.saveResourceFetchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveResourceFetchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SavedEvents(arg1 ...atc.EventType) ([]db.SavedEvent, error) {
+	var arg1Copy []atc.EventType
+	if arg1 != nil {
+		arg1Copy = make([]atc.EventType, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.savedEventsMutex.Lock()
+	ret, specificReturn := fake.savedEventsReturnsOnCall[len(fake.savedEventsArgsForCall)]
+	fake.savedEventsArgsForCall = append(fake.savedEventsArgsForCall, struct {
+		arg1 []atc.EventType
+	}{arg1Copy})
+	fake.recordInvocation("SavedEvents", []interface{}{arg1Copy})
+	fake.savedEventsMutex.Unlock()
+	if fake.SavedEventsStub != nil {
+		return fake.SavedEventsStub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.savedEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SavedEventsCallCount() int {
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
+	return len(fake.savedEventsArgsForCall)
+}
+
+func (fake *FakeBuild) SavedEventsCalls(stub func(...atc.EventType) ([]db.SavedEvent, error)) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = stub
+}
+
+func (fake *FakeBuild) SavedEventsArgsForCall(i int) []atc.EventType {
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
+	argsForCall := fake.savedEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SavedEventsReturns(result1 []db.SavedEvent, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	fake.savedEventsReturns = struct {
+		result1 []db.SavedEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SavedEventsReturnsOnCall(i int, result1 []db.SavedEvent, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	if fake.savedEventsReturnsOnCall == nil {
+		fake.savedEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.SavedEvent
+			result2 error
+		})
+	}
+	fake.savedEventsReturnsOnCall[i] = struct {
+		result1 []db.SavedEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SchedulableTime() time.Time {
+	fake.schedulableTimeMutex.Lock()
+	ret, specificReturn := fake.schedulableTimeReturnsOnCall[len(fake.schedulableTimeArgsForCall)]
+	fake.schedulableTimeArgsForCall = append(fake.schedulableTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SchedulableTime", []interface{}{})
+	fake.schedulableTimeMutex.Unlock()
+	if fake.SchedulableTimeStub != nil {
+		return fake.SchedulableTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.schedulableTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SchedulableTimeCallCount() int {
+	fake.schedulableTimeMutex.RLock()
+	defer fake.schedulableTimeMutex.RUnlock()
+	return len(fake.schedulableTimeArgsForCall)
+}
+
+func (fake *FakeBuild) SchedulableTimeCalls(stub func() time.Time) {
+	fake.schedulableTimeMutex.Lock()
+	defer fake.schedulableTimeMutex.Unlock()
+	fake.SchedulableTimeStub = stub
+}
+
+func (fake *FakeBuild) SchedulableTimeReturns(result1 time.Time) {
+	fake.schedulableTimeMutex.Lock()
+	defer fake.schedulableTimeMutex.Unlock()
+	fake.SchedulableTimeStub = nil
+	fake.schedulableTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) SchedulableTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.schedulableTimeMutex.Lock()
+	defer fake.schedulableTimeMutex.Unlock()
+	fake.SchedulableTimeStub = nil
+	if fake.schedulableTimeReturnsOnCall == nil {
+		fake.schedulableTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.schedulableTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeBuild) Schedule() (bool, error) {
 	fake.scheduleMutex.Lock()
 	ret, specificReturn := fake.scheduleReturnsOnCall[len(fake.scheduleArgsForCall)]
@@ -2621,6 +4295,126 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetPaused(arg1 bool) error {
+	fake.setPausedMutex.Lock()
+	ret, specificReturn := fake.setPausedReturnsOnCall[len(fake.setPausedArgsForCall)]
+	fake.setPausedArgsForCall = append(fake.setPausedArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("SetPaused", []interface{}{arg1})
+	fake.setPausedMutex.Unlock()
+	if fake.SetPausedStub != nil {
+		return fake.SetPausedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setPausedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetPausedCallCount() int {
+	fake.setPausedMutex.RLock()
+	defer fake.setPausedMutex.RUnlock()
+	return len(fake.setPausedArgsForCall)
+}
+
+func (fake *FakeBuild) SetPausedCalls(stub func(bool) error) {
+	fake.setPausedMutex.Lock()
+	defer fake.setPausedMutex.Unlock()
+	fake.SetPausedStub = stub
+}
+
+func (fake *FakeBuild) SetPausedArgsForCall(i int) bool {
+	fake.setPausedMutex.RLock()
+	defer fake.setPausedMutex.RUnlock()
+	argsForCall := fake.setPausedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetPausedReturns(result1 error) {
+	fake.setPausedMutex.Lock()
+	defer fake.setPausedMutex.Unlock()
+	fake.SetPausedStub = nil
+	fake.setPausedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetPausedReturnsOnCall(i int, result1 error) {
+	fake.setPausedMutex.Lock()
+	defer fake.setPausedMutex.Unlock()
+	fake.SetPausedStub = nil
+	if fake.setPausedReturnsOnCall == nil {
+		fake.setPausedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPausedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetSchedulable(arg1 bool) error {
+	fake.setSchedulableMutex.Lock()
+	ret, specificReturn := fake.setSchedulableReturnsOnCall[len(fake.setSchedulableArgsForCall)]
+	fake.setSchedulableArgsForCall = append(fake.setSchedulableArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("SetSchedulable", []interface{}{arg1})
+	fake.setSchedulableMutex.Unlock()
+	if fake.SetSchedulableStub != nil {
+		return fake.SetSchedulableStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setSchedulableReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetSchedulableCallCount() int {
+	fake.setSchedulableMutex.RLock()
+	defer fake.setSchedulableMutex.RUnlock()
+	return len(fake.setSchedulableArgsForCall)
+}
+
+func (fake *FakeBuild) SetSchedulableCalls(stub func(bool) error) {
+	fake.setSchedulableMutex.Lock()
+	defer fake.setSchedulableMutex.Unlock()
+	fake.SetSchedulableStub = stub
+}
+
+func (fake *FakeBuild) SetSchedulableArgsForCall(i int) bool {
+	fake.setSchedulableMutex.RLock()
+	defer fake.setSchedulableMutex.RUnlock()
+	argsForCall := fake.setSchedulableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetSchedulableReturns(result1 error) {
+	fake.setSchedulableMutex.Lock()
+	defer fake.setSchedulableMutex.Unlock()
+	fake.SetSchedulableStub = nil
+	fake.setSchedulableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetSchedulableReturnsOnCall(i int, result1 error) {
+	fake.setSchedulableMutex.Lock()
+	defer fake.setSchedulableMutex.Unlock()
+	fake.SetSchedulableStub = nil
+	if fake.setSchedulableReturnsOnCall == nil {
+		fake.setSchedulableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSchedulableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Start(arg1 atc.Plan) (bool, error) {
 	fake.startMutex.Lock()
 	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
@@ -2892,6 +4686,58 @@ func (fake *FakeBuild) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Token() string {
+	fake.tokenMutex.Lock()
+	ret, specificReturn := fake.tokenReturnsOnCall[len(fake.tokenArgsForCall)]
+	fake.tokenArgsForCall = append(fake.tokenArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Token", []interface{}{})
+	fake.tokenMutex.Unlock()
+	if fake.TokenStub != nil {
+		return fake.TokenStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.tokenReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TokenCallCount() int {
+	fake.tokenMutex.RLock()
+	defer fake.tokenMutex.RUnlock()
+	return len(fake.tokenArgsForCall)
+}
+
+func (fake *FakeBuild) TokenCalls(stub func() string) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = stub
+}
+
+func (fake *FakeBuild) TokenReturns(result1 string) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = nil
+	fake.tokenReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) TokenReturnsOnCall(i int, result1 string) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = nil
+	if fake.tokenReturnsOnCall == nil {
+		fake.tokenReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.tokenReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) UseInputs(arg1 []db.BuildInput) error {
 	var arg1Copy []db.BuildInput
 	if arg1 != nil {
@@ -2957,6 +4803,58 @@ func (fake *FakeBuild) UseInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) WorkerOverrides() atc.StepWorkerOverrides {
+	fake.workerOverridesMutex.Lock()
+	ret, specificReturn := fake.workerOverridesReturnsOnCall[len(fake.workerOverridesArgsForCall)]
+	fake.workerOverridesArgsForCall = append(fake.workerOverridesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WorkerOverrides", []interface{}{})
+	fake.workerOverridesMutex.Unlock()
+	if fake.WorkerOverridesStub != nil {
+		return fake.WorkerOverridesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.workerOverridesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) WorkerOverridesCallCount() int {
+	fake.workerOverridesMutex.RLock()
+	defer fake.workerOverridesMutex.RUnlock()
+	return len(fake.workerOverridesArgsForCall)
+}
+
+func (fake *FakeBuild) WorkerOverridesCalls(stub func() atc.StepWorkerOverrides) {
+	fake.workerOverridesMutex.Lock()
+	defer fake.workerOverridesMutex.Unlock()
+	fake.WorkerOverridesStub = stub
+}
+
+func (fake *FakeBuild) WorkerOverridesReturns(result1 atc.StepWorkerOverrides) {
+	fake.workerOverridesMutex.Lock()
+	defer fake.workerOverridesMutex.Unlock()
+	fake.WorkerOverridesStub = nil
+	fake.workerOverridesReturns = struct {
+		result1 atc.StepWorkerOverrides
+	}{result1}
+}
+
+func (fake *FakeBuild) WorkerOverridesReturnsOnCall(i int, result1 atc.StepWorkerOverrides) {
+	fake.workerOverridesMutex.Lock()
+	defer fake.workerOverridesMutex.Unlock()
+	fake.WorkerOverridesStub = nil
+	if fake.workerOverridesReturnsOnCall == nil {
+		fake.workerOverridesReturnsOnCall = make(map[int]struct {
+			result1 atc.StepWorkerOverrides
+		})
+	}
+	fake.workerOverridesReturnsOnCall[i] = struct {
+		result1 atc.StepWorkerOverrides
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2964,14 +4862,32 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
+	fake.annotateMutex.RLock()
+	defer fake.annotateMutex.RUnlock()
+	fake.annotationsMutex.RLock()
+	defer fake.annotationsMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.autoRetryAttemptMutex.RLock()
+	defer fake.autoRetryAttemptMutex.RUnlock()
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	fake.claimConcurrencyPoolsMutex.RLock()
+	defer fake.claimConcurrencyPoolsMutex.RUnlock()
+	fake.claimStepConcurrencyPoolsMutex.RLock()
+	defer fake.claimStepConcurrencyPoolsMutex.RUnlock()
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
+	fake.errorCodeMutex.RLock()
+	defer fake.errorCodeMutex.RUnlock()
+	fake.errorPendingMutex.RLock()
+	defer fake.errorPendingMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
 	fake.finishMutex.RLock()
@@ -2980,6 +4896,14 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.hasPlanMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.imageOverridesMutex.RLock()
+	defer fake.imageOverridesMutex.RUnlock()
+	fake.inputArtifactsMutex.RLock()
+	defer fake.inputArtifactsMutex.RUnlock()
+	fake.inputFallbacksMutex.RLock()
+	defer fake.inputFallbacksMutex.RUnlock()
+	fake.inputOverridesMutex.RLock()
+	defer fake.inputOverridesMutex.RUnlock()
 	fake.interceptibleMutex.RLock()
 	defer fake.interceptibleMutex.RUnlock()
 	fake.isAbortedMutex.RLock()
@@ -2992,6 +4916,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isManuallyTriggeredMutex.RUnlock()
 	fake.isNewerThanLastCheckOfMutex.RLock()
 	defer fake.isNewerThanLastCheckOfMutex.RUnlock()
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
@@ -3004,6 +4930,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.markAsAbortedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.pausedMutex.RLock()
+	defer fake.pausedMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -3018,16 +4946,34 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.publicPlanMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
 	defer fake.reapTimeMutex.RUnlock()
+	fake.releaseConcurrencyPoolsMutex.RLock()
+	defer fake.releaseConcurrencyPoolsMutex.RUnlock()
+	fake.releaseStepConcurrencyPoolsMutex.RLock()
+	defer fake.releaseStepConcurrencyPoolsMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.resumeNotifierMutex.RLock()
+	defer fake.resumeNotifierMutex.RUnlock()
+	fake.saveCheckpointMutex.RLock()
+	defer fake.saveCheckpointMutex.RUnlock()
+	fake.saveErrorCodeMutex.RLock()
+	defer fake.saveErrorCodeMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
+	fake.saveResourceFetchMutex.RLock()
+	defer fake.saveResourceFetchMutex.RUnlock()
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
+	fake.schedulableTimeMutex.RLock()
+	defer fake.schedulableTimeMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
 	fake.schemaMutex.RLock()
@@ -3036,6 +4982,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setPausedMutex.RLock()
+	defer fake.setPausedMutex.RUnlock()
+	fake.setSchedulableMutex.RLock()
+	defer fake.setSchedulableMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	fake.startTimeMutex.RLock()
@@ -3046,8 +4996,12 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.tokenMutex.RLock()
+	defer fake.tokenMutex.RUnlock()
 	fake.useInputsMutex.RLock()
 	defer fake.useInputsMutex.RUnlock()
+	fake.workerOverridesMutex.RLock()
+	defer fake.workerOverridesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
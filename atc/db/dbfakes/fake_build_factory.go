@@ -3,7 +3,9 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -38,6 +40,16 @@ type FakeBuildFactory struct {
 		result2 bool
 		result3 error
 	}
+	ClearSupersededPlansStub        func() error
+	clearSupersededPlansMutex       sync.RWMutex
+	clearSupersededPlansArgsForCall []struct {
+	}
+	clearSupersededPlansReturns struct {
+		result1 error
+	}
+	clearSupersededPlansReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAllStartedBuildsStub        func() ([]db.Build, error)
 	getAllStartedBuildsMutex       sync.RWMutex
 	getAllStartedBuildsArgsForCall []struct {
@@ -50,6 +62,20 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetAutoRetryableBuildsStub        func([]atc.ErrorCode, int) ([]db.Build, error)
+	getAutoRetryableBuildsMutex       sync.RWMutex
+	getAutoRetryableBuildsArgsForCall []struct {
+		arg1 []atc.ErrorCode
+		arg2 int
+	}
+	getAutoRetryableBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getAutoRetryableBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	GetDrainableBuildsStub        func() ([]db.Build, error)
 	getDrainableBuildsMutex       sync.RWMutex
 	getDrainableBuildsArgsForCall []struct {
@@ -62,6 +88,19 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetStalePendingBuildsStub        func(time.Duration) ([]db.Build, error)
+	getStalePendingBuildsMutex       sync.RWMutex
+	getStalePendingBuildsArgsForCall []struct {
+		arg1 time.Duration
+	}
+	getStalePendingBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getStalePendingBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	MarkNonInterceptibleBuildsStub        func() error
 	markNonInterceptibleBuildsMutex       sync.RWMutex
 	markNonInterceptibleBuildsArgsForCall []struct {
@@ -194,6 +233,8 @@ func (fake *FakeBuildFactory) Build(arg1 int) (db.Build, bool, error) {
 func (fake *FakeBuildFactory) BuildCallCount() int {
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.clearSupersededPlansMutex.RLock()
+	defer fake.clearSupersededPlansMutex.RUnlock()
 	return len(fake.buildArgsForCall)
 }
 
@@ -239,6 +280,58 @@ func (fake *FakeBuildFactory) BuildReturnsOnCall(i int, result1 db.Build, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) ClearSupersededPlans() error {
+	fake.clearSupersededPlansMutex.Lock()
+	ret, specificReturn := fake.clearSupersededPlansReturnsOnCall[len(fake.clearSupersededPlansArgsForCall)]
+	fake.clearSupersededPlansArgsForCall = append(fake.clearSupersededPlansArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ClearSupersededPlans", []interface{}{})
+	fake.clearSupersededPlansMutex.Unlock()
+	if fake.ClearSupersededPlansStub != nil {
+		return fake.ClearSupersededPlansStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.clearSupersededPlansReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildFactory) ClearSupersededPlansCallCount() int {
+	fake.clearSupersededPlansMutex.RLock()
+	defer fake.clearSupersededPlansMutex.RUnlock()
+	return len(fake.clearSupersededPlansArgsForCall)
+}
+
+func (fake *FakeBuildFactory) ClearSupersededPlansCalls(stub func() error) {
+	fake.clearSupersededPlansMutex.Lock()
+	defer fake.clearSupersededPlansMutex.Unlock()
+	fake.ClearSupersededPlansStub = stub
+}
+
+func (fake *FakeBuildFactory) ClearSupersededPlansReturns(result1 error) {
+	fake.clearSupersededPlansMutex.Lock()
+	defer fake.clearSupersededPlansMutex.Unlock()
+	fake.ClearSupersededPlansStub = nil
+	fake.clearSupersededPlansReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildFactory) ClearSupersededPlansReturnsOnCall(i int, result1 error) {
+	fake.clearSupersededPlansMutex.Lock()
+	defer fake.clearSupersededPlansMutex.Unlock()
+	fake.ClearSupersededPlansStub = nil
+	if fake.clearSupersededPlansReturnsOnCall == nil {
+		fake.clearSupersededPlansReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearSupersededPlansReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildFactory) GetAllStartedBuilds() ([]db.Build, error) {
 	fake.getAllStartedBuildsMutex.Lock()
 	ret, specificReturn := fake.getAllStartedBuildsReturnsOnCall[len(fake.getAllStartedBuildsArgsForCall)]
@@ -294,6 +387,75 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetAutoRetryableBuilds(arg1 []atc.ErrorCode, arg2 int) ([]db.Build, error) {
+	var arg1Copy []atc.ErrorCode
+	if arg1 != nil {
+		arg1Copy = make([]atc.ErrorCode, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.getAutoRetryableBuildsMutex.Lock()
+	ret, specificReturn := fake.getAutoRetryableBuildsReturnsOnCall[len(fake.getAutoRetryableBuildsArgsForCall)]
+	fake.getAutoRetryableBuildsArgsForCall = append(fake.getAutoRetryableBuildsArgsForCall, struct {
+		arg1 []atc.ErrorCode
+		arg2 int
+	}{arg1Copy, arg2})
+	fake.recordInvocation("GetAutoRetryableBuilds", []interface{}{arg1Copy, arg2})
+	fake.getAutoRetryableBuildsMutex.Unlock()
+	if fake.GetAutoRetryableBuildsStub != nil {
+		return fake.GetAutoRetryableBuildsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getAutoRetryableBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetAutoRetryableBuildsCallCount() int {
+	fake.getAutoRetryableBuildsMutex.RLock()
+	defer fake.getAutoRetryableBuildsMutex.RUnlock()
+	return len(fake.getAutoRetryableBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetAutoRetryableBuildsCalls(stub func([]atc.ErrorCode, int) ([]db.Build, error)) {
+	fake.getAutoRetryableBuildsMutex.Lock()
+	defer fake.getAutoRetryableBuildsMutex.Unlock()
+	fake.GetAutoRetryableBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetAutoRetryableBuildsArgsForCall(i int) ([]atc.ErrorCode, int) {
+	fake.getAutoRetryableBuildsMutex.RLock()
+	defer fake.getAutoRetryableBuildsMutex.RUnlock()
+	argsForCall := fake.getAutoRetryableBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildFactory) GetAutoRetryableBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getAutoRetryableBuildsMutex.Lock()
+	defer fake.getAutoRetryableBuildsMutex.Unlock()
+	fake.GetAutoRetryableBuildsStub = nil
+	fake.getAutoRetryableBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetAutoRetryableBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getAutoRetryableBuildsMutex.Lock()
+	defer fake.getAutoRetryableBuildsMutex.Unlock()
+	fake.GetAutoRetryableBuildsStub = nil
+	if fake.getAutoRetryableBuildsReturnsOnCall == nil {
+		fake.getAutoRetryableBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getAutoRetryableBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) GetDrainableBuilds() ([]db.Build, error) {
 	fake.getDrainableBuildsMutex.Lock()
 	ret, specificReturn := fake.getDrainableBuildsReturnsOnCall[len(fake.getDrainableBuildsArgsForCall)]
@@ -349,6 +511,69 @@ func (fake *FakeBuildFactory) GetDrainableBuildsReturnsOnCall(i int, result1 []d
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetStalePendingBuilds(arg1 time.Duration) ([]db.Build, error) {
+	fake.getStalePendingBuildsMutex.Lock()
+	ret, specificReturn := fake.getStalePendingBuildsReturnsOnCall[len(fake.getStalePendingBuildsArgsForCall)]
+	fake.getStalePendingBuildsArgsForCall = append(fake.getStalePendingBuildsArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("GetStalePendingBuilds", []interface{}{arg1})
+	fake.getStalePendingBuildsMutex.Unlock()
+	if fake.GetStalePendingBuildsStub != nil {
+		return fake.GetStalePendingBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStalePendingBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetStalePendingBuildsCallCount() int {
+	fake.getStalePendingBuildsMutex.RLock()
+	defer fake.getStalePendingBuildsMutex.RUnlock()
+	return len(fake.getStalePendingBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetStalePendingBuildsCalls(stub func(time.Duration) ([]db.Build, error)) {
+	fake.getStalePendingBuildsMutex.Lock()
+	defer fake.getStalePendingBuildsMutex.Unlock()
+	fake.GetStalePendingBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetStalePendingBuildsArgsForCall(i int) time.Duration {
+	fake.getStalePendingBuildsMutex.RLock()
+	defer fake.getStalePendingBuildsMutex.RUnlock()
+	argsForCall := fake.getStalePendingBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) GetStalePendingBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getStalePendingBuildsMutex.Lock()
+	defer fake.getStalePendingBuildsMutex.Unlock()
+	fake.GetStalePendingBuildsStub = nil
+	fake.getStalePendingBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetStalePendingBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getStalePendingBuildsMutex.Lock()
+	defer fake.getStalePendingBuildsMutex.Unlock()
+	fake.GetStalePendingBuildsStub = nil
+	if fake.getStalePendingBuildsReturnsOnCall == nil {
+		fake.getStalePendingBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getStalePendingBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) MarkNonInterceptibleBuilds() error {
 	fake.markNonInterceptibleBuildsMutex.Lock()
 	ret, specificReturn := fake.markNonInterceptibleBuildsReturnsOnCall[len(fake.markNonInterceptibleBuildsArgsForCall)]
@@ -548,8 +773,12 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.buildMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
+	fake.getAutoRetryableBuildsMutex.RLock()
+	defer fake.getAutoRetryableBuildsMutex.RUnlock()
 	fake.getDrainableBuildsMutex.RLock()
 	defer fake.getDrainableBuildsMutex.RUnlock()
+	fake.getStalePendingBuildsMutex.RLock()
+	defer fake.getStalePendingBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
 	fake.publicBuildsMutex.RLock()
@@ -110,6 +110,16 @@ type FakeCheck struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	OutputStub        func() string
+	outputMutex       sync.RWMutex
+	outputArgsForCall []struct {
+	}
+	outputReturns struct {
+		result1 string
+	}
+	outputReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -162,6 +172,28 @@ type FakeCheck struct {
 	resourceConfigScopeIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	SaveDeletedVersionsStub        func([]atc.Version) error
+	saveDeletedVersionsMutex       sync.RWMutex
+	saveDeletedVersionsArgsForCall []struct {
+		arg1 []atc.Version
+	}
+	saveDeletedVersionsReturns struct {
+		result1 error
+	}
+	saveDeletedVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveOutputStub        func(string) error
+	saveOutputMutex       sync.RWMutex
+	saveOutputArgsForCall []struct {
+		arg1 string
+	}
+	saveOutputReturns struct {
+		result1 error
+	}
+	saveOutputReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveVersionsStub        func([]atc.Version) error
 	saveVersionsMutex       sync.RWMutex
 	saveVersionsArgsForCall []struct {
@@ -173,6 +205,17 @@ type FakeCheck struct {
 	saveVersionsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveWorkerStub        func(string) error
+	saveWorkerMutex       sync.RWMutex
+	saveWorkerArgsForCall []struct {
+		arg1 string
+	}
+	saveWorkerReturns struct {
+		result1 error
+	}
+	saveWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -233,6 +276,16 @@ type FakeCheck struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct {
+	}
+	workerNameReturns struct {
+		result1 string
+	}
+	workerNameReturnsOnCall map[int]struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -730,6 +783,58 @@ func (fake *FakeCheck) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeCheck) Output() string {
+	fake.outputMutex.Lock()
+	ret, specificReturn := fake.outputReturnsOnCall[len(fake.outputArgsForCall)]
+	fake.outputArgsForCall = append(fake.outputArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Output", []interface{}{})
+	fake.outputMutex.Unlock()
+	if fake.OutputStub != nil {
+		return fake.OutputStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.outputReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) OutputCallCount() int {
+	fake.outputMutex.RLock()
+	defer fake.outputMutex.RUnlock()
+	return len(fake.outputArgsForCall)
+}
+
+func (fake *FakeCheck) OutputCalls(stub func() string) {
+	fake.outputMutex.Lock()
+	defer fake.outputMutex.Unlock()
+	fake.OutputStub = stub
+}
+
+func (fake *FakeCheck) OutputReturns(result1 string) {
+	fake.outputMutex.Lock()
+	defer fake.outputMutex.Unlock()
+	fake.OutputStub = nil
+	fake.outputReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCheck) OutputReturnsOnCall(i int, result1 string) {
+	fake.outputMutex.Lock()
+	defer fake.outputMutex.Unlock()
+	fake.OutputStub = nil
+	if fake.outputReturnsOnCall == nil {
+		fake.outputReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.outputReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeCheck) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -993,6 +1098,131 @@ func (fake *FakeCheck) ResourceConfigScopeIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeCheck) SaveDeletedVersions(arg1 []atc.Version) error {
+	var arg1Copy []atc.Version
+	if arg1 != nil {
+		arg1Copy = make([]atc.Version, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveDeletedVersionsMutex.Lock()
+	ret, specificReturn := fake.saveDeletedVersionsReturnsOnCall[len(fake.saveDeletedVersionsArgsForCall)]
+	fake.saveDeletedVersionsArgsForCall = append(fake.saveDeletedVersionsArgsForCall, struct {
+		arg1 []atc.Version
+	}{arg1Copy})
+	fake.recordInvocation("SaveDeletedVersions", []interface{}{arg1Copy})
+	fake.saveDeletedVersionsMutex.Unlock()
+	if fake.SaveDeletedVersionsStub != nil {
+		return fake.SaveDeletedVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveDeletedVersionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) SaveDeletedVersionsCallCount() int {
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	return len(fake.saveDeletedVersionsArgsForCall)
+}
+
+func (fake *FakeCheck) SaveDeletedVersionsCalls(stub func([]atc.Version) error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = stub
+}
+
+func (fake *FakeCheck) SaveDeletedVersionsArgsForCall(i int) []atc.Version {
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	argsForCall := fake.saveDeletedVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheck) SaveDeletedVersionsReturns(result1 error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = nil
+	fake.saveDeletedVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SaveDeletedVersionsReturnsOnCall(i int, result1 error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = nil
+	if fake.saveDeletedVersionsReturnsOnCall == nil {
+		fake.saveDeletedVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveDeletedVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SaveOutput(arg1 string) error {
+	fake.saveOutputMutex.Lock()
+	ret, specificReturn := fake.saveOutputReturnsOnCall[len(fake.saveOutputArgsForCall)]
+	fake.saveOutputArgsForCall = append(fake.saveOutputArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SaveOutput", []interface{}{arg1})
+	fake.saveOutputMutex.Unlock()
+	if fake.SaveOutputStub != nil {
+		return fake.SaveOutputStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveOutputReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) SaveOutputCallCount() int {
+	fake.saveOutputMutex.RLock()
+	defer fake.saveOutputMutex.RUnlock()
+	return len(fake.saveOutputArgsForCall)
+}
+
+func (fake *FakeCheck) SaveOutputCalls(stub func(string) error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = stub
+}
+
+func (fake *FakeCheck) SaveOutputArgsForCall(i int) string {
+	fake.saveOutputMutex.RLock()
+	defer fake.saveOutputMutex.RUnlock()
+	argsForCall := fake.saveOutputArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheck) SaveOutputReturns(result1 error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = nil
+	fake.saveOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SaveOutputReturnsOnCall(i int, result1 error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = nil
+	if fake.saveOutputReturnsOnCall == nil {
+		fake.saveOutputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOutputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCheck) SaveVersions(arg1 []atc.Version) error {
 	var arg1Copy []atc.Version
 	if arg1 != nil {
@@ -1058,6 +1288,66 @@ func (fake *FakeCheck) SaveVersionsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeCheck) SaveWorker(arg1 string) error {
+	fake.saveWorkerMutex.Lock()
+	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
+	fake.saveWorkerArgsForCall = append(fake.saveWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SaveWorker", []interface{}{arg1})
+	fake.saveWorkerMutex.Unlock()
+	if fake.SaveWorkerStub != nil {
+		return fake.SaveWorkerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveWorkerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) SaveWorkerCallCount() int {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	return len(fake.saveWorkerArgsForCall)
+}
+
+func (fake *FakeCheck) SaveWorkerCalls(stub func(string) error) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = stub
+}
+
+func (fake *FakeCheck) SaveWorkerArgsForCall(i int) string {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	argsForCall := fake.saveWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheck) SaveWorkerReturns(result1 error) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = nil
+	fake.saveWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SaveWorkerReturnsOnCall(i int, result1 error) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = nil
+	if fake.saveWorkerReturnsOnCall == nil {
+		fake.saveWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCheck) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -1370,6 +1660,58 @@ func (fake *FakeCheck) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeCheck) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	ret, specificReturn := fake.workerNameReturnsOnCall[len(fake.workerNameArgsForCall)]
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WorkerName", []interface{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.workerNameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeCheck) WorkerNameCalls(stub func() string) {
+	fake.workerNameMutex.Lock()
+	defer fake.workerNameMutex.Unlock()
+	fake.WorkerNameStub = stub
+}
+
+func (fake *FakeCheck) WorkerNameReturns(result1 string) {
+	fake.workerNameMutex.Lock()
+	defer fake.workerNameMutex.Unlock()
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCheck) WorkerNameReturnsOnCall(i int, result1 string) {
+	fake.workerNameMutex.Lock()
+	defer fake.workerNameMutex.Unlock()
+	fake.WorkerNameStub = nil
+	if fake.workerNameReturnsOnCall == nil {
+		fake.workerNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.workerNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeCheck) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1391,6 +1733,8 @@ func (fake *FakeCheck) Invocations() map[string][][]interface{} {
 	defer fake.finishWithErrorMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.outputMutex.RLock()
+	defer fake.outputMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.planMutex.RLock()
@@ -1401,8 +1745,14 @@ func (fake *FakeCheck) Invocations() map[string][][]interface{} {
 	defer fake.resourceConfigIDMutex.RUnlock()
 	fake.resourceConfigScopeIDMutex.RLock()
 	defer fake.resourceConfigScopeIDMutex.RUnlock()
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	fake.saveOutputMutex.RLock()
+	defer fake.saveOutputMutex.RUnlock()
 	fake.saveVersionsMutex.RLock()
 	defer fake.saveVersionsMutex.RUnlock()
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.startMutex.RLock()
@@ -1415,6 +1765,8 @@ func (fake *FakeCheck) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
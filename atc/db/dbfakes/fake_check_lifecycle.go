@@ -20,6 +20,17 @@ type FakeCheckLifecycle struct {
 	removeExpiredChecksReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RemoveExpiredResourceFetchesStub        func(time.Duration) error
+	removeExpiredResourceFetchesMutex       sync.RWMutex
+	removeExpiredResourceFetchesArgsForCall []struct {
+		arg1 time.Duration
+	}
+	removeExpiredResourceFetchesReturns struct {
+		result1 error
+	}
+	removeExpiredResourceFetchesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -84,11 +95,73 @@ func (fake *FakeCheckLifecycle) RemoveExpiredChecksReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCheckLifecycle) RemoveExpiredResourceFetches(arg1 time.Duration) error {
+	fake.removeExpiredResourceFetchesMutex.Lock()
+	ret, specificReturn := fake.removeExpiredResourceFetchesReturnsOnCall[len(fake.removeExpiredResourceFetchesArgsForCall)]
+	fake.removeExpiredResourceFetchesArgsForCall = append(fake.removeExpiredResourceFetchesArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("RemoveExpiredResourceFetches", []interface{}{arg1})
+	fake.removeExpiredResourceFetchesMutex.Unlock()
+	if fake.RemoveExpiredResourceFetchesStub != nil {
+		return fake.RemoveExpiredResourceFetchesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.removeExpiredResourceFetchesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckLifecycle) RemoveExpiredResourceFetchesCallCount() int {
+	fake.removeExpiredResourceFetchesMutex.RLock()
+	defer fake.removeExpiredResourceFetchesMutex.RUnlock()
+	return len(fake.removeExpiredResourceFetchesArgsForCall)
+}
+
+func (fake *FakeCheckLifecycle) RemoveExpiredResourceFetchesCalls(stub func(time.Duration) error) {
+	fake.removeExpiredResourceFetchesMutex.Lock()
+	defer fake.removeExpiredResourceFetchesMutex.Unlock()
+	fake.RemoveExpiredResourceFetchesStub = stub
+}
+
+func (fake *FakeCheckLifecycle) RemoveExpiredResourceFetchesArgsForCall(i int) time.Duration {
+	fake.removeExpiredResourceFetchesMutex.RLock()
+	defer fake.removeExpiredResourceFetchesMutex.RUnlock()
+	argsForCall := fake.removeExpiredResourceFetchesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckLifecycle) RemoveExpiredResourceFetchesReturns(result1 error) {
+	fake.removeExpiredResourceFetchesMutex.Lock()
+	defer fake.removeExpiredResourceFetchesMutex.Unlock()
+	fake.RemoveExpiredResourceFetchesStub = nil
+	fake.removeExpiredResourceFetchesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckLifecycle) RemoveExpiredResourceFetchesReturnsOnCall(i int, result1 error) {
+	fake.removeExpiredResourceFetchesMutex.Lock()
+	defer fake.removeExpiredResourceFetchesMutex.Unlock()
+	fake.RemoveExpiredResourceFetchesStub = nil
+	if fake.removeExpiredResourceFetchesReturnsOnCall == nil {
+		fake.removeExpiredResourceFetchesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeExpiredResourceFetchesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCheckLifecycle) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.removeExpiredChecksMutex.RLock()
 	defer fake.removeExpiredChecksMutex.RUnlock()
+	fake.removeExpiredResourceFetchesMutex.RLock()
+	defer fake.removeExpiredResourceFetchesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
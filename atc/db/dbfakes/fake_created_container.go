@@ -3,11 +3,22 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeCreatedContainer struct {
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	DestroyingStub        func() (db.DestroyingContainer, error)
 	destroyingMutex       sync.RWMutex
 	destroyingArgsForCall []struct {
@@ -82,6 +93,16 @@ type FakeCreatedContainer struct {
 	metadataReturnsOnCall map[int]struct {
 		result1 db.ContainerMetadata
 	}
+	ReleaseGPUsStub        func() error
+	releaseGPUsMutex       sync.RWMutex
+	releaseGPUsArgsForCall []struct {
+	}
+	releaseGPUsReturns struct {
+		result1 error
+	}
+	releaseGPUsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StateStub        func() string
 	stateMutex       sync.RWMutex
 	stateArgsForCall []struct {
@@ -106,6 +127,58 @@ type FakeCreatedContainer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCreatedContainer) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if fake.CreatedAtStub != nil {
+		return fake.CreatedAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createdAtReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedContainer) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeCreatedContainer) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeCreatedContainer) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCreatedContainer) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCreatedContainer) Destroying() (db.DestroyingContainer, error) {
 	fake.destroyingMutex.Lock()
 	ret, specificReturn := fake.destroyingReturnsOnCall[len(fake.destroyingArgsForCall)]
@@ -476,6 +549,58 @@ func (fake *FakeCreatedContainer) MetadataReturnsOnCall(i int, result1 db.Contai
 	}{result1}
 }
 
+func (fake *FakeCreatedContainer) ReleaseGPUs() error {
+	fake.releaseGPUsMutex.Lock()
+	ret, specificReturn := fake.releaseGPUsReturnsOnCall[len(fake.releaseGPUsArgsForCall)]
+	fake.releaseGPUsArgsForCall = append(fake.releaseGPUsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReleaseGPUs", []interface{}{})
+	fake.releaseGPUsMutex.Unlock()
+	if fake.ReleaseGPUsStub != nil {
+		return fake.ReleaseGPUsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.releaseGPUsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedContainer) ReleaseGPUsCallCount() int {
+	fake.releaseGPUsMutex.RLock()
+	defer fake.releaseGPUsMutex.RUnlock()
+	return len(fake.releaseGPUsArgsForCall)
+}
+
+func (fake *FakeCreatedContainer) ReleaseGPUsCalls(stub func() error) {
+	fake.releaseGPUsMutex.Lock()
+	defer fake.releaseGPUsMutex.Unlock()
+	fake.ReleaseGPUsStub = stub
+}
+
+func (fake *FakeCreatedContainer) ReleaseGPUsReturns(result1 error) {
+	fake.releaseGPUsMutex.Lock()
+	defer fake.releaseGPUsMutex.Unlock()
+	fake.ReleaseGPUsStub = nil
+	fake.releaseGPUsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedContainer) ReleaseGPUsReturnsOnCall(i int, result1 error) {
+	fake.releaseGPUsMutex.Lock()
+	defer fake.releaseGPUsMutex.Unlock()
+	fake.ReleaseGPUsStub = nil
+	if fake.releaseGPUsReturnsOnCall == nil {
+		fake.releaseGPUsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseGPUsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedContainer) State() string {
 	fake.stateMutex.Lock()
 	ret, specificReturn := fake.stateReturnsOnCall[len(fake.stateArgsForCall)]
@@ -583,6 +708,8 @@ func (fake *FakeCreatedContainer) WorkerNameReturnsOnCall(i int, result1 string)
 func (fake *FakeCreatedContainer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
 	fake.destroyingMutex.RLock()
 	defer fake.destroyingMutex.RUnlock()
 	fake.discontinueMutex.RLock()
@@ -597,6 +724,8 @@ func (fake *FakeCreatedContainer) Invocations() map[string][][]interface{} {
 	defer fake.markAsHijackedMutex.RUnlock()
 	fake.metadataMutex.RLock()
 	defer fake.metadataMutex.RUnlock()
+	fake.releaseGPUsMutex.RLock()
+	defer fake.releaseGPUsMutex.RUnlock()
 	fake.stateMutex.RLock()
 	defer fake.stateMutex.RUnlock()
 	fake.workerNameMutex.RLock()
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
@@ -44,6 +45,16 @@ type FakeCreatedVolume struct {
 		result1 db.CreatingVolume
 		result2 error
 	}
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	DestroyingStub        func() (db.DestroyingVolume, error)
 	destroyingMutex       sync.RWMutex
 	destroyingArgsForCall []struct {
@@ -104,6 +115,21 @@ type FakeCreatedVolume struct {
 	initializeTaskCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	LeaseStub        func(time.Duration) (db.VolumeLease, bool, error)
+	leaseMutex       sync.RWMutex
+	leaseArgsForCall []struct {
+		arg1 time.Duration
+	}
+	leaseReturns struct {
+		result1 db.VolumeLease
+		result2 bool
+		result3 error
+	}
+	leaseReturnsOnCall map[int]struct {
+		result1 db.VolumeLease
+		result2 bool
+		result3 error
+	}
 	ParentHandleStub        func() string
 	parentHandleMutex       sync.RWMutex
 	parentHandleArgsForCall []struct {
@@ -367,6 +393,58 @@ func (fake *FakeCreatedVolume) CreateChildForContainerReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if fake.CreatedAtStub != nil {
+		return fake.CreatedAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createdAtReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeCreatedVolume) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) Destroying() (db.DestroyingVolume, error) {
 	fake.destroyingMutex.Lock()
 	ret, specificReturn := fake.destroyingReturnsOnCall[len(fake.destroyingArgsForCall)]
@@ -660,6 +738,72 @@ func (fake *FakeCreatedVolume) InitializeTaskCacheReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) Lease(arg1 time.Duration) (db.VolumeLease, bool, error) {
+	fake.leaseMutex.Lock()
+	ret, specificReturn := fake.leaseReturnsOnCall[len(fake.leaseArgsForCall)]
+	fake.leaseArgsForCall = append(fake.leaseArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("Lease", []interface{}{arg1})
+	fake.leaseMutex.Unlock()
+	if fake.LeaseStub != nil {
+		return fake.LeaseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.leaseReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeCreatedVolume) LeaseCallCount() int {
+	fake.leaseMutex.RLock()
+	defer fake.leaseMutex.RUnlock()
+	return len(fake.leaseArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) LeaseCalls(stub func(time.Duration) (db.VolumeLease, bool, error)) {
+	fake.leaseMutex.Lock()
+	defer fake.leaseMutex.Unlock()
+	fake.LeaseStub = stub
+}
+
+func (fake *FakeCreatedVolume) LeaseArgsForCall(i int) time.Duration {
+	fake.leaseMutex.RLock()
+	defer fake.leaseMutex.RUnlock()
+	argsForCall := fake.leaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCreatedVolume) LeaseReturns(result1 db.VolumeLease, result2 bool, result3 error) {
+	fake.leaseMutex.Lock()
+	defer fake.leaseMutex.Unlock()
+	fake.LeaseStub = nil
+	fake.leaseReturns = struct {
+		result1 db.VolumeLease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCreatedVolume) LeaseReturnsOnCall(i int, result1 db.VolumeLease, result2 bool, result3 error) {
+	fake.leaseMutex.Lock()
+	defer fake.leaseMutex.Unlock()
+	fake.LeaseStub = nil
+	if fake.leaseReturnsOnCall == nil {
+		fake.leaseReturnsOnCall = make(map[int]struct {
+			result1 db.VolumeLease
+			result2 bool
+			result3 error
+		})
+	}
+	fake.leaseReturnsOnCall[i] = struct {
+		result1 db.VolumeLease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCreatedVolume) ParentHandle() string {
 	fake.parentHandleMutex.Lock()
 	ret, specificReturn := fake.parentHandleReturnsOnCall[len(fake.parentHandleArgsForCall)]
@@ -1097,6 +1241,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.containerHandleMutex.RUnlock()
 	fake.createChildForContainerMutex.RLock()
 	defer fake.createChildForContainerMutex.RUnlock()
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
 	fake.destroyingMutex.RLock()
 	defer fake.destroyingMutex.RUnlock()
 	fake.handleMutex.RLock()
@@ -1107,6 +1253,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
+	fake.leaseMutex.RLock()
+	defer fake.leaseMutex.RUnlock()
 	fake.parentHandleMutex.RLock()
 	defer fake.parentHandleMutex.RUnlock()
 	fake.pathMutex.RLock()
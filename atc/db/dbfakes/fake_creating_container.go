@@ -3,11 +3,25 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeCreatingContainer struct {
+	AllocateGPUsStub        func(int) ([]int, error)
+	allocateGPUsMutex       sync.RWMutex
+	allocateGPUsArgsForCall []struct {
+		arg1 int
+	}
+	allocateGPUsReturns struct {
+		result1 []int
+		result2 error
+	}
+	allocateGPUsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	CreatedStub        func() (db.CreatedContainer, error)
 	createdMutex       sync.RWMutex
 	createdArgsForCall []struct {
@@ -20,6 +34,16 @@ type FakeCreatingContainer struct {
 		result1 db.CreatedContainer
 		result2 error
 	}
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	FailedStub        func() (db.FailedContainer, error)
 	failedMutex       sync.RWMutex
 	failedArgsForCall []struct {
@@ -86,6 +110,69 @@ type FakeCreatingContainer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCreatingContainer) AllocateGPUs(arg1 int) ([]int, error) {
+	fake.allocateGPUsMutex.Lock()
+	ret, specificReturn := fake.allocateGPUsReturnsOnCall[len(fake.allocateGPUsArgsForCall)]
+	fake.allocateGPUsArgsForCall = append(fake.allocateGPUsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("AllocateGPUs", []interface{}{arg1})
+	fake.allocateGPUsMutex.Unlock()
+	if fake.AllocateGPUsStub != nil {
+		return fake.AllocateGPUsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.allocateGPUsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCreatingContainer) AllocateGPUsCallCount() int {
+	fake.allocateGPUsMutex.RLock()
+	defer fake.allocateGPUsMutex.RUnlock()
+	return len(fake.allocateGPUsArgsForCall)
+}
+
+func (fake *FakeCreatingContainer) AllocateGPUsCalls(stub func(int) ([]int, error)) {
+	fake.allocateGPUsMutex.Lock()
+	defer fake.allocateGPUsMutex.Unlock()
+	fake.AllocateGPUsStub = stub
+}
+
+func (fake *FakeCreatingContainer) AllocateGPUsArgsForCall(i int) int {
+	fake.allocateGPUsMutex.RLock()
+	defer fake.allocateGPUsMutex.RUnlock()
+	argsForCall := fake.allocateGPUsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCreatingContainer) AllocateGPUsReturns(result1 []int, result2 error) {
+	fake.allocateGPUsMutex.Lock()
+	defer fake.allocateGPUsMutex.Unlock()
+	fake.AllocateGPUsStub = nil
+	fake.allocateGPUsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatingContainer) AllocateGPUsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.allocateGPUsMutex.Lock()
+	defer fake.allocateGPUsMutex.Unlock()
+	fake.AllocateGPUsStub = nil
+	if fake.allocateGPUsReturnsOnCall == nil {
+		fake.allocateGPUsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.allocateGPUsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCreatingContainer) Created() (db.CreatedContainer, error) {
 	fake.createdMutex.Lock()
 	ret, specificReturn := fake.createdReturnsOnCall[len(fake.createdArgsForCall)]
@@ -141,6 +228,58 @@ func (fake *FakeCreatingContainer) CreatedReturnsOnCall(i int, result1 db.Create
 	}{result1, result2}
 }
 
+func (fake *FakeCreatingContainer) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if fake.CreatedAtStub != nil {
+		return fake.CreatedAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createdAtReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatingContainer) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeCreatingContainer) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeCreatingContainer) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCreatingContainer) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCreatingContainer) Failed() (db.FailedContainer, error) {
 	fake.failedMutex.Lock()
 	ret, specificReturn := fake.failedReturnsOnCall[len(fake.failedArgsForCall)]
@@ -459,8 +598,12 @@ func (fake *FakeCreatingContainer) WorkerNameReturnsOnCall(i int, result1 string
 func (fake *FakeCreatingContainer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.allocateGPUsMutex.RLock()
+	defer fake.allocateGPUsMutex.RUnlock()
 	fake.createdMutex.RLock()
 	defer fake.createdMutex.RUnlock()
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
 	fake.failedMutex.RLock()
 	defer fake.failedMutex.RUnlock()
 	fake.handleMutex.RLock()
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -10,6 +11,20 @@ import (
 )
 
 type FakeJob struct {
+	AutoRetryBuildStub        func(db.Build, atc.Plan) (db.Build, error)
+	autoRetryBuildMutex       sync.RWMutex
+	autoRetryBuildArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.Plan
+	}
+	autoRetryBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	autoRetryBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	BuildStub        func(string) (db.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -25,6 +40,20 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	BuildStatisticsStub        func(time.Time, time.Time) (atc.BuildStatistics, error)
+	buildStatisticsMutex       sync.RWMutex
+	buildStatisticsArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+	}
+	buildStatisticsReturns struct {
+		result1 atc.BuildStatistics
+		result2 error
+	}
+	buildStatisticsReturnsOnCall map[int]struct {
+		result1 atc.BuildStatistics
+		result2 error
+	}
 	BuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -55,6 +84,16 @@ type FakeJob struct {
 		result2 db.Pagination
 		result3 error
 	}
+	CacheInputResolutionStub        func() error
+	cacheInputResolutionMutex       sync.RWMutex
+	cacheInputResolutionArgsForCall []struct {
+	}
+	cacheInputResolutionReturns struct {
+		result1 error
+	}
+	cacheInputResolutionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ClearTaskCacheStub        func(string, string) (int64, error)
 	clearTaskCacheMutex       sync.RWMutex
 	clearTaskCacheArgsForCall []struct {
@@ -91,6 +130,22 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithOverridesStub        func(atc.InputVersionOverrides, atc.TaskImageOverrides, atc.InputArtifacts, atc.StepWorkerOverrides) (db.Build, error)
+	createBuildWithOverridesMutex       sync.RWMutex
+	createBuildWithOverridesArgsForCall []struct {
+		arg1 atc.InputVersionOverrides
+		arg2 atc.TaskImageOverrides
+		arg3 atc.InputArtifacts
+		arg4 atc.StepWorkerOverrides
+	}
+	createBuildWithOverridesReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithOverridesReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DeleteNextInputMappingStub        func() error
 	deleteNextInputMappingMutex       sync.RWMutex
 	deleteNextInputMappingArgsForCall []struct {
@@ -221,6 +276,16 @@ type FakeJob struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	InputResolutionCachedStub        func() bool
+	inputResolutionCachedMutex       sync.RWMutex
+	inputResolutionCachedArgsForCall []struct {
+	}
+	inputResolutionCachedReturns struct {
+		result1 bool
+	}
+	inputResolutionCachedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -293,6 +358,20 @@ type FakeJob struct {
 		result1 bool
 		result2 error
 	}
+	RerunBuildStub        func(db.Build, atc.Plan) (db.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.Plan
+	}
+	rerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	SaveIndependentInputMappingStub        func(algorithm.InputMapping) error
 	saveIndependentInputMappingMutex       sync.RWMutex
 	saveIndependentInputMappingArgsForCall []struct {
@@ -392,6 +471,70 @@ type FakeJob struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeJob) AutoRetryBuild(arg1 db.Build, arg2 atc.Plan) (db.Build, error) {
+	fake.autoRetryBuildMutex.Lock()
+	ret, specificReturn := fake.autoRetryBuildReturnsOnCall[len(fake.autoRetryBuildArgsForCall)]
+	fake.autoRetryBuildArgsForCall = append(fake.autoRetryBuildArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.Plan
+	}{arg1, arg2})
+	fake.recordInvocation("AutoRetryBuild", []interface{}{arg1, arg2})
+	fake.autoRetryBuildMutex.Unlock()
+	if fake.AutoRetryBuildStub != nil {
+		return fake.AutoRetryBuildStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.autoRetryBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) AutoRetryBuildCallCount() int {
+	fake.autoRetryBuildMutex.RLock()
+	defer fake.autoRetryBuildMutex.RUnlock()
+	return len(fake.autoRetryBuildArgsForCall)
+}
+
+func (fake *FakeJob) AutoRetryBuildCalls(stub func(db.Build, atc.Plan) (db.Build, error)) {
+	fake.autoRetryBuildMutex.Lock()
+	defer fake.autoRetryBuildMutex.Unlock()
+	fake.AutoRetryBuildStub = stub
+}
+
+func (fake *FakeJob) AutoRetryBuildArgsForCall(i int) (db.Build, atc.Plan) {
+	fake.autoRetryBuildMutex.RLock()
+	defer fake.autoRetryBuildMutex.RUnlock()
+	argsForCall := fake.autoRetryBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) AutoRetryBuildReturns(result1 db.Build, result2 error) {
+	fake.autoRetryBuildMutex.Lock()
+	defer fake.autoRetryBuildMutex.Unlock()
+	fake.AutoRetryBuildStub = nil
+	fake.autoRetryBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) AutoRetryBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.autoRetryBuildMutex.Lock()
+	defer fake.autoRetryBuildMutex.Unlock()
+	fake.AutoRetryBuildStub = nil
+	if fake.autoRetryBuildReturnsOnCall == nil {
+		fake.autoRetryBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.autoRetryBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Build(arg1 string) (db.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -458,6 +601,70 @@ func (fake *FakeJob) BuildReturnsOnCall(i int, result1 db.Build, result2 bool, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) BuildStatistics(arg1 time.Time, arg2 time.Time) (atc.BuildStatistics, error) {
+	fake.buildStatisticsMutex.Lock()
+	ret, specificReturn := fake.buildStatisticsReturnsOnCall[len(fake.buildStatisticsArgsForCall)]
+	fake.buildStatisticsArgsForCall = append(fake.buildStatisticsArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+	}{arg1, arg2})
+	fake.recordInvocation("BuildStatistics", []interface{}{arg1, arg2})
+	fake.buildStatisticsMutex.Unlock()
+	if fake.BuildStatisticsStub != nil {
+		return fake.BuildStatisticsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildStatisticsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) BuildStatisticsCallCount() int {
+	fake.buildStatisticsMutex.RLock()
+	defer fake.buildStatisticsMutex.RUnlock()
+	return len(fake.buildStatisticsArgsForCall)
+}
+
+func (fake *FakeJob) BuildStatisticsCalls(stub func(time.Time, time.Time) (atc.BuildStatistics, error)) {
+	fake.buildStatisticsMutex.Lock()
+	defer fake.buildStatisticsMutex.Unlock()
+	fake.BuildStatisticsStub = stub
+}
+
+func (fake *FakeJob) BuildStatisticsArgsForCall(i int) (time.Time, time.Time) {
+	fake.buildStatisticsMutex.RLock()
+	defer fake.buildStatisticsMutex.RUnlock()
+	argsForCall := fake.buildStatisticsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) BuildStatisticsReturns(result1 atc.BuildStatistics, result2 error) {
+	fake.buildStatisticsMutex.Lock()
+	defer fake.buildStatisticsMutex.Unlock()
+	fake.BuildStatisticsStub = nil
+	fake.buildStatisticsReturns = struct {
+		result1 atc.BuildStatistics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) BuildStatisticsReturnsOnCall(i int, result1 atc.BuildStatistics, result2 error) {
+	fake.buildStatisticsMutex.Lock()
+	defer fake.buildStatisticsMutex.Unlock()
+	fake.BuildStatisticsStub = nil
+	if fake.buildStatisticsReturnsOnCall == nil {
+		fake.buildStatisticsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildStatistics
+			result2 error
+		})
+	}
+	fake.buildStatisticsReturnsOnCall[i] = struct {
+		result1 atc.BuildStatistics
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Builds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -590,6 +797,58 @@ func (fake *FakeJob) BuildsWithTimeReturnsOnCall(i int, result1 []db.Build, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) CacheInputResolution() error {
+	fake.cacheInputResolutionMutex.Lock()
+	ret, specificReturn := fake.cacheInputResolutionReturnsOnCall[len(fake.cacheInputResolutionArgsForCall)]
+	fake.cacheInputResolutionArgsForCall = append(fake.cacheInputResolutionArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CacheInputResolution", []interface{}{})
+	fake.cacheInputResolutionMutex.Unlock()
+	if fake.CacheInputResolutionStub != nil {
+		return fake.CacheInputResolutionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.cacheInputResolutionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) CacheInputResolutionCallCount() int {
+	fake.cacheInputResolutionMutex.RLock()
+	defer fake.cacheInputResolutionMutex.RUnlock()
+	return len(fake.cacheInputResolutionArgsForCall)
+}
+
+func (fake *FakeJob) CacheInputResolutionCalls(stub func() error) {
+	fake.cacheInputResolutionMutex.Lock()
+	defer fake.cacheInputResolutionMutex.Unlock()
+	fake.CacheInputResolutionStub = stub
+}
+
+func (fake *FakeJob) CacheInputResolutionReturns(result1 error) {
+	fake.cacheInputResolutionMutex.Lock()
+	defer fake.cacheInputResolutionMutex.Unlock()
+	fake.CacheInputResolutionStub = nil
+	fake.cacheInputResolutionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) CacheInputResolutionReturnsOnCall(i int, result1 error) {
+	fake.cacheInputResolutionMutex.Lock()
+	defer fake.cacheInputResolutionMutex.Unlock()
+	fake.CacheInputResolutionStub = nil
+	if fake.cacheInputResolutionReturnsOnCall == nil {
+		fake.cacheInputResolutionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cacheInputResolutionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) ClearTaskCache(arg1 string, arg2 string) (int64, error) {
 	fake.clearTaskCacheMutex.Lock()
 	ret, specificReturn := fake.clearTaskCacheReturnsOnCall[len(fake.clearTaskCacheArgsForCall)]
@@ -761,6 +1020,72 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithOverrides(arg1 atc.InputVersionOverrides, arg2 atc.TaskImageOverrides, arg3 atc.InputArtifacts, arg4 atc.StepWorkerOverrides) (db.Build, error) {
+	fake.createBuildWithOverridesMutex.Lock()
+	ret, specificReturn := fake.createBuildWithOverridesReturnsOnCall[len(fake.createBuildWithOverridesArgsForCall)]
+	fake.createBuildWithOverridesArgsForCall = append(fake.createBuildWithOverridesArgsForCall, struct {
+		arg1 atc.InputVersionOverrides
+		arg2 atc.TaskImageOverrides
+		arg3 atc.InputArtifacts
+		arg4 atc.StepWorkerOverrides
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateBuildWithOverrides", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createBuildWithOverridesMutex.Unlock()
+	if fake.CreateBuildWithOverridesStub != nil {
+		return fake.CreateBuildWithOverridesStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createBuildWithOverridesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesCallCount() int {
+	fake.createBuildWithOverridesMutex.RLock()
+	defer fake.createBuildWithOverridesMutex.RUnlock()
+	return len(fake.createBuildWithOverridesArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesCalls(stub func(atc.InputVersionOverrides, atc.TaskImageOverrides, atc.InputArtifacts, atc.StepWorkerOverrides) (db.Build, error)) {
+	fake.createBuildWithOverridesMutex.Lock()
+	defer fake.createBuildWithOverridesMutex.Unlock()
+	fake.CreateBuildWithOverridesStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesArgsForCall(i int) (atc.InputVersionOverrides, atc.TaskImageOverrides, atc.InputArtifacts, atc.StepWorkerOverrides) {
+	fake.createBuildWithOverridesMutex.RLock()
+	defer fake.createBuildWithOverridesMutex.RUnlock()
+	argsForCall := fake.createBuildWithOverridesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithOverridesMutex.Lock()
+	defer fake.createBuildWithOverridesMutex.Unlock()
+	fake.CreateBuildWithOverridesStub = nil
+	fake.createBuildWithOverridesReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithOverridesMutex.Lock()
+	defer fake.createBuildWithOverridesMutex.Unlock()
+	fake.CreateBuildWithOverridesStub = nil
+	if fake.createBuildWithOverridesReturnsOnCall == nil {
+		fake.createBuildWithOverridesReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithOverridesReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DeleteNextInputMapping() error {
 	fake.deleteNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.deleteNextInputMappingReturnsOnCall[len(fake.deleteNextInputMappingArgsForCall)]
@@ -1386,6 +1711,58 @@ func (fake *FakeJob) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeJob) InputResolutionCached() bool {
+	fake.inputResolutionCachedMutex.Lock()
+	ret, specificReturn := fake.inputResolutionCachedReturnsOnCall[len(fake.inputResolutionCachedArgsForCall)]
+	fake.inputResolutionCachedArgsForCall = append(fake.inputResolutionCachedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("InputResolutionCached", []interface{}{})
+	fake.inputResolutionCachedMutex.Unlock()
+	if fake.InputResolutionCachedStub != nil {
+		return fake.InputResolutionCachedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.inputResolutionCachedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) InputResolutionCachedCallCount() int {
+	fake.inputResolutionCachedMutex.RLock()
+	defer fake.inputResolutionCachedMutex.RUnlock()
+	return len(fake.inputResolutionCachedArgsForCall)
+}
+
+func (fake *FakeJob) InputResolutionCachedCalls(stub func() bool) {
+	fake.inputResolutionCachedMutex.Lock()
+	defer fake.inputResolutionCachedMutex.Unlock()
+	fake.InputResolutionCachedStub = stub
+}
+
+func (fake *FakeJob) InputResolutionCachedReturns(result1 bool) {
+	fake.inputResolutionCachedMutex.Lock()
+	defer fake.inputResolutionCachedMutex.Unlock()
+	fake.InputResolutionCachedStub = nil
+	fake.inputResolutionCachedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeJob) InputResolutionCachedReturnsOnCall(i int, result1 bool) {
+	fake.inputResolutionCachedMutex.Lock()
+	defer fake.inputResolutionCachedMutex.Unlock()
+	fake.InputResolutionCachedStub = nil
+	if fake.inputResolutionCachedReturnsOnCall == nil {
+		fake.inputResolutionCachedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.inputResolutionCachedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeJob) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1753,6 +2130,70 @@ func (fake *FakeJob) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RerunBuild(arg1 db.Build, arg2 atc.Plan) (db.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	ret, specificReturn := fake.rerunBuildReturnsOnCall[len(fake.rerunBuildArgsForCall)]
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.Plan
+	}{arg1, arg2})
+	fake.recordInvocation("RerunBuild", []interface{}{arg1, arg2})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.rerunBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeJob) RerunBuildCalls(stub func(db.Build, atc.Plan) (db.Build, error)) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = stub
+}
+
+func (fake *FakeJob) RerunBuildArgsForCall(i int) (db.Build, atc.Plan) {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	argsForCall := fake.rerunBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) RerunBuildReturns(result1 db.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	if fake.rerunBuildReturnsOnCall == nil {
+		fake.rerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SaveIndependentInputMapping(arg1 algorithm.InputMapping) error {
 	fake.saveIndependentInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveIndependentInputMappingReturnsOnCall[len(fake.saveIndependentInputMappingArgsForCall)]
@@ -2264,18 +2705,26 @@ func (fake *FakeJob) UpdateFirstLoggedBuildIDReturnsOnCall(i int, result1 error)
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.autoRetryBuildMutex.RLock()
+	defer fake.autoRetryBuildMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.buildStatisticsMutex.RLock()
+	defer fake.buildStatisticsMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
 	defer fake.buildsWithTimeMutex.RUnlock()
+	fake.cacheInputResolutionMutex.RLock()
+	defer fake.cacheInputResolutionMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
 	fake.configMutex.RLock()
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithOverridesMutex.RLock()
+	defer fake.createBuildWithOverridesMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2298,6 +2747,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.hasNewInputsMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.inputResolutionCachedMutex.RLock()
+	defer fake.inputResolutionCachedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pauseMutex.RLock()
@@ -2312,6 +2763,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
 	fake.saveIndependentInputMappingMutex.RLock()
 	defer fake.saveIndependentInputMappingMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
@@ -29,6 +29,26 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	BranchStub        func() string
+	branchMutex       sync.RWMutex
+	branchArgsForCall []struct {
+	}
+	branchReturns struct {
+		result1 string
+	}
+	branchReturnsOnCall map[int]struct {
+		result1 string
+	}
+	BranchesStub        func() *atc.BranchesConfig
+	branchesMutex       sync.RWMutex
+	branchesArgsForCall []struct {
+	}
+	branchesReturns struct {
+		result1 *atc.BranchesConfig
+	}
+	branchesReturnsOnCall map[int]struct {
+		result1 *atc.BranchesConfig
+	}
 	BuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -84,6 +104,38 @@ type FakePipeline struct {
 		result1 bool
 		result2 error
 	}
+	ClearInstanceStub        func() error
+	clearInstanceMutex       sync.RWMutex
+	clearInstanceArgsForCall []struct {
+	}
+	clearInstanceReturns struct {
+		result1 error
+	}
+	clearInstanceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ClearReconciledStub        func() error
+	clearReconciledMutex       sync.RWMutex
+	clearReconciledArgsForCall []struct {
+	}
+	clearReconciledReturns struct {
+		result1 error
+	}
+	clearReconciledReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ConfigStub        func() (atc.Config, error)
+	configMutex       sync.RWMutex
+	configArgsForCall []struct {
+	}
+	configReturns struct {
+		result1 atc.Config
+		result2 error
+	}
+	configReturnsOnCall map[int]struct {
+		result1 atc.Config
+		result2 error
+	}
 	ConfigVersionStub        func() db.ConfigVersion
 	configVersionMutex       sync.RWMutex
 	configVersionArgsForCall []struct {
@@ -94,6 +146,16 @@ type FakePipeline struct {
 	configVersionReturnsOnCall map[int]struct {
 		result1 db.ConfigVersion
 	}
+	ContainerDNSStub        func() *atc.ContainerDNS
+	containerDNSMutex       sync.RWMutex
+	containerDNSArgsForCall []struct {
+	}
+	containerDNSReturns struct {
+		result1 *atc.ContainerDNS
+	}
+	containerDNSReturnsOnCall map[int]struct {
+		result1 *atc.ContainerDNS
+	}
 	CreateOneOffBuildStub        func() (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
 	createOneOffBuildArgsForCall []struct {
@@ -142,6 +204,16 @@ type FakePipeline struct {
 	deleteBuildEventsByBuildIDsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeprecationsStub        func() []atc.ConfigDeprecation
+	deprecationsMutex       sync.RWMutex
+	deprecationsArgsForCall []struct {
+	}
+	deprecationsReturns struct {
+		result1 []atc.ConfigDeprecation
+	}
+	deprecationsReturnsOnCall map[int]struct {
+		result1 []atc.ConfigDeprecation
+	}
 	DestroyStub        func() error
 	destroyMutex       sync.RWMutex
 	destroyArgsForCall []struct {
@@ -232,6 +304,27 @@ type FakePipeline struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	IgnoreTeamContainerEnvStub        func() bool
+	ignoreTeamContainerEnvMutex       sync.RWMutex
+	ignoreTeamContainerEnvArgsForCall []struct {
+	}
+	ignoreTeamContainerEnvReturns struct {
+		result1 bool
+	}
+	ignoreTeamContainerEnvReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	IsSharedWithStub        func(string) bool
+	isSharedWithMutex       sync.RWMutex
+	isSharedWithArgsForCall []struct {
+		arg1 string
+	}
+	isSharedWithReturns struct {
+		result1 bool
+	}
+	isSharedWithReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	JobStub        func(string) (db.Job, bool, error)
 	jobMutex       sync.RWMutex
 	jobArgsForCall []struct {
@@ -259,6 +352,16 @@ type FakePipeline struct {
 		result1 db.Jobs
 		result2 error
 	}
+	LabelsStub        func() atc.Labels
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct {
+	}
+	labelsReturns struct {
+		result1 atc.Labels
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 atc.Labels
+	}
 	LoadVersionsDBStub        func() (*algorithm.VersionsDB, error)
 	loadVersionsDBMutex       sync.RWMutex
 	loadVersionsDBArgsForCall []struct {
@@ -271,6 +374,39 @@ type FakePipeline struct {
 		result1 *algorithm.VersionsDB
 		result2 error
 	}
+	LocaleStub        func() atc.Locale
+	localeMutex       sync.RWMutex
+	localeArgsForCall []struct {
+	}
+	localeReturns struct {
+		result1 atc.Locale
+	}
+	localeReturnsOnCall map[int]struct {
+		result1 atc.Locale
+	}
+	MarkInstanceStub        func(int, string) error
+	markInstanceMutex       sync.RWMutex
+	markInstanceArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	markInstanceReturns struct {
+		result1 error
+	}
+	markInstanceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkReconciledStub        func(string) error
+	markReconciledMutex       sync.RWMutex
+	markReconciledArgsForCall []struct {
+		arg1 string
+	}
+	markReconciledReturns struct {
+		result1 error
+	}
+	markReconciledReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -281,6 +417,16 @@ type FakePipeline struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ParentIDStub        func() int
+	parentIDMutex       sync.RWMutex
+	parentIDArgsForCall []struct {
+	}
+	parentIDReturns struct {
+		result1 int
+	}
+	parentIDReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PauseStub        func() error
 	pauseMutex       sync.RWMutex
 	pauseArgsForCall []struct {
@@ -311,6 +457,36 @@ type FakePipeline struct {
 	publicReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	ReconciledConfigVersionStub        func() db.ConfigVersion
+	reconciledConfigVersionMutex       sync.RWMutex
+	reconciledConfigVersionArgsForCall []struct {
+	}
+	reconciledConfigVersionReturns struct {
+		result1 db.ConfigVersion
+	}
+	reconciledConfigVersionReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+	}
+	ReconciledDigestStub        func() string
+	reconciledDigestMutex       sync.RWMutex
+	reconciledDigestArgsForCall []struct {
+	}
+	reconciledDigestReturns struct {
+		result1 string
+	}
+	reconciledDigestReturnsOnCall map[int]struct {
+		result1 string
+	}
+	RedactionsStub        func() *atc.Redactions
+	redactionsMutex       sync.RWMutex
+	redactionsArgsForCall []struct {
+	}
+	redactionsReturns struct {
+		result1 *atc.Redactions
+	}
+	redactionsReturnsOnCall map[int]struct {
+		result1 *atc.Redactions
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -364,6 +540,16 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	ResourceDefaultsStub        func() atc.ResourceDefaults
+	resourceDefaultsMutex       sync.RWMutex
+	resourceDefaultsArgsForCall []struct {
+	}
+	resourceDefaultsReturns struct {
+		result1 atc.ResourceDefaults
+	}
+	resourceDefaultsReturnsOnCall map[int]struct {
+		result1 atc.ResourceDefaults
+	}
 	ResourceTypeStub        func(string) (db.ResourceType, bool, error)
 	resourceTypeMutex       sync.RWMutex
 	resourceTypeArgsForCall []struct {
@@ -433,6 +619,68 @@ type FakePipeline struct {
 		result1 db.Resources
 		result2 error
 	}
+	SaveSchedulingTickStub        func(time.Time, time.Duration, int, error) (atc.SchedulingTick, error)
+	saveSchedulingTickMutex       sync.RWMutex
+	saveSchedulingTickArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Duration
+		arg3 int
+		arg4 error
+	}
+	saveSchedulingTickReturns struct {
+		result1 atc.SchedulingTick
+		result2 error
+	}
+	saveSchedulingTickReturnsOnCall map[int]struct {
+		result1 atc.SchedulingTick
+		result2 error
+	}
+	SchedulingTickStub        func() (atc.SchedulingTick, bool, error)
+	schedulingTickMutex       sync.RWMutex
+	schedulingTickArgsForCall []struct {
+	}
+	schedulingTickReturns struct {
+		result1 atc.SchedulingTick
+		result2 bool
+		result3 error
+	}
+	schedulingTickReturnsOnCall map[int]struct {
+		result1 atc.SchedulingTick
+		result2 bool
+		result3 error
+	}
+	SchedulingWindowsStub        func() *atc.SchedulingWindows
+	schedulingWindowsMutex       sync.RWMutex
+	schedulingWindowsArgsForCall []struct {
+	}
+	schedulingWindowsReturns struct {
+		result1 *atc.SchedulingWindows
+	}
+	schedulingWindowsReturnsOnCall map[int]struct {
+		result1 *atc.SchedulingWindows
+	}
+	ShareStub        func() (string, error)
+	shareMutex       sync.RWMutex
+	shareArgsForCall []struct {
+	}
+	shareReturns struct {
+		result1 string
+		result2 error
+	}
+	shareReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SharedStub        func() bool
+	sharedMutex       sync.RWMutex
+	sharedArgsForCall []struct {
+	}
+	sharedReturns struct {
+		result1 bool
+	}
+	sharedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -453,6 +701,16 @@ type FakePipeline struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TimeZoneStub        func() atc.TimeZone
+	timeZoneMutex       sync.RWMutex
+	timeZoneArgsForCall []struct {
+	}
+	timeZoneReturns struct {
+		result1 atc.TimeZone
+	}
+	timeZoneReturnsOnCall map[int]struct {
+		result1 atc.TimeZone
+	}
 	UnpauseStub        func() error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
@@ -463,6 +721,27 @@ type FakePipeline struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UnshareStub        func() error
+	unshareMutex       sync.RWMutex
+	unshareArgsForCall []struct {
+	}
+	unshareReturns struct {
+		result1 error
+	}
+	unshareReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateDeprecationsStub        func([]atc.ConfigDeprecation) error
+	updateDeprecationsMutex       sync.RWMutex
+	updateDeprecationsArgsForCall []struct {
+		arg1 []atc.ConfigDeprecation
+	}
+	updateDeprecationsReturns struct {
+		result1 error
+	}
+	updateDeprecationsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -534,6 +813,110 @@ func (fake *FakePipeline) AcquireSchedulingLockReturnsOnCall(i int, result1 lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) Branch() string {
+	fake.branchMutex.Lock()
+	ret, specificReturn := fake.branchReturnsOnCall[len(fake.branchArgsForCall)]
+	fake.branchArgsForCall = append(fake.branchArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Branch", []interface{}{})
+	fake.branchMutex.Unlock()
+	if fake.BranchStub != nil {
+		return fake.BranchStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.branchReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) BranchCallCount() int {
+	fake.branchMutex.RLock()
+	defer fake.branchMutex.RUnlock()
+	return len(fake.branchArgsForCall)
+}
+
+func (fake *FakePipeline) BranchCalls(stub func() string) {
+	fake.branchMutex.Lock()
+	defer fake.branchMutex.Unlock()
+	fake.BranchStub = stub
+}
+
+func (fake *FakePipeline) BranchReturns(result1 string) {
+	fake.branchMutex.Lock()
+	defer fake.branchMutex.Unlock()
+	fake.BranchStub = nil
+	fake.branchReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) BranchReturnsOnCall(i int, result1 string) {
+	fake.branchMutex.Lock()
+	defer fake.branchMutex.Unlock()
+	fake.BranchStub = nil
+	if fake.branchReturnsOnCall == nil {
+		fake.branchReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.branchReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) Branches() *atc.BranchesConfig {
+	fake.branchesMutex.Lock()
+	ret, specificReturn := fake.branchesReturnsOnCall[len(fake.branchesArgsForCall)]
+	fake.branchesArgsForCall = append(fake.branchesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Branches", []interface{}{})
+	fake.branchesMutex.Unlock()
+	if fake.BranchesStub != nil {
+		return fake.BranchesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.branchesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) BranchesCallCount() int {
+	fake.branchesMutex.RLock()
+	defer fake.branchesMutex.RUnlock()
+	return len(fake.branchesArgsForCall)
+}
+
+func (fake *FakePipeline) BranchesCalls(stub func() *atc.BranchesConfig) {
+	fake.branchesMutex.Lock()
+	defer fake.branchesMutex.Unlock()
+	fake.BranchesStub = stub
+}
+
+func (fake *FakePipeline) BranchesReturns(result1 *atc.BranchesConfig) {
+	fake.branchesMutex.Lock()
+	defer fake.branchesMutex.Unlock()
+	fake.BranchesStub = nil
+	fake.branchesReturns = struct {
+		result1 *atc.BranchesConfig
+	}{result1}
+}
+
+func (fake *FakePipeline) BranchesReturnsOnCall(i int, result1 *atc.BranchesConfig) {
+	fake.branchesMutex.Lock()
+	defer fake.branchesMutex.Unlock()
+	fake.BranchesStub = nil
+	if fake.branchesReturnsOnCall == nil {
+		fake.branchesReturnsOnCall = make(map[int]struct {
+			result1 *atc.BranchesConfig
+		})
+	}
+	fake.branchesReturnsOnCall[i] = struct {
+		result1 *atc.BranchesConfig
+	}{result1}
+}
+
 func (fake *FakePipeline) Builds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -784,6 +1167,165 @@ func (fake *FakePipeline) CheckPausedReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) ClearInstance() error {
+	fake.clearInstanceMutex.Lock()
+	ret, specificReturn := fake.clearInstanceReturnsOnCall[len(fake.clearInstanceArgsForCall)]
+	fake.clearInstanceArgsForCall = append(fake.clearInstanceArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ClearInstance", []interface{}{})
+	fake.clearInstanceMutex.Unlock()
+	if fake.ClearInstanceStub != nil {
+		return fake.ClearInstanceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.clearInstanceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ClearInstanceCallCount() int {
+	fake.clearInstanceMutex.RLock()
+	defer fake.clearInstanceMutex.RUnlock()
+	return len(fake.clearInstanceArgsForCall)
+}
+
+func (fake *FakePipeline) ClearInstanceCalls(stub func() error) {
+	fake.clearInstanceMutex.Lock()
+	defer fake.clearInstanceMutex.Unlock()
+	fake.ClearInstanceStub = stub
+}
+
+func (fake *FakePipeline) ClearInstanceReturns(result1 error) {
+	fake.clearInstanceMutex.Lock()
+	defer fake.clearInstanceMutex.Unlock()
+	fake.ClearInstanceStub = nil
+	fake.clearInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ClearInstanceReturnsOnCall(i int, result1 error) {
+	fake.clearInstanceMutex.Lock()
+	defer fake.clearInstanceMutex.Unlock()
+	fake.ClearInstanceStub = nil
+	if fake.clearInstanceReturnsOnCall == nil {
+		fake.clearInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ClearReconciled() error {
+	fake.clearReconciledMutex.Lock()
+	ret, specificReturn := fake.clearReconciledReturnsOnCall[len(fake.clearReconciledArgsForCall)]
+	fake.clearReconciledArgsForCall = append(fake.clearReconciledArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ClearReconciled", []interface{}{})
+	fake.clearReconciledMutex.Unlock()
+	if fake.ClearReconciledStub != nil {
+		return fake.ClearReconciledStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.clearReconciledReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ClearReconciledCallCount() int {
+	fake.clearReconciledMutex.RLock()
+	defer fake.clearReconciledMutex.RUnlock()
+	return len(fake.clearReconciledArgsForCall)
+}
+
+func (fake *FakePipeline) ClearReconciledCalls(stub func() error) {
+	fake.clearReconciledMutex.Lock()
+	defer fake.clearReconciledMutex.Unlock()
+	fake.ClearReconciledStub = stub
+}
+
+func (fake *FakePipeline) ClearReconciledReturns(result1 error) {
+	fake.clearReconciledMutex.Lock()
+	defer fake.clearReconciledMutex.Unlock()
+	fake.ClearReconciledStub = nil
+	fake.clearReconciledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ClearReconciledReturnsOnCall(i int, result1 error) {
+	fake.clearReconciledMutex.Lock()
+	defer fake.clearReconciledMutex.Unlock()
+	fake.ClearReconciledStub = nil
+	if fake.clearReconciledReturnsOnCall == nil {
+		fake.clearReconciledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearReconciledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) Config() (atc.Config, error) {
+	fake.configMutex.Lock()
+	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
+	fake.configArgsForCall = append(fake.configArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Config", []interface{}{})
+	fake.configMutex.Unlock()
+	if fake.ConfigStub != nil {
+		return fake.ConfigStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.configReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigCallCount() int {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	return len(fake.configArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigCalls(stub func() (atc.Config, error)) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = stub
+}
+
+func (fake *FakePipeline) ConfigReturns(result1 atc.Config, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	fake.configReturns = struct {
+		result1 atc.Config
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigReturnsOnCall(i int, result1 atc.Config, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	if fake.configReturnsOnCall == nil {
+		fake.configReturnsOnCall = make(map[int]struct {
+			result1 atc.Config
+			result2 error
+		})
+	}
+	fake.configReturnsOnCall[i] = struct {
+		result1 atc.Config
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) ConfigVersion() db.ConfigVersion {
 	fake.configVersionMutex.Lock()
 	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
@@ -836,6 +1378,58 @@ func (fake *FakePipeline) ConfigVersionReturnsOnCall(i int, result1 db.ConfigVer
 	}{result1}
 }
 
+func (fake *FakePipeline) ContainerDNS() *atc.ContainerDNS {
+	fake.containerDNSMutex.Lock()
+	ret, specificReturn := fake.containerDNSReturnsOnCall[len(fake.containerDNSArgsForCall)]
+	fake.containerDNSArgsForCall = append(fake.containerDNSArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ContainerDNS", []interface{}{})
+	fake.containerDNSMutex.Unlock()
+	if fake.ContainerDNSStub != nil {
+		return fake.ContainerDNSStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerDNSReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ContainerDNSCallCount() int {
+	fake.containerDNSMutex.RLock()
+	defer fake.containerDNSMutex.RUnlock()
+	return len(fake.containerDNSArgsForCall)
+}
+
+func (fake *FakePipeline) ContainerDNSCalls(stub func() *atc.ContainerDNS) {
+	fake.containerDNSMutex.Lock()
+	defer fake.containerDNSMutex.Unlock()
+	fake.ContainerDNSStub = stub
+}
+
+func (fake *FakePipeline) ContainerDNSReturns(result1 *atc.ContainerDNS) {
+	fake.containerDNSMutex.Lock()
+	defer fake.containerDNSMutex.Unlock()
+	fake.ContainerDNSStub = nil
+	fake.containerDNSReturns = struct {
+		result1 *atc.ContainerDNS
+	}{result1}
+}
+
+func (fake *FakePipeline) ContainerDNSReturnsOnCall(i int, result1 *atc.ContainerDNS) {
+	fake.containerDNSMutex.Lock()
+	defer fake.containerDNSMutex.Unlock()
+	fake.ContainerDNSStub = nil
+	if fake.containerDNSReturnsOnCall == nil {
+		fake.containerDNSReturnsOnCall = make(map[int]struct {
+			result1 *atc.ContainerDNS
+		})
+	}
+	fake.containerDNSReturnsOnCall[i] = struct {
+		result1 *atc.ContainerDNS
+	}{result1}
+}
+
 func (fake *FakePipeline) CreateOneOffBuild() (db.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
 	ret, specificReturn := fake.createOneOffBuildReturnsOnCall[len(fake.createOneOffBuildArgsForCall)]
@@ -1074,10 +1668,62 @@ func (fake *FakePipeline) DeleteBuildEventsByBuildIDsReturnsOnCall(i int, result
 	}{result1}
 }
 
-func (fake *FakePipeline) Destroy() error {
-	fake.destroyMutex.Lock()
-	ret, specificReturn := fake.destroyReturnsOnCall[len(fake.destroyArgsForCall)]
-	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct {
+func (fake *FakePipeline) Deprecations() []atc.ConfigDeprecation {
+	fake.deprecationsMutex.Lock()
+	ret, specificReturn := fake.deprecationsReturnsOnCall[len(fake.deprecationsArgsForCall)]
+	fake.deprecationsArgsForCall = append(fake.deprecationsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Deprecations", []interface{}{})
+	fake.deprecationsMutex.Unlock()
+	if fake.DeprecationsStub != nil {
+		return fake.DeprecationsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deprecationsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) DeprecationsCallCount() int {
+	fake.deprecationsMutex.RLock()
+	defer fake.deprecationsMutex.RUnlock()
+	return len(fake.deprecationsArgsForCall)
+}
+
+func (fake *FakePipeline) DeprecationsCalls(stub func() []atc.ConfigDeprecation) {
+	fake.deprecationsMutex.Lock()
+	defer fake.deprecationsMutex.Unlock()
+	fake.DeprecationsStub = stub
+}
+
+func (fake *FakePipeline) DeprecationsReturns(result1 []atc.ConfigDeprecation) {
+	fake.deprecationsMutex.Lock()
+	defer fake.deprecationsMutex.Unlock()
+	fake.DeprecationsStub = nil
+	fake.deprecationsReturns = struct {
+		result1 []atc.ConfigDeprecation
+	}{result1}
+}
+
+func (fake *FakePipeline) DeprecationsReturnsOnCall(i int, result1 []atc.ConfigDeprecation) {
+	fake.deprecationsMutex.Lock()
+	defer fake.deprecationsMutex.Unlock()
+	fake.DeprecationsStub = nil
+	if fake.deprecationsReturnsOnCall == nil {
+		fake.deprecationsReturnsOnCall = make(map[int]struct {
+			result1 []atc.ConfigDeprecation
+		})
+	}
+	fake.deprecationsReturnsOnCall[i] = struct {
+		result1 []atc.ConfigDeprecation
+	}{result1}
+}
+
+func (fake *FakePipeline) Destroy() error {
+	fake.destroyMutex.Lock()
+	ret, specificReturn := fake.destroyReturnsOnCall[len(fake.destroyArgsForCall)]
+	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct {
 	}{})
 	fake.recordInvocation("Destroy", []interface{}{})
 	fake.destroyMutex.Unlock()
@@ -1517,6 +2163,118 @@ func (fake *FakePipeline) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakePipeline) IgnoreTeamContainerEnv() bool {
+	fake.ignoreTeamContainerEnvMutex.Lock()
+	ret, specificReturn := fake.ignoreTeamContainerEnvReturnsOnCall[len(fake.ignoreTeamContainerEnvArgsForCall)]
+	fake.ignoreTeamContainerEnvArgsForCall = append(fake.ignoreTeamContainerEnvArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IgnoreTeamContainerEnv", []interface{}{})
+	fake.ignoreTeamContainerEnvMutex.Unlock()
+	if fake.IgnoreTeamContainerEnvStub != nil {
+		return fake.IgnoreTeamContainerEnvStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.ignoreTeamContainerEnvReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) IgnoreTeamContainerEnvCallCount() int {
+	fake.ignoreTeamContainerEnvMutex.RLock()
+	defer fake.ignoreTeamContainerEnvMutex.RUnlock()
+	return len(fake.ignoreTeamContainerEnvArgsForCall)
+}
+
+func (fake *FakePipeline) IgnoreTeamContainerEnvCalls(stub func() bool) {
+	fake.ignoreTeamContainerEnvMutex.Lock()
+	defer fake.ignoreTeamContainerEnvMutex.Unlock()
+	fake.IgnoreTeamContainerEnvStub = stub
+}
+
+func (fake *FakePipeline) IgnoreTeamContainerEnvReturns(result1 bool) {
+	fake.ignoreTeamContainerEnvMutex.Lock()
+	defer fake.ignoreTeamContainerEnvMutex.Unlock()
+	fake.IgnoreTeamContainerEnvStub = nil
+	fake.ignoreTeamContainerEnvReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) IgnoreTeamContainerEnvReturnsOnCall(i int, result1 bool) {
+	fake.ignoreTeamContainerEnvMutex.Lock()
+	defer fake.ignoreTeamContainerEnvMutex.Unlock()
+	fake.IgnoreTeamContainerEnvStub = nil
+	if fake.ignoreTeamContainerEnvReturnsOnCall == nil {
+		fake.ignoreTeamContainerEnvReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.ignoreTeamContainerEnvReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) IsSharedWith(arg1 string) bool {
+	fake.isSharedWithMutex.Lock()
+	ret, specificReturn := fake.isSharedWithReturnsOnCall[len(fake.isSharedWithArgsForCall)]
+	fake.isSharedWithArgsForCall = append(fake.isSharedWithArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("IsSharedWith", []interface{}{arg1})
+	fake.isSharedWithMutex.Unlock()
+	if fake.IsSharedWithStub != nil {
+		return fake.IsSharedWithStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isSharedWithReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) IsSharedWithCallCount() int {
+	fake.isSharedWithMutex.RLock()
+	defer fake.isSharedWithMutex.RUnlock()
+	return len(fake.isSharedWithArgsForCall)
+}
+
+func (fake *FakePipeline) IsSharedWithCalls(stub func(string) bool) {
+	fake.isSharedWithMutex.Lock()
+	defer fake.isSharedWithMutex.Unlock()
+	fake.IsSharedWithStub = stub
+}
+
+func (fake *FakePipeline) IsSharedWithArgsForCall(i int) string {
+	fake.isSharedWithMutex.RLock()
+	defer fake.isSharedWithMutex.RUnlock()
+	argsForCall := fake.isSharedWithArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) IsSharedWithReturns(result1 bool) {
+	fake.isSharedWithMutex.Lock()
+	defer fake.isSharedWithMutex.Unlock()
+	fake.IsSharedWithStub = nil
+	fake.isSharedWithReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) IsSharedWithReturnsOnCall(i int, result1 bool) {
+	fake.isSharedWithMutex.Lock()
+	defer fake.isSharedWithMutex.Unlock()
+	fake.IsSharedWithStub = nil
+	if fake.isSharedWithReturnsOnCall == nil {
+		fake.isSharedWithReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isSharedWithReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakePipeline) Job(arg1 string) (db.Job, bool, error) {
 	fake.jobMutex.Lock()
 	ret, specificReturn := fake.jobReturnsOnCall[len(fake.jobArgsForCall)]
@@ -1638,6 +2396,58 @@ func (fake *FakePipeline) JobsReturnsOnCall(i int, result1 db.Jobs, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Labels() atc.Labels {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.labelsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakePipeline) LabelsCalls(stub func() atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = stub
+}
+
+func (fake *FakePipeline) LabelsReturns(result1 atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
+func (fake *FakePipeline) LabelsReturnsOnCall(i int, result1 atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 atc.Labels
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
 func (fake *FakePipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	fake.loadVersionsDBMutex.Lock()
 	ret, specificReturn := fake.loadVersionsDBReturnsOnCall[len(fake.loadVersionsDBArgsForCall)]
@@ -1693,6 +2503,179 @@ func (fake *FakePipeline) LoadVersionsDBReturnsOnCall(i int, result1 *algorithm.
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Locale() atc.Locale {
+	fake.localeMutex.Lock()
+	ret, specificReturn := fake.localeReturnsOnCall[len(fake.localeArgsForCall)]
+	fake.localeArgsForCall = append(fake.localeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Locale", []interface{}{})
+	fake.localeMutex.Unlock()
+	if fake.LocaleStub != nil {
+		return fake.LocaleStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.localeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) LocaleCallCount() int {
+	fake.localeMutex.RLock()
+	defer fake.localeMutex.RUnlock()
+	return len(fake.localeArgsForCall)
+}
+
+func (fake *FakePipeline) LocaleCalls(stub func() atc.Locale) {
+	fake.localeMutex.Lock()
+	defer fake.localeMutex.Unlock()
+	fake.LocaleStub = stub
+}
+
+func (fake *FakePipeline) LocaleReturns(result1 atc.Locale) {
+	fake.localeMutex.Lock()
+	defer fake.localeMutex.Unlock()
+	fake.LocaleStub = nil
+	fake.localeReturns = struct {
+		result1 atc.Locale
+	}{result1}
+}
+
+func (fake *FakePipeline) LocaleReturnsOnCall(i int, result1 atc.Locale) {
+	fake.localeMutex.Lock()
+	defer fake.localeMutex.Unlock()
+	fake.LocaleStub = nil
+	if fake.localeReturnsOnCall == nil {
+		fake.localeReturnsOnCall = make(map[int]struct {
+			result1 atc.Locale
+		})
+	}
+	fake.localeReturnsOnCall[i] = struct {
+		result1 atc.Locale
+	}{result1}
+}
+
+func (fake *FakePipeline) MarkInstance(arg1 int, arg2 string) error {
+	fake.markInstanceMutex.Lock()
+	ret, specificReturn := fake.markInstanceReturnsOnCall[len(fake.markInstanceArgsForCall)]
+	fake.markInstanceArgsForCall = append(fake.markInstanceArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("MarkInstance", []interface{}{arg1, arg2})
+	fake.markInstanceMutex.Unlock()
+	if fake.MarkInstanceStub != nil {
+		return fake.MarkInstanceStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markInstanceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) MarkInstanceCallCount() int {
+	fake.markInstanceMutex.RLock()
+	defer fake.markInstanceMutex.RUnlock()
+	return len(fake.markInstanceArgsForCall)
+}
+
+func (fake *FakePipeline) MarkInstanceCalls(stub func(int, string) error) {
+	fake.markInstanceMutex.Lock()
+	defer fake.markInstanceMutex.Unlock()
+	fake.MarkInstanceStub = stub
+}
+
+func (fake *FakePipeline) MarkInstanceArgsForCall(i int) (int, string) {
+	fake.markInstanceMutex.RLock()
+	defer fake.markInstanceMutex.RUnlock()
+	argsForCall := fake.markInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) MarkInstanceReturns(result1 error) {
+	fake.markInstanceMutex.Lock()
+	defer fake.markInstanceMutex.Unlock()
+	fake.MarkInstanceStub = nil
+	fake.markInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) MarkInstanceReturnsOnCall(i int, result1 error) {
+	fake.markInstanceMutex.Lock()
+	defer fake.markInstanceMutex.Unlock()
+	fake.MarkInstanceStub = nil
+	if fake.markInstanceReturnsOnCall == nil {
+		fake.markInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) MarkReconciled(arg1 string) error {
+	fake.markReconciledMutex.Lock()
+	ret, specificReturn := fake.markReconciledReturnsOnCall[len(fake.markReconciledArgsForCall)]
+	fake.markReconciledArgsForCall = append(fake.markReconciledArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("MarkReconciled", []interface{}{arg1})
+	fake.markReconciledMutex.Unlock()
+	if fake.MarkReconciledStub != nil {
+		return fake.MarkReconciledStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markReconciledReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) MarkReconciledCallCount() int {
+	fake.markReconciledMutex.RLock()
+	defer fake.markReconciledMutex.RUnlock()
+	return len(fake.markReconciledArgsForCall)
+}
+
+func (fake *FakePipeline) MarkReconciledCalls(stub func(string) error) {
+	fake.markReconciledMutex.Lock()
+	defer fake.markReconciledMutex.Unlock()
+	fake.MarkReconciledStub = stub
+}
+
+func (fake *FakePipeline) MarkReconciledArgsForCall(i int) string {
+	fake.markReconciledMutex.RLock()
+	defer fake.markReconciledMutex.RUnlock()
+	argsForCall := fake.markReconciledArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) MarkReconciledReturns(result1 error) {
+	fake.markReconciledMutex.Lock()
+	defer fake.markReconciledMutex.Unlock()
+	fake.MarkReconciledStub = nil
+	fake.markReconciledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) MarkReconciledReturnsOnCall(i int, result1 error) {
+	fake.markReconciledMutex.Lock()
+	defer fake.markReconciledMutex.Unlock()
+	fake.MarkReconciledStub = nil
+	if fake.markReconciledReturnsOnCall == nil {
+		fake.markReconciledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markReconciledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipeline) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1745,6 +2728,58 @@ func (fake *FakePipeline) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) ParentID() int {
+	fake.parentIDMutex.Lock()
+	ret, specificReturn := fake.parentIDReturnsOnCall[len(fake.parentIDArgsForCall)]
+	fake.parentIDArgsForCall = append(fake.parentIDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ParentID", []interface{}{})
+	fake.parentIDMutex.Unlock()
+	if fake.ParentIDStub != nil {
+		return fake.ParentIDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.parentIDReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ParentIDCallCount() int {
+	fake.parentIDMutex.RLock()
+	defer fake.parentIDMutex.RUnlock()
+	return len(fake.parentIDArgsForCall)
+}
+
+func (fake *FakePipeline) ParentIDCalls(stub func() int) {
+	fake.parentIDMutex.Lock()
+	defer fake.parentIDMutex.Unlock()
+	fake.ParentIDStub = stub
+}
+
+func (fake *FakePipeline) ParentIDReturns(result1 int) {
+	fake.parentIDMutex.Lock()
+	defer fake.parentIDMutex.Unlock()
+	fake.ParentIDStub = nil
+	fake.parentIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakePipeline) ParentIDReturnsOnCall(i int, result1 int) {
+	fake.parentIDMutex.Lock()
+	defer fake.parentIDMutex.Unlock()
+	fake.ParentIDStub = nil
+	if fake.parentIDReturnsOnCall == nil {
+		fake.parentIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.parentIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakePipeline) Pause() error {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
@@ -1901,6 +2936,162 @@ func (fake *FakePipeline) PublicReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakePipeline) ReconciledConfigVersion() db.ConfigVersion {
+	fake.reconciledConfigVersionMutex.Lock()
+	ret, specificReturn := fake.reconciledConfigVersionReturnsOnCall[len(fake.reconciledConfigVersionArgsForCall)]
+	fake.reconciledConfigVersionArgsForCall = append(fake.reconciledConfigVersionArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReconciledConfigVersion", []interface{}{})
+	fake.reconciledConfigVersionMutex.Unlock()
+	if fake.ReconciledConfigVersionStub != nil {
+		return fake.ReconciledConfigVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.reconciledConfigVersionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ReconciledConfigVersionCallCount() int {
+	fake.reconciledConfigVersionMutex.RLock()
+	defer fake.reconciledConfigVersionMutex.RUnlock()
+	return len(fake.reconciledConfigVersionArgsForCall)
+}
+
+func (fake *FakePipeline) ReconciledConfigVersionCalls(stub func() db.ConfigVersion) {
+	fake.reconciledConfigVersionMutex.Lock()
+	defer fake.reconciledConfigVersionMutex.Unlock()
+	fake.ReconciledConfigVersionStub = stub
+}
+
+func (fake *FakePipeline) ReconciledConfigVersionReturns(result1 db.ConfigVersion) {
+	fake.reconciledConfigVersionMutex.Lock()
+	defer fake.reconciledConfigVersionMutex.Unlock()
+	fake.ReconciledConfigVersionStub = nil
+	fake.reconciledConfigVersionReturns = struct {
+		result1 db.ConfigVersion
+	}{result1}
+}
+
+func (fake *FakePipeline) ReconciledConfigVersionReturnsOnCall(i int, result1 db.ConfigVersion) {
+	fake.reconciledConfigVersionMutex.Lock()
+	defer fake.reconciledConfigVersionMutex.Unlock()
+	fake.ReconciledConfigVersionStub = nil
+	if fake.reconciledConfigVersionReturnsOnCall == nil {
+		fake.reconciledConfigVersionReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+		})
+	}
+	fake.reconciledConfigVersionReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+	}{result1}
+}
+
+func (fake *FakePipeline) ReconciledDigest() string {
+	fake.reconciledDigestMutex.Lock()
+	ret, specificReturn := fake.reconciledDigestReturnsOnCall[len(fake.reconciledDigestArgsForCall)]
+	fake.reconciledDigestArgsForCall = append(fake.reconciledDigestArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReconciledDigest", []interface{}{})
+	fake.reconciledDigestMutex.Unlock()
+	if fake.ReconciledDigestStub != nil {
+		return fake.ReconciledDigestStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.reconciledDigestReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ReconciledDigestCallCount() int {
+	fake.reconciledDigestMutex.RLock()
+	defer fake.reconciledDigestMutex.RUnlock()
+	return len(fake.reconciledDigestArgsForCall)
+}
+
+func (fake *FakePipeline) ReconciledDigestCalls(stub func() string) {
+	fake.reconciledDigestMutex.Lock()
+	defer fake.reconciledDigestMutex.Unlock()
+	fake.ReconciledDigestStub = stub
+}
+
+func (fake *FakePipeline) ReconciledDigestReturns(result1 string) {
+	fake.reconciledDigestMutex.Lock()
+	defer fake.reconciledDigestMutex.Unlock()
+	fake.ReconciledDigestStub = nil
+	fake.reconciledDigestReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) ReconciledDigestReturnsOnCall(i int, result1 string) {
+	fake.reconciledDigestMutex.Lock()
+	defer fake.reconciledDigestMutex.Unlock()
+	fake.ReconciledDigestStub = nil
+	if fake.reconciledDigestReturnsOnCall == nil {
+		fake.reconciledDigestReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.reconciledDigestReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) Redactions() *atc.Redactions {
+	fake.redactionsMutex.Lock()
+	ret, specificReturn := fake.redactionsReturnsOnCall[len(fake.redactionsArgsForCall)]
+	fake.redactionsArgsForCall = append(fake.redactionsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Redactions", []interface{}{})
+	fake.redactionsMutex.Unlock()
+	if fake.RedactionsStub != nil {
+		return fake.RedactionsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.redactionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) RedactionsCallCount() int {
+	fake.redactionsMutex.RLock()
+	defer fake.redactionsMutex.RUnlock()
+	return len(fake.redactionsArgsForCall)
+}
+
+func (fake *FakePipeline) RedactionsCalls(stub func() *atc.Redactions) {
+	fake.redactionsMutex.Lock()
+	defer fake.redactionsMutex.Unlock()
+	fake.RedactionsStub = stub
+}
+
+func (fake *FakePipeline) RedactionsReturns(result1 *atc.Redactions) {
+	fake.redactionsMutex.Lock()
+	defer fake.redactionsMutex.Unlock()
+	fake.RedactionsStub = nil
+	fake.redactionsReturns = struct {
+		result1 *atc.Redactions
+	}{result1}
+}
+
+func (fake *FakePipeline) RedactionsReturnsOnCall(i int, result1 *atc.Redactions) {
+	fake.redactionsMutex.Lock()
+	defer fake.redactionsMutex.Unlock()
+	fake.RedactionsStub = nil
+	if fake.redactionsReturnsOnCall == nil {
+		fake.redactionsReturnsOnCall = make(map[int]struct {
+			result1 *atc.Redactions
+		})
+	}
+	fake.redactionsReturnsOnCall[i] = struct {
+		result1 *atc.Redactions
+	}{result1}
+}
+
 func (fake *FakePipeline) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -2148,6 +3339,58 @@ func (fake *FakePipeline) ResourceByIDReturnsOnCall(i int, result1 db.Resource,
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) ResourceDefaults() atc.ResourceDefaults {
+	fake.resourceDefaultsMutex.Lock()
+	ret, specificReturn := fake.resourceDefaultsReturnsOnCall[len(fake.resourceDefaultsArgsForCall)]
+	fake.resourceDefaultsArgsForCall = append(fake.resourceDefaultsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceDefaults", []interface{}{})
+	fake.resourceDefaultsMutex.Unlock()
+	if fake.ResourceDefaultsStub != nil {
+		return fake.ResourceDefaultsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceDefaultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ResourceDefaultsCallCount() int {
+	fake.resourceDefaultsMutex.RLock()
+	defer fake.resourceDefaultsMutex.RUnlock()
+	return len(fake.resourceDefaultsArgsForCall)
+}
+
+func (fake *FakePipeline) ResourceDefaultsCalls(stub func() atc.ResourceDefaults) {
+	fake.resourceDefaultsMutex.Lock()
+	defer fake.resourceDefaultsMutex.Unlock()
+	fake.ResourceDefaultsStub = stub
+}
+
+func (fake *FakePipeline) ResourceDefaultsReturns(result1 atc.ResourceDefaults) {
+	fake.resourceDefaultsMutex.Lock()
+	defer fake.resourceDefaultsMutex.Unlock()
+	fake.ResourceDefaultsStub = nil
+	fake.resourceDefaultsReturns = struct {
+		result1 atc.ResourceDefaults
+	}{result1}
+}
+
+func (fake *FakePipeline) ResourceDefaultsReturnsOnCall(i int, result1 atc.ResourceDefaults) {
+	fake.resourceDefaultsMutex.Lock()
+	defer fake.resourceDefaultsMutex.Unlock()
+	fake.ResourceDefaultsStub = nil
+	if fake.resourceDefaultsReturnsOnCall == nil {
+		fake.resourceDefaultsReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceDefaults
+		})
+	}
+	fake.resourceDefaultsReturnsOnCall[i] = struct {
+		result1 atc.ResourceDefaults
+	}{result1}
+}
+
 func (fake *FakePipeline) ResourceType(arg1 string) (db.ResourceType, bool, error) {
 	fake.resourceTypeMutex.Lock()
 	ret, specificReturn := fake.resourceTypeReturnsOnCall[len(fake.resourceTypeArgsForCall)]
@@ -2456,6 +3699,289 @@ func (fake *FakePipeline) ResourcesReturnsOnCall(i int, result1 db.Resources, re
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) SaveSchedulingTick(arg1 time.Time, arg2 time.Duration, arg3 int, arg4 error) (atc.SchedulingTick, error) {
+	fake.saveSchedulingTickMutex.Lock()
+	ret, specificReturn := fake.saveSchedulingTickReturnsOnCall[len(fake.saveSchedulingTickArgsForCall)]
+	fake.saveSchedulingTickArgsForCall = append(fake.saveSchedulingTickArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Duration
+		arg3 int
+		arg4 error
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SaveSchedulingTick", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveSchedulingTickMutex.Unlock()
+	if fake.SaveSchedulingTickStub != nil {
+		return fake.SaveSchedulingTickStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.saveSchedulingTickReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SaveSchedulingTickCallCount() int {
+	fake.saveSchedulingTickMutex.RLock()
+	defer fake.saveSchedulingTickMutex.RUnlock()
+	return len(fake.saveSchedulingTickArgsForCall)
+}
+
+func (fake *FakePipeline) SaveSchedulingTickCalls(stub func(time.Time, time.Duration, int, error) (atc.SchedulingTick, error)) {
+	fake.saveSchedulingTickMutex.Lock()
+	defer fake.saveSchedulingTickMutex.Unlock()
+	fake.SaveSchedulingTickStub = stub
+}
+
+func (fake *FakePipeline) SaveSchedulingTickArgsForCall(i int) (time.Time, time.Duration, int, error) {
+	fake.saveSchedulingTickMutex.RLock()
+	defer fake.saveSchedulingTickMutex.RUnlock()
+	argsForCall := fake.saveSchedulingTickArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePipeline) SaveSchedulingTickReturns(result1 atc.SchedulingTick, result2 error) {
+	fake.saveSchedulingTickMutex.Lock()
+	defer fake.saveSchedulingTickMutex.Unlock()
+	fake.SaveSchedulingTickStub = nil
+	fake.saveSchedulingTickReturns = struct {
+		result1 atc.SchedulingTick
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SaveSchedulingTickReturnsOnCall(i int, result1 atc.SchedulingTick, result2 error) {
+	fake.saveSchedulingTickMutex.Lock()
+	defer fake.saveSchedulingTickMutex.Unlock()
+	fake.SaveSchedulingTickStub = nil
+	if fake.saveSchedulingTickReturnsOnCall == nil {
+		fake.saveSchedulingTickReturnsOnCall = make(map[int]struct {
+			result1 atc.SchedulingTick
+			result2 error
+		})
+	}
+	fake.saveSchedulingTickReturnsOnCall[i] = struct {
+		result1 atc.SchedulingTick
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SchedulingTick() (atc.SchedulingTick, bool, error) {
+	fake.schedulingTickMutex.Lock()
+	ret, specificReturn := fake.schedulingTickReturnsOnCall[len(fake.schedulingTickArgsForCall)]
+	fake.schedulingTickArgsForCall = append(fake.schedulingTickArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SchedulingTick", []interface{}{})
+	fake.schedulingTickMutex.Unlock()
+	if fake.SchedulingTickStub != nil {
+		return fake.SchedulingTickStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.schedulingTickReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) SchedulingTickCallCount() int {
+	fake.schedulingTickMutex.RLock()
+	defer fake.schedulingTickMutex.RUnlock()
+	return len(fake.schedulingTickArgsForCall)
+}
+
+func (fake *FakePipeline) SchedulingTickCalls(stub func() (atc.SchedulingTick, bool, error)) {
+	fake.schedulingTickMutex.Lock()
+	defer fake.schedulingTickMutex.Unlock()
+	fake.SchedulingTickStub = stub
+}
+
+func (fake *FakePipeline) SchedulingTickReturns(result1 atc.SchedulingTick, result2 bool, result3 error) {
+	fake.schedulingTickMutex.Lock()
+	defer fake.schedulingTickMutex.Unlock()
+	fake.SchedulingTickStub = nil
+	fake.schedulingTickReturns = struct {
+		result1 atc.SchedulingTick
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) SchedulingTickReturnsOnCall(i int, result1 atc.SchedulingTick, result2 bool, result3 error) {
+	fake.schedulingTickMutex.Lock()
+	defer fake.schedulingTickMutex.Unlock()
+	fake.SchedulingTickStub = nil
+	if fake.schedulingTickReturnsOnCall == nil {
+		fake.schedulingTickReturnsOnCall = make(map[int]struct {
+			result1 atc.SchedulingTick
+			result2 bool
+			result3 error
+		})
+	}
+	fake.schedulingTickReturnsOnCall[i] = struct {
+		result1 atc.SchedulingTick
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) SchedulingWindows() *atc.SchedulingWindows {
+	fake.schedulingWindowsMutex.Lock()
+	ret, specificReturn := fake.schedulingWindowsReturnsOnCall[len(fake.schedulingWindowsArgsForCall)]
+	fake.schedulingWindowsArgsForCall = append(fake.schedulingWindowsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SchedulingWindows", []interface{}{})
+	fake.schedulingWindowsMutex.Unlock()
+	if fake.SchedulingWindowsStub != nil {
+		return fake.SchedulingWindowsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.schedulingWindowsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) SchedulingWindowsCallCount() int {
+	fake.schedulingWindowsMutex.RLock()
+	defer fake.schedulingWindowsMutex.RUnlock()
+	return len(fake.schedulingWindowsArgsForCall)
+}
+
+func (fake *FakePipeline) SchedulingWindowsCalls(stub func() *atc.SchedulingWindows) {
+	fake.schedulingWindowsMutex.Lock()
+	defer fake.schedulingWindowsMutex.Unlock()
+	fake.SchedulingWindowsStub = stub
+}
+
+func (fake *FakePipeline) SchedulingWindowsReturns(result1 *atc.SchedulingWindows) {
+	fake.schedulingWindowsMutex.Lock()
+	defer fake.schedulingWindowsMutex.Unlock()
+	fake.SchedulingWindowsStub = nil
+	fake.schedulingWindowsReturns = struct {
+		result1 *atc.SchedulingWindows
+	}{result1}
+}
+
+func (fake *FakePipeline) SchedulingWindowsReturnsOnCall(i int, result1 *atc.SchedulingWindows) {
+	fake.schedulingWindowsMutex.Lock()
+	defer fake.schedulingWindowsMutex.Unlock()
+	fake.SchedulingWindowsStub = nil
+	if fake.schedulingWindowsReturnsOnCall == nil {
+		fake.schedulingWindowsReturnsOnCall = make(map[int]struct {
+			result1 *atc.SchedulingWindows
+		})
+	}
+	fake.schedulingWindowsReturnsOnCall[i] = struct {
+		result1 *atc.SchedulingWindows
+	}{result1}
+}
+
+func (fake *FakePipeline) Share() (string, error) {
+	fake.shareMutex.Lock()
+	ret, specificReturn := fake.shareReturnsOnCall[len(fake.shareArgsForCall)]
+	fake.shareArgsForCall = append(fake.shareArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Share", []interface{}{})
+	fake.shareMutex.Unlock()
+	if fake.ShareStub != nil {
+		return fake.ShareStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.shareReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ShareCallCount() int {
+	fake.shareMutex.RLock()
+	defer fake.shareMutex.RUnlock()
+	return len(fake.shareArgsForCall)
+}
+
+func (fake *FakePipeline) ShareCalls(stub func() (string, error)) {
+	fake.shareMutex.Lock()
+	defer fake.shareMutex.Unlock()
+	fake.ShareStub = stub
+}
+
+func (fake *FakePipeline) ShareReturns(result1 string, result2 error) {
+	fake.shareMutex.Lock()
+	defer fake.shareMutex.Unlock()
+	fake.ShareStub = nil
+	fake.shareReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ShareReturnsOnCall(i int, result1 string, result2 error) {
+	fake.shareMutex.Lock()
+	defer fake.shareMutex.Unlock()
+	fake.ShareStub = nil
+	if fake.shareReturnsOnCall == nil {
+		fake.shareReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.shareReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) Shared() bool {
+	fake.sharedMutex.Lock()
+	ret, specificReturn := fake.sharedReturnsOnCall[len(fake.sharedArgsForCall)]
+	fake.sharedArgsForCall = append(fake.sharedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Shared", []interface{}{})
+	fake.sharedMutex.Unlock()
+	if fake.SharedStub != nil {
+		return fake.SharedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sharedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) SharedCallCount() int {
+	fake.sharedMutex.RLock()
+	defer fake.sharedMutex.RUnlock()
+	return len(fake.sharedArgsForCall)
+}
+
+func (fake *FakePipeline) SharedCalls(stub func() bool) {
+	fake.sharedMutex.Lock()
+	defer fake.sharedMutex.Unlock()
+	fake.SharedStub = stub
+}
+
+func (fake *FakePipeline) SharedReturns(result1 bool) {
+	fake.sharedMutex.Lock()
+	defer fake.sharedMutex.Unlock()
+	fake.SharedStub = nil
+	fake.sharedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) SharedReturnsOnCall(i int, result1 bool) {
+	fake.sharedMutex.Lock()
+	defer fake.sharedMutex.Unlock()
+	fake.SharedStub = nil
+	if fake.sharedReturnsOnCall == nil {
+		fake.sharedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.sharedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakePipeline) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -2560,6 +4086,58 @@ func (fake *FakePipeline) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) TimeZone() atc.TimeZone {
+	fake.timeZoneMutex.Lock()
+	ret, specificReturn := fake.timeZoneReturnsOnCall[len(fake.timeZoneArgsForCall)]
+	fake.timeZoneArgsForCall = append(fake.timeZoneArgsForCall, struct {
+	}{})
+	fake.recordInvocation("TimeZone", []interface{}{})
+	fake.timeZoneMutex.Unlock()
+	if fake.TimeZoneStub != nil {
+		return fake.TimeZoneStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.timeZoneReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) TimeZoneCallCount() int {
+	fake.timeZoneMutex.RLock()
+	defer fake.timeZoneMutex.RUnlock()
+	return len(fake.timeZoneArgsForCall)
+}
+
+func (fake *FakePipeline) TimeZoneCalls(stub func() atc.TimeZone) {
+	fake.timeZoneMutex.Lock()
+	defer fake.timeZoneMutex.Unlock()
+	fake.TimeZoneStub = stub
+}
+
+func (fake *FakePipeline) TimeZoneReturns(result1 atc.TimeZone) {
+	fake.timeZoneMutex.Lock()
+	defer fake.timeZoneMutex.Unlock()
+	fake.TimeZoneStub = nil
+	fake.timeZoneReturns = struct {
+		result1 atc.TimeZone
+	}{result1}
+}
+
+func (fake *FakePipeline) TimeZoneReturnsOnCall(i int, result1 atc.TimeZone) {
+	fake.timeZoneMutex.Lock()
+	defer fake.timeZoneMutex.Unlock()
+	fake.TimeZoneStub = nil
+	if fake.timeZoneReturnsOnCall == nil {
+		fake.timeZoneReturnsOnCall = make(map[int]struct {
+			result1 atc.TimeZone
+		})
+	}
+	fake.timeZoneReturnsOnCall[i] = struct {
+		result1 atc.TimeZone
+	}{result1}
+}
+
 func (fake *FakePipeline) Unpause() error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
@@ -2612,11 +4190,132 @@ func (fake *FakePipeline) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) Unshare() error {
+	fake.unshareMutex.Lock()
+	ret, specificReturn := fake.unshareReturnsOnCall[len(fake.unshareArgsForCall)]
+	fake.unshareArgsForCall = append(fake.unshareArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Unshare", []interface{}{})
+	fake.unshareMutex.Unlock()
+	if fake.UnshareStub != nil {
+		return fake.UnshareStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unshareReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) UnshareCallCount() int {
+	fake.unshareMutex.RLock()
+	defer fake.unshareMutex.RUnlock()
+	return len(fake.unshareArgsForCall)
+}
+
+func (fake *FakePipeline) UnshareCalls(stub func() error) {
+	fake.unshareMutex.Lock()
+	defer fake.unshareMutex.Unlock()
+	fake.UnshareStub = stub
+}
+
+func (fake *FakePipeline) UnshareReturns(result1 error) {
+	fake.unshareMutex.Lock()
+	defer fake.unshareMutex.Unlock()
+	fake.UnshareStub = nil
+	fake.unshareReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) UnshareReturnsOnCall(i int, result1 error) {
+	fake.unshareMutex.Lock()
+	defer fake.unshareMutex.Unlock()
+	fake.UnshareStub = nil
+	if fake.unshareReturnsOnCall == nil {
+		fake.unshareReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unshareReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) UpdateDeprecations(arg1 []atc.ConfigDeprecation) error {
+	var arg1Copy []atc.ConfigDeprecation
+	if arg1 != nil {
+		arg1Copy = make([]atc.ConfigDeprecation, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.updateDeprecationsMutex.Lock()
+	ret, specificReturn := fake.updateDeprecationsReturnsOnCall[len(fake.updateDeprecationsArgsForCall)]
+	fake.updateDeprecationsArgsForCall = append(fake.updateDeprecationsArgsForCall, struct {
+		arg1 []atc.ConfigDeprecation
+	}{arg1Copy})
+	fake.recordInvocation("UpdateDeprecations", []interface{}{arg1Copy})
+	fake.updateDeprecationsMutex.Unlock()
+	if fake.UpdateDeprecationsStub != nil {
+		return fake.UpdateDeprecationsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateDeprecationsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) UpdateDeprecationsCallCount() int {
+	fake.updateDeprecationsMutex.RLock()
+	defer fake.updateDeprecationsMutex.RUnlock()
+	return len(fake.updateDeprecationsArgsForCall)
+}
+
+func (fake *FakePipeline) UpdateDeprecationsCalls(stub func([]atc.ConfigDeprecation) error) {
+	fake.updateDeprecationsMutex.Lock()
+	defer fake.updateDeprecationsMutex.Unlock()
+	fake.UpdateDeprecationsStub = stub
+}
+
+func (fake *FakePipeline) UpdateDeprecationsArgsForCall(i int) []atc.ConfigDeprecation {
+	fake.updateDeprecationsMutex.RLock()
+	defer fake.updateDeprecationsMutex.RUnlock()
+	argsForCall := fake.updateDeprecationsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) UpdateDeprecationsReturns(result1 error) {
+	fake.updateDeprecationsMutex.Lock()
+	defer fake.updateDeprecationsMutex.Unlock()
+	fake.UpdateDeprecationsStub = nil
+	fake.updateDeprecationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) UpdateDeprecationsReturnsOnCall(i int, result1 error) {
+	fake.updateDeprecationsMutex.Lock()
+	defer fake.updateDeprecationsMutex.Unlock()
+	fake.UpdateDeprecationsStub = nil
+	if fake.updateDeprecationsReturnsOnCall == nil {
+		fake.updateDeprecationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateDeprecationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.acquireSchedulingLockMutex.RLock()
 	defer fake.acquireSchedulingLockMutex.RUnlock()
+	fake.branchMutex.RLock()
+	defer fake.branchMutex.RUnlock()
+	fake.branchesMutex.RLock()
+	defer fake.branchesMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
@@ -2625,8 +4324,16 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.causalityMutex.RUnlock()
 	fake.checkPausedMutex.RLock()
 	defer fake.checkPausedMutex.RUnlock()
+	fake.clearInstanceMutex.RLock()
+	defer fake.clearInstanceMutex.RUnlock()
+	fake.clearReconciledMutex.RLock()
+	defer fake.clearReconciledMutex.RUnlock()
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
+	fake.containerDNSMutex.RLock()
+	defer fake.containerDNSMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
@@ -2635,6 +4342,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.dashboardMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	fake.deprecationsMutex.RLock()
+	defer fake.deprecationsMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
 	fake.exposeMutex.RLock()
@@ -2651,20 +4360,40 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.hideMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.ignoreTeamContainerEnvMutex.RLock()
+	defer fake.ignoreTeamContainerEnvMutex.RUnlock()
+	fake.isSharedWithMutex.RLock()
+	defer fake.isSharedWithMutex.RUnlock()
 	fake.jobMutex.RLock()
 	defer fake.jobMutex.RUnlock()
 	fake.jobsMutex.RLock()
 	defer fake.jobsMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
 	fake.loadVersionsDBMutex.RLock()
 	defer fake.loadVersionsDBMutex.RUnlock()
+	fake.localeMutex.RLock()
+	defer fake.localeMutex.RUnlock()
+	fake.markInstanceMutex.RLock()
+	defer fake.markInstanceMutex.RUnlock()
+	fake.markReconciledMutex.RLock()
+	defer fake.markReconciledMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.parentIDMutex.RLock()
+	defer fake.parentIDMutex.RUnlock()
 	fake.pauseMutex.RLock()
 	defer fake.pauseMutex.RUnlock()
 	fake.pausedMutex.RLock()
 	defer fake.pausedMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
+	fake.reconciledConfigVersionMutex.RLock()
+	defer fake.reconciledConfigVersionMutex.RUnlock()
+	fake.reconciledDigestMutex.RLock()
+	defer fake.reconciledDigestMutex.RUnlock()
+	fake.redactionsMutex.RLock()
+	defer fake.redactionsMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.renameMutex.RLock()
@@ -2673,6 +4402,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceByIDMutex.RLock()
 	defer fake.resourceByIDMutex.RUnlock()
+	fake.resourceDefaultsMutex.RLock()
+	defer fake.resourceDefaultsMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
 	fake.resourceTypeByIDMutex.RLock()
@@ -2683,12 +4414,28 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.resourceVersionMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.saveSchedulingTickMutex.RLock()
+	defer fake.saveSchedulingTickMutex.RUnlock()
+	fake.schedulingTickMutex.RLock()
+	defer fake.schedulingTickMutex.RUnlock()
+	fake.schedulingWindowsMutex.RLock()
+	defer fake.schedulingWindowsMutex.RUnlock()
+	fake.shareMutex.RLock()
+	defer fake.shareMutex.RUnlock()
+	fake.sharedMutex.RLock()
+	defer fake.sharedMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.timeZoneMutex.RLock()
+	defer fake.timeZoneMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
+	fake.unshareMutex.RLock()
+	defer fake.unshareMutex.RUnlock()
+	fake.updateDeprecationsMutex.RLock()
+	defer fake.updateDeprecationsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
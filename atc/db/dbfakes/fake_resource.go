@@ -60,6 +60,19 @@ type FakeResource struct {
 	checkTimeoutReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ChecksStub        func(int) ([]db.Check, error)
+	checksMutex       sync.RWMutex
+	checksArgsForCall []struct {
+		arg1 int
+	}
+	checksReturns struct {
+		result1 []db.Check
+		result2 error
+	}
+	checksReturnsOnCall map[int]struct {
+		result1 []db.Check
+		result2 error
+	}
 	ConfigPinnedVersionStub        func() atc.Version
 	configPinnedVersionMutex       sync.RWMutex
 	configPinnedVersionArgsForCall []struct {
@@ -70,6 +83,16 @@ type FakeResource struct {
 	configPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	ConfigSourceStub        func() atc.Source
+	configSourceMutex       sync.RWMutex
+	configSourceArgsForCall []struct {
+	}
+	configSourceReturns struct {
+		result1 atc.Source
+	}
+	configSourceReturnsOnCall map[int]struct {
+		result1 atc.Source
+	}
 	CurrentPinnedVersionStub        func() atc.Version
 	currentPinnedVersionMutex       sync.RWMutex
 	currentPinnedVersionArgsForCall []struct {
@@ -91,6 +114,17 @@ type FakeResource struct {
 	disableVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DisableVersionAndAbortPendingBuildsStub        func(int) error
+	disableVersionAndAbortPendingBuildsMutex       sync.RWMutex
+	disableVersionAndAbortPendingBuildsArgsForCall []struct {
+		arg1 int
+	}
+	disableVersionAndAbortPendingBuildsReturns struct {
+		result1 error
+	}
+	disableVersionAndAbortPendingBuildsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EnableVersionStub        func(int) error
 	enableVersionMutex       sync.RWMutex
 	enableVersionArgsForCall []struct {
@@ -142,6 +176,16 @@ type FakeResource struct {
 	lastCheckStartTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	ModeStub        func() atc.ResourceMode
+	modeMutex       sync.RWMutex
+	modeArgsForCall []struct {
+	}
+	modeReturns struct {
+		result1 atc.ResourceMode
+	}
+	modeReturnsOnCall map[int]struct {
+		result1 atc.ResourceMode
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -152,6 +196,16 @@ type FakeResource struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NewVersionWebhooksStub        func() []atc.NewVersionWebhookConfig
+	newVersionWebhooksMutex       sync.RWMutex
+	newVersionWebhooksArgsForCall []struct {
+	}
+	newVersionWebhooksReturns struct {
+		result1 []atc.NewVersionWebhookConfig
+	}
+	newVersionWebhooksReturnsOnCall map[int]struct {
+		result1 []atc.NewVersionWebhookConfig
+	}
 	NotifyScanStub        func() error
 	notifyScanMutex       sync.RWMutex
 	notifyScanArgsForCall []struct {
@@ -215,6 +269,16 @@ type FakeResource struct {
 	publicReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RedactionsStub        func() *atc.Redactions
+	redactionsMutex       sync.RWMutex
+	redactionsArgsForCall []struct {
+	}
+	redactionsReturns struct {
+		result1 *atc.Redactions
+	}
+	redactionsReturnsOnCall map[int]struct {
+		result1 *atc.Redactions
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -388,6 +452,20 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	UpdateVersionMetadataStub        func(int, db.ResourceConfigMetadataFields) (bool, error)
+	updateVersionMetadataMutex       sync.RWMutex
+	updateVersionMetadataArgsForCall []struct {
+		arg1 int
+		arg2 db.ResourceConfigMetadataFields
+	}
+	updateVersionMetadataReturns struct {
+		result1 bool
+		result2 error
+	}
+	updateVersionMetadataReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	VersionsStub        func(db.Page, atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error)
 	versionsMutex       sync.RWMutex
 	versionsArgsForCall []struct {
@@ -406,6 +484,21 @@ type FakeResource struct {
 		result3 bool
 		result4 error
 	}
+	VersionsInSpanStub        func(db.VersionSpan) ([]atc.ResourceVersion, bool, error)
+	versionsInSpanMutex       sync.RWMutex
+	versionsInSpanArgsForCall []struct {
+		arg1 db.VersionSpan
+	}
+	versionsInSpanReturns struct {
+		result1 []atc.ResourceVersion
+		result2 bool
+		result3 error
+	}
+	versionsInSpanReturnsOnCall map[int]struct {
+		result1 []atc.ResourceVersion
+		result2 bool
+		result3 error
+	}
 	WebhookTokenStub        func() string
 	webhookTokenMutex       sync.RWMutex
 	webhookTokenArgsForCall []struct {
@@ -680,6 +773,69 @@ func (fake *FakeResource) CheckTimeoutReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) Checks(arg1 int) ([]db.Check, error) {
+	fake.checksMutex.Lock()
+	ret, specificReturn := fake.checksReturnsOnCall[len(fake.checksArgsForCall)]
+	fake.checksArgsForCall = append(fake.checksArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Checks", []interface{}{arg1})
+	fake.checksMutex.Unlock()
+	if fake.ChecksStub != nil {
+		return fake.ChecksStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.checksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) ChecksCallCount() int {
+	fake.checksMutex.RLock()
+	defer fake.checksMutex.RUnlock()
+	return len(fake.checksArgsForCall)
+}
+
+func (fake *FakeResource) ChecksCalls(stub func(int) ([]db.Check, error)) {
+	fake.checksMutex.Lock()
+	defer fake.checksMutex.Unlock()
+	fake.ChecksStub = stub
+}
+
+func (fake *FakeResource) ChecksArgsForCall(i int) int {
+	fake.checksMutex.RLock()
+	defer fake.checksMutex.RUnlock()
+	argsForCall := fake.checksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) ChecksReturns(result1 []db.Check, result2 error) {
+	fake.checksMutex.Lock()
+	defer fake.checksMutex.Unlock()
+	fake.ChecksStub = nil
+	fake.checksReturns = struct {
+		result1 []db.Check
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) ChecksReturnsOnCall(i int, result1 []db.Check, result2 error) {
+	fake.checksMutex.Lock()
+	defer fake.checksMutex.Unlock()
+	fake.ChecksStub = nil
+	if fake.checksReturnsOnCall == nil {
+		fake.checksReturnsOnCall = make(map[int]struct {
+			result1 []db.Check
+			result2 error
+		})
+	}
+	fake.checksReturnsOnCall[i] = struct {
+		result1 []db.Check
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) ConfigPinnedVersion() atc.Version {
 	fake.configPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.configPinnedVersionReturnsOnCall[len(fake.configPinnedVersionArgsForCall)]
@@ -732,6 +888,58 @@ func (fake *FakeResource) ConfigPinnedVersionReturnsOnCall(i int, result1 atc.Ve
 	}{result1}
 }
 
+func (fake *FakeResource) ConfigSource() atc.Source {
+	fake.configSourceMutex.Lock()
+	ret, specificReturn := fake.configSourceReturnsOnCall[len(fake.configSourceArgsForCall)]
+	fake.configSourceArgsForCall = append(fake.configSourceArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConfigSource", []interface{}{})
+	fake.configSourceMutex.Unlock()
+	if fake.ConfigSourceStub != nil {
+		return fake.ConfigSourceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.configSourceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) ConfigSourceCallCount() int {
+	fake.configSourceMutex.RLock()
+	defer fake.configSourceMutex.RUnlock()
+	return len(fake.configSourceArgsForCall)
+}
+
+func (fake *FakeResource) ConfigSourceCalls(stub func() atc.Source) {
+	fake.configSourceMutex.Lock()
+	defer fake.configSourceMutex.Unlock()
+	fake.ConfigSourceStub = stub
+}
+
+func (fake *FakeResource) ConfigSourceReturns(result1 atc.Source) {
+	fake.configSourceMutex.Lock()
+	defer fake.configSourceMutex.Unlock()
+	fake.ConfigSourceStub = nil
+	fake.configSourceReturns = struct {
+		result1 atc.Source
+	}{result1}
+}
+
+func (fake *FakeResource) ConfigSourceReturnsOnCall(i int, result1 atc.Source) {
+	fake.configSourceMutex.Lock()
+	defer fake.configSourceMutex.Unlock()
+	fake.ConfigSourceStub = nil
+	if fake.configSourceReturnsOnCall == nil {
+		fake.configSourceReturnsOnCall = make(map[int]struct {
+			result1 atc.Source
+		})
+	}
+	fake.configSourceReturnsOnCall[i] = struct {
+		result1 atc.Source
+	}{result1}
+}
+
 func (fake *FakeResource) CurrentPinnedVersion() atc.Version {
 	fake.currentPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.currentPinnedVersionReturnsOnCall[len(fake.currentPinnedVersionArgsForCall)]
@@ -844,6 +1052,66 @@ func (fake *FakeResource) DisableVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) DisableVersionAndAbortPendingBuilds(arg1 int) error {
+	fake.disableVersionAndAbortPendingBuildsMutex.Lock()
+	ret, specificReturn := fake.disableVersionAndAbortPendingBuildsReturnsOnCall[len(fake.disableVersionAndAbortPendingBuildsArgsForCall)]
+	fake.disableVersionAndAbortPendingBuildsArgsForCall = append(fake.disableVersionAndAbortPendingBuildsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("DisableVersionAndAbortPendingBuilds", []interface{}{arg1})
+	fake.disableVersionAndAbortPendingBuildsMutex.Unlock()
+	if fake.DisableVersionAndAbortPendingBuildsStub != nil {
+		return fake.DisableVersionAndAbortPendingBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.disableVersionAndAbortPendingBuildsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) DisableVersionAndAbortPendingBuildsCallCount() int {
+	fake.disableVersionAndAbortPendingBuildsMutex.RLock()
+	defer fake.disableVersionAndAbortPendingBuildsMutex.RUnlock()
+	return len(fake.disableVersionAndAbortPendingBuildsArgsForCall)
+}
+
+func (fake *FakeResource) DisableVersionAndAbortPendingBuildsCalls(stub func(int) error) {
+	fake.disableVersionAndAbortPendingBuildsMutex.Lock()
+	defer fake.disableVersionAndAbortPendingBuildsMutex.Unlock()
+	fake.DisableVersionAndAbortPendingBuildsStub = stub
+}
+
+func (fake *FakeResource) DisableVersionAndAbortPendingBuildsArgsForCall(i int) int {
+	fake.disableVersionAndAbortPendingBuildsMutex.RLock()
+	defer fake.disableVersionAndAbortPendingBuildsMutex.RUnlock()
+	argsForCall := fake.disableVersionAndAbortPendingBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) DisableVersionAndAbortPendingBuildsReturns(result1 error) {
+	fake.disableVersionAndAbortPendingBuildsMutex.Lock()
+	defer fake.disableVersionAndAbortPendingBuildsMutex.Unlock()
+	fake.DisableVersionAndAbortPendingBuildsStub = nil
+	fake.disableVersionAndAbortPendingBuildsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) DisableVersionAndAbortPendingBuildsReturnsOnCall(i int, result1 error) {
+	fake.disableVersionAndAbortPendingBuildsMutex.Lock()
+	defer fake.disableVersionAndAbortPendingBuildsMutex.Unlock()
+	fake.DisableVersionAndAbortPendingBuildsStub = nil
+	if fake.disableVersionAndAbortPendingBuildsReturnsOnCall == nil {
+		fake.disableVersionAndAbortPendingBuildsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.disableVersionAndAbortPendingBuildsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) EnableVersion(arg1 int) error {
 	fake.enableVersionMutex.Lock()
 	ret, specificReturn := fake.enableVersionReturnsOnCall[len(fake.enableVersionArgsForCall)]
@@ -1112,6 +1380,58 @@ func (fake *FakeResource) LastCheckStartTimeReturnsOnCall(i int, result1 time.Ti
 	}{result1}
 }
 
+func (fake *FakeResource) Mode() atc.ResourceMode {
+	fake.modeMutex.Lock()
+	ret, specificReturn := fake.modeReturnsOnCall[len(fake.modeArgsForCall)]
+	fake.modeArgsForCall = append(fake.modeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Mode", []interface{}{})
+	fake.modeMutex.Unlock()
+	if fake.ModeStub != nil {
+		return fake.ModeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.modeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) ModeCallCount() int {
+	fake.modeMutex.RLock()
+	defer fake.modeMutex.RUnlock()
+	return len(fake.modeArgsForCall)
+}
+
+func (fake *FakeResource) ModeCalls(stub func() atc.ResourceMode) {
+	fake.modeMutex.Lock()
+	defer fake.modeMutex.Unlock()
+	fake.ModeStub = stub
+}
+
+func (fake *FakeResource) ModeReturns(result1 atc.ResourceMode) {
+	fake.modeMutex.Lock()
+	defer fake.modeMutex.Unlock()
+	fake.ModeStub = nil
+	fake.modeReturns = struct {
+		result1 atc.ResourceMode
+	}{result1}
+}
+
+func (fake *FakeResource) ModeReturnsOnCall(i int, result1 atc.ResourceMode) {
+	fake.modeMutex.Lock()
+	defer fake.modeMutex.Unlock()
+	fake.ModeStub = nil
+	if fake.modeReturnsOnCall == nil {
+		fake.modeReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceMode
+		})
+	}
+	fake.modeReturnsOnCall[i] = struct {
+		result1 atc.ResourceMode
+	}{result1}
+}
+
 func (fake *FakeResource) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1164,6 +1484,58 @@ func (fake *FakeResource) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) NewVersionWebhooks() []atc.NewVersionWebhookConfig {
+	fake.newVersionWebhooksMutex.Lock()
+	ret, specificReturn := fake.newVersionWebhooksReturnsOnCall[len(fake.newVersionWebhooksArgsForCall)]
+	fake.newVersionWebhooksArgsForCall = append(fake.newVersionWebhooksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NewVersionWebhooks", []interface{}{})
+	fake.newVersionWebhooksMutex.Unlock()
+	if fake.NewVersionWebhooksStub != nil {
+		return fake.NewVersionWebhooksStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.newVersionWebhooksReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) NewVersionWebhooksCallCount() int {
+	fake.newVersionWebhooksMutex.RLock()
+	defer fake.newVersionWebhooksMutex.RUnlock()
+	return len(fake.newVersionWebhooksArgsForCall)
+}
+
+func (fake *FakeResource) NewVersionWebhooksCalls(stub func() []atc.NewVersionWebhookConfig) {
+	fake.newVersionWebhooksMutex.Lock()
+	defer fake.newVersionWebhooksMutex.Unlock()
+	fake.NewVersionWebhooksStub = stub
+}
+
+func (fake *FakeResource) NewVersionWebhooksReturns(result1 []atc.NewVersionWebhookConfig) {
+	fake.newVersionWebhooksMutex.Lock()
+	defer fake.newVersionWebhooksMutex.Unlock()
+	fake.NewVersionWebhooksStub = nil
+	fake.newVersionWebhooksReturns = struct {
+		result1 []atc.NewVersionWebhookConfig
+	}{result1}
+}
+
+func (fake *FakeResource) NewVersionWebhooksReturnsOnCall(i int, result1 []atc.NewVersionWebhookConfig) {
+	fake.newVersionWebhooksMutex.Lock()
+	defer fake.newVersionWebhooksMutex.Unlock()
+	fake.NewVersionWebhooksStub = nil
+	if fake.newVersionWebhooksReturnsOnCall == nil {
+		fake.newVersionWebhooksReturnsOnCall = make(map[int]struct {
+			result1 []atc.NewVersionWebhookConfig
+		})
+	}
+	fake.newVersionWebhooksReturnsOnCall[i] = struct {
+		result1 []atc.NewVersionWebhookConfig
+	}{result1}
+}
+
 func (fake *FakeResource) NotifyScan() error {
 	fake.notifyScanMutex.Lock()
 	ret, specificReturn := fake.notifyScanReturnsOnCall[len(fake.notifyScanArgsForCall)]
@@ -1487,6 +1859,58 @@ func (fake *FakeResource) PublicReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeResource) Redactions() *atc.Redactions {
+	fake.redactionsMutex.Lock()
+	ret, specificReturn := fake.redactionsReturnsOnCall[len(fake.redactionsArgsForCall)]
+	fake.redactionsArgsForCall = append(fake.redactionsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Redactions", []interface{}{})
+	fake.redactionsMutex.Unlock()
+	if fake.RedactionsStub != nil {
+		return fake.RedactionsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.redactionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) RedactionsCallCount() int {
+	fake.redactionsMutex.RLock()
+	defer fake.redactionsMutex.RUnlock()
+	return len(fake.redactionsArgsForCall)
+}
+
+func (fake *FakeResource) RedactionsCalls(stub func() *atc.Redactions) {
+	fake.redactionsMutex.Lock()
+	defer fake.redactionsMutex.Unlock()
+	fake.RedactionsStub = stub
+}
+
+func (fake *FakeResource) RedactionsReturns(result1 *atc.Redactions) {
+	fake.redactionsMutex.Lock()
+	defer fake.redactionsMutex.Unlock()
+	fake.RedactionsStub = nil
+	fake.redactionsReturns = struct {
+		result1 *atc.Redactions
+	}{result1}
+}
+
+func (fake *FakeResource) RedactionsReturnsOnCall(i int, result1 *atc.Redactions) {
+	fake.redactionsMutex.Lock()
+	defer fake.redactionsMutex.Unlock()
+	fake.RedactionsStub = nil
+	if fake.redactionsReturnsOnCall == nil {
+		fake.redactionsReturnsOnCall = make(map[int]struct {
+			result1 *atc.Redactions
+		})
+	}
+	fake.redactionsReturnsOnCall[i] = struct {
+		result1 *atc.Redactions
+	}{result1}
+}
+
 func (fake *FakeResource) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -2338,6 +2762,70 @@ func (fake *FakeResource) UpdateMetadataReturnsOnCall(i int, result1 bool, resul
 	}{result1, result2}
 }
 
+func (fake *FakeResource) UpdateVersionMetadata(arg1 int, arg2 db.ResourceConfigMetadataFields) (bool, error) {
+	fake.updateVersionMetadataMutex.Lock()
+	ret, specificReturn := fake.updateVersionMetadataReturnsOnCall[len(fake.updateVersionMetadataArgsForCall)]
+	fake.updateVersionMetadataArgsForCall = append(fake.updateVersionMetadataArgsForCall, struct {
+		arg1 int
+		arg2 db.ResourceConfigMetadataFields
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateVersionMetadata", []interface{}{arg1, arg2})
+	fake.updateVersionMetadataMutex.Unlock()
+	if fake.UpdateVersionMetadataStub != nil {
+		return fake.UpdateVersionMetadataStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateVersionMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) UpdateVersionMetadataCallCount() int {
+	fake.updateVersionMetadataMutex.RLock()
+	defer fake.updateVersionMetadataMutex.RUnlock()
+	return len(fake.updateVersionMetadataArgsForCall)
+}
+
+func (fake *FakeResource) UpdateVersionMetadataCalls(stub func(int, db.ResourceConfigMetadataFields) (bool, error)) {
+	fake.updateVersionMetadataMutex.Lock()
+	defer fake.updateVersionMetadataMutex.Unlock()
+	fake.UpdateVersionMetadataStub = stub
+}
+
+func (fake *FakeResource) UpdateVersionMetadataArgsForCall(i int) (int, db.ResourceConfigMetadataFields) {
+	fake.updateVersionMetadataMutex.RLock()
+	defer fake.updateVersionMetadataMutex.RUnlock()
+	argsForCall := fake.updateVersionMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) UpdateVersionMetadataReturns(result1 bool, result2 error) {
+	fake.updateVersionMetadataMutex.Lock()
+	defer fake.updateVersionMetadataMutex.Unlock()
+	fake.UpdateVersionMetadataStub = nil
+	fake.updateVersionMetadataReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) UpdateVersionMetadataReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.updateVersionMetadataMutex.Lock()
+	defer fake.updateVersionMetadataMutex.Unlock()
+	fake.UpdateVersionMetadataStub = nil
+	if fake.updateVersionMetadataReturnsOnCall == nil {
+		fake.updateVersionMetadataReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.updateVersionMetadataReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) Versions(arg1 db.Page, arg2 atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error) {
 	fake.versionsMutex.Lock()
 	ret, specificReturn := fake.versionsReturnsOnCall[len(fake.versionsArgsForCall)]
@@ -2408,6 +2896,72 @@ func (fake *FakeResource) VersionsReturnsOnCall(i int, result1 []atc.ResourceVer
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeResource) VersionsInSpan(arg1 db.VersionSpan) ([]atc.ResourceVersion, bool, error) {
+	fake.versionsInSpanMutex.Lock()
+	ret, specificReturn := fake.versionsInSpanReturnsOnCall[len(fake.versionsInSpanArgsForCall)]
+	fake.versionsInSpanArgsForCall = append(fake.versionsInSpanArgsForCall, struct {
+		arg1 db.VersionSpan
+	}{arg1})
+	fake.recordInvocation("VersionsInSpan", []interface{}{arg1})
+	fake.versionsInSpanMutex.Unlock()
+	if fake.VersionsInSpanStub != nil {
+		return fake.VersionsInSpanStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.versionsInSpanReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) VersionsInSpanCallCount() int {
+	fake.versionsInSpanMutex.RLock()
+	defer fake.versionsInSpanMutex.RUnlock()
+	return len(fake.versionsInSpanArgsForCall)
+}
+
+func (fake *FakeResource) VersionsInSpanCalls(stub func(db.VersionSpan) ([]atc.ResourceVersion, bool, error)) {
+	fake.versionsInSpanMutex.Lock()
+	defer fake.versionsInSpanMutex.Unlock()
+	fake.VersionsInSpanStub = stub
+}
+
+func (fake *FakeResource) VersionsInSpanArgsForCall(i int) db.VersionSpan {
+	fake.versionsInSpanMutex.RLock()
+	defer fake.versionsInSpanMutex.RUnlock()
+	argsForCall := fake.versionsInSpanArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) VersionsInSpanReturns(result1 []atc.ResourceVersion, result2 bool, result3 error) {
+	fake.versionsInSpanMutex.Lock()
+	defer fake.versionsInSpanMutex.Unlock()
+	fake.VersionsInSpanStub = nil
+	fake.versionsInSpanReturns = struct {
+		result1 []atc.ResourceVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) VersionsInSpanReturnsOnCall(i int, result1 []atc.ResourceVersion, result2 bool, result3 error) {
+	fake.versionsInSpanMutex.Lock()
+	defer fake.versionsInSpanMutex.Unlock()
+	fake.VersionsInSpanStub = nil
+	if fake.versionsInSpanReturnsOnCall == nil {
+		fake.versionsInSpanReturnsOnCall = make(map[int]struct {
+			result1 []atc.ResourceVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.versionsInSpanReturnsOnCall[i] = struct {
+		result1 []atc.ResourceVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) WebhookToken() string {
 	fake.webhookTokenMutex.Lock()
 	ret, specificReturn := fake.webhookTokenReturnsOnCall[len(fake.webhookTokenArgsForCall)]
@@ -2473,12 +3027,18 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.checkSetupErrorMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
+	fake.checksMutex.RLock()
+	defer fake.checksMutex.RUnlock()
 	fake.configPinnedVersionMutex.RLock()
 	defer fake.configPinnedVersionMutex.RUnlock()
+	fake.configSourceMutex.RLock()
+	defer fake.configSourceMutex.RUnlock()
 	fake.currentPinnedVersionMutex.RLock()
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.disableVersionMutex.RLock()
 	defer fake.disableVersionMutex.RUnlock()
+	fake.disableVersionAndAbortPendingBuildsMutex.RLock()
+	defer fake.disableVersionAndAbortPendingBuildsMutex.RUnlock()
 	fake.enableVersionMutex.RLock()
 	defer fake.enableVersionMutex.RUnlock()
 	fake.iDMutex.RLock()
@@ -2489,8 +3049,12 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.lastCheckEndTimeMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
+	fake.modeMutex.RLock()
+	defer fake.modeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.newVersionWebhooksMutex.RLock()
+	defer fake.newVersionWebhooksMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
 	defer fake.notifyScanMutex.RUnlock()
 	fake.pinCommentMutex.RLock()
@@ -2503,6 +3067,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
+	fake.redactionsMutex.RLock()
+	defer fake.redactionsMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.resourceConfigIDMutex.RLock()
@@ -2533,8 +3099,12 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.unpinVersionMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
+	fake.updateVersionMetadataMutex.RLock()
+	defer fake.updateVersionMetadataMutex.RUnlock()
 	fake.versionsMutex.RLock()
 	defer fake.versionsMutex.RUnlock()
+	fake.versionsInSpanMutex.RLock()
+	defer fake.versionsInSpanMutex.RUnlock()
 	fake.webhookTokenMutex.RLock()
 	defer fake.webhookTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -9,6 +9,34 @@ import (
 )
 
 type FakeResourceCacheFactory struct {
+	CountResourceCacheUseStub        func(db.UsedResourceCache, int, []string) error
+	countResourceCacheUseMutex       sync.RWMutex
+	countResourceCacheUseArgsForCall []struct {
+		arg1 db.UsedResourceCache
+		arg2 int
+		arg3 []string
+	}
+	countResourceCacheUseReturns struct {
+		result1 error
+	}
+	countResourceCacheUseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FindMostUsedResourceCachesStub        func(int, []string, int) ([]db.UsedResourceCache, error)
+	findMostUsedResourceCachesMutex       sync.RWMutex
+	findMostUsedResourceCachesArgsForCall []struct {
+		arg1 int
+		arg2 []string
+		arg3 int
+	}
+	findMostUsedResourceCachesReturns struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}
+	findMostUsedResourceCachesReturnsOnCall map[int]struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}
 	FindOrCreateResourceCacheStub        func(db.ResourceCacheUser, string, atc.Version, atc.Source, atc.Params, atc.VersionedResourceTypes) (db.UsedResourceCache, error)
 	findOrCreateResourceCacheMutex       sync.RWMutex
 	findOrCreateResourceCacheArgsForCall []struct {
@@ -27,6 +55,21 @@ type FakeResourceCacheFactory struct {
 		result1 db.UsedResourceCache
 		result2 error
 	}
+	ResourceCacheImageMetadataStub        func(db.UsedResourceCache) ([]byte, bool, error)
+	resourceCacheImageMetadataMutex       sync.RWMutex
+	resourceCacheImageMetadataArgsForCall []struct {
+		arg1 db.UsedResourceCache
+	}
+	resourceCacheImageMetadataReturns struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}
+	resourceCacheImageMetadataReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}
 	ResourceCacheMetadataStub        func(db.UsedResourceCache) (db.ResourceConfigMetadataFields, error)
 	resourceCacheMetadataMutex       sync.RWMutex
 	resourceCacheMetadataArgsForCall []struct {
@@ -40,6 +83,18 @@ type FakeResourceCacheFactory struct {
 		result1 db.ResourceConfigMetadataFields
 		result2 error
 	}
+	UpdateResourceCacheImageMetadataStub        func(db.UsedResourceCache, []byte) error
+	updateResourceCacheImageMetadataMutex       sync.RWMutex
+	updateResourceCacheImageMetadataArgsForCall []struct {
+		arg1 db.UsedResourceCache
+		arg2 []byte
+	}
+	updateResourceCacheImageMetadataReturns struct {
+		result1 error
+	}
+	updateResourceCacheImageMetadataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateResourceCacheMetadataStub        func(db.UsedResourceCache, []atc.MetadataField) error
 	updateResourceCacheMetadataMutex       sync.RWMutex
 	updateResourceCacheMetadataArgsForCall []struct {
@@ -56,6 +111,143 @@ type FakeResourceCacheFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeResourceCacheFactory) CountResourceCacheUse(arg1 db.UsedResourceCache, arg2 int, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.countResourceCacheUseMutex.Lock()
+	ret, specificReturn := fake.countResourceCacheUseReturnsOnCall[len(fake.countResourceCacheUseArgsForCall)]
+	fake.countResourceCacheUseArgsForCall = append(fake.countResourceCacheUseArgsForCall, struct {
+		arg1 db.UsedResourceCache
+		arg2 int
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("CountResourceCacheUse", []interface{}{arg1, arg2, arg3Copy})
+	fake.countResourceCacheUseMutex.Unlock()
+	if fake.CountResourceCacheUseStub != nil {
+		return fake.CountResourceCacheUseStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.countResourceCacheUseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceCacheFactory) CountResourceCacheUseCallCount() int {
+	fake.countResourceCacheUseMutex.RLock()
+	defer fake.countResourceCacheUseMutex.RUnlock()
+	return len(fake.countResourceCacheUseArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) CountResourceCacheUseCalls(stub func(db.UsedResourceCache, int, []string) error) {
+	fake.countResourceCacheUseMutex.Lock()
+	defer fake.countResourceCacheUseMutex.Unlock()
+	fake.CountResourceCacheUseStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) CountResourceCacheUseArgsForCall(i int) (db.UsedResourceCache, int, []string) {
+	fake.countResourceCacheUseMutex.RLock()
+	defer fake.countResourceCacheUseMutex.RUnlock()
+	argsForCall := fake.countResourceCacheUseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceCacheFactory) CountResourceCacheUseReturns(result1 error) {
+	fake.countResourceCacheUseMutex.Lock()
+	defer fake.countResourceCacheUseMutex.Unlock()
+	fake.CountResourceCacheUseStub = nil
+	fake.countResourceCacheUseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceCacheFactory) CountResourceCacheUseReturnsOnCall(i int, result1 error) {
+	fake.countResourceCacheUseMutex.Lock()
+	defer fake.countResourceCacheUseMutex.Unlock()
+	fake.CountResourceCacheUseStub = nil
+	if fake.countResourceCacheUseReturnsOnCall == nil {
+		fake.countResourceCacheUseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.countResourceCacheUseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceCacheFactory) FindMostUsedResourceCaches(arg1 int, arg2 []string, arg3 int) ([]db.UsedResourceCache, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.findMostUsedResourceCachesMutex.Lock()
+	ret, specificReturn := fake.findMostUsedResourceCachesReturnsOnCall[len(fake.findMostUsedResourceCachesArgsForCall)]
+	fake.findMostUsedResourceCachesArgsForCall = append(fake.findMostUsedResourceCachesArgsForCall, struct {
+		arg1 int
+		arg2 []string
+		arg3 int
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("FindMostUsedResourceCaches", []interface{}{arg1, arg2Copy, arg3})
+	fake.findMostUsedResourceCachesMutex.Unlock()
+	if fake.FindMostUsedResourceCachesStub != nil {
+		return fake.FindMostUsedResourceCachesStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.findMostUsedResourceCachesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheFactory) FindMostUsedResourceCachesCallCount() int {
+	fake.findMostUsedResourceCachesMutex.RLock()
+	defer fake.findMostUsedResourceCachesMutex.RUnlock()
+	return len(fake.findMostUsedResourceCachesArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) FindMostUsedResourceCachesCalls(stub func(int, []string, int) ([]db.UsedResourceCache, error)) {
+	fake.findMostUsedResourceCachesMutex.Lock()
+	defer fake.findMostUsedResourceCachesMutex.Unlock()
+	fake.FindMostUsedResourceCachesStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) FindMostUsedResourceCachesArgsForCall(i int) (int, []string, int) {
+	fake.findMostUsedResourceCachesMutex.RLock()
+	defer fake.findMostUsedResourceCachesMutex.RUnlock()
+	argsForCall := fake.findMostUsedResourceCachesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceCacheFactory) FindMostUsedResourceCachesReturns(result1 []db.UsedResourceCache, result2 error) {
+	fake.findMostUsedResourceCachesMutex.Lock()
+	defer fake.findMostUsedResourceCachesMutex.Unlock()
+	fake.FindMostUsedResourceCachesStub = nil
+	fake.findMostUsedResourceCachesReturns = struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheFactory) FindMostUsedResourceCachesReturnsOnCall(i int, result1 []db.UsedResourceCache, result2 error) {
+	fake.findMostUsedResourceCachesMutex.Lock()
+	defer fake.findMostUsedResourceCachesMutex.Unlock()
+	fake.FindMostUsedResourceCachesStub = nil
+	if fake.findMostUsedResourceCachesReturnsOnCall == nil {
+		fake.findMostUsedResourceCachesReturnsOnCall = make(map[int]struct {
+			result1 []db.UsedResourceCache
+			result2 error
+		})
+	}
+	fake.findMostUsedResourceCachesReturnsOnCall[i] = struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceCacheFactory) FindOrCreateResourceCache(arg1 db.ResourceCacheUser, arg2 string, arg3 atc.Version, arg4 atc.Source, arg5 atc.Params, arg6 atc.VersionedResourceTypes) (db.UsedResourceCache, error) {
 	fake.findOrCreateResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findOrCreateResourceCacheReturnsOnCall[len(fake.findOrCreateResourceCacheArgsForCall)]
@@ -124,6 +316,72 @@ func (fake *FakeResourceCacheFactory) FindOrCreateResourceCacheReturnsOnCall(i i
 	}{result1, result2}
 }
 
+func (fake *FakeResourceCacheFactory) ResourceCacheImageMetadata(arg1 db.UsedResourceCache) ([]byte, bool, error) {
+	fake.resourceCacheImageMetadataMutex.Lock()
+	ret, specificReturn := fake.resourceCacheImageMetadataReturnsOnCall[len(fake.resourceCacheImageMetadataArgsForCall)]
+	fake.resourceCacheImageMetadataArgsForCall = append(fake.resourceCacheImageMetadataArgsForCall, struct {
+		arg1 db.UsedResourceCache
+	}{arg1})
+	fake.recordInvocation("ResourceCacheImageMetadata", []interface{}{arg1})
+	fake.resourceCacheImageMetadataMutex.Unlock()
+	if fake.ResourceCacheImageMetadataStub != nil {
+		return fake.ResourceCacheImageMetadataStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.resourceCacheImageMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResourceCacheFactory) ResourceCacheImageMetadataCallCount() int {
+	fake.resourceCacheImageMetadataMutex.RLock()
+	defer fake.resourceCacheImageMetadataMutex.RUnlock()
+	return len(fake.resourceCacheImageMetadataArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) ResourceCacheImageMetadataCalls(stub func(db.UsedResourceCache) ([]byte, bool, error)) {
+	fake.resourceCacheImageMetadataMutex.Lock()
+	defer fake.resourceCacheImageMetadataMutex.Unlock()
+	fake.ResourceCacheImageMetadataStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) ResourceCacheImageMetadataArgsForCall(i int) db.UsedResourceCache {
+	fake.resourceCacheImageMetadataMutex.RLock()
+	defer fake.resourceCacheImageMetadataMutex.RUnlock()
+	argsForCall := fake.resourceCacheImageMetadataArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceCacheFactory) ResourceCacheImageMetadataReturns(result1 []byte, result2 bool, result3 error) {
+	fake.resourceCacheImageMetadataMutex.Lock()
+	defer fake.resourceCacheImageMetadataMutex.Unlock()
+	fake.ResourceCacheImageMetadataStub = nil
+	fake.resourceCacheImageMetadataReturns = struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResourceCacheFactory) ResourceCacheImageMetadataReturnsOnCall(i int, result1 []byte, result2 bool, result3 error) {
+	fake.resourceCacheImageMetadataMutex.Lock()
+	defer fake.resourceCacheImageMetadataMutex.Unlock()
+	fake.ResourceCacheImageMetadataStub = nil
+	if fake.resourceCacheImageMetadataReturnsOnCall == nil {
+		fake.resourceCacheImageMetadataReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resourceCacheImageMetadataReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResourceCacheFactory) ResourceCacheMetadata(arg1 db.UsedResourceCache) (db.ResourceConfigMetadataFields, error) {
 	fake.resourceCacheMetadataMutex.Lock()
 	ret, specificReturn := fake.resourceCacheMetadataReturnsOnCall[len(fake.resourceCacheMetadataArgsForCall)]
@@ -187,6 +445,72 @@ func (fake *FakeResourceCacheFactory) ResourceCacheMetadataReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeResourceCacheFactory) UpdateResourceCacheImageMetadata(arg1 db.UsedResourceCache, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.updateResourceCacheImageMetadataMutex.Lock()
+	ret, specificReturn := fake.updateResourceCacheImageMetadataReturnsOnCall[len(fake.updateResourceCacheImageMetadataArgsForCall)]
+	fake.updateResourceCacheImageMetadataArgsForCall = append(fake.updateResourceCacheImageMetadataArgsForCall, struct {
+		arg1 db.UsedResourceCache
+		arg2 []byte
+	}{arg1, arg2Copy})
+	fake.recordInvocation("UpdateResourceCacheImageMetadata", []interface{}{arg1, arg2Copy})
+	fake.updateResourceCacheImageMetadataMutex.Unlock()
+	if fake.UpdateResourceCacheImageMetadataStub != nil {
+		return fake.UpdateResourceCacheImageMetadataStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateResourceCacheImageMetadataReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceCacheFactory) UpdateResourceCacheImageMetadataCallCount() int {
+	fake.updateResourceCacheImageMetadataMutex.RLock()
+	defer fake.updateResourceCacheImageMetadataMutex.RUnlock()
+	return len(fake.updateResourceCacheImageMetadataArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) UpdateResourceCacheImageMetadataCalls(stub func(db.UsedResourceCache, []byte) error) {
+	fake.updateResourceCacheImageMetadataMutex.Lock()
+	defer fake.updateResourceCacheImageMetadataMutex.Unlock()
+	fake.UpdateResourceCacheImageMetadataStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) UpdateResourceCacheImageMetadataArgsForCall(i int) (db.UsedResourceCache, []byte) {
+	fake.updateResourceCacheImageMetadataMutex.RLock()
+	defer fake.updateResourceCacheImageMetadataMutex.RUnlock()
+	argsForCall := fake.updateResourceCacheImageMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceCacheFactory) UpdateResourceCacheImageMetadataReturns(result1 error) {
+	fake.updateResourceCacheImageMetadataMutex.Lock()
+	defer fake.updateResourceCacheImageMetadataMutex.Unlock()
+	fake.UpdateResourceCacheImageMetadataStub = nil
+	fake.updateResourceCacheImageMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceCacheFactory) UpdateResourceCacheImageMetadataReturnsOnCall(i int, result1 error) {
+	fake.updateResourceCacheImageMetadataMutex.Lock()
+	defer fake.updateResourceCacheImageMetadataMutex.Unlock()
+	fake.UpdateResourceCacheImageMetadataStub = nil
+	if fake.updateResourceCacheImageMetadataReturnsOnCall == nil {
+		fake.updateResourceCacheImageMetadataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateResourceCacheImageMetadataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceCacheFactory) UpdateResourceCacheMetadata(arg1 db.UsedResourceCache, arg2 []atc.MetadataField) error {
 	var arg2Copy []atc.MetadataField
 	if arg2 != nil {
@@ -256,10 +580,18 @@ func (fake *FakeResourceCacheFactory) UpdateResourceCacheMetadataReturnsOnCall(i
 func (fake *FakeResourceCacheFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.countResourceCacheUseMutex.RLock()
+	defer fake.countResourceCacheUseMutex.RUnlock()
+	fake.findMostUsedResourceCachesMutex.RLock()
+	defer fake.findMostUsedResourceCachesMutex.RUnlock()
 	fake.findOrCreateResourceCacheMutex.RLock()
 	defer fake.findOrCreateResourceCacheMutex.RUnlock()
+	fake.resourceCacheImageMetadataMutex.RLock()
+	defer fake.resourceCacheImageMetadataMutex.RUnlock()
 	fake.resourceCacheMetadataMutex.RLock()
 	defer fake.resourceCacheMetadataMutex.RUnlock()
+	fake.updateResourceCacheImageMetadataMutex.RLock()
+	defer fake.updateResourceCacheImageMetadataMutex.RUnlock()
 	fake.updateResourceCacheMetadataMutex.RLock()
 	defer fake.updateResourceCacheMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -42,6 +42,20 @@ type FakeResourceCacheLifecycle struct {
 	cleanUsesForFinishedBuildsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExistingResourceCacheIDsStub        func(lager.Logger, []int) ([]int, error)
+	existingResourceCacheIDsMutex       sync.RWMutex
+	existingResourceCacheIDsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []int
+	}
+	existingResourceCacheIDsReturns struct {
+		result1 []int
+		result2 error
+	}
+	existingResourceCacheIDsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -226,6 +240,75 @@ func (fake *FakeResourceCacheLifecycle) CleanUsesForFinishedBuildsReturnsOnCall(
 	}{result1}
 }
 
+func (fake *FakeResourceCacheLifecycle) ExistingResourceCacheIDs(arg1 lager.Logger, arg2 []int) ([]int, error) {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.existingResourceCacheIDsMutex.Lock()
+	ret, specificReturn := fake.existingResourceCacheIDsReturnsOnCall[len(fake.existingResourceCacheIDsArgsForCall)]
+	fake.existingResourceCacheIDsArgsForCall = append(fake.existingResourceCacheIDsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []int
+	}{arg1, arg2Copy})
+	fake.recordInvocation("ExistingResourceCacheIDs", []interface{}{arg1, arg2Copy})
+	fake.existingResourceCacheIDsMutex.Unlock()
+	if fake.ExistingResourceCacheIDsStub != nil {
+		return fake.ExistingResourceCacheIDsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.existingResourceCacheIDsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheLifecycle) ExistingResourceCacheIDsCallCount() int {
+	fake.existingResourceCacheIDsMutex.RLock()
+	defer fake.existingResourceCacheIDsMutex.RUnlock()
+	return len(fake.existingResourceCacheIDsArgsForCall)
+}
+
+func (fake *FakeResourceCacheLifecycle) ExistingResourceCacheIDsCalls(stub func(lager.Logger, []int) ([]int, error)) {
+	fake.existingResourceCacheIDsMutex.Lock()
+	defer fake.existingResourceCacheIDsMutex.Unlock()
+	fake.ExistingResourceCacheIDsStub = stub
+}
+
+func (fake *FakeResourceCacheLifecycle) ExistingResourceCacheIDsArgsForCall(i int) (lager.Logger, []int) {
+	fake.existingResourceCacheIDsMutex.RLock()
+	defer fake.existingResourceCacheIDsMutex.RUnlock()
+	argsForCall := fake.existingResourceCacheIDsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceCacheLifecycle) ExistingResourceCacheIDsReturns(result1 []int, result2 error) {
+	fake.existingResourceCacheIDsMutex.Lock()
+	defer fake.existingResourceCacheIDsMutex.Unlock()
+	fake.ExistingResourceCacheIDsStub = nil
+	fake.existingResourceCacheIDsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheLifecycle) ExistingResourceCacheIDsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.existingResourceCacheIDsMutex.Lock()
+	defer fake.existingResourceCacheIDsMutex.Unlock()
+	fake.ExistingResourceCacheIDsStub = nil
+	if fake.existingResourceCacheIDsReturnsOnCall == nil {
+		fake.existingResourceCacheIDsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.existingResourceCacheIDsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceCacheLifecycle) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -235,6 +318,8 @@ func (fake *FakeResourceCacheLifecycle) Invocations() map[string][][]interface{}
 	defer fake.cleanUpInvalidCachesMutex.RUnlock()
 	fake.cleanUsesForFinishedBuildsMutex.RLock()
 	defer fake.cleanUsesForFinishedBuildsMutex.RUnlock()
+	fake.existingResourceCacheIDsMutex.RLock()
+	defer fake.existingResourceCacheIDsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 )
@@ -76,6 +77,20 @@ type FakeResourceConfigScope struct {
 		result2 bool
 		result3 error
 	}
+	RecordCheckStub        func(time.Time, string, string, error) error
+	recordCheckMutex       sync.RWMutex
+	recordCheckArgsForCall []struct {
+		arg1 time.Time
+		arg2 string
+		arg3 string
+		arg4 error
+	}
+	recordCheckReturns struct {
+		result1 error
+	}
+	recordCheckReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ResourceStub        func() db.Resource
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
@@ -96,6 +111,17 @@ type FakeResourceConfigScope struct {
 	resourceConfigReturnsOnCall map[int]struct {
 		result1 db.ResourceConfig
 	}
+	SaveDeletedVersionsStub        func([]atc.Version) error
+	saveDeletedVersionsMutex       sync.RWMutex
+	saveDeletedVersionsArgsForCall []struct {
+		arg1 []atc.Version
+	}
+	saveDeletedVersionsReturns struct {
+		result1 error
+	}
+	saveDeletedVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveVersionsStub        func([]atc.Version) error
 	saveVersionsMutex       sync.RWMutex
 	saveVersionsArgsForCall []struct {
@@ -118,6 +144,19 @@ type FakeResourceConfigScope struct {
 	setCheckErrorReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateCredentialsStub        func([]creds.Credential) (bool, error)
+	updateCredentialsMutex       sync.RWMutex
+	updateCredentialsArgsForCall []struct {
+		arg1 []creds.Credential
+	}
+	updateCredentialsReturns struct {
+		result1 bool
+		result2 error
+	}
+	updateCredentialsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UpdateLastCheckEndTimeStub        func() (bool, error)
 	updateLastCheckEndTimeMutex       sync.RWMutex
 	updateLastCheckEndTimeArgsForCall []struct {
@@ -442,6 +481,69 @@ func (fake *FakeResourceConfigScope) LatestVersionReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceConfigScope) RecordCheck(arg1 time.Time, arg2 string, arg3 string, arg4 error) error {
+	fake.recordCheckMutex.Lock()
+	ret, specificReturn := fake.recordCheckReturnsOnCall[len(fake.recordCheckArgsForCall)]
+	fake.recordCheckArgsForCall = append(fake.recordCheckArgsForCall, struct {
+		arg1 time.Time
+		arg2 string
+		arg3 string
+		arg4 error
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RecordCheck", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordCheckMutex.Unlock()
+	if fake.RecordCheckStub != nil {
+		return fake.RecordCheckStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.recordCheckReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceConfigScope) RecordCheckCallCount() int {
+	fake.recordCheckMutex.RLock()
+	defer fake.recordCheckMutex.RUnlock()
+	return len(fake.recordCheckArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) RecordCheckCalls(stub func(time.Time, string, string, error) error) {
+	fake.recordCheckMutex.Lock()
+	defer fake.recordCheckMutex.Unlock()
+	fake.RecordCheckStub = stub
+}
+
+func (fake *FakeResourceConfigScope) RecordCheckArgsForCall(i int) (time.Time, string, string, error) {
+	fake.recordCheckMutex.RLock()
+	defer fake.recordCheckMutex.RUnlock()
+	argsForCall := fake.recordCheckArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeResourceConfigScope) RecordCheckReturns(result1 error) {
+	fake.recordCheckMutex.Lock()
+	defer fake.recordCheckMutex.Unlock()
+	fake.RecordCheckStub = nil
+	fake.recordCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceConfigScope) RecordCheckReturnsOnCall(i int, result1 error) {
+	fake.recordCheckMutex.Lock()
+	defer fake.recordCheckMutex.Unlock()
+	fake.RecordCheckStub = nil
+	if fake.recordCheckReturnsOnCall == nil {
+		fake.recordCheckReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordCheckReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceConfigScope) Resource() db.Resource {
 	fake.resourceMutex.Lock()
 	ret, specificReturn := fake.resourceReturnsOnCall[len(fake.resourceArgsForCall)]
@@ -546,6 +648,71 @@ func (fake *FakeResourceConfigScope) ResourceConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeResourceConfigScope) SaveDeletedVersions(arg1 []atc.Version) error {
+	var arg1Copy []atc.Version
+	if arg1 != nil {
+		arg1Copy = make([]atc.Version, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveDeletedVersionsMutex.Lock()
+	ret, specificReturn := fake.saveDeletedVersionsReturnsOnCall[len(fake.saveDeletedVersionsArgsForCall)]
+	fake.saveDeletedVersionsArgsForCall = append(fake.saveDeletedVersionsArgsForCall, struct {
+		arg1 []atc.Version
+	}{arg1Copy})
+	fake.recordInvocation("SaveDeletedVersions", []interface{}{arg1Copy})
+	fake.saveDeletedVersionsMutex.Unlock()
+	if fake.SaveDeletedVersionsStub != nil {
+		return fake.SaveDeletedVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveDeletedVersionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceConfigScope) SaveDeletedVersionsCallCount() int {
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	return len(fake.saveDeletedVersionsArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) SaveDeletedVersionsCalls(stub func([]atc.Version) error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = stub
+}
+
+func (fake *FakeResourceConfigScope) SaveDeletedVersionsArgsForCall(i int) []atc.Version {
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	argsForCall := fake.saveDeletedVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) SaveDeletedVersionsReturns(result1 error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = nil
+	fake.saveDeletedVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceConfigScope) SaveDeletedVersionsReturnsOnCall(i int, result1 error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = nil
+	if fake.saveDeletedVersionsReturnsOnCall == nil {
+		fake.saveDeletedVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveDeletedVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceConfigScope) SaveVersions(arg1 []atc.Version) error {
 	var arg1Copy []atc.Version
 	if arg1 != nil {
@@ -671,6 +838,74 @@ func (fake *FakeResourceConfigScope) SetCheckErrorReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeResourceConfigScope) UpdateCredentials(arg1 []creds.Credential) (bool, error) {
+	var arg1Copy []creds.Credential
+	if arg1 != nil {
+		arg1Copy = make([]creds.Credential, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.updateCredentialsMutex.Lock()
+	ret, specificReturn := fake.updateCredentialsReturnsOnCall[len(fake.updateCredentialsArgsForCall)]
+	fake.updateCredentialsArgsForCall = append(fake.updateCredentialsArgsForCall, struct {
+		arg1 []creds.Credential
+	}{arg1Copy})
+	fake.recordInvocation("UpdateCredentials", []interface{}{arg1Copy})
+	fake.updateCredentialsMutex.Unlock()
+	if fake.UpdateCredentialsStub != nil {
+		return fake.UpdateCredentialsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateCredentialsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) UpdateCredentialsCallCount() int {
+	fake.updateCredentialsMutex.RLock()
+	defer fake.updateCredentialsMutex.RUnlock()
+	return len(fake.updateCredentialsArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) UpdateCredentialsCalls(stub func([]creds.Credential) (bool, error)) {
+	fake.updateCredentialsMutex.Lock()
+	defer fake.updateCredentialsMutex.Unlock()
+	fake.UpdateCredentialsStub = stub
+}
+
+func (fake *FakeResourceConfigScope) UpdateCredentialsArgsForCall(i int) []creds.Credential {
+	fake.updateCredentialsMutex.RLock()
+	defer fake.updateCredentialsMutex.RUnlock()
+	argsForCall := fake.updateCredentialsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) UpdateCredentialsReturns(result1 bool, result2 error) {
+	fake.updateCredentialsMutex.Lock()
+	defer fake.updateCredentialsMutex.Unlock()
+	fake.UpdateCredentialsStub = nil
+	fake.updateCredentialsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateCredentialsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.updateCredentialsMutex.Lock()
+	defer fake.updateCredentialsMutex.Unlock()
+	fake.UpdateCredentialsStub = nil
+	if fake.updateCredentialsReturnsOnCall == nil {
+		fake.updateCredentialsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.updateCredentialsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceConfigScope) UpdateLastCheckEndTime() (bool, error) {
 	fake.updateLastCheckEndTimeMutex.Lock()
 	ret, specificReturn := fake.updateLastCheckEndTimeReturnsOnCall[len(fake.updateLastCheckEndTimeArgsForCall)]
@@ -689,6 +924,8 @@ func (fake *FakeResourceConfigScope) UpdateLastCheckEndTime() (bool, error) {
 }
 
 func (fake *FakeResourceConfigScope) UpdateLastCheckEndTimeCallCount() int {
+	fake.updateCredentialsMutex.RLock()
+	defer fake.updateCredentialsMutex.RUnlock()
 	fake.updateLastCheckEndTimeMutex.RLock()
 	defer fake.updateLastCheckEndTimeMutex.RUnlock()
 	return len(fake.updateLastCheckEndTimeArgsForCall)
@@ -803,10 +1040,14 @@ func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.latestVersionMutex.RLock()
 	defer fake.latestVersionMutex.RUnlock()
+	fake.recordCheckMutex.RLock()
+	defer fake.recordCheckMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceConfigMutex.RLock()
 	defer fake.resourceConfigMutex.RUnlock()
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
 	fake.saveVersionsMutex.RLock()
 	defer fake.saveVersionsMutex.RUnlock()
 	fake.setCheckErrorMutex.RLock()
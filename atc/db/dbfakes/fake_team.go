@@ -11,6 +11,18 @@ import (
 )
 
 type FakeTeam struct {
+	APITokensStub        func() ([]atc.APIToken, error)
+	aPITokensMutex       sync.RWMutex
+	aPITokensArgsForCall []struct {
+	}
+	aPITokensReturns struct {
+		result1 []atc.APIToken
+		result2 error
+	}
+	aPITokensReturnsOnCall map[int]struct {
+		result1 []atc.APIToken
+		result2 error
+	}
 	AdminStub        func() bool
 	adminMutex       sync.RWMutex
 	adminArgsForCall []struct {
@@ -61,6 +73,56 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	CACertsStub        func() string
+	cACertsMutex       sync.RWMutex
+	cACertsArgsForCall []struct {
+	}
+	cACertsReturns struct {
+		result1 string
+	}
+	cACertsReturnsOnCall map[int]struct {
+		result1 string
+	}
+	CheckPolicyStub        func() *atc.CheckPolicy
+	checkPolicyMutex       sync.RWMutex
+	checkPolicyArgsForCall []struct {
+	}
+	checkPolicyReturns struct {
+		result1 *atc.CheckPolicy
+	}
+	checkPolicyReturnsOnCall map[int]struct {
+		result1 *atc.CheckPolicy
+	}
+	ConcurrencyPoolsStub        func() atc.ConcurrencyPools
+	concurrencyPoolsMutex       sync.RWMutex
+	concurrencyPoolsArgsForCall []struct {
+	}
+	concurrencyPoolsReturns struct {
+		result1 atc.ConcurrencyPools
+	}
+	concurrencyPoolsReturnsOnCall map[int]struct {
+		result1 atc.ConcurrencyPools
+	}
+	ContainerDNSStub        func() *atc.ContainerDNS
+	containerDNSMutex       sync.RWMutex
+	containerDNSArgsForCall []struct {
+	}
+	containerDNSReturns struct {
+		result1 *atc.ContainerDNS
+	}
+	containerDNSReturnsOnCall map[int]struct {
+		result1 *atc.ContainerDNS
+	}
+	ContainerEnvStub        func() map[string]string
+	containerEnvMutex       sync.RWMutex
+	containerEnvArgsForCall []struct {
+	}
+	containerEnvReturns struct {
+		result1 map[string]string
+	}
+	containerEnvReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
 	ContainersStub        func() ([]db.Container, error)
 	containersMutex       sync.RWMutex
 	containersArgsForCall []struct {
@@ -73,6 +135,30 @@ type FakeTeam struct {
 		result1 []db.Container
 		result2 error
 	}
+	ContentScanPolicyStub        func() atc.ContentScanPolicy
+	contentScanPolicyMutex       sync.RWMutex
+	contentScanPolicyArgsForCall []struct {
+	}
+	contentScanPolicyReturns struct {
+		result1 atc.ContentScanPolicy
+	}
+	contentScanPolicyReturnsOnCall map[int]struct {
+		result1 atc.ContentScanPolicy
+	}
+	CreateAPITokenStub        func(atc.APITokenRequest, string) (atc.APIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		arg1 atc.APITokenRequest
+		arg2 string
+	}
+	createAPITokenReturns struct {
+		result1 atc.APIToken
+		result2 error
+	}
+	createAPITokenReturnsOnCall map[int]struct {
+		result1 atc.APIToken
+		result2 error
+	}
 	CreateOneOffBuildStub        func() (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
 	createOneOffBuildArgsForCall []struct {
@@ -98,6 +184,16 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	DefaultTaskImageStub        func() *atc.ImageResource
+	defaultTaskImageMutex       sync.RWMutex
+	defaultTaskImageArgsForCall []struct {
+	}
+	defaultTaskImageReturns struct {
+		result1 *atc.ImageResource
+	}
+	defaultTaskImageReturnsOnCall map[int]struct {
+		result1 *atc.ImageResource
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -250,6 +346,26 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	MaxBuildLogSizeStub        func() int64
+	maxBuildLogSizeMutex       sync.RWMutex
+	maxBuildLogSizeArgsForCall []struct {
+	}
+	maxBuildLogSizeReturns struct {
+		result1 int64
+	}
+	maxBuildLogSizeReturnsOnCall map[int]struct {
+		result1 int64
+	}
+	MaxBuildStartsPerMinuteStub        func() int
+	maxBuildStartsPerMinuteMutex       sync.RWMutex
+	maxBuildStartsPerMinuteArgsForCall []struct {
+	}
+	maxBuildStartsPerMinuteReturns struct {
+		result1 int
+	}
+	maxBuildStartsPerMinuteReturnsOnCall map[int]struct {
+		result1 int
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -298,6 +414,26 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	PipelinesRepoStub        func() *atc.PipelinesRepo
+	pipelinesRepoMutex       sync.RWMutex
+	pipelinesRepoArgsForCall []struct {
+	}
+	pipelinesRepoReturns struct {
+		result1 *atc.PipelinesRepo
+	}
+	pipelinesRepoReturnsOnCall map[int]struct {
+		result1 *atc.PipelinesRepo
+	}
+	PipelinesRepoStatusStub        func() *atc.PipelinesRepoStatus
+	pipelinesRepoStatusMutex       sync.RWMutex
+	pipelinesRepoStatusArgsForCall []struct {
+	}
+	pipelinesRepoStatusReturns struct {
+		result1 *atc.PipelinesRepoStatus
+	}
+	pipelinesRepoStatusReturnsOnCall map[int]struct {
+		result1 *atc.PipelinesRepoStatus
+	}
 	PrivateAndPublicBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	privateAndPublicBuildsMutex       sync.RWMutex
 	privateAndPublicBuildsArgsForCall []struct {
@@ -313,6 +449,16 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	PrivilegedPolicyStub        func() *atc.PrivilegedPolicy
+	privilegedPolicyMutex       sync.RWMutex
+	privilegedPolicyArgsForCall []struct {
+	}
+	privilegedPolicyReturns struct {
+		result1 *atc.PrivilegedPolicy
+	}
+	privilegedPolicyReturnsOnCall map[int]struct {
+		result1 *atc.PrivilegedPolicy
+	}
 	PublicPipelinesStub        func() ([]db.Pipeline, error)
 	publicPipelinesMutex       sync.RWMutex
 	publicPipelinesArgsForCall []struct {
@@ -336,6 +482,73 @@ type FakeTeam struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RequireImageDigestsStub        func() bool
+	requireImageDigestsMutex       sync.RWMutex
+	requireImageDigestsArgsForCall []struct {
+	}
+	requireImageDigestsReturns struct {
+		result1 bool
+	}
+	requireImageDigestsReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	ResourceDefaultsStub        func() atc.ResourceDefaults
+	resourceDefaultsMutex       sync.RWMutex
+	resourceDefaultsArgsForCall []struct {
+	}
+	resourceDefaultsReturns struct {
+		result1 atc.ResourceDefaults
+	}
+	resourceDefaultsReturnsOnCall map[int]struct {
+		result1 atc.ResourceDefaults
+	}
+	ResourceTypeHealthStub        func() ([]atc.ResourceTypeHealth, error)
+	resourceTypeHealthMutex       sync.RWMutex
+	resourceTypeHealthArgsForCall []struct {
+	}
+	resourceTypeHealthReturns struct {
+		result1 []atc.ResourceTypeHealth
+		result2 error
+	}
+	resourceTypeHealthReturnsOnCall map[int]struct {
+		result1 []atc.ResourceTypeHealth
+		result2 error
+	}
+	ResourceTypeMappingsStub        func() atc.ResourceTypeMappings
+	resourceTypeMappingsMutex       sync.RWMutex
+	resourceTypeMappingsArgsForCall []struct {
+	}
+	resourceTypeMappingsReturns struct {
+		result1 atc.ResourceTypeMappings
+	}
+	resourceTypeMappingsReturnsOnCall map[int]struct {
+		result1 atc.ResourceTypeMappings
+	}
+	RestoreStub        func() (bool, error)
+	restoreMutex       sync.RWMutex
+	restoreArgsForCall []struct {
+	}
+	restoreReturns struct {
+		result1 bool
+		result2 error
+	}
+	restoreReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeAPITokenStub        func(string) (bool, error)
+	revokeAPITokenMutex       sync.RWMutex
+	revokeAPITokenArgsForCall []struct {
+		arg1 string
+	}
+	revokeAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeAPITokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SavePipelineStub        func(string, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
@@ -368,6 +581,148 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SoftDeleteStub        func() error
+	softDeleteMutex       sync.RWMutex
+	softDeleteArgsForCall []struct {
+	}
+	softDeleteReturns struct {
+		result1 error
+	}
+	softDeleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateCACertsStub        func(string) error
+	updateCACertsMutex       sync.RWMutex
+	updateCACertsArgsForCall []struct {
+		arg1 string
+	}
+	updateCACertsReturns struct {
+		result1 error
+	}
+	updateCACertsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateCheckPolicyStub        func(*atc.CheckPolicy) error
+	updateCheckPolicyMutex       sync.RWMutex
+	updateCheckPolicyArgsForCall []struct {
+		arg1 *atc.CheckPolicy
+	}
+	updateCheckPolicyReturns struct {
+		result1 error
+	}
+	updateCheckPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateConcurrencyPoolsStub        func(atc.ConcurrencyPools) error
+	updateConcurrencyPoolsMutex       sync.RWMutex
+	updateConcurrencyPoolsArgsForCall []struct {
+		arg1 atc.ConcurrencyPools
+	}
+	updateConcurrencyPoolsReturns struct {
+		result1 error
+	}
+	updateConcurrencyPoolsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateContainerDNSStub        func(*atc.ContainerDNS) error
+	updateContainerDNSMutex       sync.RWMutex
+	updateContainerDNSArgsForCall []struct {
+		arg1 *atc.ContainerDNS
+	}
+	updateContainerDNSReturns struct {
+		result1 error
+	}
+	updateContainerDNSReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateContainerEnvStub        func(map[string]string) error
+	updateContainerEnvMutex       sync.RWMutex
+	updateContainerEnvArgsForCall []struct {
+		arg1 map[string]string
+	}
+	updateContainerEnvReturns struct {
+		result1 error
+	}
+	updateContainerEnvReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateContentScanPolicyStub        func(atc.ContentScanPolicy) error
+	updateContentScanPolicyMutex       sync.RWMutex
+	updateContentScanPolicyArgsForCall []struct {
+		arg1 atc.ContentScanPolicy
+	}
+	updateContentScanPolicyReturns struct {
+		result1 error
+	}
+	updateContentScanPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateDefaultTaskImageStub        func(*atc.ImageResource) error
+	updateDefaultTaskImageMutex       sync.RWMutex
+	updateDefaultTaskImageArgsForCall []struct {
+		arg1 *atc.ImageResource
+	}
+	updateDefaultTaskImageReturns struct {
+		result1 error
+	}
+	updateDefaultTaskImageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateMaxBuildLogSizeStub        func(int64) error
+	updateMaxBuildLogSizeMutex       sync.RWMutex
+	updateMaxBuildLogSizeArgsForCall []struct {
+		arg1 int64
+	}
+	updateMaxBuildLogSizeReturns struct {
+		result1 error
+	}
+	updateMaxBuildLogSizeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateMaxBuildStartsPerMinuteStub        func(int) error
+	updateMaxBuildStartsPerMinuteMutex       sync.RWMutex
+	updateMaxBuildStartsPerMinuteArgsForCall []struct {
+		arg1 int
+	}
+	updateMaxBuildStartsPerMinuteReturns struct {
+		result1 error
+	}
+	updateMaxBuildStartsPerMinuteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePipelinesRepoStub        func(*atc.PipelinesRepo) error
+	updatePipelinesRepoMutex       sync.RWMutex
+	updatePipelinesRepoArgsForCall []struct {
+		arg1 *atc.PipelinesRepo
+	}
+	updatePipelinesRepoReturns struct {
+		result1 error
+	}
+	updatePipelinesRepoReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePipelinesRepoStatusStub        func(atc.PipelinesRepoStatus) error
+	updatePipelinesRepoStatusMutex       sync.RWMutex
+	updatePipelinesRepoStatusArgsForCall []struct {
+		arg1 atc.PipelinesRepoStatus
+	}
+	updatePipelinesRepoStatusReturns struct {
+		result1 error
+	}
+	updatePipelinesRepoStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePrivilegedPolicyStub        func(*atc.PrivilegedPolicy) error
+	updatePrivilegedPolicyMutex       sync.RWMutex
+	updatePrivilegedPolicyArgsForCall []struct {
+		arg1 *atc.PrivilegedPolicy
+	}
+	updatePrivilegedPolicyReturns struct {
+		result1 error
+	}
+	updatePrivilegedPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -379,6 +734,51 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateSettingsStub        func(atc.Team, []string) error
+	updateSettingsMutex       sync.RWMutex
+	updateSettingsArgsForCall []struct {
+		arg1 atc.Team
+		arg2 []string
+	}
+	updateSettingsReturns struct {
+		result1 error
+	}
+	updateSettingsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateRequireImageDigestsStub        func(bool) error
+	updateRequireImageDigestsMutex       sync.RWMutex
+	updateRequireImageDigestsArgsForCall []struct {
+		arg1 bool
+	}
+	updateRequireImageDigestsReturns struct {
+		result1 error
+	}
+	updateRequireImageDigestsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateResourceDefaultsStub        func(atc.ResourceDefaults) error
+	updateResourceDefaultsMutex       sync.RWMutex
+	updateResourceDefaultsArgsForCall []struct {
+		arg1 atc.ResourceDefaults
+	}
+	updateResourceDefaultsReturns struct {
+		result1 error
+	}
+	updateResourceDefaultsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateResourceTypeMappingsStub        func(atc.ResourceTypeMappings) error
+	updateResourceTypeMappingsMutex       sync.RWMutex
+	updateResourceTypeMappingsArgsForCall []struct {
+		arg1 atc.ResourceTypeMappings
+	}
+	updateResourceTypeMappingsReturns struct {
+		result1 error
+	}
+	updateResourceTypeMappingsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -395,6 +795,61 @@ type FakeTeam struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTeam) APITokens() ([]atc.APIToken, error) {
+	fake.aPITokensMutex.Lock()
+	ret, specificReturn := fake.aPITokensReturnsOnCall[len(fake.aPITokensArgsForCall)]
+	fake.aPITokensArgsForCall = append(fake.aPITokensArgsForCall, struct {
+	}{})
+	fake.recordInvocation("APITokens", []interface{}{})
+	fake.aPITokensMutex.Unlock()
+	if fake.APITokensStub != nil {
+		return fake.APITokensStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.aPITokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) APITokensCallCount() int {
+	fake.aPITokensMutex.RLock()
+	defer fake.aPITokensMutex.RUnlock()
+	return len(fake.aPITokensArgsForCall)
+}
+
+func (fake *FakeTeam) APITokensCalls(stub func() ([]atc.APIToken, error)) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = stub
+}
+
+func (fake *FakeTeam) APITokensReturns(result1 []atc.APIToken, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	fake.aPITokensReturns = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) APITokensReturnsOnCall(i int, result1 []atc.APIToken, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	if fake.aPITokensReturnsOnCall == nil {
+		fake.aPITokensReturnsOnCall = make(map[int]struct {
+			result1 []atc.APIToken
+			result2 error
+		})
+	}
+	fake.aPITokensReturnsOnCall[i] = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Admin() bool {
 	fake.adminMutex.Lock()
 	ret, specificReturn := fake.adminReturnsOnCall[len(fake.adminArgsForCall)]
@@ -631,85 +1086,461 @@ func (fake *FakeTeam) BuildsWithTimeReturnsOnCall(i int, result1 []db.Build, res
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) Containers() ([]db.Container, error) {
-	fake.containersMutex.Lock()
-	ret, specificReturn := fake.containersReturnsOnCall[len(fake.containersArgsForCall)]
-	fake.containersArgsForCall = append(fake.containersArgsForCall, struct {
+func (fake *FakeTeam) CACerts() string {
+	fake.cACertsMutex.Lock()
+	ret, specificReturn := fake.cACertsReturnsOnCall[len(fake.cACertsArgsForCall)]
+	fake.cACertsArgsForCall = append(fake.cACertsArgsForCall, struct {
 	}{})
-	fake.recordInvocation("Containers", []interface{}{})
-	fake.containersMutex.Unlock()
-	if fake.ContainersStub != nil {
-		return fake.ContainersStub()
+	fake.recordInvocation("CACerts", []interface{}{})
+	fake.cACertsMutex.Unlock()
+	if fake.CACertsStub != nil {
+		return fake.CACertsStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	fakeReturns := fake.containersReturns
-	return fakeReturns.result1, fakeReturns.result2
+	fakeReturns := fake.cACertsReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeTeam) ContainersCallCount() int {
-	fake.containersMutex.RLock()
-	defer fake.containersMutex.RUnlock()
-	return len(fake.containersArgsForCall)
+func (fake *FakeTeam) CACertsCallCount() int {
+	fake.cACertsMutex.RLock()
+	defer fake.cACertsMutex.RUnlock()
+	return len(fake.cACertsArgsForCall)
 }
 
-func (fake *FakeTeam) ContainersCalls(stub func() ([]db.Container, error)) {
-	fake.containersMutex.Lock()
-	defer fake.containersMutex.Unlock()
-	fake.ContainersStub = stub
+func (fake *FakeTeam) CACertsCalls(stub func() string) {
+	fake.cACertsMutex.Lock()
+	defer fake.cACertsMutex.Unlock()
+	fake.CACertsStub = stub
 }
 
-func (fake *FakeTeam) ContainersReturns(result1 []db.Container, result2 error) {
-	fake.containersMutex.Lock()
-	defer fake.containersMutex.Unlock()
-	fake.ContainersStub = nil
-	fake.containersReturns = struct {
-		result1 []db.Container
-		result2 error
-	}{result1, result2}
+func (fake *FakeTeam) CACertsReturns(result1 string) {
+	fake.cACertsMutex.Lock()
+	defer fake.cACertsMutex.Unlock()
+	fake.CACertsStub = nil
+	fake.cACertsReturns = struct {
+		result1 string
+	}{result1}
 }
 
-func (fake *FakeTeam) ContainersReturnsOnCall(i int, result1 []db.Container, result2 error) {
-	fake.containersMutex.Lock()
-	defer fake.containersMutex.Unlock()
-	fake.ContainersStub = nil
-	if fake.containersReturnsOnCall == nil {
-		fake.containersReturnsOnCall = make(map[int]struct {
-			result1 []db.Container
-			result2 error
+func (fake *FakeTeam) CACertsReturnsOnCall(i int, result1 string) {
+	fake.cACertsMutex.Lock()
+	defer fake.cACertsMutex.Unlock()
+	fake.CACertsStub = nil
+	if fake.cACertsReturnsOnCall == nil {
+		fake.cACertsReturnsOnCall = make(map[int]struct {
+			result1 string
 		})
 	}
-	fake.containersReturnsOnCall[i] = struct {
-		result1 []db.Container
-		result2 error
-	}{result1, result2}
+	fake.cACertsReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
 }
 
-func (fake *FakeTeam) CreateOneOffBuild() (db.Build, error) {
-	fake.createOneOffBuildMutex.Lock()
-	ret, specificReturn := fake.createOneOffBuildReturnsOnCall[len(fake.createOneOffBuildArgsForCall)]
-	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct {
+func (fake *FakeTeam) CheckPolicy() *atc.CheckPolicy {
+	fake.checkPolicyMutex.Lock()
+	ret, specificReturn := fake.checkPolicyReturnsOnCall[len(fake.checkPolicyArgsForCall)]
+	fake.checkPolicyArgsForCall = append(fake.checkPolicyArgsForCall, struct {
 	}{})
-	fake.recordInvocation("CreateOneOffBuild", []interface{}{})
-	fake.createOneOffBuildMutex.Unlock()
-	if fake.CreateOneOffBuildStub != nil {
-		return fake.CreateOneOffBuildStub()
+	fake.recordInvocation("CheckPolicy", []interface{}{})
+	fake.checkPolicyMutex.Unlock()
+	if fake.CheckPolicyStub != nil {
+		return fake.CheckPolicyStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	fakeReturns := fake.createOneOffBuildReturns
-	return fakeReturns.result1, fakeReturns.result2
+	fakeReturns := fake.checkPolicyReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeTeam) CreateOneOffBuildCallCount() int {
-	fake.createOneOffBuildMutex.RLock()
-	defer fake.createOneOffBuildMutex.RUnlock()
-	return len(fake.createOneOffBuildArgsForCall)
+func (fake *FakeTeam) CheckPolicyCallCount() int {
+	fake.checkPolicyMutex.RLock()
+	defer fake.checkPolicyMutex.RUnlock()
+	return len(fake.checkPolicyArgsForCall)
 }
 
-func (fake *FakeTeam) CreateOneOffBuildCalls(stub func() (db.Build, error)) {
+func (fake *FakeTeam) CheckPolicyCalls(stub func() *atc.CheckPolicy) {
+	fake.checkPolicyMutex.Lock()
+	defer fake.checkPolicyMutex.Unlock()
+	fake.CheckPolicyStub = stub
+}
+
+func (fake *FakeTeam) CheckPolicyReturns(result1 *atc.CheckPolicy) {
+	fake.checkPolicyMutex.Lock()
+	defer fake.checkPolicyMutex.Unlock()
+	fake.CheckPolicyStub = nil
+	fake.checkPolicyReturns = struct {
+		result1 *atc.CheckPolicy
+	}{result1}
+}
+
+func (fake *FakeTeam) CheckPolicyReturnsOnCall(i int, result1 *atc.CheckPolicy) {
+	fake.checkPolicyMutex.Lock()
+	defer fake.checkPolicyMutex.Unlock()
+	fake.CheckPolicyStub = nil
+	if fake.checkPolicyReturnsOnCall == nil {
+		fake.checkPolicyReturnsOnCall = make(map[int]struct {
+			result1 *atc.CheckPolicy
+		})
+	}
+	fake.checkPolicyReturnsOnCall[i] = struct {
+		result1 *atc.CheckPolicy
+	}{result1}
+}
+
+func (fake *FakeTeam) ConcurrencyPools() atc.ConcurrencyPools {
+	fake.concurrencyPoolsMutex.Lock()
+	ret, specificReturn := fake.concurrencyPoolsReturnsOnCall[len(fake.concurrencyPoolsArgsForCall)]
+	fake.concurrencyPoolsArgsForCall = append(fake.concurrencyPoolsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConcurrencyPools", []interface{}{})
+	fake.concurrencyPoolsMutex.Unlock()
+	if fake.ConcurrencyPoolsStub != nil {
+		return fake.ConcurrencyPoolsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.concurrencyPoolsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ConcurrencyPoolsCallCount() int {
+	fake.concurrencyPoolsMutex.RLock()
+	defer fake.concurrencyPoolsMutex.RUnlock()
+	return len(fake.concurrencyPoolsArgsForCall)
+}
+
+func (fake *FakeTeam) ConcurrencyPoolsCalls(stub func() atc.ConcurrencyPools) {
+	fake.concurrencyPoolsMutex.Lock()
+	defer fake.concurrencyPoolsMutex.Unlock()
+	fake.ConcurrencyPoolsStub = stub
+}
+
+func (fake *FakeTeam) ConcurrencyPoolsReturns(result1 atc.ConcurrencyPools) {
+	fake.concurrencyPoolsMutex.Lock()
+	defer fake.concurrencyPoolsMutex.Unlock()
+	fake.ConcurrencyPoolsStub = nil
+	fake.concurrencyPoolsReturns = struct {
+		result1 atc.ConcurrencyPools
+	}{result1}
+}
+
+func (fake *FakeTeam) ConcurrencyPoolsReturnsOnCall(i int, result1 atc.ConcurrencyPools) {
+	fake.concurrencyPoolsMutex.Lock()
+	defer fake.concurrencyPoolsMutex.Unlock()
+	fake.ConcurrencyPoolsStub = nil
+	if fake.concurrencyPoolsReturnsOnCall == nil {
+		fake.concurrencyPoolsReturnsOnCall = make(map[int]struct {
+			result1 atc.ConcurrencyPools
+		})
+	}
+	fake.concurrencyPoolsReturnsOnCall[i] = struct {
+		result1 atc.ConcurrencyPools
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerDNS() *atc.ContainerDNS {
+	fake.containerDNSMutex.Lock()
+	ret, specificReturn := fake.containerDNSReturnsOnCall[len(fake.containerDNSArgsForCall)]
+	fake.containerDNSArgsForCall = append(fake.containerDNSArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ContainerDNS", []interface{}{})
+	fake.containerDNSMutex.Unlock()
+	if fake.ContainerDNSStub != nil {
+		return fake.ContainerDNSStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerDNSReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ContainerDNSCallCount() int {
+	fake.containerDNSMutex.RLock()
+	defer fake.containerDNSMutex.RUnlock()
+	return len(fake.containerDNSArgsForCall)
+}
+
+func (fake *FakeTeam) ContainerDNSCalls(stub func() *atc.ContainerDNS) {
+	fake.containerDNSMutex.Lock()
+	defer fake.containerDNSMutex.Unlock()
+	fake.ContainerDNSStub = stub
+}
+
+func (fake *FakeTeam) ContainerDNSReturns(result1 *atc.ContainerDNS) {
+	fake.containerDNSMutex.Lock()
+	defer fake.containerDNSMutex.Unlock()
+	fake.ContainerDNSStub = nil
+	fake.containerDNSReturns = struct {
+		result1 *atc.ContainerDNS
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerDNSReturnsOnCall(i int, result1 *atc.ContainerDNS) {
+	fake.containerDNSMutex.Lock()
+	defer fake.containerDNSMutex.Unlock()
+	fake.ContainerDNSStub = nil
+	if fake.containerDNSReturnsOnCall == nil {
+		fake.containerDNSReturnsOnCall = make(map[int]struct {
+			result1 *atc.ContainerDNS
+		})
+	}
+	fake.containerDNSReturnsOnCall[i] = struct {
+		result1 *atc.ContainerDNS
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerEnv() map[string]string {
+	fake.containerEnvMutex.Lock()
+	ret, specificReturn := fake.containerEnvReturnsOnCall[len(fake.containerEnvArgsForCall)]
+	fake.containerEnvArgsForCall = append(fake.containerEnvArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ContainerEnv", []interface{}{})
+	fake.containerEnvMutex.Unlock()
+	if fake.ContainerEnvStub != nil {
+		return fake.ContainerEnvStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerEnvReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ContainerEnvCallCount() int {
+	fake.containerEnvMutex.RLock()
+	defer fake.containerEnvMutex.RUnlock()
+	return len(fake.containerEnvArgsForCall)
+}
+
+func (fake *FakeTeam) ContainerEnvCalls(stub func() map[string]string) {
+	fake.containerEnvMutex.Lock()
+	defer fake.containerEnvMutex.Unlock()
+	fake.ContainerEnvStub = stub
+}
+
+func (fake *FakeTeam) ContainerEnvReturns(result1 map[string]string) {
+	fake.containerEnvMutex.Lock()
+	defer fake.containerEnvMutex.Unlock()
+	fake.ContainerEnvStub = nil
+	fake.containerEnvReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerEnvReturnsOnCall(i int, result1 map[string]string) {
+	fake.containerEnvMutex.Lock()
+	defer fake.containerEnvMutex.Unlock()
+	fake.ContainerEnvStub = nil
+	if fake.containerEnvReturnsOnCall == nil {
+		fake.containerEnvReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.containerEnvReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeTeam) Containers() ([]db.Container, error) {
+	fake.containersMutex.Lock()
+	ret, specificReturn := fake.containersReturnsOnCall[len(fake.containersArgsForCall)]
+	fake.containersArgsForCall = append(fake.containersArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Containers", []interface{}{})
+	fake.containersMutex.Unlock()
+	if fake.ContainersStub != nil {
+		return fake.ContainersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.containersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ContainersCallCount() int {
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
+	return len(fake.containersArgsForCall)
+}
+
+func (fake *FakeTeam) ContainersCalls(stub func() ([]db.Container, error)) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = stub
+}
+
+func (fake *FakeTeam) ContainersReturns(result1 []db.Container, result2 error) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = nil
+	fake.containersReturns = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ContainersReturnsOnCall(i int, result1 []db.Container, result2 error) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = nil
+	if fake.containersReturnsOnCall == nil {
+		fake.containersReturnsOnCall = make(map[int]struct {
+			result1 []db.Container
+			result2 error
+		})
+	}
+	fake.containersReturnsOnCall[i] = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ContentScanPolicy() atc.ContentScanPolicy {
+	fake.contentScanPolicyMutex.Lock()
+	ret, specificReturn := fake.contentScanPolicyReturnsOnCall[len(fake.contentScanPolicyArgsForCall)]
+	fake.contentScanPolicyArgsForCall = append(fake.contentScanPolicyArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ContentScanPolicy", []interface{}{})
+	fake.contentScanPolicyMutex.Unlock()
+	if fake.ContentScanPolicyStub != nil {
+		return fake.ContentScanPolicyStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.contentScanPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ContentScanPolicyCallCount() int {
+	fake.contentScanPolicyMutex.RLock()
+	defer fake.contentScanPolicyMutex.RUnlock()
+	return len(fake.contentScanPolicyArgsForCall)
+}
+
+func (fake *FakeTeam) ContentScanPolicyCalls(stub func() atc.ContentScanPolicy) {
+	fake.contentScanPolicyMutex.Lock()
+	defer fake.contentScanPolicyMutex.Unlock()
+	fake.ContentScanPolicyStub = stub
+}
+
+func (fake *FakeTeam) ContentScanPolicyReturns(result1 atc.ContentScanPolicy) {
+	fake.contentScanPolicyMutex.Lock()
+	defer fake.contentScanPolicyMutex.Unlock()
+	fake.ContentScanPolicyStub = nil
+	fake.contentScanPolicyReturns = struct {
+		result1 atc.ContentScanPolicy
+	}{result1}
+}
+
+func (fake *FakeTeam) ContentScanPolicyReturnsOnCall(i int, result1 atc.ContentScanPolicy) {
+	fake.contentScanPolicyMutex.Lock()
+	defer fake.contentScanPolicyMutex.Unlock()
+	fake.ContentScanPolicyStub = nil
+	if fake.contentScanPolicyReturnsOnCall == nil {
+		fake.contentScanPolicyReturnsOnCall = make(map[int]struct {
+			result1 atc.ContentScanPolicy
+		})
+	}
+	fake.contentScanPolicyReturnsOnCall[i] = struct {
+		result1 atc.ContentScanPolicy
+	}{result1}
+}
+
+func (fake *FakeTeam) CreateAPIToken(arg1 atc.APITokenRequest, arg2 string) (atc.APIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	ret, specificReturn := fake.createAPITokenReturnsOnCall[len(fake.createAPITokenArgsForCall)]
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		arg1 atc.APITokenRequest
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CreateAPIToken", []interface{}{arg1, arg2})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createAPITokenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeam) CreateAPITokenCalls(stub func(atc.APITokenRequest, string) (atc.APIToken, error)) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = stub
+}
+
+func (fake *FakeTeam) CreateAPITokenArgsForCall(i int) (atc.APITokenRequest, string) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	argsForCall := fake.createAPITokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) CreateAPITokenReturns(result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateAPITokenReturnsOnCall(i int, result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	if fake.createAPITokenReturnsOnCall == nil {
+		fake.createAPITokenReturnsOnCall = make(map[int]struct {
+			result1 atc.APIToken
+			result2 error
+		})
+	}
+	fake.createAPITokenReturnsOnCall[i] = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateOneOffBuild() (db.Build, error) {
+	fake.createOneOffBuildMutex.Lock()
+	ret, specificReturn := fake.createOneOffBuildReturnsOnCall[len(fake.createOneOffBuildArgsForCall)]
+	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreateOneOffBuild", []interface{}{})
+	fake.createOneOffBuildMutex.Unlock()
+	if fake.CreateOneOffBuildStub != nil {
+		return fake.CreateOneOffBuildStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createOneOffBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateOneOffBuildCallCount() int {
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	return len(fake.createOneOffBuildArgsForCall)
+}
+
+func (fake *FakeTeam) CreateOneOffBuildCalls(stub func() (db.Build, error)) {
 	fake.createOneOffBuildMutex.Lock()
 	defer fake.createOneOffBuildMutex.Unlock()
 	fake.CreateOneOffBuildStub = stub
@@ -804,6 +1635,58 @@ func (fake *FakeTeam) CreateStartedBuildReturnsOnCall(i int, result1 db.Build, r
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) DefaultTaskImage() *atc.ImageResource {
+	fake.defaultTaskImageMutex.Lock()
+	ret, specificReturn := fake.defaultTaskImageReturnsOnCall[len(fake.defaultTaskImageArgsForCall)]
+	fake.defaultTaskImageArgsForCall = append(fake.defaultTaskImageArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DefaultTaskImage", []interface{}{})
+	fake.defaultTaskImageMutex.Unlock()
+	if fake.DefaultTaskImageStub != nil {
+		return fake.DefaultTaskImageStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.defaultTaskImageReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) DefaultTaskImageCallCount() int {
+	fake.defaultTaskImageMutex.RLock()
+	defer fake.defaultTaskImageMutex.RUnlock()
+	return len(fake.defaultTaskImageArgsForCall)
+}
+
+func (fake *FakeTeam) DefaultTaskImageCalls(stub func() *atc.ImageResource) {
+	fake.defaultTaskImageMutex.Lock()
+	defer fake.defaultTaskImageMutex.Unlock()
+	fake.DefaultTaskImageStub = stub
+}
+
+func (fake *FakeTeam) DefaultTaskImageReturns(result1 *atc.ImageResource) {
+	fake.defaultTaskImageMutex.Lock()
+	defer fake.defaultTaskImageMutex.Unlock()
+	fake.DefaultTaskImageStub = nil
+	fake.defaultTaskImageReturns = struct {
+		result1 *atc.ImageResource
+	}{result1}
+}
+
+func (fake *FakeTeam) DefaultTaskImageReturnsOnCall(i int, result1 *atc.ImageResource) {
+	fake.defaultTaskImageMutex.Lock()
+	defer fake.defaultTaskImageMutex.Unlock()
+	fake.DefaultTaskImageStub = nil
+	if fake.defaultTaskImageReturnsOnCall == nil {
+		fake.defaultTaskImageReturnsOnCall = make(map[int]struct {
+			result1 *atc.ImageResource
+		})
+	}
+	fake.defaultTaskImageReturnsOnCall[i] = struct {
+		result1 *atc.ImageResource
+	}{result1}
+}
+
 func (fake *FakeTeam) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -1496,66 +2379,170 @@ func (fake *FakeTeam) IsContainerWithinTeamReturnsOnCall(i int, result1 bool, re
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Name() string {
-	fake.nameMutex.Lock()
-	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
-	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+func (fake *FakeTeam) MaxBuildLogSize() int64 {
+	fake.maxBuildLogSizeMutex.Lock()
+	ret, specificReturn := fake.maxBuildLogSizeReturnsOnCall[len(fake.maxBuildLogSizeArgsForCall)]
+	fake.maxBuildLogSizeArgsForCall = append(fake.maxBuildLogSizeArgsForCall, struct {
 	}{})
-	fake.recordInvocation("Name", []interface{}{})
-	fake.nameMutex.Unlock()
-	if fake.NameStub != nil {
-		return fake.NameStub()
+	fake.recordInvocation("MaxBuildLogSize", []interface{}{})
+	fake.maxBuildLogSizeMutex.Unlock()
+	if fake.MaxBuildLogSizeStub != nil {
+		return fake.MaxBuildLogSizeStub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.nameReturns
+	fakeReturns := fake.maxBuildLogSizeReturns
 	return fakeReturns.result1
 }
 
-func (fake *FakeTeam) NameCallCount() int {
-	fake.nameMutex.RLock()
-	defer fake.nameMutex.RUnlock()
-	return len(fake.nameArgsForCall)
+func (fake *FakeTeam) MaxBuildLogSizeCallCount() int {
+	fake.maxBuildLogSizeMutex.RLock()
+	defer fake.maxBuildLogSizeMutex.RUnlock()
+	return len(fake.maxBuildLogSizeArgsForCall)
 }
 
-func (fake *FakeTeam) NameCalls(stub func() string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = stub
+func (fake *FakeTeam) MaxBuildLogSizeCalls(stub func() int64) {
+	fake.maxBuildLogSizeMutex.Lock()
+	defer fake.maxBuildLogSizeMutex.Unlock()
+	fake.MaxBuildLogSizeStub = stub
 }
 
-func (fake *FakeTeam) NameReturns(result1 string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = nil
-	fake.nameReturns = struct {
-		result1 string
+func (fake *FakeTeam) MaxBuildLogSizeReturns(result1 int64) {
+	fake.maxBuildLogSizeMutex.Lock()
+	defer fake.maxBuildLogSizeMutex.Unlock()
+	fake.MaxBuildLogSizeStub = nil
+	fake.maxBuildLogSizeReturns = struct {
+		result1 int64
 	}{result1}
 }
 
-func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = nil
-	if fake.nameReturnsOnCall == nil {
-		fake.nameReturnsOnCall = make(map[int]struct {
-			result1 string
+func (fake *FakeTeam) MaxBuildLogSizeReturnsOnCall(i int, result1 int64) {
+	fake.maxBuildLogSizeMutex.Lock()
+	defer fake.maxBuildLogSizeMutex.Unlock()
+	fake.MaxBuildLogSizeStub = nil
+	if fake.maxBuildLogSizeReturnsOnCall == nil {
+		fake.maxBuildLogSizeReturnsOnCall = make(map[int]struct {
+			result1 int64
 		})
 	}
-	fake.nameReturnsOnCall[i] = struct {
-		result1 string
+	fake.maxBuildLogSizeReturnsOnCall[i] = struct {
+		result1 int64
 	}{result1}
 }
 
-func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
-	var arg1Copy []string
-	if arg1 != nil {
-		arg1Copy = make([]string, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *FakeTeam) MaxBuildStartsPerMinute() int {
+	fake.maxBuildStartsPerMinuteMutex.Lock()
+	ret, specificReturn := fake.maxBuildStartsPerMinuteReturnsOnCall[len(fake.maxBuildStartsPerMinuteArgsForCall)]
+	fake.maxBuildStartsPerMinuteArgsForCall = append(fake.maxBuildStartsPerMinuteArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MaxBuildStartsPerMinute", []interface{}{})
+	fake.maxBuildStartsPerMinuteMutex.Unlock()
+	if fake.MaxBuildStartsPerMinuteStub != nil {
+		return fake.MaxBuildStartsPerMinuteStub()
 	}
-	fake.orderPipelinesMutex.Lock()
-	ret, specificReturn := fake.orderPipelinesReturnsOnCall[len(fake.orderPipelinesArgsForCall)]
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.maxBuildStartsPerMinuteReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) MaxBuildStartsPerMinuteCallCount() int {
+	fake.maxBuildStartsPerMinuteMutex.RLock()
+	defer fake.maxBuildStartsPerMinuteMutex.RUnlock()
+	return len(fake.maxBuildStartsPerMinuteArgsForCall)
+}
+
+func (fake *FakeTeam) MaxBuildStartsPerMinuteCalls(stub func() int) {
+	fake.maxBuildStartsPerMinuteMutex.Lock()
+	defer fake.maxBuildStartsPerMinuteMutex.Unlock()
+	fake.MaxBuildStartsPerMinuteStub = stub
+}
+
+func (fake *FakeTeam) MaxBuildStartsPerMinuteReturns(result1 int) {
+	fake.maxBuildStartsPerMinuteMutex.Lock()
+	defer fake.maxBuildStartsPerMinuteMutex.Unlock()
+	fake.MaxBuildStartsPerMinuteStub = nil
+	fake.maxBuildStartsPerMinuteReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) MaxBuildStartsPerMinuteReturnsOnCall(i int, result1 int) {
+	fake.maxBuildStartsPerMinuteMutex.Lock()
+	defer fake.maxBuildStartsPerMinuteMutex.Unlock()
+	fake.MaxBuildStartsPerMinuteStub = nil
+	if fake.maxBuildStartsPerMinuteReturnsOnCall == nil {
+		fake.maxBuildStartsPerMinuteReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxBuildStartsPerMinuteReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if fake.NameStub != nil {
+		return fake.NameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.nameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeTeam) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeTeam) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.orderPipelinesMutex.Lock()
+	ret, specificReturn := fake.orderPipelinesReturnsOnCall[len(fake.orderPipelinesArgsForCall)]
 	fake.orderPipelinesArgsForCall = append(fake.orderPipelinesArgsForCall, struct {
 		arg1 []string
 	}{arg1Copy})
@@ -1734,6 +2721,110 @@ func (fake *FakeTeam) PipelinesReturnsOnCall(i int, result1 []db.Pipeline, resul
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) PipelinesRepo() *atc.PipelinesRepo {
+	fake.pipelinesRepoMutex.Lock()
+	ret, specificReturn := fake.pipelinesRepoReturnsOnCall[len(fake.pipelinesRepoArgsForCall)]
+	fake.pipelinesRepoArgsForCall = append(fake.pipelinesRepoArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelinesRepo", []interface{}{})
+	fake.pipelinesRepoMutex.Unlock()
+	if fake.PipelinesRepoStub != nil {
+		return fake.PipelinesRepoStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelinesRepoReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) PipelinesRepoCallCount() int {
+	fake.pipelinesRepoMutex.RLock()
+	defer fake.pipelinesRepoMutex.RUnlock()
+	return len(fake.pipelinesRepoArgsForCall)
+}
+
+func (fake *FakeTeam) PipelinesRepoCalls(stub func() *atc.PipelinesRepo) {
+	fake.pipelinesRepoMutex.Lock()
+	defer fake.pipelinesRepoMutex.Unlock()
+	fake.PipelinesRepoStub = stub
+}
+
+func (fake *FakeTeam) PipelinesRepoReturns(result1 *atc.PipelinesRepo) {
+	fake.pipelinesRepoMutex.Lock()
+	defer fake.pipelinesRepoMutex.Unlock()
+	fake.PipelinesRepoStub = nil
+	fake.pipelinesRepoReturns = struct {
+		result1 *atc.PipelinesRepo
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelinesRepoReturnsOnCall(i int, result1 *atc.PipelinesRepo) {
+	fake.pipelinesRepoMutex.Lock()
+	defer fake.pipelinesRepoMutex.Unlock()
+	fake.PipelinesRepoStub = nil
+	if fake.pipelinesRepoReturnsOnCall == nil {
+		fake.pipelinesRepoReturnsOnCall = make(map[int]struct {
+			result1 *atc.PipelinesRepo
+		})
+	}
+	fake.pipelinesRepoReturnsOnCall[i] = struct {
+		result1 *atc.PipelinesRepo
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelinesRepoStatus() *atc.PipelinesRepoStatus {
+	fake.pipelinesRepoStatusMutex.Lock()
+	ret, specificReturn := fake.pipelinesRepoStatusReturnsOnCall[len(fake.pipelinesRepoStatusArgsForCall)]
+	fake.pipelinesRepoStatusArgsForCall = append(fake.pipelinesRepoStatusArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelinesRepoStatus", []interface{}{})
+	fake.pipelinesRepoStatusMutex.Unlock()
+	if fake.PipelinesRepoStatusStub != nil {
+		return fake.PipelinesRepoStatusStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelinesRepoStatusReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) PipelinesRepoStatusCallCount() int {
+	fake.pipelinesRepoStatusMutex.RLock()
+	defer fake.pipelinesRepoStatusMutex.RUnlock()
+	return len(fake.pipelinesRepoStatusArgsForCall)
+}
+
+func (fake *FakeTeam) PipelinesRepoStatusCalls(stub func() *atc.PipelinesRepoStatus) {
+	fake.pipelinesRepoStatusMutex.Lock()
+	defer fake.pipelinesRepoStatusMutex.Unlock()
+	fake.PipelinesRepoStatusStub = stub
+}
+
+func (fake *FakeTeam) PipelinesRepoStatusReturns(result1 *atc.PipelinesRepoStatus) {
+	fake.pipelinesRepoStatusMutex.Lock()
+	defer fake.pipelinesRepoStatusMutex.Unlock()
+	fake.PipelinesRepoStatusStub = nil
+	fake.pipelinesRepoStatusReturns = struct {
+		result1 *atc.PipelinesRepoStatus
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelinesRepoStatusReturnsOnCall(i int, result1 *atc.PipelinesRepoStatus) {
+	fake.pipelinesRepoStatusMutex.Lock()
+	defer fake.pipelinesRepoStatusMutex.Unlock()
+	fake.PipelinesRepoStatusStub = nil
+	if fake.pipelinesRepoStatusReturnsOnCall == nil {
+		fake.pipelinesRepoStatusReturnsOnCall = make(map[int]struct {
+			result1 *atc.PipelinesRepoStatus
+		})
+	}
+	fake.pipelinesRepoStatusReturnsOnCall[i] = struct {
+		result1 *atc.PipelinesRepoStatus
+	}{result1}
+}
+
 func (fake *FakeTeam) PrivateAndPublicBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.privateAndPublicBuildsMutex.Lock()
 	ret, specificReturn := fake.privateAndPublicBuildsReturnsOnCall[len(fake.privateAndPublicBuildsArgsForCall)]
@@ -1800,6 +2891,58 @@ func (fake *FakeTeam) PrivateAndPublicBuildsReturnsOnCall(i int, result1 []db.Bu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) PrivilegedPolicy() *atc.PrivilegedPolicy {
+	fake.privilegedPolicyMutex.Lock()
+	ret, specificReturn := fake.privilegedPolicyReturnsOnCall[len(fake.privilegedPolicyArgsForCall)]
+	fake.privilegedPolicyArgsForCall = append(fake.privilegedPolicyArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PrivilegedPolicy", []interface{}{})
+	fake.privilegedPolicyMutex.Unlock()
+	if fake.PrivilegedPolicyStub != nil {
+		return fake.PrivilegedPolicyStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.privilegedPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) PrivilegedPolicyCallCount() int {
+	fake.privilegedPolicyMutex.RLock()
+	defer fake.privilegedPolicyMutex.RUnlock()
+	return len(fake.privilegedPolicyArgsForCall)
+}
+
+func (fake *FakeTeam) PrivilegedPolicyCalls(stub func() *atc.PrivilegedPolicy) {
+	fake.privilegedPolicyMutex.Lock()
+	defer fake.privilegedPolicyMutex.Unlock()
+	fake.PrivilegedPolicyStub = stub
+}
+
+func (fake *FakeTeam) PrivilegedPolicyReturns(result1 *atc.PrivilegedPolicy) {
+	fake.privilegedPolicyMutex.Lock()
+	defer fake.privilegedPolicyMutex.Unlock()
+	fake.PrivilegedPolicyStub = nil
+	fake.privilegedPolicyReturns = struct {
+		result1 *atc.PrivilegedPolicy
+	}{result1}
+}
+
+func (fake *FakeTeam) PrivilegedPolicyReturnsOnCall(i int, result1 *atc.PrivilegedPolicy) {
+	fake.privilegedPolicyMutex.Lock()
+	defer fake.privilegedPolicyMutex.Unlock()
+	fake.PrivilegedPolicyStub = nil
+	if fake.privilegedPolicyReturnsOnCall == nil {
+		fake.privilegedPolicyReturnsOnCall = make(map[int]struct {
+			result1 *atc.PrivilegedPolicy
+		})
+	}
+	fake.privilegedPolicyReturnsOnCall[i] = struct {
+		result1 *atc.PrivilegedPolicy
+	}{result1}
+}
+
 func (fake *FakeTeam) PublicPipelines() ([]db.Pipeline, error) {
 	fake.publicPipelinesMutex.Lock()
 	ret, specificReturn := fake.publicPipelinesReturnsOnCall[len(fake.publicPipelinesArgsForCall)]
@@ -1915,119 +3058,448 @@ func (fake *FakeTeam) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
-	fake.savePipelineMutex.Lock()
-	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
-	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
-		arg1 string
-		arg2 atc.Config
-		arg3 db.ConfigVersion
-		arg4 bool
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4})
-	fake.savePipelineMutex.Unlock()
-	if fake.SavePipelineStub != nil {
-		return fake.SavePipelineStub(arg1, arg2, arg3, arg4)
+func (fake *FakeTeam) RequireImageDigests() bool {
+	fake.requireImageDigestsMutex.Lock()
+	ret, specificReturn := fake.requireImageDigestsReturnsOnCall[len(fake.requireImageDigestsArgsForCall)]
+	fake.requireImageDigestsArgsForCall = append(fake.requireImageDigestsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RequireImageDigests", []interface{}{})
+	fake.requireImageDigestsMutex.Unlock()
+	if fake.RequireImageDigestsStub != nil {
+		return fake.RequireImageDigestsStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	fakeReturns := fake.savePipelineReturns
-	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+	fakeReturns := fake.requireImageDigestsReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeTeam) SavePipelineCallCount() int {
-	fake.savePipelineMutex.RLock()
-	defer fake.savePipelineMutex.RUnlock()
-	return len(fake.savePipelineArgsForCall)
+func (fake *FakeTeam) RequireImageDigestsCallCount() int {
+	fake.requireImageDigestsMutex.RLock()
+	defer fake.requireImageDigestsMutex.RUnlock()
+	return len(fake.requireImageDigestsArgsForCall)
 }
 
-func (fake *FakeTeam) SavePipelineCalls(stub func(string, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
-	fake.savePipelineMutex.Lock()
-	defer fake.savePipelineMutex.Unlock()
-	fake.SavePipelineStub = stub
+func (fake *FakeTeam) RequireImageDigestsCalls(stub func() bool) {
+	fake.requireImageDigestsMutex.Lock()
+	defer fake.requireImageDigestsMutex.Unlock()
+	fake.RequireImageDigestsStub = stub
 }
 
-func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, bool) {
-	fake.savePipelineMutex.RLock()
-	defer fake.savePipelineMutex.RUnlock()
-	argsForCall := fake.savePipelineArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+func (fake *FakeTeam) RequireImageDigestsReturns(result1 bool) {
+	fake.requireImageDigestsMutex.Lock()
+	defer fake.requireImageDigestsMutex.Unlock()
+	fake.RequireImageDigestsStub = nil
+	fake.requireImageDigestsReturns = struct {
+		result1 bool
+	}{result1}
 }
 
-func (fake *FakeTeam) SavePipelineReturns(result1 db.Pipeline, result2 bool, result3 error) {
-	fake.savePipelineMutex.Lock()
-	defer fake.savePipelineMutex.Unlock()
-	fake.SavePipelineStub = nil
-	fake.savePipelineReturns = struct {
-		result1 db.Pipeline
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *FakeTeam) RequireImageDigestsReturnsOnCall(i int, result1 bool) {
+	fake.requireImageDigestsMutex.Lock()
+	defer fake.requireImageDigestsMutex.Unlock()
+	fake.RequireImageDigestsStub = nil
+	if fake.requireImageDigestsReturnsOnCall == nil {
+		fake.requireImageDigestsReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.requireImageDigestsReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
 }
 
-func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
-	fake.savePipelineMutex.Lock()
-	defer fake.savePipelineMutex.Unlock()
-	fake.SavePipelineStub = nil
-	if fake.savePipelineReturnsOnCall == nil {
-		fake.savePipelineReturnsOnCall = make(map[int]struct {
-			result1 db.Pipeline
-			result2 bool
-			result3 error
+func (fake *FakeTeam) ResourceDefaults() atc.ResourceDefaults {
+	fake.resourceDefaultsMutex.Lock()
+	ret, specificReturn := fake.resourceDefaultsReturnsOnCall[len(fake.resourceDefaultsArgsForCall)]
+	fake.resourceDefaultsArgsForCall = append(fake.resourceDefaultsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceDefaults", []interface{}{})
+	fake.resourceDefaultsMutex.Unlock()
+	if fake.ResourceDefaultsStub != nil {
+		return fake.ResourceDefaultsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceDefaultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ResourceDefaultsCallCount() int {
+	fake.resourceDefaultsMutex.RLock()
+	defer fake.resourceDefaultsMutex.RUnlock()
+	return len(fake.resourceDefaultsArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceDefaultsCalls(stub func() atc.ResourceDefaults) {
+	fake.resourceDefaultsMutex.Lock()
+	defer fake.resourceDefaultsMutex.Unlock()
+	fake.ResourceDefaultsStub = stub
+}
+
+func (fake *FakeTeam) ResourceDefaultsReturns(result1 atc.ResourceDefaults) {
+	fake.resourceDefaultsMutex.Lock()
+	defer fake.resourceDefaultsMutex.Unlock()
+	fake.ResourceDefaultsStub = nil
+	fake.resourceDefaultsReturns = struct {
+		result1 atc.ResourceDefaults
+	}{result1}
+}
+
+func (fake *FakeTeam) ResourceDefaultsReturnsOnCall(i int, result1 atc.ResourceDefaults) {
+	fake.resourceDefaultsMutex.Lock()
+	defer fake.resourceDefaultsMutex.Unlock()
+	fake.ResourceDefaultsStub = nil
+	if fake.resourceDefaultsReturnsOnCall == nil {
+		fake.resourceDefaultsReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceDefaults
 		})
 	}
-	fake.savePipelineReturnsOnCall[i] = struct {
-		result1 db.Pipeline
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.resourceDefaultsReturnsOnCall[i] = struct {
+		result1 atc.ResourceDefaults
+	}{result1}
 }
 
-func (fake *FakeTeam) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
-	fake.saveWorkerMutex.Lock()
-	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
-	fake.saveWorkerArgsForCall = append(fake.saveWorkerArgsForCall, struct {
-		arg1 atc.Worker
-		arg2 time.Duration
-	}{arg1, arg2})
-	fake.recordInvocation("SaveWorker", []interface{}{arg1, arg2})
-	fake.saveWorkerMutex.Unlock()
-	if fake.SaveWorkerStub != nil {
-		return fake.SaveWorkerStub(arg1, arg2)
+func (fake *FakeTeam) ResourceTypeHealth() ([]atc.ResourceTypeHealth, error) {
+	fake.resourceTypeHealthMutex.Lock()
+	ret, specificReturn := fake.resourceTypeHealthReturnsOnCall[len(fake.resourceTypeHealthArgsForCall)]
+	fake.resourceTypeHealthArgsForCall = append(fake.resourceTypeHealthArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceTypeHealth", []interface{}{})
+	fake.resourceTypeHealthMutex.Unlock()
+	if fake.ResourceTypeHealthStub != nil {
+		return fake.ResourceTypeHealthStub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.saveWorkerReturns
+	fakeReturns := fake.resourceTypeHealthReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeTeam) SaveWorkerCallCount() int {
-	fake.saveWorkerMutex.RLock()
-	defer fake.saveWorkerMutex.RUnlock()
-	return len(fake.saveWorkerArgsForCall)
+func (fake *FakeTeam) ResourceTypeHealthCallCount() int {
+	fake.resourceTypeHealthMutex.RLock()
+	defer fake.resourceTypeHealthMutex.RUnlock()
+	return len(fake.resourceTypeHealthArgsForCall)
 }
 
-func (fake *FakeTeam) SaveWorkerCalls(stub func(atc.Worker, time.Duration) (db.Worker, error)) {
-	fake.saveWorkerMutex.Lock()
-	defer fake.saveWorkerMutex.Unlock()
-	fake.SaveWorkerStub = stub
+func (fake *FakeTeam) ResourceTypeHealthCalls(stub func() ([]atc.ResourceTypeHealth, error)) {
+	fake.resourceTypeHealthMutex.Lock()
+	defer fake.resourceTypeHealthMutex.Unlock()
+	fake.ResourceTypeHealthStub = stub
 }
 
-func (fake *FakeTeam) SaveWorkerArgsForCall(i int) (atc.Worker, time.Duration) {
-	fake.saveWorkerMutex.RLock()
-	defer fake.saveWorkerMutex.RUnlock()
-	argsForCall := fake.saveWorkerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakeTeam) ResourceTypeHealthReturns(result1 []atc.ResourceTypeHealth, result2 error) {
+	fake.resourceTypeHealthMutex.Lock()
+	defer fake.resourceTypeHealthMutex.Unlock()
+	fake.ResourceTypeHealthStub = nil
+	fake.resourceTypeHealthReturns = struct {
+		result1 []atc.ResourceTypeHealth
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeam) SaveWorkerReturns(result1 db.Worker, result2 error) {
-	fake.saveWorkerMutex.Lock()
-	defer fake.saveWorkerMutex.Unlock()
-	fake.SaveWorkerStub = nil
-	fake.saveWorkerReturns = struct {
-		result1 db.Worker
+func (fake *FakeTeam) ResourceTypeHealthReturnsOnCall(i int, result1 []atc.ResourceTypeHealth, result2 error) {
+	fake.resourceTypeHealthMutex.Lock()
+	defer fake.resourceTypeHealthMutex.Unlock()
+	fake.ResourceTypeHealthStub = nil
+	if fake.resourceTypeHealthReturnsOnCall == nil {
+		fake.resourceTypeHealthReturnsOnCall = make(map[int]struct {
+			result1 []atc.ResourceTypeHealth
+			result2 error
+		})
+	}
+	fake.resourceTypeHealthReturnsOnCall[i] = struct {
+		result1 []atc.ResourceTypeHealth
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ResourceTypeMappings() atc.ResourceTypeMappings {
+	fake.resourceTypeMappingsMutex.Lock()
+	ret, specificReturn := fake.resourceTypeMappingsReturnsOnCall[len(fake.resourceTypeMappingsArgsForCall)]
+	fake.resourceTypeMappingsArgsForCall = append(fake.resourceTypeMappingsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceTypeMappings", []interface{}{})
+	fake.resourceTypeMappingsMutex.Unlock()
+	if fake.ResourceTypeMappingsStub != nil {
+		return fake.ResourceTypeMappingsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceTypeMappingsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ResourceTypeMappingsCallCount() int {
+	fake.resourceTypeMappingsMutex.RLock()
+	defer fake.resourceTypeMappingsMutex.RUnlock()
+	return len(fake.resourceTypeMappingsArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceTypeMappingsCalls(stub func() atc.ResourceTypeMappings) {
+	fake.resourceTypeMappingsMutex.Lock()
+	defer fake.resourceTypeMappingsMutex.Unlock()
+	fake.ResourceTypeMappingsStub = stub
+}
+
+func (fake *FakeTeam) ResourceTypeMappingsReturns(result1 atc.ResourceTypeMappings) {
+	fake.resourceTypeMappingsMutex.Lock()
+	defer fake.resourceTypeMappingsMutex.Unlock()
+	fake.ResourceTypeMappingsStub = nil
+	fake.resourceTypeMappingsReturns = struct {
+		result1 atc.ResourceTypeMappings
+	}{result1}
+}
+
+func (fake *FakeTeam) ResourceTypeMappingsReturnsOnCall(i int, result1 atc.ResourceTypeMappings) {
+	fake.resourceTypeMappingsMutex.Lock()
+	defer fake.resourceTypeMappingsMutex.Unlock()
+	fake.ResourceTypeMappingsStub = nil
+	if fake.resourceTypeMappingsReturnsOnCall == nil {
+		fake.resourceTypeMappingsReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceTypeMappings
+		})
+	}
+	fake.resourceTypeMappingsReturnsOnCall[i] = struct {
+		result1 atc.ResourceTypeMappings
+	}{result1}
+}
+
+func (fake *FakeTeam) Restore() (bool, error) {
+	fake.restoreMutex.Lock()
+	ret, specificReturn := fake.restoreReturnsOnCall[len(fake.restoreArgsForCall)]
+	fake.restoreArgsForCall = append(fake.restoreArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Restore", []interface{}{})
+	fake.restoreMutex.Unlock()
+	if fake.RestoreStub != nil {
+		return fake.RestoreStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.restoreReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RestoreCallCount() int {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	return len(fake.restoreArgsForCall)
+}
+
+func (fake *FakeTeam) RestoreCalls(stub func() (bool, error)) {
+	fake.restoreMutex.Lock()
+	defer fake.restoreMutex.Unlock()
+	fake.RestoreStub = stub
+}
+
+func (fake *FakeTeam) RestoreReturns(result1 bool, result2 error) {
+	fake.restoreMutex.Lock()
+	defer fake.restoreMutex.Unlock()
+	fake.RestoreStub = nil
+	fake.restoreReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RestoreReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.restoreMutex.Lock()
+	defer fake.restoreMutex.Unlock()
+	fake.RestoreStub = nil
+	if fake.restoreReturnsOnCall == nil {
+		fake.restoreReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.restoreReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeAPIToken(arg1 string) (bool, error) {
+	fake.revokeAPITokenMutex.Lock()
+	ret, specificReturn := fake.revokeAPITokenReturnsOnCall[len(fake.revokeAPITokenArgsForCall)]
+	fake.revokeAPITokenArgsForCall = append(fake.revokeAPITokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RevokeAPIToken", []interface{}{arg1})
+	fake.revokeAPITokenMutex.Unlock()
+	if fake.RevokeAPITokenStub != nil {
+		return fake.RevokeAPITokenStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.revokeAPITokenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RevokeAPITokenCallCount() int {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	return len(fake.revokeAPITokenArgsForCall)
+}
+
+func (fake *FakeTeam) RevokeAPITokenCalls(stub func(string) (bool, error)) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = stub
+}
+
+func (fake *FakeTeam) RevokeAPITokenArgsForCall(i int) string {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	argsForCall := fake.revokeAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) RevokeAPITokenReturns(result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	fake.revokeAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeAPITokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	if fake.revokeAPITokenReturnsOnCall == nil {
+		fake.revokeAPITokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeAPITokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
+	fake.savePipelineMutex.Lock()
+	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
+	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
+		arg1 string
+		arg2 atc.Config
+		arg3 db.ConfigVersion
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4})
+	fake.savePipelineMutex.Unlock()
+	if fake.SavePipelineStub != nil {
+		return fake.SavePipelineStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.savePipelineReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineCallCount() int {
+	fake.savePipelineMutex.RLock()
+	defer fake.savePipelineMutex.RUnlock()
+	return len(fake.savePipelineArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineCalls(stub func(string, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
+	fake.savePipelineMutex.Lock()
+	defer fake.savePipelineMutex.Unlock()
+	fake.SavePipelineStub = stub
+}
+
+func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, bool) {
+	fake.savePipelineMutex.RLock()
+	defer fake.savePipelineMutex.RUnlock()
+	argsForCall := fake.savePipelineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) SavePipelineReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineMutex.Lock()
+	defer fake.savePipelineMutex.Unlock()
+	fake.SavePipelineStub = nil
+	fake.savePipelineReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineMutex.Lock()
+	defer fake.savePipelineMutex.Unlock()
+	fake.SavePipelineStub = nil
+	if fake.savePipelineReturnsOnCall == nil {
+		fake.savePipelineReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
+	fake.saveWorkerMutex.Lock()
+	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
+	fake.saveWorkerArgsForCall = append(fake.saveWorkerArgsForCall, struct {
+		arg1 atc.Worker
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("SaveWorker", []interface{}{arg1, arg2})
+	fake.saveWorkerMutex.Unlock()
+	if fake.SaveWorkerStub != nil {
+		return fake.SaveWorkerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.saveWorkerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SaveWorkerCallCount() int {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	return len(fake.saveWorkerArgsForCall)
+}
+
+func (fake *FakeTeam) SaveWorkerCalls(stub func(atc.Worker, time.Duration) (db.Worker, error)) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = stub
+}
+
+func (fake *FakeTeam) SaveWorkerArgsForCall(i int) (atc.Worker, time.Duration) {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	argsForCall := fake.saveWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SaveWorkerReturns(result1 db.Worker, result2 error) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = nil
+	fake.saveWorkerReturns = struct {
+		result1 db.Worker
 		result2 error
 	}{result1, result2}
 }
@@ -2042,10 +3514,782 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 			result2 error
 		})
 	}
-	fake.saveWorkerReturnsOnCall[i] = struct {
-		result1 db.Worker
-		result2 error
-	}{result1, result2}
+	fake.saveWorkerReturnsOnCall[i] = struct {
+		result1 db.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SoftDelete() error {
+	fake.softDeleteMutex.Lock()
+	ret, specificReturn := fake.softDeleteReturnsOnCall[len(fake.softDeleteArgsForCall)]
+	fake.softDeleteArgsForCall = append(fake.softDeleteArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SoftDelete", []interface{}{})
+	fake.softDeleteMutex.Unlock()
+	if fake.SoftDeleteStub != nil {
+		return fake.SoftDeleteStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.softDeleteReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SoftDeleteCallCount() int {
+	fake.softDeleteMutex.RLock()
+	defer fake.softDeleteMutex.RUnlock()
+	return len(fake.softDeleteArgsForCall)
+}
+
+func (fake *FakeTeam) SoftDeleteCalls(stub func() error) {
+	fake.softDeleteMutex.Lock()
+	defer fake.softDeleteMutex.Unlock()
+	fake.SoftDeleteStub = stub
+}
+
+func (fake *FakeTeam) SoftDeleteReturns(result1 error) {
+	fake.softDeleteMutex.Lock()
+	defer fake.softDeleteMutex.Unlock()
+	fake.SoftDeleteStub = nil
+	fake.softDeleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SoftDeleteReturnsOnCall(i int, result1 error) {
+	fake.softDeleteMutex.Lock()
+	defer fake.softDeleteMutex.Unlock()
+	fake.SoftDeleteStub = nil
+	if fake.softDeleteReturnsOnCall == nil {
+		fake.softDeleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.softDeleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateCACerts(arg1 string) error {
+	fake.updateCACertsMutex.Lock()
+	ret, specificReturn := fake.updateCACertsReturnsOnCall[len(fake.updateCACertsArgsForCall)]
+	fake.updateCACertsArgsForCall = append(fake.updateCACertsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("UpdateCACerts", []interface{}{arg1})
+	fake.updateCACertsMutex.Unlock()
+	if fake.UpdateCACertsStub != nil {
+		return fake.UpdateCACertsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateCACertsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateCACertsCallCount() int {
+	fake.updateCACertsMutex.RLock()
+	defer fake.updateCACertsMutex.RUnlock()
+	return len(fake.updateCACertsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateCACertsCalls(stub func(string) error) {
+	fake.updateCACertsMutex.Lock()
+	defer fake.updateCACertsMutex.Unlock()
+	fake.UpdateCACertsStub = stub
+}
+
+func (fake *FakeTeam) UpdateCACertsArgsForCall(i int) string {
+	fake.updateCACertsMutex.RLock()
+	defer fake.updateCACertsMutex.RUnlock()
+	argsForCall := fake.updateCACertsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateCACertsReturns(result1 error) {
+	fake.updateCACertsMutex.Lock()
+	defer fake.updateCACertsMutex.Unlock()
+	fake.UpdateCACertsStub = nil
+	fake.updateCACertsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateCACertsReturnsOnCall(i int, result1 error) {
+	fake.updateCACertsMutex.Lock()
+	defer fake.updateCACertsMutex.Unlock()
+	fake.UpdateCACertsStub = nil
+	if fake.updateCACertsReturnsOnCall == nil {
+		fake.updateCACertsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateCACertsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateCheckPolicy(arg1 *atc.CheckPolicy) error {
+	fake.updateCheckPolicyMutex.Lock()
+	ret, specificReturn := fake.updateCheckPolicyReturnsOnCall[len(fake.updateCheckPolicyArgsForCall)]
+	fake.updateCheckPolicyArgsForCall = append(fake.updateCheckPolicyArgsForCall, struct {
+		arg1 *atc.CheckPolicy
+	}{arg1})
+	fake.recordInvocation("UpdateCheckPolicy", []interface{}{arg1})
+	fake.updateCheckPolicyMutex.Unlock()
+	if fake.UpdateCheckPolicyStub != nil {
+		return fake.UpdateCheckPolicyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateCheckPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateCheckPolicyCallCount() int {
+	fake.updateCheckPolicyMutex.RLock()
+	defer fake.updateCheckPolicyMutex.RUnlock()
+	return len(fake.updateCheckPolicyArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateCheckPolicyCalls(stub func(*atc.CheckPolicy) error) {
+	fake.updateCheckPolicyMutex.Lock()
+	defer fake.updateCheckPolicyMutex.Unlock()
+	fake.UpdateCheckPolicyStub = stub
+}
+
+func (fake *FakeTeam) UpdateCheckPolicyArgsForCall(i int) *atc.CheckPolicy {
+	fake.updateCheckPolicyMutex.RLock()
+	defer fake.updateCheckPolicyMutex.RUnlock()
+	argsForCall := fake.updateCheckPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateCheckPolicyReturns(result1 error) {
+	fake.updateCheckPolicyMutex.Lock()
+	defer fake.updateCheckPolicyMutex.Unlock()
+	fake.UpdateCheckPolicyStub = nil
+	fake.updateCheckPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateCheckPolicyReturnsOnCall(i int, result1 error) {
+	fake.updateCheckPolicyMutex.Lock()
+	defer fake.updateCheckPolicyMutex.Unlock()
+	fake.UpdateCheckPolicyStub = nil
+	if fake.updateCheckPolicyReturnsOnCall == nil {
+		fake.updateCheckPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateCheckPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateConcurrencyPools(arg1 atc.ConcurrencyPools) error {
+	fake.updateConcurrencyPoolsMutex.Lock()
+	ret, specificReturn := fake.updateConcurrencyPoolsReturnsOnCall[len(fake.updateConcurrencyPoolsArgsForCall)]
+	fake.updateConcurrencyPoolsArgsForCall = append(fake.updateConcurrencyPoolsArgsForCall, struct {
+		arg1 atc.ConcurrencyPools
+	}{arg1})
+	fake.recordInvocation("UpdateConcurrencyPools", []interface{}{arg1})
+	fake.updateConcurrencyPoolsMutex.Unlock()
+	if fake.UpdateConcurrencyPoolsStub != nil {
+		return fake.UpdateConcurrencyPoolsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateConcurrencyPoolsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateConcurrencyPoolsCallCount() int {
+	fake.updateConcurrencyPoolsMutex.RLock()
+	defer fake.updateConcurrencyPoolsMutex.RUnlock()
+	return len(fake.updateConcurrencyPoolsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateConcurrencyPoolsCalls(stub func(atc.ConcurrencyPools) error) {
+	fake.updateConcurrencyPoolsMutex.Lock()
+	defer fake.updateConcurrencyPoolsMutex.Unlock()
+	fake.UpdateConcurrencyPoolsStub = stub
+}
+
+func (fake *FakeTeam) UpdateConcurrencyPoolsArgsForCall(i int) atc.ConcurrencyPools {
+	fake.updateConcurrencyPoolsMutex.RLock()
+	defer fake.updateConcurrencyPoolsMutex.RUnlock()
+	argsForCall := fake.updateConcurrencyPoolsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateConcurrencyPoolsReturns(result1 error) {
+	fake.updateConcurrencyPoolsMutex.Lock()
+	defer fake.updateConcurrencyPoolsMutex.Unlock()
+	fake.UpdateConcurrencyPoolsStub = nil
+	fake.updateConcurrencyPoolsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateConcurrencyPoolsReturnsOnCall(i int, result1 error) {
+	fake.updateConcurrencyPoolsMutex.Lock()
+	defer fake.updateConcurrencyPoolsMutex.Unlock()
+	fake.UpdateConcurrencyPoolsStub = nil
+	if fake.updateConcurrencyPoolsReturnsOnCall == nil {
+		fake.updateConcurrencyPoolsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateConcurrencyPoolsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContainerDNS(arg1 *atc.ContainerDNS) error {
+	fake.updateContainerDNSMutex.Lock()
+	ret, specificReturn := fake.updateContainerDNSReturnsOnCall[len(fake.updateContainerDNSArgsForCall)]
+	fake.updateContainerDNSArgsForCall = append(fake.updateContainerDNSArgsForCall, struct {
+		arg1 *atc.ContainerDNS
+	}{arg1})
+	fake.recordInvocation("UpdateContainerDNS", []interface{}{arg1})
+	fake.updateContainerDNSMutex.Unlock()
+	if fake.UpdateContainerDNSStub != nil {
+		return fake.UpdateContainerDNSStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateContainerDNSReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateContainerDNSCallCount() int {
+	fake.updateContainerDNSMutex.RLock()
+	defer fake.updateContainerDNSMutex.RUnlock()
+	return len(fake.updateContainerDNSArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateContainerDNSCalls(stub func(*atc.ContainerDNS) error) {
+	fake.updateContainerDNSMutex.Lock()
+	defer fake.updateContainerDNSMutex.Unlock()
+	fake.UpdateContainerDNSStub = stub
+}
+
+func (fake *FakeTeam) UpdateContainerDNSArgsForCall(i int) *atc.ContainerDNS {
+	fake.updateContainerDNSMutex.RLock()
+	defer fake.updateContainerDNSMutex.RUnlock()
+	argsForCall := fake.updateContainerDNSArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateContainerDNSReturns(result1 error) {
+	fake.updateContainerDNSMutex.Lock()
+	defer fake.updateContainerDNSMutex.Unlock()
+	fake.UpdateContainerDNSStub = nil
+	fake.updateContainerDNSReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContainerDNSReturnsOnCall(i int, result1 error) {
+	fake.updateContainerDNSMutex.Lock()
+	defer fake.updateContainerDNSMutex.Unlock()
+	fake.UpdateContainerDNSStub = nil
+	if fake.updateContainerDNSReturnsOnCall == nil {
+		fake.updateContainerDNSReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateContainerDNSReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContainerEnv(arg1 map[string]string) error {
+	fake.updateContainerEnvMutex.Lock()
+	ret, specificReturn := fake.updateContainerEnvReturnsOnCall[len(fake.updateContainerEnvArgsForCall)]
+	fake.updateContainerEnvArgsForCall = append(fake.updateContainerEnvArgsForCall, struct {
+		arg1 map[string]string
+	}{arg1})
+	fake.recordInvocation("UpdateContainerEnv", []interface{}{arg1})
+	fake.updateContainerEnvMutex.Unlock()
+	if fake.UpdateContainerEnvStub != nil {
+		return fake.UpdateContainerEnvStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateContainerEnvReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateContainerEnvCallCount() int {
+	fake.updateContainerEnvMutex.RLock()
+	defer fake.updateContainerEnvMutex.RUnlock()
+	return len(fake.updateContainerEnvArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateContainerEnvCalls(stub func(map[string]string) error) {
+	fake.updateContainerEnvMutex.Lock()
+	defer fake.updateContainerEnvMutex.Unlock()
+	fake.UpdateContainerEnvStub = stub
+}
+
+func (fake *FakeTeam) UpdateContainerEnvArgsForCall(i int) map[string]string {
+	fake.updateContainerEnvMutex.RLock()
+	defer fake.updateContainerEnvMutex.RUnlock()
+	argsForCall := fake.updateContainerEnvArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateContainerEnvReturns(result1 error) {
+	fake.updateContainerEnvMutex.Lock()
+	defer fake.updateContainerEnvMutex.Unlock()
+	fake.UpdateContainerEnvStub = nil
+	fake.updateContainerEnvReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContainerEnvReturnsOnCall(i int, result1 error) {
+	fake.updateContainerEnvMutex.Lock()
+	defer fake.updateContainerEnvMutex.Unlock()
+	fake.UpdateContainerEnvStub = nil
+	if fake.updateContainerEnvReturnsOnCall == nil {
+		fake.updateContainerEnvReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateContainerEnvReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContentScanPolicy(arg1 atc.ContentScanPolicy) error {
+	fake.updateContentScanPolicyMutex.Lock()
+	ret, specificReturn := fake.updateContentScanPolicyReturnsOnCall[len(fake.updateContentScanPolicyArgsForCall)]
+	fake.updateContentScanPolicyArgsForCall = append(fake.updateContentScanPolicyArgsForCall, struct {
+		arg1 atc.ContentScanPolicy
+	}{arg1})
+	fake.recordInvocation("UpdateContentScanPolicy", []interface{}{arg1})
+	fake.updateContentScanPolicyMutex.Unlock()
+	if fake.UpdateContentScanPolicyStub != nil {
+		return fake.UpdateContentScanPolicyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateContentScanPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateContentScanPolicyCallCount() int {
+	fake.updateContentScanPolicyMutex.RLock()
+	defer fake.updateContentScanPolicyMutex.RUnlock()
+	return len(fake.updateContentScanPolicyArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateContentScanPolicyCalls(stub func(atc.ContentScanPolicy) error) {
+	fake.updateContentScanPolicyMutex.Lock()
+	defer fake.updateContentScanPolicyMutex.Unlock()
+	fake.UpdateContentScanPolicyStub = stub
+}
+
+func (fake *FakeTeam) UpdateContentScanPolicyArgsForCall(i int) atc.ContentScanPolicy {
+	fake.updateContentScanPolicyMutex.RLock()
+	defer fake.updateContentScanPolicyMutex.RUnlock()
+	argsForCall := fake.updateContentScanPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateContentScanPolicyReturns(result1 error) {
+	fake.updateContentScanPolicyMutex.Lock()
+	defer fake.updateContentScanPolicyMutex.Unlock()
+	fake.UpdateContentScanPolicyStub = nil
+	fake.updateContentScanPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContentScanPolicyReturnsOnCall(i int, result1 error) {
+	fake.updateContentScanPolicyMutex.Lock()
+	defer fake.updateContentScanPolicyMutex.Unlock()
+	fake.UpdateContentScanPolicyStub = nil
+	if fake.updateContentScanPolicyReturnsOnCall == nil {
+		fake.updateContentScanPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateContentScanPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateDefaultTaskImage(arg1 *atc.ImageResource) error {
+	fake.updateDefaultTaskImageMutex.Lock()
+	ret, specificReturn := fake.updateDefaultTaskImageReturnsOnCall[len(fake.updateDefaultTaskImageArgsForCall)]
+	fake.updateDefaultTaskImageArgsForCall = append(fake.updateDefaultTaskImageArgsForCall, struct {
+		arg1 *atc.ImageResource
+	}{arg1})
+	fake.recordInvocation("UpdateDefaultTaskImage", []interface{}{arg1})
+	fake.updateDefaultTaskImageMutex.Unlock()
+	if fake.UpdateDefaultTaskImageStub != nil {
+		return fake.UpdateDefaultTaskImageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateDefaultTaskImageReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateDefaultTaskImageCallCount() int {
+	fake.updateDefaultTaskImageMutex.RLock()
+	defer fake.updateDefaultTaskImageMutex.RUnlock()
+	return len(fake.updateDefaultTaskImageArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateDefaultTaskImageCalls(stub func(*atc.ImageResource) error) {
+	fake.updateDefaultTaskImageMutex.Lock()
+	defer fake.updateDefaultTaskImageMutex.Unlock()
+	fake.UpdateDefaultTaskImageStub = stub
+}
+
+func (fake *FakeTeam) UpdateDefaultTaskImageArgsForCall(i int) *atc.ImageResource {
+	fake.updateDefaultTaskImageMutex.RLock()
+	defer fake.updateDefaultTaskImageMutex.RUnlock()
+	argsForCall := fake.updateDefaultTaskImageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateDefaultTaskImageReturns(result1 error) {
+	fake.updateDefaultTaskImageMutex.Lock()
+	defer fake.updateDefaultTaskImageMutex.Unlock()
+	fake.UpdateDefaultTaskImageStub = nil
+	fake.updateDefaultTaskImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateDefaultTaskImageReturnsOnCall(i int, result1 error) {
+	fake.updateDefaultTaskImageMutex.Lock()
+	defer fake.updateDefaultTaskImageMutex.Unlock()
+	fake.UpdateDefaultTaskImageStub = nil
+	if fake.updateDefaultTaskImageReturnsOnCall == nil {
+		fake.updateDefaultTaskImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateDefaultTaskImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateMaxBuildLogSize(arg1 int64) error {
+	fake.updateMaxBuildLogSizeMutex.Lock()
+	ret, specificReturn := fake.updateMaxBuildLogSizeReturnsOnCall[len(fake.updateMaxBuildLogSizeArgsForCall)]
+	fake.updateMaxBuildLogSizeArgsForCall = append(fake.updateMaxBuildLogSizeArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	fake.recordInvocation("UpdateMaxBuildLogSize", []interface{}{arg1})
+	fake.updateMaxBuildLogSizeMutex.Unlock()
+	if fake.UpdateMaxBuildLogSizeStub != nil {
+		return fake.UpdateMaxBuildLogSizeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateMaxBuildLogSizeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateMaxBuildLogSizeCallCount() int {
+	fake.updateMaxBuildLogSizeMutex.RLock()
+	defer fake.updateMaxBuildLogSizeMutex.RUnlock()
+	return len(fake.updateMaxBuildLogSizeArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateMaxBuildLogSizeCalls(stub func(int64) error) {
+	fake.updateMaxBuildLogSizeMutex.Lock()
+	defer fake.updateMaxBuildLogSizeMutex.Unlock()
+	fake.UpdateMaxBuildLogSizeStub = stub
+}
+
+func (fake *FakeTeam) UpdateMaxBuildLogSizeArgsForCall(i int) int64 {
+	fake.updateMaxBuildLogSizeMutex.RLock()
+	defer fake.updateMaxBuildLogSizeMutex.RUnlock()
+	argsForCall := fake.updateMaxBuildLogSizeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateMaxBuildLogSizeReturns(result1 error) {
+	fake.updateMaxBuildLogSizeMutex.Lock()
+	defer fake.updateMaxBuildLogSizeMutex.Unlock()
+	fake.UpdateMaxBuildLogSizeStub = nil
+	fake.updateMaxBuildLogSizeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateMaxBuildLogSizeReturnsOnCall(i int, result1 error) {
+	fake.updateMaxBuildLogSizeMutex.Lock()
+	defer fake.updateMaxBuildLogSizeMutex.Unlock()
+	fake.UpdateMaxBuildLogSizeStub = nil
+	if fake.updateMaxBuildLogSizeReturnsOnCall == nil {
+		fake.updateMaxBuildLogSizeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateMaxBuildLogSizeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateMaxBuildStartsPerMinute(arg1 int) error {
+	fake.updateMaxBuildStartsPerMinuteMutex.Lock()
+	ret, specificReturn := fake.updateMaxBuildStartsPerMinuteReturnsOnCall[len(fake.updateMaxBuildStartsPerMinuteArgsForCall)]
+	fake.updateMaxBuildStartsPerMinuteArgsForCall = append(fake.updateMaxBuildStartsPerMinuteArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("UpdateMaxBuildStartsPerMinute", []interface{}{arg1})
+	fake.updateMaxBuildStartsPerMinuteMutex.Unlock()
+	if fake.UpdateMaxBuildStartsPerMinuteStub != nil {
+		return fake.UpdateMaxBuildStartsPerMinuteStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateMaxBuildStartsPerMinuteReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateMaxBuildStartsPerMinuteCallCount() int {
+	fake.updateMaxBuildStartsPerMinuteMutex.RLock()
+	defer fake.updateMaxBuildStartsPerMinuteMutex.RUnlock()
+	return len(fake.updateMaxBuildStartsPerMinuteArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateMaxBuildStartsPerMinuteCalls(stub func(int) error) {
+	fake.updateMaxBuildStartsPerMinuteMutex.Lock()
+	defer fake.updateMaxBuildStartsPerMinuteMutex.Unlock()
+	fake.UpdateMaxBuildStartsPerMinuteStub = stub
+}
+
+func (fake *FakeTeam) UpdateMaxBuildStartsPerMinuteArgsForCall(i int) int {
+	fake.updateMaxBuildStartsPerMinuteMutex.RLock()
+	defer fake.updateMaxBuildStartsPerMinuteMutex.RUnlock()
+	argsForCall := fake.updateMaxBuildStartsPerMinuteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateMaxBuildStartsPerMinuteReturns(result1 error) {
+	fake.updateMaxBuildStartsPerMinuteMutex.Lock()
+	defer fake.updateMaxBuildStartsPerMinuteMutex.Unlock()
+	fake.UpdateMaxBuildStartsPerMinuteStub = nil
+	fake.updateMaxBuildStartsPerMinuteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateMaxBuildStartsPerMinuteReturnsOnCall(i int, result1 error) {
+	fake.updateMaxBuildStartsPerMinuteMutex.Lock()
+	defer fake.updateMaxBuildStartsPerMinuteMutex.Unlock()
+	fake.UpdateMaxBuildStartsPerMinuteStub = nil
+	if fake.updateMaxBuildStartsPerMinuteReturnsOnCall == nil {
+		fake.updateMaxBuildStartsPerMinuteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateMaxBuildStartsPerMinuteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepo(arg1 *atc.PipelinesRepo) error {
+	fake.updatePipelinesRepoMutex.Lock()
+	ret, specificReturn := fake.updatePipelinesRepoReturnsOnCall[len(fake.updatePipelinesRepoArgsForCall)]
+	fake.updatePipelinesRepoArgsForCall = append(fake.updatePipelinesRepoArgsForCall, struct {
+		arg1 *atc.PipelinesRepo
+	}{arg1})
+	fake.recordInvocation("UpdatePipelinesRepo", []interface{}{arg1})
+	fake.updatePipelinesRepoMutex.Unlock()
+	if fake.UpdatePipelinesRepoStub != nil {
+		return fake.UpdatePipelinesRepoStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updatePipelinesRepoReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoCallCount() int {
+	fake.updatePipelinesRepoMutex.RLock()
+	defer fake.updatePipelinesRepoMutex.RUnlock()
+	return len(fake.updatePipelinesRepoArgsForCall)
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoCalls(stub func(*atc.PipelinesRepo) error) {
+	fake.updatePipelinesRepoMutex.Lock()
+	defer fake.updatePipelinesRepoMutex.Unlock()
+	fake.UpdatePipelinesRepoStub = stub
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoArgsForCall(i int) *atc.PipelinesRepo {
+	fake.updatePipelinesRepoMutex.RLock()
+	defer fake.updatePipelinesRepoMutex.RUnlock()
+	argsForCall := fake.updatePipelinesRepoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoReturns(result1 error) {
+	fake.updatePipelinesRepoMutex.Lock()
+	defer fake.updatePipelinesRepoMutex.Unlock()
+	fake.UpdatePipelinesRepoStub = nil
+	fake.updatePipelinesRepoReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoReturnsOnCall(i int, result1 error) {
+	fake.updatePipelinesRepoMutex.Lock()
+	defer fake.updatePipelinesRepoMutex.Unlock()
+	fake.UpdatePipelinesRepoStub = nil
+	if fake.updatePipelinesRepoReturnsOnCall == nil {
+		fake.updatePipelinesRepoReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePipelinesRepoReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoStatus(arg1 atc.PipelinesRepoStatus) error {
+	fake.updatePipelinesRepoStatusMutex.Lock()
+	ret, specificReturn := fake.updatePipelinesRepoStatusReturnsOnCall[len(fake.updatePipelinesRepoStatusArgsForCall)]
+	fake.updatePipelinesRepoStatusArgsForCall = append(fake.updatePipelinesRepoStatusArgsForCall, struct {
+		arg1 atc.PipelinesRepoStatus
+	}{arg1})
+	fake.recordInvocation("UpdatePipelinesRepoStatus", []interface{}{arg1})
+	fake.updatePipelinesRepoStatusMutex.Unlock()
+	if fake.UpdatePipelinesRepoStatusStub != nil {
+		return fake.UpdatePipelinesRepoStatusStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updatePipelinesRepoStatusReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoStatusCallCount() int {
+	fake.updatePipelinesRepoStatusMutex.RLock()
+	defer fake.updatePipelinesRepoStatusMutex.RUnlock()
+	return len(fake.updatePipelinesRepoStatusArgsForCall)
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoStatusCalls(stub func(atc.PipelinesRepoStatus) error) {
+	fake.updatePipelinesRepoStatusMutex.Lock()
+	defer fake.updatePipelinesRepoStatusMutex.Unlock()
+	fake.UpdatePipelinesRepoStatusStub = stub
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoStatusArgsForCall(i int) atc.PipelinesRepoStatus {
+	fake.updatePipelinesRepoStatusMutex.RLock()
+	defer fake.updatePipelinesRepoStatusMutex.RUnlock()
+	argsForCall := fake.updatePipelinesRepoStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoStatusReturns(result1 error) {
+	fake.updatePipelinesRepoStatusMutex.Lock()
+	defer fake.updatePipelinesRepoStatusMutex.Unlock()
+	fake.UpdatePipelinesRepoStatusStub = nil
+	fake.updatePipelinesRepoStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelinesRepoStatusReturnsOnCall(i int, result1 error) {
+	fake.updatePipelinesRepoStatusMutex.Lock()
+	defer fake.updatePipelinesRepoStatusMutex.Unlock()
+	fake.UpdatePipelinesRepoStatusStub = nil
+	if fake.updatePipelinesRepoStatusReturnsOnCall == nil {
+		fake.updatePipelinesRepoStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePipelinesRepoStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePrivilegedPolicy(arg1 *atc.PrivilegedPolicy) error {
+	fake.updatePrivilegedPolicyMutex.Lock()
+	ret, specificReturn := fake.updatePrivilegedPolicyReturnsOnCall[len(fake.updatePrivilegedPolicyArgsForCall)]
+	fake.updatePrivilegedPolicyArgsForCall = append(fake.updatePrivilegedPolicyArgsForCall, struct {
+		arg1 *atc.PrivilegedPolicy
+	}{arg1})
+	fake.recordInvocation("UpdatePrivilegedPolicy", []interface{}{arg1})
+	fake.updatePrivilegedPolicyMutex.Unlock()
+	if fake.UpdatePrivilegedPolicyStub != nil {
+		return fake.UpdatePrivilegedPolicyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updatePrivilegedPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdatePrivilegedPolicyCallCount() int {
+	fake.updatePrivilegedPolicyMutex.RLock()
+	defer fake.updatePrivilegedPolicyMutex.RUnlock()
+	return len(fake.updatePrivilegedPolicyArgsForCall)
+}
+
+func (fake *FakeTeam) UpdatePrivilegedPolicyCalls(stub func(*atc.PrivilegedPolicy) error) {
+	fake.updatePrivilegedPolicyMutex.Lock()
+	defer fake.updatePrivilegedPolicyMutex.Unlock()
+	fake.UpdatePrivilegedPolicyStub = stub
+}
+
+func (fake *FakeTeam) UpdatePrivilegedPolicyArgsForCall(i int) *atc.PrivilegedPolicy {
+	fake.updatePrivilegedPolicyMutex.RLock()
+	defer fake.updatePrivilegedPolicyMutex.RUnlock()
+	argsForCall := fake.updatePrivilegedPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdatePrivilegedPolicyReturns(result1 error) {
+	fake.updatePrivilegedPolicyMutex.Lock()
+	defer fake.updatePrivilegedPolicyMutex.Unlock()
+	fake.UpdatePrivilegedPolicyStub = nil
+	fake.updatePrivilegedPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePrivilegedPolicyReturnsOnCall(i int, result1 error) {
+	fake.updatePrivilegedPolicyMutex.Lock()
+	defer fake.updatePrivilegedPolicyMutex.Unlock()
+	fake.UpdatePrivilegedPolicyStub = nil
+	if fake.updatePrivilegedPolicyReturnsOnCall == nil {
+		fake.updatePrivilegedPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePrivilegedPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
@@ -2108,6 +4352,252 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateSettings(arg1 atc.Team, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.updateSettingsMutex.Lock()
+	ret, specificReturn := fake.updateSettingsReturnsOnCall[len(fake.updateSettingsArgsForCall)]
+	fake.updateSettingsArgsForCall = append(fake.updateSettingsArgsForCall, struct {
+		arg1 atc.Team
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("UpdateSettings", []interface{}{arg1, arg2Copy})
+	fake.updateSettingsMutex.Unlock()
+	if fake.UpdateSettingsStub != nil {
+		return fake.UpdateSettingsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateSettingsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateSettingsCallCount() int {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	return len(fake.updateSettingsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateSettingsCalls(stub func(atc.Team, []string) error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = stub
+}
+
+func (fake *FakeTeam) UpdateSettingsArgsForCall(i int) (atc.Team, []string) {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	argsForCall := fake.updateSettingsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) UpdateSettingsReturns(result1 error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = nil
+	fake.updateSettingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateSettingsReturnsOnCall(i int, result1 error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = nil
+	if fake.updateSettingsReturnsOnCall == nil {
+		fake.updateSettingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSettingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateRequireImageDigests(arg1 bool) error {
+	fake.updateRequireImageDigestsMutex.Lock()
+	ret, specificReturn := fake.updateRequireImageDigestsReturnsOnCall[len(fake.updateRequireImageDigestsArgsForCall)]
+	fake.updateRequireImageDigestsArgsForCall = append(fake.updateRequireImageDigestsArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("UpdateRequireImageDigests", []interface{}{arg1})
+	fake.updateRequireImageDigestsMutex.Unlock()
+	if fake.UpdateRequireImageDigestsStub != nil {
+		return fake.UpdateRequireImageDigestsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateRequireImageDigestsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateRequireImageDigestsCallCount() int {
+	fake.updateRequireImageDigestsMutex.RLock()
+	defer fake.updateRequireImageDigestsMutex.RUnlock()
+	return len(fake.updateRequireImageDigestsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateRequireImageDigestsCalls(stub func(bool) error) {
+	fake.updateRequireImageDigestsMutex.Lock()
+	defer fake.updateRequireImageDigestsMutex.Unlock()
+	fake.UpdateRequireImageDigestsStub = stub
+}
+
+func (fake *FakeTeam) UpdateRequireImageDigestsArgsForCall(i int) bool {
+	fake.updateRequireImageDigestsMutex.RLock()
+	defer fake.updateRequireImageDigestsMutex.RUnlock()
+	argsForCall := fake.updateRequireImageDigestsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateRequireImageDigestsReturns(result1 error) {
+	fake.updateRequireImageDigestsMutex.Lock()
+	defer fake.updateRequireImageDigestsMutex.Unlock()
+	fake.UpdateRequireImageDigestsStub = nil
+	fake.updateRequireImageDigestsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateRequireImageDigestsReturnsOnCall(i int, result1 error) {
+	fake.updateRequireImageDigestsMutex.Lock()
+	defer fake.updateRequireImageDigestsMutex.Unlock()
+	fake.UpdateRequireImageDigestsStub = nil
+	if fake.updateRequireImageDigestsReturnsOnCall == nil {
+		fake.updateRequireImageDigestsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateRequireImageDigestsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateResourceDefaults(arg1 atc.ResourceDefaults) error {
+	fake.updateResourceDefaultsMutex.Lock()
+	ret, specificReturn := fake.updateResourceDefaultsReturnsOnCall[len(fake.updateResourceDefaultsArgsForCall)]
+	fake.updateResourceDefaultsArgsForCall = append(fake.updateResourceDefaultsArgsForCall, struct {
+		arg1 atc.ResourceDefaults
+	}{arg1})
+	fake.recordInvocation("UpdateResourceDefaults", []interface{}{arg1})
+	fake.updateResourceDefaultsMutex.Unlock()
+	if fake.UpdateResourceDefaultsStub != nil {
+		return fake.UpdateResourceDefaultsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateResourceDefaultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateResourceDefaultsCallCount() int {
+	fake.updateResourceDefaultsMutex.RLock()
+	defer fake.updateResourceDefaultsMutex.RUnlock()
+	return len(fake.updateResourceDefaultsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateResourceDefaultsCalls(stub func(atc.ResourceDefaults) error) {
+	fake.updateResourceDefaultsMutex.Lock()
+	defer fake.updateResourceDefaultsMutex.Unlock()
+	fake.UpdateResourceDefaultsStub = stub
+}
+
+func (fake *FakeTeam) UpdateResourceDefaultsArgsForCall(i int) atc.ResourceDefaults {
+	fake.updateResourceDefaultsMutex.RLock()
+	defer fake.updateResourceDefaultsMutex.RUnlock()
+	argsForCall := fake.updateResourceDefaultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateResourceDefaultsReturns(result1 error) {
+	fake.updateResourceDefaultsMutex.Lock()
+	defer fake.updateResourceDefaultsMutex.Unlock()
+	fake.UpdateResourceDefaultsStub = nil
+	fake.updateResourceDefaultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateResourceDefaultsReturnsOnCall(i int, result1 error) {
+	fake.updateResourceDefaultsMutex.Lock()
+	defer fake.updateResourceDefaultsMutex.Unlock()
+	fake.UpdateResourceDefaultsStub = nil
+	if fake.updateResourceDefaultsReturnsOnCall == nil {
+		fake.updateResourceDefaultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateResourceDefaultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateResourceTypeMappings(arg1 atc.ResourceTypeMappings) error {
+	fake.updateResourceTypeMappingsMutex.Lock()
+	ret, specificReturn := fake.updateResourceTypeMappingsReturnsOnCall[len(fake.updateResourceTypeMappingsArgsForCall)]
+	fake.updateResourceTypeMappingsArgsForCall = append(fake.updateResourceTypeMappingsArgsForCall, struct {
+		arg1 atc.ResourceTypeMappings
+	}{arg1})
+	fake.recordInvocation("UpdateResourceTypeMappings", []interface{}{arg1})
+	fake.updateResourceTypeMappingsMutex.Unlock()
+	if fake.UpdateResourceTypeMappingsStub != nil {
+		return fake.UpdateResourceTypeMappingsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateResourceTypeMappingsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateResourceTypeMappingsCallCount() int {
+	fake.updateResourceTypeMappingsMutex.RLock()
+	defer fake.updateResourceTypeMappingsMutex.RUnlock()
+	return len(fake.updateResourceTypeMappingsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateResourceTypeMappingsCalls(stub func(atc.ResourceTypeMappings) error) {
+	fake.updateResourceTypeMappingsMutex.Lock()
+	defer fake.updateResourceTypeMappingsMutex.Unlock()
+	fake.UpdateResourceTypeMappingsStub = stub
+}
+
+func (fake *FakeTeam) UpdateResourceTypeMappingsArgsForCall(i int) atc.ResourceTypeMappings {
+	fake.updateResourceTypeMappingsMutex.RLock()
+	defer fake.updateResourceTypeMappingsMutex.RUnlock()
+	argsForCall := fake.updateResourceTypeMappingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateResourceTypeMappingsReturns(result1 error) {
+	fake.updateResourceTypeMappingsMutex.Lock()
+	defer fake.updateResourceTypeMappingsMutex.Unlock()
+	fake.UpdateResourceTypeMappingsStub = nil
+	fake.updateResourceTypeMappingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateResourceTypeMappingsReturnsOnCall(i int, result1 error) {
+	fake.updateResourceTypeMappingsMutex.Lock()
+	defer fake.updateResourceTypeMappingsMutex.Unlock()
+	fake.UpdateResourceTypeMappingsStub = nil
+	if fake.updateResourceTypeMappingsReturnsOnCall == nil {
+		fake.updateResourceTypeMappingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateResourceTypeMappingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -2166,6 +4656,8 @@ func (fake *FakeTeam) WorkersReturnsOnCall(i int, result1 []db.Worker, result2 e
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.aPITokensMutex.RLock()
+	defer fake.aPITokensMutex.RUnlock()
 	fake.adminMutex.RLock()
 	defer fake.adminMutex.RUnlock()
 	fake.authMutex.RLock()
@@ -2174,12 +4666,28 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
 	defer fake.buildsWithTimeMutex.RUnlock()
+	fake.cACertsMutex.RLock()
+	defer fake.cACertsMutex.RUnlock()
+	fake.checkPolicyMutex.RLock()
+	defer fake.checkPolicyMutex.RUnlock()
+	fake.concurrencyPoolsMutex.RLock()
+	defer fake.concurrencyPoolsMutex.RUnlock()
+	fake.containerDNSMutex.RLock()
+	defer fake.containerDNSMutex.RUnlock()
+	fake.containerEnvMutex.RLock()
+	defer fake.containerEnvMutex.RUnlock()
 	fake.containersMutex.RLock()
 	defer fake.containersMutex.RUnlock()
+	fake.contentScanPolicyMutex.RLock()
+	defer fake.contentScanPolicyMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
+	fake.defaultTaskImageMutex.RLock()
+	defer fake.defaultTaskImageMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.findCheckContainersMutex.RLock()
@@ -2202,6 +4710,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.isCheckContainerMutex.RUnlock()
 	fake.isContainerWithinTeamMutex.RLock()
 	defer fake.isContainerWithinTeamMutex.RUnlock()
+	fake.maxBuildLogSizeMutex.RLock()
+	defer fake.maxBuildLogSizeMutex.RUnlock()
+	fake.maxBuildStartsPerMinuteMutex.RLock()
+	defer fake.maxBuildStartsPerMinuteMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
@@ -2210,18 +4722,70 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelinesMutex.RLock()
 	defer fake.pipelinesMutex.RUnlock()
+	fake.pipelinesRepoMutex.RLock()
+	defer fake.pipelinesRepoMutex.RUnlock()
+	fake.pipelinesRepoStatusMutex.RLock()
+	defer fake.pipelinesRepoStatusMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
+	fake.privilegedPolicyMutex.RLock()
+	defer fake.privilegedPolicyMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
+	fake.requireImageDigestsMutex.RLock()
+	defer fake.requireImageDigestsMutex.RUnlock()
+	fake.resourceDefaultsMutex.RLock()
+	defer fake.resourceDefaultsMutex.RUnlock()
+	fake.resourceTypeHealthMutex.RLock()
+	defer fake.resourceTypeHealthMutex.RUnlock()
+	fake.resourceTypeMappingsMutex.RLock()
+	defer fake.resourceTypeMappingsMutex.RUnlock()
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.softDeleteMutex.RLock()
+	defer fake.softDeleteMutex.RUnlock()
+	fake.updateCACertsMutex.RLock()
+	defer fake.updateCACertsMutex.RUnlock()
+	fake.updateCheckPolicyMutex.RLock()
+	defer fake.updateCheckPolicyMutex.RUnlock()
+	fake.updateConcurrencyPoolsMutex.RLock()
+	defer fake.updateConcurrencyPoolsMutex.RUnlock()
+	fake.updateContainerDNSMutex.RLock()
+	defer fake.updateContainerDNSMutex.RUnlock()
+	fake.updateContainerEnvMutex.RLock()
+	defer fake.updateContainerEnvMutex.RUnlock()
+	fake.updateContentScanPolicyMutex.RLock()
+	defer fake.updateContentScanPolicyMutex.RUnlock()
+	fake.updateDefaultTaskImageMutex.RLock()
+	defer fake.updateDefaultTaskImageMutex.RUnlock()
+	fake.updateMaxBuildLogSizeMutex.RLock()
+	defer fake.updateMaxBuildLogSizeMutex.RUnlock()
+	fake.updateMaxBuildStartsPerMinuteMutex.RLock()
+	defer fake.updateMaxBuildStartsPerMinuteMutex.RUnlock()
+	fake.updatePipelinesRepoMutex.RLock()
+	defer fake.updatePipelinesRepoMutex.RUnlock()
+	fake.updatePipelinesRepoStatusMutex.RLock()
+	defer fake.updatePipelinesRepoStatusMutex.RUnlock()
+	fake.updatePrivilegedPolicyMutex.RLock()
+	defer fake.updatePrivilegedPolicyMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.updateRequireImageDigestsMutex.RLock()
+	defer fake.updateRequireImageDigestsMutex.RUnlock()
+	fake.updateResourceDefaultsMutex.RLock()
+	defer fake.updateResourceDefaultsMutex.RUnlock()
+	fake.updateResourceTypeMappingsMutex.RLock()
+	defer fake.updateResourceTypeMappingsMutex.RUnlock()
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
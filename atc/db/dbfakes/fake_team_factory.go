@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -34,6 +35,21 @@ type FakeTeamFactory struct {
 		result1 db.Team
 		result2 error
 	}
+	FindDeletedTeamStub        func(string) (db.Team, bool, error)
+	findDeletedTeamMutex       sync.RWMutex
+	findDeletedTeamArgsForCall []struct {
+		arg1 string
+	}
+	findDeletedTeamReturns struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}
+	findDeletedTeamReturnsOnCall map[int]struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}
 	FindTeamStub        func(string) (db.Team, bool, error)
 	findTeamMutex       sync.RWMutex
 	findTeamArgsForCall []struct {
@@ -49,6 +65,21 @@ type FakeTeamFactory struct {
 		result2 bool
 		result3 error
 	}
+	FindTeamByIDStub        func(int) (db.Team, bool, error)
+	findTeamByIDMutex       sync.RWMutex
+	findTeamByIDArgsForCall []struct {
+		arg1 int
+	}
+	findTeamByIDReturns struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}
+	findTeamByIDReturnsOnCall map[int]struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}
 	GetByIDStub        func(int) db.Team
 	getByIDMutex       sync.RWMutex
 	getByIDArgsForCall []struct {
@@ -72,6 +103,19 @@ type FakeTeamFactory struct {
 		result1 []db.Team
 		result2 error
 	}
+	PurgeDeletedTeamsStub        func(time.Duration) (int, error)
+	purgeDeletedTeamsMutex       sync.RWMutex
+	purgeDeletedTeamsArgsForCall []struct {
+		arg1 time.Duration
+	}
+	purgeDeletedTeamsReturns struct {
+		result1 int
+		result2 error
+	}
+	purgeDeletedTeamsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -194,6 +238,72 @@ func (fake *FakeTeamFactory) CreateTeamReturnsOnCall(i int, result1 db.Team, res
 	}{result1, result2}
 }
 
+func (fake *FakeTeamFactory) FindDeletedTeam(arg1 string) (db.Team, bool, error) {
+	fake.findDeletedTeamMutex.Lock()
+	ret, specificReturn := fake.findDeletedTeamReturnsOnCall[len(fake.findDeletedTeamArgsForCall)]
+	fake.findDeletedTeamArgsForCall = append(fake.findDeletedTeamArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("FindDeletedTeam", []interface{}{arg1})
+	fake.findDeletedTeamMutex.Unlock()
+	if fake.FindDeletedTeamStub != nil {
+		return fake.FindDeletedTeamStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.findDeletedTeamReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeamFactory) FindDeletedTeamCallCount() int {
+	fake.findDeletedTeamMutex.RLock()
+	defer fake.findDeletedTeamMutex.RUnlock()
+	return len(fake.findDeletedTeamArgsForCall)
+}
+
+func (fake *FakeTeamFactory) FindDeletedTeamCalls(stub func(string) (db.Team, bool, error)) {
+	fake.findDeletedTeamMutex.Lock()
+	defer fake.findDeletedTeamMutex.Unlock()
+	fake.FindDeletedTeamStub = stub
+}
+
+func (fake *FakeTeamFactory) FindDeletedTeamArgsForCall(i int) string {
+	fake.findDeletedTeamMutex.RLock()
+	defer fake.findDeletedTeamMutex.RUnlock()
+	argsForCall := fake.findDeletedTeamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeamFactory) FindDeletedTeamReturns(result1 db.Team, result2 bool, result3 error) {
+	fake.findDeletedTeamMutex.Lock()
+	defer fake.findDeletedTeamMutex.Unlock()
+	fake.FindDeletedTeamStub = nil
+	fake.findDeletedTeamReturns = struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamFactory) FindDeletedTeamReturnsOnCall(i int, result1 db.Team, result2 bool, result3 error) {
+	fake.findDeletedTeamMutex.Lock()
+	defer fake.findDeletedTeamMutex.Unlock()
+	fake.FindDeletedTeamStub = nil
+	if fake.findDeletedTeamReturnsOnCall == nil {
+		fake.findDeletedTeamReturnsOnCall = make(map[int]struct {
+			result1 db.Team
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findDeletedTeamReturnsOnCall[i] = struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeamFactory) FindTeam(arg1 string) (db.Team, bool, error) {
 	fake.findTeamMutex.Lock()
 	ret, specificReturn := fake.findTeamReturnsOnCall[len(fake.findTeamArgsForCall)]
@@ -260,6 +370,72 @@ func (fake *FakeTeamFactory) FindTeamReturnsOnCall(i int, result1 db.Team, resul
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeamFactory) FindTeamByID(arg1 int) (db.Team, bool, error) {
+	fake.findTeamByIDMutex.Lock()
+	ret, specificReturn := fake.findTeamByIDReturnsOnCall[len(fake.findTeamByIDArgsForCall)]
+	fake.findTeamByIDArgsForCall = append(fake.findTeamByIDArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("FindTeamByID", []interface{}{arg1})
+	fake.findTeamByIDMutex.Unlock()
+	if fake.FindTeamByIDStub != nil {
+		return fake.FindTeamByIDStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.findTeamByIDReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeamFactory) FindTeamByIDCallCount() int {
+	fake.findTeamByIDMutex.RLock()
+	defer fake.findTeamByIDMutex.RUnlock()
+	return len(fake.findTeamByIDArgsForCall)
+}
+
+func (fake *FakeTeamFactory) FindTeamByIDCalls(stub func(int) (db.Team, bool, error)) {
+	fake.findTeamByIDMutex.Lock()
+	defer fake.findTeamByIDMutex.Unlock()
+	fake.FindTeamByIDStub = stub
+}
+
+func (fake *FakeTeamFactory) FindTeamByIDArgsForCall(i int) int {
+	fake.findTeamByIDMutex.RLock()
+	defer fake.findTeamByIDMutex.RUnlock()
+	argsForCall := fake.findTeamByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeamFactory) FindTeamByIDReturns(result1 db.Team, result2 bool, result3 error) {
+	fake.findTeamByIDMutex.Lock()
+	defer fake.findTeamByIDMutex.Unlock()
+	fake.FindTeamByIDStub = nil
+	fake.findTeamByIDReturns = struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamFactory) FindTeamByIDReturnsOnCall(i int, result1 db.Team, result2 bool, result3 error) {
+	fake.findTeamByIDMutex.Lock()
+	defer fake.findTeamByIDMutex.Unlock()
+	fake.FindTeamByIDStub = nil
+	if fake.findTeamByIDReturnsOnCall == nil {
+		fake.findTeamByIDReturnsOnCall = make(map[int]struct {
+			result1 db.Team
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findTeamByIDReturnsOnCall[i] = struct {
+		result1 db.Team
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeamFactory) GetByID(arg1 int) db.Team {
 	fake.getByIDMutex.Lock()
 	ret, specificReturn := fake.getByIDReturnsOnCall[len(fake.getByIDArgsForCall)]
@@ -375,6 +551,69 @@ func (fake *FakeTeamFactory) GetTeamsReturnsOnCall(i int, result1 []db.Team, res
 	}{result1, result2}
 }
 
+func (fake *FakeTeamFactory) PurgeDeletedTeams(arg1 time.Duration) (int, error) {
+	fake.purgeDeletedTeamsMutex.Lock()
+	ret, specificReturn := fake.purgeDeletedTeamsReturnsOnCall[len(fake.purgeDeletedTeamsArgsForCall)]
+	fake.purgeDeletedTeamsArgsForCall = append(fake.purgeDeletedTeamsArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("PurgeDeletedTeams", []interface{}{arg1})
+	fake.purgeDeletedTeamsMutex.Unlock()
+	if fake.PurgeDeletedTeamsStub != nil {
+		return fake.PurgeDeletedTeamsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.purgeDeletedTeamsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeamFactory) PurgeDeletedTeamsCallCount() int {
+	fake.purgeDeletedTeamsMutex.RLock()
+	defer fake.purgeDeletedTeamsMutex.RUnlock()
+	return len(fake.purgeDeletedTeamsArgsForCall)
+}
+
+func (fake *FakeTeamFactory) PurgeDeletedTeamsCalls(stub func(time.Duration) (int, error)) {
+	fake.purgeDeletedTeamsMutex.Lock()
+	defer fake.purgeDeletedTeamsMutex.Unlock()
+	fake.PurgeDeletedTeamsStub = stub
+}
+
+func (fake *FakeTeamFactory) PurgeDeletedTeamsArgsForCall(i int) time.Duration {
+	fake.purgeDeletedTeamsMutex.RLock()
+	defer fake.purgeDeletedTeamsMutex.RUnlock()
+	argsForCall := fake.purgeDeletedTeamsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeamFactory) PurgeDeletedTeamsReturns(result1 int, result2 error) {
+	fake.purgeDeletedTeamsMutex.Lock()
+	defer fake.purgeDeletedTeamsMutex.Unlock()
+	fake.PurgeDeletedTeamsStub = nil
+	fake.purgeDeletedTeamsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamFactory) PurgeDeletedTeamsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.purgeDeletedTeamsMutex.Lock()
+	defer fake.purgeDeletedTeamsMutex.Unlock()
+	fake.PurgeDeletedTeamsStub = nil
+	if fake.purgeDeletedTeamsReturnsOnCall == nil {
+		fake.purgeDeletedTeamsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.purgeDeletedTeamsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -382,12 +621,18 @@ func (fake *FakeTeamFactory) Invocations() map[string][][]interface{} {
 	defer fake.createDefaultTeamIfNotExistsMutex.RUnlock()
 	fake.createTeamMutex.RLock()
 	defer fake.createTeamMutex.RUnlock()
+	fake.findDeletedTeamMutex.RLock()
+	defer fake.findDeletedTeamMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
+	fake.findTeamByIDMutex.RLock()
+	defer fake.findTeamByIDMutex.RUnlock()
 	fake.getByIDMutex.RLock()
 	defer fake.getByIDMutex.RUnlock()
 	fake.getTeamsMutex.RLock()
 	defer fake.getTeamsMutex.RUnlock()
+	fake.purgeDeletedTeamsMutex.RLock()
+	defer fake.purgeDeletedTeamsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
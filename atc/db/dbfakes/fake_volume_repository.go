@@ -38,6 +38,34 @@ type FakeVolumeRepository struct {
 		result1 db.CreatingVolume
 		result2 error
 	}
+	CreateImageLayerVolumeStub        func(string, string) (db.CreatingVolume, error)
+	createImageLayerVolumeMutex       sync.RWMutex
+	createImageLayerVolumeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	createImageLayerVolumeReturns struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
+	createImageLayerVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
+	CreateResourceCacheVolumeStub        func(string, db.UsedResourceCache) (db.CreatingVolume, error)
+	createResourceCacheVolumeMutex       sync.RWMutex
+	createResourceCacheVolumeArgsForCall []struct {
+		arg1 string
+		arg2 db.UsedResourceCache
+	}
+	createResourceCacheVolumeReturns struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
+	createResourceCacheVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
 	CreateResourceCertsVolumeStub        func(string, *db.UsedWorkerResourceCerts) (db.CreatingVolume, error)
 	createResourceCertsVolumeMutex       sync.RWMutex
 	createResourceCertsVolumeArgsForCall []struct {
@@ -141,6 +169,22 @@ type FakeVolumeRepository struct {
 		result2 bool
 		result3 error
 	}
+	FindImageLayerVolumeStub        func(string, string) (db.CreatedVolume, bool, error)
+	findImageLayerVolumeMutex       sync.RWMutex
+	findImageLayerVolumeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	findImageLayerVolumeReturns struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
+	findImageLayerVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
 	FindResourceCacheVolumeStub        func(string, db.UsedResourceCache) (db.CreatedVolume, bool, error)
 	findResourceCacheVolumeMutex       sync.RWMutex
 	findResourceCacheVolumeArgsForCall []struct {
@@ -255,6 +299,18 @@ type FakeVolumeRepository struct {
 		result1 int
 		result2 error
 	}
+	RemoveExpiredVolumeLeasesStub        func() (int, error)
+	removeExpiredVolumeLeasesMutex       sync.RWMutex
+	removeExpiredVolumeLeasesArgsForCall []struct {
+	}
+	removeExpiredVolumeLeasesReturns struct {
+		result1 int
+		result2 error
+	}
+	removeExpiredVolumeLeasesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	RemoveMissingVolumesStub        func(time.Duration) (int, error)
 	removeMissingVolumesMutex       sync.RWMutex
 	removeMissingVolumesArgsForCall []struct {
@@ -413,6 +469,134 @@ func (fake *FakeVolumeRepository) CreateContainerVolumeReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeRepository) CreateImageLayerVolume(arg1 string, arg2 string) (db.CreatingVolume, error) {
+	fake.createImageLayerVolumeMutex.Lock()
+	ret, specificReturn := fake.createImageLayerVolumeReturnsOnCall[len(fake.createImageLayerVolumeArgsForCall)]
+	fake.createImageLayerVolumeArgsForCall = append(fake.createImageLayerVolumeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CreateImageLayerVolume", []interface{}{arg1, arg2})
+	fake.createImageLayerVolumeMutex.Unlock()
+	if fake.CreateImageLayerVolumeStub != nil {
+		return fake.CreateImageLayerVolumeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createImageLayerVolumeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) CreateImageLayerVolumeCallCount() int {
+	fake.createImageLayerVolumeMutex.RLock()
+	defer fake.createImageLayerVolumeMutex.RUnlock()
+	return len(fake.createImageLayerVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) CreateImageLayerVolumeCalls(stub func(string, string) (db.CreatingVolume, error)) {
+	fake.createImageLayerVolumeMutex.Lock()
+	defer fake.createImageLayerVolumeMutex.Unlock()
+	fake.CreateImageLayerVolumeStub = stub
+}
+
+func (fake *FakeVolumeRepository) CreateImageLayerVolumeArgsForCall(i int) (string, string) {
+	fake.createImageLayerVolumeMutex.RLock()
+	defer fake.createImageLayerVolumeMutex.RUnlock()
+	argsForCall := fake.createImageLayerVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeRepository) CreateImageLayerVolumeReturns(result1 db.CreatingVolume, result2 error) {
+	fake.createImageLayerVolumeMutex.Lock()
+	defer fake.createImageLayerVolumeMutex.Unlock()
+	fake.CreateImageLayerVolumeStub = nil
+	fake.createImageLayerVolumeReturns = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) CreateImageLayerVolumeReturnsOnCall(i int, result1 db.CreatingVolume, result2 error) {
+	fake.createImageLayerVolumeMutex.Lock()
+	defer fake.createImageLayerVolumeMutex.Unlock()
+	fake.CreateImageLayerVolumeStub = nil
+	if fake.createImageLayerVolumeReturnsOnCall == nil {
+		fake.createImageLayerVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingVolume
+			result2 error
+		})
+	}
+	fake.createImageLayerVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) CreateResourceCacheVolume(arg1 string, arg2 db.UsedResourceCache) (db.CreatingVolume, error) {
+	fake.createResourceCacheVolumeMutex.Lock()
+	ret, specificReturn := fake.createResourceCacheVolumeReturnsOnCall[len(fake.createResourceCacheVolumeArgsForCall)]
+	fake.createResourceCacheVolumeArgsForCall = append(fake.createResourceCacheVolumeArgsForCall, struct {
+		arg1 string
+		arg2 db.UsedResourceCache
+	}{arg1, arg2})
+	fake.recordInvocation("CreateResourceCacheVolume", []interface{}{arg1, arg2})
+	fake.createResourceCacheVolumeMutex.Unlock()
+	if fake.CreateResourceCacheVolumeStub != nil {
+		return fake.CreateResourceCacheVolumeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createResourceCacheVolumeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) CreateResourceCacheVolumeCallCount() int {
+	fake.createResourceCacheVolumeMutex.RLock()
+	defer fake.createResourceCacheVolumeMutex.RUnlock()
+	return len(fake.createResourceCacheVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) CreateResourceCacheVolumeCalls(stub func(string, db.UsedResourceCache) (db.CreatingVolume, error)) {
+	fake.createResourceCacheVolumeMutex.Lock()
+	defer fake.createResourceCacheVolumeMutex.Unlock()
+	fake.CreateResourceCacheVolumeStub = stub
+}
+
+func (fake *FakeVolumeRepository) CreateResourceCacheVolumeArgsForCall(i int) (string, db.UsedResourceCache) {
+	fake.createResourceCacheVolumeMutex.RLock()
+	defer fake.createResourceCacheVolumeMutex.RUnlock()
+	argsForCall := fake.createResourceCacheVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeRepository) CreateResourceCacheVolumeReturns(result1 db.CreatingVolume, result2 error) {
+	fake.createResourceCacheVolumeMutex.Lock()
+	defer fake.createResourceCacheVolumeMutex.Unlock()
+	fake.CreateResourceCacheVolumeStub = nil
+	fake.createResourceCacheVolumeReturns = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) CreateResourceCacheVolumeReturnsOnCall(i int, result1 db.CreatingVolume, result2 error) {
+	fake.createResourceCacheVolumeMutex.Lock()
+	defer fake.createResourceCacheVolumeMutex.Unlock()
+	fake.CreateResourceCacheVolumeStub = nil
+	if fake.createResourceCacheVolumeReturnsOnCall == nil {
+		fake.createResourceCacheVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingVolume
+			result2 error
+		})
+	}
+	fake.createResourceCacheVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeRepository) CreateResourceCertsVolume(arg1 string, arg2 *db.UsedWorkerResourceCerts) (db.CreatingVolume, error) {
 	fake.createResourceCertsVolumeMutex.Lock()
 	ret, specificReturn := fake.createResourceCertsVolumeReturnsOnCall[len(fake.createResourceCertsVolumeArgsForCall)]
@@ -862,6 +1046,73 @@ func (fake *FakeVolumeRepository) FindCreatedVolumeReturnsOnCall(i int, result1
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeRepository) FindImageLayerVolume(arg1 string, arg2 string) (db.CreatedVolume, bool, error) {
+	fake.findImageLayerVolumeMutex.Lock()
+	ret, specificReturn := fake.findImageLayerVolumeReturnsOnCall[len(fake.findImageLayerVolumeArgsForCall)]
+	fake.findImageLayerVolumeArgsForCall = append(fake.findImageLayerVolumeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("FindImageLayerVolume", []interface{}{arg1, arg2})
+	fake.findImageLayerVolumeMutex.Unlock()
+	if fake.FindImageLayerVolumeStub != nil {
+		return fake.FindImageLayerVolumeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.findImageLayerVolumeReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeRepository) FindImageLayerVolumeCallCount() int {
+	fake.findImageLayerVolumeMutex.RLock()
+	defer fake.findImageLayerVolumeMutex.RUnlock()
+	return len(fake.findImageLayerVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) FindImageLayerVolumeCalls(stub func(string, string) (db.CreatedVolume, bool, error)) {
+	fake.findImageLayerVolumeMutex.Lock()
+	defer fake.findImageLayerVolumeMutex.Unlock()
+	fake.FindImageLayerVolumeStub = stub
+}
+
+func (fake *FakeVolumeRepository) FindImageLayerVolumeArgsForCall(i int) (string, string) {
+	fake.findImageLayerVolumeMutex.RLock()
+	defer fake.findImageLayerVolumeMutex.RUnlock()
+	argsForCall := fake.findImageLayerVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeRepository) FindImageLayerVolumeReturns(result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findImageLayerVolumeMutex.Lock()
+	defer fake.findImageLayerVolumeMutex.Unlock()
+	fake.FindImageLayerVolumeStub = nil
+	fake.findImageLayerVolumeReturns = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) FindImageLayerVolumeReturnsOnCall(i int, result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findImageLayerVolumeMutex.Lock()
+	defer fake.findImageLayerVolumeMutex.Unlock()
+	fake.FindImageLayerVolumeStub = nil
+	if fake.findImageLayerVolumeReturnsOnCall == nil {
+		fake.findImageLayerVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatedVolume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findImageLayerVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeRepository) FindResourceCacheVolume(arg1 string, arg2 db.UsedResourceCache) (db.CreatedVolume, bool, error) {
 	fake.findResourceCacheVolumeMutex.Lock()
 	ret, specificReturn := fake.findResourceCacheVolumeReturnsOnCall[len(fake.findResourceCacheVolumeArgsForCall)]
@@ -1377,6 +1628,61 @@ func (fake *FakeVolumeRepository) RemoveDestroyingVolumesReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeRepository) RemoveExpiredVolumeLeases() (int, error) {
+	fake.removeExpiredVolumeLeasesMutex.Lock()
+	ret, specificReturn := fake.removeExpiredVolumeLeasesReturnsOnCall[len(fake.removeExpiredVolumeLeasesArgsForCall)]
+	fake.removeExpiredVolumeLeasesArgsForCall = append(fake.removeExpiredVolumeLeasesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RemoveExpiredVolumeLeases", []interface{}{})
+	fake.removeExpiredVolumeLeasesMutex.Unlock()
+	if fake.RemoveExpiredVolumeLeasesStub != nil {
+		return fake.RemoveExpiredVolumeLeasesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.removeExpiredVolumeLeasesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) RemoveExpiredVolumeLeasesCallCount() int {
+	fake.removeExpiredVolumeLeasesMutex.RLock()
+	defer fake.removeExpiredVolumeLeasesMutex.RUnlock()
+	return len(fake.removeExpiredVolumeLeasesArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) RemoveExpiredVolumeLeasesCalls(stub func() (int, error)) {
+	fake.removeExpiredVolumeLeasesMutex.Lock()
+	defer fake.removeExpiredVolumeLeasesMutex.Unlock()
+	fake.RemoveExpiredVolumeLeasesStub = stub
+}
+
+func (fake *FakeVolumeRepository) RemoveExpiredVolumeLeasesReturns(result1 int, result2 error) {
+	fake.removeExpiredVolumeLeasesMutex.Lock()
+	defer fake.removeExpiredVolumeLeasesMutex.Unlock()
+	fake.RemoveExpiredVolumeLeasesStub = nil
+	fake.removeExpiredVolumeLeasesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) RemoveExpiredVolumeLeasesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.removeExpiredVolumeLeasesMutex.Lock()
+	defer fake.removeExpiredVolumeLeasesMutex.Unlock()
+	fake.RemoveExpiredVolumeLeasesStub = nil
+	if fake.removeExpiredVolumeLeasesReturnsOnCall == nil {
+		fake.removeExpiredVolumeLeasesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.removeExpiredVolumeLeasesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeRepository) RemoveMissingVolumes(arg1 time.Duration) (int, error) {
 	fake.removeMissingVolumesMutex.Lock()
 	ret, specificReturn := fake.removeMissingVolumesReturnsOnCall[len(fake.removeMissingVolumesArgsForCall)]
@@ -1513,6 +1819,10 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.createBaseResourceTypeVolumeMutex.RUnlock()
 	fake.createContainerVolumeMutex.RLock()
 	defer fake.createContainerVolumeMutex.RUnlock()
+	fake.createImageLayerVolumeMutex.RLock()
+	defer fake.createImageLayerVolumeMutex.RUnlock()
+	fake.createResourceCacheVolumeMutex.RLock()
+	defer fake.createResourceCacheVolumeMutex.RUnlock()
 	fake.createResourceCertsVolumeMutex.RLock()
 	defer fake.createResourceCertsVolumeMutex.RUnlock()
 	fake.createTaskCacheVolumeMutex.RLock()
@@ -1527,6 +1837,8 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.findContainerVolumeMutex.RUnlock()
 	fake.findCreatedVolumeMutex.RLock()
 	defer fake.findCreatedVolumeMutex.RUnlock()
+	fake.findImageLayerVolumeMutex.RLock()
+	defer fake.findImageLayerVolumeMutex.RUnlock()
 	fake.findResourceCacheVolumeMutex.RLock()
 	defer fake.findResourceCacheVolumeMutex.RUnlock()
 	fake.findResourceCertsVolumeMutex.RLock()
@@ -1543,6 +1855,8 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.getTeamVolumesMutex.RUnlock()
 	fake.removeDestroyingVolumesMutex.RLock()
 	defer fake.removeDestroyingVolumesMutex.RUnlock()
+	fake.removeExpiredVolumeLeasesMutex.RLock()
+	defer fake.removeExpiredVolumeLeasesMutex.RUnlock()
 	fake.removeMissingVolumesMutex.RLock()
 	defer fake.removeMissingVolumesMutex.RUnlock()
 	fake.updateVolumesMissingSinceMutex.RLock()
@@ -42,6 +42,18 @@ type FakeWorker struct {
 	activeVolumesReturnsOnCall map[int]struct {
 		result1 int
 	}
+	AvailableGPUsStub        func() (int, error)
+	availableGPUsMutex       sync.RWMutex
+	availableGPUsArgsForCall []struct {
+	}
+	availableGPUsReturns struct {
+		result1 int
+		result2 error
+	}
+	availableGPUsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	BaggageclaimURLStub        func() *string
 	baggageclaimURLMutex       sync.RWMutex
 	baggageclaimURLArgsForCall []struct {
@@ -52,6 +64,16 @@ type FakeWorker struct {
 	baggageclaimURLReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	CapabilitiesStub        func() []atc.WorkerCapability
+	capabilitiesMutex       sync.RWMutex
+	capabilitiesArgsForCall []struct {
+	}
+	capabilitiesReturns struct {
+		result1 []atc.WorkerCapability
+	}
+	capabilitiesReturnsOnCall map[int]struct {
+		result1 []atc.WorkerCapability
+	}
 	CertsPathStub        func() *string
 	certsPathMutex       sync.RWMutex
 	certsPathArgsForCall []struct {
@@ -96,6 +118,18 @@ type FakeWorker struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiscrepanciesStub        func() ([]atc.WorkerDiscrepancy, error)
+	discrepanciesMutex       sync.RWMutex
+	discrepanciesArgsForCall []struct {
+	}
+	discrepanciesReturns struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}
+	discrepanciesReturnsOnCall map[int]struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}
 	EphemeralStub        func() bool
 	ephemeralMutex       sync.RWMutex
 	ephemeralArgsForCall []struct {
@@ -131,6 +165,16 @@ type FakeWorker struct {
 		result2 db.CreatedContainer
 		result3 error
 	}
+	GPUsStub        func() int
+	gPUsMutex       sync.RWMutex
+	gPUsArgsForCall []struct {
+	}
+	gPUsReturns struct {
+		result1 int
+	}
+	gPUsReturnsOnCall map[int]struct {
+		result1 int
+	}
 	GardenAddrStub        func() *string
 	gardenAddrMutex       sync.RWMutex
 	gardenAddrArgsForCall []struct {
@@ -171,6 +215,16 @@ type FakeWorker struct {
 	increaseActiveTasksReturnsOnCall map[int]struct {
 		result1 error
 	}
+	LabelsStub        func() atc.Labels
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct {
+	}
+	labelsReturns struct {
+		result1 atc.Labels
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 atc.Labels
+	}
 	LandStub        func() error
 	landMutex       sync.RWMutex
 	landArgsForCall []struct {
@@ -211,6 +265,16 @@ type FakeWorker struct {
 	platformReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ProtocolVersionStub        func() int
+	protocolVersionMutex       sync.RWMutex
+	protocolVersionArgsForCall []struct {
+	}
+	protocolVersionReturns struct {
+		result1 int
+	}
+	protocolVersionReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PruneStub        func() error
 	pruneMutex       sync.RWMutex
 	pruneArgsForCall []struct {
@@ -221,6 +285,19 @@ type FakeWorker struct {
 	pruneReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReconcileCensusStub        func(atc.WorkerCensus) ([]atc.WorkerDiscrepancy, error)
+	reconcileCensusMutex       sync.RWMutex
+	reconcileCensusArgsForCall []struct {
+		arg1 atc.WorkerCensus
+	}
+	reconcileCensusReturns struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}
+	reconcileCensusReturnsOnCall map[int]struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -233,6 +310,18 @@ type FakeWorker struct {
 		result1 bool
 		result2 error
 	}
+	ResolveDiscrepanciesStub        func() (int, error)
+	resolveDiscrepanciesMutex       sync.RWMutex
+	resolveDiscrepanciesArgsForCall []struct {
+	}
+	resolveDiscrepanciesReturns struct {
+		result1 int
+		result2 error
+	}
+	resolveDiscrepanciesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	ResourceCertsStub        func() (*db.UsedWorkerResourceCerts, bool, error)
 	resourceCertsMutex       sync.RWMutex
 	resourceCertsArgsForCall []struct {
@@ -287,6 +376,16 @@ type FakeWorker struct {
 	stateReturnsOnCall map[int]struct {
 		result1 db.WorkerState
 	}
+	StreamEncodingStub        func() string
+	streamEncodingMutex       sync.RWMutex
+	streamEncodingArgsForCall []struct {
+	}
+	streamEncodingReturns struct {
+		result1 string
+	}
+	streamEncodingReturnsOnCall map[int]struct {
+		result1 string
+	}
 	TagsStub        func() []string
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -490,6 +589,61 @@ func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) AvailableGPUs() (int, error) {
+	fake.availableGPUsMutex.Lock()
+	ret, specificReturn := fake.availableGPUsReturnsOnCall[len(fake.availableGPUsArgsForCall)]
+	fake.availableGPUsArgsForCall = append(fake.availableGPUsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AvailableGPUs", []interface{}{})
+	fake.availableGPUsMutex.Unlock()
+	if fake.AvailableGPUsStub != nil {
+		return fake.AvailableGPUsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.availableGPUsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) AvailableGPUsCallCount() int {
+	fake.availableGPUsMutex.RLock()
+	defer fake.availableGPUsMutex.RUnlock()
+	return len(fake.availableGPUsArgsForCall)
+}
+
+func (fake *FakeWorker) AvailableGPUsCalls(stub func() (int, error)) {
+	fake.availableGPUsMutex.Lock()
+	defer fake.availableGPUsMutex.Unlock()
+	fake.AvailableGPUsStub = stub
+}
+
+func (fake *FakeWorker) AvailableGPUsReturns(result1 int, result2 error) {
+	fake.availableGPUsMutex.Lock()
+	defer fake.availableGPUsMutex.Unlock()
+	fake.AvailableGPUsStub = nil
+	fake.availableGPUsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) AvailableGPUsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.availableGPUsMutex.Lock()
+	defer fake.availableGPUsMutex.Unlock()
+	fake.AvailableGPUsStub = nil
+	if fake.availableGPUsReturnsOnCall == nil {
+		fake.availableGPUsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.availableGPUsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) BaggageclaimURL() *string {
 	fake.baggageclaimURLMutex.Lock()
 	ret, specificReturn := fake.baggageclaimURLReturnsOnCall[len(fake.baggageclaimURLArgsForCall)]
@@ -542,6 +696,58 @@ func (fake *FakeWorker) BaggageclaimURLReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capabilities() []atc.WorkerCapability {
+	fake.capabilitiesMutex.Lock()
+	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
+	fake.capabilitiesArgsForCall = append(fake.capabilitiesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Capabilities", []interface{}{})
+	fake.capabilitiesMutex.Unlock()
+	if fake.CapabilitiesStub != nil {
+		return fake.CapabilitiesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.capabilitiesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapabilitiesCallCount() int {
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	return len(fake.capabilitiesArgsForCall)
+}
+
+func (fake *FakeWorker) CapabilitiesCalls(stub func() []atc.WorkerCapability) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = stub
+}
+
+func (fake *FakeWorker) CapabilitiesReturns(result1 []atc.WorkerCapability) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	fake.capabilitiesReturns = struct {
+		result1 []atc.WorkerCapability
+	}{result1}
+}
+
+func (fake *FakeWorker) CapabilitiesReturnsOnCall(i int, result1 []atc.WorkerCapability) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	if fake.capabilitiesReturnsOnCall == nil {
+		fake.capabilitiesReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerCapability
+		})
+	}
+	fake.capabilitiesReturnsOnCall[i] = struct {
+		result1 []atc.WorkerCapability
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsPath() *string {
 	fake.certsPathMutex.Lock()
 	ret, specificReturn := fake.certsPathReturnsOnCall[len(fake.certsPathArgsForCall)]
@@ -762,6 +968,61 @@ func (fake *FakeWorker) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Discrepancies() ([]atc.WorkerDiscrepancy, error) {
+	fake.discrepanciesMutex.Lock()
+	ret, specificReturn := fake.discrepanciesReturnsOnCall[len(fake.discrepanciesArgsForCall)]
+	fake.discrepanciesArgsForCall = append(fake.discrepanciesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Discrepancies", []interface{}{})
+	fake.discrepanciesMutex.Unlock()
+	if fake.DiscrepanciesStub != nil {
+		return fake.DiscrepanciesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.discrepanciesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) DiscrepanciesCallCount() int {
+	fake.discrepanciesMutex.RLock()
+	defer fake.discrepanciesMutex.RUnlock()
+	return len(fake.discrepanciesArgsForCall)
+}
+
+func (fake *FakeWorker) DiscrepanciesCalls(stub func() ([]atc.WorkerDiscrepancy, error)) {
+	fake.discrepanciesMutex.Lock()
+	defer fake.discrepanciesMutex.Unlock()
+	fake.DiscrepanciesStub = stub
+}
+
+func (fake *FakeWorker) DiscrepanciesReturns(result1 []atc.WorkerDiscrepancy, result2 error) {
+	fake.discrepanciesMutex.Lock()
+	defer fake.discrepanciesMutex.Unlock()
+	fake.DiscrepanciesStub = nil
+	fake.discrepanciesReturns = struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) DiscrepanciesReturnsOnCall(i int, result1 []atc.WorkerDiscrepancy, result2 error) {
+	fake.discrepanciesMutex.Lock()
+	defer fake.discrepanciesMutex.Unlock()
+	fake.DiscrepanciesStub = nil
+	if fake.discrepanciesReturnsOnCall == nil {
+		fake.discrepanciesReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerDiscrepancy
+			result2 error
+		})
+	}
+	fake.discrepanciesReturnsOnCall[i] = struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Ephemeral() bool {
 	fake.ephemeralMutex.Lock()
 	ret, specificReturn := fake.ephemeralReturnsOnCall[len(fake.ephemeralArgsForCall)]
@@ -932,6 +1193,58 @@ func (fake *FakeWorker) FindContainerReturnsOnCall(i int, result1 db.CreatingCon
 	}{result1, result2, result3}
 }
 
+func (fake *FakeWorker) GPUs() int {
+	fake.gPUsMutex.Lock()
+	ret, specificReturn := fake.gPUsReturnsOnCall[len(fake.gPUsArgsForCall)]
+	fake.gPUsArgsForCall = append(fake.gPUsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GPUs", []interface{}{})
+	fake.gPUsMutex.Unlock()
+	if fake.GPUsStub != nil {
+		return fake.GPUsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.gPUsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) GPUsCallCount() int {
+	fake.gPUsMutex.RLock()
+	defer fake.gPUsMutex.RUnlock()
+	return len(fake.gPUsArgsForCall)
+}
+
+func (fake *FakeWorker) GPUsCalls(stub func() int) {
+	fake.gPUsMutex.Lock()
+	defer fake.gPUsMutex.Unlock()
+	fake.GPUsStub = stub
+}
+
+func (fake *FakeWorker) GPUsReturns(result1 int) {
+	fake.gPUsMutex.Lock()
+	defer fake.gPUsMutex.Unlock()
+	fake.GPUsStub = nil
+	fake.gPUsReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) GPUsReturnsOnCall(i int, result1 int) {
+	fake.gPUsMutex.Lock()
+	defer fake.gPUsMutex.Unlock()
+	fake.GPUsStub = nil
+	if fake.gPUsReturnsOnCall == nil {
+		fake.gPUsReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.gPUsReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) GardenAddr() *string {
 	fake.gardenAddrMutex.Lock()
 	ret, specificReturn := fake.gardenAddrReturnsOnCall[len(fake.gardenAddrArgsForCall)]
@@ -1140,6 +1453,58 @@ func (fake *FakeWorker) IncreaseActiveTasksReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Labels() atc.Labels {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.labelsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakeWorker) LabelsCalls(stub func() atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = stub
+}
+
+func (fake *FakeWorker) LabelsReturns(result1 atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
+func (fake *FakeWorker) LabelsReturnsOnCall(i int, result1 atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 atc.Labels
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
 func (fake *FakeWorker) Land() error {
 	fake.landMutex.Lock()
 	ret, specificReturn := fake.landReturnsOnCall[len(fake.landArgsForCall)]
@@ -1348,6 +1713,58 @@ func (fake *FakeWorker) PlatformReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ProtocolVersion() int {
+	fake.protocolVersionMutex.Lock()
+	ret, specificReturn := fake.protocolVersionReturnsOnCall[len(fake.protocolVersionArgsForCall)]
+	fake.protocolVersionArgsForCall = append(fake.protocolVersionArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ProtocolVersion", []interface{}{})
+	fake.protocolVersionMutex.Unlock()
+	if fake.ProtocolVersionStub != nil {
+		return fake.ProtocolVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.protocolVersionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ProtocolVersionCallCount() int {
+	fake.protocolVersionMutex.RLock()
+	defer fake.protocolVersionMutex.RUnlock()
+	return len(fake.protocolVersionArgsForCall)
+}
+
+func (fake *FakeWorker) ProtocolVersionCalls(stub func() int) {
+	fake.protocolVersionMutex.Lock()
+	defer fake.protocolVersionMutex.Unlock()
+	fake.ProtocolVersionStub = stub
+}
+
+func (fake *FakeWorker) ProtocolVersionReturns(result1 int) {
+	fake.protocolVersionMutex.Lock()
+	defer fake.protocolVersionMutex.Unlock()
+	fake.ProtocolVersionStub = nil
+	fake.protocolVersionReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ProtocolVersionReturnsOnCall(i int, result1 int) {
+	fake.protocolVersionMutex.Lock()
+	defer fake.protocolVersionMutex.Unlock()
+	fake.ProtocolVersionStub = nil
+	if fake.protocolVersionReturnsOnCall == nil {
+		fake.protocolVersionReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.protocolVersionReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) Prune() error {
 	fake.pruneMutex.Lock()
 	ret, specificReturn := fake.pruneReturnsOnCall[len(fake.pruneArgsForCall)]
@@ -1400,6 +1817,69 @@ func (fake *FakeWorker) PruneReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ReconcileCensus(arg1 atc.WorkerCensus) ([]atc.WorkerDiscrepancy, error) {
+	fake.reconcileCensusMutex.Lock()
+	ret, specificReturn := fake.reconcileCensusReturnsOnCall[len(fake.reconcileCensusArgsForCall)]
+	fake.reconcileCensusArgsForCall = append(fake.reconcileCensusArgsForCall, struct {
+		arg1 atc.WorkerCensus
+	}{arg1})
+	fake.recordInvocation("ReconcileCensus", []interface{}{arg1})
+	fake.reconcileCensusMutex.Unlock()
+	if fake.ReconcileCensusStub != nil {
+		return fake.ReconcileCensusStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.reconcileCensusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ReconcileCensusCallCount() int {
+	fake.reconcileCensusMutex.RLock()
+	defer fake.reconcileCensusMutex.RUnlock()
+	return len(fake.reconcileCensusArgsForCall)
+}
+
+func (fake *FakeWorker) ReconcileCensusCalls(stub func(atc.WorkerCensus) ([]atc.WorkerDiscrepancy, error)) {
+	fake.reconcileCensusMutex.Lock()
+	defer fake.reconcileCensusMutex.Unlock()
+	fake.ReconcileCensusStub = stub
+}
+
+func (fake *FakeWorker) ReconcileCensusArgsForCall(i int) atc.WorkerCensus {
+	fake.reconcileCensusMutex.RLock()
+	defer fake.reconcileCensusMutex.RUnlock()
+	argsForCall := fake.reconcileCensusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) ReconcileCensusReturns(result1 []atc.WorkerDiscrepancy, result2 error) {
+	fake.reconcileCensusMutex.Lock()
+	defer fake.reconcileCensusMutex.Unlock()
+	fake.ReconcileCensusStub = nil
+	fake.reconcileCensusReturns = struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReconcileCensusReturnsOnCall(i int, result1 []atc.WorkerDiscrepancy, result2 error) {
+	fake.reconcileCensusMutex.Lock()
+	defer fake.reconcileCensusMutex.Unlock()
+	fake.ReconcileCensusStub = nil
+	if fake.reconcileCensusReturnsOnCall == nil {
+		fake.reconcileCensusReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerDiscrepancy
+			result2 error
+		})
+	}
+	fake.reconcileCensusReturnsOnCall[i] = struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1455,6 +1935,61 @@ func (fake *FakeWorker) ReloadReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) ResolveDiscrepancies() (int, error) {
+	fake.resolveDiscrepanciesMutex.Lock()
+	ret, specificReturn := fake.resolveDiscrepanciesReturnsOnCall[len(fake.resolveDiscrepanciesArgsForCall)]
+	fake.resolveDiscrepanciesArgsForCall = append(fake.resolveDiscrepanciesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResolveDiscrepancies", []interface{}{})
+	fake.resolveDiscrepanciesMutex.Unlock()
+	if fake.ResolveDiscrepanciesStub != nil {
+		return fake.ResolveDiscrepanciesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resolveDiscrepanciesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ResolveDiscrepanciesCallCount() int {
+	fake.resolveDiscrepanciesMutex.RLock()
+	defer fake.resolveDiscrepanciesMutex.RUnlock()
+	return len(fake.resolveDiscrepanciesArgsForCall)
+}
+
+func (fake *FakeWorker) ResolveDiscrepanciesCalls(stub func() (int, error)) {
+	fake.resolveDiscrepanciesMutex.Lock()
+	defer fake.resolveDiscrepanciesMutex.Unlock()
+	fake.ResolveDiscrepanciesStub = stub
+}
+
+func (fake *FakeWorker) ResolveDiscrepanciesReturns(result1 int, result2 error) {
+	fake.resolveDiscrepanciesMutex.Lock()
+	defer fake.resolveDiscrepanciesMutex.Unlock()
+	fake.ResolveDiscrepanciesStub = nil
+	fake.resolveDiscrepanciesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ResolveDiscrepanciesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.resolveDiscrepanciesMutex.Lock()
+	defer fake.resolveDiscrepanciesMutex.Unlock()
+	fake.ResolveDiscrepanciesStub = nil
+	if fake.resolveDiscrepanciesReturnsOnCall == nil {
+		fake.resolveDiscrepanciesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.resolveDiscrepanciesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) ResourceCerts() (*db.UsedWorkerResourceCerts, bool, error) {
 	fake.resourceCertsMutex.Lock()
 	ret, specificReturn := fake.resourceCertsReturnsOnCall[len(fake.resourceCertsArgsForCall)]
@@ -1721,6 +2256,58 @@ func (fake *FakeWorker) StateReturnsOnCall(i int, result1 db.WorkerState) {
 	}{result1}
 }
 
+func (fake *FakeWorker) StreamEncoding() string {
+	fake.streamEncodingMutex.Lock()
+	ret, specificReturn := fake.streamEncodingReturnsOnCall[len(fake.streamEncodingArgsForCall)]
+	fake.streamEncodingArgsForCall = append(fake.streamEncodingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("StreamEncoding", []interface{}{})
+	fake.streamEncodingMutex.Unlock()
+	if fake.StreamEncodingStub != nil {
+		return fake.StreamEncodingStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.streamEncodingReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) StreamEncodingCallCount() int {
+	fake.streamEncodingMutex.RLock()
+	defer fake.streamEncodingMutex.RUnlock()
+	return len(fake.streamEncodingArgsForCall)
+}
+
+func (fake *FakeWorker) StreamEncodingCalls(stub func() string) {
+	fake.streamEncodingMutex.Lock()
+	defer fake.streamEncodingMutex.Unlock()
+	fake.StreamEncodingStub = stub
+}
+
+func (fake *FakeWorker) StreamEncodingReturns(result1 string) {
+	fake.streamEncodingMutex.Lock()
+	defer fake.streamEncodingMutex.Unlock()
+	fake.StreamEncodingStub = nil
+	fake.streamEncodingReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) StreamEncodingReturnsOnCall(i int, result1 string) {
+	fake.streamEncodingMutex.Lock()
+	defer fake.streamEncodingMutex.Unlock()
+	fake.StreamEncodingStub = nil
+	if fake.streamEncodingReturnsOnCall == nil {
+		fake.streamEncodingReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.streamEncodingReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) Tags() []string {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -1938,8 +2525,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeTasksMutex.RUnlock()
 	fake.activeVolumesMutex.RLock()
 	defer fake.activeVolumesMutex.RUnlock()
+	fake.availableGPUsMutex.RLock()
+	defer fake.availableGPUsMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
 	defer fake.baggageclaimURLMutex.RUnlock()
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
 	fake.createContainerMutex.RLock()
@@ -1948,12 +2539,16 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.decreaseActiveTasksMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.discrepanciesMutex.RLock()
+	defer fake.discrepanciesMutex.RUnlock()
 	fake.ephemeralMutex.RLock()
 	defer fake.ephemeralMutex.RUnlock()
 	fake.expiresAtMutex.RLock()
 	defer fake.expiresAtMutex.RUnlock()
 	fake.findContainerMutex.RLock()
 	defer fake.findContainerMutex.RUnlock()
+	fake.gPUsMutex.RLock()
+	defer fake.gPUsMutex.RUnlock()
 	fake.gardenAddrMutex.RLock()
 	defer fake.gardenAddrMutex.RUnlock()
 	fake.hTTPProxyURLMutex.RLock()
@@ -1962,6 +2557,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.hTTPSProxyURLMutex.RUnlock()
 	fake.increaseActiveTasksMutex.RLock()
 	defer fake.increaseActiveTasksMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
 	fake.landMutex.RLock()
 	defer fake.landMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -1970,10 +2567,16 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.noProxyMutex.RUnlock()
 	fake.platformMutex.RLock()
 	defer fake.platformMutex.RUnlock()
+	fake.protocolVersionMutex.RLock()
+	defer fake.protocolVersionMutex.RUnlock()
 	fake.pruneMutex.RLock()
 	defer fake.pruneMutex.RUnlock()
+	fake.reconcileCensusMutex.RLock()
+	defer fake.reconcileCensusMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.resolveDiscrepanciesMutex.RLock()
+	defer fake.resolveDiscrepanciesMutex.RUnlock()
 	fake.resourceCertsMutex.RLock()
 	defer fake.resourceCertsMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
@@ -1984,6 +2587,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
 	defer fake.stateMutex.RUnlock()
+	fake.streamEncodingMutex.RLock()
+	defer fake.streamEncodingMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
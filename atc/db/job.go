@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -33,10 +34,14 @@ type Job interface {
 	Unpause() error
 
 	CreateBuild() (Build, error)
+	CreateBuildWithOverrides(atc.InputVersionOverrides, atc.TaskImageOverrides, atc.InputArtifacts, atc.StepWorkerOverrides) (Build, error)
+	RerunBuild(Build, atc.Plan) (Build, error)
+	AutoRetryBuild(Build, atc.Plan) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
+	BuildStatistics(from time.Time, to time.Time) (atc.BuildStatistics, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists() error
 	GetPendingBuilds() ([]Build, error)
@@ -55,9 +60,21 @@ type Job interface {
 
 	SetHasNewInputs(bool) error
 	HasNewInputs() bool
+
+	// InputResolutionCached is true when the job's next inputs were resolved
+	// and saved since anything which they depend on last changed, so that
+	// resolving them again would come up with the same inputs.
+	InputResolutionCached() bool
+
+	// CacheInputResolution records that the job's next inputs have been
+	// resolved as of when the job was loaded. It does nothing if they have
+	// been invalidated since.
+	CacheInputResolution() error
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs",
+	"(SELECT generation FROM job_input_resolutions WHERE job_id = j.id)",
+	"(SELECT resolved_generation FROM job_input_resolutions WHERE job_id = j.id)").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -85,6 +102,9 @@ type job struct {
 	tags               []string
 	hasNewInputs       bool
 
+	inputsGeneration         int
+	inputsResolvedGeneration sql.NullInt64
+
 	conn        Conn
 	lockFactory lock.LockFactory
 }
@@ -136,6 +156,23 @@ func (j *job) Tags() []string          { return j.tags }
 func (j *job) Public() bool            { return j.Config().Public }
 func (j *job) HasNewInputs() bool      { return j.hasNewInputs }
 
+func (j *job) InputResolutionCached() bool {
+	return j.inputsResolvedGeneration.Valid && int(j.inputsResolvedGeneration.Int64) == j.inputsGeneration
+}
+
+func (j *job) CacheInputResolution() error {
+	_, err := psql.Update("job_input_resolutions").
+		Set("resolved_generation", j.inputsGeneration).
+		Where(sq.Eq{
+			"job_id":     j.id,
+			"generation": j.inputsGeneration,
+		}).
+		RunWith(j.conn).
+		Exec()
+
+	return err
+}
+
 func (j *job) Reload() (bool, error) {
 	row := jobsQuery.Where(sq.Eq{"j.id": j.id}).
 		RunWith(j.conn).
@@ -184,6 +221,46 @@ func (j *job) FinishedAndNextBuild() (Build, Build, error) {
 	return finished, next, nil
 }
 
+// BuildStatistics summarizes the builds of the job which finished at or after
+// from and before to.
+func (j *job) BuildStatistics(from time.Time, to time.Time) (atc.BuildStatistics, error) {
+	var stats atc.BuildStatistics
+	err := j.conn.QueryRow(`
+		SELECT
+			count(*),
+			count(*) FILTER (WHERE status = 'succeeded'),
+			count(*) FILTER (WHERE status = 'failed'),
+			count(*) FILTER (WHERE status = 'errored'),
+			count(*) FILTER (WHERE status = 'aborted'),
+			COALESCE(avg(EXTRACT(EPOCH FROM end_time - start_time)) FILTER (WHERE status = 'succeeded'), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM end_time - start_time)) FILTER (WHERE status = 'succeeded'), 0),
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM end_time - start_time)) FILTER (WHERE status = 'succeeded'), 0)
+		FROM builds
+		WHERE job_id = $1
+		AND completed
+		AND end_time >= $2
+		AND end_time < $3
+	`, j.id, from, to).Scan(
+		&stats.Builds,
+		&stats.Succeeded,
+		&stats.Failed,
+		&stats.Errored,
+		&stats.Aborted,
+		&stats.MeanDuration,
+		&stats.MedianDuration,
+		&stats.P95Duration,
+	)
+	if err != nil {
+		return atc.BuildStatistics{}, err
+	}
+
+	if rated := stats.Builds - stats.Aborted; rated > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(rated)
+	}
+
+	return stats, nil
+}
+
 func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	if j.firstLoggedBuildID > newFirstLoggedBuildID {
 		return FirstLoggedBuildIDDecreasedError{
@@ -438,13 +515,18 @@ func (j *job) EnsurePendingBuildExists() error {
 		return err
 	}
 
+	token, err := newBuildToken()
+	if err != nil {
+		return err
+	}
+
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status)
-		SELECT $1, $2, $3, $4, 'pending'
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, token)
+		SELECT $1, $2, $3, $4, 'pending', $5
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID)
+	`, buildName, j.id, j.pipelineID, j.teamID, token)
 	if err != nil {
 		return err
 	}
@@ -517,6 +599,10 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild() (Build, error) {
+	return j.CreateBuildWithOverrides(nil, nil, nil, nil)
+}
+
+func (j *job) CreateBuildWithOverrides(inputOverrides atc.InputVersionOverrides, imageOverrides atc.TaskImageOverrides, inputArtifacts atc.InputArtifacts, workerOverrides atc.StepWorkerOverrides) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -529,6 +615,109 @@ func (j *job) CreateBuild() (Build, error) {
 		return nil, err
 	}
 
+	vals := map[string]interface{}{
+		"name":               buildName,
+		"job_id":             j.id,
+		"pipeline_id":        j.pipelineID,
+		"team_id":            j.teamID,
+		"status":             BuildStatusPending,
+		"manually_triggered": true,
+	}
+
+	if len(inputOverrides) > 0 {
+		payload, err := json.Marshal(inputOverrides)
+		if err != nil {
+			return nil, err
+		}
+
+		vals["input_overrides"] = string(payload)
+	}
+
+	if len(imageOverrides) > 0 {
+		payload, err := json.Marshal(imageOverrides)
+		if err != nil {
+			return nil, err
+		}
+
+		vals["image_overrides"] = string(payload)
+	}
+
+	if len(inputArtifacts) > 0 {
+		payload, err := json.Marshal(inputArtifacts)
+		if err != nil {
+			return nil, err
+		}
+
+		vals["input_artifacts"] = string(payload)
+	}
+
+	if len(workerOverrides) > 0 {
+		payload, err := json.Marshal(workerOverrides)
+		if err != nil {
+			return nil, err
+		}
+
+		vals["worker_overrides"] = string(payload)
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// RerunBuild creates a build re-running a failed build of the job with the
+// given plan, which has already resumed from the failed build's checkpoint.
+// The build uses the failed build's inputs. It is pending, like any other new
+// build, so that it's only started once the scheduler allows it to be; the
+// plan is kept on it until then.
+func (j *job) RerunBuild(original Build, plan atc.Plan) (Build, error) {
+	return j.rerunBuild(original, plan, true, 0)
+}
+
+// AutoRetryBuild is like RerunBuild, but for re-running a build which errored
+// because of a failure of the infrastructure rather than of the build itself.
+// The build isn't manually triggered, and counts which automatic retry it is.
+func (j *job) AutoRetryBuild(original Build, plan atc.Plan) (Build, error) {
+	return j.rerunBuild(original, plan, false, original.AutoRetryAttempt()+1)
+}
+
+func (j *job) rerunBuild(original Build, plan atc.Plan, manuallyTriggered bool, autoRetryAttempt int) (Build, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := json.Marshal(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedPlan, nonce, err := j.conn.EncryptionStrategy().Encrypt(metadata)
+	if err != nil {
+		return nil, err
+	}
+
 	build := &build{conn: j.conn, lockFactory: j.lockFactory}
 	err = createBuild(tx, build, map[string]interface{}{
 		"name":               buildName,
@@ -536,12 +725,26 @@ func (j *job) CreateBuild() (Build, error) {
 		"pipeline_id":        j.pipelineID,
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
-		"manually_triggered": true,
+		"manually_triggered": manuallyTriggered,
+		"private_plan":       encryptedPlan,
+		"nonce":              nonce,
+		"rerun_of":           original.ID(),
+		"auto_retry_attempt": autoRetryAttempt,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO build_resource_config_version_inputs (build_id, resource_id, version_md5, name)
+		SELECT $1, resource_id, version_md5, name
+		FROM build_resource_config_version_inputs
+		WHERE build_id = $2
+	`, build.id, original.ID())
+	if err != nil {
+		return nil, err
+	}
+
 	err = updateNextBuildForJob(tx, j.id)
 	if err != nil {
 		return nil, err
@@ -644,10 +847,11 @@ func (j *job) updatePausedJob(pause bool) error {
 }
 
 func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
-	rows, err := psql.Select("i.input_name, i.first_occurrence, i.resource_id, v.version").
+	rows, err := psql.Select("i.input_name, i.first_occurrence, i.resource_id, v.version, fv.version").
 		From(table + " i").
 		Join("jobs j ON i.job_id = j.id").
 		Join("resource_config_versions v ON v.id = i.resource_config_version_id").
+		LeftJoin("resource_config_versions fv ON fv.id = i.fallback_from_version_id").
 		Where(sq.Eq{
 			"j.name":        j.name,
 			"j.pipeline_id": j.pipelineID,
@@ -661,14 +865,16 @@ func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
 	buildInputs := []BuildInput{}
 	for rows.Next() {
 		var (
-			inputName       string
-			firstOccurrence bool
-			versionBlob     string
-			version         atc.Version
-			resourceID      int
+			inputName        string
+			firstOccurrence  bool
+			versionBlob      string
+			version          atc.Version
+			resourceID       int
+			fallbackFromBlob sql.NullString
+			fallbackFrom     atc.Version
 		)
 
-		err := rows.Scan(&inputName, &firstOccurrence, &resourceID, &versionBlob)
+		err := rows.Scan(&inputName, &firstOccurrence, &resourceID, &versionBlob, &fallbackFromBlob)
 		if err != nil {
 			return nil, err
 		}
@@ -678,11 +884,19 @@ func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
 			return nil, err
 		}
 
+		if fallbackFromBlob.Valid {
+			err = json.Unmarshal([]byte(fallbackFromBlob.String), &fallbackFrom)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		buildInputs = append(buildInputs, BuildInput{
 			Name:            inputName,
 			ResourceID:      resourceID,
 			Version:         version,
 			FirstOccurrence: firstOccurrence,
+			FallbackFrom:    fallbackFrom,
 		})
 	}
 	return buildInputs, nil
@@ -724,7 +938,7 @@ func (j *job) saveJobInputMapping(table string, inputMapping algorithm.InputMapp
 		return err
 	}
 
-	rows, err := psql.Select("input_name, resource_config_version_id, resource_id, first_occurrence").
+	rows, err := psql.Select("input_name, resource_config_version_id, resource_id, first_occurrence, fallback_from_version_id").
 		From(table).
 		Where(sq.Eq{"job_id": j.id}).
 		RunWith(tx).
@@ -737,11 +951,14 @@ func (j *job) saveJobInputMapping(table string, inputMapping algorithm.InputMapp
 	for rows.Next() {
 		var inputName string
 		var inputVersion algorithm.InputVersion
-		err = rows.Scan(&inputName, &inputVersion.VersionID, &inputVersion.ResourceID, &inputVersion.FirstOccurrence)
+		var fallbackFromVersionID sql.NullInt64
+		err = rows.Scan(&inputName, &inputVersion.VersionID, &inputVersion.ResourceID, &inputVersion.FirstOccurrence, &fallbackFromVersionID)
 		if err != nil {
 			return err
 		}
 
+		inputVersion.FallbackFromVersionID = int(fallbackFromVersionID.Int64)
+
 		oldInputMapping[inputName] = inputVersion
 	}
 
@@ -764,6 +981,11 @@ func (j *job) saveJobInputMapping(table string, inputMapping algorithm.InputMapp
 	for inputName, inputVersion := range inputMapping {
 		oldInputVersion, found := oldInputMapping[inputName]
 		if !found || inputVersion != oldInputVersion {
+			var fallbackFromVersionID interface{}
+			if inputVersion.FallbackFromVersionID != 0 {
+				fallbackFromVersionID = inputVersion.FallbackFromVersionID
+			}
+
 			_, err := psql.Insert(table).
 				SetMap(map[string]interface{}{
 					"job_id":                     j.id,
@@ -771,6 +993,7 @@ func (j *job) saveJobInputMapping(table string, inputMapping algorithm.InputMapp
 					"resource_config_version_id": inputVersion.VersionID,
 					"resource_id":                inputVersion.ResourceID,
 					"first_occurrence":           inputVersion.FirstOccurrence,
+					"fallback_from_version_id":   fallbackFromVersionID,
 				}).
 				RunWith(tx).
 				Exec()
@@ -825,15 +1048,18 @@ func (j *job) finishedBuild() (Build, error) {
 
 func scanJob(j *job, row scannable) error {
 	var (
-		configBlob []byte
-		nonce      sql.NullString
+		configBlob       []byte
+		nonce            sql.NullString
+		inputsGeneration sql.NullInt64
 	)
 
-	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs)
+	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &inputsGeneration, &j.inputsResolvedGeneration)
 	if err != nil {
 		return err
 	}
 
+	j.inputsGeneration = int(inputsGeneration.Int64)
+
 	es := j.conn.EncryptionStrategy()
 
 	var noncense *string
@@ -43,8 +43,9 @@ func (j *jobFactory) VisibleJobs(teamNames []string) (Dashboard, error) {
 func (j *jobFactory) teamJobs(teamNames []string) (Jobs, error) {
 	rows, err := jobsQuery.
 		Where(sq.Eq{
-			"t.name":   teamNames,
-			"j.active": true,
+			"t.name":       teamNames,
+			"j.active":     true,
+			"t.deleted_at": nil,
 		}).
 		OrderBy("j.id ASC").
 		RunWith(j.conn).
@@ -62,8 +63,9 @@ func (j *jobFactory) otherTeamPublicJobs(teamNames []string) (Jobs, error) {
 			"t.name": teamNames,
 		}).
 		Where(sq.Eq{
-			"p.public": true,
-			"j.active": true,
+			"p.public":     true,
+			"j.active":     true,
+			"t.deleted_at": nil,
 		}).
 		OrderBy("j.id ASC").
 		RunWith(j.conn).
@@ -78,7 +80,8 @@ func (j *jobFactory) otherTeamPublicJobs(teamNames []string) (Jobs, error) {
 func (j *jobFactory) AllActiveJobs() (Dashboard, error) {
 	rows, err := jobsQuery.
 		Where(sq.Eq{
-			"j.active": true,
+			"j.active":     true,
+			"t.deleted_at": nil,
 		}).
 		OrderBy("j.id ASC").
 		RunWith(j.conn).
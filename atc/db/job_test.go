@@ -122,6 +122,148 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("BuildStatistics", func() {
+		var now time.Time
+
+		finishBuild := func(status db.BuildStatus, duration time.Duration, ago time.Duration) {
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(build.Finish(status)).To(Succeed())
+
+			end := now.Add(-ago)
+			_, err = dbConn.Exec(`UPDATE builds SET start_time = $1, end_time = $2 WHERE id = $3`, end.Add(-duration), end, build.ID())
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+
+			finishBuild(db.BuildStatusSucceeded, 10*time.Second, time.Hour)
+			finishBuild(db.BuildStatusSucceeded, 20*time.Second, 2*time.Hour)
+			finishBuild(db.BuildStatusFailed, 5*time.Second, 3*time.Hour)
+			finishBuild(db.BuildStatusAborted, time.Second, 4*time.Hour)
+			finishBuild(db.BuildStatusErrored, time.Second, 48*time.Hour)
+
+			_, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("summarizes the builds which finished in the window", func() {
+			stats, err := job.BuildStatistics(now.Add(-24*time.Hour), now)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stats.Builds).To(Equal(4))
+			Expect(stats.Succeeded).To(Equal(2))
+			Expect(stats.Failed).To(Equal(1))
+			Expect(stats.Errored).To(Equal(0))
+			Expect(stats.Aborted).To(Equal(1))
+			Expect(stats.SuccessRate).To(BeNumerically("~", 2.0/3.0))
+			Expect(stats.MeanDuration).To(BeNumerically("~", 15))
+			Expect(stats.MedianDuration).To(BeNumerically("~", 15))
+			Expect(stats.P95Duration).To(BeNumerically("~", 19.5))
+		})
+
+		It("leaves out builds which finished outside of the window", func() {
+			stats, err := job.BuildStatistics(now.Add(-72*time.Hour), now.Add(-24*time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stats).To(Equal(atc.BuildStatistics{
+				Builds:  1,
+				Errored: 1,
+			}))
+		})
+
+		Context("when no builds finished in the window", func() {
+			It("returns empty statistics", func() {
+				stats, err := job.BuildStatistics(now.Add(-time.Minute), now)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stats).To(Equal(atc.BuildStatistics{}))
+			})
+		})
+	})
+
+	Describe("InputResolutionCached", func() {
+		reload := func() {
+			found, err := job.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		}
+
+		It("starts out not cached", func() {
+			Expect(job.InputResolutionCached()).To(BeFalse())
+		})
+
+		Context("when the input resolution is cached", func() {
+			BeforeEach(func() {
+				Expect(job.CacheInputResolution()).To(Succeed())
+				reload()
+			})
+
+			It("is cached", func() {
+				Expect(job.InputResolutionCached()).To(BeTrue())
+			})
+
+			It("is invalidated by the pipeline's versions changing", func() {
+				_, err := dbConn.Exec(`UPDATE pipelines SET cache_index = cache_index + 1 WHERE id = $1`, pipeline.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				reload()
+				Expect(job.InputResolutionCached()).To(BeFalse())
+			})
+
+			It("is invalidated by a resource of the pipeline being pinned", func() {
+				resource, found, err := pipeline.Resource("some-other-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = dbConn.Exec(`INSERT INTO resource_pins (resource_id, version, comment_text) VALUES ($1, '{"some":"version"}', '')`, resource.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				reload()
+				Expect(job.InputResolutionCached()).To(BeFalse())
+			})
+
+			It("is invalidated by the job's config changing", func() {
+				config, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+
+				config.Jobs[0].Plan = config.Jobs[0].Plan[1:]
+
+				_, _, err = team.SavePipeline("fake-pipeline", config, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				reload()
+				Expect(job.InputResolutionCached()).To(BeFalse())
+			})
+
+			It("is not invalidated by other jobs being cached", func() {
+				otherJob, found, err := pipeline.Job("some-other-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(otherJob.CacheInputResolution()).To(Succeed())
+
+				reload()
+				Expect(job.InputResolutionCached()).To(BeTrue())
+			})
+		})
+
+		Context("when the input resolution is invalidated after the job was loaded", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE pipelines SET cache_index = cache_index + 1 WHERE id = $1`, pipeline.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(job.CacheInputResolution()).To(Succeed())
+				reload()
+			})
+
+			It("isn't cached", func() {
+				Expect(job.InputResolutionCached()).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Pause and Unpause", func() {
 		It("starts out as unpaused", func() {
 			Expect(job.Paused()).To(BeFalse())
@@ -1275,6 +1417,142 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateBuildWithOverrides", func() {
+		It("creates a manually triggered build which records the overrides", func() {
+			build, err := job.CreateBuildWithOverrides(atc.InputVersionOverrides{
+				"some-input": atc.Version{"ref": "v1"},
+			}, atc.TaskImageOverrides{
+				"some-task": {Version: atc.Version{"digest": "sha256:fixed"}},
+			}, atc.InputArtifacts{
+				"other-input": 17,
+			}, atc.StepWorkerOverrides{
+				"some-task": "some-worker",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.InputOverrides()).To(Equal(atc.InputVersionOverrides{
+				"some-input": atc.Version{"ref": "v1"},
+			}))
+
+			reloaded, found, err := job.Build(build.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ImageOverrides()).To(Equal(atc.TaskImageOverrides{
+				"some-task": {Version: atc.Version{"digest": "sha256:fixed"}},
+			}))
+			Expect(build.InputArtifacts()).To(Equal(atc.InputArtifacts{
+				"other-input": 17,
+			}))
+			Expect(build.WorkerOverrides()).To(Equal(atc.StepWorkerOverrides{
+				"some-task": "some-worker",
+			}))
+
+			Expect(reloaded.InputOverrides()).To(Equal(build.InputOverrides()))
+			Expect(reloaded.ImageOverrides()).To(Equal(build.ImageOverrides()))
+			Expect(reloaded.InputArtifacts()).To(Equal(build.InputArtifacts()))
+			Expect(reloaded.WorkerOverrides()).To(Equal(build.WorkerOverrides()))
+		})
+
+		It("records no overrides for builds created without them", func() {
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.InputOverrides()).To(BeNil())
+			Expect(build.ImageOverrides()).To(BeNil())
+			Expect(build.InputArtifacts()).To(BeNil())
+			Expect(build.WorkerOverrides()).To(BeNil())
+		})
+	})
+
+	Describe("RerunBuild", func() {
+		var (
+			original db.Build
+			plan     atc.Plan
+		)
+
+		BeforeEach(func() {
+			var err error
+			original, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			plan = atc.Plan{
+				ID:   "1",
+				Task: &atc.TaskPlan{Name: "some-task"},
+			}
+
+			started, err := original.Start(plan)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			Expect(original.Finish(db.BuildStatusFailed)).To(Succeed())
+
+			found, err := original.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("keeps the plan of the finished build", func() {
+			Expect(original.PrivatePlan()).To(Equal(plan))
+		})
+
+		It("creates a pending build of the job linked to the original, keeping the plan until it's started", func() {
+			rerun, err := job.RerunBuild(original, original.PrivatePlan())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rerun.JobID()).To(Equal(job.ID()))
+			Expect(rerun.Status()).To(Equal(db.BuildStatusPending))
+			Expect(rerun.IsScheduled()).To(BeFalse())
+			Expect(rerun.RerunOf()).To(Equal(original.ID()))
+			Expect(rerun.PrivatePlan()).To(Equal(plan))
+			Expect(rerun.Name()).ToNot(Equal(original.Name()))
+
+			reloaded, found, err := job.Build(rerun.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.RerunOf()).To(Equal(original.ID()))
+		})
+
+		It("makes the build the job's next build", func() {
+			rerun, err := job.RerunBuild(original, plan)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, next, err := job.FinishedAndNextBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next.ID()).To(Equal(rerun.ID()))
+		})
+	})
+
+	Describe("AutoRetryBuild", func() {
+		var original db.Build
+
+		BeforeEach(func() {
+			var err error
+			original, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(original.Finish(db.BuildStatusErrored)).To(Succeed())
+		})
+
+		It("creates a re-run of the build which isn't manually triggered", func() {
+			retry, err := job.AutoRetryBuild(original, atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retry.Status()).To(Equal(db.BuildStatusPending))
+			Expect(retry.RerunOf()).To(Equal(original.ID()))
+			Expect(retry.IsManuallyTriggered()).To(BeFalse())
+			Expect(retry.AutoRetryAttempt()).To(Equal(1))
+		})
+
+		It("counts the retries of retries", func() {
+			retry, err := job.AutoRetryBuild(original, atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(retry.Finish(db.BuildStatusErrored)).To(Succeed())
+
+			retry, err = job.AutoRetryBuild(retry, atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retry.AutoRetryAttempt()).To(Equal(2))
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			BeforeEach(func() {
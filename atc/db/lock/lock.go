@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"hash/crc32"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 )
@@ -24,6 +26,18 @@ const (
 	LockTypeResourceScanning
 )
 
+var LockTypeNames = map[int]string{
+	LockTypeResourceConfigChecking: "ResourceConfigChecking",
+	LockTypeBuildTracking:          "BuildTracking",
+	LockTypePipelineScheduling:     "PipelineScheduling",
+	LockTypeBatch:                  "Batch",
+	LockTypeVolumeCreating:         "VolumeCreating",
+	LockTypeContainerCreating:      "ContainerCreating",
+	LockTypeDatabaseMigration:      "DatabaseMigration",
+	LockTypeActiveTasks:            "ActiveTasks",
+	LockTypeResourceScanning:       "ResourceScanning",
+}
+
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
 
 func NewBuildTrackingLockID(buildID int) LockID {
@@ -62,6 +76,15 @@ func NewResourceScanningLockID() LockID {
 
 type LockFactory interface {
 	Acquire(logger lager.Logger, ids LockID) (Lock, bool, error)
+
+	// Held returns the locks currently held by this factory.
+	Held() []HeldLock
+}
+
+// HeldLock is a lock held by this process, and when it was acquired.
+type HeldLock struct {
+	ID         LockID
+	AcquiredAt time.Time
 }
 
 type lockFactory struct {
@@ -71,40 +94,42 @@ type lockFactory struct {
 
 	acquireFunc LogFunc
 	releaseFunc LogFunc
+	waitFunc    WaitFunc
 }
 
 type LogFunc func(logger lager.Logger, id LockID)
 
+// WaitFunc is called when a lock is acquired after previous attempts to
+// acquire it failed, with the time since the first failed attempt.
+type WaitFunc func(logger lager.Logger, id LockID, wait time.Duration)
+
 func NewLockFactory(
 	conn *sql.DB,
 	acquire LogFunc,
 	release LogFunc,
+	wait WaitFunc,
 ) LockFactory {
 	return &lockFactory{
 		db: &lockDB{
 			conn:  conn,
 			mutex: &sync.Mutex{},
 		},
-		acquireFunc: acquire,
-		releaseFunc: release,
-		locks: lockRepo{
-			locks: map[string]bool{},
-			mutex: &sync.Mutex{},
-		},
+		acquireFunc:  acquire,
+		releaseFunc:  release,
+		waitFunc:     wait,
+		locks:        newLockRepo(),
 		acquireMutex: &sync.Mutex{},
 	}
 }
 
 func NewTestLockFactory(db LockDB) LockFactory {
 	return &lockFactory{
-		db: db,
-		locks: lockRepo{
-			locks: map[string]bool{},
-			mutex: &sync.Mutex{},
-		},
+		db:           db,
+		locks:        newLockRepo(),
 		acquireMutex: &sync.Mutex{},
 		acquireFunc:  func(logger lager.Logger, id LockID) {},
 		releaseFunc:  func(logger lager.Logger, id LockID) {},
+		waitFunc:     func(logger lager.Logger, id LockID, wait time.Duration) {},
 	}
 }
 
@@ -117,6 +142,7 @@ func (f *lockFactory) Acquire(logger lager.Logger, id LockID) (Lock, bool, error
 		acquireMutex: f.acquireMutex,
 		acquired:     f.acquireFunc,
 		released:     f.releaseFunc,
+		waited:       f.waitFunc,
 	}
 
 	acquired, err := l.Acquire()
@@ -131,6 +157,10 @@ func (f *lockFactory) Acquire(logger lager.Logger, id LockID) (Lock, bool, error
 	return l, true, nil
 }
 
+func (f *lockFactory) Held() []HeldLock {
+	return f.locks.Held()
+}
+
 //go:generate counterfeiter . Lock
 
 type Lock interface {
@@ -154,6 +184,7 @@ type lock struct {
 
 	acquired LogFunc
 	released LogFunc
+	waited   WaitFunc
 }
 
 func (l *lock) Acquire() (bool, error) {
@@ -175,13 +206,18 @@ func (l *lock) Acquire() (bool, error) {
 
 	if !acquired {
 		logger.Debug("not-acquired-already-held-in-db")
+		l.locks.Contended(l.id)
 		return false, nil
 	}
 
-	l.locks.Register(l.id)
+	wait, contended := l.locks.Register(l.id)
 
 	l.acquired(logger, l.id)
 
+	if contended {
+		l.waited(logger, l.id, wait)
+	}
+
 	return true, nil
 }
 
@@ -236,9 +272,29 @@ func (db *lockDB) Release(id LockID) (bool, error) {
 	return released, nil
 }
 
+// maxContended is the number of contended locks to track before forgetting
+// those which have not been acquired for staleContention.
+const (
+	maxContended    = 1000
+	staleContention = 10 * time.Minute
+)
+
 type lockRepo struct {
-	locks map[string]bool
+	locks map[string]HeldLock
 	mutex *sync.Mutex
+
+	// contended tracks when each lock that could not be acquired was first
+	// attempted, so that the time spent waiting for it can be reported once
+	// it is acquired.
+	contended map[string]time.Time
+}
+
+func newLockRepo() lockRepo {
+	return lockRepo{
+		locks:     map[string]HeldLock{},
+		mutex:     &sync.Mutex{},
+		contended: map[string]time.Time{},
+	}
 }
 
 func (lr lockRepo) IsRegistered(id LockID) bool {
@@ -251,11 +307,63 @@ func (lr lockRepo) IsRegistered(id LockID) bool {
 	return false
 }
 
-func (lr lockRepo) Register(id LockID) {
+// Register records the lock as held, returning how long it was waited for if
+// earlier attempts to acquire it failed.
+func (lr lockRepo) Register(id LockID) (time.Duration, bool) {
+	lr.mutex.Lock()
+	defer lr.mutex.Unlock()
+
+	now := time.Now()
+	lr.locks[id.toKey()] = HeldLock{
+		ID:         id,
+		AcquiredAt: now,
+	}
+
+	since, contended := lr.contended[id.toKey()]
+	if !contended {
+		return 0, false
+	}
+
+	delete(lr.contended, id.toKey())
+
+	return now.Sub(since), true
+}
+
+func (lr lockRepo) Contended(id LockID) {
+	lr.mutex.Lock()
+	defer lr.mutex.Unlock()
+
+	if _, found := lr.contended[id.toKey()]; found {
+		return
+	}
+
+	now := time.Now()
+
+	if len(lr.contended) >= maxContended {
+		for key, since := range lr.contended {
+			if now.Sub(since) > staleContention {
+				delete(lr.contended, key)
+			}
+		}
+	}
+
+	lr.contended[id.toKey()] = now
+}
+
+func (lr lockRepo) Held() []HeldLock {
 	lr.mutex.Lock()
 	defer lr.mutex.Unlock()
 
-	lr.locks[id.toKey()] = true
+	held := []HeldLock{}
+	for _, lock := range lr.locks {
+		held = append(held, lock)
+	}
+
+	sort.Slice(held, func(i, j int) bool {
+		return held[i].AcquiredAt.Before(held[j].AcquiredAt)
+	})
+
+	return held
 }
 
 func (lr lockRepo) Unregister(id LockID) {
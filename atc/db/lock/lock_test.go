@@ -30,8 +30,9 @@ var _ = Describe("Locks", func() {
 		team        db.Team
 		teamFactory db.TeamFactory
 
-		logger      *lagertest.TestLogger
-		fakeLogFunc = func(logger lager.Logger, id lock.LockID) {}
+		logger       *lagertest.TestLogger
+		fakeLogFunc  = func(logger lager.Logger, id lock.LockID) {}
+		fakeWaitFunc = func(logger lager.Logger, id lock.LockID, wait time.Duration) {}
 	)
 
 	BeforeEach(func() {
@@ -42,7 +43,7 @@ var _ = Describe("Locks", func() {
 
 		logger = lagertest.NewTestLogger("test")
 
-		lockFactory = lock.NewLockFactory(postgresRunner.OpenSingleton(), fakeLogFunc, fakeLogFunc)
+		lockFactory = lock.NewLockFactory(postgresRunner.OpenSingleton(), fakeLogFunc, fakeLogFunc, fakeWaitFunc)
 
 		dbConn = postgresRunner.OpenConn()
 		teamFactory = db.NewTeamFactory(dbConn, lockFactory)
@@ -148,7 +149,7 @@ var _ = Describe("Locks", func() {
 			var lockFactory2 lock.LockFactory
 
 			BeforeEach(func() {
-				lockFactory2 = lock.NewLockFactory(postgresRunner.OpenSingleton(), fakeLogFunc, fakeLogFunc)
+				lockFactory2 = lock.NewLockFactory(postgresRunner.OpenSingleton(), fakeLogFunc, fakeLogFunc, fakeWaitFunc)
 			})
 
 			It("does not acquire the lock", func() {
@@ -187,6 +188,60 @@ var _ = Describe("Locks", func() {
 				err = dbLock2.Release()
 				Expect(err).NotTo(HaveOccurred())
 			})
+
+			It("reports how long the lock was waited for once it is acquired", func() {
+				var waited []time.Duration
+				lockFactory2 = lock.NewLockFactory(postgresRunner.OpenSingleton(), fakeLogFunc, fakeLogFunc, func(logger lager.Logger, id lock.LockID, wait time.Duration) {
+					waited = append(waited, wait)
+				})
+
+				var acquired bool
+				var err error
+				dbLock, acquired, err = lockFactory.Acquire(logger, lock.LockID{42})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				_, acquired, err = lockFactory2.Acquire(logger, lock.LockID{42})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				time.Sleep(10 * time.Millisecond)
+
+				err = dbLock.Release()
+				Expect(err).NotTo(HaveOccurred())
+
+				dbLock2, acquired, err := lockFactory2.Acquire(logger, lock.LockID{42})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				Expect(waited).To(HaveLen(1))
+				Expect(waited[0]).To(BeNumerically(">=", 10*time.Millisecond))
+
+				err = dbLock2.Release()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Describe("Held", func() {
+			It("returns the locks held and when they were acquired", func() {
+				before := time.Now()
+
+				var acquired bool
+				var err error
+				dbLock, acquired, err = lockFactory.Acquire(logger, lock.LockID{42, 56})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				held := lockFactory.Held()
+				Expect(held).To(HaveLen(1))
+				Expect(held[0].ID).To(Equal(lock.LockID{42, 56}))
+				Expect(held[0].AcquiredAt).To(BeTemporally(">=", before))
+
+				err = dbLock.Release()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(lockFactory.Held()).To(BeEmpty())
+			})
 		})
 
 		Context("when two locks are being acquired at the same time", func() {
@@ -25,6 +25,16 @@ type FakeLockFactory struct {
 		result2 bool
 		result3 error
 	}
+	HeldStub        func() []lock.HeldLock
+	heldMutex       sync.RWMutex
+	heldArgsForCall []struct {
+	}
+	heldReturns struct {
+		result1 []lock.HeldLock
+	}
+	heldReturnsOnCall map[int]struct {
+		result1 []lock.HeldLock
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -96,11 +106,65 @@ func (fake *FakeLockFactory) AcquireReturnsOnCall(i int, result1 lock.Lock, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeLockFactory) Held() []lock.HeldLock {
+	fake.heldMutex.Lock()
+	ret, specificReturn := fake.heldReturnsOnCall[len(fake.heldArgsForCall)]
+	fake.heldArgsForCall = append(fake.heldArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Held", []interface{}{})
+	fake.heldMutex.Unlock()
+	if fake.HeldStub != nil {
+		return fake.HeldStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.heldReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLockFactory) HeldCallCount() int {
+	fake.heldMutex.RLock()
+	defer fake.heldMutex.RUnlock()
+	return len(fake.heldArgsForCall)
+}
+
+func (fake *FakeLockFactory) HeldCalls(stub func() []lock.HeldLock) {
+	fake.heldMutex.Lock()
+	defer fake.heldMutex.Unlock()
+	fake.HeldStub = stub
+}
+
+func (fake *FakeLockFactory) HeldReturns(result1 []lock.HeldLock) {
+	fake.heldMutex.Lock()
+	defer fake.heldMutex.Unlock()
+	fake.HeldStub = nil
+	fake.heldReturns = struct {
+		result1 []lock.HeldLock
+	}{result1}
+}
+
+func (fake *FakeLockFactory) HeldReturnsOnCall(i int, result1 []lock.HeldLock) {
+	fake.heldMutex.Lock()
+	defer fake.heldMutex.Unlock()
+	fake.HeldStub = nil
+	if fake.heldReturnsOnCall == nil {
+		fake.heldReturnsOnCall = make(map[int]struct {
+			result1 []lock.HeldLock
+		})
+	}
+	fake.heldReturnsOnCall[i] = struct {
+		result1 []lock.HeldLock
+	}{result1}
+}
+
 func (fake *FakeLockFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.acquireMutex.RLock()
 	defer fake.acquireMutex.RUnlock()
+	fake.heldMutex.RLock()
+	defer fake.heldMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
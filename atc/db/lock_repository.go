@@ -0,0 +1,100 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
+)
+
+//go:generate counterfeiter . LockRepository
+
+// LockRepository reports on the advisory locks held and awaited in the
+// database, by every ATC.
+type LockRepository interface {
+	AdvisoryLocks() ([]AdvisoryLock, error)
+}
+
+// AdvisoryLock is a lock held or awaited by a database session.
+type AdvisoryLock struct {
+	ID      lock.LockID
+	Granted bool
+
+	PID             int
+	ApplicationName string
+	ClientAddr      string
+	BackendStart    time.Time
+}
+
+type lockRepository struct {
+	conn Conn
+}
+
+func NewLockRepository(conn Conn) LockRepository {
+	return &lockRepository{
+		conn: conn,
+	}
+}
+
+func (repository *lockRepository) AdvisoryLocks() ([]AdvisoryLock, error) {
+	rows, err := repository.conn.Query(`
+		SELECT l.classid, l.objid, l.objsubid, l.granted, l.pid,
+			COALESCE(a.application_name, ''), COALESCE(host(a.client_addr), ''), a.backend_start
+		FROM pg_locks l
+		LEFT JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype = 'advisory'
+		AND l.database = (SELECT oid FROM pg_database WHERE datname = current_database())
+		ORDER BY l.classid, l.objid, l.granted DESC, l.pid
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	locks := []AdvisoryLock{}
+	for rows.Next() {
+		var (
+			classID, objID int64
+			objSubID       int
+			backendStart   pq.NullTime
+			advisoryLock   AdvisoryLock
+		)
+
+		err := rows.Scan(
+			&classID,
+			&objID,
+			&objSubID,
+			&advisoryLock.Granted,
+			&advisoryLock.PID,
+			&advisoryLock.ApplicationName,
+			&advisoryLock.ClientAddr,
+			&backendStart,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		advisoryLock.ID = advisoryLockID(classID, objID, objSubID)
+
+		if backendStart.Valid {
+			advisoryLock.BackendStart = backendStart.Time
+		}
+
+		locks = append(locks, advisoryLock)
+	}
+
+	return locks, nil
+}
+
+// advisoryLockID reverses the mapping of a lock.LockID onto the columns of
+// pg_locks. Locks taken with two int keys have objsubid 2, with the keys in
+// classid and objid. Locks taken with one bigint key have objsubid 1, with
+// the key split across them.
+func advisoryLockID(classID int64, objID int64, objSubID int) lock.LockID {
+	if objSubID == 2 {
+		return lock.LockID{int(int32(uint32(classID))), int(int32(uint32(objID)))}
+	}
+
+	return lock.LockID{int(classID<<32 | objID)}
+}
@@ -0,0 +1,56 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LockRepository", func() {
+	var repository db.LockRepository
+
+	BeforeEach(func() {
+		repository = db.NewLockRepository(dbConn)
+	})
+
+	Describe("AdvisoryLocks", func() {
+		var held []lock.Lock
+
+		AfterEach(func() {
+			for _, l := range held {
+				Expect(l.Release()).To(Succeed())
+			}
+
+			held = nil
+		})
+
+		acquire := func(id lock.LockID) {
+			l, acquired, err := lockFactory.Acquire(logger, id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			held = append(held, l)
+		}
+
+		It("returns the locks held, with the ids they were acquired with", func() {
+			acquire(lock.NewPipelineSchedulingLockLockID(42))
+			acquire(lock.NewTaskLockID("some-task"))
+			acquire(lock.NewResourceScanningLockID())
+
+			locks, err := repository.AdvisoryLocks()
+			Expect(err).NotTo(HaveOccurred())
+
+			ids := []lock.LockID{}
+			for _, l := range locks {
+				Expect(l.Granted).To(BeTrue())
+				Expect(l.PID).NotTo(BeZero())
+				ids = append(ids, l.ID)
+			}
+
+			Expect(ids).To(ContainElement(lock.NewPipelineSchedulingLockLockID(42)))
+			Expect(ids).To(ContainElement(lock.NewTaskLockID("some-task")))
+			Expect(ids).To(ContainElement(lock.NewResourceScanningLockID()))
+		})
+	})
+})
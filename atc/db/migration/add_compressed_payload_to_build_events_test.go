@@ -0,0 +1,59 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/DataDog/zstd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add compressed payload to build events", func() {
+	const preMigrationVersion = 1574432400
+	const postMigrationVersion = 1574518800
+
+	var (
+		db *sql.DB
+	)
+
+	Context("Down", func() {
+		It("decompresses compressed payloads back into the payload column", func() {
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+			SetupTeam(db, "some-team", "{}")
+			SetupBuild(db, "some-build")
+
+			compressed, err := zstd.Compress(nil, []byte(`{"some":"log"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO build_events (build_id, type, event_id, version, compressed_payload)
+				SELECT id, 'log', 0, '5.1', $1 FROM builds WHERE name = 'some-build'
+			`, compressed)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO build_events (build_id, type, event_id, version, payload)
+				SELECT id, 'log', 1, '5.1', '{"other":"log"}' FROM builds WHERE name = 'some-build'
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			rows, err := db.Query(`SELECT payload FROM build_events ORDER BY event_id`)
+			Expect(err).NotTo(HaveOccurred())
+
+			var payloads []string
+			for rows.Next() {
+				var payload string
+				Expect(rows.Scan(&payload)).To(Succeed())
+				payloads = append(payloads, payload)
+			}
+
+			db.Close()
+
+			Expect(payloads).To(Equal([]string{`{"some":"log"}`, `{"other":"log"}`}))
+		})
+	})
+})
@@ -100,6 +100,19 @@ func (self *OpenHelper) MigrateToVersion(version int) error {
 	return m.Migrate(version)
 }
 
+// PendingMigrations returns the migrations which would be run to migrate to
+// the given version, without running them.
+func (self *OpenHelper) PendingMigrations(version int) ([]PendingMigration, error) {
+	db, err := sql.Open(self.driver, self.dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	return NewMigrator(db, self.lockFactory, self.strategy).Pending(version)
+}
+
 func (self *OpenHelper) migrateFromMigrationVersion(db *sql.DB) error {
 
 	legacySchemaExists, err := checkTableExist(db, "migration_version")
@@ -145,6 +158,7 @@ type Migrator interface {
 	CurrentVersion() (int, error)
 	SupportedVersion() (int, error)
 	Migrate(version int) error
+	Pending(version int) ([]PendingMigration, error)
 	Up() error
 	Migrations() ([]migration, error)
 }
@@ -261,26 +275,14 @@ func (self *migrator) Migrate(toVersion int) error {
 		return err
 	}
 
-	if currentVersion <= toVersion {
-		for _, m := range migrations {
-			if currentVersion < m.Version && m.Version <= toVersion && m.Direction == "up" {
-				err = self.runMigration(m)
-				if err != nil {
-					return err
-				}
-			}
-		}
-	} else {
-		for i := len(migrations) - 1; i >= 0; i-- {
-			if currentVersion >= migrations[i].Version && migrations[i].Version > toVersion && migrations[i].Direction == "down" {
-				err = self.runMigration(migrations[i])
-				if err != nil {
-					return err
-				}
-
-			}
+	for _, m := range migrationsToRun(migrations, currentVersion, toVersion) {
+		err = self.runMigration(m)
+		if err != nil {
+			return err
 		}
+	}
 
+	if currentVersion > toVersion {
 		err = self.migrateToSchemaMigrations(toVersion)
 		if err != nil {
 			return err
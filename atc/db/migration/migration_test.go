@@ -26,13 +26,14 @@ const upgradedSchemaVersion = 1510670987
 
 var _ = Describe("Migration", func() {
 	var (
-		err         error
-		db          *sql.DB
-		lockDB      *sql.DB
-		lockFactory lock.LockFactory
-		strategy    encryption.Strategy
-		bindata     *migrationfakes.FakeBindata
-		fakeLogFunc = func(logger lager.Logger, id lock.LockID) {}
+		err          error
+		db           *sql.DB
+		lockDB       *sql.DB
+		lockFactory  lock.LockFactory
+		strategy     encryption.Strategy
+		bindata      *migrationfakes.FakeBindata
+		fakeLogFunc  = func(logger lager.Logger, id lock.LockID) {}
+		fakeWaitFunc = func(logger lager.Logger, id lock.LockID, wait time.Duration) {}
 	)
 
 	BeforeEach(func() {
@@ -42,7 +43,7 @@ var _ = Describe("Migration", func() {
 		lockDB, err = sql.Open("postgres", postgresRunner.DataSourceName())
 		Expect(err).NotTo(HaveOccurred())
 
-		lockFactory = lock.NewLockFactory(lockDB, fakeLogFunc, fakeLogFunc)
+		lockFactory = lock.NewLockFactory(lockDB, fakeLogFunc, fakeLogFunc, fakeWaitFunc)
 
 		strategy = encryption.NewNoEncryption()
 		bindata = new(migrationfakes.FakeBindata)
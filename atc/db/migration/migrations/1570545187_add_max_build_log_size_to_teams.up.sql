@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN max_build_log_size bigint NOT NULL DEFAULT 0 CHECK (max_build_log_size >= 0);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_config_versions DROP COLUMN deleted_at;
+COMMIT;
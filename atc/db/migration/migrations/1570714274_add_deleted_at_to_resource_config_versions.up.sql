@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_config_versions ADD COLUMN deleted_at timestamp with time zone;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines DROP COLUMN resource_defaults;
+  ALTER TABLE teams DROP COLUMN resource_defaults;
+COMMIT;
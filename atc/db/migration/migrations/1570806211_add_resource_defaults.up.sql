@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN resource_defaults json;
+  ALTER TABLE teams ADD COLUMN resource_defaults json;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE teams DROP COLUMN container_env;
+  ALTER TABLE pipelines DROP COLUMN ignore_team_container_env;
+COMMIT;
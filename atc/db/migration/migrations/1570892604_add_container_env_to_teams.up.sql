@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN container_env json;
+  ALTER TABLE pipelines ADD COLUMN ignore_team_container_env boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE teams DROP COLUMN container_dns;
+  ALTER TABLE pipelines DROP COLUMN container_dns;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN container_dns json;
+  ALTER TABLE pipelines ADD COLUMN container_dns json;
+COMMIT;
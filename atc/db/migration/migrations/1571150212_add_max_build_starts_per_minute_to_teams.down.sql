@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE teams DROP COLUMN max_build_starts_per_minute;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN max_build_starts_per_minute integer NOT NULL DEFAULT 0 CHECK (max_build_starts_per_minute >= 0);
+COMMIT;
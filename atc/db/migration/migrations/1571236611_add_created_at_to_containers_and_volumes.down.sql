@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE containers DROP COLUMN created_at;
+  ALTER TABLE volumes DROP COLUMN created_at;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE containers ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
+  ALTER TABLE volumes ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN token text;
+  ALTER TABLE builds ADD COLUMN annotations json;
+COMMIT;
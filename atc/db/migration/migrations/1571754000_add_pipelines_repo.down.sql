@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines DROP COLUMN reconciled_config_version, DROP COLUMN reconciled_digest;
+  ALTER TABLE teams DROP COLUMN pipelines_repo_status, DROP COLUMN pipelines_repo;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN pipelines_repo json, ADD COLUMN pipelines_repo_status json;
+  ALTER TABLE pipelines ADD COLUMN reconciled_digest text, ADD COLUMN reconciled_config_version integer;
+COMMIT;
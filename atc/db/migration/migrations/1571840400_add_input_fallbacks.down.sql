@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN input_fallbacks;
+  ALTER TABLE independent_build_inputs DROP COLUMN fallback_from_version_id;
+  ALTER TABLE next_build_inputs DROP COLUMN fallback_from_version_id;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE next_build_inputs ADD COLUMN fallback_from_version_id integer REFERENCES resource_config_versions (id) ON DELETE SET NULL;
+  ALTER TABLE independent_build_inputs ADD COLUMN fallback_from_version_id integer REFERENCES resource_config_versions (id) ON DELETE SET NULL;
+  ALTER TABLE builds ADD COLUMN input_fallbacks json;
+COMMIT;
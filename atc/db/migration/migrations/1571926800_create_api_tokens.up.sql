@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE api_tokens (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    scopes json NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_by text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    revoked_at timestamp with time zone
+  );
+
+  CREATE UNIQUE INDEX api_tokens_team_id_name_uniq ON api_tokens (team_id, name) WHERE revoked_at IS NULL;
+COMMIT;
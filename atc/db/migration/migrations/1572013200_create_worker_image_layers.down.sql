@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE volumes
+    DROP COLUMN worker_image_layer_id;
+
+  DROP TABLE IF EXISTS worker_image_layers;
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+  CREATE TABLE worker_image_layers (
+    id serial PRIMARY KEY,
+    worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+    digest text NOT NULL,
+    last_used timestamp with time zone DEFAULT now() NOT NULL
+  );
+
+  CREATE UNIQUE INDEX worker_image_layers_uniq ON worker_image_layers (worker_name, digest);
+
+  ALTER TABLE volumes
+    ADD COLUMN worker_image_layer_id integer REFERENCES worker_image_layers (id) ON DELETE SET NULL;
+
+  CREATE UNIQUE INDEX volumes_worker_image_layer_unique ON volumes (worker_image_layer_id);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP INDEX pipelines_parent_id_idx;
+  ALTER TABLE pipelines DROP COLUMN branch, DROP COLUMN parent_id, DROP COLUMN branches;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN branches json, ADD COLUMN parent_id integer, ADD COLUMN branch text;
+  CREATE INDEX pipelines_parent_id_idx ON pipelines (parent_id);
+COMMIT;
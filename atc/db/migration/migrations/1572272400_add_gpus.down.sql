@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE containers DROP COLUMN gpus;
+  ALTER TABLE workers DROP COLUMN gpus;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN gpus integer NOT NULL DEFAULT 0;
+  ALTER TABLE containers ADD COLUMN gpus integer[];
+COMMIT;
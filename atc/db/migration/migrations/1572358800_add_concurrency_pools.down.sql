@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN concurrency_pools;
+  ALTER TABLE teams DROP COLUMN concurrency_pools;
+COMMIT;
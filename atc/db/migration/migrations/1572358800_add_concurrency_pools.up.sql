@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN concurrency_pools json;
+  ALTER TABLE builds ADD COLUMN concurrency_pools text[];
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+  CREATE TABLE backfills (
+    name text PRIMARY KEY,
+    target_id bigint NOT NULL,
+    last_id bigint NOT NULL DEFAULT 0,
+    error text,
+    started_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    finished_at timestamp with time zone
+  );
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE volume_leases;
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+  CREATE TABLE volume_leases (
+    id serial PRIMARY KEY,
+    volume_id integer NOT NULL REFERENCES volumes (id) ON DELETE CASCADE,
+    expires_at timestamp with time zone NOT NULL
+  );
+
+  CREATE INDEX volume_leases_volume_id_idx ON volume_leases (volume_id);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN paused boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds
+    DROP COLUMN rerun_of,
+    DROP COLUMN checkpoint;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds
+    ADD COLUMN rerun_of integer REFERENCES builds (id) ON DELETE SET NULL,
+    ADD COLUMN checkpoint text;
+COMMIT;
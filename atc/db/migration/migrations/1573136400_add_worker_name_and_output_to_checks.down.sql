@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE checks DROP COLUMN worker_name, DROP COLUMN output;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE checks ADD COLUMN worker_name text, ADD COLUMN output text;
+COMMIT;
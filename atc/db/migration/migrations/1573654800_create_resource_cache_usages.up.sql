@@ -0,0 +1,11 @@
+BEGIN;
+  CREATE TABLE resource_cache_usages (
+    resource_cache_id integer NOT NULL REFERENCES resource_caches (id) ON DELETE CASCADE,
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    worker_tags text[] DEFAULT '{}' NOT NULL,
+    uses integer DEFAULT 0 NOT NULL,
+    last_used timestamp with time zone DEFAULT now() NOT NULL
+  );
+
+  CREATE UNIQUE INDEX resource_cache_usages_uniq ON resource_cache_usages (resource_cache_id, COALESCE(team_id, 0), worker_tags);
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE worker_discrepancies (
+    worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+    type text NOT NULL,
+    handle text NOT NULL,
+    problem text NOT NULL,
+    build_id integer,
+    since timestamp with time zone DEFAULT now() NOT NULL,
+    heartbeats integer DEFAULT 1 NOT NULL
+  );
+
+  CREATE UNIQUE INDEX worker_discrepancies_uniq ON worker_discrepancies (worker_name, type, handle);
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE resource_fetches (
+    id bigserial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    pipeline_id integer REFERENCES pipelines (id) ON DELETE CASCADE,
+    type text NOT NULL,
+    duration_ms bigint NOT NULL,
+    succeeded boolean NOT NULL,
+    create_time timestamp with time zone DEFAULT now() NOT NULL
+  );
+
+  CREATE INDEX resource_fetches_team_id_type_idx ON resource_fetches (team_id, type);
+  CREATE INDEX resource_fetches_create_time_idx ON resource_fetches (create_time);
+COMMIT;
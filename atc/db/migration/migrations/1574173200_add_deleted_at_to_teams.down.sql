@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams
+    DROP COLUMN deleted_at,
+    DROP COLUMN deletion_paused_pipelines;
+COMMIT;
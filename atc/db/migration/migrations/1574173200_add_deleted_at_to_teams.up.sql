@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams
+    ADD COLUMN deleted_at timestamp with time zone,
+    ADD COLUMN deletion_paused_pipelines json;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE builds
+    ADD COLUMN auto_retry_attempt integer NOT NULL DEFAULT 0;
+COMMIT;
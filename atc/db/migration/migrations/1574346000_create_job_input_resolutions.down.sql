@@ -0,0 +1,15 @@
+BEGIN;
+  DROP TRIGGER IF EXISTS job_input_resolution_resource_pin_trigger ON resource_pins;
+  DROP TRIGGER IF EXISTS job_input_resolution_resource_trigger ON resources;
+  DROP TRIGGER IF EXISTS job_input_resolution_cache_index_trigger ON pipelines;
+  DROP TRIGGER IF EXISTS job_input_resolution_config_trigger ON jobs;
+  DROP TRIGGER IF EXISTS job_input_resolution_insert_trigger ON jobs;
+
+  DROP FUNCTION IF EXISTS invalidate_resource_config_input_resolutions();
+  DROP FUNCTION IF EXISTS invalidate_resource_input_resolutions();
+  DROP FUNCTION IF EXISTS invalidate_pipeline_input_resolutions();
+  DROP FUNCTION IF EXISTS invalidate_job_input_resolution();
+  DROP FUNCTION IF EXISTS on_job_insert_create_input_resolution();
+
+  DROP TABLE IF EXISTS job_input_resolutions;
+COMMIT;
@@ -0,0 +1,82 @@
+BEGIN;
+  CREATE TABLE job_input_resolutions (
+    job_id integer PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE,
+    generation integer NOT NULL DEFAULT 0,
+    resolved_generation integer
+  );
+
+  INSERT INTO job_input_resolutions (job_id) SELECT id FROM jobs;
+
+  CREATE OR REPLACE FUNCTION on_job_insert_create_input_resolution() RETURNS TRIGGER AS $$
+  BEGIN
+          INSERT INTO job_input_resolutions (job_id) VALUES (NEW.id) ON CONFLICT DO NOTHING;
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE OR REPLACE FUNCTION invalidate_job_input_resolution() RETURNS TRIGGER AS $$
+  BEGIN
+          UPDATE job_input_resolutions SET generation = generation + 1 WHERE job_id = NEW.id;
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE OR REPLACE FUNCTION invalidate_pipeline_input_resolutions() RETURNS TRIGGER AS $$
+  BEGIN
+          UPDATE job_input_resolutions SET generation = generation + 1
+          WHERE job_id IN (SELECT id FROM jobs WHERE pipeline_id = NEW.id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE OR REPLACE FUNCTION invalidate_resource_input_resolutions() RETURNS TRIGGER AS $$
+  DECLARE
+          changed_resource_id integer;
+  BEGIN
+          IF TG_OP = 'DELETE' THEN
+                  changed_resource_id := OLD.resource_id;
+          ELSE
+                  changed_resource_id := NEW.resource_id;
+          END IF;
+
+          UPDATE job_input_resolutions SET generation = generation + 1
+          WHERE job_id IN (
+            SELECT j.id FROM jobs j
+            JOIN resources r ON r.pipeline_id = j.pipeline_id
+            WHERE r.id = changed_resource_id
+          );
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE OR REPLACE FUNCTION invalidate_resource_config_input_resolutions() RETURNS TRIGGER AS $$
+  BEGIN
+          UPDATE job_input_resolutions SET generation = generation + 1
+          WHERE job_id IN (SELECT id FROM jobs WHERE pipeline_id = NEW.pipeline_id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE TRIGGER job_input_resolution_insert_trigger AFTER INSERT ON jobs
+    FOR EACH ROW EXECUTE PROCEDURE on_job_insert_create_input_resolution();
+
+  -- the job's inputs or their constraints changed
+  CREATE TRIGGER job_input_resolution_config_trigger AFTER UPDATE OF config ON jobs
+    FOR EACH ROW WHEN (OLD.config IS DISTINCT FROM NEW.config)
+    EXECUTE PROCEDURE invalidate_job_input_resolution();
+
+  -- the pipeline's versions changed: new versions, new builds using or
+  -- producing them, or versions being disabled
+  CREATE TRIGGER job_input_resolution_cache_index_trigger AFTER UPDATE OF cache_index ON pipelines
+    FOR EACH ROW WHEN (OLD.cache_index IS DISTINCT FROM NEW.cache_index)
+    EXECUTE PROCEDURE invalidate_pipeline_input_resolutions();
+
+  -- a resource was reconfigured, e.g. to pin it to a version
+  CREATE TRIGGER job_input_resolution_resource_trigger AFTER UPDATE ON resources
+    FOR EACH ROW WHEN (OLD.config IS DISTINCT FROM NEW.config OR OLD.resource_config_id IS DISTINCT FROM NEW.resource_config_id)
+    EXECUTE PROCEDURE invalidate_resource_config_input_resolutions();
+
+  -- a resource was pinned or unpinned through the API
+  CREATE TRIGGER job_input_resolution_resource_pin_trigger AFTER INSERT OR UPDATE OR DELETE ON resource_pins
+    FOR EACH ROW EXECUTE PROCEDURE invalidate_resource_input_resolutions();
+COMMIT;
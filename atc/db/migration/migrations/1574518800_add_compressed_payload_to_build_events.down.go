@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"github.com/DataDog/zstd"
+	"github.com/lib/pq"
+)
+
+func (self *migrations) Down_1574518800() error {
+	type compressedEvent struct {
+		table      string
+		buildID    int
+		eventID    int
+		compressed []byte
+	}
+
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// payloads are decompressed back into the payload column in batches, so
+	// that the events of every build aren't held in memory at once
+	for {
+		rows, err := tx.Query(`
+			SELECT tableoid::regclass::text, build_id, event_id, compressed_payload
+			FROM build_events
+			WHERE compressed_payload IS NOT NULL
+			LIMIT 1000
+		`)
+		if err != nil {
+			return err
+		}
+
+		var events []compressedEvent
+		for rows.Next() {
+			var event compressedEvent
+			err = rows.Scan(&event.table, &event.buildID, &event.eventID, &event.compressed)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			events = append(events, event)
+		}
+
+		err = rows.Close()
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			payload, err := zstd.Decompress(nil, event.compressed)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(`
+				UPDATE `+pq.QuoteIdentifier(event.table)+`
+				SET payload = $3, compressed_payload = NULL
+				WHERE build_id = $1
+				AND event_id = $2
+			`, event.buildID, event.eventID, string(payload))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.Exec(`ALTER TABLE build_events ALTER COLUMN payload SET NOT NULL`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE build_events DROP COLUMN compressed_payload`)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
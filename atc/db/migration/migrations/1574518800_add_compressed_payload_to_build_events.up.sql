@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE build_events ADD COLUMN compressed_payload bytea;
+  ALTER TABLE build_events ALTER COLUMN payload DROP NOT NULL;
+COMMIT;
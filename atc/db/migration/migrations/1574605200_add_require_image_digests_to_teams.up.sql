@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN require_image_digests boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers DROP COLUMN capabilities;
+  ALTER TABLE workers DROP COLUMN protocol_version;
+COMMIT;
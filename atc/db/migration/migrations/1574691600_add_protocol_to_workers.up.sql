@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN protocol_version integer NOT NULL DEFAULT 1;
+  ALTER TABLE workers ADD COLUMN capabilities text;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines DROP COLUMN timezone, DROP COLUMN locale;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN timezone text, ADD COLUMN locale text;
+COMMIT;
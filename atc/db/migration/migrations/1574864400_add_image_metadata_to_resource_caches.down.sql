@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_caches DROP COLUMN image_metadata;
+COMMIT;
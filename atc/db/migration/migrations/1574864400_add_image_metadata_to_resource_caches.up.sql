@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_caches ADD COLUMN image_metadata json;
+COMMIT;
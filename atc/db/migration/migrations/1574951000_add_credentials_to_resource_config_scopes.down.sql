@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_config_scopes DROP COLUMN credentials;
+COMMIT;
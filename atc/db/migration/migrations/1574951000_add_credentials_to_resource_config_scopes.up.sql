@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_config_scopes ADD COLUMN credentials jsonb NOT NULL DEFAULT '{}';
+COMMIT;
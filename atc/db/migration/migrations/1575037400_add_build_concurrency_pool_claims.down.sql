@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE build_concurrency_pool_claims;
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+  CREATE TABLE build_concurrency_pool_claims (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    pool text NOT NULL,
+    UNIQUE (build_id, plan_id, pool)
+  );
+COMMIT;
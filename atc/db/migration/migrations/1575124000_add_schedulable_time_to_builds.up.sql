@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN schedulable_time timestamp with time zone;
+
+COMMIT;
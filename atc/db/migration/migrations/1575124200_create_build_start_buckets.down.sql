@@ -0,0 +1,5 @@
+BEGIN;
+
+  DROP TABLE build_start_buckets;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+  CREATE TABLE build_start_buckets (
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    level double precision NOT NULL DEFAULT 0,
+    last_leak timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  -- the global bucket has no team
+  CREATE UNIQUE INDEX build_start_buckets_team_id_key ON build_start_buckets ((COALESCE(team_id, 0)));
+
+COMMIT;
@@ -0,0 +1,202 @@
+package migration
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+)
+
+// PendingMigration is a migration which would be run to reach a version,
+// along with the locks its statements take.
+type PendingMigration struct {
+	Version   int
+	Name      string
+	Direction string
+
+	// Transactional migrations hold their locks until all of their
+	// statements have run.
+	Transactional bool
+
+	// Go migrations run arbitrary code, so their locks can't be worked out
+	// ahead of time.
+	Go bool
+
+	Locks []TableLock
+}
+
+// TableLock is a lock a statement takes on a table. EstimatedRows is
+// Postgres's estimate of the number of rows in the table, which gives an
+// idea of how long a rewrite of it would hold the lock for.
+type TableLock struct {
+	Table         string
+	Mode          string
+	EstimatedRows int64
+}
+
+const (
+	AccessExclusiveLock      = "ACCESS EXCLUSIVE"
+	ShareLock                = "SHARE"
+	ShareUpdateExclusiveLock = "SHARE UPDATE EXCLUSIVE"
+	RowExclusiveLock         = "ROW EXCLUSIVE"
+)
+
+const tableName = `(?:ONLY\s+)?((?:"[^"]+"|\w+)(?:\.(?:"[^"]+"|\w+))?)`
+
+var concurrently = regexp.MustCompile(`(?is)\bINDEX\s+CONCURRENTLY\b`)
+
+// statementLocks match statements by the lock they take, with the table
+// they take it on as the first group.
+var statementLocks = []struct {
+	pattern *regexp.Regexp
+	mode    func(statement string, match []string) string
+}{
+	{
+		regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?` + tableName),
+		constantMode(AccessExclusiveLock),
+	},
+	{
+		regexp.MustCompile(`(?is)^\s*DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?` + tableName),
+		constantMode(AccessExclusiveLock),
+	},
+	{
+		regexp.MustCompile(`(?is)^\s*TRUNCATE\s+(?:TABLE\s+)?` + tableName),
+		constantMode(AccessExclusiveLock),
+	},
+	{
+		regexp.MustCompile(`(?is)^\s*CREATE\s+(?:UNIQUE\s+)?INDEX\s+.*?\bON\s+` + tableName),
+		func(statement string, match []string) string {
+			if concurrently.MatchString(statement) {
+				return ShareUpdateExclusiveLock
+			}
+
+			return ShareLock
+		},
+	},
+	{
+		regexp.MustCompile(`(?is)^\s*LOCK\s+(?:TABLE\s+)?` + tableName + `\s+IN\s+(.+?)\s+MODE`),
+		func(statement string, match []string) string {
+			return strings.ToUpper(strings.Join(strings.Fields(match[2]), " "))
+		},
+	},
+	{
+		regexp.MustCompile(`(?is)^\s*UPDATE\s+` + tableName),
+		constantMode(RowExclusiveLock),
+	},
+	{
+		regexp.MustCompile(`(?is)^\s*DELETE\s+FROM\s+` + tableName),
+		constantMode(RowExclusiveLock),
+	},
+	{
+		regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+` + tableName),
+		constantMode(RowExclusiveLock),
+	},
+}
+
+func constantMode(mode string) func(string, []string) string {
+	return func(string, []string) string { return mode }
+}
+
+// StatementLocks works out the table locks a statement takes. Statements
+// which only create things, or which it doesn't recognize, take none.
+func StatementLocks(statement string) []TableLock {
+	for _, lock := range statementLocks {
+		match := lock.pattern.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+
+		return []TableLock{{
+			Table: strings.Replace(match[1], `"`, "", -1),
+			Mode:  lock.mode(statement, match),
+		}}
+	}
+
+	return nil
+}
+
+// Pending returns the migrations which would be run to migrate to the given
+// version, in the order they would be run in.
+func (self *migrator) Pending(toVersion int) ([]PendingMigration, error) {
+	currentVersion, err := self.CurrentVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := self.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []PendingMigration{}
+	for _, m := range migrationsToRun(migrations, currentVersion, toVersion) {
+		p := PendingMigration{
+			Version:       m.Version,
+			Name:          m.Name,
+			Direction:     m.Direction,
+			Transactional: m.Strategy == SQLTransaction,
+			Go:            m.Strategy == GoMigration,
+		}
+
+		for _, statement := range m.Statements {
+			for _, lock := range StatementLocks(statement) {
+				lock.EstimatedRows, err = estimateRows(self.db, lock.Table)
+				if err != nil {
+					return nil, err
+				}
+
+				p.Locks = append(p.Locks, lock)
+			}
+		}
+
+		pending = append(pending, p)
+	}
+
+	return pending, nil
+}
+
+// migrationsToRun returns the migrations to run, in order, to get from the
+// current version to the given one.
+func migrationsToRun(migrations []migration, currentVersion int, toVersion int) []migration {
+	toRun := []migration{}
+
+	if currentVersion <= toVersion {
+		for _, m := range migrations {
+			if currentVersion < m.Version && m.Version <= toVersion && m.Direction == "up" {
+				toRun = append(toRun, m)
+			}
+		}
+	} else {
+		for i := len(migrations) - 1; i >= 0; i-- {
+			if currentVersion >= migrations[i].Version && migrations[i].Version > toVersion && migrations[i].Direction == "down" {
+				toRun = append(toRun, migrations[i])
+			}
+		}
+	}
+
+	return toRun
+}
+
+func estimateRows(db *sql.DB, table string) (int64, error) {
+	name := table
+	if i := strings.LastIndex(table, "."); i != -1 {
+		name = table[i+1:]
+	}
+
+	var rows int64
+	err := db.QueryRow(`
+		SELECT reltuples::bigint
+		FROM pg_class
+		WHERE relname = $1
+		AND relkind = 'r'
+	`, name).Scan(&rows)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// the table is created by an earlier pending migration
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return rows, nil
+}
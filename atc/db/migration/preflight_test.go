@@ -0,0 +1,118 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/encryption"
+	"github.com/concourse/concourse/atc/db/migration"
+	"github.com/concourse/concourse/atc/db/migration/migrationfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("StatementLocks",
+	func(statement string, locks []migration.TableLock) {
+		Expect(migration.StatementLocks(statement)).To(Equal(locks))
+	},
+	Entry("altering a table", `ALTER TABLE builds ADD COLUMN foo text`,
+		[]migration.TableLock{{Table: "builds", Mode: migration.AccessExclusiveLock}}),
+	Entry("altering a quoted table", `ALTER TABLE IF EXISTS ONLY "public"."builds" DROP COLUMN foo`,
+		[]migration.TableLock{{Table: "public.builds", Mode: migration.AccessExclusiveLock}}),
+	Entry("dropping a table", `DROP TABLE IF EXISTS build_events`,
+		[]migration.TableLock{{Table: "build_events", Mode: migration.AccessExclusiveLock}}),
+	Entry("creating an index", "CREATE UNIQUE INDEX builds_name_idx\n  ON builds (job_id, name)",
+		[]migration.TableLock{{Table: "builds", Mode: migration.ShareLock}}),
+	Entry("creating an index concurrently", `CREATE INDEX CONCURRENTLY IF NOT EXISTS builds_status_idx ON builds (status)`,
+		[]migration.TableLock{{Table: "builds", Mode: migration.ShareUpdateExclusiveLock}}),
+	Entry("locking a table", `LOCK TABLE teams IN share row exclusive MODE`,
+		[]migration.TableLock{{Table: "teams", Mode: "SHARE ROW EXCLUSIVE"}}),
+	Entry("updating rows", `UPDATE builds SET status = 'errored' WHERE status = 'started'`,
+		[]migration.TableLock{{Table: "builds", Mode: migration.RowExclusiveLock}}),
+	Entry("deleting rows", `DELETE FROM containers WHERE state = 'destroying'`,
+		[]migration.TableLock{{Table: "containers", Mode: migration.RowExclusiveLock}}),
+	Entry("inserting rows", `INSERT INTO teams (name) VALUES ('main')`,
+		[]migration.TableLock{{Table: "teams", Mode: migration.RowExclusiveLock}}),
+	Entry("creating a table", `CREATE TABLE backfills (name text PRIMARY KEY)`, nil),
+)
+
+var _ = Describe("Pending", func() {
+	var (
+		err     error
+		db      *sql.DB
+		bindata *migrationfakes.FakeBindata
+	)
+
+	BeforeEach(func() {
+		db, err = sql.Open("postgres", postgresRunner.DataSourceName())
+		Expect(err).NotTo(HaveOccurred())
+
+		bindata = new(migrationfakes.FakeBindata)
+		bindata.AssetNamesReturns([]string{
+			"1510262030_initial_schema.up.sql",
+			"2000000000_alter_teams.up.sql",
+			"2000000000_alter_teams.down.sql",
+			"3000000000_go_migration.up.go",
+		})
+		bindata.AssetStub = func(name string) ([]byte, error) {
+			switch name {
+			case "2000000000_alter_teams.up.sql":
+				return []byte(`BEGIN; ALTER TABLE teams ADD COLUMN foo text; CREATE TABLE foos (id serial); COMMIT;`), nil
+			case "2000000000_alter_teams.down.sql":
+				return []byte(`BEGIN; ALTER TABLE teams DROP COLUMN foo; COMMIT;`), nil
+			case "3000000000_go_migration.up.go":
+				return []byte(`func (self *migrations) Up_3000000000() error { return nil }`), nil
+			}
+
+			return asset(name)
+		}
+
+		SetupMigrationsHistoryTableToExistAtVersion(db, initialSchemaVersion)
+	})
+
+	AfterEach(func() {
+		_ = db.Close()
+	})
+
+	It("returns the migrations to run with the locks they take", func() {
+		_, err = db.Exec(`CREATE TABLE teams (id serial PRIMARY KEY, name text)`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO teams (name) VALUES ('some-team'), ('other-team')`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`ANALYZE teams`)
+		Expect(err).NotTo(HaveOccurred())
+
+		migrator := migration.NewMigratorForMigrations(db, nil, encryption.NewNoEncryption(), bindata)
+
+		pending, err := migrator.Pending(3000000000)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(Equal([]migration.PendingMigration{
+			{
+				Version:       2000000000,
+				Name:          "2000000000_alter_teams.up.sql",
+				Direction:     "up",
+				Transactional: true,
+				Locks: []migration.TableLock{
+					{Table: "teams", Mode: migration.AccessExclusiveLock, EstimatedRows: 2},
+				},
+			},
+			{
+				Version:   3000000000,
+				Name:      "Up_3000000000",
+				Direction: "up",
+				Go:        true,
+			},
+		}))
+	})
+
+	It("returns nothing when already at the version", func() {
+		migrator := migration.NewMigratorForMigrations(db, nil, encryption.NewNoEncryption(), bindata)
+
+		pending, err := migrator.Pending(initialSchemaVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(BeEmpty())
+	})
+})
@@ -15,3 +15,14 @@ type Pagination struct {
 	Previous *Page
 	Next     *Page
 }
+
+// VersionSpan bounds a span of a resource's versions by version ID. The
+// lower bound is required; without an upper bound the span runs to the
+// latest version.
+type VersionSpan struct {
+	Since int // exclusive
+	From  int // inclusive
+
+	Until int // exclusive
+	To    int // inclusive
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -37,6 +39,40 @@ type Pipeline interface {
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
+	ResourceDefaults() atc.ResourceDefaults
+	IgnoreTeamContainerEnv() bool
+	ContainerDNS() *atc.ContainerDNS
+	Deprecations() []atc.ConfigDeprecation
+
+	// ReconciledDigest is the digest of the file in the team's pipelines
+	// repo which the pipeline was last reconciled with, or empty if the
+	// pipeline is not managed by the repo. A managed pipeline whose config
+	// version is past ReconciledConfigVersion was changed by other means.
+	ReconciledDigest() string
+	ReconciledConfigVersion() ConfigVersion
+
+	// Branches configures the instances spawned from the pipeline, one for
+	// each branch. ParentID and Branch are set on the instances themselves
+	// until they are archived.
+	Branches() *atc.BranchesConfig
+	ParentID() int
+	Branch() string
+
+	// SchedulingWindows restricts when builds of the pipeline's jobs may be
+	// triggered automatically.
+	SchedulingWindows() *atc.SchedulingWindows
+
+	Labels() atc.Labels
+
+	// TimeZone is the time zone of the pipeline's scheduling windows which
+	// don't configure a location.
+	TimeZone() atc.TimeZone
+	Locale() atc.Locale
+
+	// Redactions hide parts of the pipeline from those who aren't authorized
+	// for its team.
+	Redactions() *atc.Redactions
+
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
@@ -61,6 +97,10 @@ type Pipeline interface {
 
 	AcquireSchedulingLock(lager.Logger, time.Duration) (lock.Lock, bool, error)
 
+	// SchedulingTick is how the scheduler's last tick of the pipeline went.
+	SchedulingTick() (atc.SchedulingTick, bool, error)
+	SaveSchedulingTick(start time.Time, duration time.Duration, jobsEvaluated int, tickErr error) (atc.SchedulingTick, error)
+
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
 
 	Resource(name string) (Resource, bool, error)
@@ -78,22 +118,57 @@ type Pipeline interface {
 	Expose() error
 	Hide() error
 
+	// Shared returns true if the pipeline can be viewed by anyone with its
+	// share token, as though it were public.
+	Shared() bool
+	IsSharedWith(token string) bool
+	Share() (string, error)
+	Unshare() error
+
 	Pause() error
 	Unpause() error
 
+	UpdateDeprecations([]atc.ConfigDeprecation) error
+
+	MarkReconciled(digest string) error
+	ClearReconciled() error
+
+	Config() (atc.Config, error)
+	MarkInstance(parentID int, branch string) error
+	ClearInstance() error
+
 	Destroy() error
 	Rename(string) error
 }
 
 type pipeline struct {
-	id            int
-	name          string
-	teamID        int
-	teamName      string
-	groups        atc.GroupConfigs
-	configVersion ConfigVersion
-	paused        bool
-	public        bool
+	id               int
+	name             string
+	teamID           int
+	teamName         string
+	groups           atc.GroupConfigs
+	resourceDefaults atc.ResourceDefaults
+	configVersion    ConfigVersion
+	paused           bool
+	public           bool
+	shareTokenHash   string
+
+	ignoreTeamContainerEnv bool
+	containerDNS           *atc.ContainerDNS
+	deprecations           []atc.ConfigDeprecation
+
+	reconciledDigest        string
+	reconciledConfigVersion ConfigVersion
+
+	branches *atc.BranchesConfig
+	parentID int
+	branch   string
+
+	schedulingWindows *atc.SchedulingWindows
+	labels            atc.Labels
+	redactions        *atc.Redactions
+	timeZone          atc.TimeZone
+	locale            atc.Locale
 
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
@@ -109,11 +184,26 @@ var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
 		p.groups,
+		p.resource_defaults,
+		p.ignore_team_container_env,
+		p.container_dns,
+		p.deprecations,
+		p.reconciled_digest,
+		p.reconciled_config_version,
+		p.branches,
+		p.parent_id,
+		p.branch,
+		p.scheduling_windows,
+		p.labels,
+		p.redactions,
+		p.timezone,
+		p.locale,
 		p.version,
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		p.share_token_hash
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -125,14 +215,31 @@ func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                      { return p.id }
-func (p *pipeline) Name() string                 { return p.name }
-func (p *pipeline) TeamID() int                  { return p.teamID }
-func (p *pipeline) TeamName() string             { return p.teamName }
-func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
-func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
-func (p *pipeline) Public() bool                 { return p.public }
-func (p *pipeline) Paused() bool                 { return p.paused }
+func (p *pipeline) ID() int                                { return p.id }
+func (p *pipeline) Name() string                           { return p.name }
+func (p *pipeline) TeamID() int                            { return p.teamID }
+func (p *pipeline) TeamName() string                       { return p.teamName }
+func (p *pipeline) Groups() atc.GroupConfigs               { return p.groups }
+func (p *pipeline) ResourceDefaults() atc.ResourceDefaults { return p.resourceDefaults }
+func (p *pipeline) ConfigVersion() ConfigVersion           { return p.configVersion }
+func (p *pipeline) Public() bool                           { return p.public }
+func (p *pipeline) Shared() bool                           { return p.shareTokenHash != "" }
+func (p *pipeline) Paused() bool                           { return p.paused }
+func (p *pipeline) IgnoreTeamContainerEnv() bool           { return p.ignoreTeamContainerEnv }
+func (p *pipeline) ContainerDNS() *atc.ContainerDNS        { return p.containerDNS }
+func (p *pipeline) Deprecations() []atc.ConfigDeprecation  { return p.deprecations }
+func (p *pipeline) ReconciledDigest() string               { return p.reconciledDigest }
+func (p *pipeline) ReconciledConfigVersion() ConfigVersion { return p.reconciledConfigVersion }
+func (p *pipeline) Branches() *atc.BranchesConfig          { return p.branches }
+func (p *pipeline) ParentID() int                          { return p.parentID }
+func (p *pipeline) Branch() string                         { return p.branch }
+func (p *pipeline) SchedulingWindows() *atc.SchedulingWindows {
+	return p.schedulingWindows
+}
+func (p *pipeline) Labels() atc.Labels          { return p.labels }
+func (p *pipeline) Redactions() *atc.Redactions { return p.redactions }
+func (p *pipeline) TimeZone() atc.TimeZone      { return p.timeZone }
+func (p *pipeline) Locale() atc.Locale          { return p.locale }
 
 // IMPORTANT: This method is broken with the new resource config versions changes
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
@@ -227,10 +334,15 @@ func (p *pipeline) CreateJobBuild(jobName string) (Build, error) {
 		return nil, err
 	}
 
+	token, err := newBuildToken()
+	if err != nil {
+		return nil, err
+	}
+
 	var buildID int
 	err = psql.Insert("builds").
-		Columns("name", "job_id", "team_id", "status", "manually_triggered").
-		Values(buildName, jobID, p.teamID, "pending", true).
+		Columns("name", "job_id", "team_id", "status", "manually_triggered", "token").
+		Values(buildName, jobID, p.teamID, "pending", true, token).
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
@@ -617,6 +729,245 @@ func (p *pipeline) Unpause() error {
 	return err
 }
 
+func (p *pipeline) UpdateDeprecations(deprecations []atc.ConfigDeprecation) error {
+	payload, err := json.Marshal(deprecations)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("pipelines").
+		Set("deprecations", payload).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	p.deprecations = deprecations
+
+	return nil
+}
+
+func (p *pipeline) SchedulingTick() (atc.SchedulingTick, bool, error) {
+	var payload []byte
+	err := psql.Select("scheduling_tick").
+		From("pipelines").
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.SchedulingTick{}, false, nil
+		}
+		return atc.SchedulingTick{}, false, err
+	}
+
+	if payload == nil {
+		return atc.SchedulingTick{}, false, nil
+	}
+
+	var tick atc.SchedulingTick
+	err = json.Unmarshal(payload, &tick)
+	if err != nil {
+		return atc.SchedulingTick{}, false, err
+	}
+
+	return tick, true, nil
+}
+
+// SaveSchedulingTick records how the scheduler's tick of the pipeline which
+// started at the given time went, counting the builds the tick created, and
+// returns what was recorded.
+func (p *pipeline) SaveSchedulingTick(start time.Time, duration time.Duration, jobsEvaluated int, tickErr error) (atc.SchedulingTick, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return atc.SchedulingTick{}, err
+	}
+
+	defer Rollback(tx)
+
+	tick := atc.SchedulingTick{
+		StartTime:     start.Unix(),
+		Duration:      duration.Seconds(),
+		JobsEvaluated: jobsEvaluated,
+	}
+
+	if tickErr != nil {
+		tick.Error = tickErr.Error()
+	}
+
+	err = psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{
+			"pipeline_id":        p.id,
+			"manually_triggered": false,
+			"auto_retry_attempt": 0,
+		}).
+		Where(sq.NotEq{
+			"job_id": nil,
+		}).
+		Where(sq.GtOrEq{
+			"create_time": start,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&tick.BuildsCreated)
+	if err != nil {
+		return atc.SchedulingTick{}, err
+	}
+
+	payload, err := json.Marshal(tick)
+	if err != nil {
+		return atc.SchedulingTick{}, err
+	}
+
+	_, err = psql.Update("pipelines").
+		Set("scheduling_tick", payload).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return atc.SchedulingTick{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.SchedulingTick{}, err
+	}
+
+	return tick, nil
+}
+
+// MarkReconciled records that the pipeline's current config was set from
+// the repo file with the given digest.
+func (p *pipeline) MarkReconciled(digest string) error {
+	var configVersion ConfigVersion
+	err := psql.Update("pipelines").
+		Set("reconciled_digest", digest).
+		Set("reconciled_config_version", sq.Expr("version")).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		Suffix("RETURNING reconciled_config_version").
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&configVersion)
+	if err != nil {
+		return err
+	}
+
+	p.reconciledDigest = digest
+	p.reconciledConfigVersion = configVersion
+
+	return nil
+}
+
+// ClearReconciled releases the pipeline from being managed by the repo.
+func (p *pipeline) ClearReconciled() error {
+	_, err := psql.Update("pipelines").
+		Set("reconciled_digest", nil).
+		Set("reconciled_config_version", nil).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	p.reconciledDigest = ""
+	p.reconciledConfigVersion = 0
+
+	return nil
+}
+
+// Config assembles the pipeline's config from its jobs, resources and
+// resource types.
+func (p *pipeline) Config() (atc.Config, error) {
+	jobs, err := p.Jobs()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resources, err := p.Resources()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resourceTypes, err := p.ResourceTypes()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return atc.Config{
+		Groups:        p.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+
+		ResourceDefaults:       p.ResourceDefaults(),
+		IgnoreTeamContainerEnv: p.IgnoreTeamContainerEnv(),
+		ContainerDNS:           p.ContainerDNS(),
+		Branches:               p.Branches(),
+		SchedulingWindows:      p.SchedulingWindows(),
+		Labels:                 p.Labels(),
+		Redactions:             p.Redactions(),
+		TimeZone:               p.TimeZone(),
+		Locale:                 p.Locale(),
+	}, nil
+}
+
+// MarkInstance records that the pipeline was spawned from the pipeline with
+// the given ID for one of its branches.
+func (p *pipeline) MarkInstance(parentID int, branch string) error {
+	_, err := psql.Update("pipelines").
+		Set("parent_id", parentID).
+		Set("branch", branch).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	p.parentID = parentID
+	p.branch = branch
+
+	return nil
+}
+
+// ClearInstance archives the pipeline as an instance, leaving it to be
+// managed like any other pipeline.
+func (p *pipeline) ClearInstance() error {
+	_, err := psql.Update("pipelines").
+		Set("parent_id", nil).
+		Set("branch", nil).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	p.parentID = 0
+	p.branch = ""
+
+	return nil
+}
+
 func (p *pipeline) Hide() error {
 	_, err := psql.Update("pipelines").
 		Set("public", false).
@@ -641,6 +992,61 @@ func (p *pipeline) Expose() error {
 	return err
 }
 
+// Share generates a new share token for the pipeline, replacing any previous
+// one. Only a hash of the token is saved, so it can't be shown again.
+func (p *pipeline) Share() (string, error) {
+	token, err := newBuildToken()
+	if err != nil {
+		return "", err
+	}
+
+	hash := hashShareToken(token)
+
+	_, err = psql.Update("pipelines").
+		Set("share_token_hash", hash).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return "", err
+	}
+
+	p.shareTokenHash = hash
+
+	return token, nil
+}
+
+func (p *pipeline) Unshare() error {
+	_, err := psql.Update("pipelines").
+		Set("share_token_hash", nil).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	p.shareTokenHash = ""
+
+	return nil
+}
+
+func (p *pipeline) IsSharedWith(token string) bool {
+	if p.shareTokenHash == "" || token == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(hashShareToken(token)), []byte(p.shareTokenHash)) == 1
+}
+
+func hashShareToken(token string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
+
 func (p *pipeline) Rename(name string) error {
 	_, err := psql.Update("pipelines").
 		Set("name", name).
@@ -684,6 +1090,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		BuildOutputs:     []algorithm.BuildOutput{},
 		BuildInputs:      []algorithm.BuildInput{},
 		ResourceVersions: []algorithm.ResourceVersion{},
+		DeletedVersions:  []algorithm.ResourceVersion{},
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
 	}
@@ -701,6 +1108,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		Where(sq.Eq{
 			"b.status":      BuildStatusSucceeded,
 			"r.pipeline_id": p.id,
+			"v.deleted_at":  nil,
 		}).
 		RunWith(p.conn).
 		Query()
@@ -722,7 +1130,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.BuildOutputs = append(db.BuildOutputs, output)
 	}
 
-	rows, err = psql.Select("v.id, v.check_order, r.id, i.build_id, i.name, b.job_id, b.status = 'succeeded'").
+	rows, err = psql.Select("v.id, v.check_order, r.id, i.build_id, i.name, b.job_id, b.status, v.deleted_at IS NOT NULL").
 		From("build_resource_config_version_inputs i").
 		Join("builds b ON b.id = i.build_id").
 		Join("resource_config_versions v ON v.version_md5 = i.version_md5").
@@ -744,19 +1152,22 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	defer Close(rows)
 
 	for rows.Next() {
-		var succeeded bool
+		var status string
+		var deleted bool
 
 		var input algorithm.BuildInput
-		err = rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID, &succeeded)
+		err = rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID, &status, &deleted)
 		if err != nil {
 			return nil, err
 		}
 
 		input.ResourceVersion.CheckOrder = input.CheckOrder
+		input.Succeeded = status == string(BuildStatusSucceeded)
+		input.Failed = status == string(BuildStatusFailed)
 
 		db.BuildInputs = append(db.BuildInputs, input)
 
-		if succeeded {
+		if input.Succeeded && !deleted {
 			// implicit output
 			db.BuildOutputs = append(db.BuildOutputs, algorithm.BuildOutput{
 				ResourceVersion: input.ResourceVersion,
@@ -766,7 +1177,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		}
 	}
 
-	rows, err = psql.Select("v.id, v.check_order, r.id").
+	rows, err = psql.Select("v.id, v.check_order, r.id, v.deleted_at IS NOT NULL").
 		From("resource_config_versions v").
 		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
 		LeftJoin("resource_disabled_versions d ON d.resource_id = r.id AND d.version_md5 = v.version_md5").
@@ -788,12 +1199,17 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 
 	for rows.Next() {
 		var output algorithm.ResourceVersion
-		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID)
+		var deleted bool
+		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &deleted)
 		if err != nil {
 			return nil, err
 		}
 
-		db.ResourceVersions = append(db.ResourceVersions, output)
+		if deleted {
+			db.DeletedVersions = append(db.DeletedVersions, output)
+		} else {
+			db.ResourceVersions = append(db.ResourceVersions, output)
+		}
 	}
 
 	rows, err = psql.Select("j.name, j.id").
@@ -26,7 +26,10 @@ func NewPipelineFactory(conn Conn, lockFactory lock.LockFactory) PipelineFactory
 
 func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
-		Where(sq.Eq{"t.name": teamNames}).
+		Where(sq.Eq{
+			"t.name":       teamNames,
+			"t.deleted_at": nil,
+		}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(f.conn).
 		Query()
@@ -41,7 +44,10 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 
 	rows, err = pipelinesQuery.
 		Where(sq.NotEq{"t.name": teamNames}).
-		Where(sq.Eq{"public": true}).
+		Where(sq.Eq{
+			"public":       true,
+			"t.deleted_at": nil,
+		}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(f.conn).
 		Query()
@@ -59,6 +65,7 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 
 func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
+		Where(sq.Eq{"t.deleted_at": nil}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(f.conn).
 		Query()
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
@@ -207,6 +208,61 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Share/Unshare", func() {
+		var token string
+
+		BeforeEach(func() {
+			var err error
+			token, err = pipeline.Share()
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("shares the pipeline with the returned token", func() {
+			Expect(token).ToNot(BeEmpty())
+			Expect(pipeline.Shared()).To(BeTrue())
+			Expect(pipeline.IsSharedWith(token)).To(BeTrue())
+			Expect(pipeline.IsSharedWith("some-other-token")).To(BeFalse())
+			Expect(pipeline.IsSharedWith("")).To(BeFalse())
+		})
+
+		It("does not make the pipeline public", func() {
+			Expect(pipeline.Public()).To(BeFalse())
+		})
+
+		Context("when the pipeline is shared again", func() {
+			It("stops accepting the previous token", func() {
+				newToken, err := pipeline.Share()
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(pipeline.IsSharedWith(newToken)).To(BeTrue())
+				Expect(pipeline.IsSharedWith(token)).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline is unshared", func() {
+			BeforeEach(func() {
+				Expect(pipeline.Unshare()).To(Succeed())
+
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("no longer accepts the token", func() {
+				Expect(pipeline.Shared()).To(BeFalse())
+				Expect(pipeline.IsSharedWith(token)).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Rename", func() {
 		JustBeforeEach(func() {
 			Expect(pipeline.Rename("oopsies")).To(Succeed())
@@ -220,6 +276,218 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("UpdateDeprecations", func() {
+		var deprecations []atc.ConfigDeprecation
+
+		BeforeEach(func() {
+			deprecations = []atc.ConfigDeprecation{
+				{
+					Location:  "jobs.some-job",
+					Construct: "aggregate",
+					Message:   "aggregate is deprecated",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(pipeline.UpdateDeprecations(deprecations)).To(Succeed())
+		})
+
+		It("saves the deprecations", func() {
+			Expect(pipeline.Deprecations()).To(Equal(deprecations))
+
+			reloaded, found, err := team.Pipeline(pipeline.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.Deprecations()).To(Equal(deprecations))
+		})
+	})
+
+	Describe("SaveSchedulingTick", func() {
+		It("isn't found until the pipeline has been scheduled", func() {
+			_, found, err := pipeline.SchedulingTick()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("records the tick, counting the builds it created", func() {
+			start := time.Now().Add(-time.Second)
+
+			_, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(job.EnsurePendingBuildExists()).To(Succeed())
+
+			tick, err := pipeline.SaveSchedulingTick(start, 2*time.Second, 3, errors.New("nope"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tick).To(Equal(atc.SchedulingTick{
+				StartTime:     start.Unix(),
+				Duration:      2,
+				JobsEvaluated: 3,
+				BuildsCreated: 1,
+				Error:         "nope",
+			}))
+
+			saved, found, err := pipeline.SchedulingTick()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(saved).To(Equal(tick))
+		})
+	})
+
+	Describe("MarkReconciled", func() {
+		It("records the digest and the current config version", func() {
+			Expect(pipeline.ReconciledDigest()).To(BeEmpty())
+
+			Expect(pipeline.MarkReconciled("some-digest")).To(Succeed())
+			Expect(pipeline.ReconciledDigest()).To(Equal("some-digest"))
+			Expect(pipeline.ReconciledConfigVersion()).To(Equal(pipeline.ConfigVersion()))
+
+			reloaded, found, err := team.Pipeline(pipeline.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.ReconciledDigest()).To(Equal("some-digest"))
+			Expect(reloaded.ReconciledConfigVersion()).To(Equal(pipeline.ConfigVersion()))
+		})
+
+		Context("when the pipeline is cleared", func() {
+			It("is no longer managed", func() {
+				Expect(pipeline.MarkReconciled("some-digest")).To(Succeed())
+				Expect(pipeline.ClearReconciled()).To(Succeed())
+				Expect(pipeline.ReconciledDigest()).To(BeEmpty())
+
+				reloaded, found, err := team.Pipeline(pipeline.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ReconciledDigest()).To(BeEmpty())
+				Expect(reloaded.ReconciledConfigVersion()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("MarkInstance", func() {
+		It("records the parent and branch of the instance", func() {
+			Expect(pipeline.ParentID()).To(BeZero())
+
+			Expect(pipeline.MarkInstance(42, "feature/foo")).To(Succeed())
+			Expect(pipeline.ParentID()).To(Equal(42))
+			Expect(pipeline.Branch()).To(Equal("feature/foo"))
+
+			reloaded, found, err := team.Pipeline(pipeline.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.ParentID()).To(Equal(42))
+			Expect(reloaded.Branch()).To(Equal("feature/foo"))
+		})
+
+		Context("when the instance is cleared", func() {
+			It("is no longer an instance", func() {
+				Expect(pipeline.MarkInstance(42, "feature/foo")).To(Succeed())
+				Expect(pipeline.ClearInstance()).To(Succeed())
+				Expect(pipeline.ParentID()).To(BeZero())
+
+				reloaded, found, err := team.Pipeline(pipeline.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ParentID()).To(BeZero())
+				Expect(reloaded.Branch()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Config", func() {
+		It("returns the config the pipeline was saved with", func() {
+			config, err := pipeline.Config()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found := config.Jobs.Lookup("job-name")
+			Expect(found).To(BeTrue())
+			Expect(config.Resources).To(HaveLen(len(pipelineConfig.Resources)))
+			Expect(config.Branches).To(BeNil())
+		})
+
+		Context("when the pipeline configures branches", func() {
+			BeforeEach(func() {
+				pipelineConfig.Branches = &atc.BranchesConfig{Resource: "some-resource", Field: "refs"}
+
+				var err error
+				pipeline, _, err = team.SavePipeline("branched-pipeline", pipelineConfig, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is saved with the pipeline", func() {
+				Expect(pipeline.Branches()).To(Equal(&atc.BranchesConfig{Resource: "some-resource", Field: "refs"}))
+
+				config, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config.Branches).To(Equal(pipeline.Branches()))
+			})
+		})
+
+		Context("when the pipeline configures scheduling windows", func() {
+			windows := &atc.SchedulingWindows{
+				Deny: []atc.SchedulingWindow{{Days: []string{"Saturday", "Sunday"}}},
+			}
+
+			BeforeEach(func() {
+				pipelineConfig.SchedulingWindows = windows
+
+				var err error
+				pipeline, _, err = team.SavePipeline("windowed-pipeline", pipelineConfig, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is saved with the pipeline", func() {
+				Expect(pipeline.SchedulingWindows()).To(Equal(windows))
+
+				config, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config.SchedulingWindows).To(Equal(windows))
+			})
+		})
+	})
+
+	Describe("Labels", func() {
+		labels := atc.Labels{"service": "web", "tier": "1"}
+
+		BeforeEach(func() {
+			pipelineConfig.Labels = labels
+
+			var err error
+			pipeline, _, err = team.SavePipeline("labelled-pipeline", pipelineConfig, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is saved with the pipeline", func() {
+			Expect(pipeline.Labels()).To(Equal(labels))
+
+			config, err := pipeline.Config()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Labels).To(Equal(labels))
+		})
+	})
+
+	Describe("TimeZone and Locale", func() {
+		BeforeEach(func() {
+			pipelineConfig.TimeZone = "Europe/London"
+			pipelineConfig.Locale = "en-GB"
+
+			var err error
+			pipeline, _, err = team.SavePipeline("zoned-pipeline", pipelineConfig, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is saved with the pipeline", func() {
+			Expect(pipeline.TimeZone()).To(Equal(atc.TimeZone("Europe/London")))
+			Expect(pipeline.Locale()).To(Equal(atc.Locale("en-GB")))
+
+			config, err := pipeline.Config()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.TimeZone).To(Equal(atc.TimeZone("Europe/London")))
+			Expect(config.Locale).To(Equal(atc.Locale("en-GB")))
+		})
+	})
+
 	Describe("Resource Config Versions", func() {
 		resourceName := "some-resource"
 		otherResourceName := "some-other-resource"
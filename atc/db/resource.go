@@ -26,6 +26,7 @@ type Resource interface {
 	TeamName() string
 	Type() string
 	Source() atc.Source
+	ConfigSource() atc.Source
 	CheckEvery() string
 	CheckTimeout() string
 	LastCheckStartTime() time.Time
@@ -41,16 +42,25 @@ type Resource interface {
 	ResourceConfigID() int
 	ResourceConfigScopeID() int
 	Icon() string
+	Mode() atc.ResourceMode
+	NewVersionWebhooks() []atc.NewVersionWebhookConfig
+
+	// Redactions are those of the resource's pipeline.
+	Redactions() *atc.Redactions
 
 	CurrentPinnedVersion() atc.Version
 
 	ResourceConfigVersionID(atc.Version) (int, bool, error)
 	Versions(page Page, versionFilter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
+	VersionsInSpan(span VersionSpan) ([]atc.ResourceVersion, bool, error)
+	Checks(limit int) ([]Check, error)
 	SaveUncheckedVersion(atc.Version, ResourceConfigMetadataFields, ResourceConfig, atc.VersionedResourceTypes) (bool, error)
 	UpdateMetadata(atc.Version, ResourceConfigMetadataFields) (bool, error)
+	UpdateVersionMetadata(rcvID int, metadata ResourceConfigMetadataFields) (bool, error)
 
 	EnableVersion(rcvID int) error
 	DisableVersion(rcvID int) error
+	DisableVersionAndAbortPendingBuilds(rcvID int) error
 
 	PinVersion(rcvID int) (bool, error)
 	UnpinVersion() error
@@ -80,6 +90,9 @@ var resourcesQuery = psql.Select(
 	"rs.check_error",
 	"rp.version",
 	"rp.comment_text",
+	"p.resource_defaults",
+	"t.resource_defaults",
+	"p.redactions",
 ).
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
@@ -98,6 +111,7 @@ type resource struct {
 	teamName              string
 	type_                 string
 	source                atc.Source
+	configSource          atc.Source
 	checkEvery            string
 	checkTimeout          string
 	lastCheckStartTime    time.Time
@@ -112,6 +126,9 @@ type resource struct {
 	resourceConfigID      int
 	resourceConfigScopeID int
 	icon                  string
+	mode                  atc.ResourceMode
+	newVersionWebhooks    []atc.NewVersionWebhookConfig
+	redactions            *atc.Redactions
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -146,40 +163,45 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 			Public:       r.Public(),
 			WebhookToken: r.WebhookToken(),
 			Type:         r.Type(),
-			Source:       r.Source(),
+			Source:       r.ConfigSource(),
 			CheckEvery:   r.CheckEvery(),
 			Tags:         r.Tags(),
 			Version:      r.ConfigPinnedVersion(),
 			Icon:         r.Icon(),
+			Mode:         r.Mode(),
 		})
 	}
 
 	return configs
 }
 
-func (r *resource) ID() int                          { return r.id }
-func (r *resource) Name() string                     { return r.name }
-func (r *resource) Public() bool                     { return r.public }
-func (r *resource) PipelineID() int                  { return r.pipelineID }
-func (r *resource) PipelineName() string             { return r.pipelineName }
-func (r *resource) TeamID() int                      { return r.teamID }
-func (r *resource) TeamName() string                 { return r.teamName }
-func (r *resource) Type() string                     { return r.type_ }
-func (r *resource) Source() atc.Source               { return r.source }
-func (r *resource) CheckEvery() string               { return r.checkEvery }
-func (r *resource) CheckTimeout() string             { return r.checkTimeout }
-func (r *resource) LastCheckStartTime() time.Time    { return r.lastCheckStartTime }
-func (r *resource) LastCheckEndTime() time.Time      { return r.lastCheckEndTime }
-func (r *resource) Tags() atc.Tags                   { return r.tags }
-func (r *resource) CheckSetupError() error           { return r.checkSetupError }
-func (r *resource) CheckError() error                { return r.checkError }
-func (r *resource) WebhookToken() string             { return r.webhookToken }
-func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
-func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
-func (r *resource) PinComment() string               { return r.pinComment }
-func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
-func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
-func (r *resource) Icon() string                     { return r.icon }
+func (r *resource) ID() int                                           { return r.id }
+func (r *resource) Name() string                                      { return r.name }
+func (r *resource) Public() bool                                      { return r.public }
+func (r *resource) PipelineID() int                                   { return r.pipelineID }
+func (r *resource) PipelineName() string                              { return r.pipelineName }
+func (r *resource) TeamID() int                                       { return r.teamID }
+func (r *resource) TeamName() string                                  { return r.teamName }
+func (r *resource) Type() string                                      { return r.type_ }
+func (r *resource) Source() atc.Source                                { return r.source }
+func (r *resource) ConfigSource() atc.Source                          { return r.configSource }
+func (r *resource) CheckEvery() string                                { return r.checkEvery }
+func (r *resource) CheckTimeout() string                              { return r.checkTimeout }
+func (r *resource) LastCheckStartTime() time.Time                     { return r.lastCheckStartTime }
+func (r *resource) LastCheckEndTime() time.Time                       { return r.lastCheckEndTime }
+func (r *resource) Tags() atc.Tags                                    { return r.tags }
+func (r *resource) CheckSetupError() error                            { return r.checkSetupError }
+func (r *resource) CheckError() error                                 { return r.checkError }
+func (r *resource) WebhookToken() string                              { return r.webhookToken }
+func (r *resource) ConfigPinnedVersion() atc.Version                  { return r.configPinnedVersion }
+func (r *resource) APIPinnedVersion() atc.Version                     { return r.apiPinnedVersion }
+func (r *resource) PinComment() string                                { return r.pinComment }
+func (r *resource) ResourceConfigID() int                             { return r.resourceConfigID }
+func (r *resource) ResourceConfigScopeID() int                        { return r.resourceConfigScopeID }
+func (r *resource) Icon() string                                      { return r.icon }
+func (r *resource) Mode() atc.ResourceMode                            { return r.mode }
+func (r *resource) NewVersionWebhooks() []atc.NewVersionWebhookConfig { return r.newVersionWebhooks }
+func (r *resource) Redactions() *atc.Redactions                       { return r.redactions }
 
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
@@ -339,6 +361,34 @@ func (r *resource) UpdateMetadata(version atc.Version, metadata ResourceConfigMe
 	return true, nil
 }
 
+// UpdateVersionMetadata replaces the metadata of one of the resource's
+// versions. Builds which used the version will show the new metadata.
+func (r *resource) UpdateVersionMetadata(rcvID int, metadata ResourceConfigMetadataFields) (bool, error) {
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return false, err
+	}
+
+	results, err := psql.Update("resource_config_versions").
+		Set("metadata", string(metadataJSON)).
+		Where(sq.Eq{
+			"id":                       rcvID,
+			"resource_config_scope_id": r.ResourceConfigScopeID(),
+		}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
 func (r *resource) ResourceConfigVersionID(version atc.Version) (int, bool, error) {
 	requestedVersion, err := json.Marshal(version)
 	if err != nil {
@@ -368,6 +418,37 @@ func (r *resource) ResourceConfigVersionID(version atc.Version) (int, bool, erro
 	return id, true, nil
 }
 
+// Checks returns the most recent checks of the resource's config scope,
+// newest first. Finished checks are kept until the check recycle period has
+// passed.
+func (r *resource) Checks(limit int) ([]Check, error) {
+	rows, err := checksQuery.
+		Where(sq.Eq{"c.resource_config_scope_id": r.resourceConfigScopeID}).
+		OrderBy("c.id DESC").
+		Limit(uint64(limit)).
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	checks := []Check{}
+	for rows.Next() {
+		check := &check{conn: r.conn, lockFactory: r.lockFactory}
+
+		err = scanCheck(check, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
 func (r *resource) SetPinComment(comment string) error {
 	_, err := psql.Update("resource_pins").
 		Set("comment_text", comment).
@@ -559,12 +640,136 @@ func (r *resource) Versions(page Page, versionFilter atc.Version) ([]atc.Resourc
 	return rvs, pagination, true, nil
 }
 
+// VersionsInSpan returns the versions of the resource between the bounds of
+// the span, newest first. It returns false if either bound is not a version
+// of the resource.
+func (r *resource) VersionsInSpan(span VersionSpan) ([]atc.ResourceVersion, bool, error) {
+	query := psql.Select("v.id", "v.version", "v.metadata").
+		Column(`NOT EXISTS (
+				SELECT 1
+				FROM resource_disabled_versions d
+				WHERE v.version_md5 = d.version_md5
+				AND r.resource_config_scope_id = v.resource_config_scope_id
+				AND r.id = d.resource_id
+			)`).
+		From("resource_config_versions v, resources r").
+		Where(sq.Expr("r.resource_config_scope_id = v.resource_config_scope_id")).
+		Where(sq.Eq{"r.id": r.id}).
+		Where(sq.NotEq{"v.check_order": 0}).
+		OrderBy("v.check_order DESC")
+
+	lowerID, lowerOp := span.From, ">="
+	if span.Since != 0 {
+		lowerID, lowerOp = span.Since, ">"
+	}
+
+	lowerCheckOrder, found, err := r.versionCheckOrder(lowerID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	query = query.Where(sq.Expr("v.check_order "+lowerOp+" ?", lowerCheckOrder))
+
+	if span.Until != 0 || span.To != 0 {
+		upperID, upperOp := span.To, "<="
+		if span.Until != 0 {
+			upperID, upperOp = span.Until, "<"
+		}
+
+		upperCheckOrder, found, err := r.versionCheckOrder(upperID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !found {
+			return nil, false, nil
+		}
+
+		query = query.Where(sq.Expr("v.check_order "+upperOp+" ?", upperCheckOrder))
+	}
+
+	rows, err := query.RunWith(r.conn).Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	rvs := []atc.ResourceVersion{}
+	for rows.Next() {
+		var (
+			metadataBytes sql.NullString
+			versionBytes  string
+		)
+
+		rv := atc.ResourceVersion{}
+		err := rows.Scan(&rv.ID, &versionBytes, &metadataBytes, &rv.Enabled)
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = json.Unmarshal([]byte(versionBytes), &rv.Version)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if metadataBytes.Valid {
+			err = json.Unmarshal([]byte(metadataBytes.String), &rv.Metadata)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		rvs = append(rvs, rv)
+	}
+
+	return rvs, true, nil
+}
+
+func (r *resource) versionCheckOrder(rcvID int) (int, bool, error) {
+	var checkOrder int
+	err := psql.Select("v.check_order").
+		From("resource_config_versions v, resources r").
+		Where(sq.Expr("r.resource_config_scope_id = v.resource_config_scope_id")).
+		Where(sq.Eq{
+			"r.id": r.id,
+			"v.id": rcvID,
+		}).
+		Where(sq.NotEq{"v.check_order": 0}).
+		RunWith(r.conn).
+		QueryRow().
+		Scan(&checkOrder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return checkOrder, true, nil
+}
+
 func (r *resource) EnableVersion(rcvID int) error {
-	return r.toggleVersion(rcvID, true)
+	return r.toggleVersion(rcvID, true, false)
 }
 
+// DisableVersion disables the version and throws away the next inputs of the
+// jobs which were resolved to it, so that builds which haven't started yet
+// resolve their inputs again rather than running with it.
 func (r *resource) DisableVersion(rcvID int) error {
-	return r.toggleVersion(rcvID, false)
+	return r.toggleVersion(rcvID, false, false)
+}
+
+// DisableVersionAndAbortPendingBuilds is like DisableVersion, but aborts the
+// builds which haven't started yet and would use the version instead of
+// letting them resolve their inputs again.
+func (r *resource) DisableVersionAndAbortPendingBuilds(rcvID int) error {
+	return r.toggleVersion(rcvID, false, true)
 }
 
 func (r *resource) PinVersion(rcvID int) (bool, error) {
@@ -615,7 +820,7 @@ func (r *resource) UnpinVersion() error {
 	return nil
 }
 
-func (r *resource) toggleVersion(rcvID int, enable bool) error {
+func (r *resource) toggleVersion(rcvID int, enable bool, abortPendingBuilds bool) error {
 	tx, err := r.conn.Begin()
 	if err != nil {
 		return err
@@ -651,12 +856,129 @@ func (r *resource) toggleVersion(rcvID int, enable bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
+	var abortedBuildIDs []int
+	if !enable {
+		if abortPendingBuilds {
+			abortedBuildIDs, err = r.abortPendingBuildsWithVersion(tx, rcvID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = r.invalidateNextBuildInputsWithVersion(tx, rcvID)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = bumpCacheIndex(tx, r.pipelineID)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, buildID := range abortedBuildIDs {
+		err = r.conn.Bus().Notify(buildAbortChannel(buildID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// abortPendingBuildsWithVersion aborts the pending builds of the pipeline's
+// jobs which would use the version for an input of the resource, either
+// because it was overridden to the version when the build was triggered or
+// because the job's next inputs were resolved to it.
+func (r *resource) abortPendingBuildsWithVersion(tx Tx, rcvID int) ([]int, error) {
+	rows, err := jobsQuery.
+		Where(sq.Eq{
+			"j.pipeline_id": r.pipelineID,
+			"j.active":      true,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := scanJobs(r.conn, r.lockFactory, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	buildIDs := []int{}
+	for _, job := range jobs {
+		for _, input := range job.Config().Inputs() {
+			if input.Resource != r.name {
+				continue
+			}
+
+			rows, err := tx.Query(`
+				UPDATE builds b
+				SET aborted = true
+				WHERE b.job_id = $1
+				AND b.status = 'pending'
+				AND NOT b.aborted
+				AND (b.input_artifacts -> $2) IS NULL
+				AND CASE WHEN (b.input_overrides -> $2) IS NOT NULL
+					THEN (b.input_overrides -> $2)::jsonb = (SELECT version::jsonb FROM resource_config_versions WHERE id = $3)
+					ELSE EXISTS (
+						SELECT 1
+						FROM next_build_inputs nbi
+						WHERE nbi.job_id = b.job_id
+						AND nbi.input_name = $2
+						AND nbi.resource_config_version_id = $3
+					)
+				END
+				RETURNING b.id
+			`, job.ID(), input.Name, rcvID)
+			if err != nil {
+				return nil, err
+			}
+
+			for rows.Next() {
+				var buildID int
+				err = rows.Scan(&buildID)
+				if err != nil {
+					Close(rows)
+					return nil, err
+				}
+
+				buildIDs = append(buildIDs, buildID)
+			}
+
+			Close(rows)
+		}
+	}
+
+	return buildIDs, nil
+}
+
+// invalidateNextBuildInputsWithVersion throws away the next inputs of the
+// jobs which were resolved to the version so that they're resolved again.
+func (r *resource) invalidateNextBuildInputsWithVersion(tx Tx, rcvID int) error {
+	_, err := tx.Exec(`
+		WITH invalidated AS (
+			UPDATE jobs
+			SET inputs_determined = false
+			WHERE id IN (
+				SELECT job_id
+				FROM next_build_inputs
+				WHERE resource_id = $1
+				AND resource_config_version_id = $2
+			)
+			RETURNING id
+		)
+		DELETE FROM next_build_inputs
+		WHERE job_id IN (SELECT id FROM invalidated)
+	`, r.id, rcvID)
+	return err
 }
 
 func (r *resource) NotifyScan() error {
@@ -667,10 +989,11 @@ func scanResource(r *resource, row scannable) error {
 	var (
 		configBlob                                                                  []byte
 		checkErr, rcsCheckErr, nonce, rcID, rcScopeID, apiPinnedVersion, pinComment sql.NullString
+		pipelineResourceDefaults, teamResourceDefaults, redactions                  sql.NullString
 		lastCheckStartTime, lastCheckEndTime                                        pq.NullTime
 	)
 
-	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamID, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment)
+	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamID, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment, &pipelineResourceDefaults, &teamResourceDefaults, &redactions)
 	if err != nil {
 		return err
 	}
@@ -678,6 +1001,13 @@ func scanResource(r *resource, row scannable) error {
 	r.lastCheckStartTime = lastCheckStartTime.Time
 	r.lastCheckEndTime = lastCheckEndTime.Time
 
+	if redactions.Valid {
+		err = json.Unmarshal([]byte(redactions.String), &r.redactions)
+		if err != nil {
+			return err
+		}
+	}
+
 	es := r.conn.EncryptionStrategy()
 
 	var noncense *string
@@ -696,14 +1026,35 @@ func scanResource(r *resource, row scannable) error {
 		return err
 	}
 
+	var pipelineDefaults, teamDefaults atc.ResourceDefaults
+	if pipelineResourceDefaults.Valid {
+		err = json.Unmarshal([]byte(pipelineResourceDefaults.String), &pipelineDefaults)
+		if err != nil {
+			return err
+		}
+	}
+
+	if teamResourceDefaults.Valid {
+		err = json.Unmarshal([]byte(teamResourceDefaults.String), &teamDefaults)
+		if err != nil {
+			return err
+		}
+	}
+
 	r.public = config.Public
-	r.source = config.Source
+	r.configSource = config.Source
+
+	// the resource's own source wins over the pipeline's defaults, which in
+	// turn win over the team's
+	r.source = teamDefaults.Apply(r.type_, pipelineDefaults.Apply(r.type_, config.Source))
 	r.checkEvery = config.CheckEvery
 	r.checkTimeout = config.CheckTimeout
 	r.tags = config.Tags
 	r.webhookToken = config.WebhookToken
 	r.configPinnedVersion = config.Version
 	r.icon = config.Icon
+	r.mode = config.Mode
+	r.newVersionWebhooks = config.NewVersionWebhooks
 
 	if apiPinnedVersion.Valid {
 		err = json.Unmarshal([]byte(apiPinnedVersion.String), &r.apiPinnedVersion)
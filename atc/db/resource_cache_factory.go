@@ -3,10 +3,12 @@ package db
 import (
 	"database/sql"
 	"encoding/json"
+	"sort"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . ResourceCacheFactory
@@ -27,6 +29,21 @@ type ResourceCacheFactory interface {
 	// method can be removed at that point. See  https://github.com/concourse/concourse/issues/534
 	UpdateResourceCacheMetadata(UsedResourceCache, []atc.MetadataField) error
 	ResourceCacheMetadata(UsedResourceCache) (ResourceConfigMetadataFields, error)
+
+	// UpdateResourceCacheImageMetadata saves the metadata of the image the
+	// resource cache holds, so that containers using the image needn't read
+	// it out of the cache's volume each time.
+	UpdateResourceCacheImageMetadata(UsedResourceCache, []byte) error
+	ResourceCacheImageMetadata(UsedResourceCache) ([]byte, bool, error)
+
+	// CountResourceCacheUse records that the resource cache was used on a
+	// worker belonging to the team (0 for none) and having the tags.
+	CountResourceCacheUse(resourceCache UsedResourceCache, teamID int, workerTags []string) error
+
+	// FindMostUsedResourceCaches returns the resource caches used most on
+	// workers belonging to the team (0 for none) and having the tags, most
+	// used first.
+	FindMostUsedResourceCaches(teamID int, workerTags []string, limit int) ([]UsedResourceCache, error)
 }
 
 type resourceCacheFactory struct {
@@ -121,6 +138,116 @@ func (f *resourceCacheFactory) ResourceCacheMetadata(resourceCache UsedResourceC
 	return metadata, nil
 }
 
+func (f *resourceCacheFactory) UpdateResourceCacheImageMetadata(resourceCache UsedResourceCache, metadata []byte) error {
+	_, err := psql.Update("resource_caches").
+		Set("image_metadata", string(metadata)).
+		Where(sq.Eq{"id": resourceCache.ID()}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *resourceCacheFactory) ResourceCacheImageMetadata(resourceCache UsedResourceCache) ([]byte, bool, error) {
+	var metadata sql.NullString
+	err := psql.Select("image_metadata").
+		From("resource_caches").
+		Where(sq.Eq{"id": resourceCache.ID()}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&metadata)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	if !metadata.Valid {
+		return nil, false, nil
+	}
+
+	return []byte(metadata.String), true, nil
+}
+
+func (f *resourceCacheFactory) CountResourceCacheUse(resourceCache UsedResourceCache, teamID int, workerTags []string) error {
+	var team sql.NullInt64
+	if teamID != 0 {
+		team = sql.NullInt64{Int64: int64(teamID), Valid: true}
+	}
+
+	_, err := f.conn.Exec(`
+		INSERT INTO resource_cache_usages (resource_cache_id, team_id, worker_tags, uses)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (resource_cache_id, COALESCE(team_id, 0), worker_tags) DO UPDATE SET
+			uses = resource_cache_usages.uses + 1,
+			last_used = now()
+	`, resourceCache.ID(), team, pq.Array(sortedTags(workerTags)))
+	return err
+}
+
+func (f *resourceCacheFactory) FindMostUsedResourceCaches(teamID int, workerTags []string, limit int) ([]UsedResourceCache, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("resource_cache_id").
+		From("resource_cache_usages").
+		Where(sq.Expr("COALESCE(team_id, 0) = ?", teamID)).
+		Where(sq.Expr("worker_tags = ?", pq.Array(sortedTags(workerTags)))).
+		OrderBy("uses DESC", "last_used DESC").
+		Limit(uint64(limit)).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	Close(rows)
+
+	var resourceCaches []UsedResourceCache
+	for _, id := range ids {
+		resourceCache, found, err := findResourceCacheByID(tx, id, f.lockFactory, f.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			resourceCaches = append(resourceCaches, resourceCache)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceCaches, nil
+}
+
+// sortedTags returns a sorted copy of the tags, so that workers with the same
+// tags in a different order share their usage.
+func sortedTags(tags []string) []string {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	return sorted
+}
+
 func findResourceCacheByID(tx Tx, resourceCacheID int, lock lock.LockFactory, conn Conn) (UsedResourceCache, bool, error) {
 	var rcID int
 	var versionBytes string
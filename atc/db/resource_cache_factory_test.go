@@ -288,6 +288,101 @@ var _ = Describe("ResourceCacheFactory", func() {
 		})
 	})
 
+	Describe("ResourceCacheImageMetadata", func() {
+		var resourceCache db.UsedResourceCache
+
+		BeforeEach(func() {
+			var err error
+			resourceCache, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-base-resource-type",
+				atc.Version{"some": "version"},
+				atc.Source{"some": "source"},
+				atc.Params{},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is not found until it is saved", func() {
+			_, found, err := resourceCacheFactory.ResourceCacheImageMetadata(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			err = resourceCacheFactory.UpdateResourceCacheImageMetadata(resourceCache, []byte(`{"env":["A=1"],"user":"someone"}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			metadata, found, err := resourceCacheFactory.ResourceCacheImageMetadata(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(metadata).To(MatchJSON(`{"env":["A=1"],"user":"someone"}`))
+		})
+	})
+
+	Describe("FindMostUsedResourceCaches", func() {
+		var (
+			someCache  db.UsedResourceCache
+			otherCache db.UsedResourceCache
+		)
+
+		BeforeEach(func() {
+			var err error
+			someCache, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-base-resource-type",
+				atc.Version{"some": "version"},
+				atc.Source{"some": "source"},
+				atc.Params{},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherCache, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-base-resource-type",
+				atc.Version{"some": "other-version"},
+				atc.Source{"some": "source"},
+				atc.Params{},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(resourceCacheFactory.CountResourceCacheUse(someCache, 0, []string{"b", "a"})).To(Succeed())
+			Expect(resourceCacheFactory.CountResourceCacheUse(otherCache, 0, []string{"a", "b"})).To(Succeed())
+			Expect(resourceCacheFactory.CountResourceCacheUse(otherCache, 0, []string{"a", "b"})).To(Succeed())
+			Expect(resourceCacheFactory.CountResourceCacheUse(someCache, defaultTeam.ID(), []string{"a", "b"})).To(Succeed())
+			Expect(resourceCacheFactory.CountResourceCacheUse(someCache, 0, nil)).To(Succeed())
+		})
+
+		It("returns the caches used most on workers with the same team and tags", func() {
+			resourceCaches, err := resourceCacheFactory.FindMostUsedResourceCaches(0, []string{"a", "b"}, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceCaches).To(HaveLen(2))
+			Expect(resourceCaches[0].ID()).To(Equal(otherCache.ID()))
+			Expect(resourceCaches[1].ID()).To(Equal(someCache.ID()))
+		})
+
+		It("returns no more than the limit", func() {
+			resourceCaches, err := resourceCacheFactory.FindMostUsedResourceCaches(0, []string{"a", "b"}, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceCaches).To(HaveLen(1))
+			Expect(resourceCaches[0].ID()).To(Equal(otherCache.ID()))
+		})
+
+		It("keeps the usage of team workers separate", func() {
+			resourceCaches, err := resourceCacheFactory.FindMostUsedResourceCaches(defaultTeam.ID(), []string{"a", "b"}, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceCaches).To(HaveLen(1))
+			Expect(resourceCaches[0].ID()).To(Equal(someCache.ID()))
+		})
+
+		It("keeps the usage of untagged workers separate", func() {
+			resourceCaches, err := resourceCacheFactory.FindMostUsedResourceCaches(0, nil, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceCaches).To(HaveLen(1))
+			Expect(resourceCaches[0].ID()).To(Equal(someCache.ID()))
+		})
+	})
 })
 
 type resourceCache struct {
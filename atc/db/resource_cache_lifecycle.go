@@ -14,6 +14,10 @@ type ResourceCacheLifecycle interface {
 	CleanUsesForFinishedBuilds(lager.Logger) error
 	CleanBuildImageResourceCaches(lager.Logger) error
 	CleanUpInvalidCaches(lager.Logger) error
+
+	// ExistingResourceCacheIDs returns those of the given IDs whose resource
+	// caches still exist.
+	ExistingResourceCacheIDs(lager.Logger, []int) ([]int, error)
 }
 
 type resourceCacheLifecycle struct {
@@ -132,6 +136,36 @@ func (f *resourceCacheLifecycle) CleanUpInvalidCaches(logger lager.Logger) error
 	return nil
 }
 
+func (f *resourceCacheLifecycle) ExistingResourceCacheIDs(logger lager.Logger, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := psql.Select("id").
+		From("resource_caches").
+		Where(sq.Eq{"id": ids}).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var existingIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		existingIDs = append(existingIDs, id)
+	}
+
+	return existingIDs, nil
+}
+
 func (f *resourceCacheLifecycle) CleanUsesForPausedPipelineResources() error {
 	pausedPipelineIds, _, err := sq.
 		Select("id").
@@ -184,6 +184,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 				containerOwner = db.NewResourceConfigCheckSessionContainerOwner(
 					resourceConfig.ID(),
 					resourceConfig.OriginBaseResourceType().ID,
+					defaultTeam.ID(),
 					db.ContainerOwnerExpiries{},
 				)
 
@@ -304,6 +305,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 				containerOwner := db.NewResourceConfigCheckSessionContainerOwner(
 					resourceConfigScope.ResourceConfig().ID(),
 					resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+					defaultTeam.ID(),
 					db.ContainerOwnerExpiries{},
 				)
 
@@ -345,6 +347,29 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 			})
 		})
 	})
+
+	Describe("ExistingResourceCacheIDs", func() {
+		var existingCache db.UsedResourceCache
+
+		BeforeEach(func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			existingCache = createResourceCacheWithUser(db.ForBuild(build.ID()))
+		})
+
+		It("returns only the IDs of caches which still exist", func() {
+			ids, err := resourceCacheLifecycle.ExistingResourceCacheIDs(logger, []int{existingCache.ID(), existingCache.ID() + 1000})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(Equal([]int{existingCache.ID()}))
+		})
+
+		It("returns nothing when given no IDs", func() {
+			ids, err := resourceCacheLifecycle.ExistingResourceCacheIDs(logger, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(BeEmpty())
+		})
+	})
 })
 
 func countResourceCaches() int {
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/vars"
 )
 
 type BaseResourceTypeNotFoundError struct {
@@ -286,15 +288,19 @@ func findOrCreateResourceConfigScope(tx Tx, conn Conn, lockFactory lock.LockFact
 	var uniqueResource Resource
 	var resourceID *int
 	if resource != nil {
-		if !atc.EnableGlobalResources {
-			unique = true
-		} else {
-			customType, found := resourceTypes.Lookup(resource.Type())
-			if found {
+		customType, isCustomType := resourceTypes.Lookup(resource.Type())
+
+		switch {
+		case atc.EnableGlobalResources:
+			if isCustomType {
 				unique = customType.UniqueVersionHistory
 			} else {
 				unique = resourceConfig.CreatedByBaseResourceType().UniqueVersionHistory
 			}
+		case atc.EnableGlobalPublicResources && !isCustomType && !referencesCredentials(resource.Source()):
+			unique = resourceConfig.CreatedByBaseResourceType().UniqueVersionHistory
+		default:
+			unique = true
 		}
 
 		if unique {
@@ -391,3 +397,15 @@ func findOrCreateResourceConfigScope(tx Tx, conn Conn, lockFactory lock.LockFact
 		lockFactory:    lockFactory,
 	}, nil
 }
+
+// referencesCredentials reports whether the source has any ((var)) references,
+// which are interpolated from the team's credential manager.
+func referencesCredentials(source atc.Source) bool {
+	payload, err := json.Marshal(source)
+	if err != nil {
+		return true
+	}
+
+	_, err = vars.NewTemplate(payload).Evaluate(vars.StaticVariables{}, vars.EvaluateOpts{ExpectAllKeys: true})
+	return err != nil
+}
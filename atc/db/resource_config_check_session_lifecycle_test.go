@@ -34,6 +34,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 				owner := db.NewResourceConfigCheckSessionContainerOwner(
 					resourceConfigScope.ResourceConfig().ID(),
 					resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+					defaultTeam.ID(),
 					expiry,
 				)
 
@@ -135,6 +136,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 				owner := db.NewResourceConfigCheckSessionContainerOwner(
 					resourceConfigScope.ResourceConfig().ID(),
 					resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+					defaultTeam.ID(),
 					expiry,
 				)
 
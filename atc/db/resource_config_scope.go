@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
@@ -27,10 +28,12 @@ type ResourceConfigScope interface {
 	CheckError() error
 
 	SaveVersions(versions []atc.Version) error
+	SaveDeletedVersions(versions []atc.Version) error
 	FindVersion(atc.Version) (ResourceConfigVersion, bool, error)
 	LatestVersion() (ResourceConfigVersion, bool, error)
 
 	SetCheckError(error) error
+	RecordCheck(startTime time.Time, workerName string, output string, checkErr error) error
 
 	AcquireResourceCheckingLock(
 		logger lager.Logger,
@@ -42,6 +45,8 @@ type ResourceConfigScope interface {
 	) (bool, error)
 
 	UpdateLastCheckEndTime() (bool, error)
+
+	UpdateCredentials([]creds.Credential) (bool, error)
 }
 
 type resourceConfigScope struct {
@@ -114,6 +119,66 @@ func saveVersions(conn Conn, rcsID int, versions []atc.Version) error {
 	return nil
 }
 
+// SaveDeletedVersions marks versions that the resource reported as no longer
+// existing. Deleted versions are no longer candidates for job inputs, other
+// than those configured with `version: deleted`. A deleted version that is
+// later saved again becomes a regular version.
+func (r *resourceConfigScope) SaveDeletedVersions(versions []atc.Version) error {
+	return saveDeletedVersions(r.conn, r.ID(), versions)
+}
+
+func saveDeletedVersions(conn Conn, rcsID int, versions []atc.Version) error {
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var bumpCache bool
+
+	for _, version := range versions {
+		versionJSON, err := json.Marshal(version)
+		if err != nil {
+			return err
+		}
+
+		result, err := psql.Update("resource_config_versions").
+			Set("deleted_at", sq.Expr("now()")).
+			Where(sq.Eq{
+				"resource_config_scope_id": rcsID,
+				"deleted_at":               nil,
+			}).
+			Where(sq.Expr("version_md5 = md5(?)", string(versionJSON))).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		bumpCache = bumpCache || rowsAffected > 0
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if bumpCache {
+		err = bumpCacheIndexForPipelinesUsingResourceConfigScope(conn, rcsID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *resourceConfigScope) FindVersion(v atc.Version) (ResourceConfigVersion, bool, error) {
 	rcv := &resourceConfigVersion{
 		resourceConfigScope: r,
@@ -188,6 +253,45 @@ func (r *resourceConfigScope) SetCheckError(cause error) error {
 	return err
 }
 
+// RecordCheck records a check of the scope which has already run, i.e. one
+// run by radar rather than created as a check to be run by lidar, so that it
+// is listed along with the scope's other checks.
+func (r *resourceConfigScope) RecordCheck(startTime time.Time, workerName string, output string, checkErr error) error {
+	status := CheckStatusSucceeded
+	var checkError interface{}
+	if checkErr != nil {
+		status = CheckStatusErrored
+		checkError = checkErr.Error()
+	}
+
+	_, err := psql.Insert("checks").
+		Columns(
+			"resource_config_scope_id",
+			"schema",
+			"status",
+			"check_error",
+			"create_time",
+			"start_time",
+			"end_time",
+			"worker_name",
+			"output",
+		).
+		Values(
+			r.id,
+			schema,
+			status,
+			checkError,
+			startTime,
+			startTime,
+			sq.Expr("now()"),
+			workerName,
+			output,
+		).
+		RunWith(r.conn).
+		Exec()
+	return err
+}
+
 func (r *resourceConfigScope) AcquireResourceCheckingLock(
 	logger lager.Logger,
 ) (lock.Lock, bool, error) {
@@ -266,6 +370,95 @@ func (r *resourceConfigScope) UpdateLastCheckEndTime() (bool, error) {
 	return true, nil
 }
 
+// UpdateCredentials records the credentials interpolated into the scope's
+// source. If a credential was already recorded with another version it has
+// been rotated, so the resource config's check sessions are removed, and their
+// containers with them, rather than checking with containers configured with
+// the old secret. It returns whether any credential was rotated.
+//
+// Credentials are only ever added to the record, as a scope may be shared by
+// resources which read the same source from different secrets.
+func (r *resourceConfigScope) UpdateCredentials(credentials []creds.Credential) (bool, error) {
+	if len(credentials) == 0 {
+		return false, nil
+	}
+
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var recordedJSON []byte
+	err = psql.Select("credentials").
+		From("resource_config_scopes").
+		Where(sq.Eq{"id": r.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&recordedJSON)
+	if err != nil {
+		return false, err
+	}
+
+	recorded := map[string]string{}
+	err = json.Unmarshal(recordedJSON, &recorded)
+	if err != nil {
+		return false, err
+	}
+
+	var changed, rotated bool
+	for _, credential := range credentials {
+		version, found := recorded[credential.Path]
+		if found && version == credential.Version {
+			continue
+		}
+
+		if found {
+			rotated = true
+		}
+
+		changed = true
+		recorded[credential.Path] = credential.Version
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	recordedJSON, err = json.Marshal(recorded)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = psql.Update("resource_config_scopes").
+		Set("credentials", recordedJSON).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	if rotated {
+		_, err = psql.Delete("resource_config_check_sessions").
+			Where(sq.Eq{"resource_config_id": r.resourceConfig.ID()}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return rotated, nil
+}
+
 func saveResourceVersion(tx Tx, rcsID int, version atc.Version, metadata ResourceConfigMetadataFields) (bool, error) {
 	versionJSON, err := json.Marshal(version)
 	if err != nil {
@@ -282,7 +475,7 @@ func saveResourceVersion(tx Tx, rcsID int, version atc.Version, metadata Resourc
 		INSERT INTO resource_config_versions (resource_config_scope_id, version, version_md5, metadata)
 		SELECT $1, $2, md5($3), $4
 		ON CONFLICT (resource_config_scope_id, version_md5)
-		DO UPDATE SET metadata = COALESCE(NULLIF(excluded.metadata, 'null'::jsonb), resource_config_versions.metadata)
+		DO UPDATE SET metadata = COALESCE(NULLIF(excluded.metadata, 'null'::jsonb), resource_config_versions.metadata), deleted_at = NULL
 		RETURNING check_order
 		`, rcsID, string(versionJSON), string(versionJSON), string(metadataJSON)).Scan(&checkOrder)
 	if err != nil {
@@ -1,9 +1,11 @@
 package db_test
 
 import (
+	"errors"
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	. "github.com/onsi/ginkgo"
@@ -142,6 +144,50 @@ var _ = Describe("Resource Config Scope", func() {
 		})
 	})
 
+	Describe("SaveDeletedVersions", func() {
+		var resourceID int
+
+		BeforeEach(func() {
+			err := resourceScope.SaveVersions([]atc.Version{
+				{"ref": "v1"},
+				{"ref": "v2"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceID = resource.ID()
+
+			err = resourceScope.SaveDeletedVersions([]atc.Version{{"ref": "v1"}})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("separates the deleted versions from the candidates", func() {
+			versionsDB, err := pipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versionsDB.AllVersionsOfResource(resourceID).Len()).To(Equal(1))
+			Expect(versionsDB.AllDeletedVersionsOfResource(resourceID).Len()).To(Equal(1))
+		})
+
+		Context("when a deleted version is saved again", func() {
+			BeforeEach(func() {
+				err := resourceScope.SaveVersions([]atc.Version{{"ref": "v1"}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer deleted", func() {
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(versionsDB.AllVersionsOfResource(resourceID).Len()).To(Equal(2))
+				Expect(versionsDB.AllDeletedVersionsOfResource(resourceID).IsEmpty()).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("LatestVersion", func() {
 		Context("when the resource config exists", func() {
 			var latestCV db.ResourceConfigVersion
@@ -327,6 +373,112 @@ var _ = Describe("Resource Config Scope", func() {
 		})
 	})
 
+	Describe("RecordCheck", func() {
+		var startTime time.Time
+
+		BeforeEach(func() {
+			startTime = time.Now().Add(-time.Minute).Truncate(time.Second)
+		})
+
+		It("lists the check with the resource's checks", func() {
+			err := resourceScope.RecordCheck(startTime, "some-worker", "some-output", errors.New("some-error"))
+			Expect(err).ToNot(HaveOccurred())
+
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			checks, err := resource.Checks(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checks).To(HaveLen(1))
+
+			Expect(checks[0].Status()).To(Equal(db.CheckStatusErrored))
+			Expect(checks[0].CheckError()).To(MatchError("some-error"))
+			Expect(checks[0].WorkerName()).To(Equal("some-worker"))
+			Expect(checks[0].Output()).To(Equal("some-output"))
+			Expect(checks[0].StartTime()).To(BeTemporally("==", startTime))
+			Expect(checks[0].EndTime()).To(BeTemporally("~", time.Now(), time.Second))
+		})
+
+		It("is not started by the checker", func() {
+			err := resourceScope.RecordCheck(startTime, "some-worker", "", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			checks, err := checkFactory.StartedChecks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checks).To(BeEmpty())
+		})
+	})
+
+	Describe("UpdateCredentials", func() {
+		var (
+			resourceConfigScope db.ResourceConfigScope
+			owner               db.ContainerOwner
+		)
+
+		BeforeEach(func() {
+			var err error
+			resourceConfigScope, err = defaultResource.SetResourceConfig(defaultResource.Source(), atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			owner = db.NewResourceConfigCheckSessionContainerOwner(
+				resourceConfigScope.ResourceConfig().ID(),
+				resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+				defaultTeam.ID(),
+				db.ContainerOwnerExpiries{Min: time.Minute, Max: time.Minute},
+			)
+
+			tx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = owner.Create(tx, defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tx.Commit()).To(Succeed())
+
+			rotated, err := resourceConfigScope.UpdateCredentials([]creds.Credential{
+				{Path: "/concourse/some-secret", Version: "1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeFalse())
+		})
+
+		It("keeps the check sessions when the credentials are the same", func() {
+			rotated, err := resourceConfigScope.UpdateCredentials([]creds.Credential{
+				{Path: "/concourse/some-secret", Version: "1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeFalse())
+
+			_, found, err := owner.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("keeps the check sessions when other credentials are added", func() {
+			rotated, err := resourceConfigScope.UpdateCredentials([]creds.Credential{
+				{Path: "/concourse/other-secret", Version: "1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeFalse())
+
+			_, found, err := owner.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("removes the check sessions when a credential is rotated", func() {
+			rotated, err := resourceConfigScope.UpdateCredentials([]creds.Credential{
+				{Path: "/concourse/some-secret", Version: "2"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeTrue())
+
+			_, found, err := owner.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
 	Describe("AcquireResourceCheckingLock", func() {
 		var (
 			someResource        db.Resource
@@ -58,6 +58,7 @@ func (r *resourceFactory) VisibleResources(teamNames []string) ([]Resource, erro
 				sq.Eq{"p.public": true},
 			},
 		}).
+		Where(sq.Eq{"t.deleted_at": nil}).
 		OrderBy("r.id ASC").
 		RunWith(r.conn).
 		Query()
@@ -70,6 +71,7 @@ func (r *resourceFactory) VisibleResources(teamNames []string) ([]Resource, erro
 
 func (r *resourceFactory) AllResources() ([]Resource, error) {
 	rows, err := resourcesQuery.
+		Where(sq.Eq{"t.deleted_at": nil}).
 		OrderBy("r.id ASC").
 		RunWith(r.conn).
 		Query()
@@ -97,6 +97,76 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("resource defaults", func() {
+		BeforeEach(func() {
+			err := defaultTeam.UpdateResourceDefaults(atc.ResourceDefaults{
+				"git": atc.Source{
+					"some":        "team-repository",
+					"team-only":   "team-value",
+					"team-shared": "team-value",
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, _, err = defaultTeam.SavePipeline(
+				"pipeline-with-resources",
+				atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-other-resource",
+							Type:   "git",
+							Source: atc.Source{"some": "other-repository"},
+						},
+						{
+							Name:   "some-resource",
+							Type:   "registry-image",
+							Source: atc.Source{"some": "repository"},
+						},
+					},
+					ResourceDefaults: atc.ResourceDefaults{
+						"git": atc.Source{
+							"some":        "pipeline-repository",
+							"team-shared": "pipeline-value",
+						},
+					},
+				},
+				pipeline.ConfigVersion(),
+				false,
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("merges the pipeline and team defaults into the resource's source", func() {
+			resource, found, err := pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(resource.Source()).To(Equal(atc.Source{
+				"some":        "other-repository",
+				"team-only":   "team-value",
+				"team-shared": "pipeline-value",
+			}))
+			Expect(resource.ConfigSource()).To(Equal(atc.Source{"some": "other-repository"}))
+		})
+
+		It("leaves resources of other types alone", func() {
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(resource.Source()).To(Equal(atc.Source{"some": "repository"}))
+		})
+
+		It("is saved with the pipeline", func() {
+			Expect(pipeline.ResourceDefaults()).To(Equal(atc.ResourceDefaults{
+				"git": atc.Source{
+					"some":        "pipeline-repository",
+					"team-shared": "pipeline-value",
+				},
+			}))
+		})
+	})
+
 	Describe("(Pipeline).Resource", func() {
 		var (
 			err      error
@@ -232,6 +302,11 @@ var _ = Describe("Resource", func() {
 						Type:   "some-resourceType",
 						Source: atc.Source{"some": "repository"},
 					},
+					{
+						Name:   "secret-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "((repository))"},
+					},
 				},
 			}
 
@@ -573,6 +648,88 @@ var _ = Describe("Resource", func() {
 				})
 			})
 		})
+
+		Context("when the enable global public resources flag is set to true", func() {
+			var (
+				resource1 db.Resource
+				resource2 db.Resource
+			)
+
+			BeforeEach(func() {
+				atc.EnableGlobalResources = false
+				atc.EnableGlobalPublicResources = true
+
+				setupTx, err := dbConn.Begin()
+				Expect(err).ToNot(HaveOccurred())
+
+				brt := db.BaseResourceType{
+					Name: "some-type",
+				}
+
+				_, err = brt.FindOrCreate(setupTx, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(setupTx.Commit()).To(Succeed())
+
+				var found bool
+				resource1, found, err = pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = resource1.SetResourceConfig(atc.Source{"some": "repository"}, atc.VersionedResourceTypes{})
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err = resource1.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			AfterEach(func() {
+				atc.EnableGlobalPublicResources = false
+			})
+
+			Context("when another resource with a source without credentials uses the same resource config", func() {
+				BeforeEach(func() {
+					var found bool
+					var err error
+					resource2, found, err = pipeline.Resource("some-other-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					_, err = resource2.SetResourceConfig(atc.Source{"some": "repository"}, atc.VersionedResourceTypes{})
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err = resource2.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("shares a version history with the first resource", func() {
+					Expect(resource1.ResourceConfigScopeID()).To(Equal(resource2.ResourceConfigScopeID()))
+				})
+			})
+
+			Context("when another resource with a source referencing credentials uses the same resource config", func() {
+				BeforeEach(func() {
+					var found bool
+					var err error
+					resource2, found, err = pipeline.Resource("secret-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					_, err = resource2.SetResourceConfig(atc.Source{"some": "repository"}, atc.VersionedResourceTypes{})
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err = resource2.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("has a unique version history", func() {
+					Expect(resource1.ResourceConfigID()).To(Equal(resource2.ResourceConfigID()))
+					Expect(resource1.ResourceConfigScopeID()).ToNot(Equal(resource2.ResourceConfigScopeID()))
+				})
+			})
+		})
 	})
 
 	Describe("SetCheckSetupError", func() {
@@ -1091,6 +1248,29 @@ var _ = Describe("Resource", func() {
 					Expect(historyPage[0].Metadata).To(Equal([]atc.MetadataField{{Name: "name1", Value: "value1"}}))
 				})
 			})
+
+			Context("when the version metadata is updated by id", func() {
+				It("returns a version with metadata updated", func() {
+					metadata := db.ResourceConfigMetadataFields{{Name: "name2", Value: "value2"}}
+
+					updated, err := resource.UpdateVersionMetadata(resourceVersions[9].ID, metadata)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(updated).To(BeTrue())
+
+					historyPage, _, found, err := resource.Versions(db.Page{Limit: 1}, nil)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(len(historyPage)).To(Equal(1))
+					Expect(historyPage[0].Version).To(Equal(resourceVersions[9].Version))
+					Expect(historyPage[0].Metadata).To(Equal([]atc.MetadataField{{Name: "name2", Value: "value2"}}))
+				})
+
+				It("returns false when the version does not exist", func() {
+					updated, err := resource.UpdateVersionMetadata(resourceVersions[9].ID+1000, nil)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(updated).To(BeFalse())
+				})
+			})
 		})
 
 		Context("when check orders are different than versions ids", func() {
@@ -1264,6 +1444,246 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("DisableVersion", func() {
+		var (
+			resource       db.Resource
+			job            db.Job
+			otherJob       db.Job
+			disabledRCV    db.ResourceConfigVersion
+			otherRCV       db.ResourceConfigVersion
+			pendingBuild   db.Build
+			overrideBuild  db.Build
+			otherOverride  db.Build
+			disableVersion func(int) error
+		)
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "git",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			pipeline, _, err = defaultTeam.SavePipeline(
+				"pipeline-with-resources",
+				atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "git",
+							Source: atc.Source{"some": "repository"},
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							Plan: atc.PlanSequence{{Get: "some-input", Resource: "some-resource"}},
+						},
+						{
+							Name: "some-other-job",
+							Plan: atc.PlanSequence{{Get: "some-resource"}},
+						},
+					},
+				},
+				pipeline.ConfigVersion(),
+				false,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherJob, found, err = pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceScope, err := resource.SetResourceConfig(atc.Source{"some": "repository"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceScope.SaveVersions([]atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			disabledRCV, found, err = resourceScope.FindVersion(atc.Version{"ref": "v2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherRCV, found, err = resourceScope.FindVersion(atc.Version{"ref": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = job.SaveNextInputMapping(algorithm.InputMapping{
+				"some-input": {VersionID: disabledRCV.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = otherJob.SaveNextInputMapping(algorithm.InputMapping{
+				"some-resource": {VersionID: otherRCV.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			pendingBuild, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			overrideBuild, err = otherJob.CreateBuildWithOverrides(atc.InputVersionOverrides{
+				"some-resource": atc.Version{"ref": "v2"},
+			}, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherOverride, err = job.CreateBuildWithOverrides(atc.InputVersionOverrides{
+				"some-input": atc.Version{"ref": "v1"},
+			}, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			disableVersion = resource.DisableVersion
+		})
+
+		JustBeforeEach(func() {
+			Expect(disableVersion(disabledRCV.ID())).To(Succeed())
+		})
+
+		It("throws away the next inputs of the jobs which were resolved to it", func() {
+			_, found, err := job.GetNextBuildInputs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("keeps the next inputs of the jobs which were resolved to other versions", func() {
+			inputs, found, err := otherJob.GetNextBuildInputs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(inputs).To(HaveLen(1))
+		})
+
+		It("does not abort pending builds", func() {
+			for _, build := range []db.Build{pendingBuild, overrideBuild, otherOverride} {
+				_, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.IsAborted()).To(BeFalse())
+			}
+		})
+
+		Context("when aborting pending builds", func() {
+			BeforeEach(func() {
+				disableVersion = resource.DisableVersionAndAbortPendingBuilds
+			})
+
+			It("aborts the builds whose next inputs were resolved to it", func() {
+				_, err := pendingBuild.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuild.IsAborted()).To(BeTrue())
+			})
+
+			It("aborts the builds which override an input to it", func() {
+				_, err := overrideBuild.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(overrideBuild.IsAborted()).To(BeTrue())
+			})
+
+			It("does not abort the builds which override the input to another version", func() {
+				_, err := otherOverride.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(otherOverride.IsAborted()).To(BeFalse())
+			})
+
+			It("still throws away the next inputs", func() {
+				_, found, err := job.GetNextBuildInputs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("VersionsInSpan", func() {
+		var (
+			resource   db.Resource
+			versionIDs []int
+		)
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "git",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceScope, err := resource.SetResourceConfig(atc.Source{"some": "other-repository"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			versions := []atc.Version{
+				{"ref": "v0"},
+				{"ref": "v1"},
+				{"ref": "v2"},
+				{"ref": "v3"},
+			}
+
+			err = resourceScope.SaveVersions(versions)
+			Expect(err).ToNot(HaveOccurred())
+
+			versionIDs = []int{}
+			for _, version := range versions {
+				rcv, found, err := resourceScope.FindVersion(version)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versionIDs = append(versionIDs, rcv.ID())
+			}
+		})
+
+		spannedRefs := func(span db.VersionSpan) []string {
+			versions, found, err := resource.VersionsInSpan(span)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			refs := []string{}
+			for _, version := range versions {
+				refs = append(refs, version.Version["ref"])
+			}
+
+			return refs
+		}
+
+		It("returns the versions between inclusive bounds, newest first", func() {
+			Expect(spannedRefs(db.VersionSpan{From: versionIDs[1], To: versionIDs[2]})).To(Equal([]string{"v2", "v1"}))
+		})
+
+		It("returns the versions between exclusive bounds", func() {
+			Expect(spannedRefs(db.VersionSpan{Since: versionIDs[0], Until: versionIDs[3]})).To(Equal([]string{"v2", "v1"}))
+		})
+
+		It("returns the versions up to the latest without an upper bound", func() {
+			Expect(spannedRefs(db.VersionSpan{Since: versionIDs[1]})).To(Equal([]string{"v3", "v2"}))
+		})
+
+		Context("when a bound is not a version of the resource", func() {
+			It("returns not found", func() {
+				_, found, err := resource.VersionsInSpan(db.VersionSpan{From: versionIDs[0], To: versionIDs[3] + 100})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("PinVersion/UnpinVersion", func() {
 		var resource db.Resource
 		var resID int
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ResourceTypeHealth reports, for each type of resource in the team's
+// pipelines, how its checks and fetches have fared. Checks are counted once
+// for each type of resource using the scope they checked, and only finished
+// checks are counted. Types which are no longer used by any resource are
+// still reported while their checks or fetches are kept.
+func (t *team) ResourceTypeHealth() ([]atc.ResourceTypeHealth, error) {
+	healthByType := map[string]*atc.ResourceTypeHealth{}
+
+	health := func(resourceType string) *atc.ResourceTypeHealth {
+		h, found := healthByType[resourceType]
+		if !found {
+			h = &atc.ResourceTypeHealth{Type: resourceType}
+			healthByType[resourceType] = h
+		}
+
+		return h
+	}
+
+	rows, err := t.conn.Query(`
+		SELECT r.type, count(DISTINCT r.pipeline_id), count(*)
+		FROM resources r
+		JOIN pipelines p ON p.id = r.pipeline_id
+		WHERE p.team_id = $1
+		AND r.active
+		GROUP BY r.type
+	`, t.id)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var resourceType string
+		var pipelines, resources int
+		err = rows.Scan(&resourceType, &pipelines, &resources)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		h := health(resourceType)
+		h.Pipelines = pipelines
+		h.Resources = resources
+	}
+
+	Close(rows)
+
+	rows, err = t.conn.Query(`
+		SELECT c.type, count(*), count(*) FILTER (WHERE c.status = $2),
+			COALESCE(avg(EXTRACT(EPOCH FROM c.end_time - c.start_time)), 0)
+		FROM (
+			SELECT DISTINCT r.type, c.id, c.status, c.start_time, c.end_time
+			FROM checks c
+			JOIN resources r ON r.resource_config_scope_id = c.resource_config_scope_id
+			JOIN pipelines p ON p.id = r.pipeline_id
+			WHERE p.team_id = $1
+			AND r.active
+			AND c.status != $3
+		) c
+		GROUP BY c.type
+	`, t.id, CheckStatusErrored, CheckStatusStarted)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var resourceType string
+		var checks, failed int
+		var average float64
+		err = rows.Scan(&resourceType, &checks, &failed, &average)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		h := health(resourceType)
+		h.Checks = checks
+		h.FailedChecks = failed
+		h.CheckFailureRate = float64(failed) / float64(checks)
+		h.AverageCheckDuration = average
+	}
+
+	Close(rows)
+
+	rows, err = t.conn.Query(`
+		SELECT type, count(*), count(*) FILTER (WHERE NOT succeeded),
+			avg(duration_ms), max(duration_ms)
+		FROM resource_fetches
+		WHERE team_id = $1
+		GROUP BY type
+	`, t.id)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var resourceType string
+		var fetches, failed int
+		var average float64
+		var max int64
+		err = rows.Scan(&resourceType, &fetches, &failed, &average, &max)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		h := health(resourceType)
+		h.Fetches = fetches
+		h.FailedFetches = failed
+		h.FetchFailureRate = float64(failed) / float64(fetches)
+		h.AverageFetchDuration = average / 1000
+		h.MaxFetchDuration = float64(max) / 1000
+	}
+
+	Close(rows)
+
+	healths := make([]atc.ResourceTypeHealth, 0, len(healthByType))
+	for _, h := range healthByType {
+		healths = append(healths, *h)
+	}
+
+	sort.Slice(healths, func(i, j int) bool {
+		return healths[i].Type < healths[j].Type
+	})
+
+	return healths, nil
+}
@@ -25,10 +25,34 @@ type Team interface {
 	Admin() bool
 
 	Auth() atc.TeamAuth
+	MaxBuildLogSize() int64
+	MaxBuildStartsPerMinute() int
+	ResourceDefaults() atc.ResourceDefaults
+	ContainerEnv() map[string]string
+	CACerts() string
+	ContainerDNS() *atc.ContainerDNS
+	CheckPolicy() *atc.CheckPolicy
+	DefaultTaskImage() *atc.ImageResource
+	PipelinesRepo() *atc.PipelinesRepo
+	PipelinesRepoStatus() *atc.PipelinesRepoStatus
+	ConcurrencyPools() atc.ConcurrencyPools
+	ContentScanPolicy() atc.ContentScanPolicy
+	ResourceTypeMappings() atc.ResourceTypeMappings
+	PrivilegedPolicy() *atc.PrivilegedPolicy
+	RequireImageDigests() bool
 
 	Delete() error
 	Rename(string) error
 
+	// SoftDelete deletes the team such that it can be restored until it's
+	// purged. Its pipelines are paused, its running builds are aborted and
+	// its workers are unregistered, but its data is kept.
+	SoftDelete() error
+
+	// Restore undoes the team's deletion, unpausing the pipelines which were
+	// paused by it. It returns false if the team isn't deleted.
+	Restore() (bool, error)
+
 	SavePipeline(
 		pipelineName string,
 		config atc.Config,
@@ -64,6 +88,32 @@ type Team interface {
 	FindWorkerForVolume(handle string) (Worker, bool, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdateMaxBuildLogSize(size int64) error
+	UpdateMaxBuildStartsPerMinute(limit int) error
+	UpdateResourceDefaults(defaults atc.ResourceDefaults) error
+	UpdateContainerEnv(env map[string]string) error
+	UpdateCACerts(certs string) error
+	UpdateContainerDNS(dns *atc.ContainerDNS) error
+	UpdateCheckPolicy(policy *atc.CheckPolicy) error
+	UpdateDefaultTaskImage(image *atc.ImageResource) error
+	UpdatePipelinesRepo(repo *atc.PipelinesRepo) error
+	UpdatePipelinesRepoStatus(status atc.PipelinesRepoStatus) error
+	UpdateConcurrencyPools(pools atc.ConcurrencyPools) error
+	UpdateContentScanPolicy(policy atc.ContentScanPolicy) error
+	UpdateResourceTypeMappings(mappings atc.ResourceTypeMappings) error
+	UpdatePrivilegedPolicy(policy *atc.PrivilegedPolicy) error
+	UpdateRequireImageDigests(require bool) error
+
+	// UpdateSettings updates the team's settings named by the given fields,
+	// as in the JSON representation of the team, to those of the given team.
+	// They're updated together, so either all of them are or none are.
+	UpdateSettings(settings atc.Team, fields []string) error
+
+	ResourceTypeHealth() ([]atc.ResourceTypeHealth, error)
+
+	APITokens() ([]atc.APIToken, error)
+	CreateAPIToken(request atc.APITokenRequest, createdBy string) (atc.APIToken, error)
+	RevokeAPIToken(name string) (bool, error)
 }
 
 type team struct {
@@ -75,13 +125,57 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	maxBuildLogSize         int64
+	maxBuildStartsPerMinute int
+	resourceDefaults        atc.ResourceDefaults
+	containerEnv            map[string]string
+	caCerts                 string
+	containerDNS            *atc.ContainerDNS
+	checkPolicy             *atc.CheckPolicy
+	defaultTaskImage        *atc.ImageResource
+	pipelinesRepo           *atc.PipelinesRepo
+	pipelinesRepoStatus     *atc.PipelinesRepoStatus
+	concurrencyPools        atc.ConcurrencyPools
+	contentScanPolicy       atc.ContentScanPolicy
+	resourceTypeMappings    atc.ResourceTypeMappings
+	privilegedPolicy        *atc.PrivilegedPolicy
+	requireImageDigests     bool
 }
 
 func (t *team) ID() int      { return t.id }
 func (t *team) Name() string { return t.name }
 func (t *team) Admin() bool  { return t.admin }
 
-func (t *team) Auth() atc.TeamAuth { return t.auth }
+func (t *team) Auth() atc.TeamAuth                     { return t.auth }
+func (t *team) MaxBuildLogSize() int64                 { return t.maxBuildLogSize }
+func (t *team) MaxBuildStartsPerMinute() int           { return t.maxBuildStartsPerMinute }
+func (t *team) ResourceDefaults() atc.ResourceDefaults { return t.resourceDefaults }
+func (t *team) ContainerEnv() map[string]string        { return t.containerEnv }
+func (t *team) CACerts() string                        { return t.caCerts }
+func (t *team) ContainerDNS() *atc.ContainerDNS        { return t.containerDNS }
+func (t *team) CheckPolicy() *atc.CheckPolicy          { return t.checkPolicy }
+func (t *team) DefaultTaskImage() *atc.ImageResource   { return t.defaultTaskImage }
+func (t *team) PipelinesRepo() *atc.PipelinesRepo      { return t.pipelinesRepo }
+func (t *team) PipelinesRepoStatus() *atc.PipelinesRepoStatus {
+	return t.pipelinesRepoStatus
+}
+func (t *team) ConcurrencyPools() atc.ConcurrencyPools {
+	return t.concurrencyPools
+}
+func (t *team) ContentScanPolicy() atc.ContentScanPolicy {
+	return t.contentScanPolicy
+}
+func (t *team) ResourceTypeMappings() atc.ResourceTypeMappings {
+	return t.resourceTypeMappings
+}
+func (t *team) PrivilegedPolicy() *atc.PrivilegedPolicy {
+	return t.privilegedPolicy
+}
+
+func (t *team) RequireImageDigests() bool {
+	return t.requireImageDigests
+}
 
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
@@ -94,6 +188,191 @@ func (t *team) Delete() error {
 	return err
 }
 
+func (t *team) SoftDelete() error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Update("pipelines").
+		Set("paused", true).
+		Where(sq.Eq{
+			"team_id": t.id,
+			"paused":  false,
+		}).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	pausedPipelines := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		pausedPipelines = append(pausedPipelines, id)
+	}
+
+	Close(rows)
+
+	payload, err := json.Marshal(pausedPipelines)
+	if err != nil {
+		return err
+	}
+
+	result, err := psql.Update("teams").
+		Set("deleted_at", sq.Expr("now()")).
+		Set("deletion_paused_pipelines", payload).
+		Where(sq.Eq{
+			"id":         t.id,
+			"deleted_at": nil,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	_, err = psql.Delete("workers").
+		Where(sq.Eq{
+			"team_id": t.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rows, err = psql.Update("builds").
+		Set("aborted", true).
+		Where(sq.Eq{
+			"team_id":   t.id,
+			"completed": false,
+			"aborted":   false,
+		}).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	abortedBuildIDs := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		abortedBuildIDs = append(abortedBuildIDs, id)
+	}
+
+	Close(rows)
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, buildID := range abortedBuildIDs {
+		err = t.conn.Bus().Notify(buildAbortChannel(buildID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *team) Restore() (bool, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var payload sql.NullString
+	err = psql.Select("deletion_paused_pipelines").
+		From("teams").
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		Where(sq.NotEq{
+			"deleted_at": nil,
+		}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	_, err = psql.Update("teams").
+		Set("deleted_at", nil).
+		Set("deletion_paused_pipelines", nil).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	var pausedPipelines []int
+	if payload.Valid {
+		err = json.Unmarshal([]byte(payload.String), &pausedPipelines)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(pausedPipelines) > 0 {
+		_, err = psql.Update("pipelines").
+			Set("paused", false).
+			Where(sq.Eq{
+				"team_id": t.id,
+				"id":      pausedPipelines,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (t *team) Rename(name string) error {
 	_, err := psql.Update("teams").
 		Set("name", name).
@@ -206,9 +485,11 @@ func (t *team) Containers() ([]Container, error) {
 		return nil, err
 	}
 
+	// check containers also have the team, but were found above
 	rows, err = selectContainers("c").
 		Where(sq.Eq{
-			"c.team_id": t.id,
+			"c.team_id":                          t.id,
+			"c.resource_config_check_session_id": nil,
 		}).
 		RunWith(t.conn).
 		Query()
@@ -347,6 +628,36 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	resourceDefaultsPayload, err := json.Marshal(config.ResourceDefaults)
+	if err != nil {
+		return nil, false, err
+	}
+
+	containerDNSPayload, err := json.Marshal(config.ContainerDNS)
+	if err != nil {
+		return nil, false, err
+	}
+
+	branchesPayload, err := json.Marshal(config.Branches)
+	if err != nil {
+		return nil, false, err
+	}
+
+	schedulingWindowsPayload, err := json.Marshal(config.SchedulingWindows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	labelsPayload, err := json.Marshal(config.Labels)
+	if err != nil {
+		return nil, false, err
+	}
+
+	redactionsPayload, err := json.Marshal(config.Redactions)
+	if err != nil {
+		return nil, false, err
+	}
+
 	jobGroups := make(map[string][]string)
 	for _, group := range config.Groups {
 		for _, job := range group.Jobs {
@@ -378,12 +689,21 @@ func (t *team) SavePipeline(
 	if existingConfig == 0 {
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"groups":   groupsPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("currval('pipelines_id_seq')"),
-				"paused":   initiallyPaused,
-				"team_id":  t.id,
+				"name":                      pipelineName,
+				"groups":                    groupsPayload,
+				"resource_defaults":         resourceDefaultsPayload,
+				"ignore_team_container_env": config.IgnoreTeamContainerEnv,
+				"container_dns":             containerDNSPayload,
+				"branches":                  branchesPayload,
+				"scheduling_windows":        schedulingWindowsPayload,
+				"labels":                    labelsPayload,
+				"redactions":                redactionsPayload,
+				"timezone":                  config.TimeZone,
+				"locale":                    config.Locale,
+				"version":                   sq.Expr("nextval('config_version_seq')"),
+				"ordering":                  sq.Expr("currval('pipelines_id_seq')"),
+				"paused":                    initiallyPaused,
+				"team_id":                   t.id,
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -396,6 +716,15 @@ func (t *team) SavePipeline(
 	} else {
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
+			Set("resource_defaults", resourceDefaultsPayload).
+			Set("ignore_team_container_env", config.IgnoreTeamContainerEnv).
+			Set("container_dns", containerDNSPayload).
+			Set("branches", branchesPayload).
+			Set("scheduling_windows", schedulingWindowsPayload).
+			Set("labels", labelsPayload).
+			Set("redactions", redactionsPayload).
+			Set("timezone", config.TimeZone).
+			Set("locale", config.Locale).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Where(sq.Eq{
 				"name":    pipelineName,
@@ -753,7 +1082,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, max_build_log_size, max_build_starts_per_minute, resource_defaults, container_env, ca_certs, container_dns, check_policy, default_task_image, pipelines_repo, pipelines_repo_status, concurrency_pools, content_scan_policy, resource_type_mappings, privileged_policy, require_image_digests
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -763,82 +1092,553 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
-func (t *team) FindCheckContainers(pipelineName string, resourceName string, secretManager creds.Secrets) ([]Container, map[int]time.Time, error) {
-	pipeline, found, err := t.Pipeline(pipelineName)
+func (t *team) UpdateMaxBuildLogSize(size int64) error {
+	_, err := psql.Update("teams").
+		Set("max_build_log_size", size).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
 	if err != nil {
-		return nil, nil, err
-	}
-	if !found {
-		return nil, nil, nil
+		return err
 	}
 
-	resource, found, err := pipeline.Resource(resourceName)
+	t.maxBuildLogSize = size
+
+	return nil
+}
+
+func (t *team) UpdateMaxBuildStartsPerMinute(limit int) error {
+	_, err := psql.Update("teams").
+		Set("max_build_starts_per_minute", limit).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
-	if !found {
-		return nil, nil, nil
+
+	t.maxBuildStartsPerMinute = limit
+
+	return nil
+}
+
+func (t *team) UpdateResourceDefaults(defaults atc.ResourceDefaults) error {
+	payload, err := json.Marshal(defaults)
+	if err != nil {
+		return err
 	}
 
-	pipelineResourceTypes, err := pipeline.ResourceTypes()
+	_, err = psql.Update("teams").
+		Set("resource_defaults", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	variables := creds.NewVariables(secretManager, t.name, pipeline.Name())
+	t.resourceDefaults = defaults
 
-	versionedResourceTypes := pipelineResourceTypes.Deserialize()
+	return nil
+}
 
-	source, err := creds.NewSource(variables, resource.Source()).Evaluate()
+func (t *team) UpdateContainerEnv(env map[string]string) error {
+	payload, err := json.Marshal(env)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	resourceTypes, err := creds.NewVersionedResourceTypes(variables, versionedResourceTypes).Evaluate()
+	_, err = psql.Update("teams").
+		Set("container_env", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	resourceConfigFactory := NewResourceConfigFactory(t.conn, t.lockFactory)
-	resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
-		resource.Type(),
-		source,
-		resourceTypes,
-	)
-	if err != nil {
-		return nil, nil, err
-	}
+	t.containerEnv = env
 
-	rows, err := selectContainers("c").
-		Join("resource_config_check_sessions rccs ON rccs.id = c.resource_config_check_session_id").
+	return nil
+}
+
+func (t *team) UpdateCACerts(certs string) error {
+	_, err := psql.Update("teams").
+		Set("ca_certs", certs).
 		Where(sq.Eq{
-			"rccs.resource_config_id": resourceConfig.ID(),
+			"id": t.id,
 		}).
-		Distinct().
 		RunWith(t.conn).
-		Query()
+		Exec()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	var containers []Container
+	t.caCerts = certs
 
-	containers, err = scanContainers(rows, t.conn, containers)
+	return nil
+}
+
+func (t *team) UpdateContainerDNS(dns *atc.ContainerDNS) error {
+	payload, err := json.Marshal(dns)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	rows, err = psql.Select("c.id", "rccs.expires_at").
-		From("containers c").
-		Join("resource_config_check_sessions rccs ON rccs.id = c.resource_config_check_session_id").
+	_, err = psql.Update("teams").
+		Set("container_dns", payload).
 		Where(sq.Eq{
-			"rccs.resource_config_id": resourceConfig.ID(),
+			"id": t.id,
 		}).
-		Distinct().
 		RunWith(t.conn).
-		Query()
+		Exec()
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+
+	t.containerDNS = dns
+
+	return nil
+}
+
+func (t *team) UpdateCheckPolicy(policy *atc.CheckPolicy) error {
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("check_policy", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.checkPolicy = policy
+
+	return nil
+}
+
+func (t *team) UpdateDefaultTaskImage(image *atc.ImageResource) error {
+	payload, err := json.Marshal(image)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("default_task_image", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.defaultTaskImage = image
+
+	return nil
+}
+
+func (t *team) UpdatePipelinesRepo(repo *atc.PipelinesRepo) error {
+	payload, err := json.Marshal(repo)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("pipelines_repo", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.pipelinesRepo = repo
+
+	return nil
+}
+
+func (t *team) UpdatePipelinesRepoStatus(status atc.PipelinesRepoStatus) error {
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("pipelines_repo_status", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.pipelinesRepoStatus = &status
+
+	return nil
+}
+
+func (t *team) UpdateConcurrencyPools(pools atc.ConcurrencyPools) error {
+	payload, err := json.Marshal(pools)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("concurrency_pools", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.concurrencyPools = pools
+
+	return nil
+}
+
+func (t *team) UpdateContentScanPolicy(policy atc.ContentScanPolicy) error {
+	_, err := psql.Update("teams").
+		Set("content_scan_policy", policy).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.contentScanPolicy = policy
+
+	return nil
+}
+
+func (t *team) UpdateResourceTypeMappings(mappings atc.ResourceTypeMappings) error {
+	payload, err := json.Marshal(mappings)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("resource_type_mappings", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.resourceTypeMappings = mappings
+
+	return nil
+}
+
+func (t *team) UpdatePrivilegedPolicy(policy *atc.PrivilegedPolicy) error {
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("privileged_policy", payload).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.privilegedPolicy = policy
+
+	return nil
+}
+
+func (t *team) UpdateRequireImageDigests(require bool) error {
+	_, err := psql.Update("teams").
+		Set("require_image_digests", require).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.requireImageDigests = require
+
+	return nil
+}
+
+func (t *team) UpdateSettings(settings atc.Team, fields []string) error {
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		value, err := teamSettingValue(settings, field)
+		if err != nil {
+			return err
+		}
+
+		values[field] = value
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	// as when updating the provider auth alone
+	if _, found := values["auth"]; found {
+		values["legacy_auth"] = nil
+		values["nonce"] = nil
+	}
+
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	query, args, err := psql.Update("teams").
+		SetMap(values).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		Suffix("RETURNING id, name, admin, auth, nonce, max_build_log_size, max_build_starts_per_minute, resource_defaults, container_env, ca_certs, container_dns, check_policy, default_task_image, pipelines_repo, pipelines_repo_status, concurrency_pools, content_scan_policy, resource_type_mappings, privileged_policy, require_image_digests").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = t.queryTeam(tx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// teamSettingValue returns the value of the team's setting to be saved in the
+// column named by its field.
+func teamSettingValue(settings atc.Team, field string) (interface{}, error) {
+	switch field {
+	case "auth":
+		return json.Marshal(settings.Auth)
+	case "max_build_log_size":
+		return settings.MaxBuildLogSize, nil
+	case "max_build_starts_per_minute":
+		return settings.MaxBuildStartsPerMinute, nil
+	case "resource_defaults":
+		return json.Marshal(settings.ResourceDefaults)
+	case "container_env":
+		return json.Marshal(settings.ContainerEnv)
+	case "ca_certs":
+		return settings.CACerts, nil
+	case "container_dns":
+		return json.Marshal(settings.ContainerDNS)
+	case "check_policy":
+		return json.Marshal(settings.CheckPolicy)
+	case "default_task_image":
+		return json.Marshal(settings.DefaultTaskImage)
+	case "pipelines_repo":
+		return json.Marshal(settings.PipelinesRepo)
+	case "concurrency_pools":
+		return json.Marshal(settings.ConcurrencyPools)
+	case "content_scan_policy":
+		return settings.ContentScanPolicy, nil
+	case "resource_type_mappings":
+		return json.Marshal(settings.ResourceTypeMappings)
+	case "privileged_policy":
+		return json.Marshal(settings.PrivilegedPolicy)
+	case "require_image_digests":
+		return settings.RequireImageDigests, nil
+	default:
+		return nil, fmt.Errorf("unknown team setting: %s", field)
+	}
+}
+
+func (t *team) APITokens() ([]atc.APIToken, error) {
+	rows, err := apiTokensQuery.
+		Where(sq.Eq{"a.team_id": t.id}).
+		OrderBy("a.id").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tokens := []atc.APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (t *team) CreateAPIToken(request atc.APITokenRequest, createdBy string) (atc.APIToken, error) {
+	scopes, err := json.Marshal(request.Scopes)
+	if err != nil {
+		return atc.APIToken{}, err
+	}
+
+	var id int
+	err = psql.Insert("api_tokens").
+		SetMap(map[string]interface{}{
+			"team_id":    t.id,
+			"name":       request.Name,
+			"scopes":     scopes,
+			"expires_at": time.Unix(request.ExpiresAt, 0),
+			"created_by": createdBy,
+		}).
+		Suffix("RETURNING id").
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return atc.APIToken{}, ErrAPITokenExists
+		}
+
+		return atc.APIToken{}, err
+	}
+
+	return scanAPIToken(apiTokensQuery.
+		Where(sq.Eq{"a.id": id}).
+		RunWith(t.conn).
+		QueryRow())
+}
+
+func (t *team) RevokeAPIToken(name string) (bool, error) {
+	result, err := psql.Update("api_tokens").
+		Set("revoked_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"team_id":    t.id,
+			"name":       name,
+			"revoked_at": nil,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (t *team) FindCheckContainers(pipelineName string, resourceName string, secretManager creds.Secrets) ([]Container, map[int]time.Time, error) {
+	pipeline, found, err := t.Pipeline(pipelineName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, nil
+	}
+
+	resource, found, err := pipeline.Resource(resourceName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, nil
+	}
+
+	pipelineResourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := creds.NewVariables(secretManager, t.name, pipeline.Name())
+
+	versionedResourceTypes := pipelineResourceTypes.Deserialize()
+
+	source, err := creds.NewSource(variables, resource.Source()).Evaluate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resourceTypes, err := creds.NewVersionedResourceTypes(variables, versionedResourceTypes).Evaluate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resourceConfigFactory := NewResourceConfigFactory(t.conn, t.lockFactory)
+	resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
+		resource.Type(),
+		source,
+		resourceTypes,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := selectContainers("c").
+		Join("resource_config_check_sessions rccs ON rccs.id = c.resource_config_check_session_id").
+		Where(sq.Eq{
+			"rccs.resource_config_id": resourceConfig.ID(),
+		}).
+		Distinct().
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var containers []Container
+
+	containers, err = scanContainers(rows, t.conn, containers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = psql.Select("c.id", "rccs.expires_at").
+		From("containers c").
+		Join("resource_config_check_sessions rccs ON rccs.id = c.resource_config_check_session_id").
+		Where(sq.Eq{
+			"rccs.resource_config_id": resourceConfig.ID(),
+		}).
+		Distinct().
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	defer Close(rows)
@@ -1145,8 +1945,9 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 }
 
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var groups, resourceDefaults, containerDNS, deprecations, reconciledDigest, branches, branch, schedulingWindows, labels, redactions, timeZone, locale, shareTokenHash sql.NullString
+	var reconciledConfigVersion, parentID sql.NullInt64
+	err := scan.Scan(&p.id, &p.name, &groups, &resourceDefaults, &p.ignoreTeamContainerEnv, &containerDNS, &deprecations, &reconciledDigest, &reconciledConfigVersion, &branches, &parentID, &branch, &schedulingWindows, &labels, &redactions, &timeZone, &locale, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &shareTokenHash)
 	if err != nil {
 		return err
 	}
@@ -1161,6 +1962,66 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.groups = pipelineGroups
 	}
 
+	if resourceDefaults.Valid {
+		var pipelineResourceDefaults atc.ResourceDefaults
+		err = json.Unmarshal([]byte(resourceDefaults.String), &pipelineResourceDefaults)
+		if err != nil {
+			return err
+		}
+
+		p.resourceDefaults = pipelineResourceDefaults
+	}
+
+	if containerDNS.Valid {
+		err = json.Unmarshal([]byte(containerDNS.String), &p.containerDNS)
+		if err != nil {
+			return err
+		}
+	}
+
+	if deprecations.Valid {
+		err = json.Unmarshal([]byte(deprecations.String), &p.deprecations)
+		if err != nil {
+			return err
+		}
+	}
+
+	if branches.Valid {
+		err = json.Unmarshal([]byte(branches.String), &p.branches)
+		if err != nil {
+			return err
+		}
+	}
+
+	if schedulingWindows.Valid {
+		err = json.Unmarshal([]byte(schedulingWindows.String), &p.schedulingWindows)
+		if err != nil {
+			return err
+		}
+	}
+
+	if labels.Valid {
+		err = json.Unmarshal([]byte(labels.String), &p.labels)
+		if err != nil {
+			return err
+		}
+	}
+
+	if redactions.Valid {
+		err = json.Unmarshal([]byte(redactions.String), &p.redactions)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.reconciledDigest = reconciledDigest.String
+	p.reconciledConfigVersion = ConfigVersion(reconciledConfigVersion.Int64)
+	p.parentID = int(parentID.Int64)
+	p.branch = branch.String
+	p.shareTokenHash = shareTokenHash.String
+	p.timeZone = atc.TimeZone(timeZone.String)
+	p.locale = atc.Locale(locale.String)
+
 	return nil
 }
 
@@ -1211,7 +2072,7 @@ func scanContainers(rows *sql.Rows, conn Conn, initContainers []Container) ([]Co
 }
 
 func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
-	var providerAuth, nonce sql.NullString
+	var providerAuth, nonce, resourceDefaults, containerEnv, caCerts, containerDNS, checkPolicy, defaultTaskImage, pipelinesRepo, pipelinesRepoStatus, concurrencyPools, contentScanPolicy, resourceTypeMappings, privilegedPolicy sql.NullString
 
 	err := tx.QueryRow(query, params...).Scan(
 		&t.id,
@@ -1219,6 +2080,21 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.maxBuildLogSize,
+		&t.maxBuildStartsPerMinute,
+		&resourceDefaults,
+		&containerEnv,
+		&caCerts,
+		&containerDNS,
+		&checkPolicy,
+		&defaultTaskImage,
+		&pipelinesRepo,
+		&pipelinesRepoStatus,
+		&concurrencyPools,
+		&contentScanPolicy,
+		&resourceTypeMappings,
+		&privilegedPolicy,
+		&t.requireImageDigests,
 	)
 	if err != nil {
 		return err
@@ -1233,5 +2109,83 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		t.auth = auth
 	}
 
+	if resourceDefaults.Valid {
+		var defaults atc.ResourceDefaults
+		err = json.Unmarshal([]byte(resourceDefaults.String), &defaults)
+		if err != nil {
+			return err
+		}
+		t.resourceDefaults = defaults
+	}
+
+	if containerEnv.Valid {
+		var env map[string]string
+		err = json.Unmarshal([]byte(containerEnv.String), &env)
+		if err != nil {
+			return err
+		}
+		t.containerEnv = env
+	}
+
+	t.caCerts = caCerts.String
+
+	if containerDNS.Valid {
+		err = json.Unmarshal([]byte(containerDNS.String), &t.containerDNS)
+		if err != nil {
+			return err
+		}
+	}
+
+	if checkPolicy.Valid {
+		err = json.Unmarshal([]byte(checkPolicy.String), &t.checkPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	if defaultTaskImage.Valid {
+		err = json.Unmarshal([]byte(defaultTaskImage.String), &t.defaultTaskImage)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pipelinesRepo.Valid {
+		err = json.Unmarshal([]byte(pipelinesRepo.String), &t.pipelinesRepo)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pipelinesRepoStatus.Valid {
+		err = json.Unmarshal([]byte(pipelinesRepoStatus.String), &t.pipelinesRepoStatus)
+		if err != nil {
+			return err
+		}
+	}
+
+	if concurrencyPools.Valid {
+		err = json.Unmarshal([]byte(concurrencyPools.String), &t.concurrencyPools)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.contentScanPolicy = atc.ContentScanPolicy(contentScanPolicy.String)
+
+	if resourceTypeMappings.Valid {
+		err = json.Unmarshal([]byte(resourceTypeMappings.String), &t.resourceTypeMappings)
+		if err != nil {
+			return err
+		}
+	}
+
+	if privilegedPolicy.Valid {
+		err = json.Unmarshal([]byte(privilegedPolicy.String), &t.privilegedPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
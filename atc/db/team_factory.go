@@ -2,7 +2,9 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
 	"strings"
+	"time"
 
 	"encoding/json"
 
@@ -16,9 +18,15 @@ import (
 type TeamFactory interface {
 	CreateTeam(atc.Team) (Team, error)
 	FindTeam(string) (Team, bool, error)
+	FindDeletedTeam(string) (Team, bool, error)
+	FindTeamByID(teamID int) (Team, bool, error)
 	GetTeams() ([]Team, error)
 	GetByID(teamID int) Team
 	CreateDefaultTeamIfNotExists() (Team, error)
+
+	// PurgeDeletedTeams deletes all the data of the teams which were deleted
+	// longer ago than the grace period, returning how many there were.
+	PurgeDeletedTeams(gracePeriod time.Duration) (int, error)
 }
 
 type teamFactory struct {
@@ -50,10 +58,55 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	resourceDefaults, err := json.Marshal(t.ResourceDefaults)
+	if err != nil {
+		return nil, err
+	}
+
+	containerEnv, err := json.Marshal(t.ContainerEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	containerDNS, err := json.Marshal(t.ContainerDNS)
+	if err != nil {
+		return nil, err
+	}
+
+	checkPolicy, err := json.Marshal(t.CheckPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultTaskImage, err := json.Marshal(t.DefaultTaskImage)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelinesRepo, err := json.Marshal(t.PipelinesRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrencyPools, err := json.Marshal(t.ConcurrencyPools)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTypeMappings, err := json.Marshal(t.ResourceTypeMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	privilegedPolicy, err := json.Marshal(t.PrivilegedPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, max_build_log_size, max_build_starts_per_minute, resource_defaults, container_env, ca_certs, container_dns, check_policy, default_task_image, pipelines_repo, concurrency_pools, content_scan_policy, resource_type_mappings, privileged_policy, require_image_digests").
+		Values(t.Name, auth, admin, t.MaxBuildLogSize, t.MaxBuildStartsPerMinute, resourceDefaults, containerEnv, t.CACerts, containerDNS, checkPolicy, defaultTaskImage, pipelinesRepo, concurrencyPools, string(t.ContentScanPolicy), resourceTypeMappings, privilegedPolicy, t.RequireImageDigests).
+		Suffix("RETURNING id, name, admin, auth, max_build_log_size, max_build_starts_per_minute, resource_defaults, container_env, ca_certs, container_dns, check_policy, default_task_image, pipelines_repo, pipelines_repo_status, concurrency_pools, content_scan_policy, resource_type_mappings, privileged_policy, require_image_digests").
 		RunWith(tx).
 		QueryRow()
 
@@ -83,15 +136,32 @@ func (factory *teamFactory) GetByID(teamID int) Team {
 	}
 }
 
+// FindTeam finds the team with the given name, unless it has been deleted.
 func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
+	return factory.findTeam(sq.And{
+		sq.Eq{"LOWER(name)": strings.ToLower(teamName)},
+		sq.Eq{"deleted_at": nil},
+	})
+}
+
+// FindDeletedTeam finds the team with the given name if it has been deleted
+// but not yet purged, so that it can be restored.
+func (factory *teamFactory) FindDeletedTeam(teamName string) (Team, bool, error) {
+	return factory.findTeam(sq.And{
+		sq.Eq{"LOWER(name)": strings.ToLower(teamName)},
+		sq.NotEq{"deleted_at": nil},
+	})
+}
+
+func (factory *teamFactory) findTeam(where sq.Sqlizer) (Team, bool, error) {
 	team := &team{
 		conn:        factory.conn,
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, max_build_log_size, max_build_starts_per_minute, resource_defaults, container_env, ca_certs, container_dns, check_policy, default_task_image, pipelines_repo, pipelines_repo_status, concurrency_pools, content_scan_policy, resource_type_mappings, privileged_policy, require_image_digests").
 		From("teams").
-		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
+		Where(where).
 		RunWith(factory.conn).
 		QueryRow()
 
@@ -107,9 +177,33 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 	return team, true, nil
 }
 
+func (factory *teamFactory) FindTeamByID(teamID int) (Team, bool, error) {
+	team := &team{
+		conn:        factory.conn,
+		lockFactory: factory.lockFactory,
+	}
+
+	row := psql.Select("id, name, admin, auth, max_build_log_size, max_build_starts_per_minute, resource_defaults, container_env, ca_certs, container_dns, check_policy, default_task_image, pipelines_repo, pipelines_repo_status, concurrency_pools, content_scan_policy, resource_type_mappings, privileged_policy, require_image_digests").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(factory.conn).
+		QueryRow()
+
+	err := factory.scanTeam(team, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return team, true, nil
+}
+
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, max_build_log_size, max_build_starts_per_minute, resource_defaults, container_env, ca_certs, container_dns, check_policy, default_task_image, pipelines_repo, pipelines_repo_status, concurrency_pools, content_scan_policy, resource_type_mappings, privileged_policy, require_image_digests").
 		From("teams").
+		Where(sq.Eq{"deleted_at": nil}).
 		OrderBy("id ASC").
 		RunWith(factory.conn).
 		Query()
@@ -148,7 +242,8 @@ func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 		return nil, err
 	}
 
-	t, found, err := factory.FindTeam(atc.DefaultTeamName)
+	// a deleted default team still exists until it's purged
+	t, found, err := factory.findTeam(sq.Eq{"LOWER(name)": strings.ToLower(atc.DefaultTeamName)})
 	if err != nil {
 		return nil, err
 	}
@@ -165,14 +260,47 @@ func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 	)
 }
 
+func (factory *teamFactory) PurgeDeletedTeams(gracePeriod time.Duration) (int, error) {
+	result, err := psql.Delete("teams").
+		Where(sq.NotEq{"deleted_at": nil}).
+		Where(sq.Expr(fmt.Sprintf("now() - deleted_at > '%d seconds'::interval", int(gracePeriod.Seconds())))).
+		RunWith(factory.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(purged), nil
+}
+
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, resourceDefaults, containerEnv, caCerts, containerDNS, checkPolicy, defaultTaskImage, pipelinesRepo, pipelinesRepoStatus, concurrencyPools, contentScanPolicy, resourceTypeMappings, privilegedPolicy sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&t.maxBuildLogSize,
+		&t.maxBuildStartsPerMinute,
+		&resourceDefaults,
+		&containerEnv,
+		&caCerts,
+		&containerDNS,
+		&checkPolicy,
+		&defaultTaskImage,
+		&pipelinesRepo,
+		&pipelinesRepoStatus,
+		&concurrencyPools,
+		&contentScanPolicy,
+		&resourceTypeMappings,
+		&privilegedPolicy,
+		&t.requireImageDigests,
 	)
 
 	if providerAuth.Valid {
@@ -182,5 +310,79 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	if resourceDefaults.Valid {
+		err = json.Unmarshal([]byte(resourceDefaults.String), &t.resourceDefaults)
+		if err != nil {
+			return err
+		}
+	}
+
+	if containerEnv.Valid {
+		err = json.Unmarshal([]byte(containerEnv.String), &t.containerEnv)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.caCerts = caCerts.String
+
+	if containerDNS.Valid {
+		err = json.Unmarshal([]byte(containerDNS.String), &t.containerDNS)
+		if err != nil {
+			return err
+		}
+	}
+
+	if checkPolicy.Valid {
+		err = json.Unmarshal([]byte(checkPolicy.String), &t.checkPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	if defaultTaskImage.Valid {
+		err = json.Unmarshal([]byte(defaultTaskImage.String), &t.defaultTaskImage)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pipelinesRepo.Valid {
+		err = json.Unmarshal([]byte(pipelinesRepo.String), &t.pipelinesRepo)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pipelinesRepoStatus.Valid {
+		err = json.Unmarshal([]byte(pipelinesRepoStatus.String), &t.pipelinesRepoStatus)
+		if err != nil {
+			return err
+		}
+	}
+
+	if concurrencyPools.Valid {
+		err = json.Unmarshal([]byte(concurrencyPools.String), &t.concurrencyPools)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.contentScanPolicy = atc.ContentScanPolicy(contentScanPolicy.String)
+
+	if resourceTypeMappings.Valid {
+		err = json.Unmarshal([]byte(resourceTypeMappings.String), &t.resourceTypeMappings)
+		if err != nil {
+			return err
+		}
+	}
+
+	if privilegedPolicy.Valid {
+		err = json.Unmarshal([]byte(privilegedPolicy.String), &t.privilegedPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -73,6 +75,68 @@ var _ = Describe("Team Factory", func() {
 		})
 	})
 
+	Describe("PurgeDeletedTeams", func() {
+		var team db.Team
+
+		BeforeEach(func() {
+			var err error
+			team, err = teamFactory.CreateTeam(atcTeam)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.SoftDelete()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the team was deleted within the grace period", func() {
+			It("keeps it", func() {
+				purged, err := teamFactory.PurgeDeletedTeams(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(purged).To(BeZero())
+
+				_, found, err := teamFactory.FindDeletedTeam(atcTeam.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the team was deleted longer than the grace period ago", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE teams SET deleted_at = now() - interval '2 hours' WHERE id = $1`, team.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("purges it", func() {
+				purged, err := teamFactory.PurgeDeletedTeams(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(purged).To(Equal(1))
+
+				_, found, err := teamFactory.FindDeletedTeam(atcTeam.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("FindTeamByID", func() {
+		It("finds the team with the id", func() {
+			created, err := teamFactory.CreateTeam(atcTeam)
+			Expect(err).ToNot(HaveOccurred())
+
+			team, found, err := teamFactory.FindTeamByID(created.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(team.Name()).To(Equal(atcTeam.Name))
+			Expect(team.Auth()).To(Equal(atcTeam.Auth))
+		})
+
+		It("returns not found when there is no team with the id", func() {
+			team, found, err := teamFactory.FindTeamByID(999999)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(team).To(BeNil())
+		})
+	})
+
 	Describe("CreateDefaultTeamIfNotExists", func() {
 		It("creates the default team", func() {
 			t, found, err := teamFactory.FindTeam(atc.DefaultTeamName)
@@ -2,6 +2,7 @@ package db_test
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -73,6 +74,148 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("SoftDelete", func() {
+		var (
+			pausedPipeline   db.Pipeline
+			unpausedPipeline db.Pipeline
+			runningBuild     db.Build
+			finishedBuild    db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			pausedPipeline, _, err = otherTeam.SavePipeline("paused-pipeline", atc.Config{}, db.ConfigVersion(0), true)
+			Expect(err).ToNot(HaveOccurred())
+
+			unpausedPipeline, _, err = otherTeam.SavePipeline("unpaused-pipeline", atc.Config{}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = unpausedPipeline.Expose()
+			Expect(err).ToNot(HaveOccurred())
+
+			runningBuild, err = otherTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			finishedBuild, err = otherTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(finishedBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			_, err = otherTeam.SaveWorker(atc.Worker{
+				Name:            "some-team-worker",
+				GardenAddr:      "1.2.3.4:7777",
+				BaggageclaimURL: "1.2.3.4:7788",
+			}, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = otherTeam.SoftDelete()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("hides the team", func() {
+			_, found, err := teamFactory.FindTeam("some-other-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("can still find it as a deleted team", func() {
+			deletedTeam, found, err := teamFactory.FindDeletedTeam("some-other-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(deletedTeam.ID()).To(Equal(otherTeam.ID()))
+		})
+
+		It("pauses its pipelines", func() {
+			found, err := unpausedPipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(unpausedPipeline.Paused()).To(BeTrue())
+		})
+
+		It("unregisters its workers", func() {
+			workers, err := otherTeam.Workers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workers).To(BeEmpty())
+		})
+
+		It("aborts its running builds", func() {
+			found, err := runningBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(runningBuild.IsAborted()).To(BeTrue())
+
+			found, err = finishedBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(finishedBuild.IsAborted()).To(BeFalse())
+		})
+
+		It("no longer lists its pipelines or builds, even public ones", func() {
+			pipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
+
+			pipelines, err := pipelineFactory.AllPipelines()
+			Expect(err).ToNot(HaveOccurred())
+			for _, pipeline := range pipelines {
+				Expect(pipeline.TeamID()).ToNot(Equal(otherTeam.ID()))
+			}
+
+			pipelines, err = pipelineFactory.VisiblePipelines([]string{"some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+			for _, pipeline := range pipelines {
+				Expect(pipeline.TeamID()).ToNot(Equal(otherTeam.ID()))
+			}
+
+			builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 100})
+			Expect(err).ToNot(HaveOccurred())
+			for _, build := range builds {
+				Expect(build.TeamID()).ToNot(Equal(otherTeam.ID()))
+			}
+		})
+
+		Describe("Restore", func() {
+			var (
+				restored bool
+				err      error
+			)
+
+			JustBeforeEach(func() {
+				restored, err = otherTeam.Restore()
+			})
+
+			It("restores the team", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(restored).To(BeTrue())
+
+				_, found, err := teamFactory.FindTeam("some-other-team")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("unpauses only the pipelines it paused", func() {
+				found, err := unpausedPipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(unpausedPipeline.Paused()).To(BeFalse())
+
+				found, err = pausedPipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pausedPipeline.Paused()).To(BeTrue())
+			})
+
+			Context("when the team has already been restored", func() {
+				BeforeEach(func() {
+					_, err := otherTeam.Restore()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns false", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(restored).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Describe("Rename", func() {
 		JustBeforeEach(func() {
 			Expect(team.Rename("oopsies")).To(Succeed())
@@ -404,6 +547,88 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("ResourceTypeHealth", func() {
+		var healths []atc.ResourceTypeHealth
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-base-resource-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			resourceConfigScope, err := defaultResource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+			Expect(err).NotTo(HaveOccurred())
+
+			metadata := db.CheckMetadata{
+				TeamID:             defaultTeam.ID(),
+				TeamName:           defaultTeam.Name(),
+				PipelineName:       defaultPipeline.Name(),
+				ResourceConfigID:   resourceConfigScope.ResourceConfig().ID(),
+				BaseResourceTypeID: resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+			}
+
+			check, created, err := checkFactory.CreateCheck(resourceConfigScope.ID(), false, atc.Plan{}, metadata)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(check.Start()).To(Succeed())
+			Expect(check.Finish()).To(Succeed())
+
+			check, created, err = checkFactory.CreateCheck(resourceConfigScope.ID(), false, atc.Plan{}, metadata)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(check.Start()).To(Succeed())
+			Expect(check.FinishWithError(errors.New("nope"))).To(Succeed())
+
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.SaveResourceFetch("some-base-resource-type", 2*time.Second, true)).To(Succeed())
+			Expect(build.SaveResourceFetch("some-base-resource-type", 4*time.Second, false)).To(Succeed())
+			Expect(build.SaveResourceFetch("some-custom-type", time.Second, true)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			healths, err = defaultTeam.ResourceTypeHealth()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports the checks and fetches of each type of resource", func() {
+			Expect(healths).To(HaveLen(2))
+
+			Expect(healths[0].Type).To(Equal("some-base-resource-type"))
+			Expect(healths[0].Pipelines).To(Equal(1))
+			Expect(healths[0].Resources).To(Equal(1))
+			Expect(healths[0].Checks).To(Equal(2))
+			Expect(healths[0].FailedChecks).To(Equal(1))
+			Expect(healths[0].CheckFailureRate).To(Equal(0.5))
+			Expect(healths[0].Fetches).To(Equal(2))
+			Expect(healths[0].FailedFetches).To(Equal(1))
+			Expect(healths[0].FetchFailureRate).To(Equal(0.5))
+			Expect(healths[0].AverageFetchDuration).To(Equal(3.0))
+			Expect(healths[0].MaxFetchDuration).To(Equal(4.0))
+
+			Expect(healths[1]).To(Equal(atc.ResourceTypeHealth{
+				Type:                 "some-custom-type",
+				Fetches:              1,
+				AverageFetchDuration: 1,
+				MaxFetchDuration:     1,
+			}))
+		})
+
+		It("does not report on other teams", func() {
+			otherHealths, err := otherTeam.ResourceTypeHealth()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherHealths).To(BeEmpty())
+		})
+	})
+
 	Describe("Containers", func() {
 		var (
 			fakeSecretManager      *credsfakes.FakeSecrets
@@ -442,6 +667,7 @@ var _ = Describe("Team", func() {
 					db.NewResourceConfigCheckSessionContainerOwner(
 						resourceConfigScope.ResourceConfig().ID(),
 						resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						expiries,
 					),
 					db.ContainerMetadata{},
@@ -492,6 +718,7 @@ var _ = Describe("Team", func() {
 					db.NewResourceConfigCheckSessionContainerOwner(
 						resourceConfigScope.ResourceConfig().ID(),
 						resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						expiries,
 					),
 					db.ContainerMetadata{
@@ -573,6 +800,7 @@ var _ = Describe("Team", func() {
 						db.NewResourceConfigCheckSessionContainerOwner(
 							resourceConfigScope.ResourceConfig().ID(),
 							resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+							defaultTeam.ID(),
 							expiries,
 						),
 						db.ContainerMetadata{
@@ -608,6 +836,7 @@ var _ = Describe("Team", func() {
 						db.NewResourceConfigCheckSessionContainerOwner(
 							resourceConfigScope.ResourceConfig().ID(),
 							resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+							defaultTeam.ID(),
 							expiries,
 						),
 						db.ContainerMetadata{
@@ -643,6 +872,7 @@ var _ = Describe("Team", func() {
 					db.NewResourceConfigCheckSessionContainerOwner(
 						resourceConfigScope.ResourceConfig().ID(),
 						resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						expiries,
 					),
 					db.ContainerMetadata{
@@ -854,6 +1084,355 @@ var _ = Describe("Team", func() {
 				})
 			})
 		})
+
+		Describe("UpdateMaxBuildLogSize", func() {
+			It("saves the limit to the existing team", func() {
+				err := team.UpdateMaxBuildLogSize(1024)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.MaxBuildLogSize()).To(Equal(int64(1024)))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.MaxBuildLogSize()).To(Equal(int64(1024)))
+			})
+		})
+
+		Describe("UpdateMaxBuildStartsPerMinute", func() {
+			It("saves the limit to the existing team", func() {
+				err := team.UpdateMaxBuildStartsPerMinute(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.MaxBuildStartsPerMinute()).To(Equal(10))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.MaxBuildStartsPerMinute()).To(Equal(10))
+			})
+		})
+
+		Describe("UpdateResourceDefaults", func() {
+			It("saves the defaults to the existing team", func() {
+				defaults := atc.ResourceDefaults{
+					"registry-image": atc.Source{"registry_mirror": map[string]interface{}{"host": "mirror.example.com"}},
+				}
+
+				err := team.UpdateResourceDefaults(defaults)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.ResourceDefaults()).To(Equal(defaults))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ResourceDefaults()).To(Equal(defaults))
+			})
+		})
+
+		Describe("UpdateContainerEnv", func() {
+			It("saves the env to the existing team", func() {
+				env := map[string]string{"HTTP_PROXY": "http://proxy.example.com"}
+
+				err := team.UpdateContainerEnv(env)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.ContainerEnv()).To(Equal(env))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ContainerEnv()).To(Equal(env))
+			})
+		})
+
+		Describe("UpdateCACerts", func() {
+			It("saves the certs to the existing team", func() {
+				err := team.UpdateCACerts("some-ca-cert")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.CACerts()).To(Equal("some-ca-cert"))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.CACerts()).To(Equal("some-ca-cert"))
+			})
+		})
+
+		Describe("UpdateContainerDNS", func() {
+			It("saves the dns config to the existing team", func() {
+				dns := &atc.ContainerDNS{
+					Servers: []string{"10.0.0.2"},
+					Search:  []string{"corp.example.com"},
+				}
+
+				err := team.UpdateContainerDNS(dns)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.ContainerDNS()).To(Equal(dns))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ContainerDNS()).To(Equal(dns))
+			})
+		})
+
+		Describe("UpdateCheckPolicy", func() {
+			It("saves the check policy to the existing team", func() {
+				policy := &atc.CheckPolicy{
+					DefaultEvery:  "10m",
+					MinEvery:      "1m",
+					MaxEvery:      "1h",
+					MaxConcurrent: 10,
+				}
+
+				err := team.UpdateCheckPolicy(policy)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.CheckPolicy()).To(Equal(policy))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.CheckPolicy()).To(Equal(policy))
+			})
+		})
+
+		Describe("UpdateDefaultTaskImage", func() {
+			It("saves the default task image to the existing team", func() {
+				image := &atc.ImageResource{
+					Type:   "docker-image",
+					Source: atc.Source{"repository": "busybox"},
+				}
+
+				err := team.UpdateDefaultTaskImage(image)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.DefaultTaskImage()).To(Equal(image))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.DefaultTaskImage()).To(Equal(image))
+			})
+		})
+
+		Describe("UpdatePipelinesRepo", func() {
+			It("saves the pipelines repo to the existing team", func() {
+				repo := &atc.PipelinesRepo{
+					URI:    "https://example.com/pipelines.git",
+					Branch: "main",
+					Path:   "ci",
+				}
+
+				err := team.UpdatePipelinesRepo(repo)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.PipelinesRepo()).To(Equal(repo))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.PipelinesRepo()).To(Equal(repo))
+			})
+		})
+
+		Describe("UpdateConcurrencyPools", func() {
+			It("saves the pools to the existing team", func() {
+				pools := atc.ConcurrencyPools{"integration-db": 3}
+
+				err := team.UpdateConcurrencyPools(pools)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.ConcurrencyPools()).To(Equal(pools))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ConcurrencyPools()).To(Equal(pools))
+			})
+		})
+
+		Describe("UpdateContentScanPolicy", func() {
+			It("saves the policy to the existing team", func() {
+				err := team.UpdateContentScanPolicy(atc.ContentScanPolicyBlock)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.ContentScanPolicy()).To(Equal(atc.ContentScanPolicyBlock))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ContentScanPolicy()).To(Equal(atc.ContentScanPolicyBlock))
+			})
+		})
+
+		Describe("UpdateRequireImageDigests", func() {
+			It("saves the requirement to the existing team", func() {
+				Expect(team.RequireImageDigests()).To(BeFalse())
+
+				err := team.UpdateRequireImageDigests(true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.RequireImageDigests()).To(BeTrue())
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.RequireImageDigests()).To(BeTrue())
+			})
+		})
+
+		Describe("UpdateResourceTypeMappings", func() {
+			It("saves the mappings to the existing team", func() {
+				mappings := atc.ResourceTypeMappings{
+					"git": {Type: "registry-image", Source: atc.Source{"repository": "internal/git-resource"}, Privileged: true},
+				}
+
+				err := team.UpdateResourceTypeMappings(mappings)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.ResourceTypeMappings()).To(Equal(mappings))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.ResourceTypeMappings()).To(Equal(mappings))
+			})
+		})
+
+		Describe("UpdatePrivilegedPolicy", func() {
+			It("saves the policy to the existing team", func() {
+				policy := &atc.PrivilegedPolicy{Allow: []string{"infra/*"}, OneOffBuilds: true}
+
+				err := team.UpdatePrivilegedPolicy(policy)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.PrivilegedPolicy()).To(Equal(policy))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.PrivilegedPolicy()).To(Equal(policy))
+			})
+		})
+
+		Describe("UpdatePipelinesRepoStatus", func() {
+			It("saves the status to the existing team", func() {
+				status := atc.PipelinesRepoStatus{
+					Revision:     "abc123",
+					ReconciledAt: 42,
+					Pipelines: []atc.PipelineReconciliation{
+						{Name: "some-pipeline", Status: atc.PipelineCreated},
+					},
+				}
+
+				err := team.UpdatePipelinesRepoStatus(status)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.PipelinesRepoStatus()).To(Equal(&status))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.PipelinesRepoStatus()).To(Equal(&status))
+			})
+		})
+	})
+
+	Describe("API tokens", func() {
+		var (
+			request atc.APITokenRequest
+			created atc.APIToken
+		)
+
+		BeforeEach(func() {
+			request = atc.APITokenRequest{
+				Name:      "deployer",
+				Scopes:    []atc.APITokenScope{{Action: atc.CreateJobBuild, Pipeline: "some-pipeline"}},
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			}
+
+			var err error
+			created, err = defaultTeam.CreateAPIToken(request, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("saves the token", func() {
+			Expect(created.ID).ToNot(BeZero())
+			Expect(created.Name).To(Equal("deployer"))
+			Expect(created.TeamName).To(Equal(defaultTeam.Name()))
+			Expect(created.Scopes).To(Equal(request.Scopes))
+			Expect(created.ExpiresAt).To(Equal(request.ExpiresAt))
+			Expect(created.CreatedBy).To(Equal("some-user"))
+			Expect(created.RevokedAt).To(BeZero())
+
+			tokens, err := defaultTeam.APITokens()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(Equal([]atc.APIToken{created}))
+		})
+
+		It("does not allow two tokens with the same name", func() {
+			_, err := defaultTeam.CreateAPIToken(request, "some-user")
+			Expect(err).To(Equal(db.ErrAPITokenExists))
+		})
+
+		Describe("RevokeAPIToken", func() {
+			It("revokes the token", func() {
+				revocations := db.NewAPITokenRepository(dbConn)
+
+				revoked, err := revocations.IsRevoked(created.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoked).To(BeFalse())
+
+				found, err := defaultTeam.RevokeAPIToken("deployer")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				revoked, err = revocations.IsRevoked(created.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoked).To(BeTrue())
+
+				tokens, err := defaultTeam.APITokens()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tokens[0].RevokedAt).ToNot(BeZero())
+			})
+
+			It("lets the name be used again", func() {
+				_, err := defaultTeam.RevokeAPIToken("deployer")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = defaultTeam.CreateAPIToken(request, "some-user")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns false for unknown tokens", func() {
+				found, err := defaultTeam.RevokeAPIToken("bogus")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		It("treats tokens of destroyed teams as revoked", func() {
+			err := defaultTeam.Delete()
+			Expect(err).ToNot(HaveOccurred())
+
+			revoked, err := db.NewAPITokenRepository(dbConn).IsRevoked(created.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("treats tokens of deleted teams as revoked", func() {
+			err := defaultTeam.SoftDelete()
+			Expect(err).ToNot(HaveOccurred())
+
+			revoked, err := db.NewAPITokenRepository(dbConn).IsRevoked(created.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
 	})
 
 	Describe("Pipelines", func() {
@@ -2507,6 +3086,7 @@ var _ = Describe("Team", func() {
 							db.NewResourceConfigCheckSessionContainerOwner(
 								resourceConfig.ID(),
 								resourceConfig.OriginBaseResourceType().ID,
+								defaultTeam.ID(),
 								expiries,
 							),
 							db.ContainerMetadata{},
@@ -2561,6 +3141,7 @@ var _ = Describe("Team", func() {
 								db.NewResourceConfigCheckSessionContainerOwner(
 									resourceConfig.ID(),
 									resourceConfig.OriginBaseResourceType().ID,
+									defaultTeam.ID(),
 									expiries,
 								),
 							)
@@ -2628,6 +3209,7 @@ var _ = Describe("Team", func() {
 					db.NewResourceConfigCheckSessionContainerOwner(
 						resourceConfigScope.ResourceConfig().ID(),
 						resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						expiries,
 					),
 					db.ContainerMetadata{},
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -16,7 +17,10 @@ var (
 	ErrVolumeCannotBeDestroyedWithChildrenPresent = errors.New("volume cannot be destroyed as children are present")
 	ErrVolumeStateTransitionFailed                = errors.New("could not transition volume state")
 	ErrVolumeMissing                              = errors.New("volume no longer in db")
+	ErrVolumeLeased                               = errors.New("volume is leased")
 	ErrInvalidResourceCache                       = errors.New("invalid resource cache")
+	ErrResourceCacheVolumeExists                  = errors.New("resource cache volume already exists on worker")
+	ErrImageLayerVolumeExists                     = errors.New("image layer volume already exists on worker")
 )
 
 type ErrVolumeMarkStateFailed struct {
@@ -53,6 +57,7 @@ const (
 	VolumeTypeResourceCerts VolumeType = "resource-certs"
 	VolumeTypeTaskCache     VolumeType = "task-cache"
 	VolumeTypeArtifact      VolumeType = "artifact"
+	VolumeTypeImageLayer    VolumeType = "image-layer"
 	VolumeTypeUknown        VolumeType = "unknown" // for migration to life
 )
 
@@ -79,6 +84,7 @@ type creatingVolume struct {
 	workerTaskCacheID        int
 	workerResourceCertsID    int
 	workerArtifactID         int
+	createdAt                time.Time
 	conn                     Conn
 }
 
@@ -114,6 +120,7 @@ func (volume *creatingVolume) Created() (CreatedVolume, error) {
 		workerBaseResourceTypeID: volume.workerBaseResourceTypeID,
 		workerTaskCacheID:        volume.workerTaskCacheID,
 		workerResourceCertsID:    volume.workerResourceCertsID,
+		createdAt:                volume.createdAt,
 	}, nil
 }
 
@@ -149,7 +156,9 @@ type CreatedVolume interface {
 	WorkerArtifactID() int
 	CreateChildForContainer(CreatingContainer, string) (CreatingVolume, error)
 	Destroying() (DestroyingVolume, error)
+	Lease(ttl time.Duration) (VolumeLease, bool, error)
 	WorkerName() string
+	CreatedAt() time.Time
 
 	InitializeResourceCache(UsedResourceCache) error
 	InitializeArtifact(name string, buildID int) (WorkerArtifact, error)
@@ -176,6 +185,7 @@ type createdVolume struct {
 	workerTaskCacheID        int
 	workerResourceCertsID    int
 	workerArtifactID         int
+	createdAt                time.Time
 	conn                     Conn
 }
 
@@ -193,6 +203,7 @@ func (volume *createdVolume) TeamID() int             { return volume.teamID }
 func (volume *createdVolume) ContainerHandle() string { return volume.containerHandle }
 func (volume *createdVolume) ParentHandle() string    { return volume.parentHandle }
 func (volume *createdVolume) WorkerArtifactID() int   { return volume.workerArtifactID }
+func (volume *createdVolume) CreatedAt() time.Time    { return volume.createdAt }
 
 func (volume *createdVolume) ResourceType() (*VolumeResourceType, error) {
 	if volume.resourceCacheID == 0 {
@@ -540,13 +551,14 @@ func (volume *createdVolume) CreateChildForContainer(container CreatingContainer
 	}
 
 	var volumeID int
+	var createdAt time.Time
 	err = psql.Insert("volumes").
 		Columns(columnNames...).
 		Values(columnValues...).
-		Suffix("RETURNING id").
+		Suffix("RETURNING id, created_at").
 		RunWith(tx).
 		QueryRow().
-		Scan(&volumeID)
+		Scan(&volumeID, &createdAt)
 	if err != nil {
 		return nil, err
 	}
@@ -565,17 +577,55 @@ func (volume *createdVolume) CreateChildForContainer(container CreatingContainer
 		typ:             VolumeTypeContainer,
 		containerHandle: container.Handle(),
 		parentHandle:    volume.Handle(),
+		createdAt:       createdAt,
 		conn:            volume.conn,
 	}, nil
 }
 
+// Destroying marks the volume as destroying, so that its worker's volume
+// sweeper destroys it. A volume which is leased is left alone, returning
+// ErrVolumeLeased.
 func (volume *createdVolume) Destroying() (DestroyingVolume, error) {
-	err := volumeStateTransition(
+	tx, err := volume.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	// locking the volume's row waits for any lease being taken out on it
+	var leased bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM volume_leases
+			WHERE volume_id = v.id
+			AND expires_at > now()
+		)
+		FROM volumes v
+		WHERE v.id = $1
+		FOR UPDATE OF v
+	`, volume.id).Scan(&leased)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVolumeMarkStateFailed{VolumeStateDestroying}
+		}
+
+		return nil, err
+	}
+
+	if leased {
+		return nil, ErrVolumeLeased
+	}
+
+	err = volumeStateTransition(
 		volume.id,
-		volume.conn,
+		tx,
 		VolumeStateCreated,
 		VolumeStateDestroying,
 	)
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
 		if err == ErrVolumeStateTransitionFailed {
 			return nil, ErrVolumeMarkStateFailed{VolumeStateDestroying}
@@ -680,7 +730,7 @@ func (volume *failedVolume) Destroy() (bool, error) {
 	return true, nil
 }
 
-func volumeStateTransition(volumeID int, conn Conn, from, to VolumeState) error {
+func volumeStateTransition(volumeID int, conn sq.BaseRunner, from, to VolumeState) error {
 	rows, err := psql.Update("volumes").
 		Set("state", string(to)).
 		Where(sq.And{
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . VolumeLease
+
+// A VolumeLease keeps a volume from being marked as destroying while it's
+// being streamed or about to be mounted. The lease lapses if it isn't
+// renewed before its TTL is up, so a lease held by an ATC which goes away
+// doesn't keep the volume around forever.
+type VolumeLease interface {
+	Renew(ttl time.Duration) (bool, error)
+	Release() error
+}
+
+type volumeLease struct {
+	id   int
+	conn Conn
+}
+
+// Lease leases the volume for the given TTL. A volume which is no longer
+// in the created state can't be leased, as its worker may already be
+// destroying it.
+func (volume *createdVolume) Lease(ttl time.Duration) (VolumeLease, bool, error) {
+	tx, err := volume.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	var state string
+	err = psql.Select("state").
+		From("volumes").
+		Where(sq.Eq{"id": volume.id}).
+		Suffix("FOR SHARE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	if VolumeState(state) != VolumeStateCreated {
+		return nil, false, nil
+	}
+
+	var id int
+	err = psql.Insert("volume_leases").
+		Columns("volume_id", "expires_at").
+		Values(volume.id, sq.Expr("now() + ?::interval", leaseInterval(ttl))).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &volumeLease{
+		id:   id,
+		conn: volume.conn,
+	}, true, nil
+}
+
+// Renew extends the lease to the given TTL from now, returning false if the
+// lease has already lapsed and its volume been marked as destroying.
+func (lease *volumeLease) Renew(ttl time.Duration) (bool, error) {
+	result, err := psql.Update("volume_leases").
+		Set("expires_at", sq.Expr("now() + ?::interval", leaseInterval(ttl))).
+		Where(sq.Eq{"id": lease.id}).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM volumes v WHERE v.id = volume_id AND v.state = ?)", VolumeStateCreated)).
+		RunWith(lease.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (lease *volumeLease) Release() error {
+	_, err := psql.Delete("volume_leases").
+		Where(sq.Eq{"id": lease.id}).
+		RunWith(lease.conn).
+		Exec()
+	return err
+}
+
+// RemoveExpiredVolumeLeases removes the leases which lapsed without being
+// released.
+func (repository *volumeRepository) RemoveExpiredVolumeLeases() (int, error) {
+	result, err := psql.Delete("volume_leases").
+		Where(sq.Expr("expires_at <= now()")).
+		RunWith(repository.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+func leaseInterval(ttl time.Duration) string {
+	return fmt.Sprintf("%d milliseconds", int64(ttl/time.Millisecond))
+}
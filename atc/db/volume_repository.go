@@ -6,6 +6,7 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
@@ -21,10 +22,14 @@ type VolumeRepository interface {
 	CreateBaseResourceTypeVolume(*UsedWorkerBaseResourceType) (CreatingVolume, error)
 
 	FindResourceCacheVolume(workerName string, resourceCache UsedResourceCache) (CreatedVolume, bool, error)
+	CreateResourceCacheVolume(workerName string, resourceCache UsedResourceCache) (CreatingVolume, error)
 
 	FindTaskCacheVolume(teamID int, workerName string, taskCache UsedTaskCache) (CreatedVolume, bool, error)
 	CreateTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, error)
 
+	FindImageLayerVolume(workerName string, digest string) (CreatedVolume, bool, error)
+	CreateImageLayerVolume(workerName string, digest string) (CreatingVolume, error)
+
 	FindResourceCertsVolume(workerName string, uwrc *UsedWorkerResourceCerts) (CreatingVolume, CreatedVolume, error)
 	CreateResourceCertsVolume(workerName string, uwrc *UsedWorkerResourceCerts) (CreatingVolume, error)
 
@@ -42,6 +47,7 @@ type VolumeRepository interface {
 
 	UpdateVolumesMissingSince(workerName string, handles []string) error
 	RemoveMissingVolumes(gracePeriod time.Duration) (removed int, err error)
+	RemoveExpiredVolumeLeases() (removed int, err error)
 }
 
 const noTeam = 0
@@ -433,6 +439,163 @@ func (repository *volumeRepository) FindResourceCacheVolume(workerName string, r
 	return createdVolume, true, nil
 }
 
+// CreateResourceCacheVolume creates a volume which is owned by the resource
+// cache from the start, rather than one which is later initialized from a get
+// container's volume. It is used when hydrating a resource cache onto a worker
+// from outside of a container, e.g. from a remote resource cache store.
+func (repository *volumeRepository) CreateResourceCacheVolume(workerName string, resourceCache UsedResourceCache) (CreatingVolume, error) {
+	tx, err := repository.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	workerResourceCache, err := WorkerResourceCache{
+		WorkerName:    workerName,
+		ResourceCache: resourceCache,
+	}.FindOrCreate(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	var volumeID int
+	var createdAt time.Time
+	err = psql.Insert("volumes").
+		Columns("worker_name", "handle", "worker_resource_cache_id").
+		Values(workerName, handle.String(), workerResourceCache.ID).
+		Suffix("RETURNING id, created_at").
+		RunWith(tx).
+		QueryRow().
+		Scan(&volumeID, &createdAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return nil, ErrResourceCacheVolumeExists
+		}
+
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &creatingVolume{
+		workerName: workerName,
+
+		id:              volumeID,
+		handle:          handle.String(),
+		typ:             VolumeTypeResource,
+		resourceCacheID: resourceCache.ID(),
+		createdAt:       createdAt,
+
+		conn: repository.conn,
+	}, nil
+}
+
+// FindImageLayerVolume finds the created volume caching the image layer with
+// the given digest on the worker, marking the layer as used.
+func (repository *volumeRepository) FindImageLayerVolume(workerName string, digest string) (CreatedVolume, bool, error) {
+	workerImageLayer, found, err := WorkerImageLayer{
+		WorkerName: workerName,
+		Digest:     digest,
+	}.Find(repository.conn)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	_, createdVolume, err := repository.findVolume(0, workerName, map[string]interface{}{
+		"v.worker_image_layer_id": workerImageLayer.ID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if createdVolume == nil {
+		return nil, false, nil
+	}
+
+	_, err = psql.Update("worker_image_layers").
+		Set("last_used", sq.Expr("now()")).
+		Where(sq.Eq{"id": workerImageLayer.ID}).
+		RunWith(repository.conn).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return createdVolume, true, nil
+}
+
+// CreateImageLayerVolume creates a volume for caching the image layer with
+// the given digest on the worker. Only one volume may exist for a layer on a
+// worker, so ErrImageLayerVolumeExists is returned if it is already cached or
+// being cached.
+func (repository *volumeRepository) CreateImageLayerVolume(workerName string, digest string) (CreatingVolume, error) {
+	tx, err := repository.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	workerImageLayer, err := WorkerImageLayer{
+		WorkerName: workerName,
+		Digest:     digest,
+	}.FindOrCreate(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	var volumeID int
+	var createdAt time.Time
+	err = psql.Insert("volumes").
+		Columns("worker_name", "handle", "worker_image_layer_id").
+		Values(workerName, handle.String(), workerImageLayer.ID).
+		Suffix("RETURNING id, created_at").
+		RunWith(tx).
+		QueryRow().
+		Scan(&volumeID, &createdAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return nil, ErrImageLayerVolumeExists
+		}
+
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &creatingVolume{
+		workerName: workerName,
+
+		id:        volumeID,
+		handle:    handle.String(),
+		typ:       VolumeTypeImageLayer,
+		createdAt: createdAt,
+
+		conn: repository.conn,
+	}, nil
+}
+
 func (repository *volumeRepository) FindCreatedVolume(handle string) (CreatedVolume, bool, error) {
 	_, createdVolume, err := getVolume(repository.conn, map[string]interface{}{
 		"v.handle": handle,
@@ -463,6 +626,7 @@ func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error
 				"v.worker_task_cache_id":         nil,
 				"v.worker_resource_certs_id":     nil,
 				"v.worker_artifact_id":           nil,
+				"v.worker_image_layer_id":        nil,
 			},
 		).
 		Where(sq.Eq{"v.state": string(VolumeStateCreated)}).
@@ -542,9 +706,11 @@ func (repository *volumeRepository) GetDestroyingVolumes(workerName string) ([]s
 
 // 1. open tx
 // 2. lookup worker resource type id
-//   * if not found, fail; worker must have new version or no longer supports type
+//   - if not found, fail; worker must have new version or no longer supports type
+//
 // 3. insert into volumes in 'initializing' state
-//   * if fails (fkey violation; worker type gone), fail for same reason as 2.
+//   - if fails (fkey violation; worker type gone), fail for same reason as 2.
+//
 // 4. commit tx
 func (repository *volumeRepository) createVolume(
 	teamID int,
@@ -553,6 +719,7 @@ func (repository *volumeRepository) createVolume(
 	volumeType VolumeType,
 ) (*creatingVolume, error) {
 	var volumeID int
+	var createdAt time.Time
 	handle, err := uuid.NewV4()
 	if err != nil {
 		return nil, err
@@ -573,10 +740,10 @@ func (repository *volumeRepository) createVolume(
 	err = psql.Insert("volumes").
 		Columns(columnNames...). // hey, replace this with SetMap plz
 		Values(columnValues...).
-		Suffix("RETURNING id").
+		Suffix("RETURNING id, created_at").
 		RunWith(repository.conn).
 		QueryRow().
-		Scan(&volumeID)
+		Scan(&volumeID, &createdAt)
 	if err != nil {
 		return nil, err
 	}
@@ -584,10 +751,11 @@ func (repository *volumeRepository) createVolume(
 	return &creatingVolume{
 		workerName: workerName,
 
-		id:     volumeID,
-		handle: handle.String(),
-		typ:    volumeType,
-		teamID: teamID,
+		id:        volumeID,
+		handle:    handle.String(),
+		typ:       volumeType,
+		teamID:    teamID,
+		createdAt: createdAt,
 
 		conn: repository.conn,
 	}, nil
@@ -645,6 +813,7 @@ var volumeColumns = []string{
 	"v.worker_task_cache_id",
 	"v.worker_resource_certs_id",
 	"v.worker_artifact_id",
+	"v.created_at",
 	`case
 	when v.worker_base_resource_type_id is not NULL then 'resource-type'
 	when v.worker_resource_cache_id is not NULL then 'resource'
@@ -652,6 +821,7 @@ var volumeColumns = []string{
 	when v.worker_task_cache_id is not NULL then 'task-cache'
 	when v.worker_resource_certs_id is not NULL then 'resource-certs'
 	when v.worker_artifact_id is not NULL then 'artifact'
+	when v.worker_image_layer_id is not NULL then 'image-layer'
 	else 'unknown'
 end`,
 }
@@ -670,6 +840,7 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 	var sqWorkerTaskCacheID sql.NullInt64
 	var sqWorkerResourceCertsID sql.NullInt64
 	var sqWorkerArtifactID sql.NullInt64
+	var createdAt time.Time
 	var volumeType VolumeType
 
 	err := row.Scan(
@@ -686,6 +857,7 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 		&sqWorkerTaskCacheID,
 		&sqWorkerResourceCertsID,
 		&sqWorkerArtifactID,
+		&createdAt,
 		&volumeType,
 	)
 	if err != nil {
@@ -753,6 +925,7 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 			workerTaskCacheID:        workerTaskCacheID,
 			workerResourceCertsID:    workerResourceCertsID,
 			workerArtifactID:         workerArtifactID,
+			createdAt:                createdAt,
 			conn:                     conn,
 		}, nil, nil, nil
 	case VolumeStateCreating:
@@ -770,6 +943,7 @@ func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, De
 			workerTaskCacheID:        workerTaskCacheID,
 			workerResourceCertsID:    workerResourceCertsID,
 			workerArtifactID:         workerArtifactID,
+			createdAt:                createdAt,
 			conn:                     conn,
 		}, nil, nil, nil, nil
 	case VolumeStateDestroying:
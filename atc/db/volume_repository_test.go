@@ -75,6 +75,8 @@ var _ = Describe("VolumeFactory", func() {
 			Expect(volumes).To(HaveLen(1))
 			Expect(volumes[0].Handle()).To(Equal(createdVolume.Handle()))
 			Expect(volumes[0].Type()).To(Equal(db.VolumeTypeTaskCache))
+			Expect(volumes[0].CreatedAt()).To(BeTemporally("==", createdVolume.CreatedAt()))
+			Expect(volumes[0].CreatedAt()).To(BeTemporally("~", time.Now(), time.Minute))
 		})
 
 		Context("with container volumes", func() {
@@ -538,6 +540,92 @@ var _ = Describe("VolumeFactory", func() {
 				Expect(found).To(BeTrue())
 			})
 		})
+
+		Context("when the volume was created for the resource cache directly", func() {
+			var creatingVolume db.CreatingVolume
+
+			BeforeEach(func() {
+				var err error
+				creatingVolume, err = volumeRepository.CreateResourceCacheVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return the volume while it is still creating", func() {
+				_, found, err := volumeRepository.FindResourceCacheVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("returns the volume once it is created", func() {
+				_, err := creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				createdVolume, found, err := volumeRepository.FindResourceCacheVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(createdVolume.Handle()).To(Equal(creatingVolume.Handle()))
+				Expect(createdVolume.Type()).To(Equal(db.VolumeTypeResource))
+			})
+
+			It("does not allow a second volume for the same resource cache on the worker", func() {
+				_, err := volumeRepository.CreateResourceCacheVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).To(Equal(db.ErrResourceCacheVolumeExists))
+			})
+		})
+	})
+
+	Describe("image layer volumes", func() {
+		var creatingVolume db.CreatingVolume
+
+		BeforeEach(func() {
+			var err error
+			creatingVolume, err = volumeRepository.CreateImageLayerVolume(defaultWorker.Name(), "sha256:some-layer")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not find the volume while it is still creating", func() {
+			_, found, err := volumeRepository.FindImageLayerVolume(defaultWorker.Name(), "sha256:some-layer")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("finds the volume once it is created", func() {
+			_, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, found, err := volumeRepository.FindImageLayerVolume(defaultWorker.Name(), "sha256:some-layer")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(createdVolume.Handle()).To(Equal(creatingVolume.Handle()))
+			Expect(createdVolume.Type()).To(Equal(db.VolumeTypeImageLayer))
+
+			_, found, err = volumeRepository.FindImageLayerVolume(defaultWorker.Name(), "sha256:other-layer")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not allow a second volume for the same layer on the worker", func() {
+			_, err := volumeRepository.CreateImageLayerVolume(defaultWorker.Name(), "sha256:some-layer")
+			Expect(err).To(Equal(db.ErrImageLayerVolumeExists))
+		})
+
+		It("orphans the volume once the layer is removed", func() {
+			_, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec("DELETE FROM worker_image_layers")
+			Expect(err).NotTo(HaveOccurred())
+
+			orphanedVolumes, err := volumeRepository.GetOrphanedVolumes()
+			Expect(err).NotTo(HaveOccurred())
+
+			var handles []string
+			for _, volume := range orphanedVolumes {
+				handles = append(handles, volume.Handle())
+			}
+
+			Expect(handles).To(ContainElement(creatingVolume.Handle()))
+		})
 	})
 
 	Describe("RemoveDestroyingVolumes", func() {
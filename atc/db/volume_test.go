@@ -26,6 +26,7 @@ var _ = Describe("Volume", func() {
 			db.NewResourceConfigCheckSessionContainerOwner(
 				resourceConfig.ID(),
 				resourceConfig.OriginBaseResourceType().ID,
+				defaultTeam.ID(),
 				expiries,
 			),
 			db.ContainerMetadata{Type: "check"},
@@ -182,6 +183,74 @@ var _ = Describe("Volume", func() {
 		})
 	})
 
+	Describe("createdVolume.Lease", func() {
+		var createdVolume db.CreatedVolume
+
+		BeforeEach(func() {
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), defaultCreatingContainer, "/path/to/volume")
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps the volume from being marked as destroying until it is released", func() {
+			lease, leased, err := createdVolume.Lease(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leased).To(BeTrue())
+
+			_, err = createdVolume.Destroying()
+			Expect(err).To(Equal(db.ErrVolumeLeased))
+
+			Expect(lease.Release()).To(Succeed())
+
+			_, err = createdVolume.Destroying()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can be renewed while the volume is created", func() {
+			lease, _, err := createdVolume.Lease(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			renewed, err := lease.Renew(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renewed).To(BeTrue())
+		})
+
+		Context("when the lease has expired", func() {
+			It("no longer keeps the volume from being marked as destroying", func() {
+				lease, _, err := createdVolume.Lease(time.Millisecond)
+				Expect(err).ToNot(HaveOccurred())
+
+				time.Sleep(10 * time.Millisecond)
+
+				_, err = createdVolume.Destroying()
+				Expect(err).ToNot(HaveOccurred())
+
+				renewed, err := lease.Renew(time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(renewed).To(BeFalse())
+
+				removed, err := volumeRepository.RemoveExpiredVolumeLeases()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(removed).To(Equal(1))
+			})
+		})
+
+		Context("when the volume is already destroying", func() {
+			BeforeEach(func() {
+				_, err := createdVolume.Destroying()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is not leased", func() {
+				_, leased, err := createdVolume.Lease(time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(leased).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("createdVolume.InitializeResourceCache", func() {
 		var createdVolume db.CreatedVolume
 		var resourceCache db.UsedResourceCache
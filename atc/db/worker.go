@@ -54,6 +54,11 @@ type Worker interface {
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
+	Labels() atc.Labels
+	GPUs() int
+	StreamEncoding() string
+	ProtocolVersion() int
+	Capabilities() []atc.WorkerCapability
 	TeamID() int
 	TeamName() string
 	StartTime() time.Time
@@ -68,11 +73,16 @@ type Worker interface {
 	Delete() error
 
 	ActiveTasks() (int, error)
+	AvailableGPUs() (int, error)
 	IncreaseActiveTasks() error
 	DecreaseActiveTasks() error
 
 	FindContainer(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
+
+	ReconcileCensus(census atc.WorkerCensus) ([]atc.WorkerDiscrepancy, error)
+	Discrepancies() ([]atc.WorkerDiscrepancy, error)
+	ResolveDiscrepancies() (int, error)
 }
 
 type worker struct {
@@ -92,6 +102,11 @@ type worker struct {
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
+	labels           atc.Labels
+	gpus             int
+	streamEncoding   string
+	protocolVersion  int
+	capabilities     []atc.WorkerCapability
 	teamID           int
 	teamName         string
 	startTime        time.Time
@@ -115,6 +130,11 @@ func (worker *worker) ActiveVolumes() int                      { return worker.a
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
+func (worker *worker) Labels() atc.Labels                      { return worker.labels }
+func (worker *worker) GPUs() int                               { return worker.gpus }
+func (worker *worker) StreamEncoding() string                  { return worker.streamEncoding }
+func (worker *worker) ProtocolVersion() int                    { return worker.protocolVersion }
+func (worker *worker) Capabilities() []atc.WorkerCapability    { return worker.capabilities }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
@@ -282,7 +302,8 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 	}
 
 	var containerID int
-	cols := []interface{}{&containerID}
+	var createdAt time.Time
+	cols := []interface{}{&containerID, &createdAt}
 
 	metadata := &ContainerMetadata{}
 	cols = append(cols, metadata.ScanTargets()...)
@@ -309,7 +330,7 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 
 	err = psql.Insert("containers").
 		SetMap(insMap).
-		Suffix("RETURNING id, " + strings.Join(containerMetadataColumns, ", ")).
+		Suffix("RETURNING id, created_at, " + strings.Join(containerMetadataColumns, ", ")).
 		RunWith(tx).
 		QueryRow().
 		Scan(cols...)
@@ -331,6 +352,7 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 		handle.String(),
 		worker.name,
 		*metadata,
+		createdAt,
 		worker.conn,
 	), nil
 }
@@ -411,3 +433,22 @@ func (worker *worker) DecreaseActiveTasks() error {
 
 	return nil
 }
+
+// AvailableGPUs returns the number of the worker's GPUs which are not
+// allocated to a container.
+func (worker *worker) AvailableGPUs() (int, error) {
+	var available int
+	err := psql.Select("w.gpus - COALESCE(SUM(cardinality(c.gpus)), 0)").
+		From("workers w").
+		LeftJoin("containers c ON c.worker_name = w.name AND c.gpus IS NOT NULL AND c.state NOT IN ('failed', 'destroying')").
+		Where(sq.Eq{"w.name": worker.name}).
+		GroupBy("w.gpus").
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&available)
+	if err != nil {
+		return 0, err
+	}
+
+	return available, nil
+}
@@ -0,0 +1,326 @@
+package db
+
+import (
+	"database/sql"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+const (
+	discrepancyTypeContainer = "container"
+	discrepancyTypeVolume    = "volume"
+)
+
+// ReconcileCensus compares the containers and volumes which the worker
+// reports having with those the database thinks it has, and saves the
+// discrepancies between them. Discrepancies which were already found by the
+// previous census have their heartbeats counted up, and those which are no
+// longer found are forgotten.
+func (worker *worker) ReconcileCensus(census atc.WorkerCensus) ([]atc.WorkerDiscrepancy, error) {
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	found, err := worker.censusDiscrepancies(tx, census)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := queryWorkerDiscrepancies(tx, sq.Eq{"worker_name": worker.name})
+	if err != nil {
+		return nil, err
+	}
+
+	previousByHandle := map[string]atc.WorkerDiscrepancy{}
+	for _, discrepancy := range previous {
+		previousByHandle[discrepancy.Type+"/"+discrepancy.Handle] = discrepancy
+	}
+
+	_, err = psql.Delete("worker_discrepancies").
+		Where(sq.Eq{"worker_name": worker.name}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, discrepancy := range found {
+		since := sq.Expr("now()")
+		heartbeats := 1
+
+		prev, seen := previousByHandle[discrepancy.Type+"/"+discrepancy.Handle]
+		if seen && prev.Problem == discrepancy.Problem {
+			since = sq.Expr("to_timestamp(?)", prev.Since)
+			heartbeats = prev.Heartbeats + 1
+		}
+
+		var buildID sql.NullInt64
+		if discrepancy.BuildID != 0 {
+			buildID = sql.NullInt64{Int64: int64(discrepancy.BuildID), Valid: true}
+		}
+
+		_, err = psql.Insert("worker_discrepancies").
+			Columns("worker_name", "type", "handle", "problem", "build_id", "since", "heartbeats").
+			Values(worker.name, discrepancy.Type, discrepancy.Handle, discrepancy.Problem, buildID, since, heartbeats).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	discrepancies, err := queryWorkerDiscrepancies(tx, sq.Eq{"worker_name": worker.name})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return discrepancies, nil
+}
+
+// Discrepancies returns the discrepancies found by the worker's last census.
+func (worker *worker) Discrepancies() ([]atc.WorkerDiscrepancy, error) {
+	return queryWorkerDiscrepancies(worker.conn, sq.Eq{"worker_name": worker.name})
+}
+
+// ResolveDiscrepancies brings the database in line with the worker for each
+// discrepancy found by more than one census. Containers and volumes missing
+// on the worker are removed from the database, and those unknown to the
+// database are recorded as destroying, so that the worker destroys them. It
+// returns the number of discrepancies resolved.
+func (worker *worker) ResolveDiscrepancies() (int, error) {
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	discrepancies, err := queryWorkerDiscrepancies(tx, sq.And{
+		sq.Eq{"worker_name": worker.name},
+		sq.Gt{"heartbeats": 1},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, discrepancy := range discrepancies {
+		err = worker.resolveDiscrepancy(tx, discrepancy)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = psql.Delete("worker_discrepancies").
+			Where(sq.Eq{
+				"worker_name": worker.name,
+				"type":        discrepancy.Type,
+				"handle":      discrepancy.Handle,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(discrepancies), nil
+}
+
+func (worker *worker) resolveDiscrepancy(tx Tx, discrepancy atc.WorkerDiscrepancy) error {
+	table := "containers"
+	missingStates := []string{atc.ContainerStateCreated, atc.ContainerStateFailed}
+	if discrepancy.Type == discrepancyTypeVolume {
+		table = "volumes"
+		missingStates = []string{string(VolumeStateCreated), string(VolumeStateFailed)}
+	}
+
+	var err error
+	switch discrepancy.Problem {
+	case atc.DiscrepancyMissingOnWorker:
+		query := psql.Delete(table).
+			Where(sq.Eq{
+				"worker_name": worker.name,
+				"handle":      discrepancy.Handle,
+				"state":       missingStates,
+			})
+
+		if discrepancy.Type == discrepancyTypeVolume {
+			// volumes with children are left alone; the children are missing
+			// too, and the volume is found again once they've been removed
+			query = query.Where(sq.Expr("NOT EXISTS (SELECT 1 FROM volumes c WHERE c.parent_id = volumes.id)"))
+		}
+
+		_, err = query.RunWith(tx).Exec()
+
+	case atc.DiscrepancyUnknownToDB:
+		_, err = psql.Insert(table).
+			Columns("handle", "worker_name", "state").
+			Values(discrepancy.Handle, worker.name, atc.ContainerStateDestroying).
+			Suffix("ON CONFLICT DO NOTHING").
+			RunWith(tx).
+			Exec()
+	}
+
+	return err
+}
+
+func (worker *worker) censusDiscrepancies(tx Tx, census atc.WorkerCensus) ([]atc.WorkerDiscrepancy, error) {
+	discrepancies := []atc.WorkerDiscrepancy{}
+
+	reportedContainers := map[string]atc.CensusContainer{}
+	for _, container := range census.Containers {
+		reportedContainers[container.Handle] = container
+	}
+
+	rows, err := psql.Select("handle", "state", "meta_build_id").
+		From("containers").
+		Where(sq.Eq{"worker_name": worker.name}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	knownContainers := map[string]bool{}
+	for rows.Next() {
+		var handle, state string
+		var buildID sql.NullInt64
+		err = rows.Scan(&handle, &state, &buildID)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		knownContainers[handle] = true
+
+		_, reported := reportedContainers[handle]
+		if state == atc.ContainerStateCreated && !reported {
+			discrepancies = append(discrepancies, atc.WorkerDiscrepancy{
+				Type:    discrepancyTypeContainer,
+				Handle:  handle,
+				Problem: atc.DiscrepancyMissingOnWorker,
+				BuildID: int(buildID.Int64),
+			})
+		}
+	}
+
+	Close(rows)
+
+	for _, container := range census.Containers {
+		if !knownContainers[container.Handle] {
+			discrepancies = append(discrepancies, atc.WorkerDiscrepancy{
+				Type:    discrepancyTypeContainer,
+				Handle:  container.Handle,
+				Problem: atc.DiscrepancyUnknownToDB,
+				BuildID: container.BuildID,
+			})
+		}
+	}
+
+	reportedVolumes := map[string]bool{}
+	for _, handle := range census.Volumes {
+		reportedVolumes[handle] = true
+	}
+
+	rows, err = psql.Select("handle", "state").
+		From("volumes").
+		Where(sq.Eq{"worker_name": worker.name}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	knownVolumes := map[string]bool{}
+	for rows.Next() {
+		var handle, state string
+		err = rows.Scan(&handle, &state)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		knownVolumes[handle] = true
+
+		if state == string(VolumeStateCreated) && !reportedVolumes[handle] {
+			discrepancies = append(discrepancies, atc.WorkerDiscrepancy{
+				Type:    discrepancyTypeVolume,
+				Handle:  handle,
+				Problem: atc.DiscrepancyMissingOnWorker,
+			})
+		}
+	}
+
+	Close(rows)
+
+	for _, handle := range census.Volumes {
+		if !knownVolumes[handle] {
+			discrepancies = append(discrepancies, atc.WorkerDiscrepancy{
+				Type:    discrepancyTypeVolume,
+				Handle:  handle,
+				Problem: atc.DiscrepancyUnknownToDB,
+			})
+		}
+	}
+
+	sort.Slice(discrepancies, func(i, j int) bool {
+		if discrepancies[i].Type != discrepancies[j].Type {
+			return discrepancies[i].Type < discrepancies[j].Type
+		}
+
+		return discrepancies[i].Handle < discrepancies[j].Handle
+	})
+
+	return discrepancies, nil
+}
+
+func queryWorkerDiscrepancies(runner sq.BaseRunner, where sq.Sqlizer) ([]atc.WorkerDiscrepancy, error) {
+	rows, err := psql.Select("type", "handle", "problem", "build_id", "EXTRACT(EPOCH FROM since)::bigint", "heartbeats").
+		From("worker_discrepancies").
+		Where(where).
+		OrderBy("type", "handle").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	discrepancies := []atc.WorkerDiscrepancy{}
+	for rows.Next() {
+		var discrepancy atc.WorkerDiscrepancy
+		var buildID sql.NullInt64
+		err = rows.Scan(
+			&discrepancy.Type,
+			&discrepancy.Handle,
+			&discrepancy.Problem,
+			&buildID,
+			&discrepancy.Since,
+			&discrepancy.Heartbeats,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		discrepancy.BuildID = int(buildID.Int64)
+
+		discrepancies = append(discrepancies, discrepancy)
+	}
+
+	return discrepancies, nil
+}
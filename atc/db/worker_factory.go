@@ -50,6 +50,11 @@ var workersQuery = psql.Select(`
 		w.resource_types,
 		w.platform,
 		w.tags,
+		w.labels,
+		w.gpus,
+		w.stream_encoding,
+		w.protocol_version,
+		w.capabilities,
 		t.name,
 		w.team_id,
 		w.start_time,
@@ -124,22 +129,25 @@ func getWorkers(conn Conn, query sq.SelectBuilder) ([]Worker, error) {
 
 func scanWorker(worker *worker, row scannable) error {
 	var (
-		version       sql.NullString
-		addStr        sql.NullString
-		state         string
-		bcURLStr      sql.NullString
-		certsPathStr  sql.NullString
-		httpProxyURL  sql.NullString
-		httpsProxyURL sql.NullString
-		noProxy       sql.NullString
-		resourceTypes []byte
-		platform      sql.NullString
-		tags          []byte
-		teamName      sql.NullString
-		teamID        sql.NullInt64
-		startTime     pq.NullTime
-		expiresAt     pq.NullTime
-		ephemeral     sql.NullBool
+		version        sql.NullString
+		addStr         sql.NullString
+		state          string
+		bcURLStr       sql.NullString
+		certsPathStr   sql.NullString
+		httpProxyURL   sql.NullString
+		httpsProxyURL  sql.NullString
+		noProxy        sql.NullString
+		resourceTypes  []byte
+		platform       sql.NullString
+		tags           []byte
+		labels         []byte
+		streamEncoding sql.NullString
+		capabilities   []byte
+		teamName       sql.NullString
+		teamID         sql.NullInt64
+		startTime      pq.NullTime
+		expiresAt      pq.NullTime
+		ephemeral      sql.NullBool
 	)
 
 	err := row.Scan(
@@ -157,6 +165,11 @@ func scanWorker(worker *worker, row scannable) error {
 		&resourceTypes,
 		&platform,
 		&tags,
+		&labels,
+		&worker.gpus,
+		&streamEncoding,
+		&worker.protocolVersion,
+		&capabilities,
 		&teamName,
 		&teamID,
 		&startTime,
@@ -199,6 +212,10 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.noProxy = noProxy.String
 	}
 
+	if streamEncoding.Valid {
+		worker.streamEncoding = streamEncoding.String
+	}
+
 	if teamName.Valid {
 		worker.teamName = teamName.String
 	}
@@ -220,6 +237,20 @@ func scanWorker(worker *worker, row scannable) error {
 		return err
 	}
 
+	if labels != nil {
+		err = json.Unmarshal(labels, &worker.labels)
+		if err != nil {
+			return err
+		}
+	}
+
+	if capabilities != nil {
+		err = json.Unmarshal(capabilities, &worker.capabilities)
+		if err != nil {
+			return err
+		}
+	}
+
 	return json.Unmarshal(tags, &worker.tags)
 }
 
@@ -334,10 +365,14 @@ func (f *workerFactory) FindWorkersForContainerByOwner(owner ContainerOwner) ([]
 	return workers, nil
 }
 
+// BuildContainersCountPerWorker counts the containers of running builds on
+// each worker, leaving out the containers of paused builds as they aren't
+// doing any work.
 func (f *workerFactory) BuildContainersCountPerWorker() (map[string]int, error) {
 	rows, err := psql.Select("worker_name, COUNT(*)").
 		From("containers").
 		Where("build_id IS NOT NULL").
+		Where("NOT EXISTS (SELECT 1 FROM builds b WHERE b.id = containers.build_id AND b.paused)").
 		GroupBy("worker_name").
 		RunWith(f.conn).
 		Query()
@@ -375,6 +410,21 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	labels, err := json.Marshal(atcWorker.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities, err := json.Marshal(atcWorker.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	protocolVersion := atcWorker.ProtocolVersion
+	if protocolVersion == 0 {
+		protocolVersion = atc.LegacyWorkerProtocolVersion
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -400,6 +450,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		atcWorker.ActiveVolumes,
 		resourceTypes,
 		tags,
+		labels,
+		atcWorker.GPUs,
+		atcWorker.StreamEncoding,
+		protocolVersion,
+		capabilities,
 		atcWorker.Platform,
 		atcWorker.BaggageclaimURL,
 		atcWorker.CertsPath,
@@ -431,6 +486,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"active_volumes",
 			"resource_types",
 			"tags",
+			"labels",
+			"gpus",
+			"stream_encoding",
+			"protocol_version",
+			"capabilities",
 			"platform",
 			"baggageclaim_url",
 			"certs_path",
@@ -456,6 +516,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				active_volumes = ?,
 				resource_types = ?,
 				tags = ?,
+				labels = ?,
+				gpus = ?,
+				stream_encoding = ?,
+				protocol_version = ?,
+				capabilities = ?,
 				platform = ?,
 				baggageclaim_url = ?,
 				certs_path = ?,
@@ -505,6 +570,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
+		labels:           atcWorker.Labels,
+		gpus:             atcWorker.GPUs,
+		streamEncoding:   atcWorker.StreamEncoding,
+		protocolVersion:  protocolVersion,
+		capabilities:     atcWorker.Capabilities,
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
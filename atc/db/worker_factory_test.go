@@ -44,8 +44,14 @@ var _ = Describe("WorkerFactory", func() {
 			},
 			Platform:  "some-platform",
 			Tags:      atc.Tags{"some", "tags"},
+			Labels:    atc.Labels{"zone": "us-east-1"},
 			Name:      "some-name",
 			StartTime: 1565367209,
+
+			StreamEncoding: "gzip",
+
+			ProtocolVersion: 2,
+			Capabilities:    []atc.WorkerCapability{atc.WorkerCapabilityGPUs},
 		}
 	})
 
@@ -242,6 +248,10 @@ var _ = Describe("WorkerFactory", func() {
 				}))
 				Expect(foundWorker.Platform()).To(Equal("some-platform"))
 				Expect(foundWorker.Tags()).To(Equal([]string{"some", "tags"}))
+				Expect(foundWorker.Labels()).To(Equal(atc.Labels{"zone": "us-east-1"}))
+				Expect(foundWorker.StreamEncoding()).To(Equal("gzip"))
+				Expect(foundWorker.ProtocolVersion()).To(Equal(2))
+				Expect(foundWorker.Capabilities()).To(Equal([]atc.WorkerCapability{atc.WorkerCapabilityGPUs}))
 				Expect(foundWorker.StartTime().Unix()).To(Equal(int64(1565367209)))
 				Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
 			})
@@ -629,6 +639,7 @@ var _ = Describe("WorkerFactory", func() {
 					owner = db.NewResourceConfigCheckSessionContainerOwner(
 						rcs.ResourceConfig().ID(),
 						rcs.ResourceConfig().OriginBaseResourceType().ID,
+						defaultTeam.ID(),
 						ownerExpiries,
 					)
 
@@ -797,5 +808,17 @@ var _ = Describe("WorkerFactory", func() {
 			Expect(containersCountByWorker[defaultWorker.Name()]).To(Equal(1))
 			Expect(containersCountByWorker[worker.Name()]).To(Equal(1))
 		})
+
+		Context("when the build is paused", func() {
+			BeforeEach(func() {
+				Expect(build.SetPaused(true)).To(Succeed())
+			})
+
+			It("does not count the build's containers", func() {
+				containersCountByWorker, err := workerFactory.BuildContainersCountPerWorker()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(containersCountByWorker).To(BeEmpty())
+			})
+		})
 	})
 })
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// WorkerImageLayer is a layer of a registry image, identified by the digest
+// of its blob, which is cached in a volume on a worker so that images sharing
+// the layer do not download it again.
+type WorkerImageLayer struct {
+	WorkerName string
+	Digest     string
+}
+
+type UsedWorkerImageLayer struct {
+	ID int
+}
+
+// FindOrCreate also marks the layer as used, so that it is not removed by
+// garbage collection while images are still being assembled from it.
+func (layer WorkerImageLayer) FindOrCreate(tx Tx) (*UsedWorkerImageLayer, error) {
+	var id int
+	err := psql.Insert("worker_image_layers").
+		Columns("worker_name", "digest").
+		Values(layer.WorkerName, layer.Digest).
+		Suffix(`
+			ON CONFLICT (worker_name, digest) DO UPDATE SET
+				last_used = now()
+			RETURNING id
+		`).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsedWorkerImageLayer{
+		ID: id,
+	}, nil
+}
+
+func (layer WorkerImageLayer) Find(runner sq.Runner) (*UsedWorkerImageLayer, bool, error) {
+	var id int
+	err := psql.Select("id").
+		From("worker_image_layers").
+		Where(sq.Eq{
+			"worker_name": layer.WorkerName,
+			"digest":      layer.Digest,
+		}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return &UsedWorkerImageLayer{
+		ID: id,
+	}, true, nil
+}
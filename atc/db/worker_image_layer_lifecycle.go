@@ -0,0 +1,32 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . WorkerImageLayerLifecycle
+
+type WorkerImageLayerLifecycle interface {
+	RemoveUnusedImageLayers() error
+}
+
+type imageLayerLifecycle struct {
+	conn Conn
+}
+
+func NewImageLayerLifecycle(conn Conn) *imageLayerLifecycle {
+	return &imageLayerLifecycle{
+		conn: conn,
+	}
+}
+
+// RemoveUnusedImageLayers removes image layers which no image has been
+// assembled from for a day, leaving their volumes to be garbage collected.
+func (lifecycle *imageLayerLifecycle) RemoveUnusedImageLayers() error {
+	_, err := psql.Delete("worker_image_layers").
+		Where(sq.Expr("last_used < NOW() - interval '24 hours'")).
+		RunWith(lifecycle.conn).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,42 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerImageLayerLifecycle", func() {
+	var imageLayerLifecycle db.WorkerImageLayerLifecycle
+
+	BeforeEach(func() {
+		imageLayerLifecycle = db.NewImageLayerLifecycle(dbConn)
+	})
+
+	Describe("RemoveUnusedImageLayers", func() {
+		BeforeEach(func() {
+			_, err := dbConn.Exec("INSERT INTO worker_image_layers(worker_name, digest, last_used) VALUES($1, 'sha256:old', NOW() - '25 hours'::interval)", defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec("INSERT INTO worker_image_layers(worker_name, digest) VALUES($1, 'sha256:recent')", defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes layers which have not been used for a day", func() {
+			err := imageLayerLifecycle.RemoveUnusedImageLayers()
+			Expect(err).ToNot(HaveOccurred())
+
+			var digests []string
+			rows, err := dbConn.Query("SELECT digest FROM worker_image_layers")
+			Expect(err).ToNot(HaveOccurred())
+
+			for rows.Next() {
+				var digest string
+				Expect(rows.Scan(&digest)).To(Succeed())
+				digests = append(digests, digest)
+			}
+
+			Expect(digests).To(ConsistOf("sha256:recent"))
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/db"
 
@@ -245,6 +246,7 @@ var _ = Describe("Worker", func() {
 			containerOwner = NewResourceConfigCheckSessionContainerOwner(
 				resourceConfig.ID(),
 				resourceConfig.OriginBaseResourceType().ID,
+				defaultTeam.ID(),
 				expiries,
 			)
 		})
@@ -280,6 +282,32 @@ var _ = Describe("Worker", func() {
 				})
 			})
 
+			Context("when finding for another team", func() {
+				BeforeEach(func() {
+					otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+					Expect(err).ToNot(HaveOccurred())
+
+					resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
+						"some-resource-type",
+						atc.Source{"some": "source"},
+						atc.VersionedResourceTypes{},
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					containerOwner = NewResourceConfigCheckSessionContainerOwner(
+						resourceConfig.ID(),
+						resourceConfig.OriginBaseResourceType().ID,
+						otherTeam.ID(),
+						expiries,
+					)
+				})
+
+				It("does not find it", func() {
+					Expect(foundCreatingContainer).To(BeNil())
+					Expect(foundCreatedContainer).To(BeNil())
+				})
+			})
+
 			Context("when there is a created container", func() {
 				BeforeEach(func() {
 					_, err := creatingContainer.Created()
@@ -436,4 +464,114 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("ReconcileCensus/ResolveDiscrepancies", func() {
+		var (
+			createdContainer CreatedContainer
+			discrepancies    []atc.WorkerDiscrepancy
+		)
+
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
+				"some-resource-type",
+				atc.Source{"some": "source"},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			owner := NewResourceConfigCheckSessionContainerOwner(
+				resourceConfig.ID(),
+				resourceConfig.OriginBaseResourceType().ID,
+				defaultTeam.ID(),
+				ContainerOwnerExpiries{Min: 5 * time.Minute, Max: time.Hour},
+			)
+
+			creatingContainer, err := worker.CreateContainer(owner, ContainerMetadata{Type: "check"})
+			Expect(err).ToNot(HaveOccurred())
+
+			createdContainer, err = creatingContainer.Created()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		reconcile := func() {
+			var err error
+			discrepancies, err = worker.ReconcileCensus(atc.WorkerCensus{
+				Containers: []atc.CensusContainer{
+					{Handle: "some-unknown-handle", BuildID: 42},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("finds containers missing on the worker and unknown to the database", func() {
+			reconcile()
+
+			Expect(discrepancies).To(HaveLen(2))
+			Expect(discrepancies[0].Handle).To(Equal(createdContainer.Handle()))
+			Expect(discrepancies[0].Problem).To(Equal(atc.DiscrepancyMissingOnWorker))
+			Expect(discrepancies[0].Heartbeats).To(Equal(1))
+			Expect(discrepancies[1].Handle).To(Equal("some-unknown-handle"))
+			Expect(discrepancies[1].Problem).To(Equal(atc.DiscrepancyUnknownToDB))
+			Expect(discrepancies[1].BuildID).To(Equal(42))
+
+			saved, err := worker.Discrepancies()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(Equal(discrepancies))
+		})
+
+		It("does not resolve discrepancies found by a single census", func() {
+			reconcile()
+
+			resolved, err := worker.ResolveDiscrepancies()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(Equal(0))
+		})
+
+		Context("when the discrepancies are found again", func() {
+			BeforeEach(func() {
+				reconcile()
+				reconcile()
+			})
+
+			It("counts up their heartbeats", func() {
+				Expect(discrepancies).To(HaveLen(2))
+				Expect(discrepancies[0].Heartbeats).To(Equal(2))
+				Expect(discrepancies[1].Heartbeats).To(Equal(2))
+			})
+
+			It("resolves them", func() {
+				resolved, err := worker.ResolveDiscrepancies()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resolved).To(Equal(2))
+
+				var count int
+				err = psql.Select("COUNT(*)").
+					From("containers").
+					Where(sq.Eq{"handle": createdContainer.Handle()}).
+					RunWith(dbConn).
+					QueryRow().
+					Scan(&count)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+
+				var state string
+				err = psql.Select("state").
+					From("containers").
+					Where(sq.Eq{"handle": "some-unknown-handle"}).
+					RunWith(dbConn).
+					QueryRow().
+					Scan(&state)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(atc.ContainerStateDestroying))
+
+				remaining, err := worker.Discrepancies()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(remaining).To(BeEmpty())
+			})
+		})
+	})
 })
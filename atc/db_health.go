@@ -0,0 +1,45 @@
+package atc
+
+// DBHealth is how bloated the database's busiest tables and their indexes
+// are, as last counted by postgres' statistics collector, along with
+// maintenance which would help. These tables degrade silently when
+// autovacuum can't keep up with the rows builds and checks churn through.
+type DBHealth struct {
+	Tables []TableHealth `json:"tables"`
+}
+
+type TableHealth struct {
+	Name string `json:"name"`
+
+	LiveTuples int64 `json:"live_tuples"`
+	DeadTuples int64 `json:"dead_tuples"`
+
+	// DeadTupleRatio is the share of the table's tuples which are dead, from
+	// 0 to 1.
+	DeadTupleRatio float64 `json:"dead_tuple_ratio"`
+
+	TableBytes int64 `json:"table_bytes"`
+	IndexBytes int64 `json:"index_bytes"`
+
+	// LastVacuumed and LastAnalyzed are when the table was last vacuumed or
+	// analyzed, whether manually or by autovacuum. They are left out if it
+	// never has been.
+	LastVacuumed int64 `json:"last_vacuumed,omitempty"`
+	LastAnalyzed int64 `json:"last_analyzed,omitempty"`
+
+	Indexes []IndexHealth `json:"indexes"`
+
+	Suggestions []string `json:"suggestions"`
+}
+
+type IndexHealth struct {
+	Name  string `json:"name"`
+	Bytes int64  `json:"bytes"`
+	Scans int64  `json:"scans"`
+
+	// Valid is false for an index left behind by a failed concurrent build,
+	// which is kept up to date but never used.
+	Valid bool `json:"valid"`
+
+	Suggestions []string `json:"suggestions"`
+}
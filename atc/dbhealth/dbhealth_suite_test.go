@@ -0,0 +1,13 @@
+package dbhealth_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDBHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DB Health Suite")
+}
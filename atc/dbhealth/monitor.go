@@ -0,0 +1,56 @@
+package dbhealth
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// Monitor emits the health of the database's busiest tables each time it
+// runs, and logs the maintenance suggested for them, so that bloat is
+// noticed before queries against the tables slow down.
+type Monitor struct {
+	repository db.DBHealthRepository
+}
+
+func NewMonitor(repository db.DBHealthRepository) *Monitor {
+	return &Monitor{
+		repository: repository,
+	}
+}
+
+func (m *Monitor) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("db-health")
+
+	health, err := m.repository.Health()
+	if err != nil {
+		logger.Error("failed-to-get-health", err)
+		return err
+	}
+
+	for _, table := range health.Tables {
+		suggestions := append([]string{}, table.Suggestions...)
+		for _, index := range table.Indexes {
+			suggestions = append(suggestions, index.Suggestions...)
+		}
+
+		for _, suggestion := range suggestions {
+			logger.Info("maintenance-suggested", lager.Data{
+				"table":      table.Name,
+				"suggestion": suggestion,
+			})
+		}
+
+		metric.DatabaseTableHealth{
+			Table:       table.Name,
+			DeadTuples:  table.DeadTuples,
+			Bytes:       table.TableBytes + table.IndexBytes,
+			Suggestions: len(suggestions),
+		}.Emit(logger)
+	}
+
+	return nil
+}
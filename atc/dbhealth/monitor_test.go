@@ -0,0 +1,105 @@
+package dbhealth_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/dbhealth"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Monitor", func() {
+	var (
+		fakeRepository *dbfakes.FakeDBHealthRepository
+		logger         *lagertest.TestLogger
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeDBHealthRepository)
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), logger)
+		runErr = dbhealth.NewMonitor(fakeRepository).Run(ctx)
+	})
+
+	Context("when getting the health fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeRepository.HealthReturns(atc.DBHealth{}, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when maintenance is suggested for a table", func() {
+		var emitter *metricfakes.FakeEmitter
+
+		BeforeEach(func() {
+			fakeRepository.HealthReturns(atc.DBHealth{
+				Tables: []atc.TableHealth{
+					{
+						Name:        "builds",
+						DeadTuples:  50000,
+						TableBytes:  1000,
+						IndexBytes:  500,
+						Suggestions: []string{"VACUUM ANALYZE builds"},
+						Indexes: []atc.IndexHealth{
+							{Name: "builds_pkey", Valid: true, Suggestions: []string{}},
+							{Name: "builds_bad", Suggestions: []string{"DROP INDEX builds_bad"}},
+						},
+					},
+				},
+			}, nil)
+
+			emitterFactory := new(metricfakes.FakeEmitterFactory)
+			emitter = new(metricfakes.FakeEmitter)
+
+			metric.RegisterEmitter(emitterFactory)
+			emitterFactory.IsConfiguredReturns(true)
+			emitterFactory.NewEmitterReturns(emitter, nil)
+
+			metric.Initialize(lager.NewLogger("dont-care"), "test", map[string]string{}, 1000, nil)
+		})
+
+		It("emits the table's dead tuples and size as warnings", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Eventually(emitter.EmitCallCount).Should(Equal(2))
+
+			events := map[string]metric.Event{}
+			for i := 0; i < emitter.EmitCallCount(); i++ {
+				_, event := emitter.EmitArgsForCall(i)
+				events[event.Name] = event
+			}
+
+			Expect(events["database table dead tuples"].Value).To(Equal(int64(50000)))
+			Expect(events["database table dead tuples"].State).To(Equal(metric.EventStateWarning))
+			Expect(events["database table dead tuples"].Attributes).To(Equal(map[string]string{"table": "builds"}))
+
+			Expect(events["database table bytes"].Value).To(Equal(int64(1500)))
+			Expect(events["database table bytes"].State).To(Equal(metric.EventStateWarning))
+		})
+
+		It("logs the suggestions for the table and its indexes", func() {
+			Expect(logger.LogMessages()).To(Equal([]string{
+				"test.db-health.maintenance-suggested",
+				"test.db-health.maintenance-suggested",
+			}))
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package atc
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConfigDeprecation is a construct in a pipeline's config which still works
+// but is slated for removal, along with where it was found.
+type ConfigDeprecation struct {
+	// Location identifies the part of the config using the construct, e.g.
+	// "jobs.some-job" or "resources.some-resource".
+	Location string `json:"location"`
+
+	// Construct names what is deprecated, e.g. "aggregate".
+	Construct string `json:"construct"`
+
+	Message string `json:"message"`
+}
+
+// DeprecatedResourceTypes maps the names of resource types which pipelines
+// should migrate away from to a message saying what to use instead.
+type DeprecatedResourceTypes map[string]string
+
+// Deprecations returns the deprecated constructs used by the config. Uses of
+// the given resource types are included unless the pipeline defines a
+// resource type of the same name.
+func (c Config) Deprecations(deprecatedTypes DeprecatedResourceTypes) []ConfigDeprecation {
+	deprecations := []ConfigDeprecation{}
+
+	deprecatedType := func(location string, typeName string) {
+		if _, overridden := c.ResourceTypes.Lookup(typeName); overridden {
+			return
+		}
+
+		message, found := deprecatedTypes[typeName]
+		if !found {
+			return
+		}
+
+		if message == "" {
+			message = fmt.Sprintf("resource type '%s' is deprecated", typeName)
+		}
+
+		deprecations = append(deprecations, ConfigDeprecation{
+			Location:  location,
+			Construct: "type: " + typeName,
+			Message:   message,
+		})
+	}
+
+	for _, resourceType := range c.ResourceTypes {
+		deprecatedType("resource_types."+resourceType.Name, resourceType.Type)
+	}
+
+	for _, resource := range c.Resources {
+		deprecatedType("resources."+resource.Name, resource.Type)
+	}
+
+	for _, job := range c.Jobs {
+		location := "jobs." + job.Name
+
+		if job.BuildLogsToRetain != 0 {
+			deprecations = append(deprecations, ConfigDeprecation{
+				Location:  location,
+				Construct: "build_logs_to_retain",
+				Message:   "build_logs_to_retain is deprecated; use build_log_retention instead",
+			})
+		}
+
+		for _, plan := range job.Plans() {
+			if plan.Aggregate != nil {
+				deprecations = append(deprecations, ConfigDeprecation{
+					Location:  location,
+					Construct: "aggregate",
+					Message:   "aggregate is deprecated and will be removed in a future version; use in_parallel instead",
+				})
+
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(deprecations, func(i, j int) bool {
+		return deprecations[i].Location < deprecations[j].Location
+	})
+
+	return deprecations
+}
@@ -0,0 +1,114 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	Describe("Deprecations", func() {
+		var (
+			config          atc.Config
+			deprecatedTypes atc.DeprecatedResourceTypes
+		)
+
+		BeforeEach(func() {
+			config = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "some-type"},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{Get: "some-resource"},
+						},
+					},
+				},
+			}
+
+			deprecatedTypes = atc.DeprecatedResourceTypes{
+				"some-deprecated-type": "use some-type instead",
+				"some-other-type":      "",
+			}
+		})
+
+		It("returns nothing for a config without deprecated constructs", func() {
+			Expect(config.Deprecations(deprecatedTypes)).To(BeEmpty())
+		})
+
+		It("reports jobs using aggregate once", func() {
+			config.Jobs[0].Plan = atc.PlanSequence{
+				{Aggregate: &atc.PlanSequence{{Get: "some-resource"}}},
+				{Aggregate: &atc.PlanSequence{{Get: "some-resource"}}},
+			}
+
+			Expect(config.Deprecations(deprecatedTypes)).To(Equal([]atc.ConfigDeprecation{
+				{
+					Location:  "jobs.some-job",
+					Construct: "aggregate",
+					Message:   "aggregate is deprecated and will be removed in a future version; use in_parallel instead",
+				},
+			}))
+		})
+
+		It("reports aggregate within hooks", func() {
+			config.Jobs[0].Ensure = &atc.PlanConfig{
+				Aggregate: &atc.PlanSequence{{Get: "some-resource"}},
+			}
+
+			Expect(config.Deprecations(deprecatedTypes)).To(HaveLen(1))
+		})
+
+		It("reports jobs using build_logs_to_retain", func() {
+			config.Jobs[0].BuildLogsToRetain = 10
+
+			Expect(config.Deprecations(deprecatedTypes)).To(Equal([]atc.ConfigDeprecation{
+				{
+					Location:  "jobs.some-job",
+					Construct: "build_logs_to_retain",
+					Message:   "build_logs_to_retain is deprecated; use build_log_retention instead",
+				},
+			}))
+		})
+
+		It("reports resources and resource types of deprecated types", func() {
+			config.Resources = append(config.Resources, atc.ResourceConfig{
+				Name: "some-old-resource",
+				Type: "some-deprecated-type",
+			})
+
+			config.ResourceTypes = atc.ResourceTypes{
+				{Name: "some-custom-type", Type: "some-other-type"},
+			}
+
+			Expect(config.Deprecations(deprecatedTypes)).To(Equal([]atc.ConfigDeprecation{
+				{
+					Location:  "resource_types.some-custom-type",
+					Construct: "type: some-other-type",
+					Message:   "resource type 'some-other-type' is deprecated",
+				},
+				{
+					Location:  "resources.some-old-resource",
+					Construct: "type: some-deprecated-type",
+					Message:   "use some-type instead",
+				},
+			}))
+		})
+
+		It("does not report types the pipeline defines itself", func() {
+			config.Resources = append(config.Resources, atc.ResourceConfig{
+				Name: "some-old-resource",
+				Type: "some-deprecated-type",
+			})
+
+			config.ResourceTypes = atc.ResourceTypes{
+				{Name: "some-deprecated-type", Type: "some-type"},
+			}
+
+			Expect(config.Deprecations(deprecatedTypes)).To(BeEmpty())
+		})
+	})
+})
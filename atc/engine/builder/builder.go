@@ -41,6 +41,12 @@ func NewStepBuilder(
 	externalURL string,
 	secrets creds.Secrets,
 	redactSecrets bool,
+	teamFactory db.TeamFactory,
+	caCerts string,
+	containerDNS *atc.ContainerDNS,
+	defaultTaskImage *atc.ImageResource,
+	contentScanPolicy atc.ContentScanPolicy,
+	checksumArtifacts bool,
 ) *stepBuilder {
 	return &stepBuilder{
 		stepFactory:     stepFactory,
@@ -48,6 +54,13 @@ func NewStepBuilder(
 		externalURL:     externalURL,
 		secrets:         secrets,
 		redactSecrets:   redactSecrets,
+		teamFactory:     teamFactory,
+		caCerts:         caCerts,
+		containerDNS:    containerDNS,
+
+		defaultTaskImage:  defaultTaskImage,
+		contentScanPolicy: contentScanPolicy,
+		checksumArtifacts: checksumArtifacts,
 	}
 }
 
@@ -57,6 +70,16 @@ type stepBuilder struct {
 	externalURL     string
 	secrets         creds.Secrets
 	redactSecrets   bool
+	teamFactory     db.TeamFactory
+	caCerts         string
+	containerDNS    *atc.ContainerDNS
+
+	defaultTaskImage    *atc.ImageResource
+	contentScanPolicy   atc.ContentScanPolicy
+	requireImageDigests bool
+	checksumArtifacts   bool
+
+	containerEnv map[string]string
 }
 
 func (builder *stepBuilder) BuildStep(build db.Build) (exec.Step, error) {
@@ -68,8 +91,59 @@ func (builder *stepBuilder) BuildStep(build db.Build) (exec.Step, error) {
 		return exec.IdentityStep{}, errors.New("Schema not supported")
 	}
 
+	var pipeline db.Pipeline
+	if build.PipelineID() != 0 {
+		buildPipeline, found, err := build.Pipeline()
+		if err != nil {
+			return exec.IdentityStep{}, err
+		}
+
+		if found {
+			pipeline = buildPipeline
+		}
+	}
+
+	team, found, err := builder.teamFactory.FindTeam(build.TeamName())
+	if err != nil {
+		return exec.IdentityStep{}, err
+	}
+
+	// the team's config is looked up once per build rather than for every
+	// step, so build the plan with a copy of the builder that carries it along
+	buildBuilder := *builder
+	if found {
+		if pipeline == nil || !pipeline.IgnoreTeamContainerEnv() {
+			buildBuilder.containerEnv = team.ContainerEnv()
+		}
+
+		buildBuilder.caCerts = builder.caCerts + team.CACerts()
+
+		if team.ContainerDNS() != nil {
+			buildBuilder.containerDNS = team.ContainerDNS()
+		}
+
+		if team.DefaultTaskImage() != nil {
+			buildBuilder.defaultTaskImage = team.DefaultTaskImage()
+		}
+
+		if team.ContentScanPolicy() != "" {
+			buildBuilder.contentScanPolicy = team.ContentScanPolicy()
+		}
+
+		buildBuilder.requireImageDigests = team.RequireImageDigests()
+
+		err = checkPrivilegedPolicy(team, build, build.PrivatePlan())
+		if err != nil {
+			return exec.IdentityStep{}, err
+		}
+	}
+
+	if pipeline != nil && pipeline.ContainerDNS() != nil {
+		buildBuilder.containerDNS = pipeline.ContainerDNS()
+	}
+
 	credVarsTracker := vars.NewCredVarsTracker(creds.NewVariables(builder.secrets, build.TeamName(), build.PipelineName()), builder.redactSecrets)
-	return builder.buildStep(build, build.PrivatePlan(), credVarsTracker), nil
+	return buildBuilder.buildStep(build, build.PrivatePlan(), credVarsTracker), nil
 }
 
 func (builder *stepBuilder) CheckStep(check db.Check) (exec.Step, error) {
@@ -82,11 +156,34 @@ func (builder *stepBuilder) CheckStep(check db.Check) (exec.Step, error) {
 		return exec.IdentityStep{}, errors.New("Schema not supported")
 	}
 
+	team, found, err := builder.teamFactory.FindTeam(check.TeamName())
+	if err != nil {
+		return exec.IdentityStep{}, err
+	}
+
+	checkBuilder := *builder
+	if found {
+		pipeline, found, err := team.Pipeline(check.PipelineName())
+		if err != nil {
+			return exec.IdentityStep{}, err
+		}
+
+		if !found || !pipeline.IgnoreTeamContainerEnv() {
+			checkBuilder.containerEnv = team.ContainerEnv()
+		}
+
+		checkBuilder.caCerts = builder.caCerts + team.CACerts()
+	}
+
 	credVarsTracker := vars.NewCredVarsTracker(creds.NewVariables(builder.secrets, check.TeamName(), check.PipelineName()), builder.redactSecrets)
-	return builder.buildCheckStep(check, check.Plan(), credVarsTracker), nil
+	return checkBuilder.buildCheckStep(check, check.Plan(), credVarsTracker), nil
 }
 
 func (builder *stepBuilder) buildStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
+	if len(plan.ConcurrencyPools) > 0 {
+		return builder.buildPoolStep(build, plan, credVarsTracker)
+	}
+
 	if plan.Aggregate != nil {
 		return builder.buildAggregateStep(build, plan, credVarsTracker)
 	}
@@ -187,13 +284,131 @@ func (builder *stepBuilder) buildDoStep(build db.Build, plan atc.Plan, credVarsT
 	for i := len(*plan.Do) - 1; i >= 0; i-- {
 		innerPlan := (*plan.Do)[i]
 		innerPlan.Attempts = plan.Attempts
-		previous := builder.buildStep(build, innerPlan, credVarsTracker)
+
+		if i > 0 {
+			parallelPlan := (*plan.Do)[i-1]
+			parallelPlan.Attempts = plan.Attempts
+
+			needed, ok := fanInBranches(parallelPlan, innerPlan)
+			if ok {
+				fanIn := builder.buildFanInStep(build, parallelPlan, innerPlan, needed, credVarsTracker)
+				step = exec.OnSuccess(exec.Checkpoint(innerPlan.ID, fanIn), step)
+				i--
+				continue
+			}
+		}
+
+		previous := exec.Checkpoint(innerPlan.ID, builder.buildStep(build, innerPlan, credVarsTracker))
 		step = exec.OnSuccess(previous, step)
 	}
 
 	return step
 }
 
+// buildFanInStep builds a parallel step and the task after it such that the
+// task starts as soon as the given branches of the parallel step succeed.
+func (builder *stepBuilder) buildFanInStep(build db.Build, parallelPlan atc.Plan, nextPlan atc.Plan, needed map[int]bool, credVarsTracker vars.CredVarsTracker) exec.Step {
+	fanIn := exec.NewFanIn()
+
+	var branchPlans []atc.Plan
+	if parallelPlan.Aggregate != nil {
+		branchPlans = *parallelPlan.Aggregate
+	} else {
+		branchPlans = parallelPlan.InParallel.Steps
+	}
+
+	var branches []exec.Step
+	for i, branchPlan := range branchPlans {
+		branchPlan.Attempts = parallelPlan.Attempts
+		branch := builder.buildStep(build, branchPlan, credVarsTracker)
+		if needed[i] {
+			branch = fanIn.Needed(branch)
+		}
+
+		branches = append(branches, branch)
+	}
+
+	var parallel exec.Step
+	if parallelPlan.Aggregate != nil {
+		parallel = exec.AggregateStep(branches)
+	} else {
+		parallel = exec.InParallel(branches, parallelPlan.InParallel.Limit, parallelPlan.InParallel.FailFast)
+	}
+
+	next := builder.buildStep(build, nextPlan, credVarsTracker)
+
+	return fanIn.Then(exec.Checkpoint(parallelPlan.ID, parallel), next)
+}
+
+// fanInBranches returns the branches of a parallel step which fetch the
+// artifacts the task after it needs, if the task can start once they've
+// succeeded.
+//
+// This is only known ahead of time for tasks with inline configs after
+// parallel steps made up only of gets; a config file may come from any
+// branch, and other kinds of steps may register any artifacts. There's no
+// point in starting early if the task needs every branch, or none of them.
+func fanInBranches(parallelPlan atc.Plan, nextPlan atc.Plan) (map[int]bool, bool) {
+	task := nextPlan.Task
+	if task == nil || task.Config == nil || task.ConfigPath != "" {
+		return nil, false
+	}
+
+	var branchPlans []atc.Plan
+	switch {
+	case parallelPlan.Aggregate != nil:
+		branchPlans = *parallelPlan.Aggregate
+	case parallelPlan.InParallel != nil:
+		branchPlans = parallelPlan.InParallel.Steps
+	default:
+		return nil, false
+	}
+
+	artifacts := map[string]bool{}
+	for _, input := range task.Config.Inputs {
+		name := input.Name
+		if mapped, found := task.InputMapping[name]; found {
+			name = mapped
+		}
+
+		artifacts[name] = true
+	}
+
+	if task.ImageArtifactName != "" {
+		artifacts[task.ImageArtifactName] = true
+	}
+
+	needed := map[int]bool{}
+	for i, branchPlan := range branchPlans {
+		if branchPlan.Get == nil {
+			return nil, false
+		}
+
+		if artifacts[branchPlan.Get.Name] {
+			needed[i] = true
+		}
+	}
+
+	if len(needed) == 0 || len(needed) == len(branchPlans) {
+		return nil, false
+	}
+
+	return needed, true
+}
+
+func (builder *stepBuilder) buildPoolStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
+	innerPlan := plan
+	innerPlan.ConcurrencyPools = nil
+	step := builder.buildStep(build, innerPlan, credVarsTracker)
+	return exec.Pool(
+		step,
+		plan.ID,
+		plan.ConcurrencyPools,
+		build,
+		builder.delegateFactory.BuildStepDelegate(build, plan.ID, credVarsTracker),
+	)
+}
+
 func (builder *stepBuilder) buildTimeoutStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
 	innerPlan := plan.Timeout.Step
 	innerPlan.Attempts = plan.Attempts
@@ -263,6 +478,25 @@ func (builder *stepBuilder) buildRetryStep(build db.Build, plan atc.Plan, credVa
 
 func (builder *stepBuilder) buildGetStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
 
+	if artifactID, found := build.InputArtifacts()[plan.Get.Name]; found {
+		// the input was uploaded when the build was triggered, so it's given
+		// to the build rather than fetched
+		return builder.buildArtifactInputStep(build, atc.Plan{
+			ID: plan.ID,
+			ArtifactInput: &atc.ArtifactInputPlan{
+				ArtifactID: artifactID,
+				Name:       plan.Get.Name,
+			},
+		}, credVarsTracker)
+	}
+
+	if worker, found := build.WorkerOverrides()[plan.Get.Name]; found {
+		// copied so as to not modify the build's plan
+		getPlan := *plan.Get
+		getPlan.Worker = worker
+		plan.Get = &getPlan
+	}
+
 	containerMetadata := builder.containerMetadata(
 		build,
 		db.ContainerTypeGet,
@@ -285,6 +519,13 @@ func (builder *stepBuilder) buildGetStep(build db.Build, plan atc.Plan, credVars
 
 func (builder *stepBuilder) buildPutStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
 
+	if worker, found := build.WorkerOverrides()[plan.Put.Name]; found {
+		// copied so as to not modify the build's plan
+		putPlan := *plan.Put
+		putPlan.Worker = worker
+		plan.Put = &putPlan
+	}
+
 	containerMetadata := builder.containerMetadata(
 		build,
 		db.ContainerTypePut,
@@ -319,6 +560,8 @@ func (builder *stepBuilder) buildCheckStep(check db.Check, plan atc.Plan, credVa
 		ResourceConfigID:      check.ResourceConfigID(),
 		BaseResourceTypeID:    check.BaseResourceTypeID(),
 		ExternalURL:           builder.externalURL,
+		ContainerEnv:          builder.containerEnv,
+		CACerts:               builder.caCerts,
 	}
 
 	return builder.stepFactory.CheckStep(
@@ -331,6 +574,20 @@ func (builder *stepBuilder) buildCheckStep(check db.Check, plan atc.Plan, credVa
 
 func (builder *stepBuilder) buildTaskStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
 
+	if override, found := build.ImageOverrides()[plan.Task.Name]; found {
+		// copied so as to not modify the build's plan
+		taskPlan := *plan.Task
+		taskPlan.ImageOverride = &override
+		plan.Task = &taskPlan
+	}
+
+	if worker, found := build.WorkerOverrides()[plan.Task.Name]; found {
+		// copied so as to not modify the build's plan
+		taskPlan := *plan.Task
+		taskPlan.Worker = worker
+		plan.Task = &taskPlan
+	}
+
 	containerMetadata := builder.containerMetadata(
 		build,
 		db.ContainerTypeTask,
@@ -410,5 +667,14 @@ func (builder *stepBuilder) stepMetadata(
 		PipelineID:   build.PipelineID(),
 		PipelineName: build.PipelineName(),
 		ExternalURL:  externalURL,
+		BuildToken:   build.Token(),
+		ContainerEnv: builder.containerEnv,
+		CACerts:      builder.caCerts,
+		ContainerDNS: builder.containerDNS,
+
+		DefaultTaskImage:    builder.defaultTaskImage,
+		ContentScanPolicy:   builder.contentScanPolicy,
+		RequireImageDigests: builder.requireImageDigests,
+		ChecksumArtifacts:   builder.checksumArtifacts,
 	}
 }
@@ -1,6 +1,9 @@
 package builder_test
 
 import (
+	"context"
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/engine/builder/builderfakes"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
 )
 
 type StepBuilder interface {
@@ -28,6 +32,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory     *builderfakes.FakeStepFactory
 			fakeDelegateFactory *builderfakes.FakeDelegateFactory
 			fakeSecretManager   *credsfakes.FakeSecrets
+			fakeTeamFactory     *dbfakes.FakeTeamFactory
 
 			planFactory atc.PlanFactory
 			stepBuilder StepBuilder
@@ -37,6 +42,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory = new(builderfakes.FakeStepFactory)
 			fakeDelegateFactory = new(builderfakes.FakeDelegateFactory)
 			fakeSecretManager = new(credsfakes.FakeSecrets)
+			fakeTeamFactory = new(dbfakes.FakeTeamFactory)
 
 			stepBuilder = builder.NewStepBuilder(
 				fakeStepFactory,
@@ -44,6 +50,12 @@ var _ = Describe("Builder", func() {
 				"http://example.com",
 				fakeSecretManager,
 				false,
+				fakeTeamFactory,
+				"",
+				nil,
+				nil,
+				"",
+				false,
 			)
 
 			planFactory = atc.NewPlanFactory(123)
@@ -260,6 +272,88 @@ var _ = Describe("Builder", func() {
 					})
 				})
 
+				Context("with a task after a parallel step of gets", func() {
+					var (
+						taskStep        *execfakes.FakeStep
+						releaseUnneeded chan struct{}
+						taskConfig      *atc.TaskConfig
+					)
+
+					BeforeEach(func() {
+						releaseUnneeded = make(chan struct{})
+
+						fakeStepFactory.GetStepStub = func(plan atc.Plan, _ exec.StepMetadata, _ db.ContainerMetadata, _ exec.GetDelegate) exec.Step {
+							getStep := new(execfakes.FakeStep)
+							getStep.SucceededReturns(true)
+
+							if plan.Get.Name == "unneeded" {
+								getStep.RunStub = func(context.Context, exec.RunState) error {
+									<-releaseUnneeded
+									return nil
+								}
+							}
+
+							return getStep
+						}
+
+						taskStep = new(execfakes.FakeStep)
+						taskStep.SucceededReturns(true)
+						taskStep.RunStub = func(context.Context, exec.RunState) error {
+							close(releaseUnneeded)
+							return nil
+						}
+						fakeStepFactory.TaskStepReturns(taskStep)
+
+						taskConfig = &atc.TaskConfig{
+							Inputs: []atc.TaskInputConfig{{Name: "some-input"}},
+						}
+					})
+
+					JustBeforeEach(func() {
+						expectedPlan = planFactory.NewPlan(atc.DoPlan{
+							planFactory.NewPlan(atc.InParallelPlan{
+								Steps: []atc.Plan{
+									planFactory.NewPlan(atc.GetPlan{Name: "needed"}),
+									planFactory.NewPlan(atc.GetPlan{Name: "unneeded"}),
+								},
+							}),
+							planFactory.NewPlan(atc.TaskPlan{
+								Name:         "some-task",
+								Config:       taskConfig,
+								InputMapping: map[string]string{"some-input": "needed"},
+							}),
+						})
+
+						fakeBuild.PrivatePlanReturns(expectedPlan)
+					})
+
+					It("starts the task once the gets it needs succeed", func() {
+						step, err := stepBuilder.BuildStep(fakeBuild)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(step.Run(context.Background(), new(execfakes.FakeRunState))).To(Succeed())
+						Expect(taskStep.RunCallCount()).To(Equal(1))
+						Expect(step.Succeeded()).To(BeTrue())
+					})
+
+					Context("when the task's config comes from a file", func() {
+						BeforeEach(func() {
+							taskConfig = nil
+
+							taskStep.RunStub = nil
+							close(releaseUnneeded)
+						})
+
+						It("waits for every get before starting the task", func() {
+							step, err := stepBuilder.BuildStep(fakeBuild)
+							Expect(err).ToNot(HaveOccurred())
+
+							Expect(step.Run(context.Background(), new(execfakes.FakeRunState))).To(Succeed())
+							Expect(taskStep.RunCallCount()).To(Equal(1))
+						})
+					})
+				})
+
 				Context("with a retry plan", func() {
 					var (
 						getPlan       atc.Plan
@@ -491,6 +585,25 @@ var _ = Describe("Builder", func() {
 								BuildName:    "42",
 							}))
 						})
+
+						Context("when the build has an uploaded artifact for the input", func() {
+							BeforeEach(func() {
+								fakeBuild.InputArtifactsReturns(atc.InputArtifacts{"some-input": 17})
+							})
+
+							It("gives the build the artifact instead of fetching it", func() {
+								Expect(fakeStepFactory.GetStepCallCount()).To(BeZero())
+								Expect(fakeStepFactory.ArtifactInputStepCallCount()).To(Equal(1))
+
+								plan, build, _ := fakeStepFactory.ArtifactInputStepArgsForCall(0)
+								Expect(plan.ID).To(Equal(expectedPlan.ID))
+								Expect(plan.ArtifactInput).To(Equal(&atc.ArtifactInputPlan{
+									ArtifactID: 17,
+									Name:       "some-input",
+								}))
+								Expect(build).To(Equal(fakeBuild))
+							})
+						})
 					})
 
 					Context("that contains tasks", func() {
@@ -518,6 +631,42 @@ var _ = Describe("Builder", func() {
 								BuildName:    "42",
 							}))
 						})
+
+						Context("when the build overrides the task's image", func() {
+							BeforeEach(func() {
+								fakeBuild.ImageOverridesReturns(atc.TaskImageOverrides{
+									"some-task": {Version: atc.Version{"digest": "sha256:fixed"}},
+								})
+							})
+
+							It("constructs the task with the override", func() {
+								plan, _, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
+								Expect(plan.Task.ImageOverride).To(Equal(&atc.TaskImageOverride{
+									Version: atc.Version{"digest": "sha256:fixed"},
+								}))
+							})
+
+							It("does not modify the build's plan", func() {
+								Expect(expectedPlan.Task.ImageOverride).To(BeNil())
+							})
+						})
+
+						Context("when the build pins the task to a worker", func() {
+							BeforeEach(func() {
+								fakeBuild.WorkerOverridesReturns(atc.StepWorkerOverrides{
+									"some-task": "some-worker",
+								})
+							})
+
+							It("constructs the task pinned to the worker", func() {
+								plan, _, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
+								Expect(plan.Task.Worker).To(Equal("some-worker"))
+							})
+
+							It("does not modify the build's plan", func() {
+								Expect(expectedPlan.Task.Worker).To(BeEmpty())
+							})
+						})
 					})
 
 					Context("that contains outputs", func() {
@@ -583,6 +732,25 @@ var _ = Describe("Builder", func() {
 								BuildName:    "42",
 							}))
 						})
+
+						Context("when the build pins the put and get to workers", func() {
+							BeforeEach(func() {
+								fakeBuild.WorkerOverridesReturns(atc.StepWorkerOverrides{
+									"some-put": "some-worker",
+									"some-get": "some-other-worker",
+								})
+							})
+
+							It("constructs the put pinned to its worker", func() {
+								plan, _, _, _ := fakeStepFactory.PutStepArgsForCall(0)
+								Expect(plan.Put.Worker).To(Equal("some-worker"))
+							})
+
+							It("constructs the dependent get pinned to its worker", func() {
+								plan, _, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+								Expect(plan.Get.Worker).To(Equal("some-other-worker"))
+							})
+						})
 					})
 				})
 
@@ -749,6 +917,282 @@ var _ = Describe("Builder", func() {
 						}))
 					})
 				})
+
+				Context("running steps in concurrency pools", func() {
+					var (
+						inputPlan atc.Plan
+						getStep   *execfakes.FakeStep
+					)
+
+					BeforeEach(func() {
+						getStep = new(execfakes.FakeStep)
+						fakeStepFactory.GetStepReturns(getStep)
+
+						fakeBuild.ClaimStepConcurrencyPoolsReturns(true, nil)
+
+						inputPlan = planFactory.NewPlan(atc.GetPlan{
+							Name: "some-input",
+						})
+						inputPlan.ConcurrencyPools = []string{"some-pool"}
+
+						expectedPlan = inputPlan
+					})
+
+					It("constructs the step without the pools", func() {
+						Expect(fakeStepFactory.GetStepCallCount()).To(Equal(1))
+						plan, _, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+						Expect(plan.ConcurrencyPools).To(BeEmpty())
+						Expect(plan.ID).To(Equal(inputPlan.ID))
+					})
+
+					It("holds the pools while the step runs", func() {
+						step, err := stepBuilder.BuildStep(fakeBuild)
+						Expect(err).ToNot(HaveOccurred())
+
+						getStep.RunStub = func(context.Context, exec.RunState) error {
+							Expect(fakeBuild.ClaimStepConcurrencyPoolsCallCount()).To(Equal(1))
+							Expect(fakeBuild.ReleaseStepConcurrencyPoolsCallCount()).To(BeZero())
+							return nil
+						}
+
+						Expect(step.Run(context.Background(), new(execfakes.FakeRunState))).To(Succeed())
+						Expect(getStep.RunCallCount()).To(Equal(1))
+
+						planID, pools := fakeBuild.ClaimStepConcurrencyPoolsArgsForCall(0)
+						Expect(planID).To(Equal(inputPlan.ID))
+						Expect(pools).To(Equal([]string{"some-pool"}))
+						Expect(fakeBuild.ReleaseStepConcurrencyPoolsCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the team has container env", func() {
+					var (
+						fakeTeam     *dbfakes.FakeTeam
+						fakePipeline *dbfakes.FakePipeline
+					)
+
+					BeforeEach(func() {
+						fakeTeam = new(dbfakes.FakeTeam)
+						fakeTeam.ContainerEnvReturns(map[string]string{"HTTP_PROXY": "http://proxy.example.com"})
+						fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+						fakePipeline = new(dbfakes.FakePipeline)
+						fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+						expectedPlan = planFactory.NewPlan(atc.GetPlan{
+							Name: "some-input",
+						})
+					})
+
+					It("looks up the build's team", func() {
+						Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+						Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+					})
+
+					It("passes the env along in the step metadata", func() {
+						_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+						Expect(stepMetadata.ContainerEnv).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy.example.com"}))
+					})
+
+					Context("when the team has CA certs", func() {
+						BeforeEach(func() {
+							fakeTeam.CACertsReturns("team-ca\n")
+						})
+
+						It("passes them along in the step metadata", func() {
+							_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+							Expect(stepMetadata.CACerts).To(Equal("team-ca\n"))
+						})
+
+						Context("when there are CA certs for the installation", func() {
+							BeforeEach(func() {
+								stepBuilder = builder.NewStepBuilder(
+									fakeStepFactory,
+									fakeDelegateFactory,
+									"http://example.com",
+									fakeSecretManager,
+									false,
+									fakeTeamFactory,
+									"installation-ca\n",
+									nil,
+									nil,
+									"",
+									false,
+								)
+							})
+
+							It("passes both along in the step metadata", func() {
+								_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+								Expect(stepMetadata.CACerts).To(Equal("installation-ca\nteam-ca\n"))
+							})
+						})
+					})
+
+					Context("when there is DNS config for the installation", func() {
+						BeforeEach(func() {
+							stepBuilder = builder.NewStepBuilder(
+								fakeStepFactory,
+								fakeDelegateFactory,
+								"http://example.com",
+								fakeSecretManager,
+								false,
+								fakeTeamFactory,
+								"",
+								&atc.ContainerDNS{Servers: []string{"10.0.0.1"}},
+								nil,
+								"",
+								false,
+							)
+						})
+
+						It("passes it along in the step metadata", func() {
+							_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+							Expect(stepMetadata.ContainerDNS).To(Equal(&atc.ContainerDNS{Servers: []string{"10.0.0.1"}}))
+						})
+
+						Context("when the team has its own DNS config", func() {
+							BeforeEach(func() {
+								fakeTeam.ContainerDNSReturns(&atc.ContainerDNS{Servers: []string{"10.0.0.2"}})
+							})
+
+							It("passes the team's along instead", func() {
+								_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+								Expect(stepMetadata.ContainerDNS).To(Equal(&atc.ContainerDNS{Servers: []string{"10.0.0.2"}}))
+							})
+
+							Context("when the pipeline has its own DNS config", func() {
+								BeforeEach(func() {
+									fakePipeline.ContainerDNSReturns(&atc.ContainerDNS{Servers: []string{"10.0.0.3"}, Search: []string{"corp.example.com"}})
+								})
+
+								It("passes the pipeline's along instead", func() {
+									_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+									Expect(stepMetadata.ContainerDNS).To(Equal(&atc.ContainerDNS{Servers: []string{"10.0.0.3"}, Search: []string{"corp.example.com"}}))
+								})
+							})
+						})
+					})
+
+					Context("when there is a default task image for the installation", func() {
+						BeforeEach(func() {
+							stepBuilder = builder.NewStepBuilder(
+								fakeStepFactory,
+								fakeDelegateFactory,
+								"http://example.com",
+								fakeSecretManager,
+								false,
+								fakeTeamFactory,
+								"",
+								nil,
+								&atc.ImageResource{Type: "docker-image", Source: atc.Source{"repository": "busybox"}},
+								"",
+								false,
+							)
+						})
+
+						It("passes it along in the step metadata", func() {
+							_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+							Expect(stepMetadata.DefaultTaskImage).To(Equal(&atc.ImageResource{Type: "docker-image", Source: atc.Source{"repository": "busybox"}}))
+						})
+
+						Context("when the team has its own default task image", func() {
+							BeforeEach(func() {
+								fakeTeam.DefaultTaskImageReturns(&atc.ImageResource{Type: "registry-image", Source: atc.Source{"repository": "alpine"}})
+							})
+
+							It("passes the team's along instead", func() {
+								_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+								Expect(stepMetadata.DefaultTaskImage).To(Equal(&atc.ImageResource{Type: "registry-image", Source: atc.Source{"repository": "alpine"}}))
+							})
+						})
+					})
+
+					Context("when there is a content scan policy for the installation", func() {
+						BeforeEach(func() {
+							stepBuilder = builder.NewStepBuilder(
+								fakeStepFactory,
+								fakeDelegateFactory,
+								"http://example.com",
+								fakeSecretManager,
+								false,
+								fakeTeamFactory,
+								"",
+								nil,
+								nil,
+								atc.ContentScanPolicyWarn,
+								false,
+							)
+						})
+
+						It("passes it along in the step metadata", func() {
+							_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+							Expect(stepMetadata.ContentScanPolicy).To(Equal(atc.ContentScanPolicyWarn))
+						})
+
+						Context("when the team has its own content scan policy", func() {
+							BeforeEach(func() {
+								fakeTeam.ContentScanPolicyReturns(atc.ContentScanPolicyBlock)
+							})
+
+							It("passes the team's along instead", func() {
+								_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+								Expect(stepMetadata.ContentScanPolicy).To(Equal(atc.ContentScanPolicyBlock))
+							})
+						})
+					})
+
+					Context("when the team has a privileged policy", func() {
+						BeforeEach(func() {
+							fakeTeam.PrivilegedPolicyReturns(&atc.PrivilegedPolicy{Allow: []string{"infra"}})
+
+							expectedPlan = planFactory.NewPlan(atc.DoPlan{
+								planFactory.NewPlan(atc.GetPlan{
+									Name: "some-input",
+									Type: "custom",
+									VersionedResourceTypes: atc.VersionedResourceTypes{
+										{ResourceType: atc.ResourceType{Name: "custom", Type: "docker-image", Privileged: true}},
+									},
+								}),
+								planFactory.NewPlan(atc.TaskPlan{
+									Name:       "some-task",
+									Privileged: true,
+								}),
+							})
+						})
+
+						It("refuses the plan's first privileged step", func() {
+							Expect(err).To(Equal(atc.PrivilegedNotAllowedError{
+								Team:     "some-team",
+								Pipeline: "some-pipeline",
+								Job:      "some-job",
+								Step:     "some-input",
+							}))
+							Expect(fakeStepFactory.GetStepCallCount()).To(BeZero())
+						})
+
+						Context("when the policy allows the build's pipeline", func() {
+							BeforeEach(func() {
+								fakeTeam.PrivilegedPolicyReturns(&atc.PrivilegedPolicy{Allow: []string{"some-pipeline"}})
+							})
+
+							It("builds the plan", func() {
+								Expect(err).ToNot(HaveOccurred())
+								Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(1))
+							})
+						})
+					})
+
+					Context("when the pipeline ignores the team's container env", func() {
+						BeforeEach(func() {
+							fakePipeline.IgnoreTeamContainerEnvReturns(true)
+						})
+
+						It("does not pass the env along", func() {
+							_, stepMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
+							Expect(stepMetadata.ContainerEnv).To(BeEmpty())
+						})
+					})
+				})
 			})
 		})
 	})
@@ -761,6 +1205,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory     *builderfakes.FakeStepFactory
 			fakeDelegateFactory *builderfakes.FakeDelegateFactory
 			fakeSecretManager   *credsfakes.FakeSecrets
+			fakeTeamFactory     *dbfakes.FakeTeamFactory
 
 			planFactory atc.PlanFactory
 			stepBuilder StepBuilder
@@ -770,6 +1215,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory = new(builderfakes.FakeStepFactory)
 			fakeDelegateFactory = new(builderfakes.FakeDelegateFactory)
 			fakeSecretManager = new(credsfakes.FakeSecrets)
+			fakeTeamFactory = new(dbfakes.FakeTeamFactory)
 
 			stepBuilder = builder.NewStepBuilder(
 				fakeStepFactory,
@@ -777,6 +1223,12 @@ var _ = Describe("Builder", func() {
 				"http://example.com",
 				fakeSecretManager,
 				false,
+				fakeTeamFactory,
+				"",
+				nil,
+				nil,
+				"",
+				false,
 			)
 
 			planFactory = atc.NewPlanFactory(123)
@@ -855,6 +1307,53 @@ var _ = Describe("Builder", func() {
 							Type: db.ContainerTypeCheck,
 						}))
 					})
+
+					Context("when the team has container env", func() {
+						var (
+							fakeTeam     *dbfakes.FakeTeam
+							fakePipeline *dbfakes.FakePipeline
+						)
+
+						BeforeEach(func() {
+							fakeCheck.TeamNameReturns("some-team")
+							fakeCheck.PipelineNameReturns("some-pipeline")
+
+							fakePipeline = new(dbfakes.FakePipeline)
+
+							fakeTeam = new(dbfakes.FakeTeam)
+							fakeTeam.ContainerEnvReturns(map[string]string{"HTTPS_PROXY": "http://proxy.example.com"})
+							fakeTeam.PipelineReturns(fakePipeline, true, nil)
+							fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("injects it into the check container", func() {
+							Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+
+							_, stepMetadata, _, _ := fakeStepFactory.CheckStepArgsForCall(0)
+							Expect(stepMetadata.ContainerEnv).To(Equal(map[string]string{"HTTPS_PROXY": "http://proxy.example.com"}))
+						})
+
+						Context("when the pipeline ignores the team's container env", func() {
+							BeforeEach(func() {
+								fakePipeline.IgnoreTeamContainerEnvReturns(true)
+							})
+
+							It("leaves it out of the check container", func() {
+								_, stepMetadata, _, _ := fakeStepFactory.CheckStepArgsForCall(0)
+								Expect(stepMetadata.ContainerEnv).To(BeNil())
+							})
+						})
+
+						Context("when finding the pipeline fails", func() {
+							BeforeEach(func() {
+								fakeTeam.PipelineReturns(nil, false, errors.New("nope"))
+							})
+
+							It("errors", func() {
+								Expect(err).To(HaveOccurred())
+							})
+						})
+					})
 				})
 			})
 		})
@@ -14,14 +14,19 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/versionhook"
 	"github.com/concourse/concourse/vars"
 )
 
-func NewDelegateFactory() *delegateFactory {
-	return &delegateFactory{}
+func NewDelegateFactory(versionNotifier versionhook.Notifier) *delegateFactory {
+	return &delegateFactory{
+		versionNotifier: versionNotifier,
+	}
 }
 
-type delegateFactory struct{}
+type delegateFactory struct {
+	versionNotifier versionhook.Notifier
+}
 
 func (delegate *delegateFactory) GetDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker) exec.GetDelegate {
 	return NewGetDelegate(build, planID, credVarsTracker, clock.NewClock())
@@ -36,7 +41,7 @@ func (delegate *delegateFactory) TaskDelegate(build db.Build, planID atc.PlanID,
 }
 
 func (delegate *delegateFactory) CheckDelegate(check db.Check, planID atc.PlanID, credVarsTracker vars.CredVarsTracker) exec.CheckDelegate {
-	return NewCheckDelegate(check, planID, credVarsTracker, clock.NewClock())
+	return NewCheckDelegate(check, planID, credVarsTracker, clock.NewClock(), delegate.versionNotifier)
 }
 
 func (delegate *delegateFactory) BuildStepDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker) exec.BuildStepDelegate {
@@ -74,10 +79,12 @@ func (d *getDelegate) Initializing(logger lager.Logger) {
 	logger.Info("initializing")
 }
 
-func (d *getDelegate) Starting(logger lager.Logger) {
+func (d *getDelegate) Starting(logger lager.Logger, workerName string, cached bool) {
 	err := d.build.SaveEvent(event.StartGet{
 		Time:   time.Now().Unix(),
 		Origin: d.eventOrigin,
+		Worker: workerName,
+		Cached: cached,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-start-get-event", err)
@@ -141,6 +148,13 @@ func (d *getDelegate) UpdateVersion(log lager.Logger, plan atc.GetPlan, info exe
 	}
 }
 
+func (d *getDelegate) Fetched(logger lager.Logger, plan atc.GetPlan, duration time.Duration, succeeded bool) {
+	err := d.build.SaveResourceFetch(plan.Type, duration, succeeded)
+	if err != nil {
+		logger.Error("failed-to-save-resource-fetch", err)
+	}
+}
+
 func NewPutDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker, clock clock.Clock) exec.PutDelegate {
 	return &putDelegate{
 		BuildStepDelegate: NewBuildStepDelegate(build, planID, credVarsTracker, clock),
@@ -172,10 +186,11 @@ func (d *putDelegate) Initializing(logger lager.Logger) {
 	logger.Info("initializing")
 }
 
-func (d *putDelegate) Starting(logger lager.Logger) {
+func (d *putDelegate) Starting(logger lager.Logger, workerName string) {
 	err := d.build.SaveEvent(event.StartPut{
 		Time:   time.Now().Unix(),
 		Origin: d.eventOrigin,
+		Worker: workerName,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-start-put-event", err)
@@ -254,11 +269,12 @@ func (d *taskDelegate) Initializing(logger lager.Logger, taskConfig atc.TaskConf
 	logger.Info("initializing")
 }
 
-func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig) {
+func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig, workerName string) {
 	err := d.build.SaveEvent(event.StartTask{
 		Origin:     d.eventOrigin,
 		Time:       time.Now().Unix(),
 		TaskConfig: event.ShadowTaskConfig(taskConfig),
+		Worker:     workerName,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-initialize-task-event", err)
@@ -282,32 +298,82 @@ func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus)
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
-func NewCheckDelegate(check db.Check, planID atc.PlanID, credVarsTracker vars.CredVarsTracker, clock clock.Clock) exec.CheckDelegate {
+func NewCheckDelegate(check db.Check, planID atc.PlanID, credVarsTracker vars.CredVarsTracker, clock clock.Clock, versionNotifier versionhook.Notifier) exec.CheckDelegate {
 	return &checkDelegate{
 		BuildStepDelegate: NewBuildStepDelegate(nil, planID, credVarsTracker, clock),
 
-		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		check:       check,
-		clock:       clock,
+		eventOrigin:     event.Origin{ID: event.OriginID(planID)},
+		check:           check,
+		clock:           clock,
+		credVarsTracker: credVarsTracker,
+		versionNotifier: versionNotifier,
 	}
 }
 
 type checkDelegate struct {
 	exec.BuildStepDelegate
 
-	check       db.Check
-	eventOrigin event.Origin
-	clock       clock.Clock
+	check           db.Check
+	eventOrigin     event.Origin
+	clock           clock.Clock
+	credVarsTracker vars.CredVarsTracker
+	versionNotifier versionhook.Notifier
+}
+
+// SaveVersions saves the versions found by the check, and tells the webhooks
+// of each resource checking the same versions about those which are new.
+func (d *checkDelegate) SaveVersions(logger lager.Logger, versions []atc.Version) error {
+	err := d.check.SaveVersions(versions)
+	if err != nil {
+		return err
+	}
+
+	var from atc.Version
+	if plan := d.check.Plan(); plan.Check != nil {
+		from = plan.Check.FromVersion
+	}
+
+	found := versionhook.NewSince(from, versions)
+	if len(found) == 0 {
+		return nil
+	}
+
+	checkables, err := d.check.AllCheckables()
+	if err != nil {
+		logger.Error("failed-to-get-checkables", err)
+		return nil
+	}
+
+	for _, checkable := range checkables {
+		if resource, ok := checkable.(db.Resource); ok {
+			d.versionNotifier.NewVersions(logger, resource, d.credVarsTracker, found)
+		}
+	}
+
+	return nil
+}
+
+func (d *checkDelegate) SaveDeletedVersions(versions []atc.Version) error {
+	return d.check.SaveDeletedVersions(versions)
 }
 
-func (d *checkDelegate) SaveVersions(versions []atc.Version) error {
-	return d.check.SaveVersions(versions)
+func (d *checkDelegate) SaveWorker(workerName string) error {
+	return d.check.SaveWorker(workerName)
 }
 
-func (*checkDelegate) Stdout() io.Writer                                 { return ioutil.Discard }
-func (*checkDelegate) Stderr() io.Writer                                 { return ioutil.Discard }
-func (*checkDelegate) ImageVersionDetermined(db.UsedResourceCache) error { return nil }
-func (*checkDelegate) Errored(lager.Logger, string)                      { return }
+func (d *checkDelegate) SaveOutput(output string) error {
+	return d.check.SaveOutput(output)
+}
+
+func (*checkDelegate) Stdout() io.Writer                                    { return ioutil.Discard }
+func (*checkDelegate) Stderr() io.Writer                                    { return ioutil.Discard }
+func (*checkDelegate) ImageCheckStarted() error                             { return nil }
+func (*checkDelegate) ImageVersionDetermined(db.UsedResourceCache) error    { return nil }
+func (*checkDelegate) ImageFetchStarted() error                             { return nil }
+func (*checkDelegate) ImageFetchFinished() error                            { return nil }
+func (*checkDelegate) ImageBytesStreamed(int64) error                       { return nil }
+func (*checkDelegate) ContainerPrepared(time.Duration, time.Duration) error { return nil }
+func (*checkDelegate) Errored(lager.Logger, string, atc.ErrorCode)          { return }
 
 func NewBuildStepDelegate(
 	build db.Build,
@@ -334,8 +400,79 @@ func (delegate *buildStepDelegate) Variables() vars.CredVarsTracker {
 	return delegate.credVarsTracker
 }
 
+func (delegate *buildStepDelegate) ImageCheckStarted() error {
+	return delegate.build.SaveEvent(event.ImageCheckStarted{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+}
+
 func (delegate *buildStepDelegate) ImageVersionDetermined(resourceCache db.UsedResourceCache) error {
-	return delegate.build.SaveImageResourceVersion(resourceCache)
+	err := delegate.build.SaveImageResourceVersion(resourceCache)
+	if err != nil {
+		return err
+	}
+
+	return delegate.build.SaveEvent(event.ImageVersionDetermined{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:         delegate.clock.Now().Unix(),
+		ImageVersion: resourceCache.Version(),
+	})
+}
+
+func (delegate *buildStepDelegate) ImageFetchStarted() error {
+	return delegate.build.SaveEvent(event.ImageFetchStarted{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+}
+
+func (delegate *buildStepDelegate) ImageFetchFinished() error {
+	return delegate.build.SaveEvent(event.ImageFetchFinished{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+}
+
+func (delegate *buildStepDelegate) ImageBytesStreamed(bytes int64) error {
+	return delegate.build.SaveEvent(event.ImageBytesStreamed{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:  delegate.clock.Now().Unix(),
+		Bytes: bytes,
+	})
+}
+
+func (delegate *buildStepDelegate) ContainerPrepared(imageFetchDuration, inputStreamDuration time.Duration) error {
+	return delegate.build.SaveEvent(event.ContainerPrepared{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:                delegate.clock.Now().Unix(),
+		ImageFetchDuration:  int64(imageFetchDuration.Seconds()),
+		InputStreamDuration: int64(inputStreamDuration.Seconds()),
+	})
+}
+
+func (delegate *buildStepDelegate) ArtifactChecksummed(name string, checksum string, size int64) error {
+	return delegate.build.SaveEvent(event.ArtifactChecksum{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:     delegate.clock.Now().Unix(),
+		Artifact: name,
+		Checksum: checksum,
+		Size:     size,
+	})
 }
 
 type credVarsIterator struct {
@@ -376,13 +513,14 @@ func (delegate *buildStepDelegate) Stderr() io.Writer {
 	)
 }
 
-func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string) {
+func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string, code atc.ErrorCode) {
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
 		},
-		Time: delegate.clock.Now().Unix(),
+		Time:      delegate.clock.Now().Unix(),
+		ErrorCode: code,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-error-event", err)
@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/versionhook/versionhookfakes"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -57,6 +58,20 @@ var _ = Describe("DelegateFactory", func() {
 			delegate = builder.NewGetDelegate(fakeBuild, "some-plan-id", credVarsTracker, fakeClock)
 		})
 
+		Describe("Starting", func() {
+			JustBeforeEach(func() {
+				delegate.Starting(logger, "some-worker", true)
+			})
+
+			It("saves an event with the worker and whether the cache was used", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				startEvent := fakeBuild.SaveEventArgsForCall(0).(event.StartGet)
+				Expect(startEvent.Origin).To(Equal(event.Origin{ID: event.OriginID("some-plan-id")}))
+				Expect(startEvent.Worker).To(Equal("some-worker"))
+				Expect(startEvent.Cached).To(BeTrue())
+			})
+		})
+
 		Describe("Finished", func() {
 			JustBeforeEach(func() {
 				delegate.Finished(logger, exitStatus, info)
@@ -149,6 +164,21 @@ var _ = Describe("DelegateFactory", func() {
 				})
 			})
 		})
+
+		Describe("Fetched", func() {
+			JustBeforeEach(func() {
+				plan := atc.GetPlan{Name: "some-get", Type: "some-type"}
+				delegate.Fetched(logger, plan, 3*time.Second, false)
+			})
+
+			It("saves the fetch for the build", func() {
+				Expect(fakeBuild.SaveResourceFetchCallCount()).To(Equal(1))
+				resourceType, duration, succeeded := fakeBuild.SaveResourceFetchArgsForCall(0)
+				Expect(resourceType).To(Equal("some-type"))
+				Expect(duration).To(Equal(3 * time.Second))
+				Expect(succeeded).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("PutDelegate", func() {
@@ -167,6 +197,19 @@ var _ = Describe("DelegateFactory", func() {
 			delegate = builder.NewPutDelegate(fakeBuild, "some-plan-id", credVarsTracker, fakeClock)
 		})
 
+		Describe("Starting", func() {
+			JustBeforeEach(func() {
+				delegate.Starting(logger, "some-worker")
+			})
+
+			It("saves an event with the worker", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				startEvent := fakeBuild.SaveEventArgsForCall(0).(event.StartPut)
+				Expect(startEvent.Origin).To(Equal(event.Origin{ID: event.OriginID("some-plan-id")}))
+				Expect(startEvent.Worker).To(Equal("some-worker"))
+			})
+		})
+
 		Describe("Finished", func() {
 			JustBeforeEach(func() {
 				delegate.Finished(logger, exitStatus, info)
@@ -240,7 +283,7 @@ var _ = Describe("DelegateFactory", func() {
 
 		Describe("Starting", func() {
 			JustBeforeEach(func() {
-				delegate.Starting(logger, config)
+				delegate.Starting(logger, config, "some-worker")
 			})
 
 			It("saves an event", func() {
@@ -248,6 +291,10 @@ var _ = Describe("DelegateFactory", func() {
 				event := fakeBuild.SaveEventArgsForCall(0)
 				Expect(event.EventType()).To(Equal(atc.EventType("start-task")))
 			})
+
+			It("records the worker running the task", func() {
+				Expect(fakeBuild.SaveEventArgsForCall(0).(event.StartTask).Worker).To(Equal("some-worker"))
+			})
 		})
 
 		Describe("Finished", func() {
@@ -265,21 +312,32 @@ var _ = Describe("DelegateFactory", func() {
 
 	Describe("CheckDelegate", func() {
 		var (
-			delegate  exec.CheckDelegate
-			fakeCheck *dbfakes.FakeCheck
-			versions  []atc.Version
+			delegate            exec.CheckDelegate
+			fakeCheck           *dbfakes.FakeCheck
+			fakeVersionNotifier *versionhookfakes.FakeNotifier
+			versions            []atc.Version
 		)
 
 		BeforeEach(func() {
 			fakeCheck = new(dbfakes.FakeCheck)
+			fakeVersionNotifier = new(versionhookfakes.FakeNotifier)
 
-			delegate = builder.NewCheckDelegate(fakeCheck, "some-plan-id", credVarsTracker, fakeClock)
+			delegate = builder.NewCheckDelegate(fakeCheck, "some-plan-id", credVarsTracker, fakeClock, fakeVersionNotifier)
 			versions = []atc.Version{{"some": "version"}}
 		})
 
 		Describe("SaveVersions", func() {
+			var (
+				fakeResourceType *dbfakes.FakeResourceType
+			)
+
+			BeforeEach(func() {
+				fakeResourceType = new(dbfakes.FakeResourceType)
+				fakeCheck.AllCheckablesReturns([]db.Checkable{fakeResource, fakeResourceType}, nil)
+			})
+
 			JustBeforeEach(func() {
-				Expect(delegate.SaveVersions(versions)).To(Succeed())
+				Expect(delegate.SaveVersions(logger, versions)).To(Succeed())
 			})
 
 			It("saves an event", func() {
@@ -287,6 +345,59 @@ var _ = Describe("DelegateFactory", func() {
 				actualVersions := fakeCheck.SaveVersionsArgsForCall(0)
 				Expect(actualVersions).To(Equal(versions))
 			})
+
+			It("tells the resources checking the versions about them", func() {
+				Expect(fakeVersionNotifier.NewVersionsCallCount()).To(Equal(1))
+
+				_, resource, variables, notifiedVersions := fakeVersionNotifier.NewVersionsArgsForCall(0)
+				Expect(resource).To(Equal(fakeResource))
+				Expect(variables).To(Equal(credVarsTracker))
+				Expect(notifiedVersions).To(Equal(versions))
+			})
+
+			Context("when the check is from one of the versions", func() {
+				BeforeEach(func() {
+					fakeCheck.PlanReturns(atc.Plan{
+						Check: &atc.CheckPlan{FromVersion: atc.Version{"some": "version"}},
+					})
+					versions = []atc.Version{{"some": "version"}, {"some": "other-version"}}
+				})
+
+				It("leaves it out, as it isn't new", func() {
+					Expect(fakeVersionNotifier.NewVersionsCallCount()).To(Equal(1))
+
+					_, _, _, notifiedVersions := fakeVersionNotifier.NewVersionsArgsForCall(0)
+					Expect(notifiedVersions).To(Equal([]atc.Version{{"some": "other-version"}}))
+				})
+			})
+
+			Context("when only the version checked from is found", func() {
+				BeforeEach(func() {
+					fakeCheck.PlanReturns(atc.Plan{
+						Check: &atc.CheckPlan{FromVersion: atc.Version{"some": "version"}},
+					})
+				})
+
+				It("tells no one", func() {
+					Expect(fakeVersionNotifier.NewVersionsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Describe("SaveWorker", func() {
+			It("saves the worker to the check", func() {
+				Expect(delegate.SaveWorker("some-worker")).To(Succeed())
+				Expect(fakeCheck.SaveWorkerCallCount()).To(Equal(1))
+				Expect(fakeCheck.SaveWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+		})
+
+		Describe("SaveOutput", func() {
+			It("saves the output to the check", func() {
+				Expect(delegate.SaveOutput("some-output")).To(Succeed())
+				Expect(fakeCheck.SaveOutputCallCount()).To(Equal(1))
+				Expect(fakeCheck.SaveOutputArgsForCall(0)).To(Equal("some-output"))
+			})
 		})
 	})
 
@@ -304,6 +415,7 @@ var _ = Describe("DelegateFactory", func() {
 
 			BeforeEach(func() {
 				fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+				fakeResourceCache.VersionReturns(atc.Version{"some": "version"})
 				fakeResourceCache.IDReturns(42)
 			})
 
@@ -315,6 +427,71 @@ var _ = Describe("DelegateFactory", func() {
 				Expect(fakeBuild.SaveImageResourceVersionCallCount()).To(Equal(1))
 				Expect(fakeBuild.SaveImageResourceVersionArgsForCall(0)).To(Equal(fakeResourceCache))
 			})
+
+			It("saves an event with the image's version", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ImageVersionDetermined{
+					Origin: event.Origin{
+						ID: event.OriginID("some-plan-id"),
+					},
+					Time:         123456789,
+					ImageVersion: atc.Version{"some": "version"},
+				}))
+			})
+		})
+
+		Describe("ArtifactChecksummed", func() {
+			JustBeforeEach(func() {
+				Expect(delegate.ArtifactChecksummed("some-artifact", "sha256:some-checksum", 2048)).To(Succeed())
+			})
+
+			It("saves an event with the artifact's checksum and size", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ArtifactChecksum{
+					Origin: event.Origin{
+						ID: event.OriginID("some-plan-id"),
+					},
+					Time:     123456789,
+					Artifact: "some-artifact",
+					Checksum: "sha256:some-checksum",
+					Size:     2048,
+				}))
+			})
+		})
+
+		Describe("ImageBytesStreamed", func() {
+			JustBeforeEach(func() {
+				Expect(delegate.ImageBytesStreamed(1024)).To(Succeed())
+			})
+
+			It("saves an event with how much of the image has been streamed", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ImageBytesStreamed{
+					Origin: event.Origin{
+						ID: event.OriginID("some-plan-id"),
+					},
+					Time:  123456789,
+					Bytes: 1024,
+				}))
+			})
+		})
+
+		Describe("ContainerPrepared", func() {
+			JustBeforeEach(func() {
+				Expect(delegate.ContainerPrepared(12*time.Second, 3500*time.Millisecond)).To(Succeed())
+			})
+
+			It("saves an event with how long the container took to prepare", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ContainerPrepared{
+					Origin: event.Origin{
+						ID: event.OriginID("some-plan-id"),
+					},
+					Time:                123456789,
+					ImageFetchDuration:  12,
+					InputStreamDuration: 3,
+				}))
+			})
 		})
 
 		Describe("Stdout", func() {
@@ -424,8 +601,14 @@ var _ = Describe("DelegateFactory", func() {
 		})
 
 		Describe("Errored", func() {
+			var code atc.ErrorCode
+
+			BeforeEach(func() {
+				code = ""
+			})
+
 			JustBeforeEach(func() {
-				delegate.Errored(logger, "fake error message")
+				delegate.Errored(logger, "fake error message", code)
 			})
 
 			Context("when saving the event succeeds", func() {
@@ -445,6 +628,24 @@ var _ = Describe("DelegateFactory", func() {
 				})
 			})
 
+			Context("when the error has a code", func() {
+				BeforeEach(func() {
+					code = atc.ErrorCodeImageCheckFailed
+				})
+
+				It("saves the code with the event", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Error{
+						Time:      123456789,
+						Message:   "fake error message",
+						ErrorCode: atc.ErrorCodeImageCheckFailed,
+						Origin: event.Origin{
+							ID: "some-plan-id",
+						},
+					}))
+				})
+			})
+
 			Context("when saving the event fails", func() {
 				disaster := errors.New("nope")
 
@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// checkPrivilegedPolicy refuses a build's plan if it has privileged steps
+// which the team's privileged policy doesn't allow the build's pipeline and
+// job to run. The image resources of task configs loaded from files aren't
+// known until the build runs, so the workers check the policy again as they
+// create containers.
+func checkPrivilegedPolicy(team db.Team, build db.Build, plan atc.Plan) error {
+	if team.PrivilegedPolicy().Allows(build.PipelineName(), build.JobName()) {
+		return nil
+	}
+
+	var err error
+	plan.Each(func(step atc.Plan) {
+		if err != nil {
+			return
+		}
+
+		if name, privileged := privilegedStep(step); privileged {
+			err = atc.PrivilegedNotAllowedError{
+				Team:     build.TeamName(),
+				Pipeline: build.PipelineName(),
+				Job:      build.JobName(),
+				Step:     name,
+			}
+		}
+	})
+
+	return err
+}
+
+func privilegedStep(plan atc.Plan) (string, bool) {
+	switch {
+	case plan.Task != nil:
+		if plan.Task.Privileged {
+			return plan.Task.Name, true
+		}
+
+		if plan.Task.Config != nil && plan.Task.Config.ImageResource != nil {
+			return plan.Task.Name, plan.Task.VersionedResourceTypes.Privileged(plan.Task.Config.ImageResource.Type)
+		}
+	case plan.Get != nil:
+		return plan.Get.Name, plan.Get.VersionedResourceTypes.Privileged(plan.Get.Type)
+	case plan.Put != nil:
+		return plan.Put.Name, plan.Put.VersionedResourceTypes.Privileged(plan.Put.Type)
+	}
+
+	return "", false
+}
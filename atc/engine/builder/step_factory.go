@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/contentscan"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec"
@@ -21,9 +22,11 @@ type stepFactory struct {
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
 	defaultLimits         atc.ContainerLimits
+	checkLimits           map[string]atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	resourceFactory       resource.ResourceFactory
 	lockFactory           lock.LockFactory
+	contentScanner        contentscan.Scanner
 }
 
 func NewStepFactory(
@@ -33,9 +36,11 @@ func NewStepFactory(
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	defaultLimits atc.ContainerLimits,
+	checkLimits map[string]atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
 	lockFactory lock.LockFactory,
+	contentScanner contentscan.Scanner,
 ) *stepFactory {
 	return &stepFactory{
 		pool:                  pool,
@@ -44,9 +49,11 @@ func NewStepFactory(
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
 		defaultLimits:         defaultLimits,
+		checkLimits:           checkLimits,
 		strategy:              strategy,
 		resourceFactory:       resourceFactory,
 		lockFactory:           lockFactory,
+		contentScanner:        contentScanner,
 	}
 }
 
@@ -67,6 +74,7 @@ func (factory *stepFactory) GetStep(
 		factory.resourceCacheFactory,
 		factory.strategy,
 		factory.pool,
+		factory.contentScanner,
 		delegate,
 	)
 
@@ -90,6 +98,7 @@ func (factory *stepFactory) PutStep(
 		factory.resourceConfigFactory,
 		factory.strategy,
 		factory.pool,
+		factory.contentScanner,
 		delegate,
 	)
 
@@ -107,6 +116,7 @@ func (factory *stepFactory) CheckStep(
 	checkStep := exec.NewCheckStep(
 		plan.ID,
 		*plan.Check,
+		factory.checkLimits[plan.Check.Type],
 		stepMetadata,
 		containerMetadata,
 		factory.resourceFactory,
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+// buildCheckpointer keeps the latest checkpoint a job build reaches in its
+// sequence of steps, saving it only if the build fails so that a running
+// build doesn't write anything for each of its steps.
+type buildCheckpointer struct {
+	build db.Build
+	steps map[atc.PlanID]bool
+
+	lock      sync.Mutex
+	planID    atc.PlanID
+	artifacts []exec.CheckpointArtifact
+}
+
+func newBuildCheckpointer(build db.Build) *buildCheckpointer {
+	steps := map[atc.PlanID]bool{}
+	for _, id := range atc.CheckpointSteps(build.PrivatePlan()) {
+		steps[id] = true
+	}
+
+	return &buildCheckpointer{
+		build: build,
+		steps: steps,
+	}
+}
+
+func (checkpointer *buildCheckpointer) Checkpoint(id atc.PlanID, artifacts []exec.CheckpointArtifact) {
+	if !checkpointer.steps[id] {
+		return
+	}
+
+	checkpointer.lock.Lock()
+	checkpointer.planID = id
+	checkpointer.artifacts = artifacts
+	checkpointer.lock.Unlock()
+}
+
+// save keeps the volumes of the checkpoint's artifacts around as worker
+// artifacts of the build and saves the checkpoint. Volumes which have
+// already been kept are given by their handles, along with the IDs of the
+// worker artifacts they were kept as.
+func (checkpointer *buildCheckpointer) save(logger lager.Logger, kept map[string]int) {
+	checkpointer.lock.Lock()
+	defer checkpointer.lock.Unlock()
+
+	if checkpointer.planID == "" {
+		return
+	}
+
+	checkpoint := atc.BuildCheckpoint{
+		PlanID:    checkpointer.planID,
+		Artifacts: []atc.CheckpointArtifact{},
+	}
+
+	for _, artifact := range checkpointer.artifacts {
+		saved := atc.CheckpointArtifact{
+			Name:      artifact.Name,
+			GetPlanID: artifact.GetPlanID,
+			Version:   artifact.Version,
+		}
+
+		if artifact.Volume != nil {
+			id, found := kept[artifact.Volume.Handle()]
+			if !found {
+				workerArtifact, err := artifact.Volume.InitializeArtifact(artifact.Name, checkpointer.build.ID())
+				if err != nil {
+					logger.Error("failed-to-initialize-artifact", err, lager.Data{"artifact": artifact.Name})
+					return
+				}
+
+				id = workerArtifact.ID()
+			}
+
+			saved.ArtifactID = id
+		}
+
+		checkpoint.Artifacts = append(checkpoint.Artifacts, saved)
+	}
+
+	err := checkpointer.build.SaveCheckpoint(checkpoint)
+	if err != nil {
+		logger.Error("failed-to-save-checkpoint", err)
+		return
+	}
+
+	logger.Info("saved-checkpoint", lager.Data{"plan-id": checkpoint.PlanID})
+}
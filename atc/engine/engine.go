@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -10,7 +12,9 @@ import (
 	"code.cloudfoundry.org/lager/lagerctx"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/metric"
 )
@@ -36,9 +40,16 @@ type StepBuilder interface {
 	CheckStep(db.Check) (exec.Step, error)
 }
 
-func NewEngine(builder StepBuilder) Engine {
+// NewEngine constructs an Engine. When keepOutputs is set, the artifacts
+// held in volumes at the end of each job build are kept around as worker
+// artifacts of the build, so that they can be downloaded. Builds refused by
+// their team's policies are recorded with the auditor.
+func NewEngine(builder StepBuilder, teamFactory db.TeamFactory, auditor auditor.Auditor, keepOutputs bool) Engine {
 	return &engine{
 		builder:       builder,
+		teamFactory:   teamFactory,
+		auditor:       auditor,
+		keepOutputs:   keepOutputs,
 		release:       make(chan bool),
 		trackedStates: new(sync.Map),
 		waitGroup:     new(sync.WaitGroup),
@@ -47,6 +58,9 @@ func NewEngine(builder StepBuilder) Engine {
 
 type engine struct {
 	builder       StepBuilder
+	teamFactory   db.TeamFactory
+	auditor       auditor.Auditor
+	keepOutputs   bool
 	release       chan bool
 	trackedStates *sync.Map
 	waitGroup     *sync.WaitGroup
@@ -73,6 +87,9 @@ func (engine *engine) NewBuild(build db.Build) Runnable {
 		cancel,
 		build,
 		engine.builder,
+		engine.teamFactory,
+		engine.auditor,
+		engine.keepOutputs,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -99,6 +116,9 @@ func NewBuild(
 	cancel func(),
 	build db.Build,
 	builder StepBuilder,
+	teamFactory db.TeamFactory,
+	auditor auditor.Auditor,
+	keepOutputs bool,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -107,8 +127,11 @@ func NewBuild(
 		ctx:    ctx,
 		cancel: cancel,
 
-		build:   build,
-		builder: builder,
+		build:       build,
+		builder:     builder,
+		teamFactory: teamFactory,
+		auditor:     auditor,
+		keepOutputs: keepOutputs,
 
 		release:       release,
 		trackedStates: trackedStates,
@@ -120,8 +143,11 @@ type engineBuild struct {
 	ctx    context.Context
 	cancel func()
 
-	build   db.Build
-	builder StepBuilder
+	build       db.Build
+	builder     StepBuilder
+	teamFactory db.TeamFactory
+	auditor     auditor.Auditor
+	keepOutputs bool
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -175,14 +201,40 @@ func (b *engineBuild) Run(logger lager.Logger) {
 
 	defer notifier.Close()
 
-	step, err := b.builder.BuildStep(b.build)
+	build := b.build
+
+	limitedBuild := b.limitLogs(logger)
+	if limitedBuild != nil {
+		build = limitedBuild
+	}
+
+	step, err := b.builder.BuildStep(build)
 	if err != nil {
 		logger.Error("failed-to-build-step", err)
+
+		// a plan refused by the team's policy will never be allowed to run,
+		// so the build errors rather than being retried
+		var notAllowed atc.PrivilegedNotAllowedError
+		if errors.As(err, &notAllowed) {
+			saveErr := build.SaveEvent(event.Error{
+				Message: err.Error(),
+				Origin:  event.Origin{ID: event.OriginID(b.build.PrivatePlan().ID)},
+				Time:    time.Now().Unix(),
+			})
+			if saveErr != nil {
+				logger.Error("failed-to-save-error-event", saveErr)
+			}
+
+			b.finish(logger.Session("finish"), err, false)
+		}
+
 		return
 	}
 
-	b.trackStarted(logger)
-	defer b.trackFinished(logger)
+	labels := b.labels(logger)
+
+	b.trackStarted(logger, labels)
+	defer b.trackFinished(logger, labels)
 
 	logger.Info("running")
 
@@ -201,9 +253,20 @@ func (b *engineBuild) Run(logger lager.Logger) {
 		}
 	}()
 
+	var checkpointer *buildCheckpointer
+	if b.build.JobID() != 0 {
+		checkpointer = newBuildCheckpointer(b.build)
+	}
+
 	done := make(chan error)
 	go func() {
 		ctx := lagerctx.NewContext(b.ctx, logger)
+		ctx = exec.WithPauseGate(ctx, &pauseGate{build: b.build, logger: logger})
+
+		if checkpointer != nil {
+			ctx = exec.WithCheckpointer(ctx, checkpointer)
+		}
+
 		done <- step.Run(ctx, state)
 	}()
 
@@ -212,16 +275,75 @@ func (b *engineBuild) Run(logger lager.Logger) {
 		logger.Info("releasing")
 
 	case err = <-done:
+		if limitedBuild != nil {
+			flushErr := limitedBuild.flush()
+			if flushErr != nil {
+				logger.Error("failed-to-save-log-tail", flushErr)
+			}
+		}
+
+		// the outputs and checkpoint are saved before the build finishes, so
+		// that they're there as soon as it's seen to have finished
+		kept := map[string]int{}
+		if b.keepOutputs && b.build.JobID() != 0 && err != context.Canceled {
+			kept = b.keepArtifacts(logger.Session("keep-outputs"), state)
+		}
+
+		if checkpointer != nil && err != context.Canceled && !step.Succeeded() {
+			checkpointer.save(logger.Session("save-checkpoint"), kept)
+		}
+
 		b.finish(logger.Session("finish"), err, step.Succeeded())
 	}
 }
 
+// limitLogs returns the build wrapped to enforce its team's limit on log
+// size, or nil if the team has no limit.
+func (b *engineBuild) limitLogs(logger lager.Logger) *logLimitedBuild {
+	team, found, err := b.teamFactory.FindTeam(b.build.TeamName())
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return nil
+	}
+
+	if !found || team.MaxBuildLogSize() <= 0 {
+		return nil
+	}
+
+	limitedBuild := newLogLimitedBuild(b.build, team.MaxBuildLogSize())
+
+	err = limitedBuild.resume()
+	if err != nil {
+		logger.Error("failed-to-resume-log-limit", err)
+		return nil
+	}
+
+	return limitedBuild
+}
+
 func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 	if err == context.Canceled {
 		b.saveStatus(logger, atc.StatusAborted)
 		logger.Info("aborted")
 
 	} else if err != nil {
+		var notAllowed atc.PrivilegedNotAllowedError
+		if errors.As(err, &notAllowed) {
+			b.auditor.AuditEvent(auditor.PrivilegedNotAllowed, lager.Data{
+				"build":    b.build.ID(),
+				"team":     notAllowed.Team,
+				"pipeline": notAllowed.Pipeline,
+				"job":      notAllowed.Job,
+				"step":     notAllowed.Step,
+			})
+		}
+
+		if code := errorCode(err); code != "" {
+			if err := b.build.SaveErrorCode(code); err != nil {
+				logger.Error("failed-to-save-error-code", err)
+			}
+		}
+
 		b.saveStatus(logger, atc.StatusErrored)
 		logger.Info("errored", lager.Data{"error": err.Error()})
 
@@ -235,23 +357,74 @@ func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 	}
 }
 
+// errorCode returns the code of the failure which errored a build. Network
+// errors are only ever seen talking to workers, so they're counted as the
+// worker's failure even though nothing gave them a code.
+func errorCode(err error) atc.ErrorCode {
+	if code := atc.ErrorCodeOf(err); code != "" {
+		return code
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return atc.ErrorCodeWorkerNetworkFailure
+	}
+
+	return ""
+}
+
 func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	if err := b.build.Finish(db.BuildStatus(status)); err != nil {
 		logger.Error("failed-to-finish-build", err)
 	}
 }
 
-func (b *engineBuild) trackStarted(logger lager.Logger) {
+// labels returns the labels of the build's pipeline and job, which are
+// attached to its metrics.
+func (b *engineBuild) labels(logger lager.Logger) atc.Labels {
+	if b.build.PipelineID() == 0 {
+		return nil
+	}
+
+	pipeline, found, err := b.build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return nil
+	}
+
+	if !found {
+		return nil
+	}
+
+	labels := pipeline.Labels()
+
+	if b.build.JobID() != 0 {
+		job, found, err := pipeline.Job(b.build.JobName())
+		if err != nil {
+			logger.Error("failed-to-find-job", err)
+			return labels
+		}
+
+		if found {
+			labels = labels.Merge(job.Config().Labels)
+		}
+	}
+
+	return labels
+}
+
+func (b *engineBuild) trackStarted(logger lager.Logger, labels atc.Labels) {
 	metric.BuildStarted{
 		PipelineName: b.build.PipelineName(),
 		JobName:      b.build.JobName(),
 		BuildName:    b.build.Name(),
 		BuildID:      b.build.ID(),
 		TeamName:     b.build.TeamName(),
+		Labels:       labels,
 	}.Emit(logger)
 }
 
-func (b *engineBuild) trackFinished(logger lager.Logger) {
+func (b *engineBuild) trackFinished(logger lager.Logger, labels atc.Labels) {
 	found, err := b.build.Reload()
 	if err != nil {
 		logger.Error("failed-to-load-build-from-db", err)
@@ -272,6 +445,7 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 			BuildStatus:   b.build.Status(),
 			BuildDuration: b.build.EndTime().Sub(b.build.StartTime()),
 			TeamName:      b.build.TeamName(),
+			Labels:        labels,
 		}.Emit(logger)
 	}
 }
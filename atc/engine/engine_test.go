@@ -3,18 +3,25 @@ package engine_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	. "github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/enginefakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -25,6 +32,8 @@ var _ = Describe("Engine", func() {
 		fakeBuild       *dbfakes.FakeBuild
 		fakeCheck       *dbfakes.FakeCheck
 		fakeStepBuilder *enginefakes.FakeStepBuilder
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeAuditor     *auditorfakes.FakeAuditor
 	)
 
 	BeforeEach(func() {
@@ -35,6 +44,8 @@ var _ = Describe("Engine", func() {
 		fakeCheck.IDReturns(128)
 
 		fakeStepBuilder = new(enginefakes.FakeStepBuilder)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeAuditor = new(auditorfakes.FakeAuditor)
 	})
 
 	Describe("NewBuild", func() {
@@ -44,7 +55,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepBuilder)
+			engine = NewEngine(fakeStepBuilder, fakeTeamFactory, fakeAuditor, false)
 		})
 
 		JustBeforeEach(func() {
@@ -63,7 +74,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepBuilder)
+			engine = NewEngine(fakeStepBuilder, fakeTeamFactory, fakeAuditor, false)
 		})
 
 		JustBeforeEach(func() {
@@ -77,25 +88,32 @@ var _ = Describe("Engine", func() {
 
 	Describe("Build", func() {
 		var (
-			build     Runnable
-			release   chan bool
-			cancel    chan bool
-			waitGroup *sync.WaitGroup
+			build       Runnable
+			keepOutputs bool
+			release     chan bool
+			cancel      chan bool
+			waitGroup   *sync.WaitGroup
 		)
 
 		BeforeEach(func() {
-
-			ctx := context.Background()
+			keepOutputs = false
 			cancel = make(chan bool)
 			release = make(chan bool)
-			trackedStates := new(sync.Map)
 			waitGroup = new(sync.WaitGroup)
+		})
+
+		JustBeforeEach(func() {
+			ctx := context.Background()
+			trackedStates := new(sync.Map)
 
 			build = NewBuild(
 				ctx,
 				func() { cancel <- true },
 				fakeBuild,
 				fakeStepBuilder,
+				fakeTeamFactory,
+				fakeAuditor,
+				keepOutputs,
 				release,
 				trackedStates,
 				waitGroup,
@@ -204,6 +222,155 @@ var _ = Describe("Engine", func() {
 								})
 							})
 
+							Context("when the build is paused between steps", func() {
+								var (
+									secondStep         *execfakes.FakeStep
+									resumed            chan struct{}
+									fakeResumeNotifier *dbfakes.FakeNotifier
+								)
+
+								BeforeEach(func() {
+									fakeStep.SucceededReturns(true)
+
+									secondStep = new(execfakes.FakeStep)
+									secondStep.SucceededReturns(true)
+
+									fakeStepBuilder.BuildStepReturns(exec.OnSuccess(fakeStep, secondStep), nil)
+
+									fakeBuild.PausedReturnsOnCall(0, true, nil)
+									fakeBuild.PausedReturnsOnCall(1, false, nil)
+
+									resumed = make(chan struct{}, 1)
+									resumed <- struct{}{}
+
+									fakeResumeNotifier = new(dbfakes.FakeNotifier)
+									fakeResumeNotifier.NotifyReturns(resumed)
+									fakeBuild.ResumeNotifierReturns(fakeResumeNotifier, nil)
+								})
+
+								It("runs the next step once the build is resumed", func() {
+									waitGroup.Wait()
+									Expect(fakeBuild.PausedCallCount()).To(Equal(2))
+									Expect(secondStep.RunCallCount()).To(Equal(1))
+									Expect(fakeResumeNotifier.CloseCallCount()).To(Equal(1))
+
+									Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+									Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+								})
+							})
+
+							Context("when a job build fails after a checkpoint", func() {
+								var (
+									secondStep         *execfakes.FakeStep
+									fakeWorkerArtifact *dbfakes.FakeWorkerArtifact
+									fakeVolume         *workerfakes.FakeVolume
+								)
+
+								BeforeEach(func() {
+									fakeBuild.JobIDReturns(1)
+									fakeBuild.PrivatePlanReturns(atc.Plan{
+										ID: "0",
+										Do: &atc.DoPlan{
+											{ID: "1", Task: &atc.TaskPlan{Name: "first"}},
+											{ID: "2", Task: &atc.TaskPlan{Name: "second"}},
+										},
+									})
+
+									fakeWorkerArtifact = new(dbfakes.FakeWorkerArtifact)
+									fakeWorkerArtifact.IDReturns(42)
+
+									fakeVolume = new(workerfakes.FakeVolume)
+									fakeVolume.InitializeArtifactReturns(fakeWorkerArtifact, nil)
+
+									fakeStep.RunStub = func(ctx context.Context, state exec.RunState) error {
+										state.Artifacts().RegisterSource("some-output", exec.NewTaskArtifactSource(fakeVolume))
+										return nil
+									}
+									fakeStep.SucceededReturns(true)
+
+									secondStep = new(execfakes.FakeStep)
+									secondStep.SucceededReturns(false)
+
+									fakeStepBuilder.BuildStepReturns(exec.OnSuccess(
+										exec.Checkpoint("1", fakeStep),
+										exec.Checkpoint("2", secondStep),
+									), nil)
+								})
+
+								It("saves the checkpoint, keeping the artifacts' volumes", func() {
+									waitGroup.Wait()
+
+									Expect(fakeVolume.InitializeArtifactCallCount()).To(Equal(1))
+									name, buildID := fakeVolume.InitializeArtifactArgsForCall(0)
+									Expect(name).To(Equal("some-output"))
+									Expect(buildID).To(Equal(fakeBuild.ID()))
+
+									Expect(fakeBuild.SaveCheckpointCallCount()).To(Equal(1))
+									Expect(fakeBuild.SaveCheckpointArgsForCall(0)).To(Equal(atc.BuildCheckpoint{
+										PlanID: "1",
+										Artifacts: []atc.CheckpointArtifact{
+											{Name: "some-output", ArtifactID: 42},
+										},
+									}))
+
+									Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusFailed))
+								})
+
+								Context("when build outputs are kept", func() {
+									BeforeEach(func() {
+										keepOutputs = true
+									})
+
+									It("keeps the artifacts once, saving them in the checkpoint", func() {
+										waitGroup.Wait()
+
+										Expect(fakeVolume.InitializeArtifactCallCount()).To(Equal(1))
+
+										Expect(fakeBuild.SaveCheckpointCallCount()).To(Equal(1))
+										Expect(fakeBuild.SaveCheckpointArgsForCall(0).Artifacts).To(Equal([]atc.CheckpointArtifact{
+											{Name: "some-output", ArtifactID: 42},
+										}))
+									})
+
+									Context("when the build succeeds", func() {
+										BeforeEach(func() {
+											secondStep.SucceededReturns(true)
+										})
+
+										It("keeps the artifacts", func() {
+											waitGroup.Wait()
+
+											Expect(fakeVolume.InitializeArtifactCallCount()).To(Equal(1))
+											name, buildID := fakeVolume.InitializeArtifactArgsForCall(0)
+											Expect(name).To(Equal("some-output"))
+											Expect(buildID).To(Equal(fakeBuild.ID()))
+										})
+									})
+								})
+
+								Context("when the build is aborted", func() {
+									BeforeEach(func() {
+										secondStep.RunReturns(context.Canceled)
+									})
+
+									It("doesn't save the checkpoint", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.SaveCheckpointCallCount()).To(BeZero())
+									})
+								})
+
+								Context("when the build succeeds", func() {
+									BeforeEach(func() {
+										secondStep.SucceededReturns(true)
+									})
+
+									It("doesn't save the checkpoint", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.SaveCheckpointCallCount()).To(BeZero())
+									})
+								})
+							})
+
 							Context("when the build finishes without error", func() {
 								BeforeEach(func() {
 									fakeStep.RunReturns(nil)
@@ -244,6 +411,42 @@ var _ = Describe("Engine", func() {
 									Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 									Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
 								})
+
+								It("does not save an error code", func() {
+									waitGroup.Wait()
+									Expect(fakeBuild.SaveErrorCodeCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the build finishes with an error which has a code", func() {
+								BeforeEach(func() {
+									fakeStep.RunReturns(exec.MissingInputsError{Inputs: []string{"some-input"}})
+								})
+
+								It("saves the error code before finishing the build", func() {
+									waitGroup.Wait()
+									Expect(fakeBuild.SaveErrorCodeCallCount()).To(Equal(1))
+									Expect(fakeBuild.SaveErrorCodeArgsForCall(0)).To(Equal(atc.ErrorCodeInputResolutionFailed))
+
+									Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+									Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+								})
+							})
+
+							Context("when the build finishes with a network error", func() {
+								BeforeEach(func() {
+									fakeStep.RunReturns(fmt.Errorf("streaming volume: %w", &net.OpError{
+										Op:  "dial",
+										Net: "tcp",
+										Err: errors.New("connection refused"),
+									}))
+								})
+
+								It("saves it as a worker network failure", func() {
+									waitGroup.Wait()
+									Expect(fakeBuild.SaveErrorCodeCallCount()).To(Equal(1))
+									Expect(fakeBuild.SaveErrorCodeArgsForCall(0)).To(Equal(atc.ErrorCodeWorkerNetworkFailure))
+								})
 							})
 
 							Context("when the build finishes with cancelled error", func() {
@@ -257,6 +460,216 @@ var _ = Describe("Engine", func() {
 									Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
 								})
 							})
+
+							Context("when the team limits the size of build logs", func() {
+								var storedEvents []atc.Event
+
+								BeforeEach(func() {
+									fakeTeam := new(dbfakes.FakeTeam)
+									fakeTeam.MaxBuildLogSizeReturns(10)
+									fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+									fakeBuild.TeamNameReturns("some-team")
+
+									// the build's events are kept in order of their IDs
+									storedEvents = nil
+									fakeBuild.SaveEventStub = func(ev atc.Event) error {
+										storedEvents = append(storedEvents, ev)
+										return nil
+									}
+									fakeBuild.SavedEventsStub = func(types ...atc.EventType) ([]db.SavedEvent, error) {
+										var saved []db.SavedEvent
+										for id, ev := range storedEvents {
+											for _, t := range types {
+												if ev.EventType() == t {
+													saved = append(saved, db.SavedEvent{ID: id, Event: ev})
+												}
+											}
+										}
+										return saved, nil
+									}
+								})
+
+								It("picks up from the build's saved log output", func() {
+									waitGroup.Wait()
+									Expect(fakeBuild.SavedEventsCallCount()).To(Equal(1))
+									Expect(fakeBuild.SavedEventsArgsForCall(0)).To(ConsistOf(event.EventTypeLog))
+								})
+
+								Context("when the build is resumed after filling the head", func() {
+									origin := event.Origin{ID: "some-step", Source: event.OriginSourceStdout}
+
+									BeforeEach(func() {
+										storedEvents = []atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "01234"},
+										}
+
+										fakeStep.RunStub = func(context.Context, exec.RunState) error {
+											build := fakeStepBuilder.BuildStepArgsForCall(0)
+											Expect(build.SaveEvent(event.Log{Time: 2, Origin: origin, Payload: "abc"})).To(Succeed())
+											return nil
+										}
+									})
+
+									It("keeps its output within the limit as though it had not stopped", func() {
+										waitGroup.Wait()
+										Expect(storedEvents).To(Equal([]atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "01234"},
+											event.Log{Time: 2, Origin: origin, Payload: "abc"},
+										}))
+									})
+								})
+
+								Context("when the build is resumed part way through the head", func() {
+									origin := event.Origin{ID: "some-step", Source: event.OriginSourceStdout}
+
+									BeforeEach(func() {
+										storedEvents = []atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "012"},
+										}
+
+										fakeStep.RunStub = func(context.Context, exec.RunState) error {
+											build := fakeStepBuilder.BuildStepArgsForCall(0)
+											Expect(build.SaveEvent(event.Log{Time: 2, Origin: origin, Payload: "3456789ab"})).To(Succeed())
+											return nil
+										}
+									})
+
+									It("fills the rest of the head", func() {
+										waitGroup.Wait()
+										Expect(storedEvents).To(Equal([]atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "012"},
+											event.Log{Time: 2, Origin: origin, Payload: "34"},
+											event.LogTruncated{Time: 2, Origin: event.Origin{ID: "some-step"}, Size: 2},
+											event.Log{Time: 2, Origin: origin, Payload: "789ab"},
+										}))
+									})
+								})
+
+								Context("when the build's saved log events cannot be read", func() {
+									BeforeEach(func() {
+										fakeBuild.SavedEventsStub = nil
+										fakeBuild.SavedEventsReturns(nil, errors.New("nope"))
+									})
+
+									It("builds the step with the build itself", func() {
+										waitGroup.Wait()
+										Expect(fakeStepBuilder.BuildStepArgsForCall(0)).To(Equal(fakeBuild))
+									})
+								})
+
+								It("looks up the build's team", func() {
+									waitGroup.Wait()
+									Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+									Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+								})
+
+								Context("when a step's output exceeds the limit", func() {
+									origin := event.Origin{ID: "some-step", Source: event.OriginSourceStdout}
+
+									var storedWhileRunning []atc.Event
+
+									BeforeEach(func() {
+										fakeStep.RunStub = func(context.Context, exec.RunState) error {
+											build := fakeStepBuilder.BuildStepArgsForCall(0)
+											Expect(build.SaveEvent(event.Log{Time: 1, Origin: origin, Payload: "0123456789"})).To(Succeed())
+											Expect(build.SaveEvent(event.Log{Time: 2, Origin: origin, Payload: "abcdefgh"})).To(Succeed())
+											Expect(build.SaveEvent(event.FinishTask{Time: 3, Origin: event.Origin{ID: "some-step"}})).To(Succeed())
+											storedWhileRunning = append([]atc.Event{}, storedEvents...)
+											return nil
+										}
+									})
+
+									It("only saves the head while the build runs", func() {
+										waitGroup.Wait()
+										Expect(storedWhileRunning).To(Equal([]atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "01234"},
+											event.FinishTask{Time: 3, Origin: event.Origin{ID: "some-step"}},
+										}))
+									})
+
+									It("saves the tail once the build has run, after marking the truncation", func() {
+										waitGroup.Wait()
+										Expect(storedEvents).To(Equal([]atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "01234"},
+											event.FinishTask{Time: 3, Origin: event.Origin{ID: "some-step"}},
+											event.LogTruncated{Time: 2, Origin: event.Origin{ID: "some-step"}, Size: 8},
+											event.Log{Time: 2, Origin: origin, Payload: "defgh"},
+										}))
+									})
+
+									Context("when the build finishes", func() {
+										var storedWhenFinished []atc.Event
+
+										BeforeEach(func() {
+											fakeBuild.FinishStub = func(db.BuildStatus) error {
+												storedWhenFinished = append([]atc.Event{}, storedEvents...)
+												return nil
+											}
+										})
+
+										It("has already saved the tail", func() {
+											waitGroup.Wait()
+											Expect(storedWhenFinished).To(HaveLen(4))
+										})
+									})
+								})
+
+								Context("when many events are dropped", func() {
+									origin := event.Origin{ID: "some-step", Source: event.OriginSourceStdout}
+
+									BeforeEach(func() {
+										fakeStep.RunStub = func(context.Context, exec.RunState) error {
+											build := fakeStepBuilder.BuildStepArgsForCall(0)
+											Expect(build.SaveEvent(event.Log{Time: 1, Origin: origin, Payload: "01234"})).To(Succeed())
+											Expect(build.SaveEvent(event.Log{Time: 2, Origin: origin, Payload: "ab"})).To(Succeed())
+											Expect(build.SaveEvent(event.Log{Time: 3, Origin: origin, Payload: "cd"})).To(Succeed())
+											Expect(build.SaveEvent(event.Log{Time: 4, Origin: origin, Payload: "efghij"})).To(Succeed())
+											return nil
+										}
+									})
+
+									It("marks the truncation once", func() {
+										waitGroup.Wait()
+										Expect(storedEvents).To(Equal([]atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "01234"},
+											event.LogTruncated{Time: 4, Origin: event.Origin{ID: "some-step"}, Size: 5},
+											event.Log{Time: 4, Origin: origin, Payload: "fghij"},
+										}))
+									})
+								})
+
+								Context("when a step's output goes past the head but fits in the tail", func() {
+									origin := event.Origin{ID: "some-step", Source: event.OriginSourceStderr}
+
+									BeforeEach(func() {
+										fakeStep.RunStub = func(context.Context, exec.RunState) error {
+											build := fakeStepBuilder.BuildStepArgsForCall(0)
+											Expect(build.SaveEvent(event.Log{Time: 1, Origin: origin, Payload: "01234567"})).To(Succeed())
+											return nil
+										}
+									})
+
+									It("saves all of it without marking a truncation", func() {
+										waitGroup.Wait()
+										Expect(storedEvents).To(Equal([]atc.Event{
+											event.Log{Time: 1, Origin: origin, Payload: "01234"},
+											event.Log{Time: 1, Origin: origin, Payload: "567"},
+										}))
+									})
+								})
+							})
+
+							Context("when the team does not limit the size of build logs", func() {
+								BeforeEach(func() {
+									fakeTeamFactory.FindTeamReturns(new(dbfakes.FakeTeam), true, nil)
+								})
+
+								It("builds the step with the build itself", func() {
+									waitGroup.Wait()
+									Expect(fakeStepBuilder.BuildStepArgsForCall(0)).To(Equal(fakeBuild))
+								})
+							})
 						})
 
 						Context("when converting the plan to a step fails", func() {
@@ -271,6 +684,57 @@ var _ = Describe("Engine", func() {
 							It("closes the notifier", func() {
 								Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
 							})
+
+							It("does not finish the build", func() {
+								Expect(fakeBuild.FinishCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the team does not allow the plan to run privileged containers", func() {
+							BeforeEach(func() {
+								fakeBuild.PrivatePlanReturns(atc.Plan{ID: "some-plan"})
+								fakeStepBuilder.BuildStepReturns(nil, atc.PrivilegedNotAllowedError{
+									Team:     "some-team",
+									Pipeline: "some-pipeline",
+									Job:      "some-job",
+									Step:     "some-task",
+								})
+							})
+
+							It("saves an error event", func() {
+								Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+								ev, ok := fakeBuild.SaveEventArgsForCall(0).(event.Error)
+								Expect(ok).To(BeTrue())
+								Expect(ev.Message).To(ContainSubstring("step 'some-task' is privileged"))
+								Expect(ev.Origin.ID).To(Equal(event.OriginID("some-plan")))
+							})
+
+							It("errors the build with the error code", func() {
+								Expect(fakeBuild.SaveErrorCodeCallCount()).To(Equal(1))
+								Expect(fakeBuild.SaveErrorCodeArgsForCall(0)).To(Equal(atc.ErrorCodePrivilegedNotAllowed))
+
+								Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+								Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+							})
+
+							It("audits the refusal", func() {
+								Expect(fakeAuditor.AuditEventCallCount()).To(Equal(1))
+
+								action, data := fakeAuditor.AuditEventArgsForCall(0)
+								Expect(action).To(Equal(auditor.PrivilegedNotAllowed))
+								Expect(data).To(Equal(lager.Data{
+									"build":    128,
+									"team":     "some-team",
+									"pipeline": "some-pipeline",
+									"job":      "some-job",
+									"step":     "some-task",
+								}))
+							})
+
+							It("releases the lock", func() {
+								Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+							})
 						})
 					})
 
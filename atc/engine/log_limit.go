@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// logLimitedBuild caps the total size of log output saved for a build.
+//
+// The first half of the limit is saved as it is written, so that it can be
+// followed live. Output after that isn't saved while the build runs: the most
+// recent half of the limit is held in memory instead, and saved once the
+// build has run, after a single log-truncated event recording how much output
+// was dropped between the head and the tail.
+//
+// The output held for the tail is lost if the build is released, e.g. by an
+// ATC shutting down, before it has run.
+type logLimitedBuild struct {
+	db.Build
+
+	headSize int64
+	tailSize int64
+
+	lock      sync.Mutex
+	saved     int64
+	tail      []event.Log
+	tailBytes int64
+	truncated *event.LogTruncated
+}
+
+func newLogLimitedBuild(build db.Build, limit int64) *logLimitedBuild {
+	headSize := limit / 2
+
+	return &logLimitedBuild{
+		Build: build,
+
+		headSize: headSize,
+		tailSize: limit - headSize,
+	}
+}
+
+// resume picks up from the build's saved log output, so that a build which
+// is resumed, e.g. by another ATC, doesn't save more than the head's worth
+// of it. Only the head is saved while a build runs, so this reads no more
+// than that.
+func (b *logLimitedBuild) resume() error {
+	savedEvents, err := b.Build.SavedEvents(event.EventTypeLog)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, saved := range savedEvents {
+		b.saved += int64(len(saved.Event.(event.Log).Payload))
+	}
+
+	if b.saved > b.headSize {
+		b.saved = b.headSize
+	}
+
+	return nil
+}
+
+func (b *logLimitedBuild) SaveEvent(ev atc.Event) error {
+	if logEvent, ok := ev.(event.Log); ok {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		return b.saveLog(logEvent)
+	}
+
+	return b.Build.SaveEvent(ev)
+}
+
+func (b *logLimitedBuild) saveLog(ev event.Log) error {
+	remaining := b.headSize - b.saved
+	if remaining > 0 {
+		head, rest := splitPayload(ev.Payload, remaining)
+
+		if head != "" {
+			headEvent := ev
+			headEvent.Payload = head
+
+			err := b.Build.SaveEvent(headEvent)
+			if err != nil {
+				return err
+			}
+		}
+
+		if rest == "" {
+			b.saved += int64(len(head))
+			return nil
+		}
+
+		b.saved = b.headSize
+		ev.Payload = rest
+	}
+
+	b.tail = append(b.tail, ev)
+	b.tailBytes += int64(len(ev.Payload))
+
+	for b.tailBytes > b.tailSize {
+		over := b.tailBytes - b.tailSize
+		oldest := &b.tail[0]
+
+		if b.truncated == nil {
+			b.truncated = &event.LogTruncated{Origin: event.Origin{ID: oldest.Origin.ID}}
+		}
+
+		b.truncated.Time = oldest.Time
+
+		if int64(len(oldest.Payload)) <= over {
+			b.truncated.Size += int64(len(oldest.Payload))
+			b.tailBytes -= int64(len(oldest.Payload))
+			b.tail = b.tail[1:]
+			continue
+		}
+
+		i := int(over)
+		for i < len(oldest.Payload) && !utf8.RuneStart(oldest.Payload[i]) {
+			i++
+		}
+
+		oldest.Payload = oldest.Payload[i:]
+		b.truncated.Size += int64(i)
+		b.tailBytes -= int64(i)
+	}
+
+	return nil
+}
+
+// flush saves the output held for the tail, after the log-truncated event if
+// any output was dropped.
+func (b *logLimitedBuild) flush() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.truncated != nil {
+		err := b.Build.SaveEvent(*b.truncated)
+		if err != nil {
+			return err
+		}
+
+		b.truncated = nil
+	}
+
+	for len(b.tail) > 0 {
+		err := b.Build.SaveEvent(b.tail[0])
+		if err != nil {
+			return err
+		}
+
+		b.tailBytes -= int64(len(b.tail[0].Payload))
+		b.tail = b.tail[1:]
+	}
+
+	return nil
+}
+
+// splitPayload splits the payload after at most n bytes without splitting a
+// multi-byte character.
+func splitPayload(payload string, n int64) (string, string) {
+	if int64(len(payload)) <= n {
+		return payload, ""
+	}
+
+	i := int(n)
+	for i > 0 && !utf8.RuneStart(payload[i]) {
+		i--
+	}
+
+	return payload[:i], payload[i:]
+}
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// keepArtifacts keeps the artifacts held in volumes at the end of the build
+// around as worker artifacts of the build, so that they can be downloaded
+// once the build's containers are gone. The IDs of the worker artifacts are
+// returned by the handles of their volumes.
+//
+// Artifacts fetched by get steps aren't kept, as they're in the resources'
+// caches.
+func (b *engineBuild) keepArtifacts(logger lager.Logger, state exec.RunState) map[string]int {
+	kept := map[string]int{}
+
+	for name, source := range state.Artifacts().AsMap() {
+		volume, ok := source.(worker.Volume)
+		if !ok {
+			continue
+		}
+
+		artifact, err := volume.InitializeArtifact(string(name), b.build.ID())
+		if err != nil {
+			logger.Error("failed-to-initialize-artifact", err, lager.Data{"artifact": name})
+			continue
+		}
+
+		kept[volume.Handle()] = artifact.ID()
+	}
+
+	return kept
+}
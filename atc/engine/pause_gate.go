@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// pauseGate holds a build between its steps while it's paused. Only the
+// paused state is checked between steps; the build is only listened to for
+// being resumed once it's found to be paused.
+type pauseGate struct {
+	build  db.Build
+	logger lager.Logger
+}
+
+func (gate *pauseGate) WaitIfPaused(ctx context.Context) error {
+	paused, err := gate.build.Paused()
+	if err != nil {
+		return err
+	}
+
+	if !paused {
+		return nil
+	}
+
+	notifier, err := gate.build.ResumeNotifier()
+	if err != nil {
+		return err
+	}
+
+	defer notifier.Close()
+
+	gate.logger.Info("paused")
+
+	for paused {
+		select {
+		case <-notifier.Notify():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		paused, err = gate.build.Paused()
+		if err != nil {
+			return err
+		}
+	}
+
+	gate.logger.Info("resumed")
+
+	return nil
+}
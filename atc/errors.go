@@ -1,6 +1,9 @@
 package atc
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type MalformedConfigError struct {
 	UnmarshalError error
@@ -9,3 +12,60 @@ type MalformedConfigError struct {
 func (err MalformedConfigError) Error() string {
 	return fmt.Sprintf("malformed config: %s", err.UnmarshalError.Error())
 }
+
+// ErrorCode identifies a common kind of failure, so that clients can tell
+// failures apart without parsing their messages.
+type ErrorCode string
+
+const (
+	ErrorCodeImageUnavailable      ErrorCode = "image_unavailable"
+	ErrorCodeNoWorkersMatchingTags ErrorCode = "no_workers_matching_tags"
+	ErrorCodeQuotaExceeded         ErrorCode = "quota_exceeded"
+	ErrorCodeInputResolutionFailed ErrorCode = "input_resolution_failed"
+	ErrorCodePrivilegedNotAllowed  ErrorCode = "privileged_not_allowed"
+	ErrorCodePendingTimedOut       ErrorCode = "pending_timed_out"
+	ErrorCodeWorkerUnavailable     ErrorCode = "worker_unavailable"
+	ErrorCodeWorkerNetworkFailure  ErrorCode = "worker_network_failure"
+	ErrorCodeVolumeMissing         ErrorCode = "volume_missing"
+	ErrorCodeImageDigestRequired   ErrorCode = "image_digest_required"
+
+	// ErrorCodeImageCheckFailed is for the check of an image resource
+	// exiting with a failure, which is down to the image's configuration
+	// rather than the worker running it, so it isn't retried.
+	ErrorCodeImageCheckFailed ErrorCode = "image_check_failed"
+)
+
+// InfrastructureErrorCodes are the codes of failures of the workers running a
+// build rather than of the build itself, which running it again may get past.
+var InfrastructureErrorCodes = []ErrorCode{
+	ErrorCodeWorkerUnavailable,
+	ErrorCodeWorkerNetworkFailure,
+	ErrorCodeVolumeMissing,
+}
+
+// ErrorCoder is implemented by errors which are one of the kinds of failure
+// with an ErrorCode.
+type ErrorCoder interface {
+	ErrorCode() ErrorCode
+}
+
+// ErrorCodeOf returns the code of the first error in err's chain which has
+// one, or an empty code if none do.
+func ErrorCodeOf(err error) ErrorCode {
+	for err != nil {
+		if coder, ok := err.(ErrorCoder); ok && coder.ErrorCode() != "" {
+			return coder.ErrorCode()
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return ""
+}
+
+// ErrorResponse is the body of an API response for a failure which has an
+// ErrorCode.
+type ErrorResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
@@ -0,0 +1,38 @@
+package atc_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type codedError struct {
+	code atc.ErrorCode
+}
+
+func (err codedError) Error() string            { return "some-coded-error" }
+func (err codedError) ErrorCode() atc.ErrorCode { return err.code }
+
+var _ = Describe("ErrorCodeOf", func() {
+	It("returns the code of an error which has one", func() {
+		Expect(atc.ErrorCodeOf(codedError{atc.ErrorCodeQuotaExceeded})).To(Equal(atc.ErrorCodeQuotaExceeded))
+	})
+
+	It("returns the code of an error wrapped by another", func() {
+		err := fmt.Errorf("some-context: %w", codedError{atc.ErrorCodeImageUnavailable})
+		Expect(atc.ErrorCodeOf(err)).To(Equal(atc.ErrorCodeImageUnavailable))
+	})
+
+	It("skips errors whose code is empty", func() {
+		err := codedError{""}
+		Expect(atc.ErrorCodeOf(err)).To(BeEmpty())
+	})
+
+	It("returns an empty code for errors without one", func() {
+		Expect(atc.ErrorCodeOf(errors.New("some-error"))).To(BeEmpty())
+		Expect(atc.ErrorCodeOf(nil)).To(BeEmpty())
+	})
+})
@@ -6,10 +6,14 @@ type Error struct {
 	Message string `json:"message"`
 	Origin  Origin `json:"origin"`
 	Time    int64  `json:"time"`
+
+	// ErrorCode is set when the error is a common kind of failure, e.g. an
+	// image's check failing rather than the worker running it.
+	ErrorCode atc.ErrorCode `json:"error_code,omitempty"`
 }
 
 func (Error) EventType() atc.EventType  { return EventTypeError }
-func (Error) Version() atc.EventVersion { return "4.1" }
+func (Error) Version() atc.EventVersion { return "4.2" }
 
 type FinishTask struct {
 	Time       int64  `json:"time"`
@@ -75,10 +79,11 @@ type StartTask struct {
 	Time       int64      `json:"time"`
 	Origin     Origin     `json:"origin"`
 	TaskConfig TaskConfig `json:"config"`
+	Worker     string     `json:"worker,omitempty"`
 }
 
 func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
-func (StartTask) Version() atc.EventVersion { return "5.0" }
+func (StartTask) Version() atc.EventVersion { return "5.1" }
 
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
@@ -97,6 +102,99 @@ type Log struct {
 func (Log) EventType() atc.EventType  { return EventTypeLog }
 func (Log) Version() atc.EventVersion { return "5.1" }
 
+type LogTruncated struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Size   int64  `json:"size"`
+}
+
+func (LogTruncated) EventType() atc.EventType  { return EventTypeLogTruncated }
+func (LogTruncated) Version() atc.EventVersion { return "1.0" }
+
+// Warning is a warning about the build's plan, e.g. one of its steps fetching
+// an artifact which none of its other steps use. Type and Message are those
+// of the equivalent atc.ConfigWarning.
+type Warning struct {
+	Time    int64  `json:"time"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (Warning) EventType() atc.EventType  { return EventTypeWarning }
+func (Warning) Version() atc.EventVersion { return "1.0" }
+
+// ContainerPrepared is saved once a step's container has been created, with
+// how long its image took to fetch and its inputs took to stream to the
+// worker. Durations are in seconds.
+type ContainerPrepared struct {
+	Origin              Origin `json:"origin"`
+	Time                int64  `json:"time"`
+	ImageFetchDuration  int64  `json:"image_fetch_duration"`
+	InputStreamDuration int64  `json:"input_stream_duration"`
+}
+
+func (ContainerPrepared) EventType() atc.EventType  { return EventTypeContainerPrepared }
+func (ContainerPrepared) Version() atc.EventVersion { return "1.0" }
+
+// ImageCheckStarted, ImageVersionDetermined, ImageFetchStarted,
+// ImageBytesStreamed and ImageFetchFinished are saved as a step's image is
+// fetched, so that its progress can be shown while the step is preparing.
+type ImageCheckStarted struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (ImageCheckStarted) EventType() atc.EventType  { return EventTypeImageCheckStarted }
+func (ImageCheckStarted) Version() atc.EventVersion { return "1.0" }
+
+type ImageVersionDetermined struct {
+	Origin       Origin      `json:"origin"`
+	Time         int64       `json:"time"`
+	ImageVersion atc.Version `json:"version"`
+}
+
+func (ImageVersionDetermined) EventType() atc.EventType  { return EventTypeImageVersionDetermined }
+func (ImageVersionDetermined) Version() atc.EventVersion { return "1.0" }
+
+type ImageFetchStarted struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (ImageFetchStarted) EventType() atc.EventType  { return EventTypeImageFetchStarted }
+func (ImageFetchStarted) Version() atc.EventVersion { return "1.0" }
+
+type ImageBytesStreamed struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+	Bytes  int64  `json:"bytes"`
+}
+
+func (ImageBytesStreamed) EventType() atc.EventType  { return EventTypeImageBytesStreamed }
+func (ImageBytesStreamed) Version() atc.EventVersion { return "1.0" }
+
+type ImageFetchFinished struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (ImageFetchFinished) EventType() atc.EventType  { return EventTypeImageFetchFinished }
+func (ImageFetchFinished) Version() atc.EventVersion { return "1.0" }
+
+// ArtifactChecksum is saved for each artifact a step fetches or produces,
+// when artifacts are checksummed. The checksum and size are of the
+// artifact's uncompressed tar stream.
+type ArtifactChecksum struct {
+	Origin   Origin `json:"origin"`
+	Time     int64  `json:"time"`
+	Artifact string `json:"artifact"`
+	Checksum string `json:"checksum"`
+	Size     int64  `json:"size"`
+}
+
+func (ArtifactChecksum) EventType() atc.EventType  { return EventTypeArtifactChecksum }
+func (ArtifactChecksum) Version() atc.EventVersion { return "1.0" }
+
 type Origin struct {
 	ID     OriginID     `json:"id,omitempty"`
 	Source OriginSource `json:"source,omitempty"`
@@ -122,10 +220,15 @@ func (InitializeGet) Version() atc.EventVersion { return "2.0" }
 type StartGet struct {
 	Origin Origin `json:"origin"`
 	Time   int64  `json:"time,omitempty"`
+	Worker string `json:"worker,omitempty"`
+
+	// Cached is true if the version was already fetched on the worker, so the
+	// cache is used instead of running the resource's get.
+	Cached bool `json:"cached,omitempty"`
 }
 
 func (StartGet) EventType() atc.EventType  { return EventTypeStartGet }
-func (StartGet) Version() atc.EventVersion { return "1.0" }
+func (StartGet) Version() atc.EventVersion { return "1.1" }
 
 type FinishGet struct {
 	Origin          Origin              `json:"origin"`
@@ -149,10 +252,11 @@ func (InitializePut) Version() atc.EventVersion { return "2.0" }
 type StartPut struct {
 	Origin Origin `json:"origin"`
 	Time   int64  `json:"time,omitempty"`
+	Worker string `json:"worker,omitempty"`
 }
 
 func (StartPut) EventType() atc.EventType  { return EventTypeStartPut }
-func (StartPut) Version() atc.EventVersion { return "1.0" }
+func (StartPut) Version() atc.EventVersion { return "1.1" }
 
 type FinishPut struct {
 	Origin          Origin              `json:"origin"`
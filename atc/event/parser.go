@@ -45,7 +45,16 @@ func init() {
 	RegisterEvent(FinishPut{})
 	RegisterEvent(Status{})
 	RegisterEvent(Log{})
+	RegisterEvent(LogTruncated{})
+	RegisterEvent(Warning{})
 	RegisterEvent(Error{})
+	RegisterEvent(ContainerPrepared{})
+	RegisterEvent(ImageCheckStarted{})
+	RegisterEvent(ImageVersionDetermined{})
+	RegisterEvent(ImageFetchStarted{})
+	RegisterEvent(ImageBytesStreamed{})
+	RegisterEvent(ImageFetchFinished{})
+	RegisterEvent(ArtifactChecksum{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
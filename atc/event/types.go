@@ -36,6 +36,33 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// step's container was prepared (image fetched; inputs streamed)
+	EventTypeContainerPrepared atc.EventType = "container-prepared"
+
+	// step's image resource started being checked
+	EventTypeImageCheckStarted atc.EventType = "image-check-started"
+
+	// step's image resource version was determined
+	EventTypeImageVersionDetermined atc.EventType = "image-version-determined"
+
+	// step's image started being fetched onto the worker
+	EventTypeImageFetchStarted atc.EventType = "image-fetch-started"
+
+	// some of step's image was streamed from another worker
+	EventTypeImageBytesStreamed atc.EventType = "image-bytes-streamed"
+
+	// step's image finished being fetched onto the worker
+	EventTypeImageFetchFinished atc.EventType = "image-fetch-finished"
+
+	// artifact registered by a step was checksummed
+	EventTypeArtifactChecksum atc.EventType = "artifact-checksum"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// build log exceeded the team's limit and was partially discarded
+	EventTypeLogTruncated atc.EventType = "log-truncated"
+
+	// something about the build's plan is worth the user's attention
+	EventTypeWarning atc.EventType = "warning"
 )
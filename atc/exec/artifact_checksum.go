@@ -0,0 +1,71 @@
+package exec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/DataDog/zstd"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// checksumArtifact streams an artifact which a step registered in the build's
+// artifact repository through sha256, so that its checksum and size can be
+// compared with those of the artifact when another step uses it. Failing to
+// checksum the artifact only warns, as the artifact itself is fine.
+func checksumArtifact(
+	ctx context.Context,
+	logger lager.Logger,
+	name string,
+	source worker.ArtifactSource,
+	delegate BuildStepDelegate,
+) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	logger = logger.Session("checksum-artifact", lager.Data{"artifact": name})
+
+	destination := &checksumDestination{}
+
+	err := source.StreamTo(ctx, logger, destination)
+	if err != nil {
+		logger.Error("failed-to-checksum", err)
+		fmt.Fprintf(delegate.Stderr(), "[WARNING] failed to checksum '%s': %s\n", name, err)
+		return
+	}
+
+	err = delegate.ArtifactChecksummed(name, destination.checksum, destination.size)
+	if err != nil {
+		logger.Error("failed-to-save-checksum", err)
+	}
+}
+
+// checksumDestination is an artifact destination which checksums the tar
+// stream of whatever is streamed in rather than storing it. The stream is
+// decompressed first, so that the checksum doesn't depend on the encoding
+// the worker streamed it with.
+type checksumDestination struct {
+	checksum string
+	size     int64
+}
+
+func (dest *checksumDestination) StreamIn(ctx context.Context, path string, content io.Reader) error {
+	zstdReader := zstd.NewReader(content)
+	defer zstdReader.Close()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(hash, zstdReader)
+	if err != nil {
+		return err
+	}
+
+	dest.checksum = "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	dest.size = size
+
+	return nil
+}
@@ -1,10 +1,13 @@
 package exec
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -19,6 +22,7 @@ import (
 type CheckStep struct {
 	planID            atc.PlanID
 	plan              atc.CheckPlan
+	limits            atc.ContainerLimits
 	metadata          StepMetadata
 	containerMetadata db.ContainerMetadata
 	resourceFactory   resource.ResourceFactory
@@ -33,12 +37,72 @@ type CheckStep struct {
 type CheckDelegate interface {
 	BuildStepDelegate
 
-	SaveVersions([]atc.Version) error
+	SaveVersions(lager.Logger, []atc.Version) error
+	SaveDeletedVersions([]atc.Version) error
+	SaveWorker(string) error
+	SaveOutput(string) error
 }
 
+// CheckKilledError is returned when a check is still running at its hard
+// timeout, having not exited after being interrupted at its timeout.
+type CheckKilledError struct {
+	Timeout time.Duration
+}
+
+func (err CheckKilledError) Error() string {
+	return fmt.Sprintf("Killed after %v while checking for new versions", err.Timeout)
+}
+
+type checkResult struct {
+	versions []atc.Version
+	deleted  []atc.Version
+	err      error
+}
+
+// CheckOutput collects a check's stderr, to be recorded with the check. It
+// may be written to by a check which is still running after it is abandoned,
+// while its output is read.
+type CheckOutput struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (o *CheckOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.Write(p)
+}
+
+// String returns the end of the output, up to maxCheckOutput, as that's
+// where errors tend to be.
+func (o *CheckOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	output := o.buf.String()
+	if len(output) > maxCheckOutput {
+		start := len(output) - maxCheckOutput
+		for start < len(output) && !utf8.RuneStart(output[start]) {
+			start++
+		}
+
+		output = output[start:]
+	}
+
+	return output
+}
+
+// maxCheckOutput bounds how much of a check's stderr is recorded with it.
+const maxCheckOutput = 64 * 1024
+
+// killedExitStatus is the exit status of a process killed with SIGKILL, which
+// is how the kernel stops a container exceeding its memory limit.
+const killedExitStatus = 128 + 9
+
 func NewCheckStep(
 	planID atc.PlanID,
 	plan atc.CheckPlan,
+	limits atc.ContainerLimits,
 	metadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	resourceFactory resource.ResourceFactory,
@@ -49,6 +113,7 @@ func NewCheckStep(
 	return &CheckStep{
 		planID:            planID,
 		plan:              plan,
+		limits:            limits,
 		metadata:          metadata,
 		containerMetadata: containerMetadata,
 		resourceFactory:   resourceFactory,
@@ -83,9 +148,11 @@ func (step *CheckStep) Run(ctx context.Context, state RunState) error {
 		BindMounts: []worker.BindMountSource{
 			&worker.CertsVolumeMount{Logger: logger},
 		},
-		Tags:   step.plan.Tags,
-		TeamID: step.metadata.TeamID,
-		Env:    step.metadata.Env(),
+		Tags:    step.plan.Tags,
+		TeamID:  step.metadata.TeamID,
+		Env:     step.metadata.Env(),
+		CACerts: step.metadata.CACerts,
+		Limits:  worker.ContainerLimits(step.limits),
 	}
 
 	workerSpec := worker.WorkerSpec{
@@ -103,6 +170,7 @@ func (step *CheckStep) Run(ctx context.Context, state RunState) error {
 	owner := db.NewResourceConfigCheckSessionContainerOwner(
 		step.metadata.ResourceConfigID,
 		step.metadata.BaseResourceTypeID,
+		step.metadata.TeamID,
 		expires,
 	)
 
@@ -119,6 +187,12 @@ func (step *CheckStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	err = step.delegate.SaveWorker(chosenWorker.Name())
+	if err != nil {
+		logger.Error("failed-to-save-worker", err)
+		return err
+	}
+
 	container, err := chosenWorker.FindOrCreateContainer(
 		ctx,
 		logger,
@@ -139,16 +213,85 @@ func (step *CheckStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	var hardTimeout time.Duration
+	if step.plan.HardTimeout != "" {
+		hardTimeout, err = time.ParseDuration(step.plan.HardTimeout)
+		if err != nil {
+			logger.Error("failed-to-parse-hard-timeout", err)
+			return err
+		}
+	}
+
 	deadline, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	checkable := step.resourceFactory.NewResourceForContainer(container)
 
-	versions, err := checkable.Check(deadline, source, step.plan.FromVersion)
+	stderr := new(CheckOutput)
+	checked := make(chan checkResult, 1)
+	go func() {
+		versions, deleted, err := checkable.CheckWithDeletions(deadline, source, step.plan.FromVersion, stderr)
+		checked <- checkResult{versions, deleted, err}
+	}()
+
+	// the check is interrupted at the timeout, but may not exit in response;
+	// if it's still running at the hard timeout its processes are killed and
+	// it is abandoned, so that it doesn't hold on to its container forever
+	var hardDeadline <-chan time.Time
+	if hardTimeout > timeout {
+		timer := time.NewTimer(hardTimeout)
+		defer timer.Stop()
+
+		hardDeadline = timer.C
+	}
+
+	var result checkResult
+	select {
+	case result = <-checked:
+	case <-hardDeadline:
+		logger.Info("killing-check-after-hard-timeout", lager.Data{"hard-timeout": hardTimeout.String()})
+
+		if stopErr := container.Stop(true); stopErr != nil {
+			logger.Error("failed-to-kill-check", stopErr)
+		}
+
+		result = checkResult{err: CheckKilledError{Timeout: hardTimeout}}
+	}
+
+	versions, deleted, err := result.versions, result.deleted, result.err
+
+	if saveErr := step.delegate.SaveOutput(stderr.String()); saveErr != nil {
+		logger.Error("failed-to-save-output", saveErr)
+	}
+
 	if err != nil {
+		if killed, ok := err.(CheckKilledError); ok {
+			metric.CheckTimedOut{
+				CheckName:             step.plan.Name,
+				ResourceConfigScopeID: strconv.Itoa(step.metadata.ResourceConfigScopeID),
+				Killed:                true,
+			}.Emit(logger)
+
+			return killed
+		}
+
 		if err == context.DeadlineExceeded {
+			metric.CheckTimedOut{
+				CheckName:             step.plan.Name,
+				ResourceConfigScopeID: strconv.Itoa(step.metadata.ResourceConfigScopeID),
+			}.Emit(logger)
+
 			return fmt.Errorf("Timed out after %v while checking for new versions", timeout)
 		}
+
+		if step.killedByLimits(err) {
+			metric.CheckKilledByLimits{
+				CheckName:             step.plan.Name,
+				ResourceConfigScopeID: strconv.Itoa(step.metadata.ResourceConfigScopeID),
+				ResourceType:          step.plan.Type,
+			}.Emit(logger)
+		}
+
 		return err
 	}
 
@@ -158,17 +301,40 @@ func (step *CheckStep) Run(ctx context.Context, state RunState) error {
 		Success:               err == nil,
 	}.Emit(logger)
 
-	err = step.delegate.SaveVersions(versions)
+	err = step.delegate.SaveVersions(logger, versions)
 	if err != nil {
 		logger.Error("failed-to-save-versions", err)
 		return err
 	}
 
+	if len(deleted) > 0 {
+		err = step.delegate.SaveDeletedVersions(deleted)
+		if err != nil {
+			logger.Error("failed-to-save-deleted-versions", err)
+			return err
+		}
+	}
+
 	step.succeeded = true
 
 	return nil
 }
 
+// killedByLimits guesses whether the check failed because its container ran
+// out of memory: its script having been killed is the only sign of that.
+func (step *CheckStep) killedByLimits(err error) bool {
+	if step.limits.Memory == nil {
+		return false
+	}
+
+	scriptErr, ok := err.(resource.ErrResourceScriptFailed)
+	if !ok {
+		return false
+	}
+
+	return scriptErr.ExitStatus == killedExitStatus
+}
+
 func (step *CheckStep) Succeeded() bool {
 	return step.succeeded
 }
@@ -3,6 +3,8 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -11,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
@@ -30,6 +33,7 @@ var _ = Describe("CheckStep", func() {
 		fakeSecretManager   *credsfakes.FakeSecrets
 		fakeDelegate        *execfakes.FakeCheckDelegate
 		checkPlan           *atc.CheckPlan
+		limits              atc.ContainerLimits
 
 		interpolatedResourceTypes atc.VersionedResourceTypes
 
@@ -44,7 +48,7 @@ var _ = Describe("CheckStep", func() {
 			TeamID:             123,
 		}
 
-		owner = db.NewResourceConfigCheckSessionContainerOwner(stepMetadata.ResourceConfigID, stepMetadata.BaseResourceTypeID, db.ContainerOwnerExpiries{
+		owner = db.NewResourceConfigCheckSessionContainerOwner(stepMetadata.ResourceConfigID, stepMetadata.BaseResourceTypeID, stepMetadata.TeamID, db.ContainerOwnerExpiries{
 			Min: 5 * time.Minute,
 			Max: 1 * time.Hour,
 		})
@@ -89,6 +93,8 @@ var _ = Describe("CheckStep", func() {
 			},
 		}
 
+		limits = atc.ContainerLimits{}
+
 		checkPlan = &atc.CheckPlan{
 			Name:                   "some-name",
 			Type:                   "some-resource-type",
@@ -113,6 +119,7 @@ var _ = Describe("CheckStep", func() {
 		checkStep = exec.NewCheckStep(
 			plan.ID,
 			*plan.Check,
+			limits,
 			stepMetadata,
 			containerMetadata,
 			fakeResourceFactory,
@@ -178,6 +185,37 @@ var _ = Describe("CheckStep", func() {
 			Expect(delegate).To(Equal(fakeDelegate))
 		})
 
+		Context("when the resource type has check limits", func() {
+			var cpu, memory uint64
+
+			BeforeEach(func() {
+				cpu = 512
+				memory = 1024 * 1024 * 1024
+				limits = atc.ContainerLimits{CPU: &cpu, Memory: &memory}
+			})
+
+			It("creates the container with the limits", func() {
+				_, _, _, _, _, actualContainerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+				Expect(actualContainerSpec.Limits).To(Equal(worker.ContainerLimits{CPU: &cpu, Memory: &memory}))
+			})
+
+			Context("when the check is killed", func() {
+				BeforeEach(func() {
+					fakeResource.CheckWithDeletionsReturns(nil, nil, resource.ErrResourceScriptFailed{
+						Path:       "/opt/resource/check",
+						ExitStatus: 137,
+					})
+				})
+
+				It("returns the error", func() {
+					Expect(stepErr).To(Equal(resource.ErrResourceScriptFailed{
+						Path:       "/opt/resource/check",
+						ExitStatus: 137,
+					}))
+				})
+			})
+		})
+
 		Context("when the timeout cannot be parsed", func() {
 			BeforeEach(func() {
 				checkPlan.Timeout = "bad-value"
@@ -191,31 +229,169 @@ var _ = Describe("CheckStep", func() {
 
 		It("times out after the specified timeout", func() {
 			now := time.Now()
-			ctx, _, _ := fakeResource.CheckArgsForCall(0)
+			ctx, _, _, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 			deadline, _ := ctx.Deadline()
 			Expect(deadline).Should(BeTemporally("~", now.Add(10*time.Second), time.Second))
 		})
 
 		It("runs the check resource action", func() {
-			Expect(fakeResource.CheckCallCount()).To(Equal(1))
+			Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(1))
+		})
+
+		Context("when the check times out", func() {
+			BeforeEach(func() {
+				checkPlan.Timeout = "10ms"
+				checkPlan.HardTimeout = "1m"
+
+				fakeResource.CheckWithDeletionsStub = func(ctx context.Context, _ atc.Source, _ atc.Version, _ io.Writer) ([]atc.Version, []atc.Version, error) {
+					<-ctx.Done()
+					return nil, nil, ctx.Err()
+				}
+			})
+
+			It("returns a timeout error", func() {
+				Expect(stepErr).To(MatchError("Timed out after 10ms while checking for new versions"))
+				Expect(checkStep.Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when the check is still running at the hard timeout", func() {
+			var (
+				fakeContainer *workerfakes.FakeContainer
+				hung          chan struct{}
+			)
+
+			BeforeEach(func() {
+				checkPlan.Timeout = "10ms"
+				checkPlan.HardTimeout = "50ms"
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+
+				hung = make(chan struct{})
+				fakeResource.CheckWithDeletionsStub = func(_ context.Context, _ atc.Source, _ atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
+					_, err := io.WriteString(stderr, "still going")
+					Expect(err).NotTo(HaveOccurred())
+
+					<-hung
+					return nil, nil, nil
+				}
+			})
+
+			AfterEach(func() {
+				close(hung)
+			})
+
+			It("kills the check's processes", func() {
+				Expect(fakeContainer.StopCallCount()).To(Equal(1))
+				Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+			})
+
+			It("returns an error without waiting for the check", func() {
+				Expect(stepErr).To(Equal(exec.CheckKilledError{Timeout: 50 * time.Millisecond}))
+				Expect(stepErr).To(MatchError("Killed after 50ms while checking for new versions"))
+				Expect(checkStep.Succeeded()).To(BeFalse())
+			})
+
+			It("saves the output so far", func() {
+				Expect(fakeDelegate.SaveOutputCallCount()).To(Equal(1))
+				Expect(fakeDelegate.SaveOutputArgsForCall(0)).To(Equal("still going"))
+			})
+		})
+
+		It("saves the chosen worker", func() {
+			Expect(fakeDelegate.SaveWorkerCallCount()).To(Equal(1))
+			Expect(fakeDelegate.SaveWorkerArgsForCall(0)).To(Equal("some-worker"))
+		})
+
+		Context("when saving the worker fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDelegate.SaveWorkerReturns(disaster)
+			})
+
+			It("returns the error without checking", func() {
+				Expect(stepErr).To(Equal(disaster))
+				Expect(fakeResource.CheckWithDeletionsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the check writes to stderr", func() {
+			BeforeEach(func() {
+				fakeResource.CheckWithDeletionsStub = func(_ context.Context, _ atc.Source, _ atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
+					_, err := io.WriteString(stderr, "some-output")
+					Expect(err).NotTo(HaveOccurred())
+					return nil, nil, errors.New("nope")
+				}
+			})
+
+			It("saves the output even though the check failed", func() {
+				Expect(stepErr).To(HaveOccurred())
+				Expect(fakeDelegate.SaveOutputCallCount()).To(Equal(1))
+				Expect(fakeDelegate.SaveOutputArgsForCall(0)).To(Equal("some-output"))
+			})
+		})
+
+		Context("when the check writes a lot to stderr", func() {
+			BeforeEach(func() {
+				fakeResource.CheckWithDeletionsStub = func(_ context.Context, _ atc.Source, _ atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
+					_, err := io.WriteString(stderr, strings.Repeat("a", 100*1024)+"the-end")
+					Expect(err).NotTo(HaveOccurred())
+					return nil, nil, nil
+				}
+			})
+
+			It("saves only the end of the output", func() {
+				output := fakeDelegate.SaveOutputArgsForCall(0)
+				Expect(output).To(HaveLen(64 * 1024))
+				Expect(output).To(HaveSuffix("the-end"))
+			})
 		})
 
 		Context("when resource check succeeds", func() {
 			BeforeEach(func() {
-				fakeResource.CheckReturns(versions, nil)
+				fakeResource.CheckWithDeletionsReturns(versions, nil, nil)
 			})
 
 			It("saves the versions", func() {
 				Expect(fakeDelegate.SaveVersionsCallCount()).To(Equal(1))
 
-				actualVersions := fakeDelegate.SaveVersionsArgsForCall(0)
+				_, actualVersions := fakeDelegate.SaveVersionsArgsForCall(0)
 				Expect(actualVersions).To(Equal(versions))
 			})
+
+			It("does not save any deleted versions", func() {
+				Expect(fakeDelegate.SaveDeletedVersionsCallCount()).To(BeZero())
+			})
+
+			Context("when the resource reports deleted versions", func() {
+				deleted := []atc.Version{{"version": "0"}}
+
+				BeforeEach(func() {
+					fakeResource.CheckWithDeletionsReturns(versions, deleted, nil)
+				})
+
+				It("saves the deleted versions", func() {
+					Expect(fakeDelegate.SaveDeletedVersionsCallCount()).To(Equal(1))
+					Expect(fakeDelegate.SaveDeletedVersionsArgsForCall(0)).To(Equal(deleted))
+				})
+
+				Context("when saving the deleted versions fails", func() {
+					BeforeEach(func() {
+						fakeDelegate.SaveDeletedVersionsReturns(errors.New("nope"))
+					})
+
+					It("returns error", func() {
+						Expect(stepErr).To(HaveOccurred())
+					})
+				})
+			})
 		})
 
 		Context("when performing the check fails", func() {
 			BeforeEach(func() {
-				fakeResource.CheckReturns(nil, errors.New("nope"))
+				fakeResource.CheckWithDeletionsReturns(nil, nil, errors.New("nope"))
 			})
 
 			It("returns error", func() {
@@ -0,0 +1,96 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+//go:generate counterfeiter . Checkpointer
+
+// A Checkpointer records the artifacts registered by a build each time one
+// of its steps succeeds, so that a build which fails can be re-run from the
+// step it failed at.
+type Checkpointer interface {
+	Checkpoint(atc.PlanID, []CheckpointArtifact)
+}
+
+// CheckpointArtifact is an artifact registered by the time a step succeeded.
+// Artifacts fetched by get steps are given by the get step's plan ID and the
+// version fetched, and others by the volume holding them.
+type CheckpointArtifact struct {
+	Name string
+
+	Volume worker.Volume
+
+	GetPlanID atc.PlanID
+	Version   atc.Version
+}
+
+type checkpointerKey struct{}
+
+// WithCheckpointer returns a context which the build's steps are run with so
+// that the build's checkpoints are recorded.
+func WithCheckpointer(ctx context.Context, checkpointer Checkpointer) context.Context {
+	return context.WithValue(ctx, checkpointerKey{}, checkpointer)
+}
+
+// CheckpointStep records a checkpoint once the step it wraps succeeds.
+type CheckpointStep struct {
+	id   atc.PlanID
+	step Step
+}
+
+// Checkpoint constructs a CheckpointStep for the step with the given plan ID.
+func Checkpoint(id atc.PlanID, step Step) Step {
+	return CheckpointStep{
+		id:   id,
+		step: step,
+	}
+}
+
+// Run runs the step and, if it succeeds, records the artifacts registered by
+// then with the context's Checkpointer. No checkpoint is recorded if any of
+// the artifacts can't be found again by a re-run.
+func (c CheckpointStep) Run(ctx context.Context, state RunState) error {
+	err := c.step.Run(ctx, state)
+	if err != nil {
+		return err
+	}
+
+	if !c.step.Succeeded() {
+		return nil
+	}
+
+	checkpointer, ok := ctx.Value(checkpointerKey{}).(Checkpointer)
+	if !ok {
+		return nil
+	}
+
+	artifacts := []CheckpointArtifact{}
+	for name, source := range state.Artifacts().AsMap() {
+		artifact := CheckpointArtifact{Name: string(name)}
+
+		switch s := source.(type) {
+		case *getArtifactSource:
+			artifact.GetPlanID = s.planID
+			artifact.Version = s.versionedSource.Version()
+		case worker.Volume:
+			artifact.Volume = s
+		default:
+			return nil
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	checkpointer.Checkpoint(c.id, artifacts)
+
+	return nil
+}
+
+// Succeeded is true if the step succeeded.
+func (c CheckpointStep) Succeeded() bool {
+	return c.step.Succeeded()
+}
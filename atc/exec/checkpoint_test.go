@@ -0,0 +1,118 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint Step", func() {
+	var (
+		ctx context.Context
+
+		step         *execfakes.FakeStep
+		checkpointer *execfakes.FakeCheckpointer
+		volume       *workerfakes.FakeVolume
+
+		repo  *artifact.Repository
+		state *execfakes.FakeRunState
+
+		checkpointStep exec.Step
+
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		step = new(execfakes.FakeStep)
+		checkpointer = new(execfakes.FakeCheckpointer)
+		volume = new(workerfakes.FakeVolume)
+
+		ctx = exec.WithCheckpointer(context.Background(), checkpointer)
+
+		repo = artifact.NewRepository()
+		repo.RegisterSource("some-output", exec.NewTaskArtifactSource(volume))
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+
+		checkpointStep = exec.Checkpoint("some-plan-id", step)
+	})
+
+	JustBeforeEach(func() {
+		stepErr = checkpointStep.Run(ctx, state)
+	})
+
+	Context("when the step succeeds", func() {
+		BeforeEach(func() {
+			step.SucceededReturns(true)
+		})
+
+		It("records the artifacts registered by then", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(checkpointer.CheckpointCallCount()).To(Equal(1))
+
+			id, artifacts := checkpointer.CheckpointArgsForCall(0)
+			Expect(id).To(BeEquivalentTo("some-plan-id"))
+			Expect(artifacts).To(HaveLen(1))
+			Expect(artifacts[0].Name).To(Equal("some-output"))
+			Expect(artifacts[0].Volume).To(Equal(exec.NewTaskArtifactSource(volume)))
+		})
+
+		It("succeeds", func() {
+			Expect(checkpointStep.Succeeded()).To(BeTrue())
+		})
+
+		Context("when an artifact isn't held in a volume", func() {
+			BeforeEach(func() {
+				repo.RegisterSource("some-stream", new(workerfakes.FakeArtifactSource))
+			})
+
+			It("doesn't record a checkpoint", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(checkpointer.CheckpointCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when there's no checkpointer", func() {
+			BeforeEach(func() {
+				ctx = context.Background()
+			})
+
+			It("runs the step", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(step.RunCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the step fails", func() {
+		BeforeEach(func() {
+			step.SucceededReturns(false)
+		})
+
+		It("doesn't record a checkpoint", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(checkpointer.CheckpointCallCount()).To(BeZero())
+			Expect(checkpointStep.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the step errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			step.RunReturns(disaster)
+		})
+
+		It("returns the error without recording a checkpoint", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(checkpointer.CheckpointCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/contentscan"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// ContentBlockedError is returned by a step whose content the content
+// scanner reported findings for, when the team's policy is to block.
+type ContentBlockedError struct {
+	Artifact string
+	Findings []string
+}
+
+func (err ContentBlockedError) Error() string {
+	return fmt.Sprintf("content of '%s' blocked by content scan: %s", err.Artifact, strings.Join(err.Findings, ", "))
+}
+
+// scanContent passes an artifact through the content scanner, writing any
+// findings to the step's stderr. Whether findings, or a failure to scan,
+// error the step depends on the policy, which defaults to warning.
+func scanContent(
+	ctx context.Context,
+	logger lager.Logger,
+	scanner contentscan.Scanner,
+	metadata StepMetadata,
+	step string,
+	name string,
+	source worker.ArtifactSource,
+	stderr io.Writer,
+) error {
+	logger = logger.Session("scan-content", lager.Data{"artifact": name})
+
+	subject := contentscan.Subject{
+		TeamName:     metadata.TeamName,
+		PipelineName: metadata.PipelineName,
+		JobName:      metadata.JobName,
+		BuildID:      metadata.BuildID,
+		BuildName:    metadata.BuildName,
+		Step:         step,
+		Artifact:     name,
+	}
+
+	destination := &scanDestination{
+		scanner: scanner,
+		subject: subject,
+	}
+
+	block := metadata.ContentScanPolicy == atc.ContentScanPolicyBlock
+
+	err := source.StreamTo(ctx, logger, destination)
+	if err != nil {
+		logger.Error("failed-to-scan", err)
+
+		if block {
+			return err
+		}
+
+		fmt.Fprintf(stderr, "[WARNING] failed to scan content of '%s': %s\n", name, err)
+		return nil
+	}
+
+	if destination.result.Clean() {
+		return nil
+	}
+
+	logger.Info("findings", lager.Data{"findings": destination.result.Findings})
+
+	for _, finding := range destination.result.Findings {
+		fmt.Fprintf(stderr, "[CONTENT SCAN] %s: %s\n", name, finding)
+	}
+
+	if block {
+		return ContentBlockedError{
+			Artifact: name,
+			Findings: destination.result.Findings,
+		}
+	}
+
+	return nil
+}
+
+// scanDestination is an artifact destination which scans whatever is
+// streamed in rather than storing it.
+type scanDestination struct {
+	scanner contentscan.Scanner
+	subject contentscan.Subject
+
+	result contentscan.Result
+}
+
+func (dest *scanDestination) StreamIn(ctx context.Context, path string, content io.Reader) error {
+	result, err := dest.scanner.Scan(ctx, dest.subject, content)
+	if err != nil {
+		return err
+	}
+
+	dest.result = result
+
+	return nil
+}
@@ -2,8 +2,10 @@
 package execfakes
 
 import (
+	"github.com/concourse/concourse/atc"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
@@ -12,11 +14,78 @@ import (
 )
 
 type FakeBuildStepDelegate struct {
-	ErroredStub        func(lager.Logger, string)
+	ArtifactChecksummedStub        func(string, string, int64) error
+	artifactChecksummedMutex       sync.RWMutex
+	artifactChecksummedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	artifactChecksummedReturns struct {
+		result1 error
+	}
+	artifactChecksummedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerPreparedStub        func(time.Duration, time.Duration) error
+	containerPreparedMutex       sync.RWMutex
+	containerPreparedArgsForCall []struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}
+	containerPreparedReturns struct {
+		result1 error
+	}
+	containerPreparedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ErroredStub        func(lager.Logger, string, atc.ErrorCode)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorCode
+	}
+	ImageBytesStreamedStub        func(int64) error
+	imageBytesStreamedMutex       sync.RWMutex
+	imageBytesStreamedArgsForCall []struct {
+		arg1 int64
+	}
+	imageBytesStreamedReturns struct {
+		result1 error
+	}
+	imageBytesStreamedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageCheckStartedStub        func() error
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+	}
+	imageCheckStartedReturns struct {
+		result1 error
+	}
+	imageCheckStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchFinishedStub        func() error
+	imageFetchFinishedMutex       sync.RWMutex
+	imageFetchFinishedArgsForCall []struct {
+	}
+	imageFetchFinishedReturns struct {
+		result1 error
+	}
+	imageFetchFinishedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchStartedStub        func() error
+	imageFetchStartedMutex       sync.RWMutex
+	imageFetchStartedArgsForCall []struct {
+	}
+	imageFetchStartedReturns struct {
+		result1 error
+	}
+	imageFetchStartedReturnsOnCall map[int]struct {
+		result1 error
 	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
@@ -63,16 +132,140 @@ type FakeBuildStepDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildStepDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeBuildStepDelegate) ArtifactChecksummed(arg1 string, arg2 string, arg3 int64) error {
+	fake.artifactChecksummedMutex.Lock()
+	ret, specificReturn := fake.artifactChecksummedReturnsOnCall[len(fake.artifactChecksummedArgsForCall)]
+	fake.artifactChecksummedArgsForCall = append(fake.artifactChecksummedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ArtifactChecksummed", []interface{}{arg1, arg2, arg3})
+	fake.artifactChecksummedMutex.Unlock()
+	if fake.ArtifactChecksummedStub != nil {
+		return fake.ArtifactChecksummedStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.artifactChecksummedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) ArtifactChecksummedCallCount() int {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	return len(fake.artifactChecksummedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ArtifactChecksummedCalls(stub func(string, string, int64) error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ArtifactChecksummedArgsForCall(i int) (string, string, int64) {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	argsForCall := fake.artifactChecksummedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildStepDelegate) ArtifactChecksummedReturns(result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	fake.artifactChecksummedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ArtifactChecksummedReturnsOnCall(i int, result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	if fake.artifactChecksummedReturnsOnCall == nil {
+		fake.artifactChecksummedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.artifactChecksummedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ContainerPrepared(arg1 time.Duration, arg2 time.Duration) error {
+	fake.containerPreparedMutex.Lock()
+	ret, specificReturn := fake.containerPreparedReturnsOnCall[len(fake.containerPreparedArgsForCall)]
+	fake.containerPreparedArgsForCall = append(fake.containerPreparedArgsForCall, struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("ContainerPrepared", []interface{}{arg1, arg2})
+	fake.containerPreparedMutex.Unlock()
+	if fake.ContainerPreparedStub != nil {
+		return fake.ContainerPreparedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerPreparedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) ContainerPreparedCallCount() int {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	return len(fake.containerPreparedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ContainerPreparedCalls(stub func(time.Duration, time.Duration) error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ContainerPreparedArgsForCall(i int) (time.Duration, time.Duration) {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	argsForCall := fake.containerPreparedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildStepDelegate) ContainerPreparedReturns(result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	fake.containerPreparedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ContainerPreparedReturnsOnCall(i int, result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	if fake.containerPreparedReturnsOnCall == nil {
+		fake.containerPreparedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.containerPreparedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorCode) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorCode
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -82,17 +275,233 @@ func (fake *FakeBuildStepDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeBuildStepDelegate) ErroredCalls(stub func(lager.Logger, string)) {
+func (fake *FakeBuildStepDelegate) ErroredCalls(stub func(lager.Logger, string, atc.ErrorCode)) {
 	fake.erroredMutex.Lock()
 	defer fake.erroredMutex.Unlock()
 	fake.ErroredStub = stub
 }
 
-func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorCode) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	argsForCall := fake.erroredArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildStepDelegate) ImageBytesStreamed(arg1 int64) error {
+	fake.imageBytesStreamedMutex.Lock()
+	ret, specificReturn := fake.imageBytesStreamedReturnsOnCall[len(fake.imageBytesStreamedArgsForCall)]
+	fake.imageBytesStreamedArgsForCall = append(fake.imageBytesStreamedArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	fake.recordInvocation("ImageBytesStreamed", []interface{}{arg1})
+	fake.imageBytesStreamedMutex.Unlock()
+	if fake.ImageBytesStreamedStub != nil {
+		return fake.ImageBytesStreamedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageBytesStreamedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) ImageBytesStreamedCallCount() int {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	return len(fake.imageBytesStreamedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageBytesStreamedCalls(stub func(int64) error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ImageBytesStreamedArgsForCall(i int) int64 {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	argsForCall := fake.imageBytesStreamedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildStepDelegate) ImageBytesStreamedReturns(result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	fake.imageBytesStreamedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageBytesStreamedReturnsOnCall(i int, result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	if fake.imageBytesStreamedReturnsOnCall == nil {
+		fake.imageBytesStreamedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageBytesStreamedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStarted() error {
+	fake.imageCheckStartedMutex.Lock()
+	ret, specificReturn := fake.imageCheckStartedReturnsOnCall[len(fake.imageCheckStartedArgsForCall)]
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		return fake.ImageCheckStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageCheckStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStartedCalls(stub func() error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStartedReturns(result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	fake.imageCheckStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckStartedReturnsOnCall(i int, result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	if fake.imageCheckStartedReturnsOnCall == nil {
+		fake.imageCheckStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageCheckStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchFinished() error {
+	fake.imageFetchFinishedMutex.Lock()
+	ret, specificReturn := fake.imageFetchFinishedReturnsOnCall[len(fake.imageFetchFinishedArgsForCall)]
+	fake.imageFetchFinishedArgsForCall = append(fake.imageFetchFinishedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchFinished", []interface{}{})
+	fake.imageFetchFinishedMutex.Unlock()
+	if fake.ImageFetchFinishedStub != nil {
+		return fake.ImageFetchFinishedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchFinishedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchFinishedCallCount() int {
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	return len(fake.imageFetchFinishedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchFinishedCalls(stub func() error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchFinishedReturns(result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	fake.imageFetchFinishedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchFinishedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	if fake.imageFetchFinishedReturnsOnCall == nil {
+		fake.imageFetchFinishedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchFinishedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchStarted() error {
+	fake.imageFetchStartedMutex.Lock()
+	ret, specificReturn := fake.imageFetchStartedReturnsOnCall[len(fake.imageFetchStartedArgsForCall)]
+	fake.imageFetchStartedArgsForCall = append(fake.imageFetchStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchStarted", []interface{}{})
+	fake.imageFetchStartedMutex.Unlock()
+	if fake.ImageFetchStartedStub != nil {
+		return fake.ImageFetchStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchStartedCallCount() int {
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
+	return len(fake.imageFetchStartedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchStartedCalls(stub func() error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchStartedReturns(result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	fake.imageFetchStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchStartedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	if fake.imageFetchStartedReturnsOnCall == nil {
+		fake.imageFetchStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeBuildStepDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
@@ -314,8 +723,20 @@ func (fake *FakeBuildStepDelegate) VariablesReturnsOnCall(i int, result1 vars.Cr
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.stderrMutex.RLock()
@@ -4,6 +4,7 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -13,11 +14,78 @@ import (
 )
 
 type FakeCheckDelegate struct {
-	ErroredStub        func(lager.Logger, string)
+	ArtifactChecksummedStub        func(string, string, int64) error
+	artifactChecksummedMutex       sync.RWMutex
+	artifactChecksummedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	artifactChecksummedReturns struct {
+		result1 error
+	}
+	artifactChecksummedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerPreparedStub        func(time.Duration, time.Duration) error
+	containerPreparedMutex       sync.RWMutex
+	containerPreparedArgsForCall []struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}
+	containerPreparedReturns struct {
+		result1 error
+	}
+	containerPreparedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ErroredStub        func(lager.Logger, string, atc.ErrorCode)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorCode
+	}
+	ImageBytesStreamedStub        func(int64) error
+	imageBytesStreamedMutex       sync.RWMutex
+	imageBytesStreamedArgsForCall []struct {
+		arg1 int64
+	}
+	imageBytesStreamedReturns struct {
+		result1 error
+	}
+	imageBytesStreamedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageCheckStartedStub        func() error
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+	}
+	imageCheckStartedReturns struct {
+		result1 error
+	}
+	imageCheckStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchFinishedStub        func() error
+	imageFetchFinishedMutex       sync.RWMutex
+	imageFetchFinishedArgsForCall []struct {
+	}
+	imageFetchFinishedReturns struct {
+		result1 error
+	}
+	imageFetchFinishedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchStartedStub        func() error
+	imageFetchStartedMutex       sync.RWMutex
+	imageFetchStartedArgsForCall []struct {
+	}
+	imageFetchStartedReturns struct {
+		result1 error
+	}
+	imageFetchStartedReturnsOnCall map[int]struct {
+		result1 error
 	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
@@ -30,10 +98,33 @@ type FakeCheckDelegate struct {
 	imageVersionDeterminedReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SaveVersionsStub        func([]atc.Version) error
+	SaveDeletedVersionsStub        func([]atc.Version) error
+	saveDeletedVersionsMutex       sync.RWMutex
+	saveDeletedVersionsArgsForCall []struct {
+		arg1 []atc.Version
+	}
+	saveDeletedVersionsReturns struct {
+		result1 error
+	}
+	saveDeletedVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveOutputStub        func(string) error
+	saveOutputMutex       sync.RWMutex
+	saveOutputArgsForCall []struct {
+		arg1 string
+	}
+	saveOutputReturns struct {
+		result1 error
+	}
+	saveOutputReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveVersionsStub        func(lager.Logger, []atc.Version) error
 	saveVersionsMutex       sync.RWMutex
 	saveVersionsArgsForCall []struct {
-		arg1 []atc.Version
+		arg1 lager.Logger
+		arg2 []atc.Version
 	}
 	saveVersionsReturns struct {
 		result1 error
@@ -41,6 +132,17 @@ type FakeCheckDelegate struct {
 	saveVersionsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveWorkerStub        func(string) error
+	saveWorkerMutex       sync.RWMutex
+	saveWorkerArgsForCall []struct {
+		arg1 string
+	}
+	saveWorkerReturns struct {
+		result1 error
+	}
+	saveWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StderrStub        func() io.Writer
 	stderrMutex       sync.RWMutex
 	stderrArgsForCall []struct {
@@ -75,16 +177,140 @@ type FakeCheckDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeCheckDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeCheckDelegate) ArtifactChecksummed(arg1 string, arg2 string, arg3 int64) error {
+	fake.artifactChecksummedMutex.Lock()
+	ret, specificReturn := fake.artifactChecksummedReturnsOnCall[len(fake.artifactChecksummedArgsForCall)]
+	fake.artifactChecksummedArgsForCall = append(fake.artifactChecksummedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ArtifactChecksummed", []interface{}{arg1, arg2, arg3})
+	fake.artifactChecksummedMutex.Unlock()
+	if fake.ArtifactChecksummedStub != nil {
+		return fake.ArtifactChecksummedStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.artifactChecksummedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) ArtifactChecksummedCallCount() int {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	return len(fake.artifactChecksummedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ArtifactChecksummedCalls(stub func(string, string, int64) error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = stub
+}
+
+func (fake *FakeCheckDelegate) ArtifactChecksummedArgsForCall(i int) (string, string, int64) {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	argsForCall := fake.artifactChecksummedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCheckDelegate) ArtifactChecksummedReturns(result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	fake.artifactChecksummedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ArtifactChecksummedReturnsOnCall(i int, result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	if fake.artifactChecksummedReturnsOnCall == nil {
+		fake.artifactChecksummedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.artifactChecksummedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ContainerPrepared(arg1 time.Duration, arg2 time.Duration) error {
+	fake.containerPreparedMutex.Lock()
+	ret, specificReturn := fake.containerPreparedReturnsOnCall[len(fake.containerPreparedArgsForCall)]
+	fake.containerPreparedArgsForCall = append(fake.containerPreparedArgsForCall, struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("ContainerPrepared", []interface{}{arg1, arg2})
+	fake.containerPreparedMutex.Unlock()
+	if fake.ContainerPreparedStub != nil {
+		return fake.ContainerPreparedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerPreparedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) ContainerPreparedCallCount() int {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	return len(fake.containerPreparedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ContainerPreparedCalls(stub func(time.Duration, time.Duration) error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = stub
+}
+
+func (fake *FakeCheckDelegate) ContainerPreparedArgsForCall(i int) (time.Duration, time.Duration) {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	argsForCall := fake.containerPreparedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckDelegate) ContainerPreparedReturns(result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	fake.containerPreparedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ContainerPreparedReturnsOnCall(i int, result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	if fake.containerPreparedReturnsOnCall == nil {
+		fake.containerPreparedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.containerPreparedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorCode) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorCode
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -94,17 +320,233 @@ func (fake *FakeCheckDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeCheckDelegate) ErroredCalls(stub func(lager.Logger, string)) {
+func (fake *FakeCheckDelegate) ErroredCalls(stub func(lager.Logger, string, atc.ErrorCode)) {
 	fake.erroredMutex.Lock()
 	defer fake.erroredMutex.Unlock()
 	fake.ErroredStub = stub
 }
 
-func (fake *FakeCheckDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeCheckDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorCode) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	argsForCall := fake.erroredArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCheckDelegate) ImageBytesStreamed(arg1 int64) error {
+	fake.imageBytesStreamedMutex.Lock()
+	ret, specificReturn := fake.imageBytesStreamedReturnsOnCall[len(fake.imageBytesStreamedArgsForCall)]
+	fake.imageBytesStreamedArgsForCall = append(fake.imageBytesStreamedArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	fake.recordInvocation("ImageBytesStreamed", []interface{}{arg1})
+	fake.imageBytesStreamedMutex.Unlock()
+	if fake.ImageBytesStreamedStub != nil {
+		return fake.ImageBytesStreamedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageBytesStreamedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) ImageBytesStreamedCallCount() int {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	return len(fake.imageBytesStreamedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ImageBytesStreamedCalls(stub func(int64) error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = stub
+}
+
+func (fake *FakeCheckDelegate) ImageBytesStreamedArgsForCall(i int) int64 {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	argsForCall := fake.imageBytesStreamedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckDelegate) ImageBytesStreamedReturns(result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	fake.imageBytesStreamedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ImageBytesStreamedReturnsOnCall(i int, result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	if fake.imageBytesStreamedReturnsOnCall == nil {
+		fake.imageBytesStreamedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageBytesStreamedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ImageCheckStarted() error {
+	fake.imageCheckStartedMutex.Lock()
+	ret, specificReturn := fake.imageCheckStartedReturnsOnCall[len(fake.imageCheckStartedArgsForCall)]
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		return fake.ImageCheckStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageCheckStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ImageCheckStartedCalls(stub func() error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = stub
+}
+
+func (fake *FakeCheckDelegate) ImageCheckStartedReturns(result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	fake.imageCheckStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ImageCheckStartedReturnsOnCall(i int, result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	if fake.imageCheckStartedReturnsOnCall == nil {
+		fake.imageCheckStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageCheckStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ImageFetchFinished() error {
+	fake.imageFetchFinishedMutex.Lock()
+	ret, specificReturn := fake.imageFetchFinishedReturnsOnCall[len(fake.imageFetchFinishedArgsForCall)]
+	fake.imageFetchFinishedArgsForCall = append(fake.imageFetchFinishedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchFinished", []interface{}{})
+	fake.imageFetchFinishedMutex.Unlock()
+	if fake.ImageFetchFinishedStub != nil {
+		return fake.ImageFetchFinishedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchFinishedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) ImageFetchFinishedCallCount() int {
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	return len(fake.imageFetchFinishedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ImageFetchFinishedCalls(stub func() error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = stub
+}
+
+func (fake *FakeCheckDelegate) ImageFetchFinishedReturns(result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	fake.imageFetchFinishedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ImageFetchFinishedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	if fake.imageFetchFinishedReturnsOnCall == nil {
+		fake.imageFetchFinishedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchFinishedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ImageFetchStarted() error {
+	fake.imageFetchStartedMutex.Lock()
+	ret, specificReturn := fake.imageFetchStartedReturnsOnCall[len(fake.imageFetchStartedArgsForCall)]
+	fake.imageFetchStartedArgsForCall = append(fake.imageFetchStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchStarted", []interface{}{})
+	fake.imageFetchStartedMutex.Unlock()
+	if fake.ImageFetchStartedStub != nil {
+		return fake.ImageFetchStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) ImageFetchStartedCallCount() int {
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
+	return len(fake.imageFetchStartedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ImageFetchStartedCalls(stub func() error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = stub
+}
+
+func (fake *FakeCheckDelegate) ImageFetchStartedReturns(result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	fake.imageFetchStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ImageFetchStartedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	if fake.imageFetchStartedReturnsOnCall == nil {
+		fake.imageFetchStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeCheckDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
@@ -167,21 +609,147 @@ func (fake *FakeCheckDelegate) ImageVersionDeterminedReturnsOnCall(i int, result
 	}{result1}
 }
 
-func (fake *FakeCheckDelegate) SaveVersions(arg1 []atc.Version) error {
+func (fake *FakeCheckDelegate) SaveDeletedVersions(arg1 []atc.Version) error {
 	var arg1Copy []atc.Version
 	if arg1 != nil {
 		arg1Copy = make([]atc.Version, len(arg1))
 		copy(arg1Copy, arg1)
 	}
+	fake.saveDeletedVersionsMutex.Lock()
+	ret, specificReturn := fake.saveDeletedVersionsReturnsOnCall[len(fake.saveDeletedVersionsArgsForCall)]
+	fake.saveDeletedVersionsArgsForCall = append(fake.saveDeletedVersionsArgsForCall, struct {
+		arg1 []atc.Version
+	}{arg1Copy})
+	fake.recordInvocation("SaveDeletedVersions", []interface{}{arg1Copy})
+	fake.saveDeletedVersionsMutex.Unlock()
+	if fake.SaveDeletedVersionsStub != nil {
+		return fake.SaveDeletedVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveDeletedVersionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) SaveDeletedVersionsCallCount() int {
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	return len(fake.saveDeletedVersionsArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SaveDeletedVersionsCalls(stub func([]atc.Version) error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = stub
+}
+
+func (fake *FakeCheckDelegate) SaveDeletedVersionsArgsForCall(i int) []atc.Version {
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	argsForCall := fake.saveDeletedVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckDelegate) SaveDeletedVersionsReturns(result1 error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = nil
+	fake.saveDeletedVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) SaveDeletedVersionsReturnsOnCall(i int, result1 error) {
+	fake.saveDeletedVersionsMutex.Lock()
+	defer fake.saveDeletedVersionsMutex.Unlock()
+	fake.SaveDeletedVersionsStub = nil
+	if fake.saveDeletedVersionsReturnsOnCall == nil {
+		fake.saveDeletedVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveDeletedVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) SaveOutput(arg1 string) error {
+	fake.saveOutputMutex.Lock()
+	ret, specificReturn := fake.saveOutputReturnsOnCall[len(fake.saveOutputArgsForCall)]
+	fake.saveOutputArgsForCall = append(fake.saveOutputArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SaveOutput", []interface{}{arg1})
+	fake.saveOutputMutex.Unlock()
+	if fake.SaveOutputStub != nil {
+		return fake.SaveOutputStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveOutputReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) SaveOutputCallCount() int {
+	fake.saveOutputMutex.RLock()
+	defer fake.saveOutputMutex.RUnlock()
+	return len(fake.saveOutputArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SaveOutputCalls(stub func(string) error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = stub
+}
+
+func (fake *FakeCheckDelegate) SaveOutputArgsForCall(i int) string {
+	fake.saveOutputMutex.RLock()
+	defer fake.saveOutputMutex.RUnlock()
+	argsForCall := fake.saveOutputArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckDelegate) SaveOutputReturns(result1 error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = nil
+	fake.saveOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) SaveOutputReturnsOnCall(i int, result1 error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = nil
+	if fake.saveOutputReturnsOnCall == nil {
+		fake.saveOutputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOutputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) SaveVersions(arg1 lager.Logger, arg2 []atc.Version) error {
+	var arg2Copy []atc.Version
+	if arg2 != nil {
+		arg2Copy = make([]atc.Version, len(arg2))
+		copy(arg2Copy, arg2)
+	}
 	fake.saveVersionsMutex.Lock()
 	ret, specificReturn := fake.saveVersionsReturnsOnCall[len(fake.saveVersionsArgsForCall)]
 	fake.saveVersionsArgsForCall = append(fake.saveVersionsArgsForCall, struct {
-		arg1 []atc.Version
-	}{arg1Copy})
-	fake.recordInvocation("SaveVersions", []interface{}{arg1Copy})
+		arg1 lager.Logger
+		arg2 []atc.Version
+	}{arg1, arg2Copy})
+	fake.recordInvocation("SaveVersions", []interface{}{arg1, arg2Copy})
 	fake.saveVersionsMutex.Unlock()
 	if fake.SaveVersionsStub != nil {
-		return fake.SaveVersionsStub(arg1)
+		return fake.SaveVersionsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -196,17 +764,17 @@ func (fake *FakeCheckDelegate) SaveVersionsCallCount() int {
 	return len(fake.saveVersionsArgsForCall)
 }
 
-func (fake *FakeCheckDelegate) SaveVersionsCalls(stub func([]atc.Version) error) {
+func (fake *FakeCheckDelegate) SaveVersionsCalls(stub func(lager.Logger, []atc.Version) error) {
 	fake.saveVersionsMutex.Lock()
 	defer fake.saveVersionsMutex.Unlock()
 	fake.SaveVersionsStub = stub
 }
 
-func (fake *FakeCheckDelegate) SaveVersionsArgsForCall(i int) []atc.Version {
+func (fake *FakeCheckDelegate) SaveVersionsArgsForCall(i int) (lager.Logger, []atc.Version) {
 	fake.saveVersionsMutex.RLock()
 	defer fake.saveVersionsMutex.RUnlock()
 	argsForCall := fake.saveVersionsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeCheckDelegate) SaveVersionsReturns(result1 error) {
@@ -232,6 +800,66 @@ func (fake *FakeCheckDelegate) SaveVersionsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) SaveWorker(arg1 string) error {
+	fake.saveWorkerMutex.Lock()
+	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
+	fake.saveWorkerArgsForCall = append(fake.saveWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SaveWorker", []interface{}{arg1})
+	fake.saveWorkerMutex.Unlock()
+	if fake.SaveWorkerStub != nil {
+		return fake.SaveWorkerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveWorkerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) SaveWorkerCallCount() int {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	return len(fake.saveWorkerArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SaveWorkerCalls(stub func(string) error) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = stub
+}
+
+func (fake *FakeCheckDelegate) SaveWorkerArgsForCall(i int) string {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	argsForCall := fake.saveWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckDelegate) SaveWorkerReturns(result1 error) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = nil
+	fake.saveWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) SaveWorkerReturnsOnCall(i int, result1 error) {
+	fake.saveWorkerMutex.Lock()
+	defer fake.saveWorkerMutex.Unlock()
+	fake.SaveWorkerStub = nil
+	if fake.saveWorkerReturnsOnCall == nil {
+		fake.saveWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCheckDelegate) Stderr() io.Writer {
 	fake.stderrMutex.Lock()
 	ret, specificReturn := fake.stderrReturnsOnCall[len(fake.stderrArgsForCall)]
@@ -391,12 +1019,30 @@ func (fake *FakeCheckDelegate) VariablesReturnsOnCall(i int, result1 vars.CredVa
 func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.saveDeletedVersionsMutex.RLock()
+	defer fake.saveDeletedVersionsMutex.RUnlock()
+	fake.saveOutputMutex.RLock()
+	defer fake.saveOutputMutex.RUnlock()
 	fake.saveVersionsMutex.RLock()
 	defer fake.saveVersionsMutex.RUnlock()
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
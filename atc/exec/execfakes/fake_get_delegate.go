@@ -4,6 +4,7 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -13,11 +14,45 @@ import (
 )
 
 type FakeGetDelegate struct {
-	ErroredStub        func(lager.Logger, string)
+	ArtifactChecksummedStub        func(string, string, int64) error
+	artifactChecksummedMutex       sync.RWMutex
+	artifactChecksummedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	artifactChecksummedReturns struct {
+		result1 error
+	}
+	artifactChecksummedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerPreparedStub        func(time.Duration, time.Duration) error
+	containerPreparedMutex       sync.RWMutex
+	containerPreparedArgsForCall []struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}
+	containerPreparedReturns struct {
+		result1 error
+	}
+	containerPreparedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ErroredStub        func(lager.Logger, string, atc.ErrorCode)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorCode
+	}
+	FetchedStub        func(lager.Logger, atc.GetPlan, time.Duration, bool)
+	fetchedMutex       sync.RWMutex
+	fetchedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.GetPlan
+		arg3 time.Duration
+		arg4 bool
 	}
 	FinishedStub        func(lager.Logger, exec.ExitStatus, exec.VersionInfo)
 	finishedMutex       sync.RWMutex
@@ -26,6 +61,47 @@ type FakeGetDelegate struct {
 		arg2 exec.ExitStatus
 		arg3 exec.VersionInfo
 	}
+	ImageBytesStreamedStub        func(int64) error
+	imageBytesStreamedMutex       sync.RWMutex
+	imageBytesStreamedArgsForCall []struct {
+		arg1 int64
+	}
+	imageBytesStreamedReturns struct {
+		result1 error
+	}
+	imageBytesStreamedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageCheckStartedStub        func() error
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+	}
+	imageCheckStartedReturns struct {
+		result1 error
+	}
+	imageCheckStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchFinishedStub        func() error
+	imageFetchFinishedMutex       sync.RWMutex
+	imageFetchFinishedArgsForCall []struct {
+	}
+	imageFetchFinishedReturns struct {
+		result1 error
+	}
+	imageFetchFinishedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchStartedStub        func() error
+	imageFetchStartedMutex       sync.RWMutex
+	imageFetchStartedArgsForCall []struct {
+	}
+	imageFetchStartedReturns struct {
+		result1 error
+	}
+	imageFetchStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -42,10 +118,12 @@ type FakeGetDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
-	StartingStub        func(lager.Logger)
+	StartingStub        func(lager.Logger, string, bool)
 	startingMutex       sync.RWMutex
 	startingArgsForCall []struct {
 		arg1 lager.Logger
+		arg2 string
+		arg3 bool
 	}
 	StderrStub        func() io.Writer
 	stderrMutex       sync.RWMutex
@@ -88,16 +166,140 @@ type FakeGetDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeGetDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeGetDelegate) ArtifactChecksummed(arg1 string, arg2 string, arg3 int64) error {
+	fake.artifactChecksummedMutex.Lock()
+	ret, specificReturn := fake.artifactChecksummedReturnsOnCall[len(fake.artifactChecksummedArgsForCall)]
+	fake.artifactChecksummedArgsForCall = append(fake.artifactChecksummedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ArtifactChecksummed", []interface{}{arg1, arg2, arg3})
+	fake.artifactChecksummedMutex.Unlock()
+	if fake.ArtifactChecksummedStub != nil {
+		return fake.ArtifactChecksummedStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.artifactChecksummedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) ArtifactChecksummedCallCount() int {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	return len(fake.artifactChecksummedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ArtifactChecksummedCalls(stub func(string, string, int64) error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = stub
+}
+
+func (fake *FakeGetDelegate) ArtifactChecksummedArgsForCall(i int) (string, string, int64) {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	argsForCall := fake.artifactChecksummedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGetDelegate) ArtifactChecksummedReturns(result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	fake.artifactChecksummedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ArtifactChecksummedReturnsOnCall(i int, result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	if fake.artifactChecksummedReturnsOnCall == nil {
+		fake.artifactChecksummedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.artifactChecksummedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ContainerPrepared(arg1 time.Duration, arg2 time.Duration) error {
+	fake.containerPreparedMutex.Lock()
+	ret, specificReturn := fake.containerPreparedReturnsOnCall[len(fake.containerPreparedArgsForCall)]
+	fake.containerPreparedArgsForCall = append(fake.containerPreparedArgsForCall, struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("ContainerPrepared", []interface{}{arg1, arg2})
+	fake.containerPreparedMutex.Unlock()
+	if fake.ContainerPreparedStub != nil {
+		return fake.ContainerPreparedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerPreparedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) ContainerPreparedCallCount() int {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	return len(fake.containerPreparedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ContainerPreparedCalls(stub func(time.Duration, time.Duration) error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = stub
+}
+
+func (fake *FakeGetDelegate) ContainerPreparedArgsForCall(i int) (time.Duration, time.Duration) {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	argsForCall := fake.containerPreparedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGetDelegate) ContainerPreparedReturns(result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	fake.containerPreparedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ContainerPreparedReturnsOnCall(i int, result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	if fake.containerPreparedReturnsOnCall == nil {
+		fake.containerPreparedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.containerPreparedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorCode) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorCode
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -107,17 +309,51 @@ func (fake *FakeGetDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeGetDelegate) ErroredCalls(stub func(lager.Logger, string)) {
+func (fake *FakeGetDelegate) ErroredCalls(stub func(lager.Logger, string, atc.ErrorCode)) {
 	fake.erroredMutex.Lock()
 	defer fake.erroredMutex.Unlock()
 	fake.ErroredStub = stub
 }
 
-func (fake *FakeGetDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeGetDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorCode) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	argsForCall := fake.erroredArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGetDelegate) Fetched(arg1 lager.Logger, arg2 atc.GetPlan, arg3 time.Duration, arg4 bool) {
+	fake.fetchedMutex.Lock()
+	fake.fetchedArgsForCall = append(fake.fetchedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.GetPlan
+		arg3 time.Duration
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Fetched", []interface{}{arg1, arg2, arg3, arg4})
+	fake.fetchedMutex.Unlock()
+	if fake.FetchedStub != nil {
+		fake.FetchedStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeGetDelegate) FetchedCallCount() int {
+	fake.fetchedMutex.RLock()
+	defer fake.fetchedMutex.RUnlock()
+	return len(fake.fetchedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) FetchedCalls(stub func(lager.Logger, atc.GetPlan, time.Duration, bool)) {
+	fake.fetchedMutex.Lock()
+	defer fake.fetchedMutex.Unlock()
+	fake.FetchedStub = stub
+}
+
+func (fake *FakeGetDelegate) FetchedArgsForCall(i int) (lager.Logger, atc.GetPlan, time.Duration, bool) {
+	fake.fetchedMutex.RLock()
+	defer fake.fetchedMutex.RUnlock()
+	argsForCall := fake.fetchedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeGetDelegate) Finished(arg1 lager.Logger, arg2 exec.ExitStatus, arg3 exec.VersionInfo) {
@@ -153,6 +389,222 @@ func (fake *FakeGetDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exit
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeGetDelegate) ImageBytesStreamed(arg1 int64) error {
+	fake.imageBytesStreamedMutex.Lock()
+	ret, specificReturn := fake.imageBytesStreamedReturnsOnCall[len(fake.imageBytesStreamedArgsForCall)]
+	fake.imageBytesStreamedArgsForCall = append(fake.imageBytesStreamedArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	fake.recordInvocation("ImageBytesStreamed", []interface{}{arg1})
+	fake.imageBytesStreamedMutex.Unlock()
+	if fake.ImageBytesStreamedStub != nil {
+		return fake.ImageBytesStreamedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageBytesStreamedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) ImageBytesStreamedCallCount() int {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	return len(fake.imageBytesStreamedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ImageBytesStreamedCalls(stub func(int64) error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = stub
+}
+
+func (fake *FakeGetDelegate) ImageBytesStreamedArgsForCall(i int) int64 {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	argsForCall := fake.imageBytesStreamedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGetDelegate) ImageBytesStreamedReturns(result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	fake.imageBytesStreamedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ImageBytesStreamedReturnsOnCall(i int, result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	if fake.imageBytesStreamedReturnsOnCall == nil {
+		fake.imageBytesStreamedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageBytesStreamedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ImageCheckStarted() error {
+	fake.imageCheckStartedMutex.Lock()
+	ret, specificReturn := fake.imageCheckStartedReturnsOnCall[len(fake.imageCheckStartedArgsForCall)]
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		return fake.ImageCheckStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageCheckStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ImageCheckStartedCalls(stub func() error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = stub
+}
+
+func (fake *FakeGetDelegate) ImageCheckStartedReturns(result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	fake.imageCheckStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ImageCheckStartedReturnsOnCall(i int, result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	if fake.imageCheckStartedReturnsOnCall == nil {
+		fake.imageCheckStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageCheckStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ImageFetchFinished() error {
+	fake.imageFetchFinishedMutex.Lock()
+	ret, specificReturn := fake.imageFetchFinishedReturnsOnCall[len(fake.imageFetchFinishedArgsForCall)]
+	fake.imageFetchFinishedArgsForCall = append(fake.imageFetchFinishedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchFinished", []interface{}{})
+	fake.imageFetchFinishedMutex.Unlock()
+	if fake.ImageFetchFinishedStub != nil {
+		return fake.ImageFetchFinishedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchFinishedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) ImageFetchFinishedCallCount() int {
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	return len(fake.imageFetchFinishedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ImageFetchFinishedCalls(stub func() error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = stub
+}
+
+func (fake *FakeGetDelegate) ImageFetchFinishedReturns(result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	fake.imageFetchFinishedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ImageFetchFinishedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	if fake.imageFetchFinishedReturnsOnCall == nil {
+		fake.imageFetchFinishedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchFinishedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ImageFetchStarted() error {
+	fake.imageFetchStartedMutex.Lock()
+	ret, specificReturn := fake.imageFetchStartedReturnsOnCall[len(fake.imageFetchStartedArgsForCall)]
+	fake.imageFetchStartedArgsForCall = append(fake.imageFetchStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchStarted", []interface{}{})
+	fake.imageFetchStartedMutex.Unlock()
+	if fake.ImageFetchStartedStub != nil {
+		return fake.ImageFetchStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) ImageFetchStartedCallCount() int {
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
+	return len(fake.imageFetchStartedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ImageFetchStartedCalls(stub func() error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = stub
+}
+
+func (fake *FakeGetDelegate) ImageFetchStartedReturns(result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	fake.imageFetchStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ImageFetchStartedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	if fake.imageFetchStartedReturnsOnCall == nil {
+		fake.imageFetchStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeGetDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -244,15 +696,17 @@ func (fake *FakeGetDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
-func (fake *FakeGetDelegate) Starting(arg1 lager.Logger) {
+func (fake *FakeGetDelegate) Starting(arg1 lager.Logger, arg2 string, arg3 bool) {
 	fake.startingMutex.Lock()
 	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
 		arg1 lager.Logger
-	}{arg1})
-	fake.recordInvocation("Starting", []interface{}{arg1})
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Starting", []interface{}{arg1, arg2, arg3})
 	fake.startingMutex.Unlock()
 	if fake.StartingStub != nil {
-		fake.StartingStub(arg1)
+		fake.StartingStub(arg1, arg2, arg3)
 	}
 }
 
@@ -262,17 +716,17 @@ func (fake *FakeGetDelegate) StartingCallCount() int {
 	return len(fake.startingArgsForCall)
 }
 
-func (fake *FakeGetDelegate) StartingCalls(stub func(lager.Logger)) {
+func (fake *FakeGetDelegate) StartingCalls(stub func(lager.Logger, string, bool)) {
 	fake.startingMutex.Lock()
 	defer fake.startingMutex.Unlock()
 	fake.StartingStub = stub
 }
 
-func (fake *FakeGetDelegate) StartingArgsForCall(i int) lager.Logger {
+func (fake *FakeGetDelegate) StartingArgsForCall(i int) (lager.Logger, string, bool) {
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	argsForCall := fake.startingArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeGetDelegate) Stderr() io.Writer {
@@ -467,10 +921,24 @@ func (fake *FakeGetDelegate) VariablesReturnsOnCall(i int, result1 vars.CredVars
 func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.fetchedMutex.RLock()
+	defer fake.fetchedMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
@@ -4,6 +4,7 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -13,11 +14,37 @@ import (
 )
 
 type FakePutDelegate struct {
-	ErroredStub        func(lager.Logger, string)
+	ArtifactChecksummedStub        func(string, string, int64) error
+	artifactChecksummedMutex       sync.RWMutex
+	artifactChecksummedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	artifactChecksummedReturns struct {
+		result1 error
+	}
+	artifactChecksummedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerPreparedStub        func(time.Duration, time.Duration) error
+	containerPreparedMutex       sync.RWMutex
+	containerPreparedArgsForCall []struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}
+	containerPreparedReturns struct {
+		result1 error
+	}
+	containerPreparedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ErroredStub        func(lager.Logger, string, atc.ErrorCode)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorCode
 	}
 	FinishedStub        func(lager.Logger, exec.ExitStatus, exec.VersionInfo)
 	finishedMutex       sync.RWMutex
@@ -26,6 +53,47 @@ type FakePutDelegate struct {
 		arg2 exec.ExitStatus
 		arg3 exec.VersionInfo
 	}
+	ImageBytesStreamedStub        func(int64) error
+	imageBytesStreamedMutex       sync.RWMutex
+	imageBytesStreamedArgsForCall []struct {
+		arg1 int64
+	}
+	imageBytesStreamedReturns struct {
+		result1 error
+	}
+	imageBytesStreamedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageCheckStartedStub        func() error
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+	}
+	imageCheckStartedReturns struct {
+		result1 error
+	}
+	imageCheckStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchFinishedStub        func() error
+	imageFetchFinishedMutex       sync.RWMutex
+	imageFetchFinishedArgsForCall []struct {
+	}
+	imageFetchFinishedReturns struct {
+		result1 error
+	}
+	imageFetchFinishedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchStartedStub        func() error
+	imageFetchStartedMutex       sync.RWMutex
+	imageFetchStartedArgsForCall []struct {
+	}
+	imageFetchStartedReturns struct {
+		result1 error
+	}
+	imageFetchStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -51,10 +119,11 @@ type FakePutDelegate struct {
 		arg4 atc.VersionedResourceTypes
 		arg5 exec.VersionInfo
 	}
-	StartingStub        func(lager.Logger)
+	StartingStub        func(lager.Logger, string)
 	startingMutex       sync.RWMutex
 	startingArgsForCall []struct {
 		arg1 lager.Logger
+		arg2 string
 	}
 	StderrStub        func() io.Writer
 	stderrMutex       sync.RWMutex
@@ -90,16 +159,140 @@ type FakePutDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePutDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakePutDelegate) ArtifactChecksummed(arg1 string, arg2 string, arg3 int64) error {
+	fake.artifactChecksummedMutex.Lock()
+	ret, specificReturn := fake.artifactChecksummedReturnsOnCall[len(fake.artifactChecksummedArgsForCall)]
+	fake.artifactChecksummedArgsForCall = append(fake.artifactChecksummedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ArtifactChecksummed", []interface{}{arg1, arg2, arg3})
+	fake.artifactChecksummedMutex.Unlock()
+	if fake.ArtifactChecksummedStub != nil {
+		return fake.ArtifactChecksummedStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.artifactChecksummedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePutDelegate) ArtifactChecksummedCallCount() int {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	return len(fake.artifactChecksummedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ArtifactChecksummedCalls(stub func(string, string, int64) error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = stub
+}
+
+func (fake *FakePutDelegate) ArtifactChecksummedArgsForCall(i int) (string, string, int64) {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	argsForCall := fake.artifactChecksummedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePutDelegate) ArtifactChecksummedReturns(result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	fake.artifactChecksummedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ArtifactChecksummedReturnsOnCall(i int, result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	if fake.artifactChecksummedReturnsOnCall == nil {
+		fake.artifactChecksummedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.artifactChecksummedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ContainerPrepared(arg1 time.Duration, arg2 time.Duration) error {
+	fake.containerPreparedMutex.Lock()
+	ret, specificReturn := fake.containerPreparedReturnsOnCall[len(fake.containerPreparedArgsForCall)]
+	fake.containerPreparedArgsForCall = append(fake.containerPreparedArgsForCall, struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("ContainerPrepared", []interface{}{arg1, arg2})
+	fake.containerPreparedMutex.Unlock()
+	if fake.ContainerPreparedStub != nil {
+		return fake.ContainerPreparedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerPreparedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePutDelegate) ContainerPreparedCallCount() int {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	return len(fake.containerPreparedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ContainerPreparedCalls(stub func(time.Duration, time.Duration) error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = stub
+}
+
+func (fake *FakePutDelegate) ContainerPreparedArgsForCall(i int) (time.Duration, time.Duration) {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	argsForCall := fake.containerPreparedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePutDelegate) ContainerPreparedReturns(result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	fake.containerPreparedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ContainerPreparedReturnsOnCall(i int, result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	if fake.containerPreparedReturnsOnCall == nil {
+		fake.containerPreparedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.containerPreparedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorCode) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorCode
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -109,17 +302,17 @@ func (fake *FakePutDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakePutDelegate) ErroredCalls(stub func(lager.Logger, string)) {
+func (fake *FakePutDelegate) ErroredCalls(stub func(lager.Logger, string, atc.ErrorCode)) {
 	fake.erroredMutex.Lock()
 	defer fake.erroredMutex.Unlock()
 	fake.ErroredStub = stub
 }
 
-func (fake *FakePutDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakePutDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorCode) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	argsForCall := fake.erroredArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakePutDelegate) Finished(arg1 lager.Logger, arg2 exec.ExitStatus, arg3 exec.VersionInfo) {
@@ -155,6 +348,222 @@ func (fake *FakePutDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exit
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakePutDelegate) ImageBytesStreamed(arg1 int64) error {
+	fake.imageBytesStreamedMutex.Lock()
+	ret, specificReturn := fake.imageBytesStreamedReturnsOnCall[len(fake.imageBytesStreamedArgsForCall)]
+	fake.imageBytesStreamedArgsForCall = append(fake.imageBytesStreamedArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	fake.recordInvocation("ImageBytesStreamed", []interface{}{arg1})
+	fake.imageBytesStreamedMutex.Unlock()
+	if fake.ImageBytesStreamedStub != nil {
+		return fake.ImageBytesStreamedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageBytesStreamedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePutDelegate) ImageBytesStreamedCallCount() int {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	return len(fake.imageBytesStreamedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageBytesStreamedCalls(stub func(int64) error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = stub
+}
+
+func (fake *FakePutDelegate) ImageBytesStreamedArgsForCall(i int) int64 {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	argsForCall := fake.imageBytesStreamedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePutDelegate) ImageBytesStreamedReturns(result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	fake.imageBytesStreamedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageBytesStreamedReturnsOnCall(i int, result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	if fake.imageBytesStreamedReturnsOnCall == nil {
+		fake.imageBytesStreamedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageBytesStreamedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageCheckStarted() error {
+	fake.imageCheckStartedMutex.Lock()
+	ret, specificReturn := fake.imageCheckStartedReturnsOnCall[len(fake.imageCheckStartedArgsForCall)]
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		return fake.ImageCheckStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageCheckStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePutDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageCheckStartedCalls(stub func() error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = stub
+}
+
+func (fake *FakePutDelegate) ImageCheckStartedReturns(result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	fake.imageCheckStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageCheckStartedReturnsOnCall(i int, result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	if fake.imageCheckStartedReturnsOnCall == nil {
+		fake.imageCheckStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageCheckStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageFetchFinished() error {
+	fake.imageFetchFinishedMutex.Lock()
+	ret, specificReturn := fake.imageFetchFinishedReturnsOnCall[len(fake.imageFetchFinishedArgsForCall)]
+	fake.imageFetchFinishedArgsForCall = append(fake.imageFetchFinishedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchFinished", []interface{}{})
+	fake.imageFetchFinishedMutex.Unlock()
+	if fake.ImageFetchFinishedStub != nil {
+		return fake.ImageFetchFinishedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchFinishedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePutDelegate) ImageFetchFinishedCallCount() int {
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	return len(fake.imageFetchFinishedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageFetchFinishedCalls(stub func() error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = stub
+}
+
+func (fake *FakePutDelegate) ImageFetchFinishedReturns(result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	fake.imageFetchFinishedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageFetchFinishedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	if fake.imageFetchFinishedReturnsOnCall == nil {
+		fake.imageFetchFinishedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchFinishedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageFetchStarted() error {
+	fake.imageFetchStartedMutex.Lock()
+	ret, specificReturn := fake.imageFetchStartedReturnsOnCall[len(fake.imageFetchStartedArgsForCall)]
+	fake.imageFetchStartedArgsForCall = append(fake.imageFetchStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchStarted", []interface{}{})
+	fake.imageFetchStartedMutex.Unlock()
+	if fake.ImageFetchStartedStub != nil {
+		return fake.ImageFetchStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePutDelegate) ImageFetchStartedCallCount() int {
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
+	return len(fake.imageFetchStartedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageFetchStartedCalls(stub func() error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = stub
+}
+
+func (fake *FakePutDelegate) ImageFetchStartedReturns(result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	fake.imageFetchStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageFetchStartedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	if fake.imageFetchStartedReturnsOnCall == nil {
+		fake.imageFetchStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePutDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -281,15 +690,16 @@ func (fake *FakePutDelegate) SaveOutputArgsForCall(i int) (lager.Logger, atc.Put
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
-func (fake *FakePutDelegate) Starting(arg1 lager.Logger) {
+func (fake *FakePutDelegate) Starting(arg1 lager.Logger, arg2 string) {
 	fake.startingMutex.Lock()
 	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
 		arg1 lager.Logger
-	}{arg1})
-	fake.recordInvocation("Starting", []interface{}{arg1})
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("Starting", []interface{}{arg1, arg2})
 	fake.startingMutex.Unlock()
 	if fake.StartingStub != nil {
-		fake.StartingStub(arg1)
+		fake.StartingStub(arg1, arg2)
 	}
 }
 
@@ -299,17 +709,17 @@ func (fake *FakePutDelegate) StartingCallCount() int {
 	return len(fake.startingArgsForCall)
 }
 
-func (fake *FakePutDelegate) StartingCalls(stub func(lager.Logger)) {
+func (fake *FakePutDelegate) StartingCalls(stub func(lager.Logger, string)) {
 	fake.startingMutex.Lock()
 	defer fake.startingMutex.Unlock()
 	fake.StartingStub = stub
 }
 
-func (fake *FakePutDelegate) StartingArgsForCall(i int) lager.Logger {
+func (fake *FakePutDelegate) StartingArgsForCall(i int) (lager.Logger, string) {
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	argsForCall := fake.startingArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakePutDelegate) Stderr() io.Writer {
@@ -471,10 +881,22 @@ func (fake *FakePutDelegate) VariablesReturnsOnCall(i int, result1 vars.CredVars
 func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
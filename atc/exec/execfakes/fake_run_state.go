@@ -7,6 +7,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/vars"
 )
 
 type FakeRunState struct {
@@ -20,6 +21,16 @@ type FakeRunState struct {
 	artifactsReturnsOnCall map[int]struct {
 		result1 *artifact.Repository
 	}
+	LocalVariablesStub        func() vars.Variables
+	localVariablesMutex       sync.RWMutex
+	localVariablesArgsForCall []struct {
+	}
+	localVariablesReturns struct {
+		result1 vars.Variables
+	}
+	localVariablesReturnsOnCall map[int]struct {
+		result1 vars.Variables
+	}
 	ResultStub        func(atc.PlanID, interface{}) bool
 	resultMutex       sync.RWMutex
 	resultArgsForCall []struct {
@@ -32,6 +43,12 @@ type FakeRunState struct {
 	resultReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	StoreLocalVarStub        func(string, interface{})
+	storeLocalVarMutex       sync.RWMutex
+	storeLocalVarArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+	}
 	StoreResultStub        func(atc.PlanID, interface{})
 	storeResultMutex       sync.RWMutex
 	storeResultArgsForCall []struct {
@@ -94,6 +111,58 @@ func (fake *FakeRunState) ArtifactsReturnsOnCall(i int, result1 *artifact.Reposi
 	}{result1}
 }
 
+func (fake *FakeRunState) LocalVariables() vars.Variables {
+	fake.localVariablesMutex.Lock()
+	ret, specificReturn := fake.localVariablesReturnsOnCall[len(fake.localVariablesArgsForCall)]
+	fake.localVariablesArgsForCall = append(fake.localVariablesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LocalVariables", []interface{}{})
+	fake.localVariablesMutex.Unlock()
+	if fake.LocalVariablesStub != nil {
+		return fake.LocalVariablesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.localVariablesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) LocalVariablesCallCount() int {
+	fake.localVariablesMutex.RLock()
+	defer fake.localVariablesMutex.RUnlock()
+	return len(fake.localVariablesArgsForCall)
+}
+
+func (fake *FakeRunState) LocalVariablesCalls(stub func() vars.Variables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = stub
+}
+
+func (fake *FakeRunState) LocalVariablesReturns(result1 vars.Variables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = nil
+	fake.localVariablesReturns = struct {
+		result1 vars.Variables
+	}{result1}
+}
+
+func (fake *FakeRunState) LocalVariablesReturnsOnCall(i int, result1 vars.Variables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = nil
+	if fake.localVariablesReturnsOnCall == nil {
+		fake.localVariablesReturnsOnCall = make(map[int]struct {
+			result1 vars.Variables
+		})
+	}
+	fake.localVariablesReturnsOnCall[i] = struct {
+		result1 vars.Variables
+	}{result1}
+}
+
 func (fake *FakeRunState) Result(arg1 atc.PlanID, arg2 interface{}) bool {
 	fake.resultMutex.Lock()
 	ret, specificReturn := fake.resultReturnsOnCall[len(fake.resultArgsForCall)]
@@ -155,6 +224,38 @@ func (fake *FakeRunState) ResultReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeRunState) StoreLocalVar(arg1 string, arg2 interface{}) {
+	fake.storeLocalVarMutex.Lock()
+	fake.storeLocalVarArgsForCall = append(fake.storeLocalVarArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+	}{arg1, arg2})
+	fake.recordInvocation("StoreLocalVar", []interface{}{arg1, arg2})
+	fake.storeLocalVarMutex.Unlock()
+	if fake.StoreLocalVarStub != nil {
+		fake.StoreLocalVarStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRunState) StoreLocalVarCallCount() int {
+	fake.storeLocalVarMutex.RLock()
+	defer fake.storeLocalVarMutex.RUnlock()
+	return len(fake.storeLocalVarArgsForCall)
+}
+
+func (fake *FakeRunState) StoreLocalVarCalls(stub func(string, interface{})) {
+	fake.storeLocalVarMutex.Lock()
+	defer fake.storeLocalVarMutex.Unlock()
+	fake.StoreLocalVarStub = stub
+}
+
+func (fake *FakeRunState) StoreLocalVarArgsForCall(i int) (string, interface{}) {
+	fake.storeLocalVarMutex.RLock()
+	defer fake.storeLocalVarMutex.RUnlock()
+	argsForCall := fake.storeLocalVarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeRunState) StoreResult(arg1 atc.PlanID, arg2 interface{}) {
 	fake.storeResultMutex.Lock()
 	fake.storeResultArgsForCall = append(fake.storeResultArgsForCall, struct {
@@ -192,8 +293,12 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.localVariablesMutex.RLock()
+	defer fake.localVariablesMutex.RUnlock()
 	fake.resultMutex.RLock()
 	defer fake.resultMutex.RUnlock()
+	fake.storeLocalVarMutex.RLock()
+	defer fake.storeLocalVarMutex.RUnlock()
 	fake.storeResultMutex.RLock()
 	defer fake.storeResultMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -4,6 +4,7 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -13,11 +14,37 @@ import (
 )
 
 type FakeTaskDelegate struct {
-	ErroredStub        func(lager.Logger, string)
+	ArtifactChecksummedStub        func(string, string, int64) error
+	artifactChecksummedMutex       sync.RWMutex
+	artifactChecksummedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	artifactChecksummedReturns struct {
+		result1 error
+	}
+	artifactChecksummedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerPreparedStub        func(time.Duration, time.Duration) error
+	containerPreparedMutex       sync.RWMutex
+	containerPreparedArgsForCall []struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}
+	containerPreparedReturns struct {
+		result1 error
+	}
+	containerPreparedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ErroredStub        func(lager.Logger, string, atc.ErrorCode)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorCode
 	}
 	FinishedStub        func(lager.Logger, exec.ExitStatus)
 	finishedMutex       sync.RWMutex
@@ -25,6 +52,47 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 exec.ExitStatus
 	}
+	ImageBytesStreamedStub        func(int64) error
+	imageBytesStreamedMutex       sync.RWMutex
+	imageBytesStreamedArgsForCall []struct {
+		arg1 int64
+	}
+	imageBytesStreamedReturns struct {
+		result1 error
+	}
+	imageBytesStreamedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageCheckStartedStub        func() error
+	imageCheckStartedMutex       sync.RWMutex
+	imageCheckStartedArgsForCall []struct {
+	}
+	imageCheckStartedReturns struct {
+		result1 error
+	}
+	imageCheckStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchFinishedStub        func() error
+	imageFetchFinishedMutex       sync.RWMutex
+	imageFetchFinishedArgsForCall []struct {
+	}
+	imageFetchFinishedReturns struct {
+		result1 error
+	}
+	imageFetchFinishedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImageFetchStartedStub        func() error
+	imageFetchStartedMutex       sync.RWMutex
+	imageFetchStartedArgsForCall []struct {
+	}
+	imageFetchStartedReturns struct {
+		result1 error
+	}
+	imageFetchStartedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -42,11 +110,12 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
 	}
-	StartingStub        func(lager.Logger, atc.TaskConfig)
+	StartingStub        func(lager.Logger, atc.TaskConfig, string)
 	startingMutex       sync.RWMutex
 	startingArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
+		arg3 string
 	}
 	StderrStub        func() io.Writer
 	stderrMutex       sync.RWMutex
@@ -82,16 +151,140 @@ type FakeTaskDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeTaskDelegate) ArtifactChecksummed(arg1 string, arg2 string, arg3 int64) error {
+	fake.artifactChecksummedMutex.Lock()
+	ret, specificReturn := fake.artifactChecksummedReturnsOnCall[len(fake.artifactChecksummedArgsForCall)]
+	fake.artifactChecksummedArgsForCall = append(fake.artifactChecksummedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ArtifactChecksummed", []interface{}{arg1, arg2, arg3})
+	fake.artifactChecksummedMutex.Unlock()
+	if fake.ArtifactChecksummedStub != nil {
+		return fake.ArtifactChecksummedStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.artifactChecksummedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ArtifactChecksummedCallCount() int {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	return len(fake.artifactChecksummedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ArtifactChecksummedCalls(stub func(string, string, int64) error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ArtifactChecksummedArgsForCall(i int) (string, string, int64) {
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	argsForCall := fake.artifactChecksummedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDelegate) ArtifactChecksummedReturns(result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	fake.artifactChecksummedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ArtifactChecksummedReturnsOnCall(i int, result1 error) {
+	fake.artifactChecksummedMutex.Lock()
+	defer fake.artifactChecksummedMutex.Unlock()
+	fake.ArtifactChecksummedStub = nil
+	if fake.artifactChecksummedReturnsOnCall == nil {
+		fake.artifactChecksummedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.artifactChecksummedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ContainerPrepared(arg1 time.Duration, arg2 time.Duration) error {
+	fake.containerPreparedMutex.Lock()
+	ret, specificReturn := fake.containerPreparedReturnsOnCall[len(fake.containerPreparedArgsForCall)]
+	fake.containerPreparedArgsForCall = append(fake.containerPreparedArgsForCall, struct {
+		arg1 time.Duration
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("ContainerPrepared", []interface{}{arg1, arg2})
+	fake.containerPreparedMutex.Unlock()
+	if fake.ContainerPreparedStub != nil {
+		return fake.ContainerPreparedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.containerPreparedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ContainerPreparedCallCount() int {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	return len(fake.containerPreparedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ContainerPreparedCalls(stub func(time.Duration, time.Duration) error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ContainerPreparedArgsForCall(i int) (time.Duration, time.Duration) {
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
+	argsForCall := fake.containerPreparedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskDelegate) ContainerPreparedReturns(result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	fake.containerPreparedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ContainerPreparedReturnsOnCall(i int, result1 error) {
+	fake.containerPreparedMutex.Lock()
+	defer fake.containerPreparedMutex.Unlock()
+	fake.ContainerPreparedStub = nil
+	if fake.containerPreparedReturnsOnCall == nil {
+		fake.containerPreparedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.containerPreparedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorCode) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorCode
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -101,17 +294,17 @@ func (fake *FakeTaskDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeTaskDelegate) ErroredCalls(stub func(lager.Logger, string)) {
+func (fake *FakeTaskDelegate) ErroredCalls(stub func(lager.Logger, string, atc.ErrorCode)) {
 	fake.erroredMutex.Lock()
 	defer fake.erroredMutex.Unlock()
 	fake.ErroredStub = stub
 }
 
-func (fake *FakeTaskDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeTaskDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorCode) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	argsForCall := fake.erroredArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeTaskDelegate) Finished(arg1 lager.Logger, arg2 exec.ExitStatus) {
@@ -146,6 +339,222 @@ func (fake *FakeTaskDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exi
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTaskDelegate) ImageBytesStreamed(arg1 int64) error {
+	fake.imageBytesStreamedMutex.Lock()
+	ret, specificReturn := fake.imageBytesStreamedReturnsOnCall[len(fake.imageBytesStreamedArgsForCall)]
+	fake.imageBytesStreamedArgsForCall = append(fake.imageBytesStreamedArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	fake.recordInvocation("ImageBytesStreamed", []interface{}{arg1})
+	fake.imageBytesStreamedMutex.Unlock()
+	if fake.ImageBytesStreamedStub != nil {
+		return fake.ImageBytesStreamedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageBytesStreamedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ImageBytesStreamedCallCount() int {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	return len(fake.imageBytesStreamedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageBytesStreamedCalls(stub func(int64) error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ImageBytesStreamedArgsForCall(i int) int64 {
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	argsForCall := fake.imageBytesStreamedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskDelegate) ImageBytesStreamedReturns(result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	fake.imageBytesStreamedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ImageBytesStreamedReturnsOnCall(i int, result1 error) {
+	fake.imageBytesStreamedMutex.Lock()
+	defer fake.imageBytesStreamedMutex.Unlock()
+	fake.ImageBytesStreamedStub = nil
+	if fake.imageBytesStreamedReturnsOnCall == nil {
+		fake.imageBytesStreamedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageBytesStreamedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStarted() error {
+	fake.imageCheckStartedMutex.Lock()
+	ret, specificReturn := fake.imageCheckStartedReturnsOnCall[len(fake.imageCheckStartedArgsForCall)]
+	fake.imageCheckStartedArgsForCall = append(fake.imageCheckStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageCheckStarted", []interface{}{})
+	fake.imageCheckStartedMutex.Unlock()
+	if fake.ImageCheckStartedStub != nil {
+		return fake.ImageCheckStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageCheckStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStartedCallCount() int {
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	return len(fake.imageCheckStartedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStartedCalls(stub func() error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStartedReturns(result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	fake.imageCheckStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ImageCheckStartedReturnsOnCall(i int, result1 error) {
+	fake.imageCheckStartedMutex.Lock()
+	defer fake.imageCheckStartedMutex.Unlock()
+	fake.ImageCheckStartedStub = nil
+	if fake.imageCheckStartedReturnsOnCall == nil {
+		fake.imageCheckStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageCheckStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchFinished() error {
+	fake.imageFetchFinishedMutex.Lock()
+	ret, specificReturn := fake.imageFetchFinishedReturnsOnCall[len(fake.imageFetchFinishedArgsForCall)]
+	fake.imageFetchFinishedArgsForCall = append(fake.imageFetchFinishedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchFinished", []interface{}{})
+	fake.imageFetchFinishedMutex.Unlock()
+	if fake.ImageFetchFinishedStub != nil {
+		return fake.ImageFetchFinishedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchFinishedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ImageFetchFinishedCallCount() int {
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	return len(fake.imageFetchFinishedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageFetchFinishedCalls(stub func() error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ImageFetchFinishedReturns(result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	fake.imageFetchFinishedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchFinishedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchFinishedMutex.Lock()
+	defer fake.imageFetchFinishedMutex.Unlock()
+	fake.ImageFetchFinishedStub = nil
+	if fake.imageFetchFinishedReturnsOnCall == nil {
+		fake.imageFetchFinishedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchFinishedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchStarted() error {
+	fake.imageFetchStartedMutex.Lock()
+	ret, specificReturn := fake.imageFetchStartedReturnsOnCall[len(fake.imageFetchStartedArgsForCall)]
+	fake.imageFetchStartedArgsForCall = append(fake.imageFetchStartedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ImageFetchStarted", []interface{}{})
+	fake.imageFetchStartedMutex.Unlock()
+	if fake.ImageFetchStartedStub != nil {
+		return fake.ImageFetchStartedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.imageFetchStartedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ImageFetchStartedCallCount() int {
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
+	return len(fake.imageFetchStartedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageFetchStartedCalls(stub func() error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ImageFetchStartedReturns(result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	fake.imageFetchStartedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchStartedReturnsOnCall(i int, result1 error) {
+	fake.imageFetchStartedMutex.Lock()
+	defer fake.imageFetchStartedMutex.Unlock()
+	fake.ImageFetchStartedStub = nil
+	if fake.imageFetchStartedReturnsOnCall == nil {
+		fake.imageFetchStartedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.imageFetchStartedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -238,16 +647,17 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) (lager.Logger, atc.
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeTaskDelegate) Starting(arg1 lager.Logger, arg2 atc.TaskConfig) {
+func (fake *FakeTaskDelegate) Starting(arg1 lager.Logger, arg2 atc.TaskConfig, arg3 string) {
 	fake.startingMutex.Lock()
 	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
-	}{arg1, arg2})
-	fake.recordInvocation("Starting", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Starting", []interface{}{arg1, arg2, arg3})
 	fake.startingMutex.Unlock()
 	if fake.StartingStub != nil {
-		fake.StartingStub(arg1, arg2)
+		fake.StartingStub(arg1, arg2, arg3)
 	}
 }
 
@@ -257,17 +667,17 @@ func (fake *FakeTaskDelegate) StartingCallCount() int {
 	return len(fake.startingArgsForCall)
 }
 
-func (fake *FakeTaskDelegate) StartingCalls(stub func(lager.Logger, atc.TaskConfig)) {
+func (fake *FakeTaskDelegate) StartingCalls(stub func(lager.Logger, atc.TaskConfig, string)) {
 	fake.startingMutex.Lock()
 	defer fake.startingMutex.Unlock()
 	fake.StartingStub = stub
 }
 
-func (fake *FakeTaskDelegate) StartingArgsForCall(i int) (lager.Logger, atc.TaskConfig) {
+func (fake *FakeTaskDelegate) StartingArgsForCall(i int) (lager.Logger, atc.TaskConfig, string) {
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	argsForCall := fake.startingArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeTaskDelegate) Stderr() io.Writer {
@@ -429,10 +839,22 @@ func (fake *FakeTaskDelegate) VariablesReturnsOnCall(i int, result1 vars.CredVar
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactChecksummedMutex.RLock()
+	defer fake.artifactChecksummedMutex.RUnlock()
+	fake.containerPreparedMutex.RLock()
+	defer fake.containerPreparedMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.imageBytesStreamedMutex.RLock()
+	defer fake.imageBytesStreamedMutex.RUnlock()
+	fake.imageCheckStartedMutex.RLock()
+	defer fake.imageCheckStartedMutex.RUnlock()
+	fake.imageFetchFinishedMutex.RLock()
+	defer fake.imageFetchFinishedMutex.RUnlock()
+	fake.imageFetchStartedMutex.RLock()
+	defer fake.imageFetchStartedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
@@ -0,0 +1,160 @@
+package exec
+
+import (
+	"context"
+	"sync"
+)
+
+// FanIn lets the step after a parallel step (an in_parallel or aggregate)
+// start as soon as the branches producing the artifacts it needs have
+// succeeded, rather than once every branch has finished. The branches it
+// needs are wrapped with Needed as the parallel step is built, and the two
+// steps are then joined with Then.
+type FanIn struct {
+	needed int
+}
+
+// NewFanIn constructs a FanIn which doesn't need any branches yet.
+func NewFanIn() *FanIn {
+	return &FanIn{}
+}
+
+// Needed wraps a branch of the parallel step which the next step needs.
+func (fanIn *FanIn) Needed(step Step) Step {
+	fanIn.needed++
+
+	return fanInBranch{
+		fanIn: fanIn,
+		step:  step,
+	}
+}
+
+// Then constructs a FanInStep which runs the parallel step and then the next
+// step.
+func (fanIn *FanIn) Then(parallel Step, next Step) Step {
+	return FanInStep{
+		fanIn:    fanIn,
+		parallel: parallel,
+		next:     next,
+	}
+}
+
+// fanInRun tracks the needed branches which have yet to succeed during a run
+// of a FanInStep.
+type fanInRun struct {
+	lock      sync.Mutex
+	remaining int
+	ready     chan struct{}
+}
+
+func (run *fanInRun) succeeded() {
+	run.lock.Lock()
+	defer run.lock.Unlock()
+
+	run.remaining--
+	if run.remaining == 0 {
+		close(run.ready)
+	}
+}
+
+type fanInBranch struct {
+	fanIn *FanIn
+	step  Step
+}
+
+func (branch fanInBranch) Run(ctx context.Context, state RunState) error {
+	err := branch.step.Run(ctx, state)
+	if err == nil && branch.step.Succeeded() {
+		if run, ok := ctx.Value(branch.fanIn).(*fanInRun); ok {
+			run.succeeded()
+		}
+	}
+
+	return err
+}
+
+func (branch fanInBranch) Succeeded() bool {
+	return branch.step.Succeeded()
+}
+
+// FanInStep runs a parallel step and then the step after it, starting the
+// step after it early once the branches it needs have succeeded.
+type FanInStep struct {
+	fanIn    *FanIn
+	parallel Step
+	next     Step
+}
+
+// Run runs the parallel step. Once the needed branches have succeeded, the
+// next step is run alongside the rest of the branches. If any of them fail,
+// the next step is canceled, as it wouldn't have run at all otherwise.
+//
+// If the parallel step finishes before the needed branches have all
+// succeeded, the next step is run afterwards only if the parallel step
+// succeeded, as with an OnSuccessStep.
+func (step FanInStep) Run(ctx context.Context, state RunState) error {
+	run := &fanInRun{
+		remaining: step.fanIn.needed,
+		ready:     make(chan struct{}),
+	}
+
+	if run.remaining == 0 {
+		close(run.ready)
+	}
+
+	parallelDone := make(chan error, 1)
+	go func() {
+		parallelDone <- step.parallel.Run(context.WithValue(ctx, step.fanIn, run), state)
+	}()
+
+	select {
+	case <-run.ready:
+		return step.runEarly(ctx, state, parallelDone)
+
+	case err := <-parallelDone:
+		if err != nil {
+			return err
+		}
+
+		if !step.parallel.Succeeded() {
+			return nil
+		}
+
+		err = waitIfPaused(ctx)
+		if err != nil {
+			return err
+		}
+
+		return step.next.Run(ctx, state)
+	}
+}
+
+func (step FanInStep) runEarly(ctx context.Context, state RunState, parallelDone <-chan error) error {
+	err := waitIfPaused(ctx)
+	if err != nil {
+		<-parallelDone
+		return err
+	}
+
+	nextCtx, cancelNext := context.WithCancel(ctx)
+	defer cancelNext()
+
+	nextDone := make(chan error, 1)
+	go func() {
+		nextDone <- step.next.Run(nextCtx, state)
+	}()
+
+	parallelErr := <-parallelDone
+	if parallelErr != nil || !step.parallel.Succeeded() {
+		cancelNext()
+		<-nextDone
+		return parallelErr
+	}
+
+	return <-nextDone
+}
+
+// Succeeded is true if the parallel step and the next step both succeeded.
+func (step FanInStep) Succeeded() bool {
+	return step.parallel.Succeeded() && step.next.Succeeded()
+}
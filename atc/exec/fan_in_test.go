@@ -0,0 +1,172 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FanIn", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		neededStep   *execfakes.FakeStep
+		unneededStep *execfakes.FakeStep
+		nextStep     *execfakes.FakeStep
+
+		releaseUnneeded chan struct{}
+		nextStarted     chan struct{}
+
+		repo  *artifact.Repository
+		state *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		neededStep = new(execfakes.FakeStep)
+		neededStep.SucceededReturns(true)
+
+		releaseUnneeded = make(chan struct{})
+		unneededStep = new(execfakes.FakeStep)
+		unneededStep.SucceededReturns(true)
+		unneededStep.RunStub = func(context.Context, RunState) error {
+			<-releaseUnneeded
+			return nil
+		}
+
+		nextStarted = make(chan struct{})
+		nextStep = new(execfakes.FakeStep)
+		nextStep.SucceededReturns(true)
+		nextStep.RunStub = func(context.Context, RunState) error {
+			close(nextStarted)
+			return nil
+		}
+
+		repo = artifact.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		fanIn := NewFanIn()
+		parallel := InParallel([]Step{fanIn.Needed(neededStep), unneededStep}, 0, false)
+		step = fanIn.Then(parallel, nextStep)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the needed branches succeed before the others finish", func() {
+		BeforeEach(func() {
+			nextStep.RunStub = func(context.Context, RunState) error {
+				close(releaseUnneeded)
+				return nil
+			}
+		})
+
+		It("runs the next step before the parallel step finishes", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(nextStep.RunCallCount()).To(Equal(1))
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		Context("when another branch then fails", func() {
+			BeforeEach(func() {
+				unneededStep.SucceededReturns(false)
+				nextStep.RunStub = func(ctx context.Context, state RunState) error {
+					close(releaseUnneeded)
+					<-ctx.Done()
+					return ctx.Err()
+				}
+			})
+
+			It("cancels the next step", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(nextStep.RunCallCount()).To(Equal(1))
+			})
+
+			It("fails", func() {
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when another branch then errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				unneededStep.RunStub = func(context.Context, RunState) error {
+					<-releaseUnneeded
+					return disaster
+				}
+
+				nextStep.RunStub = func(ctx context.Context, state RunState) error {
+					close(releaseUnneeded)
+					<-ctx.Done()
+					return ctx.Err()
+				}
+			})
+
+			It("returns the parallel step's error", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("nope")))
+			})
+		})
+	})
+
+	Context("when a needed branch fails", func() {
+		BeforeEach(func() {
+			neededStep.SucceededReturns(false)
+			close(releaseUnneeded)
+		})
+
+		It("does not run the next step", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(nextStep.RunCallCount()).To(BeZero())
+		})
+
+		It("fails", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when a needed branch errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			neededStep.RunReturns(disaster)
+			close(releaseUnneeded)
+		})
+
+		It("returns the error without running the next step", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("nope")))
+			Expect(nextStep.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when canceled", func() {
+		BeforeEach(func() {
+			neededStep.RunStub = func(ctx context.Context, state RunState) error {
+				cancel()
+				close(releaseUnneeded)
+				return ctx.Err()
+			}
+		})
+
+		It("returns ctx.Err()", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(nextStep.RunCallCount()).To(BeZero())
+		})
+	})
+})
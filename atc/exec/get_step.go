@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 
@@ -12,6 +13,7 @@ import (
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/DataDog/zstd"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/contentscan"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/artifact"
@@ -42,9 +44,13 @@ type GetDelegate interface {
 	BuildStepDelegate
 
 	Initializing(lager.Logger)
-	Starting(lager.Logger)
+	Starting(logger lager.Logger, workerName string, cached bool)
 	Finished(lager.Logger, ExitStatus, VersionInfo)
 	UpdateVersion(lager.Logger, atc.GetPlan, VersionInfo)
+
+	// Fetched is called after the resource was fetched, i.e. the version
+	// wasn't already cached on the worker, with how long the fetch took.
+	Fetched(logger lager.Logger, plan atc.GetPlan, duration time.Duration, succeeded bool)
 }
 
 // GetStep will fetch a version of a resource on a worker that supports the
@@ -58,6 +64,7 @@ type GetStep struct {
 	resourceCacheFactory db.ResourceCacheFactory
 	strategy             worker.ContainerPlacementStrategy
 	workerPool           worker.Pool
+	contentScanner       contentscan.Scanner
 	delegate             GetDelegate
 	succeeded            bool
 }
@@ -71,6 +78,7 @@ func NewGetStep(
 	resourceCacheFactory db.ResourceCacheFactory,
 	strategy worker.ContainerPlacementStrategy,
 	workerPool worker.Pool,
+	contentScanner contentscan.Scanner,
 	delegate GetDelegate,
 ) Step {
 	return &GetStep{
@@ -82,6 +90,7 @@ func NewGetStep(
 		resourceCacheFactory: resourceCacheFactory,
 		strategy:             strategy,
 		workerPool:           workerPool,
+		contentScanner:       contentScanner,
 		delegate:             delegate,
 	}
 }
@@ -107,6 +116,9 @@ func NewGetStep(
 // If the worker has a VolumeManager but did not have the cache initially, the
 // fetched ArtifactSource is initialized, thus warming the worker's cache.
 //
+// If a content scanner is configured, the resulting ArtifactSource is passed
+// through it, which may error the step depending on the team's policy.
+//
 // At the end, the resulting ArtifactSource (either from using the cache or
 // fetching the resource) is registered under the step's SourceName.
 func (step *GetStep) Run(ctx context.Context, state RunState) error {
@@ -144,14 +156,18 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		ImageSpec: worker.ImageSpec{
 			ResourceType: step.plan.Type,
 		},
-		TeamID: step.metadata.TeamID,
-		Env:    step.metadata.Env(),
+		TeamID:  step.metadata.TeamID,
+		Env:     step.metadata.Env(),
+		CACerts: step.metadata.CACerts,
+		DNS:     step.metadata.ContainerDNS,
 	}
 
 	workerSpec := worker.WorkerSpec{
 		ResourceType:  step.plan.Type,
 		Tags:          step.plan.Tags,
+		Labels:        step.plan.Labels,
 		TeamID:        step.metadata.TeamID,
+		WorkerName:    step.plan.Worker,
 		ResourceTypes: resourceTypes,
 	}
 
@@ -190,7 +206,14 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
-	step.delegate.Starting(logger)
+	_, cached, err := resourceInstance.FindOn(logger, chosenWorker)
+	if err != nil {
+		return err
+	}
+
+	step.delegate.Starting(logger, chosenWorker.Name(), cached)
+
+	fetchStart := time.Now()
 
 	versionedSource, err := step.resourceFetcher.Fetch(
 		ctx,
@@ -202,6 +225,12 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		resourceInstance,
 		step.delegate,
 	)
+
+	// aborting the build isn't the resource type's fault
+	if !cached && err != context.Canceled {
+		step.delegate.Fetched(logger, step.plan, time.Since(fetchStart), err == nil)
+	}
+
 	if err != nil {
 		logger.Error("failed-to-fetch-resource", err)
 
@@ -213,10 +242,24 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
-	state.Artifacts().RegisterSource(artifact.Name(step.plan.Name), &getArtifactSource{
+	artifactSource := &getArtifactSource{
+		planID:           step.planID,
 		resourceInstance: resourceInstance,
 		versionedSource:  versionedSource,
-	})
+	}
+
+	if step.contentScanner != nil {
+		err = scanContent(ctx, logger, step.contentScanner, step.metadata, "get", step.plan.Name, artifactSource, step.delegate.Stderr())
+		if err != nil {
+			return err
+		}
+	}
+
+	state.Artifacts().RegisterSource(artifact.Name(step.plan.Name), artifactSource)
+
+	if step.metadata.ChecksumArtifacts {
+		checksumArtifact(ctx, logger, step.plan.Name, artifactSource, step.delegate)
+	}
 
 	versionInfo := VersionInfo{
 		Version:  versionedSource.Version(),
@@ -240,6 +283,7 @@ func (step *GetStep) Succeeded() bool {
 }
 
 type getArtifactSource struct {
+	planID           atc.PlanID
 	resourceInstance resource.ResourceInstance
 	versionedSource  resource.VersionedSource
 }
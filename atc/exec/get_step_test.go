@@ -2,6 +2,7 @@ package exec_test
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"errors"
@@ -16,6 +17,8 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/DataDog/zstd"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/contentscan"
+	"github.com/concourse/concourse/atc/contentscan/contentscanfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
@@ -41,6 +44,8 @@ var _ = Describe("GetStep", func() {
 		fakeResourceFetcher      *fetcherfakes.FakeFetcher
 		fakeResourceCacheFactory *dbfakes.FakeResourceCacheFactory
 		fakeDelegate             *execfakes.FakeGetDelegate
+		fakeContentScanner       *contentscanfakes.FakeScanner
+		contentScanner           contentscan.Scanner
 		getPlan                  *atc.GetPlan
 
 		fakeVersionedSource       *resourcefakes.FakeVersionedSource
@@ -80,6 +85,7 @@ var _ = Describe("GetStep", func() {
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeResourceFetcher = new(fetcherfakes.FakeFetcher)
 		fakePool = new(workerfakes.FakePool)
+		fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 
@@ -96,6 +102,9 @@ var _ = Describe("GetStep", func() {
 		fakeDelegate = new(execfakes.FakeGetDelegate)
 		fakeDelegate.VariablesReturns(credVarsTracker)
 
+		fakeContentScanner = new(contentscanfakes.FakeScanner)
+		contentScanner = nil
+
 		uninterpolatedResourceTypes := atc.VersionedResourceTypes{
 			{
 				ResourceType: atc.ResourceType{
@@ -148,6 +157,7 @@ var _ = Describe("GetStep", func() {
 			fakeResourceCacheFactory,
 			fakeStrategy,
 			fakePool,
+			contentScanner,
 			fakeDelegate,
 		)
 
@@ -220,6 +230,49 @@ var _ = Describe("GetStep", func() {
 			Expect(resourceInstance.LockName("fake-worker")).To(Equal(expectedLockName))
 		})
 
+		It("starts the step on the chosen worker", func() {
+			Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+			_, workerName, cached := fakeDelegate.StartingArgsForCall(0)
+			Expect(workerName).To(Equal("some-worker"))
+			Expect(cached).To(BeFalse())
+		})
+
+		It("records the fetch via the delegate", func() {
+			Expect(fakeDelegate.FetchedCallCount()).To(Equal(1))
+			_, plan, _, succeeded := fakeDelegate.FetchedArgsForCall(0)
+			Expect(plan.Type).To(Equal("some-resource-type"))
+			Expect(succeeded).To(BeTrue())
+		})
+
+		Context("when the version is already cached on the worker", func() {
+			BeforeEach(func() {
+				fakeWorker.FindVolumeForResourceCacheReturns(new(workerfakes.FakeVolume), true, nil)
+			})
+
+			It("starts the step using the cache", func() {
+				Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+				_, _, cached := fakeDelegate.StartingArgsForCall(0)
+				Expect(cached).To(BeTrue())
+			})
+
+			It("does not record a fetch", func() {
+				Expect(fakeDelegate.FetchedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when looking for the cache on the worker fails", func() {
+			disaster := errors.New("oh no")
+
+			BeforeEach(func() {
+				fakeWorker.FindVolumeForResourceCacheReturns(nil, false, disaster)
+			})
+
+			It("returns the error without fetching", func() {
+				Expect(stepErr).To(Equal(disaster))
+				Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+			})
+		})
+
 		It("secrets are tracked", func() {
 			mapit := vars.NewMapCredVarsTrackerIterator()
 			credVarsTracker.IterateInterpolatedCreds(mapit)
@@ -273,6 +326,142 @@ var _ = Describe("GetStep", func() {
 				})
 			})
 
+			Context("when artifacts are checksummed", func() {
+				BeforeEach(func() {
+					stepMetadata.ChecksumArtifacts = true
+
+					compressed := new(bytes.Buffer)
+					zstdWriter := zstd.NewWriter(compressed)
+					_, err := zstdWriter.Write([]byte("some-content"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(zstdWriter.Close()).To(Succeed())
+
+					fakeVersionedSource.StreamOutReturns(ioutil.NopCloser(compressed), nil)
+				})
+
+				AfterEach(func() {
+					stepMetadata.ChecksumArtifacts = false
+				})
+
+				It("saves the checksum and size of the uncompressed content", func() {
+					Expect(fakeDelegate.ArtifactChecksummedCallCount()).To(Equal(1))
+					name, checksum, size := fakeDelegate.ArtifactChecksummedArgsForCall(0)
+					Expect(name).To(Equal("some-name"))
+					Expect(checksum).To(Equal("sha256:0a8cac771ca188eacc57e2c96c31f5611925c5ecedccb16b8c236d6c0d325112"))
+					Expect(size).To(Equal(int64(len("some-content"))))
+				})
+
+				Context("when the content can't be streamed", func() {
+					var stderrBuf *gbytes.Buffer
+
+					BeforeEach(func() {
+						stderrBuf = gbytes.NewBuffer()
+						fakeDelegate.StderrReturns(stderrBuf)
+
+						fakeVersionedSource.StreamOutReturns(nil, errors.New("nope"))
+					})
+
+					It("warns and carries on", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(getStep.Succeeded()).To(BeTrue())
+						Expect(stderrBuf).To(gbytes.Say(`failed to checksum 'some-name': nope`))
+						Expect(fakeDelegate.ArtifactChecksummedCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when a content scanner is configured", func() {
+				var stderrBuf *gbytes.Buffer
+
+				BeforeEach(func() {
+					contentScanner = fakeContentScanner
+
+					stderrBuf = gbytes.NewBuffer()
+					fakeDelegate.StderrReturns(stderrBuf)
+
+					fakeVersionedSource.StreamOutReturns(gbytes.NewBuffer(), nil)
+				})
+
+				It("scans the fetched content", func() {
+					Expect(fakeContentScanner.ScanCallCount()).To(Equal(1))
+					_, subject, _ := fakeContentScanner.ScanArgsForCall(0)
+					Expect(subject).To(Equal(contentscan.Subject{
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						BuildID:      42,
+						BuildName:    "some-build",
+						Step:         "get",
+						Artifact:     "some-name",
+					}))
+				})
+
+				Context("when the scan has findings", func() {
+					BeforeEach(func() {
+						fakeContentScanner.ScanReturns(contentscan.Result{Findings: []string{"aws-access-key in creds.txt"}}, nil)
+					})
+
+					It("writes the findings to stderr", func() {
+						Expect(stderrBuf).To(gbytes.Say(`\[CONTENT SCAN\] some-name: aws-access-key in creds.txt`))
+					})
+
+					It("is successful", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(getStep.Succeeded()).To(BeTrue())
+					})
+
+					Context("when the policy is to block", func() {
+						BeforeEach(func() {
+							stepMetadata.ContentScanPolicy = atc.ContentScanPolicyBlock
+						})
+
+						AfterEach(func() {
+							stepMetadata.ContentScanPolicy = ""
+						})
+
+						It("returns the findings", func() {
+							Expect(stepErr).To(Equal(exec.ContentBlockedError{
+								Artifact: "some-name",
+								Findings: []string{"aws-access-key in creds.txt"},
+							}))
+						})
+
+						It("does not register the artifact", func() {
+							_, found := artifactRepository.SourceFor("some-name")
+							Expect(found).To(BeFalse())
+						})
+
+						It("does not finish the step via the delegate", func() {
+							Expect(fakeDelegate.FinishedCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when scanning fails", func() {
+					BeforeEach(func() {
+						fakeContentScanner.ScanReturns(contentscan.Result{}, errors.New("scanner down"))
+					})
+
+					It("warns and carries on", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stderrBuf).To(gbytes.Say(`failed to scan content of 'some-name': scanner down`))
+					})
+
+					Context("when the policy is to block", func() {
+						BeforeEach(func() {
+							stepMetadata.ContentScanPolicy = atc.ContentScanPolicyBlock
+						})
+
+						AfterEach(func() {
+							stepMetadata.ContentScanPolicy = ""
+						})
+
+						It("returns the error", func() {
+							Expect(stepErr).To(MatchError("scanner down"))
+						})
+					})
+				})
+			})
+
 			Describe("the source registered with the repository", func() {
 				var artifactSource worker.ArtifactSource
 
@@ -445,6 +634,12 @@ var _ = Describe("GetStep", func() {
 				Expect(info).To(BeZero())
 			})
 
+			It("records the failed fetch via the delegate", func() {
+				Expect(fakeDelegate.FetchedCallCount()).To(Equal(1))
+				_, _, _, succeeded := fakeDelegate.FetchedArgsForCall(0)
+				Expect(succeeded).To(BeFalse())
+			})
+
 			It("returns nil", func() {
 				Expect(stepErr).ToNot(HaveOccurred())
 			})
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 )
 
 const AbortedLogMessage = "interrupted"
@@ -43,7 +44,7 @@ func (step LogErrorStep) Run(ctx context.Context, state RunState) error {
 
 	logger.Info("errored", lager.Data{"error": runErr.Error()})
 
-	step.delegate.Errored(logger, message)
+	step.delegate.Errored(logger, message, atc.ErrorCodeOf(runErr))
 
 	return runErr
 }
@@ -3,10 +3,13 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/image"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -74,7 +77,7 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs 'interrupted'", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
+				_, message, _ := fakeDelegate.ErroredArgsForCall(0)
 				Expect(message).To(Equal("interrupted"))
 			})
 		})
@@ -90,7 +93,7 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs 'timeout exceeded'", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
+				_, message, _ := fakeDelegate.ErroredArgsForCall(0)
 				Expect(message).To(Equal("timeout exceeded"))
 			})
 		})
@@ -108,8 +111,27 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs the error", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
+				_, message, code := fakeDelegate.ErroredArgsForCall(0)
 				Expect(message).To(Equal("disaster"))
+				Expect(code).To(BeEmpty())
+			})
+		})
+
+		Context("when the image's check failed", func() {
+			checkErr := image.ErrImageCheckFailed{
+				ExitStatus: 1,
+				Stderr:     "bad credentials",
+			}
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(fmt.Errorf("fetch image: %w", checkErr))
+			})
+
+			It("logs the error with its code, so it can be told apart from the worker failing", func() {
+				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+				_, message, code := fakeDelegate.ErroredArgsForCall(0)
+				Expect(message).To(ContainSubstring("checking for the image failed: exit status 1"))
+				Expect(code).To(Equal(atc.ErrorCodeImageCheckFailed))
 			})
 		})
 	})
@@ -24,7 +24,8 @@ func OnSuccess(firstStep Step, secondStep Step) Step {
 // the first step is ready.
 //
 // If the first step succeeds (that is, its Success result is true), the second
-// step is executed. If the second step errors, its error is returned.
+// step is executed. If the second step errors, its error is returned. The
+// second step isn't started while the build is paused.
 func (o OnSuccessStep) Run(ctx context.Context, state RunState) error {
 	stepRunErr := o.step.Run(ctx, state)
 	if stepRunErr != nil {
@@ -36,6 +37,11 @@ func (o OnSuccessStep) Run(ctx context.Context, state RunState) error {
 		return nil
 	}
 
+	err := waitIfPaused(ctx)
+	if err != nil {
+		return err
+	}
+
 	return o.hook.Run(ctx, state)
 }
 
@@ -75,6 +75,36 @@ var _ = Describe("On Success Step", func() {
 		It("returns nil", func() {
 			Expect(stepErr).ToNot(HaveOccurred())
 		})
+
+		Context("when the build has a pause gate", func() {
+			var gate *execfakes.FakePauseGate
+
+			BeforeEach(func() {
+				gate = new(execfakes.FakePauseGate)
+				gate.WaitIfPausedStub = func(context.Context) error {
+					Expect(hook.RunCallCount()).To(BeZero())
+					return nil
+				}
+
+				ctx = exec.WithPauseGate(ctx, gate)
+			})
+
+			It("waits at the gate before running the hook", func() {
+				Expect(gate.WaitIfPausedCallCount()).To(Equal(1))
+				Expect(hook.RunCallCount()).To(Equal(1))
+			})
+
+			Context("when the build is aborted while paused", func() {
+				BeforeEach(func() {
+					gate.WaitIfPausedReturns(context.Canceled)
+				})
+
+				It("returns the error without running the hook", func() {
+					Expect(stepErr).To(Equal(context.Canceled))
+					Expect(hook.RunCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Context("when the step errors", func() {
@@ -0,0 +1,30 @@
+package exec
+
+import "context"
+
+//go:generate counterfeiter . PauseGate
+
+// A PauseGate holds a build before each of its steps while the build is
+// paused.
+type PauseGate interface {
+	// WaitIfPaused returns once the build isn't paused, or with the
+	// context's error if it's canceled first.
+	WaitIfPaused(context.Context) error
+}
+
+type pauseGateKey struct{}
+
+// WithPauseGate returns a context which the build's steps are run with so
+// that they hold between steps while the build is paused.
+func WithPauseGate(ctx context.Context, gate PauseGate) context.Context {
+	return context.WithValue(ctx, pauseGateKey{}, gate)
+}
+
+func waitIfPaused(ctx context.Context) error {
+	gate, ok := ctx.Value(pauseGateKey{}).(PauseGate)
+	if !ok {
+		return nil
+	}
+
+	return gate.WaitIfPaused(ctx)
+}
@@ -0,0 +1,89 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+)
+
+// PoolClaimInterval is how often a step waiting to join its concurrency
+// pools tries again.
+var PoolClaimInterval = 10 * time.Second
+
+//go:generate counterfeiter . PoolClaimer
+
+// PoolClaimer makes steps members of their team's concurrency pools.
+type PoolClaimer interface {
+	ClaimStepConcurrencyPools(atc.PlanID, []string) (bool, error)
+	ReleaseStepConcurrencyPools(atc.PlanID) error
+}
+
+// PoolStep runs a step as a member of concurrency pools, waiting until it
+// may join them and leaving them once it has run.
+type PoolStep struct {
+	step     Step
+	planID   atc.PlanID
+	pools    []string
+	claimer  PoolClaimer
+	delegate BuildStepDelegate
+}
+
+func Pool(step Step, planID atc.PlanID, pools []string, claimer PoolClaimer, delegate BuildStepDelegate) *PoolStep {
+	return &PoolStep{
+		step:     step,
+		planID:   planID,
+		pools:    pools,
+		claimer:  claimer,
+		delegate: delegate,
+	}
+}
+
+// Run claims the pools, retrying every PoolClaimInterval while any of them
+// is full, and then runs the nested step. The pools are released once the
+// nested step has run, however it exits.
+func (ps *PoolStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).Session("pool-step", lager.Data{"pools": ps.pools})
+
+	waiting := false
+	for {
+		claimed, err := ps.claimer.ClaimStepConcurrencyPools(ps.planID, ps.pools)
+		if err != nil {
+			logger.Error("failed-to-claim-pools", err)
+			return err
+		}
+
+		if claimed {
+			break
+		}
+
+		if !waiting {
+			waiting = true
+			fmt.Fprintf(ps.delegate.Stdout(), "waiting to join concurrency pools: %s\n", strings.Join(ps.pools, ", "))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(PoolClaimInterval):
+		}
+	}
+
+	defer func() {
+		err := ps.claimer.ReleaseStepConcurrencyPools(ps.planID)
+		if err != nil {
+			logger.Error("failed-to-release-pools", err)
+		}
+	}()
+
+	return ps.step.Run(ctx, state)
+}
+
+// Succeeded delegates to the nested step.
+func (ps *PoolStep) Succeeded() bool {
+	return ps.step.Succeeded()
+}
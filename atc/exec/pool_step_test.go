@@ -0,0 +1,142 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Pool Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep     *execfakes.FakeStep
+		fakeClaimer  *execfakes.FakePoolClaimer
+		fakeDelegate *execfakes.FakeBuildStepDelegate
+		state        *execfakes.FakeRunState
+
+		stdout *gbytes.Buffer
+
+		step    Step
+		stepErr error
+
+		oldInterval time.Duration
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeClaimer = new(execfakes.FakePoolClaimer)
+		fakeClaimer.ClaimStepConcurrencyPoolsReturns(true, nil)
+
+		stdout = gbytes.NewBuffer()
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+
+		state = new(execfakes.FakeRunState)
+
+		oldInterval = PoolClaimInterval
+		PoolClaimInterval = time.Millisecond
+	})
+
+	AfterEach(func() {
+		PoolClaimInterval = oldInterval
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = Pool(fakeStep, "some-plan-id", []string{"some-pool"}, fakeClaimer, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("claims the pools for the plan", func() {
+		Expect(fakeClaimer.ClaimStepConcurrencyPoolsCallCount()).To(Equal(1))
+		planID, pools := fakeClaimer.ClaimStepConcurrencyPoolsArgsForCall(0)
+		Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+		Expect(pools).To(Equal([]string{"some-pool"}))
+	})
+
+	It("runs the step and then releases the pools", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(fakeStep.RunCallCount()).To(Equal(1))
+		Expect(fakeClaimer.ReleaseStepConcurrencyPoolsCallCount()).To(Equal(1))
+		Expect(fakeClaimer.ReleaseStepConcurrencyPoolsArgsForCall(0)).To(Equal(atc.PlanID("some-plan-id")))
+	})
+
+	Context("when the pools are full", func() {
+		BeforeEach(func() {
+			fakeClaimer.ClaimStepConcurrencyPoolsReturnsOnCall(0, false, nil)
+			fakeClaimer.ClaimStepConcurrencyPoolsReturnsOnCall(1, false, nil)
+		})
+
+		It("waits until they can be claimed before running the step", func() {
+			Expect(fakeClaimer.ClaimStepConcurrencyPoolsCallCount()).To(Equal(3))
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+
+		It("says it is waiting once", func() {
+			Expect(stdout).To(gbytes.Say("waiting to join concurrency pools: some-pool"))
+			Expect(stdout).ToNot(gbytes.Say("waiting"))
+		})
+
+		Context("when the context is canceled", func() {
+			BeforeEach(func() {
+				fakeClaimer.ClaimStepConcurrencyPoolsStub = func(atc.PlanID, []string) (bool, error) {
+					cancel()
+					return false, nil
+				}
+			})
+
+			It("returns without running the step or releasing the pools", func() {
+				Expect(stepErr).To(Equal(context.Canceled))
+				Expect(fakeStep.RunCallCount()).To(BeZero())
+				Expect(fakeClaimer.ReleaseStepConcurrencyPoolsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when claiming the pools fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeClaimer.ClaimStepConcurrencyPoolsReturns(false, disaster)
+		})
+
+		It("returns the error without running the step", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the step fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStep.RunReturns(disaster)
+		})
+
+		It("returns the error and still releases the pools", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeClaimer.ReleaseStepConcurrencyPoolsCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("Succeeded", func() {
+		It("delegates to the step", func() {
+			fakeStep.SucceededReturns(true)
+			Expect(step.Succeeded()).To(BeTrue())
+
+			fakeStep.SucceededReturns(false)
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
@@ -18,6 +19,10 @@ func (e PutInputNotFoundError) Error() string {
 	return fmt.Sprintf("input not found: %s", e.Input)
 }
 
+func (e PutInputNotFoundError) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeInputResolutionFailed
+}
+
 type PutInputs interface {
 	FindAll(*artifact.Repository) ([]worker.InputSource, error)
 }
@@ -2,10 +2,12 @@ package exec
 
 import (
 	"context"
+	"path"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/contentscan"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
@@ -18,7 +20,7 @@ type PutDelegate interface {
 	BuildStepDelegate
 
 	Initializing(lager.Logger)
-	Starting(lager.Logger)
+	Starting(logger lager.Logger, workerName string)
 	Finished(lager.Logger, ExitStatus, VersionInfo)
 	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, VersionInfo)
 }
@@ -34,6 +36,7 @@ type PutStep struct {
 	resourceConfigFactory db.ResourceConfigFactory
 	strategy              worker.ContainerPlacementStrategy
 	pool                  worker.Pool
+	contentScanner        contentscan.Scanner
 	delegate              PutDelegate
 	succeeded             bool
 }
@@ -47,6 +50,7 @@ func NewPutStep(
 	resourceConfigFactory db.ResourceConfigFactory,
 	strategy worker.ContainerPlacementStrategy,
 	pool worker.Pool,
+	contentScanner contentscan.Scanner,
 	delegate PutDelegate,
 ) *PutStep {
 	return &PutStep{
@@ -58,6 +62,7 @@ func NewPutStep(
 		resourceConfigFactory: resourceConfigFactory,
 		pool:                  pool,
 		strategy:              strategy,
+		contentScanner:        contentScanner,
 		delegate:              delegate,
 	}
 }
@@ -67,6 +72,8 @@ func NewPutStep(
 //
 // All worker.ArtifactSources present in the worker.ArtifactRepository are then brought into
 // the container, using volumes if possible, and streaming content over if not.
+// If a content scanner is configured, each of them is passed through it
+// first, which may error the step depending on the team's policy.
 //
 // The resource's put script is then invoked. If the context is canceled, the
 // script will be interrupted.
@@ -114,6 +121,17 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	if step.contentScanner != nil {
+		for _, input := range containerInputs {
+			name := path.Base(input.DestinationPath())
+
+			err = scanContent(ctx, logger, step.contentScanner, step.metadata, "put", name, input.Source(), step.delegate.Stderr())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	containerSpec := worker.ContainerSpec{
 		ImageSpec: worker.ImageSpec{
 			ResourceType: step.plan.Type,
@@ -123,7 +141,9 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 
 		Dir: step.containerMetadata.WorkingDirectory,
 
-		Env: step.metadata.Env(),
+		Env:     step.metadata.Env(),
+		CACerts: step.metadata.CACerts,
+		DNS:     step.metadata.ContainerDNS,
 
 		Inputs: containerInputs,
 	}
@@ -131,7 +151,9 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 	workerSpec := worker.WorkerSpec{
 		ResourceType:  step.plan.Type,
 		Tags:          step.plan.Tags,
+		Labels:        step.plan.Labels,
 		TeamID:        step.metadata.TeamID,
+		WorkerName:    step.plan.Worker,
 		ResourceTypes: resourceTypes,
 	}
 
@@ -166,7 +188,7 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
-	step.delegate.Starting(logger)
+	step.delegate.Starting(logger, chosenWorker.Name())
 
 	putResource := step.resourceFactory.NewResourceForContainer(container)
 	versionResult, err := putResource.Put(
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 
+	"code.cloudfoundry.org/lager"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/contentscan"
+	"github.com/concourse/concourse/atc/contentscan/contentscanfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
@@ -32,6 +36,8 @@ var _ = Describe("PutStep", func() {
 		fakeResourceFactory       *resourcefakes.FakeResourceFactory
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeDelegate              *execfakes.FakePutDelegate
+		fakeContentScanner        *contentscanfakes.FakeScanner
+		contentScanner            contentscan.Scanner
 		putPlan                   *atc.PutPlan
 
 		interpolatedResourceTypes atc.VersionedResourceTypes
@@ -86,6 +92,9 @@ var _ = Describe("PutStep", func() {
 		fakeDelegate.StderrReturns(stderrBuf)
 		fakeDelegate.VariablesReturns(vars.NewCredVarsTracker(credVarsTracker, false))
 
+		fakeContentScanner = new(contentscanfakes.FakeScanner)
+		contentScanner = nil
+
 		repo = artifact.NewRepository()
 		state = new(execfakes.FakeRunState)
 		state.ArtifactsReturns(repo)
@@ -142,6 +151,7 @@ var _ = Describe("PutStep", func() {
 			fakeResourceConfigFactory,
 			fakeStrategy,
 			fakePool,
+			contentScanner,
 			fakeDelegate,
 		)
 
@@ -263,6 +273,62 @@ var _ = Describe("PutStep", func() {
 				})
 			})
 
+			Context("when a content scanner is configured", func() {
+				BeforeEach(func() {
+					contentScanner = fakeContentScanner
+
+					putPlan.Inputs = &atc.InputsConfig{
+						Specified: []string{"some-source"},
+					}
+
+					fakeSource.StreamToStub = func(ctx context.Context, logger lager.Logger, dest worker.ArtifactDestination) error {
+						return dest.StreamIn(ctx, ".", gbytes.NewBuffer())
+					}
+				})
+
+				It("scans each input", func() {
+					Expect(fakeContentScanner.ScanCallCount()).To(Equal(1))
+					_, subject, _ := fakeContentScanner.ScanArgsForCall(0)
+					Expect(subject).To(Equal(contentscan.Subject{
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						BuildID:      42,
+						BuildName:    "some-build",
+						Step:         "put",
+						Artifact:     "some-source",
+					}))
+				})
+
+				Context("when the scan has findings", func() {
+					BeforeEach(func() {
+						fakeContentScanner.ScanReturns(contentscan.Result{Findings: []string{"private-key in id_rsa"}}, nil)
+					})
+
+					It("writes the findings to stderr and puts anyway", func() {
+						Expect(stderrBuf).To(gbytes.Say(`\[CONTENT SCAN\] some-source: private-key in id_rsa`))
+						Expect(fakeResource.PutCallCount()).To(Equal(1))
+					})
+
+					Context("when the policy is to block", func() {
+						BeforeEach(func() {
+							stepMetadata.ContentScanPolicy = atc.ContentScanPolicyBlock
+						})
+
+						AfterEach(func() {
+							stepMetadata.ContentScanPolicy = ""
+						})
+
+						It("returns the findings without putting", func() {
+							Expect(stepErr).To(Equal(exec.ContentBlockedError{
+								Artifact: "some-source",
+								Findings: []string{"private-key in id_rsa"},
+							}))
+							Expect(fakeResource.PutCallCount()).To(Equal(0))
+						})
+					})
+				})
+			})
+
 			It("puts the resource with the given context", func() {
 				Expect(fakeResource.PutCallCount()).To(Equal(1))
 				putCtx, _, _, _ := fakeResource.PutArgsForCall(0)
@@ -289,6 +355,12 @@ var _ = Describe("PutStep", func() {
 				Expect(fakeResource.PutCallCount()).To(Equal(1))
 			})
 
+			It("starts the step on the chosen worker", func() {
+				Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+				_, workerName := fakeDelegate.StartingArgsForCall(0)
+				Expect(workerName).To(Equal("some-worker"))
+			})
+
 			It("is successful", func() {
 				Expect(putStep.Succeeded()).To(BeTrue())
 			})
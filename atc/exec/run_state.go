@@ -6,17 +6,20 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/vars"
 )
 
 type runState struct {
 	artifacts *artifact.Repository
 	results   *sync.Map
+	localVars *localVariables
 }
 
 func NewRunState() RunState {
 	return &runState{
 		artifacts: artifact.NewRepository(),
 		results:   &sync.Map{},
+		localVars: &localVariables{},
 	}
 }
 
@@ -41,3 +44,33 @@ func (state *runState) Result(id atc.PlanID, to interface{}) bool {
 func (state *runState) StoreResult(id atc.PlanID, val interface{}) {
 	state.results.Store(id, val)
 }
+
+func (state *runState) LocalVariables() vars.Variables {
+	return state.localVars
+}
+
+func (state *runState) StoreLocalVar(name string, val interface{}) {
+	state.localVars.Store(name, val)
+}
+
+// localVariables are stored by steps as they finish, while later steps
+// running in parallel may be reading them.
+type localVariables struct {
+	sync.Map
+}
+
+func (v *localVariables) Get(varDef vars.VariableDefinition) (interface{}, bool, error) {
+	val, found := v.Load(varDef.Name)
+	return val, found, nil
+}
+
+func (v *localVariables) List() ([]vars.VariableDefinition, error) {
+	var defs []vars.VariableDefinition
+
+	v.Range(func(name, _ interface{}) bool {
+		defs = append(defs, vars.VariableDefinition{Name: name.(string)})
+		return true
+	})
+
+	return defs, nil
+}
@@ -3,6 +3,7 @@ package exec_test
 import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -93,4 +94,37 @@ var _ = Describe("RunState", func() {
 			})
 		})
 	})
+
+	Describe("LocalVariables", func() {
+		It("has none to begin with", func() {
+			defs, err := state.LocalVariables().List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(BeEmpty())
+		})
+
+		Context("when a local var has been stored", func() {
+			BeforeEach(func() {
+				state.StoreLocalVar("some-step", map[string]interface{}{"some": "value"})
+			})
+
+			It("gets it", func() {
+				val, found, err := state.LocalVariables().Get(vars.VariableDefinition{Name: "some-step"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal(map[string]interface{}{"some": "value"}))
+			})
+
+			It("lists it", func() {
+				defs, err := state.LocalVariables().List()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(defs).To(ConsistOf(vars.VariableDefinition{Name: "some-step"}))
+			})
+
+			It("does not get other vars", func() {
+				_, found, err := state.LocalVariables().Get(vars.VariableDefinition{Name: "some-other-step"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
 })
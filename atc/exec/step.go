@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/vars"
@@ -37,14 +38,23 @@ type Step interface {
 type BuildOutputFilter func(text string) string
 
 type BuildStepDelegate interface {
+	ImageCheckStarted() error
 	ImageVersionDetermined(db.UsedResourceCache) error
+	ImageFetchStarted() error
+	ImageFetchFinished() error
+	ImageBytesStreamed(bytes int64) error
+	ContainerPrepared(imageFetchDuration, inputStreamDuration time.Duration) error
+
+	// ArtifactChecksummed is called with the checksum and size of an artifact
+	// the step registered, when artifacts are checksummed.
+	ArtifactChecksummed(name string, checksum string, size int64) error
 
 	Stdout() io.Writer
 	Stderr() io.Writer
 
 	Variables() vars.CredVarsTracker
 
-	Errored(lager.Logger, string)
+	Errored(lager.Logger, string, atc.ErrorCode)
 }
 
 //go:generate counterfeiter . RunState
@@ -54,6 +64,11 @@ type RunState interface {
 
 	Result(atc.PlanID, interface{}) bool
 	StoreResult(atc.PlanID, interface{})
+
+	// LocalVariables are the vars produced by earlier steps in the build,
+	// which are interpolated into the file task configs of later steps.
+	LocalVariables() vars.Variables
+	StoreLocalVar(string, interface{})
 }
 
 // VersionInfo is the version and metadata of a resource that was fetched or
@@ -2,6 +2,10 @@ package exec
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
 )
 
 type StepMetadata struct {
@@ -17,6 +21,38 @@ type StepMetadata struct {
 	ResourceConfigID      int
 	BaseResourceTypeID    int
 	ExternalURL           string
+
+	// BuildToken authenticates the build's own tasks to the build
+	// annotations API while the build is running.
+	BuildToken string
+
+	// ContainerEnv is the team's environment to inject into every container
+	// the step runs. Anything the step configures itself takes precedence.
+	ContainerEnv map[string]string
+
+	// CACerts is a PEM-encoded bundle of CA certificates to trust in every
+	// container the step runs.
+	CACerts string
+
+	// ContainerDNS, if set, replaces the name resolution configured by the
+	// worker in every container the step runs.
+	ContainerDNS *atc.ContainerDNS
+
+	// DefaultTaskImage, if set, is used by tasks which configure neither an
+	// image nor an image_resource.
+	DefaultTaskImage *atc.ImageResource
+
+	// ContentScanPolicy decides whether the content scanner's findings for
+	// the content of get and put steps error the step.
+	ContentScanPolicy atc.ContentScanPolicy
+
+	// RequireImageDigests errors task steps whose image_resource is not
+	// pinned to an image digest.
+	RequireImageDigests bool
+
+	// ChecksumArtifacts streams every artifact fetched by get steps and
+	// produced by task steps to checksum it.
+	ChecksumArtifacts bool
 }
 
 func (metadata StepMetadata) Env() []string {
@@ -58,5 +94,63 @@ func (metadata StepMetadata) Env() []string {
 		env = append(env, "ATC_EXTERNAL_URL="+metadata.ExternalURL)
 	}
 
+	return append(env, metadata.containerEnv(env)...)
+}
+
+// TaskEnv returns the environment for a task container: the task's params,
+// plus any of the team's container env that the params do not override.
+//
+// If the build has a token, the task is also given what it needs to
+// annotate its build.
+func (metadata StepMetadata) TaskEnv(params atc.TaskEnv) []string {
+	env := params.Env()
+	env = append(metadata.buildTokenEnv(env), env...)
+	return append(metadata.containerEnv(env), env...)
+}
+
+func (metadata StepMetadata) buildTokenEnv(existing []string) []string {
+	if metadata.BuildToken == "" {
+		return nil
+	}
+
+	set := map[string]bool{}
+	for _, e := range existing {
+		set[strings.SplitN(e, "=", 2)[0]] = true
+	}
+
+	env := []string{}
+	for _, e := range []string{
+		"ATC_EXTERNAL_URL=" + metadata.ExternalURL,
+		fmt.Sprintf("BUILD_ID=%d", metadata.BuildID),
+		"BUILD_TOKEN=" + metadata.BuildToken,
+	} {
+		if !set[strings.SplitN(e, "=", 2)[0]] {
+			env = append(env, e)
+		}
+	}
+
+	return env
+}
+
+func (metadata StepMetadata) containerEnv(existing []string) []string {
+	set := map[string]bool{}
+	for _, e := range existing {
+		set[strings.SplitN(e, "=", 2)[0]] = true
+	}
+
+	names := []string{}
+	for name := range metadata.ContainerEnv {
+		if !set[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	env := []string{}
+	for _, name := range names {
+		env = append(env, name+"="+metadata.ContainerEnv[name])
+	}
+
 	return env
 }
@@ -1,6 +1,7 @@
 package exec_test
 
 import (
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec"
 
 	. "github.com/onsi/ginkgo"
@@ -53,5 +54,85 @@ var _ = Describe("StepMetadata", func() {
 				}))
 			})
 		})
+
+		Context("when there is container env", func() {
+			BeforeEach(func() {
+				stepMetadata = exec.StepMetadata{
+					BuildID: 1,
+					ContainerEnv: map[string]string{
+						"HTTP_PROXY": "http://proxy.example.com",
+						"BUILD_ID":   "not-the-build-id",
+						"NO_PROXY":   "localhost",
+					},
+				}
+			})
+
+			It("appends it without overriding the build metadata", func() {
+				Expect(stepMetadata.Env()).To(Equal([]string{
+					"BUILD_ID=1",
+					"HTTP_PROXY=http://proxy.example.com",
+					"NO_PROXY=localhost",
+				}))
+			})
+		})
+	})
+
+	Describe("TaskEnv", func() {
+		BeforeEach(func() {
+			stepMetadata = exec.StepMetadata{
+				BuildID: 1,
+				ContainerEnv: map[string]string{
+					"HTTP_PROXY": "http://proxy.example.com",
+					"NO_PROXY":   "localhost",
+				},
+			}
+		})
+
+		It("includes the container env and the params, letting the params win", func() {
+			Expect(stepMetadata.TaskEnv(atc.TaskEnv{
+				"NO_PROXY":   "*.internal",
+				"SOME_PARAM": "some-value",
+			})).To(ConsistOf(
+				"HTTP_PROXY=http://proxy.example.com",
+				"NO_PROXY=*.internal",
+				"SOME_PARAM=some-value",
+			))
+		})
+
+		It("does not include the build metadata", func() {
+			Expect(stepMetadata.TaskEnv(nil)).To(Equal([]string{
+				"HTTP_PROXY=http://proxy.example.com",
+				"NO_PROXY=localhost",
+			}))
+		})
+
+		Context("when the build has a token", func() {
+			BeforeEach(func() {
+				stepMetadata.ExternalURL = "https://ci.example.com"
+				stepMetadata.BuildToken = "some-token"
+			})
+
+			It("includes what the task needs to annotate the build", func() {
+				Expect(stepMetadata.TaskEnv(nil)).To(ConsistOf(
+					"ATC_EXTERNAL_URL=https://ci.example.com",
+					"BUILD_ID=1",
+					"BUILD_TOKEN=some-token",
+					"HTTP_PROXY=http://proxy.example.com",
+					"NO_PROXY=localhost",
+				))
+			})
+
+			It("lets the params win", func() {
+				Expect(stepMetadata.TaskEnv(atc.TaskEnv{
+					"BUILD_TOKEN": "overridden",
+				})).To(ConsistOf(
+					"ATC_EXTERNAL_URL=https://ci.example.com",
+					"BUILD_ID=1",
+					"BUILD_TOKEN=overridden",
+					"HTTP_PROXY=http://proxy.example.com",
+					"NO_PROXY=localhost",
+				))
+			})
+		})
 	})
 })
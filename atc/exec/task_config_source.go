@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -158,6 +159,45 @@ func (configSource OverrideParamsConfigSource) Warnings() []string {
 	return configSource.WarningList
 }
 
+// OverrideImageConfigSource is used to override the version and/or source of
+// a task's image_resource for a single build.
+type OverrideImageConfigSource struct {
+	ConfigSource TaskConfigSource
+	Override     atc.TaskImageOverride
+}
+
+// FetchConfig overrides the image_resource of the config, failing if it has
+// none to override.
+func (configSource OverrideImageConfigSource) FetchConfig(ctx context.Context, logger lager.Logger, source *artifact.Repository) (atc.TaskConfig, error) {
+	taskConfig, err := configSource.ConfigSource.FetchConfig(ctx, logger, source)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if taskConfig.ImageResource == nil {
+		return atc.TaskConfig{}, errors.New("task config has no image_resource to override")
+	}
+
+	imageResource := *taskConfig.ImageResource
+
+	if len(configSource.Override.Source) > 0 {
+		imageResource.Source = configSource.Override.Source
+	}
+
+	if len(configSource.Override.Version) > 0 {
+		version := configSource.Override.Version
+		imageResource.Version = &version
+	}
+
+	taskConfig.ImageResource = &imageResource
+
+	return taskConfig, nil
+}
+
+func (configSource OverrideImageConfigSource) Warnings() []string {
+	return configSource.ConfigSource.Warnings()
+}
+
 // InterpolateTemplateConfigSource represents a config source interpolated by template vars
 type InterpolateTemplateConfigSource struct {
 	ConfigSource TaskConfigSource
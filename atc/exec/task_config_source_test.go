@@ -334,6 +334,84 @@ run: {path: a/file}
 		})
 	})
 
+	Describe("OverrideImageConfigSource", func() {
+		var (
+			config   atc.TaskConfig
+			override atc.TaskImageOverride
+
+			fetchedConfig atc.TaskConfig
+			fetchErr      error
+		)
+
+		BeforeEach(func() {
+			config = atc.TaskConfig{
+				Platform: "some-platform",
+				ImageResource: &atc.ImageResource{
+					Type:    "registry-image",
+					Source:  atc.Source{"repository": "some/image"},
+					Version: &atc.Version{"digest": "sha256:original"},
+				},
+				Run: atc.TaskRunConfig{Path: "echo"},
+			}
+
+			override = atc.TaskImageOverride{}
+		})
+
+		JustBeforeEach(func() {
+			configSource := OverrideImageConfigSource{
+				ConfigSource: StaticConfigSource{Config: &config},
+				Override:     override,
+			}
+
+			fetchedConfig, fetchErr = configSource.FetchConfig(context.TODO(), logger, repo)
+		})
+
+		Context("when the version is overridden", func() {
+			BeforeEach(func() {
+				override.Version = atc.Version{"digest": "sha256:fixed"}
+			})
+
+			It("replaces the version, keeping the source", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedConfig.ImageResource).To(Equal(&atc.ImageResource{
+					Type:    "registry-image",
+					Source:  atc.Source{"repository": "some/image"},
+					Version: &atc.Version{"digest": "sha256:fixed"},
+				}))
+			})
+
+			It("does not modify the original config", func() {
+				Expect(config.ImageResource.Version).To(Equal(&atc.Version{"digest": "sha256:original"}))
+			})
+		})
+
+		Context("when the source is overridden", func() {
+			BeforeEach(func() {
+				override.Source = atc.Source{"repository": "some/fork"}
+			})
+
+			It("replaces the source, keeping the version", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedConfig.ImageResource).To(Equal(&atc.ImageResource{
+					Type:    "registry-image",
+					Source:  atc.Source{"repository": "some/fork"},
+					Version: &atc.Version{"digest": "sha256:original"},
+				}))
+			})
+		})
+
+		Context("when the config has no image_resource", func() {
+			BeforeEach(func() {
+				config.ImageResource = nil
+				override.Version = atc.Version{"digest": "sha256:fixed"}
+			})
+
+			It("fails", func() {
+				Expect(fetchErr).To(MatchError("task config has no image_resource to override"))
+			})
+		})
+	})
+
 	Describe("ValidatingConfigSource", func() {
 		var (
 			fakeConfigSource *execfakes.FakeTaskConfigSource
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"path"
 	"path/filepath"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
 )
 
 // MissingInputsError is returned when any of the task's required inputs are
@@ -32,6 +34,10 @@ func (err MissingInputsError) Error() string {
 	return fmt.Sprintf("missing inputs: %s", strings.Join(err.Inputs, ", "))
 }
 
+func (err MissingInputsError) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeInputResolutionFailed
+}
+
 type MissingTaskImageSourceError struct {
 	SourceName string
 }
@@ -56,7 +62,7 @@ type TaskDelegate interface {
 	BuildStepDelegate
 
 	Initializing(lager.Logger, atc.TaskConfig)
-	Starting(lager.Logger, atc.TaskConfig)
+	Starting(logger lager.Logger, config atc.TaskConfig, workerName string)
 	Finished(lager.Logger, ExitStatus)
 }
 
@@ -135,8 +141,10 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		// external task - construct a source which reads it from file
 		taskConfigSource = FileConfigSource{ConfigPath: step.plan.ConfigPath}
 
-		// for interpolation - use 'vars' from the pipeline, and then fill remaining with cred variables
-		taskVars = []vars.Variables{vars.StaticVariables(step.plan.Vars), variables}
+		// for interpolation - use 'vars' from the pipeline, then cred variables,
+		// and then fill remaining with vars produced by earlier steps, so that
+		// an earlier step can't override a credential
+		taskVars = []vars.Variables{vars.StaticVariables(step.plan.Vars), variables, state.LocalVariables()}
 	} else {
 		// embedded task - first we take it
 		taskConfigSource = StaticConfigSource{Config: step.plan.Config}
@@ -148,6 +156,11 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 	// override params
 	taskConfigSource = &OverrideParamsConfigSource{ConfigSource: taskConfigSource, Params: step.plan.Params}
 
+	// override image for this build
+	if step.plan.ImageOverride != nil {
+		taskConfigSource = OverrideImageConfigSource{ConfigSource: taskConfigSource, Override: *step.plan.ImageOverride}
+	}
+
 	// interpolate template vars
 	taskConfigSource = InterpolateTemplateConfigSource{ConfigSource: taskConfigSource, Vars: taskVars}
 
@@ -173,6 +186,24 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		config.Limits.Memory = step.defaultLimits.Memory
 	}
 
+	if step.plan.ImageArtifactName == "" && config.ImageResource == nil && config.RootfsURI == "" && step.metadata.DefaultTaskImage != nil {
+		defaultImage := *step.metadata.DefaultTaskImage
+		config.ImageResource = &defaultImage
+
+		fmt.Fprintf(step.delegate.Stderr(), "no image configured; using default task image of type '%s'\n", defaultImage.Type)
+	}
+
+	if step.plan.ImageOverride != nil {
+		fmt.Fprintln(step.delegate.Stderr(), "using the image_resource overridden when this build was triggered")
+	}
+
+	if step.metadata.RequireImageDigests && step.plan.ImageArtifactName == "" && config.ImageResource != nil && !config.ImageResource.PinnedByDigest() {
+		return atc.ImageDigestRequiredError{
+			Team: step.metadata.TeamName,
+			Step: step.plan.Name,
+		}
+	}
+
 	step.delegate.Initializing(logger, config)
 
 	workerSpec, err := step.workerSpec(logger, resourceTypes, repository, config)
@@ -207,7 +238,7 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 				step.delegate.Initializing(logger, config)
 
 			case runtime.StartingEvent:
-				step.delegate.Starting(logger, config)
+				step.delegate.Starting(logger, config, ev.WorkerName)
 
 			case runtime.FinishedEvent:
 				step.delegate.Finished(logger, ExitStatus(ev.ExitStatus))
@@ -234,7 +265,7 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 	err = result.Err
 	if err != nil {
 		if err == context.Canceled || err == context.DeadlineExceeded {
-			registerErr := step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
+			registerErr := step.registerOutputs(ctx, logger, repository, config, result.VolumeMounts, step.containerMetadata)
 			if registerErr != nil {
 				return registerErr
 			}
@@ -245,14 +276,21 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 	step.succeeded = (result.Status == 0)
 	step.delegate.Finished(logger, ExitStatus(result.Status))
 
-	err = step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
+	err = step.registerOutputs(ctx, logger, repository, config, result.VolumeMounts, step.containerMetadata)
 	if err != nil {
 		return err
 	}
 
+	if step.succeeded && step.plan.OutputVars != "" {
+		err = step.storeOutputVars(ctx, logger, repository, state)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Do not initialize caches for one-off builds
 	if step.metadata.JobID != 0 {
-		err = step.registerCaches(logger, repository, config, result.VolumeMounts, step.containerMetadata)
+		err = step.registerCaches(ctx, logger, repository, config, result.VolumeMounts, step.containerMetadata)
 		if err != nil {
 			return err
 		}
@@ -266,6 +304,42 @@ func (step *TaskStep) Succeeded() bool {
 	return step.succeeded
 }
 
+// storeOutputVars loads the vars the task wrote to its output vars file, and
+// stores them under the task's name for later steps to use.
+func (step *TaskStep) storeOutputVars(ctx context.Context, logger lager.Logger, repository *artifact.Repository, state RunState) error {
+	segs := strings.SplitN(step.plan.OutputVars, "/", 2)
+	if len(segs) != 2 {
+		return UnspecifiedArtifactSourceError{step.plan.OutputVars}
+	}
+
+	source, found := repository.SourceFor(artifact.Name(segs[0]))
+	if !found {
+		return fmt.Errorf("unknown artifact source: '%s' in output vars file path '%s'", segs[0], step.plan.OutputVars)
+	}
+
+	stream, err := source.StreamFile(ctx, logger, segs[1])
+	if err != nil {
+		return err
+	}
+
+	defer stream.Close()
+
+	byteVars, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return err
+	}
+
+	var outputVars map[string]interface{}
+	err = yaml.Unmarshal(byteVars, &outputVars)
+	if err != nil {
+		return fmt.Errorf("failed to load output vars from %s: %s", step.plan.OutputVars, err)
+	}
+
+	state.StoreLocalVar(step.plan.Name, outputVars)
+
+	return nil
+}
+
 func (step *TaskStep) imageSpec(logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig) (worker.ImageSpec, error) {
 	imageSpec := worker.ImageSpec{
 		Privileged: bool(step.plan.Privileged),
@@ -344,15 +418,24 @@ func (step *TaskStep) containerSpec(logger lager.Logger, repository *artifact.Re
 		return worker.ContainerSpec{}, err
 	}
 
+	params, files, err := step.fileParams(config.Params)
+	if err != nil {
+		return worker.ContainerSpec{}, err
+	}
+
 	containerSpec := worker.ContainerSpec{
 		Platform:  config.Platform,
+		GPUs:      step.plan.GPUs,
 		Tags:      step.plan.Tags,
 		TeamID:    step.metadata.TeamID,
 		ImageSpec: imageSpec,
 		Limits:    worker.ContainerLimits(config.Limits),
 		User:      config.Run.User,
 		Dir:       metadata.WorkingDirectory,
-		Env:       config.Params.Env(),
+		Env:       step.metadata.TaskEnv(params),
+		Files:     files,
+		CACerts:   step.metadata.CACerts,
+		DNS:       step.metadata.ContainerDNS,
 		Type:      metadata.Type,
 
 		Inputs:  []worker.InputSource{},
@@ -369,14 +452,77 @@ func (step *TaskStep) containerSpec(logger lager.Logger, repository *artifact.Re
 		containerSpec.Outputs[output.Name] = path
 	}
 
+	err = checkFilesOutsideMounts(containerSpec)
+	if err != nil {
+		return worker.ContainerSpec{}, err
+	}
+
 	return containerSpec, nil
 }
 
+// checkFilesOutsideMounts ensures that no file param is written into an
+// input, output or cache, where it would be saved along with the artifact.
+func checkFilesOutsideMounts(spec worker.ContainerSpec) error {
+	mounts := []string{}
+	for _, input := range spec.Inputs {
+		mounts = append(mounts, filepath.Clean(input.DestinationPath()))
+	}
+
+	for _, output := range spec.Outputs {
+		mounts = append(mounts, filepath.Clean(output))
+	}
+
+	for filePath := range spec.Files {
+		dest := filePath
+		if !filepath.IsAbs(dest) {
+			dest = filepath.Join(spec.Dir, dest)
+		}
+
+		for _, mount := range mounts {
+			if dest == mount || strings.HasPrefix(dest, mount+"/") {
+				return fmt.Errorf("file param path '%s' is inside the mount at '%s'", filePath, mount)
+			}
+		}
+	}
+
+	return nil
+}
+
+// fileParams separates the params which are given to the task as files from
+// those set in its environment.
+func (step *TaskStep) fileParams(params atc.TaskEnv) (atc.TaskEnv, map[string]string, error) {
+	if len(step.plan.FileParams) == 0 {
+		return params, nil, nil
+	}
+
+	envParams := atc.TaskEnv{}
+	for name, value := range params {
+		if _, isFile := step.plan.FileParams[name]; !isFile {
+			envParams[name] = value
+		}
+	}
+
+	files := map[string]string{}
+	for name, path := range step.plan.FileParams {
+		value, found := params[name]
+		if !found {
+			return nil, nil, fmt.Errorf("file param '%s' is not set", name)
+		}
+
+		files[path] = value
+	}
+
+	return envParams, files, nil
+}
+
 func (step *TaskStep) workerSpec(logger lager.Logger, resourceTypes atc.VersionedResourceTypes, repository *artifact.Repository, config atc.TaskConfig) (worker.WorkerSpec, error) {
 	workerSpec := worker.WorkerSpec{
 		Platform:      config.Platform,
 		Tags:          step.plan.Tags,
+		Labels:        step.plan.Labels,
+		GPUs:          step.plan.GPUs,
 		TeamID:        step.metadata.TeamID,
+		WorkerName:    step.plan.Worker,
 		ResourceTypes: resourceTypes,
 	}
 
@@ -392,7 +538,7 @@ func (step *TaskStep) workerSpec(logger lager.Logger, resourceTypes atc.Versione
 	return workerSpec, nil
 }
 
-func (step *TaskStep) registerOutputs(logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
+func (step *TaskStep) registerOutputs(ctx context.Context, logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
 	logger.Debug("registering-outputs", lager.Data{"outputs": config.Outputs})
 
 	for _, output := range config.Outputs {
@@ -407,6 +553,10 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *artifact.
 			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
 				source := NewTaskArtifactSource(mount.Volume)
 				repository.RegisterSource(artifact.Name(outputName), source)
+
+				if step.metadata.ChecksumArtifacts {
+					checksumArtifact(ctx, logger, outputName, source, step.delegate)
+				}
 			}
 		}
 	}
@@ -414,7 +564,7 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *artifact.
 	return nil
 }
 
-func (step *TaskStep) registerCaches(logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
+func (step *TaskStep) registerCaches(ctx context.Context, logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
 	logger.Debug("initializing-caches", lager.Data{"caches": config.Caches})
 
 	for _, cacheConfig := range config.Caches {
@@ -423,6 +573,7 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *artifact.R
 				logger.Debug("initializing-cache", lager.Data{"path": volumeMount.MountPath})
 
 				err := volumeMount.Volume.InitializeTaskCache(
+					ctx,
 					logger,
 					step.metadata.JobID,
 					step.plan.Name,
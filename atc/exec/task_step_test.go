@@ -59,11 +59,7 @@ var _ = Describe("TaskStep", func() {
 			StepName:         "some-step",
 		}
 
-		stepMetadata = exec.StepMetadata{
-			TeamID:  123,
-			BuildID: 1234,
-			JobID:   12345,
-		}
+		stepMetadata exec.StepMetadata
 
 		planID = atc.PlanID(42)
 	)
@@ -116,6 +112,12 @@ var _ = Describe("TaskStep", func() {
 			},
 		}
 
+		stepMetadata = exec.StepMetadata{
+			TeamID:  123,
+			BuildID: 1234,
+			JobID:   12345,
+		}
+
 		taskPlan = &atc.TaskPlan{
 			Name:                   "some-task",
 			Privileged:             false,
@@ -248,6 +250,42 @@ var _ = Describe("TaskStep", func() {
 			Expect(taskProcessSpec.Args).To(Equal([]string{"some", "args"}))
 		})
 
+		Context("when params are given as files", func() {
+			BeforeEach(func() {
+				taskPlan.FileParams = map[string]string{"SECURE": "secrets/secure"}
+			})
+
+			It("writes them to files instead of the environment", func() {
+				Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+				_, _, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.Files).To(Equal(map[string]string{"secrets/secure": "secret-task-param"}))
+				Expect(containerSpec.Env).ToNot(ContainElement(ContainSubstring("secret-task-param")))
+			})
+
+			Context("when a file param is not set", func() {
+				BeforeEach(func() {
+					taskPlan.FileParams = map[string]string{"MISSING": "secrets/missing"}
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(MatchError("file param 'MISSING' is not set"))
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a file param would be written into an output", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Outputs = []atc.TaskOutputConfig{{Name: "some-output"}}
+					taskPlan.FileParams = map[string]string{"SECURE": "some-output/secure"}
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(MatchError("file param path 'some-output/secure' is inside the mount at 'some-artifact-root/some-output'"))
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when privileged", func() {
 			BeforeEach(func() {
 				taskPlan.Privileged = true
@@ -314,6 +352,7 @@ var _ = Describe("TaskStep", func() {
 				It("returns a MissingInputsError", func() {
 					Expect(stepErr).To(BeAssignableToTypeOf(exec.MissingInputsError{}))
 					Expect(stepErr.(exec.MissingInputsError).Inputs).To(ConsistOf("some-other-input"))
+					Expect(atc.ErrorCodeOf(stepErr)).To(Equal(atc.ErrorCodeInputResolutionFailed))
 				})
 			})
 		})
@@ -471,14 +510,14 @@ var _ = Describe("TaskStep", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 
 					Expect(fakeVolume1.InitializeTaskCacheCallCount()).To(Equal(1))
-					_, jID, stepName, cachePath, p := fakeVolume1.InitializeTaskCacheArgsForCall(0)
+					_, _, jID, stepName, cachePath, p := fakeVolume1.InitializeTaskCacheArgsForCall(0)
 					Expect(jID).To(Equal(stepMetadata.JobID))
 					Expect(stepName).To(Equal("some-task"))
 					Expect(cachePath).To(Equal("some-path-1"))
 					Expect(p).To(Equal(bool(taskPlan.Privileged)))
 
 					Expect(fakeVolume2.InitializeTaskCacheCallCount()).To(Equal(1))
-					_, jID, stepName, cachePath, p = fakeVolume2.InitializeTaskCacheArgsForCall(0)
+					_, _, jID, stepName, cachePath, p = fakeVolume2.InitializeTaskCacheArgsForCall(0)
 					Expect(jID).To(Equal(stepMetadata.JobID))
 					Expect(stepName).To(Equal("some-task"))
 					Expect(cachePath).To(Equal("some-path-2"))
@@ -746,6 +785,107 @@ var _ = Describe("TaskStep", func() {
 					Tags:          []string{"step", "tags"},
 				}))
 			})
+
+			Context("when the task is pinned to a worker", func() {
+				BeforeEach(func() {
+					taskPlan.Worker = "some-worker"
+				})
+
+				It("only runs it on that worker", func() {
+					_, _, _, _, _, workerSpec, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+					Expect(workerSpec.WorkerName).To(Equal("some-worker"))
+				})
+			})
+		})
+
+		Context("when no image is configured", func() {
+			BeforeEach(func() {
+				taskPlan.Config = &atc.TaskConfig{
+					Platform: "some-platform",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+				}
+			})
+
+			It("does not configure an image", func() {
+				_, _, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.ImageSpec.ImageResource).To(BeNil())
+				Expect(containerSpec.ImageSpec.ImageURL).To(BeEmpty())
+			})
+
+			Context("when there is a default task image", func() {
+				BeforeEach(func() {
+					stepMetadata.DefaultTaskImage = &atc.ImageResource{
+						Type:   "docker",
+						Source: atc.Source{"repository": "busybox"},
+					}
+				})
+
+				It("creates the specs with the default image", func() {
+					_, _, _, _, containerSpec, workerSpec, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+					Expect(containerSpec.ImageSpec.ImageResource).To(Equal(&worker.ImageResource{
+						Type:   "docker",
+						Source: atc.Source{"repository": "busybox"},
+					}))
+
+					Expect(workerSpec.ResourceType).To(Equal("docker"))
+				})
+
+				It("records the default image in the task config", func() {
+					_, config := fakeDelegate.InitializingArgsForCall(0)
+					Expect(config.ImageResource).To(Equal(&atc.ImageResource{
+						Type:   "docker",
+						Source: atc.Source{"repository": "busybox"},
+					}))
+				})
+
+				It("notes that the default image is used", func() {
+					Expect(stderrBuf).To(gbytes.Say("using default task image of type 'docker'"))
+				})
+
+				Context("when the task configures an image", func() {
+					BeforeEach(func() {
+						taskPlan.Config.RootfsURI = "some-image"
+					})
+
+					It("uses the configured image", func() {
+						_, _, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+						Expect(containerSpec.ImageSpec.ImageResource).To(BeNil())
+						Expect(containerSpec.ImageSpec.ImageURL).To(Equal("some-image"))
+					})
+				})
+			})
+
+			Context("when the team requires image digests", func() {
+				BeforeEach(func() {
+					stepMetadata.TeamName = "some-team"
+					stepMetadata.RequireImageDigests = true
+					taskPlan.Config.ImageResource = &atc.ImageResource{
+						Type:   "docker",
+						Source: atc.Source{"repository": "busybox"},
+					}
+				})
+
+				It("errors without running the task", func() {
+					Expect(stepErr).To(Equal(atc.ImageDigestRequiredError{
+						Team: "some-team",
+						Step: "some-task",
+					}))
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+
+				Context("when the image_resource is pinned by digest", func() {
+					BeforeEach(func() {
+						taskPlan.Config.ImageResource.Version = &atc.Version{"digest": "sha256:abc123"}
+					})
+
+					It("runs the task", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+					})
+				})
+			})
 		})
 
 		Context("when a run dir is specified", func() {
@@ -820,6 +960,20 @@ var _ = Describe("TaskStep", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
 
+				Context("when the task has output vars in an unknown artifact", func() {
+					BeforeEach(func() {
+						taskPlan.OutputVars = "some-unknown-output/vars.yml"
+					})
+
+					It("returns an error", func() {
+						Expect(stepErr).To(MatchError("unknown artifact source: 'some-unknown-output' in output vars file path 'some-unknown-output/vars.yml'"))
+					})
+
+					It("does not store any vars", func() {
+						Expect(state.StoreLocalVarCallCount()).To(BeZero())
+					})
+				})
+
 				Describe("the registered sources", func() {
 					var (
 						artifactSource1 worker.ArtifactSource
@@ -1007,6 +1161,48 @@ var _ = Describe("TaskStep", func() {
 							})
 						})
 					})
+
+					Context("when the task has output vars", func() {
+						BeforeEach(func() {
+							taskPlan.OutputVars = "some-output/vars.yml"
+
+							tgzBuffer := gbytes.NewBuffer()
+							fakeVolume1.StreamOutReturns(tgzBuffer, nil)
+
+							varsContent := "some-var: some-value\nnested:\n  field: 1\n"
+
+							zstdWriter := zstd.NewWriter(tgzBuffer)
+							defer zstdWriter.Close()
+
+							tarWriter := tar.NewWriter(zstdWriter)
+							defer tarWriter.Close()
+
+							err := tarWriter.WriteHeader(&tar.Header{
+								Name: "vars.yml",
+								Mode: 0644,
+								Size: int64(len(varsContent)),
+							})
+							Expect(err).NotTo(HaveOccurred())
+
+							_, err = tarWriter.Write([]byte(varsContent))
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("streams the vars file out of the output", func() {
+							_, path := fakeVolume1.StreamOutArgsForCall(0)
+							Expect(path).To(Equal("vars.yml"))
+						})
+
+						It("stores the vars under the task's name", func() {
+							Expect(state.StoreLocalVarCallCount()).To(Equal(1))
+							name, val := state.StoreLocalVarArgsForCall(0)
+							Expect(name).To(Equal("some-task"))
+							Expect(val).To(Equal(map[string]interface{}{
+								"some-var": "some-value",
+								"nested":   map[string]interface{}{"field": float64(1)},
+							}))
+						})
+					})
 				})
 			})
 
@@ -1025,6 +1221,17 @@ var _ = Describe("TaskStep", func() {
 				It("returns successfully", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
+
+				Context("when the task has output vars", func() {
+					BeforeEach(func() {
+						taskPlan.OutputVars = "some-output/vars.yml"
+					})
+
+					It("does not store them", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(state.StoreLocalVarCallCount()).To(BeZero())
+					})
+				})
 			})
 		})
 
@@ -1192,4 +1399,69 @@ var _ = Describe("TaskStep", func() {
 		})
 
 	})
+
+	Context("when the plan has a config path", func() {
+		var fakeConfigSource *workerfakes.FakeArtifactSource
+
+		BeforeEach(func() {
+			taskPlan.ConfigPath = "some-input/task.yml"
+			taskPlan.Vars = atc.Params{"static-var": "some-static-value"}
+
+			fakeConfigSource = new(workerfakes.FakeArtifactSource)
+			fakeConfigSource.StreamFileReturns(ioutil.NopCloser(strings.NewReader(`
+platform: some-platform
+rootfs_uri: some-image
+params:
+  STATIC: ((static-var))
+  LOCAL: ((some-step.some-field))
+  CRED: ((source-param))
+run:
+  path: ls
+`)), nil)
+
+			repo.RegisterSource("some-input", fakeConfigSource)
+
+			state.LocalVariablesReturns(vars.StaticVariables{
+				"some-step": map[string]interface{}{"some-field": "some-local-value"},
+			})
+
+			fakeClient.RunTaskStepReturns(worker.TaskResult{Status: 0})
+		})
+
+		It("interpolates the config with the plan's vars, creds, and vars from earlier steps", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+
+			_, _, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+			Expect(containerSpec.Env).To(ContainElement("STATIC=some-static-value"))
+			Expect(containerSpec.Env).To(ContainElement("LOCAL=some-local-value"))
+			Expect(containerSpec.Env).To(ContainElement("CRED=super-secret-source"))
+		})
+
+		Context("when a var from an earlier step has the same name as a cred", func() {
+			BeforeEach(func() {
+				state.LocalVariablesReturns(vars.StaticVariables{
+					"some-step":    map[string]interface{}{"some-field": "some-local-value"},
+					"source-param": "overridden",
+				})
+			})
+
+			It("interpolates the cred", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				_, _, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.Env).To(ContainElement("CRED=super-secret-source"))
+			})
+		})
+
+		Context("when a var from an earlier step is missing", func() {
+			BeforeEach(func() {
+				state.LocalVariablesReturns(vars.StaticVariables{})
+			})
+
+			It("returns an error", func() {
+				Expect(stepErr).To(HaveOccurred())
+				Expect(stepErr.Error()).To(ContainSubstring("some-step"))
+			})
+		})
+	})
 })
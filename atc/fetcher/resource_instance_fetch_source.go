@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -98,19 +99,33 @@ func (s *resourceInstanceFetchSource) Find() (resource.VersionedSource, bool, er
 		return nil, false, nil
 	}
 
-	metadata, err := s.dbResourceCacheFactory.ResourceCacheMetadata(s.resourceInstance.ResourceCache())
+	versionedSource, err := s.cachedVersionedSource(sLog, volume)
 	if err != nil {
-		sLog.Error("failed-to-get-resource-cache-metadata", err)
 		return nil, false, err
 	}
 
+	return versionedSource, true, nil
+}
+
+// cachedVersionedSource uses the worker's volume for the resource cache
+// rather than running the get.
+func (s *resourceInstanceFetchSource) cachedVersionedSource(logger lager.Logger, volume worker.Volume) (resource.VersionedSource, error) {
+	metric.ResourceCaches.Hit(s.containerSpec.ImageSpec.ResourceType, s.worker.Name())
+	s.countUse(logger)
+
+	metadata, err := s.dbResourceCacheFactory.ResourceCacheMetadata(s.resourceInstance.ResourceCache())
+	if err != nil {
+		logger.Error("failed-to-get-resource-cache-metadata", err)
+		return nil, err
+	}
+
 	s.logger.Debug("found-initialized-versioned-source", lager.Data{"version": s.resourceInstance.Version(), "metadata": metadata.ToATCMetadata()})
 
 	return resource.NewGetVersionedSource(
 		volume,
 		s.resourceInstance.Version(),
 		metadata.ToATCMetadata(),
-	), true, nil
+	), nil
 }
 
 // Create runs under the lock but we need to make sure volume does not exist
@@ -127,6 +142,21 @@ func (s *resourceInstanceFetchSource) Create(ctx context.Context) (resource.Vers
 		return versionedSource, nil
 	}
 
+	// hydrating the cache from the resource cache store is done here, under
+	// the lock, so that it is only downloaded once instead of on every lookup
+	hydratedVolume, found, err := s.worker.HydrateVolumeForResourceCache(ctx, sLog, s.resourceInstance.ResourceCache())
+	if err != nil {
+		sLog.Error("failed-to-hydrate-volume", err)
+		return nil, err
+	}
+
+	if found {
+		sLog.Debug("hydrated")
+		return s.cachedVersionedSource(sLog, hydratedVolume)
+	}
+
+	metric.ResourceCaches.Miss(s.containerSpec.ImageSpec.ResourceType, s.worker.Name())
+
 	s.containerSpec.BindMounts = []worker.BindMountSource{
 		&worker.CertsVolumeMount{Logger: s.logger},
 	}
@@ -181,7 +211,7 @@ func (s *resourceInstanceFetchSource) Create(ctx context.Context) (resource.Vers
 		return nil, err
 	}
 
-	err = volume.InitializeResourceCache(s.resourceInstance.ResourceCache())
+	err = volume.InitializeResourceCache(sLog, s.resourceInstance.ResourceCache())
 	if err != nil {
 		sLog.Error("failed-to-initialize-cache", err)
 		return nil, err
@@ -193,5 +223,17 @@ func (s *resourceInstanceFetchSource) Create(ctx context.Context) (resource.Vers
 		return nil, err
 	}
 
+	s.countUse(sLog)
+
 	return versionedSource, nil
 }
+
+// countUse records the use of the resource cache against the worker's team
+// and tags, so that the most used caches can be prewarmed on new workers
+// like it.
+func (s *resourceInstanceFetchSource) countUse(logger lager.Logger) {
+	err := s.dbResourceCacheFactory.CountResourceCacheUse(s.resourceInstance.ResourceCache(), s.worker.TeamID(), s.worker.Tags())
+	if err != nil {
+		logger.Error("failed-to-count-resource-cache-use", err)
+	}
+}
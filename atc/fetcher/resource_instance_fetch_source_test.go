@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/worker"
@@ -70,6 +71,8 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 		})
 
 		fakeWorker = new(workerfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorker.TagsReturns(atc.Tags{"some-tag"})
 		fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
 
 		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
@@ -120,6 +123,9 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 			metadata,
 			fakeDelegate,
 		)
+
+		// forget whatever other specs counted
+		metric.ResourceCaches.Delta()
 	})
 
 	AfterEach(func() {
@@ -143,6 +149,44 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 				Expect(found).To(BeTrue())
 				Expect(versionedSource).To(Equal(expectedInitializedVersionedSource))
 			})
+
+			It("counts a cache hit", func() {
+				_, _, err := fetchSource.Find()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metric.ResourceCaches.Delta()).To(Equal([]metric.CacheUsageStat{
+					{
+						CacheUsageKey:   metric.CacheUsageKey{ResourceType: "fake-resource-type", Worker: "some-worker"},
+						CacheUsageCount: metric.CacheUsageCount{Hits: 1},
+					},
+				}))
+			})
+
+			It("counts a use of the cache against the worker's tags", func() {
+				_, _, err := fetchSource.Find()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeResourceCacheFactory.CountResourceCacheUseCallCount()).To(Equal(1))
+
+				resourceCache, teamID, tags := fakeResourceCacheFactory.CountResourceCacheUseArgsForCall(0)
+				Expect(resourceCache).To(Equal(fakeUsedResourceCache))
+				Expect(teamID).To(BeZero())
+				Expect(tags).To(Equal([]string{"some-tag"}))
+			})
+
+			Context("when the worker belongs to a team", func() {
+				BeforeEach(func() {
+					fakeWorker.IsOwnedByTeamReturns(true)
+					fakeWorker.TeamIDReturns(42)
+				})
+
+				It("counts a use of the cache against the team", func() {
+					_, _, err := fetchSource.Find()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeResourceCacheFactory.CountResourceCacheUseCallCount()).To(Equal(1))
+
+					_, teamID, _ := fakeResourceCacheFactory.CountResourceCacheUseArgsForCall(0)
+					Expect(teamID).To(Equal(42))
+				})
+			})
 		})
 
 		Context("when there is no volume", func() {
@@ -156,6 +200,12 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 				Expect(found).To(BeFalse())
 				Expect(versionedSource).To(BeNil())
 			})
+
+			It("does not count anything", func() {
+				_, _, err := fetchSource.Find()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metric.ResourceCaches.Delta()).To(BeEmpty())
+			})
 		})
 	})
 
@@ -199,6 +249,57 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 				fakeResourceInstance.FindOnReturns(nil, false, nil)
 			})
 
+			It("tries to hydrate it from the resource cache store", func() {
+				Expect(fakeWorker.HydrateVolumeForResourceCacheCallCount()).To(Equal(1))
+				_, _, rc := fakeWorker.HydrateVolumeForResourceCacheArgsForCall(0)
+				Expect(rc).To(Equal(fakeUsedResourceCache))
+			})
+
+			Context("when the volume is hydrated", func() {
+				var hydratedVolume *workerfakes.FakeVolume
+
+				BeforeEach(func() {
+					hydratedVolume = new(workerfakes.FakeVolume)
+					fakeWorker.HydrateVolumeForResourceCacheReturns(hydratedVolume, true, nil)
+				})
+
+				It("does not fetch resource", func() {
+					Expect(initErr).NotTo(HaveOccurred())
+					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(0))
+				})
+
+				It("uses the hydrated volume", func() {
+					Expect(initErr).NotTo(HaveOccurred())
+					Expect(versionedSource).To(Equal(resource.NewGetVersionedSource(
+						hydratedVolume,
+						fakeResourceInstance.Version(),
+						[]atc.MetadataField{{Name: "some", Value: "metadata"}},
+					)))
+				})
+
+				It("counts a cache hit", func() {
+					Expect(metric.ResourceCaches.Delta()).To(Equal([]metric.CacheUsageStat{
+						{
+							CacheUsageKey:   metric.CacheUsageKey{ResourceType: "fake-resource-type", Worker: "some-worker"},
+							CacheUsageCount: metric.CacheUsageCount{Hits: 1},
+						},
+					}))
+				})
+			})
+
+			Context("when hydrating the volume fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeWorker.HydrateVolumeForResourceCacheReturns(nil, false, disaster)
+				})
+
+				It("returns the error without fetching the resource", func() {
+					Expect(initErr).To(Equal(disaster))
+					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(0))
+				})
+			})
+
 			It("creates container with volume and worker", func() {
 				Expect(initErr).NotTo(HaveOccurred())
 
@@ -226,10 +327,23 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 				Expect(fakeContainer.RunCallCount()).To(Equal(1))
 			})
 
+			It("counts a cache miss", func() {
+				Expect(metric.ResourceCaches.Delta()).To(Equal([]metric.CacheUsageStat{
+					{
+						CacheUsageKey:   metric.CacheUsageKey{ResourceType: "fake-resource-type", Worker: "some-worker"},
+						CacheUsageCount: metric.CacheUsageCount{Misses: 1},
+					},
+				}))
+			})
+
+			It("counts a use of the cache once it is initialized", func() {
+				Expect(fakeResourceCacheFactory.CountResourceCacheUseCallCount()).To(Equal(1))
+			})
+
 			It("initializes cache", func() {
 				Expect(initErr).NotTo(HaveOccurred())
 				Expect(fakeVolume.InitializeResourceCacheCallCount()).To(Equal(1))
-				rc := fakeVolume.InitializeResourceCacheArgsForCall(0)
+				_, rc := fakeVolume.InitializeResourceCacheArgsForCall(0)
 				Expect(rc).To(Equal(fakeUsedResourceCache))
 			})
 
@@ -12,6 +12,7 @@ type buildCollector struct {
 
 type buildFactory interface {
 	MarkNonInterceptibleBuilds() error
+	ClearSupersededPlans() error
 }
 
 func NewBuildCollector(buildFactory buildFactory) *buildCollector {
@@ -26,5 +27,10 @@ func (b *buildCollector) Run(ctx context.Context) error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	return b.buildFactory.MarkNonInterceptibleBuilds()
+	err := b.buildFactory.MarkNonInterceptibleBuilds()
+	if err != nil {
+		return err
+	}
+
+	return b.buildFactory.ClearSupersededPlans()
 }
@@ -26,5 +26,10 @@ func (c *checkCollector) Run(ctx context.Context) error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	return c.checkLifecycle.RemoveExpiredChecks(c.recyclePeriod)
+	err := c.checkLifecycle.RemoveExpiredChecks(c.recyclePeriod)
+	if err != nil {
+		return err
+	}
+
+	return c.checkLifecycle.RemoveExpiredResourceFetches(c.recyclePeriod)
 }
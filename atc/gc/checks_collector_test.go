@@ -29,5 +29,14 @@ var _ = Describe("CheckCollector", func() {
 			recyclePeriod := fakeCheckLifecycle.RemoveExpiredChecksArgsForCall(0)
 			Expect(recyclePeriod).To(Equal(time.Hour * 24))
 		})
+
+		It("tells the check lifecycle to remove expired resource fetches", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCheckLifecycle.RemoveExpiredResourceFetchesCallCount()).To(Equal(1))
+			recyclePeriod := fakeCheckLifecycle.RemoveExpiredResourceFetchesArgsForCall(0)
+			Expect(recyclePeriod).To(Equal(time.Hour * 24))
+		})
 	})
 })
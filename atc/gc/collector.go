@@ -14,6 +14,8 @@ type Collector interface {
 
 type aggregateCollector struct {
 	buildCollector                      Collector
+	pendingBuildCollector               Collector
+	teamCollector                       Collector
 	workerCollector                     Collector
 	resourceCacheUseCollector           Collector
 	resourceConfigCollector             Collector
@@ -22,16 +24,20 @@ type aggregateCollector struct {
 	containerCollector                  Collector
 	resourceConfigCheckSessionCollector Collector
 	artifactCollector                   Collector
+	imageLayerCollector                 Collector
 	checkCollector                      Collector
 }
 
 func NewCollector(
 	buildCollector Collector,
+	pendingBuildCollector Collector,
+	teamCollector Collector,
 	workers Collector,
 	resourceCacheUses Collector,
 	resourceConfigs Collector,
 	resourceCaches Collector,
 	artifactCollector Collector,
+	imageLayerCollector Collector,
 	checkCollector Collector,
 	volumes Collector,
 	containers Collector,
@@ -39,11 +45,14 @@ func NewCollector(
 ) Collector {
 	return &aggregateCollector{
 		buildCollector:                      buildCollector,
+		pendingBuildCollector:               pendingBuildCollector,
+		teamCollector:                       teamCollector,
 		workerCollector:                     workers,
 		resourceCacheUseCollector:           resourceCacheUses,
 		resourceConfigCollector:             resourceConfigs,
 		resourceCacheCollector:              resourceCaches,
 		artifactCollector:                   artifactCollector,
+		imageLayerCollector:                 imageLayerCollector,
 		checkCollector:                      checkCollector,
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
@@ -61,6 +70,16 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("failed-to-run-build-collector", err)
 	}
 
+	err = c.pendingBuildCollector.Run(ctx)
+	if err != nil {
+		logger.Error("failed-to-run-pending-build-collector", err)
+	}
+
+	err = c.teamCollector.Run(ctx)
+	if err != nil {
+		logger.Error("failed-to-run-team-collector", err)
+	}
+
 	err = c.workerCollector.Run(ctx)
 	if err != nil {
 		logger.Error("failed-to-run-worker-collector", err)
@@ -91,6 +110,11 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("artifact-collector", err)
 	}
 
+	err = c.imageLayerCollector.Run(ctx)
+	if err != nil {
+		logger.Error("image-layer-collector", err)
+	}
+
 	err = c.checkCollector.Run(ctx)
 	if err != nil {
 		logger.Error("check-collector", err)
@@ -15,11 +15,14 @@ var _ = Describe("Aggregate Collector", func() {
 		subject Collector
 
 		fakeBuildCollector                      *gcfakes.FakeCollector
+		fakePendingBuildCollector               *gcfakes.FakeCollector
+		fakeTeamCollector                       *gcfakes.FakeCollector
 		fakeWorkerCollector                     *gcfakes.FakeCollector
 		fakeResourceCacheUseCollector           *gcfakes.FakeCollector
 		fakeResourceConfigCollector             *gcfakes.FakeCollector
 		fakeResourceCacheCollector              *gcfakes.FakeCollector
 		fakeArtifactCollector                   *gcfakes.FakeCollector
+		fakeImageLayerCollector                 *gcfakes.FakeCollector
 		fakeCheckCollector                      *gcfakes.FakeCollector
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
@@ -31,11 +34,14 @@ var _ = Describe("Aggregate Collector", func() {
 
 	BeforeEach(func() {
 		fakeBuildCollector = new(gcfakes.FakeCollector)
+		fakePendingBuildCollector = new(gcfakes.FakeCollector)
+		fakeTeamCollector = new(gcfakes.FakeCollector)
 		fakeWorkerCollector = new(gcfakes.FakeCollector)
 		fakeResourceCacheUseCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCollector = new(gcfakes.FakeCollector)
 		fakeResourceCacheCollector = new(gcfakes.FakeCollector)
 		fakeArtifactCollector = new(gcfakes.FakeCollector)
+		fakeImageLayerCollector = new(gcfakes.FakeCollector)
 		fakeCheckCollector = new(gcfakes.FakeCollector)
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
@@ -43,11 +49,14 @@ var _ = Describe("Aggregate Collector", func() {
 
 		subject = NewCollector(
 			fakeBuildCollector,
+			fakePendingBuildCollector,
+			fakeTeamCollector,
 			fakeWorkerCollector,
 			fakeResourceCacheUseCollector,
 			fakeResourceConfigCollector,
 			fakeResourceCacheCollector,
 			fakeArtifactCollector,
+			fakeImageLayerCollector,
 			fakeCheckCollector,
 			fakeVolumeCollector,
 			fakeContainerCollector,
@@ -76,6 +85,8 @@ var _ = Describe("Aggregate Collector", func() {
 			})
 
 			It("runs the rest of collectors", func() {
+				Expect(fakePendingBuildCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeTeamCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeWorkerCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceCacheUseCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceConfigCollector.RunCallCount()).To(Equal(1))
@@ -294,6 +305,23 @@ var _ = Describe("Aggregate Collector", func() {
 										})
 									})
 
+									Context("when the image layer collector errors", func() {
+										BeforeEach(func() {
+											fakeImageLayerCollector.RunReturns(disaster)
+										})
+
+										It("does not return an error", func() {
+											Expect(err).NotTo(HaveOccurred())
+										})
+
+										It("runs the rest of collectors", func() {
+											Expect(fakeImageLayerCollector.RunCallCount()).To(Equal(1))
+											Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
+											Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+											Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+										})
+									})
+
 									Context("when the check collector succeeds", func() {
 										It("attempts to collect", func() {
 											Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
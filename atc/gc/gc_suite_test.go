@@ -50,6 +50,7 @@ var (
 	usedResourceType db.ResourceType
 	logger           *lagertest.TestLogger
 	fakeLogFunc      = func(logger lager.Logger, id lock.LockID) {}
+	fakeWaitFunc     = func(logger lager.Logger, id lock.LockID, wait time.Duration) {}
 )
 
 var _ = BeforeSuite(func() {
@@ -67,7 +68,7 @@ var _ = BeforeEach(func() {
 
 	dbConn = postgresRunner.OpenConn()
 
-	lockFactory = lock.NewLockFactory(postgresRunner.OpenSingleton(), fakeLogFunc, fakeLogFunc)
+	lockFactory = lock.NewLockFactory(postgresRunner.OpenSingleton(), fakeLogFunc, fakeLogFunc, fakeWaitFunc)
 
 	teamFactory = db.NewTeamFactory(dbConn, lockFactory)
 	buildFactory = db.NewBuildFactory(dbConn, lockFactory, 0)
@@ -0,0 +1,27 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type imageLayerCollector struct {
+	imageLayerLifecycle db.WorkerImageLayerLifecycle
+}
+
+func NewImageLayerCollector(imageLayerLifecycle db.WorkerImageLayerLifecycle) *imageLayerCollector {
+	return &imageLayerCollector{
+		imageLayerLifecycle: imageLayerLifecycle,
+	}
+}
+
+func (i *imageLayerCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("image-layer-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	return i.imageLayerLifecycle.RemoveUnusedImageLayers()
+}
@@ -0,0 +1,30 @@
+package gc_test
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImageLayerCollector", func() {
+	var collector gc.Collector
+	var fakeImageLayerLifecycle *dbfakes.FakeWorkerImageLayerLifecycle
+
+	BeforeEach(func() {
+		fakeImageLayerLifecycle = new(dbfakes.FakeWorkerImageLayerLifecycle)
+
+		collector = gc.NewImageLayerCollector(fakeImageLayerLifecycle)
+	})
+
+	Describe("Run", func() {
+		It("tells the image layer lifecycle to remove unused image layers", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeImageLayerLifecycle.RemoveUnusedImageLayersCallCount()).To(Equal(1))
+		})
+	})
+})
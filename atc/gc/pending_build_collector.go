@@ -0,0 +1,84 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type pendingBuildCollector struct {
+	buildFactory pendingBuildFactory
+	maxPending   time.Duration
+}
+
+type pendingBuildFactory interface {
+	GetStalePendingBuilds(time.Duration) ([]db.Build, error)
+}
+
+// NewPendingBuildCollector returns a collector which errors builds that have
+// been schedulable for longer than maxPending without starting, e.g. because
+// no workers match the tags of their inputs' resources, rather than leaving
+// them pending forever. Builds held on purpose, e.g. by serial groups or
+// concurrency pools, are left be, as is everything if maxPending is 0.
+func NewPendingBuildCollector(buildFactory pendingBuildFactory, maxPending time.Duration) *pendingBuildCollector {
+	return &pendingBuildCollector{
+		buildFactory: buildFactory,
+		maxPending:   maxPending,
+	}
+}
+
+func (c *pendingBuildCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("pending-build-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if c.maxPending == 0 {
+		return nil
+	}
+
+	builds, err := c.buildFactory.GetStalePendingBuilds(c.maxPending)
+	if err != nil {
+		logger.Error("failed-to-get-stale-pending-builds", err)
+		return err
+	}
+
+	for _, build := range builds {
+		pending := time.Since(build.SchedulableTime())
+
+		message := fmt.Sprintf(
+			"build could have been scheduled for %s without starting, which is longer than the maximum of %s",
+			pending.Round(time.Second),
+			c.maxPending,
+		)
+
+		errored, err := build.ErrorPending(atc.ErrorCodePendingTimedOut, message)
+		if err != nil {
+			logger.Error("failed-to-error-build", err, lager.Data{"build": build.ID()})
+			continue
+		}
+
+		if !errored {
+			continue
+		}
+
+		logger.Info("errored-build", lager.Data{"build": build.ID(), "pending": pending.String()})
+
+		metric.BuildPendingTimedOut{
+			PipelineName:    build.PipelineName(),
+			JobName:         build.JobName(),
+			BuildName:       build.Name(),
+			BuildID:         build.ID(),
+			TeamName:        build.TeamName(),
+			PendingDuration: pending,
+		}.Emit(logger)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PendingBuildCollector", func() {
+	var (
+		collector        gc.Collector
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		maxPending       time.Duration
+
+		fakeBuild *dbfakes.FakeBuild
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		maxPending = time.Hour
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.SchedulableTimeReturns(time.Now().Add(-2 * time.Hour))
+		fakeBuild.ErrorPendingReturns(true, nil)
+
+		fakeBuildFactory.GetStalePendingBuildsReturns([]db.Build{fakeBuild}, nil)
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewPendingBuildCollector(fakeBuildFactory, maxPending)
+		err = collector.Run(context.TODO())
+	})
+
+	It("looks for builds schedulable for longer than the max", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeBuildFactory.GetStalePendingBuildsCallCount()).To(Equal(1))
+		Expect(fakeBuildFactory.GetStalePendingBuildsArgsForCall(0)).To(Equal(time.Hour))
+	})
+
+	It("errors them, explaining why", func() {
+		Expect(fakeBuild.ErrorPendingCallCount()).To(Equal(1))
+		code, message := fakeBuild.ErrorPendingArgsForCall(0)
+		Expect(code).To(Equal(atc.ErrorCodePendingTimedOut))
+		Expect(message).To(ContainSubstring("build could have been scheduled for 2h0m0s without starting"))
+		Expect(message).To(ContainSubstring("maximum of 1h0m0s"))
+	})
+
+	Context("when erroring a build fails", func() {
+		var otherBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			fakeBuild.ErrorPendingReturns(false, errors.New("nope"))
+
+			otherBuild = new(dbfakes.FakeBuild)
+			fakeBuildFactory.GetStalePendingBuildsReturns([]db.Build{fakeBuild, otherBuild}, nil)
+		})
+
+		It("carries on with the rest", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherBuild.ErrorPendingCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the builds fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetStalePendingBuildsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+
+	Context("when the max is 0", func() {
+		BeforeEach(func() {
+			maxPending = 0
+		})
+
+		It("leaves pending builds be", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeBuildFactory.GetStalePendingBuildsCallCount()).To(BeZero())
+		})
+	})
+})
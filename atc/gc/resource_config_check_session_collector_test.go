@@ -52,7 +52,7 @@ var _ = Describe("ResourceConfigCheckSessionCollector", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceConfig := resourceConfigScope.ResourceConfig()
-			owner = db.NewResourceConfigCheckSessionContainerOwner(resourceConfig.ID(), resourceConfig.OriginBaseResourceType().ID, ownerExpiries)
+			owner = db.NewResourceConfigCheckSessionContainerOwner(resourceConfig.ID(), resourceConfig.OriginBaseResourceType().ID, defaultTeam.ID(), ownerExpiries)
 
 			workerFactory := db.NewWorkerFactory(dbConn)
 			defaultWorkerPayload := atc.Worker{
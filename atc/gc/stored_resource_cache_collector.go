@@ -0,0 +1,65 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type storedResourceCacheCollector struct {
+	cacheLifecycle db.ResourceCacheLifecycle
+	cacheStore     worker.ResourceCacheStore
+}
+
+// NewStoredResourceCacheCollector returns a Collector which deletes caches
+// from the resource cache store once their resource caches have been
+// collected from the database.
+func NewStoredResourceCacheCollector(cacheLifecycle db.ResourceCacheLifecycle, cacheStore worker.ResourceCacheStore) Collector {
+	return &storedResourceCacheCollector{
+		cacheLifecycle: cacheLifecycle,
+		cacheStore:     cacheStore,
+	}
+}
+
+func (srcc *storedResourceCacheCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("stored-resource-cache-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	storedIDs, err := srcc.cacheStore.List(logger)
+	if err != nil {
+		logger.Error("failed-to-list-stored-resource-caches", err)
+		return err
+	}
+
+	existingIDs, err := srcc.cacheLifecycle.ExistingResourceCacheIDs(logger, storedIDs)
+	if err != nil {
+		logger.Error("failed-to-find-existing-resource-caches", err)
+		return err
+	}
+
+	existing := map[int]bool{}
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+
+	for _, id := range storedIDs {
+		if existing[id] {
+			continue
+		}
+
+		err := srcc.cacheStore.Delete(logger, id)
+		if err != nil {
+			logger.Error("failed-to-delete-stored-resource-cache", err, lager.Data{"resource-cache": id})
+			continue
+		}
+
+		logger.Debug("deleted-stored-resource-cache", lager.Data{"resource-cache": id})
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoredResourceCacheCollector", func() {
+	var collector gc.Collector
+	var fakeResourceCacheLifecycle *dbfakes.FakeResourceCacheLifecycle
+	var fakeResourceCacheStore *workerfakes.FakeResourceCacheStore
+
+	BeforeEach(func() {
+		fakeResourceCacheLifecycle = new(dbfakes.FakeResourceCacheLifecycle)
+		fakeResourceCacheStore = new(workerfakes.FakeResourceCacheStore)
+
+		collector = gc.NewStoredResourceCacheCollector(fakeResourceCacheLifecycle, fakeResourceCacheStore)
+	})
+
+	Describe("Run", func() {
+		var runErr error
+
+		BeforeEach(func() {
+			fakeResourceCacheStore.ListReturns([]int{1, 2, 3}, nil)
+			fakeResourceCacheLifecycle.ExistingResourceCacheIDsReturns([]int{2}, nil)
+		})
+
+		JustBeforeEach(func() {
+			runErr = collector.Run(context.TODO())
+		})
+
+		It("deletes the stored caches whose resource caches no longer exist", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			_, ids := fakeResourceCacheLifecycle.ExistingResourceCacheIDsArgsForCall(0)
+			Expect(ids).To(Equal([]int{1, 2, 3}))
+
+			Expect(fakeResourceCacheStore.DeleteCallCount()).To(Equal(2))
+			_, deleted := fakeResourceCacheStore.DeleteArgsForCall(0)
+			Expect(deleted).To(Equal(1))
+			_, deleted = fakeResourceCacheStore.DeleteArgsForCall(1)
+			Expect(deleted).To(Equal(3))
+		})
+
+		Context("when deleting a stored cache fails", func() {
+			BeforeEach(func() {
+				fakeResourceCacheStore.DeleteReturnsOnCall(0, errors.New("nope"))
+			})
+
+			It("carries on deleting the others", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeResourceCacheStore.DeleteCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when finding the existing resource caches fails", func() {
+			BeforeEach(func() {
+				fakeResourceCacheLifecycle.ExistingResourceCacheIDsReturns(nil, errors.New("nope"))
+			})
+
+			It("deletes nothing", func() {
+				Expect(runErr).To(HaveOccurred())
+				Expect(fakeResourceCacheStore.DeleteCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+)
+
+type teamCollector struct {
+	teamFactory deletedTeamFactory
+	gracePeriod time.Duration
+}
+
+type deletedTeamFactory interface {
+	PurgeDeletedTeams(time.Duration) (int, error)
+}
+
+// NewTeamCollector returns a collector which purges teams that were deleted
+// longer than gracePeriod ago. Until then they can still be restored.
+func NewTeamCollector(teamFactory deletedTeamFactory, gracePeriod time.Duration) *teamCollector {
+	return &teamCollector{
+		teamFactory: teamFactory,
+		gracePeriod: gracePeriod,
+	}
+}
+
+func (c *teamCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("team-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	purged, err := c.teamFactory.PurgeDeletedTeams(c.gracePeriod)
+	if err != nil {
+		logger.Error("failed-to-purge-deleted-teams", err)
+		return err
+	}
+
+	if purged > 0 {
+		logger.Info("purged-deleted-teams", lager.Data{"count": purged})
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamCollector", func() {
+	var (
+		collector       gc.Collector
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewTeamCollector(fakeTeamFactory, 72*time.Hour)
+		err = collector.Run(context.TODO())
+	})
+
+	It("purges teams deleted longer than the grace period ago", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeTeamFactory.PurgeDeletedTeamsCallCount()).To(Equal(1))
+		Expect(fakeTeamFactory.PurgeDeletedTeamsArgsForCall(0)).To(Equal(72 * time.Hour))
+	})
+
+	Context("when purging fails", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.PurgeDeletedTeamsReturns(0, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError("disaster"))
+		})
+	})
+})
@@ -52,6 +52,12 @@ func (vc *volumeCollector) Run(ctx context.Context) error {
 		logger.Error("failed-to-clean-up-missing-volumes", err)
 	}
 
+	_, err = vc.volumeRepository.RemoveExpiredVolumeLeases()
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		logger.Error("failed-to-clean-up-expired-volume-leases", err)
+	}
+
 	return errs
 }
 
@@ -101,6 +107,13 @@ func (vc *volumeCollector) markOrphanedVolumesAsDestroying(logger lager.Logger)
 
 		_, err = orphanedVolume.Destroying()
 		if err != nil {
+			if err == db.ErrVolumeLeased {
+				// it's being streamed or about to be mounted; try again
+				// once it has been released
+				vLog.Debug("volume-is-leased")
+				continue
+			}
+
 			vLog.Error("failed-to-transition", err)
 			continue
 		}
@@ -83,6 +83,10 @@ var _ = Describe("VolumeCollector", func() {
 				Expect(fakeVolumeRepository.RemoveMissingVolumesCallCount()).To(Equal(1))
 				Expect(fakeVolumeRepository.RemoveMissingVolumesArgsForCall(0)).To(Equal(missingVolumeGracePeriod))
 			})
+
+			It("deletes expired volume leases", func() {
+				Expect(fakeVolumeRepository.RemoveExpiredVolumeLeasesCallCount()).To(Equal(1))
+			})
 		})
 
 		Context("when there are failed volumes", func() {
@@ -109,7 +113,10 @@ var _ = Describe("VolumeCollector", func() {
 		})
 
 		Context("when there are orphaned volumes", func() {
-			var expectedOrphanedVolumeHandles []string
+			var (
+				expectedOrphanedVolumeHandles []string
+				orphanedVolume                db.CreatedVolume
+			)
 
 			JustBeforeEach(func() {
 				creatingContainer2, err = worker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", team.ID()), db.ContainerMetadata{
@@ -120,9 +127,9 @@ var _ = Describe("VolumeCollector", func() {
 
 				creatingVolume1, err := volumeRepository.CreateContainerVolume(team.ID(), worker.Name(), creatingContainer1, "some-path-1")
 				Expect(err).NotTo(HaveOccurred())
-				expectedOrphanedVolumeHandles = append(expectedOrphanedVolumeHandles, creatingVolume1.Handle())
+				expectedOrphanedVolumeHandles = []string{creatingVolume1.Handle()}
 
-				_, err = creatingVolume1.Created()
+				orphanedVolume, err = creatingVolume1.Created()
 				Expect(err).NotTo(HaveOccurred())
 
 				creatingVolume2, err := volumeRepository.CreateContainerVolume(team.ID(), worker.Name(), creatingContainer2, "some-path-1")
@@ -155,6 +162,35 @@ var _ = Describe("VolumeCollector", func() {
 
 				Expect(destroyingVolumes).To(Equal(expectedOrphanedVolumeHandles))
 			})
+
+			Context("when an orphaned volume is leased", func() {
+				var lease db.VolumeLease
+
+				JustBeforeEach(func() {
+					var leased bool
+					lease, leased, err = orphanedVolume.Lease(time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(leased).To(BeTrue())
+				})
+
+				It("leaves it alone until the lease is released", func() {
+					err = volumeCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					destroyingVolumes, err := volumeRepository.GetDestroyingVolumes(worker.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(destroyingVolumes).To(BeEmpty())
+
+					Expect(lease.Release()).To(Succeed())
+
+					err = volumeCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					destroyingVolumes, err = volumeRepository.GetDestroyingVolumes(worker.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(destroyingVolumes).To(Equal(expectedOrphanedVolumeHandles))
+				})
+			})
 		})
 	})
 })
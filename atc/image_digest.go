@@ -0,0 +1,70 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PinnedByDigest returns whether the image resource refers to its image by
+// digest rather than only by tag, so that the same image is run every time.
+// The digest may be given as the version to fetch, in the source, or as part
+// of the repository, e.g. "some/image@sha256:...".
+func (image ImageResource) PinnedByDigest() bool {
+	if image.Version != nil && isDigest((*image.Version)["digest"]) {
+		return true
+	}
+
+	if digest, ok := image.Source["digest"].(string); ok && isDigest(digest) {
+		return true
+	}
+
+	if repository, ok := image.Source["repository"].(string); ok {
+		at := strings.LastIndex(repository, "@")
+		if at != -1 && isDigest(repository[at+1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDigest(digest string) bool {
+	colon := strings.Index(digest, ":")
+	return colon > 0 && colon < len(digest)-1
+}
+
+// ImagesNotPinnedByDigest returns a message for each image_resource of the
+// config's tasks which is not pinned by digest. Tasks whose config is loaded
+// from a file are checked when they run instead.
+func (c Config) ImagesNotPinnedByDigest() []string {
+	var messages []string
+
+	for _, job := range c.Jobs {
+		for _, plan := range job.Plans() {
+			if plan.TaskConfig == nil || plan.TaskConfig.ImageResource == nil {
+				continue
+			}
+
+			if !plan.TaskConfig.ImageResource.PinnedByDigest() {
+				messages = append(messages, fmt.Sprintf("jobs.%s.plan.task(%s).config.image_resource must be pinned by digest", job.Name, plan.Task))
+			}
+		}
+	}
+
+	return messages
+}
+
+// ImageDigestRequiredError is returned when a task of a team which requires
+// image digests configures an image_resource which is not pinned by one.
+type ImageDigestRequiredError struct {
+	Team string
+	Step string
+}
+
+func (err ImageDigestRequiredError) Error() string {
+	return fmt.Sprintf("the image_resource of task '%s' must be pinned by digest, as team '%s' requires image digests", err.Step, err.Team)
+}
+
+func (ImageDigestRequiredError) ErrorCode() ErrorCode {
+	return ErrorCodeImageDigestRequired
+}
@@ -0,0 +1,92 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image digests", func() {
+	Describe("ImageResource.PinnedByDigest", func() {
+		It("is pinned by a digest version", func() {
+			image := atc.ImageResource{
+				Type:    "registry-image",
+				Source:  atc.Source{"repository": "some/image"},
+				Version: &atc.Version{"digest": "sha256:abc123"},
+			}
+			Expect(image.PinnedByDigest()).To(BeTrue())
+		})
+
+		It("is pinned by a digest in the source", func() {
+			image := atc.ImageResource{
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some/image", "digest": "sha256:abc123"},
+			}
+			Expect(image.PinnedByDigest()).To(BeTrue())
+		})
+
+		It("is pinned by a digest in the repository", func() {
+			image := atc.ImageResource{
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some/image@sha256:abc123"},
+			}
+			Expect(image.PinnedByDigest()).To(BeTrue())
+		})
+
+		It("is not pinned by a tag", func() {
+			image := atc.ImageResource{
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some/image", "tag": "1.2.3"},
+			}
+			Expect(image.PinnedByDigest()).To(BeFalse())
+		})
+
+		It("is not pinned by a malformed digest", func() {
+			image := atc.ImageResource{
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some/image@sha256:"},
+			}
+			Expect(image.PinnedByDigest()).To(BeFalse())
+		})
+	})
+
+	Describe("Config.ImagesNotPinnedByDigest", func() {
+		It("lists the tasks whose image_resource is not pinned", func() {
+			config := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{
+								Task: "pinned",
+								TaskConfig: &atc.TaskConfig{
+									ImageResource: &atc.ImageResource{
+										Type:   "registry-image",
+										Source: atc.Source{"repository": "some/image@sha256:abc123"},
+									},
+								},
+							},
+							{
+								Task: "unpinned",
+								TaskConfig: &atc.TaskConfig{
+									ImageResource: &atc.ImageResource{
+										Type:   "registry-image",
+										Source: atc.Source{"repository": "some/image"},
+									},
+								},
+							},
+							{
+								Task:           "from-file",
+								TaskConfigPath: "some-input/task.yml",
+							},
+						},
+					},
+				},
+			}
+
+			Expect(config.ImagesNotPinnedByDigest()).To(Equal([]string{
+				"jobs.some-job.plan.task(unpinned).config.image_resource must be pinned by digest",
+			}))
+		})
+	})
+})
@@ -14,6 +14,10 @@ type Job struct {
 	TransitionBuild      *Build `json:"transition_build,omitempty"`
 	HasNewInputs         bool   `json:"has_new_inputs,omitempty"`
 
+	SchedulingWindows *SchedulingWindows `json:"scheduling_windows,omitempty"`
+
+	Labels Labels `json:"labels,omitempty"`
+
 	Inputs  []JobInput  `json:"inputs"`
 	Outputs []JobOutput `json:"outputs"`
 
@@ -28,6 +32,8 @@ type JobInput struct {
 	Version  *VersionConfig `json:"version,omitempty"`
 	Params   Params         `json:"params,omitempty"`
 	Tags     Tags           `json:"tags,omitempty"`
+
+	FallbackAfter int `json:"fallback_after,omitempty"`
 }
 
 type JobOutput struct {
@@ -44,3 +50,24 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+// ScheduleDryRun is the outcome of determining a job's next inputs without
+// creating a build. Inputs is only set if the inputs resolved; otherwise
+// Reason explains why they did not.
+type ScheduleDryRun struct {
+	Resolved          bool         `json:"resolved"`
+	Inputs            []BuildInput `json:"inputs,omitempty"`
+	UnsatisfiedInputs []string     `json:"unsatisfied_inputs,omitempty"`
+	Reason            string       `json:"reason,omitempty"`
+	ErrorCode         ErrorCode    `json:"error_code,omitempty"`
+}
+
+// EmbeddedStatus is the status of a job, or of a pipeline's job in the worst
+// state, as shown by its badge. It is meant to be embedded in READMEs and
+// dashboards, so it reveals no more of the pipeline than the badge does.
+type EmbeddedStatus struct {
+	Status  string `json:"status"`
+	Job     string `json:"job,omitempty"`
+	Build   string `json:"build,omitempty"`
+	EndTime int64  `json:"end_time,omitempty"`
+}
@@ -1,5 +1,10 @@
 package atc
 
+import (
+	"sort"
+	"time"
+)
+
 type JobConfig struct {
 	Name    string `json:"name"`
 	OldName string `json:"old_name,omitempty"`
@@ -14,6 +19,24 @@ type JobConfig struct {
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	Gates []GateConfig `json:"gates,omitempty"`
+
+	// After are jobs whose builds must have finished, with the latest one
+	// succeeding, before any of the job's builds are started. Unlike passed
+	// constraints they order jobs without sharing a resource.
+	After []string `json:"after,omitempty"`
+
+	// SchedulingWindows override the pipeline's scheduling windows.
+	SchedulingWindows *SchedulingWindows `json:"scheduling_windows,omitempty"`
+
+	// Labels are attached to the metrics of the job's builds along with the
+	// pipeline's labels, overriding any with the same name.
+	Labels Labels `json:"labels,omitempty"`
+
+	// ConcurrencyPools are the team's pools which the job's builds are
+	// members of while they run.
+	ConcurrencyPools []string `json:"concurrency_pools,omitempty"`
+
 	Abort   *PlanConfig `json:"on_abort,omitempty"`
 	Error   *PlanConfig `json:"on_error,omitempty"`
 	Failure *PlanConfig `json:"on_failure,omitempty"`
@@ -28,6 +51,42 @@ type BuildLogRetention struct {
 	Days   int `json:"days,omitempty"`
 }
 
+// GateConfig is an external condition which must report healthy before any
+// of the job's builds are started. Exactly one of HTTP or Metric is set.
+type GateConfig struct {
+	Name string `json:"name"`
+
+	HTTP   *HTTPGateConfig   `json:"http,omitempty"`
+	Metric *MetricGateConfig `json:"metric,omitempty"`
+
+	// CheckEvery is how long the gate's status is reused before the
+	// condition is evaluated again.
+	CheckEvery string `json:"check_every,omitempty"`
+}
+
+// MaxHTTPGateTimeout is the longest an HTTP gate may wait for its URL to
+// respond, so that one slow gate cannot hold up the scheduler's probes.
+const MaxHTTPGateTimeout = time.Minute
+
+// HTTPGateConfig probes a URL, which is healthy if it responds with the
+// expected status, or any 2xx status if none is given. Credentials may be
+// used in its headers.
+type HTTPGateConfig struct {
+	URL            string            `json:"url"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	ExpectedStatus int               `json:"expected_status,omitempty"`
+	Timeout        string            `json:"timeout,omitempty"`
+}
+
+// MetricGateConfig queries a metric from one of the providers configured
+// on the ATC, which is healthy while the value is within Min and Max.
+type MetricGateConfig struct {
+	Provider string   `json:"provider"`
+	Query    string   `json:"query"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{
 		Abort:   config.Abort,
@@ -62,6 +121,25 @@ func (config JobConfig) GetSerialGroups() []string {
 	return []string{}
 }
 
+// Pools returns the concurrency pools which the job's builds are members of
+// for as long as they run. Pools joined by the job's steps are only joined
+// while those steps run.
+func (config JobConfig) Pools() []string {
+	seen := map[string]bool{}
+	pools := []string{}
+
+	for _, name := range config.ConcurrencyPools {
+		if !seen[name] {
+			seen[name] = true
+			pools = append(pools, name)
+		}
+	}
+
+	sort.Strings(pools)
+
+	return pools
+}
+
 func (config JobConfig) Plans() []PlanConfig {
 	plan := collectPlans(PlanConfig{
 		Do:      &config.Plan,
@@ -167,6 +245,8 @@ func (config JobConfig) Inputs() []JobInput {
 				Trigger:  plan.Trigger,
 				Params:   plan.Params,
 				Tags:     plan.Tags,
+
+				FallbackAfter: plan.FallbackAfter,
 			})
 		}
 	}
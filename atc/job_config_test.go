@@ -99,6 +99,35 @@ var _ = Describe("JobConfig", func() {
 		})
 	})
 
+	Describe("Pools", func() {
+		It("returns the pools joined by the job, but not those joined by its steps", func() {
+			jobConfig := atc.JobConfig{
+				ConcurrencyPools: []string{"staging", "integration-db"},
+				Plan: atc.PlanSequence{
+					{Get: "repo"},
+					{
+						Task:             "integration",
+						ConcurrencyPools: []string{"integration-db", "browsers"},
+						Ensure: &atc.PlanConfig{
+							Task:             "cleanup",
+							ConcurrencyPools: []string{"cleanup"},
+						},
+					},
+				},
+			}
+
+			Expect(jobConfig.Pools()).To(Equal([]string{"integration-db", "staging"}))
+		})
+
+		It("returns an empty slice if only the job's steps join pools", func() {
+			jobConfig := atc.JobConfig{
+				Plan: atc.PlanSequence{{Get: "repo", ConcurrencyPools: []string{"integration-db"}}},
+			}
+
+			Expect(jobConfig.Pools()).To(BeEmpty())
+		})
+	})
+
 	Describe("Inputs", func() {
 		var (
 			jobConfig atc.JobConfig
@@ -0,0 +1,48 @@
+package atc
+
+// JobStatistics summarizes how a job's builds went over each of a number of
+// windows of time ending now, for SLO dashboards and spotting builds getting
+// slower without fetching every build.
+type JobStatistics struct {
+	Windows []JobStatisticsWindow `json:"windows"`
+}
+
+// JobStatisticsWindow compares the builds which finished within the window
+// with the builds which finished in the window of the same length before it.
+type JobStatisticsWindow struct {
+	// Window is the length of the window, e.g. "24h0m0s".
+	Window string `json:"window"`
+
+	Current  BuildStatistics `json:"current"`
+	Previous BuildStatistics `json:"previous"`
+
+	Trend JobStatisticsTrend `json:"trend"`
+}
+
+// BuildStatistics summarizes a set of finished builds. Durations are in
+// seconds and only cover the builds which succeeded, so failures which end
+// early don't hide builds getting slower.
+type BuildStatistics struct {
+	Builds    int `json:"builds"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Errored   int `json:"errored"`
+	Aborted   int `json:"aborted"`
+
+	// SuccessRate is the share of the builds which weren't aborted which
+	// succeeded, from 0 to 1.
+	SuccessRate float64 `json:"success_rate"`
+
+	MeanDuration   float64 `json:"mean_duration"`
+	MedianDuration float64 `json:"median_duration"`
+	P95Duration    float64 `json:"p95_duration"`
+}
+
+// JobStatisticsTrend is how the current window's statistics changed from the
+// previous window's. Each change is left out when either window has nothing
+// to compare.
+type JobStatisticsTrend struct {
+	SuccessRateChange    *float64 `json:"success_rate_change,omitempty"`
+	MedianDurationChange *float64 `json:"median_duration_change,omitempty"`
+	P95DurationChange    *float64 `json:"p95_duration_change,omitempty"`
+}
@@ -0,0 +1,104 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Labels describe workers, pipelines and jobs with key/value pairs, e.g. a
+// worker's zone or the service a pipeline deploys. Steps select the workers
+// they can run on with labels of their own, and the API can filter by them.
+type Labels map[string]string
+
+// Matches returns true if the labels have every label of the selector with
+// the same value.
+func (labels Labels) Matches(selector Labels) bool {
+	for name, value := range selector {
+		if actual, found := labels[name]; !found || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Merge returns the labels with the given labels added, overriding any with
+// the same name.
+func (labels Labels) Merge(overrides Labels) Labels {
+	if len(labels) == 0 && len(overrides) == 0 {
+		return nil
+	}
+
+	merged := Labels{}
+	for name, value := range labels {
+		merged[name] = value
+	}
+
+	for name, value := range overrides {
+		merged[name] = value
+	}
+
+	return merged
+}
+
+func (labels Labels) Validate() error {
+	names := []string{}
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	errorMessages := []string{}
+	for _, name := range names {
+		if !labelNameRegexp.MatchString(name) {
+			errorMessages = append(errorMessages, fmt.Sprintf("invalid label name: '%s'", name))
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// LabelSelector selects things by their labels. Labels given without a value
+// only have to be set.
+type LabelSelector struct {
+	Values Labels
+	Names  []string
+}
+
+// ParseLabelSelector parses label query params, each of which is either
+// NAME:VALUE or NAME.
+func ParseLabelSelector(params []string) (LabelSelector, error) {
+	selector := LabelSelector{Values: Labels{}}
+
+	for _, param := range params {
+		segs := strings.SplitN(param, ":", 2)
+		if segs[0] == "" {
+			return LabelSelector{}, errors.New("malformed label selector: " + param)
+		}
+
+		if len(segs) == 1 {
+			selector.Names = append(selector.Names, segs[0])
+		} else {
+			selector.Values[segs[0]] = segs[1]
+		}
+	}
+
+	return selector, nil
+}
+
+func (selector LabelSelector) Matches(labels Labels) bool {
+	for _, name := range selector.Names {
+		if _, found := labels[name]; !found {
+			return false
+		}
+	}
+
+	return labels.Matches(selector.Values)
+}
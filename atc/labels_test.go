@@ -0,0 +1,52 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Labels", func() {
+	Describe("Validate", func() {
+		It("accepts names made of letters, numbers and separators", func() {
+			Expect(atc.Labels{"service": "web", "owner.team": "a", "tier-1_x/y": ""}.Validate()).To(Succeed())
+		})
+
+		It("rejects other names", func() {
+			err := atc.Labels{"": "a", "with space": "b", "-leading": "c"}.Validate()
+			Expect(err).To(MatchError("invalid label name: ''\ninvalid label name: '-leading'\ninvalid label name: 'with space'"))
+		})
+	})
+
+	Describe("Merge", func() {
+		It("overrides labels with the same name", func() {
+			merged := atc.Labels{"service": "web", "tier": "1"}.Merge(atc.Labels{"tier": "2"})
+			Expect(merged).To(Equal(atc.Labels{"service": "web", "tier": "2"}))
+		})
+	})
+
+	Describe("LabelSelector", func() {
+		labels := atc.Labels{"service": "web", "tier": "1"}
+
+		It("matches labels with the given values and names", func() {
+			selector, err := atc.ParseLabelSelector([]string{"service:web", "tier"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selector.Matches(labels)).To(BeTrue())
+		})
+
+		It("does not match when a value differs or a name is missing", func() {
+			selector, err := atc.ParseLabelSelector([]string{"service:api"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selector.Matches(labels)).To(BeFalse())
+
+			selector, err = atc.ParseLabelSelector([]string{"owner"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selector.Matches(labels)).To(BeFalse())
+		})
+
+		It("errors when a selector has no name", func() {
+			_, err := atc.ParseLabelSelector([]string{":web"})
+			Expect(err).To(MatchError("malformed label selector: :web"))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/checkpolicy"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/engine"
 )
@@ -12,11 +13,13 @@ func NewChecker(
 	logger lager.Logger,
 	checkFactory db.CheckFactory,
 	engine engine.Engine,
+	checkPolicy checkpolicy.Enforcer,
 ) *checker {
 	return &checker{
 		logger:       logger,
 		checkFactory: checkFactory,
 		engine:       engine,
+		checkPolicy:  checkPolicy,
 	}
 }
 
@@ -25,6 +28,7 @@ type checker struct {
 
 	checkFactory db.CheckFactory
 	engine       engine.Engine
+	checkPolicy  checkpolicy.Enforcer
 }
 
 func (c *checker) Run(ctx context.Context) error {
@@ -42,8 +46,23 @@ func (c *checker) Run(ctx context.Context) error {
 			"check": check.ID(),
 		})
 
+		release, acquired, err := c.checkPolicy.Acquire(btLog, check.TeamName())
+		if err != nil {
+			btLog.Error("failed-to-acquire-check-slot", err)
+			continue
+		}
+
+		if !acquired {
+			// the check stays started, so it will be run once the team has
+			// fewer checks running
+			continue
+		}
+
 		engineCheck := c.engine.NewCheck(check)
-		go engineCheck.Run(btLog)
+		go func() {
+			defer release()
+			engineCheck.Run(btLog)
+		}()
 	}
 
 	return nil
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/concourse/atc/checkpolicy/checkpolicyfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine"
@@ -25,6 +27,7 @@ var _ = Describe("Checker", func() {
 
 		fakeCheckFactory *dbfakes.FakeCheckFactory
 		fakeEngine       *enginefakes.FakeEngine
+		fakeCheckPolicy  *checkpolicyfakes.FakeEnforcer
 
 		checker Checker
 		logger  *lagertest.TestLogger
@@ -33,12 +36,15 @@ var _ = Describe("Checker", func() {
 	BeforeEach(func() {
 		fakeCheckFactory = new(dbfakes.FakeCheckFactory)
 		fakeEngine = new(enginefakes.FakeEngine)
+		fakeCheckPolicy = new(checkpolicyfakes.FakeEnforcer)
+		fakeCheckPolicy.AcquireReturns(func() {}, true, nil)
 
 		logger = lagertest.NewTestLogger("test")
 		checker = lidar.NewChecker(
 			logger,
 			fakeCheckFactory,
 			fakeEngine,
+			fakeCheckPolicy,
 		)
 	})
 
@@ -63,11 +69,14 @@ var _ = Describe("Checker", func() {
 
 			BeforeEach(func() {
 
-				fakeCheckFactory.StartedChecksReturns([]db.Check{
-					new(dbfakes.FakeCheck),
-					new(dbfakes.FakeCheck),
-					new(dbfakes.FakeCheck),
-				}, nil)
+				fakeChecks := []db.Check{}
+				for _, teamName := range []string{"some-team", "some-team", "other-team"} {
+					fakeCheck := new(dbfakes.FakeCheck)
+					fakeCheck.TeamNameReturns(teamName)
+					fakeChecks = append(fakeChecks, fakeCheck)
+				}
+
+				fakeCheckFactory.StartedChecksReturns(fakeChecks, nil)
 
 				engineChecks = []*enginefakes.FakeRunnable{}
 				fakeEngine.NewCheckStub = func(build db.Check) engine.Runnable {
@@ -86,6 +95,46 @@ var _ = Describe("Checker", func() {
 				Eventually(engineChecks[1].RunCallCount).Should(Equal(1))
 				Eventually(engineChecks[2].RunCallCount).Should(Equal(1))
 			})
+
+			It("holds one of the team's concurrent checks until each check finishes", func() {
+				released := make(chan string, 3)
+				fakeCheckPolicy.AcquireStub = func(_ lager.Logger, teamName string) (func(), bool, error) {
+					return func() { released <- teamName }, true, nil
+				}
+
+				err = checker.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeCheckPolicy.AcquireCallCount()).To(Equal(6))
+				_, teamName := fakeCheckPolicy.AcquireArgsForCall(5)
+				Expect(teamName).To(Equal("other-team"))
+
+				Eventually(released).Should(HaveLen(3))
+			})
+
+			Context("when a team is at its check concurrency limit", func() {
+				BeforeEach(func() {
+					fakeCheckPolicy.AcquireStub = func(_ lager.Logger, teamName string) (func(), bool, error) {
+						return func() {}, teamName != "some-team", nil
+					}
+				})
+
+				It("only runs the checks of other teams", func() {
+					Expect(engineChecks).To(HaveLen(1))
+					Eventually(engineChecks[0].RunCallCount).Should(Equal(1))
+				})
+			})
+
+			Context("when acquiring a check slot fails", func() {
+				BeforeEach(func() {
+					fakeCheckPolicy.AcquireReturns(nil, false, errors.New("nope"))
+				})
+
+				It("does not run the checks", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(engineChecks).To(BeEmpty())
+				})
+			})
 		})
 	})
 })
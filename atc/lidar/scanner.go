@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/checkpolicy"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/pkg/errors"
@@ -17,6 +18,7 @@ func NewScanner(
 	secrets creds.Secrets,
 	defaultCheckTimeout time.Duration,
 	defaultCheckInterval time.Duration,
+	checkPolicy checkpolicy.Enforcer,
 ) *scanner {
 	return &scanner{
 		logger:               logger,
@@ -24,6 +26,7 @@ func NewScanner(
 		secrets:              secrets,
 		defaultCheckTimeout:  defaultCheckTimeout,
 		defaultCheckInterval: defaultCheckInterval,
+		checkPolicy:          checkPolicy,
 	}
 }
 
@@ -34,6 +37,7 @@ type scanner struct {
 	secrets              creds.Secrets
 	defaultCheckTimeout  time.Duration
 	defaultCheckInterval time.Duration
+	checkPolicy          checkpolicy.Enforcer
 }
 
 func (s *scanner) Run(ctx context.Context) error {
@@ -99,13 +103,10 @@ func (s *scanner) check(checkable db.Checkable, resourceTypes db.ResourceTypes)
 		}
 	}
 
-	interval := s.defaultCheckInterval
-	if every := checkable.CheckEvery(); every != "" {
-		interval, err = time.ParseDuration(every)
-		if err != nil {
-			s.logger.Error("failed-to-parse-check-every", err)
-			return err
-		}
+	interval, err := s.checkPolicy.Interval(s.logger, checkable.TeamName(), checkable.CheckEvery(), s.defaultCheckInterval)
+	if err != nil {
+		s.logger.Error("failed-to-parse-check-every", err)
+		return err
 	}
 
 	if time.Now().Before(checkable.LastCheckEndTime().Add(interval)) {
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy/checkpolicyfakes"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -26,6 +28,7 @@ var _ = Describe("Scanner", func() {
 
 		fakeCheckFactory *dbfakes.FakeCheckFactory
 		fakeSecrets      *credsfakes.FakeSecrets
+		fakeCheckPolicy  *checkpolicyfakes.FakeEnforcer
 
 		logger  *lagertest.TestLogger
 		scanner Scanner
@@ -34,6 +37,10 @@ var _ = Describe("Scanner", func() {
 	BeforeEach(func() {
 		fakeCheckFactory = new(dbfakes.FakeCheckFactory)
 		fakeSecrets = new(credsfakes.FakeSecrets)
+		fakeCheckPolicy = new(checkpolicyfakes.FakeEnforcer)
+		fakeCheckPolicy.IntervalStub = func(_ lager.Logger, _ string, checkEvery string, defaultInterval time.Duration) (time.Duration, error) {
+			return (*atc.CheckPolicy)(nil).Interval(checkEvery, defaultInterval)
+		}
 
 		logger = lagertest.NewTestLogger("test")
 		scanner = lidar.NewScanner(
@@ -42,6 +49,7 @@ var _ = Describe("Scanner", func() {
 			fakeSecrets,
 			time.Minute*1,
 			time.Minute*1,
+			fakeCheckPolicy,
 		)
 	})
 
@@ -175,6 +183,25 @@ var _ = Describe("Scanner", func() {
 								})
 							})
 
+							Context("when the team's check policy raises the interval", func() {
+								BeforeEach(func() {
+									fakeResource.TeamNameReturns("some-team")
+									fakeResource.LastCheckEndTimeReturns(time.Now().Add(-time.Minute))
+									fakeCheckPolicy.IntervalReturns(time.Hour, nil)
+								})
+
+								It("applies the policy to the resource's check_every", func() {
+									_, teamName, checkEvery, defaultInterval := fakeCheckPolicy.IntervalArgsForCall(0)
+									Expect(teamName).To(Equal("some-team"))
+									Expect(checkEvery).To(Equal("10s"))
+									Expect(defaultInterval).To(Equal(time.Minute))
+								})
+
+								It("does not check", func() {
+									Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+								})
+							})
+
 							Context("when the checkable has a pinned version", func() {
 								BeforeEach(func() {
 									fakeResource.CurrentPinnedVersionReturns(atc.Version{"some": "version"})
@@ -0,0 +1,21 @@
+package atc
+
+// Lock is a database advisory lock held or awaited by an ATC.
+//
+// AcquiredAt is only known for locks held by the ATC which served the
+// request.
+type Lock struct {
+	Type       string       `json:"type"`
+	ID         []int        `json:"id"`
+	Holder     *LockHolder  `json:"holder,omitempty"`
+	AcquiredAt int64        `json:"acquired_at,omitempty"`
+	Waiting    []LockHolder `json:"waiting,omitempty"`
+}
+
+// LockHolder identifies the database session holding or waiting for a lock.
+type LockHolder struct {
+	PID             int    `json:"pid"`
+	ApplicationName string `json:"application_name,omitempty"`
+	ClientAddr      string `json:"client_addr,omitempty"`
+	ConnectedAt     int64  `json:"connected_at,omitempty"`
+}
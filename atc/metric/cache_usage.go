@@ -0,0 +1,111 @@
+package metric
+
+import (
+	"sort"
+	"sync"
+)
+
+// ResourceCaches counts whether fetching a resource found its cache already
+// initialized on the chosen worker.
+var ResourceCaches CacheUsage
+
+// CacheUsage counts cache hits and misses by resource type and worker. The
+// zero value is ready to use.
+type CacheUsage struct {
+	mu sync.Mutex
+
+	totals  map[CacheUsageKey]CacheUsageCount
+	emitted map[CacheUsageKey]CacheUsageCount
+}
+
+type CacheUsageKey struct {
+	ResourceType string
+	Worker       string
+}
+
+type CacheUsageCount struct {
+	Hits   int
+	Misses int
+}
+
+type CacheUsageStat struct {
+	CacheUsageKey
+	CacheUsageCount
+}
+
+func (usage *CacheUsage) Hit(resourceType string, worker string) {
+	usage.record(CacheUsageKey{ResourceType: resourceType, Worker: worker}, CacheUsageCount{Hits: 1})
+}
+
+func (usage *CacheUsage) Miss(resourceType string, worker string) {
+	usage.record(CacheUsageKey{ResourceType: resourceType, Worker: worker}, CacheUsageCount{Misses: 1})
+}
+
+func (usage *CacheUsage) record(key CacheUsageKey, count CacheUsageCount) {
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+
+	if usage.totals == nil {
+		usage.totals = map[CacheUsageKey]CacheUsageCount{}
+	}
+
+	total := usage.totals[key]
+	total.Hits += count.Hits
+	total.Misses += count.Misses
+	usage.totals[key] = total
+}
+
+// Totals returns the hits and misses counted since the ATC started.
+func (usage *CacheUsage) Totals() []CacheUsageStat {
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+
+	stats := []CacheUsageStat{}
+	for key, total := range usage.totals {
+		stats = append(stats, CacheUsageStat{key, total})
+	}
+
+	sortCacheUsageStats(stats)
+
+	return stats
+}
+
+// Delta returns the hits and misses counted since it was last called,
+// leaving out any resource type and worker with neither.
+func (usage *CacheUsage) Delta() []CacheUsageStat {
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+
+	if usage.emitted == nil {
+		usage.emitted = map[CacheUsageKey]CacheUsageCount{}
+	}
+
+	stats := []CacheUsageStat{}
+	for key, total := range usage.totals {
+		emitted := usage.emitted[key]
+		if total == emitted {
+			continue
+		}
+
+		stats = append(stats, CacheUsageStat{key, CacheUsageCount{
+			Hits:   total.Hits - emitted.Hits,
+			Misses: total.Misses - emitted.Misses,
+		}})
+
+		usage.emitted[key] = total
+	}
+
+	sortCacheUsageStats(stats)
+
+	return stats
+}
+
+func sortCacheUsageStats(stats []CacheUsageStat) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].ResourceType != stats[j].ResourceType {
+			return stats[i].ResourceType < stats[j].ResourceType
+		}
+
+		return stats[i].Worker < stats[j].Worker
+	})
+}
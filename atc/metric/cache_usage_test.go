@@ -0,0 +1,63 @@
+package metric_test
+
+import (
+	. "github.com/concourse/concourse/atc/metric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CacheUsage", func() {
+	var usage *CacheUsage
+
+	stat := func(resourceType string, worker string, hits int, misses int) CacheUsageStat {
+		return CacheUsageStat{
+			CacheUsageKey:   CacheUsageKey{ResourceType: resourceType, Worker: worker},
+			CacheUsageCount: CacheUsageCount{Hits: hits, Misses: misses},
+		}
+	}
+
+	BeforeEach(func() {
+		usage = new(CacheUsage)
+	})
+
+	It("starts out empty", func() {
+		Expect(usage.Totals()).To(BeEmpty())
+		Expect(usage.Delta()).To(BeEmpty())
+	})
+
+	Context("when hits and misses are counted", func() {
+		BeforeEach(func() {
+			usage.Hit("git", "worker-b")
+			usage.Hit("git", "worker-a")
+			usage.Miss("git", "worker-a")
+			usage.Hit("s3", "worker-a")
+		})
+
+		It("totals them by resource type and worker", func() {
+			Expect(usage.Totals()).To(Equal([]CacheUsageStat{
+				stat("git", "worker-a", 1, 1),
+				stat("git", "worker-b", 1, 0),
+				stat("s3", "worker-a", 1, 0),
+			}))
+		})
+
+		It("returns only what was counted since the last delta", func() {
+			Expect(usage.Delta()).To(HaveLen(3))
+			Expect(usage.Delta()).To(BeEmpty())
+
+			usage.Miss("s3", "worker-a")
+
+			Expect(usage.Delta()).To(Equal([]CacheUsageStat{
+				stat("s3", "worker-a", 0, 1),
+			}))
+		})
+
+		It("keeps totalling across deltas", func() {
+			usage.Delta()
+			usage.Miss("s3", "worker-a")
+
+			Expect(usage.Totals()).To(ContainElement(stat("s3", "worker-a", 1, 1)))
+		})
+	})
+})
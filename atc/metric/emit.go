@@ -2,7 +2,6 @@ package metric
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -66,52 +65,67 @@ var (
 	emissions       chan eventEmission
 )
 
-func Initialize(logger lager.Logger, host string, attributes map[string]string, bufferSize uint32) error {
+// Initialize starts emitting events to every configured emitter. Each
+// emitter may be limited to comma-separated metric families, keyed by its
+// description, so that a busy backend only receives the metrics it's used
+// for.
+func Initialize(logger lager.Logger, host string, attributes map[string]string, bufferSize uint32, families map[string]string) error {
 	logger.Debug("metric-initialize", lager.Data{
 		"host":        host,
 		"attributes":  attributes,
 		"buffer-size": bufferSize,
+		"families":    families,
 	})
 
-	var (
-		emitterDescriptions []string
-		err                 error
-	)
+	familiesByEmitter, err := emitterFamilies(families)
+	if err != nil {
+		return err
+	}
 
+	var emitters multiEmitter
 	for _, factory := range emitterFactories {
-		if factory.IsConfigured() {
-			emitterDescriptions = append(emitterDescriptions, factory.Description())
+		if !factory.IsConfigured() {
+			continue
 		}
-	}
-	if len(emitterDescriptions) > 1 {
-		return fmt.Errorf("Multiple emitters configured: %s", strings.Join(emitterDescriptions, ", "))
-	}
 
-	for _, factory := range emitterFactories {
-		if factory.IsConfigured() {
-			emitter, err = factory.NewEmitter()
-			if err != nil {
-				return err
-			}
+		configured, err := factory.NewEmitter()
+		if err != nil {
+			return err
 		}
+
+		emitters = append(emitters, newFilteredEmitter(configured, familiesByEmitter[factory.Description()]))
+	}
+
+	switch len(emitters) {
+	case 0:
+		emitter = nil
+	case 1:
+		emitter = emitters[0]
+	default:
+		emitter = emitters
 	}
 
 	if emitter == nil {
 		return nil
 	}
 
-	emitter = emitter
 	eventHost = host
 	eventAttributes = attributes
 	emissions = make(chan eventEmission, int(bufferSize))
 
-	go emitLoop()
+	go emitLoop(emitter, emissions)
 
 	return nil
 }
 
+// Deinitialize stops emitting events and forgets the registered emitters. It
+// is safe to call even if Initialize failed or was never called.
 func Deinitialize(logger lager.Logger) {
-	close(emissions)
+	if emissions != nil {
+		close(emissions)
+		emissions = nil
+	}
+
 	emitterFactories = nil
 }
 
@@ -143,7 +157,10 @@ func emit(logger lager.Logger, event Event) {
 	}
 }
 
-func emitLoop() {
+// emitLoop emits events until the emissions channel is closed. The emitter and
+// channel are passed in so that events queued before Deinitialize aren't
+// emitted by whatever is initialized next.
+func emitLoop(emitter Emitter, emissions <-chan eventEmission) {
 	for emission := range emissions {
 		emitter.Emit(emission.logger.Session("emit"), emission.event)
 	}
@@ -28,15 +28,25 @@ type PrometheusEmitter struct {
 	dbConnections  *prometheus.GaugeVec
 	dbQueriesTotal prometheus.Counter
 
+	dbTableDeadTuples *prometheus.GaugeVec
+	dbTableBytes      *prometheus.GaugeVec
+
 	errorLogs *prometheus.CounterVec
 
 	httpRequestsDuration *prometheus.HistogramVec
 
 	locksHeld *prometheus.GaugeVec
+	locksWait *prometheus.HistogramVec
 
 	pipelineScheduled *prometheus.CounterVec
 
 	resourceChecksVec *prometheus.CounterVec
+	checksTimedOut    *prometheus.CounterVec
+
+	resourceCacheHits   *prometheus.CounterVec
+	resourceCacheMisses *prometheus.CounterVec
+
+	buildEventsDropped *prometheus.CounterVec
 
 	schedulingFullDuration    *prometheus.CounterVec
 	schedulingLoadingDuration *prometheus.CounterVec
@@ -46,6 +56,11 @@ type PrometheusEmitter struct {
 	workerTasks       *prometheus.GaugeVec
 	workersRegistered *prometheus.GaugeVec
 
+	workerRequestsDuration *prometheus.HistogramVec
+
+	workerCreationsQueued *prometheus.GaugeVec
+	workerCreationWait    *prometheus.HistogramVec
+
 	workerLastSeen map[string]time.Time
 	mu             sync.Mutex
 }
@@ -88,6 +103,14 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	}, []string{"type"})
 	prometheus.MustRegister(locksHeld)
 
+	locksWait := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "concourse",
+		Subsystem: "locks",
+		Name:      "wait_seconds",
+		Help:      "Time spent waiting for contended database locks",
+	}, []string{"type"})
+	prometheus.MustRegister(locksWait)
+
 	// build metrics
 	buildsStarted := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "concourse",
@@ -194,6 +217,39 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(workerTasks)
 
+	workerRequestsDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken by workers' Garden and Baggageclaim servers to respond to requests",
+		},
+		[]string{"worker", "service", "method"},
+	)
+	prometheus.MustRegister(workerRequestsDuration)
+
+	workerCreationsQueued := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "creations_queued",
+			Help:      "Number of container and volume creations waiting for a slot per worker",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerCreationsQueued)
+
+	workerCreationWait := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "creation_wait_seconds",
+			Help:      "Time container and volume creations spent waiting for a slot per worker",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerCreationWait)
+
 	workersRegistered := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "concourse",
@@ -270,6 +326,28 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(dbConnections)
 
+	dbTableDeadTuples := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "table_dead_tuples",
+			Help:      "Number of dead tuples waiting to be vacuumed in each of the busiest database tables",
+		},
+		[]string{"table"},
+	)
+	prometheus.MustRegister(dbTableDeadTuples)
+
+	dbTableBytes := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "table_bytes",
+			Help:      "Size of each of the busiest database tables and their indexes in bytes",
+		},
+		[]string{"table"},
+	)
+	prometheus.MustRegister(dbTableBytes)
+
 	resourceChecksVec := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "concourse",
@@ -281,6 +359,50 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(resourceChecksVec)
 
+	checksTimedOut := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "checks_timed_out_total",
+			Help:      "Counts the number of resource checks which timed out, and whether they had to be killed",
+		},
+		[]string{"killed"},
+	)
+	prometheus.MustRegister(checksTimedOut)
+
+	resourceCacheHits := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "cache_hits_total",
+			Help:      "Counts the number of fetches which found the resource cache already on the worker",
+		},
+		[]string{"resource_type", "worker"},
+	)
+	prometheus.MustRegister(resourceCacheHits)
+
+	resourceCacheMisses := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "cache_misses_total",
+			Help:      "Counts the number of fetches which had to run the resource to populate its cache on the worker",
+		},
+		[]string{"resource_type", "worker"},
+	)
+	prometheus.MustRegister(resourceCacheMisses)
+
+	buildEventsDropped := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "events_dropped_total",
+			Help:      "Counts the number of build events dropped from the buffers of slow event stream consumers",
+		},
+		[]string{"consumer"},
+	)
+	prometheus.MustRegister(buildEventsDropped)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -301,15 +423,25 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		dbConnections:  dbConnections,
 		dbQueriesTotal: dbQueriesTotal,
 
+		dbTableDeadTuples: dbTableDeadTuples,
+		dbTableBytes:      dbTableBytes,
+
 		errorLogs: errorLogs,
 
 		httpRequestsDuration: httpRequestsDuration,
 
 		locksHeld: locksHeld,
+		locksWait: locksWait,
 
 		pipelineScheduled: pipelineScheduled,
 
 		resourceChecksVec: resourceChecksVec,
+		checksTimedOut:    checksTimedOut,
+
+		resourceCacheHits:   resourceCacheHits,
+		resourceCacheMisses: resourceCacheMisses,
+
+		buildEventsDropped: buildEventsDropped,
 
 		schedulingFullDuration:    schedulingFullDuration,
 		schedulingLoadingDuration: schedulingLoadingDuration,
@@ -319,6 +451,11 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		workerLastSeen:    map[string]time.Time{},
 		workerVolumes:     workerVolumes,
 		workerTasks:       workerTasks,
+
+		workerRequestsDuration: workerRequestsDuration,
+
+		workerCreationsQueued: workerCreationsQueued,
+		workerCreationWait:    workerCreationWait,
 	}
 	go emitter.periodicMetricGC()
 
@@ -339,6 +476,8 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.errorLogsMetric(logger, event)
 	case "lock held":
 		emitter.lock(logger, event)
+	case "lock wait time":
+		emitter.lockWait(logger, event)
 	case "build started":
 		emitter.buildsStarted.Inc()
 	case "build finished":
@@ -353,6 +492,12 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workersRegisteredMetric(logger, event)
 	case "http response time":
 		emitter.httpResponseTimeMetrics(logger, event)
+	case "worker request duration":
+		emitter.workerRequestDurationMetric(logger, event)
+	case "worker creations queued":
+		emitter.workerCreationsQueuedMetric(logger, event)
+	case "worker creation wait time":
+		emitter.workerCreationWaitMetric(logger, event)
 	case "scheduling: full duration (ms)":
 		emitter.schedulingMetrics(logger, event)
 	case "scheduling: loading versions duration (ms)":
@@ -363,8 +508,20 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "database connections":
 		emitter.databaseMetrics(logger, event)
+	case "database table dead tuples":
+		emitter.databaseTableMetric(logger, emitter.dbTableDeadTuples, event)
+	case "database table bytes":
+		emitter.databaseTableMetric(logger, emitter.dbTableBytes, event)
+	case "resource cache hits":
+		emitter.resourceCacheMetric(logger, emitter.resourceCacheHits, event)
+	case "resource cache misses":
+		emitter.resourceCacheMetric(logger, emitter.resourceCacheMisses, event)
+	case "build events dropped":
+		emitter.buildEventsDroppedMetric(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "check timed out":
+		emitter.checkTimedOutMetric(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -384,6 +541,22 @@ func (emitter *PrometheusEmitter) lock(logger lager.Logger, event metric.Event)
 	}
 }
 
+func (emitter *PrometheusEmitter) lockWait(logger lager.Logger, event metric.Event) {
+	lockType, exists := event.Attributes["type"]
+	if !exists {
+		logger.Error("failed-to-find-type-in-event", fmt.Errorf("expected type to exist in event.Attributes"))
+		return
+	}
+
+	wait, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("lock-wait-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+		return
+	}
+
+	emitter.locksWait.WithLabelValues(lockType).Observe(wait / 1000)
+}
+
 func (emitter *PrometheusEmitter) errorLogsMetric(logger lager.Logger, event metric.Event) {
 	message, exists := event.Attributes["message"]
 	if !exists {
@@ -571,6 +744,66 @@ func (emitter *PrometheusEmitter) httpResponseTimeMetrics(logger lager.Logger, e
 	emitter.httpRequestsDuration.WithLabelValues(method, route, status).Observe(responseTime / 1000)
 }
 
+func (emitter *PrometheusEmitter) workerRequestDurationMetric(logger lager.Logger, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+
+	service, exists := event.Attributes["service"]
+	if !exists {
+		logger.Error("failed-to-find-service-in-event", fmt.Errorf("expected service to exist in event.Attributes"))
+		return
+	}
+
+	method, exists := event.Attributes["method"]
+	if !exists {
+		logger.Error("failed-to-find-method-in-event", fmt.Errorf("expected method to exist in event.Attributes"))
+		return
+	}
+
+	duration, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("worker-request-duration-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+		return
+	}
+
+	emitter.workerRequestsDuration.WithLabelValues(worker, service, method).Observe(duration / 1000)
+}
+
+func (emitter *PrometheusEmitter) workerCreationsQueuedMetric(logger lager.Logger, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+
+	queued, ok := event.Value.(int)
+	if !ok {
+		logger.Error("worker-creations-queued-event-value-type-mismatch", fmt.Errorf("expected event.Value to be an int"))
+		return
+	}
+
+	emitter.workerCreationsQueued.WithLabelValues(worker).Set(float64(queued))
+}
+
+func (emitter *PrometheusEmitter) workerCreationWaitMetric(logger lager.Logger, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+
+	wait, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("worker-creation-wait-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+		return
+	}
+
+	emitter.workerCreationWait.WithLabelValues(worker).Observe(wait / 1000)
+}
+
 func (emitter *PrometheusEmitter) schedulingMetrics(logger lager.Logger, event metric.Event) {
 	pipeline, exists := event.Attributes["pipeline"]
 	if !exists {
@@ -618,6 +851,22 @@ func (emitter *PrometheusEmitter) databaseMetrics(logger lager.Logger, event met
 
 }
 
+func (emitter *PrometheusEmitter) databaseTableMetric(logger lager.Logger, gauge *prometheus.GaugeVec, event metric.Event) {
+	table, exists := event.Attributes["table"]
+	if !exists {
+		logger.Error("failed-to-find-table-in-event", fmt.Errorf("expected table to exist in event.Attributes"))
+		return
+	}
+
+	value, ok := event.Value.(int64)
+	if !ok {
+		logger.Error("db-table-value-type-mismatch", fmt.Errorf("expected event.Value to be an int64"))
+		return
+	}
+
+	gauge.WithLabelValues(table).Set(float64(value))
+}
+
 func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metric.Event) {
 	pipeline, exists := event.Attributes["pipeline"]
 	if !exists {
@@ -633,6 +882,47 @@ func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metr
 	emitter.resourceChecksVec.WithLabelValues(team, pipeline).Inc()
 }
 
+func (emitter *PrometheusEmitter) resourceCacheMetric(logger lager.Logger, counter *prometheus.CounterVec, event metric.Event) {
+	resourceType, exists := event.Attributes["resource_type"]
+	if !exists {
+		logger.Error("failed-to-find-resource-type-in-event", fmt.Errorf("expected resource_type to exist in event.Attributes"))
+		return
+	}
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+		return
+	}
+
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("resource-cache-value-type-mismatch", fmt.Errorf("expected event.Value to be an int"))
+		return
+	}
+
+	counter.WithLabelValues(resourceType, worker).Add(float64(value))
+}
+
+func (emitter *PrometheusEmitter) buildEventsDroppedMetric(logger lager.Logger, event metric.Event) {
+	consumer, exists := event.Attributes["consumer"]
+	if !exists {
+		logger.Error("failed-to-find-consumer-in-event", fmt.Errorf("expected consumer to exist in event.Attributes"))
+		return
+	}
+
+	emitter.buildEventsDropped.WithLabelValues(consumer).Inc()
+}
+
+func (emitter *PrometheusEmitter) checkTimedOutMetric(logger lager.Logger, event metric.Event) {
+	killed, exists := event.Attributes["killed"]
+	if !exists {
+		logger.Error("failed-to-find-killed-in-event", fmt.Errorf("expected killed to exist in event.Attributes"))
+		return
+	}
+
+	emitter.checksTimedOut.WithLabelValues(killed).Inc()
+}
+
 // updateLastSeen tracks for each worker when it last received a metric event.
 func (emitter *PrometheusEmitter) updateLastSeen(event metric.Event) {
 	emitter.mu.Lock()
@@ -642,7 +932,7 @@ func (emitter *PrometheusEmitter) updateLastSeen(event metric.Event) {
 	}
 }
 
-//periodically remove stale metrics for workers
+// periodically remove stale metrics for workers
 func (emitter *PrometheusEmitter) periodicMetricGC() {
 	for {
 		emitter.mu.Lock()
@@ -657,6 +947,13 @@ func (emitter *PrometheusEmitter) periodicMetricGC() {
 					emitter.workerVolumes.DeleteLabelValues(worker, platform)
 					emitter.workerTasks.DeleteLabelValues(worker, platform)
 				}
+				for _, service := range []string{"garden", "baggageclaim"} {
+					for _, method := range []string{"GET", "HEAD", "POST", "PUT", "DELETE"} {
+						emitter.workerRequestsDuration.DeleteLabelValues(worker, service, method)
+					}
+				}
+				emitter.workerCreationsQueued.DeleteLabelValues(worker)
+				emitter.workerCreationWait.DeleteLabelValues(worker)
 				delete(emitter.workerLastSeen, worker)
 			}
 		}
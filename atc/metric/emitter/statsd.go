@@ -0,0 +1,97 @@
+package emitter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/DataDog/datadog-go/statsd"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/pkg/errors"
+)
+
+// StatsDEmitter emits metrics to a plain StatsD server, which unlike
+// dogstatsd has no tags. Each metric's attributes are appended to its name
+// instead, in order of their keys, e.g. worker_tasks.platform.linux.
+type StatsDEmitter struct {
+	client *statsd.Client
+}
+
+type StatsDConfig struct {
+	Host   string `long:"statsd-host" description:"StatsD server host to emit metrics to."`
+	Port   string `long:"statsd-port" description:"StatsD server port to emit metrics to."`
+	Prefix string `long:"statsd-prefix" description:"Prefix for all metrics to easily find them in StatsD."`
+}
+
+func init() {
+	metric.RegisterEmitter(&StatsDConfig{})
+}
+
+func (config *StatsDConfig) Description() string { return "StatsD" }
+
+func (config *StatsDConfig) IsConfigured() bool { return config.Host != "" && config.Port != "" }
+
+func (config *StatsDConfig) NewEmitter() (metric.Emitter, error) {
+	client, err := statsd.New(fmt.Sprintf("%s:%s", config.Host, config.Port))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Prefix != "" {
+		if strings.HasSuffix(config.Prefix, ".") {
+			client.Namespace = config.Prefix
+		} else {
+			client.Namespace = fmt.Sprintf("%s.", config.Prefix)
+		}
+	}
+
+	return &StatsDEmitter{
+		client: client,
+	}, nil
+}
+
+func (emitter *StatsDEmitter) Emit(logger lager.Logger, event metric.Event) {
+	name := statsdName(event)
+
+	value, err := getFloatHelper(event.Value)
+	if err != nil {
+		logger.Error("failed-to-convert-metric-for-statsd", nil, lager.Data{
+			"metric-name": name,
+		})
+		return
+	}
+
+	err = emitter.client.Gauge(name, value, nil, 1)
+	if err != nil {
+		logger.Error("failed-to-send-metric",
+			errors.Wrap(metric.ErrFailedToEmit, err.Error()))
+		return
+	}
+}
+
+func statsdName(event metric.Event) string {
+	segments := []string{statsdSegment(event.Name)}
+
+	keys := []string{}
+	for k := range event.Attributes {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		value := statsdSegment(event.Attributes[k])
+		if value == "" {
+			continue
+		}
+
+		segments = append(segments, statsdSegment(k), value)
+	}
+
+	return strings.Join(segments, ".")
+}
+
+func statsdSegment(s string) string {
+	return specialChars.ReplaceAllString(strings.Replace(strings.ToLower(s), " ", "_", -1), "")
+}
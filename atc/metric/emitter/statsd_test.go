@@ -0,0 +1,71 @@
+package emitter_test
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/emitter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatsDEmitter", func() {
+	var (
+		server        *net.UDPConn
+		statsdEmitter metric.Emitter
+	)
+
+	received := func() string {
+		buf := make([]byte, 1024)
+
+		err := server.SetReadDeadline(time.Now().Add(5 * time.Second))
+		Expect(err).NotTo(HaveOccurred())
+
+		n, err := server.Read(buf)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(buf[:n])
+	}
+
+	BeforeEach(func() {
+		var err error
+		server, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, port, err := net.SplitHostPort(server.LocalAddr().String())
+		Expect(err).NotTo(HaveOccurred())
+
+		config := &emitter.StatsDConfig{
+			Host:   "127.0.0.1",
+			Port:   port,
+			Prefix: "concourse",
+		}
+
+		Expect(config.IsConfigured()).To(BeTrue())
+
+		statsdEmitter, err = config.NewEmitter()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("emits a gauge named after the metric and its attributes", func() {
+		statsdEmitter.Emit(lager.NewLogger("test"), metric.Event{
+			Name:  "worker tasks",
+			Value: 3,
+			Attributes: map[string]string{
+				"worker":   "some-worker",
+				"platform": "linux",
+				"empty":    "",
+			},
+		})
+
+		Expect(strings.TrimSpace(received())).To(Equal("concourse.worker_tasks.platform.linux.worker.someworker:3.000000|g"))
+	})
+})
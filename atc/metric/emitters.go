@@ -0,0 +1,93 @@
+package metric
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// MetricFamily returns the family an event belongs to, which is the first
+// word of its name, e.g. "build" for "build finished" or "scheduling" for
+// "scheduling: job duration (ms)".
+func MetricFamily(name string) string {
+	family := strings.ToLower(name)
+	if i := strings.IndexAny(family, " :"); i != -1 {
+		family = family[:i]
+	}
+
+	return family
+}
+
+// emitterFamilies parses the comma-separated metric families each emitter
+// is limited to, keyed by emitter description. Descriptions are matched
+// case-insensitively, and must be of a registered emitter.
+func emitterFamilies(families map[string]string) (map[string][]string, error) {
+	parsed := map[string][]string{}
+
+	for description, list := range families {
+		found := false
+		for _, factory := range emitterFactories {
+			if strings.EqualFold(factory.Description(), description) {
+				description = factory.Description()
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown metric emitter '%s'", description)
+		}
+
+		for _, family := range strings.Split(list, ",") {
+			family = strings.ToLower(strings.TrimSpace(family))
+			if family != "" {
+				parsed[description] = append(parsed[description], family)
+			}
+		}
+	}
+
+	return parsed, nil
+}
+
+// filteredEmitter only passes on events in the metric families it's limited
+// to.
+type filteredEmitter struct {
+	Emitter
+
+	families map[string]bool
+}
+
+func newFilteredEmitter(emitter Emitter, families []string) Emitter {
+	if len(families) == 0 {
+		return emitter
+	}
+
+	filtered := filteredEmitter{
+		Emitter:  emitter,
+		families: map[string]bool{},
+	}
+
+	for _, family := range families {
+		filtered.families[family] = true
+	}
+
+	return filtered
+}
+
+func (emitter filteredEmitter) Emit(logger lager.Logger, event Event) {
+	if !emitter.families[MetricFamily(event.Name)] {
+		return
+	}
+
+	emitter.Emitter.Emit(logger, event)
+}
+
+// multiEmitter emits each event to every configured emitter in turn.
+type multiEmitter []Emitter
+
+func (emitters multiEmitter) Emit(logger lager.Logger, event Event) {
+	for _, emitter := range emitters {
+		emitter.Emit(logger, event)
+	}
+}
@@ -0,0 +1,100 @@
+package metric_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Emitters", func() {
+	var (
+		prometheusEmitter *metricfakes.FakeEmitter
+		datadogEmitter    *metricfakes.FakeEmitter
+
+		families map[string]string
+		initErr  error
+	)
+
+	newFactory := func(description string, emitter metric.Emitter) *metricfakes.FakeEmitterFactory {
+		factory := new(metricfakes.FakeEmitterFactory)
+		factory.DescriptionReturns(description)
+		factory.IsConfiguredReturns(true)
+		factory.NewEmitterReturns(emitter, nil)
+		return factory
+	}
+
+	emittedNames := func(emitter *metricfakes.FakeEmitter) []string {
+		names := []string{}
+		for i := 0; i < emitter.EmitCallCount(); i++ {
+			_, event := emitter.EmitArgsForCall(i)
+			names = append(names, event.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		prometheusEmitter = new(metricfakes.FakeEmitter)
+		datadogEmitter = new(metricfakes.FakeEmitter)
+
+		metric.RegisterEmitter(newFactory("Prometheus", prometheusEmitter))
+		metric.RegisterEmitter(newFactory("Datadog", datadogEmitter))
+
+		families = nil
+	})
+
+	JustBeforeEach(func() {
+		initErr = metric.Initialize(lagertest.NewTestLogger("test"), "test", map[string]string{}, 1000, families)
+		if initErr == nil {
+			metric.WorkerTasks{WorkerName: "some-worker", Tasks: 1}.Emit(lagertest.NewTestLogger("test"))
+			metric.BuildEventsDropped{Consumer: "some-consumer", BuildID: 1}.Emit(lagertest.NewTestLogger("test"))
+		}
+	})
+
+	AfterEach(func() {
+		metric.Deinitialize(nil)
+	})
+
+	It("emits every event to each configured emitter", func() {
+		Expect(initErr).NotTo(HaveOccurred())
+
+		Eventually(func() []string { return emittedNames(prometheusEmitter) }).Should(Equal([]string{"worker tasks", "build events dropped"}))
+		Eventually(func() []string { return emittedNames(datadogEmitter) }).Should(Equal([]string{"worker tasks", "build events dropped"}))
+	})
+
+	Context("when an emitter is limited to metric families", func() {
+		BeforeEach(func() {
+			families = map[string]string{"prometheus": "build, lock"}
+		})
+
+		It("only emits the events in those families to it", func() {
+			Expect(initErr).NotTo(HaveOccurred())
+
+			Eventually(func() []string { return emittedNames(datadogEmitter) }).Should(HaveLen(2))
+			Expect(emittedNames(prometheusEmitter)).To(Equal([]string{"build events dropped"}))
+		})
+	})
+
+	Context("when families are given for an unknown emitter", func() {
+		BeforeEach(func() {
+			families = map[string]string{"carrier-pigeon": "build"}
+		})
+
+		It("errors", func() {
+			Expect(initErr).To(MatchError("unknown metric emitter 'carrier-pigeon'"))
+		})
+	})
+
+	DescribeTable("MetricFamily",
+		func(name string, family string) {
+			Expect(metric.MetricFamily(name)).To(Equal(family))
+		},
+		Entry("is the first word of the name", "build finished", "build"),
+		Entry("leaves out a trailing colon", "scheduling: job duration (ms)", "scheduling"),
+		Entry("is lowercase", "HTTP response time", "http"),
+		Entry("is the whole name of a single word", "worker", "worker"),
+	)
+})
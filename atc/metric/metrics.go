@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
@@ -23,6 +24,12 @@ var FailedVolumes = Meter(0)
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
 
+var ResourceTypeImageCacheHits = Meter(0)
+var ResourceTypeImageCacheMisses = Meter(0)
+
+var ImageLayerCacheHits = Meter(0)
+var ImageLayerCacheMisses = Meter(0)
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
@@ -112,6 +119,47 @@ func (event SchedulingJobDuration) Emit(logger lager.Logger) {
 	)
 }
 
+// SchedulingTick is emitted after each tick of a pipeline's scheduler.
+// Errored is set if the tick stopped short.
+type SchedulingTick struct {
+	PipelineName  string
+	JobsEvaluated int
+	BuildsCreated int
+	Errored       bool
+}
+
+func (event SchedulingTick) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if event.Errored {
+		state = EventStateWarning
+	}
+
+	attributes := map[string]string{
+		"pipeline": event.PipelineName,
+		"errored":  strconv.FormatBool(event.Errored),
+	}
+
+	emit(
+		logger.Session("scheduling-jobs-evaluated"),
+		Event{
+			Name:       "scheduling: jobs evaluated",
+			Value:      event.JobsEvaluated,
+			State:      state,
+			Attributes: attributes,
+		},
+	)
+
+	emit(
+		logger.Session("scheduling-builds-created"),
+		Event{
+			Name:       "scheduling: builds created",
+			Value:      event.BuildsCreated,
+			State:      state,
+			Attributes: attributes,
+		},
+	)
+}
+
 type WorkerContainers struct {
 	WorkerName string
 	Platform   string
@@ -183,6 +231,68 @@ func (event WorkerTasks) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerDiscrepancies struct {
+	WorkerName    string
+	Discrepancies int
+}
+
+func (event WorkerDiscrepancies) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if event.Discrepancies > 0 {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("worker-discrepancies"),
+		Event{
+			Name:  "worker discrepancies",
+			Value: event.Discrepancies,
+			State: state,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
+type WorkerCreationsQueued struct {
+	WorkerName string
+	Queued     int
+}
+
+func (event WorkerCreationsQueued) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("worker-creations-queued"),
+		Event{
+			Name:  "worker creations queued",
+			Value: event.Queued,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
+type WorkerCreationWaited struct {
+	WorkerName string
+	Duration   time.Duration
+}
+
+func (event WorkerCreationWaited) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("worker-creation-waited"),
+		Event{
+			Name:  "worker creation wait time",
+			Value: ms(event.Duration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
 type VolumesToBeGarbageCollected struct {
 	Volumes int
 }
@@ -335,6 +445,7 @@ type BuildStarted struct {
 	BuildName    string
 	BuildID      int
 	TeamName     string
+	Labels       atc.Labels
 }
 
 func (event BuildStarted) Emit(logger lager.Logger) {
@@ -344,13 +455,13 @@ func (event BuildStarted) Emit(logger lager.Logger) {
 			Name:  "build started",
 			Value: event.BuildID,
 			State: EventStateOK,
-			Attributes: map[string]string{
+			Attributes: labelAttributes(event.Labels, map[string]string{
 				"pipeline":   event.PipelineName,
 				"job":        event.JobName,
 				"build_name": event.BuildName,
 				"build_id":   strconv.Itoa(event.BuildID),
 				"team_name":  event.TeamName,
-			},
+			}),
 		},
 	)
 }
@@ -363,6 +474,7 @@ type BuildFinished struct {
 	BuildStatus   db.BuildStatus
 	BuildDuration time.Duration
 	TeamName      string
+	Labels        atc.Labels
 }
 
 func (event BuildFinished) Emit(logger lager.Logger) {
@@ -372,13 +484,174 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 			Name:  "build finished",
 			Value: ms(event.BuildDuration),
 			State: EventStateOK,
-			Attributes: map[string]string{
+			Attributes: labelAttributes(event.Labels, map[string]string{
 				"pipeline":     event.PipelineName,
 				"job":          event.JobName,
 				"build_name":   event.BuildName,
 				"build_id":     strconv.Itoa(event.BuildID),
 				"build_status": string(event.BuildStatus),
 				"team_name":    event.TeamName,
+			}),
+		},
+	)
+}
+
+// BuildPendingTimedOut is emitted when a build is errored for having been
+// pending for too long without starting.
+type BuildPendingTimedOut struct {
+	PipelineName    string
+	JobName         string
+	BuildName       string
+	BuildID         int
+	TeamName        string
+	PendingDuration time.Duration
+}
+
+func (event BuildPendingTimedOut) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("build-pending-timed-out"),
+		Event{
+			Name:  "build pending timed out",
+			Value: ms(event.PendingDuration),
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"pipeline":   event.PipelineName,
+				"job":        event.JobName,
+				"build_name": event.BuildName,
+				"build_id":   strconv.Itoa(event.BuildID),
+				"team_name":  event.TeamName,
+			},
+		},
+	)
+}
+
+// BuildAutoRetried is emitted for each build which is re-run automatically
+// because it errored with an infrastructure failure.
+type BuildAutoRetried struct {
+	PipelineName string
+	JobName      string
+	BuildName    string
+	BuildID      int
+	TeamName     string
+	ErrorCode    atc.ErrorCode
+	Attempt      int
+}
+
+func (event BuildAutoRetried) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("build-auto-retried"),
+		Event{
+			Name:  "build auto retried",
+			Value: event.Attempt,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"pipeline":   event.PipelineName,
+				"job":        event.JobName,
+				"build_name": event.BuildName,
+				"build_id":   strconv.Itoa(event.BuildID),
+				"team_name":  event.TeamName,
+				"error_code": string(event.ErrorCode),
+			},
+		},
+	)
+}
+
+// labelAttributes adds the pipeline and job labels to a build's attributes,
+// prefixed so that they can't clash with the attributes every build has.
+func labelAttributes(labels atc.Labels, attributes map[string]string) map[string]string {
+	for name, value := range labels {
+		attributes["label_"+name] = value
+	}
+
+	return attributes
+}
+
+// BuildEventsDropped is emitted for each build event dropped from a slow
+// consumer's buffer.
+type BuildEventsDropped struct {
+	Consumer string
+	BuildID  int
+}
+
+func (event BuildEventsDropped) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("build-events-dropped"),
+		Event{
+			Name:  "build events dropped",
+			Value: 1,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"consumer": event.Consumer,
+				"build_id": strconv.Itoa(event.BuildID),
+			},
+		},
+	)
+}
+
+// DatabaseTableHealth is emitted for each of the busiest database tables
+// with the dead tuples waiting to be vacuumed from it and its size. It warns
+// when maintenance is suggested for the table or its indexes.
+type DatabaseTableHealth struct {
+	Table       string
+	DeadTuples  int64
+	Bytes       int64
+	Suggestions int
+}
+
+func (event DatabaseTableHealth) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if event.Suggestions > 0 {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("database-table-dead-tuples"),
+		Event{
+			Name:  "database table dead tuples",
+			Value: event.DeadTuples,
+			State: state,
+			Attributes: map[string]string{
+				"table": event.Table,
+			},
+		},
+	)
+
+	emit(
+		logger.Session("database-table-bytes"),
+		Event{
+			Name:  "database table bytes",
+			Value: event.Bytes,
+			State: state,
+			Attributes: map[string]string{
+				"table": event.Table,
+			},
+		},
+	)
+}
+
+// CrossZoneVolumeStreamed is emitted with the number of bytes of a volume
+// streamed between workers in different zones, which cloud providers usually
+// charge for.
+type CrossZoneVolumeStreamed struct {
+	SourceWorker string
+	SourceZone   string
+	TargetWorker string
+	TargetZone   string
+	Bytes        int64
+}
+
+func (event CrossZoneVolumeStreamed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("cross-zone-volume-streamed"),
+		Event{
+			Name:  "cross-zone bytes streamed",
+			Value: event.Bytes,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"source_worker": event.SourceWorker,
+				"source_zone":   event.SourceZone,
+				"target_worker": event.TargetWorker,
+				"target_zone":   event.TargetZone,
 			},
 		},
 	)
@@ -442,6 +715,42 @@ func (event HTTPResponseTime) Emit(logger lager.Logger) {
 	)
 }
 
+// WorkerRequestDuration is how long a request to one of a worker's servers
+// took to respond, up to the response's headers.
+type WorkerRequestDuration struct {
+	WorkerName string
+	Service    string
+	Method     string
+	Duration   time.Duration
+	Success    bool
+}
+
+func (event WorkerRequestDuration) Emit(logger lager.Logger) {
+	state := EventStateOK
+
+	if event.Duration > 1*time.Second {
+		state = EventStateWarning
+	}
+
+	if !event.Success || event.Duration > 10*time.Second {
+		state = EventStateCritical
+	}
+
+	emit(
+		logger.Session("worker-request-duration"),
+		Event{
+			Name:  "worker request duration",
+			Value: ms(event.Duration),
+			State: state,
+			Attributes: map[string]string{
+				"worker":  event.WorkerName,
+				"service": event.Service,
+				"method":  event.Method,
+			},
+		},
+	)
+}
+
 type ResourceCheck struct {
 	PipelineName string
 	ResourceName string
@@ -494,14 +803,53 @@ func (event CheckFinished) Emit(logger lager.Logger) {
 	)
 }
 
-var lockTypeNames = map[int]string{
-	lock.LockTypeResourceConfigChecking: "ResourceConfigChecking",
-	lock.LockTypeBuildTracking:          "BuildTracking",
-	lock.LockTypePipelineScheduling:     "PipelineScheduling",
-	lock.LockTypeBatch:                  "Batch",
-	lock.LockTypeVolumeCreating:         "VolumeCreating",
-	lock.LockTypeContainerCreating:      "ContainerCreating",
-	lock.LockTypeDatabaseMigration:      "DatabaseMigration",
+// CheckTimedOut is emitted when a check runs past its timeout. Killed is set
+// if the check also ran past its hard timeout and had to be killed.
+type CheckTimedOut struct {
+	ResourceConfigScopeID string
+	CheckName             string
+	Killed                bool
+}
+
+func (event CheckTimedOut) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("check-timed-out"),
+		Event{
+			Name:  "check timed out",
+			Value: 1,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"scope_id":   event.ResourceConfigScopeID,
+				"check_name": event.CheckName,
+				"killed":     strconv.FormatBool(event.Killed),
+			},
+		},
+	)
+}
+
+// CheckKilledByLimits is emitted when a check fails after its container
+// appears to have been killed for exceeding the limits configured for its
+// resource type.
+type CheckKilledByLimits struct {
+	ResourceConfigScopeID string
+	CheckName             string
+	ResourceType          string
+}
+
+func (event CheckKilledByLimits) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("check-killed-by-limits"),
+		Event{
+			Name:  "check killed by limits",
+			Value: 1,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"scope_id":      event.ResourceConfigScopeID,
+				"check_name":    event.CheckName,
+				"resource_type": event.ResourceType,
+			},
+		},
+	)
 }
 
 type LockAcquired struct {
@@ -547,7 +895,7 @@ func LogLockAcquired(logger lager.Logger, lockID lock.LockID) {
 		return
 	}
 
-	if lockType, ok := lockTypeNames[lockID[0]]; ok {
+	if lockType, ok := lock.LockTypeNames[lockID[0]]; ok {
 		LockAcquired{LockType: lockType}.Emit(logger)
 	}
 }
@@ -559,11 +907,42 @@ func LogLockReleased(logger lager.Logger, lockID lock.LockID) {
 		return
 	}
 
-	if lockType, ok := lockTypeNames[lockID[0]]; ok {
+	if lockType, ok := lock.LockTypeNames[lockID[0]]; ok {
 		LockReleased{LockType: lockType}.Emit(logger)
 	}
 }
 
+type LockWaited struct {
+	LockType string
+	Duration time.Duration
+}
+
+func (event LockWaited) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("lock-waited"),
+		Event{
+			Name:  "lock wait time",
+			Value: ms(event.Duration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"type": event.LockType,
+			},
+		},
+	)
+}
+
+// LogLockWaited reports how long a lock was contended before this ATC
+// acquired it.
+func LogLockWaited(logger lager.Logger, lockID lock.LockID, wait time.Duration) {
+	if len(lockID) == 0 {
+		return
+	}
+
+	if lockType, ok := lock.LockTypeNames[lockID[0]]; ok {
+		LockWaited{LockType: lockType, Duration: wait}.Emit(logger)
+	}
+}
+
 type WorkersState struct {
 	WorkerStateByName map[string]db.WorkerState
 }
@@ -0,0 +1,60 @@
+package metric_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build metrics", func() {
+	var emitter *metricfakes.FakeEmitter
+
+	BeforeEach(func() {
+		emitterFactory := &metricfakes.FakeEmitterFactory{}
+		emitter = &metricfakes.FakeEmitter{}
+
+		metric.RegisterEmitter(emitterFactory)
+		emitterFactory.IsConfiguredReturns(true)
+		emitterFactory.NewEmitterReturns(emitter, nil)
+		metric.Initialize(lagertest.NewTestLogger("test"), "test", map[string]string{}, 1000, nil)
+	})
+
+	AfterEach(func() {
+		metric.Deinitialize(nil)
+	})
+
+	It("attaches the pipeline and job labels to the build's attributes", func() {
+		metric.BuildFinished{
+			PipelineName:  "some-pipeline",
+			JobName:       "some-job",
+			BuildName:     "42",
+			BuildID:       123,
+			BuildStatus:   db.BuildStatusSucceeded,
+			BuildDuration: time.Minute,
+			TeamName:      "some-team",
+			Labels:        atc.Labels{"service": "web", "job": "not-the-job"},
+		}.Emit(lagertest.NewTestLogger("test"))
+
+		Eventually(emitter.EmitCallCount).Should(Equal(1))
+
+		_, event := emitter.EmitArgsForCall(0)
+		Expect(event.Name).To(Equal("build finished"))
+		Expect(event.Attributes).To(Equal(map[string]string{
+			"pipeline":      "some-pipeline",
+			"job":           "some-job",
+			"build_name":    "42",
+			"build_id":      "123",
+			"build_status":  "succeeded",
+			"team_name":     "some-team",
+			"label_service": "web",
+			"label_job":     "not-the-job",
+		}))
+	})
+})
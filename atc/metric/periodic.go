@@ -107,6 +107,69 @@ func tick(logger lager.Logger) {
 		},
 	)
 
+	emit(
+		logger.Session("resource-type-image-cache-hits"),
+		Event{
+			Name:  "resource type image cache hits",
+			Value: ResourceTypeImageCacheHits.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("resource-type-image-cache-misses"),
+		Event{
+			Name:  "resource type image cache misses",
+			Value: ResourceTypeImageCacheMisses.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("image-layer-cache-hits"),
+		Event{
+			Name:  "image layer cache hits",
+			Value: ImageLayerCacheHits.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("image-layer-cache-misses"),
+		Event{
+			Name:  "image layer cache misses",
+			Value: ImageLayerCacheMisses.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	for _, stat := range ResourceCaches.Delta() {
+		attributes := map[string]string{
+			"resource_type": stat.ResourceType,
+			"worker":        stat.Worker,
+		}
+
+		emit(
+			logger.Session("resource-cache-hits"),
+			Event{
+				Name:       "resource cache hits",
+				Value:      stat.Hits,
+				State:      EventStateOK,
+				Attributes: attributes,
+			},
+		)
+
+		emit(
+			logger.Session("resource-cache-misses"),
+			Event{
+				Name:       "resource cache misses",
+				Value:      stat.Misses,
+				State:      EventStateOK,
+				Attributes: attributes,
+			},
+		)
+	}
+
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
@@ -0,0 +1,27 @@
+package atc
+
+// MigrationStatus is the version the database schema is at, and how far the
+// backfills filling in data for schema changes online have got.
+type MigrationStatus struct {
+	CurrentVersion   int `json:"current_version"`
+	SupportedVersion int `json:"supported_version"`
+
+	Backfills []BackfillProgress `json:"backfills"`
+}
+
+type BackfillProgress struct {
+	Name string `json:"name"`
+
+	// TargetID is the id of the last row the backfill visits, and LastID
+	// the id of the last row it has visited so far.
+	TargetID int     `json:"target_id"`
+	LastID   int     `json:"last_id"`
+	Percent  float64 `json:"percent"`
+
+	// Error is why the last batch failed. Failed batches are retried.
+	Error string `json:"error,omitempty"`
+
+	StartedAt  int64 `json:"started_at"`
+	UpdatedAt  int64 `json:"updated_at"`
+	FinishedAt int64 `json:"finished_at,omitempty"`
+}
@@ -5,10 +5,70 @@ type Pipeline struct {
 	Name     string       `json:"name"`
 	Paused   bool         `json:"paused"`
 	Public   bool         `json:"public"`
+	Shared   bool         `json:"shared,omitempty"`
 	Groups   GroupConfigs `json:"groups,omitempty"`
 	TeamName string       `json:"team_name"`
+
+	Deprecations []ConfigDeprecation `json:"deprecations,omitempty"`
+
+	SchedulingWindows *SchedulingWindows `json:"scheduling_windows,omitempty"`
+
+	Labels Labels `json:"labels,omitempty"`
+
+	TimeZone TimeZone `json:"timezone,omitempty"`
+	Locale   Locale   `json:"locale,omitempty"`
+}
+
+// SharedPipeline is the response to sharing a pipeline. The share token is
+// only ever returned here.
+type SharedPipeline struct {
+	Token string `json:"token"`
 }
 
 type RenameRequest struct {
 	NewName string `json:"name"`
 }
+
+// PipelineGraph is the graph of a pipeline's jobs and the resources they get
+// and put, laid out in layers from left to right.
+type PipelineGraph struct {
+	Ranks int         `json:"ranks"`
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+const (
+	GraphNodeJob    = "job"
+	GraphNodeInput  = "input"
+	GraphNodeOutput = "output"
+)
+
+// GraphNode is a job, or a resource a job gets or puts. Resources are shown
+// once per job, so Job is set for input and output nodes too.
+type GraphNode struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Job      string `json:"job"`
+	Resource string `json:"resource,omitempty"`
+
+	// Rank is the node's layer and Order its position within the layer.
+	Rank  int `json:"rank"`
+	Order int `json:"order"`
+}
+
+// GraphEdge connects two nodes. Edges between jobs come from passed
+// constraints on the given resource, or from the target job's after
+// dependencies if there is no resource; those spanning more than one layer
+// carry a waypoint in each layer they cross.
+type GraphEdge struct {
+	Source    string          `json:"source"`
+	Target    string          `json:"target"`
+	Resource  string          `json:"resource,omitempty"`
+	Waypoints []GraphWaypoint `json:"waypoints,omitempty"`
+}
+
+type GraphWaypoint struct {
+	Rank  int `json:"rank"`
+	Order int `json:"order"`
+}
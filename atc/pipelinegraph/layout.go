@@ -0,0 +1,301 @@
+package pipelinegraph
+
+import (
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// sweeps is the number of times node order is refined in each direction.
+const sweeps = 4
+
+// Layout returns the graph of the given jobs laid out in layers.
+//
+// Each job's rank is determined by the longest chain of passed constraints
+// and after dependencies leading to it. Inputs without passed constraints are placed in the layer
+// before their job and outputs in the layer after it. Nodes within a layer
+// are then ordered to reduce crossing edges, using the barycenter of each
+// node's neighbours in the adjacent layer.
+func Layout(jobs atc.JobConfigs) atc.PipelineGraph {
+	l := newLayout(jobs)
+	l.order()
+	return l.graph()
+}
+
+type vertex struct {
+	node *atc.GraphNode
+
+	rank int
+	pos  int
+
+	preds []*vertex
+	succs []*vertex
+}
+
+type edge struct {
+	edge  atc.GraphEdge
+	dummy []*vertex
+}
+
+type layout struct {
+	vertices []*vertex
+	edges    []*edge
+	layers   [][]*vertex
+}
+
+func newLayout(jobs atc.JobConfigs) *layout {
+	l := &layout{}
+
+	depths := jobDepths(jobs)
+
+	jobVertices := map[string]*vertex{}
+	for _, job := range jobs {
+		rank := 2*depths[job.Name] + 1
+
+		jobVertex := l.addVertex(&atc.GraphNode{
+			ID:   "job:" + job.Name,
+			Type: atc.GraphNodeJob,
+			Name: job.Name,
+			Job:  job.Name,
+		}, rank)
+
+		jobVertices[job.Name] = jobVertex
+
+		for _, input := range job.Inputs() {
+			if len(input.Passed) > 0 {
+				continue
+			}
+
+			in := l.addVertex(&atc.GraphNode{
+				ID:       "input:" + job.Name + ":" + input.Name,
+				Type:     atc.GraphNodeInput,
+				Name:     input.Name,
+				Job:      job.Name,
+				Resource: input.Resource,
+			}, rank-1)
+
+			l.connect(in, jobVertex, "")
+		}
+
+		outputs := map[string]bool{}
+		for _, output := range job.Outputs() {
+			if outputs[output.Name] {
+				continue
+			}
+
+			outputs[output.Name] = true
+
+			out := l.addVertex(&atc.GraphNode{
+				ID:       "output:" + job.Name + ":" + output.Name,
+				Type:     atc.GraphNodeOutput,
+				Name:     output.Name,
+				Job:      job.Name,
+				Resource: output.Resource,
+			}, rank+1)
+
+			l.connect(jobVertex, out, "")
+		}
+	}
+
+	for _, job := range jobs {
+		connected := map[string]bool{}
+		for _, input := range job.Inputs() {
+			for _, passed := range input.Passed {
+				upstream, found := jobVertices[passed]
+				if !found || connected[passed+":"+input.Resource] {
+					continue
+				}
+
+				connected[passed+":"+input.Resource] = true
+
+				l.connect(upstream, jobVertices[job.Name], input.Resource)
+			}
+		}
+
+		for _, after := range job.After {
+			upstream, found := jobVertices[after]
+			if !found || connected[after+":"] {
+				continue
+			}
+
+			connected[after+":"] = true
+
+			l.connect(upstream, jobVertices[job.Name], "")
+		}
+	}
+
+	return l
+}
+
+// jobDepths returns the length of the longest chain of passed constraints
+// and after dependencies leading to each job. Cycles are broken wherever
+// they are first found.
+func jobDepths(jobs atc.JobConfigs) map[string]int {
+	upstream := map[string][]string{}
+	for _, job := range jobs {
+		for _, input := range job.Inputs() {
+			upstream[job.Name] = append(upstream[job.Name], input.Passed...)
+		}
+
+		upstream[job.Name] = append(upstream[job.Name], job.After...)
+	}
+
+	depths := map[string]int{}
+	visiting := map[string]bool{}
+
+	var depth func(string) int
+	depth = func(name string) int {
+		if d, found := depths[name]; found {
+			return d
+		}
+
+		if visiting[name] {
+			return -1
+		}
+
+		visiting[name] = true
+
+		d := 0
+		for _, up := range upstream[name] {
+			if _, found := jobs.Lookup(up); !found {
+				continue
+			}
+
+			if upDepth := depth(up); upDepth+1 > d {
+				d = upDepth + 1
+			}
+		}
+
+		visiting[name] = false
+		depths[name] = d
+
+		return d
+	}
+
+	for _, job := range jobs {
+		depth(job.Name)
+	}
+
+	return depths
+}
+
+func (l *layout) addVertex(node *atc.GraphNode, rank int) *vertex {
+	for len(l.layers) <= rank {
+		l.layers = append(l.layers, nil)
+	}
+
+	v := &vertex{
+		node: node,
+		rank: rank,
+		pos:  len(l.layers[rank]),
+	}
+
+	l.layers[rank] = append(l.layers[rank], v)
+
+	if node != nil {
+		l.vertices = append(l.vertices, v)
+	}
+
+	return v
+}
+
+// connect adds an edge, with a dummy vertex in each layer it crosses so that
+// the ordering keeps room for it to pass through.
+func (l *layout) connect(source *vertex, target *vertex, resource string) {
+	e := &edge{
+		edge: atc.GraphEdge{
+			Source:   source.node.ID,
+			Target:   target.node.ID,
+			Resource: resource,
+		},
+	}
+
+	prev := source
+	for rank := source.rank + 1; rank < target.rank; rank++ {
+		dummy := l.addVertex(nil, rank)
+		e.dummy = append(e.dummy, dummy)
+
+		link(prev, dummy)
+		prev = dummy
+	}
+
+	if source.rank < target.rank {
+		link(prev, target)
+	}
+
+	l.edges = append(l.edges, e)
+}
+
+func link(from *vertex, to *vertex) {
+	from.succs = append(from.succs, to)
+	to.preds = append(to.preds, from)
+}
+
+func (l *layout) order() {
+	for i := 0; i < sweeps; i++ {
+		for rank := 1; rank < len(l.layers); rank++ {
+			sortLayer(l.layers[rank], func(v *vertex) []*vertex { return v.preds })
+		}
+
+		for rank := len(l.layers) - 2; rank >= 0; rank-- {
+			sortLayer(l.layers[rank], func(v *vertex) []*vertex { return v.succs })
+		}
+	}
+}
+
+// sortLayer orders the layer by the average position of each vertex's
+// neighbours. Vertices without neighbours keep their position.
+func sortLayer(layer []*vertex, neighbours func(*vertex) []*vertex) {
+	barycenters := map[*vertex]float64{}
+	for _, v := range layer {
+		ns := neighbours(v)
+		if len(ns) == 0 {
+			barycenters[v] = float64(v.pos)
+			continue
+		}
+
+		sum := 0
+		for _, n := range ns {
+			sum += n.pos
+		}
+
+		barycenters[v] = float64(sum) / float64(len(ns))
+	}
+
+	sort.SliceStable(layer, func(i, j int) bool {
+		return barycenters[layer[i]] < barycenters[layer[j]]
+	})
+
+	for i, v := range layer {
+		v.pos = i
+	}
+}
+
+func (l *layout) graph() atc.PipelineGraph {
+	graph := atc.PipelineGraph{
+		Ranks: len(l.layers),
+		Nodes: []atc.GraphNode{},
+		Edges: []atc.GraphEdge{},
+	}
+
+	for _, v := range l.vertices {
+		node := *v.node
+		node.Rank = v.rank
+		node.Order = v.pos
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	for _, e := range l.edges {
+		edge := e.edge
+		for _, dummy := range e.dummy {
+			edge.Waypoints = append(edge.Waypoints, atc.GraphWaypoint{
+				Rank:  dummy.rank,
+				Order: dummy.pos,
+			})
+		}
+
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	return graph
+}
@@ -0,0 +1,223 @@
+package pipelinegraph_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/pipelinegraph"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Layout", func() {
+	var (
+		jobs  atc.JobConfigs
+		graph atc.PipelineGraph
+	)
+
+	node := func(id string) atc.GraphNode {
+		for _, node := range graph.Nodes {
+			if node.ID == id {
+				return node
+			}
+		}
+
+		Fail("no node " + id)
+		return atc.GraphNode{}
+	}
+
+	edge := func(source, target string) atc.GraphEdge {
+		for _, edge := range graph.Edges {
+			if edge.Source == source && edge.Target == target {
+				return edge
+			}
+		}
+
+		Fail("no edge from " + source + " to " + target)
+		return atc.GraphEdge{}
+	}
+
+	BeforeEach(func() {
+		jobs = atc.JobConfigs{
+			{
+				Name: "unit",
+				Plan: atc.PlanSequence{
+					{Get: "repo", Trigger: true},
+				},
+			},
+			{
+				Name: "build",
+				Plan: atc.PlanSequence{
+					{Get: "repo", Passed: []string{"unit"}},
+					{Get: "version"},
+					{Put: "image"},
+				},
+			},
+			{
+				Name: "deploy",
+				Plan: atc.PlanSequence{
+					{Get: "repo", Passed: []string{"build"}},
+					{Get: "image", Passed: []string{"build"}},
+				},
+			},
+			{
+				Name: "notify",
+				Plan: atc.PlanSequence{
+					{Get: "repo", Passed: []string{"unit", "deploy"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		graph = pipelinegraph.Layout(jobs)
+	})
+
+	It("ranks jobs by their longest chain of passed constraints", func() {
+		Expect(node("job:unit").Rank).To(Equal(1))
+		Expect(node("job:build").Rank).To(Equal(3))
+		Expect(node("job:deploy").Rank).To(Equal(5))
+		Expect(node("job:notify").Rank).To(Equal(7))
+		Expect(graph.Ranks).To(Equal(8))
+	})
+
+	It("places inputs without passed constraints before their job", func() {
+		Expect(node("input:unit:repo")).To(MatchFields(IgnoreExtras, Fields{
+			"Type":     Equal(atc.GraphNodeInput),
+			"Name":     Equal("repo"),
+			"Job":      Equal("unit"),
+			"Resource": Equal("repo"),
+			"Rank":     Equal(0),
+		}))
+
+		Expect(node("input:build:version").Rank).To(Equal(2))
+		Expect(edge("input:build:version", "job:build").Resource).To(BeEmpty())
+	})
+
+	It("places outputs after their job", func() {
+		Expect(node("output:build:image").Rank).To(Equal(4))
+		Expect(edge("job:build", "output:build:image")).NotTo(BeZero())
+	})
+
+	It("connects jobs once per resource with passed constraints", func() {
+		Expect(edge("job:unit", "job:build").Resource).To(Equal("repo"))
+
+		passed := 0
+		for _, e := range graph.Edges {
+			if e.Source == "job:build" && e.Target == "job:deploy" {
+				passed++
+			}
+		}
+
+		Expect(passed).To(Equal(2))
+	})
+
+	It("gives edges spanning several layers a waypoint in each layer they cross", func() {
+		Expect(edge("job:unit", "job:build").Waypoints).To(HaveLen(1))
+
+		waypoints := edge("job:unit", "job:notify").Waypoints
+		Expect(waypoints).To(HaveLen(5))
+
+		for i, waypoint := range waypoints {
+			Expect(waypoint.Rank).To(Equal(i + 2))
+		}
+	})
+
+	It("gives every node and waypoint a distinct position within its layer", func() {
+		positions := map[atc.GraphWaypoint]bool{}
+		for _, node := range graph.Nodes {
+			position := atc.GraphWaypoint{Rank: node.Rank, Order: node.Order}
+			Expect(positions).NotTo(HaveKey(position))
+			positions[position] = true
+		}
+
+		for _, edge := range graph.Edges {
+			for _, waypoint := range edge.Waypoints {
+				Expect(positions).NotTo(HaveKey(waypoint))
+				positions[waypoint] = true
+			}
+		}
+	})
+
+	Context("when edges would cross", func() {
+		BeforeEach(func() {
+			jobs = atc.JobConfigs{
+				{Name: "a"},
+				{Name: "b"},
+				{
+					Name: "after-b",
+					Plan: atc.PlanSequence{{Get: "repo", Passed: []string{"b"}}},
+				},
+				{
+					Name: "after-a",
+					Plan: atc.PlanSequence{{Get: "repo", Passed: []string{"a"}}},
+				},
+			}
+		})
+
+		It("orders the nodes to uncross them", func() {
+			Expect(node("job:a").Order).To(BeNumerically("<", node("job:b").Order))
+			Expect(node("job:after-a").Order).To(BeNumerically("<", node("job:after-b").Order))
+		})
+	})
+
+	Context("when a job runs after another", func() {
+		BeforeEach(func() {
+			jobs = append(jobs, atc.JobConfig{
+				Name:  "smoke-test",
+				After: []string{"deploy", "deploy"},
+				Plan:  atc.PlanSequence{{Task: "smoke"}},
+			})
+		})
+
+		It("ranks it after the job", func() {
+			Expect(node("job:smoke-test").Rank).To(Equal(7))
+		})
+
+		It("connects the jobs once without a resource", func() {
+			Expect(edge("job:deploy", "job:smoke-test").Resource).To(BeEmpty())
+
+			after := 0
+			for _, e := range graph.Edges {
+				if e.Target == "job:smoke-test" {
+					after++
+				}
+			}
+
+			Expect(after).To(Equal(1))
+		})
+	})
+
+	Context("when passed constraints form a cycle", func() {
+		BeforeEach(func() {
+			jobs = atc.JobConfigs{
+				{
+					Name: "a",
+					Plan: atc.PlanSequence{{Get: "repo", Passed: []string{"b"}}},
+				},
+				{
+					Name: "b",
+					Plan: atc.PlanSequence{{Get: "repo", Passed: []string{"a"}}},
+				},
+			}
+		})
+
+		It("still lays out every job", func() {
+			Expect(graph.Nodes).To(HaveLen(2))
+			Expect(graph.Edges).To(HaveLen(2))
+		})
+	})
+
+	Context("when there are no jobs", func() {
+		BeforeEach(func() {
+			jobs = nil
+		})
+
+		It("returns an empty graph", func() {
+			Expect(graph).To(Equal(atc.PipelineGraph{
+				Nodes: []atc.GraphNode{},
+				Edges: []atc.GraphEdge{},
+			}))
+		})
+	})
+})
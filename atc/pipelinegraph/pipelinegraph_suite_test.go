@@ -0,0 +1,13 @@
+package pipelinegraph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPipelinegraph(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pipelinegraph Suite")
+}
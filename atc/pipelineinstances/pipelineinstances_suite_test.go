@@ -0,0 +1,13 @@
+package pipelineinstances_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPipelineinstances(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pipelineinstances Suite")
+}
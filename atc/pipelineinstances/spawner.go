@@ -0,0 +1,364 @@
+package pipelineinstances
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+// BranchVar is the var which is interpolated with the branch in the config
+// of each instance.
+const BranchVar = "branch"
+
+// Spawner creates an instance of each pipeline configured with branches for
+// every branch listed by the latest version of its branches resource, and
+// archives the instances of branches which are no longer listed.
+//
+// Instances are kept in sync with their parent's config. They are archived
+// by pausing them and releasing them from their parent, so that they can
+// still be looked at or destroyed by hand. An archived instance, like any
+// other pipeline already using an instance's name, has to be destroyed
+// before the instance can be spawned again.
+type Spawner struct {
+	pipelineFactory         db.PipelineFactory
+	teamFactory             db.TeamFactory
+	deprecatedResourceTypes atc.DeprecatedResourceTypes
+}
+
+func NewSpawner(
+	pipelineFactory db.PipelineFactory,
+	teamFactory db.TeamFactory,
+	deprecatedResourceTypes atc.DeprecatedResourceTypes,
+) *Spawner {
+	return &Spawner{
+		pipelineFactory:         pipelineFactory,
+		teamFactory:             teamFactory,
+		deprecatedResourceTypes: deprecatedResourceTypes,
+	}
+}
+
+func (s *Spawner) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("pipeline-instances")
+
+	pipelines, err := s.pipelineFactory.AllPipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		return err
+	}
+
+	byID := map[int]db.Pipeline{}
+	byName := map[int]map[string]db.Pipeline{}
+	instances := map[int][]db.Pipeline{}
+	for _, pipeline := range pipelines {
+		byID[pipeline.ID()] = pipeline
+
+		if byName[pipeline.TeamID()] == nil {
+			byName[pipeline.TeamID()] = map[string]db.Pipeline{}
+		}
+
+		byName[pipeline.TeamID()][pipeline.Name()] = pipeline
+
+		if pipeline.ParentID() != 0 {
+			instances[pipeline.ParentID()] = append(instances[pipeline.ParentID()], pipeline)
+		}
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.Branches() == nil {
+			continue
+		}
+
+		logger := logger.Session("spawn", lager.Data{
+			"team":     pipeline.TeamName(),
+			"pipeline": pipeline.Name(),
+		})
+
+		s.spawn(logger, pipeline, byName[pipeline.TeamID()], instances[pipeline.ID()])
+	}
+
+	// instances are archived along with their parent's branches config, or
+	// once their parent has been destroyed
+	for parentID, orphans := range instances {
+		parent, found := byID[parentID]
+		if found && parent.Branches() != nil {
+			continue
+		}
+
+		for _, instance := range orphans {
+			s.archive(logger, instance)
+		}
+	}
+
+	return nil
+}
+
+func (s *Spawner) spawn(logger lager.Logger, parent db.Pipeline, existing map[string]db.Pipeline, instances []db.Pipeline) {
+	branches, found, err := s.branches(parent)
+	if err != nil {
+		logger.Error("failed-to-list-branches", err)
+		return
+	}
+
+	// without a version the branches are not known yet, so no instances are
+	// archived either
+	if !found {
+		logger.Debug("no-branches-listed")
+		return
+	}
+
+	config, err := parent.Config()
+	if err != nil {
+		logger.Error("failed-to-get-config", err)
+		return
+	}
+
+	config = withoutBranches(config)
+
+	team := s.teamFactory.GetByID(parent.TeamID())
+
+	listed := map[string]bool{}
+	for _, branch := range branches {
+		listed[branch] = true
+
+		s.spawnInstance(logger, team, parent, existing[InstanceName(parent.Name(), branch)], branch, config)
+	}
+
+	for _, instance := range instances {
+		if !listed[instance.Branch()] {
+			s.archive(logger, instance)
+		}
+	}
+}
+
+// branches returns the branches listed by the latest version of the
+// pipeline's branches resource, or false if it has no versions yet.
+func (s *Spawner) branches(pipeline db.Pipeline) ([]string, bool, error) {
+	branchesConfig := pipeline.Branches()
+
+	resource, found, err := pipeline.Resource(branchesConfig.Resource)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, fmt.Errorf("unknown resource '%s'", branchesConfig.Resource)
+	}
+
+	versions, _, found, err := resource.Versions(db.Page{Limit: 1}, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found || len(versions) == 0 {
+		return nil, false, nil
+	}
+
+	field := branchesConfig.Field
+	if field == "" {
+		field = atc.DefaultBranchesField
+	}
+
+	seen := map[string]bool{}
+	branches := []string{}
+	for _, branch := range strings.Split(versions[0].Version[field], ",") {
+		branch = strings.TrimSpace(branch)
+		if branch == "" || seen[branch] {
+			continue
+		}
+
+		seen[branch] = true
+		branches = append(branches, branch)
+	}
+
+	sort.Strings(branches)
+
+	return branches, true, nil
+}
+
+func (s *Spawner) spawnInstance(logger lager.Logger, team db.Team, parent db.Pipeline, instance db.Pipeline, branch string, parentConfig atc.Config) {
+	name := InstanceName(parent.Name(), branch)
+
+	logger = logger.Session("instance", lager.Data{"instance": name, "branch": branch})
+
+	if instance != nil && (instance.ParentID() != parent.ID() || instance.Branch() != branch) {
+		logger.Info("conflicting-pipeline")
+		return
+	}
+
+	config, err := InstanceConfig(parentConfig, branch)
+	if err != nil {
+		logger.Error("failed-to-interpolate-config", err)
+		return
+	}
+
+	var from db.ConfigVersion
+	if instance != nil {
+		current, err := instance.Config()
+		if err != nil {
+			logger.Error("failed-to-get-instance-config", err)
+			return
+		}
+
+		same, err := sameConfig(current, config)
+		if err != nil {
+			logger.Error("failed-to-compare-configs", err)
+			return
+		}
+
+		if same {
+			return
+		}
+
+		from = instance.ConfigVersion()
+	}
+
+	_, errorMessages := config.Validate()
+	if len(errorMessages) > 0 {
+		logger.Info("invalid-config", lager.Data{"errors": errorMessages})
+		return
+	}
+
+	if team.RequireImageDigests() {
+		errorMessages = config.ImagesNotPinnedByDigest()
+		if len(errorMessages) > 0 {
+			logger.Info("images-not-pinned-by-digest", lager.Data{"errors": errorMessages})
+			return
+		}
+	}
+
+	saved, created, err := team.SavePipeline(name, config, from, false)
+	if err != nil {
+		logger.Error("failed-to-save-pipeline", err)
+		return
+	}
+
+	err = saved.UpdateDeprecations(config.Deprecations(s.deprecatedResourceTypes))
+	if err != nil {
+		logger.Error("failed-to-save-deprecations", err)
+	}
+
+	if created {
+		err = saved.MarkInstance(parent.ID(), branch)
+		if err != nil {
+			logger.Error("failed-to-mark-instance", err)
+			return
+		}
+	}
+
+	logger.Info("spawned", lager.Data{"created": created})
+}
+
+func (s *Spawner) archive(logger lager.Logger, instance db.Pipeline) {
+	logger = logger.Session("archive", lager.Data{
+		"team":     instance.TeamName(),
+		"instance": instance.Name(),
+		"branch":   instance.Branch(),
+	})
+
+	err := instance.Pause()
+	if err != nil {
+		logger.Error("failed-to-pause", err)
+		return
+	}
+
+	err = instance.ClearInstance()
+	if err != nil {
+		logger.Error("failed-to-release", err)
+		return
+	}
+
+	logger.Info("archived")
+}
+
+// InstanceName is the name of the instance of a pipeline for a branch.
+// Slashes in the branch are replaced, as they are not allowed in names.
+func InstanceName(pipelineName string, branch string) string {
+	return pipelineName + "-" + strings.Replace(branch, "/", "-", -1)
+}
+
+// InstanceConfig is the config of the instance of a pipeline for a branch,
+// with the branch var interpolated. Other vars are left as they are, to be
+// resolved when the instance runs.
+func InstanceConfig(config atc.Config, branch string) (atc.Config, error) {
+	payload, err := yaml.Marshal(config)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	interpolated, err := vars.NewTemplate(payload).Evaluate(vars.StaticVariables{
+		BranchVar: branch,
+	}, vars.EvaluateOpts{})
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var instanceConfig atc.Config
+	err = yaml.Unmarshal(interpolated, &instanceConfig)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return instanceConfig, nil
+}
+
+// withoutBranches removes the branches config from a parent's config, along
+// with the branches resource unless a job uses it too.
+func withoutBranches(config atc.Config) atc.Config {
+	if config.Branches == nil {
+		return config
+	}
+
+	branchesResource := config.Branches.Resource
+	config.Branches = nil
+
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			if input.Resource == branchesResource {
+				return config
+			}
+		}
+
+		for _, output := range job.Outputs() {
+			if output.Resource == branchesResource {
+				return config
+			}
+		}
+	}
+
+	resources := atc.ResourceConfigs{}
+	for _, resource := range config.Resources {
+		if resource.Name != branchesResource {
+			resources = append(resources, resource)
+		}
+	}
+
+	config.Resources = resources
+
+	return config
+}
+
+// sameConfig compares configs by their JSON representation, so that empty
+// and missing fields are treated the same.
+func sameConfig(a atc.Config, b atc.Config) (bool, error) {
+	payloadA, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+
+	payloadB, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(payloadA, payloadB), nil
+}
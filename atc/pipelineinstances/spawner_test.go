@@ -0,0 +1,311 @@
+package pipelineinstances_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/pipelineinstances"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Spawner", func() {
+	var (
+		fakePipelineFactory *dbfakes.FakePipelineFactory
+		fakeTeamFactory     *dbfakes.FakeTeamFactory
+		fakeTeam            *dbfakes.FakeTeam
+		fakeParent          *dbfakes.FakePipeline
+		fakeResource        *dbfakes.FakeResource
+		savedPipeline       *dbfakes.FakePipeline
+		pipelines           []db.Pipeline
+
+		parentConfig atc.Config
+
+		spawner *pipelineinstances.Spawner
+		runErr  error
+	)
+
+	instanceConfig := func(branch string) atc.Config {
+		return atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "repo",
+					Type:   "git",
+					Source: atc.Source{"uri": "https://example.com/repo.git", "branch": branch, "private_key": "((key))"},
+				},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "test",
+					Plan: atc.PlanSequence{
+						{Get: "repo"},
+					},
+				},
+			},
+		}
+	}
+
+	instance := func(id int, name string, branch string) *dbfakes.FakePipeline {
+		pipeline := new(dbfakes.FakePipeline)
+		pipeline.IDReturns(id)
+		pipeline.NameReturns(name)
+		pipeline.TeamIDReturns(1)
+		pipeline.ParentIDReturns(1)
+		pipeline.BranchReturns(branch)
+		pipeline.ConfigVersionReturns(db.ConfigVersion(3))
+		pipeline.ConfigReturns(instanceConfig(branch), nil)
+		return pipeline
+	}
+
+	savedNames := func() []string {
+		names := []string{}
+		for i := 0; i < fakeTeam.SavePipelineCallCount(); i++ {
+			name, _, _, _ := fakeTeam.SavePipelineArgsForCall(i)
+			names = append(names, name)
+		}
+
+		return names
+	}
+
+	BeforeEach(func() {
+		parentConfig = instanceConfig("((branch))")
+		parentConfig.Resources = append(parentConfig.Resources, atc.ResourceConfig{
+			Name:   "branches",
+			Type:   "git-branches",
+			Source: atc.Source{"uri": "https://example.com/repo.git"},
+		})
+		parentConfig.Branches = &atc.BranchesConfig{Resource: "branches"}
+
+		fakeResource = new(dbfakes.FakeResource)
+		fakeResource.VersionsReturns([]atc.ResourceVersion{
+			{Version: atc.Version{"branches": "master, feature/foo,master"}},
+		}, db.Pagination{}, true, nil)
+
+		fakeParent = new(dbfakes.FakePipeline)
+		fakeParent.IDReturns(1)
+		fakeParent.NameReturns("app")
+		fakeParent.TeamIDReturns(1)
+		fakeParent.BranchesReturns(parentConfig.Branches)
+		fakeParent.ConfigReturns(parentConfig, nil)
+		fakeParent.ResourceReturns(fakeResource, true, nil)
+
+		pipelines = []db.Pipeline{fakeParent}
+
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		fakePipelineFactory.AllPipelinesStub = func() ([]db.Pipeline, error) {
+			return pipelines, nil
+		}
+
+		savedPipeline = new(dbfakes.FakePipeline)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.SavePipelineReturns(savedPipeline, true, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		spawner = pipelineinstances.NewSpawner(
+			fakePipelineFactory,
+			fakeTeamFactory,
+			atc.DeprecatedResourceTypes{},
+		)
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = spawner.Run(ctx)
+	})
+
+	It("lists the branches from the latest version of the resource", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeParent.ResourceArgsForCall(0)).To(Equal("branches"))
+		Expect(fakeResource.VersionsCallCount()).To(Equal(1))
+
+		page, _ := fakeResource.VersionsArgsForCall(0)
+		Expect(page).To(Equal(db.Page{Limit: 1}))
+	})
+
+	It("spawns an instance for each branch", func() {
+		Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(1))
+		Expect(savedNames()).To(Equal([]string{"app-feature-foo", "app-master"}))
+
+		_, config, from, paused := fakeTeam.SavePipelineArgsForCall(0)
+		Expect(config).To(Equal(instanceConfig("feature/foo")))
+		Expect(from).To(BeZero())
+		Expect(paused).To(BeFalse())
+
+		Expect(savedPipeline.MarkInstanceCallCount()).To(Equal(2))
+
+		parentID, branch := savedPipeline.MarkInstanceArgsForCall(0)
+		Expect(parentID).To(Equal(1))
+		Expect(branch).To(Equal("feature/foo"))
+	})
+
+	Context("when the resource lists branches in a custom field", func() {
+		BeforeEach(func() {
+			fakeParent.BranchesReturns(&atc.BranchesConfig{Resource: "branches", Field: "refs"})
+			fakeResource.VersionsReturns([]atc.ResourceVersion{
+				{Version: atc.Version{"branches": "master", "refs": "develop"}},
+			}, db.Pagination{}, true, nil)
+		})
+
+		It("spawns instances for the branches in that field", func() {
+			Expect(savedNames()).To(Equal([]string{"app-develop"}))
+		})
+	})
+
+	Context("when a job uses the branches resource too", func() {
+		BeforeEach(func() {
+			parentConfig.Jobs[0].Plan = append(parentConfig.Jobs[0].Plan, atc.PlanConfig{Get: "branches"})
+			fakeParent.ConfigReturns(parentConfig, nil)
+		})
+
+		It("keeps the resource in the instances", func() {
+			_, config, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(config.Resources).To(HaveLen(2))
+			Expect(config.Branches).To(BeNil())
+		})
+	})
+
+	Context("when the resource has no versions yet", func() {
+		var fakeInstance *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakeInstance = instance(2, "app-old", "old")
+			pipelines = append(pipelines, fakeInstance)
+
+			fakeResource.VersionsReturns(nil, db.Pagination{}, false, nil)
+		})
+
+		It("neither spawns nor archives instances", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+			Expect(fakeInstance.PauseCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the instance already exists", func() {
+		var fakeInstance *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakeResource.VersionsReturns([]atc.ResourceVersion{
+				{Version: atc.Version{"branches": "master"}},
+			}, db.Pagination{}, true, nil)
+
+			fakeInstance = instance(2, "app-master", "master")
+			pipelines = append(pipelines, fakeInstance)
+		})
+
+		Context("with the same config as the parent", func() {
+			It("leaves it alone", func() {
+				Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+				Expect(fakeInstance.PauseCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the parent's config has changed", func() {
+			BeforeEach(func() {
+				parentConfig.Jobs[0].Public = true
+				fakeParent.ConfigReturns(parentConfig, nil)
+				fakeTeam.SavePipelineReturns(fakeInstance, false, nil)
+			})
+
+			It("updates the instance from its current version", func() {
+				Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+				name, config, from, _ := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(name).To(Equal("app-master"))
+				Expect(config.Jobs[0].Public).To(BeTrue())
+				Expect(from).To(Equal(db.ConfigVersion(3)))
+
+				Expect(fakeInstance.MarkInstanceCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when a pipeline which is not an instance has the same name", func() {
+		var fakePipeline *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakePipeline = instance(2, "app-master", "")
+			fakePipeline.ParentIDReturns(0)
+			pipelines = append(pipelines, fakePipeline)
+		})
+
+		It("does not touch it", func() {
+			Expect(savedNames()).To(Equal([]string{"app-feature-foo"}))
+			Expect(fakePipeline.PauseCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the branch of an instance is no longer listed", func() {
+		var fakeInstance *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakeInstance = instance(2, "app-old", "old")
+			pipelines = append(pipelines, fakeInstance)
+		})
+
+		It("archives the instance", func() {
+			Expect(fakeInstance.PauseCallCount()).To(Equal(1))
+			Expect(fakeInstance.ClearInstanceCallCount()).To(Equal(1))
+		})
+
+		Context("when pausing fails", func() {
+			BeforeEach(func() {
+				fakeInstance.PauseReturns(errors.New("nope"))
+			})
+
+			It("keeps it as an instance to try again", func() {
+				Expect(fakeInstance.ClearInstanceCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the parent no longer configures branches", func() {
+		var fakeInstance *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakeParent.BranchesReturns(nil)
+
+			fakeInstance = instance(2, "app-master", "master")
+			pipelines = append(pipelines, fakeInstance)
+		})
+
+		It("archives its instances", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+			Expect(fakeInstance.PauseCallCount()).To(Equal(1))
+			Expect(fakeInstance.ClearInstanceCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the parent has been destroyed", func() {
+		var fakeInstance *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakeInstance = instance(3, "gone-master", "master")
+			fakeInstance.ParentIDReturns(42)
+			pipelines = append(pipelines, fakeInstance)
+		})
+
+		It("archives its instances", func() {
+			Expect(fakeInstance.PauseCallCount()).To(Equal(1))
+			Expect(fakeInstance.ClearInstanceCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the pipelines fails", func() {
+		BeforeEach(func() {
+			fakePipelineFactory.AllPipelinesStub = nil
+			fakePipelineFactory.AllPipelinesReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
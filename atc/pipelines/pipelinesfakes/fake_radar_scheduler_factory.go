@@ -26,10 +26,11 @@ type FakeRadarSchedulerFactory struct {
 	buildScanRunnerFactoryReturnsOnCall map[int]struct {
 		result1 radar.ScanRunnerFactory
 	}
-	BuildSchedulerStub        func(db.Pipeline) scheduler.BuildScheduler
+	BuildSchedulerStub        func(db.Pipeline, vars.Variables) scheduler.BuildScheduler
 	buildSchedulerMutex       sync.RWMutex
 	buildSchedulerArgsForCall []struct {
 		arg1 db.Pipeline
+		arg2 vars.Variables
 	}
 	buildSchedulerReturns struct {
 		result1 scheduler.BuildScheduler
@@ -104,16 +105,17 @@ func (fake *FakeRadarSchedulerFactory) BuildScanRunnerFactoryReturnsOnCall(i int
 	}{result1}
 }
 
-func (fake *FakeRadarSchedulerFactory) BuildScheduler(arg1 db.Pipeline) scheduler.BuildScheduler {
+func (fake *FakeRadarSchedulerFactory) BuildScheduler(arg1 db.Pipeline, arg2 vars.Variables) scheduler.BuildScheduler {
 	fake.buildSchedulerMutex.Lock()
 	ret, specificReturn := fake.buildSchedulerReturnsOnCall[len(fake.buildSchedulerArgsForCall)]
 	fake.buildSchedulerArgsForCall = append(fake.buildSchedulerArgsForCall, struct {
 		arg1 db.Pipeline
-	}{arg1})
-	fake.recordInvocation("BuildScheduler", []interface{}{arg1})
+		arg2 vars.Variables
+	}{arg1, arg2})
+	fake.recordInvocation("BuildScheduler", []interface{}{arg1, arg2})
 	fake.buildSchedulerMutex.Unlock()
 	if fake.BuildSchedulerStub != nil {
-		return fake.BuildSchedulerStub(arg1)
+		return fake.BuildSchedulerStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -128,17 +130,17 @@ func (fake *FakeRadarSchedulerFactory) BuildSchedulerCallCount() int {
 	return len(fake.buildSchedulerArgsForCall)
 }
 
-func (fake *FakeRadarSchedulerFactory) BuildSchedulerCalls(stub func(db.Pipeline) scheduler.BuildScheduler) {
+func (fake *FakeRadarSchedulerFactory) BuildSchedulerCalls(stub func(db.Pipeline, vars.Variables) scheduler.BuildScheduler) {
 	fake.buildSchedulerMutex.Lock()
 	defer fake.buildSchedulerMutex.Unlock()
 	fake.BuildSchedulerStub = stub
 }
 
-func (fake *FakeRadarSchedulerFactory) BuildSchedulerArgsForCall(i int) db.Pipeline {
+func (fake *FakeRadarSchedulerFactory) BuildSchedulerArgsForCall(i int) (db.Pipeline, vars.Variables) {
 	fake.buildSchedulerMutex.RLock()
 	defer fake.buildSchedulerMutex.RUnlock()
 	argsForCall := fake.buildSchedulerArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRadarSchedulerFactory) BuildSchedulerReturns(result1 scheduler.BuildScheduler) {
@@ -5,14 +5,18 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/factory"
+	"github.com/concourse/concourse/atc/scheduler/gate"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputconfig"
 	"github.com/concourse/concourse/atc/scheduler/maxinflight"
+	"github.com/concourse/concourse/atc/scheduler/startlimit"
+	"github.com/concourse/concourse/atc/versionhook"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/vars"
 )
@@ -21,7 +25,7 @@ import (
 
 type RadarSchedulerFactory interface {
 	BuildScanRunnerFactory(dbPipeline db.Pipeline, externalURL string, variables vars.Variables, notifications radar.Notifications) radar.ScanRunnerFactory
-	BuildScheduler(pipeline db.Pipeline) scheduler.BuildScheduler
+	BuildScheduler(pipeline db.Pipeline, variables vars.Variables) scheduler.BuildScheduler
 }
 
 type radarSchedulerFactory struct {
@@ -31,6 +35,15 @@ type radarSchedulerFactory struct {
 	resourceTypeCheckingInterval time.Duration
 	resourceCheckingInterval     time.Duration
 	strategy                     worker.ContainerPlacementStrategy
+	startLimiter                 startlimit.Limiter
+	checkPolicy                  checkpolicy.Enforcer
+	versionNotifier              versionhook.Notifier
+	gates                        gate.Evaluator
+	inputVersionLimit            int
+	teamFactory                  db.TeamFactory
+	caCerts                      string
+	checkLimits                  map[string]atc.ContainerLimits
+	checkKillGracePeriod         time.Duration
 }
 
 func NewRadarSchedulerFactory(
@@ -40,6 +53,15 @@ func NewRadarSchedulerFactory(
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
 	strategy worker.ContainerPlacementStrategy,
+	startLimiter startlimit.Limiter,
+	checkPolicy checkpolicy.Enforcer,
+	versionNotifier versionhook.Notifier,
+	gates gate.Evaluator,
+	inputVersionLimit int,
+	teamFactory db.TeamFactory,
+	caCerts string,
+	checkLimits map[string]atc.ContainerLimits,
+	checkKillGracePeriod time.Duration,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		pool:                         pool,
@@ -48,6 +70,15 @@ func NewRadarSchedulerFactory(
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
 		resourceCheckingInterval:     resourceCheckingInterval,
 		strategy:                     strategy,
+		startLimiter:                 startLimiter,
+		checkPolicy:                  checkPolicy,
+		versionNotifier:              versionNotifier,
+		gates:                        gates,
+		inputVersionLimit:            inputVersionLimit,
+		teamFactory:                  teamFactory,
+		caCerts:                      caCerts,
+		checkLimits:                  checkLimits,
+		checkKillGracePeriod:         checkKillGracePeriod,
 	}
 }
 
@@ -63,14 +94,21 @@ func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(dbPipeline db.Pipeline,
 		externalURL,
 		variables,
 		rsf.strategy,
+		rsf.checkPolicy,
+		rsf.versionNotifier,
+		rsf.teamFactory,
+		rsf.caCerts,
+		rsf.checkLimits,
+		rsf.checkKillGracePeriod,
 		notifications,
 	)
 }
 
-func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline) scheduler.BuildScheduler {
+func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, variables vars.Variables) scheduler.BuildScheduler {
 	inputMapper := inputmapper.NewInputMapper(
 		pipeline,
 		inputconfig.NewTransformer(pipeline),
+		rsf.inputVersionLimit,
 	)
 	return &scheduler.Scheduler{
 		Pipeline:    pipeline,
@@ -82,6 +120,11 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline) scheduler
 				atc.NewPlanFactory(time.Now().Unix()),
 			),
 			inputMapper,
+			rsf.startLimiter,
+			rsf.gates,
+			variables,
+			clock.NewClock(),
 		),
+		Clock: clock.NewClock(),
 	}
 }
@@ -0,0 +1,53 @@
+package atc
+
+// PipelinesRepo configures the directory of a git repository holding one
+// config file per pipeline, named after the pipeline, e.g. "ci.yml". The
+// team's pipelines are created, updated and archived to match it.
+type PipelinesRepo struct {
+	URI    string `json:"uri"`
+	Branch string `json:"branch,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+const (
+	// PipelineInSync means the pipeline already matches the repo.
+	PipelineInSync = "in-sync"
+
+	// PipelineCreated means the pipeline was created from the repo.
+	PipelineCreated = "created"
+
+	// PipelineUpdated means the pipeline's file changed in the repo and the
+	// pipeline was updated to match.
+	PipelineUpdated = "updated"
+
+	// PipelineDrifted means the pipeline's config was changed other than by
+	// the repo, and has been restored to match the repo.
+	PipelineDrifted = "drifted"
+
+	// PipelineArchived means the pipeline's file was removed from the repo,
+	// so the pipeline was paused and is no longer managed by the repo.
+	PipelineArchived = "archived"
+
+	// PipelineConflicted means the repo has a file for a pipeline which was
+	// set by hand. The pipeline is left alone until it is destroyed.
+	PipelineConflicted = "conflicted"
+
+	// PipelineInvalid means the pipeline's file could not be parsed or is
+	// not a valid config. The pipeline is left as it was.
+	PipelineInvalid = "invalid"
+)
+
+// PipelinesRepoStatus describes the last reconciliation of a team's
+// pipelines with its PipelinesRepo.
+type PipelinesRepoStatus struct {
+	Revision     string                   `json:"revision,omitempty"`
+	ReconciledAt int64                    `json:"reconciled_at"`
+	Error        string                   `json:"error,omitempty"`
+	Pipelines    []PipelineReconciliation `json:"pipelines,omitempty"`
+}
+
+type PipelineReconciliation struct {
+	Name   string   `json:"name"`
+	Status string   `json:"status"`
+	Errors []string `json:"errors,omitempty"`
+}
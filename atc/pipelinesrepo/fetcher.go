@@ -0,0 +1,122 @@
+package pipelinesrepo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . Fetcher
+
+// Fetcher reads the pipeline configs from a team's pipelines repo.
+type Fetcher interface {
+	// Fetch returns the revision of the repo's branch and the contents of
+	// each config file in the repo's path, keyed by pipeline name.
+	Fetch(ctx context.Context, repo atc.PipelinesRepo) (string, map[string][]byte, error)
+}
+
+// NewGitFetcher returns a Fetcher which shallow clones repos with the git
+// CLI. Only the given protocols, e.g. "https:ssh", may be used by repo URIs.
+func NewGitFetcher(allowedProtocols string) Fetcher {
+	return &gitFetcher{
+		allowedProtocols: allowedProtocols,
+	}
+}
+
+type gitFetcher struct {
+	allowedProtocols string
+}
+
+func (f *gitFetcher) Fetch(ctx context.Context, repo atc.PipelinesRepo) (string, map[string][]byte, error) {
+	dir, err := ioutil.TempDir("", "pipelines-repo")
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--quiet", "--depth", "1", "--single-branch"}
+	if repo.Branch != "" {
+		args = append(args, "--branch", repo.Branch)
+	}
+
+	_, err = f.git(ctx, "", append(args, "--", repo.URI, dir)...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	revision, err := f.git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", nil, err
+	}
+
+	configsDir, err := filepath.EvalSymlinks(filepath.Join(dir, filepath.FromSlash(repo.Path)))
+	if err != nil {
+		return "", nil, fmt.Errorf("path '%s' not found in repo", repo.Path)
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if configsDir != root && !strings.HasPrefix(configsDir, root+string(filepath.Separator)) {
+		return "", nil, fmt.Errorf("path '%s' is outside of the repo", repo.Path)
+	}
+
+	entries, err := ioutil.ReadDir(configsDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	configs := map[string][]byte{}
+	for _, entry := range entries {
+		// symlinks are skipped so that files outside of the repo can't be read
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+
+		config, err := ioutil.ReadFile(filepath.Join(configsDir, entry.Name()))
+		if err != nil {
+			return "", nil, err
+		}
+
+		configs[strings.TrimSuffix(entry.Name(), ext)] = config
+	}
+
+	return revision, configs, nil
+}
+
+func (f *gitFetcher) git(ctx context.Context, dir string, args ...string) (string, error) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_ALLOW_PROTOCOL="+f.allowedProtocols,
+		"GIT_TERMINAL_PROMPT=0",
+	)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %s: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,127 @@
+package pipelinesrepo_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/pipelinesrepo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GitFetcher", func() {
+	var (
+		repoDir string
+		repo    atc.PipelinesRepo
+
+		fetcher pipelinesrepo.Fetcher
+	)
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+
+		output, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+
+		return string(output)
+	}
+
+	writeFile := func(path string, contents string) {
+		path = filepath.Join(repoDir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is not installed")
+		}
+
+		var err error
+		repoDir, err = ioutil.TempDir("", "pipelines-repo-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		git("init", "--quiet")
+		git("checkout", "--quiet", "-b", "main")
+
+		writeFile("ci/some-pipeline.yml", "jobs: []\n")
+		writeFile("ci/other-pipeline.yaml", "resources: []\n")
+		writeFile("ci/README.md", "not a pipeline\n")
+		writeFile("elsewhere/ignored.yml", "jobs: []\n")
+		Expect(os.Symlink("/etc/hostname", filepath.Join(repoDir, "ci", "linked.yml"))).To(Succeed())
+
+		git("add", "-A")
+		git("commit", "--quiet", "-m", "pipelines")
+
+		repo = atc.PipelinesRepo{
+			URI:    "file://" + repoDir,
+			Branch: "main",
+			Path:   "ci",
+		}
+
+		fetcher = pipelinesrepo.NewGitFetcher("file")
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(repoDir)
+	})
+
+	It("returns the revision and the pipeline configs in the path", func() {
+		revision, configs, err := fetcher.Fetch(context.Background(), repo)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(revision).To(HaveLen(40))
+		Expect(git("rev-parse", "HEAD")).To(HavePrefix(revision))
+
+		Expect(configs).To(Equal(map[string][]byte{
+			"some-pipeline":  []byte("jobs: []\n"),
+			"other-pipeline": []byte("resources: []\n"),
+		}))
+	})
+
+	Context("when the path does not exist", func() {
+		BeforeEach(func() {
+			repo.Path = "missing"
+		})
+
+		It("returns an error", func() {
+			_, _, err := fetcher.Fetch(context.Background(), repo)
+			Expect(err).To(MatchError("path 'missing' not found in repo"))
+		})
+	})
+
+	Context("when the path is a symlink out of the repo", func() {
+		BeforeEach(func() {
+			Expect(os.Symlink("/etc", filepath.Join(repoDir, "outside"))).To(Succeed())
+			git("add", "-A")
+			git("commit", "--quiet", "-m", "symlink")
+
+			repo.Path = "outside"
+		})
+
+		It("returns an error", func() {
+			_, _, err := fetcher.Fetch(context.Background(), repo)
+			Expect(err).To(MatchError("path 'outside' is outside of the repo"))
+		})
+	})
+
+	Context("when the repo's protocol is not allowed", func() {
+		BeforeEach(func() {
+			fetcher = pipelinesrepo.NewGitFetcher("https")
+		})
+
+		It("returns an error", func() {
+			_, _, err := fetcher.Fetch(context.Background(), repo)
+			Expect(err).To(MatchError(ContainSubstring("git clone failed")))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package pipelinesrepo_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPipelinesrepo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pipelinesrepo Suite")
+}
@@ -0,0 +1,262 @@
+package pipelinesrepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"sigs.k8s.io/yaml"
+)
+
+// FetchTimeout bounds the fetching of each team's pipelines repo.
+const FetchTimeout = time.Minute
+
+// Reconciler creates, updates and archives each team's pipelines to match
+// the team's pipelines repo, recording the outcome on the team.
+//
+// Pipelines created from the repo are managed by it: they are updated
+// whenever their file changes, restored if their config is changed by other
+// means, and paused and released once their file is removed. Pipelines set
+// by hand are never touched, even if the repo has a file for them.
+type Reconciler struct {
+	clock                   clock.Clock
+	teamFactory             db.TeamFactory
+	fetcher                 Fetcher
+	deprecatedResourceTypes atc.DeprecatedResourceTypes
+}
+
+func NewReconciler(
+	clock clock.Clock,
+	teamFactory db.TeamFactory,
+	fetcher Fetcher,
+	deprecatedResourceTypes atc.DeprecatedResourceTypes,
+) *Reconciler {
+	return &Reconciler{
+		clock:                   clock,
+		teamFactory:             teamFactory,
+		fetcher:                 fetcher,
+		deprecatedResourceTypes: deprecatedResourceTypes,
+	}
+}
+
+func (r *Reconciler) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("pipelines-repo-reconciler")
+
+	teams, err := r.teamFactory.GetTeams()
+	if err != nil {
+		logger.Error("failed-to-get-teams", err)
+		return err
+	}
+
+	for _, team := range teams {
+		repo := team.PipelinesRepo()
+		if repo == nil {
+			continue
+		}
+
+		logger := logger.Session("reconcile", lager.Data{"team": team.Name()})
+
+		status := r.reconcile(ctx, logger, team, *repo)
+
+		err = team.UpdatePipelinesRepoStatus(status)
+		if err != nil {
+			logger.Error("failed-to-update-status", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, logger lager.Logger, team db.Team, repo atc.PipelinesRepo) atc.PipelinesRepoStatus {
+	status := atc.PipelinesRepoStatus{
+		ReconciledAt: r.clock.Now().Unix(),
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, FetchTimeout)
+	defer cancel()
+
+	revision, configs, err := r.fetcher.Fetch(fetchCtx, repo)
+	if err != nil {
+		logger.Info("failed-to-fetch", lager.Data{"error": err.Error()})
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Revision = revision
+
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		status.Error = err.Error()
+		return status
+	}
+
+	existing := map[string]db.Pipeline{}
+	for _, pipeline := range pipelines {
+		existing[pipeline.Name()] = pipeline
+	}
+
+	names := []string{}
+	for name := range configs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		status.Pipelines = append(status.Pipelines, r.reconcilePipeline(logger, team, existing[name], name, configs[name]))
+	}
+
+	for _, pipeline := range pipelines {
+		if _, found := configs[pipeline.Name()]; found || pipeline.ReconciledDigest() == "" {
+			continue
+		}
+
+		status.Pipelines = append(status.Pipelines, r.archivePipeline(logger, pipeline))
+	}
+
+	return status
+}
+
+func (r *Reconciler) reconcilePipeline(logger lager.Logger, team db.Team, pipeline db.Pipeline, name string, payload []byte) atc.PipelineReconciliation {
+	logger = logger.Session("pipeline", lager.Data{"pipeline": name})
+
+	reconciliation := atc.PipelineReconciliation{Name: name}
+
+	digest := fmt.Sprintf("%x", sha256.Sum256(payload))
+
+	var drifted bool
+	var from db.ConfigVersion
+	if pipeline != nil {
+		if pipeline.ReconciledDigest() == "" {
+			reconciliation.Status = atc.PipelineConflicted
+			reconciliation.Errors = []string{"pipeline was not created from the repo; destroy it to manage it from the repo"}
+			return reconciliation
+		}
+
+		drifted = pipeline.ConfigVersion() != pipeline.ReconciledConfigVersion()
+		if !drifted && pipeline.ReconciledDigest() == digest {
+			reconciliation.Status = atc.PipelineInSync
+			return reconciliation
+		}
+
+		from = pipeline.ConfigVersion()
+	}
+
+	config, err := parseConfig(payload)
+	if err != nil {
+		reconciliation.Status = atc.PipelineInvalid
+		reconciliation.Errors = []string{err.Error()}
+		return reconciliation
+	}
+
+	_, errorMessages := config.Validate()
+	if len(errorMessages) > 0 {
+		reconciliation.Status = atc.PipelineInvalid
+		reconciliation.Errors = errorMessages
+		return reconciliation
+	}
+
+	if team.RequireImageDigests() {
+		errorMessages = config.ImagesNotPinnedByDigest()
+		if len(errorMessages) > 0 {
+			reconciliation.Status = atc.PipelineInvalid
+			reconciliation.Errors = errorMessages
+			return reconciliation
+		}
+	}
+
+	saved, created, err := team.SavePipeline(name, config, from, false)
+	if err != nil {
+		logger.Error("failed-to-save-pipeline", err)
+		reconciliation.Status = atc.PipelineInvalid
+		reconciliation.Errors = []string{fmt.Sprintf("failed to save config: %s", err)}
+		return reconciliation
+	}
+
+	err = saved.UpdateDeprecations(config.Deprecations(r.deprecatedResourceTypes))
+	if err != nil {
+		logger.Error("failed-to-save-deprecations", err)
+	}
+
+	err = saved.MarkReconciled(digest)
+	if err != nil {
+		logger.Error("failed-to-mark-reconciled", err)
+	}
+
+	switch {
+	case created:
+		reconciliation.Status = atc.PipelineCreated
+	case drifted:
+		reconciliation.Status = atc.PipelineDrifted
+	default:
+		reconciliation.Status = atc.PipelineUpdated
+	}
+
+	logger.Info("reconciled", lager.Data{"status": reconciliation.Status})
+
+	return reconciliation
+}
+
+func (r *Reconciler) archivePipeline(logger lager.Logger, pipeline db.Pipeline) atc.PipelineReconciliation {
+	logger = logger.Session("archive", lager.Data{"pipeline": pipeline.Name()})
+
+	reconciliation := atc.PipelineReconciliation{
+		Name:   pipeline.Name(),
+		Status: atc.PipelineArchived,
+	}
+
+	err := pipeline.Pause()
+	if err != nil {
+		logger.Error("failed-to-pause", err)
+		reconciliation.Errors = append(reconciliation.Errors, fmt.Sprintf("failed to pause: %s", err))
+		return reconciliation
+	}
+
+	err = pipeline.ClearReconciled()
+	if err != nil {
+		logger.Error("failed-to-release", err)
+		reconciliation.Errors = append(reconciliation.Errors, fmt.Sprintf("failed to release: %s", err))
+	}
+
+	return reconciliation
+}
+
+// parseConfig reads a pipeline config the same way as setting it through
+// the API, ignoring unknown top-level keys such as those holding anchors.
+func parseConfig(payload []byte) (atc.Config, error) {
+	var config atc.Config
+
+	toplevels := map[string]interface{}{}
+	err := yaml.Unmarshal(payload, &toplevels)
+	if err != nil {
+		return config, fmt.Errorf("malformed config: %s", err)
+	}
+
+	for key := range toplevels {
+		switch key {
+		case "groups", "jobs", "resources", "resource_types", "branches":
+		default:
+			delete(toplevels, key)
+		}
+	}
+
+	known, err := yaml.Marshal(toplevels)
+	if err != nil {
+		return config, err
+	}
+
+	err = yaml.UnmarshalStrict(known, &config, yaml.DisallowUnknownFields)
+	if err != nil {
+		return config, fmt.Errorf("malformed config: %s", err)
+	}
+
+	return config, nil
+}
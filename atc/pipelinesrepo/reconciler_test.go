@@ -0,0 +1,288 @@
+package pipelinesrepo_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/pipelinesrepo"
+	"github.com/concourse/concourse/atc/pipelinesrepo/pipelinesrepofakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reconciler", func() {
+	const validConfig = `
+resources:
+- name: some-resource
+  type: git
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+
+	var (
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeFetcher     *pipelinesrepofakes.FakeFetcher
+		savedPipeline   *dbfakes.FakePipeline
+		configs         map[string][]byte
+
+		reconciler *pipelinesrepo.Reconciler
+		runErr     error
+	)
+
+	digest := func(config string) string {
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(config)))
+	}
+
+	managedPipeline := func(name string, digest string, version int) *dbfakes.FakePipeline {
+		pipeline := new(dbfakes.FakePipeline)
+		pipeline.NameReturns(name)
+		pipeline.ReconciledDigestReturns(digest)
+		pipeline.ReconciledConfigVersionReturns(db.ConfigVersion(version))
+		pipeline.ConfigVersionReturns(db.ConfigVersion(version))
+		return pipeline
+	}
+
+	status := func() atc.PipelinesRepoStatus {
+		Expect(fakeTeam.UpdatePipelinesRepoStatusCallCount()).To(Equal(1))
+		return fakeTeam.UpdatePipelinesRepoStatusArgsForCall(0)
+	}
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+		fakeTeam.PipelinesRepoReturns(&atc.PipelinesRepo{URI: "https://example.com/pipelines.git", Path: "ci"})
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetTeamsReturns([]db.Team{fakeTeam}, nil)
+
+		savedPipeline = new(dbfakes.FakePipeline)
+		fakeTeam.SavePipelineReturns(savedPipeline, true, nil)
+
+		configs = map[string][]byte{"some-pipeline": []byte(validConfig)}
+
+		fakeFetcher = new(pipelinesrepofakes.FakeFetcher)
+		fakeFetcher.FetchStub = func(context.Context, atc.PipelinesRepo) (string, map[string][]byte, error) {
+			return "abc123", configs, nil
+		}
+
+		reconciler = pipelinesrepo.NewReconciler(
+			fakeclock.NewFakeClock(time.Unix(42, 0)),
+			fakeTeamFactory,
+			fakeFetcher,
+			atc.DeprecatedResourceTypes{},
+		)
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = reconciler.Run(ctx)
+	})
+
+	It("fetches the team's repo", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeFetcher.FetchCallCount()).To(Equal(1))
+
+		_, repo := fakeFetcher.FetchArgsForCall(0)
+		Expect(repo).To(Equal(atc.PipelinesRepo{URI: "https://example.com/pipelines.git", Path: "ci"}))
+	})
+
+	Context("when the team has no pipelines repo", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelinesRepoReturns(nil)
+		})
+
+		It("leaves the team alone", func() {
+			Expect(fakeFetcher.FetchCallCount()).To(BeZero())
+			Expect(fakeTeam.UpdatePipelinesRepoStatusCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when fetching fails", func() {
+		BeforeEach(func() {
+			fakeFetcher.FetchStub = nil
+			fakeFetcher.FetchReturns("", nil, errors.New("repository not found"))
+		})
+
+		It("records the error", func() {
+			Expect(status()).To(Equal(atc.PipelinesRepoStatus{
+				ReconciledAt: 42,
+				Error:        "repository not found",
+			}))
+		})
+
+		It("does not touch any pipelines", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the repo has a new pipeline", func() {
+		It("creates it unpaused and marks it as managed", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+			name, config, from, initiallyPaused := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(config.Jobs).To(HaveLen(1))
+			Expect(from).To(BeZero())
+			Expect(initiallyPaused).To(BeFalse())
+
+			Expect(savedPipeline.MarkReconciledCallCount()).To(Equal(1))
+			Expect(savedPipeline.MarkReconciledArgsForCall(0)).To(Equal(digest(validConfig)))
+		})
+
+		It("records its deprecations", func() {
+			Expect(savedPipeline.UpdateDeprecationsCallCount()).To(Equal(1))
+		})
+
+		It("records that it was created", func() {
+			Expect(status()).To(Equal(atc.PipelinesRepoStatus{
+				Revision:     "abc123",
+				ReconciledAt: 42,
+				Pipelines: []atc.PipelineReconciliation{
+					{Name: "some-pipeline", Status: atc.PipelineCreated},
+				},
+			}))
+		})
+	})
+
+	Context("when a managed pipeline matches the repo", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelinesReturns([]db.Pipeline{managedPipeline("some-pipeline", digest(validConfig), 3)}, nil)
+		})
+
+		It("does not save it", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+			Expect(status().Pipelines).To(Equal([]atc.PipelineReconciliation{
+				{Name: "some-pipeline", Status: atc.PipelineInSync},
+			}))
+		})
+	})
+
+	Context("when a managed pipeline's file has changed", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelinesReturns([]db.Pipeline{managedPipeline("some-pipeline", "old-digest", 3)}, nil)
+			fakeTeam.SavePipelineReturns(savedPipeline, false, nil)
+		})
+
+		It("updates it from its current config version", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+			_, _, from, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(from).To(Equal(db.ConfigVersion(3)))
+
+			Expect(savedPipeline.MarkReconciledArgsForCall(0)).To(Equal(digest(validConfig)))
+			Expect(status().Pipelines).To(Equal([]atc.PipelineReconciliation{
+				{Name: "some-pipeline", Status: atc.PipelineUpdated},
+			}))
+		})
+	})
+
+	Context("when a managed pipeline's config was changed other than by the repo", func() {
+		BeforeEach(func() {
+			pipeline := managedPipeline("some-pipeline", digest(validConfig), 3)
+			pipeline.ConfigVersionReturns(4)
+
+			fakeTeam.PipelinesReturns([]db.Pipeline{pipeline}, nil)
+			fakeTeam.SavePipelineReturns(savedPipeline, false, nil)
+		})
+
+		It("restores it and reports the drift", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+
+			_, _, from, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(from).To(Equal(db.ConfigVersion(4)))
+
+			Expect(status().Pipelines).To(Equal([]atc.PipelineReconciliation{
+				{Name: "some-pipeline", Status: atc.PipelineDrifted},
+			}))
+		})
+	})
+
+	Context("when the repo has a file for a pipeline set by hand", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelinesReturns([]db.Pipeline{managedPipeline("some-pipeline", "", 3)}, nil)
+		})
+
+		It("leaves the pipeline alone and reports the conflict", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+
+			reconciliations := status().Pipelines
+			Expect(reconciliations).To(HaveLen(1))
+			Expect(reconciliations[0].Status).To(Equal(atc.PipelineConflicted))
+		})
+	})
+
+	Context("when a pipeline's file is invalid", func() {
+		BeforeEach(func() {
+			configs = map[string][]byte{
+				"malformed": []byte("jobs: {"),
+				"invalid":   []byte("jobs:\n- plan: []\n"),
+			}
+		})
+
+		It("saves neither and reports why", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+
+			reconciliations := status().Pipelines
+			Expect(reconciliations).To(HaveLen(2))
+
+			Expect(reconciliations[0].Name).To(Equal("invalid"))
+			Expect(reconciliations[0].Status).To(Equal(atc.PipelineInvalid))
+			Expect(reconciliations[0].Errors).To(ContainElement(ContainSubstring("jobs[0] has no name")))
+
+			Expect(reconciliations[1].Name).To(Equal("malformed"))
+			Expect(reconciliations[1].Status).To(Equal(atc.PipelineInvalid))
+			Expect(reconciliations[1].Errors).To(ConsistOf(ContainSubstring("malformed config")))
+		})
+	})
+
+	Context("when the file has unknown top-level keys", func() {
+		BeforeEach(func() {
+			configs = map[string][]byte{
+				"some-pipeline": []byte("shared: &shared {}\n" + validConfig),
+			}
+		})
+
+		It("ignores them", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a managed pipeline's file was removed", func() {
+		var removed *dbfakes.FakePipeline
+		var unmanaged *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			configs = map[string][]byte{}
+
+			removed = managedPipeline("removed-pipeline", "some-digest", 1)
+			unmanaged = managedPipeline("unmanaged-pipeline", "", 1)
+			fakeTeam.PipelinesReturns([]db.Pipeline{removed, unmanaged}, nil)
+		})
+
+		It("pauses and releases it", func() {
+			Expect(removed.PauseCallCount()).To(Equal(1))
+			Expect(removed.ClearReconciledCallCount()).To(Equal(1))
+
+			Expect(status().Pipelines).To(Equal([]atc.PipelineReconciliation{
+				{Name: "removed-pipeline", Status: atc.PipelineArchived},
+			}))
+		})
+
+		It("leaves pipelines set by hand alone", func() {
+			Expect(unmanaged.PauseCallCount()).To(BeZero())
+			Expect(unmanaged.ClearReconciledCallCount()).To(BeZero())
+		})
+	})
+})
@@ -4,6 +4,10 @@ type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
+	// ConcurrencyPools are the team's pools which the step is a member of
+	// while it runs.
+	ConcurrencyPools []string `json:"concurrency_pools,omitempty"`
+
 	Aggregate  *AggregatePlan  `json:"aggregate,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
 	Do         *DoPlan         `json:"do,omitempty"`
@@ -92,6 +96,11 @@ type GetPlan struct {
 	Version     *Version `json:"version,omitempty"`
 	VersionFrom *PlanID  `json:"version_from,omitempty"`
 	Tags        Tags     `json:"tags,omitempty"`
+	Labels      Labels   `json:"labels,omitempty"`
+
+	// Worker is set for builds which were triggered with the step pinned to
+	// a worker.
+	Worker string `json:"worker,omitempty"`
 
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
@@ -103,8 +112,13 @@ type PutPlan struct {
 	Source   Source        `json:"source"`
 	Params   Params        `json:"params,omitempty"`
 	Tags     Tags          `json:"tags,omitempty"`
+	Labels   Labels        `json:"labels,omitempty"`
 	Inputs   *InputsConfig `json:"inputs,omitempty"`
 
+	// Worker is set for builds which were triggered with the step pinned to
+	// a worker.
+	Worker string `json:"worker,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -116,24 +130,50 @@ type CheckPlan struct {
 	Timeout     string  `json:"timeout,omitempty"`
 	FromVersion Version `json:"from_version,omitempty"`
 
+	// HardTimeout is how long the check may run before its container's
+	// processes are killed and the check is abandoned, for checks which
+	// don't exit after being interrupted at Timeout.
+	HardTimeout string `json:"hard_timeout,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
 type TaskPlan struct {
 	Name string `json:"name,omitempty"`
 
-	Privileged bool `json:"privileged"`
-	Tags       Tags `json:"tags,omitempty"`
+	Privileged bool   `json:"privileged"`
+	GPUs       int    `json:"gpus,omitempty"`
+	Tags       Tags   `json:"tags,omitempty"`
+	Labels     Labels `json:"labels,omitempty"`
 
 	ConfigPath string      `json:"config_path,omitempty"`
 	Config     *TaskConfig `json:"config,omitempty"`
 	Vars       Params      `json:"vars,omitempty"`
 
+	// OutputVars is the path of a YAML file in one of the task's outputs,
+	// e.g. out/vars.yml, whose vars are loaded once the task succeeds. They
+	// are used to interpolate the file task configs of later steps in the
+	// build, under the task's name, e.g. ((unit.go-version)).
+	OutputVars string `json:"output_vars,omitempty"`
+
 	Params            Params            `json:"params,omitempty"`
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 
+	// FileParams maps the names of params to the paths of files, relative to
+	// the task's working directory, which they're written to instead of
+	// being set in its environment.
+	FileParams map[string]string `json:"file_params,omitempty"`
+
+	// ImageOverride is set for builds which were triggered with an override
+	// of the task's image_resource.
+	ImageOverride *TaskImageOverride `json:"image_override,omitempty"`
+
+	// Worker is set for builds which were triggered with the step pinned to
+	// a worker.
+	Worker string `json:"worker,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -144,3 +184,39 @@ type DependentGetPlan struct {
 	Name     string `json:"name,omitempty"`
 	Resource string `json:"resource"`
 }
+
+// Each calls f with the plan and then each of the plans nested in it, in
+// order.
+func (plan Plan) Each(f func(Plan)) {
+	f(plan)
+
+	var nested []Plan
+	switch {
+	case plan.Aggregate != nil:
+		nested = *plan.Aggregate
+	case plan.InParallel != nil:
+		nested = plan.InParallel.Steps
+	case plan.Do != nil:
+		nested = *plan.Do
+	case plan.Retry != nil:
+		nested = *plan.Retry
+	case plan.OnAbort != nil:
+		nested = []Plan{plan.OnAbort.Step, plan.OnAbort.Next}
+	case plan.OnError != nil:
+		nested = []Plan{plan.OnError.Step, plan.OnError.Next}
+	case plan.Ensure != nil:
+		nested = []Plan{plan.Ensure.Step, plan.Ensure.Next}
+	case plan.OnSuccess != nil:
+		nested = []Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.OnFailure != nil:
+		nested = []Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.Try != nil:
+		nested = []Plan{plan.Try.Step}
+	case plan.Timeout != nil:
+		nested = []Plan{plan.Timeout.Step}
+	}
+
+	for _, p := range nested {
+		p.Each(f)
+	}
+}
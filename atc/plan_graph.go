@@ -0,0 +1,214 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PlanGraph is a plan laid out as a directed graph of its steps, for tools
+// which visualize or analyze plans without knowing how each kind of step
+// nests the others.
+type PlanGraph struct {
+	Nodes []PlanGraphNode `json:"nodes"`
+	Edges []PlanGraphEdge `json:"edges"`
+}
+
+// PlanGraphNode is a step of the plan, including the steps which only
+// arrange others, such as do and on_success.
+type PlanGraphNode struct {
+	ID   PlanID `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type PlanGraphEdgeType string
+
+const (
+	// PlanGraphEdgeContains goes from a step to each step nested in it.
+	PlanGraphEdgeContains PlanGraphEdgeType = "contains"
+
+	// PlanGraphEdgeNext goes from a step of a do to the step run after it.
+	PlanGraphEdgeNext PlanGraphEdgeType = "next"
+
+	// PlanGraphEdgeRetry goes from an attempt of a retried step to the attempt
+	// run if it fails.
+	PlanGraphEdgeRetry PlanGraphEdgeType = "retry"
+
+	// PlanGraphEdgeVersionFrom goes from a put to the get which fetches the
+	// version it created.
+	PlanGraphEdgeVersionFrom PlanGraphEdgeType = "version_from"
+
+	// The hook edges go from a step to the hook run after it.
+	PlanGraphEdgeOnSuccess PlanGraphEdgeType = "on_success"
+	PlanGraphEdgeOnFailure PlanGraphEdgeType = "on_failure"
+	PlanGraphEdgeOnError   PlanGraphEdgeType = "on_error"
+	PlanGraphEdgeOnAbort   PlanGraphEdgeType = "on_abort"
+	PlanGraphEdgeEnsure    PlanGraphEdgeType = "ensure"
+)
+
+// PlanGraphEdge means the To step depends on the From step in the way given
+// by its type. Every edge but a contains edge means To runs after From.
+type PlanGraphEdge struct {
+	From PlanID            `json:"from"`
+	To   PlanID            `json:"to"`
+	Type PlanGraphEdgeType `json:"type"`
+}
+
+// NewPlanGraph lays out the plan as a graph. Public plans leave out which put
+// a get fetches the version of, so graphs of them have no version_from edges.
+func NewPlanGraph(plan Plan) PlanGraph {
+	graph := PlanGraph{
+		Nodes: []PlanGraphNode{},
+		Edges: []PlanGraphEdge{},
+	}
+
+	plan.Each(func(p Plan) {
+		stepType, name := planStep(p)
+
+		graph.Nodes = append(graph.Nodes, PlanGraphNode{
+			ID:   p.ID,
+			Type: stepType,
+			Name: name,
+		})
+
+		var nested []Plan
+		switch {
+		case p.Aggregate != nil:
+			nested = *p.Aggregate
+		case p.InParallel != nil:
+			nested = p.InParallel.Steps
+		case p.Do != nil:
+			nested = *p.Do
+			graph.sequence(nested, PlanGraphEdgeNext)
+		case p.Retry != nil:
+			nested = *p.Retry
+			graph.sequence(nested, PlanGraphEdgeRetry)
+		case p.OnAbort != nil:
+			nested = []Plan{p.OnAbort.Step, p.OnAbort.Next}
+			graph.sequence(nested, PlanGraphEdgeOnAbort)
+		case p.OnError != nil:
+			nested = []Plan{p.OnError.Step, p.OnError.Next}
+			graph.sequence(nested, PlanGraphEdgeOnError)
+		case p.Ensure != nil:
+			nested = []Plan{p.Ensure.Step, p.Ensure.Next}
+			graph.sequence(nested, PlanGraphEdgeEnsure)
+		case p.OnSuccess != nil:
+			nested = []Plan{p.OnSuccess.Step, p.OnSuccess.Next}
+			graph.sequence(nested, PlanGraphEdgeOnSuccess)
+		case p.OnFailure != nil:
+			nested = []Plan{p.OnFailure.Step, p.OnFailure.Next}
+			graph.sequence(nested, PlanGraphEdgeOnFailure)
+		case p.Try != nil:
+			nested = []Plan{p.Try.Step}
+		case p.Timeout != nil:
+			nested = []Plan{p.Timeout.Step}
+		case p.Get != nil && p.Get.VersionFrom != nil:
+			graph.Edges = append(graph.Edges, PlanGraphEdge{
+				From: *p.Get.VersionFrom,
+				To:   p.ID,
+				Type: PlanGraphEdgeVersionFrom,
+			})
+		}
+
+		for _, child := range nested {
+			graph.Edges = append(graph.Edges, PlanGraphEdge{
+				From: p.ID,
+				To:   child.ID,
+				Type: PlanGraphEdgeContains,
+			})
+		}
+	})
+
+	return graph
+}
+
+func (graph *PlanGraph) sequence(plans []Plan, edgeType PlanGraphEdgeType) {
+	for i := 1; i < len(plans); i++ {
+		graph.Edges = append(graph.Edges, PlanGraphEdge{
+			From: plans[i-1].ID,
+			To:   plans[i].ID,
+			Type: edgeType,
+		})
+	}
+}
+
+func planStep(plan Plan) (string, string) {
+	switch {
+	case plan.Aggregate != nil:
+		return "aggregate", ""
+	case plan.InParallel != nil:
+		return "in_parallel", ""
+	case plan.Do != nil:
+		return "do", ""
+	case plan.Get != nil:
+		return "get", plan.Get.Name
+	case plan.Put != nil:
+		return "put", plan.Put.Name
+	case plan.Check != nil:
+		return "check", plan.Check.Name
+	case plan.Task != nil:
+		return "task", plan.Task.Name
+	case plan.OnAbort != nil:
+		return "on_abort", ""
+	case plan.OnError != nil:
+		return "on_error", ""
+	case plan.Ensure != nil:
+		return "ensure", ""
+	case plan.OnSuccess != nil:
+		return "on_success", ""
+	case plan.OnFailure != nil:
+		return "on_failure", ""
+	case plan.Try != nil:
+		return "try", ""
+	case plan.Timeout != nil:
+		return "timeout", ""
+	case plan.Retry != nil:
+		return "retry", ""
+	case plan.ArtifactInput != nil:
+		return "artifact_input", plan.ArtifactInput.Name
+	case plan.ArtifactOutput != nil:
+		return "artifact_output", plan.ArtifactOutput.Name
+	case plan.DependentGet != nil:
+		return "dependent_get", plan.DependentGet.Name
+	default:
+		return "", ""
+	}
+}
+
+// DOT renders the graph in Graphviz's DOT language. Steps which only arrange
+// others are drawn as boxes, and contains edges are dotted.
+func (graph PlanGraph) DOT() string {
+	var dot strings.Builder
+
+	dot.WriteString("digraph plan {\n")
+
+	for _, node := range graph.Nodes {
+		label := node.Type
+		if node.Name != "" {
+			label += ": " + node.Name
+		}
+
+		shape := "ellipse"
+		switch node.Type {
+		case "get", "put", "check", "task", "artifact_input", "artifact_output", "dependent_get":
+		default:
+			shape = "box"
+		}
+
+		fmt.Fprintf(&dot, "  %s [label=%s, shape=%s];\n", strconv.Quote(string(node.ID)), strconv.Quote(label), shape)
+	}
+
+	for _, edge := range graph.Edges {
+		style := "solid"
+		if edge.Type == PlanGraphEdgeContains {
+			style = "dotted"
+		}
+
+		fmt.Fprintf(&dot, "  %s -> %s [label=%s, style=%s];\n", strconv.Quote(string(edge.From)), strconv.Quote(string(edge.To)), strconv.Quote(string(edge.Type)), style)
+	}
+
+	dot.WriteString("}\n")
+
+	return dot.String()
+}
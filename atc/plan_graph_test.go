@@ -0,0 +1,75 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PlanGraph", func() {
+	var plan atc.Plan
+
+	BeforeEach(func() {
+		putID := atc.PlanID("5")
+
+		plan = atc.Plan{
+			ID: "1",
+			Do: &atc.DoPlan{
+				{ID: "2", Get: &atc.GetPlan{Name: "repo", Resource: "repo"}},
+				{ID: "3", Retry: &atc.RetryPlan{
+					{ID: "3a", Task: &atc.TaskPlan{Name: "unit"}},
+					{ID: "3b", Task: &atc.TaskPlan{Name: "unit"}},
+				}},
+				{ID: "4", OnSuccess: &atc.OnSuccessPlan{
+					Step: atc.Plan{ID: putID, Put: &atc.PutPlan{Name: "release", Resource: "release"}},
+					Next: atc.Plan{ID: "6", Get: &atc.GetPlan{Name: "release", Resource: "release", VersionFrom: &putID}},
+				}},
+			},
+		}
+	})
+
+	Describe("NewPlanGraph", func() {
+		It("has a node for each step", func() {
+			Expect(atc.NewPlanGraph(plan).Nodes).To(Equal([]atc.PlanGraphNode{
+				{ID: "1", Type: "do"},
+				{ID: "2", Type: "get", Name: "repo"},
+				{ID: "3", Type: "retry"},
+				{ID: "3a", Type: "task", Name: "unit"},
+				{ID: "3b", Type: "task", Name: "unit"},
+				{ID: "4", Type: "on_success"},
+				{ID: "5", Type: "put", Name: "release"},
+				{ID: "6", Type: "get", Name: "release"},
+			}))
+		})
+
+		It("has edges for nesting, ordering, hooks, and dependent gets", func() {
+			Expect(atc.NewPlanGraph(plan).Edges).To(ConsistOf(
+				atc.PlanGraphEdge{From: "1", To: "2", Type: atc.PlanGraphEdgeContains},
+				atc.PlanGraphEdge{From: "1", To: "3", Type: atc.PlanGraphEdgeContains},
+				atc.PlanGraphEdge{From: "1", To: "4", Type: atc.PlanGraphEdgeContains},
+				atc.PlanGraphEdge{From: "2", To: "3", Type: atc.PlanGraphEdgeNext},
+				atc.PlanGraphEdge{From: "3", To: "4", Type: atc.PlanGraphEdgeNext},
+				atc.PlanGraphEdge{From: "3", To: "3a", Type: atc.PlanGraphEdgeContains},
+				atc.PlanGraphEdge{From: "3", To: "3b", Type: atc.PlanGraphEdgeContains},
+				atc.PlanGraphEdge{From: "3a", To: "3b", Type: atc.PlanGraphEdgeRetry},
+				atc.PlanGraphEdge{From: "4", To: "5", Type: atc.PlanGraphEdgeContains},
+				atc.PlanGraphEdge{From: "4", To: "6", Type: atc.PlanGraphEdgeContains},
+				atc.PlanGraphEdge{From: "5", To: "6", Type: atc.PlanGraphEdgeOnSuccess},
+				atc.PlanGraphEdge{From: "5", To: "6", Type: atc.PlanGraphEdgeVersionFrom},
+			))
+		})
+	})
+
+	Describe("DOT", func() {
+		It("renders the nodes and edges", func() {
+			dot := atc.NewPlanGraph(plan).DOT()
+
+			Expect(dot).To(HavePrefix("digraph plan {\n"))
+			Expect(dot).To(ContainSubstring(`  "1" [label="do", shape=box];`))
+			Expect(dot).To(ContainSubstring(`  "5" [label="put: release", shape=ellipse];`))
+			Expect(dot).To(ContainSubstring(`  "1" -> "2" [label="contains", style=dotted];`))
+			Expect(dot).To(ContainSubstring(`  "5" -> "6" [label="on_success", style=solid];`))
+			Expect(dot).To(HaveSuffix("}\n"))
+		})
+	})
+})
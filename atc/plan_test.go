@@ -0,0 +1,65 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan", func() {
+	Describe("Each", func() {
+		It("visits the plan and each nested plan in order", func() {
+			plan := atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					{
+						ID: "2",
+						InParallel: &atc.InParallelPlan{
+							Steps: []atc.Plan{
+								{ID: "3", Get: &atc.GetPlan{Name: "a"}},
+								{ID: "4", Retry: &atc.RetryPlan{{ID: "5", Task: &atc.TaskPlan{Name: "b"}}}},
+							},
+						},
+					},
+					{
+						ID: "6",
+						OnFailure: &atc.OnFailurePlan{
+							Step: atc.Plan{ID: "7", Timeout: &atc.TimeoutPlan{Step: atc.Plan{ID: "8", Put: &atc.PutPlan{Name: "c"}}}},
+							Next: atc.Plan{ID: "9", Try: &atc.TryPlan{Step: atc.Plan{ID: "10", Task: &atc.TaskPlan{Name: "d"}}}},
+						},
+					},
+				},
+			}
+
+			var visited []atc.PlanID
+			plan.Each(func(p atc.Plan) {
+				visited = append(visited, p.ID)
+			})
+
+			Expect(visited).To(Equal([]atc.PlanID{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}))
+		})
+	})
+})
+
+var _ = Describe("VersionedResourceTypes", func() {
+	Describe("Privileged", func() {
+		types := atc.VersionedResourceTypes{
+			{ResourceType: atc.ResourceType{Name: "docker", Type: "registry-image", Privileged: true}},
+			{ResourceType: atc.ResourceType{Name: "custom", Type: "docker"}},
+			{ResourceType: atc.ResourceType{Name: "plain", Type: "registry-image"}},
+			{ResourceType: atc.ResourceType{Name: "loop-a", Type: "loop-b"}},
+			{ResourceType: atc.ResourceType{Name: "loop-b", Type: "loop-a"}},
+		}
+
+		It("is true for privileged types and types whose image is fetched by one", func() {
+			Expect(types.Privileged("docker")).To(BeTrue())
+			Expect(types.Privileged("custom")).To(BeTrue())
+		})
+
+		It("is false for other types", func() {
+			Expect(types.Privileged("plain")).To(BeFalse())
+			Expect(types.Privileged("git")).To(BeFalse())
+			Expect(types.Privileged("loop-a")).To(BeFalse())
+		})
+	})
+})
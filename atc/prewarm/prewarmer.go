@@ -0,0 +1,228 @@
+package prewarm
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// Prewarmer creates volumes for the most used resource caches on workers
+// which have just registered, so that a newly started worker doesn't have to
+// fetch every resource from scratch. Usage is counted separately for each
+// team and set of tags, so a worker is only prewarmed with the caches used on
+// workers like it.
+//
+// A cache is hydrated from the resource cache store if one is configured, and
+// is otherwise streamed from another worker which has it, chosen by the
+// stream source strategy. Caches which no running worker has are left to be
+// fetched as usual. Bytes streamed between workers whose zone labels differ
+// are reported as a metric.
+type Prewarmer struct {
+	workerProvider       worker.WorkerProvider
+	resourceCacheFactory db.ResourceCacheFactory
+	streamSourceStrategy worker.StreamSourceStrategy
+	zoneLabel            string
+	limit                int
+	interval             time.Duration
+}
+
+// NewPrewarmer returns a Prewarmer which prewarms up to limit resource caches
+// on each newly registered worker. It is to be run on the given interval. A
+// worker's zone is the value of its zoneLabel label.
+func NewPrewarmer(
+	workerProvider worker.WorkerProvider,
+	resourceCacheFactory db.ResourceCacheFactory,
+	streamSourceStrategy worker.StreamSourceStrategy,
+	zoneLabel string,
+	limit int,
+	interval time.Duration,
+) *Prewarmer {
+	return &Prewarmer{
+		workerProvider:       workerProvider,
+		resourceCacheFactory: resourceCacheFactory,
+		streamSourceStrategy: streamSourceStrategy,
+		zoneLabel:            zoneLabel,
+		limit:                limit,
+		interval:             interval,
+	}
+}
+
+func (p *Prewarmer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("prewarm")
+
+	workers, err := p.workerProvider.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+		return err
+	}
+
+	for _, w := range p.registered(workers) {
+		p.prewarm(ctx, logger.Session("worker", lager.Data{"worker": w.Name()}), w, workers)
+	}
+
+	return nil
+}
+
+// registered returns the workers which have registered within the last
+// interval, going by the start times they registered with. As those are kept
+// in the database, workers aren't taken as new again when the ATC restarts or
+// another ATC takes over prewarming. A worker which is prewarmed twice finds
+// its volumes the second time.
+func (p *Prewarmer) registered(workers []worker.Worker) []worker.Worker {
+	var registered []worker.Worker
+	for _, w := range workers {
+		if w.Uptime() < p.interval {
+			registered = append(registered, w)
+		}
+	}
+
+	return registered
+}
+
+func (p *Prewarmer) prewarm(ctx context.Context, logger lager.Logger, target worker.Worker, workers []worker.Worker) {
+	resourceCaches, err := p.resourceCacheFactory.FindMostUsedResourceCaches(target.TeamID(), target.Tags(), p.limit)
+	if err != nil {
+		logger.Error("failed-to-find-most-used-resource-caches", err)
+		return
+	}
+
+	prewarmed := 0
+	for _, resourceCache := range resourceCaches {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if !supportsResourceCache(target, resourceCache) {
+			continue
+		}
+
+		cacheLogger := logger.WithData(lager.Data{"resource-cache": resourceCache.ID()})
+
+		ok, err := p.prewarmResourceCache(ctx, cacheLogger, target, workers, resourceCache)
+		if err != nil {
+			cacheLogger.Error("failed-to-prewarm-resource-cache", err)
+			continue
+		}
+
+		if ok {
+			prewarmed++
+		}
+	}
+
+	logger.Info("prewarmed", lager.Data{"resource-caches": prewarmed})
+}
+
+func (p *Prewarmer) prewarmResourceCache(
+	ctx context.Context,
+	logger lager.Logger,
+	target worker.Worker,
+	workers []worker.Worker,
+	resourceCache db.UsedResourceCache,
+) (bool, error) {
+	_, found, err := target.FindVolumeForResourceCache(logger, resourceCache)
+	if err != nil {
+		return false, err
+	}
+
+	if found {
+		return true, nil
+	}
+
+	_, found, err = target.HydrateVolumeForResourceCache(ctx, logger, resourceCache)
+	if err != nil {
+		return false, err
+	}
+
+	if found {
+		return true, nil
+	}
+
+	var sources []worker.Worker
+	for _, source := range workers {
+		if source.Name() != target.Name() {
+			sources = append(sources, source)
+		}
+	}
+
+	for _, source := range p.streamSourceStrategy.Order(target, sources) {
+		volume, found, err := source.FindVolumeForResourceCache(logger, resourceCache)
+		if err != nil {
+			logger.Error("failed-to-find-volume-on-worker", err, lager.Data{"source-worker": source.Name()})
+			continue
+		}
+
+		if !found {
+			continue
+		}
+
+		tarStream, err := volume.StreamOut(lagerctx.NewContext(ctx, logger), ".")
+		if err != nil {
+			logger.Error("failed-to-stream-out-volume", err, lager.Data{"source-worker": source.Name()})
+			continue
+		}
+
+		counted := &countingReader{Reader: tarStream}
+
+		_, created, err := target.CreateVolumeForResourceCache(ctx, logger, resourceCache, counted)
+		_ = tarStream.Close()
+
+		p.emitCrossZoneStreamed(logger, source, target, counted.bytes)
+
+		if err != nil {
+			return false, err
+		}
+
+		return created, nil
+	}
+
+	return false, nil
+}
+
+func (p *Prewarmer) emitCrossZoneStreamed(logger lager.Logger, source worker.Worker, target worker.Worker, bytes int64) {
+	sourceZone := worker.WorkerZone(source, p.zoneLabel)
+	targetZone := worker.WorkerZone(target, p.zoneLabel)
+
+	if sourceZone == "" || targetZone == "" || sourceZone == targetZone {
+		return
+	}
+
+	metric.CrossZoneVolumeStreamed{
+		SourceWorker: source.Name(),
+		SourceZone:   sourceZone,
+		TargetWorker: target.Name(),
+		TargetZone:   targetZone,
+		Bytes:        bytes,
+	}.Emit(logger)
+}
+
+type countingReader struct {
+	io.Reader
+	bytes int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func supportsResourceCache(w worker.Worker, resourceCache db.UsedResourceCache) bool {
+	baseResourceType := resourceCache.BaseResourceType()
+	if baseResourceType == nil {
+		return false
+	}
+
+	for _, resourceType := range w.ResourceTypes() {
+		if resourceType.Type == baseResourceType.Name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,302 @@
+package prewarm_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+	"github.com/concourse/concourse/atc/prewarm"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prewarmer", func() {
+	var (
+		fakeWorkerProvider       *workerfakes.FakeWorkerProvider
+		fakeResourceCacheFactory *dbfakes.FakeResourceCacheFactory
+
+		newWorker      *workerfakes.FakeWorker
+		existingWorker *workerfakes.FakeWorker
+
+		fakeResourceCache *dbfakes.FakeUsedResourceCache
+
+		streamSourceStrategy worker.StreamSourceStrategy
+
+		prewarmer *prewarm.Prewarmer
+
+		ctx    context.Context
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeWorkerProvider = new(workerfakes.FakeWorkerProvider)
+		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+
+		newWorker = new(workerfakes.FakeWorker)
+		newWorker.NameReturns("new-worker")
+		newWorker.TagsReturns(atc.Tags{"some-tag"})
+		newWorker.TeamIDReturns(42)
+		newWorker.UptimeReturns(time.Minute)
+		newWorker.ResourceTypesReturns([]atc.WorkerResourceType{{Type: "some-base-type"}})
+
+		existingWorker = new(workerfakes.FakeWorker)
+		existingWorker.NameReturns("existing-worker")
+		existingWorker.UptimeReturns(time.Hour)
+		existingWorker.ResourceTypesReturns([]atc.WorkerResourceType{{Type: "some-base-type"}})
+
+		fakeWorkerProvider.RunningWorkersReturns([]worker.Worker{existingWorker, newWorker}, nil)
+
+		fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+		fakeResourceCache.IDReturns(1)
+		fakeResourceCache.BaseResourceTypeReturns(&db.UsedBaseResourceType{Name: "some-base-type"})
+
+		fakeResourceCacheFactory.FindMostUsedResourceCachesStub = func(teamID int, tags []string, limit int) ([]db.UsedResourceCache, error) {
+			if teamID == 42 {
+				return []db.UsedResourceCache{fakeResourceCache}, nil
+			}
+
+			return nil, nil
+		}
+
+		streamSourceStrategy = worker.NewArbitraryStreamSourceStrategy()
+
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+	})
+
+	JustBeforeEach(func() {
+		prewarmer = prewarm.NewPrewarmer(fakeWorkerProvider, fakeResourceCacheFactory, streamSourceStrategy, "zone", 5, 2*time.Minute)
+
+		runErr = prewarmer.Run(ctx)
+	})
+
+	It("looks up the most used resource caches for the new worker's team and tags", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakeResourceCacheFactory.FindMostUsedResourceCachesCallCount()).To(Equal(1))
+
+		teamID, tags, limit := fakeResourceCacheFactory.FindMostUsedResourceCachesArgsForCall(0)
+		Expect(teamID).To(Equal(42))
+		Expect(tags).To(Equal([]string{"some-tag"}))
+		Expect(limit).To(Equal(5))
+	})
+
+	Context("when the worker finds the resource cache's volume", func() {
+		BeforeEach(func() {
+			newWorker.FindVolumeForResourceCacheReturns(new(workerfakes.FakeVolume), true, nil)
+		})
+
+		It("does not look for it on other workers", func() {
+			Expect(newWorker.FindVolumeForResourceCacheCallCount()).To(Equal(1))
+			_, resourceCache := newWorker.FindVolumeForResourceCacheArgsForCall(0)
+			Expect(resourceCache).To(Equal(fakeResourceCache))
+
+			Expect(existingWorker.FindVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+
+		It("does not hydrate it from the resource cache store", func() {
+			Expect(newWorker.HydrateVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the worker hydrates the resource cache's volume", func() {
+		BeforeEach(func() {
+			newWorker.HydrateVolumeForResourceCacheReturns(new(workerfakes.FakeVolume), true, nil)
+		})
+
+		It("hydrates it after failing to find it", func() {
+			Expect(newWorker.FindVolumeForResourceCacheCallCount()).To(Equal(1))
+			Expect(newWorker.HydrateVolumeForResourceCacheCallCount()).To(Equal(1))
+			_, _, resourceCache := newWorker.HydrateVolumeForResourceCacheArgsForCall(0)
+			Expect(resourceCache).To(Equal(fakeResourceCache))
+		})
+
+		It("does not look for it on other workers", func() {
+			Expect(existingWorker.FindVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when another worker has the resource cache's volume", func() {
+		var fakeVolume *workerfakes.FakeVolume
+
+		BeforeEach(func() {
+			fakeVolume = new(workerfakes.FakeVolume)
+			fakeVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-tar")), nil)
+			existingWorker.FindVolumeForResourceCacheReturns(fakeVolume, true, nil)
+		})
+
+		It("does not hydrate it on the other worker", func() {
+			Expect(existingWorker.HydrateVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+
+		It("streams it to the new worker", func() {
+			Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+			_, path := fakeVolume.StreamOutArgsForCall(0)
+			Expect(path).To(Equal("."))
+
+			Expect(newWorker.CreateVolumeForResourceCacheCallCount()).To(Equal(1))
+			_, _, resourceCache, tarStream := newWorker.CreateVolumeForResourceCacheArgsForCall(0)
+			Expect(resourceCache).To(Equal(fakeResourceCache))
+
+			contents, err := ioutil.ReadAll(tarStream)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-tar"))
+		})
+
+		Context("when a worker in the new worker's zone also has it", func() {
+			var nearVolume *workerfakes.FakeVolume
+
+			BeforeEach(func() {
+				newWorker.LabelsReturns(atc.Labels{"zone": "us-east-1a"})
+				existingWorker.LabelsReturns(atc.Labels{"zone": "us-east-1b"})
+
+				nearWorker := new(workerfakes.FakeWorker)
+				nearWorker.NameReturns("near-worker")
+				nearWorker.UptimeReturns(time.Hour)
+				nearWorker.LabelsReturns(atc.Labels{"zone": "us-east-1a"})
+
+				nearVolume = new(workerfakes.FakeVolume)
+				nearVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-tar")), nil)
+				nearWorker.FindVolumeForResourceCacheReturns(nearVolume, true, nil)
+
+				fakeWorkerProvider.RunningWorkersReturns([]worker.Worker{existingWorker, nearWorker, newWorker}, nil)
+			})
+
+			Context("with the arbitrary strategy", func() {
+				It("streams it from the first worker found", func() {
+					Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+					Expect(nearVolume.StreamOutCallCount()).To(BeZero())
+				})
+			})
+
+			Context("with the nearest strategy", func() {
+				BeforeEach(func() {
+					streamSourceStrategy = worker.NewNearestStreamSourceStrategy("zone")
+				})
+
+				It("streams it from the worker in the same zone", func() {
+					Expect(nearVolume.StreamOutCallCount()).To(Equal(1))
+					Expect(fakeVolume.StreamOutCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the workers are in different zones", func() {
+			var emitter *metricfakes.FakeEmitter
+
+			BeforeEach(func() {
+				newWorker.LabelsReturns(atc.Labels{"zone": "us-east-1a"})
+				existingWorker.LabelsReturns(atc.Labels{"zone": "us-east-1b"})
+
+				newWorker.CreateVolumeForResourceCacheStub = func(_ context.Context, _ lager.Logger, _ db.UsedResourceCache, tarStream io.Reader) (worker.Volume, bool, error) {
+					_, err := ioutil.ReadAll(tarStream)
+					return new(workerfakes.FakeVolume), true, err
+				}
+
+				emitterFactory := new(metricfakes.FakeEmitterFactory)
+				emitter = new(metricfakes.FakeEmitter)
+
+				metric.RegisterEmitter(emitterFactory)
+				emitterFactory.IsConfiguredReturns(true)
+				emitterFactory.NewEmitterReturns(emitter, nil)
+
+				metric.Initialize(lager.NewLogger("dont-care"), "test", map[string]string{}, 1000, nil)
+			})
+
+			It("emits the bytes streamed across zones", func() {
+				Eventually(emitter.EmitCallCount).Should(Equal(1))
+
+				_, event := emitter.EmitArgsForCall(0)
+				Expect(event.Name).To(Equal("cross-zone bytes streamed"))
+				Expect(event.Value).To(Equal(int64(len("some-tar"))))
+				Expect(event.Attributes).To(Equal(map[string]string{
+					"source_worker": "existing-worker",
+					"source_zone":   "us-east-1b",
+					"target_worker": "new-worker",
+					"target_zone":   "us-east-1a",
+				}))
+			})
+		})
+	})
+
+	Context("when the worker does not support the resource cache's type", func() {
+		BeforeEach(func() {
+			fakeResourceCache.BaseResourceTypeReturns(&db.UsedBaseResourceType{Name: "other-base-type"})
+		})
+
+		It("skips it", func() {
+			Expect(newWorker.FindVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when looking up the most used resource caches fails", func() {
+		BeforeEach(func() {
+			fakeResourceCacheFactory.FindMostUsedResourceCachesReturns(nil, errors.New("nope"))
+			fakeResourceCacheFactory.FindMostUsedResourceCachesStub = nil
+		})
+
+		It("does not fail the run", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when getting the running workers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerProvider.RunningWorkersReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when run again", func() {
+		JustBeforeEach(func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeResourceCacheFactory.FindMostUsedResourceCachesCallCount()).To(Equal(1))
+		})
+
+		It("does not prewarm workers which registered before the last interval", func() {
+			newWorker.UptimeReturns(2 * time.Minute)
+
+			Expect(prewarmer.Run(ctx)).To(Succeed())
+			Expect(fakeResourceCacheFactory.FindMostUsedResourceCachesCallCount()).To(Equal(1))
+		})
+
+		It("prewarms workers which have registered again since", func() {
+			newWorker.UptimeReturns(time.Second)
+
+			Expect(prewarmer.Run(ctx)).To(Succeed())
+			Expect(fakeResourceCacheFactory.FindMostUsedResourceCachesCallCount()).To(Equal(2))
+
+			teamID, _, _ := fakeResourceCacheFactory.FindMostUsedResourceCachesArgsForCall(1)
+			Expect(teamID).To(Equal(42))
+		})
+	})
+
+	Context("when run by another prewarmer, e.g. after a restart", func() {
+		JustBeforeEach(func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			prewarmer = prewarm.NewPrewarmer(fakeWorkerProvider, fakeResourceCacheFactory, streamSourceStrategy, "zone", 5, 2*time.Minute)
+			newWorker.UptimeReturns(3 * time.Minute)
+		})
+
+		It("does not prewarm workers which registered before the last interval again", func() {
+			Expect(prewarmer.Run(ctx)).To(Succeed())
+			Expect(fakeResourceCacheFactory.FindMostUsedResourceCachesCallCount()).To(Equal(1))
+		})
+	})
+})
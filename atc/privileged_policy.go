@@ -0,0 +1,95 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PrivilegedPolicy restricts which of a team's pipelines and jobs may run
+// privileged containers, i.e. privileged tasks and the containers of
+// privileged resource types. Without a policy, all of them may.
+//
+// Each entry in Allow is either a pipeline name or a pipeline/job pair, and
+// may be a glob, e.g. "deploy-*" or "infra/build-*".
+type PrivilegedPolicy struct {
+	Allow []string `json:"allow,omitempty"`
+
+	// OneOffBuilds allows builds run with fly execute to be privileged.
+	OneOffBuilds bool `json:"one_off_builds,omitempty"`
+}
+
+// Allows returns whether the given pipeline and job may run privileged
+// containers. Containers for checking resources belong to no job, so only
+// match entries for their whole pipeline or with a wildcard job. A nil policy
+// allows everything.
+func (policy *PrivilegedPolicy) Allows(pipelineName string, jobName string) bool {
+	if policy == nil {
+		return true
+	}
+
+	if pipelineName == "" {
+		return policy.OneOffBuilds
+	}
+
+	for _, pattern := range policy.Allow {
+		subject := pipelineName
+		if strings.Contains(pattern, "/") {
+			subject = pipelineName + "/" + jobName
+		}
+
+		if matched, _ := path.Match(pattern, subject); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (policy PrivilegedPolicy) Validate() error {
+	errorMessages := []string{}
+
+	for i, pattern := range policy.Allow {
+		if pattern == "" || strings.Count(pattern, "/") > 1 {
+			errorMessages = append(errorMessages, fmt.Sprintf("allow[%d] must be a pipeline or pipeline/job: '%s'", i, pattern))
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("allow[%d] is not a valid glob: '%s'", i, pattern))
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// PrivilegedNotAllowedError is returned when a step would run a privileged
+// container for a pipeline or job which its team's privileged policy doesn't
+// allow to.
+type PrivilegedNotAllowedError struct {
+	Team     string
+	Pipeline string
+	Job      string
+	Step     string
+}
+
+func (err PrivilegedNotAllowedError) Error() string {
+	owner := "one-off builds"
+	if err.Pipeline != "" {
+		owner = fmt.Sprintf("pipeline '%s'", err.Pipeline)
+		if err.Job != "" {
+			owner = fmt.Sprintf("job '%s/%s'", err.Pipeline, err.Job)
+		}
+	}
+
+	return fmt.Sprintf("step '%s' is privileged, but team '%s' does not allow %s to run privileged containers", err.Step, err.Team, owner)
+}
+
+func (PrivilegedNotAllowedError) ErrorCode() ErrorCode {
+	return ErrorCodePrivilegedNotAllowed
+}
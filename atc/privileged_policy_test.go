@@ -0,0 +1,62 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrivilegedPolicy", func() {
+	Describe("Allows", func() {
+		It("allows everything without a policy", func() {
+			var policy *atc.PrivilegedPolicy
+			Expect(policy.Allows("some-pipeline", "some-job")).To(BeTrue())
+			Expect(policy.Allows("", "")).To(BeTrue())
+		})
+
+		Context("with a policy", func() {
+			policy := &atc.PrivilegedPolicy{
+				Allow: []string{"infra", "deploy-*/build-*"},
+			}
+
+			It("allows every job of an allowed pipeline", func() {
+				Expect(policy.Allows("infra", "some-job")).To(BeTrue())
+				Expect(policy.Allows("infra", "")).To(BeTrue())
+			})
+
+			It("allows jobs matching a pipeline/job entry", func() {
+				Expect(policy.Allows("deploy-web", "build-image")).To(BeTrue())
+				Expect(policy.Allows("deploy-web", "deploy")).To(BeFalse())
+				Expect(policy.Allows("deploy-web", "")).To(BeFalse())
+			})
+
+			It("does not allow other pipelines", func() {
+				Expect(policy.Allows("infra-2", "some-job")).To(BeFalse())
+			})
+
+			It("does not allow one-off builds unless they're enabled", func() {
+				Expect(policy.Allows("", "")).To(BeFalse())
+				Expect((&atc.PrivilegedPolicy{OneOffBuilds: true}).Allows("", "")).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Validate", func() {
+		It("accepts pipelines and pipeline/job pairs", func() {
+			Expect(atc.PrivilegedPolicy{Allow: []string{"infra", "deploy-*/build-?"}}.Validate()).To(Succeed())
+		})
+
+		It("rejects empty entries, extra slashes and bad globs", func() {
+			err := atc.PrivilegedPolicy{Allow: []string{"", "a/b/c", "infra/["}}.Validate()
+			Expect(err).To(MatchError("allow[0] must be a pipeline or pipeline/job: ''\nallow[1] must be a pipeline or pipeline/job: 'a/b/c'\nallow[2] is not a valid glob: 'infra/['"))
+		})
+	})
+
+	Describe("PrivilegedNotAllowedError", func() {
+		It("has an error code", func() {
+			err := atc.PrivilegedNotAllowedError{Team: "some-team", Pipeline: "some-pipeline", Job: "some-job", Step: "build"}
+			Expect(atc.ErrorCodeOf(err)).To(Equal(atc.ErrorCodePrivilegedNotAllowed))
+			Expect(err.Error()).To(Equal("step 'build' is privileged, but team 'some-team' does not allow job 'some-pipeline/some-job' to run privileged containers"))
+		})
+	})
+})
@@ -219,13 +219,15 @@ func (plan OnSuccessPlan) Public() *json.RawMessage {
 
 func (plan PutPlan) Public() *json.RawMessage {
 	return enc(struct {
-		Type     string `json:"type"`
-		Name     string `json:"name,omitempty"`
-		Resource string `json:"resource"`
+		Type     string        `json:"type"`
+		Name     string        `json:"name,omitempty"`
+		Resource string        `json:"resource"`
+		Inputs   *InputsConfig `json:"inputs,omitempty"`
 	}{
 		Type:     plan.Type,
 		Name:     plan.Name,
 		Resource: plan.Resource,
+		Inputs:   plan.Inputs,
 	})
 }
 
@@ -240,12 +242,37 @@ func (plan CheckPlan) Public() *json.RawMessage {
 }
 
 func (plan TaskPlan) Public() *json.RawMessage {
+	// only the inputs and outputs of the config are public, so that the
+	// artifacts of finished builds can still be worked out
+	type publicTaskConfig struct {
+		Inputs  []TaskInputConfig  `json:"inputs,omitempty"`
+		Outputs []TaskOutputConfig `json:"outputs,omitempty"`
+	}
+
+	var config *publicTaskConfig
+	if plan.Config != nil && (len(plan.Config.Inputs) != 0 || len(plan.Config.Outputs) != 0) {
+		config = &publicTaskConfig{
+			Inputs:  plan.Config.Inputs,
+			Outputs: plan.Config.Outputs,
+		}
+	}
+
 	return enc(struct {
-		Name       string `json:"name"`
-		Privileged bool   `json:"privileged"`
+		Name              string            `json:"name"`
+		Privileged        bool              `json:"privileged"`
+		ConfigPath        string            `json:"config_path,omitempty"`
+		Config            *publicTaskConfig `json:"config,omitempty"`
+		InputMapping      map[string]string `json:"input_mapping,omitempty"`
+		OutputMapping     map[string]string `json:"output_mapping,omitempty"`
+		ImageArtifactName string            `json:"image,omitempty"`
 	}{
-		Name:       plan.Name,
-		Privileged: plan.Privileged,
+		Name:              plan.Name,
+		Privileged:        plan.Privileged,
+		ConfigPath:        plan.ConfigPath,
+		Config:            config,
+		InputMapping:      plan.InputMapping,
+		OutputMapping:     plan.OutputMapping,
+		ImageArtifactName: plan.ImageArtifactName,
 	})
 }
 
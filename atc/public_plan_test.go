@@ -365,7 +365,8 @@ var _ = Describe("Plan", func() {
           "id": "2",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       ]
@@ -400,7 +401,8 @@ var _ = Describe("Plan", func() {
       "id": "5",
       "task": {
         "name": "name",
-        "privileged": true
+        "privileged": true,
+        "config_path": "some/config/path.yml"
       }
     },
     {
@@ -410,14 +412,16 @@ var _ = Describe("Plan", func() {
           "id": "7",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         "ensure": {
           "id": "8",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       }
@@ -429,14 +433,16 @@ var _ = Describe("Plan", func() {
           "id": "10",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         "on_success": {
           "id": "11",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       }
@@ -448,14 +454,16 @@ var _ = Describe("Plan", func() {
           "id": "13",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         "on_failure": {
           "id": "14",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       }
@@ -467,14 +475,16 @@ var _ = Describe("Plan", func() {
           "id": "16",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         "on_abort": {
           "id": "17",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       }
@@ -486,7 +496,8 @@ var _ = Describe("Plan", func() {
           "id": "19",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       }
@@ -498,7 +509,8 @@ var _ = Describe("Plan", func() {
           "id": "21",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         "duration": "lol"
@@ -511,7 +523,8 @@ var _ = Describe("Plan", func() {
           "id": "23",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       ]
@@ -523,21 +536,24 @@ var _ = Describe("Plan", func() {
           "id": "25",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         {
           "id": "26",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         {
           "id": "27",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       ]
@@ -549,14 +565,16 @@ var _ = Describe("Plan", func() {
 						"id": "29",
 						"task": {
 							"name": "name",
-							"privileged": false
+							"privileged": false,
+							"config_path": "some/config/path.yml"
 						}
 				},
         "on_abort": {
           "id": "30",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
 				}
       }
@@ -581,14 +599,16 @@ var _ = Describe("Plan", func() {
           "id": "34",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         },
         "on_error": {
           "id": "35",
           "task": {
             "name": "name",
-            "privileged": false
+            "privileged": false,
+            "config_path": "some/config/path.yml"
           }
         }
       }
@@ -601,7 +621,8 @@ var _ = Describe("Plan", func() {
 						"id": "37",
 						"task": {
 							"name": "name",
-							"privileged": false
+							"privileged": false,
+							"config_path": "some/config/path.yml"
 						}
 					}
 				],
@@ -613,5 +634,68 @@ var _ = Describe("Plan", func() {
 }
 `))
 		})
+
+		It("keeps the names of the artifacts which steps use and register", func() {
+			plan := atc.Plan{
+				ID: "0",
+				Do: &atc.DoPlan{
+					{
+						ID: "1",
+						Task: &atc.TaskPlan{
+							Name: "name",
+							Config: &atc.TaskConfig{
+								Params:  atc.TaskEnv{"some": "secret"},
+								Inputs:  []atc.TaskInputConfig{{Name: "repo", Optional: true}},
+								Outputs: []atc.TaskOutputConfig{{Name: "out", Path: "some/path"}},
+							},
+							InputMapping:      map[string]string{"repo": "source"},
+							OutputMapping:     map[string]string{"out": "binary"},
+							ImageArtifactName: "image",
+						},
+					},
+					{
+						ID: "2",
+						Put: &atc.PutPlan{
+							Type:     "type",
+							Name:     "name",
+							Resource: "resource",
+							Params:   atc.Params{"some": "params"},
+							Inputs:   &atc.InputsConfig{Specified: []string{"binary"}},
+						},
+					},
+				},
+			}
+
+			json := plan.Public()
+			Expect(json).ToNot(BeNil())
+			Expect([]byte(*json)).To(MatchJSON(`{
+  "id": "0",
+  "do": [
+    {
+      "id": "1",
+      "task": {
+        "name": "name",
+        "privileged": false,
+        "config": {
+          "inputs": [{"name": "repo", "optional": true}],
+          "outputs": [{"name": "out", "path": "some/path"}]
+        },
+        "input_mapping": {"repo": "source"},
+        "output_mapping": {"out": "binary"},
+        "image": "image"
+      }
+    },
+    {
+      "id": "2",
+      "put": {
+        "type": "type",
+        "name": "name",
+        "resource": "resource",
+        "inputs": ["binary"]
+      }
+    }
+  ]
+}`))
+		})
 	})
 })
@@ -0,0 +1,138 @@
+package radar
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// killedExitStatus is the exit status of a process killed with SIGKILL, which
+// is how a container's processes are killed when it runs out of memory.
+const killedExitStatus = 128 + 9
+
+// checkContainer is the configuration of a pipeline's check containers which
+// comes from its team and the installation. Check containers are owned by
+// the team as well as the resource config, so it's never given to another
+// team's checks.
+type checkContainer struct {
+	// caCerts is a PEM-encoded bundle of the installation's CA certificates
+	// followed by the team's, to trust in the container. The team's are
+	// only trusted by its own checks, as the container is the team's.
+	caCerts string
+
+	// env is the team's environment to inject into the container, unless the
+	// pipeline opts out of it.
+	env map[string]string
+}
+
+func teamCheckContainer(teamFactory db.TeamFactory, pipeline db.Pipeline, caCerts string) (checkContainer, error) {
+	config := checkContainer{
+		caCerts: caCerts,
+	}
+
+	team, found, err := teamFactory.FindTeam(pipeline.TeamName())
+	if err != nil {
+		return checkContainer{}, err
+	}
+
+	if found {
+		config.caCerts += team.CACerts()
+
+		if !pipeline.IgnoreTeamContainerEnv() {
+			config.env = team.ContainerEnv()
+		}
+	}
+
+	return config, nil
+}
+
+// killedByLimits guesses whether a check failed because its container ran out
+// of memory: its script having been killed is the only sign of that.
+func killedByLimits(limits atc.ContainerLimits, err error) bool {
+	if limits.Memory == nil {
+		return false
+	}
+
+	scriptErr, ok := err.(resource.ErrResourceScriptFailed)
+	if !ok {
+		return false
+	}
+
+	return scriptErr.ExitStatus == killedExitStatus
+}
+
+type checkResult struct {
+	versions []atc.Version
+	deleted  []atc.Version
+	err      error
+}
+
+// checkWithHardTimeout checks the resource in the container, interrupting the
+// check at the timeout. The check may not exit in response; if it's still
+// running killGracePeriod after the timeout its processes are killed and it is
+// abandoned, so that it doesn't hold on to its container forever.
+func checkWithHardTimeout(
+	logger lager.Logger,
+	clock clock.Clock,
+	container worker.Container,
+	res resource.Resource,
+	source atc.Source,
+	fromVersion atc.Version,
+	stderr io.Writer,
+	timeout time.Duration,
+	killGracePeriod time.Duration,
+) ([]atc.Version, []atc.Version, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	checked := make(chan checkResult, 1)
+	go func() {
+		versions, deleted, err := res.CheckWithDeletions(ctx, source, fromVersion, stderr)
+		checked <- checkResult{versions, deleted, err}
+	}()
+
+	var hardDeadline <-chan time.Time
+	if killGracePeriod > 0 {
+		timer := clock.NewTimer(timeout + killGracePeriod)
+		defer timer.Stop()
+
+		hardDeadline = timer.C()
+	}
+
+	select {
+	case result := <-checked:
+		return result.versions, result.deleted, result.err
+	case <-hardDeadline:
+		hardTimeout := timeout + killGracePeriod
+		logger.Info("killing-check-after-hard-timeout", lager.Data{"hard-timeout": hardTimeout.String()})
+
+		if stopErr := container.Stop(true); stopErr != nil {
+			logger.Error("failed-to-kill-check", stopErr)
+		}
+
+		return nil, nil, exec.CheckKilledError{Timeout: hardTimeout}
+	}
+}
+
+// recordCheck records a check run by radar, so that it's listed along with
+// the checks run by lidar. The output is nil if the check failed before it
+// could run.
+func recordCheck(logger lager.Logger, resourceConfigScope db.ResourceConfigScope, startTime time.Time, workerName string, output *exec.CheckOutput, checkErr error) {
+	var stderr string
+	if output != nil {
+		stderr = output.String()
+	}
+
+	err := resourceConfigScope.RecordCheck(startTime, workerName, stderr, checkErr)
+	if err != nil {
+		logger.Error("failed-to-record-check", err)
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/versionhook"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/vars"
 )
@@ -30,6 +34,12 @@ type resourceScanner struct {
 	externalURL           string
 	variables             vars.Variables
 	strategy              worker.ContainerPlacementStrategy
+	checkPolicy           checkpolicy.Enforcer
+	versionNotifier       versionhook.Notifier
+	teamFactory           db.TeamFactory
+	caCerts               string
+	checkLimits           map[string]atc.ContainerLimits
+	killGracePeriod       time.Duration
 }
 
 func NewResourceScanner(
@@ -42,6 +52,12 @@ func NewResourceScanner(
 	externalURL string,
 	variables vars.Variables,
 	strategy worker.ContainerPlacementStrategy,
+	checkPolicy checkpolicy.Enforcer,
+	versionNotifier versionhook.Notifier,
+	teamFactory db.TeamFactory,
+	caCerts string,
+	checkLimits map[string]atc.ContainerLimits,
+	killGracePeriod time.Duration,
 ) Scanner {
 	return &resourceScanner{
 		clock:                 clock,
@@ -53,6 +69,12 @@ func NewResourceScanner(
 		externalURL:           externalURL,
 		variables:             variables,
 		strategy:              strategy,
+		checkPolicy:           checkPolicy,
+		versionNotifier:       versionNotifier,
+		teamFactory:           teamFactory,
+		caCerts:               caCerts,
+		checkLimits:           checkLimits,
+		killGracePeriod:       killGracePeriod,
 	}
 }
 
@@ -104,7 +126,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		return 0, err
 	}
 
-	interval, err := scanner.checkInterval(savedResource.CheckEvery())
+	interval, err := scanner.checkPolicy.Interval(logger, scanner.dbPipeline.TeamName(), savedResource.CheckEvery(), scanner.defaultInterval)
 	if err != nil {
 		scanner.setResourceCheckError(logger, savedResource, err)
 		logger.Error("failed-to-read-check-interval", err)
@@ -165,7 +187,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		return 0, err
 	}
 
-	source, err := creds.NewSource(scanner.variables, savedResource.Source()).Evaluate()
+	source, credentials, err := creds.NewSource(scanner.variables, savedResource.Source()).EvaluateWithCredentials()
 	if err != nil {
 		logger.Error("failed-to-evaluate-resource-source", err)
 		scanner.setResourceCheckError(logger, savedResource, err)
@@ -182,6 +204,17 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		return 0, err
 	}
 
+	rotated, err := resourceConfigScope.UpdateCredentials(credentials)
+	if err != nil {
+		logger.Error("failed-to-update-resource-config-credentials", err)
+		scanner.setResourceCheckError(logger, savedResource, err)
+		return 0, err
+	}
+
+	if rotated {
+		logger.Info("credentials-rotated")
+	}
+
 	// Clear out check error on the resource
 	scanner.setResourceCheckError(logger, savedResource, nil)
 
@@ -205,6 +238,24 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		fromVersion = currentVersion
 	}
 
+	for {
+		release, acquired, err := scanner.checkPolicy.Acquire(logger, scanner.dbPipeline.TeamName())
+		if err != nil {
+			return interval, err
+		}
+
+		if acquired {
+			defer release()
+			break
+		}
+
+		if !mustComplete {
+			return interval, ErrFailedToAcquireLock
+		}
+
+		scanner.clock.Sleep(time.Second)
+	}
+
 	for {
 		lock, acquired, err := resourceConfigScope.AcquireResourceCheckingLock(
 			logger,
@@ -295,10 +346,17 @@ func (scanner *resourceScanner) check(
 		return errPipelineRemoved
 	}
 
+	checkContainer, err := teamCheckContainer(scanner.teamFactory, scanner.dbPipeline, scanner.caCerts)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return err
+	}
+
 	metadata := resource.TrackerMetadata{
 		ResourceName: savedResource.Name(),
 		PipelineName: savedResource.PipelineName(),
 		ExternalURL:  scanner.externalURL,
+		ContainerEnv: checkContainer.env,
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -308,9 +366,11 @@ func (scanner *resourceScanner) check(
 		BindMounts: []worker.BindMountSource{
 			&worker.CertsVolumeMount{Logger: logger},
 		},
-		Tags:   savedResource.Tags(),
-		TeamID: scanner.dbPipeline.TeamID(),
-		Env:    metadata.Env(),
+		Tags:    savedResource.Tags(),
+		TeamID:  scanner.dbPipeline.TeamID(),
+		Env:     metadata.Env(),
+		CACerts: checkContainer.caCerts,
+		Limits:  worker.ContainerLimits(scanner.checkLimits[savedResource.Type()]),
 	}
 
 	workerSpec := worker.WorkerSpec{
@@ -323,9 +383,12 @@ func (scanner *resourceScanner) check(
 	owner := db.NewResourceConfigCheckSessionContainerOwner(
 		resourceConfigScope.ResourceConfig().ID(),
 		resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+		scanner.dbPipeline.TeamID(),
 		ContainerExpiries,
 	)
 
+	checkStarted := scanner.clock.Now()
+
 	chosenWorker, err := scanner.pool.FindOrChooseWorkerForContainer(
 		context.Background(),
 		logger,
@@ -335,6 +398,7 @@ func (scanner *resourceScanner) check(
 		scanner.strategy,
 	)
 	if err != nil {
+		recordCheck(logger, resourceConfigScope, checkStarted, "", nil, err)
 		logger.Error("failed-to-choose-a-worker", err)
 		chkErr := resourceConfigScope.SetCheckError(err)
 		if chkErr != nil {
@@ -362,6 +426,7 @@ func (scanner *resourceScanner) check(
 		if err == worker.ResourceConfigCheckSessionExpiredError {
 			return nil
 		}
+		recordCheck(logger, resourceConfigScope, checkStarted, chosenWorker.Name(), nil, err)
 		logger.Error("failed-to-create-or-find-container", err)
 		chkErr := resourceConfigScope.SetCheckError(err)
 		if chkErr != nil {
@@ -374,16 +439,28 @@ func (scanner *resourceScanner) check(
 		"from": fromVersion,
 	})
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
 	res := scanner.resourceFactory.NewResourceForContainer(container)
-	newVersions, err := res.Check(ctx, source, fromVersion)
+	stderr := new(exec.CheckOutput)
+	newVersions, deletedVersions, err := checkWithHardTimeout(logger, scanner.clock, container, res, source, fromVersion, stderr, timeout, scanner.killGracePeriod)
 	if err == context.DeadlineExceeded {
+		metric.CheckTimedOut{
+			CheckName:             savedResource.Name(),
+			ResourceConfigScopeID: strconv.Itoa(resourceConfigScope.ID()),
+		}.Emit(logger)
+
 		err = fmt.Errorf("Timed out after %v while checking for new versions - perhaps increase your resource check timeout?", timeout)
 	}
 
+	if _, killed := err.(exec.CheckKilledError); killed {
+		metric.CheckTimedOut{
+			CheckName:             savedResource.Name(),
+			ResourceConfigScopeID: strconv.Itoa(resourceConfigScope.ID()),
+			Killed:                true,
+		}.Emit(logger)
+	}
+
 	resourceConfigScope.SetCheckError(err)
+	recordCheck(logger, resourceConfigScope, checkStarted, chosenWorker.Name(), stderr, err)
 	metric.ResourceCheck{
 		PipelineName: scanner.dbPipeline.Name(),
 		ResourceName: savedResource.Name(),
@@ -392,6 +469,14 @@ func (scanner *resourceScanner) check(
 	}.Emit(logger)
 
 	if err != nil {
+		if killedByLimits(scanner.checkLimits[savedResource.Type()], err) {
+			metric.CheckKilledByLimits{
+				CheckName:             savedResource.Name(),
+				ResourceConfigScopeID: strconv.Itoa(resourceConfigScope.ID()),
+				ResourceType:          savedResource.Type(),
+			}.Emit(logger)
+		}
+
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
 			return rErr
@@ -417,6 +502,19 @@ func (scanner *resourceScanner) check(
 
 			return err
 		}
+
+		scanner.versionNotifier.NewVersions(logger, savedResource, scanner.variables, versionhook.NewSince(fromVersion, newVersions))
+	}
+
+	if len(deletedVersions) > 0 {
+		err = resourceConfigScope.SaveDeletedVersions(deletedVersions)
+		if err != nil {
+			logger.Error("failed-to-save-deleted-versions", err, lager.Data{
+				"versions": deletedVersions,
+			})
+
+			return err
+		}
 	}
 
 	updated, err := resourceConfigScope.UpdateLastCheckEndTime()
@@ -452,20 +550,6 @@ func (scanner *resourceScanner) parseResourceCheckTimeoutOrDefault(checkTimeout
 	return interval, nil
 }
 
-func (scanner *resourceScanner) checkInterval(checkEvery string) (time.Duration, error) {
-	interval := scanner.defaultInterval
-	if checkEvery != "" {
-		configuredInterval, err := time.ParseDuration(checkEvery)
-		if err != nil {
-			return 0, err
-		}
-
-		interval = configuredInterval
-	}
-
-	return interval, nil
-}
-
 func (scanner *resourceScanner) setResourceCheckError(logger lager.Logger, savedResource db.Resource, err error) {
 	setErr := savedResource.SetCheckSetupError(err)
 	if setErr != nil {
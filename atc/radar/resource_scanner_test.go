@@ -3,17 +3,22 @@ package radar_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy/checkpolicyfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/radar"
+	"github.com/concourse/concourse/atc/versionhook/versionhookfakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/concourse/vars"
@@ -34,9 +39,14 @@ var _ = Describe("ResourceScanner", func() {
 		fakeWorker                *workerfakes.FakeWorker
 		fakePool                  *workerfakes.FakePool
 		fakeStrategy              *workerfakes.FakeContainerPlacementStrategy
+		fakeCheckPolicy           *checkpolicyfakes.FakeEnforcer
+		fakeVersionNotifier       *versionhookfakes.FakeNotifier
 		fakeResourceFactory       *rfakes.FakeResourceFactory
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeDBPipeline            *dbfakes.FakePipeline
+		fakeTeamFactory           *dbfakes.FakeTeamFactory
+		fakeTeam                  *dbfakes.FakeTeam
+		checkLimits               map[string]atc.ContainerLimits
 		fakeClock                 *fakeclock.FakeClock
 		interval                  time.Duration
 		variables                 vars.Variables
@@ -85,6 +95,12 @@ var _ = Describe("ResourceScanner", func() {
 
 		fakeContainer = new(workerfakes.FakeContainer)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+		fakeCheckPolicy = new(checkpolicyfakes.FakeEnforcer)
+		fakeCheckPolicy.IntervalStub = func(_ lager.Logger, _ string, checkEvery string, defaultInterval time.Duration) (time.Duration, error) {
+			return (*atc.CheckPolicy)(nil).Interval(checkEvery, defaultInterval)
+		}
+		fakeCheckPolicy.AcquireReturns(func() {}, true, nil)
+		fakeVersionNotifier = new(versionhookfakes.FakeNotifier)
 		fakePool = new(workerfakes.FakePool)
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeResourceFactory = new(rfakes.FakeResourceFactory)
@@ -92,6 +108,10 @@ var _ = Describe("ResourceScanner", func() {
 		fakeResourceType = new(dbfakes.FakeResourceType)
 		fakeDBResource = new(dbfakes.FakeResource)
 		fakeDBPipeline = new(dbfakes.FakePipeline)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+		checkLimits = map[string]atc.ContainerLimits{}
 		fakeResourceConfig = new(dbfakes.FakeResourceConfig)
 		fakeResourceConfig.IDReturns(123)
 		fakeResourceConfig.OriginBaseResourceTypeReturns(&db.UsedBaseResourceType{ID: 456})
@@ -102,6 +122,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeDBPipeline.IDReturns(42)
 		fakeDBPipeline.NameReturns("some-pipeline")
 		fakeDBPipeline.TeamIDReturns(teamID)
+		fakeDBPipeline.TeamNameReturns("some-team")
 		fakeClock = fakeclock.NewFakeClock(epoch)
 
 		fakeDBPipeline.ReloadReturns(true, nil)
@@ -133,6 +154,12 @@ var _ = Describe("ResourceScanner", func() {
 			"https://www.example.com",
 			variables,
 			fakeStrategy,
+			fakeCheckPolicy,
+			fakeVersionNotifier,
+			fakeTeamFactory,
+			"some-installation-cert\n",
+			checkLimits,
+			time.Minute,
 		)
 	})
 
@@ -158,6 +185,22 @@ var _ = Describe("ResourceScanner", func() {
 			actualInterval, runErr = scanner.Run(scanLogger, 39)
 		})
 
+		Context("when the team is at its check concurrency limit", func() {
+			BeforeEach(func() {
+				fakeCheckPolicy.AcquireReturns(nil, false, nil)
+			})
+
+			It("does not check", func() {
+				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(BeZero())
+				Expect(fakeResource.CheckWithDeletionsCallCount()).To(BeZero())
+			})
+
+			It("returns the configured interval", func() {
+				Expect(runErr).To(Equal(ErrFailedToAcquireLock))
+				Expect(actualInterval).To(Equal(interval))
+			})
+		})
+
 		Context("when the lock cannot be acquired", func() {
 			BeforeEach(func() {
 				results := make(chan bool, 4)
@@ -195,7 +238,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("does not check", func() {
-					Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
 				})
 
 				It("returns the configured interval", func() {
@@ -210,7 +253,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("does not check", func() {
-					Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
 				})
 
 				It("returns the configured interval", func() {
@@ -225,7 +268,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks immediately", func() {
-					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(1))
 				})
 
 				It("constructs the resource of the correct type", func() {
@@ -239,7 +282,7 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(err).To(BeNil())
 
 					_, _, owner, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
-					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, radar.ContainerExpiries)))
+					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, radar.ContainerExpiries)))
 					Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 						ResourceType: "git",
 					}))
@@ -260,7 +303,7 @@ var _ = Describe("ResourceScanner", func() {
 					var metadata db.ContainerMetadata
 					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 					_, _, _, owner, metadata, containerSpec, resourceTypes = fakeWorker.FindOrCreateContainerArgsForCall(0)
-					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, radar.ContainerExpiries)))
+					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, radar.ContainerExpiries)))
 					Expect(metadata).To(Equal(db.ContainerMetadata{
 						Type: db.ContainerTypeCheck,
 					}))
@@ -277,6 +320,121 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(resourceTypes).To(Equal(interpolatedResourceTypes))
 				})
 
+				It("trusts the installation's CA certs in the container", func() {
+					_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+					Expect(containerSpec.CACerts).To(Equal("some-installation-cert\n"))
+				})
+
+				Context("when the team has CA certs", func() {
+					BeforeEach(func() {
+						fakeTeam.CACertsReturns("some-team-cert\n")
+					})
+
+					It("trusts them as well as the installation's", func() {
+						Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+
+						_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(containerSpec.CACerts).To(Equal("some-installation-cert\nsome-team-cert\n"))
+					})
+
+					It("checks in a container of the team's own", func() {
+						_, _, _, owner, _, _, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, radar.ContainerExpiries)))
+					})
+				})
+
+				It("does not limit the container", func() {
+					_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+					Expect(containerSpec.Limits).To(BeZero())
+				})
+
+				Context("when the resource type has check limits", func() {
+					var cpu, memory uint64
+
+					BeforeEach(func() {
+						cpu = 512
+						memory = 1024 * 1024 * 1024
+						checkLimits["git"] = atc.ContainerLimits{CPU: &cpu, Memory: &memory}
+						checkLimits["some-other-type"] = atc.ContainerLimits{CPU: &memory}
+					})
+
+					It("creates the container with the limits", func() {
+						_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(containerSpec.Limits).To(Equal(worker.ContainerLimits{CPU: &cpu, Memory: &memory}))
+					})
+				})
+
+				Context("when the check does not exit after timing out", func() {
+					BeforeEach(func() {
+						fakeResource.CheckWithDeletionsStub = func(ctx context.Context, _ atc.Source, _ atc.Version, _ io.Writer) ([]atc.Version, []atc.Version, error) {
+							fakeClock.WaitForWatcherAndIncrement(time.Hour + time.Minute)
+							<-ctx.Done()
+							return nil, nil, ctx.Err()
+						}
+					})
+
+					It("kills it after the grace period", func() {
+						Expect(runErr).To(Equal(exec.CheckKilledError{Timeout: time.Hour + time.Minute}))
+
+						Expect(fakeContainer.StopCallCount()).To(Equal(1))
+						Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+					})
+
+					It("sets the check error", func() {
+						Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
+						Expect(fakeResourceConfigScope.SetCheckErrorArgsForCall(0)).To(Equal(exec.CheckKilledError{Timeout: time.Hour + time.Minute}))
+					})
+
+					It("does not save any versions", func() {
+						Expect(fakeResourceConfigScope.SaveVersionsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the team has container env", func() {
+					BeforeEach(func() {
+						fakeTeam.ContainerEnvReturns(map[string]string{
+							"HTTPS_PROXY":   "http://proxy.example.com",
+							"RESOURCE_NAME": "some-other-resource",
+						})
+					})
+
+					It("injects it into the container, without overriding the resource's metadata", func() {
+						_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(containerSpec.Env).To(Equal([]string{
+							"ATC_EXTERNAL_URL=https://www.example.com",
+							"RESOURCE_PIPELINE_NAME=some-pipeline",
+							"RESOURCE_NAME=some-resource",
+							"HTTPS_PROXY=http://proxy.example.com",
+						}))
+					})
+
+					Context("when the pipeline ignores the team's container env", func() {
+						BeforeEach(func() {
+							fakeDBPipeline.IgnoreTeamContainerEnvReturns(true)
+						})
+
+						It("leaves it out of the container", func() {
+							_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+							Expect(containerSpec.Env).To(Equal([]string{
+								"ATC_EXTERNAL_URL=https://www.example.com",
+								"RESOURCE_PIPELINE_NAME=some-pipeline",
+								"RESOURCE_NAME=some-resource",
+							}))
+						})
+					})
+				})
+
+				Context("when finding the team fails", func() {
+					BeforeEach(func() {
+						fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+					})
+
+					It("does not check", func() {
+						Expect(runErr).To(HaveOccurred())
+						Expect(fakeResource.CheckWithDeletionsCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when the resource config has a specified check interval", func() {
 					BeforeEach(func() {
 						fakeDBResource.CheckEveryReturns("10ms")
@@ -317,6 +475,36 @@ var _ = Describe("ResourceScanner", func() {
 					})
 				})
 
+				Context("when the team's check policy overrides the interval", func() {
+					BeforeEach(func() {
+						fakeDBResource.CheckEveryReturns("10ms")
+						fakeCheckPolicy.IntervalReturns(5*time.Minute, nil)
+					})
+
+					It("leases for and returns the team's interval", func() {
+						_, teamName, checkEvery, defaultInterval := fakeCheckPolicy.IntervalArgsForCall(0)
+						Expect(teamName).To(Equal("some-team"))
+						Expect(checkEvery).To(Equal("10ms"))
+						Expect(defaultInterval).To(Equal(interval))
+
+						leaseInterval, _ := fakeResourceConfigScope.UpdateLastCheckStartTimeArgsForCall(0)
+						Expect(leaseInterval).To(Equal(5 * time.Minute))
+						Expect(actualInterval).To(Equal(5 * time.Minute))
+					})
+				})
+
+				It("holds one of the team's concurrent checks while checking", func() {
+					released := false
+					fakeCheckPolicy.AcquireReturns(func() { released = true }, true, nil)
+
+					_, _ = scanner.Run(scanLogger, 39)
+
+					Expect(fakeCheckPolicy.AcquireCallCount()).To(Equal(2))
+					_, teamName := fakeCheckPolicy.AcquireArgsForCall(1)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(released).To(BeTrue())
+				})
+
 				It("grabs a periodic resource checking lock before checking, breaks lock after done", func() {
 					Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(1))
 					Expect(fakeResourceConfigScope.UpdateLastCheckStartTimeCallCount()).To(Equal(1))
@@ -369,7 +557,7 @@ var _ = Describe("ResourceScanner", func() {
 								err := fakeDBResource.SetCheckSetupErrorArgsForCall(0)
 								Expect(err).To(Equal(errors.New("oops")))
 
-								Expect(fakeResource.CheckCallCount()).To(Equal(0))
+								Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
 							})
 						})
 
@@ -413,7 +601,7 @@ var _ = Describe("ResourceScanner", func() {
 
 				Context("when there is no current version", func() {
 					It("checks from nil", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 						Expect(version).To(BeNil())
 					})
 				})
@@ -428,7 +616,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("checks from it", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "1"}))
 					})
 				})
@@ -452,7 +640,7 @@ var _ = Describe("ResourceScanner", func() {
 						}
 
 						check := 0
-						fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+						fakeResource.CheckWithDeletionsStub = func(ctx context.Context, source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
 							defer GinkgoRecover()
 
 							Expect(source).To(Equal(resourceConfig.Source))
@@ -461,7 +649,7 @@ var _ = Describe("ResourceScanner", func() {
 							result := checkResults[check]
 							check++
 
-							return result, nil
+							return result, nil, nil
 						}
 					})
 
@@ -476,6 +664,15 @@ var _ = Describe("ResourceScanner", func() {
 						}))
 					})
 
+					It("notifies the resource's webhooks of them", func() {
+						Expect(fakeVersionNotifier.NewVersionsCallCount()).To(Equal(1))
+
+						_, resource, notifiedVariables, versions := fakeVersionNotifier.NewVersionsArgsForCall(0)
+						Expect(resource).To(Equal(fakeDBResource))
+						Expect(notifiedVariables).To(Equal(variables))
+						Expect(versions).To(Equal(nextVersions))
+					})
+
 					Context("when saving versions fails", func() {
 						BeforeEach(func() {
 							fakeResourceConfigScope.SaveVersionsReturns(errors.New("failed"))
@@ -484,6 +681,38 @@ var _ = Describe("ResourceScanner", func() {
 						It("returns an error", func() {
 							Expect(runErr).To(HaveOccurred())
 						})
+
+						It("does not notify the resource's webhooks", func() {
+							Expect(fakeVersionNotifier.NewVersionsCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when the check reports deleted versions", func() {
+					var deletedVersions []atc.Version
+
+					BeforeEach(func() {
+						deletedVersions = []atc.Version{{"version": "0"}}
+						fakeResource.CheckWithDeletionsReturns(nil, deletedVersions, nil)
+					})
+
+					It("saves the deleted versions", func() {
+						Expect(fakeResourceConfigScope.SaveDeletedVersionsCallCount()).To(Equal(1))
+						Expect(fakeResourceConfigScope.SaveDeletedVersionsArgsForCall(0)).To(Equal(deletedVersions))
+					})
+
+					It("updates last check finished", func() {
+						Expect(fakeResourceConfigScope.UpdateLastCheckEndTimeCallCount()).To(Equal(1))
+					})
+
+					Context("when saving the deleted versions fails", func() {
+						BeforeEach(func() {
+							fakeResourceConfigScope.SaveDeletedVersionsReturns(errors.New("failed"))
+						})
+
+						It("returns an error", func() {
+							Expect(runErr).To(HaveOccurred())
+						})
 					})
 				})
 
@@ -491,7 +720,7 @@ var _ = Describe("ResourceScanner", func() {
 					disaster := errors.New("nope")
 
 					BeforeEach(func() {
-						fakeResource.CheckReturns(nil, disaster)
+						fakeResource.CheckWithDeletionsReturns(nil, nil, disaster)
 					})
 
 					It("exits with the failure", func() {
@@ -504,7 +733,7 @@ var _ = Describe("ResourceScanner", func() {
 					scriptFail := resource.ErrResourceScriptFailed{}
 
 					BeforeEach(func() {
-						fakeResource.CheckReturns(nil, scriptFail)
+						fakeResource.CheckWithDeletionsReturns(nil, nil, scriptFail)
 					})
 
 					It("returns no error", func() {
@@ -518,7 +747,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("does not check", func() {
-						Expect(fakeResource.CheckCallCount()).To(BeZero())
+						Expect(fakeResource.CheckWithDeletionsCallCount()).To(BeZero())
 					})
 
 					It("returns the default interval", func() {
@@ -662,7 +891,7 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(err).To(BeNil())
 
 				_, _, owner, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
-				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, radar.ContainerExpiries)))
+				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, radar.ContainerExpiries)))
 				Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 					ResourceType: "git",
 				}))
@@ -682,7 +911,7 @@ var _ = Describe("ResourceScanner", func() {
 
 				var metadata db.ContainerMetadata
 				_, _, _, owner, metadata, containerSpec, resourceTypes = fakeWorker.FindOrCreateContainerArgsForCall(0)
-				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, radar.ContainerExpiries)))
+				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, radar.ContainerExpiries)))
 				Expect(metadata).To(Equal(db.ContainerMetadata{
 					Type: db.ContainerTypeCheck,
 				}))
@@ -724,6 +953,28 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			It("records the credentials interpolated into the source on the scope", func() {
+				Expect(fakeResourceConfigScope.UpdateCredentialsCallCount()).To(Equal(1))
+
+				credentials := fakeResourceConfigScope.UpdateCredentialsArgsForCall(0)
+				Expect(credentials).To(HaveLen(1))
+				Expect(credentials[0].Path).To(Equal("source-params"))
+			})
+
+			Context("when recording the credentials fails", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.UpdateCredentialsReturns(false, errors.New("catastrophe"))
+				})
+
+				It("sets the check error and returns the error", func() {
+					Expect(scanErr).To(HaveOccurred())
+					Expect(fakeDBResource.SetCheckSetupErrorCallCount()).To(Equal(1))
+
+					resourceErr := fakeDBResource.SetCheckSetupErrorArgsForCall(0)
+					Expect(resourceErr).To(MatchError("catastrophe"))
+				})
+			})
+
 			Context("when creating the container fails", func() {
 				BeforeEach(func() {
 					fakeWorker.FindOrCreateContainerReturns(nil, errors.New("catastrophe"))
@@ -750,6 +1001,16 @@ var _ = Describe("ResourceScanner", func() {
 					resourceErr := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(resourceErr).To(MatchError("catastrophe"))
 				})
+
+				It("records the check without a worker", func() {
+					Expect(fakeResourceConfigScope.RecordCheckCallCount()).To(Equal(1))
+
+					startTime, workerName, output, checkErr := fakeResourceConfigScope.RecordCheckArgsForCall(0)
+					Expect(startTime).To(Equal(epoch))
+					Expect(workerName).To(BeEmpty())
+					Expect(output).To(BeEmpty())
+					Expect(checkErr).To(MatchError("catastrophe"))
+				})
 			})
 
 			Context("when the resource config has a specified check interval", func() {
@@ -793,7 +1054,7 @@ var _ = Describe("ResourceScanner", func() {
 
 				It("times out after the specified timeout", func() {
 					now := time.Now()
-					ctx, _, _ := fakeResource.CheckArgsForCall(0)
+					ctx, _, _, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					deadline, _ := ctx.Deadline()
 					Expect(deadline).Should(BeTemporally("~", now.Add(10*time.Second), time.Second))
 				})
@@ -833,7 +1094,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("does not check", func() {
-						Expect(fakeResource.CheckCallCount()).To(Equal(0))
+						Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
 					})
 				})
 
@@ -843,7 +1104,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("checks from the pinned version", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "1"}))
 					})
 				})
@@ -875,7 +1136,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -892,7 +1153,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("does not check", func() {
-					Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
 				})
 			})
 
@@ -908,18 +1169,35 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
 				Context("when the check returns only the latest version", func() {
 					BeforeEach(func() {
-						fakeResource.CheckReturns([]atc.Version{atc.Version(latestVersion)}, nil)
+						fakeResource.CheckWithDeletionsReturns([]atc.Version{atc.Version(latestVersion)}, nil, nil)
 					})
 
 					It("does not save it", func() {
 						Expect(fakeResourceConfigScope.SaveVersionsCallCount()).To(Equal(0))
 					})
+
+					It("does not notify the resource's webhooks", func() {
+						Expect(fakeVersionNotifier.NewVersionsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the check returns the latest version and newer ones", func() {
+					BeforeEach(func() {
+						fakeResource.CheckWithDeletionsReturns([]atc.Version{atc.Version(latestVersion), {"version": "2"}}, nil, nil)
+					})
+
+					It("notifies the resource's webhooks of only the newer ones", func() {
+						Expect(fakeVersionNotifier.NewVersionsCallCount()).To(Equal(1))
+
+						_, _, _, versions := fakeVersionNotifier.NewVersionsArgsForCall(0)
+						Expect(versions).To(Equal([]atc.Version{{"version": "2"}}))
+					})
 				})
 			})
 
@@ -942,16 +1220,17 @@ var _ = Describe("ResourceScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckWithDeletionsStub = func(ctx context.Context, source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
 
 						checkedFrom <- from
+						fmt.Fprint(stderr, "some-output")
 						result := checkResults[check]
 						check++
 
-						return result, nil
+						return result, nil, nil
 					}
 				})
 
@@ -970,6 +1249,16 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(fakeResourceConfigScope.UpdateLastCheckEndTimeCallCount()).To(Equal(1))
 				})
 
+				It("records the check with its worker and output", func() {
+					Expect(fakeResourceConfigScope.RecordCheckCallCount()).To(Equal(1))
+
+					startTime, workerName, output, checkErr := fakeResourceConfigScope.RecordCheckArgsForCall(0)
+					Expect(startTime).To(Equal(epoch))
+					Expect(workerName).To(Equal("some-worker"))
+					Expect(output).To(Equal("some-output"))
+					Expect(checkErr).ToNot(HaveOccurred())
+				})
+
 				Context("when saving fails", func() {
 					BeforeEach(func() {
 						fakeResourceConfigScope.SaveVersionsReturns(errors.New("some-error"))
@@ -983,8 +1272,8 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when the check does not return any new versions", func() {
 				BeforeEach(func() {
-					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
-						return []atc.Version{}, nil
+					fakeResource.CheckWithDeletionsStub = func(ctx context.Context, source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
+						return []atc.Version{}, nil, nil
 					}
 				})
 
@@ -997,7 +1286,7 @@ var _ = Describe("ResourceScanner", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
-					fakeResource.CheckReturns(nil, disaster)
+					fakeResource.CheckWithDeletionsReturns(nil, nil, disaster)
 				})
 
 				It("returns the error", func() {
@@ -1010,13 +1299,20 @@ var _ = Describe("ResourceScanner", func() {
 					err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(err).To(Equal(disaster))
 				})
+
+				It("records the check's error", func() {
+					Expect(fakeResourceConfigScope.RecordCheckCallCount()).To(Equal(1))
+
+					_, _, _, checkErr := fakeResourceConfigScope.RecordCheckArgsForCall(0)
+					Expect(checkErr).To(Equal(disaster))
+				})
 			})
 
 			Context("when checking fails with ErrResourceScriptFailed", func() {
 				scriptFail := resource.ErrResourceScriptFailed{}
 
 				BeforeEach(func() {
-					fakeResource.CheckReturns(nil, scriptFail)
+					fakeResource.CheckWithDeletionsReturns(nil, nil, scriptFail)
 				})
 
 				It("returns no error", func() {
@@ -1066,7 +1362,7 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when fromVersion is nil", func() {
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -1079,13 +1375,13 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
 				Context("when the check returns only the latest version", func() {
 					BeforeEach(func() {
-						fakeResource.CheckReturns([]atc.Version{fromVersion}, nil)
+						fakeResource.CheckWithDeletionsReturns([]atc.Version{fromVersion}, nil, nil)
 					})
 
 					It("saves it", func() {
@@ -1100,7 +1396,7 @@ var _ = Describe("ResourceScanner", func() {
 				scriptFail := resource.ErrResourceScriptFailed{}
 
 				BeforeEach(func() {
-					fakeResource.CheckReturns(nil, scriptFail)
+					fakeResource.CheckWithDeletionsReturns(nil, nil, scriptFail)
 				})
 
 				It("returns the error", func() {
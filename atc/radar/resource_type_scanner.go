@@ -2,14 +2,19 @@ package radar
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/vars"
@@ -25,6 +30,11 @@ type resourceTypeScanner struct {
 	externalURL           string
 	variables             vars.Variables
 	strategy              worker.ContainerPlacementStrategy
+	checkPolicy           checkpolicy.Enforcer
+	teamFactory           db.TeamFactory
+	caCerts               string
+	checkLimits           map[string]atc.ContainerLimits
+	killGracePeriod       time.Duration
 }
 
 func NewResourceTypeScanner(
@@ -37,6 +47,11 @@ func NewResourceTypeScanner(
 	externalURL string,
 	variables vars.Variables,
 	strategy worker.ContainerPlacementStrategy,
+	checkPolicy checkpolicy.Enforcer,
+	teamFactory db.TeamFactory,
+	caCerts string,
+	checkLimits map[string]atc.ContainerLimits,
+	killGracePeriod time.Duration,
 ) Scanner {
 	return &resourceTypeScanner{
 		clock:                 clock,
@@ -48,6 +63,11 @@ func NewResourceTypeScanner(
 		externalURL:           externalURL,
 		variables:             variables,
 		strategy:              strategy,
+		checkPolicy:           checkPolicy,
+		teamFactory:           teamFactory,
+		caCerts:               caCerts,
+		checkLimits:           checkLimits,
+		killGracePeriod:       killGracePeriod,
 	}
 }
 
@@ -80,7 +100,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int
 		"resource-type": savedResourceType.Name(),
 	})
 
-	interval, err := scanner.checkInterval(savedResourceType.CheckEvery())
+	interval, err := scanner.checkPolicy.Interval(logger, scanner.dbPipeline.TeamName(), savedResourceType.CheckEvery(), scanner.defaultInterval)
 	if err != nil {
 		scanner.setCheckError(logger, savedResourceType, err)
 		return 0, err
@@ -126,7 +146,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int
 		return 0, err
 	}
 
-	source, err := creds.NewSource(scanner.variables, savedResourceType.Source()).Evaluate()
+	source, credentials, err := creds.NewSource(scanner.variables, savedResourceType.Source()).EvaluateWithCredentials()
 	if err != nil {
 		logger.Error("failed-to-evaluate-resource-type-source", err)
 		scanner.setCheckError(logger, savedResourceType, err)
@@ -143,9 +163,38 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int
 		return 0, err
 	}
 
+	rotated, err := resourceConfigScope.UpdateCredentials(credentials)
+	if err != nil {
+		logger.Error("failed-to-update-resource-config-credentials", err)
+		scanner.setCheckError(logger, savedResourceType, err)
+		return 0, err
+	}
+
+	if rotated {
+		logger.Info("credentials-rotated")
+	}
+
 	// Clear out the check error on the resource type
 	scanner.setCheckError(logger, savedResourceType, err)
 
+	for {
+		release, acquired, err := scanner.checkPolicy.Acquire(logger, scanner.dbPipeline.TeamName())
+		if err != nil {
+			return interval, err
+		}
+
+		if acquired {
+			defer release()
+			break
+		}
+
+		if !mustComplete {
+			return interval, ErrFailedToAcquireLock
+		}
+
+		scanner.clock.Sleep(time.Second)
+	}
+
 	reattempt := true
 	for reattempt {
 		reattempt = mustComplete
@@ -237,6 +286,12 @@ func (scanner *resourceTypeScanner) check(
 		return nil
 	}
 
+	checkContainer, err := teamCheckContainer(scanner.teamFactory, scanner.dbPipeline, scanner.caCerts)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return err
+	}
+
 	containerSpec := worker.ContainerSpec{
 		ImageSpec: worker.ImageSpec{
 			ResourceType: savedResourceType.Type(),
@@ -246,6 +301,9 @@ func (scanner *resourceTypeScanner) check(
 		BindMounts: []worker.BindMountSource{
 			&worker.CertsVolumeMount{Logger: logger},
 		},
+		Env:     resource.TrackerMetadata{ContainerEnv: checkContainer.env}.Env(),
+		CACerts: checkContainer.caCerts,
+		Limits:  worker.ContainerLimits(scanner.checkLimits[savedResourceType.Type()]),
 	}
 
 	workerSpec := worker.WorkerSpec{
@@ -258,9 +316,12 @@ func (scanner *resourceTypeScanner) check(
 	owner := db.NewResourceConfigCheckSessionContainerOwner(
 		resourceConfigScope.ResourceConfig().ID(),
 		resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+		scanner.dbPipeline.TeamID(),
 		ContainerExpiries,
 	)
 
+	checkStarted := scanner.clock.Now()
+
 	chosenWorker, err := scanner.pool.FindOrChooseWorkerForContainer(
 		context.Background(),
 		logger,
@@ -270,6 +331,7 @@ func (scanner *resourceTypeScanner) check(
 		scanner.strategy,
 	)
 	if err != nil {
+		recordCheck(logger, resourceConfigScope, checkStarted, "", nil, err)
 		chkErr := resourceConfigScope.SetCheckError(err)
 		if chkErr != nil {
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
@@ -285,6 +347,7 @@ func (scanner *resourceTypeScanner) check(
 		db.NewResourceConfigCheckSessionContainerOwner(
 			resourceConfigScope.ResourceConfig().ID(),
 			resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+			scanner.dbPipeline.TeamID(),
 			ContainerExpiries,
 		),
 		db.ContainerMetadata{
@@ -294,6 +357,7 @@ func (scanner *resourceTypeScanner) check(
 		versionedResourceTypes.Without(savedResourceType.Name()),
 	)
 	if err != nil {
+		recordCheck(logger, resourceConfigScope, checkStarted, chosenWorker.Name(), nil, err)
 		chkErr := resourceConfigScope.SetCheckError(err)
 		if chkErr != nil {
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
@@ -303,9 +367,36 @@ func (scanner *resourceTypeScanner) check(
 	}
 
 	res := scanner.resourceFactory.NewResourceForContainer(container)
-	newVersions, err := res.Check(context.TODO(), source, fromVersion)
+	stderr := new(exec.CheckOutput)
+	newVersions, deletedVersions, err := checkWithHardTimeout(logger, scanner.clock, container, res, source, fromVersion, stderr, GlobalResourceCheckTimeout, scanner.killGracePeriod)
+	if err == context.DeadlineExceeded {
+		metric.CheckTimedOut{
+			CheckName:             savedResourceType.Name(),
+			ResourceConfigScopeID: strconv.Itoa(resourceConfigScope.ID()),
+		}.Emit(logger)
+
+		err = fmt.Errorf("Timed out after %v while checking for new versions", GlobalResourceCheckTimeout)
+	}
+
+	if _, killed := err.(exec.CheckKilledError); killed {
+		metric.CheckTimedOut{
+			CheckName:             savedResourceType.Name(),
+			ResourceConfigScopeID: strconv.Itoa(resourceConfigScope.ID()),
+			Killed:                true,
+		}.Emit(logger)
+	}
+
 	resourceConfigScope.SetCheckError(err)
+	recordCheck(logger, resourceConfigScope, checkStarted, chosenWorker.Name(), stderr, err)
 	if err != nil {
+		if killedByLimits(scanner.checkLimits[savedResourceType.Type()], err) {
+			metric.CheckKilledByLimits{
+				CheckName:             savedResourceType.Name(),
+				ResourceConfigScopeID: strconv.Itoa(resourceConfigScope.ID()),
+				ResourceType:          savedResourceType.Type(),
+			}.Emit(logger)
+		}
+
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
 			return rErr
@@ -317,37 +408,32 @@ func (scanner *resourceTypeScanner) check(
 
 	if len(newVersions) == 0 || (!saveGiven && reflect.DeepEqual(newVersions, []atc.Version{fromVersion})) {
 		logger.Debug("no-new-versions")
-		return nil
-	}
-
-	logger.Info("versions-found", lager.Data{
-		"versions": newVersions,
-		"total":    len(newVersions),
-	})
-
-	err = resourceConfigScope.SaveVersions(newVersions)
-	if err != nil {
-		logger.Error("failed-to-save-resource-config-versions", err, lager.Data{
+	} else {
+		logger.Info("versions-found", lager.Data{
 			"versions": newVersions,
+			"total":    len(newVersions),
 		})
-		return err
-	}
-
-	return nil
-}
 
-func (scanner *resourceTypeScanner) checkInterval(checkEvery string) (time.Duration, error) {
-	interval := scanner.defaultInterval
-	if checkEvery != "" {
-		configuredInterval, err := time.ParseDuration(checkEvery)
+		err = resourceConfigScope.SaveVersions(newVersions)
 		if err != nil {
-			return 0, err
+			logger.Error("failed-to-save-resource-config-versions", err, lager.Data{
+				"versions": newVersions,
+			})
+			return err
 		}
+	}
 
-		interval = configuredInterval
+	if len(deletedVersions) > 0 {
+		err = resourceConfigScope.SaveDeletedVersions(deletedVersions)
+		if err != nil {
+			logger.Error("failed-to-save-deleted-versions", err, lager.Data{
+				"versions": deletedVersions,
+			})
+			return err
+		}
 	}
 
-	return interval, nil
+	return nil
 }
 
 func (scanner *resourceTypeScanner) setCheckError(logger lager.Logger, savedResourceType db.ResourceType, err error) {
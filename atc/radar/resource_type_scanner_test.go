@@ -3,16 +3,19 @@ package radar_test
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy/checkpolicyfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/exec"
 	. "github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
@@ -32,9 +35,13 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeWorker                *workerfakes.FakeWorker
 		fakePool                  *workerfakes.FakePool
 		fakeStrategy              *workerfakes.FakeContainerPlacementStrategy
+		fakeCheckPolicy           *checkpolicyfakes.FakeEnforcer
 		fakeResourceFactory       *rfakes.FakeResourceFactory
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeDBPipeline            *dbfakes.FakePipeline
+		fakeTeamFactory           *dbfakes.FakeTeamFactory
+		fakeTeam                  *dbfakes.FakeTeam
+		checkLimits               map[string]atc.ContainerLimits
 		fakeResourceConfig        *dbfakes.FakeResourceConfig
 		fakeResourceConfigScope   *dbfakes.FakeResourceConfigScope
 		fakeClock                 *fakeclock.FakeClock
@@ -54,6 +61,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 	BeforeEach(func() {
 		fakeLock = &lockfakes.FakeLock{}
 		interval = 1 * time.Minute
+		GlobalResourceCheckTimeout = 1 * time.Hour
 		variables = vars.StaticVariables{
 			"source-params": "some-secret-sauce",
 		}
@@ -74,12 +82,21 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 		fakeContainer = new(workerfakes.FakeContainer)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+		fakeCheckPolicy = new(checkpolicyfakes.FakeEnforcer)
+		fakeCheckPolicy.IntervalStub = func(_ lager.Logger, _ string, checkEvery string, defaultInterval time.Duration) (time.Duration, error) {
+			return (*atc.CheckPolicy)(nil).Interval(checkEvery, defaultInterval)
+		}
+		fakeCheckPolicy.AcquireReturns(func() {}, true, nil)
 		fakePool = new(workerfakes.FakePool)
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeResourceFactory = new(rfakes.FakeResourceFactory)
 		fakeResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 		fakeResourceType = new(dbfakes.FakeResourceType)
 		fakeDBPipeline = new(dbfakes.FakePipeline)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+		checkLimits = map[string]atc.ContainerLimits{}
 		fakeResourceConfig = new(dbfakes.FakeResourceConfig)
 		fakeResourceConfig.IDReturns(123)
 		fakeResourceConfig.OriginBaseResourceTypeReturns(&db.UsedBaseResourceType{ID: 456})
@@ -98,6 +115,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeDBPipeline.IDReturns(42)
 		fakeDBPipeline.NameReturns("some-pipeline")
 		fakeDBPipeline.TeamIDReturns(teamID)
+		fakeDBPipeline.TeamNameReturns("some-team")
 		fakeDBPipeline.ReloadReturns(true, nil)
 		fakeDBPipeline.ResourceTypesReturns([]db.ResourceType{fakeResourceType}, nil)
 		fakeDBPipeline.ResourceTypeByIDReturns(fakeResourceType, true, nil)
@@ -112,6 +130,11 @@ var _ = Describe("ResourceTypeScanner", func() {
 			"https://www.example.com",
 			variables,
 			fakeStrategy,
+			fakeCheckPolicy,
+			fakeTeamFactory,
+			"some-installation-cert\n",
+			checkLimits,
+			time.Minute,
 		)
 	})
 
@@ -137,13 +160,47 @@ var _ = Describe("ResourceTypeScanner", func() {
 			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), fakeResourceType.ID())
 		})
 
+		Context("when the team's check policy overrides the interval", func() {
+			BeforeEach(func() {
+				fakeCheckPolicy.IntervalReturns(5*time.Minute, nil)
+				fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
+				fakeResourceConfigScope.UpdateLastCheckStartTimeReturns(true, nil)
+			})
+
+			It("leases for and returns the team's interval", func() {
+				_, teamName, _, defaultInterval := fakeCheckPolicy.IntervalArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+				Expect(defaultInterval).To(Equal(interval))
+
+				leaseInterval, _ := fakeResourceConfigScope.UpdateLastCheckStartTimeArgsForCall(0)
+				Expect(leaseInterval).To(Equal(5 * time.Minute))
+				Expect(actualInterval).To(Equal(5 * time.Minute))
+			})
+		})
+
+		Context("when the team is at its check concurrency limit", func() {
+			BeforeEach(func() {
+				fakeCheckPolicy.AcquireReturns(nil, false, nil)
+			})
+
+			It("does not check", func() {
+				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(BeZero())
+				Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
+			})
+
+			It("returns the configured interval", func() {
+				Expect(runErr).To(Equal(ErrFailedToAcquireLock))
+				Expect(actualInterval).To(Equal(interval))
+			})
+		})
+
 		Context("when the lock cannot be acquired", func() {
 			BeforeEach(func() {
 				fakeResourceConfigScope.AcquireResourceCheckingLockReturns(nil, false, nil)
 			})
 
 			It("does not check", func() {
-				Expect(fakeResource.CheckCallCount()).To(Equal(0))
+				Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
 			})
 
 			It("returns the configured interval", func() {
@@ -163,7 +220,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("does not check", func() {
-					Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(0))
 				})
 
 				It("returns the configured interval", func() {
@@ -178,7 +235,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks immediately", func() {
-					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(1))
 				})
 
 				It("constructs the resource of the correct type", func() {
@@ -188,7 +245,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(resourceTypes).To(Equal(atc.VersionedResourceTypes{}))
 
 					_, _, owner, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
-					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 					Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 						ResourceType: "registry-image",
 					}))
@@ -204,7 +261,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 
 					_, _, _, owner, metadata, containerSpec, resourceTypes = fakeWorker.FindOrCreateContainerArgsForCall(0)
-					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 					Expect(metadata).To(Equal(db.ContainerMetadata{
 						Type: db.ContainerTypeCheck,
 					}))
@@ -216,6 +273,113 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(resourceTypes).To(Equal(atc.VersionedResourceTypes{}))
 				})
 
+				It("trusts the installation's CA certs in the container", func() {
+					_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+					Expect(containerSpec.CACerts).To(Equal("some-installation-cert\n"))
+				})
+
+				Context("when the team has CA certs", func() {
+					BeforeEach(func() {
+						fakeTeam.CACertsReturns("some-team-cert\n")
+					})
+
+					It("trusts them as well as the installation's", func() {
+						Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+
+						_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(containerSpec.CACerts).To(Equal("some-installation-cert\nsome-team-cert\n"))
+					})
+
+					It("checks in a container of the team's own", func() {
+						_, _, _, owner, _, _, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
+					})
+				})
+
+				It("does not limit the container", func() {
+					_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+					Expect(containerSpec.Limits).To(BeZero())
+				})
+
+				Context("when the resource type has check limits", func() {
+					var cpu, memory uint64
+
+					BeforeEach(func() {
+						cpu = 512
+						memory = 1024 * 1024 * 1024
+						checkLimits["registry-image"] = atc.ContainerLimits{CPU: &cpu, Memory: &memory}
+						checkLimits["some-other-type"] = atc.ContainerLimits{CPU: &memory}
+					})
+
+					It("creates the container with the limits", func() {
+						_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(containerSpec.Limits).To(Equal(worker.ContainerLimits{CPU: &cpu, Memory: &memory}))
+					})
+				})
+
+				Context("when the check does not exit after timing out", func() {
+					BeforeEach(func() {
+						fakeResource.CheckWithDeletionsStub = func(ctx context.Context, _ atc.Source, _ atc.Version, _ io.Writer) ([]atc.Version, []atc.Version, error) {
+							fakeClock.WaitForWatcherAndIncrement(time.Hour + time.Minute)
+							<-ctx.Done()
+							return nil, nil, ctx.Err()
+						}
+					})
+
+					It("kills it after the grace period", func() {
+						Expect(runErr).To(Equal(exec.CheckKilledError{Timeout: time.Hour + time.Minute}))
+
+						Expect(fakeContainer.StopCallCount()).To(Equal(1))
+						Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+					})
+
+					It("sets the check error", func() {
+						Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
+						Expect(fakeResourceConfigScope.SetCheckErrorArgsForCall(0)).To(Equal(exec.CheckKilledError{Timeout: time.Hour + time.Minute}))
+					})
+
+					It("does not save any versions", func() {
+						Expect(fakeResourceConfigScope.SaveVersionsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the team has container env", func() {
+					BeforeEach(func() {
+						fakeTeam.ContainerEnvReturns(map[string]string{
+							"HTTPS_PROXY": "http://proxy.example.com",
+						})
+					})
+
+					It("injects it into the container", func() {
+						_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+						Expect(containerSpec.Env).To(Equal([]string{
+							"HTTPS_PROXY=http://proxy.example.com",
+						}))
+					})
+
+					Context("when the pipeline ignores the team's container env", func() {
+						BeforeEach(func() {
+							fakeDBPipeline.IgnoreTeamContainerEnvReturns(true)
+						})
+
+						It("leaves it out of the container", func() {
+							_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+							Expect(containerSpec.Env).To(BeEmpty())
+						})
+					})
+				})
+
+				Context("when finding the team fails", func() {
+					BeforeEach(func() {
+						fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+					})
+
+					It("does not check", func() {
+						Expect(runErr).To(HaveOccurred())
+						Expect(fakeResource.CheckWithDeletionsCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when the resource type overrides a base resource type", func() {
 					BeforeEach(func() {
 						otherResourceType := fakeResourceType
@@ -246,7 +410,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						Expect(err).To(BeNil())
 
 						_, _, owner, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
-						Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+						Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 						Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 							ResourceType: "registry-image",
 						}))
@@ -259,7 +423,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 						Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 						_, _, _, owner, metadata, containerSpec, resourceTypes = fakeWorker.FindOrCreateContainerArgsForCall(0)
-						Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+						Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 						Expect(metadata).To(Equal(db.ContainerMetadata{
 							Type: db.ContainerTypeCheck,
 						}))
@@ -328,7 +492,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					})
 
 					It("checks from nil", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 						Expect(version).To(BeNil())
 					})
 				})
@@ -343,8 +507,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 					})
 
 					It("checks with it", func() {
-						Expect(fakeResource.CheckCallCount()).To(Equal(1))
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(1))
+						_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "42"}))
 					})
 				})
@@ -368,7 +532,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						}
 
 						check := 0
-						fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+						fakeResource.CheckWithDeletionsStub = func(ctx context.Context, source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
 							defer GinkgoRecover()
 
 							Expect(source).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
@@ -377,7 +541,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 							result := checkResults[check]
 							check++
 
-							return result, nil
+							return result, nil, nil
 						}
 					})
 
@@ -389,11 +553,35 @@ var _ = Describe("ResourceTypeScanner", func() {
 					})
 				})
 
+				Context("when the check reports deleted versions", func() {
+					var deletedVersions []atc.Version
+
+					BeforeEach(func() {
+						deletedVersions = []atc.Version{{"custom": "version"}}
+						fakeResource.CheckWithDeletionsReturns(nil, deletedVersions, nil)
+					})
+
+					It("saves the deleted versions", func() {
+						Expect(fakeResourceConfigScope.SaveDeletedVersionsCallCount()).To(Equal(1))
+						Expect(fakeResourceConfigScope.SaveDeletedVersionsArgsForCall(0)).To(Equal(deletedVersions))
+					})
+
+					Context("when saving the deleted versions fails", func() {
+						BeforeEach(func() {
+							fakeResourceConfigScope.SaveDeletedVersionsReturns(errors.New("failed"))
+						})
+
+						It("returns an error", func() {
+							Expect(runErr).To(HaveOccurred())
+						})
+					})
+				})
+
 				Context("when checking fails", func() {
 					disaster := errors.New("nope")
 
 					BeforeEach(func() {
-						fakeResource.CheckReturns(nil, disaster)
+						fakeResource.CheckWithDeletionsReturns(nil, nil, disaster)
 					})
 
 					It("exits with the failure", func() {
@@ -415,7 +603,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					})
 
 					It("does not check", func() {
-						Expect(fakeResource.CheckCallCount()).To(BeZero())
+						Expect(fakeResource.CheckWithDeletionsCallCount()).To(BeZero())
 					})
 
 					It("returns the default interval", func() {
@@ -458,7 +646,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			})
 
 			It("checks immediately", func() {
-				Expect(fakeResource.CheckCallCount()).To(Equal(1))
+				Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(1))
 			})
 
 			It("constructs the resource of the correct type", func() {
@@ -472,7 +660,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				Expect(err).To(BeNil())
 
 				_, _, owner, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
-				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 				Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 					ResourceType: "registry-image",
 				}))
@@ -487,7 +675,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 				Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 				_, _, _, owner, metadata, containerSpec, resourceTypes = fakeWorker.FindOrCreateContainerArgsForCall(0)
-				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 				Expect(metadata).To(Equal(db.ContainerMetadata{
 					Type: db.ContainerTypeCheck,
 				}))
@@ -596,7 +784,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(resourceTypes).To(Equal(interpolatedResourceTypes))
 
 					_, _, owner, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
-					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 					Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 						ResourceType: "registry-image",
 					}))
@@ -609,7 +797,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 					_, _, _, owner, metadata, containerSpec, resourceTypes = fakeWorker.FindOrCreateContainerArgsForCall(0)
-					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, ContainerExpiries)))
+					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(123, 456, teamID, ContainerExpiries)))
 					Expect(metadata).To(Equal(db.ContainerMetadata{
 						Type: db.ContainerTypeCheck,
 					}))
@@ -680,7 +868,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -695,8 +883,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks with it", func() {
-					Expect(fakeResource.CheckCallCount()).To(Equal(1))
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(Equal(1))
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "42"}))
 				})
 			})
@@ -720,7 +908,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckWithDeletionsStub = func(ctx context.Context, source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
@@ -729,7 +917,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						result := checkResults[check]
 						check++
 
-						return result, nil
+						return result, nil, nil
 					}
 				})
 
@@ -745,7 +933,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
-					fakeResource.CheckReturns(nil, disaster)
+					fakeResource.CheckWithDeletionsReturns(nil, nil, disaster)
 				})
 
 				It("exits with the failure", func() {
@@ -833,7 +1021,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("does not check", func() {
-					Expect(fakeResource.CheckCallCount()).To(BeZero())
+					Expect(fakeResource.CheckWithDeletionsCallCount()).To(BeZero())
 				})
 
 				It("does not return an error", func() {
@@ -880,7 +1068,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 			Context("when fromVersion is nil", func() {
 				It("checks from the current version", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"custom": "version"}))
 				})
 			})
@@ -893,13 +1081,13 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckWithDeletionsArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
 				Context("when the check returns only the latest version", func() {
 					BeforeEach(func() {
-						fakeResource.CheckReturns([]atc.Version{fromVersion}, nil)
+						fakeResource.CheckWithDeletionsReturns([]atc.Version{fromVersion}, nil, nil)
 					})
 
 					It("saves it", func() {
@@ -914,7 +1102,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				scriptFail := resource.ErrResourceScriptFailed{}
 
 				BeforeEach(func() {
-					fakeResource.CheckReturns(nil, scriptFail)
+					fakeResource.CheckWithDeletionsReturns(nil, nil, scriptFail)
 				})
 
 				It("returns the error", func() {
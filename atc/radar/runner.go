@@ -100,6 +100,10 @@ func (r *Runner) tick(ctx context.Context) error {
 
 func (r *Runner) scanResources(ctx context.Context, resources db.Resources) {
 	for _, resource := range resources {
+		if !resource.Mode().Checked() {
+			continue
+		}
+
 		scopedName := "resource:" + resource.Name()
 		if _, found := r.scanning.Load(scopedName); found {
 			continue
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/radar"
@@ -91,6 +92,21 @@ var _ = Describe("Runner", func() {
 		Expect(resources).To(ConsistOf([]string{"some-resource", "some-other-resource"}))
 	})
 
+	Context("when a resource is put_only", func() {
+		BeforeEach(func() {
+			fakeResource2.ModeReturns(atc.ResourceModePutOnly)
+		})
+
+		It("does not scan for it", func() {
+			Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(1))
+
+			_, call1Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+			Expect(call1Resource.Name()).To(Equal("some-resource"))
+
+			Consistently(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(1))
+		})
+	})
+
 	Context("when new resources are configured", func() {
 		BeforeEach(func() {
 			fakeResource3 := new(dbfakes.FakeResource)
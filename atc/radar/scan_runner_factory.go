@@ -3,8 +3,11 @@ package radar
 import (
 	"time"
 
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/versionhook"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/vars"
 
@@ -37,6 +40,12 @@ func NewScanRunnerFactory(
 	externalURL string,
 	variables vars.Variables,
 	strategy worker.ContainerPlacementStrategy,
+	checkPolicy checkpolicy.Enforcer,
+	versionNotifier versionhook.Notifier,
+	teamFactory db.TeamFactory,
+	caCerts string,
+	checkLimits map[string]atc.ContainerLimits,
+	killGracePeriod time.Duration,
 	notifications Notifications,
 ) ScanRunnerFactory {
 	resourceTypeScanner := NewResourceTypeScanner(
@@ -49,6 +58,11 @@ func NewScanRunnerFactory(
 		externalURL,
 		variables,
 		strategy,
+		checkPolicy,
+		teamFactory,
+		caCerts,
+		checkLimits,
+		killGracePeriod,
 	)
 
 	resourceScanner := NewResourceScanner(
@@ -61,6 +75,12 @@ func NewScanRunnerFactory(
 		externalURL,
 		variables,
 		strategy,
+		checkPolicy,
+		versionNotifier,
+		teamFactory,
+		caCerts,
+		checkLimits,
+		killGracePeriod,
 	)
 	return &scanRunnerFactory{
 		clock:               clock,
@@ -4,9 +4,12 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/checkpolicy"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/versionhook"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -28,6 +31,12 @@ type scannerFactory struct {
 	externalURL                  string
 	secretManager                creds.Secrets
 	strategy                     worker.ContainerPlacementStrategy
+	checkPolicy                  checkpolicy.Enforcer
+	versionNotifier              versionhook.Notifier
+	teamFactory                  db.TeamFactory
+	caCerts                      string
+	checkLimits                  map[string]atc.ContainerLimits
+	killGracePeriod              time.Duration
 }
 
 var ContainerExpiries = db.ContainerOwnerExpiries{
@@ -44,6 +53,12 @@ func NewScannerFactory(
 	externalURL string,
 	secretManager creds.Secrets,
 	strategy worker.ContainerPlacementStrategy,
+	checkPolicy checkpolicy.Enforcer,
+	versionNotifier versionhook.Notifier,
+	teamFactory db.TeamFactory,
+	caCerts string,
+	checkLimits map[string]atc.ContainerLimits,
+	killGracePeriod time.Duration,
 ) ScannerFactory {
 	return &scannerFactory{
 		pool:                         pool,
@@ -54,6 +69,12 @@ func NewScannerFactory(
 		externalURL:                  externalURL,
 		secretManager:                secretManager,
 		strategy:                     strategy,
+		checkPolicy:                  checkPolicy,
+		versionNotifier:              versionNotifier,
+		teamFactory:                  teamFactory,
+		caCerts:                      caCerts,
+		checkLimits:                  checkLimits,
+		killGracePeriod:              killGracePeriod,
 	}
 }
 
@@ -70,6 +91,12 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 		f.externalURL,
 		variables,
 		f.strategy,
+		f.checkPolicy,
+		f.versionNotifier,
+		f.teamFactory,
+		f.caCerts,
+		f.checkLimits,
+		f.killGracePeriod,
 	)
 }
 
@@ -86,5 +113,10 @@ func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner
 		f.externalURL,
 		variables,
 		f.strategy,
+		f.checkPolicy,
+		f.teamFactory,
+		f.caCerts,
+		f.checkLimits,
+		f.killGracePeriod,
 	)
 }
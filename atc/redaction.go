@@ -0,0 +1,84 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted source fields.
+const RedactedValue = "[redacted]"
+
+// Redactions hide parts of an exposed pipeline from anyone who isn't
+// authorized for its team, beyond the credentials which are never shown, so
+// that things like internal hostnames don't leak through a public pipeline.
+type Redactions struct {
+	// Sources hides the sources of resource types entirely.
+	Sources bool `json:"sources,omitempty"`
+
+	// SourceFields hides the values of the given top-level source fields,
+	// e.g. uri or repository.
+	SourceFields []string `json:"source_fields,omitempty"`
+
+	// CheckErrors hides the check errors of resources and resource types,
+	// which would otherwise be shown to any authenticated user.
+	CheckErrors bool `json:"check_errors,omitempty"`
+
+	// VersionMetadata hides the metadata of resource versions, even for
+	// public resources.
+	VersionMetadata bool `json:"version_metadata,omitempty"`
+}
+
+// Source returns the source with the redactions applied. Redactions may be
+// nil, in which case the source is returned as-is.
+func (redactions *Redactions) Source(source Source) Source {
+	if redactions == nil || source == nil {
+		return source
+	}
+
+	if redactions.Sources {
+		return nil
+	}
+
+	if len(redactions.SourceFields) == 0 {
+		return source
+	}
+
+	redacted := Source{}
+	for field, value := range source {
+		redacted[field] = value
+	}
+
+	for _, field := range redactions.SourceFields {
+		if _, found := redacted[field]; found {
+			redacted[field] = RedactedValue
+		}
+	}
+
+	return redacted
+}
+
+// HidesCheckErrors returns true if check errors should be hidden.
+func (redactions *Redactions) HidesCheckErrors() bool {
+	return redactions != nil && redactions.CheckErrors
+}
+
+// HidesVersionMetadata returns true if version metadata should be hidden.
+func (redactions *Redactions) HidesVersionMetadata() bool {
+	return redactions != nil && redactions.VersionMetadata
+}
+
+func (redactions Redactions) Validate() error {
+	errorMessages := []string{}
+	for i, field := range redactions.SourceFields {
+		if strings.TrimSpace(field) == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("source_fields[%d] is empty", i))
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redactions", func() {
+	source := atc.Source{"uri": "git@git.internal:repo.git", "branch": "master"}
+
+	Describe("Source", func() {
+		It("returns the source as-is without redactions", func() {
+			var redactions *atc.Redactions
+			Expect(redactions.Source(source)).To(Equal(source))
+		})
+
+		It("hides the whole source", func() {
+			redactions := &atc.Redactions{Sources: true}
+			Expect(redactions.Source(source)).To(BeNil())
+		})
+
+		It("hides the given fields without modifying the original", func() {
+			redactions := &atc.Redactions{SourceFields: []string{"uri", "missing"}}
+			Expect(redactions.Source(source)).To(Equal(atc.Source{"uri": atc.RedactedValue, "branch": "master"}))
+			Expect(source["uri"]).To(Equal("git@git.internal:repo.git"))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("rejects empty source fields", func() {
+			err := atc.Redactions{SourceFields: []string{"uri", " "}}.Validate()
+			Expect(err).To(MatchError("source_fields[1] is empty"))
+		})
+	})
+})
@@ -18,3 +18,8 @@ type Resource struct {
 }
 
 var EnableGlobalResources bool
+
+// EnableGlobalPublicResources shares the version history of equivalent
+// resources only when they use a base resource type and their source doesn't
+// reference any credentials, e.g. public repositories used by many teams.
+var EnableGlobalPublicResources bool
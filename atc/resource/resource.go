@@ -21,6 +21,11 @@ type Resource interface {
 	Get(context.Context, worker.Volume, IOConfig, atc.Source, atc.Params, atc.Version) (VersionedSource, error)
 	Put(context.Context, IOConfig, atc.Source, atc.Params) (VersionResult, error)
 	Check(context.Context, atc.Source, atc.Version) ([]atc.Version, error)
+
+	// CheckWithDeletions is like Check, but also returns the versions the
+	// resource reported as deleted. The check script's stderr is written to
+	// the given writer, if any, as well as being included in any error.
+	CheckWithDeletions(context.Context, atc.Source, atc.Version, io.Writer) ([]atc.Version, []atc.Version, error)
 }
 
 type ResourceType string
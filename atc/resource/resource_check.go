@@ -1,7 +1,10 @@
 package resource
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 
 	"github.com/concourse/concourse/atc"
 )
@@ -11,21 +14,57 @@ type checkRequest struct {
 	Version atc.Version `json:"version"`
 }
 
+// checkResponse is the output of a check script. Most resources emit a plain
+// list of versions; resources that can detect versions going away (e.g. a
+// closed pull request) may instead emit an object listing them separately:
+//
+//	{"versions": [...], "deleted": [...]}
+type checkResponse struct {
+	Versions []atc.Version `json:"versions"`
+	Deleted  []atc.Version `json:"deleted"`
+}
+
+func (response *checkResponse) UnmarshalJSON(payload []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(payload), []byte("[")) {
+		return json.Unmarshal(payload, &response.Versions)
+	}
+
+	type target checkResponse
+	return json.Unmarshal(payload, (*target)(response))
+}
+
 func (resource *resource) Check(ctx context.Context, source atc.Source, fromVersion atc.Version) ([]atc.Version, error) {
-	var versions []atc.Version
+	versions, _, err := resource.CheckWithDeletions(ctx, source, fromVersion, nil)
+	return versions, err
+}
+
+func (resource *resource) CheckWithDeletions(ctx context.Context, source atc.Source, fromVersion atc.Version, stderr io.Writer) ([]atc.Version, []atc.Version, error) {
+	var response checkResponse
+
+	var logDest io.Writer
+	output := new(bytes.Buffer)
+	if stderr != nil {
+		logDest = io.MultiWriter(stderr, output)
+	}
 
 	err := resource.runScript(
 		ctx,
 		"/opt/resource/check",
 		nil,
 		checkRequest{source, fromVersion},
-		&versions,
-		nil,
+		&response,
+		logDest,
 		false,
 	)
 	if err != nil {
-		return nil, err
+		// stderr only ends up in the error when it isn't sent elsewhere
+		if failed, ok := err.(ErrResourceScriptFailed); ok && logDest != nil {
+			failed.Stderr = output.String()
+			return nil, nil, failed
+		}
+
+		return nil, nil, err
 	}
 
-	return versions, nil
+	return response.Versions, response.Deleted, nil
 }
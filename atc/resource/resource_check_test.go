@@ -1,6 +1,7 @@
 package resource_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io/ioutil"
@@ -25,8 +26,11 @@ var _ = Describe("Resource Check", func() {
 
 		checkScriptProcess *gardenfakes.FakeProcess
 
-		checkResult []atc.Version
-		checkErr    error
+		checkResult  []atc.Version
+		checkDeleted []atc.Version
+		checkErr     error
+
+		stderr *bytes.Buffer
 	)
 
 	BeforeEach(func() {
@@ -44,7 +48,10 @@ var _ = Describe("Resource Check", func() {
 		}
 
 		checkResult = nil
+		checkDeleted = nil
 		checkErr = nil
+
+		stderr = nil
 	})
 
 	JustBeforeEach(func() {
@@ -62,7 +69,11 @@ var _ = Describe("Resource Check", func() {
 			return checkScriptProcess, nil
 		}
 
-		checkResult, checkErr = resourceForContainer.Check(context.TODO(), source, version)
+		if stderr != nil {
+			checkResult, checkDeleted, checkErr = resourceForContainer.CheckWithDeletions(context.TODO(), source, version, stderr)
+		} else {
+			checkResult, checkDeleted, checkErr = resourceForContainer.CheckWithDeletions(context.TODO(), source, version, nil)
+		}
 	})
 
 	It("runs /opt/resource/check the request on stdin", func() {
@@ -92,6 +103,26 @@ var _ = Describe("Resource Check", func() {
 				atc.Version{"ver": "ghi"},
 			}))
 
+			Expect(checkDeleted).To(BeEmpty())
+		})
+	})
+
+	Context("when /check outputs an object with deleted versions", func() {
+		BeforeEach(func() {
+			checkScriptStdout = `{"versions":[{"ver":"ghi"}],"deleted":[{"ver":"abc"},{"ver":"def"}]}`
+		})
+
+		It("returns the versions and the deleted versions", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+
+			Expect(checkResult).To(Equal([]atc.Version{
+				atc.Version{"ver": "ghi"},
+			}))
+
+			Expect(checkDeleted).To(Equal([]atc.Version{
+				atc.Version{"ver": "abc"},
+				atc.Version{"ver": "def"},
+			}))
 		})
 	})
 
@@ -119,6 +150,29 @@ var _ = Describe("Resource Check", func() {
 			Expect(checkErr.Error()).To(ContainSubstring("exit status 9"))
 			Expect(checkErr.Error()).To(ContainSubstring("some-stderr"))
 		})
+
+		Context("when given a writer for stderr", func() {
+			BeforeEach(func() {
+				stderr = new(bytes.Buffer)
+			})
+
+			It("writes stderr to it and still includes it in the error", func() {
+				Expect(stderr.String()).To(Equal("some-stderr"))
+				Expect(checkErr.Error()).To(ContainSubstring("some-stderr"))
+			})
+		})
+	})
+
+	Context("when given a writer for stderr", func() {
+		BeforeEach(func() {
+			checkScriptStderr = "some-progress"
+			stderr = new(bytes.Buffer)
+		})
+
+		It("writes stderr to it", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+			Expect(stderr.String()).To(Equal("some-progress"))
+		})
 	})
 
 	Context("when the output of /opt/resource/check is malformed", func() {
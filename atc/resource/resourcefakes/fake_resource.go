@@ -3,6 +3,7 @@ package resourcefakes
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/concourse/concourse/atc"
@@ -26,6 +27,24 @@ type FakeResource struct {
 		result1 []atc.Version
 		result2 error
 	}
+	CheckWithDeletionsStub        func(context.Context, atc.Source, atc.Version, io.Writer) ([]atc.Version, []atc.Version, error)
+	checkWithDeletionsMutex       sync.RWMutex
+	checkWithDeletionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 atc.Source
+		arg3 atc.Version
+		arg4 io.Writer
+	}
+	checkWithDeletionsReturns struct {
+		result1 []atc.Version
+		result2 []atc.Version
+		result3 error
+	}
+	checkWithDeletionsReturnsOnCall map[int]struct {
+		result1 []atc.Version
+		result2 []atc.Version
+		result3 error
+	}
 	GetStub        func(context.Context, worker.Volume, resource.IOConfig, atc.Source, atc.Params, atc.Version) (resource.VersionedSource, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
@@ -129,6 +148,75 @@ func (fake *FakeResource) CheckReturnsOnCall(i int, result1 []atc.Version, resul
 	}{result1, result2}
 }
 
+func (fake *FakeResource) CheckWithDeletions(arg1 context.Context, arg2 atc.Source, arg3 atc.Version, arg4 io.Writer) ([]atc.Version, []atc.Version, error) {
+	fake.checkWithDeletionsMutex.Lock()
+	ret, specificReturn := fake.checkWithDeletionsReturnsOnCall[len(fake.checkWithDeletionsArgsForCall)]
+	fake.checkWithDeletionsArgsForCall = append(fake.checkWithDeletionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 atc.Source
+		arg3 atc.Version
+		arg4 io.Writer
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CheckWithDeletions", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checkWithDeletionsMutex.Unlock()
+	if fake.CheckWithDeletionsStub != nil {
+		return fake.CheckWithDeletionsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.checkWithDeletionsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) CheckWithDeletionsCallCount() int {
+	fake.checkWithDeletionsMutex.RLock()
+	defer fake.checkWithDeletionsMutex.RUnlock()
+	return len(fake.checkWithDeletionsArgsForCall)
+}
+
+func (fake *FakeResource) CheckWithDeletionsCalls(stub func(context.Context, atc.Source, atc.Version, io.Writer) ([]atc.Version, []atc.Version, error)) {
+	fake.checkWithDeletionsMutex.Lock()
+	defer fake.checkWithDeletionsMutex.Unlock()
+	fake.CheckWithDeletionsStub = stub
+}
+
+func (fake *FakeResource) CheckWithDeletionsArgsForCall(i int) (context.Context, atc.Source, atc.Version, io.Writer) {
+	fake.checkWithDeletionsMutex.RLock()
+	defer fake.checkWithDeletionsMutex.RUnlock()
+	argsForCall := fake.checkWithDeletionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeResource) CheckWithDeletionsReturns(result1 []atc.Version, result2 []atc.Version, result3 error) {
+	fake.checkWithDeletionsMutex.Lock()
+	defer fake.checkWithDeletionsMutex.Unlock()
+	fake.CheckWithDeletionsStub = nil
+	fake.checkWithDeletionsReturns = struct {
+		result1 []atc.Version
+		result2 []atc.Version
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) CheckWithDeletionsReturnsOnCall(i int, result1 []atc.Version, result2 []atc.Version, result3 error) {
+	fake.checkWithDeletionsMutex.Lock()
+	defer fake.checkWithDeletionsMutex.Unlock()
+	fake.CheckWithDeletionsStub = nil
+	if fake.checkWithDeletionsReturnsOnCall == nil {
+		fake.checkWithDeletionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Version
+			result2 []atc.Version
+			result3 error
+		})
+	}
+	fake.checkWithDeletionsReturnsOnCall[i] = struct {
+		result1 []atc.Version
+		result2 []atc.Version
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) Get(arg1 context.Context, arg2 worker.Volume, arg3 resource.IOConfig, arg4 atc.Source, arg5 atc.Params, arg6 atc.Version) (resource.VersionedSource, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
@@ -268,6 +356,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkMutex.RLock()
 	defer fake.checkMutex.RUnlock()
+	fake.checkWithDeletionsMutex.RLock()
+	defer fake.checkWithDeletionsMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	fake.putMutex.RLock()
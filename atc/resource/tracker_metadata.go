@@ -1,11 +1,19 @@
 package resource
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 type TrackerMetadata struct {
 	ExternalURL  string
 	PipelineName string
 	ResourceName string
+
+	// ContainerEnv is the team's environment to inject into the container.
+	// The variables above take precedence.
+	ContainerEnv map[string]string
 }
 
 type EmptyMetadata struct{}
@@ -29,5 +37,23 @@ func (m TrackerMetadata) Env() []string {
 		env = append(env, fmt.Sprintf("RESOURCE_NAME=%s", m.ResourceName))
 	}
 
+	set := map[string]bool{}
+	for _, e := range env {
+		set[strings.SplitN(e, "=", 2)[0]] = true
+	}
+
+	names := []string{}
+	for name := range m.ContainerEnv {
+		if !set[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		env = append(env, name+"="+m.ContainerEnv[name])
+	}
+
 	return env
 }
@@ -19,6 +19,23 @@ var _ = Describe("TrackerMetadata", func() {
 		}))
 	})
 
+	It("includes the team's container env, without overriding the metadata", func() {
+		Expect(TrackerMetadata{
+			ExternalURL:  "https://www.example.com",
+			ResourceName: "some-resource-name",
+			ContainerEnv: map[string]string{
+				"HTTPS_PROXY":      "http://proxy.example.com",
+				"NO_PROXY":         "localhost",
+				"ATC_EXTERNAL_URL": "https://elsewhere.example.com",
+			},
+		}.Env()).To(Equal([]string{
+			"ATC_EXTERNAL_URL=https://www.example.com",
+			"RESOURCE_NAME=some-resource-name",
+			"HTTPS_PROXY=http://proxy.example.com",
+			"NO_PROXY=localhost",
+		}))
+	})
+
 	It("does not include fields that are not set", func() {
 		Expect(TrackerMetadata{
 			PipelineName: "some-pipeline-name",
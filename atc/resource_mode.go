@@ -0,0 +1,57 @@
+package atc
+
+import "fmt"
+
+// ResourceMode restricts how a resource is used. Resources without a mode
+// are checked and may be fetched and pushed to.
+type ResourceMode string
+
+const (
+	// ResourceModeCheckOnly resources are only checked, e.g. to keep a
+	// history of versions, and are never fetched or pushed to by jobs.
+	ResourceModeCheckOnly ResourceMode = "check_only"
+
+	// ResourceModeGetOnly resources are checked and fetched, but never
+	// pushed to.
+	ResourceModeGetOnly ResourceMode = "get_only"
+
+	// ResourceModePutOnly resources are only pushed to, e.g. notifications,
+	// so they are never checked and putting to them fetches nothing after.
+	ResourceModePutOnly ResourceMode = "put_only"
+)
+
+func (mode ResourceMode) Validate() error {
+	switch mode {
+	case "", ResourceModeCheckOnly, ResourceModeGetOnly, ResourceModePutOnly:
+		return nil
+	default:
+		return fmt.Errorf("unknown mode '%s', must be check_only, get_only or put_only", mode)
+	}
+}
+
+// Checked returns whether versions of the resource are checked for.
+func (mode ResourceMode) Checked() bool {
+	return mode != ResourceModePutOnly
+}
+
+// AllowsGet returns whether jobs may fetch the resource.
+func (mode ResourceMode) AllowsGet() bool {
+	return mode != ResourceModeCheckOnly && mode != ResourceModePutOnly
+}
+
+// AllowsPut returns whether jobs may push to the resource.
+func (mode ResourceMode) AllowsPut() bool {
+	return mode != ResourceModeCheckOnly && mode != ResourceModeGetOnly
+}
+
+// ResourceModeError is returned when a step uses a resource in a way its
+// mode does not allow.
+type ResourceModeError struct {
+	Resource string
+	Mode     ResourceMode
+	Step     string
+}
+
+func (err ResourceModeError) Error() string {
+	return fmt.Sprintf("cannot %s resource '%s' as it is %s", err.Step, err.Resource, err.Mode)
+}
@@ -0,0 +1,24 @@
+package atc
+
+// ResourceTypeHealth is how checks and fetches of a resource type have been
+// faring across a team's pipelines, over the period for which checks are
+// kept. Durations are in seconds.
+type ResourceTypeHealth struct {
+	Type string `json:"type"`
+
+	// Pipelines and Resources count the team's active resources of the type.
+	Pipelines int `json:"pipelines"`
+	Resources int `json:"resources"`
+
+	Checks               int     `json:"checks"`
+	FailedChecks         int     `json:"failed_checks"`
+	CheckFailureRate     float64 `json:"check_failure_rate"`
+	AverageCheckDuration float64 `json:"average_check_duration"`
+
+	// Fetches only counts gets which weren't already cached on the worker.
+	Fetches              int     `json:"fetches"`
+	FailedFetches        int     `json:"failed_fetches"`
+	FetchFailureRate     float64 `json:"fetch_failure_rate"`
+	AverageFetchDuration float64 `json:"average_fetch_duration"`
+	MaxFetchDuration     float64 `json:"max_fetch_duration"`
+}
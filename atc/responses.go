@@ -5,10 +5,25 @@ type ClearTaskCacheResponse struct {
 }
 
 type SaveConfigResponse struct {
-	Errors   []string        `json:"errors,omitempty"`
-	Warnings []ConfigWarning `json:"warnings,omitempty"`
+	Errors       []string            `json:"errors,omitempty"`
+	Warnings     []ConfigWarning     `json:"warnings,omitempty"`
+	Deprecations []ConfigDeprecation `json:"deprecations,omitempty"`
+
+	// Conflict is set when the config was not saved because the pipeline's
+	// config changed since the version it was based on.
+	Conflict *ConfigConflict `json:"conflict,omitempty"`
+}
+
+// ConfigConflict describes the pipeline's current config, which a rejected
+// config would have overwritten. Diff is a line diff of the current config
+// to the rejected config, with removed lines prefixed by "- " and added
+// lines by "+ ".
+type ConfigConflict struct {
+	Version string `json:"version"`
+	Diff    string `json:"diff"`
 }
 
 type ConfigResponse struct {
-	Config Config `json:"config"`
+	Config       Config              `json:"config"`
+	Deprecations []ConfigDeprecation `json:"deprecations,omitempty"`
 }
@@ -6,29 +6,43 @@ const (
 	SaveConfig = "SaveConfig"
 	GetConfig  = "GetConfig"
 
-	GetBuild            = "GetBuild"
-	GetBuildPlan        = "GetBuildPlan"
-	CreateBuild         = "CreateBuild"
-	ListBuilds          = "ListBuilds"
-	BuildEvents         = "BuildEvents"
-	BuildResources      = "BuildResources"
-	AbortBuild          = "AbortBuild"
-	GetBuildPreparation = "GetBuildPreparation"
+	GetBuild                 = "GetBuild"
+	GetBuildPlan             = "GetBuildPlan"
+	GetBuildArtifactRegistry = "GetBuildArtifactRegistry"
+	CreateBuild              = "CreateBuild"
+	ListBuilds               = "ListBuilds"
+	BuildEvents              = "BuildEvents"
+	MultiplexBuildEvents     = "MultiplexBuildEvents"
+	BuildResources           = "BuildResources"
+	AbortBuild               = "AbortBuild"
+	PauseBuild               = "PauseBuild"
+	ResumeBuild              = "ResumeBuild"
+	RerunBuild               = "RerunBuild"
+	GetBuildPreparation      = "GetBuildPreparation"
+	ExplainBuild             = "ExplainBuild"
+	GetBuildSnapshot         = "GetBuildSnapshot"
+	GetBuildTimeline         = "GetBuildTimeline"
+	GetBuildPlanGraph        = "GetBuildPlanGraph"
+	AnnotateBuild            = "AnnotateBuild"
 
 	GetCheck = "GetCheck"
 
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	ListAllJobs    = "ListAllJobs"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	GetJob            = "GetJob"
+	CreateJobBuild    = "CreateJobBuild"
+	ListAllJobs       = "ListAllJobs"
+	ListJobs          = "ListJobs"
+	ListJobBuilds     = "ListJobBuilds"
+	ListJobInputs     = "ListJobInputs"
+	GetJobPlanGraph   = "GetJobPlanGraph"
+	GetJobStatistics  = "GetJobStatistics"
+	DryRunJobSchedule = "DryRunJobSchedule"
+	GetJobBuild       = "GetJobBuild"
+	PauseJob          = "PauseJob"
+	UnpauseJob        = "UnpauseJob"
+	GetVersionsDB     = "GetVersionsDB"
+	JobBadge          = "JobBadge"
+	MainJobBadge      = "MainJobBadge"
+	JobStatus         = "JobStatus"
 
 	ClearTaskCache = "ClearTaskCache"
 
@@ -39,33 +53,41 @@ const (
 	CheckResource        = "CheckResource"
 	CheckResourceWebHook = "CheckResourceWebHook"
 	CheckResourceType    = "CheckResourceType"
+	ListResourceChecks   = "ListResourceChecks"
 
 	ListResourceVersions          = "ListResourceVersions"
 	GetResourceVersion            = "GetResourceVersion"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
 	PinResourceVersion            = "PinResourceVersion"
+	UpdateResourceVersionMetadata = "UpdateResourceVersionMetadata"
 	UnpinResource                 = "UnpinResource"
 	SetPinCommentOnResource       = "SetPinCommentOnResource"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 	GetResourceCausality          = "GetResourceCausality"
+	ListResourceVersionSpan       = "ListResourceVersionSpan"
 
 	GetCC = "GetCC"
 
-	ListAllPipelines    = "ListAllPipelines"
-	ListPipelines       = "ListPipelines"
-	GetPipeline         = "GetPipeline"
-	DeletePipeline      = "DeletePipeline"
-	OrderPipelines      = "OrderPipelines"
-	PausePipeline       = "PausePipeline"
-	UnpausePipeline     = "UnpausePipeline"
-	ExposePipeline      = "ExposePipeline"
-	HidePipeline        = "HidePipeline"
-	RenamePipeline      = "RenamePipeline"
-	ListPipelineBuilds  = "ListPipelineBuilds"
-	CreatePipelineBuild = "CreatePipelineBuild"
-	PipelineBadge       = "PipelineBadge"
+	ListAllPipelines      = "ListAllPipelines"
+	ListPipelines         = "ListPipelines"
+	GetPipeline           = "GetPipeline"
+	GetPipelineGraph      = "GetPipelineGraph"
+	GetPipelineScheduling = "GetPipelineScheduling"
+	DeletePipeline        = "DeletePipeline"
+	OrderPipelines        = "OrderPipelines"
+	PausePipeline         = "PausePipeline"
+	UnpausePipeline       = "UnpausePipeline"
+	ExposePipeline        = "ExposePipeline"
+	HidePipeline          = "HidePipeline"
+	SharePipeline         = "SharePipeline"
+	UnsharePipeline       = "UnsharePipeline"
+	RenamePipeline        = "RenamePipeline"
+	ListPipelineBuilds    = "ListPipelineBuilds"
+	CreatePipelineBuild   = "CreatePipelineBuild"
+	PipelineBadge         = "PipelineBadge"
+	PipelineStatus        = "PipelineStatus"
 
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
@@ -75,6 +97,9 @@ const (
 	ListWorkers     = "ListWorkers"
 	DeleteWorker    = "DeleteWorker"
 
+	ListWorkerDiscrepancies    = "ListWorkerDiscrepancies"
+	ResolveWorkerDiscrepancies = "ResolveWorkerDiscrepancies"
+
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
 
@@ -85,6 +110,7 @@ const (
 	ListContainers           = "ListContainers"
 	GetContainer             = "GetContainer"
 	HijackContainer          = "HijackContainer"
+	HijackCheckContainer     = "HijackCheckContainer"
 	ListDestroyingContainers = "ListDestroyingContainers"
 	ReportWorkerContainers   = "ReportWorkerContainers"
 
@@ -97,18 +123,64 @@ const (
 	SetTeam        = "SetTeam"
 	RenameTeam     = "RenameTeam"
 	DestroyTeam    = "DestroyTeam"
+	RestoreTeam    = "RestoreTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
-	CreateArtifact     = "CreateArtifact"
-	GetArtifact        = "GetArtifact"
-	ListBuildArtifacts = "ListBuildArtifacts"
+	GetPipelinesRepoStatus  = "GetPipelinesRepoStatus"
+	GetResourceTypeMappings = "GetResourceTypeMappings"
+	GetResourceTypeHealth   = "GetResourceTypeHealth"
+
+	ListAPITokens  = "ListAPITokens"
+	CreateAPIToken = "CreateAPIToken"
+	RevokeAPIToken = "RevokeAPIToken"
+
+	CreateArtifact        = "CreateArtifact"
+	GetArtifact           = "GetArtifact"
+	ListBuildArtifacts    = "ListBuildArtifacts"
+	DownloadBuildArtifact = "DownloadBuildArtifact"
 
 	ListActiveUsersSince = "ListActiveUsersSince"
+
+	ListLocks = "ListLocks"
+
+	GetCacheEfficiency = "GetCacheEfficiency"
+
+	GetMigrationStatus = "GetMigrationStatus"
+
+	GetDBHealth = "GetDBHealth"
 )
 
 const (
 	ClearTaskCacheQueryPath = "cache_path"
 	SaveConfigCheckCreds    = "check_creds"
+
+	BuildEventsStepIDQuery   = "step_id"
+	BuildEventsStepNameQuery = "step_name"
+	BuildEventsTypeQuery     = "event"
+	BuildEventsSourceQuery   = "source"
+
+	// The builds whose events are multiplexed are selected either by ID, or
+	// as the builds of a job, or as the builds with a version of a resource
+	// as an input. Jobs and resources are named along with their team and
+	// pipeline.
+	MultiplexBuildEventsBuildIDQuery  = "build_id"
+	MultiplexBuildEventsTeamQuery     = "team_name"
+	MultiplexBuildEventsPipelineQuery = "pipeline_name"
+	MultiplexBuildEventsJobQuery      = "job_name"
+	MultiplexBuildEventsResourceQuery = "resource_name"
+	MultiplexBuildEventsVersionQuery  = "resource_version_id"
+
+	// EmbedTokenQuery carries an API token for the actions in EmbedActions,
+	// as badges embedded in pages can't send an Authorization header.
+	EmbedTokenQuery = "token"
+
+	// ShareTokenQuery carries a pipeline's share token, allowing anyone with
+	// the token to view the pipeline as though it were public.
+	ShareTokenQuery = "share_token"
+
+	// BuildTokenHeader carries a build's token, given to its tasks as
+	// $BUILD_TOKEN, to authorize annotating the build while it runs.
+	BuildTokenHeader = "X-Concourse-Build-Token"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -118,13 +190,24 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
+	{Path: "/api/v1/builds/events", Method: "GET", Name: MultiplexBuildEvents},
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
+	{Path: "/api/v1/builds/:build_id/artifact-registry", Method: "GET", Name: GetBuildArtifactRegistry},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/pause", Method: "PUT", Name: PauseBuild},
+	{Path: "/api/v1/builds/:build_id/resume", Method: "PUT", Name: ResumeBuild},
+	{Path: "/api/v1/builds/:build_id/rerun", Method: "POST", Name: RerunBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/explain", Method: "GET", Name: ExplainBuild},
+	{Path: "/api/v1/builds/:build_id/snapshot", Method: "GET", Name: GetBuildSnapshot},
+	{Path: "/api/v1/builds/:build_id/timeline", Method: "GET", Name: GetBuildTimeline},
+	{Path: "/api/v1/builds/:build_id/plan/graph", Method: "GET", Name: GetBuildPlanGraph},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/artifacts/:artifact_name", Method: "GET", Name: DownloadBuildArtifact},
+	{Path: "/api/v1/builds/:build_id/annotations", Method: "PUT", Name: AnnotateBuild},
 
 	{Path: "/api/v1/checks/:check_id", Method: "GET", Name: GetCheck},
 
@@ -134,11 +217,15 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/plan/graph", Method: "GET", Name: GetJobPlanGraph},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/statistics", Method: "GET", Name: GetJobStatistics},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule/dry-run", Method: "POST", Name: DryRunJobSchedule},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/status", Method: "GET", Name: JobStatus},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tasks/:step_name/cache", Method: "DELETE", Name: ClearTaskCache},
 
@@ -151,11 +238,16 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/expose", Method: "PUT", Name: ExposePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/share", Method: "PUT", Name: SharePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unshare", Method: "PUT", Name: UnsharePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/graph", Method: "GET", Name: GetPipelineGraph},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/scheduling", Method: "GET", Name: GetPipelineScheduling},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/status", Method: "GET", Name: PipelineStatus},
 
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -164,17 +256,20 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check", Method: "POST", Name: CheckResourceType},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", Method: "GET", Name: ListResourceChecks},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/metadata", Method: "PUT", Name: UpdateResourceVersionMetadata},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment", Method: "PUT", Name: SetPinCommentOnResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/causality", Method: "GET", Name: GetResourceCausality},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/span", Method: "GET", Name: ListResourceVersionSpan},
 
 	{Path: "/api/v1/teams/:team_name/cc.xml", Method: "GET", Name: GetCC},
 
@@ -185,6 +280,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
+	{Path: "/api/v1/workers/:worker_name/discrepancies", Method: "GET", Name: ListWorkerDiscrepancies},
+	{Path: "/api/v1/workers/:worker_name/discrepancies/resolve", Method: "PUT", Name: ResolveWorkerDiscrepancies},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
@@ -195,11 +292,20 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/users", Method: "GET", Name: ListActiveUsersSince},
 
+	{Path: "/api/v1/locks", Method: "GET", Name: ListLocks},
+
+	{Path: "/api/v1/cache-efficiency", Method: "GET", Name: GetCacheEfficiency},
+
+	{Path: "/api/v1/migrations", Method: "GET", Name: GetMigrationStatus},
+
+	{Path: "/api/v1/db-health", Method: "GET", Name: GetDBHealth},
+
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
 	{Path: "/api/v1/teams/:team_name/containers", Method: "GET", Name: ListContainers},
 	{Path: "/api/v1/teams/:team_name/containers/:id", Method: "GET", Name: GetContainer},
 	{Path: "/api/v1/teams/:team_name/containers/:id/hijack", Method: "GET", Name: HijackContainer},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/hijack", Method: "GET", Name: HijackCheckContainer},
 
 	{Path: "/api/v1/teams/:team_name/volumes", Method: "GET", Name: ListVolumes},
 	{Path: "/api/v1/volumes/destroying", Method: "GET", Name: ListDestroyingVolumes},
@@ -210,7 +316,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
+	{Path: "/api/v1/teams/:team_name/restore", Method: "PUT", Name: RestoreTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/pipelines-repo/status", Method: "GET", Name: GetPipelinesRepoStatus},
+	{Path: "/api/v1/teams/:team_name/resource-type-mappings", Method: "GET", Name: GetResourceTypeMappings},
+	{Path: "/api/v1/teams/:team_name/resource-type-health", Method: "GET", Name: GetResourceTypeHealth},
+	{Path: "/api/v1/teams/:team_name/api-tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/teams/:team_name/api-tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/teams/:team_name/api-tokens/:api_token_name", Method: "DELETE", Name: RevokeAPIToken},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
@@ -9,4 +9,7 @@ const (
 type Event struct {
 	EventType  string
 	ExitStatus int
+
+	// WorkerName is the worker running the task, for StartingEvent.
+	WorkerName string
 }
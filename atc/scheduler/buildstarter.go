@@ -1,11 +1,20 @@
 package scheduler
 
 import (
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/scheduler/gate"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/concourse/atc/scheduler/maxinflight"
+	"github.com/concourse/concourse/atc/scheduler/startlimit"
+	"github.com/concourse/concourse/vars"
 )
 
 //go:generate counterfeiter . BuildStarter
@@ -31,12 +40,20 @@ func NewBuildStarter(
 	maxInFlightUpdater maxinflight.Updater,
 	factory BuildFactory,
 	inputMapper inputmapper.InputMapper,
+	startLimiter startlimit.Limiter,
+	gates gate.Evaluator,
+	variables vars.Variables,
+	clock clock.Clock,
 ) BuildStarter {
 	return &buildStarter{
 		pipeline:           pipeline,
 		maxInFlightUpdater: maxInFlightUpdater,
 		factory:            factory,
 		inputMapper:        inputMapper,
+		startLimiter:       startLimiter,
+		gates:              gates,
+		variables:          variables,
+		clock:              clock,
 	}
 }
 
@@ -45,6 +62,10 @@ type buildStarter struct {
 	maxInFlightUpdater maxinflight.Updater
 	factory            BuildFactory
 	inputMapper        inputmapper.InputMapper
+	startLimiter       startlimit.Limiter
+	gates              gate.Evaluator
+	variables          vars.Variables
+	clock              clock.Clock
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -54,8 +75,10 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	resourceTypes atc.VersionedResourceTypes,
 	nextPendingBuildsForJob []db.Build,
 ) error {
+	withinWindows := withinSchedulingWindows(s.pipeline, job, s.clock.Now())
+
 	for _, nextPendingBuild := range nextPendingBuildsForJob {
-		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes)
+		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes, withinWindows)
 		if err != nil {
 			return err
 		}
@@ -74,6 +97,7 @@ func (s *buildStarter) tryStartNextPendingBuild(
 	job db.Job,
 	resources db.Resources,
 	resourceTypes atc.VersionedResourceTypes,
+	withinWindows bool,
 ) (bool, error) {
 	logger = logger.Session("try-start-next-pending-build", lager.Data{
 		"build-id":   nextPendingBuild.ID(),
@@ -90,105 +114,149 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return true, nil
 	}
 
+	// builds which were triggered automatically before the scheduling windows
+	// stopped allowing them wait for them to allow it again; manually
+	// triggered builds queued behind them carry on
+	if !withinWindows && !nextPendingBuild.IsManuallyTriggered() {
+		logger.Debug("outside-scheduling-windows")
+		s.setSchedulable(logger, nextPendingBuild, false)
+		return true, nil
+	}
+
 	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, job, nextPendingBuild.ID())
 	if err != nil {
 		return false, err
 	}
 	if reachedMaxInFlight {
+		s.setSchedulable(logger, nextPendingBuild, false)
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		for _, input := range job.Config().Inputs() {
-			resource, found := resources.Lookup(input.Resource)
+	pipelinePaused, err := s.pipeline.CheckPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		return false, err
+	}
+	if pipelinePaused {
+		s.setSchedulable(logger, nextPendingBuild, false)
+		return false, nil
+	}
 
-			if !found {
-				logger.Debug("failed-to-find-resource")
-				return false, nil
-			}
+	if job.Paused() {
+		s.setSchedulable(logger, nextPendingBuild, false)
+		return false, nil
+	}
 
-			if resource.CurrentPinnedVersion() != nil {
-				continue
-			}
+	if !s.gates.Open(logger, job, s.variables) {
+		s.setSchedulable(logger, nextPendingBuild, false)
+		return false, nil
+	}
 
-			if nextPendingBuild.IsNewerThanLastCheckOf(resource) {
-				return false, nil
-			}
-		}
+	done, err := s.afterJobsDone(logger, job)
+	if err != nil {
+		return false, err
+	}
 
-		versions, err := s.pipeline.LoadVersionsDB()
-		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
-			return false, err
-		}
+	if !done {
+		s.setSchedulable(logger, nextPendingBuild, false)
+		return false, nil
+	}
 
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job, resources)
-		if err != nil {
-			return false, err
-		}
+	// re-runs use the inputs of the builds they re-run, which were copied to
+	// them when they were created, and their kept plans
+	isRerun := nextPendingBuild.RerunOf() != 0
 
-		dbResourceTypes, err := s.pipeline.ResourceTypes()
+	var (
+		buildInputs []db.BuildInput
+		found       bool
+	)
+
+	if isRerun {
+		found = true
+	} else {
+		buildInputs, resourceTypes, found, err = s.nextBuildInputs(logger, nextPendingBuild, job, resources, resourceTypes)
 		if err != nil {
 			return false, err
 		}
-		resourceTypes = dbResourceTypes.Deserialize()
 	}
 
-	buildInputs, found, err := job.GetNextBuildInputs()
-	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
-		return false, err
-	}
 	if !found {
+		// nothing but its inputs holds the build, which may never resolve,
+		// e.g. if no worker can check their resources
+		s.setSchedulable(logger, nextPendingBuild, true)
 		return false, nil
 	}
 
-	pipelinePaused, err := s.pipeline.CheckPaused()
+	allowed, err := s.startLimiter.Allow(logger, s.pipeline)
 	if err != nil {
-		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		logger.Error("failed-to-check-build-start-limit", err)
 		return false, err
 	}
-	if pipelinePaused {
+
+	if !allowed {
+		logger.Debug("build-start-limit-reached")
+		s.setSchedulable(logger, nextPendingBuild, false)
 		return false, nil
 	}
 
-	if job.Paused() {
-		return false, nil
+	pools := job.Config().Pools()
+	if len(pools) > 0 {
+		claimed, err := nextPendingBuild.ClaimConcurrencyPools(pools)
+		if err != nil {
+			logger.Error("failed-to-claim-concurrency-pools", err)
+			s.startLimiter.Refund(logger, s.pipeline)
+			return false, err
+		}
+
+		if !claimed {
+			logger.Debug("concurrency-pool-full", lager.Data{"pools": pools})
+			s.setSchedulable(logger, nextPendingBuild, false)
+			s.startLimiter.Refund(logger, s.pipeline)
+			return false, nil
+		}
 	}
 
 	updated, err := nextPendingBuild.Schedule()
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
+		s.releaseConcurrencyPools(logger, nextPendingBuild, pools)
+		s.startLimiter.Refund(logger, s.pipeline)
 		return false, err
 	}
 
 	if !updated {
 		logger.Debug("build-already-scheduled")
+		s.releaseConcurrencyPools(logger, nextPendingBuild, pools)
+		s.startLimiter.Refund(logger, s.pipeline)
 		return false, nil
 	}
 
-	err = nextPendingBuild.UseInputs(buildInputs)
-	if err != nil {
-		return false, err
-	}
+	plan := nextPendingBuild.PrivatePlan()
+	if !isRerun {
+		err = nextPendingBuild.UseInputs(buildInputs)
+		if err != nil {
+			return false, err
+		}
 
-	resourceConfigs := atc.ResourceConfigs{}
-	for _, v := range resources {
-		resourceConfigs = append(resourceConfigs, atc.ResourceConfig{
-			Name:   v.Name(),
-			Type:   v.Type(),
-			Source: v.Source(),
-			Tags:   v.Tags(),
-		})
-	}
+		resourceConfigs := atc.ResourceConfigs{}
+		for _, v := range resources {
+			resourceConfigs = append(resourceConfigs, atc.ResourceConfig{
+				Name:   v.Name(),
+				Type:   v.Type(),
+				Source: v.Source(),
+				Tags:   v.Tags(),
+				Mode:   v.Mode(),
+			})
+		}
 
-	plan, err := s.factory.Create(job.Config(), resourceConfigs, resourceTypes, buildInputs)
-	if err != nil {
-		// Don't use ErrorBuild because it logs a build event, and this build hasn't started
-		if err = nextPendingBuild.Finish(db.BuildStatusErrored); err != nil {
-			logger.Error("failed-to-mark-build-as-errored", err)
+		plan, err = s.factory.Create(job.Config(), resourceConfigs, resourceTypes, buildInputs)
+		if err != nil {
+			// Don't use ErrorBuild because it logs a build event, and this build hasn't started
+			if err = nextPendingBuild.Finish(db.BuildStatusErrored); err != nil {
+				logger.Error("failed-to-mark-build-as-errored", err)
+			}
+			return false, nil
 		}
-		return false, nil
 	}
 
 	started, err := nextPendingBuild.Start(plan)
@@ -204,5 +272,205 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	if isRerun {
+		return true, nil
+	}
+
+	for _, warning := range atc.UnusedArtifactWarnings("jobs."+job.Name(), job.Config()) {
+		err = nextPendingBuild.SaveEvent(event.Warning{
+			Time:    time.Now().Unix(),
+			Type:    warning.Type,
+			Message: warning.Message,
+		})
+		if err != nil {
+			logger.Error("failed-to-save-warning", err)
+		}
+	}
+
 	return true, nil
 }
+
+// nextBuildInputs determines the inputs of a pending build, and the resource
+// types to run it with.
+func (s *buildStarter) nextBuildInputs(
+	logger lager.Logger,
+	nextPendingBuild db.Build,
+	job db.Job,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) ([]db.BuildInput, atc.VersionedResourceTypes, bool, error) {
+	var (
+		buildInputs []db.BuildInput
+		found       bool
+		err         error
+	)
+
+	overrides := nextPendingBuild.InputOverrides()
+
+	if nextPendingBuild.IsManuallyTriggered() {
+		for _, input := range job.Config().Inputs() {
+			resource, found := resources.Lookup(input.Resource)
+
+			if !found {
+				logger.Debug("failed-to-find-resource")
+				return nil, nil, false, nil
+			}
+
+			if resource.CurrentPinnedVersion() != nil {
+				continue
+			}
+
+			if _, overridden := overrides[input.Name]; overridden {
+				continue
+			}
+
+			if _, uploaded := nextPendingBuild.InputArtifacts()[input.Name]; uploaded {
+				continue
+			}
+
+			if nextPendingBuild.IsNewerThanLastCheckOf(resource) {
+				return nil, nil, false, nil
+			}
+		}
+
+		versions, err := s.pipeline.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			return nil, nil, false, err
+		}
+
+		if len(overrides) > 0 {
+			buildInputs, found, err = s.overriddenBuildInputs(logger, versions, job, resources, overrides)
+			if err != nil {
+				return nil, nil, false, err
+			}
+		} else {
+			_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job, resources)
+			if err != nil {
+				return nil, nil, false, err
+			}
+		}
+
+		dbResourceTypes, err := s.pipeline.ResourceTypes()
+		if err != nil {
+			return nil, nil, false, err
+		}
+		resourceTypes = dbResourceTypes.Deserialize()
+	}
+
+	if len(overrides) == 0 {
+		buildInputs, found, err = job.GetNextBuildInputs()
+		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
+			return nil, nil, false, err
+		}
+	}
+
+	return buildInputs, resourceTypes, found, nil
+}
+
+// setSchedulable records whether the pending build is held only by something
+// other than the scheduler's limits, so that builds which can't start are
+// timed out only from when they became schedulable. The build is only
+// updated if that changed.
+func (s *buildStarter) setSchedulable(logger lager.Logger, build db.Build, schedulable bool) {
+	if build.SchedulableTime().IsZero() != schedulable {
+		return
+	}
+
+	err := build.SetSchedulable(schedulable)
+	if err != nil {
+		logger.Error("failed-to-update-build-schedulable", err)
+	}
+}
+
+// releaseConcurrencyPools gives up the pools claimed for a build which was
+// not scheduled, so that it doesn't hold their slots while it is pending.
+func (s *buildStarter) releaseConcurrencyPools(logger lager.Logger, build db.Build, pools []string) {
+	if len(pools) == 0 {
+		return
+	}
+
+	err := build.ReleaseConcurrencyPools()
+	if err != nil {
+		logger.Error("failed-to-release-concurrency-pools", err)
+	}
+}
+
+// afterJobsDone returns whether every job the job runs after has finished
+// its builds, with the latest one succeeding.
+func (s *buildStarter) afterJobsDone(logger lager.Logger, job db.Job) (bool, error) {
+	for _, name := range job.Config().After {
+		upstream, found, err := s.pipeline.Job(name)
+		if err != nil {
+			logger.Error("failed-to-get-job", err, lager.Data{"after": name})
+			return false, err
+		}
+
+		if !found {
+			logger.Debug("after-job-not-found", lager.Data{"after": name})
+			return false, nil
+		}
+
+		finished, next, err := upstream.FinishedAndNextBuild()
+		if err != nil {
+			logger.Error("failed-to-get-builds-of-job", err, lager.Data{"after": name})
+			return false, err
+		}
+
+		if next != nil || finished == nil || finished.Status() != db.BuildStatusSucceeded {
+			logger.Debug("waiting-for-job", lager.Data{"after": name})
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// overriddenBuildInputs determines the inputs of a build triggered with
+// versions chosen for some of its inputs. These inputs are specific to the
+// build, so unlike the job's next inputs they are not saved.
+func (s *buildStarter) overriddenBuildInputs(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	resources db.Resources,
+	overrides atc.InputVersionOverrides,
+) ([]db.BuildInput, bool, error) {
+	resolution, err := s.inputMapper.ResolveBuildInputMapping(logger, versions, job, resources, overrides)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if resolution.Mapping == nil {
+		logger.Debug("failed-to-resolve-overridden-inputs", lager.Data{"unsatisfied": resolution.Unsatisfied})
+		return nil, false, nil
+	}
+
+	buildInputs := []db.BuildInput{}
+	for name, input := range resolution.Mapping {
+		version, found, err := s.pipeline.ResourceVersion(input.VersionID)
+		if err != nil {
+			logger.Error("failed-to-get-resource-version", err)
+			return nil, false, err
+		}
+
+		if !found {
+			return nil, false, nil
+		}
+
+		buildInputs = append(buildInputs, db.BuildInput{
+			Name:            name,
+			Version:         version.Version,
+			ResourceID:      input.ResourceID,
+			Metadata:        db.NewResourceConfigMetadataFields(version.Metadata),
+			FirstOccurrence: input.FirstOccurrence,
+		})
+	}
+
+	sort.Slice(buildInputs, func(i, j int) bool {
+		return buildInputs[i].Name < buildInputs[j].Name
+	})
+
+	return buildInputs, true, nil
+}
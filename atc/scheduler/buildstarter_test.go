@@ -2,18 +2,25 @@ package scheduler_test
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/scheduler"
+	"github.com/concourse/concourse/atc/scheduler/gate/gatefakes"
+	"github.com/concourse/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/concourse/atc/scheduler/maxinflight/maxinflightfakes"
 	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
+	"github.com/concourse/concourse/atc/scheduler/startlimit/startlimitfakes"
+	"github.com/concourse/concourse/vars"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -26,6 +33,10 @@ var _ = Describe("BuildStarter", func() {
 		fakeFactory     *schedulerfakes.FakeBuildFactory
 		pendingBuilds   []db.Build
 		fakeInputMapper *inputmapperfakes.FakeInputMapper
+		fakeLimiter     *startlimitfakes.FakeLimiter
+		fakeGates       *gatefakes.FakeEvaluator
+		variables       vars.Variables
+		fakeClock       *fakeclock.FakeClock
 
 		buildStarter scheduler.BuildStarter
 
@@ -37,8 +48,14 @@ var _ = Describe("BuildStarter", func() {
 		fakeUpdater = new(maxinflightfakes.FakeUpdater)
 		fakeFactory = new(schedulerfakes.FakeBuildFactory)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
+		fakeLimiter = new(startlimitfakes.FakeLimiter)
+		fakeLimiter.AllowReturns(true, nil)
+		fakeGates = new(gatefakes.FakeEvaluator)
+		fakeGates.OpenReturns(true)
+		variables = vars.StaticVariables{"some": "credential"}
+		fakeClock = fakeclock.NewFakeClock(time.Date(2019, 11, 16, 12, 0, 0, 0, time.UTC))
 
-		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeInputMapper)
+		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeInputMapper, fakeLimiter, fakeGates, variables, fakeClock)
 
 		disaster = errors.New("bad thing")
 	})
@@ -167,6 +184,149 @@ var _ = Describe("BuildStarter", func() {
 					})
 				})
 
+				Context("when the build has uploaded artifacts for some inputs", func() {
+					BeforeEach(func() {
+						createdBuild.InputArtifactsReturns(atc.InputArtifacts{"input-1": 17})
+
+						job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "input-1", Resource: "some-resource"}, {Get: "input-2", Resource: "other-resource"}}})
+
+						otherResource := new(dbfakes.FakeResource)
+						otherResource.NameReturns("other-resource")
+						resources = db.Resources{resource, otherResource}
+
+						createdBuild.IsNewerThanLastCheckOfStub = func(r db.Resource) bool {
+							return r.Name() == "some-resource"
+						}
+					})
+
+					It("does not wait for those inputs to be checked", func() {
+						Expect(createdBuild.IsNewerThanLastCheckOfCallCount()).To(Equal(1))
+						Expect(createdBuild.IsNewerThanLastCheckOfArgsForCall(0).Name()).To(Equal("other-resource"))
+
+						Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the build overrides the versions of some inputs", func() {
+					var versionsDB *algorithm.VersionsDB
+
+					BeforeEach(func() {
+						createdBuild.InputOverridesReturns(atc.InputVersionOverrides{
+							"input-1": atc.Version{"ref": "v1"},
+						})
+
+						job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "input-1", Resource: "some-resource"}, {Get: "input-2", Resource: "other-resource"}}})
+
+						otherResource := new(dbfakes.FakeResource)
+						otherResource.NameReturns("other-resource")
+						resources = db.Resources{resource, otherResource}
+
+						createdBuild.IsNewerThanLastCheckOfStub = func(r db.Resource) bool {
+							return r.Name() == "some-resource"
+						}
+
+						versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"j1": 1}}
+						fakePipeline.LoadVersionsDBReturns(versionsDB, nil)
+
+						fakePipeline.ResourceVersionStub = func(id int) (atc.ResourceVersion, bool, error) {
+							return atc.ResourceVersion{
+								ID:       id,
+								Version:  atc.Version{"id": fmt.Sprintf("%d", id)},
+								Metadata: []atc.MetadataField{{Name: "some", Value: "metadata"}},
+							}, true, nil
+						}
+
+						fakeInputMapper.ResolveBuildInputMappingReturns(inputmapper.Resolution{
+							Mapping: algorithm.InputMapping{
+								"input-1": algorithm.InputVersion{VersionID: 1, ResourceID: 11},
+								"input-2": algorithm.InputVersion{VersionID: 2, ResourceID: 22, FirstOccurrence: true},
+							},
+						}, nil)
+
+						createdBuild.ScheduleReturns(true, nil)
+						createdBuild.StartReturns(true, nil)
+					})
+
+					It("does not wait for the overridden inputs to be checked", func() {
+						Expect(createdBuild.IsNewerThanLastCheckOfCallCount()).To(Equal(1))
+						Expect(createdBuild.IsNewerThanLastCheckOfArgsForCall(0).Name()).To(Equal("other-resource"))
+					})
+
+					It("resolves the build's inputs with the overrides", func() {
+						Expect(fakeInputMapper.ResolveBuildInputMappingCallCount()).To(Equal(1))
+						_, actualVersionsDB, actualJob, _, overrides := fakeInputMapper.ResolveBuildInputMappingArgsForCall(0)
+						Expect(actualVersionsDB).To(Equal(versionsDB))
+						Expect(actualJob.Name()).To(Equal("some-job"))
+						Expect(overrides).To(Equal(atc.InputVersionOverrides{
+							"input-1": atc.Version{"ref": "v1"},
+						}))
+					})
+
+					It("does not determine the job's next inputs", func() {
+						Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+						Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+					})
+
+					It("warns about the artifacts which the build's plan doesn't use", func() {
+						Expect(createdBuild.SaveEventCallCount()).To(Equal(2))
+
+						warning, ok := createdBuild.SaveEventArgsForCall(0).(event.Warning)
+						Expect(ok).To(BeTrue())
+						Expect(warning.Type).To(Equal("pipeline"))
+						Expect(warning.Message).To(Equal("jobs.some-job.plan[0].get.input-1 fetches an artifact which no step uses ('input-1')"))
+
+						warning, ok = createdBuild.SaveEventArgsForCall(1).(event.Warning)
+						Expect(ok).To(BeTrue())
+						Expect(warning.Message).To(Equal("jobs.some-job.plan[1].get.input-2 fetches an artifact which no step uses ('input-2')"))
+					})
+
+					It("starts the build with the resolved inputs", func() {
+						Expect(tryStartErr).NotTo(HaveOccurred())
+
+						Expect(createdBuild.UseInputsCallCount()).To(Equal(1))
+						Expect(createdBuild.UseInputsArgsForCall(0)).To(Equal([]db.BuildInput{
+							{
+								Name:       "input-1",
+								Version:    atc.Version{"id": "1"},
+								ResourceID: 11,
+								Metadata:   db.ResourceConfigMetadataFields{{Name: "some", Value: "metadata"}},
+							},
+							{
+								Name:            "input-2",
+								Version:         atc.Version{"id": "2"},
+								ResourceID:      22,
+								Metadata:        db.ResourceConfigMetadataFields{{Name: "some", Value: "metadata"}},
+								FirstOccurrence: true,
+							},
+						}))
+
+						Expect(createdBuild.StartCallCount()).To(Equal(1))
+					})
+
+					Context("when the inputs cannot be resolved", func() {
+						BeforeEach(func() {
+							fakeInputMapper.ResolveBuildInputMappingReturns(inputmapper.Resolution{
+								Unsatisfied: []string{"input-1"},
+							}, nil)
+						})
+
+						It("does not start the build", func() {
+							Expect(tryStartErr).NotTo(HaveOccurred())
+							Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when resolving the inputs fails", func() {
+						BeforeEach(func() {
+							fakeInputMapper.ResolveBuildInputMappingReturns(inputmapper.Resolution{}, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(tryStartErr).To(Equal(disaster))
+						})
+					})
+				})
+
 				Context("when all resources are checked after build create time or pinned", func() {
 					BeforeEach(func() {
 						fakeDBResourceType := new(dbfakes.FakeResourceType)
@@ -392,6 +552,46 @@ var _ = Describe("BuildStarter", func() {
 					fakePipeline.PausedReturns(false)
 				})
 
+				Context("when the build re-runs another build", func() {
+					var rerunPlan atc.Plan
+
+					BeforeEach(func() {
+						rerunPlan = atc.Plan{ID: "some-plan", Task: &atc.TaskPlan{Name: "some-task"}}
+
+						createdBuild.RerunOfReturns(42)
+						createdBuild.PrivatePlanReturns(rerunPlan)
+						createdBuild.ScheduleReturns(true, nil)
+						createdBuild.StartReturns(true, nil)
+					})
+
+					It("schedules it like any other build", func() {
+						Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
+						Expect(fakeGates.OpenCallCount()).To(Equal(1))
+						Expect(fakeLimiter.AllowCallCount()).To(Equal(1))
+						Expect(createdBuild.ScheduleCallCount()).To(Equal(1))
+					})
+
+					It("starts it with its kept plan and the inputs it was created with", func() {
+						Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+						Expect(createdBuild.UseInputsCallCount()).To(BeZero())
+						Expect(fakeFactory.CreateCallCount()).To(BeZero())
+
+						Expect(createdBuild.StartCallCount()).To(Equal(1))
+						Expect(createdBuild.StartArgsForCall(0)).To(Equal(rerunPlan))
+					})
+
+					Context("when max in flight is reached", func() {
+						BeforeEach(func() {
+							fakeUpdater.UpdateMaxInFlightReachedReturns(true, nil)
+						})
+
+						It("leaves it pending", func() {
+							Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+							Expect(createdBuild.StartCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("when there are several pending builds", func() {
 					var pendingBuild1 *dbfakes.FakeBuild
 					var pendingBuild2 *dbfakes.FakeBuild
@@ -410,6 +610,52 @@ var _ = Describe("BuildStarter", func() {
 						pendingBuilds = []db.Build{pendingBuild1, pendingBuild2, pendingBuild3}
 					})
 
+					Context("when the pipeline's scheduling windows deny the current time", func() {
+						BeforeEach(func() {
+							fakePipeline.SchedulingWindowsReturns(&atc.SchedulingWindows{
+								Deny: []atc.SchedulingWindow{{Days: []string{"Saturday", "Sunday"}}},
+							})
+
+							pendingBuild2.IsManuallyTriggeredReturns(true)
+						})
+
+						It("doesn't schedule the builds which were triggered automatically", func() {
+							Expect(tryStartErr).NotTo(HaveOccurred())
+							Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+							Expect(pendingBuild3.ScheduleCallCount()).To(BeZero())
+						})
+
+						It("schedules the manually triggered build queued behind them", func() {
+							Expect(pendingBuild2.ScheduleCallCount()).To(Equal(1))
+						})
+
+						Context("when a held build was schedulable", func() {
+							BeforeEach(func() {
+								pendingBuild1.SchedulableTimeReturns(time.Now().Add(-time.Hour))
+							})
+
+							It("marks it as no longer schedulable", func() {
+								Expect(pendingBuild1.SetSchedulableCallCount()).To(Equal(1))
+								Expect(pendingBuild1.SetSchedulableArgsForCall(0)).To(BeFalse())
+							})
+						})
+
+						Context("when the job's own scheduling windows allow the current time", func() {
+							BeforeEach(func() {
+								job.ConfigReturns(atc.JobConfig{
+									Name: "some-job",
+									SchedulingWindows: &atc.SchedulingWindows{
+										Allow: []atc.SchedulingWindow{{Start: "09:00", Stop: "17:00"}},
+									},
+								})
+							})
+
+							It("schedules the builds triggered automatically", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+							})
+						})
+					})
+
 					Context("when marking the build as scheduled fails", func() {
 						BeforeEach(func() {
 							pendingBuild1.ScheduleReturns(false, disaster)
@@ -422,6 +668,12 @@ var _ = Describe("BuildStarter", func() {
 						It("marked the right build as scheduled", func() {
 							Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
 						})
+
+						It("refunds the build start", func() {
+							Expect(fakeLimiter.RefundCallCount()).To(Equal(1))
+							_, actualPipeline := fakeLimiter.RefundArgsForCall(0)
+							Expect(actualPipeline).To(Equal(fakePipeline))
+						})
 					})
 
 					Context("when someone else already scheduled the build", func() {
@@ -436,6 +688,10 @@ var _ = Describe("BuildStarter", func() {
 						It("doesn't try to use inputs for build", func() {
 							Expect(pendingBuild1.UseInputsCallCount()).To(BeZero())
 						})
+
+						It("refunds the build start", func() {
+							Expect(fakeLimiter.RefundCallCount()).To(Equal(1))
+						})
 					})
 
 					Context("when marking the build as scheduled succeeds", func() {
@@ -443,6 +699,10 @@ var _ = Describe("BuildStarter", func() {
 							pendingBuild1.ScheduleReturns(true, nil)
 						})
 
+						It("keeps the build start", func() {
+							Expect(fakeLimiter.RefundCallCount()).To(BeZero())
+						})
+
 						Context("when using inputs for build fails", func() {
 							BeforeEach(func() {
 								pendingBuild1.UseInputsReturns(disaster)
@@ -620,6 +880,21 @@ var _ = Describe("BuildStarter", func() {
 
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("marks the build as schedulable", func() {
+							Expect(pendingBuild1.SetSchedulableCallCount()).To(Equal(1))
+							Expect(pendingBuild1.SetSchedulableArgsForCall(0)).To(BeTrue())
+						})
+
+						Context("when the build is already schedulable", func() {
+							BeforeEach(func() {
+								pendingBuild1.SchedulableTimeReturns(time.Now().Add(-time.Hour))
+							})
+
+							It("keeps the time it became schedulable", func() {
+								Expect(pendingBuild1.SetSchedulableCallCount()).To(BeZero())
+							})
+						})
 					})
 
 					Context("when checking if the pipeline is paused fails", func() {
@@ -638,6 +913,21 @@ var _ = Describe("BuildStarter", func() {
 
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("does not mark the build as schedulable", func() {
+							Expect(pendingBuild1.SetSchedulableCallCount()).To(BeZero())
+						})
+
+						Context("when the build was schedulable", func() {
+							BeforeEach(func() {
+								pendingBuild1.SchedulableTimeReturns(time.Now().Add(-time.Hour))
+							})
+
+							It("marks the build as no longer schedulable", func() {
+								Expect(pendingBuild1.SetSchedulableCallCount()).To(Equal(1))
+								Expect(pendingBuild1.SetSchedulableArgsForCall(0)).To(BeFalse())
+							})
+						})
 					})
 
 					Context("when the job is paused", func() {
@@ -648,6 +938,214 @@ var _ = Describe("BuildStarter", func() {
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
+
+					Context("when the job's gates are closed", func() {
+						BeforeEach(func() {
+							fakeGates.OpenReturns(false)
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("evaluated the gates of the job", func() {
+							Expect(fakeGates.OpenCallCount()).To(Equal(1))
+							_, actualJob, actualVariables := fakeGates.OpenArgsForCall(0)
+							Expect(actualJob).To(Equal(job))
+							Expect(actualVariables).To(Equal(variables))
+						})
+
+						It("does not count towards the build start limit", func() {
+							Expect(fakeLimiter.AllowCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the job runs after another job", func() {
+						var (
+							upstreamJob   *dbfakes.FakeJob
+							finishedBuild *dbfakes.FakeBuild
+						)
+
+						BeforeEach(func() {
+							job.ConfigReturns(atc.JobConfig{
+								Name:  "some-job",
+								After: []string{"upstream-job"},
+							})
+
+							finishedBuild = new(dbfakes.FakeBuild)
+							finishedBuild.StatusReturns(db.BuildStatusSucceeded)
+
+							upstreamJob = new(dbfakes.FakeJob)
+							upstreamJob.FinishedAndNextBuildReturns(finishedBuild, nil, nil)
+							fakePipeline.JobReturns(upstreamJob, true, nil)
+						})
+
+						It("schedules the build once the job's latest build succeeded", func() {
+							Expect(fakePipeline.JobCallCount()).To(Equal(1))
+							Expect(fakePipeline.JobArgsForCall(0)).To(Equal("upstream-job"))
+							Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+						})
+
+						Context("when the job's latest build did not succeed", func() {
+							BeforeEach(func() {
+								finishedBuild.StatusReturns(db.BuildStatusFailed)
+							})
+
+							itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+							itUpdatedMaxInFlightForTheFirstBuild()
+						})
+
+						Context("when the job has a build pending or running", func() {
+							BeforeEach(func() {
+								upstreamJob.FinishedAndNextBuildReturns(finishedBuild, new(dbfakes.FakeBuild), nil)
+							})
+
+							itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+							itUpdatedMaxInFlightForTheFirstBuild()
+
+							It("does not count towards the build start limit", func() {
+								Expect(fakeLimiter.AllowCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the job has never been built", func() {
+							BeforeEach(func() {
+								upstreamJob.FinishedAndNextBuildReturns(nil, nil, nil)
+							})
+
+							itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+							itUpdatedMaxInFlightForTheFirstBuild()
+						})
+
+						Context("when getting the job's builds fails", func() {
+							BeforeEach(func() {
+								upstreamJob.FinishedAndNextBuildReturns(nil, nil, disaster)
+							})
+
+							itReturnsTheError()
+							itUpdatedMaxInFlightForTheFirstBuild()
+						})
+					})
+
+					Context("when the job joins concurrency pools", func() {
+						BeforeEach(func() {
+							job.ConfigReturns(atc.JobConfig{
+								Name:             "some-job",
+								ConcurrencyPools: []string{"integration-db"},
+							})
+							pendingBuild1.ClaimConcurrencyPoolsReturns(true, nil)
+						})
+
+						It("claims the pools for the build", func() {
+							Expect(pendingBuild1.ClaimConcurrencyPoolsCallCount()).To(Equal(1))
+							Expect(pendingBuild1.ClaimConcurrencyPoolsArgsForCall(0)).To(Equal([]string{"integration-db"}))
+						})
+
+						It("keeps the pools once the build is scheduled", func() {
+							Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+							Expect(pendingBuild1.ReleaseConcurrencyPoolsCallCount()).To(BeZero())
+						})
+
+						Context("when claiming the pools fails", func() {
+							BeforeEach(func() {
+								pendingBuild1.ClaimConcurrencyPoolsReturns(false, disaster)
+							})
+
+							itReturnsTheError()
+							itUpdatedMaxInFlightForTheFirstBuild()
+
+							It("refunds the build start", func() {
+								Expect(fakeLimiter.RefundCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when a pool is full", func() {
+							BeforeEach(func() {
+								pendingBuild1.ClaimConcurrencyPoolsReturns(false, nil)
+							})
+
+							itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+							itUpdatedMaxInFlightForTheFirstBuild()
+
+							It("does not schedule the build", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+							})
+
+							Context("when the build was schedulable", func() {
+								BeforeEach(func() {
+									pendingBuild1.SchedulableTimeReturns(time.Now().Add(-time.Hour))
+								})
+
+								It("marks the build as no longer schedulable", func() {
+									Expect(pendingBuild1.SetSchedulableCallCount()).To(Equal(1))
+									Expect(pendingBuild1.SetSchedulableArgsForCall(0)).To(BeFalse())
+								})
+							})
+
+							It("refunds the build start", func() {
+								Expect(fakeLimiter.RefundCallCount()).To(Equal(1))
+								_, actualPipeline := fakeLimiter.RefundArgsForCall(0)
+								Expect(actualPipeline).To(Equal(fakePipeline))
+							})
+						})
+
+						Context("when the build start limit is reached", func() {
+							BeforeEach(func() {
+								fakeLimiter.AllowReturns(false, nil)
+							})
+
+							itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+
+							It("does not claim the pools", func() {
+								Expect(pendingBuild1.ClaimConcurrencyPoolsCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when marking the build as scheduled fails", func() {
+							BeforeEach(func() {
+								pendingBuild1.ScheduleReturns(false, disaster)
+							})
+
+							itReturnsTheError()
+
+							It("releases the pools", func() {
+								Expect(pendingBuild1.ReleaseConcurrencyPoolsCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when someone else already scheduled the build", func() {
+							BeforeEach(func() {
+								pendingBuild1.ScheduleReturns(false, nil)
+							})
+
+							It("releases the pools", func() {
+								Expect(pendingBuild1.ReleaseConcurrencyPoolsCallCount()).To(Equal(1))
+							})
+						})
+					})
+
+					Context("when checking the build start limit fails", func() {
+						BeforeEach(func() {
+							fakeLimiter.AllowReturns(false, disaster)
+						})
+
+						itReturnsTheError()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when the build start limit is reached", func() {
+						BeforeEach(func() {
+							fakeLimiter.AllowReturns(false, nil)
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("checked the limit for the pipeline", func() {
+							Expect(fakeLimiter.AllowCallCount()).To(Equal(1))
+							_, actualPipeline := fakeLimiter.AllowArgsForCall(0)
+							Expect(actualPipeline).To(Equal(fakePipeline))
+						})
+					})
 				})
 			})
 		})
@@ -120,6 +120,9 @@ func (factory *buildFactory) constructPlanFromConfig(
 		plan = factory.planFactory.NewPlan(retryStep)
 	}
 
+	// the pools are held for all of the step's attempts, but not its hooks
+	plan.ConcurrencyPools = planConfig.ConcurrencyPools
+
 	return factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         planConfig.Hooks(),
@@ -163,6 +166,10 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		if !resource.Mode.AllowsPut() {
+			return atc.Plan{}, atc.ResourceModeError{Resource: resourceName, Mode: resource.Mode, Step: "put to"}
+		}
+
 		atcPutPlan := atc.PutPlan{
 			Type:     resource.Type,
 			Name:     logicalName,
@@ -170,6 +177,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Source:   resource.Source,
 			Params:   planConfig.Params,
 			Tags:     planConfig.Tags,
+			Labels:   planConfig.Labels,
 			Inputs:   planConfig.Inputs,
 
 			VersionedResourceTypes: resourceTypes,
@@ -177,6 +185,12 @@ func (factory *buildFactory) constructUnhookedPlan(
 
 		putPlan := factory.planFactory.NewPlan(atcPutPlan)
 
+		// there is nothing to fetch from a resource which is only pushed to
+		if resource.Mode == atc.ResourceModePutOnly {
+			plan = putPlan
+			break
+		}
+
 		dependentGetPlan := factory.planFactory.NewPlan(atc.GetPlan{
 			Type:        resource.Type,
 			Name:        logicalName,
@@ -185,6 +199,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 
 			Params: planConfig.GetParams,
 			Tags:   planConfig.Tags,
+			Labels: planConfig.Labels,
 			Source: resource.Source,
 
 			VersionedResourceTypes: resourceTypes,
@@ -206,6 +221,10 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		if !resource.Mode.AllowsGet() {
+			return atc.Plan{}, atc.ResourceModeError{Resource: resourceName, Mode: resource.Mode, Step: "get"}
+		}
+
 		name := planConfig.Get
 		var version atc.Version
 		for _, input := range inputs {
@@ -223,6 +242,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Params:   planConfig.Params,
 			Version:  &version,
 			Tags:     planConfig.Tags,
+			Labels:   planConfig.Labels,
 
 			VersionedResourceTypes: resourceTypes,
 		})
@@ -231,13 +251,17 @@ func (factory *buildFactory) constructUnhookedPlan(
 		plan = factory.planFactory.NewPlan(atc.TaskPlan{
 			Name:              planConfig.Task,
 			Privileged:        planConfig.Privileged,
+			GPUs:              planConfig.GPUs,
 			Config:            planConfig.TaskConfig,
 			ConfigPath:        planConfig.TaskConfigPath,
 			Vars:              planConfig.TaskVars,
+			OutputVars:        planConfig.TaskOutputVars,
 			Tags:              planConfig.Tags,
+			Labels:            planConfig.Labels,
 			Params:            planConfig.Params,
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
+			FileParams:        planConfig.FileParams,
 			ImageArtifactName: planConfig.ImageArtifactName,
 
 			VersionedResourceTypes: resourceTypes,
@@ -92,4 +92,24 @@ var _ = Describe("Factory Get", func() {
 			Expect(err).To(Equal(factory.ErrResourceNotFound))
 		})
 	})
+
+	Context("with a get of a put_only resource", func() {
+		BeforeEach(func() {
+			resources[0].Mode = atc.ResourceModePutOnly
+
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:      "some-get",
+						Resource: "some-resource",
+					},
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).To(MatchError("cannot get resource 'some-resource' as it is put_only"))
+		})
+	})
 })
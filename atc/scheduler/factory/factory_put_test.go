@@ -105,6 +105,62 @@ var _ = Describe("Factory Put", func() {
 				Expect(err).To(Equal(factory.ErrResourceNotFound))
 			})
 		})
+
+		Context("with a put to a put_only resource", func() {
+			BeforeEach(func() {
+				resources[0].Mode = atc.ResourceModePutOnly
+
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Put:      "some-put",
+							Resource: "some-resource",
+						},
+					},
+				}
+			})
+
+			It("returns the put without a get after it", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.PutPlan{
+					Type:     "git",
+					Name:     "some-put",
+					Resource: "some-resource",
+					Source: atc.Source{
+						"uri": "git://some-resource",
+					},
+					VersionedResourceTypes: resourceTypes,
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
+		Context("with a put to a get_only resource", func() {
+			BeforeEach(func() {
+				resources[0].Mode = atc.ResourceModeGetOnly
+
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Put:      "some-put",
+							Resource: "some-resource",
+						},
+					},
+				}
+			})
+
+			It("returns an error", func() {
+				_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).To(Equal(atc.ResourceModeError{
+					Resource: "some-resource",
+					Mode:     atc.ResourceModeGetOnly,
+					Step:     "put to",
+				}))
+				Expect(err).To(MatchError("cannot put to resource 'some-resource' as it is get_only"))
+			})
+		})
 	})
 
 	Describe("Put/Get build plan", func() {
@@ -0,0 +1,238 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+// DefaultInterval is how long a gate's status is reused if the gate does not
+// configure check_every.
+const DefaultInterval = time.Minute
+
+// DefaultTimeout bounds the evaluation of a gate if it does not configure a
+// timeout. Configured timeouts are capped at atc.MaxHTTPGateTimeout.
+const DefaultTimeout = 10 * time.Second
+
+//go:generate counterfeiter . Evaluator
+
+// Evaluator decides whether a job's gates allow its builds to start.
+// Credentials in the gates' config are interpolated with the given
+// variables.
+type Evaluator interface {
+	Open(logger lager.Logger, job db.Job, variables vars.Variables) bool
+}
+
+//go:generate counterfeiter . Provider
+
+// Provider looks up the current value of a metric for metric gates.
+type Provider interface {
+	Query(ctx context.Context, query string) (float64, error)
+}
+
+// NewEvaluator returns an Evaluator which probes HTTP gates with the given
+// client and queries metric gates from the named providers.
+//
+// A gate is closed if its condition cannot be evaluated, so that e.g. an
+// unreachable status page holds builds back rather than letting them
+// through. Gates are evaluated in the background, so that a slow gate does
+// not hold up scheduling; a gate is closed until its first evaluation
+// completes, and while it is evaluated again once its status has expired.
+// Each gate's status is cached in memory for its check_every, and shared by
+// the pipeline's jobs configuring an identical gate.
+func NewEvaluator(clock clock.Clock, client *http.Client, providers map[string]Provider) Evaluator {
+	return &evaluator{
+		clock:      clock,
+		client:     client,
+		providers:  providers,
+		statuses:   map[string]status{},
+		evaluating: map[string]bool{},
+	}
+}
+
+type status struct {
+	healthy bool
+	reason  string
+	expires time.Time
+}
+
+type evaluator struct {
+	clock     clock.Clock
+	client    *http.Client
+	providers map[string]Provider
+
+	lock       sync.Mutex
+	statuses   map[string]status
+	evaluating map[string]bool
+}
+
+func (e *evaluator) Open(logger lager.Logger, job db.Job, variables vars.Variables) bool {
+	for _, gate := range job.Config().Gates {
+		status := e.status(logger, job, gate, variables)
+		if !status.healthy {
+			logger.Debug("gate-closed", lager.Data{"gate": gate.Name, "reason": status.reason})
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e *evaluator) status(logger lager.Logger, job db.Job, gate atc.GateConfig, variables vars.Variables) status {
+	config, err := json.Marshal(gate)
+	if err != nil {
+		return status{reason: err.Error()}
+	}
+
+	// statuses are only shared within a pipeline, as its credentials may be
+	// interpolated into the gate's config
+	key := fmt.Sprintf("%d:%s", job.PipelineID(), config)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	cached, found := e.statuses[key]
+	if found && e.clock.Now().Before(cached.expires) {
+		return cached
+	}
+
+	if !e.evaluating[key] {
+		e.evaluating[key] = true
+		go e.refresh(logger, key, gate, variables)
+	}
+
+	return status{reason: "gate is being evaluated"}
+}
+
+func (e *evaluator) refresh(logger lager.Logger, key string, gate atc.GateConfig, variables vars.Variables) {
+	evaluated := e.evaluate(logger, gate, variables)
+
+	interval := DefaultInterval
+	if gate.CheckEvery != "" {
+		var err error
+		interval, err = time.ParseDuration(gate.CheckEvery)
+		if err != nil {
+			interval = DefaultInterval
+		}
+	}
+
+	now := e.clock.Now()
+	evaluated.expires = now.Add(interval)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for k, s := range e.statuses {
+		if !now.Before(s.expires) && !e.evaluating[k] {
+			delete(e.statuses, k)
+		}
+	}
+
+	e.statuses[key] = evaluated
+	delete(e.evaluating, key)
+}
+
+func (e *evaluator) evaluate(logger lager.Logger, gate atc.GateConfig, variables vars.Variables) status {
+	logger = logger.Session("evaluate-gate", lager.Data{"gate": gate.Name})
+
+	switch {
+	case gate.HTTP != nil:
+		return e.probe(logger, *gate.HTTP, variables)
+	case gate.Metric != nil:
+		return e.measure(logger, *gate.Metric)
+	default:
+		return status{reason: "gate has no condition"}
+	}
+}
+
+func (e *evaluator) probe(logger lager.Logger, config atc.HTTPGateConfig, variables vars.Variables) status {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout(config.Timeout))
+	defer cancel()
+
+	req, err := http.NewRequest("GET", config.URL, nil)
+	if err != nil {
+		return status{reason: err.Error()}
+	}
+
+	for name, value := range config.Headers {
+		evaluated, err := creds.NewString(variables, value).Evaluate()
+		if err != nil {
+			logger.Info("failed-to-evaluate-header", lager.Data{"header": name, "error": err.Error()})
+			return status{reason: fmt.Sprintf("failed to evaluate header '%s': %s", name, err)}
+		}
+
+		req.Header.Set(name, evaluated)
+	}
+
+	resp, err := e.client.Do(req.WithContext(ctx))
+	if err != nil {
+		logger.Info("failed-to-probe", lager.Data{"error": err.Error()})
+		return status{reason: err.Error()}
+	}
+
+	_ = resp.Body.Close()
+
+	healthy := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if config.ExpectedStatus != 0 {
+		healthy = resp.StatusCode == config.ExpectedStatus
+	}
+
+	if !healthy {
+		return status{reason: fmt.Sprintf("unexpected response status: %s", resp.Status)}
+	}
+
+	return status{healthy: true}
+}
+
+func (e *evaluator) measure(logger lager.Logger, config atc.MetricGateConfig) status {
+	provider, found := e.providers[config.Provider]
+	if !found {
+		return status{reason: fmt.Sprintf("unknown metric provider '%s'", config.Provider)}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	value, err := provider.Query(ctx, config.Query)
+	if err != nil {
+		logger.Info("failed-to-query-metric", lager.Data{"error": err.Error()})
+		return status{reason: err.Error()}
+	}
+
+	if config.Min != nil && value < *config.Min {
+		return status{reason: fmt.Sprintf("value %g is below min %g", value, *config.Min)}
+	}
+
+	if config.Max != nil && value > *config.Max {
+		return status{reason: fmt.Sprintf("value %g is above max %g", value, *config.Max)}
+	}
+
+	return status{healthy: true}
+}
+
+func timeout(configured string) time.Duration {
+	if configured == "" {
+		return DefaultTimeout
+	}
+
+	duration, err := time.ParseDuration(configured)
+	if err != nil {
+		return DefaultTimeout
+	}
+
+	if duration > atc.MaxHTTPGateTimeout {
+		return atc.MaxHTTPGateTimeout
+	}
+
+	return duration
+}
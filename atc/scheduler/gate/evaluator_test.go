@@ -0,0 +1,282 @@
+package gate_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/scheduler/gate"
+	"github.com/concourse/concourse/atc/scheduler/gate/gatefakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Evaluator", func() {
+	var (
+		fakeClock    *fakeclock.FakeClock
+		fakeProvider *gatefakes.FakeProvider
+		server       *ghttp.Server
+		fakeJob      *dbfakes.FakeJob
+		gates        []atc.GateConfig
+		variables    vars.Variables
+
+		evaluator gate.Evaluator
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		fakeProvider = new(gatefakes.FakeProvider)
+		server = ghttp.NewServer()
+		server.SetAllowUnhandledRequests(true)
+		server.SetUnhandledRequestStatusCode(http.StatusOK)
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.PipelineIDReturns(1)
+		gates = nil
+		variables = vars.StaticVariables{"token": "some-token"}
+
+		evaluator = gate.NewEvaluator(fakeClock, http.DefaultClient, map[string]gate.Provider{
+			"prometheus": fakeProvider,
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	open := func() bool {
+		fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", Gates: gates})
+		return evaluator.Open(lagertest.NewTestLogger("test"), fakeJob, variables)
+	}
+
+	// gates are evaluated in the background, and are closed until then
+	itOpens := func() {
+		Eventually(open).Should(BeTrue())
+	}
+
+	itStaysClosed := func() {
+		Consistently(open, 200*time.Millisecond).Should(BeFalse())
+	}
+
+	It("is open for jobs without gates", func() {
+		Expect(open()).To(BeTrue())
+	})
+
+	Context("with an http gate", func() {
+		BeforeEach(func() {
+			gates = []atc.GateConfig{{
+				Name: "status",
+				HTTP: &atc.HTTPGateConfig{
+					URL:     server.URL() + "/health",
+					Headers: map[string]string{"Authorization": "Bearer ((token))"},
+				},
+			}}
+		})
+
+		It("is open while the probe succeeds", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/health"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			itOpens()
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("is closed until the probe completes", func() {
+			probed := make(chan struct{})
+			server.AppendHandlers(func(http.ResponseWriter, *http.Request) {
+				<-probed
+			})
+
+			Expect(open()).To(BeFalse())
+			Expect(open()).To(BeFalse())
+
+			close(probed)
+			itOpens()
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("is closed while the probe fails", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusServiceUnavailable, nil))
+			itStaysClosed()
+		})
+
+		It("is closed when the server can't be reached", func() {
+			gates[0].HTTP.URL = "http://127.0.0.1:1/health"
+			itStaysClosed()
+		})
+
+		It("is closed when its headers can't be interpolated", func() {
+			variables = vars.StaticVariables{}
+			itStaysClosed()
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		Context("when a status is expected", func() {
+			BeforeEach(func() {
+				gates[0].HTTP.ExpectedStatus = http.StatusAccepted
+			})
+
+			It("is closed for other statuses", func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+				itStaysClosed()
+			})
+
+			It("is open for the expected status", func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusAccepted, nil))
+				itOpens()
+			})
+		})
+
+		Describe("caching", func() {
+			BeforeEach(func() {
+				gates[0].CheckEvery = "30s"
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, nil),
+					ghttp.RespondWith(http.StatusServiceUnavailable, nil),
+				)
+			})
+
+			It("reuses the status until check_every has elapsed", func() {
+				itOpens()
+
+				fakeClock.Increment(29 * time.Second)
+				Expect(open()).To(BeTrue())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+				fakeClock.Increment(time.Second)
+				itStaysClosed()
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+
+			It("shares the status between the pipeline's jobs with the same gate", func() {
+				itOpens()
+
+				otherJob := new(dbfakes.FakeJob)
+				otherJob.PipelineIDReturns(1)
+				otherJob.ConfigReturns(atc.JobConfig{Name: "other-job", Gates: gates})
+				Expect(evaluator.Open(lagertest.NewTestLogger("test"), otherJob, variables)).To(BeTrue())
+
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("does not share the status with other pipelines", func() {
+				itOpens()
+
+				otherJob := new(dbfakes.FakeJob)
+				otherJob.PipelineIDReturns(2)
+				otherJob.ConfigReturns(atc.JobConfig{Name: "some-job", Gates: gates})
+				Consistently(func() bool {
+					return evaluator.Open(lagertest.NewTestLogger("test"), otherJob, variables)
+				}, 200*time.Millisecond).Should(BeFalse())
+
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+
+			It("evaluates the gate again when its config changes", func() {
+				itOpens()
+
+				gates[0].HTTP.ExpectedStatus = http.StatusServiceUnavailable
+				itOpens()
+
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+
+	Context("with a metric gate", func() {
+		BeforeEach(func() {
+			max := 1.0
+
+			gates = []atc.GateConfig{{
+				Name: "error-budget",
+				Metric: &atc.MetricGateConfig{
+					Provider: "prometheus",
+					Query:    "burn_rate",
+					Max:      &max,
+				},
+			}}
+		})
+
+		It("queries the provider", func() {
+			open()
+
+			Eventually(fakeProvider.QueryCallCount).Should(Equal(1))
+			_, query := fakeProvider.QueryArgsForCall(0)
+			Expect(query).To(Equal("burn_rate"))
+		})
+
+		It("is open while the value is within range", func() {
+			fakeProvider.QueryReturns(0.5, nil)
+			itOpens()
+		})
+
+		It("is closed while the value is above the max", func() {
+			fakeProvider.QueryReturns(1.5, nil)
+			itStaysClosed()
+		})
+
+		It("is closed while the value is below the min", func() {
+			min := 0.9
+			gates[0].Metric.Min = &min
+
+			fakeProvider.QueryReturns(0.5, nil)
+			itStaysClosed()
+		})
+
+		It("is closed when the query fails", func() {
+			fakeProvider.QueryStub = func(context.Context, string) (float64, error) {
+				return 0, errors.New("nope")
+			}
+
+			itStaysClosed()
+		})
+
+		It("is closed when the provider is unknown", func() {
+			gates[0].Metric.Provider = "bogus"
+			itStaysClosed()
+			Expect(fakeProvider.QueryCallCount()).To(BeZero())
+		})
+	})
+
+	Context("with several gates", func() {
+		BeforeEach(func() {
+			max := 1.0
+
+			gates = []atc.GateConfig{
+				{
+					Name:   "error-budget",
+					Metric: &atc.MetricGateConfig{Provider: "prometheus", Query: "burn_rate", Max: &max},
+				},
+				{
+					Name: "status",
+					HTTP: &atc.HTTPGateConfig{URL: server.URL() + "/health"},
+				},
+			}
+		})
+
+		It("is open only while every gate is open", func() {
+			fakeProvider.QueryReturns(0.5, nil)
+			server.AppendHandlers(ghttp.RespondWith(http.StatusServiceUnavailable, nil))
+
+			itStaysClosed()
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("stops at the first closed gate", func() {
+			fakeProvider.QueryReturns(1.5, nil)
+
+			itStaysClosed()
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NewPrometheusProvider returns a Provider evaluating instant queries
+// against the Prometheus server at the given URL. Queries must result in a
+// scalar or a vector with exactly one series.
+func NewPrometheusProvider(client *http.Client, serverURL string) Provider {
+	return &prometheusProvider{
+		client: client,
+		url:    strings.TrimSuffix(serverURL, "/"),
+	}
+}
+
+type prometheusProvider struct {
+	client *http.Client
+	url    string
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+func (p *prometheusProvider) Query(ctx context.Context, query string) (float64, error) {
+	req, err := http.NewRequest("GET", p.url+"/api/v1/query?"+url.Values{"query": {query}}.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	var body prometheusResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return 0, fmt.Errorf("malformed response (%s): %s", resp.Status, err)
+	}
+
+	if body.Status != "success" {
+		return 0, fmt.Errorf("query failed (%s): %s", resp.Status, body.Error)
+	}
+
+	var sample []interface{}
+	switch body.Data.ResultType {
+	case "scalar":
+		err = json.Unmarshal(body.Data.Result, &sample)
+		if err != nil {
+			return 0, err
+		}
+
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+
+		err = json.Unmarshal(body.Data.Result, &vector)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(vector) != 1 {
+			return 0, fmt.Errorf("query returned %d series, expected 1", len(vector))
+		}
+
+		sample = vector[0].Value
+
+	default:
+		return 0, fmt.Errorf("unsupported result type '%s'", body.Data.ResultType)
+	}
+
+	if len(sample) != 2 {
+		return 0, errors.New("malformed sample")
+	}
+
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.New("malformed sample value")
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
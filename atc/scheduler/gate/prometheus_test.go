@@ -0,0 +1,115 @@
+package gate_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/scheduler/gate"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("PrometheusProvider", func() {
+	var (
+		server   *ghttp.Server
+		provider gate.Provider
+
+		value    float64
+		queryErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		provider = gate.NewPrometheusProvider(http.DefaultClient, server.URL()+"/")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		value, queryErr = provider.Query(context.Background(), "sum(rate(errors[5m]))")
+	})
+
+	respondWith := func(status int, body string) {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/query", "query=sum%28rate%28errors%5B5m%5D%29%29"),
+			ghttp.RespondWith(status, body),
+		))
+	}
+
+	Context("when the query results in a vector with one series", func() {
+		BeforeEach(func() {
+			respondWith(http.StatusOK, `{
+				"status": "success",
+				"data": {
+					"resultType": "vector",
+					"result": [{"metric": {}, "value": [1571490000.123, "0.25"]}]
+				}
+			}`)
+		})
+
+		It("returns the value", func() {
+			Expect(queryErr).NotTo(HaveOccurred())
+			Expect(value).To(Equal(0.25))
+		})
+	})
+
+	Context("when the query results in a scalar", func() {
+		BeforeEach(func() {
+			respondWith(http.StatusOK, `{
+				"status": "success",
+				"data": {"resultType": "scalar", "result": [1571490000.123, "3"]}
+			}`)
+		})
+
+		It("returns the value", func() {
+			Expect(queryErr).NotTo(HaveOccurred())
+			Expect(value).To(Equal(3.0))
+		})
+	})
+
+	Context("when the query results in several series", func() {
+		BeforeEach(func() {
+			respondWith(http.StatusOK, `{
+				"status": "success",
+				"data": {
+					"resultType": "vector",
+					"result": [
+						{"metric": {"a": "1"}, "value": [1571490000.123, "1"]},
+						{"metric": {"a": "2"}, "value": [1571490000.123, "2"]}
+					]
+				}
+			}`)
+		})
+
+		It("returns an error", func() {
+			Expect(queryErr).To(MatchError("query returned 2 series, expected 1"))
+		})
+	})
+
+	Context("when the query fails", func() {
+		BeforeEach(func() {
+			respondWith(http.StatusBadRequest, `{
+				"status": "error",
+				"errorType": "bad_data",
+				"error": "parse error"
+			}`)
+		})
+
+		It("returns an error", func() {
+			Expect(queryErr).To(MatchError("query failed (400 Bad Request): parse error"))
+		})
+	})
+
+	Context("when the response is not json", func() {
+		BeforeEach(func() {
+			respondWith(http.StatusBadGateway, `<html></html>`)
+		})
+
+		It("returns an error", func() {
+			Expect(queryErr).To(HaveOccurred())
+		})
+	})
+})
@@ -56,13 +56,23 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 			jobs[db.JobIDs[passedJobName]] = struct{}{}
 		}
 
+		fallbackAfter := 0
+		if input.Version.LatestPassingOrNewest {
+			fallbackAfter = input.FallbackAfter
+			if fallbackAfter == 0 {
+				fallbackAfter = atc.DefaultFallbackAfter
+			}
+		}
+
 		inputConfigs = append(inputConfigs, algorithm.InputConfig{
-			Name:            input.Name,
-			UseEveryVersion: input.Version.Every,
-			PinnedVersionID: pinnedVersionID,
-			ResourceID:      db.ResourceIDs[input.Resource],
-			Passed:          jobs,
-			JobID:           db.JobIDs[jobName],
+			Name:               input.Name,
+			UseEveryVersion:    input.Version.Every,
+			UseDeletedVersions: input.Version.Deleted,
+			PinnedVersionID:    pinnedVersionID,
+			ResourceID:         db.ResourceIDs[input.Resource],
+			Passed:             jobs,
+			JobID:              db.JobIDs[jobName],
+			FallbackAfter:      fallbackAfter,
 		})
 	}
 
@@ -106,6 +106,63 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input has version: deleted", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version:  &atc.VersionConfig{Deleted: true},
+					}}
+				})
+
+				It("uses deleted versions", func() {
+					Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+						Name:               "job-input-1",
+						UseDeletedVersions: true,
+						ResourceID:         11,
+						Passed:             algorithm.JobSet{},
+						JobID:              1,
+					}))
+				})
+			})
+
+			Context("when an input has version: latest-passing-or-newest", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{
+						{
+							Name:     "job-input-1",
+							Resource: "r1",
+							Version:  &atc.VersionConfig{LatestPassingOrNewest: true},
+						},
+						{
+							Name:          "job-input-2",
+							Resource:      "r1",
+							Version:       &atc.VersionConfig{LatestPassingOrNewest: true},
+							FallbackAfter: 5,
+						},
+					}
+				})
+
+				It("falls back after the configured number of failures, or the default", func() {
+					Expect(algorithmInputs).To(ConsistOf(
+						algorithm.InputConfig{
+							Name:          "job-input-1",
+							ResourceID:    11,
+							Passed:        algorithm.JobSet{},
+							JobID:         1,
+							FallbackAfter: atc.DefaultFallbackAfter,
+						},
+						algorithm.InputConfig{
+							Name:          "job-input-2",
+							ResourceID:    11,
+							Passed:        algorithm.JobSet{},
+							JobID:         1,
+							FallbackAfter: 5,
+						},
+					))
+				})
+			})
+
 			Context("when an input has a pinned version", func() {
 				BeforeEach(func() {
 					jobInputs = []atc.JobInput{
@@ -1,6 +1,9 @@
 package inputmapper
 
 import (
+	"errors"
+	"sync"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -8,6 +11,11 @@ import (
 	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputconfig"
 )
 
+// ErrResolutionIncomplete is returned when resolving a job's next inputs
+// gave up after exploring as many versions as it's allowed to for now. The
+// next attempt resumes from where it left off.
+var ErrResolutionIncomplete = errors.New("input resolution incomplete")
+
 //go:generate counterfeiter . InputMapper
 
 type InputMapper interface {
@@ -17,15 +25,65 @@ type InputMapper interface {
 		job db.Job,
 		resources db.Resources,
 	) (algorithm.InputMapping, error)
+
+	ResolveNextInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+		resources db.Resources,
+	) (Resolution, error)
+
+	ResolveBuildInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+		resources db.Resources,
+		overrides atc.InputVersionOverrides,
+	) (Resolution, error)
 }
 
-func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer) InputMapper {
-	return &inputMapper{pipeline: pipeline, transformer: transformer}
+// Resolution is the outcome of determining a job's next inputs.
+type Resolution struct {
+	// Independent maps every input which can be satisfied on its own,
+	// ignoring the constraints between inputs.
+	Independent algorithm.InputMapping
+
+	// Unsatisfied names the inputs which cannot be satisfied on their own.
+	Unsatisfied []string
+
+	// Mapping satisfies every input together. It is nil if any input is
+	// unsatisfied or no combination of versions satisfies the inputs' passed
+	// constraints.
+	Mapping algorithm.InputMapping
+
+	// Incomplete is true if no Mapping was found because resolving the
+	// inputs together gave up after exploring too many versions.
+	Incomplete bool
+}
+
+// NewInputMapper returns an InputMapper which, when resolving a job's next
+// inputs, explores at most versionLimit versions of each input before giving
+// up until the next attempt, which resumes from where it left off. A
+// versionLimit of 0 means there is no limit.
+//
+// Where each job's last attempt got to is only kept in memory, so an attempt
+// made by another ATC starts from the beginning.
+func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer, versionLimit int) InputMapper {
+	return &inputMapper{
+		pipeline:     pipeline,
+		transformer:  transformer,
+		versionLimit: versionLimit,
+		checkpoints:  map[int]algorithm.ReduceCheckpoint{},
+	}
 }
 
 type inputMapper struct {
-	pipeline    db.Pipeline
-	transformer inputconfig.Transformer
+	pipeline     db.Pipeline
+	transformer  inputconfig.Transformer
+	versionLimit int
+
+	checkpointsLock sync.Mutex
+	checkpoints     map[int]algorithm.ReduceCheckpoint
 }
 
 func (i *inputMapper) SaveNextInputMapping(
@@ -36,6 +94,83 @@ func (i *inputMapper) SaveNextInputMapping(
 ) (algorithm.InputMapping, error) {
 	logger = logger.Session("save-next-input-mapping")
 
+	resolution, err := i.ResolveNextInputMapping(logger, versions, job, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	err = job.SaveIndependentInputMapping(resolution.Independent)
+	if err != nil {
+		logger.Error("failed-to-save-independent-input-mapping", err)
+		return nil, err
+	}
+
+	if len(resolution.Unsatisfied) > 0 {
+		// this is necessary to prevent builds from running with missing pinned versions
+		err := job.DeleteNextInputMapping()
+		if err != nil {
+			logger.Error("failed-to-delete-next-input-mapping-after-missing-pending", err)
+		}
+
+		return nil, err
+	}
+
+	if resolution.Mapping == nil {
+		err := job.DeleteNextInputMapping()
+		if err != nil {
+			logger.Error("failed-to-delete-next-input-mapping-after-failed-resolve", err)
+			return nil, err
+		}
+
+		if resolution.Incomplete {
+			logger.Info("resolution-incomplete", lager.Data{"version-limit": i.versionLimit})
+			return nil, ErrResolutionIncomplete
+		}
+
+		return nil, nil
+	}
+
+	err = job.SaveNextInputMapping(resolution.Mapping)
+	if err != nil {
+		logger.Error("failed-to-save-next-input-mapping", err)
+		return nil, err
+	}
+
+	return resolution.Mapping, nil
+}
+
+// ResolveNextInputMapping determines the job's next inputs without saving
+// them.
+func (i *inputMapper) ResolveNextInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	resources db.Resources,
+) (Resolution, error) {
+	return i.resolve(logger, versions, job, resources, nil)
+}
+
+// ResolveBuildInputMapping determines the inputs of a single build whose
+// versions of some inputs were chosen when it was triggered. The overridden
+// inputs are pinned to their versions, ignoring their passed constraints,
+// and the rest are determined as usual.
+func (i *inputMapper) ResolveBuildInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	resources db.Resources,
+	overrides atc.InputVersionOverrides,
+) (Resolution, error) {
+	return i.resolve(logger.Session("resolve-build-input-mapping"), versions, job, resources, overrides)
+}
+
+func (i *inputMapper) resolve(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	resources db.Resources,
+	overrides atc.InputVersionOverrides,
+) (Resolution, error) {
 	inputConfigs := job.Config().Inputs()
 
 	for i, inputConfig := range inputConfigs {
@@ -46,6 +181,12 @@ func (i *inputMapper) SaveNextInputMapping(
 			continue
 		}
 
+		if version, overridden := overrides[inputConfig.Name]; overridden {
+			inputConfigs[i].Version = &atc.VersionConfig{Pinned: version}
+			inputConfigs[i].Passed = nil
+			continue
+		}
+
 		if inputConfig.Version != nil && inputConfig.Version.Pinned != nil {
 			continue
 		}
@@ -58,48 +199,60 @@ func (i *inputMapper) SaveNextInputMapping(
 	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
 	if err != nil {
 		logger.Error("failed-to-get-algorithm-input-configs", err)
-		return nil, err
+		return Resolution{}, err
+	}
+
+	resolution := Resolution{
+		Independent: algorithm.InputMapping{},
 	}
 
-	independentMapping := algorithm.InputMapping{}
 	for _, inputConfig := range algorithmInputConfigs {
+		if _, overridden := overrides[inputConfig.Name]; overridden && inputConfig.PinnedVersionID == 0 {
+			// the overridden version no longer exists
+			continue
+		}
+
 		singletonMapping, ok := algorithm.InputConfigs{inputConfig}.Resolve(versions)
 		if ok {
-			independentMapping[inputConfig.Name] = singletonMapping[inputConfig.Name]
+			resolution.Independent[inputConfig.Name] = singletonMapping[inputConfig.Name]
 		}
 	}
 
-	err = job.SaveIndependentInputMapping(independentMapping)
-	if err != nil {
-		logger.Error("failed-to-save-independent-input-mapping", err)
-		return nil, err
+	for _, inputConfig := range inputConfigs {
+		if _, found := resolution.Independent[inputConfig.Name]; !found {
+			resolution.Unsatisfied = append(resolution.Unsatisfied, inputConfig.Name)
+		}
 	}
 
-	if len(independentMapping) < len(inputConfigs) {
-		// this is necessary to prevent builds from running with missing pinned versions
-		err := job.DeleteNextInputMapping()
-		if err != nil {
-			logger.Error("failed-to-delete-next-input-mapping-after-missing-pending", err)
+	if len(resolution.Unsatisfied) > 0 {
+		return resolution, nil
+	}
+
+	// builds whose inputs were overridden are resolved in full, as they're
+	// only resolved the once
+	if overrides != nil {
+		resolvedMapping, ok := algorithmInputConfigs.Resolve(versions)
+		if ok {
+			resolution.Mapping = resolvedMapping
 		}
 
-		return nil, err
+		return resolution, nil
 	}
 
-	resolvedMapping, ok := algorithmInputConfigs.Resolve(versions)
-	if !ok {
-		err := job.DeleteNextInputMapping()
-		if err != nil {
-			logger.Error("failed-to-delete-next-input-mapping-after-failed-resolve", err)
-		}
+	i.checkpointsLock.Lock()
+	defer i.checkpointsLock.Unlock()
 
-		return nil, err
+	resolvedMapping, checkpoint, ok := algorithmInputConfigs.ResolveWithLimit(versions, i.versionLimit, i.checkpoints[job.ID()])
+	if ok {
+		resolution.Mapping = resolvedMapping
 	}
 
-	err = job.SaveNextInputMapping(resolvedMapping)
-	if err != nil {
-		logger.Error("failed-to-save-next-input-mapping", err)
-		return nil, err
+	if checkpoint != nil {
+		resolution.Incomplete = true
+		i.checkpoints[job.ID()] = checkpoint
+	} else {
+		delete(i.checkpoints, job.ID())
 	}
 
-	return resolvedMapping, nil
+	return resolution, nil
 }
@@ -29,7 +29,7 @@ var _ = Describe("Inputmapper", func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakeTransformer = new(inputconfigfakes.FakeTransformer)
 
-		inputMapper = inputmapper.NewInputMapper(fakePipeline, fakeTransformer)
+		inputMapper = inputmapper.NewInputMapper(fakePipeline, fakeTransformer, 0)
 
 		disaster = errors.New("bad thing")
 	})
@@ -261,6 +261,67 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 
+		Context("when resolving the inputs together explores more versions than the limit", func() {
+			BeforeEach(func() {
+				inputMapper = inputmapper.NewInputMapper(fakePipeline, fakeTransformer, 1)
+
+				versionsDB.ResourceVersions = []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 3, ResourceID: 11, CheckOrder: 2},
+					{VersionID: 4, ResourceID: 11, CheckOrder: 3},
+				}
+
+				versionsDB.BuildInputs = []algorithm.BuildInput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+						BuildID:         97,
+						JobID:           1,
+						InputName:       "a",
+					},
+				}
+
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.IDReturns(1)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Get: "a", Version: &atc.VersionConfig{Every: true}},
+					},
+				})
+
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{
+						Name:            "a",
+						ResourceID:      11,
+						Passed:          algorithm.JobSet{},
+						UseEveryVersion: true,
+						JobID:           1,
+					},
+				}, nil)
+			})
+
+			It("deleted the next input mapping", func() {
+				Expect(fakeJob.DeleteNextInputMappingCallCount()).To(Equal(1))
+				Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+			})
+
+			It("returns ErrResolutionIncomplete", func() {
+				Expect(mappingErr).To(Equal(inputmapper.ErrResolutionIncomplete))
+			})
+
+			It("resumes from where it left off next time", func() {
+				mapping, err := inputMapper.SaveNextInputMapping(
+					lagertest.NewTestLogger("test"),
+					versionsDB,
+					fakeJob,
+					resources,
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mapping["a"].VersionID).To(Equal(3))
+				Expect(fakeJob.SaveNextInputMappingCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when some inputs don't resolve", func() {
 			BeforeEach(func() {
 				fakeJob = new(dbfakes.FakeJob)
@@ -583,4 +644,237 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 	})
+
+	Describe("ResolveNextInputMapping", func() {
+		var (
+			versionsDB    *algorithm.VersionsDB
+			fakeJob       *dbfakes.FakeJob
+			resolution    inputmapper.Resolution
+			resolutionErr error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11, "b": 12, "no-versions": 13},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+						BuildID:         98,
+						JobID:           2,
+					},
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+						BuildID:         99,
+						JobID:           2,
+					},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+		})
+
+		JustBeforeEach(func() {
+			resolution, resolutionErr = inputMapper.ResolveNextInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+				db.Resources{},
+			)
+		})
+
+		AfterEach(func() {
+			Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.DeleteNextInputMappingCallCount()).To(BeZero())
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(resolutionErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when inputs resolve", func() {
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Get: "a", Version: &atc.VersionConfig{Latest: true}},
+						{Get: "b", Version: &atc.VersionConfig{Latest: true}},
+					},
+				})
+
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{Name: "a", ResourceID: 11, Passed: algorithm.JobSet{}, JobID: 1},
+					{Name: "b", ResourceID: 12, Passed: algorithm.JobSet{}, JobID: 1},
+				}, nil)
+			})
+
+			It("returns the resolved mapping without saving it", func() {
+				Expect(resolutionErr).NotTo(HaveOccurred())
+				Expect(resolution.Unsatisfied).To(BeEmpty())
+				Expect(resolution.Mapping).To(Equal(algorithm.InputMapping{
+					"a": algorithm.InputVersion{VersionID: 1, ResourceID: 11, FirstOccurrence: true},
+					"b": algorithm.InputVersion{VersionID: 2, ResourceID: 12, FirstOccurrence: true},
+				}))
+				Expect(resolution.Independent).To(Equal(resolution.Mapping))
+			})
+		})
+
+		Context("when some inputs don't resolve", func() {
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Get: "a", Version: &atc.VersionConfig{Latest: true}},
+						{Get: "no-versions", Version: &atc.VersionConfig{Latest: true}},
+					},
+				})
+
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{Name: "a", ResourceID: 11, Passed: algorithm.JobSet{}, JobID: 1},
+					{Name: "no-versions", ResourceID: 13, Passed: algorithm.JobSet{}, JobID: 1},
+				}, nil)
+			})
+
+			It("names the unsatisfied inputs and returns no mapping", func() {
+				Expect(resolutionErr).NotTo(HaveOccurred())
+				Expect(resolution.Unsatisfied).To(Equal([]string{"no-versions"}))
+				Expect(resolution.Independent).To(Equal(algorithm.InputMapping{
+					"a": algorithm.InputVersion{VersionID: 1, ResourceID: 11, FirstOccurrence: true},
+				}))
+				Expect(resolution.Mapping).To(BeNil())
+			})
+		})
+
+		Context("when inputs only resolve individually", func() {
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Get: "a", Version: &atc.VersionConfig{Latest: true}, Passed: []string{"upstream"}},
+						{Get: "b", Version: &atc.VersionConfig{Latest: true}, Passed: []string{"upstream"}},
+					},
+				})
+
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{Name: "a", ResourceID: 11, Passed: algorithm.JobSet{2: struct{}{}}, JobID: 1},
+					{Name: "b", ResourceID: 12, Passed: algorithm.JobSet{2: struct{}{}}, JobID: 1},
+				}, nil)
+			})
+
+			It("returns no mapping and no unsatisfied inputs", func() {
+				Expect(resolutionErr).NotTo(HaveOccurred())
+				Expect(resolution.Unsatisfied).To(BeEmpty())
+				Expect(resolution.Independent).To(HaveLen(2))
+				Expect(resolution.Mapping).To(BeNil())
+			})
+		})
+	})
+
+	Describe("ResolveBuildInputMapping", func() {
+		var (
+			versionsDB    *algorithm.VersionsDB
+			fakeJob       *dbfakes.FakeJob
+			resources     db.Resources
+			resolution    inputmapper.Resolution
+			resolutionErr error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11, "b": 12},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 3, ResourceID: 11, CheckOrder: 2},
+					{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "a", Passed: []string{"upstream"}},
+					{Get: "b"},
+				},
+			})
+
+			resourceA := new(dbfakes.FakeResource)
+			resourceA.NameReturns("a")
+			resourceA.CurrentPinnedVersionReturns(atc.Version{"pinned": "version"})
+
+			resourceB := new(dbfakes.FakeResource)
+			resourceB.NameReturns("b")
+
+			resources = db.Resources{resourceA, resourceB}
+
+			fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+				{Name: "a", ResourceID: 11, PinnedVersionID: 1, Passed: algorithm.JobSet{}, JobID: 1},
+				{Name: "b", ResourceID: 12, Passed: algorithm.JobSet{}, JobID: 1},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			resolution, resolutionErr = inputMapper.ResolveBuildInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+				resources,
+				atc.InputVersionOverrides{"a": atc.Version{"ref": "v1"}},
+			)
+		})
+
+		It("pins the overridden inputs without their passed constraints", func() {
+			Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(1))
+			_, _, actualJobInputs := fakeTransformer.TransformInputConfigsArgsForCall(0)
+			Expect(actualJobInputs).To(ConsistOf(
+				atc.JobInput{
+					Name:     "a",
+					Resource: "a",
+					Version:  &atc.VersionConfig{Pinned: atc.Version{"ref": "v1"}},
+				},
+				atc.JobInput{
+					Name:     "b",
+					Resource: "b",
+				},
+			))
+		})
+
+		It("returns the mapping of every input", func() {
+			Expect(resolutionErr).NotTo(HaveOccurred())
+			Expect(resolution.Mapping).To(Equal(algorithm.InputMapping{
+				"a": algorithm.InputVersion{VersionID: 1, ResourceID: 11, FirstOccurrence: true},
+				"b": algorithm.InputVersion{VersionID: 2, ResourceID: 12, FirstOccurrence: true},
+			}))
+		})
+
+		It("does not save any mapping", func() {
+			Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+		})
+
+		Context("when an overridden version no longer exists", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{Name: "a", ResourceID: 11, Passed: algorithm.JobSet{}, JobID: 1},
+					{Name: "b", ResourceID: 12, Passed: algorithm.JobSet{}, JobID: 1},
+				}, nil)
+			})
+
+			It("leaves the input unsatisfied rather than using the latest version", func() {
+				Expect(resolutionErr).NotTo(HaveOccurred())
+				Expect(resolution.Unsatisfied).To(Equal([]string{"a"}))
+				Expect(resolution.Mapping).To(BeNil())
+			})
+		})
+	})
 })
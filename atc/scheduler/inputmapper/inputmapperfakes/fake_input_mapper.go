@@ -5,12 +5,46 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper"
 )
 
 type FakeInputMapper struct {
+	ResolveBuildInputMappingStub        func(lager.Logger, *algorithm.VersionsDB, db.Job, db.Resources, atc.InputVersionOverrides) (inputmapper.Resolution, error)
+	resolveBuildInputMappingMutex       sync.RWMutex
+	resolveBuildInputMappingArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *algorithm.VersionsDB
+		arg3 db.Job
+		arg4 db.Resources
+		arg5 atc.InputVersionOverrides
+	}
+	resolveBuildInputMappingReturns struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}
+	resolveBuildInputMappingReturnsOnCall map[int]struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}
+	ResolveNextInputMappingStub        func(lager.Logger, *algorithm.VersionsDB, db.Job, db.Resources) (inputmapper.Resolution, error)
+	resolveNextInputMappingMutex       sync.RWMutex
+	resolveNextInputMappingArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *algorithm.VersionsDB
+		arg3 db.Job
+		arg4 db.Resources
+	}
+	resolveNextInputMappingReturns struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}
+	resolveNextInputMappingReturnsOnCall map[int]struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}
 	SaveNextInputMappingStub        func(lager.Logger, *algorithm.VersionsDB, db.Job, db.Resources) (algorithm.InputMapping, error)
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -31,6 +65,139 @@ type FakeInputMapper struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeInputMapper) ResolveBuildInputMapping(arg1 lager.Logger, arg2 *algorithm.VersionsDB, arg3 db.Job, arg4 db.Resources, arg5 atc.InputVersionOverrides) (inputmapper.Resolution, error) {
+	fake.resolveBuildInputMappingMutex.Lock()
+	ret, specificReturn := fake.resolveBuildInputMappingReturnsOnCall[len(fake.resolveBuildInputMappingArgsForCall)]
+	fake.resolveBuildInputMappingArgsForCall = append(fake.resolveBuildInputMappingArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *algorithm.VersionsDB
+		arg3 db.Job
+		arg4 db.Resources
+		arg5 atc.InputVersionOverrides
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("ResolveBuildInputMapping", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.resolveBuildInputMappingMutex.Unlock()
+	if fake.ResolveBuildInputMappingStub != nil {
+		return fake.ResolveBuildInputMappingStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resolveBuildInputMappingReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInputMapper) ResolveBuildInputMappingCallCount() int {
+	fake.resolveBuildInputMappingMutex.RLock()
+	defer fake.resolveBuildInputMappingMutex.RUnlock()
+	return len(fake.resolveBuildInputMappingArgsForCall)
+}
+
+func (fake *FakeInputMapper) ResolveBuildInputMappingCalls(stub func(lager.Logger, *algorithm.VersionsDB, db.Job, db.Resources, atc.InputVersionOverrides) (inputmapper.Resolution, error)) {
+	fake.resolveBuildInputMappingMutex.Lock()
+	defer fake.resolveBuildInputMappingMutex.Unlock()
+	fake.ResolveBuildInputMappingStub = stub
+}
+
+func (fake *FakeInputMapper) ResolveBuildInputMappingArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, db.Job, db.Resources, atc.InputVersionOverrides) {
+	fake.resolveBuildInputMappingMutex.RLock()
+	defer fake.resolveBuildInputMappingMutex.RUnlock()
+	argsForCall := fake.resolveBuildInputMappingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeInputMapper) ResolveBuildInputMappingReturns(result1 inputmapper.Resolution, result2 error) {
+	fake.resolveBuildInputMappingMutex.Lock()
+	defer fake.resolveBuildInputMappingMutex.Unlock()
+	fake.ResolveBuildInputMappingStub = nil
+	fake.resolveBuildInputMappingReturns = struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) ResolveBuildInputMappingReturnsOnCall(i int, result1 inputmapper.Resolution, result2 error) {
+	fake.resolveBuildInputMappingMutex.Lock()
+	defer fake.resolveBuildInputMappingMutex.Unlock()
+	fake.ResolveBuildInputMappingStub = nil
+	if fake.resolveBuildInputMappingReturnsOnCall == nil {
+		fake.resolveBuildInputMappingReturnsOnCall = make(map[int]struct {
+			result1 inputmapper.Resolution
+			result2 error
+		})
+	}
+	fake.resolveBuildInputMappingReturnsOnCall[i] = struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) ResolveNextInputMapping(arg1 lager.Logger, arg2 *algorithm.VersionsDB, arg3 db.Job, arg4 db.Resources) (inputmapper.Resolution, error) {
+	fake.resolveNextInputMappingMutex.Lock()
+	ret, specificReturn := fake.resolveNextInputMappingReturnsOnCall[len(fake.resolveNextInputMappingArgsForCall)]
+	fake.resolveNextInputMappingArgsForCall = append(fake.resolveNextInputMappingArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *algorithm.VersionsDB
+		arg3 db.Job
+		arg4 db.Resources
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ResolveNextInputMapping", []interface{}{arg1, arg2, arg3, arg4})
+	fake.resolveNextInputMappingMutex.Unlock()
+	if fake.ResolveNextInputMappingStub != nil {
+		return fake.ResolveNextInputMappingStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resolveNextInputMappingReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInputMapper) ResolveNextInputMappingCallCount() int {
+	fake.resolveNextInputMappingMutex.RLock()
+	defer fake.resolveNextInputMappingMutex.RUnlock()
+	return len(fake.resolveNextInputMappingArgsForCall)
+}
+
+func (fake *FakeInputMapper) ResolveNextInputMappingCalls(stub func(lager.Logger, *algorithm.VersionsDB, db.Job, db.Resources) (inputmapper.Resolution, error)) {
+	fake.resolveNextInputMappingMutex.Lock()
+	defer fake.resolveNextInputMappingMutex.Unlock()
+	fake.ResolveNextInputMappingStub = stub
+}
+
+func (fake *FakeInputMapper) ResolveNextInputMappingArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, db.Job, db.Resources) {
+	fake.resolveNextInputMappingMutex.RLock()
+	defer fake.resolveNextInputMappingMutex.RUnlock()
+	argsForCall := fake.resolveNextInputMappingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeInputMapper) ResolveNextInputMappingReturns(result1 inputmapper.Resolution, result2 error) {
+	fake.resolveNextInputMappingMutex.Lock()
+	defer fake.resolveNextInputMappingMutex.Unlock()
+	fake.ResolveNextInputMappingStub = nil
+	fake.resolveNextInputMappingReturns = struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) ResolveNextInputMappingReturnsOnCall(i int, result1 inputmapper.Resolution, result2 error) {
+	fake.resolveNextInputMappingMutex.Lock()
+	defer fake.resolveNextInputMappingMutex.Unlock()
+	fake.ResolveNextInputMappingStub = nil
+	if fake.resolveNextInputMappingReturnsOnCall == nil {
+		fake.resolveNextInputMappingReturnsOnCall = make(map[int]struct {
+			result1 inputmapper.Resolution
+			result2 error
+		})
+	}
+	fake.resolveNextInputMappingReturnsOnCall[i] = struct {
+		result1 inputmapper.Resolution
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInputMapper) SaveNextInputMapping(arg1 lager.Logger, arg2 *algorithm.VersionsDB, arg3 db.Job, arg4 db.Resources) (algorithm.InputMapping, error) {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -100,6 +267,10 @@ func (fake *FakeInputMapper) SaveNextInputMappingReturnsOnCall(i int, result1 al
 func (fake *FakeInputMapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.resolveBuildInputMappingMutex.RLock()
+	defer fake.resolveBuildInputMappingMutex.RUnlock()
+	fake.resolveNextInputMappingMutex.RLock()
+	defer fake.resolveNextInputMappingMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -83,27 +83,24 @@ func (runner *Runner) tick(logger lager.Logger) error {
 
 	start := time.Now()
 
+	var jobsEvaluated int
+	var tickErr error
+
 	defer func() {
+		duration := time.Since(start)
+
 		metric.SchedulingFullDuration{
 			PipelineName: runner.Pipeline.Name(),
-			Duration:     time.Since(start),
+			Duration:     duration,
 		}.Emit(logger)
-	}()
-
-	versions, err := runner.Pipeline.LoadVersionsDB()
-	if err != nil {
-		logger.Error("failed-to-load-versions-db", err)
-		return err
-	}
 
-	metric.SchedulingLoadVersionsDuration{
-		PipelineName: runner.Pipeline.Name(),
-		Duration:     time.Since(start),
-	}.Emit(logger)
+		runner.saveTick(logger, start, duration, jobsEvaluated, tickErr)
+	}()
 
 	found, err := runner.Pipeline.Reload()
 	if err != nil {
 		logger.Error("failed-to-update-pipeline-config", err)
+		tickErr = err
 		return nil
 	}
 
@@ -114,23 +111,47 @@ func (runner *Runner) tick(logger lager.Logger) error {
 	resources, err := runner.Pipeline.Resources()
 	if err != nil {
 		logger.Error("failed-to-get-resources", err)
+		tickErr = err
 		return err
 	}
 
 	jobs, err := runner.Pipeline.Jobs()
 	if err != nil {
 		logger.Error("failed-to-get-jobs", err)
+		tickErr = err
 		return err
 	}
 
 	resourceTypes, err := runner.Pipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resource-types", err)
+		tickErr = err
 		return err
 	}
 
+	// the jobs are loaded before the versions so that anything invalidating
+	// their input resolutions in the meantime isn't missed
+	var versions *algorithm.VersionsDB
+	if !inputResolutionsCached(jobs) {
+		loadStart := time.Now()
+
+		versions, err = runner.Pipeline.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			tickErr = err
+			return err
+		}
+
+		metric.SchedulingLoadVersionsDuration{
+			PipelineName: runner.Pipeline.Name(),
+			Duration:     time.Since(loadStart),
+		}.Emit(logger)
+	}
+
 	sLog := logger.Session("scheduling")
 
+	jobsEvaluated = len(jobs)
+
 	schedulingTimes, err := runner.Scheduler.Schedule(
 		sLog,
 		versions,
@@ -147,5 +168,36 @@ func (runner *Runner) tick(logger lager.Logger) error {
 		}.Emit(sLog)
 	}
 
+	tickErr = err
+
 	return err
 }
+
+// inputResolutionsCached returns true if none of the jobs' inputs need to be
+// resolved, in which case the versions they'd be resolved from aren't needed.
+func inputResolutionsCached(jobs []db.Job) bool {
+	for _, job := range jobs {
+		if !job.InputResolutionCached() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// saveTick records how the tick went, so that it can be seen without
+// digging through the logs of whichever ATC ran it.
+func (runner *Runner) saveTick(logger lager.Logger, start time.Time, duration time.Duration, jobsEvaluated int, tickErr error) {
+	tick, err := runner.Pipeline.SaveSchedulingTick(start, duration, jobsEvaluated, tickErr)
+	if err != nil {
+		logger.Error("failed-to-save-scheduling-tick", err)
+		return
+	}
+
+	metric.SchedulingTick{
+		PipelineName:  runner.Pipeline.Name(),
+		JobsEvaluated: tick.JobsEvaluated,
+		BuildsCreated: tick.BuildsCreated,
+		Errored:       tick.Error != "",
+	}.Emit(logger)
+}
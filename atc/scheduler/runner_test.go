@@ -178,6 +178,57 @@ var _ = Describe("Runner", func() {
 		Expect(resourceTypes).To(Equal(versionedResourceTypes))
 	})
 
+	Context("when every job's input resolution is cached", func() {
+		BeforeEach(func() {
+			fakeJob1.InputResolutionCachedReturns(true)
+			fakeJob2.InputResolutionCachedReturns(true)
+		})
+
+		It("schedules without loading the versions", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
+
+			_, versions, _, _, _ := scheduler.ScheduleArgsForCall(0)
+			Expect(versions).To(BeNil())
+			Expect(fakePipeline.LoadVersionsDBCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when only some jobs' input resolutions are cached", func() {
+		BeforeEach(func() {
+			fakeJob1.InputResolutionCachedReturns(true)
+		})
+
+		It("loads the versions for the others", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
+
+			_, versions, _, _, _ := scheduler.ScheduleArgsForCall(0)
+			Expect(versions).To(Equal(someVersions))
+		})
+	})
+
+	It("saves how each tick went", func() {
+		Eventually(fakePipeline.SaveSchedulingTickCallCount).Should(BeNumerically(">=", 1))
+
+		start, duration, jobsEvaluated, tickErr := fakePipeline.SaveSchedulingTickArgsForCall(0)
+		Expect(start).To(BeTemporally("~", time.Now(), time.Second))
+		Expect(duration).To(BeNumerically(">=", 0))
+		Expect(jobsEvaluated).To(Equal(2))
+		Expect(tickErr).ToNot(HaveOccurred())
+	})
+
+	Context("when scheduling fails", func() {
+		BeforeEach(func() {
+			scheduler.ScheduleReturns(nil, errors.New("nope"))
+		})
+
+		It("saves the error with the tick", func() {
+			Eventually(fakePipeline.SaveSchedulingTickCallCount).Should(BeNumerically(">=", 1))
+
+			_, _, _, tickErr := fakePipeline.SaveSchedulingTickArgsForCall(0)
+			Expect(tickErr).To(MatchError("nope"))
+		})
+	})
+
 	Context("when in noop mode", func() {
 		BeforeEach(func() {
 			noop = true
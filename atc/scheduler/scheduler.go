@@ -3,6 +3,7 @@ package scheduler
 import (
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -14,6 +15,7 @@ type Scheduler struct {
 	Pipeline     db.Pipeline
 	InputMapper  inputmapper.InputMapper
 	BuildStarter BuildStarter
+	Clock        clock.Clock
 }
 
 func (s *Scheduler) Schedule(
@@ -65,12 +67,26 @@ func (s *Scheduler) ensurePendingBuildExists(
 	job db.Job,
 	resources db.Resources,
 ) error {
+	// nothing the job's inputs depend on has changed since they were last
+	// resolved, so resolving them again would come to the same conclusion
+	if job.InputResolutionCached() {
+		return nil
+	}
+
 	inputMapping, err := s.InputMapper.SaveNextInputMapping(logger, versions, job, resources)
+	if err == inputmapper.ErrResolutionIncomplete {
+		// resolving the inputs picks up where it left off on the next tick,
+		// so don't cache the resolution
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
 
-	var hasNewInputs bool
+	withinWindows := withinSchedulingWindows(s.Pipeline, job, s.Clock.Now())
+
+	var hasNewInputs, deferred bool
 	for _, inputConfig := range job.Config().Inputs() {
 		inputVersion, ok := inputMapping[inputConfig.Name]
 
@@ -78,6 +94,12 @@ func (s *Scheduler) ensurePendingBuildExists(
 		if ok && inputVersion.FirstOccurrence {
 			hasNewInputs = true
 			if inputConfig.Trigger {
+				if !withinWindows {
+					logger.Debug("outside-scheduling-windows", lager.Data{"job": job.Name()})
+					deferred = true
+					break
+				}
+
 				err := job.EnsurePendingBuildExists()
 				if err != nil {
 					logger.Error("failed-to-ensure-pending-build-exists", err)
@@ -95,5 +117,29 @@ func (s *Scheduler) ensurePendingBuildExists(
 		}
 	}
 
+	// a build deferred until the scheduling windows allow it has to be
+	// triggered by a later tick, so the inputs must be looked at again
+	if deferred {
+		return nil
+	}
+
+	err = job.CacheInputResolution()
+	if err != nil {
+		logger.Error("failed-to-cache-input-resolution", err)
+		return err
+	}
+
 	return nil
 }
+
+// withinSchedulingWindows returns whether the job's scheduling windows, or
+// the pipeline's if it has none of its own, allow builds to be triggered
+// automatically at the given time.
+func withinSchedulingWindows(pipeline db.Pipeline, job db.Job, now time.Time) bool {
+	windows := job.Config().SchedulingWindows
+	if windows == nil {
+		windows = pipeline.SchedulingWindows()
+	}
+
+	return windows == nil || windows.InTimeZone(pipeline.TimeZone()).Allows(now)
+}
@@ -2,13 +2,16 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/scheduler"
+	"github.com/concourse/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
 	. "github.com/onsi/ginkgo"
@@ -20,6 +23,7 @@ var _ = Describe("Scheduler", func() {
 		fakePipeline     *dbfakes.FakePipeline
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
+		fakeClock        *fakeclock.FakeClock
 
 		scheduler *Scheduler
 
@@ -31,10 +35,14 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 
+		// a Saturday at noon
+		fakeClock = fakeclock.NewFakeClock(time.Date(2019, 11, 16, 12, 0, 0, 0, time.UTC))
+
 		scheduler = &Scheduler{
 			Pipeline:     fakePipeline,
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
+			Clock:        fakeClock,
 		}
 
 		disaster = errors.New("bad thing")
@@ -111,6 +119,45 @@ var _ = Describe("Scheduler", func() {
 				It("returns the error", func() {
 					Expect(scheduleErr).To(Equal(disaster))
 				})
+
+				It("doesn't cache the input resolution", func() {
+					Expect(fakeJob.CacheInputResolutionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when resolving the next inputs is incomplete", func() {
+				BeforeEach(func() {
+					fakeInputMapper.SaveNextInputMappingReturns(nil, inputmapper.ErrResolutionIncomplete)
+				})
+
+				It("doesn't return an error", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+				})
+
+				It("doesn't cache the input resolution, so it's resumed next time", func() {
+					Expect(fakeJob.CacheInputResolutionCallCount()).To(BeZero())
+				})
+
+				It("still starts their pending builds", func() {
+					Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when the jobs' input resolutions are cached", func() {
+				BeforeEach(func() {
+					fakeJob.InputResolutionCachedReturns(true)
+					fakeJob2.InputResolutionCachedReturns(true)
+				})
+
+				It("doesn't resolve their inputs again", func() {
+					Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+					Expect(fakeJob.CacheInputResolutionCallCount()).To(BeZero())
+				})
+
+				It("still starts their pending builds", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(2))
+				})
 			})
 
 			Context("when saving the next input mapping succeeds", func() {
@@ -129,6 +176,21 @@ var _ = Describe("Scheduler", func() {
 					Expect(actualJob.Name()).To(Equal(fakeJob2.Name()))
 				})
 
+				It("caches the jobs' input resolutions", func() {
+					Expect(fakeJob.CacheInputResolutionCallCount()).To(Equal(1))
+					Expect(fakeJob2.CacheInputResolutionCallCount()).To(Equal(1))
+				})
+
+				Context("when caching an input resolution fails", func() {
+					BeforeEach(func() {
+						fakeJob.CacheInputResolutionReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+
 				Context("when starting pending builds for job fails", func() {
 					BeforeEach(func() {
 						fakeBuildStarter.TryStartPendingBuildsForJobReturns(disaster)
@@ -292,6 +354,60 @@ var _ = Describe("Scheduler", func() {
 						Expect(scheduleErr).NotTo(HaveOccurred())
 					})
 				})
+
+				Context("when the pipeline's scheduling windows deny the current time", func() {
+					BeforeEach(func() {
+						fakePipeline.SchedulingWindowsReturns(&atc.SchedulingWindows{
+							Deny: []atc.SchedulingWindow{{Days: []string{"Saturday", "Sunday"}}},
+						})
+					})
+
+					It("doesn't create a pending build", func() {
+						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					})
+
+					It("still marks the job as having new inputs", func() {
+						Expect(fakeJob.SetHasNewInputsCallCount()).To(Equal(1))
+						Expect(fakeJob.SetHasNewInputsArgsForCall(0)).To(BeTrue())
+					})
+
+					It("doesn't cache the input resolution, so that the build is triggered once the windows allow it", func() {
+						Expect(fakeJob.CacheInputResolutionCallCount()).To(BeZero())
+					})
+
+					It("leaves pending builds which were already created to the build starter", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+						Expect(scheduleErr).NotTo(HaveOccurred())
+					})
+
+					Context("when the job's own scheduling windows allow the current time", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Plan: atc.PlanSequence{
+									{Get: "a", Trigger: true},
+									{Get: "b", Trigger: false},
+								},
+								SchedulingWindows: &atc.SchedulingWindows{
+									Allow: []atc.SchedulingWindow{{Start: "09:00", Stop: "17:00"}},
+								},
+							})
+						})
+
+						It("creates a pending build", func() {
+							Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+						})
+
+						Context("when the pipeline is in a time zone where the current time is outside them", func() {
+							BeforeEach(func() {
+								fakePipeline.TimeZoneReturns("Asia/Tokyo")
+							})
+
+							It("doesn't create a pending build", func() {
+								Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+							})
+						})
+					})
+				})
 			})
 
 			Context("when no first occurrence", func() {
@@ -0,0 +1,138 @@
+package startlimit
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Limiter
+
+// Limiter caps the rate at which builds are started, both across the whole
+// installation and per team, so that a backlog of builds becoming
+// schedulable at once (e.g. after an outage) is started gradually.
+type Limiter interface {
+	// Allow reserves a build start for the pipeline's team, returning false
+	// if either limit has been reached.
+	Allow(logger lager.Logger, pipeline db.Pipeline) (bool, error)
+
+	// Refund gives back a build start reserved by Allow, for when the build
+	// could not be scheduled after all.
+	Refund(logger lager.Logger, pipeline db.Pipeline)
+}
+
+// TeamLimitCacheDuration is how long a team's configured limit is used before
+// it is looked up again.
+var TeamLimitCacheDuration = time.Minute
+
+// NewLimiter returns a Limiter allowing at most globalPerMinute build starts
+// per minute across all teams, and at most each team's configured
+// MaxBuildStartsPerMinute for the team's own builds. A limit of 0 means
+// unlimited.
+//
+// Build starts are counted in the given buckets, which are shared by every
+// ATC, so the limits hold for the whole installation however many ATCs are
+// scheduling builds.
+func NewLimiter(clock clock.Clock, teamFactory db.TeamFactory, buckets db.BuildStartBuckets, globalPerMinute int) Limiter {
+	return &limiter{
+		clock:           clock,
+		teamFactory:     teamFactory,
+		buckets:         buckets,
+		globalPerMinute: globalPerMinute,
+		teams:           map[int]teamLimit{},
+	}
+}
+
+type limiter struct {
+	clock           clock.Clock
+	teamFactory     db.TeamFactory
+	buckets         db.BuildStartBuckets
+	globalPerMinute int
+
+	lock  sync.Mutex
+	teams map[int]teamLimit
+}
+
+// teamLimit is a team's cached limit.
+type teamLimit struct {
+	perMinute int
+	fetchedAt time.Time
+}
+
+func (l *limiter) Allow(logger lager.Logger, pipeline db.Pipeline) (bool, error) {
+	teamPerMinute, err := l.teamLimit(logger, pipeline)
+	if err != nil {
+		return false, err
+	}
+
+	if l.globalPerMinute == 0 && teamPerMinute == 0 {
+		return true, nil
+	}
+
+	allowed, err := l.buckets.Reserve(pipeline.TeamID(), l.globalPerMinute, teamPerMinute)
+	if err != nil {
+		logger.Error("failed-to-reserve-build-start", err)
+		return false, err
+	}
+
+	if !allowed {
+		logger.Debug("build-start-limit-reached", lager.Data{
+			"global-limit": l.globalPerMinute,
+			"team-limit":   teamPerMinute,
+		})
+	}
+
+	return allowed, nil
+}
+
+func (l *limiter) Refund(logger lager.Logger, pipeline db.Pipeline) {
+	l.lock.Lock()
+	teamPerMinute := l.teams[pipeline.TeamID()].perMinute
+	l.lock.Unlock()
+
+	// nothing was reserved if there were no limits
+	if l.globalPerMinute == 0 && teamPerMinute == 0 {
+		return
+	}
+
+	err := l.buckets.Refund(pipeline.TeamID())
+	if err != nil {
+		logger.Error("failed-to-refund-build-start", err)
+	}
+}
+
+// teamLimit returns the pipeline's team's limit, looking it up if the cached
+// one has expired. The lookup is done without holding the lock so that a slow
+// query doesn't hold up other teams.
+func (l *limiter) teamLimit(logger lager.Logger, pipeline db.Pipeline) (int, error) {
+	l.lock.Lock()
+	cached, found := l.teams[pipeline.TeamID()]
+	l.lock.Unlock()
+
+	if found && l.clock.Since(cached.fetchedAt) < TeamLimitCacheDuration {
+		return cached.perMinute, nil
+	}
+
+	team, found, err := l.teamFactory.FindTeam(pipeline.TeamName())
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return 0, err
+	}
+
+	var perMinute int
+	if found {
+		perMinute = team.MaxBuildStartsPerMinute()
+	}
+
+	l.lock.Lock()
+	l.teams[pipeline.TeamID()] = teamLimit{
+		perMinute: perMinute,
+		fetchedAt: l.clock.Now(),
+	}
+	l.lock.Unlock()
+
+	return perMinute, nil
+}
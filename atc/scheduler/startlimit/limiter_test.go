@@ -0,0 +1,169 @@
+package startlimit_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/scheduler/startlimit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Limiter", func() {
+	var (
+		fakeClock       *fakeclock.FakeClock
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeBuckets     *dbfakes.FakeBuildStartBuckets
+		pipeline        *dbfakes.FakePipeline
+		otherPipeline   *dbfakes.FakePipeline
+		globalLimit     int
+
+		limiter startlimit.Limiter
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakeBuckets = new(dbfakes.FakeBuildStartBuckets)
+		fakeBuckets.ReserveReturns(true, nil)
+
+		pipeline = new(dbfakes.FakePipeline)
+		pipeline.TeamIDReturns(1)
+		pipeline.TeamNameReturns("some-team")
+
+		otherPipeline = new(dbfakes.FakePipeline)
+		otherPipeline.TeamIDReturns(2)
+		otherPipeline.TeamNameReturns("other-team")
+
+		globalLimit = 0
+	})
+
+	JustBeforeEach(func() {
+		limiter = startlimit.NewLimiter(fakeClock, fakeTeamFactory, fakeBuckets, globalLimit)
+	})
+
+	allowed := func(pipeline *dbfakes.FakePipeline) bool {
+		allowed, err := limiter.Allow(lagertest.NewTestLogger("test"), pipeline)
+		Expect(err).ToNot(HaveOccurred())
+		return allowed
+	}
+
+	It("looks up the pipeline's team", func() {
+		allowed(pipeline)
+		Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+		Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+	})
+
+	It("caches the team's limit for a while", func() {
+		allowed(pipeline)
+		allowed(pipeline)
+		Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+
+		fakeClock.Increment(startlimit.TeamLimitCacheDuration)
+		allowed(pipeline)
+		Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(2))
+	})
+
+	Context("when finding the team fails", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			_, err := limiter.Allow(lagertest.NewTestLogger("test"), pipeline)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when no limits are configured", func() {
+		It("allows builds to start without reserving them", func() {
+			Expect(allowed(pipeline)).To(BeTrue())
+			Expect(fakeBuckets.ReserveCallCount()).To(BeZero())
+		})
+
+		It("does not refund build starts", func() {
+			allowed(pipeline)
+			limiter.Refund(lagertest.NewTestLogger("test"), pipeline)
+			Expect(fakeBuckets.RefundCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a global limit is configured", func() {
+		BeforeEach(func() {
+			globalLimit = 2
+		})
+
+		It("reserves the build start in the buckets", func() {
+			Expect(allowed(pipeline)).To(BeTrue())
+
+			Expect(fakeBuckets.ReserveCallCount()).To(Equal(1))
+			teamID, globalPerMinute, teamPerMinute := fakeBuckets.ReserveArgsForCall(0)
+			Expect(teamID).To(Equal(1))
+			Expect(globalPerMinute).To(Equal(2))
+			Expect(teamPerMinute).To(BeZero())
+		})
+
+		It("refuses the build start when the buckets are full", func() {
+			fakeBuckets.ReserveReturns(false, nil)
+			Expect(allowed(pipeline)).To(BeFalse())
+		})
+
+		It("returns the error when reserving fails", func() {
+			fakeBuckets.ReserveReturns(false, errors.New("nope"))
+
+			_, err := limiter.Allow(lagertest.NewTestLogger("test"), pipeline)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("refunds build starts to the pipeline's team", func() {
+			allowed(pipeline)
+			limiter.Refund(lagertest.NewTestLogger("test"), pipeline)
+
+			Expect(fakeBuckets.RefundCallCount()).To(Equal(1))
+			Expect(fakeBuckets.RefundArgsForCall(0)).To(Equal(1))
+		})
+	})
+
+	Context("when the team has a limit configured", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamStub = func(name string) (db.Team, bool, error) {
+				team := new(dbfakes.FakeTeam)
+				if name == "some-team" {
+					team.MaxBuildStartsPerMinuteReturns(1)
+				}
+				return team, true, nil
+			}
+		})
+
+		It("reserves the team's build starts with its limit", func() {
+			Expect(allowed(pipeline)).To(BeTrue())
+
+			Expect(fakeBuckets.ReserveCallCount()).To(Equal(1))
+			teamID, globalPerMinute, teamPerMinute := fakeBuckets.ReserveArgsForCall(0)
+			Expect(teamID).To(Equal(1))
+			Expect(globalPerMinute).To(BeZero())
+			Expect(teamPerMinute).To(Equal(1))
+		})
+
+		It("does not reserve other teams' build starts", func() {
+			Expect(allowed(otherPipeline)).To(BeTrue())
+			Expect(fakeBuckets.ReserveCallCount()).To(BeZero())
+		})
+
+		It("refunds the team's build starts", func() {
+			allowed(pipeline)
+			limiter.Refund(lagertest.NewTestLogger("test"), pipeline)
+			Expect(fakeBuckets.RefundCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package startlimit_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestStartlimit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Startlimit Suite")
+}
@@ -0,0 +1,19 @@
+package atc
+
+// SchedulingTick is how the scheduler's last go at a pipeline went. Times are
+// unix timestamps and durations are in seconds.
+type SchedulingTick struct {
+	StartTime int64   `json:"start_time"`
+	Duration  float64 `json:"duration"`
+
+	// JobsEvaluated is how many of the pipeline's jobs were considered for
+	// new builds.
+	JobsEvaluated int `json:"jobs_evaluated"`
+
+	// BuildsCreated is how many builds were triggered by new versions of the
+	// jobs' inputs. Builds created by hand aren't counted.
+	BuildsCreated int `json:"builds_created"`
+
+	// Error is why the tick stopped short, if it did.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,209 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SchedulingWindows limit when new versions of a job's trigger: true inputs
+// start builds automatically, e.g. to stop deploys on weekends. Automatically
+// triggered builds which are already pending wait until the windows allow
+// them. Builds which are triggered manually are not affected.
+//
+// If any Allow windows are given, builds are only triggered during them.
+// Builds are never triggered during Deny windows.
+type SchedulingWindows struct {
+	Allow []SchedulingWindow `json:"allow,omitempty"`
+	Deny  []SchedulingWindow `json:"deny,omitempty"`
+}
+
+// SchedulingWindow recurs on the given days between the given times of day,
+// in the given location. Without days it recurs every day, and without start
+// and stop it lasts the whole day. A window which stops before it starts runs
+// past midnight, e.g. from 22:00 to 06:00, and its days are the days it
+// starts on.
+type SchedulingWindow struct {
+	Days     []string `json:"days,omitempty"`
+	Start    string   `json:"start,omitempty"`
+	Stop     string   `json:"stop,omitempty"`
+	Location string   `json:"location,omitempty"`
+}
+
+const schedulingWindowTimeLayout = "15:04"
+
+// Allows returns whether builds may be triggered automatically at the given
+// time. Windows are validated with the pipeline's config, so any which fail
+// to parse are ignored.
+func (windows SchedulingWindows) Allows(t time.Time) bool {
+	for _, window := range windows.Deny {
+		if window.Contains(t) {
+			return false
+		}
+	}
+
+	if len(windows.Allow) == 0 {
+		return true
+	}
+
+	for _, window := range windows.Allow {
+		if window.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (windows SchedulingWindows) Validate() error {
+	errorMessages := []string{}
+
+	for i, window := range windows.Allow {
+		err := window.Validate()
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("allow[%d] %s", i, err))
+		}
+	}
+
+	for i, window := range windows.Deny {
+		err := window.Validate()
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("deny[%d] %s", i, err))
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, ", "))
+	}
+
+	return nil
+}
+
+// InTimeZone returns the windows with those which don't configure a location
+// in the given time zone instead of UTC.
+func (windows SchedulingWindows) InTimeZone(tz TimeZone) SchedulingWindows {
+	return SchedulingWindows{
+		Allow: windowsInTimeZone(windows.Allow, tz),
+		Deny:  windowsInTimeZone(windows.Deny, tz),
+	}
+}
+
+func windowsInTimeZone(windows []SchedulingWindow, tz TimeZone) []SchedulingWindow {
+	if windows == nil {
+		return nil
+	}
+
+	inTimeZone := make([]SchedulingWindow, len(windows))
+	for i, window := range windows {
+		if window.Location == "" {
+			window.Location = string(tz)
+		}
+
+		inTimeZone[i] = window
+	}
+
+	return inTimeZone
+}
+
+// Contains returns whether the window includes the given time.
+func (window SchedulingWindow) Contains(t time.Time) bool {
+	location, err := time.LoadLocation(window.Location)
+	if err != nil {
+		return false
+	}
+
+	t = t.In(location)
+
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	start, stop := time.Duration(0), 24*time.Hour
+	if window.Start != "" {
+		start, err = parseTimeOfDay(window.Start)
+		if err != nil {
+			return false
+		}
+	}
+
+	if window.Stop != "" {
+		stop, err = parseTimeOfDay(window.Stop)
+		if err != nil {
+			return false
+		}
+	}
+
+	day := t.Weekday()
+
+	if stop <= start {
+		if sinceMidnight >= start {
+			return window.onDay(day)
+		}
+
+		if sinceMidnight < stop {
+			return window.onDay((day + 6) % 7)
+		}
+
+		return false
+	}
+
+	return sinceMidnight >= start && sinceMidnight < stop && window.onDay(day)
+}
+
+func (window SchedulingWindow) Validate() error {
+	if _, err := time.LoadLocation(window.Location); err != nil {
+		return fmt.Errorf("has invalid location: %s", window.Location)
+	}
+
+	if window.Start != "" {
+		if _, err := parseTimeOfDay(window.Start); err != nil {
+			return fmt.Errorf("has invalid start: %s", window.Start)
+		}
+	}
+
+	if window.Stop != "" {
+		if _, err := parseTimeOfDay(window.Stop); err != nil {
+			return fmt.Errorf("has invalid stop: %s", window.Stop)
+		}
+	}
+
+	for _, day := range window.Days {
+		if _, found := parseWeekday(day); !found {
+			return fmt.Errorf("has invalid day: %s", day)
+		}
+	}
+
+	return nil
+}
+
+func (window SchedulingWindow) onDay(weekday time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+
+	for _, day := range window.Days {
+		if d, found := parseWeekday(day); found && d == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse(schedulingWindowTimeLayout, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) {
+			return weekday, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,85 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchedulingWindows", func() {
+	// 2019-11-16 is a Saturday
+	saturdayNoon := time.Date(2019, 11, 16, 12, 0, 0, 0, time.UTC)
+	mondayNoon := time.Date(2019, 11, 18, 12, 0, 0, 0, time.UTC)
+	mondayNight := time.Date(2019, 11, 18, 23, 0, 0, 0, time.UTC)
+	tuesdayMorning := time.Date(2019, 11, 19, 5, 0, 0, 0, time.UTC)
+
+	Describe("Allows", func() {
+		It("allows any time without windows", func() {
+			Expect(atc.SchedulingWindows{}.Allows(saturdayNoon)).To(BeTrue())
+		})
+
+		It("denies times in deny windows", func() {
+			windows := atc.SchedulingWindows{
+				Deny: []atc.SchedulingWindow{{Days: []string{"Saturday", "Sunday"}}},
+			}
+
+			Expect(windows.Allows(saturdayNoon)).To(BeFalse())
+			Expect(windows.Allows(mondayNoon)).To(BeTrue())
+		})
+
+		It("only allows times in allow windows when there are any", func() {
+			windows := atc.SchedulingWindows{
+				Allow: []atc.SchedulingWindow{{Start: "09:00", Stop: "17:00"}},
+			}
+
+			Expect(windows.Allows(mondayNoon)).To(BeTrue())
+			Expect(windows.Allows(mondayNight)).To(BeFalse())
+		})
+
+		It("gives deny windows precedence over allow windows", func() {
+			windows := atc.SchedulingWindows{
+				Allow: []atc.SchedulingWindow{{Start: "09:00", Stop: "17:00"}},
+				Deny:  []atc.SchedulingWindow{{Days: []string{"saturday"}}},
+			}
+
+			Expect(windows.Allows(mondayNoon)).To(BeTrue())
+			Expect(windows.Allows(saturdayNoon)).To(BeFalse())
+		})
+	})
+
+	Describe("InTimeZone", func() {
+		It("puts windows without a location in the time zone", func() {
+			windows := atc.SchedulingWindows{
+				Allow: []atc.SchedulingWindow{{Start: "09:00", Stop: "17:00"}},
+				Deny:  []atc.SchedulingWindow{{Days: []string{"Sunday"}, Location: "UTC"}},
+			}
+
+			Expect(windows.InTimeZone("Asia/Tokyo")).To(Equal(atc.SchedulingWindows{
+				Allow: []atc.SchedulingWindow{{Start: "09:00", Stop: "17:00", Location: "Asia/Tokyo"}},
+				Deny:  []atc.SchedulingWindow{{Days: []string{"Sunday"}, Location: "UTC"}},
+			}))
+
+			Expect(windows.InTimeZone("Asia/Tokyo").Allows(mondayNoon)).To(BeFalse())
+			Expect(windows.Allow[0].Location).To(BeEmpty())
+		})
+	})
+
+	Describe("Contains", func() {
+		It("runs windows which stop before they start past midnight", func() {
+			window := atc.SchedulingWindow{Days: []string{"Monday"}, Start: "22:00", Stop: "06:00"}
+
+			Expect(window.Contains(mondayNight)).To(BeTrue())
+			Expect(window.Contains(tuesdayMorning)).To(BeTrue())
+			Expect(window.Contains(mondayNoon)).To(BeFalse())
+		})
+
+		It("compares times in the window's location", func() {
+			window := atc.SchedulingWindow{Start: "09:00", Stop: "17:00", Location: "America/New_York"}
+
+			Expect(window.Contains(mondayNoon)).To(BeFalse())
+			Expect(window.Contains(mondayNoon.Add(4 * time.Hour))).To(BeTrue())
+		})
+	})
+})
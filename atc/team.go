@@ -1,9 +1,195 @@
 package atc
 
+import "time"
+
 type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	// MaxBuildLogSize is the number of bytes of log output a single build may
+	// save before the rest is truncated. Zero means unlimited.
+	MaxBuildLogSize int64 `json:"max_build_log_size,omitempty"`
+
+	// MaxBuildStartsPerMinute caps how many of the team's builds may be
+	// started per minute, on top of any cap configured for the
+	// installation. Zero means unlimited.
+	//
+	// Each ATC enforces the cap separately, so with several web nodes up to
+	// this many builds may be started per minute by each of them.
+	MaxBuildStartsPerMinute int `json:"max_build_starts_per_minute,omitempty"`
+
+	// ResourceDefaults are applied to the resources of every pipeline in the
+	// team, beneath any defaults configured by the pipeline itself.
+	ResourceDefaults ResourceDefaults `json:"resource_defaults,omitempty"`
+
+	// ContainerEnv is set in every task and resource container run by the
+	// team's builds, unless the step sets the variable itself or the
+	// pipeline opts out with ignore_team_container_env.
+	ContainerEnv map[string]string `json:"container_env,omitempty"`
+
+	// CACerts is a PEM-encoded bundle of CA certificates trusted in every
+	// task and resource container run by the team, in addition to any
+	// configured for the installation.
+	CACerts string `json:"ca_certs,omitempty"`
+
+	// ContainerDNS is used by the team's build containers in place of the
+	// installation's, unless the pipeline configures its own.
+	ContainerDNS *ContainerDNS `json:"container_dns,omitempty"`
+
+	// CheckPolicy limits how often and how many of the team's resources may
+	// be checked. Only admins may change it.
+	CheckPolicy *CheckPolicy `json:"check_policy,omitempty"`
+
+	// DefaultTaskImage is used by the team's tasks which configure neither
+	// an image nor an image_resource, in place of any default configured
+	// for the installation.
+	DefaultTaskImage *ImageResource `json:"default_task_image,omitempty"`
+
+	// PipelinesRepo is a git repository which the team's pipelines are
+	// continuously reconciled with.
+	PipelinesRepo *PipelinesRepo `json:"pipelines_repo,omitempty"`
+
+	// ConcurrencyPools limits how many of the team's builds may run at once
+	// as members of each named pool, across all of the team's pipelines.
+	ConcurrencyPools ConcurrencyPools `json:"concurrency_pools,omitempty"`
+
+	// ContentScanPolicy decides what happens to the team's builds when the
+	// content scanner finds something, in place of the installation's
+	// policy. Only admins may change it.
+	ContentScanPolicy ContentScanPolicy `json:"content_scan_policy,omitempty"`
+
+	// ResourceTypeMappings remaps base resource types to alternative images
+	// for the team's resources, overriding the installation's mappings of
+	// the same types.
+	ResourceTypeMappings ResourceTypeMappings `json:"resource_type_mappings,omitempty"`
+
+	// PrivilegedPolicy restricts which of the team's pipelines and jobs may
+	// run privileged containers. Only admins may change it.
+	PrivilegedPolicy *PrivilegedPolicy `json:"privileged_policy,omitempty"`
+
+	// RequireImageDigests rejects pipelines whose tasks configure an
+	// image_resource which is not pinned by digest, and errors such tasks
+	// loaded from files when they run. Only admins may change it.
+	RequireImageDigests bool `json:"require_image_digests,omitempty"`
+
+	// Clear names settings to reset to their defaults when updating the
+	// team, e.g. max_build_log_size. Settings which are left out of an update
+	// are otherwise left as they are, and empty settings are left out.
+	Clear []string `json:"clear,omitempty"`
+}
+
+// CheckPolicy overrides the check_every of a team's resources and resource
+// types. DefaultEvery is used in place of the installation's default
+// interval, and intervals outside of MinEvery and MaxEvery are clamped to
+// them. MaxConcurrent caps the number of the team's checks running at once;
+// zero means unlimited.
+type CheckPolicy struct {
+	DefaultEvery  string `json:"default_every,omitempty"`
+	MinEvery      string `json:"min_every,omitempty"`
+	MaxEvery      string `json:"max_every,omitempty"`
+	MaxConcurrent int    `json:"max_concurrent,omitempty"`
+}
+
+// Interval returns the interval at which a resource configured with the
+// given check_every should be checked. A nil policy applies no overrides.
+func (policy *CheckPolicy) Interval(checkEvery string, defaultInterval time.Duration) (time.Duration, error) {
+	interval := defaultInterval
+
+	if policy != nil && policy.DefaultEvery != "" {
+		every, err := time.ParseDuration(policy.DefaultEvery)
+		if err != nil {
+			return 0, err
+		}
+
+		interval = every
+	}
+
+	if checkEvery != "" {
+		every, err := time.ParseDuration(checkEvery)
+		if err != nil {
+			return 0, err
+		}
+
+		interval = every
+	}
+
+	if policy == nil {
+		return interval, nil
+	}
+
+	if policy.MinEvery != "" {
+		min, err := time.ParseDuration(policy.MinEvery)
+		if err != nil {
+			return 0, err
+		}
+
+		if interval < min {
+			interval = min
+		}
+	}
+
+	if policy.MaxEvery != "" {
+		max, err := time.ParseDuration(policy.MaxEvery)
+		if err != nil {
+			return 0, err
+		}
+
+		if interval > max {
+			interval = max
+		}
+	}
+
+	return interval, nil
+}
+
+// ContentScanPolicy is what happens to a build when the content scanner
+// reports findings for, or fails to scan, the content of one of its steps.
+type ContentScanPolicy string
+
+const (
+	// ContentScanPolicyBlock errors the step.
+	ContentScanPolicyBlock ContentScanPolicy = "block"
+
+	// ContentScanPolicyWarn shows the findings in the step's output and
+	// lets the build carry on.
+	ContentScanPolicyWarn ContentScanPolicy = "warn"
+)
+
+// ConcurrencyPools maps the name of each pool to the number of builds which
+// may be members of it at once.
+type ConcurrencyPools map[string]int
+
+// ResourceTypeMappings maps the name of a base resource type to the image
+// which should implement it instead.
+type ResourceTypeMappings map[string]ResourceTypeMapping
+
+// ResourceTypeMapping is the image which implements a remapped base resource
+// type. The image is fetched using Type, which must be a base resource type
+// that is not itself remapped.
+type ResourceTypeMapping struct {
+	Type       string `json:"type"`
+	Source     Source `json:"source"`
+	Privileged bool   `json:"privileged,omitempty"`
+}
+
+// Override returns the mappings with those in overrides taking precedence
+// over any of the same type.
+func (mappings ResourceTypeMappings) Override(overrides ResourceTypeMappings) ResourceTypeMappings {
+	if len(overrides) == 0 {
+		return mappings
+	}
+
+	merged := ResourceTypeMappings{}
+	for name, mapping := range mappings {
+		merged[name] = mapping
+	}
+
+	for name, mapping := range overrides {
+		merged[name] = mapping
+	}
+
+	return merged
 }
 
 type TeamAuth map[string]map[string][]string
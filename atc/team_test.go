@@ -0,0 +1,237 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckPolicy", func() {
+	Describe("Interval", func() {
+		var policy *atc.CheckPolicy
+
+		BeforeEach(func() {
+			policy = &atc.CheckPolicy{
+				MinEvery: "5m",
+				MaxEvery: "1h",
+			}
+		})
+
+		It("uses the resource's check_every when it is within range", func() {
+			Expect(policy.Interval("10m", time.Minute)).To(Equal(10 * time.Minute))
+		})
+
+		It("raises intervals below the minimum", func() {
+			Expect(policy.Interval("10s", time.Minute)).To(Equal(5 * time.Minute))
+		})
+
+		It("lowers intervals above the maximum", func() {
+			Expect(policy.Interval("24h", time.Minute)).To(Equal(time.Hour))
+		})
+
+		It("clamps the installation's default interval", func() {
+			Expect(policy.Interval("", time.Minute)).To(Equal(5 * time.Minute))
+		})
+
+		Context("when the policy has a default", func() {
+			BeforeEach(func() {
+				policy.DefaultEvery = "30m"
+			})
+
+			It("is used for resources without a check_every", func() {
+				Expect(policy.Interval("", time.Minute)).To(Equal(30 * time.Minute))
+			})
+
+			It("does not override the resource's check_every", func() {
+				Expect(policy.Interval("10m", time.Minute)).To(Equal(10 * time.Minute))
+			})
+		})
+
+		Context("when the policy is nil", func() {
+			BeforeEach(func() {
+				policy = nil
+			})
+
+			It("uses the resource's check_every", func() {
+				Expect(policy.Interval("10s", time.Minute)).To(Equal(10 * time.Second))
+			})
+
+			It("falls back to the installation's default interval", func() {
+				Expect(policy.Interval("", time.Minute)).To(Equal(time.Minute))
+			})
+		})
+
+		It("errors when check_every is not a duration", func() {
+			_, err := policy.Interval("often", time.Minute)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("accepts a valid policy", func() {
+			Expect(atc.CheckPolicy{
+				DefaultEvery:  "10m",
+				MinEvery:      "1m",
+				MaxEvery:      "1h",
+				MaxConcurrent: 10,
+			}.Validate()).To(Succeed())
+		})
+
+		It("rejects durations that do not parse", func() {
+			err := atc.CheckPolicy{MinEvery: "often"}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("invalid min_every")))
+		})
+
+		It("rejects durations that are not positive", func() {
+			err := atc.CheckPolicy{DefaultEvery: "0s"}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("default_every must be positive ('0s')")))
+		})
+
+		It("rejects a minimum greater than the maximum", func() {
+			err := atc.CheckPolicy{MinEvery: "1h", MaxEvery: "1m"}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("min_every must not be greater than max_every")))
+		})
+
+		It("rejects a negative max_concurrent", func() {
+			err := atc.CheckPolicy{MaxConcurrent: -1}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("max_concurrent must not be negative")))
+		})
+	})
+})
+
+var _ = Describe("ImageResource", func() {
+	Describe("Validate", func() {
+		It("accepts an image with a type", func() {
+			Expect(atc.ImageResource{
+				Type:   "docker-image",
+				Source: atc.Source{"repository": "busybox"},
+			}.Validate()).To(Succeed())
+		})
+
+		It("rejects an image without a type", func() {
+			err := atc.ImageResource{Source: atc.Source{"repository": "busybox"}}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("missing type")))
+		})
+	})
+})
+
+var _ = Describe("PipelinesRepo", func() {
+	Describe("Validate", func() {
+		It("accepts a repo with a uri", func() {
+			Expect(atc.PipelinesRepo{
+				URI:    "https://github.com/example/pipelines.git",
+				Branch: "main",
+				Path:   "ci/pipelines",
+			}.Validate()).To(Succeed())
+		})
+
+		It("rejects a repo without a uri", func() {
+			err := atc.PipelinesRepo{Path: "ci"}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("missing uri")))
+		})
+
+		It("rejects a uri or branch which could be mistaken for a flag", func() {
+			err := atc.PipelinesRepo{URI: "--upload-pack=touch", Branch: "-b"}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("invalid uri: --upload-pack=touch")))
+			Expect(err).To(MatchError(ContainSubstring("invalid branch: -b")))
+		})
+
+		It("rejects a path outside of the repo", func() {
+			err := atc.PipelinesRepo{URI: "https://example.com/repo.git", Path: "ci/../../etc"}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("path must be within the repo: ci/../../etc")))
+
+			err = atc.PipelinesRepo{URI: "https://example.com/repo.git", Path: "/etc"}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("path must be within the repo: /etc")))
+		})
+	})
+})
+
+var _ = Describe("ConcurrencyPools", func() {
+	Describe("Validate", func() {
+		It("accepts pools with positive limits", func() {
+			Expect(atc.ConcurrencyPools{"integration-db": 3, "staging": 1}.Validate()).To(Succeed())
+		})
+
+		It("accepts no pools", func() {
+			Expect(atc.ConcurrencyPools(nil).Validate()).To(Succeed())
+		})
+
+		It("rejects a pool without a name", func() {
+			err := atc.ConcurrencyPools{"": 1}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("pool has no name")))
+		})
+
+		It("rejects a pool without a positive limit", func() {
+			err := atc.ConcurrencyPools{"integration-db": 0}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("limit of pool 'integration-db' must be positive")))
+		})
+	})
+})
+
+var _ = Describe("ContentScanPolicy", func() {
+	Describe("Validate", func() {
+		It("accepts block and warn", func() {
+			Expect(atc.ContentScanPolicyBlock.Validate()).To(Succeed())
+			Expect(atc.ContentScanPolicyWarn.Validate()).To(Succeed())
+		})
+
+		It("rejects anything else", func() {
+			err := atc.ContentScanPolicy("ignore").Validate()
+			Expect(err).To(MatchError("unknown content scan policy 'ignore': must be block or warn"))
+		})
+	})
+})
+
+var _ = Describe("ResourceTypeMappings", func() {
+	Describe("Override", func() {
+		It("prefers the overriding mapping of a type", func() {
+			mappings := atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "installation/git"}},
+				"s3":  {Type: "registry-image", Source: atc.Source{"repository": "installation/s3"}},
+			}
+
+			Expect(mappings.Override(atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "team/git"}},
+			})).To(Equal(atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "team/git"}},
+				"s3":  {Type: "registry-image", Source: atc.Source{"repository": "installation/s3"}},
+			}))
+		})
+
+		It("leaves the mappings alone", func() {
+			mappings := atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "installation/git"}},
+			}
+
+			mappings.Override(atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "team/git"}},
+			})
+
+			Expect(mappings["git"].Source).To(Equal(atc.Source{"repository": "installation/git"}))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("accepts mappings to base types", func() {
+			Expect(atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "internal/git-resource"}},
+			}.Validate()).To(Succeed())
+		})
+
+		It("rejects a mapping without a type", func() {
+			err := atc.ResourceTypeMappings{"git": {}}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("mapping of 'git' is missing type")))
+		})
+
+		It("rejects a mapping which uses a remapped type", func() {
+			err := atc.ResourceTypeMappings{
+				"git":            {Type: "registry-image"},
+				"registry-image": {Type: "docker-image"},
+			}.Validate()
+			Expect(err).To(MatchError(ContainSubstring("mapping of 'git' uses remapped type 'registry-image'")))
+		})
+	})
+})
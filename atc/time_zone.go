@@ -0,0 +1,45 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// TimeZone is the IANA time zone a pipeline's times are in, e.g.
+// Europe/London. The empty time zone is UTC.
+type TimeZone string
+
+func (tz TimeZone) Validate() error {
+	if _, err := time.LoadLocation(string(tz)); err != nil {
+		return fmt.Errorf("unknown time zone '%s'", tz)
+	}
+
+	return nil
+}
+
+// Location returns the time zone's location. Time zones are validated with
+// the pipeline's config, so any which fail to load are UTC.
+func (tz TimeZone) Location() *time.Location {
+	location, err := time.LoadLocation(string(tz))
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// Locale is the BCP 47 language tag clients display a pipeline's times and
+// durations with, e.g. en-GB. It is only checked for being well-formed, as
+// the locales available are up to each client.
+type Locale string
+
+var localeRegexp = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+func (locale Locale) Validate() error {
+	if locale != "" && !localeRegexp.MatchString(string(locale)) {
+		return fmt.Errorf("invalid locale '%s', must be a language tag such as en-GB", locale)
+	}
+
+	return nil
+}
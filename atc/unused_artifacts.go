@@ -0,0 +1,163 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnusedArtifactWarnings warns about the artifacts which a job's get steps
+// fetch, or its tasks output, but which none of the job's steps use. The
+// identifier is the job's, e.g. jobs.some-job.
+//
+// Nothing is warned about when a step may use any of the artifacts, i.e. a
+// put without specified inputs or a task whose config is loaded from a file,
+// as whether an artifact is used can't be known before the build runs.
+func UnusedArtifactWarnings(identifier string, job JobConfig) []ConfigWarning {
+	usage := &artifactUsage{
+		used: map[string]bool{},
+	}
+
+	usage.visit(identifier+".plan", PlanConfig{Do: &job.Plan})
+
+	for _, hook := range []struct {
+		name string
+		plan *PlanConfig
+	}{
+		{"abort", job.Abort},
+		{"error", job.Error},
+		{"failure", job.Failure},
+		{"ensure", job.Ensure},
+		{"success", job.Success},
+	} {
+		if hook.plan != nil {
+			usage.visit(identifier+"."+hook.name, *hook.plan)
+		}
+	}
+
+	if usage.usesAll {
+		return nil
+	}
+
+	warnings := []ConfigWarning{}
+	for _, artifact := range usage.registered {
+		if usage.used[artifact.name] {
+			continue
+		}
+
+		warnings = append(warnings, ConfigWarning{
+			Type:    "pipeline",
+			Message: fmt.Sprintf("%s %s an artifact which no step uses ('%s')", artifact.identifier, artifact.verb, artifact.name),
+		})
+	}
+
+	return warnings
+}
+
+type artifactUsage struct {
+	registered []registeredArtifact
+	used       map[string]bool
+
+	// usesAll is set when a step may use any artifact
+	usesAll bool
+}
+
+type registeredArtifact struct {
+	identifier string
+	verb       string
+	name       string
+}
+
+func (usage *artifactUsage) visit(identifier string, plan PlanConfig) {
+	switch {
+	case plan.Do != nil:
+		for i, step := range *plan.Do {
+			usage.visit(fmt.Sprintf("%s[%d]", identifier, i), step)
+		}
+
+	case plan.Aggregate != nil:
+		for i, step := range *plan.Aggregate {
+			usage.visit(fmt.Sprintf("%s.aggregate[%d]", identifier, i), step)
+		}
+
+	case plan.InParallel != nil:
+		for i, step := range plan.InParallel.Steps {
+			usage.visit(fmt.Sprintf("%s.in_parallel[%d]", identifier, i), step)
+		}
+
+	case plan.Get != "":
+		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
+
+		usage.registered = append(usage.registered, registeredArtifact{
+			identifier: identifier,
+			verb:       "fetches",
+			name:       plan.Get,
+		})
+
+	case plan.Put != "":
+		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
+
+		if plan.Inputs == nil || plan.Inputs.All {
+			usage.usesAll = true
+		} else {
+			for _, input := range plan.Inputs.Specified {
+				usage.used[input] = true
+			}
+		}
+
+	case plan.Task != "":
+		identifier = fmt.Sprintf("%s.task.%s", identifier, plan.Task)
+
+		if plan.TaskConfigPath != "" || plan.TaskConfig == nil {
+			usage.usesAll = true
+			break
+		}
+
+		if plan.ImageArtifactName != "" {
+			usage.used[plan.ImageArtifactName] = true
+		}
+
+		for _, input := range plan.TaskConfig.Inputs {
+			name := input.Name
+			if mapped, found := plan.InputMapping[name]; found {
+				name = mapped
+			}
+
+			usage.used[name] = true
+		}
+
+		for _, output := range plan.TaskConfig.Outputs {
+			name := output.Name
+			if mapped, found := plan.OutputMapping[name]; found {
+				name = mapped
+			}
+
+			usage.registered = append(usage.registered, registeredArtifact{
+				identifier: fmt.Sprintf("%s.output.%s", identifier, output.Name),
+				verb:       "registers",
+				name:       name,
+			})
+		}
+
+		if segs := strings.SplitN(plan.TaskOutputVars, "/", 2); len(segs) == 2 {
+			usage.used[segs[0]] = true
+		}
+
+	case plan.Try != nil:
+		usage.visit(identifier+".try", *plan.Try)
+	}
+
+	for _, hook := range []struct {
+		name string
+		plan *PlanConfig
+	}{
+		{"abort", plan.Abort},
+		{"error", plan.Error},
+		{"ensure", plan.Ensure},
+		{"success", plan.Success},
+		{"failure", plan.Failure},
+	} {
+		if hook.plan != nil {
+			usage.visit(identifier+"."+hook.name, *hook.plan)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -43,6 +46,45 @@ func (c Config) Validate() ([]ConfigWarning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
+	containerDNSErr := validateContainerDNS(c)
+	if containerDNSErr != nil {
+		errorMessages = append(errorMessages, formatErr("container dns", containerDNSErr))
+	}
+
+	branchesErr := validateBranches(c)
+	if branchesErr != nil {
+		errorMessages = append(errorMessages, formatErr("branches", branchesErr))
+	}
+
+	if c.SchedulingWindows != nil {
+		schedulingWindowsErr := c.SchedulingWindows.Validate()
+		if schedulingWindowsErr != nil {
+			errorMessages = append(errorMessages, formatErr("scheduling windows", schedulingWindowsErr))
+		}
+	}
+
+	labelsErr := c.Labels.Validate()
+	if labelsErr != nil {
+		errorMessages = append(errorMessages, formatErr("labels", labelsErr))
+	}
+
+	if c.Redactions != nil {
+		redactionsErr := c.Redactions.Validate()
+		if redactionsErr != nil {
+			errorMessages = append(errorMessages, formatErr("redactions", redactionsErr))
+		}
+	}
+
+	timeZoneErr := c.TimeZone.Validate()
+	if timeZoneErr != nil {
+		errorMessages = append(errorMessages, formatErr("timezone", timeZoneErr))
+	}
+
+	localeErr := c.Locale.Validate()
+	if localeErr != nil {
+		errorMessages = append(errorMessages, formatErr("locale", localeErr))
+	}
+
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
@@ -137,6 +179,14 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		errorMessages = append(errorMessages, validateNewVersionWebhooks(identifier, resource.NewVersionWebhooks)...)
+
+		if err := resource.Mode.Validate(); err != nil {
+			errorMessages = append(errorMessages, identifier+" has "+err.Error())
+		} else if !resource.Mode.Checked() && len(resource.NewVersionWebhooks) > 0 {
+			errorMessages = append(errorMessages, identifier+" is put_only so is never checked for new versions to notify webhooks of")
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -144,6 +194,28 @@ func validateResources(c Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateNewVersionWebhooks(identifier string, webhooks []NewVersionWebhookConfig) []string {
+	errorMessages := []string{}
+
+	for i, webhook := range webhooks {
+		webhookIdentifier := fmt.Sprintf("%s.new_version_webhooks[%d]", identifier, i)
+
+		if webhook.URL == "" {
+			errorMessages = append(errorMessages, webhookIdentifier+" has no url")
+		} else if u, err := url.Parse(webhook.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			errorMessages = append(errorMessages, webhookIdentifier+" has invalid url: "+webhook.URL)
+		}
+
+		if webhook.Timeout != "" {
+			if _, err := time.ParseDuration(webhook.Timeout); err != nil {
+				errorMessages = append(errorMessages, webhookIdentifier+" has invalid timeout: "+err.Error())
+			}
+		}
+	}
+
+	return errorMessages
+}
+
 func validateResourceTypes(c Config) error {
 	errorMessages := []string{}
 
@@ -178,11 +250,181 @@ func validateResourceTypes(c Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateContainerDNS(c Config) error {
+	if c.ContainerDNS == nil {
+		return nil
+	}
+
+	return c.ContainerDNS.Validate()
+}
+
+func (dns ContainerDNS) Validate() error {
+	errorMessages := []string{}
+
+	if len(dns.Servers) == 0 && len(dns.Search) == 0 {
+		errorMessages = append(errorMessages, "no servers or search domains given")
+	}
+
+	for i, server := range dns.Servers {
+		if net.ParseIP(server) == nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("servers[%d] is not an IP address ('%s')", i, server))
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func validateBranches(c Config) error {
+	if c.Branches == nil {
+		return nil
+	}
+
+	if c.Branches.Resource == "" {
+		return errors.New("no resource given")
+	}
+
+	if _, found := c.Resources.Lookup(c.Branches.Resource); !found {
+		return fmt.Errorf("unknown resource '%s'", c.Branches.Resource)
+	}
+
+	return nil
+}
+
+func (policy CheckPolicy) Validate() error {
+	errorMessages := []string{}
+
+	parse := func(name string, value string) (time.Duration, bool) {
+		if value == "" {
+			return 0, false
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("invalid %s: %s", name, err))
+			return 0, false
+		}
+
+		if duration <= 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s must be positive ('%s')", name, value))
+			return 0, false
+		}
+
+		return duration, true
+	}
+
+	parse("default_every", policy.DefaultEvery)
+	min, hasMin := parse("min_every", policy.MinEvery)
+	max, hasMax := parse("max_every", policy.MaxEvery)
+
+	if hasMin && hasMax && min > max {
+		errorMessages = append(errorMessages, "min_every must not be greater than max_every")
+	}
+
+	if policy.MaxConcurrent < 0 {
+		errorMessages = append(errorMessages, "max_concurrent must not be negative")
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func (image ImageResource) Validate() error {
+	errorMessages := []string{}
+
+	if image.Type == "" {
+		errorMessages = append(errorMessages, "missing type")
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func (repo PipelinesRepo) Validate() error {
+	errorMessages := []string{}
+
+	if repo.URI == "" {
+		errorMessages = append(errorMessages, "missing uri")
+	} else if strings.HasPrefix(repo.URI, "-") {
+		errorMessages = append(errorMessages, "invalid uri: "+repo.URI)
+	}
+
+	if strings.HasPrefix(repo.Branch, "-") {
+		errorMessages = append(errorMessages, "invalid branch: "+repo.Branch)
+	}
+
+	if repo.Path != "" {
+		cleaned := path.Clean(repo.Path)
+		if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			errorMessages = append(errorMessages, "path must be within the repo: "+repo.Path)
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func (pools ConcurrencyPools) Validate() error {
+	errorMessages := []string{}
+
+	names := []string{}
+	for name := range pools {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "" {
+			errorMessages = append(errorMessages, "pool has no name")
+		} else if pools[name] <= 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("limit of pool '%s' must be positive", name))
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func (mappings ResourceTypeMappings) Validate() error {
+	errorMessages := []string{}
+
+	names := []string{}
+	for name := range mappings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		mapping := mappings[name]
+
+		if name == "" {
+			errorMessages = append(errorMessages, "mapping has no resource type")
+		} else if mapping.Type == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("mapping of '%s' is missing type", name))
+		} else if _, remapped := mappings[mapping.Type]; remapped {
+			errorMessages = append(errorMessages, fmt.Sprintf("mapping of '%s' uses remapped type '%s'", name, mapping.Type))
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func (policy ContentScanPolicy) Validate() error {
+	switch policy {
+	case ContentScanPolicyBlock, ContentScanPolicyWarn:
+		return nil
+	default:
+		return fmt.Errorf("unknown content scan policy '%s': must be %s or %s", policy, ContentScanPolicyBlock, ContentScanPolicyWarn)
+	}
+}
+
 func validateResourcesUnused(c Config) []string {
 	usedResources := usedResources(c)
 
 	var errorMessages []string
 	for _, resource := range c.Resources {
+		// check_only resources are only there to be checked
+		if resource.Mode == ResourceModeCheckOnly {
+			continue
+		}
+
 		if _, used := usedResources[resource.Name]; !used {
 			message := fmt.Sprintf("resource '%s' is not used", resource.Name)
 			errorMessages = append(errorMessages, message)
@@ -195,6 +437,11 @@ func validateResourcesUnused(c Config) []string {
 func usedResources(c Config) map[string]bool {
 	usedResources := make(map[string]bool)
 
+	// the branches resource is checked to spawn instances of the pipeline
+	if c.Branches != nil {
+		usedResources[c.Branches.Resource] = true
+	}
+
 	for _, job := range c.Jobs {
 		for _, input := range job.Inputs() {
 			usedResources[input.Resource] = true
@@ -261,6 +508,33 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 			}
 		}
 
+		errorMessages = append(errorMessages, validateGates(identifier, job.Gates)...)
+		errorMessages = append(errorMessages, validateAfter(c, identifier, job)...)
+
+		if job.SchedulingWindows != nil {
+			err := job.SchedulingWindows.Validate()
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+".scheduling_windows "+err.Error())
+			}
+		}
+
+		err := job.Labels.Validate()
+		if err != nil {
+			errorMessages = append(errorMessages, identifier+".labels "+err.Error())
+		}
+
+		pools := job.Pools()
+		for _, plan := range job.Plans() {
+			pools = append(pools, plan.ConcurrencyPools...)
+		}
+
+		for _, pool := range pools {
+			if pool == "" {
+				errorMessages = append(errorMessages, identifier+" joins a concurrency pool with no name")
+				break
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -300,6 +574,29 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+		registered, artifactWarnings, artifactErrMessages := validateArtifacts(identifier+".plan", PlanConfig{Do: &job.Plan}, map[string]bool{})
+		warnings = append(warnings, artifactWarnings...)
+		errorMessages = append(errorMessages, artifactErrMessages...)
+
+		for _, hook := range []struct {
+			name string
+			plan *PlanConfig
+		}{
+			{"abort", job.Abort},
+			{"error", job.Error},
+			{"failure", job.Failure},
+			{"ensure", job.Ensure},
+			{"success", job.Success},
+		} {
+			if hook.plan != nil {
+				_, artifactWarnings, artifactErrMessages := validateArtifacts(identifier+"."+hook.name, *hook.plan, registered)
+				warnings = append(warnings, artifactWarnings...)
+				errorMessages = append(errorMessages, artifactErrMessages...)
+			}
+		}
+
+		warnings = append(warnings, UnusedArtifactWarnings(identifier, job)...)
+
 		encountered := map[string]int{}
 		for _, input := range job.Inputs() {
 			encountered[input.Name]++
@@ -313,9 +610,165 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 		}
 	}
 
+	errorMessages = append(errorMessages, validateAfterCycles(c)...)
+
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateAfter(c Config, identifier string, job JobConfig) []string {
+	errorMessages := []string{}
+
+	seen := map[string]bool{}
+	for _, name := range job.After {
+		if seen[name] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.after references a job more than once ('%s')", identifier, name))
+			continue
+		}
+
+		seen[name] = true
+
+		if name == job.Name {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.after references the job itself", identifier))
+			continue
+		}
+
+		if _, found := c.Jobs.Lookup(name); !found {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.after references an unknown job ('%s')", identifier, name))
+		}
+	}
+
+	return errorMessages
+}
+
+// validateAfterCycles reports jobs which end up waiting for themselves
+// through the jobs they run after, as none of their builds could ever start.
+func validateAfterCycles(c Config) []string {
+	errorMessages := []string{}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := map[string]int{}
+
+	var visit func(job JobConfig, path []string)
+	visit = func(job JobConfig, path []string) {
+		state[job.Name] = visiting
+		path = append(path, job.Name)
+
+		for _, name := range job.After {
+			upstream, found := c.Jobs.Lookup(name)
+			if !found || name == job.Name {
+				continue
+			}
+
+			switch state[name] {
+			case visiting:
+				cycle := []string{}
+				for i, n := range path {
+					if n == name {
+						cycle = append(cycle, path[i:]...)
+						break
+					}
+				}
+
+				errorMessages = append(errorMessages, fmt.Sprintf(
+					"jobs.%s.after creates a cycle (%s -> %s)",
+					job.Name, strings.Join(cycle, " -> "), name,
+				))
+			case unvisited:
+				visit(upstream, path)
+			}
+		}
+
+		state[job.Name] = visited
+	}
+
+	for _, job := range c.Jobs {
+		if state[job.Name] == unvisited {
+			visit(job, nil)
+		}
+	}
+
+	return errorMessages
+}
+
+func validateGates(identifier string, gates []GateConfig) []string {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+	for i, gate := range gates {
+		var gateIdentifier string
+		if gate.Name == "" {
+			gateIdentifier = fmt.Sprintf("%s.gates[%d]", identifier, i)
+			errorMessages = append(errorMessages, gateIdentifier+" has no name")
+		} else {
+			gateIdentifier = fmt.Sprintf("%s.gates.%s", identifier, gate.Name)
+		}
+
+		if other, exists := names[gate.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s.gates[%d] and %s.gates[%d] have the same name ('%s')",
+					identifier, other, identifier, i, gate.Name))
+		} else if gate.Name != "" {
+			names[gate.Name] = i
+		}
+
+		if gate.CheckEvery != "" {
+			interval, err := time.ParseDuration(gate.CheckEvery)
+			if err != nil {
+				errorMessages = append(errorMessages, gateIdentifier+" has invalid check_every: "+err.Error())
+			} else if interval <= 0 {
+				errorMessages = append(errorMessages, gateIdentifier+" has non-positive check_every: "+gate.CheckEvery)
+			}
+		}
+
+		switch {
+		case gate.HTTP != nil && gate.Metric != nil:
+			errorMessages = append(errorMessages, gateIdentifier+" has both http and metric specified")
+
+		case gate.HTTP != nil:
+			if gate.HTTP.URL == "" {
+				errorMessages = append(errorMessages, gateIdentifier+".http has no url")
+			} else if u, err := url.Parse(gate.HTTP.URL); err != nil || u.Scheme == "" || u.Host == "" {
+				errorMessages = append(errorMessages, gateIdentifier+".http has invalid url: "+gate.HTTP.URL)
+			}
+
+			if gate.HTTP.Timeout != "" {
+				timeout, err := time.ParseDuration(gate.HTTP.Timeout)
+				if err != nil {
+					errorMessages = append(errorMessages, gateIdentifier+".http has invalid timeout: "+err.Error())
+				} else if timeout > MaxHTTPGateTimeout {
+					errorMessages = append(errorMessages, fmt.Sprintf("%s.http has timeout longer than the maximum of %s: %s", gateIdentifier, MaxHTTPGateTimeout, gate.HTTP.Timeout))
+				}
+			}
+
+		case gate.Metric != nil:
+			if gate.Metric.Provider == "" {
+				errorMessages = append(errorMessages, gateIdentifier+".metric has no provider")
+			}
+
+			if gate.Metric.Query == "" {
+				errorMessages = append(errorMessages, gateIdentifier+".metric has no query")
+			}
+
+			if gate.Metric.Min == nil && gate.Metric.Max == nil {
+				errorMessages = append(errorMessages, gateIdentifier+".metric has neither min nor max")
+			} else if gate.Metric.Min != nil && gate.Metric.Max != nil && *gate.Metric.Min > *gate.Metric.Max {
+				errorMessages = append(errorMessages, gateIdentifier+".metric has min greater than max")
+			}
+
+		default:
+			errorMessages = append(errorMessages, gateIdentifier+" has neither http nor metric specified")
+		}
+	}
+
+	return errorMessages
+}
+
 type foundTypes struct {
 	identifier string
 	found      map[string]bool
@@ -419,7 +872,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "gpus", "config", "file", "file_params", "output_vars"},
 			plan, identifier)...,
 		)
 
@@ -448,6 +901,10 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 			}
 		}
 
+		if resource, found := c.Resources.Lookup(plan.ResourceName()); found && !resource.Mode.AllowsGet() {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s cannot get resource '%s' as it is %s", identifier, resource.Name, resource.Mode))
+		}
+
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
@@ -490,11 +947,43 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 			}
 		}
 
+		if plan.Version != nil && plan.Version.Deleted && len(plan.Passed) != 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s uses deleted versions, which cannot be constrained by passed",
+					identifier,
+				),
+			)
+		}
+
+		if plan.Version != nil && plan.Version.LatestPassingOrNewest && len(plan.Passed) != 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s uses latest-passing-or-newest versions, which cannot be constrained by passed",
+					identifier,
+				),
+			)
+		}
+
+		if plan.FallbackAfter < 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s has a negative fallback_after: %d", identifier, plan.FallbackAfter),
+			)
+		} else if plan.FallbackAfter != 0 && (plan.Version == nil || !plan.Version.LatestPassingOrNewest) {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s sets fallback_after but does not use latest-passing-or-newest versions", identifier),
+			)
+		}
+
 	case plan.Put != "":
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "gpus", "config", "file", "file_params", "output_vars", "fallback_after"},
 			plan, identifier)...,
 		)
 
@@ -523,6 +1012,10 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 			}
 		}
 
+		if resource, found := c.Resources.Lookup(plan.ResourceName()); found && !resource.Mode.AllowsPut() {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s cannot put to resource '%s' as it is %s", identifier, resource.Name, resource.Mode))
+		}
+
 	case plan.Task != "":
 		identifier = fmt.Sprintf("%s.task.%s", identifier, plan.Task)
 
@@ -551,10 +1044,30 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "fallback_after"},
 			plan, identifier)...,
 		)
 
+		if plan.GPUs < 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has a negative number of gpus: %d", identifier, plan.GPUs))
+		}
+
+		fileParams := []string{}
+		for name, filePath := range plan.FileParams {
+			if filePath == "" {
+				fileParams = append(fileParams, name)
+			}
+		}
+
+		sort.Strings(fileParams)
+		for _, name := range fileParams {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has no path for file param '%s'", identifier, name))
+		}
+
+		if plan.TaskOutputVars != "" && len(strings.SplitN(plan.TaskOutputVars, "/", 2)) != 2 {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has output vars '%s' which are not in an output, e.g. output/vars.yml", identifier, plan.TaskOutputVars))
+		}
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -613,6 +1126,166 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 	return warnings, errorMessages
 }
 
+// validateArtifacts checks that steps run in parallel don't register
+// artifacts under the same name, and warns about steps using artifacts which
+// none of the steps before it, given as available, register. It returns the
+// names of the artifacts registered by the step.
+func validateArtifacts(identifier string, plan PlanConfig, available map[string]bool) (map[string]bool, []ConfigWarning, []string) {
+	registered := map[string]bool{}
+	warnings := []ConfigWarning{}
+	errorMessages := []string{}
+
+	with := func(available map[string]bool, registered map[string]bool) map[string]bool {
+		result := map[string]bool{}
+		for name := range available {
+			result[name] = true
+		}
+		for name := range registered {
+			result[name] = true
+		}
+		return result
+	}
+
+	use := func(identifier string, name string) {
+		if !available[name] {
+			warnings = append(warnings, ConfigWarning{
+				Type:    "pipeline",
+				Message: fmt.Sprintf("%s refers to an artifact which no earlier step registers ('%s')", identifier, name),
+			})
+		}
+	}
+
+	validateParallel := func(steps []PlanConfig, label string) {
+		registeredBy := map[string]int{}
+		for i, step := range steps {
+			subIdentifier := fmt.Sprintf("%s.%s[%d]", identifier, label, i)
+			stepRegistered, stepWarnings, stepErrMessages := validateArtifacts(subIdentifier, step, available)
+			warnings = append(warnings, stepWarnings...)
+			errorMessages = append(errorMessages, stepErrMessages...)
+
+			names := []string{}
+			for name := range stepRegistered {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				if other, found := registeredBy[name]; found {
+					errorMessages = append(errorMessages, fmt.Sprintf(
+						"%s.%s[%d] and %s.%s[%d] run in parallel and register the same artifact name ('%s')",
+						identifier, label, other,
+						identifier, label, i,
+						name,
+					))
+				} else {
+					registeredBy[name] = i
+				}
+
+				registered[name] = true
+			}
+		}
+	}
+
+	switch {
+	case plan.Do != nil:
+		inner := available
+		for i, step := range *plan.Do {
+			subIdentifier := fmt.Sprintf("%s[%d]", identifier, i)
+			stepRegistered, stepWarnings, stepErrMessages := validateArtifacts(subIdentifier, step, inner)
+			warnings = append(warnings, stepWarnings...)
+			errorMessages = append(errorMessages, stepErrMessages...)
+			inner = with(inner, stepRegistered)
+			registered = with(registered, stepRegistered)
+		}
+
+	case plan.Aggregate != nil:
+		validateParallel(*plan.Aggregate, "aggregate")
+
+	case plan.InParallel != nil:
+		validateParallel(plan.InParallel.Steps, "in_parallel")
+
+	case plan.Get != "":
+		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
+		registered[plan.Get] = true
+
+	case plan.Put != "":
+		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
+
+		if plan.Inputs != nil {
+			for _, input := range plan.Inputs.Specified {
+				use(identifier+".inputs", input)
+			}
+		}
+
+		registered[plan.Put] = true
+
+	case plan.Task != "":
+		identifier = fmt.Sprintf("%s.task.%s", identifier, plan.Task)
+
+		// the artifact of a file given by a var isn't known until it's interpolated
+		if segs := strings.SplitN(plan.TaskConfigPath, "/", 2); len(segs) == 2 && !strings.Contains(segs[0], "((") {
+			use(identifier+".file", segs[0])
+		}
+
+		if plan.ImageArtifactName != "" {
+			use(identifier+".image", plan.ImageArtifactName)
+		}
+
+		if plan.TaskConfig != nil {
+			for _, input := range plan.TaskConfig.Inputs {
+				name := input.Name
+				if mapped, found := plan.InputMapping[name]; found {
+					name = mapped
+				}
+
+				if !input.Optional {
+					use(fmt.Sprintf("%s.input.%s", identifier, input.Name), name)
+				}
+			}
+
+			for _, output := range plan.TaskConfig.Outputs {
+				name := output.Name
+				if mapped, found := plan.OutputMapping[name]; found {
+					name = mapped
+				}
+
+				registered[name] = true
+			}
+		} else {
+			for _, name := range plan.OutputMapping {
+				registered[name] = true
+			}
+		}
+
+	case plan.Try != nil:
+		stepRegistered, stepWarnings, stepErrMessages := validateArtifacts(identifier+".try", *plan.Try, available)
+		warnings = append(warnings, stepWarnings...)
+		errorMessages = append(errorMessages, stepErrMessages...)
+		registered = stepRegistered
+	}
+
+	for _, hook := range []struct {
+		name string
+		plan *PlanConfig
+	}{
+		{"abort", plan.Abort},
+		{"error", plan.Error},
+		{"ensure", plan.Ensure},
+		{"success", plan.Success},
+		{"failure", plan.Failure},
+	} {
+		if hook.plan != nil {
+			hookRegistered, hookWarnings, hookErrMessages := validateArtifacts(identifier+"."+hook.name, *hook.plan, with(available, registered))
+			warnings = append(warnings, hookWarnings...)
+			errorMessages = append(errorMessages, hookErrMessages...)
+			registered = with(registered, hookRegistered)
+		}
+	}
+
+	return registered, warnings, errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -635,6 +1308,14 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.Privileged {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "gpus":
+			if plan.GPUs != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "file_params":
+			if len(plan.FileParams) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "config":
 			if plan.TaskConfig != nil {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -643,6 +1324,14 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "output_vars":
+			if plan.TaskOutputVars != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "fallback_after":
+			if plan.FallbackAfter != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -13,6 +13,7 @@ var _ = Describe("ValidateConfig", func() {
 	var (
 		config Config
 
+		warnings      []ConfigWarning
 		errorMessages []string
 	)
 
@@ -84,7 +85,7 @@ var _ = Describe("ValidateConfig", func() {
 	})
 
 	JustBeforeEach(func() {
-		_, errorMessages = config.Validate()
+		warnings, errorMessages = config.Validate()
 	})
 
 	Context("when the config is valid", func() {
@@ -238,6 +239,67 @@ var _ = Describe("ValidateConfig", func() {
 				))
 			})
 		})
+
+		Context("when a resource has valid new version webhooks", func() {
+			BeforeEach(func() {
+				config.Resources[0].NewVersionWebhooks = []NewVersionWebhookConfig{
+					{
+						URL:     "https://ci.example.com/hooks/new-version",
+						Headers: map[string]string{"Authorization": "Bearer ((token))"},
+						Timeout: "5s",
+					},
+				}
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a resource has invalid new version webhooks", func() {
+			BeforeEach(func() {
+				config.Resources[0].NewVersionWebhooks = []NewVersionWebhookConfig{
+					{},
+					{URL: "hooks", Timeout: "soon"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.new_version_webhooks[0] has no url"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.new_version_webhooks[1] has invalid url: hooks"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.new_version_webhooks[1] has invalid timeout"))
+			})
+		})
+
+		Context("when a resource has an unknown mode", func() {
+			BeforeEach(func() {
+				config.Resources[0].Mode = "sometimes"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has unknown mode 'sometimes', must be check_only, get_only or put_only"))
+			})
+		})
+
+		Context("when a put_only resource has new version webhooks", func() {
+			BeforeEach(func() {
+				config.Resources[0].Mode = ResourceModePutOnly
+				config.Resources[0].NewVersionWebhooks = []NewVersionWebhookConfig{
+					{URL: "https://ci.example.com/hooks/new-version"},
+				}
+				config.Jobs[0].Plan = config.Jobs[0].Plan[1:]
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource is put_only so is never checked for new versions to notify webhooks of"))
+			})
+		})
 	})
 
 	Describe("unused resources", func() {
@@ -423,6 +485,17 @@ var _ = Describe("ValidateConfig", func() {
 				Expect(errorMessages[0]).To(ContainSubstring("resource 'put-alias' is not used"))
 			})
 		})
+
+		Context("when an unused resource is check_only", func() {
+			BeforeEach(func() {
+				config.Resources[0].Mode = ResourceModeCheckOnly
+			})
+
+			It("does not complain about it", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).NotTo(ContainSubstring("resource 'unused-resource' is not used"))
+			})
+		})
 	})
 
 	Describe("invalid resource types", func() {
@@ -484,9 +557,216 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid container dns", func() {
+		Context("when neither servers nor search domains are given", func() {
+			BeforeEach(func() {
+				config.ContainerDNS = &ContainerDNS{}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid container dns:"))
+				Expect(errorMessages[0]).To(ContainSubstring("no servers or search domains given"))
+			})
+		})
+
+		Context("when only search domains are given", func() {
+			BeforeEach(func() {
+				config.ContainerDNS = &ContainerDNS{
+					Search: []string{"corp.example.com"},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a server is not an IP address", func() {
+			BeforeEach(func() {
+				config.ContainerDNS = &ContainerDNS{
+					Servers: []string{"10.0.0.2", "dns.example.com"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid container dns:"))
+				Expect(errorMessages[0]).To(ContainSubstring("servers[1] is not an IP address ('dns.example.com')"))
+			})
+		})
+	})
+
+	Describe("branches", func() {
+		Context("when the resource listing the branches is in the pipeline", func() {
+			BeforeEach(func() {
+				config.Branches = &BranchesConfig{Resource: "some-resource"}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when the resource is only used to list the branches", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, ResourceConfig{
+					Name: "branches",
+					Type: "git-branches",
+				})
+
+				config.Branches = &BranchesConfig{Resource: "branches"}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when no resource is given", func() {
+			BeforeEach(func() {
+				config.Branches = &BranchesConfig{Field: "branches"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid branches:"))
+				Expect(errorMessages[0]).To(ContainSubstring("no resource given"))
+			})
+		})
+
+		Context("when the resource is not in the pipeline", func() {
+			BeforeEach(func() {
+				config.Branches = &BranchesConfig{Resource: "bogus-resource"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid branches:"))
+				Expect(errorMessages[0]).To(ContainSubstring("unknown resource 'bogus-resource'"))
+			})
+		})
+	})
+
+	Describe("scheduling windows", func() {
+		Context("when the windows are valid", func() {
+			BeforeEach(func() {
+				config.SchedulingWindows = &SchedulingWindows{
+					Deny: []SchedulingWindow{{Days: []string{"Saturday", "sunday"}, Location: "Europe/London"}},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when a window is invalid", func() {
+			BeforeEach(func() {
+				config.SchedulingWindows = &SchedulingWindows{
+					Allow: []SchedulingWindow{{Start: "9am", Stop: "17:00"}},
+					Deny:  []SchedulingWindow{{Days: []string{"Caturday"}}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid scheduling windows:"))
+				Expect(errorMessages[0]).To(ContainSubstring("allow[0] has invalid start: 9am"))
+				Expect(errorMessages[0]).To(ContainSubstring("deny[0] has invalid day: Caturday"))
+			})
+		})
+
+		Context("when a job's window is invalid", func() {
+			BeforeEach(func() {
+				config.Jobs[0].SchedulingWindows = &SchedulingWindows{
+					Deny: []SchedulingWindow{{Location: "Nowhere/Special"}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.scheduling_windows deny[0] has invalid location: Nowhere/Special"))
+			})
+		})
+	})
+
+	Describe("labels", func() {
+		Context("when a pipeline label has an invalid name", func() {
+			BeforeEach(func() {
+				config.Labels = Labels{"service": "web", "bad name": "x"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid labels:"))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid label name: 'bad name'"))
+			})
+		})
+
+		Context("when a job label has an invalid name", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Labels = Labels{"-tier": "1"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.labels invalid label name: '-tier'"))
+			})
+		})
+	})
+
+	Describe("timezone and locale", func() {
+		Context("when they are valid", func() {
+			BeforeEach(func() {
+				config.TimeZone = "Europe/London"
+				config.Locale = "en-GB"
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when the timezone is unknown", func() {
+			BeforeEach(func() {
+				config.TimeZone = "Mars/Olympus_Mons"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid timezone:"))
+				Expect(errorMessages[0]).To(ContainSubstring("unknown time zone 'Mars/Olympus_Mons'"))
+			})
+		})
+
+		Context("when the locale is not a language tag", func() {
+			BeforeEach(func() {
+				config.Locale = "en_GB.UTF-8"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid locale:"))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid locale 'en_GB.UTF-8', must be a language tag such as en-GB"))
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job JobConfig
 
+		jobWarnings := func() []ConfigWarning {
+			jobWarnings := []ConfigWarning{}
+			for _, warning := range warnings {
+				if strings.HasPrefix(warning.Message, "jobs."+job.Name+".") {
+					jobWarnings = append(jobWarnings, warning)
+				}
+			}
+
+			return jobWarnings
+		}
+
 		BeforeEach(func() {
 			job = JobConfig{
 				Name: "some-other-job",
@@ -693,6 +973,7 @@ var _ = Describe("ValidateConfig", func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Get:            "lol",
 						Privileged:     true,
+						GPUs:           1,
 						TaskConfigPath: "task.yml",
 					})
 
@@ -702,7 +983,7 @@ var _ = Describe("ValidateConfig", func() {
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
 					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol has invalid fields specified (privileged, file)"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol has invalid fields specified (privileged, gpus, file)"))
 				})
 			})
 
@@ -725,6 +1006,106 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan requests a negative number of gpus", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "task.yml",
+						GPUs:           -1,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol has a negative number of gpus: -1"))
+				})
+			})
+
+			Context("when a task plan has a file param with no path", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "task.yml",
+						FileParams:     map[string]string{"TOKEN": ""},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol has no path for file param 'TOKEN'"))
+				})
+			})
+
+			Context("when a task plan has output vars which are not in an output", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "task.yml",
+						TaskOutputVars: "vars.yml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol has output vars 'vars.yml' which are not in an output"))
+				})
+			})
+
+			Context("when a get plan has output vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:            "some-resource",
+						TaskOutputVars: "out/vars.yml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (output_vars)"))
+				})
+			})
+
+			Context("when a get plan has file params", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:        "some-resource",
+						FileParams: map[string]string{"TOKEN": "token"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (file_params)"))
+				})
+			})
+
+			Context("when a step joins a concurrency pool with no name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:             "lol",
+						TaskConfigPath:   "task.yml",
+						ConcurrencyPools: []string{""},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job joins a concurrency pool with no name"))
+				})
+			})
+
 			Context("when a task plan has neither a config or a path set", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -786,6 +1167,192 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when steps run in parallel register the same artifact name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						InParallel: &InParallelConfig{
+							Steps: PlanSequence{
+								{Get: "some-resource"},
+								{
+									Task:          "some-task",
+									TaskConfig:    &TaskConfig{Platform: "linux", Run: TaskRunConfig{Path: "ls"}, Outputs: []TaskOutputConfig{{Name: "out"}}},
+									OutputMapping: map[string]string{"out": "some-resource"},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel[0] and jobs.some-other-job.plan[0].in_parallel[1] run in parallel and register the same artifact name ('some-resource')"))
+				})
+			})
+
+			Context("when steps run one after another register the same artifact name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan,
+						PlanConfig{Get: "some-resource"},
+						PlanConfig{
+							Task:          "some-task",
+							TaskConfig:    &TaskConfig{Platform: "linux", Run: TaskRunConfig{Path: "ls"}, Outputs: []TaskOutputConfig{{Name: "out"}}},
+							OutputMapping: map[string]string{"out": "some-resource"},
+						},
+					)
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
+			Context("when a step uses an artifact no earlier step registers", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan,
+						PlanConfig{
+							InParallel: &InParallelConfig{
+								Steps: PlanSequence{
+									{Get: "some-resource"},
+									{
+										Task:           "some-task",
+										TaskConfigPath: "some-resource/task.yml",
+									},
+								},
+							},
+						},
+						PlanConfig{
+							Task: "other-task",
+							TaskConfig: &TaskConfig{
+								Platform: "linux",
+								Run:      TaskRunConfig{Path: "ls"},
+								Inputs: []TaskInputConfig{
+									{Name: "in"},
+									{Name: "missing"},
+									{Name: "maybe", Optional: true},
+								},
+							},
+							InputMapping: map[string]string{"in": "some-resource"},
+						},
+						PlanConfig{Put: "some-resource", Inputs: &InputsConfig{Specified: []string{"some-resource", "other"}}},
+					)
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("warns about each use", func() {
+					Expect(errorMessages).To(BeEmpty())
+					Expect(jobWarnings()).To(ConsistOf(
+						ConfigWarning{
+							Type:    "pipeline",
+							Message: "jobs.some-other-job.plan[0].in_parallel[1].task.some-task.file refers to an artifact which no earlier step registers ('some-resource')",
+						},
+						ConfigWarning{
+							Type:    "pipeline",
+							Message: "jobs.some-other-job.plan[1].task.other-task.input.missing refers to an artifact which no earlier step registers ('missing')",
+						},
+						ConfigWarning{
+							Type:    "pipeline",
+							Message: "jobs.some-other-job.plan[2].put.some-resource.inputs refers to an artifact which no earlier step registers ('other')",
+						},
+					))
+				})
+			})
+
+			Context("when a job hook uses an artifact registered by the plan", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{Get: "some-resource"})
+					job.Ensure = &PlanConfig{Task: "cleanup", TaskConfigPath: "some-resource/cleanup.yml"}
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not warn", func() {
+					Expect(jobWarnings()).To(BeEmpty())
+				})
+			})
+
+			Context("when a job fetches or outputs artifacts which no step uses", func() {
+				var put PlanConfig
+				var taskOutputVars string
+
+				BeforeEach(func() {
+					put = PlanConfig{Put: "some-resource", Inputs: &InputsConfig{Specified: []string{"built"}}}
+					taskOutputVars = ""
+				})
+
+				JustBeforeEach(func() {
+					job.Plan = append(job.Plan,
+						PlanConfig{
+							InParallel: &InParallelConfig{
+								Steps: PlanSequence{
+									{Get: "some-resource"},
+									{Get: "unused", Resource: "some-resource"},
+								},
+							},
+						},
+						PlanConfig{
+							Task: "some-task",
+							TaskConfig: &TaskConfig{
+								Platform: "linux",
+								Run:      TaskRunConfig{Path: "ls"},
+								Inputs:   []TaskInputConfig{{Name: "in"}},
+								Outputs:  []TaskOutputConfig{{Name: "built"}, {Name: "scratch"}},
+							},
+							InputMapping:   map[string]string{"in": "some-resource"},
+							TaskOutputVars: taskOutputVars,
+						},
+						put,
+					)
+
+					config.Jobs = append(config.Jobs, job)
+
+					warnings, errorMessages = config.Validate()
+				})
+
+				It("warns about each unused artifact", func() {
+					Expect(errorMessages).To(BeEmpty())
+					Expect(jobWarnings()).To(ConsistOf(
+						ConfigWarning{
+							Type:    "pipeline",
+							Message: "jobs.some-other-job.plan[0].in_parallel[1].get.unused fetches an artifact which no step uses ('unused')",
+						},
+						ConfigWarning{
+							Type:    "pipeline",
+							Message: "jobs.some-other-job.plan[1].task.some-task.output.scratch registers an artifact which no step uses ('scratch')",
+						},
+					))
+				})
+
+				Context("when the task loads output vars from an output", func() {
+					BeforeEach(func() {
+						taskOutputVars = "scratch/vars.yml"
+					})
+
+					It("does not warn about the output", func() {
+						Expect(jobWarnings()).To(ConsistOf(
+							ConfigWarning{
+								Type:    "pipeline",
+								Message: "jobs.some-other-job.plan[0].in_parallel[1].get.unused fetches an artifact which no step uses ('unused')",
+							},
+						))
+					})
+				})
+
+				Context("when a put may use any artifact", func() {
+					BeforeEach(func() {
+						put.Inputs = nil
+					})
+
+					It("does not warn", func() {
+						Expect(jobWarnings()).To(BeEmpty())
+					})
+				})
+			})
+
 			Context("when a put plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -899,6 +1466,75 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan refers to a put_only resource", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, ResourceConfig{
+						Name: "some-put-only-resource",
+						Type: "some-type",
+						Mode: ResourceModePutOnly,
+					})
+
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "custom-name",
+						Resource: "some-put-only-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.custom-name cannot get resource 'some-put-only-resource' as it is put_only"))
+				})
+			})
+
+			Context("when a put plan refers to a get_only resource", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, ResourceConfig{
+						Name: "some-get-only-resource",
+						Type: "some-type",
+						Mode: ResourceModeGetOnly,
+					})
+
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-get-only-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-get-only-resource cannot put to resource 'some-get-only-resource' as it is get_only"))
+				})
+			})
+
+			Context("when a plan refers to a check_only resource", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, ResourceConfig{
+						Name: "some-check-only-resource",
+						Type: "some-type",
+						Mode: ResourceModeCheckOnly,
+					})
+
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-check-only-resource",
+					}, PlanConfig{
+						Put: "some-check-only-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for both steps", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-check-only-resource cannot get resource 'some-check-only-resource' as it is check_only"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[1].put.some-check-only-resource cannot put to resource 'some-check-only-resource' as it is check_only"))
+				})
+			})
+
 			Context("when a job ensure hook refers to a resource that does exist", func() {
 				BeforeEach(func() {
 					job.Ensure = &PlanConfig{
@@ -1306,6 +1942,106 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job's input uses deleted versions with passed constraints", func() {
+			BeforeEach(func() {
+				job := JobConfig{
+					Name: "some-other-job",
+				}
+
+				job.Plan = append(job.Plan, PlanConfig{
+					Get:     "some-resource",
+					Passed:  []string{"some-job"},
+					Version: &VersionConfig{Deleted: true},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource uses deleted versions, which cannot be constrained by passed"))
+			})
+		})
+
+		Context("when a job's input uses latest-passing-or-newest versions with passed constraints", func() {
+			BeforeEach(func() {
+				job := JobConfig{
+					Name: "some-other-job",
+				}
+
+				job.Plan = append(job.Plan, PlanConfig{
+					Get:     "some-resource",
+					Passed:  []string{"some-job"},
+					Version: &VersionConfig{LatestPassingOrNewest: true},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource uses latest-passing-or-newest versions, which cannot be constrained by passed"))
+			})
+		})
+
+		Context("when a job's input configures fallback_after", func() {
+			var plan PlanConfig
+
+			BeforeEach(func() {
+				plan = PlanConfig{
+					Get:           "some-resource",
+					Version:       &VersionConfig{LatestPassingOrNewest: true},
+					FallbackAfter: 2,
+				}
+			})
+
+			JustBeforeEach(func() {
+				config.Jobs = append(config.Jobs, JobConfig{
+					Name: "some-other-job",
+					Plan: PlanSequence{plan},
+				})
+
+				_, errorMessages = config.Validate()
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+
+			Context("when it is negative", func() {
+				BeforeEach(func() {
+					plan.FallbackAfter = -1
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has a negative fallback_after: -1"))
+				})
+			})
+
+			Context("when the input does not use latest-passing-or-newest versions", func() {
+				BeforeEach(func() {
+					plan.Version = &VersionConfig{Every: true}
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource sets fallback_after but does not use latest-passing-or-newest versions"))
+				})
+			})
+
+			Context("when it is set on a put", func() {
+				BeforeEach(func() {
+					plan = PlanConfig{Put: "some-resource", FallbackAfter: 2}
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource has invalid fields specified (fallback_after)"))
+				})
+			})
+		})
+
 		Context("when two jobs have the same name", func() {
 			BeforeEach(func() {
 				config.Jobs = append(config.Jobs, config.Jobs...)
@@ -1358,5 +2094,91 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has valid gates", func() {
+			BeforeEach(func() {
+				max := 0.1
+				config.Jobs[0].Gates = []GateConfig{
+					{
+						Name:       "healthy",
+						HTTP:       &HTTPGateConfig{URL: "https://status.example.com/health", Timeout: "5s"},
+						CheckEvery: "30s",
+					},
+					{
+						Name:   "error-budget",
+						Metric: &MetricGateConfig{Provider: "prometheus", Query: "burn_rate", Max: &max},
+					},
+				}
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job has invalid gates", func() {
+			BeforeEach(func() {
+				min, max := 2.0, 1.0
+				config.Jobs[0].Gates = []GateConfig{
+					{HTTP: &HTTPGateConfig{URL: "https://status.example.com"}},
+					{Name: "neither"},
+					{Name: "both", HTTP: &HTTPGateConfig{URL: "https://status.example.com"}, Metric: &MetricGateConfig{}},
+					{Name: "bad-url", HTTP: &HTTPGateConfig{URL: "status"}, CheckEvery: "often"},
+					{Name: "bad-metric", Metric: &MetricGateConfig{Min: &min, Max: &max}},
+					{Name: "bad-metric", Metric: &MetricGateConfig{Provider: "prometheus", Query: "up"}},
+					{Name: "slow", HTTP: &HTTPGateConfig{URL: "https://status.example.com", Timeout: "1h"}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates[0] has no name"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.neither has neither http nor metric specified"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.both has both http and metric specified"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.bad-url.http has invalid url: status"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.bad-url has invalid check_every"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.bad-metric.metric has no provider"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.bad-metric.metric has no query"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.bad-metric.metric has min greater than max"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates[4] and jobs.some-job.gates[5] have the same name ('bad-metric')"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.bad-metric.metric has neither min nor max"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.gates.slow.http has timeout longer than the maximum of 1m0s: 1h"))
+			})
+		})
+
+		Context("when a job runs after another job", func() {
+			BeforeEach(func() {
+				config.Jobs[0].After = []string{"some-empty-job"}
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job has invalid after dependencies", func() {
+			BeforeEach(func() {
+				config.Jobs[0].After = []string{"some-job", "bogus-job", "some-empty-job", "some-empty-job"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.after references the job itself"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.after references an unknown job ('bogus-job')"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.after references a job more than once ('some-empty-job')"))
+			})
+		})
+
+		Context("when jobs run after each other in a cycle", func() {
+			BeforeEach(func() {
+				config.Jobs[0].After = []string{"some-empty-job"}
+				config.Jobs[1].After = []string{"some-job"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-empty-job.after creates a cycle (some-job -> some-empty-job -> some-job)"))
+			})
+		})
+
 	})
 })
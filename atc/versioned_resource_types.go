@@ -28,3 +28,14 @@ func (types VersionedResourceTypes) Without(name string) VersionedResourceTypes
 
 	return newTypes
 }
+
+// Privileged returns whether containers of the named type run privileged,
+// either because the type is or because the type which runs its image is.
+func (types VersionedResourceTypes) Privileged(name string) bool {
+	t, found := types.Lookup(name)
+	if !found {
+		return false
+	}
+
+	return t.Privileged || types.Without(name).Privileged(t.Type)
+}
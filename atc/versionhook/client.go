@@ -0,0 +1,119 @@
+package versionhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NewClient returns a client for sending webhooks which refuses to connect
+// to loopback, private, link-local and unspecified addresses, so that
+// pipelines cannot use webhooks to reach into the ATC's own network. Hosts
+// which are allowed explicitly may be at any address.
+//
+// Webhooks are sent directly rather than through any proxy configured in the
+// environment, as their destinations could not be checked otherwise.
+func NewClient(allowedHosts []string) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+				host, port, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err
+				}
+
+				if hostAllowed(allowedHosts, host) {
+					return dialer.DialContext(ctx, network, addr)
+				}
+
+				// the host is resolved here, and its address dialed, so that
+				// it can't resolve to another address once it has been checked
+				addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, ip := range addrs {
+					if !publicIP(ip.IP) {
+						return nil, fmt.Errorf("host '%s' resolves to non-public address %s", host, ip.IP)
+					}
+				}
+
+				if len(addrs) == 0 {
+					return nil, fmt.Errorf("host '%s' has no addresses", host)
+				}
+
+				return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+			},
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
+
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	for _, block := range privateBlocks {
+		if block.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var privateBlocks = parseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	blocks := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		_, block, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// hostAllowed returns whether the host is one of the allowed hosts, or a
+// subdomain of a wildcard one.
+func hostAllowed(allowedHosts []string, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+
+			continue
+		}
+
+		if host == allowed {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package versionhook_test
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/concourse/concourse/atc/versionhook"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server       *ghttp.Server
+		allowedHosts []string
+
+		resp *http.Response
+		err  error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", "/hook", ghttp.RespondWith(http.StatusOK, nil))
+
+		allowedHosts = nil
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		resp, err = versionhook.NewClient(allowedHosts).Post(server.URL()+"/hook", "application/json", nil)
+	})
+
+	It("refuses to connect to loopback addresses", func() {
+		Expect(err).To(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	Context("when the host is allowed", func() {
+		BeforeEach(func() {
+			serverURL, err := url.Parse(server.URL())
+			Expect(err).ToNot(HaveOccurred())
+
+			allowedHosts = []string{serverURL.Hostname()}
+		})
+
+		It("connects to it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
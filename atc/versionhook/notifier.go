@@ -0,0 +1,192 @@
+package versionhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+// DefaultTimeout bounds each webhook request if the webhook does not
+// configure a timeout.
+const DefaultTimeout = 10 * time.Second
+
+//go:generate counterfeiter . Notifier
+
+// Notifier tells the webhooks configured on a resource about the new
+// versions found by checking it.
+type Notifier interface {
+	NewVersions(logger lager.Logger, resource db.Resource, variables vars.Variables, versions []atc.Version)
+}
+
+// Payload is the body POSTed to each of a resource's new version webhooks.
+type Payload struct {
+	Team     string        `json:"team"`
+	Pipeline string        `json:"pipeline"`
+	Resource string        `json:"resource"`
+	Type     string        `json:"type"`
+	URL      string        `json:"url"`
+	Versions []atc.Version `json:"versions"`
+}
+
+// NewNotifier returns a Notifier which sends webhooks with the given client.
+// Resource URLs in the payload are relative to the given external URL.
+//
+// If any allowed hosts are given, webhooks are only sent to them. A host may
+// be given as a wildcard, e.g. '*.example.com', to allow all of its
+// subdomains.
+//
+// Webhooks are sent in the background, so a slow or unreachable endpoint
+// does not hold up checking. Failed deliveries are logged and not retried.
+func NewNotifier(client *http.Client, externalURL string, allowedHosts []string) Notifier {
+	return &notifier{
+		client:       client,
+		externalURL:  externalURL,
+		allowedHosts: allowedHosts,
+	}
+}
+
+type notifier struct {
+	client       *http.Client
+	externalURL  string
+	allowedHosts []string
+}
+
+// NewSince returns the versions found by checking from the given version,
+// other than that version, which is usually reported again but isn't new.
+func NewSince(from atc.Version, versions []atc.Version) []atc.Version {
+	found := []atc.Version{}
+	for _, version := range versions {
+		if from == nil || !reflect.DeepEqual(version, from) {
+			found = append(found, version)
+		}
+	}
+
+	return found
+}
+
+func (n *notifier) NewVersions(logger lager.Logger, resource db.Resource, variables vars.Variables, versions []atc.Version) {
+	webhooks := resource.NewVersionWebhooks()
+	if len(webhooks) == 0 || len(versions) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(Payload{
+		Team:     resource.TeamName(),
+		Pipeline: resource.PipelineName(),
+		Resource: resource.Name(),
+		Type:     resource.Type(),
+		URL: fmt.Sprintf(
+			"%s/teams/%s/pipelines/%s/resources/%s",
+			n.externalURL,
+			url.PathEscape(resource.TeamName()),
+			url.PathEscape(resource.PipelineName()),
+			url.PathEscape(resource.Name()),
+		),
+		Versions: versions,
+	})
+	if err != nil {
+		logger.Error("failed-to-marshal-new-version-payload", err)
+		return
+	}
+
+	for i, webhook := range webhooks {
+		logger := logger.Session("new-version-webhook", lager.Data{"webhook": i})
+
+		err := n.checkDestination(webhook.URL)
+		if err != nil {
+			logger.Info("destination-not-allowed", lager.Data{"error": err.Error()})
+			continue
+		}
+
+		headers, err := evaluateHeaders(variables, webhook.Headers)
+		if err != nil {
+			logger.Error("failed-to-evaluate-headers", err)
+			continue
+		}
+
+		go n.send(logger, webhook, headers, payload)
+	}
+}
+
+func (n *notifier) send(logger lager.Logger, webhook atc.NewVersionWebhookConfig, headers map[string]string, payload []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout(webhook.Timeout))
+	defer cancel()
+
+	req, err := http.NewRequest("POST", webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		logger.Error("failed-to-create-request", err)
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err != nil {
+		logger.Info("failed-to-send", lager.Data{"error": err.Error()})
+		return
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		logger.Info("unexpected-response-status", lager.Data{"status": resp.Status})
+	}
+}
+
+func (n *notifier) checkDestination(destination string) error {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	}
+
+	if len(n.allowedHosts) > 0 && !hostAllowed(n.allowedHosts, u.Hostname()) {
+		return fmt.Errorf("host '%s' is not allowed", u.Hostname())
+	}
+
+	return nil
+}
+
+func evaluateHeaders(variables vars.Variables, headers map[string]string) (map[string]string, error) {
+	evaluated := map[string]string{}
+	for name, value := range headers {
+		var err error
+		evaluated[name], err = creds.NewString(variables, value).Evaluate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return evaluated, nil
+}
+
+func timeout(configured string) time.Duration {
+	if configured == "" {
+		return DefaultTimeout
+	}
+
+	duration, err := time.ParseDuration(configured)
+	if err != nil {
+		return DefaultTimeout
+	}
+
+	return duration
+}
@@ -0,0 +1,130 @@
+package versionhook_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/versionhook"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		server       *ghttp.Server
+		fakeResource *dbfakes.FakeResource
+		variables    vars.StaticVariables
+		versions     []atc.Version
+		allowedHosts []string
+
+		notifier versionhook.Notifier
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		fakeResource = new(dbfakes.FakeResource)
+		fakeResource.TeamNameReturns("some-team")
+		fakeResource.PipelineNameReturns("some-pipeline")
+		fakeResource.NameReturns("some-resource")
+		fakeResource.TypeReturns("git")
+
+		variables = vars.StaticVariables{"token": "some-token"}
+		versions = []atc.Version{{"ref": "v1"}, {"ref": "v2"}}
+		allowedHosts = nil
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		notifier = versionhook.NewNotifier(http.DefaultClient, "https://ci.example.com", allowedHosts)
+		notifier.NewVersions(lagertest.NewTestLogger("test"), fakeResource, variables, versions)
+	})
+
+	Context("when the resource has webhooks", func() {
+		BeforeEach(func() {
+			fakeResource.NewVersionWebhooksReturns([]atc.NewVersionWebhookConfig{
+				{
+					URL:     server.URL() + "/hooks/a",
+					Headers: map[string]string{"Authorization": "Bearer ((token))"},
+				},
+				{
+					URL: server.URL() + "/hooks/b",
+				},
+			})
+
+			payload := versionhook.Payload{
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+				Resource: "some-resource",
+				Type:     "git",
+				URL:      "https://ci.example.com/teams/some-team/pipelines/some-pipeline/resources/some-resource",
+				Versions: []atc.Version{{"ref": "v1"}, {"ref": "v2"}},
+			}
+
+			server.RouteToHandler("POST", "/hooks/a", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyJSONRepresenting(payload),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+
+			server.RouteToHandler("POST", "/hooks/b", ghttp.CombineHandlers(
+				ghttp.VerifyJSONRepresenting(payload),
+				ghttp.RespondWith(http.StatusInternalServerError, nil),
+			))
+		})
+
+		It("posts the new versions to each webhook", func() {
+			Eventually(server.ReceivedRequests).Should(HaveLen(2))
+		})
+
+		Context("when a header's credential cannot be found", func() {
+			BeforeEach(func() {
+				variables = vars.StaticVariables{}
+			})
+
+			It("skips that webhook", func() {
+				Eventually(server.ReceivedRequests).Should(HaveLen(1))
+				Consistently(server.ReceivedRequests).Should(HaveLen(1))
+				Expect(server.ReceivedRequests()[0].URL.Path).To(Equal("/hooks/b"))
+			})
+		})
+
+		Context("when the webhooks' host is allowed", func() {
+			BeforeEach(func() {
+				allowedHosts = []string{"ci.example.com", "127.0.0.1"}
+			})
+
+			It("posts the new versions to each webhook", func() {
+				Eventually(server.ReceivedRequests).Should(HaveLen(2))
+			})
+		})
+
+		Context("when the webhooks' host is not allowed", func() {
+			BeforeEach(func() {
+				allowedHosts = []string{"*.example.com"}
+			})
+
+			It("does not post anything", func() {
+				Consistently(server.ReceivedRequests).Should(BeEmpty())
+			})
+		})
+
+		Context("when there are no versions", func() {
+			BeforeEach(func() {
+				versions = nil
+			})
+
+			It("does not post anything", func() {
+				Consistently(server.ReceivedRequests).Should(BeEmpty())
+			})
+		})
+	})
+})
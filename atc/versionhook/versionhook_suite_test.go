@@ -0,0 +1,13 @@
+package versionhook_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestVersionhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Versionhook Suite")
+}
@@ -23,4 +23,5 @@ type Volume struct {
 	PipelineName     string                  `json:"pipeline_name"`
 	JobName          string                  `json:"job_name"`
 	StepName         string                  `json:"step_name"`
+	CreatedAt        int64                   `json:"created_at,omitempty"`
 }
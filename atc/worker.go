@@ -22,19 +22,39 @@ type Worker struct {
 
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
-	Platform  string   `json:"platform"`
-	Tags      []string `json:"tags"`
-	Team      string   `json:"team"`
-	Name      string   `json:"name"`
-	Version   string   `json:"version"`
-	StartTime int64    `json:"start_time"`
-	Ephemeral bool     `json:"ephemeral"`
-	State     string   `json:"state"`
+	Platform string   `json:"platform"`
+	Tags     []string `json:"tags"`
+	Labels   Labels   `json:"labels,omitempty"`
+	GPUs     int      `json:"gpus,omitempty"`
+
+	// StreamEncoding is the compression used for volumes streamed to and
+	// from the worker's baggageclaim. Empty means zstd.
+	StreamEncoding string `json:"stream_encoding,omitempty"`
+
+	// ProtocolVersion and Capabilities are offered by the worker when it
+	// registers, and replaced with the ones negotiated with the ATC.
+	ProtocolVersion int                `json:"protocol_version,omitempty"`
+	Capabilities    []WorkerCapability `json:"capabilities,omitempty"`
+
+	Team      string `json:"team"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	StartTime int64  `json:"start_time"`
+	Ephemeral bool   `json:"ephemeral"`
+	State     string `json:"state"`
+
+	// Census is reported with heartbeats, and is never saved or returned.
+	Census *WorkerCensus `json:"census,omitempty"`
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
 var ErrNoWorkers = errors.New("no workers available for checking")
+var ErrInvalidWorkerGPUs = errors.New("invalid worker gpus, must not be negative")
+var ErrInvalidWorkerStreamEncoding = errors.New("invalid worker stream encoding, must be 'zstd' or 'gzip'")
+var ErrInvalidWorkerLabel = errors.New("invalid worker label, names must start with a letter or number and only contain letters, numbers, '-', '_', '.' and '/'")
+
+var labelNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._/-]*$`)
 
 func (w Worker) Validate() error {
 	if w.Version != "" && !regexp.MustCompile(`^[0-9\.]+$`).MatchString(w.Version) {
@@ -45,6 +65,22 @@ func (w Worker) Validate() error {
 		return ErrMissingWorkerGardenAddress
 	}
 
+	if w.GPUs < 0 {
+		return ErrInvalidWorkerGPUs
+	}
+
+	switch w.StreamEncoding {
+	case "", "zstd", "gzip":
+	default:
+		return ErrInvalidWorkerStreamEncoding
+	}
+
+	for name := range w.Labels {
+		if !labelNameRegexp.MatchString(name) {
+			return ErrInvalidWorkerLabel
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// negotiatedProtocol returns whether the worker negotiated its protocol
+// version and capabilities with the ATC when it registered.
+func negotiatedProtocol(dbWorker db.Worker) bool {
+	return dbWorker.ProtocolVersion() > atc.LegacyWorkerProtocolVersion
+}
+
+// lacksCapability returns whether the worker negotiated a protocol without
+// the capability, so the ATC must not rely on it. Workers which predate
+// negotiation are relied on for everything, as they always have been.
+func lacksCapability(dbWorker db.Worker, capability atc.WorkerCapability) bool {
+	if !negotiatedProtocol(dbWorker) {
+		return false
+	}
+
+	for _, c := range dbWorker.Capabilities() {
+		if c == capability {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,6 +1,8 @@
 package worker
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 )
@@ -9,8 +11,8 @@ type CertsVolumeMount struct {
 	Logger lager.Logger
 }
 
-func (s *CertsVolumeMount) VolumeOn(worker Worker) (garden.BindMount, bool, error) {
-	volume, found, err := worker.CertsVolume(s.Logger.Session("worker-certs-volume"))
+func (s *CertsVolumeMount) VolumeOn(ctx context.Context, worker Worker) (garden.BindMount, bool, error) {
+	volume, found, err := worker.CertsVolume(ctx, s.Logger.Session("worker-certs-volume"))
 	if err != nil {
 		return garden.BindMount{}, false, err
 	}
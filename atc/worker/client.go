@@ -25,7 +25,7 @@ const taskExitStatusPropertyName = "concourse:exit-status"
 type Client interface {
 	FindContainer(logger lager.Logger, teamID int, handle string) (Container, bool, error)
 	FindVolume(logger lager.Logger, teamID int, handle string) (Volume, bool, error)
-	CreateVolume(logger lager.Logger, vSpec VolumeSpec, wSpec WorkerSpec, volumeType db.VolumeType) (Volume, error)
+	CreateVolume(ctx context.Context, logger lager.Logger, vSpec VolumeSpec, wSpec WorkerSpec, volumeType db.VolumeType) (Volume, error)
 	RunTaskStep(
 		context.Context,
 		lager.Logger,
@@ -107,13 +107,13 @@ func (client *client) FindVolume(logger lager.Logger, teamID int, handle string)
 	return worker.LookupVolume(logger, handle)
 }
 
-func (client *client) CreateVolume(logger lager.Logger, volumeSpec VolumeSpec, workerSpec WorkerSpec, volumeType db.VolumeType) (Volume, error) {
+func (client *client) CreateVolume(ctx context.Context, logger lager.Logger, volumeSpec VolumeSpec, workerSpec WorkerSpec, volumeType db.VolumeType) (Volume, error) {
 	worker, err := client.pool.FindOrChooseWorker(logger, workerSpec)
 	if err != nil {
 		return nil, err
 	}
 
-	return worker.CreateVolume(logger, volumeSpec, workerSpec.TeamID, volumeType)
+	return worker.CreateVolume(ctx, logger, volumeSpec, workerSpec.TeamID, volumeType)
 }
 
 func (client *client) RunTaskStep(
@@ -172,6 +172,8 @@ func (client *client) RunTaskStep(
 			return TaskResult{-1, []VolumeMount{}, err}
 		}
 
+		releaseGPUs(logger, containerSpec, container)
+
 		return TaskResult{Status: status, VolumeMounts: container.VolumeMounts(), Err: nil}
 
 	}
@@ -188,7 +190,8 @@ func (client *client) RunTaskStep(
 		logger.Info("spawning")
 
 		events <- runtime.Event{
-			EventType: runtime.StartingEvent,
+			EventType:  runtime.StartingEvent,
+			WorkerName: chosenWorker.Name(),
 		}
 
 		process, err = container.Run(
@@ -233,6 +236,10 @@ func (client *client) RunTaskStep(
 		}
 
 		status := <-exitStatusChan
+		if status.processErr == nil {
+			releaseGPUs(logger, containerSpec, container)
+		}
+
 		return TaskResult{Status: status.processStatus, VolumeMounts: container.VolumeMounts(), Err: ctx.Err()}
 
 	case status := <-exitStatusChan:
@@ -240,6 +247,8 @@ func (client *client) RunTaskStep(
 			return TaskResult{Status: status.processStatus, VolumeMounts: []VolumeMount{}, Err: status.processErr}
 		}
 
+		releaseGPUs(logger, containerSpec, container)
+
 		err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", status.processStatus))
 		if err != nil {
 			return TaskResult{Status: status.processStatus, VolumeMounts: []VolumeMount{}, Err: err}
@@ -247,6 +256,22 @@ func (client *client) RunTaskStep(
 		return TaskResult{Status: status.processStatus, VolumeMounts: container.VolumeMounts(), Err: nil}
 	}
 }
+
+// releaseGPUs releases the GPUs allocated to a task's container once the task
+// has exited. The container is kept for hijacking, but no longer needs them.
+// If the task's process is lost rather than having exited, they're left
+// allocated, as it may still be running.
+func releaseGPUs(logger lager.Logger, containerSpec ContainerSpec, container Container) {
+	if containerSpec.GPUs == 0 {
+		return
+	}
+
+	err := container.ReleaseGPUs()
+	if err != nil {
+		logger.Error("failed-to-release-gpus", err)
+	}
+}
+
 func (client *client) chooseTaskWorker(
 	ctx context.Context,
 	logger lager.Logger,
@@ -182,7 +182,7 @@ var _ = Describe("Client", func() {
 		})
 
 		JustBeforeEach(func() {
-			_, err = client.CreateVolume(logger, volumeSpec, workerSpec, volumeType)
+			_, err = client.CreateVolume(context.Background(), logger, volumeSpec, workerSpec, volumeType)
 		})
 
 		Context("when no workers can be found", func() {
@@ -204,7 +204,7 @@ var _ = Describe("Client", func() {
 			It("creates the volume on the worker", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(1))
-				l, spec, id, t := fakeWorker.CreateVolumeArgsForCall(0)
+				_, l, spec, id, t := fakeWorker.CreateVolumeArgsForCall(0)
 				Expect(l).To(Equal(logger))
 				Expect(spec).To(Equal(volumeSpec))
 				Expect(id).To(Equal(1))
@@ -431,6 +431,16 @@ var _ = Describe("Client", func() {
 				Expect(status).To(Equal(8))
 			})
 
+			Context("when the container has gpus", func() {
+				BeforeEach(func() {
+					fakeContainerSpec.GPUs = 1
+				})
+
+				It("releases them", func() {
+					Expect(fakeContainer.ReleaseGPUsCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("when 'limit-active-tasks' strategy is chosen", func() {
 				BeforeEach(func() {
 					fakeStrategy.ModifiesActiveTasksReturns(true)
@@ -553,7 +563,7 @@ var _ = Describe("Client", func() {
 				})
 
 				It("does not send a Starting event", func() {
-					Expect(eventChan).ToNot(Receive(Equal(runtime.Event{runtime.StartingEvent, 0})))
+					Expect(eventChan).ToNot(Receive())
 				})
 
 				It("does not create a new container", func() {
@@ -650,6 +660,20 @@ var _ = Describe("Client", func() {
 						))
 					})
 
+					It("leaves the container's gpus alone if it has none", func() {
+						Expect(fakeContainer.ReleaseGPUsCallCount()).To(BeZero())
+					})
+
+					Context("when the container has gpus", func() {
+						BeforeEach(func() {
+							fakeContainerSpec.GPUs = 1
+						})
+
+						It("releases them", func() {
+							Expect(fakeContainer.ReleaseGPUsCallCount()).To(Equal(1))
+						})
+					})
+
 					Context("when 'limit-active-tasks' strategy is chosen", func() {
 						BeforeEach(func() {
 							fakeStrategy.ModifiesActiveTasksReturns(true)
@@ -664,6 +688,7 @@ var _ = Describe("Client", func() {
 				Context("when the process exits with an error", func() {
 					disaster := errors.New("process failed")
 					BeforeEach(func() {
+						fakeContainerSpec.GPUs = 1
 						fakeProcessExitCode = 128 + 15
 						fakeProcess.WaitReturns(fakeProcessExitCode, disaster)
 					})
@@ -673,6 +698,10 @@ var _ = Describe("Client", func() {
 						Expect(err).To(Equal(disaster))
 					})
 
+					It("keeps the container's gpus", func() {
+						Expect(fakeContainer.ReleaseGPUsCallCount()).To(BeZero())
+					})
+
 					It("returns no volume mounts", func() {
 						Expect(volumeMounts).To(BeEmpty())
 					})
@@ -703,7 +732,10 @@ var _ = Describe("Client", func() {
 				})
 
 				It("sends a Starting event", func() {
-					Expect(eventChan).To(Receive(Equal(runtime.Event{"Starting", 0})))
+					Expect(eventChan).To(Receive(Equal(runtime.Event{
+						EventType:  runtime.StartingEvent,
+						WorkerName: "some-worker",
+					})))
 				})
 
 				It("runs a new process in the container", func() {
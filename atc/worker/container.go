@@ -24,6 +24,8 @@ type Container interface {
 	WorkerName() string
 
 	MarkAsHijacked() error
+
+	ReleaseGPUs() error
 }
 
 type gardenWorkerContainer struct {
@@ -96,6 +98,10 @@ func (container *gardenWorkerContainer) MarkAsHijacked() error {
 	return container.dbContainer.MarkAsHijacked()
 }
 
+func (container *gardenWorkerContainer) ReleaseGPUs() error {
+	return container.dbContainer.ReleaseGPUs()
+}
+
 func (container *gardenWorkerContainer) Run(ctx context.Context, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
 	spec.User = container.user
 	return container.Container.Run(ctx, spec, io)
@@ -1,7 +1,9 @@
 package worker
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
@@ -13,8 +15,14 @@ type WorkerSpec struct {
 	Platform      string
 	ResourceType  string
 	Tags          []string
+	Labels        atc.Labels
+	GPUs          int
 	TeamID        int
 	ResourceTypes atc.VersionedResourceTypes
+
+	// WorkerName restricts the spec to the named worker, for steps of a
+	// build which was triggered with them pinned to one.
+	WorkerName string
 }
 
 type ContainerSpec struct {
@@ -44,6 +52,23 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional PEM-encoded CA certificates to place in the container and
+	// trust alongside the image's own.
+	CACerts string
+
+	// Optional DNS configuration to write to the container's resolv.conf in
+	// place of the one configured by the worker.
+	DNS *atc.ContainerDNS
+
+	// Number of the worker's GPUs to allocate to the container. Their devices
+	// are mounted into it.
+	GPUs int
+
+	// Optional files to write into the container, keyed by their path
+	// relative to Dir. They're kept on a tmpfs, readable only by the
+	// container's user, and linked to from their paths.
+	Files map[string]string
 }
 
 //go:generate counterfeiter . InputSource
@@ -56,7 +81,7 @@ type InputSource interface {
 //go:generate counterfeiter . BindMountSource
 
 type BindMountSource interface {
-	VolumeOn(Worker) (garden.BindMount, bool, error)
+	VolumeOn(context.Context, Worker) (garden.BindMount, bool, error)
 }
 
 // OutputPaths is a mapping from output name to its path in the container.
@@ -68,6 +93,11 @@ type ImageSpec struct {
 	ImageResource       *ImageResource
 	ImageArtifactSource ArtifactSource
 	Privileged          bool
+
+	// Tags are the tags of the step the image is for, taken from its
+	// ContainerSpec, which images fetched by resources are prefetched onto
+	// other workers with.
+	Tags []string
 }
 
 type ImageResource struct {
@@ -114,5 +144,26 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	attrs = append(attrs, labelDescriptions(spec.Labels)...)
+
+	if spec.GPUs > 0 {
+		attrs = append(attrs, fmt.Sprintf("%d gpus", spec.GPUs))
+	}
+
+	if spec.WorkerName != "" {
+		attrs = append(attrs, fmt.Sprintf("worker '%s'", spec.WorkerName))
+	}
+
 	return strings.Join(attrs, ", ")
 }
+
+func labelDescriptions(labels atc.Labels) []string {
+	var descriptions []string
+	for name, value := range labels {
+		descriptions = append(descriptions, fmt.Sprintf("label '%s=%s'", name, value))
+	}
+
+	sort.Strings(descriptions)
+
+	return descriptions
+}
@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+//go:generate counterfeiter . CreationLimiter
+
+// CreationLimiter caps the number of containers and volumes being created on
+// each worker at once. Bursts of simultaneous creates are what tip over
+// Garden and overlay filesystems, regardless of how many containers a worker
+// already has.
+//
+// Acquire blocks until a slot on the named worker is free or the context is
+// done, and returns a func which gives the slot back.
+type CreationLimiter interface {
+	Acquire(ctx context.Context, logger lager.Logger, workerName string) (func(), error)
+}
+
+type creationLimiter struct {
+	limit int
+
+	lock   sync.Mutex
+	slots  map[string]chan struct{}
+	queued map[string]int
+}
+
+// NewCreationLimiter returns a CreationLimiter which allows the given number
+// of concurrent creations per worker.
+func NewCreationLimiter(limit int) CreationLimiter {
+	return &creationLimiter{
+		limit:  limit,
+		slots:  map[string]chan struct{}{},
+		queued: map[string]int{},
+	}
+}
+
+func (limiter *creationLimiter) Acquire(ctx context.Context, logger lager.Logger, workerName string) (func(), error) {
+	slots := limiter.slotsFor(workerName)
+
+	release := func() { <-slots }
+
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	logger.Debug("waiting-for-creation-slot", lager.Data{"worker": workerName})
+
+	start := time.Now()
+
+	limiter.enqueue(logger, workerName, 1)
+	defer limiter.enqueue(logger, workerName, -1)
+
+	select {
+	case slots <- struct{}{}:
+		metric.WorkerCreationWaited{
+			WorkerName: workerName,
+			Duration:   time.Since(start),
+		}.Emit(logger)
+
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (limiter *creationLimiter) slotsFor(workerName string) chan struct{} {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	slots, found := limiter.slots[workerName]
+	if !found {
+		slots = make(chan struct{}, limiter.limit)
+		limiter.slots[workerName] = slots
+	}
+
+	return slots
+}
+
+func (limiter *creationLimiter) enqueue(logger lager.Logger, workerName string, delta int) {
+	limiter.lock.Lock()
+	limiter.queued[workerName] += delta
+	queued := limiter.queued[workerName]
+	limiter.lock.Unlock()
+
+	metric.WorkerCreationsQueued{
+		WorkerName: workerName,
+		Queued:     queued,
+	}.Emit(logger)
+}
+
+// acquireCreationSlot acquires a slot from the limiter, if there is one.
+func acquireCreationSlot(ctx context.Context, logger lager.Logger, limiter CreationLimiter, workerName string) (func(), error) {
+	if limiter == nil {
+		return func() {}, nil
+	}
+
+	return limiter.Acquire(ctx, logger, workerName)
+}
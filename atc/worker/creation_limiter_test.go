@@ -0,0 +1,69 @@
+package worker_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreationLimiter", func() {
+	var (
+		logger  *lagertest.TestLogger
+		limiter worker.CreationLimiter
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		limiter = worker.NewCreationLimiter(2)
+	})
+
+	It("allows up to the limit of creations on a worker at once", func() {
+		_, err := limiter.Acquire(context.Background(), logger, "some-worker")
+		Expect(err).ToNot(HaveOccurred())
+
+		release, err := limiter.Acquire(context.Background(), logger, "some-worker")
+		Expect(err).ToNot(HaveOccurred())
+
+		acquired := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+
+			_, err := limiter.Acquire(context.Background(), logger, "some-worker")
+			Expect(err).ToNot(HaveOccurred())
+
+			close(acquired)
+		}()
+
+		Consistently(acquired).ShouldNot(BeClosed())
+
+		release()
+
+		Eventually(acquired).Should(BeClosed())
+	})
+
+	It("limits each worker separately", func() {
+		for i := 0; i < 2; i++ {
+			_, err := limiter.Acquire(context.Background(), logger, "some-worker")
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		_, err := limiter.Acquire(context.Background(), logger, "other-worker")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("gives up waiting when the context is done", func() {
+		for i := 0; i < 2; i++ {
+			_, err := limiter.Acquire(context.Background(), logger, "some-worker")
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := limiter.Acquire(ctx, logger, "some-worker")
+		Expect(err).To(Equal(context.Canceled))
+	})
+})
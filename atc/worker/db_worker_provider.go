@@ -1,7 +1,6 @@
 package worker
 
 import (
-	"net/http"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -17,19 +16,21 @@ import (
 )
 
 type dbWorkerProvider struct {
-	lockFactory                       lock.LockFactory
-	retryBackOffFactory               retryhttp.BackOffFactory
-	imageFactory                      ImageFactory
-	dbResourceCacheFactory            db.ResourceCacheFactory
-	dbResourceConfigFactory           db.ResourceConfigFactory
-	dbWorkerBaseResourceTypeFactory   db.WorkerBaseResourceTypeFactory
-	dbTaskCacheFactory                db.TaskCacheFactory
-	dbWorkerTaskCacheFactory          db.WorkerTaskCacheFactory
-	dbVolumeRepository                db.VolumeRepository
-	dbTeamFactory                     db.TeamFactory
-	dbWorkerFactory                   db.WorkerFactory
-	workerVersion                     version.Version
-	baggageclaimResponseHeaderTimeout time.Duration
+	lockFactory                     lock.LockFactory
+	retryBackOffFactory             retryhttp.BackOffFactory
+	imageFactory                    ImageFactory
+	dbResourceCacheFactory          db.ResourceCacheFactory
+	dbResourceConfigFactory         db.ResourceConfigFactory
+	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory
+	dbTaskCacheFactory              db.TaskCacheFactory
+	dbWorkerTaskCacheFactory        db.WorkerTaskCacheFactory
+	dbVolumeRepository              db.VolumeRepository
+	dbTeamFactory                   db.TeamFactory
+	dbWorkerFactory                 db.WorkerFactory
+	workerVersion                   version.Version
+	workerConnections               *transport.WorkerConnections
+	resourceCacheStore              ResourceCacheStore
+	creationLimiter                 CreationLimiter
 }
 
 func NewDBWorkerProvider(
@@ -45,22 +46,26 @@ func NewDBWorkerProvider(
 	dbTeamFactory db.TeamFactory,
 	workerFactory db.WorkerFactory,
 	workerVersion version.Version,
-	baggageclaimResponseHeaderTimeout time.Duration,
+	workerConnections *transport.WorkerConnections,
+	resourceCacheStore ResourceCacheStore,
+	creationLimiter CreationLimiter,
 ) WorkerProvider {
 	return &dbWorkerProvider{
-		lockFactory:                       lockFactory,
-		retryBackOffFactory:               retryBackOffFactory,
-		imageFactory:                      imageFactory,
-		dbResourceCacheFactory:            dbResourceCacheFactory,
-		dbResourceConfigFactory:           dbResourceConfigFactory,
-		dbWorkerBaseResourceTypeFactory:   dbWorkerBaseResourceTypeFactory,
-		dbTaskCacheFactory:                dbTaskCacheFactory,
-		dbWorkerTaskCacheFactory:          dbWorkerTaskCacheFactory,
-		dbVolumeRepository:                dbVolumeRepository,
-		dbTeamFactory:                     dbTeamFactory,
-		dbWorkerFactory:                   workerFactory,
-		workerVersion:                     workerVersion,
-		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		lockFactory:                     lockFactory,
+		retryBackOffFactory:             retryBackOffFactory,
+		imageFactory:                    imageFactory,
+		dbResourceCacheFactory:          dbResourceCacheFactory,
+		dbResourceConfigFactory:         dbResourceConfigFactory,
+		dbWorkerBaseResourceTypeFactory: dbWorkerBaseResourceTypeFactory,
+		dbTaskCacheFactory:              dbTaskCacheFactory,
+		dbWorkerTaskCacheFactory:        dbWorkerTaskCacheFactory,
+		dbVolumeRepository:              dbVolumeRepository,
+		dbTeamFactory:                   dbTeamFactory,
+		dbWorkerFactory:                 workerFactory,
+		workerVersion:                   workerVersion,
+		workerConnections:               workerConnections,
+		resourceCacheStore:              resourceCacheStore,
+		creationLimiter:                 creationLimiter,
 	}
 }
 
@@ -70,6 +75,14 @@ func (provider *dbWorkerProvider) RunningWorkers(logger lager.Logger) ([]Worker,
 		return nil, err
 	}
 
+	// workers which are no longer saved have been pruned or retired
+	var savedWorkerNames []string
+	for _, savedWorker := range savedWorkers {
+		savedWorkerNames = append(savedWorkerNames, savedWorker.Name())
+	}
+
+	provider.workerConnections.Retain(savedWorkerNames)
+
 	buildContainersCountPerWorker, err := provider.dbWorkerFactory.BuildContainersCountPerWorker()
 	if err != nil {
 		return nil, err
@@ -176,6 +189,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		logger.Session("garden-connection"),
 		savedWorker.Name(),
 		savedWorker.GardenAddr(),
+		provider.workerConnections.Garden(savedWorker.Name()),
 		provider.retryBackOffFactory,
 		5*time.Minute,
 	)
@@ -186,10 +200,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		savedWorker.Name(),
 		savedWorker.BaggageclaimURL(),
 		provider.dbWorkerFactory,
-		&http.Transport{
-			DisableKeepAlives:     true,
-			ResponseHeaderTimeout: provider.baggageclaimResponseHeaderTimeout,
-		},
+		provider.workerConnections.Baggageclaim(savedWorker.Name()),
 	))
 
 	volumeClient := NewVolumeClient(
@@ -201,6 +212,8 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbWorkerBaseResourceTypeFactory,
 		provider.dbTaskCacheFactory,
 		provider.dbWorkerTaskCacheFactory,
+		provider.resourceCacheStore,
+		provider.creationLimiter,
 	)
 
 	return NewGardenWorker(
@@ -211,5 +224,6 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbTeamFactory,
 		savedWorker,
 		buildContainersCount,
+		provider.creationLimiter,
 	)
 }
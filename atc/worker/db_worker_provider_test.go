@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden/client"
 	"code.cloudfoundry.org/garden/client/connection"
 	gfakes "code.cloudfoundry.org/garden/gardenfakes"
@@ -18,6 +19,7 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	. "github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/transport"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/retryhttp/retryhttpfakes"
 	"github.com/cppforlife/go-semi-semantic/version"
@@ -175,7 +177,11 @@ var _ = Describe("DBProvider", func() {
 			fakeDBTeamFactory,
 			fakeDBWorkerFactory,
 			wantWorkerVersion,
-			baggageclaimResponseHeaderTimeout,
+			transport.NewWorkerConnections(logger, clock.NewClock(), transport.WorkerConnectionsConfig{
+				BaggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+			}),
+			nil,
+			nil,
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
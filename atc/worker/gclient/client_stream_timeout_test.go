@@ -51,6 +51,7 @@ var _ = Describe("stream http client", func() {
 				fakeLogger,
 				"wont-talk-to-you",
 				hostname,
+				&http.Transport{DisableKeepAlives: true},
 				retryhttp.NewExponentialBackOffFactory(1*time.Second),
 				1*time.Second,
 			)
@@ -17,6 +17,7 @@ type gardenClientFactory struct {
 	logger                     lager.Logger
 	workerName                 string
 	workerHost                 *string
+	roundTripper               http.RoundTripper
 	retryBackOffFactory        retryhttp.BackOffFactory
 	streamClientRequestTimeout time.Duration
 }
@@ -26,6 +27,7 @@ func NewGardenClientFactory(
 	logger lager.Logger,
 	workerName string,
 	workerHost *string,
+	roundTripper http.RoundTripper,
 	retryBackOffFactory retryhttp.BackOffFactory,
 	streamClientRequestTimeout time.Duration,
 ) *gardenClientFactory {
@@ -34,6 +36,7 @@ func NewGardenClientFactory(
 		logger:                     logger,
 		workerName:                 workerName,
 		workerHost:                 workerHost,
+		roundTripper:               roundTripper,
 		retryBackOffFactory:        retryBackOffFactory,
 		streamClientRequestTimeout: streamClientRequestTimeout,
 	}
@@ -48,7 +51,7 @@ func (gcf *gardenClientFactory) NewClient() Client {
 		Transport: &retryhttp.RetryRoundTripper{
 			Logger:         gcf.logger.Session("retryable-http-client"),
 			BackOffFactory: gcf.retryBackOffFactory,
-			RoundTripper:   transport.NewGardenRoundTripper(gcf.workerName, gcf.workerHost, gcf.db, &http.Transport{DisableKeepAlives: true}),
+			RoundTripper:   transport.NewGardenRoundTripper(gcf.workerName, gcf.workerHost, gcf.db, gcf.roundTripper),
 			Retryer:        retryer,
 		},
 		Timeout: gcf.streamClientRequestTimeout,
@@ -28,6 +28,7 @@ func (i *imageProvidedByPreviousStepOnSameWorker) FetchForContainer(
 	container db.CreatingContainer,
 ) (worker.FetchedImage, error) {
 	imageVolume, err := i.volumeClient.FindOrCreateCOWVolumeForContainer(
+		ctx,
 		logger,
 		worker.VolumeSpec{
 			Strategy:   i.artifactVolume.COWStrategy(),
@@ -43,7 +44,7 @@ func (i *imageProvidedByPreviousStepOnSameWorker) FetchForContainer(
 		return worker.FetchedImage{}, err
 	}
 
-	imageMetadataReader, err := i.imageSpec.ImageArtifactSource.StreamFile(ctx, logger, ImageMetadataFile)
+	imageMetadataReader, err := streamImageMetadata(ctx, logger, artifactFileStreamer(logger, i.imageSpec.ImageArtifactSource), imageVolume)
 	if err != nil {
 		logger.Error("failed-to-stream-metadata-file", err)
 		return worker.FetchedImage{}, err
@@ -66,10 +67,17 @@ func (i *imageProvidedByPreviousStepOnSameWorker) FetchForContainer(
 	}, nil
 }
 
+func artifactFileStreamer(logger lager.Logger, source worker.ArtifactSource) imageFileStreamer {
+	return func(ctx context.Context, path string) (io.ReadCloser, error) {
+		return source.StreamFile(ctx, logger, path)
+	}
+}
+
 type imageProvidedByPreviousStepOnDifferentWorker struct {
 	imageSpec    worker.ImageSpec
 	teamID       int
 	volumeClient worker.VolumeClient
+	delegate     worker.ImageFetchingDelegate
 }
 
 func (i *imageProvidedByPreviousStepOnDifferentWorker) FetchForContainer(
@@ -78,6 +86,7 @@ func (i *imageProvidedByPreviousStepOnDifferentWorker) FetchForContainer(
 	container db.CreatingContainer,
 ) (worker.FetchedImage, error) {
 	imageVolume, err := i.volumeClient.FindOrCreateVolumeForContainer(
+		ctx,
 		logger,
 		worker.VolumeSpec{
 			Strategy:   baggageclaim.EmptyStrategy{},
@@ -92,8 +101,19 @@ func (i *imageProvidedByPreviousStepOnDifferentWorker) FetchForContainer(
 		return worker.FetchedImage{}, err
 	}
 
+	err = i.delegate.ImageFetchStarted()
+	if err != nil {
+		logger.Error("failed-to-record-image-fetch-started", err)
+	}
+
 	dest := artifactDestination{
 		destination: imageVolume,
+		progress: func(bytes int64) {
+			err := i.delegate.ImageBytesStreamed(bytes)
+			if err != nil {
+				logger.Error("failed-to-record-image-bytes-streamed", err)
+			}
+		},
 	}
 
 	err = i.imageSpec.ImageArtifactSource.StreamTo(ctx, logger, &dest)
@@ -102,7 +122,12 @@ func (i *imageProvidedByPreviousStepOnDifferentWorker) FetchForContainer(
 		return worker.FetchedImage{}, err
 	}
 
-	imageMetadataReader, err := i.imageSpec.ImageArtifactSource.StreamFile(ctx, logger, ImageMetadataFile)
+	err = i.delegate.ImageFetchFinished()
+	if err != nil {
+		logger.Error("failed-to-record-image-fetch-finished", err)
+	}
+
+	imageMetadataReader, err := streamImageMetadata(ctx, logger, artifactFileStreamer(logger, i.imageSpec.ImageArtifactSource), imageVolume)
 	if err != nil {
 		logger.Error("failed-to-stream-metadata-file", err)
 		return worker.FetchedImage{}, err
@@ -150,6 +175,7 @@ func (i *imageFromResource) FetchForContainer(
 	}
 
 	imageVolume, err := i.volumeClient.FindOrCreateCOWVolumeForContainer(
+		ctx,
 		logger.Session("create-cow-volume"),
 		worker.VolumeSpec{
 			Strategy:   imageParentVolume.COWStrategy(),
@@ -198,6 +224,7 @@ func (i *imageFromBaseResourceType) FetchForContainer(
 	for _, t := range i.worker.ResourceTypes() {
 		if t.Type == i.resourceTypeName {
 			importVolume, err := i.volumeClient.FindOrCreateVolumeForBaseResourceType(
+				ctx,
 				logger,
 				worker.VolumeSpec{
 					Strategy:   baggageclaim.ImportStrategy{Path: t.Image},
@@ -211,6 +238,7 @@ func (i *imageFromBaseResourceType) FetchForContainer(
 			}
 
 			cowVolume, err := i.volumeClient.FindOrCreateCOWVolumeForContainer(
+				ctx,
 				logger,
 				worker.VolumeSpec{
 					Strategy:   importVolume.COWStrategy(),
@@ -256,10 +284,51 @@ func (i *imageFromRootfsURI) FetchForContainer(
 	}, nil
 }
 
+// imageProgressInterval is how many bytes of an image are streamed between
+// each report of its progress.
+const imageProgressInterval = 16 * 1024 * 1024
+
 type artifactDestination struct {
 	destination worker.Volume
+
+	// progress, if set, is called with the total bytes streamed in every
+	// imageProgressInterval bytes, and once the stream is done.
+	progress func(int64)
 }
 
 func (wad *artifactDestination) StreamIn(ctx context.Context, path string, tarStream io.Reader) error {
-	return wad.destination.StreamIn(ctx, path, tarStream)
+	if wad.progress == nil {
+		return wad.destination.StreamIn(ctx, path, tarStream)
+	}
+
+	reader := &progressReader{reader: tarStream, progress: wad.progress}
+
+	err := wad.destination.StreamIn(ctx, path, reader)
+	if err != nil {
+		return err
+	}
+
+	wad.progress(reader.total)
+
+	return nil
+}
+
+type progressReader struct {
+	reader   io.Reader
+	progress func(int64)
+
+	total    int64
+	reported int64
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+
+	r.total += int64(n)
+	if r.total-r.reported >= imageProgressInterval {
+		r.reported = r.total
+		r.progress(r.total)
+	}
+
+	return n, err
 }
@@ -13,13 +13,16 @@ var ErrUnsupportedResourceType = errors.New("unsupported resource type")
 
 type imageFactory struct {
 	imageResourceFetcherFactory ImageResourceFetcherFactory
+	resourceTypeMappings        ResourceTypeMappings
 }
 
 func NewImageFactory(
 	imageResourceFetcherFactory ImageResourceFetcherFactory,
+	resourceTypeMappings ResourceTypeMappings,
 ) worker.ImageFactory {
 	return &imageFactory{
 		imageResourceFetcherFactory: imageResourceFetcherFactory,
+		resourceTypeMappings:        resourceTypeMappings,
 	}
 }
 
@@ -52,13 +55,14 @@ func (f *imageFactory) GetImage(
 			imageSpec:    imageSpec,
 			teamID:       teamID,
 			volumeClient: volumeClient,
+			delegate:     delegate,
 		}, nil
 	}
 
 	// check if custom resource
 	resourceType, found := resourceTypes.Lookup(imageSpec.ResourceType)
 	if found {
-		imageResourceFetcher := f.imageResourceFetcherFactory.NewImageResourceFetcher(
+		imageResourceFetcher := f.imageResourceFetcherFactory.NewResourceTypeImageFetcher(
 			worker,
 			w.ImageResource{
 				Type:   resourceType.Type,
@@ -67,6 +71,7 @@ func (f *imageFactory) GetImage(
 			},
 			resourceType.Version,
 			teamID,
+			imageSpec.Tags,
 			resourceTypes.Without(imageSpec.ResourceType),
 			delegate,
 		)
@@ -91,6 +96,7 @@ func (f *imageFactory) GetImage(
 			*imageSpec.ImageResource,
 			version,
 			teamID,
+			imageSpec.Tags,
 			resourceTypes,
 			delegate,
 		)
@@ -105,6 +111,38 @@ func (f *imageFactory) GetImage(
 	}
 
 	if imageSpec.ResourceType != "" {
+		mappings, err := f.resourceTypeMappings.ForTeam(teamID)
+		if err != nil {
+			logger.Error("failed-to-find-resource-type-mappings", err)
+			return nil, err
+		}
+
+		// a remapped base type is implemented by the mapping's image, fetched
+		// without the pipeline's resource types so that they cannot shadow it
+		mapping, found := mappings[imageSpec.ResourceType]
+		if found {
+			imageResourceFetcher := f.imageResourceFetcherFactory.NewResourceTypeImageFetcher(
+				worker,
+				w.ImageResource{
+					Type:   mapping.Type,
+					Source: mapping.Source,
+				},
+				nil,
+				teamID,
+				imageSpec.Tags,
+				atc.VersionedResourceTypes{},
+				delegate,
+			)
+
+			return &imageFromResource{
+				imageResourceFetcher: imageResourceFetcher,
+
+				privileged:   mapping.Privileged,
+				teamID:       teamID,
+				volumeClient: volumeClient,
+			}, nil
+		}
+
 		return &imageFromBaseResourceType{
 			worker:           worker,
 			resourceTypeName: imageSpec.ResourceType,
@@ -0,0 +1,175 @@
+package image
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// ImagePrefetchRequest is an image which was just fetched onto a worker, to
+// be prefetched onto the other workers which could run the same steps.
+type ImagePrefetchRequest struct {
+	Worker        worker.Worker
+	Volume        worker.Volume
+	ResourceCache db.UsedResourceCache
+
+	TeamID        int
+	Tags          []string
+	ResourceType  string
+	ResourceTypes atc.VersionedResourceTypes
+}
+
+// ImagePrefetchQueue carries images fetched by image resource fetchers to the
+// ImagePrefetcher. Images are dropped rather than queued once it's full, so
+// that fetching an image never waits on prefetching another.
+type ImagePrefetchQueue chan ImagePrefetchRequest
+
+func NewImagePrefetchQueue(size int) ImagePrefetchQueue {
+	return make(ImagePrefetchQueue, size)
+}
+
+func (queue ImagePrefetchQueue) enqueue(logger lager.Logger, request ImagePrefetchRequest) {
+	if queue == nil {
+		return
+	}
+
+	select {
+	case queue <- request:
+	default:
+		logger.Info("image-prefetch-queue-full")
+	}
+}
+
+// ImagePrefetcher streams each image on its queue from the worker which
+// fetched it onto every other running worker which could run the same step,
+// i.e. which has the step's tags and belongs to its team or to none, so that
+// builds scheduled on those workers don't download the image from its
+// registry again. At most maxInFlight workers are prefetched onto at once.
+type ImagePrefetcher struct {
+	logger         lager.Logger
+	queue          ImagePrefetchQueue
+	workerProvider worker.WorkerProvider
+	maxInFlight    int
+}
+
+func NewImagePrefetcher(
+	logger lager.Logger,
+	queue ImagePrefetchQueue,
+	workerProvider worker.WorkerProvider,
+	maxInFlight int,
+) *ImagePrefetcher {
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	return &ImagePrefetcher{
+		logger:         logger,
+		queue:          queue,
+		workerProvider: workerProvider,
+		maxInFlight:    maxInFlight,
+	}
+}
+
+func (p *ImagePrefetcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	close(ready)
+
+	for {
+		select {
+		case request := <-p.queue:
+			p.Prefetch(ctx, request)
+		case <-signals:
+			return nil
+		}
+	}
+}
+
+// Prefetch streams the requested image onto each worker which could run the
+// same steps and doesn't already have it.
+func (p *ImagePrefetcher) Prefetch(ctx context.Context, request ImagePrefetchRequest) {
+	logger := p.logger.Session("prefetch", lager.Data{
+		"resource-cache": request.ResourceCache.ID(),
+		"source-worker":  request.Worker.Name(),
+	})
+
+	workers, err := p.workerProvider.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+		return
+	}
+
+	spec := worker.WorkerSpec{
+		Tags:          request.Tags,
+		TeamID:        request.TeamID,
+		ResourceType:  request.ResourceType,
+		ResourceTypes: request.ResourceTypes,
+	}
+
+	inFlight := make(chan struct{}, p.maxInFlight)
+
+	wg := new(sync.WaitGroup)
+	for _, target := range workers {
+		if target.Name() == request.Worker.Name() || !target.Satisfies(logger, spec) {
+			continue
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func(target worker.Worker) {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+
+			targetLogger := logger.WithData(lager.Data{"target-worker": target.Name()})
+
+			err := p.prefetchOnto(ctx, targetLogger, target, request)
+			if err != nil {
+				targetLogger.Error("failed-to-prefetch-image", err)
+			}
+		}(target)
+	}
+
+	wg.Wait()
+}
+
+func (p *ImagePrefetcher) prefetchOnto(ctx context.Context, logger lager.Logger, target worker.Worker, request ImagePrefetchRequest) error {
+	_, found, err := target.FindVolumeForResourceCache(logger, request.ResourceCache)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		return nil
+	}
+
+	tarStream, err := request.Volume.StreamOut(lagerctx.NewContext(ctx, logger), ".")
+	if err != nil {
+		return err
+	}
+
+	defer tarStream.Close()
+
+	_, created, err := target.CreateVolumeForResourceCache(ctx, logger, request.ResourceCache, tarStream)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		logger.Debug("prefetched")
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package image_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/image"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImagePrefetcher", func() {
+	var (
+		fakeWorkerProvider    *workerfakes.FakeWorkerProvider
+		sourceWorker          *workerfakes.FakeWorker
+		sourceVolume          *workerfakes.FakeVolume
+		fakeUsedResourceCache *dbfakes.FakeUsedResourceCache
+
+		request     image.ImagePrefetchRequest
+		maxInFlight int
+	)
+
+	newWorker := func(name string, satisfies bool) *workerfakes.FakeWorker {
+		w := new(workerfakes.FakeWorker)
+		w.NameReturns(name)
+		w.SatisfiesReturns(satisfies)
+		w.CreateVolumeForResourceCacheStub = func(_ context.Context, _ lager.Logger, _ db.UsedResourceCache, tarStream io.Reader) (worker.Volume, bool, error) {
+			_, err := ioutil.ReadAll(tarStream)
+			return new(workerfakes.FakeVolume), true, err
+		}
+		return w
+	}
+
+	BeforeEach(func() {
+		fakeWorkerProvider = new(workerfakes.FakeWorkerProvider)
+
+		sourceWorker = newWorker("source-worker", true)
+		sourceWorker.TagsReturns(atc.Tags{"some-tag"})
+
+		sourceVolume = new(workerfakes.FakeVolume)
+		sourceVolume.StreamOutStub = func(context.Context, string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader("some-tar")), nil
+		}
+
+		fakeUsedResourceCache = new(dbfakes.FakeUsedResourceCache)
+		fakeUsedResourceCache.IDReturns(42)
+
+		request = image.ImagePrefetchRequest{
+			Worker:        sourceWorker,
+			Volume:        sourceVolume,
+			ResourceCache: fakeUsedResourceCache,
+			TeamID:        123,
+			Tags:          []string{"step-tag"},
+			ResourceType:  "registry-image",
+		}
+
+		maxInFlight = 2
+	})
+
+	JustBeforeEach(func() {
+		image.NewImagePrefetcher(
+			lagertest.NewTestLogger("test"),
+			image.NewImagePrefetchQueue(1),
+			fakeWorkerProvider,
+			maxInFlight,
+		).Prefetch(context.Background(), request)
+	})
+
+	Context("when other workers can run the same steps", func() {
+		var (
+			emptyWorker    *workerfakes.FakeWorker
+			fetchedWorker  *workerfakes.FakeWorker
+			unsuitedWorker *workerfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			emptyWorker = newWorker("empty-worker", true)
+			fetchedWorker = newWorker("fetched-worker", true)
+			fetchedWorker.FindVolumeForResourceCacheReturns(new(workerfakes.FakeVolume), true, nil)
+			unsuitedWorker = newWorker("unsuited-worker", false)
+
+			fakeWorkerProvider.RunningWorkersReturns([]worker.Worker{
+				sourceWorker,
+				emptyWorker,
+				fetchedWorker,
+				unsuitedWorker,
+			}, nil)
+		})
+
+		It("only considers workers which satisfy the step's tags and team", func() {
+			Expect(emptyWorker.SatisfiesCallCount()).To(Equal(1))
+			_, spec := emptyWorker.SatisfiesArgsForCall(0)
+			Expect(spec).To(Equal(worker.WorkerSpec{
+				Tags:         []string{"step-tag"},
+				TeamID:       123,
+				ResourceType: "registry-image",
+			}))
+		})
+
+		It("streams the image onto the workers which don't have it", func() {
+			Expect(emptyWorker.CreateVolumeForResourceCacheCallCount()).To(Equal(1))
+			_, _, resourceCache, _ := emptyWorker.CreateVolumeForResourceCacheArgsForCall(0)
+			Expect(resourceCache).To(Equal(fakeUsedResourceCache))
+
+			Expect(sourceVolume.StreamOutCallCount()).To(Equal(1))
+			_, path := sourceVolume.StreamOutArgsForCall(0)
+			Expect(path).To(Equal("."))
+		})
+
+		It("skips the workers which already have it", func() {
+			Expect(fetchedWorker.CreateVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+
+		It("skips the workers which can't run the same steps", func() {
+			Expect(unsuitedWorker.FindVolumeForResourceCacheCallCount()).To(BeZero())
+			Expect(unsuitedWorker.CreateVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+
+		It("skips the worker which fetched it", func() {
+			Expect(sourceWorker.FindVolumeForResourceCacheCallCount()).To(BeZero())
+			Expect(sourceWorker.CreateVolumeForResourceCacheCallCount()).To(BeZero())
+		})
+
+		Context("when prefetching onto a worker fails", func() {
+			var otherWorker *workerfakes.FakeWorker
+
+			BeforeEach(func() {
+				emptyWorker.FindVolumeForResourceCacheReturns(nil, false, errors.New("nope"))
+
+				otherWorker = newWorker("other-worker", true)
+				fakeWorkerProvider.RunningWorkersReturns([]worker.Worker{
+					sourceWorker,
+					emptyWorker,
+					otherWorker,
+				}, nil)
+			})
+
+			It("still prefetches onto the other workers", func() {
+				Expect(emptyWorker.CreateVolumeForResourceCacheCallCount()).To(BeZero())
+				Expect(otherWorker.CreateVolumeForResourceCacheCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when more workers need the image than may be prefetched onto at once", func() {
+		var (
+			targets     []*workerfakes.FakeWorker
+			inFlight    int32
+			maxObserved int32
+		)
+
+		BeforeEach(func() {
+			maxInFlight = 2
+
+			atomic.StoreInt32(&inFlight, 0)
+			atomic.StoreInt32(&maxObserved, 0)
+
+			workers := []worker.Worker{sourceWorker}
+
+			targets = nil
+			for i := 0; i < 5; i++ {
+				target := newWorker(fmt.Sprintf("worker-%d", i), true)
+				target.CreateVolumeForResourceCacheStub = func(_ context.Context, _ lager.Logger, _ db.UsedResourceCache, tarStream io.Reader) (worker.Volume, bool, error) {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+
+					for {
+						observed := atomic.LoadInt32(&maxObserved)
+						if current <= observed || atomic.CompareAndSwapInt32(&maxObserved, observed, current) {
+							break
+						}
+					}
+
+					time.Sleep(10 * time.Millisecond)
+
+					_, err := ioutil.ReadAll(tarStream)
+					return new(workerfakes.FakeVolume), true, err
+				}
+
+				targets = append(targets, target)
+				workers = append(workers, target)
+			}
+
+			fakeWorkerProvider.RunningWorkersReturns(workers, nil)
+		})
+
+		It("prefetches onto all of them, a bounded number at a time", func() {
+			for _, target := range targets {
+				Expect(target.CreateVolumeForResourceCacheCallCount()).To(Equal(1))
+			}
+
+			Expect(atomic.LoadInt32(&maxObserved)).To(BeNumerically("<=", maxInFlight))
+		})
+	})
+
+	Context("when getting the running workers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerProvider.RunningWorkersReturns(nil, errors.New("nope"))
+		})
+
+		It("doesn't stream the image", func() {
+			Expect(sourceVolume.StreamOutCallCount()).To(BeZero())
+		})
+	})
+})
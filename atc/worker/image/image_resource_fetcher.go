@@ -2,30 +2,101 @@ package image
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/DataDog/zstd"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/fetcher"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
 
 const ImageMetadataFile = "metadata.json"
 
+// ImageDigestFile is written by image resources alongside the rootfs and
+// holds the digest of the image that was fetched.
+const ImageDigestFile = "digest"
+
 // ErrImageUnavailable is returned when a task's configured image resource
 // has no versions.
-var ErrImageUnavailable = errors.New("no versions of image available")
+var ErrImageUnavailable error = imageUnavailableError{}
+
+type imageUnavailableError struct{}
+
+func (imageUnavailableError) Error() string {
+	return "no versions of image available"
+}
+
+func (imageUnavailableError) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeImageUnavailable
+}
 
 var ErrImageGetDidNotProduceVolume = errors.New("fetching the image did not produce a volume")
 
+// ImageIntegrityError is returned when a fetched image does not match the
+// digest it is expected to have, e.g. the one reported by the image
+// resource's version or metadata.
+type ImageIntegrityError struct {
+	Expected string
+	Actual   string
+}
+
+func (err ImageIntegrityError) Error() string {
+	return fmt.Sprintf("image integrity check failed: expected digest %s, got %s", err.Expected, err.Actual)
+}
+
+// imageCheckStderrSnippetSize is how much of the end of a failed image
+// check's stderr is kept in its error.
+const imageCheckStderrSnippetSize = 1024
+
+// ErrImageCheckFailed is returned when the check for the latest version of
+// an image resource exits with a failure, as opposed to the worker failing
+// to run it. Stderr is the end of what the check wrote to stderr.
+type ErrImageCheckFailed struct {
+	ExitStatus int
+	Stderr     string
+}
+
+func newErrImageCheckFailed(err resource.ErrResourceScriptFailed) ErrImageCheckFailed {
+	stderr := err.Stderr
+	if len(stderr) > imageCheckStderrSnippetSize {
+		stderr = "..." + stderr[len(stderr)-imageCheckStderrSnippetSize:]
+	}
+
+	return ErrImageCheckFailed{
+		ExitStatus: err.ExitStatus,
+		Stderr:     stderr,
+	}
+}
+
+func (err ErrImageCheckFailed) Error() string {
+	msg := fmt.Sprintf("checking for the image failed: exit status %d", err.ExitStatus)
+
+	if len(err.Stderr) > 0 {
+		msg += "\n\nstderr:\n" + err.Stderr
+	}
+
+	return msg
+}
+
+func (ErrImageCheckFailed) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeImageCheckFailed
+}
+
 //go:generate counterfeiter . ImageResourceFetcherFactory
 
 type ImageResourceFetcherFactory interface {
@@ -34,6 +105,20 @@ type ImageResourceFetcherFactory interface {
 		worker.ImageResource,
 		atc.Version,
 		int,
+		[]string,
+		atc.VersionedResourceTypes,
+		worker.ImageFetchingDelegate,
+	) ImageResourceFetcher
+
+	// NewResourceTypeImageFetcher is like NewImageResourceFetcher, but for
+	// the image of a custom resource type. Images it fetches are reused by
+	// later fetches of the same image on the same worker.
+	NewResourceTypeImageFetcher(
+		worker.Worker,
+		worker.ImageResource,
+		atc.Version,
+		int,
+		[]string,
 		atc.VersionedResourceTypes,
 		worker.ImageFetchingDelegate,
 	) ImageResourceFetcher
@@ -55,20 +140,42 @@ type imageResourceFetcherFactory struct {
 	dbResourceConfigFactory db.ResourceConfigFactory
 	resourceFetcher         fetcher.Fetcher
 	resourceFactory         resource.ResourceFactory
+
+	resourceTypeImages  *resourceTypeImages
+	registryImageLayers *registryImageLayers
+	prefetchQueue       ImagePrefetchQueue
 }
 
+// NewImageResourceFetcherFactory constructs an ImageResourceFetcherFactory.
+// If registryClient is not nil, registry-image images are assembled from
+// layers cached on workers, using the client to download any missing layers.
+// If prefetchQueue is not nil, images which are fetched are queued on it to
+// be prefetched onto other workers.
 func NewImageResourceFetcherFactory(
 	dbResourceCacheFactory db.ResourceCacheFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
 	resourceFetcher fetcher.Fetcher,
 	resourceFactory resource.ResourceFactory,
+	registryClient *http.Client,
+	prefetchQueue ImagePrefetchQueue,
 ) ImageResourceFetcherFactory {
-	return &imageResourceFetcherFactory{
+	factory := &imageResourceFetcherFactory{
 		dbResourceCacheFactory:  dbResourceCacheFactory,
 		dbResourceConfigFactory: dbResourceConfigFactory,
 		resourceFetcher:         resourceFetcher,
 		resourceFactory:         resourceFactory,
+
+		resourceTypeImages: newResourceTypeImages(),
+		prefetchQueue:      prefetchQueue,
+	}
+
+	if registryClient != nil {
+		factory.registryImageLayers = &registryImageLayers{
+			httpClient: registryClient,
+		}
 	}
+
+	return factory
 }
 
 func (f *imageResourceFetcherFactory) NewImageResourceFetcher(
@@ -76,6 +183,34 @@ func (f *imageResourceFetcherFactory) NewImageResourceFetcher(
 	imageResource worker.ImageResource,
 	version atc.Version,
 	teamID int,
+	tags []string,
+	customTypes atc.VersionedResourceTypes,
+	imageFetchingDelegate worker.ImageFetchingDelegate,
+) ImageResourceFetcher {
+	return &imageResourceFetcher{
+		worker:                  worker,
+		resourceFactory:         f.resourceFactory,
+		resourceFetcher:         f.resourceFetcher,
+		dbResourceCacheFactory:  f.dbResourceCacheFactory,
+		dbResourceConfigFactory: f.dbResourceConfigFactory,
+		registryImageLayers:     f.registryImageLayers,
+		prefetchQueue:           f.prefetchQueue,
+
+		imageResource:         imageResource,
+		version:               version,
+		teamID:                teamID,
+		tags:                  tags,
+		customTypes:           customTypes,
+		imageFetchingDelegate: imageFetchingDelegate,
+	}
+}
+
+func (f *imageResourceFetcherFactory) NewResourceTypeImageFetcher(
+	worker worker.Worker,
+	imageResource worker.ImageResource,
+	version atc.Version,
+	teamID int,
+	tags []string,
 	customTypes atc.VersionedResourceTypes,
 	imageFetchingDelegate worker.ImageFetchingDelegate,
 ) ImageResourceFetcher {
@@ -85,10 +220,14 @@ func (f *imageResourceFetcherFactory) NewImageResourceFetcher(
 		resourceFetcher:         f.resourceFetcher,
 		dbResourceCacheFactory:  f.dbResourceCacheFactory,
 		dbResourceConfigFactory: f.dbResourceConfigFactory,
+		resourceTypeImages:      f.resourceTypeImages,
+		registryImageLayers:     f.registryImageLayers,
+		prefetchQueue:           f.prefetchQueue,
 
 		imageResource:         imageResource,
 		version:               version,
 		teamID:                teamID,
+		tags:                  tags,
 		customTypes:           customTypes,
 		imageFetchingDelegate: imageFetchingDelegate,
 	}
@@ -100,10 +239,14 @@ type imageResourceFetcher struct {
 	resourceFetcher         fetcher.Fetcher
 	dbResourceCacheFactory  db.ResourceCacheFactory
 	dbResourceConfigFactory db.ResourceConfigFactory
+	resourceTypeImages      *resourceTypeImages
+	registryImageLayers     *registryImageLayers
+	prefetchQueue           ImagePrefetchQueue
 
 	imageResource         worker.ImageResource
 	version               atc.Version
 	teamID                int
+	tags                  []string
 	customTypes           atc.VersionedResourceTypes
 	imageFetchingDelegate worker.ImageFetchingDelegate
 }
@@ -116,7 +259,11 @@ func (i *imageResourceFetcher) Fetch(
 ) (worker.Volume, io.ReadCloser, atc.Version, error) {
 	version := i.version
 	if version == nil {
-		var err error
+		err := i.imageFetchingDelegate.ImageCheckStarted()
+		if err != nil {
+			logger.Error("failed-to-record-image-check-started", err)
+		}
+
 		version, err = i.getLatestVersion(ctx, logger, container)
 		if err != nil {
 			logger.Error("failed-to-get-latest-image-version", err)
@@ -157,6 +304,32 @@ func (i *imageResourceFetcher) Fetch(
 		return nil, nil, nil, err
 	}
 
+	if i.resourceTypeImages != nil {
+		volume, metadata, found := i.resourceTypeImages.find(logger, i.worker, resourceCache)
+		if found {
+			metric.ResourceTypeImageCacheHits.Inc()
+			logger.Debug("reusing-resource-type-image", lager.Data{"volume": volume.Handle()})
+			return volume, ioutil.NopCloser(bytes.NewReader(metadata)), version, nil
+		}
+
+		metric.ResourceTypeImageCacheMisses.Inc()
+	}
+
+	volume, metadata, found := i.findCachedImage(logger, resourceCache)
+	if found {
+		logger.Debug("reusing-cached-image", lager.Data{"volume": volume.Handle()})
+		return volume, ioutil.NopCloser(bytes.NewReader(metadata)), version, nil
+	}
+
+	err = i.imageFetchingDelegate.ImageFetchStarted()
+	if err != nil {
+		logger.Error("failed-to-record-image-fetch-started", err)
+	}
+
+	if i.registryImageLayers != nil {
+		i.assembleFromLayers(ctx, logger, version, resourceCache)
+	}
+
 	containerMetadata := db.ContainerMetadata{
 		Type: db.ContainerTypeGet,
 	}
@@ -185,33 +358,222 @@ func (i *imageResourceFetcher) Fetch(
 		return nil, nil, nil, err
 	}
 
-	volume := versionedSource.Volume()
+	volume = versionedSource.Volume()
 	if volume == nil {
 		return nil, nil, nil, ErrImageGetDidNotProduceVolume
 	}
 
-	reader, err := versionedSource.StreamOut(ctx, ImageMetadataFile)
+	err = i.imageFetchingDelegate.ImageFetchFinished()
+	if err != nil {
+		logger.Error("failed-to-record-image-fetch-finished", err)
+	}
+
+	digest := expectedDigest(version, versionedSource.Metadata())
+
+	err = i.verifyDigestFile(ctx, logger, versionedSource, digest)
+	if err != nil {
+		logger.Error("failed-to-verify-image-digest", err)
+		return nil, nil, nil, err
+	}
+
+	imageVolume, metadata, unpacked, err := unpackedImage(ctx, logger, i.worker, tarFileStreamer(versionedSource.StreamOut), volume, digest)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	// the metadata is saved to find the image in the resource cache's volume
+	// next time, which images unpacked into a volume of their own aren't in
+	if !unpacked {
+		i.saveImageMetadata(logger, resourceCache, metadata)
+	}
+
+	i.prefetchQueue.enqueue(logger, ImagePrefetchRequest{
+		Worker:        i.worker,
+		Volume:        volume,
+		ResourceCache: resourceCache,
+		TeamID:        i.teamID,
+		Tags:          i.tags,
+		ResourceType:  i.imageResource.Type,
+		ResourceTypes: i.customTypes,
+	})
+
+	if i.resourceTypeImages != nil {
+		i.resourceTypeImages.register(i.worker, resourceCache, imageVolume, metadata)
+	}
+
+	return imageVolume, ioutil.NopCloser(bytes.NewReader(metadata)), version, nil
+}
+
+// findCachedImage finds the image's volume on the worker if its metadata was
+// saved with the resource cache when it was fetched, so that the metadata
+// needn't be streamed out of the volume again.
+func (i *imageResourceFetcher) findCachedImage(
+	logger lager.Logger,
+	resourceCache db.UsedResourceCache,
+) (worker.Volume, []byte, bool) {
+	metadata, found, err := i.dbResourceCacheFactory.ResourceCacheImageMetadata(resourceCache)
+	if err != nil {
+		logger.Error("failed-to-get-image-metadata", err)
+		return nil, nil, false
+	}
+
+	if !found {
+		return nil, nil, false
+	}
+
+	volume, found, err := i.worker.FindVolumeForResourceCache(logger, resourceCache)
+	if err != nil {
+		logger.Error("failed-to-find-image-volume", err)
+		return nil, nil, false
+	}
+
+	if !found {
+		return nil, nil, false
+	}
+
+	return volume, metadata, true
+}
+
+// saveImageMetadata saves the parts of the image's metadata which containers
+// use with its resource cache. Metadata which can't be parsed isn't saved, so
+// that it is read from the volume and reported as malformed every time.
+func (i *imageResourceFetcher) saveImageMetadata(
+	logger lager.Logger,
+	resourceCache db.UsedResourceCache,
+	metadata []byte,
+) {
+	var imageMetadata worker.ImageMetadata
+	err := json.Unmarshal(metadata, &imageMetadata)
+	if err != nil {
+		return
+	}
+
+	payload, err := json.Marshal(imageMetadata)
+	if err != nil {
+		return
+	}
+
+	err = i.dbResourceCacheFactory.UpdateResourceCacheImageMetadata(resourceCache, payload)
+	if err != nil {
+		logger.Error("failed-to-save-image-metadata", err)
+	}
+}
+
+// assembleFromLayers populates the image's resource cache on the worker from
+// cached layers when the image is a plain registry-image, so that fetching it
+// below finds the cache rather than running the resource. If the image can't
+// be assembled, it is left to the resource to fetch.
+func (i *imageResourceFetcher) assembleFromLayers(
+	ctx context.Context,
+	logger lager.Logger,
+	version atc.Version,
+	resourceCache db.UsedResourceCache,
+) {
+	if i.imageResource.Type != registryImageType {
+		return
+	}
+
+	if _, found := i.customTypes.Lookup(registryImageType); found {
+		return
+	}
+
+	if i.imageResource.Params != nil && len(*i.imageResource.Params) > 0 {
+		return
+	}
+
+	digest, found := version["digest"]
+	if !found {
+		return
+	}
+
+	source, ok := parseRegistrySource(i.imageResource.Source)
+	if !ok {
+		logger.Debug("image-source-not-supported-for-layer-caching")
+		return
+	}
+
+	_, found, err := i.worker.FindVolumeForResourceCache(logger, resourceCache)
+	if err != nil {
+		logger.Error("failed-to-find-image-volume", err)
+		return
+	}
+
+	if found {
+		return
+	}
+
+	err = i.registryImageLayers.Assemble(ctx, logger, i.worker, source, digest, resourceCache)
+	if err != nil {
+		logger.Error("failed-to-assemble-image-from-layers", err)
+	}
+}
+
+// verifyDigestFile compares the digest the image resource wrote into the
+// fetched volume against the expected one, so that a volume holding some
+// other image than the version, e.g. a stale cache, is not used as a
+// container's rootfs. The rootfs itself is not hashed, as the image's digest
+// can't be computed from it; images in the OCI image layout are verified
+// against their content when they are unpacked instead. Images for which
+// either digest is missing are not verified.
+func (i *imageResourceFetcher) verifyDigestFile(
+	ctx context.Context,
+	logger lager.Logger,
+	versionedSource resource.VersionedSource,
+	expected string,
+) error {
+	if expected == "" {
+		return nil
+	}
+
+	reader, err := versionedSource.StreamOut(ctx, ImageDigestFile)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			logger.Debug("image-digest-file-not-found")
+			return nil
+		}
+
+		return err
+	}
+
+	defer reader.Close()
+
 	zstdReader := zstd.NewReader(reader)
+	defer zstdReader.Close()
+
 	tarReader := tar.NewReader(zstdReader)
 
 	_, err = tarReader.Next()
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("could not read file \"%s\" from tar", ImageMetadataFile)
+		return fmt.Errorf("could not read file \"%s\" from tar", ImageDigestFile)
 	}
 
-	releasingReader := &fileReadMultiCloser{
-		reader: tarReader,
-		closers: []io.Closer{
-			reader,
-			zstdReader,
-		},
+	actual, err := ioutil.ReadAll(tarReader)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(string(actual)) != expected {
+		return ImageIntegrityError{
+			Expected: expected,
+			Actual:   strings.TrimSpace(string(actual)),
+		}
 	}
 
-	return volume, releasingReader, version, nil
+	return nil
+}
+
+func expectedDigest(version atc.Version, metadata []atc.MetadataField) string {
+	if digest, found := version["digest"]; found {
+		return digest
+	}
+
+	for _, field := range metadata {
+		if field.Name == "digest" {
+			return field.Value
+		}
+	}
+
+	return ""
 }
 
 func (i *imageResourceFetcher) ensureVersionOfType(
@@ -308,6 +670,10 @@ func (i *imageResourceFetcher) getLatestVersion(
 	checkingResource := i.resourceFactory.NewResourceForContainer(imageContainer)
 	versions, err := checkingResource.Check(context.TODO(), i.imageResource.Source, nil)
 	if err != nil {
+		if scriptErr, ok := err.(resource.ErrResourceScriptFailed); ok {
+			return nil, newErrImageCheckFailed(scriptErr)
+		}
+
 		return nil, err
 	}
 
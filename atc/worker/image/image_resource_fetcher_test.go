@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/DataDog/zstd"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -54,6 +56,8 @@ var _ = Describe("Image", func() {
 	var fetchedVersion atc.Version
 	var fetchErr error
 	var teamID int
+	var tags []string
+	var prefetchQueue image.ImagePrefetchQueue
 
 	BeforeEach(func() {
 		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
@@ -76,6 +80,8 @@ var _ = Describe("Image", func() {
 		fakeWorker.NameReturns("some-worker")
 		fakeWorker.TagsReturns(atc.Tags{"worker", "tags"})
 		teamID = 123
+		tags = []string{"step-tag"}
+		prefetchQueue = nil
 
 		customTypes = atc.VersionedResourceTypes{
 			{
@@ -105,11 +111,14 @@ var _ = Describe("Image", func() {
 			fakeResourceConfigFactory,
 			fakeResourceFetcher,
 			fakeResourceFactory,
+			nil,
+			prefetchQueue,
 		).NewImageResourceFetcher(
 			fakeWorker,
 			imageResource,
 			version,
 			teamID,
+			tags,
 			customTypes,
 			fakeImageFetchingDelegate,
 		)
@@ -342,6 +351,12 @@ var _ = Describe("Image", func() {
 								Expect(fakeImageFetchingDelegate.ImageVersionDeterminedArgsForCall(0)).To(Equal(fakeUsedResourceCache))
 							})
 
+							It("reports the progress of checking for and fetching the image", func() {
+								Expect(fakeImageFetchingDelegate.ImageCheckStartedCallCount()).To(Equal(1))
+								Expect(fakeImageFetchingDelegate.ImageFetchStartedCallCount()).To(Equal(1))
+								Expect(fakeImageFetchingDelegate.ImageFetchFinishedCallCount()).To(Equal(1))
+							})
+
 							It("fetches resource with correct session", func() {
 								Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
 								_, _, actualContainerMetadata, actualWorker, containerSpec, actualCustomTypes, resourceInstance, delegate := fakeResourceFetcher.FetchArgsForCall(0)
@@ -427,6 +442,7 @@ var _ = Describe("Image", func() {
 
 				It("exits with ErrImageUnavailable", func() {
 					Expect(fetchErr).To(Equal(image.ErrImageUnavailable))
+					Expect(atc.ErrorCodeOf(fetchErr)).To(Equal(atc.ErrorCodeImageUnavailable))
 				})
 
 				It("does not attempt to save any versions in the database", func() {
@@ -452,6 +468,43 @@ var _ = Describe("Image", func() {
 					Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(0))
 				})
 			})
+
+			Context("when the check script fails", func() {
+				BeforeEach(func() {
+					fakeCheckResource.CheckReturns(nil, resource.ErrResourceScriptFailed{
+						Path:       "/opt/resource/check",
+						ExitStatus: 1,
+						Stderr:     "bad credentials",
+					})
+				})
+
+				It("returns an image check failure with the exit status and stderr", func() {
+					Expect(fetchErr).To(Equal(image.ErrImageCheckFailed{
+						ExitStatus: 1,
+						Stderr:     "bad credentials",
+					}))
+				})
+
+				It("has an error code telling it apart from worker failures", func() {
+					Expect(atc.ErrorCodeOf(fetchErr)).To(Equal(atc.ErrorCodeImageCheckFailed))
+				})
+
+				Context("when its stderr is long", func() {
+					BeforeEach(func() {
+						fakeCheckResource.CheckReturns(nil, resource.ErrResourceScriptFailed{
+							ExitStatus: 2,
+							Stderr:     strings.Repeat("a", 2000) + strings.Repeat("b", 1024),
+						})
+					})
+
+					It("keeps only the end of it", func() {
+						Expect(fetchErr).To(Equal(image.ErrImageCheckFailed{
+							ExitStatus: 2,
+							Stderr:     "..." + strings.Repeat("b", 1024),
+						}))
+					})
+				})
+			})
 		})
 
 		Context("when creating or finding the Check container fails", func() {
@@ -536,6 +589,106 @@ var _ = Describe("Image", func() {
 						Expect(fakeResourceFactory.NewResourceForContainerCallCount()).To(BeZero())
 					})
 
+					It("reports fetching the image without checking for it", func() {
+						Expect(fakeImageFetchingDelegate.ImageCheckStartedCallCount()).To(BeZero())
+						Expect(fakeImageFetchingDelegate.ImageFetchStartedCallCount()).To(Equal(1))
+						Expect(fakeImageFetchingDelegate.ImageFetchFinishedCallCount()).To(Equal(1))
+					})
+
+					It("does not save metadata which can't be parsed", func() {
+						Expect(fakeResourceCacheFactory.UpdateResourceCacheImageMetadataCallCount()).To(BeZero())
+					})
+
+					Context("when the metadata can be parsed", func() {
+						BeforeEach(func() {
+							fakeVersionedSource.StreamOutReturns(tgzStreamWith(`{"env":["A=1"],"user":"someone","extra":true}`), nil)
+						})
+
+						It("saves the parsed metadata with the resource cache", func() {
+							Expect(fakeResourceCacheFactory.UpdateResourceCacheImageMetadataCallCount()).To(Equal(1))
+							resourceCache, metadata := fakeResourceCacheFactory.UpdateResourceCacheImageMetadataArgsForCall(0)
+							Expect(resourceCache).To(Equal(fakeUsedResourceCache))
+							Expect(metadata).To(MatchJSON(`{"env":["A=1"],"user":"someone"}`))
+						})
+					})
+
+					Context("when the image's metadata was saved and its volume is on the worker", func() {
+						var fakeCachedVolume *workerfakes.FakeVolume
+
+						BeforeEach(func() {
+							fakeCachedVolume = new(workerfakes.FakeVolume)
+							fakeResourceCacheFactory.ResourceCacheImageMetadataReturns([]byte(`{"env":["A=1"],"user":"someone"}`), true, nil)
+							fakeWorker.FindVolumeForResourceCacheReturns(fakeCachedVolume, true, nil)
+						})
+
+						It("returns the volume and saved metadata without fetching the image", func() {
+							Expect(fetchErr).ToNot(HaveOccurred())
+							Expect(fetchedVolume).To(Equal(fakeCachedVolume))
+							Expect(ioutil.ReadAll(fetchedMetadataReader)).To(MatchJSON(`{"env":["A=1"],"user":"someone"}`))
+							Expect(fetchedVersion).To(Equal(atc.Version{"some": "version"}))
+
+							Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+							Expect(fakeVersionedSource.StreamOutCallCount()).To(BeZero())
+						})
+
+						It("still saves the image resource version", func() {
+							Expect(fakeImageFetchingDelegate.ImageVersionDeterminedCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when the image's metadata was saved but its volume is not on the worker", func() {
+						BeforeEach(func() {
+							fakeResourceCacheFactory.ResourceCacheImageMetadataReturns([]byte(`{}`), true, nil)
+						})
+
+						It("fetches the image", func() {
+							Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+							Expect(fetchedVolume).To(Equal(fakeVolume))
+						})
+					})
+
+					Context("when images are prefetched", func() {
+						BeforeEach(func() {
+							prefetchQueue = image.NewImagePrefetchQueue(1)
+						})
+
+						It("queues the image to be prefetched onto other workers", func() {
+							var request image.ImagePrefetchRequest
+							Expect(prefetchQueue).To(Receive(&request))
+
+							Expect(request.Worker).To(Equal(fakeWorker))
+							Expect(request.Volume).To(Equal(fakeVolume))
+							Expect(request.ResourceCache).To(Equal(fakeUsedResourceCache))
+							Expect(request.TeamID).To(Equal(123))
+							Expect(request.Tags).To(Equal([]string{"step-tag"}))
+							Expect(request.ResourceType).To(Equal("docker"))
+							Expect(request.ResourceTypes).To(Equal(customTypes))
+						})
+
+						Context("when the image was already on the worker", func() {
+							BeforeEach(func() {
+								fakeResourceCacheFactory.ResourceCacheImageMetadataReturns([]byte(`{}`), true, nil)
+								fakeWorker.FindVolumeForResourceCacheReturns(new(workerfakes.FakeVolume), true, nil)
+							})
+
+							It("does not queue it", func() {
+								Expect(prefetchQueue).NotTo(Receive())
+							})
+						})
+
+						Context("when the queue is full", func() {
+							BeforeEach(func() {
+								prefetchQueue <- image.ImagePrefetchRequest{}
+							})
+
+							It("fetches the image without waiting to queue it", func() {
+								Expect(fetchErr).NotTo(HaveOccurred())
+								Expect(fetchedVolume).To(Equal(fakeVolume))
+								Expect(prefetchQueue).To(HaveLen(1))
+							})
+						})
+					})
+
 					It("succeeds", func() {
 						Expect(fetchErr).To(BeNil())
 					})
@@ -558,6 +711,17 @@ var _ = Describe("Image", func() {
 						Expect(fetchedVersion).To(Equal(atc.Version{"some": "version"}))
 					})
 
+					It("creates the resource cache for the pinned version", func() {
+						Expect(fakeResourceCacheFactory.FindOrCreateResourceCacheCallCount()).To(Equal(1))
+						user, resourceType, cacheVersion, source, params, cacheCustomTypes := fakeResourceCacheFactory.FindOrCreateResourceCacheArgsForCall(0)
+						Expect(user).To(Equal(db.ForContainer(fakeCreatingContainer.ID())))
+						Expect(resourceType).To(Equal("docker"))
+						Expect(cacheVersion).To(Equal(atc.Version{"some": "version"}))
+						Expect(source).To(Equal(atc.Source{"some": "super-secret-sauce"}))
+						Expect(params).To(Equal(atc.Params{"some": "params"}))
+						Expect(cacheCustomTypes).To(Equal(customTypes))
+					})
+
 					It("saved the image resource version in the database", func() {
 						Expect(fakeImageFetchingDelegate.ImageVersionDeterminedCallCount()).To(Equal(1))
 						Expect(fakeImageFetchingDelegate.ImageVersionDeterminedArgsForCall(0)).To(Equal(fakeUsedResourceCache))
@@ -614,6 +778,86 @@ var _ = Describe("Image", func() {
 							Expect(fetchErr).To(Equal(image.ErrImageGetDidNotProduceVolume))
 						})
 					})
+
+					Context("when the version includes a digest", func() {
+						var digestFile io.ReadCloser
+
+						BeforeEach(func() {
+							version = atc.Version{"digest": "sha256:some-digest"}
+							digestFile = tgzStreamWith("sha256:some-digest\n")
+
+							fakeVersionedSource.StreamOutStub = func(ctx context.Context, path string) (io.ReadCloser, error) {
+								if path == image.ImageDigestFile {
+									return digestFile, nil
+								}
+
+								return tgzStreamWith("some-tar-contents"), nil
+							}
+						})
+
+						It("succeeds when the fetched digest matches", func() {
+							Expect(fetchErr).ToNot(HaveOccurred())
+							Expect(ioutil.ReadAll(fetchedMetadataReader)).To(Equal([]byte("some-tar-contents")))
+						})
+
+						It("streams out the digest file", func() {
+							Expect(fakeVersionedSource.StreamOutCallCount()).To(Equal(2))
+							_, src := fakeVersionedSource.StreamOutArgsForCall(0)
+							Expect(src).To(Equal("digest"))
+						})
+
+						Context("when the fetched digest does not match", func() {
+							BeforeEach(func() {
+								digestFile = tgzStreamWith("sha256:some-other-digest")
+							})
+
+							It("returns an integrity error", func() {
+								Expect(fetchErr).To(Equal(image.ImageIntegrityError{
+									Expected: "sha256:some-digest",
+									Actual:   "sha256:some-other-digest",
+								}))
+							})
+						})
+
+						Context("when the image has no digest file", func() {
+							BeforeEach(func() {
+								fakeVersionedSource.StreamOutStub = func(ctx context.Context, path string) (io.ReadCloser, error) {
+									if path == image.ImageDigestFile {
+										return nil, baggageclaim.ErrFileNotFound
+									}
+
+									return tgzStreamWith("some-tar-contents"), nil
+								}
+							})
+
+							It("succeeds without verifying", func() {
+								Expect(fetchErr).ToNot(HaveOccurred())
+							})
+						})
+					})
+
+					Context("when the metadata includes a digest", func() {
+						BeforeEach(func() {
+							fakeVersionedSource.MetadataReturns([]atc.MetadataField{
+								{Name: "digest", Value: "sha256:some-digest"},
+							})
+
+							fakeVersionedSource.StreamOutStub = func(ctx context.Context, path string) (io.ReadCloser, error) {
+								if path == image.ImageDigestFile {
+									return tgzStreamWith("sha256:some-other-digest"), nil
+								}
+
+								return tgzStreamWith("some-tar-contents"), nil
+							}
+						})
+
+						It("verifies the fetched digest against it", func() {
+							Expect(fetchErr).To(Equal(image.ImageIntegrityError{
+								Expected: "sha256:some-digest",
+								Actual:   "sha256:some-other-digest",
+							}))
+						})
+					})
 				})
 			})
 		})
@@ -636,6 +880,187 @@ var _ = Describe("Image", func() {
 	})
 })
 
+var _ = Describe("Resource type image", func() {
+	var (
+		fakeResourceFetcher       *fetcherfakes.FakeFetcher
+		fakeResourceCacheFactory  *dbfakes.FakeResourceCacheFactory
+		fakeUsedResourceCache     *dbfakes.FakeUsedResourceCache
+		fakeImageFetchingDelegate *workerfakes.FakeImageFetchingDelegate
+		fakeVersionedSource       *resourcefakes.FakeVersionedSource
+		fakeVolume                *workerfakes.FakeVolume
+		fakeWorker                *workerfakes.FakeWorker
+
+		factory image.ImageResourceFetcherFactory
+
+		logger lager.Logger
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeResourceFetcher = new(fetcherfakes.FakeFetcher)
+		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+		fakeImageFetchingDelegate = new(workerfakes.FakeImageFetchingDelegate)
+
+		fakeUsedResourceCache = new(dbfakes.FakeUsedResourceCache)
+		fakeUsedResourceCache.IDReturns(42)
+		fakeResourceCacheFactory.FindOrCreateResourceCacheReturns(fakeUsedResourceCache, nil)
+
+		fakeVolume = new(workerfakes.FakeVolume)
+		fakeVolume.HandleReturns("some-image-volume")
+
+		fakeVersionedSource = new(resourcefakes.FakeVersionedSource)
+		fakeVersionedSource.VolumeReturns(fakeVolume)
+		fakeVersionedSource.StreamOutStub = func(context.Context, string) (io.ReadCloser, error) {
+			return tgzStreamWith("some-tar-contents"), nil
+		}
+		fakeResourceFetcher.FetchReturns(fakeVersionedSource, nil)
+
+		fakeWorker = new(workerfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorker.LookupVolumeReturns(fakeVolume, true, nil)
+
+		factory = image.NewImageResourceFetcherFactory(
+			fakeResourceCacheFactory,
+			new(dbfakes.FakeResourceConfigFactory),
+			fakeResourceFetcher,
+			new(resourcefakes.FakeResourceFactory),
+			nil,
+			nil,
+		)
+	})
+
+	fetch := func(w worker.Worker) (worker.Volume, []byte, error) {
+		fetcher := factory.NewResourceTypeImageFetcher(
+			w,
+			worker.ImageResource{
+				Type:   "docker-image",
+				Source: atc.Source{"some": "source"},
+			},
+			atc.Version{"some": "version"},
+			123,
+			nil,
+			atc.VersionedResourceTypes{},
+			fakeImageFetchingDelegate,
+		)
+
+		volume, reader, _, err := fetcher.Fetch(context.TODO(), logger, new(dbfakes.FakeCreatingContainer), false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		metadata, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		return volume, metadata, nil
+	}
+
+	It("fetches the image the first time", func() {
+		volume, metadata, err := fetch(fakeWorker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(volume).To(Equal(fakeVolume))
+		Expect(metadata).To(Equal([]byte("some-tar-contents")))
+
+		Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+		Expect(fakeWorker.LookupVolumeCallCount()).To(BeZero())
+	})
+
+	Context("when the image was already fetched on the worker", func() {
+		BeforeEach(func() {
+			_, _, err := fetch(fakeWorker)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reuses the volume without fetching again", func() {
+			volume, metadata, err := fetch(fakeWorker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volume).To(Equal(fakeVolume))
+			Expect(metadata).To(Equal([]byte("some-tar-contents")))
+
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+			Expect(fakeVersionedSource.StreamOutCallCount()).To(Equal(1))
+
+			Expect(fakeWorker.LookupVolumeCallCount()).To(Equal(1))
+			_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+			Expect(handle).To(Equal("some-image-volume"))
+		})
+
+		It("still records the version of the image", func() {
+			_, _, err := fetch(fakeWorker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeResourceCacheFactory.FindOrCreateResourceCacheCallCount()).To(Equal(2))
+			Expect(fakeImageFetchingDelegate.ImageVersionDeterminedCallCount()).To(Equal(2))
+		})
+
+		It("fetches the image again for a different worker", func() {
+			otherWorker := new(workerfakes.FakeWorker)
+			otherWorker.NameReturns("some-other-worker")
+
+			_, _, err := fetch(otherWorker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(2))
+			Expect(otherWorker.LookupVolumeCallCount()).To(BeZero())
+		})
+
+		It("fetches the image again for a different version", func() {
+			otherResourceCache := new(dbfakes.FakeUsedResourceCache)
+			otherResourceCache.IDReturns(43)
+			fakeResourceCacheFactory.FindOrCreateResourceCacheReturns(otherResourceCache, nil)
+
+			_, _, err := fetch(fakeWorker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(2))
+		})
+
+		Context("when the volume is gone from the worker", func() {
+			BeforeEach(func() {
+				fakeWorker.LookupVolumeReturns(nil, false, nil)
+			})
+
+			It("fetches the image again", func() {
+				_, metadata, err := fetch(fakeWorker)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata).To(Equal([]byte("some-tar-contents")))
+				Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when looking up the volume fails", func() {
+			BeforeEach(func() {
+				fakeWorker.LookupVolumeReturns(nil, false, errors.New("nope"))
+			})
+
+			It("fetches the image again", func() {
+				_, _, err := fetch(fakeWorker)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	It("does not reuse images fetched for other purposes", func() {
+		_, _, err := fetch(fakeWorker)
+		Expect(err).NotTo(HaveOccurred())
+
+		fetcher := factory.NewImageResourceFetcher(
+			fakeWorker,
+			worker.ImageResource{
+				Type:   "docker-image",
+				Source: atc.Source{"some": "source"},
+			},
+			atc.Version{"some": "version"},
+			123,
+			nil,
+			atc.VersionedResourceTypes{},
+			fakeImageFetchingDelegate,
+		)
+
+		_, _, _, err = fetcher.Fetch(context.TODO(), logger, new(dbfakes.FakeCreatingContainer), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(2))
+	})
+})
+
 func tgzStreamWith(metadata string) io.ReadCloser {
 	buffer := gbytes.NewBuffer()
 
@@ -3,9 +3,11 @@ package image_test
 import (
 	"context"
 	"errors"
+	"io"
 	"io/ioutil"
 	"strings"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/baggageclaim/baggageclaimfakes"
@@ -31,6 +33,7 @@ var _ = Describe("Image", func() {
 		fakeImageFetchingDelegate       *workerfakes.FakeImageFetchingDelegate
 		fakeImageResourceFetcherFactory *imagefakes.FakeImageResourceFetcherFactory
 		fakeImageResourceFetcher        *imagefakes.FakeImageResourceFetcher
+		fakeResourceTypeMappings        *imagefakes.FakeResourceTypeMappings
 	)
 
 	BeforeEach(func() {
@@ -46,7 +49,9 @@ var _ = Describe("Image", func() {
 		fakeImageResourceFetcherFactory = new(imagefakes.FakeImageResourceFetcherFactory)
 		fakeImageResourceFetcher = new(imagefakes.FakeImageResourceFetcher)
 		fakeImageResourceFetcherFactory.NewImageResourceFetcherReturns(fakeImageResourceFetcher)
-		imageFactory = image.NewImageFactory(fakeImageResourceFetcherFactory)
+		fakeImageResourceFetcherFactory.NewResourceTypeImageFetcherReturns(fakeImageResourceFetcher)
+		fakeResourceTypeMappings = new(imagefakes.FakeResourceTypeMappings)
+		imageFactory = image.NewImageFactory(fakeImageResourceFetcherFactory, fakeResourceTypeMappings)
 	})
 
 	Describe("imageProvidedByPreviousStepOnSameWorker", func() {
@@ -91,7 +96,7 @@ var _ = Describe("Image", func() {
 			_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(Equal(1))
-			_, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
+			_, _, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
 			Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 				Strategy:   cowStrategy,
 				Privileged: true,
@@ -157,7 +162,7 @@ var _ = Describe("Image", func() {
 			_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeVolumeClient.FindOrCreateVolumeForContainerCallCount()).To(Equal(1))
-			_, volumeSpec, container, teamID, path := fakeVolumeClient.FindOrCreateVolumeForContainerArgsForCall(0)
+			_, _, volumeSpec, container, teamID, path := fakeVolumeClient.FindOrCreateVolumeForContainerArgsForCall(0)
 			Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 				Strategy:   baggageclaim.EmptyStrategy{},
 				Privileged: true,
@@ -190,6 +195,27 @@ var _ = Describe("Image", func() {
 			Expect(fakeContainerRootfsVolume.StreamInCallCount()).To(Equal(1))
 		})
 
+		It("reports the progress of fetching the image", func() {
+			fakeImageArtifactSource.StreamToStub = func(ctx context.Context, logger lager.Logger, dest worker.ArtifactDestination) error {
+				Expect(fakeImageFetchingDelegate.ImageFetchStartedCallCount()).To(Equal(1))
+				Expect(fakeImageFetchingDelegate.ImageFetchFinishedCallCount()).To(BeZero())
+
+				return dest.StreamIn(ctx, "fake-path", strings.NewReader("fake-tar-stream"))
+			}
+
+			fakeContainerRootfsVolume.StreamInStub = func(ctx context.Context, path string, tarStream io.Reader) error {
+				_, err := ioutil.ReadAll(tarStream)
+				return err
+			}
+
+			_, err := img.FetchForContainer(ctx, logger, fakeContainer)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeImageFetchingDelegate.ImageBytesStreamedCallCount()).To(Equal(1))
+			Expect(fakeImageFetchingDelegate.ImageBytesStreamedArgsForCall(0)).To(Equal(int64(len("fake-tar-stream"))))
+			Expect(fakeImageFetchingDelegate.ImageFetchFinishedCallCount()).To(Equal(1))
+		})
+
 		Context("when streamTo fails", func() {
 			BeforeEach(func() {
 				fakeImageArtifactSource.StreamFileReturns(nil, errors.New("some error"))
@@ -258,6 +284,7 @@ var _ = Describe("Image", func() {
 							Source: atc.Source{"some": "source"},
 						},
 						Privileged: true,
+						Tags:       []string{"step-tag"},
 					},
 					42,
 					fakeImageFetchingDelegate,
@@ -267,12 +294,13 @@ var _ = Describe("Image", func() {
 			})
 
 			It("fetches image without custom resource type", func() {
-				worker, imageResource, version, teamID, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
+				worker, imageResource, version, teamID, tags, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
 				Expect(worker).To(Equal(fakeWorker))
 				Expect(imageResource.Type).To(Equal("some-image-resource-type"))
 				Expect(imageResource.Source).To(Equal(atc.Source{"some": "source"}))
 				Expect(version).To(BeNil())
 				Expect(teamID).To(Equal(42))
+				Expect(tags).To(Equal([]string{"step-tag"}))
 				Expect(resourceTypes).To(Equal(atc.VersionedResourceTypes{}))
 				Expect(delegate).To(Equal(fakeImageFetchingDelegate))
 			})
@@ -281,7 +309,7 @@ var _ = Describe("Image", func() {
 				_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(Equal(1))
-				_, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
+				_, _, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
 				Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 					Strategy:   cowStrategy,
 					Privileged: true,
@@ -348,7 +376,7 @@ var _ = Describe("Image", func() {
 			})
 
 			It("fetches unprivileged image without custom resource type", func() {
-				worker, imageResource, version, teamID, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
+				worker, imageResource, version, teamID, _, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewResourceTypeImageFetcherArgsForCall(0)
 				Expect(worker).To(Equal(fakeWorker))
 				Expect(imageResource.Type).To(Equal("some-base-resource-type"))
 				Expect(imageResource.Source).To(Equal(atc.Source{
@@ -376,7 +404,7 @@ var _ = Describe("Image", func() {
 				_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(Equal(1))
-				_, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
+				_, _, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
 				Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 					Strategy:   cowStrategy,
 					Privileged: false,
@@ -443,7 +471,7 @@ var _ = Describe("Image", func() {
 			})
 
 			It("fetches image without custom resource type", func() {
-				worker, imageResource, version, teamID, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewImageResourceFetcherArgsForCall(0)
+				worker, imageResource, version, teamID, _, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewResourceTypeImageFetcherArgsForCall(0)
 				Expect(worker).To(Equal(fakeWorker))
 				Expect(imageResource.Type).To(Equal("some-base-image-resource-type"))
 				Expect(imageResource.Source).To(Equal(atc.Source{
@@ -470,7 +498,7 @@ var _ = Describe("Image", func() {
 				_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(Equal(1))
-				_, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
+				_, _, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
 				Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 					Strategy:   cowStrategy,
 					Privileged: true,
@@ -496,6 +524,105 @@ var _ = Describe("Image", func() {
 				}))
 			})
 		})
+
+		Context("when image is provided as a remapped base resource type", func() {
+			var resourceTypes atc.VersionedResourceTypes
+			var getErr error
+
+			BeforeEach(func() {
+				fakeResourceTypeMappings.ForTeamReturns(atc.ResourceTypeMappings{
+					"some-base-resource-type": {
+						Type:       "some-base-image-resource-type",
+						Source:     atc.Source{"some": "mapped-source"},
+						Privileged: true,
+					},
+				}, nil)
+
+				resourceTypes = atc.VersionedResourceTypes{
+					{
+						ResourceType: atc.ResourceType{
+							Name: "some-base-image-resource-type",
+							Type: "some-other-base-resource-type",
+						},
+						Version: atc.Version{"some": "shadowing-version"},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				img, getErr = imageFactory.GetImage(
+					logger,
+					fakeWorker,
+					fakeVolumeClient,
+					worker.ImageSpec{
+						ResourceType: "some-base-resource-type",
+					},
+					42,
+					fakeImageFetchingDelegate,
+					resourceTypes,
+				)
+			})
+
+			It("looks up the mappings of the team", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(fakeResourceTypeMappings.ForTeamCallCount()).To(Equal(1))
+				Expect(fakeResourceTypeMappings.ForTeamArgsForCall(0)).To(Equal(42))
+			})
+
+			It("fetches the mapped image at its latest version without the pipeline's resource types", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(fakeImageResourceFetcherFactory.NewResourceTypeImageFetcherCallCount()).To(Equal(1))
+				worker, imageResource, version, teamID, _, resourceTypes, delegate := fakeImageResourceFetcherFactory.NewResourceTypeImageFetcherArgsForCall(0)
+				Expect(worker).To(Equal(fakeWorker))
+				Expect(imageResource.Type).To(Equal("some-base-image-resource-type"))
+				Expect(imageResource.Source).To(Equal(atc.Source{"some": "mapped-source"}))
+				Expect(version).To(BeNil())
+				Expect(teamID).To(Equal(42))
+				Expect(resourceTypes).To(BeEmpty())
+				Expect(delegate).To(Equal(fakeImageFetchingDelegate))
+			})
+
+			It("returns the fetched image with the privilege of the mapping", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				fetchedImage, err := img.FetchForContainer(ctx, logger, fakeContainer)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchedImage.Privileged).To(BeTrue())
+				Expect(fetchedImage.URL).To(Equal("raw://some-path/rootfs"))
+			})
+
+			Context("when the pipeline defines a custom type of the same name", func() {
+				BeforeEach(func() {
+					resourceTypes = atc.VersionedResourceTypes{
+						{
+							ResourceType: atc.ResourceType{
+								Name: "some-base-resource-type",
+								Type: "some-other-base-resource-type",
+							},
+							Version: atc.Version{"some": "custom-version"},
+						},
+					}
+				})
+
+				It("uses the custom type", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(fakeResourceTypeMappings.ForTeamCallCount()).To(BeZero())
+					_, imageResource, _, _, _, _, _ := fakeImageResourceFetcherFactory.NewResourceTypeImageFetcherArgsForCall(0)
+					Expect(imageResource.Type).To(Equal("some-other-base-resource-type"))
+				})
+			})
+
+			Context("when looking up the mappings fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeResourceTypeMappings.ForTeamReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(getErr).To(Equal(disaster))
+				})
+			})
+		})
 	})
 
 	Describe("imageFromBaseResourceType", func() {
@@ -546,7 +673,7 @@ var _ = Describe("Image", func() {
 			_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeVolumeClient.FindOrCreateVolumeForBaseResourceTypeCallCount()).To(Equal(1))
-			_, volumeSpec, teamID, resourceTypeName := fakeVolumeClient.FindOrCreateVolumeForBaseResourceTypeArgsForCall(0)
+			_, _, volumeSpec, teamID, resourceTypeName := fakeVolumeClient.FindOrCreateVolumeForBaseResourceTypeArgsForCall(0)
 			Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 				Strategy: baggageclaim.ImportStrategy{
 					Path: "some-base-image-path",
@@ -561,7 +688,7 @@ var _ = Describe("Image", func() {
 			_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(Equal(1))
-			_, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
+			_, _, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
 			Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 				Strategy:   cowStrategy,
 				Privileged: false,
@@ -594,7 +721,7 @@ var _ = Describe("Image", func() {
 				_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeVolumeClient.FindOrCreateVolumeForBaseResourceTypeCallCount()).To(Equal(1))
-				_, volumeSpec, teamID, resourceTypeName := fakeVolumeClient.FindOrCreateVolumeForBaseResourceTypeArgsForCall(0)
+				_, _, volumeSpec, teamID, resourceTypeName := fakeVolumeClient.FindOrCreateVolumeForBaseResourceTypeArgsForCall(0)
 				Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 					Strategy: baggageclaim.ImportStrategy{
 						Path: "some-base-image-path",
@@ -609,7 +736,7 @@ var _ = Describe("Image", func() {
 				_, err := img.FetchForContainer(ctx, logger, fakeContainer)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(Equal(1))
-				_, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
+				_, _, volumeSpec, container, volume, teamID, path := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
 				Expect(volumeSpec).To(Equal(worker.VolumeSpec{
 					Strategy:   cowStrategy,
 					Privileged: true,
@@ -10,15 +10,16 @@ import (
 )
 
 type FakeImageResourceFetcherFactory struct {
-	NewImageResourceFetcherStub        func(worker.Worker, worker.ImageResource, atc.Version, int, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) image.ImageResourceFetcher
+	NewImageResourceFetcherStub        func(worker.Worker, worker.ImageResource, atc.Version, int, []string, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) image.ImageResourceFetcher
 	newImageResourceFetcherMutex       sync.RWMutex
 	newImageResourceFetcherArgsForCall []struct {
 		arg1 worker.Worker
 		arg2 worker.ImageResource
 		arg3 atc.Version
 		arg4 int
-		arg5 atc.VersionedResourceTypes
-		arg6 worker.ImageFetchingDelegate
+		arg5 []string
+		arg6 atc.VersionedResourceTypes
+		arg7 worker.ImageFetchingDelegate
 	}
 	newImageResourceFetcherReturns struct {
 		result1 image.ImageResourceFetcher
@@ -26,11 +27,33 @@ type FakeImageResourceFetcherFactory struct {
 	newImageResourceFetcherReturnsOnCall map[int]struct {
 		result1 image.ImageResourceFetcher
 	}
+	NewResourceTypeImageFetcherStub        func(worker.Worker, worker.ImageResource, atc.Version, int, []string, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) image.ImageResourceFetcher
+	newResourceTypeImageFetcherMutex       sync.RWMutex
+	newResourceTypeImageFetcherArgsForCall []struct {
+		arg1 worker.Worker
+		arg2 worker.ImageResource
+		arg3 atc.Version
+		arg4 int
+		arg5 []string
+		arg6 atc.VersionedResourceTypes
+		arg7 worker.ImageFetchingDelegate
+	}
+	newResourceTypeImageFetcherReturns struct {
+		result1 image.ImageResourceFetcher
+	}
+	newResourceTypeImageFetcherReturnsOnCall map[int]struct {
+		result1 image.ImageResourceFetcher
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcher(arg1 worker.Worker, arg2 worker.ImageResource, arg3 atc.Version, arg4 int, arg5 atc.VersionedResourceTypes, arg6 worker.ImageFetchingDelegate) image.ImageResourceFetcher {
+func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcher(arg1 worker.Worker, arg2 worker.ImageResource, arg3 atc.Version, arg4 int, arg5 []string, arg6 atc.VersionedResourceTypes, arg7 worker.ImageFetchingDelegate) image.ImageResourceFetcher {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
 	fake.newImageResourceFetcherMutex.Lock()
 	ret, specificReturn := fake.newImageResourceFetcherReturnsOnCall[len(fake.newImageResourceFetcherArgsForCall)]
 	fake.newImageResourceFetcherArgsForCall = append(fake.newImageResourceFetcherArgsForCall, struct {
@@ -38,13 +61,14 @@ func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcher(arg1 worker
 		arg2 worker.ImageResource
 		arg3 atc.Version
 		arg4 int
-		arg5 atc.VersionedResourceTypes
-		arg6 worker.ImageFetchingDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("NewImageResourceFetcher", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg5 []string
+		arg6 atc.VersionedResourceTypes
+		arg7 worker.ImageFetchingDelegate
+	}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7})
+	fake.recordInvocation("NewImageResourceFetcher", []interface{}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7})
 	fake.newImageResourceFetcherMutex.Unlock()
 	if fake.NewImageResourceFetcherStub != nil {
-		return fake.NewImageResourceFetcherStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.NewImageResourceFetcherStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1
@@ -59,17 +83,17 @@ func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherCallCount()
 	return len(fake.newImageResourceFetcherArgsForCall)
 }
 
-func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherCalls(stub func(worker.Worker, worker.ImageResource, atc.Version, int, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) image.ImageResourceFetcher) {
+func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherCalls(stub func(worker.Worker, worker.ImageResource, atc.Version, int, []string, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) image.ImageResourceFetcher) {
 	fake.newImageResourceFetcherMutex.Lock()
 	defer fake.newImageResourceFetcherMutex.Unlock()
 	fake.NewImageResourceFetcherStub = stub
 }
 
-func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherArgsForCall(i int) (worker.Worker, worker.ImageResource, atc.Version, int, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) {
+func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherArgsForCall(i int) (worker.Worker, worker.ImageResource, atc.Version, int, []string, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) {
 	fake.newImageResourceFetcherMutex.RLock()
 	defer fake.newImageResourceFetcherMutex.RUnlock()
 	argsForCall := fake.newImageResourceFetcherArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
 }
 
 func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherReturns(result1 image.ImageResourceFetcher) {
@@ -95,11 +119,84 @@ func (fake *FakeImageResourceFetcherFactory) NewImageResourceFetcherReturnsOnCal
 	}{result1}
 }
 
+func (fake *FakeImageResourceFetcherFactory) NewResourceTypeImageFetcher(arg1 worker.Worker, arg2 worker.ImageResource, arg3 atc.Version, arg4 int, arg5 []string, arg6 atc.VersionedResourceTypes, arg7 worker.ImageFetchingDelegate) image.ImageResourceFetcher {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	fake.newResourceTypeImageFetcherMutex.Lock()
+	ret, specificReturn := fake.newResourceTypeImageFetcherReturnsOnCall[len(fake.newResourceTypeImageFetcherArgsForCall)]
+	fake.newResourceTypeImageFetcherArgsForCall = append(fake.newResourceTypeImageFetcherArgsForCall, struct {
+		arg1 worker.Worker
+		arg2 worker.ImageResource
+		arg3 atc.Version
+		arg4 int
+		arg5 []string
+		arg6 atc.VersionedResourceTypes
+		arg7 worker.ImageFetchingDelegate
+	}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7})
+	fake.recordInvocation("NewResourceTypeImageFetcher", []interface{}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7})
+	fake.newResourceTypeImageFetcherMutex.Unlock()
+	if fake.NewResourceTypeImageFetcherStub != nil {
+		return fake.NewResourceTypeImageFetcherStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.newResourceTypeImageFetcherReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeImageResourceFetcherFactory) NewResourceTypeImageFetcherCallCount() int {
+	fake.newResourceTypeImageFetcherMutex.RLock()
+	defer fake.newResourceTypeImageFetcherMutex.RUnlock()
+	return len(fake.newResourceTypeImageFetcherArgsForCall)
+}
+
+func (fake *FakeImageResourceFetcherFactory) NewResourceTypeImageFetcherCalls(stub func(worker.Worker, worker.ImageResource, atc.Version, int, []string, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) image.ImageResourceFetcher) {
+	fake.newResourceTypeImageFetcherMutex.Lock()
+	defer fake.newResourceTypeImageFetcherMutex.Unlock()
+	fake.NewResourceTypeImageFetcherStub = stub
+}
+
+func (fake *FakeImageResourceFetcherFactory) NewResourceTypeImageFetcherArgsForCall(i int) (worker.Worker, worker.ImageResource, atc.Version, int, []string, atc.VersionedResourceTypes, worker.ImageFetchingDelegate) {
+	fake.newResourceTypeImageFetcherMutex.RLock()
+	defer fake.newResourceTypeImageFetcherMutex.RUnlock()
+	argsForCall := fake.newResourceTypeImageFetcherArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+}
+
+func (fake *FakeImageResourceFetcherFactory) NewResourceTypeImageFetcherReturns(result1 image.ImageResourceFetcher) {
+	fake.newResourceTypeImageFetcherMutex.Lock()
+	defer fake.newResourceTypeImageFetcherMutex.Unlock()
+	fake.NewResourceTypeImageFetcherStub = nil
+	fake.newResourceTypeImageFetcherReturns = struct {
+		result1 image.ImageResourceFetcher
+	}{result1}
+}
+
+func (fake *FakeImageResourceFetcherFactory) NewResourceTypeImageFetcherReturnsOnCall(i int, result1 image.ImageResourceFetcher) {
+	fake.newResourceTypeImageFetcherMutex.Lock()
+	defer fake.newResourceTypeImageFetcherMutex.Unlock()
+	fake.NewResourceTypeImageFetcherStub = nil
+	if fake.newResourceTypeImageFetcherReturnsOnCall == nil {
+		fake.newResourceTypeImageFetcherReturnsOnCall = make(map[int]struct {
+			result1 image.ImageResourceFetcher
+		})
+	}
+	fake.newResourceTypeImageFetcherReturnsOnCall[i] = struct {
+		result1 image.ImageResourceFetcher
+	}{result1}
+}
+
 func (fake *FakeImageResourceFetcherFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.newImageResourceFetcherMutex.RLock()
 	defer fake.newImageResourceFetcherMutex.RUnlock()
+	fake.newResourceTypeImageFetcherMutex.RLock()
+	defer fake.newResourceTypeImageFetcherMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
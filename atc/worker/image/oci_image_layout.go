@@ -0,0 +1,433 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/DataDog/zstd"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// OCIImageIndexFile is written by image resources which fetch images in the
+// OCI image layout, rather than as a rootfs with a metadata.json. It points
+// to the image's manifests, which are kept in the blobs directory alongside
+// it with the image's config and layers.
+const OCIImageIndexFile = "index.json"
+
+const ociBlobsDir = "blobs"
+
+var ErrOCIImageLayoutEmpty = errors.New("OCI image layout has no manifests")
+
+// imageFileStreamer returns the contents of a single file in a fetched image.
+type imageFileStreamer func(ctx context.Context, path string) (io.ReadCloser, error)
+
+// tarFileStreamer reads single files out of the zstd-compressed tar streams
+// of a volume, e.g. versioned source's StreamOut.
+func tarFileStreamer(streamOut func(context.Context, string) (io.ReadCloser, error)) imageFileStreamer {
+	return func(ctx context.Context, path string) (io.ReadCloser, error) {
+		reader, err := streamOut(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		zstdReader := zstd.NewReader(reader)
+		tarReader := tar.NewReader(zstdReader)
+
+		_, err = tarReader.Next()
+		if err != nil {
+			zstdReader.Close()
+			reader.Close()
+			return nil, fmt.Errorf("could not read file \"%s\" from tar", path)
+		}
+
+		return &fileReadMultiCloser{
+			reader: tarReader,
+			closers: []io.Closer{
+				reader,
+				zstdReader,
+			},
+		}, nil
+	}
+}
+
+// OCIImageUnpackRetryInterval is how often a fetch waits for an image in the
+// OCI image layout which is being unpacked on the worker by another fetch.
+var OCIImageUnpackRetryInterval = 5 * time.Second
+
+// streamImageMetadata returns the metadata of an image fetched by a previous
+// step. Images in the OCI image layout, which have no metadata file, are
+// unpacked into the given volume first, which then has a rootfs and metadata
+// like any other image. The volume must belong to the container alone.
+func streamImageMetadata(
+	ctx context.Context,
+	logger lager.Logger,
+	streamFile imageFileStreamer,
+	volume worker.Volume,
+) (io.ReadCloser, error) {
+	metadataReader, err := streamFile(ctx, ImageMetadataFile)
+	if err == nil {
+		return metadataReader, nil
+	}
+
+	indexReader, indexErr := streamFile(ctx, OCIImageIndexFile)
+	if indexErr != nil {
+		return nil, err
+	}
+
+	image, err := ociImageLayout{streamFile: streamFile}.Image(ctx, indexReader)
+	if err != nil {
+		logger.Error("failed-to-read-oci-image-layout", err)
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+
+	written := make(chan error, 1)
+	go func() {
+		err := image.Write(ctx, writer)
+		writer.CloseWithError(err)
+		written <- err
+	}()
+
+	err = volume.StreamIn(ctx, ".", reader)
+	reader.Close()
+
+	writeErr := <-written
+	if err != nil {
+		logger.Error("failed-to-unpack-oci-image-layout", err)
+		return nil, err
+	}
+
+	if writeErr != nil {
+		logger.Error("failed-to-unpack-oci-image-layout", writeErr)
+		return nil, writeErr
+	}
+
+	logger.Debug("unpacked-oci-image-layout", lager.Data{"layers": len(image.layers)})
+
+	return ioutil.NopCloser(bytes.NewReader(image.metadata)), nil
+}
+
+// unpackedImage returns the volume and metadata of an image fetched by an
+// image resource into the given volume. Images in the OCI image layout are
+// unpacked into a volume of their own on the worker, as the fetched volume
+// is the image resource's initialized cache and may already be in use by
+// other fetches. The unpacked image is cached by the digest of its manifest,
+// so that it is only unpacked once per worker; fetches which find it being
+// unpacked wait for it.
+//
+// If expectedDigest is given, images in the OCI image layout must have it as
+// the digest of their manifest or of an index leading to it. As the manifest
+// and its layers are verified against their content, this verifies the
+// image itself rather than what the image resource says it fetched.
+func unpackedImage(
+	ctx context.Context,
+	logger lager.Logger,
+	imageWorker worker.Worker,
+	streamFile imageFileStreamer,
+	volume worker.Volume,
+	expectedDigest string,
+) (worker.Volume, []byte, bool, error) {
+	metadataReader, err := streamFile(ctx, ImageMetadataFile)
+	if err == nil {
+		defer metadataReader.Close()
+
+		metadata, err := ioutil.ReadAll(metadataReader)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		return volume, metadata, false, nil
+	}
+
+	indexReader, indexErr := streamFile(ctx, OCIImageIndexFile)
+	if indexErr != nil {
+		return nil, nil, false, err
+	}
+
+	image, err := ociImageLayout{streamFile: streamFile}.Image(ctx, indexReader)
+	if err != nil {
+		logger.Error("failed-to-read-oci-image-layout", err)
+		return nil, nil, false, err
+	}
+
+	if expectedDigest != "" && !image.hasDigest(expectedDigest) {
+		return nil, nil, false, ImageIntegrityError{
+			Expected: expectedDigest,
+			Actual:   image.digest,
+		}
+	}
+
+	logger = logger.Session("unpack-oci-image-layout", lager.Data{"digest": image.digest})
+
+	for {
+		unpackedVolume, found, err := imageWorker.FindVolumeForImageLayer(logger, image.digest)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		if found {
+			logger.Debug("found-unpacked-image", lager.Data{"volume": unpackedVolume.Handle()})
+			return unpackedVolume, image.metadata, true, nil
+		}
+
+		reader, writer := io.Pipe()
+
+		written := make(chan error, 1)
+		go func() {
+			err := image.Write(ctx, writer)
+			writer.CloseWithError(err)
+			written <- err
+		}()
+
+		unpackedVolume, created, err := imageWorker.CreateVolumeForUnpackedImage(ctx, logger, image.digest, reader)
+		reader.Close()
+
+		writeErr := <-written
+		if err != nil {
+			// failing to write the image, e.g. a layer not matching its
+			// digest, is what made streaming it in fail
+			if writeErr != nil {
+				err = writeErr
+			}
+
+			logger.Error("failed-to-unpack", err)
+			return nil, nil, false, err
+		}
+
+		if created {
+			if writeErr != nil {
+				return nil, nil, false, writeErr
+			}
+
+			logger.Debug("unpacked", lager.Data{"layers": len(image.layers)})
+
+			return unpackedVolume, image.metadata, true, nil
+		}
+
+		logger.Debug("image-being-unpacked-elsewhere")
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, false, ctx.Err()
+		case <-time.After(OCIImageUnpackRetryInterval):
+		}
+	}
+}
+
+// ociImageLayout reads an image in the OCI image layout out of the volume it
+// was fetched into.
+type ociImageLayout struct {
+	streamFile imageFileStreamer
+}
+
+// ociImage is the image of a manifest in an OCI image layout.
+type ociImage struct {
+	// digest is the digest of the image's manifest
+	digest string
+
+	// digests are the digests of the indexes leading to the image's
+	// manifest, followed by the manifest's own
+	digests []string
+
+	layers   []imageLayer
+	metadata []byte
+}
+
+// hasDigest returns whether the digest is that of the image's manifest, or of
+// an index leading to it, e.g. one listing a manifest for each platform.
+func (image ociImage) hasDigest(digest string) bool {
+	for _, d := range image.digests {
+		if d == digest {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Image reads the image of the manifest for linux/amd64 out of the layout,
+// given its index.
+func (layout ociImageLayout) Image(ctx context.Context, indexReader io.ReadCloser) (ociImage, error) {
+	defer indexReader.Close()
+
+	payload, err := ioutil.ReadAll(io.LimitReader(indexReader, maxManifestSize))
+	if err != nil {
+		return ociImage{}, err
+	}
+
+	var index registryManifest
+	err = json.Unmarshal(payload, &index)
+	if err != nil {
+		return ociImage{}, err
+	}
+
+	manifest, digests, err := layout.manifest(ctx, index.Manifests)
+	if err != nil {
+		return ociImage{}, err
+	}
+
+	configPayload, err := layout.blob(ctx, manifest.Config.Digest)
+	if err != nil {
+		return ociImage{}, err
+	}
+
+	var config registryImageConfig
+	err = json.Unmarshal(configPayload, &config)
+	if err != nil {
+		return ociImage{}, err
+	}
+
+	var imageLayers []imageLayer
+	for _, layer := range manifest.Layers {
+		if !gzipLayerMediaTypes[layer.MediaType] {
+			return ociImage{}, UnsupportedLayerError{
+				Digest:    layer.Digest,
+				MediaType: layer.MediaType,
+			}
+		}
+
+		imageLayers = append(imageLayers, layout.blobLayer(layer.Digest))
+	}
+
+	metadata, err := json.Marshal(worker.ImageMetadata{
+		Env:  config.Config.Env,
+		User: config.Config.User,
+	})
+	if err != nil {
+		return ociImage{}, err
+	}
+
+	return ociImage{
+		digest:   digests[len(digests)-1],
+		digests:  digests,
+		layers:   imageLayers,
+		metadata: metadata,
+	}, nil
+}
+
+// Write writes a zstd-compressed tar stream of the image's rootfs, merged
+// from its layers, with its metadata alongside it.
+func (image ociImage) Write(ctx context.Context, w io.Writer) error {
+	return writeImage(ctx, w, image.layers, image.metadata, "")
+}
+
+// manifest returns the manifest for linux/amd64 out of the given ones,
+// following nested indexes, along with the digests of the nested indexes and
+// then of the manifest. A single manifest is assumed to be for linux/amd64 if
+// it doesn't say.
+func (layout ociImageLayout) manifest(ctx context.Context, descriptors []registryDescriptor) (registryManifest, []string, error) {
+	if len(descriptors) == 0 {
+		return registryManifest{}, nil, ErrOCIImageLayoutEmpty
+	}
+
+	var descriptor *registryDescriptor
+	if len(descriptors) == 1 && descriptors[0].Platform.OS == "" {
+		descriptor = &descriptors[0]
+	} else {
+		for i, platformManifest := range descriptors {
+			if platformManifest.Platform.OS == "linux" && platformManifest.Platform.Architecture == "amd64" {
+				descriptor = &descriptors[i]
+				break
+			}
+		}
+	}
+
+	if descriptor == nil {
+		return registryManifest{}, nil, ErrNoManifestForPlatform
+	}
+
+	payload, err := layout.blob(ctx, descriptor.Digest)
+	if err != nil {
+		return registryManifest{}, nil, err
+	}
+
+	var manifest registryManifest
+	err = json.Unmarshal(payload, &manifest)
+	if err != nil {
+		return registryManifest{}, nil, err
+	}
+
+	if len(manifest.Manifests) != 0 {
+		nested, digests, err := layout.manifest(ctx, manifest.Manifests)
+		if err != nil {
+			return registryManifest{}, nil, err
+		}
+
+		return nested, append([]string{descriptor.Digest}, digests...), nil
+	}
+
+	return manifest, []string{descriptor.Digest}, nil
+}
+
+// blob reads a manifest or config out of the layout's blobs, verifying it
+// against its digest.
+func (layout ociImageLayout) blob(ctx context.Context, digest string) ([]byte, error) {
+	blobPath, err := ociBlobPath(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := layout.streamFile(ctx, blobPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	payload, err := ioutil.ReadAll(io.LimitReader(reader, maxManifestSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if actual := sha256Digest(payload); actual != digest {
+		return nil, ImageIntegrityError{
+			Expected: digest,
+			Actual:   actual,
+		}
+	}
+
+	return payload, nil
+}
+
+// blobLayer merges a gzip-compressed layer out of the layout's blobs,
+// verifying it against its digest.
+func (layout ociImageLayout) blobLayer(digest string) imageLayer {
+	return func(ctx context.Context, merger *layerMerger) error {
+		blobPath, err := ociBlobPath(digest)
+		if err != nil {
+			return err
+		}
+
+		reader, err := layout.streamFile(ctx, blobPath)
+		if err != nil {
+			return err
+		}
+
+		defer reader.Close()
+
+		return mergeGzipLayer(merger, reader, digest)
+	}
+}
+
+// ociBlobPath returns the path of a blob in the layout, e.g.
+// blobs/sha256/abc... for sha256:abc.... Only sha256 digests are supported,
+// as blobs are verified against them.
+func ociBlobPath(digest string) (string, error) {
+	segs := strings.SplitN(digest, ":", 2)
+	if len(segs) != 2 || segs[0] != "sha256" || segs[1] == "" || strings.Contains(segs[1], "/") {
+		return "", fmt.Errorf("OCI image layout has unsupported digest '%s'", digest)
+	}
+
+	return path.Join(ociBlobsDir, segs[0], segs[1]), nil
+}
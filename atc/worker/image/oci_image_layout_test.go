@@ -0,0 +1,579 @@
+package image_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/DataDog/zstd"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/fetcher/fetcherfakes"
+	"github.com/concourse/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/image"
+	"github.com/concourse/concourse/atc/worker/image/imagefakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OCI image layout", func() {
+	type file struct {
+		name    string
+		content string
+		dir     bool
+	}
+
+	var (
+		layout     map[string][]byte
+		manifest   map[string]interface{}
+		layerBlobs []string
+
+		unpackVolume *workerfakes.FakeVolume
+		unpacked     map[string]string
+
+		logger lager.Logger
+	)
+
+	digestOf := func(content []byte) string {
+		sum := sha256.Sum256(content)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	blobPath := func(digest string) string {
+		return path.Join("blobs", strings.Replace(digest, ":", "/", 1))
+	}
+
+	addBlob := func(content []byte) string {
+		digest := digestOf(content)
+		layout[blobPath(digest)] = content
+		return digest
+	}
+
+	gzipTar := func(files []file) []byte {
+		buffer := new(bytes.Buffer)
+		gzipWriter := gzip.NewWriter(buffer)
+		tarWriter := tar.NewWriter(gzipWriter)
+		for _, f := range files {
+			header := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}
+			if f.dir {
+				header = &tar.Header{Name: f.name, Mode: 0755, Typeflag: tar.TypeDir}
+			}
+
+			Expect(tarWriter.WriteHeader(header)).To(Succeed())
+			_, err := tarWriter.Write([]byte(f.content))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzipWriter.Close()).To(Succeed())
+		return buffer.Bytes()
+	}
+
+	writeIndex := func(manifests ...map[string]interface{}) {
+		index, err := json.Marshal(map[string]interface{}{
+			"schemaVersion": 2,
+			"manifests":     manifests,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		layout[image.OCIImageIndexFile] = index
+	}
+
+	manifestDescriptor := func() map[string]interface{} {
+		payload, err := json.Marshal(manifest)
+		Expect(err).NotTo(HaveOccurred())
+
+		return map[string]interface{}{
+			"mediaType": "application/vnd.oci.image.manifest.v1+json",
+			"digest":    addBlob(payload),
+		}
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		layout = map[string][]byte{}
+
+		baseDigest := addBlob(gzipTar([]file{
+			{name: "etc/", dir: true},
+			{name: "etc/os-release", content: "base"},
+			{name: "etc/removed", content: "gone"},
+			{name: "bin/", dir: true},
+			{name: "bin/sh", content: "base-sh"},
+		}))
+
+		appDigest := addBlob(gzipTar([]file{
+			{name: "etc/", dir: true},
+			{name: "etc/.wh.removed"},
+			{name: "etc/app.conf", content: "app"},
+			{name: "bin/sh", content: "app-sh"},
+		}))
+
+		layerBlobs = []string{blobPath(baseDigest), blobPath(appDigest)}
+
+		configDigest := addBlob([]byte(`{"config":{"Env":["PATH=/bin"],"User":"app"}}`))
+
+		manifest = map[string]interface{}{
+			"schemaVersion": 2,
+			"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+			"config":        map[string]interface{}{"digest": configDigest},
+			"layers": []map[string]interface{}{
+				{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": baseDigest},
+				{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": appDigest},
+			},
+		}
+
+		unpacked = nil
+		unpackVolume = new(workerfakes.FakeVolume)
+		unpackVolume.PathReturns("some-path")
+		unpackVolume.StreamInStub = func(_ context.Context, path string, tarStream io.Reader) error {
+			Expect(path).To(Equal("."))
+
+			unpacked = map[string]string{}
+
+			tarReader := tar.NewReader(zstd.NewReader(tarStream))
+			for {
+				header, err := tarReader.Next()
+				if err == io.EOF {
+					break
+				}
+
+				if err != nil {
+					return err
+				}
+
+				content, err := ioutil.ReadAll(tarReader)
+				if err != nil {
+					return err
+				}
+
+				unpacked[header.Name] = string(content)
+			}
+
+			return nil
+		}
+	})
+
+	Context("when an image resource fetches it", func() {
+		var (
+			manifestDigest string
+			version        atc.Version
+
+			fakeVersionedSource *resourcefakes.FakeVersionedSource
+			layoutVolume        *workerfakes.FakeVolume
+			fakeWorker          *workerfakes.FakeWorker
+
+			originalRetryInterval time.Duration
+
+			fetchedVolume   worker.Volume
+			fetchedMetadata []byte
+			fetchErr        error
+		)
+
+		BeforeEach(func() {
+			descriptor := manifestDescriptor()
+			manifestDigest = descriptor["digest"].(string)
+			writeIndex(descriptor)
+
+			version = atc.Version{"ref": "some-ref"}
+
+			layoutVolume = new(workerfakes.FakeVolume)
+
+			fakeVersionedSource = new(resourcefakes.FakeVersionedSource)
+			fakeVersionedSource.VolumeReturns(layoutVolume)
+			fakeVersionedSource.StreamOutStub = func(_ context.Context, path string) (io.ReadCloser, error) {
+				content, found := layout[path]
+				if !found {
+					return nil, baggageclaim.ErrFileNotFound
+				}
+
+				buffer := new(bytes.Buffer)
+				zstdWriter := zstd.NewWriter(buffer)
+				tarWriter := tar.NewWriter(zstdWriter)
+				Expect(tarWriter.WriteHeader(&tar.Header{Name: path, Mode: 0644, Size: int64(len(content))})).To(Succeed())
+				_, err := tarWriter.Write(content)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tarWriter.Close()).To(Succeed())
+				Expect(zstdWriter.Close()).To(Succeed())
+
+				return ioutil.NopCloser(buffer), nil
+			}
+
+			fakeWorker = new(workerfakes.FakeWorker)
+			fakeWorker.CreateVolumeForUnpackedImageStub = func(_ context.Context, _ lager.Logger, _ string, image io.Reader) (worker.Volume, bool, error) {
+				err := unpackVolume.StreamIn(context.TODO(), ".", image)
+				if err != nil {
+					return nil, false, err
+				}
+
+				return unpackVolume, true, nil
+			}
+
+			originalRetryInterval = image.OCIImageUnpackRetryInterval
+			image.OCIImageUnpackRetryInterval = 0
+		})
+
+		AfterEach(func() {
+			image.OCIImageUnpackRetryInterval = originalRetryInterval
+		})
+
+		JustBeforeEach(func() {
+			fakeResourceFetcher := new(fetcherfakes.FakeFetcher)
+			fakeResourceFetcher.FetchReturns(fakeVersionedSource, nil)
+
+			fakeResourceCacheFactory := new(dbfakes.FakeResourceCacheFactory)
+			fakeResourceCacheFactory.FindOrCreateResourceCacheReturns(new(dbfakes.FakeUsedResourceCache), nil)
+
+			fetcher := image.NewImageResourceFetcherFactory(
+				fakeResourceCacheFactory,
+				new(dbfakes.FakeResourceConfigFactory),
+				fakeResourceFetcher,
+				new(resourcefakes.FakeResourceFactory),
+				nil,
+				nil,
+			).NewImageResourceFetcher(
+				fakeWorker,
+				worker.ImageResource{
+					Type:   "docker-image",
+					Source: atc.Source{"repository": "some/image"},
+				},
+				version,
+				123,
+				nil,
+				atc.VersionedResourceTypes{},
+				new(workerfakes.FakeImageFetchingDelegate),
+			)
+
+			var metadataReader io.ReadCloser
+			fetchedVolume, metadataReader, _, fetchErr = fetcher.Fetch(context.TODO(), logger, new(dbfakes.FakeCreatingContainer), false)
+			if fetchErr == nil {
+				var err error
+				fetchedMetadata, err = ioutil.ReadAll(metadataReader)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("unpacks the layers into a rootfs in a volume of its own, applying whiteouts", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(fetchedVolume).To(Equal(unpackVolume))
+			Expect(layoutVolume.StreamInCallCount()).To(BeZero())
+
+			Expect(fakeWorker.CreateVolumeForUnpackedImageCallCount()).To(Equal(1))
+			_, _, digest, _ := fakeWorker.CreateVolumeForUnpackedImageArgsForCall(0)
+			Expect(digest).To(Equal(manifestDigest))
+
+			Expect(unpacked).To(Equal(map[string]string{
+				"metadata.json":         `{"env":["PATH=/bin"],"user":"app"}`,
+				"rootfs/":               "",
+				"rootfs/etc":            "",
+				"rootfs/etc/app.conf":   "app",
+				"rootfs/etc/os-release": "base",
+				"rootfs/bin":            "",
+				"rootfs/bin/sh":         "app-sh",
+			}))
+		})
+
+		It("returns the metadata from the image's config", func() {
+			Expect(fetchedMetadata).To(MatchJSON(`{"env":["PATH=/bin"],"user":"app"}`))
+		})
+
+		It("streams each layer out of the blobs", func() {
+			var paths []string
+			for i := 0; i < fakeVersionedSource.StreamOutCallCount(); i++ {
+				_, path := fakeVersionedSource.StreamOutArgsForCall(i)
+				paths = append(paths, path)
+			}
+
+			Expect(paths).To(ContainElement(layerBlobs[0]))
+			Expect(paths).To(ContainElement(layerBlobs[1]))
+		})
+
+		Context("when the image has already been unpacked on the worker", func() {
+			var unpackedVolume *workerfakes.FakeVolume
+
+			BeforeEach(func() {
+				unpackedVolume = new(workerfakes.FakeVolume)
+				fakeWorker.FindVolumeForImageLayerReturns(unpackedVolume, true, nil)
+			})
+
+			It("uses it, without unpacking the image again", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedVolume).To(Equal(unpackedVolume))
+				Expect(fetchedMetadata).To(MatchJSON(`{"env":["PATH=/bin"],"user":"app"}`))
+
+				_, digest := fakeWorker.FindVolumeForImageLayerArgsForCall(0)
+				Expect(digest).To(Equal(manifestDigest))
+				Expect(fakeWorker.CreateVolumeForUnpackedImageCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when another fetch is unpacking the image on the worker", func() {
+			var unpackedVolume *workerfakes.FakeVolume
+
+			BeforeEach(func() {
+				unpackedVolume = new(workerfakes.FakeVolume)
+
+				fakeWorker.CreateVolumeForUnpackedImageStub = nil
+				fakeWorker.CreateVolumeForUnpackedImageReturns(nil, false, nil)
+				fakeWorker.FindVolumeForImageLayerReturnsOnCall(1, unpackedVolume, true, nil)
+			})
+
+			It("waits for it to be unpacked", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedVolume).To(Equal(unpackedVolume))
+				Expect(fakeWorker.FindVolumeForImageLayerCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when a layer does not match its digest", func() {
+			BeforeEach(func() {
+				layout[layerBlobs[1]] = gzipTar([]file{{name: "etc/tampered", content: "evil"}})
+			})
+
+			It("returns an integrity error", func() {
+				Expect(fetchErr).To(BeAssignableToTypeOf(worker.LayerIntegrityError{}))
+			})
+		})
+
+		Context("when the version includes a digest", func() {
+			BeforeEach(func() {
+				version = atc.Version{"digest": manifestDigest}
+			})
+
+			It("verifies the manifest against it", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(unpacked).To(HaveKeyWithValue("rootfs/etc/app.conf", "app"))
+			})
+
+			Context("when it is not the manifest's digest", func() {
+				BeforeEach(func() {
+					version = atc.Version{"digest": "sha256:some-other-digest"}
+				})
+
+				It("returns an integrity error without unpacking the image", func() {
+					Expect(fetchErr).To(Equal(image.ImageIntegrityError{
+						Expected: "sha256:some-other-digest",
+						Actual:   manifestDigest,
+					}))
+
+					Expect(fakeWorker.FindVolumeForImageLayerCallCount()).To(BeZero())
+					Expect(fakeWorker.CreateVolumeForUnpackedImageCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when it is the digest of an index leading to the manifest", func() {
+				BeforeEach(func() {
+					nested, err := json.Marshal(map[string]interface{}{
+						"schemaVersion": 2,
+						"manifests":     []map[string]interface{}{manifestDescriptor()},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					indexDigest := addBlob(nested)
+					writeIndex(map[string]interface{}{
+						"mediaType": "application/vnd.oci.image.index.v1+json",
+						"digest":    indexDigest,
+					})
+
+					version = atc.Version{"digest": indexDigest}
+				})
+
+				It("verifies the manifest against it", func() {
+					Expect(fetchErr).NotTo(HaveOccurred())
+					Expect(unpacked).To(HaveKeyWithValue("rootfs/etc/app.conf", "app"))
+				})
+			})
+		})
+
+		Context("when the index lists a manifest for each platform", func() {
+			BeforeEach(func() {
+				linux := manifestDescriptor()
+				linux["platform"] = map[string]string{"os": "linux", "architecture": "amd64"}
+
+				payload, err := json.Marshal(map[string]interface{}{"schemaVersion": 2})
+				Expect(err).NotTo(HaveOccurred())
+
+				windows := map[string]interface{}{
+					"digest":   addBlob(payload),
+					"platform": map[string]string{"os": "windows", "architecture": "amd64"},
+				}
+
+				writeIndex(windows, linux)
+			})
+
+			It("unpacks the manifest for linux/amd64", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(unpacked).To(HaveKeyWithValue("rootfs/etc/app.conf", "app"))
+			})
+		})
+
+		Context("when the index points to a nested index", func() {
+			BeforeEach(func() {
+				nested, err := json.Marshal(map[string]interface{}{
+					"schemaVersion": 2,
+					"manifests":     []map[string]interface{}{manifestDescriptor()},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				writeIndex(map[string]interface{}{
+					"mediaType": "application/vnd.oci.image.index.v1+json",
+					"digest":    addBlob(nested),
+				})
+			})
+
+			It("unpacks the manifest it points to", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(unpacked).To(HaveKeyWithValue("rootfs/etc/app.conf", "app"))
+			})
+		})
+
+		Context("when the index has no manifest for linux/amd64", func() {
+			BeforeEach(func() {
+				descriptor := manifestDescriptor()
+				descriptor["platform"] = map[string]string{"os": "linux", "architecture": "arm64"}
+				writeIndex(descriptor)
+			})
+
+			It("returns an error", func() {
+				Expect(fetchErr).To(Equal(image.ErrNoManifestForPlatform))
+			})
+		})
+
+		Context("when the index has no manifests", func() {
+			BeforeEach(func() {
+				writeIndex()
+			})
+
+			It("returns an error", func() {
+				Expect(fetchErr).To(Equal(image.ErrOCIImageLayoutEmpty))
+			})
+		})
+
+		Context("when the manifest does not match its digest", func() {
+			var digest string
+
+			BeforeEach(func() {
+				descriptor := manifestDescriptor()
+				digest = descriptor["digest"].(string)
+				layout[blobPath(digest)] = []byte(`{"tampered":true}`)
+			})
+
+			It("returns an integrity error", func() {
+				Expect(fetchErr).To(Equal(image.ImageIntegrityError{
+					Expected: digest,
+					Actual:   digestOf([]byte(`{"tampered":true}`)),
+				}))
+			})
+		})
+
+		Context("when a layer is not gzip-compressed", func() {
+			BeforeEach(func() {
+				layers := manifest["layers"].([]map[string]interface{})
+				layers[1]["mediaType"] = "application/vnd.oci.image.layer.v1.tar+zstd"
+
+				writeIndex(manifestDescriptor())
+			})
+
+			It("returns an error", func() {
+				Expect(fetchErr).To(BeAssignableToTypeOf(image.UnsupportedLayerError{}))
+			})
+		})
+
+		Context("when the image also has a metadata file", func() {
+			BeforeEach(func() {
+				layout[image.ImageMetadataFile] = []byte(`{"env":["A=1"]}`)
+			})
+
+			It("is used as it is, without unpacking the layout", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedVolume).To(Equal(layoutVolume))
+				Expect(fakeWorker.CreateVolumeForUnpackedImageCallCount()).To(BeZero())
+				Expect(fetchedMetadata).To(MatchJSON(`{"env":["A=1"]}`))
+			})
+		})
+
+		Context("when the image has neither a metadata file nor an index", func() {
+			BeforeEach(func() {
+				delete(layout, image.OCIImageIndexFile)
+			})
+
+			It("returns the error streaming out the metadata", func() {
+				Expect(fetchErr).To(Equal(baggageclaim.ErrFileNotFound))
+			})
+		})
+	})
+
+	Context("when a previous step provides it as an artifact", func() {
+		var (
+			fetchedImage worker.FetchedImage
+			fetchErr     error
+		)
+
+		BeforeEach(func() {
+			writeIndex(manifestDescriptor())
+		})
+
+		JustBeforeEach(func() {
+			fakeArtifactSource := new(workerfakes.FakeArtifactSource)
+			fakeArtifactSource.VolumeOnReturns(new(workerfakes.FakeVolume), true, nil)
+			fakeArtifactSource.StreamFileStub = func(_ context.Context, _ lager.Logger, path string) (io.ReadCloser, error) {
+				content, found := layout[path]
+				if !found {
+					return nil, exec.FileNotFoundError{Path: path}
+				}
+
+				return ioutil.NopCloser(bytes.NewReader(content)), nil
+			}
+
+			fakeVolumeClient := new(workerfakes.FakeVolumeClient)
+			fakeVolumeClient.FindOrCreateCOWVolumeForContainerReturns(unpackVolume, nil)
+
+			imageFactory := image.NewImageFactory(
+				new(imagefakes.FakeImageResourceFetcherFactory),
+				new(imagefakes.FakeResourceTypeMappings),
+			)
+
+			img, err := imageFactory.GetImage(
+				logger,
+				new(workerfakes.FakeWorker),
+				fakeVolumeClient,
+				worker.ImageSpec{ImageArtifactSource: fakeArtifactSource},
+				42,
+				new(workerfakes.FakeImageFetchingDelegate),
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			fetchedImage, fetchErr = img.FetchForContainer(context.TODO(), logger, new(dbfakes.FakeCreatingContainer))
+		})
+
+		It("unpacks it into the container's image volume", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(unpacked).To(HaveKeyWithValue("rootfs/etc/app.conf", "app"))
+
+			Expect(fetchedImage).To(Equal(worker.FetchedImage{
+				Metadata: worker.ImageMetadata{
+					Env:  []string{"PATH=/bin"},
+					User: "app",
+				},
+				URL: "raw://some-path/rootfs",
+			}))
+		})
+	})
+})
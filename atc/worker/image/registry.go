@@ -0,0 +1,360 @@
+package image
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+const defaultRegistryHost = "registry-1.docker.io"
+
+// maxManifestSize limits how much of a manifest or image config is read, as
+// they are held in memory.
+const maxManifestSize = 4 * 1024 * 1024
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+var gzipLayerMediaTypes = map[string]bool{
+	"application/vnd.docker.image.rootfs.diff.tar.gzip": true,
+	"application/vnd.oci.image.layer.v1.tar+gzip":       true,
+}
+
+var ErrNoManifestForPlatform = errors.New("image has no manifest for linux/amd64")
+
+// UnsupportedLayerError is returned when an image has a layer which can't be
+// cached, e.g. because it is not gzip-compressed.
+type UnsupportedLayerError struct {
+	Digest    string
+	MediaType string
+}
+
+func (err UnsupportedLayerError) Error() string {
+	return fmt.Sprintf("image layer %s has unsupported media type '%s'", err.Digest, err.MediaType)
+}
+
+// registrySource is the subset of a registry-image resource's source which
+// is understood when fetching images layer by layer.
+type registrySource struct {
+	Repository string
+	Username   string
+	Password   string
+}
+
+// parseRegistrySource returns false if the source configures anything which
+// is only understood by the registry-image resource itself, such as a mirror
+// or ECR credentials, in which case the resource has to fetch the image.
+func parseRegistrySource(source atc.Source) (registrySource, bool) {
+	var parsed registrySource
+	for key, value := range source {
+		str, ok := value.(string)
+		if !ok {
+			return registrySource{}, false
+		}
+
+		switch key {
+		case "repository":
+			parsed.Repository = str
+		case "username":
+			parsed.Username = str
+		case "password":
+			parsed.Password = str
+		case "tag":
+		default:
+			return registrySource{}, false
+		}
+	}
+
+	if parsed.Repository == "" {
+		return registrySource{}, false
+	}
+
+	return parsed, true
+}
+
+type registryDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	Platform  struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	} `json:"platform"`
+}
+
+type registryManifest struct {
+	MediaType string               `json:"mediaType"`
+	Config    registryDescriptor   `json:"config"`
+	Layers    []registryDescriptor `json:"layers"`
+
+	// Manifests is only set for manifest lists, which point to a manifest for
+	// each platform.
+	Manifests []registryDescriptor `json:"manifests"`
+}
+
+type registryImageConfig struct {
+	Config struct {
+		Env  []string `json:"Env"`
+		User string   `json:"User"`
+	} `json:"config"`
+}
+
+// registryClient talks to a single repository of a Docker registry, using
+// the v2 API.
+type registryClient struct {
+	httpClient *http.Client
+	source     registrySource
+
+	host string
+	name string
+
+	authorization string
+}
+
+func newRegistryClient(httpClient *http.Client, source registrySource) *registryClient {
+	host := defaultRegistryHost
+	name := source.Repository
+
+	parts := strings.SplitN(source.Repository, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		host = parts[0]
+		name = parts[1]
+	} else if len(parts) == 1 {
+		name = "library/" + name
+	}
+
+	return &registryClient{
+		httpClient: httpClient,
+		source:     source,
+
+		host: host,
+		name: name,
+	}
+}
+
+// Manifest fetches the manifest for an image digest, verifying it against
+// the digest. If the digest is of a manifest list, the manifest for
+// linux/amd64 is returned.
+func (client *registryClient) Manifest(ctx context.Context, digest string) (registryManifest, error) {
+	response, err := client.get(ctx, "manifests/"+digest, manifestMediaTypes)
+	if err != nil {
+		return registryManifest{}, err
+	}
+
+	defer response.Body.Close()
+
+	payload, err := ioutil.ReadAll(io.LimitReader(response.Body, maxManifestSize))
+	if err != nil {
+		return registryManifest{}, err
+	}
+
+	if actual := sha256Digest(payload); actual != digest {
+		return registryManifest{}, ImageIntegrityError{
+			Expected: digest,
+			Actual:   actual,
+		}
+	}
+
+	var manifest registryManifest
+	err = json.Unmarshal(payload, &manifest)
+	if err != nil {
+		return registryManifest{}, err
+	}
+
+	if len(manifest.Manifests) == 0 {
+		return manifest, nil
+	}
+
+	for _, platformManifest := range manifest.Manifests {
+		if platformManifest.Platform.OS == "linux" && platformManifest.Platform.Architecture == "amd64" {
+			return client.Manifest(ctx, platformManifest.Digest)
+		}
+	}
+
+	return registryManifest{}, ErrNoManifestForPlatform
+}
+
+// Config fetches the image config of a manifest and returns the metadata
+// used when running containers from the image.
+func (client *registryClient) Config(ctx context.Context, manifest registryManifest) (registryImageConfig, error) {
+	blob, err := client.Blob(ctx, manifest.Config.Digest)
+	if err != nil {
+		return registryImageConfig{}, err
+	}
+
+	defer blob.Close()
+
+	payload, err := ioutil.ReadAll(io.LimitReader(blob, maxManifestSize))
+	if err != nil {
+		return registryImageConfig{}, err
+	}
+
+	if actual := sha256Digest(payload); actual != manifest.Config.Digest {
+		return registryImageConfig{}, ImageIntegrityError{
+			Expected: manifest.Config.Digest,
+			Actual:   actual,
+		}
+	}
+
+	var config registryImageConfig
+	err = json.Unmarshal(payload, &config)
+	if err != nil {
+		return registryImageConfig{}, err
+	}
+
+	return config, nil
+}
+
+// Blob streams a blob, such as an image layer. The caller is responsible for
+// verifying its digest.
+func (client *registryClient) Blob(ctx context.Context, digest string) (io.ReadCloser, error) {
+	response, err := client.get(ctx, "blobs/"+digest, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (client *registryClient) get(ctx context.Context, path string, accept []string) (*http.Response, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/%s", client.host, client.name, path)
+
+	response, err := client.do(ctx, endpoint, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized && client.authorization == "" {
+		challenge := response.Header.Get("WWW-Authenticate")
+		response.Body.Close()
+
+		err = client.authenticate(ctx, challenge)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = client.do(ctx, endpoint, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("unexpected response from %s: %s", endpoint, response.Status)
+	}
+
+	return response, nil
+}
+
+func (client *registryClient) do(ctx context.Context, endpoint string, accept []string) (*http.Response, error) {
+	request, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accept) > 0 {
+		request.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+
+	if client.authorization != "" {
+		request.Header.Set("Authorization", client.authorization)
+	}
+
+	return client.httpClient.Do(request.WithContext(ctx))
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authenticate responds to the challenge of a registry, either by using basic
+// auth or by fetching a bearer token scoped to pulling the repository.
+func (client *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme := strings.SplitN(challenge, " ", 2)[0]
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		credentials := client.source.Username + ":" + client.source.Password
+		client.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+		return nil
+
+	case "bearer":
+		params := map[string]string{}
+		for _, match := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+			params[match[1]] = match[2]
+		}
+
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return fmt.Errorf("invalid auth challenge: %s", challenge)
+		}
+
+		query := realm.Query()
+		if params["service"] != "" {
+			query.Set("service", params["service"])
+		}
+
+		query.Set("scope", fmt.Sprintf("repository:%s:pull", client.name))
+		realm.RawQuery = query.Encode()
+
+		request, err := http.NewRequest("GET", realm.String(), nil)
+		if err != nil {
+			return err
+		}
+
+		if client.source.Username != "" {
+			request.SetBasicAuth(client.source.Username, client.source.Password)
+		}
+
+		response, err := client.httpClient.Do(request.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected response from %s: %s", realm.Host, response.Status)
+		}
+
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+
+		err = json.NewDecoder(response.Body).Decode(&token)
+		if err != nil {
+			return err
+		}
+
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+
+		client.authorization = "Bearer " + token.Token
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported auth challenge: %s", challenge)
+	}
+}
+
+func sha256Digest(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
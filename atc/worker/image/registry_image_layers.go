@@ -0,0 +1,418 @@
+package image
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/DataDog/zstd"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// registryImageType is the type of image resources whose images can be
+// assembled from layers cached on workers.
+const registryImageType = "registry-image"
+
+const (
+	rootfsDir         = "rootfs"
+	whiteoutPrefix    = ".wh."
+	whiteoutOpaqueDir = ".wh..wh..opq"
+)
+
+// registryImageLayers assembles registry images from their layers, caching
+// each layer in a volume on the worker keyed by its digest. Images which
+// share base layers only download the layers they don't have in common.
+type registryImageLayers struct {
+	httpClient *http.Client
+}
+
+// Assemble creates the resource cache volume for a registry image on the
+// worker from its layers, downloading any which are not cached yet. The
+// volume has the same layout as one populated by the registry-image
+// resource, so it is then found like any other cached image.
+func (layers *registryImageLayers) Assemble(
+	ctx context.Context,
+	logger lager.Logger,
+	imageWorker worker.Worker,
+	source registrySource,
+	digest string,
+	resourceCache db.UsedResourceCache,
+) error {
+	logger = logger.Session("assemble-image-from-layers", lager.Data{
+		"repository": source.Repository,
+		"digest":     digest,
+	})
+
+	client := newRegistryClient(layers.httpClient, source)
+
+	manifest, err := client.Manifest(ctx, digest)
+	if err != nil {
+		return err
+	}
+
+	config, err := client.Config(ctx, manifest)
+	if err != nil {
+		return err
+	}
+
+	var imageLayers []imageLayer
+	for _, layer := range manifest.Layers {
+		if !gzipLayerMediaTypes[layer.MediaType] {
+			return UnsupportedLayerError{
+				Digest:    layer.Digest,
+				MediaType: layer.MediaType,
+			}
+		}
+
+		imageLayer, err := layers.layer(ctx, logger, client, imageWorker, layer.Digest)
+		if err != nil {
+			return err
+		}
+
+		imageLayers = append(imageLayers, imageLayer)
+	}
+
+	metadata, err := json.Marshal(worker.ImageMetadata{
+		Env:  config.Config.Env,
+		User: config.Config.User,
+	})
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+
+	written := make(chan error, 1)
+	go func() {
+		err := writeImage(ctx, writer, imageLayers, metadata, digest)
+		writer.CloseWithError(err)
+		written <- err
+	}()
+
+	_, created, err := imageWorker.CreateVolumeForResourceCache(ctx, logger, resourceCache, reader)
+	reader.Close()
+
+	writeErr := <-written
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		logger.Debug("image-being-assembled-elsewhere")
+		return nil
+	}
+
+	if writeErr != nil {
+		return writeErr
+	}
+
+	logger.Debug("assembled", lager.Data{"layers": len(imageLayers)})
+
+	return nil
+}
+
+// layer returns the image layer with the given digest, caching it on the
+// worker if it isn't already. If another fetch is caching it at the same
+// time, the layer is merged straight from the registry rather than waiting.
+func (layers *registryImageLayers) layer(
+	ctx context.Context,
+	logger lager.Logger,
+	client *registryClient,
+	imageWorker worker.Worker,
+	digest string,
+) (imageLayer, error) {
+	volume, found, err := imageWorker.FindVolumeForImageLayer(logger, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		metric.ImageLayerCacheHits.Inc()
+		return volumeLayer(volume), nil
+	}
+
+	metric.ImageLayerCacheMisses.Inc()
+
+	blob, err := client.Blob(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer blob.Close()
+
+	volume, created, err := imageWorker.CreateVolumeForImageLayer(ctx, logger, digest, blob)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		logger.Debug("image-layer-being-cached-elsewhere", lager.Data{"layer": digest})
+		return registryBlobLayer(client, digest), nil
+	}
+
+	return volumeLayer(volume), nil
+}
+
+// imageLayer merges one of an image's layers into the rootfs being written.
+type imageLayer func(context.Context, *layerMerger) error
+
+// writeImage writes a zstd-compressed tar stream of the image, with its
+// metadata and digest alongside the rootfs merged from its layers. The
+// digest is left out if it's empty.
+//
+// The metadata is written last, so that an image being written into a volume
+// which is already in use isn't taken to be complete before its rootfs is.
+func writeImage(ctx context.Context, w io.Writer, layers []imageLayer, metadata []byte, digest string) error {
+	zstdWriter := zstd.NewWriter(w)
+	tarWriter := tar.NewWriter(zstdWriter)
+
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:     rootfsDir + "/",
+		Typeflag: tar.TypeDir,
+		Mode:     0755,
+	})
+	if err != nil {
+		return err
+	}
+
+	merger := newLayerMerger(tarWriter, rootfsDir)
+
+	// layers are merged from the top down, so that the first entry written
+	// for each path is the one which is visible in the image
+	for i := len(layers) - 1; i >= 0; i-- {
+		err := layers[i](ctx, merger)
+		if err != nil {
+			return err
+		}
+	}
+
+	type file struct {
+		name    string
+		content []byte
+	}
+
+	var files []file
+	if digest != "" {
+		files = append(files, file{ImageDigestFile, []byte(digest)})
+	}
+
+	files = append(files, file{ImageMetadataFile, metadata})
+
+	for _, file := range files {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(file.content)),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tarWriter.Write(file.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return zstdWriter.Close()
+}
+
+// volumeLayer merges a layer cached in a volume.
+func volumeLayer(volume worker.Volume) imageLayer {
+	return func(ctx context.Context, merger *layerMerger) error {
+		stream, err := volume.StreamOut(ctx, ".")
+		if err != nil {
+			return err
+		}
+
+		defer stream.Close()
+
+		zstdReader := zstd.NewReader(stream)
+		defer zstdReader.Close()
+
+		return merger.Merge(tar.NewReader(zstdReader))
+	}
+}
+
+// registryBlobLayer merges a gzip-compressed layer downloaded from the
+// registry, without caching it.
+func registryBlobLayer(client *registryClient, digest string) imageLayer {
+	return func(ctx context.Context, merger *layerMerger) error {
+		blob, err := client.Blob(ctx, digest)
+		if err != nil {
+			return err
+		}
+
+		defer blob.Close()
+
+		return mergeGzipLayer(merger, blob, digest)
+	}
+}
+
+// mergeGzipLayer merges a gzip-compressed layer, verifying it against its
+// digest.
+func mergeGzipLayer(merger *layerMerger, reader io.Reader, digest string) error {
+	hash := sha256.New()
+	blob := io.TeeReader(reader, hash)
+
+	gzipReader, err := gzip.NewReader(blob)
+	if err != nil {
+		return err
+	}
+
+	defer gzipReader.Close()
+
+	err = merger.Merge(tar.NewReader(gzipReader))
+	if err != nil {
+		return err
+	}
+
+	// the tar and gzip readers may stop short of the end of the blob
+	_, err = io.Copy(ioutil.Discard, blob)
+	if err != nil {
+		return err
+	}
+
+	if actual := "sha256:" + hex.EncodeToString(hash.Sum(nil)); actual != digest {
+		return worker.LayerIntegrityError{
+			Expected: digest,
+			Actual:   actual,
+		}
+	}
+
+	return nil
+}
+
+// layerMerger writes the entries of image layers which are visible in the
+// final image, given layers from the top down. Whiteout files in a layer hide
+// the entries below it, and are not written themselves.
+type layerMerger struct {
+	out  *tar.Writer
+	root string
+
+	// written maps the paths written by the layers merged so far to whether
+	// they are directories
+	written map[string]bool
+	hidden  map[string]bool
+	opaque  map[string]bool
+}
+
+func newLayerMerger(out *tar.Writer, root string) *layerMerger {
+	return &layerMerger{
+		out:  out,
+		root: root,
+
+		written: map[string]bool{},
+		hidden:  map[string]bool{},
+		opaque:  map[string]bool{},
+	}
+}
+
+func (merger *layerMerger) Merge(layer *tar.Reader) error {
+	var whiteouts, opaqueDirs []string
+
+	for {
+		header, err := layer.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean("/" + header.Name)
+		if name == "/" {
+			continue
+		}
+
+		dir, base := path.Split(name)
+		if base == whiteoutOpaqueDir {
+			opaqueDirs = append(opaqueDirs, path.Clean(dir))
+			continue
+		}
+
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			whiteouts = append(whiteouts, path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)))
+			continue
+		}
+
+		if merger.shadowed(name) {
+			continue
+		}
+
+		merger.written[name] = header.Typeflag == tar.TypeDir
+
+		header.Name = merger.root + name
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = merger.root + path.Clean("/"+header.Linkname)
+		}
+
+		err = merger.out.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(merger.out, layer)
+		if err != nil {
+			return err
+		}
+	}
+
+	// whiteouts only apply to the layers below, so they take effect once the
+	// whole layer has been merged
+	for _, name := range whiteouts {
+		merger.hidden[name] = true
+	}
+
+	for _, dir := range opaqueDirs {
+		merger.opaque[dir] = true
+	}
+
+	return nil
+}
+
+// shadowed returns true if a path has been written or removed by a layer
+// above, or if one of its parents has been replaced or emptied.
+func (merger *layerMerger) shadowed(name string) bool {
+	if _, found := merger.written[name]; found {
+		return true
+	}
+
+	for p := name; ; p = path.Dir(p) {
+		if merger.hidden[p] {
+			return true
+		}
+
+		if p != name {
+			if merger.opaque[p] {
+				return true
+			}
+
+			if isDir, found := merger.written[p]; found && !isDir {
+				return true
+			}
+		}
+
+		if p == "/" {
+			return false
+		}
+	}
+}
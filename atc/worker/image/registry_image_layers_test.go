@@ -0,0 +1,403 @@
+package image_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/DataDog/zstd"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/fetcher/fetcherfakes"
+	"github.com/concourse/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/image"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry image layers", func() {
+	type file struct {
+		name    string
+		content string
+		dir     bool
+	}
+
+	var (
+		registry *httptest.Server
+		blobs    map[string][]byte
+		requests []string
+
+		baseLayer, appLayer []file
+		baseDigest          string
+		appDigest           string
+		imageDigest         string
+
+		fakeResourceFetcher       *fetcherfakes.FakeFetcher
+		fakeResourceCacheFactory  *dbfakes.FakeResourceCacheFactory
+		fakeResourceCache         *dbfakes.FakeUsedResourceCache
+		fakeVersionedSource       *resourcefakes.FakeVersionedSource
+		fakeCacheVolume           *workerfakes.FakeVolume
+		fakeWorker                *workerfakes.FakeWorker
+		fakeImageFetchingDelegate *workerfakes.FakeImageFetchingDelegate
+
+		cachedLayers map[string][]file
+		assembled    map[string]string
+
+		imageResource worker.ImageResource
+		logger        lager.Logger
+		fetchErr      error
+	)
+
+	digestOf := func(content []byte) string {
+		sum := sha256.Sum256(content)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	writeTar := func(w io.Writer, files []file) {
+		tarWriter := tar.NewWriter(w)
+		for _, f := range files {
+			header := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}
+			if f.dir {
+				header = &tar.Header{Name: f.name, Mode: 0755, Typeflag: tar.TypeDir}
+			}
+
+			Expect(tarWriter.WriteHeader(header)).To(Succeed())
+			_, err := tarWriter.Write([]byte(f.content))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(tarWriter.Close()).To(Succeed())
+	}
+
+	gzipTar := func(files []file) []byte {
+		buffer := new(bytes.Buffer)
+		gzipWriter := gzip.NewWriter(buffer)
+		writeTar(gzipWriter, files)
+		Expect(gzipWriter.Close()).To(Succeed())
+		return buffer.Bytes()
+	}
+
+	zstdTar := func(files []file) io.ReadCloser {
+		buffer := new(bytes.Buffer)
+		zstdWriter := zstd.NewWriter(buffer)
+		writeTar(zstdWriter, files)
+		Expect(zstdWriter.Close()).To(Succeed())
+		return ioutil.NopCloser(buffer)
+	}
+
+	layerVolume := func(files []file) *workerfakes.FakeVolume {
+		volume := new(workerfakes.FakeVolume)
+		volume.StreamOutStub = func(context.Context, string) (io.ReadCloser, error) {
+			return zstdTar(append([]file{{name: "./", dir: true}}, files...)), nil
+		}
+
+		return volume
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		baseLayer = []file{
+			{name: "etc/", dir: true},
+			{name: "etc/os-release", content: "base"},
+			{name: "etc/removed", content: "gone"},
+			{name: "var/", dir: true},
+			{name: "var/cache/", dir: true},
+			{name: "var/cache/stale", content: "stale"},
+			{name: "bin/", dir: true},
+			{name: "bin/sh", content: "base-sh"},
+		}
+
+		appLayer = []file{
+			{name: "etc/", dir: true},
+			{name: "etc/.wh.removed"},
+			{name: "etc/app.conf", content: "app"},
+			{name: "var/cache/", dir: true},
+			{name: "var/cache/.wh..wh..opq"},
+			{name: "var/cache/fresh", content: "fresh"},
+			{name: "bin/sh", content: "app-sh"},
+		}
+
+		blobs = map[string][]byte{}
+
+		baseBlob := gzipTar(baseLayer)
+		baseDigest = digestOf(baseBlob)
+		blobs[baseDigest] = baseBlob
+
+		appBlob := gzipTar(appLayer)
+		appDigest = digestOf(appBlob)
+		blobs[appDigest] = appBlob
+
+		config := []byte(`{"config":{"Env":["PATH=/bin"],"User":"app"}}`)
+		configDigest := digestOf(config)
+		blobs[configDigest] = config
+
+		manifest, err := json.Marshal(map[string]interface{}{
+			"schemaVersion": 2,
+			"mediaType":     "application/vnd.docker.distribution.manifest.v2+json",
+			"config":        map[string]interface{}{"digest": configDigest},
+			"layers": []map[string]interface{}{
+				{"mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip", "digest": baseDigest},
+				{"mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip", "digest": appDigest},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		imageDigest = digestOf(manifest)
+
+		requests = nil
+		registry = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path)
+
+			if r.URL.Path == "/token" {
+				Expect(r.URL.Query().Get("scope")).To(Equal("repository:some/image:pull"))
+				username, password, _ := r.BasicAuth()
+				Expect(username + ":" + password).To(Equal("some-user:some-password"))
+				w.Write([]byte(`{"token":"some-token"}`))
+				return
+			}
+
+			if r.Header.Get("Authorization") != "Bearer some-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="some-registry"`, r.Host))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			switch {
+			case r.URL.Path == "/v2/some/image/manifests/"+imageDigest:
+				w.Write(manifest)
+			case strings.HasPrefix(r.URL.Path, "/v2/some/image/blobs/"):
+				blob, found := blobs[strings.TrimPrefix(r.URL.Path, "/v2/some/image/blobs/")]
+				if !found {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.Write(blob)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		imageResource = worker.ImageResource{
+			Type: "registry-image",
+			Source: atc.Source{
+				"repository": strings.TrimPrefix(registry.URL, "https://") + "/some/image",
+				"username":   "some-user",
+				"password":   "some-password",
+			},
+		}
+
+		fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+		fakeResourceCacheFactory.FindOrCreateResourceCacheReturns(fakeResourceCache, nil)
+
+		fakeCacheVolume = new(workerfakes.FakeVolume)
+		fakeVersionedSource = new(resourcefakes.FakeVersionedSource)
+		fakeVersionedSource.VolumeReturns(fakeCacheVolume)
+		fakeVersionedSource.StreamOutStub = func(_ context.Context, path string) (io.ReadCloser, error) {
+			if path == image.ImageDigestFile {
+				return zstdTar([]file{{name: path, content: imageDigest}}), nil
+			}
+
+			return zstdTar([]file{{name: path, content: "{}"}}), nil
+		}
+
+		fakeResourceFetcher = new(fetcherfakes.FakeFetcher)
+		fakeResourceFetcher.FetchReturns(fakeVersionedSource, nil)
+
+		cachedLayers = map[string][]file{baseDigest: baseLayer}
+		assembled = nil
+
+		fakeWorker = new(workerfakes.FakeWorker)
+		fakeWorker.FindVolumeForImageLayerStub = func(_ lager.Logger, digest string) (worker.Volume, bool, error) {
+			files, found := cachedLayers[digest]
+			if !found {
+				return nil, false, nil
+			}
+
+			return layerVolume(files), true, nil
+		}
+		fakeWorker.CreateVolumeForImageLayerStub = func(_ context.Context, _ lager.Logger, digest string, layer io.Reader) (worker.Volume, bool, error) {
+			blob, err := ioutil.ReadAll(layer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blob).To(Equal(blobs[digest]))
+
+			return layerVolume(appLayer), true, nil
+		}
+		fakeWorker.CreateVolumeForResourceCacheStub = func(_ context.Context, _ lager.Logger, _ db.UsedResourceCache, tarStream io.Reader) (worker.Volume, bool, error) {
+			assembled = map[string]string{}
+
+			tarReader := tar.NewReader(zstd.NewReader(tarStream))
+			for {
+				header, err := tarReader.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).NotTo(HaveOccurred())
+
+				content, err := ioutil.ReadAll(tarReader)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, duplicate := assembled[header.Name]
+				Expect(duplicate).To(BeFalse(), header.Name)
+
+				assembled[header.Name] = string(content)
+			}
+
+			return fakeCacheVolume, true, nil
+		}
+
+		fakeImageFetchingDelegate = new(workerfakes.FakeImageFetchingDelegate)
+	})
+
+	AfterEach(func() {
+		registry.Close()
+	})
+
+	JustBeforeEach(func() {
+		fetcher := image.NewImageResourceFetcherFactory(
+			fakeResourceCacheFactory,
+			new(dbfakes.FakeResourceConfigFactory),
+			fakeResourceFetcher,
+			new(resourcefakes.FakeResourceFactory),
+			registry.Client(),
+			nil,
+		).NewImageResourceFetcher(
+			fakeWorker,
+			imageResource,
+			atc.Version{"digest": imageDigest},
+			123,
+			nil,
+			atc.VersionedResourceTypes{},
+			fakeImageFetchingDelegate,
+		)
+
+		_, _, _, fetchErr = fetcher.Fetch(context.TODO(), logger, new(dbfakes.FakeCreatingContainer), false)
+	})
+
+	It("assembles the image from its layers before fetching it", func() {
+		Expect(fetchErr).NotTo(HaveOccurred())
+
+		Expect(fakeWorker.CreateVolumeForResourceCacheCallCount()).To(Equal(1))
+		_, _, resourceCache, _ := fakeWorker.CreateVolumeForResourceCacheArgsForCall(0)
+		Expect(resourceCache).To(Equal(fakeResourceCache))
+
+		Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+	})
+
+	It("only downloads the layers which are not cached on the worker", func() {
+		Expect(fakeWorker.CreateVolumeForImageLayerCallCount()).To(Equal(1))
+		_, _, digest, _ := fakeWorker.CreateVolumeForImageLayerArgsForCall(0)
+		Expect(digest).To(Equal(appDigest))
+
+		Expect(requests).NotTo(ContainElement("/v2/some/image/blobs/" + baseDigest))
+	})
+
+	It("merges the layers into the rootfs, applying whiteouts", func() {
+		Expect(assembled).To(Equal(map[string]string{
+			"metadata.json":          `{"env":["PATH=/bin"],"user":"app"}`,
+			"digest":                 imageDigest,
+			"rootfs/":                "",
+			"rootfs/etc":             "",
+			"rootfs/etc/app.conf":    "app",
+			"rootfs/etc/os-release":  "base",
+			"rootfs/var/cache":       "",
+			"rootfs/var/cache/fresh": "fresh",
+			"rootfs/var":             "",
+			"rootfs/bin/sh":          "app-sh",
+			"rootfs/bin":             "",
+		}))
+	})
+
+	Context("when the image is already cached on the worker", func() {
+		BeforeEach(func() {
+			fakeWorker.FindVolumeForResourceCacheReturns(fakeCacheVolume, true, nil)
+		})
+
+		It("does not assemble it again", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(fakeWorker.CreateVolumeForResourceCacheCallCount()).To(BeZero())
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	Context("when a layer is being cached by another fetch", func() {
+		BeforeEach(func() {
+			fakeWorker.CreateVolumeForImageLayerStub = func(_ context.Context, _ lager.Logger, digest string, layer io.Reader) (worker.Volume, bool, error) {
+				return nil, false, nil
+			}
+		})
+
+		It("merges the layer straight from the registry", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(fakeWorker.CreateVolumeForResourceCacheCallCount()).To(Equal(1))
+
+			Expect(assembled).To(HaveKeyWithValue("rootfs/etc/app.conf", "app"))
+			Expect(assembled).To(HaveKeyWithValue("rootfs/bin/sh", "app-sh"))
+			Expect(assembled).NotTo(HaveKey("rootfs/etc/removed"))
+		})
+
+		It("downloads the layer again rather than waiting", func() {
+			blobRequests := 0
+			for _, request := range requests {
+				if request == "/v2/some/image/blobs/"+appDigest {
+					blobRequests++
+				}
+			}
+
+			Expect(blobRequests).To(Equal(2))
+		})
+	})
+
+	Context("when a layer does not match its digest", func() {
+		BeforeEach(func() {
+			fakeWorker.CreateVolumeForImageLayerStub = func(_ context.Context, _ lager.Logger, digest string, layer io.Reader) (worker.Volume, bool, error) {
+				return nil, false, worker.LayerIntegrityError{Expected: digest}
+			}
+		})
+
+		It("leaves the image to be fetched by the resource", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(fakeWorker.CreateVolumeForResourceCacheCallCount()).To(BeZero())
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the source configures something only the resource understands", func() {
+		BeforeEach(func() {
+			imageResource.Source["registry_mirror"] = map[string]interface{}{"host": "some-mirror"}
+		})
+
+		It("leaves the image to be fetched by the resource", func() {
+			Expect(requests).To(BeEmpty())
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the image is not a registry-image", func() {
+		BeforeEach(func() {
+			imageResource.Type = "docker-image"
+		})
+
+		It("leaves the image to be fetched by the resource", func() {
+			Expect(requests).To(BeEmpty())
+			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+		})
+	})
+})
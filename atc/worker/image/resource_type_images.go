@@ -0,0 +1,99 @@
+package image
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// maxResourceTypeImages bounds the number of images remembered across all
+// workers. Entries are only hints, so evicting one just costs a full fetch.
+const maxResourceTypeImages = 1000
+
+// resourceTypeImages remembers the volume fetched on each worker for each
+// version of a custom resource type's image, so that later containers using
+// the type on the same worker can reuse it without going through the fetcher.
+type resourceTypeImages struct {
+	lock   sync.Mutex
+	images map[resourceTypeImageKey]resourceTypeImage
+}
+
+type resourceTypeImageKey struct {
+	workerName      string
+	resourceCacheID int
+}
+
+type resourceTypeImage struct {
+	volumeHandle string
+	metadata     []byte
+}
+
+func newResourceTypeImages() *resourceTypeImages {
+	return &resourceTypeImages{
+		images: map[resourceTypeImageKey]resourceTypeImage{},
+	}
+}
+
+// find returns the volume and image metadata registered for the resource
+// cache on the worker. Entries whose volume has gone away are forgotten.
+func (images *resourceTypeImages) find(
+	logger lager.Logger,
+	w worker.Worker,
+	resourceCache db.UsedResourceCache,
+) (worker.Volume, []byte, bool) {
+	key := resourceTypeImageKey{
+		workerName:      w.Name(),
+		resourceCacheID: resourceCache.ID(),
+	}
+
+	images.lock.Lock()
+	image, found := images.images[key]
+	images.lock.Unlock()
+
+	if !found {
+		return nil, nil, false
+	}
+
+	volume, found, err := w.LookupVolume(logger, image.volumeHandle)
+	if err != nil {
+		logger.Error("failed-to-lookup-resource-type-image-volume", err)
+		return nil, nil, false
+	}
+
+	if !found {
+		images.lock.Lock()
+		delete(images.images, key)
+		images.lock.Unlock()
+
+		return nil, nil, false
+	}
+
+	return volume, image.metadata, true
+}
+
+func (images *resourceTypeImages) register(
+	w worker.Worker,
+	resourceCache db.UsedResourceCache,
+	volume worker.Volume,
+	metadata []byte,
+) {
+	images.lock.Lock()
+	defer images.lock.Unlock()
+
+	if len(images.images) >= maxResourceTypeImages {
+		for key := range images.images {
+			delete(images.images, key)
+			break
+		}
+	}
+
+	images.images[resourceTypeImageKey{
+		workerName:      w.Name(),
+		resourceCacheID: resourceCache.ID(),
+	}] = resourceTypeImage{
+		volumeHandle: volume.Handle(),
+		metadata:     metadata,
+	}
+}
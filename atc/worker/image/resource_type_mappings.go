@@ -0,0 +1,55 @@
+package image
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . ResourceTypeMappings
+
+// ResourceTypeMappings finds the images which implement remapped base
+// resource types for a team.
+type ResourceTypeMappings interface {
+	ForTeam(teamID int) (atc.ResourceTypeMappings, error)
+}
+
+type resourceTypeMappings struct {
+	installationMappings atc.ResourceTypeMappings
+	teamFactory          db.TeamFactory
+}
+
+// NewResourceTypeMappings constructs ResourceTypeMappings which apply the
+// installation's mappings, overridden by those of each team.
+func NewResourceTypeMappings(
+	installationMappings atc.ResourceTypeMappings,
+	teamFactory db.TeamFactory,
+) ResourceTypeMappings {
+	return &resourceTypeMappings{
+		installationMappings: installationMappings,
+		teamFactory:          teamFactory,
+	}
+}
+
+func (m *resourceTypeMappings) ForTeam(teamID int) (atc.ResourceTypeMappings, error) {
+	team, found, err := m.teamFactory.FindTeamByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return m.installationMappings, nil
+	}
+
+	mappings := m.installationMappings.Override(team.ResourceTypeMappings())
+
+	// the team's mappings were validated on their own, but combined with the
+	// installation's they may now map a type to one which is itself remapped
+	err = mappings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource type mappings for team '%s': %s", team.Name(), err)
+	}
+
+	return mappings, nil
+}
@@ -0,0 +1,102 @@
+package image_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker/image"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceTypeMappings", func() {
+	var (
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		mappings        image.ResourceTypeMappings
+
+		teamMappings atc.ResourceTypeMappings
+		err          error
+	)
+
+	BeforeEach(func() {
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+		fakeTeamFactory.FindTeamByIDReturns(fakeTeam, true, nil)
+
+		mappings = image.NewResourceTypeMappings(atc.ResourceTypeMappings{
+			"git": {Type: "registry-image", Source: atc.Source{"repository": "installation/git"}},
+			"s3":  {Type: "registry-image", Source: atc.Source{"repository": "installation/s3"}},
+		}, fakeTeamFactory)
+	})
+
+	JustBeforeEach(func() {
+		teamMappings, err = mappings.ForTeam(42)
+	})
+
+	It("finds the team by its id", func() {
+		Expect(fakeTeamFactory.FindTeamByIDCallCount()).To(Equal(1))
+		Expect(fakeTeamFactory.FindTeamByIDArgsForCall(0)).To(Equal(42))
+	})
+
+	It("returns the installation's mappings", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(teamMappings).To(Equal(atc.ResourceTypeMappings{
+			"git": {Type: "registry-image", Source: atc.Source{"repository": "installation/git"}},
+			"s3":  {Type: "registry-image", Source: atc.Source{"repository": "installation/s3"}},
+		}))
+	})
+
+	Context("when the team overrides a mapping", func() {
+		BeforeEach(func() {
+			fakeTeam.ResourceTypeMappingsReturns(atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "team/git"}},
+			})
+		})
+
+		It("returns the team's mapping in place of the installation's", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teamMappings).To(Equal(atc.ResourceTypeMappings{
+				"git": {Type: "registry-image", Source: atc.Source{"repository": "team/git"}},
+				"s3":  {Type: "registry-image", Source: atc.Source{"repository": "installation/s3"}},
+			}))
+		})
+	})
+
+	Context("when the team's mappings remap a type used by the installation's", func() {
+		BeforeEach(func() {
+			fakeTeam.ResourceTypeMappingsReturns(atc.ResourceTypeMappings{
+				"registry-image": {Type: "docker-image", Source: atc.Source{"repository": "team/registry-image"}},
+			})
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError(ContainSubstring("invalid resource type mappings for team 'some-team'")))
+		})
+	})
+
+	Context("when the team is not found", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamByIDReturns(nil, false, nil)
+		})
+
+		It("returns the installation's mappings", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teamMappings).To(HaveLen(2))
+		})
+	})
+
+	Context("when finding the team fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamByIDReturns(nil, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
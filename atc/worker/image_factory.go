@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -46,7 +47,26 @@ type Image interface {
 type ImageFetchingDelegate interface {
 	Stdout() io.Writer
 	Stderr() io.Writer
+
+	// ImageCheckStarted is called when the image's resource starts being
+	// checked for its latest version. Images pinned to a version skip it.
+	ImageCheckStarted() error
 	ImageVersionDetermined(db.UsedResourceCache) error
+
+	// ImageFetchStarted and ImageFetchFinished are called around fetching
+	// the image onto the worker, whether by running its resource or by
+	// streaming it from another worker.
+	ImageFetchStarted() error
+	ImageFetchFinished() error
+
+	// ImageBytesStreamed is called periodically while an image is streamed
+	// from another worker, with the total bytes streamed so far.
+	ImageBytesStreamed(bytes int64) error
+
+	// ContainerPrepared is called once a container has been created, with how
+	// long its image took to fetch and its volumes took to create, which
+	// includes streaming its inputs from other workers.
+	ContainerPrepared(imageFetchDuration, inputStreamDuration time.Duration) error
 }
 
 type ImageMetadata struct {
@@ -56,6 +76,11 @@ type ImageMetadata struct {
 
 type NoopImageFetchingDelegate struct{}
 
-func (NoopImageFetchingDelegate) Stdout() io.Writer                                 { return ioutil.Discard }
-func (NoopImageFetchingDelegate) Stderr() io.Writer                                 { return ioutil.Discard }
-func (NoopImageFetchingDelegate) ImageVersionDetermined(db.UsedResourceCache) error { return nil }
+func (NoopImageFetchingDelegate) Stdout() io.Writer                                    { return ioutil.Discard }
+func (NoopImageFetchingDelegate) Stderr() io.Writer                                    { return ioutil.Discard }
+func (NoopImageFetchingDelegate) ImageCheckStarted() error                             { return nil }
+func (NoopImageFetchingDelegate) ImageVersionDetermined(db.UsedResourceCache) error    { return nil }
+func (NoopImageFetchingDelegate) ImageFetchStarted() error                             { return nil }
+func (NoopImageFetchingDelegate) ImageFetchFinished() error                            { return nil }
+func (NoopImageFetchingDelegate) ImageBytesStreamed(int64) error                       { return nil }
+func (NoopImageFetchingDelegate) ContainerPrepared(time.Duration, time.Duration) error { return nil }
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -56,6 +57,16 @@ func (err NoCompatibleWorkersError) Error() string {
 	return fmt.Sprintf("no workers satisfying: %s", err.Spec.Description())
 }
 
+// ErrorCode is only set when the spec has tags; without them no workers
+// satisfying it is down to the platform or resource type instead.
+func (err NoCompatibleWorkersError) ErrorCode() atc.ErrorCode {
+	if len(err.Spec.Tags) == 0 {
+		return ""
+	}
+
+	return atc.ErrorCodeNoWorkersMatchingTags
+}
+
 //go:generate counterfeiter . Pool
 
 type Pool interface {
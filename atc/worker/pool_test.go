@@ -433,3 +433,15 @@ var _ = Describe("Pool", func() {
 	})
 
 })
+
+var _ = Describe("NoCompatibleWorkersError", func() {
+	It("has the no_workers_matching_tags error code when the spec has tags", func() {
+		err := NoCompatibleWorkersError{Spec: WorkerSpec{Platform: "linux", Tags: []string{"some-tag"}}}
+		Expect(atc.ErrorCodeOf(err)).To(Equal(atc.ErrorCodeNoWorkersMatchingTags))
+	})
+
+	It("has no error code when the spec has no tags", func() {
+		err := NoCompatibleWorkersError{Spec: WorkerSpec{Platform: "linux"}}
+		Expect(atc.ErrorCodeOf(err)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . ResourceCacheStore
+
+// ResourceCacheStore persists the contents of initialized resource cache
+// volumes outside of any one worker, so that a cache survives the worker being
+// recreated and can be hydrated onto other workers which need it rather than
+// being fetched again.
+//
+// Streams are the zstd-compressed tarballs produced by baggageclaim's
+// StreamOut, and are handed back to StreamIn as-is.
+type ResourceCacheStore interface {
+	Contains(lager.Logger, db.UsedResourceCache) (bool, error)
+	Put(lager.Logger, db.UsedResourceCache, io.Reader) error
+	Get(lager.Logger, db.UsedResourceCache) (io.ReadCloser, bool, error)
+
+	// List and Delete identify stored caches by resource cache ID, so that
+	// caches which no longer exist can be garbage-collected.
+	List(lager.Logger) ([]int, error)
+	Delete(lager.Logger, int) error
+}
+
+type dirResourceCacheStore struct {
+	dir string
+}
+
+// NewDirResourceCacheStore returns a ResourceCacheStore which keeps cache
+// contents as files in the given directory. The directory is typically a
+// mount of an object store bucket shared by all web nodes.
+func NewDirResourceCacheStore(dir string) ResourceCacheStore {
+	return &dirResourceCacheStore{
+		dir: dir,
+	}
+}
+
+func (store *dirResourceCacheStore) Contains(logger lager.Logger, resourceCache db.UsedResourceCache) (bool, error) {
+	_, err := os.Stat(store.path(resourceCache))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		logger.Error("failed-to-stat-stored-resource-cache", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (store *dirResourceCacheStore) Put(logger lager.Logger, resourceCache db.UsedResourceCache, tarStream io.Reader) error {
+	tmp, err := ioutil.TempFile(store.dir, ".resource-cache-")
+	if err != nil {
+		logger.Error("failed-to-create-temp-file", err)
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, tarStream)
+	if err != nil {
+		_ = tmp.Close()
+		logger.Error("failed-to-write-resource-cache", err)
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		logger.Error("failed-to-close-resource-cache", err)
+		return err
+	}
+
+	// rename so that a partially written cache is never visible to Get
+	return os.Rename(tmp.Name(), store.path(resourceCache))
+}
+
+func (store *dirResourceCacheStore) Get(logger lager.Logger, resourceCache db.UsedResourceCache) (io.ReadCloser, bool, error) {
+	file, err := os.Open(store.path(resourceCache))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		logger.Error("failed-to-open-stored-resource-cache", err)
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (store *dirResourceCacheStore) List(logger lager.Logger) ([]int, error) {
+	entries, err := ioutil.ReadDir(store.dir)
+	if err != nil {
+		logger.Error("failed-to-read-resource-cache-store", err)
+		return nil, err
+	}
+
+	var ids []int
+	for _, entry := range entries {
+		// skips temp files of caches which are still being written
+		name := entry.Name()
+		if !strings.HasSuffix(name, cacheFileExtension) {
+			continue
+		}
+
+		id, err := strconv.Atoi(strings.TrimSuffix(name, cacheFileExtension))
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (store *dirResourceCacheStore) Delete(logger lager.Logger, resourceCacheID int) error {
+	err := os.Remove(store.pathForID(resourceCacheID))
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error("failed-to-remove-stored-resource-cache", err)
+		return err
+	}
+
+	return nil
+}
+
+const cacheFileExtension = ".tar.zst"
+
+func (store *dirResourceCacheStore) path(resourceCache db.UsedResourceCache) string {
+	return store.pathForID(resourceCache.ID())
+}
+
+func (store *dirResourceCacheStore) pathForID(resourceCacheID int) string {
+	return filepath.Join(store.dir, strconv.Itoa(resourceCacheID)+cacheFileExtension)
+}
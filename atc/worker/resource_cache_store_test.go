@@ -0,0 +1,111 @@
+package worker_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DirResourceCacheStore", func() {
+	var (
+		logger        *lagertest.TestLogger
+		dir           string
+		resourceCache *dbfakes.FakeUsedResourceCache
+
+		store worker.ResourceCacheStore
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		dir, err = ioutil.TempDir("", "resource-cache-store")
+		Expect(err).ToNot(HaveOccurred())
+
+		resourceCache = new(dbfakes.FakeUsedResourceCache)
+		resourceCache.IDReturns(42)
+
+		store = worker.NewDirResourceCacheStore(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("when the cache has not been stored", func() {
+		It("does not contain it", func() {
+			Expect(store.Contains(logger, resourceCache)).To(BeFalse())
+		})
+
+		It("does not find it", func() {
+			_, found, err := store.Get(logger, resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not list it", func() {
+			Expect(store.List(logger)).To(BeEmpty())
+		})
+
+		It("succeeds at deleting it", func() {
+			Expect(store.Delete(logger, 42)).To(Succeed())
+		})
+	})
+
+	Context("when the cache has been stored", func() {
+		BeforeEach(func() {
+			err := store.Put(logger, resourceCache, strings.NewReader("some-tar"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("contains it", func() {
+			Expect(store.Contains(logger, resourceCache)).To(BeTrue())
+		})
+
+		It("returns the stored stream", func() {
+			stream, found, err := store.Get(logger, resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer stream.Close()
+			Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-tar")))
+		})
+
+		It("does not leave any temporary files behind", func() {
+			entries, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+
+		It("does not contain other caches", func() {
+			otherCache := new(dbfakes.FakeUsedResourceCache)
+			otherCache.IDReturns(43)
+			Expect(store.Contains(logger, otherCache)).To(BeFalse())
+		})
+
+		It("lists it by ID, ignoring anything else in the directory", func() {
+			err := ioutil.WriteFile(filepath.Join(dir, ".resource-cache-123"), []byte("partial"), 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(store.List(logger)).To(Equal([]int{42}))
+		})
+
+		Context("when it is deleted", func() {
+			BeforeEach(func() {
+				Expect(store.Delete(logger, 42)).To(Succeed())
+			})
+
+			It("no longer contains it", func() {
+				Expect(store.Contains(logger, resourceCache)).To(BeFalse())
+				Expect(store.List(logger)).To(BeEmpty())
+			})
+		})
+	})
+})
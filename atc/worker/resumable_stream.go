@@ -0,0 +1,321 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// StreamResumeAttempts is how many times a volume stream which was
+// interrupted is resumed before giving up.
+var StreamResumeAttempts = 5
+
+// StreamResumeInterval is how long to wait before resuming an interrupted
+// volume stream.
+var StreamResumeInterval = time.Second
+
+// streamChunkSize is how much of a stream each recorded checksum covers.
+const streamChunkSize = 1024 * 1024
+
+// ErrStreamChanged is returned when a resumed volume stream doesn't have the
+// same content as the stream it resumes, e.g. because the volume was changed
+// in the meantime.
+var ErrStreamChanged = errors.New("volume stream changed while resuming it")
+
+// ErrInvalidStreamRange is returned for a Range header which doesn't ask for
+// a volume stream from an offset onwards.
+var ErrInvalidStreamRange = errors.New("invalid volume stream range")
+
+// StreamRetry describes an interrupted volume stream being resumed.
+type StreamRetry struct {
+	Volume  string
+	Worker  string
+	Offset  int64
+	Attempt int
+	Err     error
+}
+
+func (retry StreamRetry) String() string {
+	return fmt.Sprintf(
+		"volume stream from worker '%s' interrupted after %d bytes (%s); resuming (attempt %d of %d)",
+		retry.Worker,
+		retry.Offset,
+		retry.Err,
+		retry.Attempt,
+		StreamResumeAttempts,
+	)
+}
+
+// StreamRetryNotifier is told about each interrupted volume stream which is
+// resumed.
+type StreamRetryNotifier func(StreamRetry)
+
+type streamRetryNotifierKey struct{}
+
+// WithStreamRetryNotifier returns a context which volume streams started with
+// it notify when they are resumed, so that the retries can be surfaced to
+// whoever is waiting on them.
+func WithStreamRetryNotifier(ctx context.Context, notify StreamRetryNotifier) context.Context {
+	return context.WithValue(ctx, streamRetryNotifierKey{}, notify)
+}
+
+func streamRetryNotifier(ctx context.Context) StreamRetryNotifier {
+	notify, ok := ctx.Value(streamRetryNotifierKey{}).(StreamRetryNotifier)
+	if !ok {
+		return func(StreamRetry) {}
+	}
+
+	return notify
+}
+
+// resumableStream reads a volume stream, reopening it if reading from it
+// fails part of the way through. Workers can't stream a volume from an
+// offset, so the reopened stream is read up to where the interrupted one left
+// off and checked against checksums of each chunk that was already read
+// before carrying on. Only the worker streaming the volume out is resumed;
+// the worker extracting it can't pick up part of the way through an archive.
+type resumableStream struct {
+	ctx    context.Context
+	logger lager.Logger
+	open   func() (io.ReadCloser, error)
+	notify StreamRetryNotifier
+
+	volume string
+	worker string
+
+	current  io.ReadCloser
+	offset   int64
+	attempts int
+
+	checksums [][]byte
+	chunk     hash.Hash
+	chunkLen  int
+}
+
+func openResumableStream(
+	ctx context.Context,
+	logger lager.Logger,
+	volume string,
+	worker string,
+	open func() (io.ReadCloser, error),
+) (io.ReadCloser, error) {
+	current, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &resumableStream{
+		ctx:    ctx,
+		logger: logger,
+		open:   open,
+		notify: streamRetryNotifier(ctx),
+
+		volume: volume,
+		worker: worker,
+
+		current: current,
+		chunk:   sha256.New(),
+	}, nil
+}
+
+func (s *resumableStream) Read(p []byte) (int, error) {
+	for {
+		n, err := s.current.Read(p)
+		s.record(p[:n])
+
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+
+		resumeErr := s.resume(err)
+		if resumeErr != nil {
+			return n, resumeErr
+		}
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (s *resumableStream) Close() error {
+	return s.current.Close()
+}
+
+// record keeps track of how much of the stream has been read, and checksums
+// of each chunk of it.
+func (s *resumableStream) record(read []byte) {
+	s.offset += int64(len(read))
+
+	for len(read) > 0 {
+		n := streamChunkSize - s.chunkLen
+		if n > len(read) {
+			n = len(read)
+		}
+
+		s.chunk.Write(read[:n])
+		s.chunkLen += n
+		read = read[n:]
+
+		if s.chunkLen == streamChunkSize {
+			s.checksums = append(s.checksums, s.chunk.Sum(nil))
+			s.chunk.Reset()
+			s.chunkLen = 0
+		}
+	}
+}
+
+func (s *resumableStream) resume(cause error) error {
+	for {
+		if s.ctx.Err() != nil || s.attempts >= StreamResumeAttempts {
+			return cause
+		}
+
+		s.attempts++
+
+		logger := s.logger.Session("resume-stream", lager.Data{
+			"volume":  s.volume,
+			"offset":  s.offset,
+			"attempt": s.attempts,
+		})
+
+		logger.Info("interrupted", lager.Data{"error": cause.Error()})
+
+		s.notify(StreamRetry{
+			Volume:  s.volume,
+			Worker:  s.worker,
+			Offset:  s.offset,
+			Attempt: s.attempts,
+			Err:     cause,
+		})
+
+		_ = s.current.Close()
+
+		select {
+		case <-time.After(StreamResumeInterval):
+		case <-s.ctx.Done():
+			return cause
+		}
+
+		next, err := s.open()
+		if err != nil {
+			logger.Error("failed-to-reopen", err)
+			cause = err
+			continue
+		}
+
+		s.current = next
+
+		err = s.skip()
+		if err == ErrStreamChanged {
+			logger.Error("failed-to-verify", err)
+			return err
+		}
+
+		if err != nil {
+			logger.Error("failed-to-skip", err)
+			cause = err
+			continue
+		}
+
+		logger.Info("resumed")
+
+		return nil
+	}
+}
+
+// skip reads the reopened stream up to the offset which had been read
+// already, checking it matches what was read before.
+func (s *resumableStream) skip() error {
+	buf := make([]byte, streamChunkSize)
+	chunk := sha256.New()
+
+	for _, checksum := range s.checksums {
+		_, err := io.ReadFull(s.current, buf)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		chunk.Reset()
+		chunk.Write(buf)
+
+		if !bytes.Equal(chunk.Sum(nil), checksum) {
+			return ErrStreamChanged
+		}
+	}
+
+	_, err := io.ReadFull(s.current, buf[:s.chunkLen])
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+
+	chunk.Reset()
+	chunk.Write(buf[:s.chunkLen])
+
+	if !bytes.Equal(chunk.Sum(nil), s.chunk.Sum(nil)) {
+		return ErrStreamChanged
+	}
+
+	return nil
+}
+
+// unexpectedEOF treats a reopened stream ending before the offset it's
+// resuming from as the stream having changed.
+func unexpectedEOF(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrStreamChanged
+	}
+
+	return err
+}
+
+// ParseStreamRange parses the offset out of a "bytes=N-" Range header, which
+// is how a download of a volume stream is resumed from part of the way
+// through. An empty header is an offset of 0.
+func ParseStreamRange(header string) (int64, error) {
+	if header == "" {
+		return 0, nil
+	}
+
+	spec := strings.TrimPrefix(header, "bytes=")
+	if spec == header || !strings.HasSuffix(spec, "-") {
+		return 0, ErrInvalidStreamRange
+	}
+
+	offset, err := strconv.ParseInt(strings.TrimSuffix(spec, "-"), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidStreamRange
+	}
+
+	return offset, nil
+}
+
+// StreamOutFrom streams a volume's contents out from the given offset, for
+// resuming a download of it. Workers can't stream a volume from an offset,
+// so the stream is read up to it and discarded before it's returned, rather
+// than sent again. ErrStreamChanged is returned if the stream ends before the
+// offset.
+func StreamOutFrom(ctx context.Context, volume Volume, path string, offset int64) (io.ReadCloser, error) {
+	out, err := volume.StreamOut(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.CopyN(ioutil.Discard, out, offset)
+	if err != nil {
+		_ = out.Close()
+		return nil, unexpectedEOF(err)
+	}
+
+	return out, nil
+}
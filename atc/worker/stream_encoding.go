@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/DataDog/zstd"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// streamEncoding returns the encoding used for streams to and from the
+// worker's baggageclaim. Workers which don't advertise one use zstd, unless
+// they negotiated a protocol without it.
+func streamEncoding(dbWorker db.Worker) baggageclaim.Encoding {
+	switch dbWorker.StreamEncoding() {
+	case string(baggageclaim.GzipEncoding):
+		return baggageclaim.GzipEncoding
+	case string(baggageclaim.ZstdEncoding):
+		return baggageclaim.ZstdEncoding
+	}
+
+	if lacksCapability(dbWorker, atc.WorkerCapabilityZstdStreaming) {
+		return baggageclaim.GzipEncoding
+	}
+
+	return baggageclaim.ZstdEncoding
+}
+
+// transcode re-compresses a stream from one encoding to another in the
+// background. Errors reading or decompressing the source are passed on to
+// the reader of the returned stream.
+func transcode(src io.Reader, from baggageclaim.Encoding, to baggageclaim.Encoding) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(recompress(pw, src, from, to))
+	}()
+
+	return pr
+}
+
+func recompress(dst io.Writer, src io.Reader, from baggageclaim.Encoding, to baggageclaim.Encoding) error {
+	var decompressed io.ReadCloser
+	if from == baggageclaim.GzipEncoding {
+		gzReader, err := gzip.NewReader(src)
+		if err != nil {
+			return err
+		}
+
+		decompressed = gzReader
+	} else {
+		decompressed = zstd.NewReader(src)
+	}
+
+	defer decompressed.Close()
+
+	var compressed io.WriteCloser
+	if to == baggageclaim.GzipEncoding {
+		compressed = gzip.NewWriter(dst)
+	} else {
+		compressed = zstd.NewWriter(dst)
+	}
+
+	_, err := io.Copy(compressed, decompressed)
+	if err != nil {
+		compressed.Close()
+		return err
+	}
+
+	return compressed.Close()
+}
+
+// encodeStream returns a zstd-compressed stream in the given encoding. A
+// stream out of a volume on a worker using the same encoding is passed
+// through as it came, rather than being transcoded there and back.
+func encodeStream(stream io.Reader, encoding baggageclaim.Encoding) io.ReadCloser {
+	if leased, ok := stream.(*leasedReadCloser); ok {
+		if encoded, ok := leased.ReadCloser.(*encodedStream); ok {
+			if source, ok := encoded.passThrough(encoding); ok {
+				return ioutil.NopCloser(source)
+			}
+		}
+	}
+
+	if encoding == baggageclaim.ZstdEncoding {
+		return ioutil.NopCloser(stream)
+	}
+
+	return transcode(stream, baggageclaim.ZstdEncoding, encoding)
+}
+
+// encodedStream transcodes a stream in another encoding to zstd once it is
+// first read. Until then, its source may be passed through instead.
+type encodedStream struct {
+	source   io.ReadCloser
+	encoding baggageclaim.Encoding
+
+	lock          sync.Mutex
+	transcoded    io.ReadCloser
+	passedThrough bool
+}
+
+func (s *encodedStream) Read(p []byte) (int, error) {
+	s.lock.Lock()
+	if s.passedThrough {
+		s.lock.Unlock()
+		return 0, errors.New("stream has already been passed through")
+	}
+
+	if s.transcoded == nil {
+		s.transcoded = transcode(s.source, s.encoding, baggageclaim.ZstdEncoding)
+	}
+
+	transcoded := s.transcoded
+	s.lock.Unlock()
+
+	return transcoded.Read(p)
+}
+
+// passThrough returns the source stream if it is in the given encoding and
+// hasn't been read from yet.
+func (s *encodedStream) passThrough(encoding baggageclaim.Encoding) (io.Reader, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.encoding != encoding || s.transcoded != nil || s.passedThrough {
+		return nil, false
+	}
+
+	s.passedThrough = true
+
+	return s.source, true
+}
+
+// Close closes both the transcoded stream and the stream it was transcoded
+// from.
+func (s *encodedStream) Close() error {
+	s.lock.Lock()
+	transcoded := s.transcoded
+	s.lock.Unlock()
+
+	var err error
+	if transcoded != nil {
+		err = transcoded.Close()
+	}
+
+	sourceErr := s.source.Close()
+	if err == nil {
+		err = sourceErr
+	}
+
+	return err
+}
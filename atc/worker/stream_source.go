@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"sort"
+)
+
+// StreamSourceStrategy decides which of the workers which have a volume it is
+// streamed from when another worker needs it, e.g. when a resource cache is
+// prewarmed on a newly registered worker.
+type StreamSourceStrategy interface {
+	// Order returns the sources in the order in which streaming to the target
+	// should be attempted.
+	Order(target Worker, sources []Worker) []Worker
+}
+
+// ArbitraryStreamSourceStrategy streams from the sources in whatever order
+// they were found.
+type ArbitraryStreamSourceStrategy struct{}
+
+func NewArbitraryStreamSourceStrategy() StreamSourceStrategy {
+	return ArbitraryStreamSourceStrategy{}
+}
+
+func (ArbitraryStreamSourceStrategy) Order(target Worker, sources []Worker) []Worker {
+	return sources
+}
+
+// NearestStreamSourceStrategy prefers sources in the same zone as the target,
+// as given by the value of each worker's zone label, so that volumes only
+// cross zones when no worker in the target's zone has them. Sources which are
+// equally near are tried starting with the one running the fewest build
+// containers.
+type NearestStreamSourceStrategy struct {
+	zoneLabel string
+}
+
+func NewNearestStreamSourceStrategy(zoneLabel string) StreamSourceStrategy {
+	return NearestStreamSourceStrategy{
+		zoneLabel: zoneLabel,
+	}
+}
+
+func (strategy NearestStreamSourceStrategy) Order(target Worker, sources []Worker) []Worker {
+	targetZone := WorkerZone(target, strategy.zoneLabel)
+
+	nearness := func(source Worker) int {
+		if targetZone != "" && WorkerZone(source, strategy.zoneLabel) == targetZone {
+			return 0
+		}
+
+		return 1
+	}
+
+	ordered := make([]Worker, len(sources))
+	copy(ordered, sources)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ni, nj := nearness(ordered[i]), nearness(ordered[j]); ni != nj {
+			return ni < nj
+		}
+
+		return ordered[i].BuildContainers() < ordered[j].BuildContainers()
+	})
+
+	return ordered
+}
+
+// WorkerZone returns the value of the worker's zone label, or "" if the label
+// isn't set.
+func WorkerZone(worker Worker, zoneLabel string) string {
+	if zoneLabel == "" {
+		return ""
+	}
+
+	return worker.Labels()[zoneLabel]
+}
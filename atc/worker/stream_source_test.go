@@ -0,0 +1,91 @@
+package worker_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamSourceStrategy", func() {
+	var (
+		target *workerfakes.FakeWorker
+
+		farIdle   *workerfakes.FakeWorker
+		nearBusy  *workerfakes.FakeWorker
+		nearIdle  *workerfakes.FakeWorker
+		noZone    *workerfakes.FakeWorker
+		sources   []worker.Worker
+		strategy  worker.StreamSourceStrategy
+		ordered   []worker.Worker
+		zoneLabel string
+	)
+
+	newWorker := func(zone string, buildContainers int) *workerfakes.FakeWorker {
+		w := new(workerfakes.FakeWorker)
+		if zone != "" {
+			w.LabelsReturns(atc.Labels{"zone": zone})
+		}
+		w.BuildContainersReturns(buildContainers)
+		return w
+	}
+
+	BeforeEach(func() {
+		zoneLabel = "zone"
+
+		target = newWorker("us-east-1a", 0)
+
+		farIdle = newWorker("us-east-1b", 0)
+		nearBusy = newWorker("us-east-1a", 10)
+		nearIdle = newWorker("us-east-1a", 1)
+		noZone = newWorker("", 2)
+
+		sources = []worker.Worker{farIdle, nearBusy, noZone, nearIdle}
+	})
+
+	Describe("arbitrary", func() {
+		BeforeEach(func() {
+			strategy = worker.NewArbitraryStreamSourceStrategy()
+		})
+
+		It("keeps the sources in order", func() {
+			Expect(strategy.Order(target, sources)).To(Equal(sources))
+		})
+	})
+
+	Describe("nearest", func() {
+		JustBeforeEach(func() {
+			strategy = worker.NewNearestStreamSourceStrategy(zoneLabel)
+			ordered = strategy.Order(target, sources)
+		})
+
+		It("prefers sources in the target's zone, then the least loaded", func() {
+			Expect(ordered).To(Equal([]worker.Worker{nearIdle, nearBusy, farIdle, noZone}))
+		})
+
+		It("does not reorder the given sources", func() {
+			Expect(sources).To(Equal([]worker.Worker{farIdle, nearBusy, noZone, nearIdle}))
+		})
+
+		Context("when the target has no zone", func() {
+			BeforeEach(func() {
+				target = newWorker("", 0)
+			})
+
+			It("orders the sources by load", func() {
+				Expect(ordered).To(Equal([]worker.Worker{farIdle, nearIdle, noZone, nearBusy}))
+			})
+		})
+
+		Context("when no zone label is configured", func() {
+			BeforeEach(func() {
+				zoneLabel = ""
+			})
+
+			It("orders the sources by load", func() {
+				Expect(ordered).To(Equal([]worker.Worker{farIdle, nearIdle, noZone, nearBusy}))
+			})
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package transport
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+)
 
 type WorkerMissingError struct {
 	WorkerName string
@@ -10,6 +14,10 @@ func (e WorkerMissingError) Error() string {
 	return fmt.Sprintf("worker %s disappeared while trying to reach it", e.WorkerName)
 }
 
+func (e WorkerMissingError) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeWorkerUnavailable
+}
+
 type WorkerUnreachableError struct {
 	WorkerName  string
 	WorkerState string
@@ -18,3 +26,22 @@ type WorkerUnreachableError struct {
 func (e WorkerUnreachableError) Error() string {
 	return fmt.Sprintf("worker '%s' is unreachable (state is '%s')", e.WorkerName, e.WorkerState)
 }
+
+func (e WorkerUnreachableError) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeWorkerUnavailable
+}
+
+// WorkerCircuitOpenError is returned in place of sending a request to a
+// worker whose recent requests have all failed, until its circuit breaker
+// cools down.
+type WorkerCircuitOpenError struct {
+	WorkerName string
+}
+
+func (e WorkerCircuitOpenError) Error() string {
+	return fmt.Sprintf("worker '%s' is not being sent requests after repeated failures", e.WorkerName)
+}
+
+func (e WorkerCircuitOpenError) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeWorkerUnavailable
+}
@@ -0,0 +1,247 @@
+package transport
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+const (
+	ServiceGarden       = "garden"
+	ServiceBaggageclaim = "baggageclaim"
+)
+
+// WorkerConnectionsConfig tunes the connections kept to workers.
+//
+// MaxIdleConns is the number of idle connections kept open to each of a
+// worker's servers; zero opens a new connection for every request.
+// TCPKeepAlive is the interval between keepalive probes on the connections.
+//
+// The circuit breaker is opt-in: once CircuitBreakerThreshold requests in a
+// row to one of a worker's servers fail to get a response, requests to it
+// fail fast until CircuitBreakerCooldown has passed. A threshold of zero, the
+// default, disables it.
+type WorkerConnectionsConfig struct {
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+	TCPKeepAlive    time.Duration
+
+	BaggageclaimResponseHeaderTimeout time.Duration
+
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+}
+
+// WorkerConnections keeps a transport for each of every worker's Garden and
+// Baggageclaim servers, so that all of the clients created for a worker
+// share its pooled connections rather than dialing for each request.
+type WorkerConnections struct {
+	logger lager.Logger
+	clock  clock.Clock
+	config WorkerConnectionsConfig
+
+	transportsL sync.Mutex
+	transports  map[workerService]*workerTransport
+}
+
+type workerTransport struct {
+	http.RoundTripper
+
+	pool *http.Transport
+}
+
+type workerService struct {
+	workerName string
+	service    string
+}
+
+func NewWorkerConnections(logger lager.Logger, clock clock.Clock, config WorkerConnectionsConfig) *WorkerConnections {
+	return &WorkerConnections{
+		logger: logger,
+		clock:  clock,
+		config: config,
+
+		transports: map[workerService]*workerTransport{},
+	}
+}
+
+// Garden returns the transport for the worker's Garden server.
+func (c *WorkerConnections) Garden(workerName string) http.RoundTripper {
+	return c.transport(workerName, ServiceGarden, 0)
+}
+
+// Baggageclaim returns the transport for the worker's Baggageclaim server.
+func (c *WorkerConnections) Baggageclaim(workerName string) http.RoundTripper {
+	return c.transport(workerName, ServiceBaggageclaim, c.config.BaggageclaimResponseHeaderTimeout)
+}
+
+func (c *WorkerConnections) transport(workerName string, service string, responseHeaderTimeout time.Duration) http.RoundTripper {
+	c.transportsL.Lock()
+	defer c.transportsL.Unlock()
+
+	key := workerService{workerName, service}
+
+	transport, found := c.transports[key]
+	if found {
+		return transport
+	}
+
+	pool := &http.Transport{
+		DialContext: (&net.Dialer{
+			KeepAlive: c.config.TCPKeepAlive,
+		}).DialContext,
+		DisableKeepAlives:     c.config.MaxIdleConns == 0,
+		MaxIdleConnsPerHost:   c.config.MaxIdleConns,
+		IdleConnTimeout:       c.config.IdleConnTimeout,
+		ResponseHeaderTimeout: responseHeaderTimeout,
+	}
+
+	var roundTripper http.RoundTripper = &timedRoundTripper{
+		logger:     c.logger,
+		clock:      c.clock,
+		workerName: workerName,
+		service:    service,
+		inner:      pool,
+	}
+
+	if c.config.CircuitBreakerThreshold > 0 {
+		roundTripper = &circuitBreaker{
+			clock:      c.clock,
+			workerName: workerName,
+			threshold:  c.config.CircuitBreakerThreshold,
+			cooldown:   c.config.CircuitBreakerCooldown,
+			inner:      roundTripper,
+		}
+	}
+
+	transport = &workerTransport{
+		RoundTripper: roundTripper,
+		pool:         pool,
+	}
+
+	c.transports[key] = transport
+
+	return transport
+}
+
+// Retain drops the transports of workers other than the given ones, closing
+// their idle connections, so that workers which have been pruned or retired
+// don't keep connections open or hold on to their circuit breakers if they
+// register again.
+func (c *WorkerConnections) Retain(workerNames []string) {
+	c.transportsL.Lock()
+	defer c.transportsL.Unlock()
+
+	retained := map[string]bool{}
+	for _, name := range workerNames {
+		retained[name] = true
+	}
+
+	for key, transport := range c.transports {
+		if retained[key.workerName] {
+			continue
+		}
+
+		transport.pool.CloseIdleConnections()
+		delete(c.transports, key)
+
+		c.logger.Debug("dropped-worker-transport", lager.Data{
+			"worker":  key.workerName,
+			"service": key.service,
+		})
+	}
+}
+
+// timedRoundTripper emits how long each request to a worker takes to get a
+// response.
+type timedRoundTripper struct {
+	logger     lager.Logger
+	clock      clock.Clock
+	workerName string
+	service    string
+	inner      http.RoundTripper
+}
+
+func (t *timedRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := t.clock.Now()
+
+	response, err := t.inner.RoundTrip(request)
+
+	metric.WorkerRequestDuration{
+		WorkerName: t.workerName,
+		Service:    t.service,
+		Method:     request.Method,
+		Duration:   t.clock.Since(start),
+		Success:    err == nil,
+	}.Emit(t.logger)
+
+	return response, err
+}
+
+// circuitBreaker fails requests to a worker fast once enough of them have
+// failed in a row, until the cooldown has passed. After the cooldown a
+// single request is let through to try the worker again; if it fails too,
+// the breaker opens for another cooldown.
+type circuitBreaker struct {
+	clock      clock.Clock
+	workerName string
+	threshold  int
+	cooldown   time.Duration
+	inner      http.RoundTripper
+
+	lock     sync.Mutex
+	failures int
+	openedAt time.Time
+	trying   bool
+}
+
+func (b *circuitBreaker) RoundTrip(request *http.Request) (*http.Response, error) {
+	trial, err := b.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := b.inner.RoundTrip(request)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if trial {
+		b.trying = false
+	}
+
+	if err == nil {
+		b.failures = 0
+	} else if request.Context().Err() == nil {
+		// requests which were canceled or timed out by the caller say
+		// nothing about the worker
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openedAt = b.clock.Now()
+		}
+	}
+
+	return response, err
+}
+
+func (b *circuitBreaker) allow() (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.failures < b.threshold {
+		return false, nil
+	}
+
+	if b.trying || b.clock.Since(b.openedAt) < b.cooldown {
+		return false, WorkerCircuitOpenError{WorkerName: b.workerName}
+	}
+
+	b.trying = true
+
+	return true, nil
+}
@@ -0,0 +1,252 @@
+package transport_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+	"github.com/concourse/concourse/atc/worker/transport"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerConnections", func() {
+	var (
+		logger      *lagertest.TestLogger
+		fakeClock   *fakeclock.FakeClock
+		config      transport.WorkerConnectionsConfig
+		connections *transport.WorkerConnections
+
+		server         *httptest.Server
+		newConnections int32
+		failing        int32
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		config = transport.WorkerConnectionsConfig{
+			MaxIdleConns:    2,
+			IdleConnTimeout: time.Minute,
+			TCPKeepAlive:    30 * time.Second,
+		}
+
+		atomic.StoreInt32(&newConnections, 0)
+		atomic.StoreInt32(&failing, 0)
+
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.LoadInt32(&failing) == 1 {
+				// close the connection without a response, failing the request
+				conn, _, err := w.(http.Hijacker).Hijack()
+				Expect(err).NotTo(HaveOccurred())
+				conn.Close()
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&newConnections, 1)
+			}
+		}
+		server.Start()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		connections = transport.NewWorkerConnections(logger, fakeClock, config)
+	})
+
+	get := func(roundTripper http.RoundTripper) error {
+		request, err := http.NewRequest("GET", server.URL, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := roundTripper.RoundTrip(request)
+		if err != nil {
+			return err
+		}
+
+		_, _ = ioutil.ReadAll(response.Body)
+		return response.Body.Close()
+	}
+
+	It("returns the same transport for each of a worker's servers", func() {
+		Expect(connections.Garden("some-worker")).To(BeIdenticalTo(connections.Garden("some-worker")))
+		Expect(connections.Baggageclaim("some-worker")).To(BeIdenticalTo(connections.Baggageclaim("some-worker")))
+
+		Expect(connections.Garden("some-worker")).NotTo(BeIdenticalTo(connections.Baggageclaim("some-worker")))
+		Expect(connections.Garden("some-worker")).NotTo(BeIdenticalTo(connections.Garden("some-other-worker")))
+	})
+
+	It("reuses connections across the transports returned for a worker", func() {
+		Expect(get(connections.Garden("some-worker"))).To(Succeed())
+		Expect(get(connections.Garden("some-worker"))).To(Succeed())
+
+		Expect(atomic.LoadInt32(&newConnections)).To(Equal(int32(1)))
+	})
+
+	Describe("Retain", func() {
+		var gardenTransport http.RoundTripper
+
+		JustBeforeEach(func() {
+			gardenTransport = connections.Garden("some-worker")
+			Expect(get(gardenTransport)).To(Succeed())
+		})
+
+		Context("when the worker is retained", func() {
+			JustBeforeEach(func() {
+				connections.Retain([]string{"some-worker", "some-other-worker"})
+			})
+
+			It("keeps its transport and connections", func() {
+				Expect(connections.Garden("some-worker")).To(BeIdenticalTo(gardenTransport))
+
+				Expect(get(connections.Garden("some-worker"))).To(Succeed())
+				Expect(atomic.LoadInt32(&newConnections)).To(Equal(int32(1)))
+			})
+		})
+
+		Context("when the worker is gone", func() {
+			JustBeforeEach(func() {
+				connections.Retain([]string{"some-other-worker"})
+			})
+
+			It("drops its transport", func() {
+				Expect(connections.Garden("some-worker")).NotTo(BeIdenticalTo(gardenTransport))
+			})
+
+			It("closes its idle connections", func() {
+				Expect(get(gardenTransport)).To(Succeed())
+				Expect(atomic.LoadInt32(&newConnections)).To(Equal(int32(2)))
+			})
+		})
+	})
+
+	Context("when no idle connections are kept", func() {
+		BeforeEach(func() {
+			config.MaxIdleConns = 0
+		})
+
+		It("opens a connection for every request", func() {
+			Expect(get(connections.Garden("some-worker"))).To(Succeed())
+			Expect(get(connections.Garden("some-worker"))).To(Succeed())
+
+			Expect(atomic.LoadInt32(&newConnections)).To(Equal(int32(2)))
+		})
+	})
+
+	Context("when a metric emitter is configured", func() {
+		var emitter *metricfakes.FakeEmitter
+
+		BeforeEach(func() {
+			emitterFactory := new(metricfakes.FakeEmitterFactory)
+			emitter = new(metricfakes.FakeEmitter)
+
+			metric.RegisterEmitter(emitterFactory)
+			emitterFactory.IsConfiguredReturns(true)
+			emitterFactory.NewEmitterReturns(emitter, nil)
+
+			metric.Initialize(lager.NewLogger("dont-care"), "test", map[string]string{}, 1000, nil)
+		})
+
+		It("emits the duration of each request", func() {
+			Expect(get(connections.Baggageclaim("some-worker"))).To(Succeed())
+
+			Eventually(emitter.EmitCallCount).Should(Equal(1))
+
+			_, event := emitter.EmitArgsForCall(0)
+			Expect(event.Name).To(Equal("worker request duration"))
+			Expect(event.Attributes).To(Equal(map[string]string{
+				"worker":  "some-worker",
+				"service": "baggageclaim",
+				"method":  "GET",
+			}))
+		})
+	})
+
+	Context("when the circuit breaker is enabled", func() {
+		var worker http.RoundTripper
+
+		BeforeEach(func() {
+			config.CircuitBreakerThreshold = 2
+			config.CircuitBreakerCooldown = 10 * time.Second
+		})
+
+		JustBeforeEach(func() {
+			worker = connections.Garden("some-worker")
+		})
+
+		Context("when requests in a row fail to reach the threshold", func() {
+			JustBeforeEach(func() {
+				atomic.StoreInt32(&failing, 1)
+
+				Expect(get(worker)).NotTo(Succeed())
+				Expect(get(worker)).NotTo(Succeed())
+			})
+
+			It("fails requests without sending them until the cooldown passes", func() {
+				connectionsBefore := atomic.LoadInt32(&newConnections)
+
+				Expect(get(worker)).To(Equal(transport.WorkerCircuitOpenError{WorkerName: "some-worker"}))
+				Expect(atomic.LoadInt32(&newConnections)).To(Equal(connectionsBefore))
+			})
+
+			It("does not affect the worker's other servers", func() {
+				atomic.StoreInt32(&failing, 0)
+
+				Expect(get(connections.Baggageclaim("some-worker"))).To(Succeed())
+			})
+
+			Context("when the cooldown passes", func() {
+				JustBeforeEach(func() {
+					fakeClock.Increment(10 * time.Second)
+				})
+
+				It("lets a request through and closes again if it succeeds", func() {
+					atomic.StoreInt32(&failing, 0)
+
+					Expect(get(worker)).To(Succeed())
+					Expect(get(worker)).To(Succeed())
+				})
+
+				It("opens for another cooldown if the request fails", func() {
+					Expect(get(worker)).NotTo(Succeed())
+
+					atomic.StoreInt32(&failing, 0)
+					Expect(get(worker)).To(Equal(transport.WorkerCircuitOpenError{WorkerName: "some-worker"}))
+
+					fakeClock.Increment(10 * time.Second)
+					Expect(get(worker)).To(Succeed())
+				})
+			})
+		})
+
+		Context("when a request succeeds before reaching the threshold", func() {
+			It("starts counting failures again", func() {
+				atomic.StoreInt32(&failing, 1)
+				Expect(get(worker)).NotTo(Succeed())
+
+				atomic.StoreInt32(&failing, 0)
+				Expect(get(worker)).To(Succeed())
+
+				atomic.StoreInt32(&failing, 1)
+				Expect(get(worker)).NotTo(Succeed())
+
+				atomic.StoreInt32(&failing, 0)
+				Expect(get(worker)).To(Succeed())
+			})
+		})
+	})
+})
@@ -3,12 +3,23 @@ package worker
 import (
 	"context"
 	"io"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/db"
 )
 
+// VolumeLeaseTTL is how long a lease on a volume lasts if the ATC holding
+// it goes away. Leases are renewed every half TTL while they're held.
+var VolumeLeaseTTL = 5 * time.Minute
+
+// ResourceCacheStoreTimeout bounds how long storing an initialized resource
+// cache in the resource cache store may take before it is given up on.
+var ResourceCacheStoreTimeout = 30 * time.Minute
+
 //go:generate counterfeiter . Volume
 
 type Volume interface {
@@ -25,12 +36,14 @@ type Volume interface {
 
 	COWStrategy() baggageclaim.COWStrategy
 
-	InitializeResourceCache(db.UsedResourceCache) error
-	InitializeTaskCache(logger lager.Logger, jobID int, stepName string, path string, privileged bool) error
+	InitializeResourceCache(lager.Logger, db.UsedResourceCache) error
+	InitializeTaskCache(ctx context.Context, logger lager.Logger, jobID int, stepName string, path string, privileged bool) error
 	InitializeArtifact(name string, buildID int) (db.WorkerArtifact, error)
 
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
 
+	Lease(lager.Logger) (release func(), leased bool, err error)
+
 	WorkerName() string
 	Destroy() error
 }
@@ -84,12 +97,105 @@ func (v *volume) SetPrivileged(privileged bool) error {
 	return v.bcVolume.SetPrivileged(privileged)
 }
 
+// StreamIn extracts a zstd-compressed tar stream into the volume. The stream
+// is transcoded if the worker streams volumes in a different encoding, unless
+// it was streamed out of a volume in that encoding to begin with.
 func (v *volume) StreamIn(ctx context.Context, path string, tarStream io.Reader) error {
-	return v.bcVolume.StreamIn(ctx, path, baggageclaim.ZstdEncoding, tarStream)
+	encoding := v.volumeClient.StreamEncoding()
+
+	encoded := encodeStream(tarStream, encoding)
+	defer encoded.Close()
+
+	return v.bcVolume.StreamIn(ctx, path, encoding, encoded)
 }
 
+// StreamOut returns a zstd-compressed tar stream of the volume's contents,
+// transcoded if the worker streams volumes in a different encoding. The
+// volume is leased until the returned stream is closed, so that it isn't
+// destroyed out from under the stream. If the stream is interrupted, it is
+// resumed from where it left off.
 func (v *volume) StreamOut(ctx context.Context, path string) (io.ReadCloser, error) {
-	return v.bcVolume.StreamOut(ctx, path, baggageclaim.ZstdEncoding)
+	logger := lagerctx.FromContext(ctx)
+
+	release, leased, err := v.Lease(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if !leased {
+		return nil, ErrCreatedVolumeNotFound{Handle: v.Handle(), WorkerName: v.WorkerName()}
+	}
+
+	encoding := v.volumeClient.StreamEncoding()
+
+	out, err := openResumableStream(ctx, logger, v.Handle(), v.WorkerName(), func() (io.ReadCloser, error) {
+		return v.bcVolume.StreamOut(ctx, path, encoding)
+	})
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	if encoding != baggageclaim.ZstdEncoding {
+		out = &encodedStream{
+			source:   out,
+			encoding: encoding,
+		}
+	}
+
+	return &leasedReadCloser{ReadCloser: out, release: release}, nil
+}
+
+// Lease keeps the volume from being garbage collected until release is
+// called, renewing the lease until then. A volume which is already being
+// destroyed is not leased.
+func (v *volume) Lease(logger lager.Logger) (func(), bool, error) {
+	lease, leased, err := v.dbVolume.Lease(VolumeLeaseTTL)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !leased {
+		return nil, false, nil
+	}
+
+	logger = logger.Session("lease", lager.Data{"volume": v.Handle()})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(VolumeLeaseTTL / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				renewed, err := lease.Renew(VolumeLeaseTTL)
+				if err != nil {
+					logger.Error("failed-to-renew", err)
+					continue
+				}
+
+				if !renewed {
+					logger.Info("lapsed")
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+
+			err := lease.Release()
+			if err != nil {
+				logger.Error("failed-to-release", err)
+			}
+		})
+	}, true, nil
 }
 
 func (v *volume) Properties() (baggageclaim.VolumeProperties, error) {
@@ -110,8 +216,26 @@ func (v *volume) COWStrategy() baggageclaim.COWStrategy {
 	}
 }
 
-func (v *volume) InitializeResourceCache(urc db.UsedResourceCache) error {
-	return v.dbVolume.InitializeResourceCache(urc)
+func (v *volume) InitializeResourceCache(logger lager.Logger, urc db.UsedResourceCache) error {
+	err := v.dbVolume.InitializeResourceCache(urc)
+	if err != nil {
+		return err
+	}
+
+	// storing streams the whole volume through the ATC, so it happens in the
+	// background rather than holding up the step that fetched the cache.
+	// failing to store it only costs a future fetch.
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ResourceCacheStoreTimeout)
+		defer cancel()
+
+		err := v.volumeClient.StoreVolumeForResourceCache(ctx, logger, v, urc)
+		if err != nil {
+			logger.Error("failed-to-store-resource-cache", err)
+		}
+	}()
+
+	return nil
 }
 
 func (v *volume) InitializeArtifact(name string, buildID int) (db.WorkerArtifact, error) {
@@ -119,6 +243,7 @@ func (v *volume) InitializeArtifact(name string, buildID int) (db.WorkerArtifact
 }
 
 func (v *volume) InitializeTaskCache(
+	ctx context.Context,
 	logger lager.Logger,
 	jobID int,
 	stepName string,
@@ -134,6 +259,7 @@ func (v *volume) InitializeTaskCache(
 	// always create, if there are any existing task cache volumes they will be gced
 	// after initialization of the current one
 	importVolume, err := v.volumeClient.CreateVolumeForTaskCache(
+		ctx,
 		logger,
 		VolumeSpec{
 			Strategy:   baggageclaim.ImportStrategy{Path: v.bcVolume.Path()},
@@ -148,9 +274,19 @@ func (v *volume) InitializeTaskCache(
 		return err
 	}
 
-	return importVolume.InitializeTaskCache(logger, jobID, stepName, path, privileged)
+	return importVolume.InitializeTaskCache(ctx, logger, jobID, stepName, path, privileged)
 }
 
 func (v *volume) CreateChildForContainer(creatingContainer db.CreatingContainer, mountPath string) (db.CreatingVolume, error) {
 	return v.dbVolume.CreateChildForContainer(creatingContainer, mountPath)
 }
+
+type leasedReadCloser struct {
+	io.ReadCloser
+	release func()
+}
+
+func (rc *leasedReadCloser) Close() error {
+	defer rc.release()
+	return rc.ReadCloser.Close()
+}
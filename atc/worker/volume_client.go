@@ -1,13 +1,19 @@
 package worker
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/metric"
@@ -19,6 +25,7 @@ const creatingVolumeRetryDelay = 1 * time.Second
 
 type VolumeClient interface {
 	FindOrCreateVolumeForContainer(
+		context.Context,
 		lager.Logger,
 		VolumeSpec,
 		db.CreatingContainer,
@@ -26,6 +33,7 @@ type VolumeClient interface {
 		string,
 	) (Volume, error)
 	FindOrCreateCOWVolumeForContainer(
+		context.Context,
 		lager.Logger,
 		VolumeSpec,
 		db.CreatingContainer,
@@ -34,12 +42,14 @@ type VolumeClient interface {
 		string,
 	) (Volume, error)
 	FindOrCreateVolumeForBaseResourceType(
+		context.Context,
 		lager.Logger,
 		VolumeSpec,
 		int,
 		string,
 	) (Volume, error)
 	CreateVolume(
+		context.Context,
 		lager.Logger,
 		VolumeSpec,
 		int,
@@ -50,6 +60,39 @@ type VolumeClient interface {
 		lager.Logger,
 		db.UsedResourceCache,
 	) (Volume, bool, error)
+	HydrateVolumeForResourceCache(
+		context.Context,
+		lager.Logger,
+		db.UsedResourceCache,
+	) (Volume, bool, error)
+	StoreVolumeForResourceCache(
+		context.Context,
+		lager.Logger,
+		Volume,
+		db.UsedResourceCache,
+	) error
+	CreateVolumeForResourceCache(
+		context.Context,
+		lager.Logger,
+		db.UsedResourceCache,
+		io.Reader,
+	) (Volume, bool, error)
+	FindVolumeForImageLayer(
+		logger lager.Logger,
+		digest string,
+	) (Volume, bool, error)
+	CreateVolumeForImageLayer(
+		ctx context.Context,
+		logger lager.Logger,
+		digest string,
+		layer io.Reader,
+	) (Volume, bool, error)
+	CreateVolumeForUnpackedImage(
+		ctx context.Context,
+		logger lager.Logger,
+		digest string,
+		image io.Reader,
+	) (Volume, bool, error)
 	FindVolumeForTaskCache(
 		logger lager.Logger,
 		teamID int,
@@ -58,6 +101,7 @@ type VolumeClient interface {
 		path string,
 	) (Volume, bool, error)
 	CreateVolumeForTaskCache(
+		ctx context.Context,
 		logger lager.Logger,
 		volumeSpec VolumeSpec,
 		teamID int,
@@ -66,10 +110,13 @@ type VolumeClient interface {
 		path string,
 	) (Volume, error)
 	FindOrCreateVolumeForResourceCerts(
+		ctx context.Context,
 		logger lager.Logger,
 	) (volume Volume, found bool, err error)
 
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
+
+	StreamEncoding() baggageclaim.Encoding
 }
 
 type VolumeSpec struct {
@@ -98,8 +145,27 @@ func (e ErrCreatedVolumeNotFound) Error() string {
 	return fmt.Sprintf("volume '%s' disappeared from worker '%s'", e.Handle, e.WorkerName)
 }
 
+func (e ErrCreatedVolumeNotFound) ErrorCode() atc.ErrorCode {
+	return atc.ErrorCodeVolumeMissing
+}
+
 var ErrBaseResourceTypeNotFound = errors.New("base resource type not found")
 
+// ErrUnsupportedLayerDigest is returned when caching an image layer whose
+// digest does not use the sha256 algorithm.
+var ErrUnsupportedLayerDigest = errors.New("unsupported image layer digest")
+
+// LayerIntegrityError is returned when the contents of an image layer do not
+// match its digest.
+type LayerIntegrityError struct {
+	Expected string
+	Actual   string
+}
+
+func (err LayerIntegrityError) Error() string {
+	return fmt.Sprintf("image layer integrity check failed: expected digest %s, got %s", err.Expected, err.Actual)
+}
+
 type volumeClient struct {
 	baggageclaimClient              baggageclaim.Client
 	lockFactory                     lock.LockFactory
@@ -109,6 +175,8 @@ type volumeClient struct {
 	dbWorkerTaskCacheFactory        db.WorkerTaskCacheFactory
 	clock                           clock.Clock
 	dbWorker                        db.Worker
+	resourceCacheStore              ResourceCacheStore
+	creationLimiter                 CreationLimiter
 }
 
 func NewVolumeClient(
@@ -121,6 +189,8 @@ func NewVolumeClient(
 	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory,
 	dbTaskCacheFactory db.TaskCacheFactory,
 	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory,
+	resourceCacheStore ResourceCacheStore,
+	creationLimiter CreationLimiter,
 ) VolumeClient {
 	return &volumeClient{
 		baggageclaimClient:              baggageclaimClient,
@@ -131,10 +201,13 @@ func NewVolumeClient(
 		dbWorkerTaskCacheFactory:        dbWorkerTaskCacheFactory,
 		clock:                           clock,
 		dbWorker:                        dbWorker,
+		resourceCacheStore:              resourceCacheStore,
+		creationLimiter:                 creationLimiter,
 	}
 }
 
 func (c *volumeClient) FindOrCreateVolumeForContainer(
+	ctx context.Context,
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
 	container db.CreatingContainer,
@@ -142,6 +215,7 @@ func (c *volumeClient) FindOrCreateVolumeForContainer(
 	mountPath string,
 ) (Volume, error) {
 	return c.findOrCreateVolume(
+		ctx,
 		logger.Session("find-or-create-volume-for-container"),
 		volumeSpec,
 		func() (db.CreatingVolume, db.CreatedVolume, error) {
@@ -154,6 +228,7 @@ func (c *volumeClient) FindOrCreateVolumeForContainer(
 }
 
 func (c *volumeClient) FindOrCreateCOWVolumeForContainer(
+	ctx context.Context,
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
 	container db.CreatingContainer,
@@ -162,6 +237,7 @@ func (c *volumeClient) FindOrCreateCOWVolumeForContainer(
 	mountPath string,
 ) (Volume, error) {
 	return c.findOrCreateVolume(
+		ctx,
 		logger.Session("find-or-create-cow-volume-for-container"),
 		volumeSpec,
 		func() (db.CreatingVolume, db.CreatedVolume, error) {
@@ -174,6 +250,7 @@ func (c *volumeClient) FindOrCreateCOWVolumeForContainer(
 }
 
 func (c *volumeClient) CreateVolume(
+	ctx context.Context,
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
 	teamID int,
@@ -181,6 +258,7 @@ func (c *volumeClient) CreateVolume(
 	volumeType db.VolumeType,
 ) (Volume, error) {
 	return c.findOrCreateVolume(
+		ctx,
 		logger.Session("find-or-create-volume-for-artifact"),
 		volumeSpec,
 		func() (db.CreatingVolume, db.CreatedVolume, error) {
@@ -193,6 +271,7 @@ func (c *volumeClient) CreateVolume(
 }
 
 func (c *volumeClient) FindOrCreateVolumeForBaseResourceType(
+	ctx context.Context,
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
 	teamID int,
@@ -209,6 +288,7 @@ func (c *volumeClient) FindOrCreateVolumeForBaseResourceType(
 	}
 
 	return c.findOrCreateVolume(
+		ctx,
 		logger.Session("find-or-create-volume-for-base-resource-type"),
 		volumeSpec,
 		func() (db.CreatingVolume, db.CreatedVolume, error) {
@@ -247,7 +327,332 @@ func (c *volumeClient) FindVolumeForResourceCache(
 	return NewVolume(bcVolume, dbVolume, c), true, nil
 }
 
+// StoreVolumeForResourceCache saves the contents of an initialized resource
+// cache volume to the resource cache store, if one is configured and it does
+// not already have the cache.
+func (c *volumeClient) StoreVolumeForResourceCache(
+	ctx context.Context,
+	logger lager.Logger,
+	volume Volume,
+	usedResourceCache db.UsedResourceCache,
+) error {
+	if c.resourceCacheStore == nil {
+		return nil
+	}
+
+	logger = logger.Session("store-volume-for-resource-cache", lager.Data{
+		"volume":         volume.Handle(),
+		"resource-cache": usedResourceCache.ID(),
+	})
+
+	stored, err := c.resourceCacheStore.Contains(logger, usedResourceCache)
+	if err != nil {
+		return err
+	}
+
+	if stored {
+		logger.Debug("already-stored")
+		return nil
+	}
+
+	tarStream, err := volume.StreamOut(ctx, ".")
+	if err != nil {
+		logger.Error("failed-to-stream-out-volume", err)
+		return err
+	}
+
+	defer tarStream.Close()
+
+	err = c.resourceCacheStore.Put(logger, usedResourceCache, tarStream)
+	if err != nil {
+		logger.Error("failed-to-store-resource-cache", err)
+		return err
+	}
+
+	logger.Debug("stored")
+
+	return nil
+}
+
+// HydrateVolumeForResourceCache creates a volume for the resource cache on
+// this worker from the resource cache store. It downloads the whole cache, so
+// it is left to callers which would otherwise fetch it, rather than being done
+// when looking the volume up.
+func (c *volumeClient) HydrateVolumeForResourceCache(
+	ctx context.Context,
+	logger lager.Logger,
+	usedResourceCache db.UsedResourceCache,
+) (Volume, bool, error) {
+	if c.resourceCacheStore == nil {
+		return nil, false, nil
+	}
+
+	logger = logger.Session("hydrate-volume-for-resource-cache", lager.Data{
+		"resource-cache": usedResourceCache.ID(),
+	})
+
+	tarStream, found, err := c.resourceCacheStore.Get(logger, usedResourceCache)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	defer tarStream.Close()
+
+	return c.CreateVolumeForResourceCache(ctx, logger, usedResourceCache, tarStream)
+}
+
+// CreateVolumeForResourceCache creates a volume for the resource cache on
+// this worker from a zstd-compressed tar stream of its contents. If the
+// volume is already being created elsewhere, found is false.
+func (c *volumeClient) CreateVolumeForResourceCache(
+	ctx context.Context,
+	logger lager.Logger,
+	usedResourceCache db.UsedResourceCache,
+	tarStream io.Reader,
+) (Volume, bool, error) {
+	encoding := c.StreamEncoding()
+
+	encoded := encodeStream(tarStream, encoding)
+	defer encoded.Close()
+
+	tarStream = encoded
+
+	volume, err := c.createVolumeFromStream(
+		ctx,
+		logger.Session("create-volume-for-resource-cache"),
+		func() (db.CreatingVolume, error) {
+			return c.dbVolumeRepository.CreateResourceCacheVolume(c.dbWorker.Name(), usedResourceCache)
+		},
+		encoding,
+		tarStream,
+		nil,
+	)
+	if err != nil {
+		if err == db.ErrResourceCacheVolumeExists {
+			logger.Debug("resource-cache-volume-being-created-elsewhere")
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return volume, true, nil
+}
+
+func (c *volumeClient) FindVolumeForImageLayer(
+	logger lager.Logger,
+	digest string,
+) (Volume, bool, error) {
+	dbVolume, found, err := c.dbVolumeRepository.FindImageLayerVolume(c.dbWorker.Name(), digest)
+	if err != nil {
+		logger.Error("failed-to-lookup-image-layer-volume-in-db", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	bcVolume, found, err := c.baggageclaimClient.LookupVolume(logger, dbVolume.Handle())
+	if err != nil {
+		logger.Error("failed-to-lookup-volume-in-bc", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	return NewVolume(bcVolume, dbVolume, c), true, nil
+}
+
+// CreateVolumeForImageLayer caches an image layer on this worker, extracting
+// its gzip-compressed tar blob into a new volume. The blob is verified
+// against its sha256 digest before the volume can be found. If the layer is
+// already being cached elsewhere, found is false.
+func (c *volumeClient) CreateVolumeForImageLayer(
+	ctx context.Context,
+	logger lager.Logger,
+	digest string,
+	layer io.Reader,
+) (Volume, bool, error) {
+	logger = logger.Session("create-volume-for-image-layer", lager.Data{
+		"digest": digest,
+	})
+
+	if !strings.HasPrefix(digest, "sha256:") {
+		return nil, false, ErrUnsupportedLayerDigest
+	}
+
+	hash := sha256.New()
+
+	volume, err := c.createVolumeFromStream(
+		ctx,
+		logger,
+		func() (db.CreatingVolume, error) {
+			return c.dbVolumeRepository.CreateImageLayerVolume(c.dbWorker.Name(), digest)
+		},
+		baggageclaim.GzipEncoding,
+		io.TeeReader(layer, hash),
+		func() error {
+			actual := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+			if actual != digest {
+				return LayerIntegrityError{
+					Expected: digest,
+					Actual:   actual,
+				}
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		if err == db.ErrImageLayerVolumeExists {
+			logger.Debug("image-layer-volume-being-created-elsewhere")
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return volume, true, nil
+}
+
+// CreateVolumeForUnpackedImage caches an image unpacked from the OCI image
+// layout on this worker, from a zstd-compressed tar stream of its rootfs and
+// metadata. It is cached like an image layer, keyed by the digest of the
+// image's manifest, and is found with FindVolumeForImageLayer. If the image
+// is already being unpacked elsewhere, found is false.
+func (c *volumeClient) CreateVolumeForUnpackedImage(
+	ctx context.Context,
+	logger lager.Logger,
+	digest string,
+	image io.Reader,
+) (Volume, bool, error) {
+	logger = logger.Session("create-volume-for-unpacked-image", lager.Data{
+		"digest": digest,
+	})
+
+	encoding := c.StreamEncoding()
+
+	encoded := encodeStream(image, encoding)
+	defer encoded.Close()
+
+	image = encoded
+
+	volume, err := c.createVolumeFromStream(
+		ctx,
+		logger,
+		func() (db.CreatingVolume, error) {
+			return c.dbVolumeRepository.CreateImageLayerVolume(c.dbWorker.Name(), digest)
+		},
+		encoding,
+		image,
+		nil,
+	)
+	if err != nil {
+		if err == db.ErrImageLayerVolumeExists {
+			logger.Debug("unpacked-image-volume-being-created-elsewhere")
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return volume, true, nil
+}
+
+// createVolumeFromStream creates a volume outside of any container from a tar
+// stream. The volume stays in the 'creating' state until its contents have
+// been streamed in and verified, so it is never found or used while
+// partially populated.
+func (c *volumeClient) createVolumeFromStream(
+	ctx context.Context,
+	logger lager.Logger,
+	createVolume func() (db.CreatingVolume, error),
+	encoding baggageclaim.Encoding,
+	tarStream io.Reader,
+	verify func() error,
+) (Volume, error) {
+	creatingVolume, err := createVolume()
+	if err != nil {
+		logger.Error("failed-to-create-volume-in-db", err)
+		return nil, err
+	}
+
+	logger = logger.WithData(lager.Data{"volume": creatingVolume.Handle()})
+
+	// the slot is held while streaming in, as that's the bulk of the work
+	release, err := acquireCreationSlot(ctx, logger, c.creationLimiter, c.dbWorker.Name())
+	if err != nil {
+		c.markVolumeFailed(logger, creatingVolume)
+		return nil, err
+	}
+
+	defer release()
+
+	bcVolume, err := c.baggageclaimClient.CreateVolume(
+		logger.Session("create-volume"),
+		creatingVolume.Handle(),
+		VolumeSpec{Strategy: baggageclaim.EmptyStrategy{}}.baggageclaimVolumeSpec(),
+	)
+	if err != nil {
+		logger.Error("failed-to-create-volume-in-baggageclaim", err)
+		c.markVolumeFailed(logger, creatingVolume)
+		return nil, err
+	}
+
+	metric.VolumesCreated.Inc()
+
+	err = bcVolume.StreamIn(ctx, ".", encoding, tarStream)
+	if err == nil && verify != nil {
+		err = verify()
+	}
+
+	if err != nil {
+		logger.Error("failed-to-stream-in-volume", err)
+
+		destroyErr := bcVolume.Destroy()
+		if destroyErr != nil {
+			logger.Error("failed-to-destroy-partially-created-volume", destroyErr)
+		}
+
+		c.markVolumeFailed(logger, creatingVolume)
+		return nil, err
+	}
+
+	createdVolume, err := creatingVolume.Created()
+	if err != nil {
+		logger.Error("failed-to-initialize-volume", err)
+		return nil, err
+	}
+
+	logger.Debug("created")
+
+	return NewVolume(bcVolume, createdVolume, c), nil
+}
+
+// StreamEncoding is the encoding which the worker's baggageclaim streams
+// volumes in, as advertised by the worker when it registered.
+func (c *volumeClient) StreamEncoding() baggageclaim.Encoding {
+	return streamEncoding(c.dbWorker)
+}
+
+func (c *volumeClient) markVolumeFailed(logger lager.Logger, creatingVolume db.CreatingVolume) {
+	_, err := creatingVolume.Failed()
+	if err != nil {
+		logger.Error("failed-to-mark-volume-as-failed", err)
+	}
+
+	metric.FailedVolumes.Inc()
+}
+
 func (c *volumeClient) CreateVolumeForTaskCache(
+	ctx context.Context,
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
 	teamID int,
@@ -269,6 +674,7 @@ func (c *volumeClient) CreateVolumeForTaskCache(
 	usedWorkerTaskCache, err := c.dbWorkerTaskCacheFactory.FindOrCreate(workerTaskCache)
 
 	return c.findOrCreateVolume(
+		ctx,
 		logger.Session("find-or-create-volume-for-container"),
 		volumeSpec,
 		func() (db.CreatingVolume, db.CreatedVolume, error) {
@@ -280,7 +686,7 @@ func (c *volumeClient) CreateVolumeForTaskCache(
 	)
 }
 
-func (c *volumeClient) FindOrCreateVolumeForResourceCerts(logger lager.Logger) (Volume, bool, error) {
+func (c *volumeClient) FindOrCreateVolumeForResourceCerts(ctx context.Context, logger lager.Logger) (Volume, bool, error) {
 
 	logger.Debug("finding-worker-resource-certs")
 	usedResourceCerts, found, err := c.dbWorker.ResourceCerts()
@@ -301,6 +707,7 @@ func (c *volumeClient) FindOrCreateVolumeForResourceCerts(logger lager.Logger) (
 	}
 
 	volume, err := c.findOrCreateVolume(
+		ctx,
 		logger.Session("find-or-create-volume-for-resource-certs"),
 		VolumeSpec{
 			Strategy: baggageclaim.ImportStrategy{
@@ -384,6 +791,7 @@ func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume,
 }
 
 func (c *volumeClient) findOrCreateVolume(
+	ctx context.Context,
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
 	findVolumeFunc func() (db.CreatingVolume, db.CreatedVolume, error),
@@ -440,7 +848,7 @@ func (c *volumeClient) findOrCreateVolume(
 
 	if !acquired {
 		c.clock.Sleep(creatingVolumeRetryDelay)
-		return c.findOrCreateVolume(logger, volumeSpec, findVolumeFunc, createVolumeFunc)
+		return c.findOrCreateVolume(ctx, logger, volumeSpec, findVolumeFunc, createVolumeFunc)
 	}
 
 	defer lock.Release()
@@ -459,11 +867,17 @@ func (c *volumeClient) findOrCreateVolume(
 	} else {
 		logger.Debug("creating-real-volume")
 
+		release, err := acquireCreationSlot(ctx, logger, c.creationLimiter, c.dbWorker.Name())
+		if err != nil {
+			return nil, err
+		}
+
 		bcVolume, err = c.baggageclaimClient.CreateVolume(
 			logger.Session("create-volume"),
 			creatingVolume.Handle(),
 			volumeSpec.baggageclaimVolumeSpec(),
 		)
+		release()
 		if err != nil {
 			logger.Error("failed-to-create-volume-in-baggageclaim", err)
 
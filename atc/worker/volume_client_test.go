@@ -1,14 +1,24 @@
 package worker_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/DataDog/zstd"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock"
@@ -32,6 +42,7 @@ var _ = Describe("VolumeClient", func() {
 		fakeTaskCacheFactory              *dbfakes.FakeTaskCacheFactory
 		fakeClock                         *fakeclock.FakeClock
 		dbWorker                          *dbfakes.FakeWorker
+		fakeResourceCacheStore            *workerfakes.FakeResourceCacheStore
 
 		volumeClient worker.VolumeClient
 	)
@@ -50,6 +61,7 @@ var _ = Describe("VolumeClient", func() {
 		fakeTaskCacheFactory = new(dbfakes.FakeTaskCacheFactory)
 		fakeWorkerTaskCacheFactory = new(dbfakes.FakeWorkerTaskCacheFactory)
 		fakeLock = new(lockfakes.FakeLock)
+		fakeResourceCacheStore = new(workerfakes.FakeResourceCacheStore)
 
 		volumeClient = worker.NewVolumeClient(
 			fakeBaggageclaimClient,
@@ -61,6 +73,8 @@ var _ = Describe("VolumeClient", func() {
 			fakeWorkerBaseResourceTypeFactory,
 			fakeTaskCacheFactory,
 			fakeWorkerTaskCacheFactory,
+			fakeResourceCacheStore,
+			nil,
 		)
 	})
 
@@ -71,12 +85,14 @@ var _ = Describe("VolumeClient", func() {
 		var container db.CreatingContainer
 		var fakeCreatingVolume *dbfakes.FakeCreatingVolume
 		var volumeStrategy baggageclaim.Strategy
+		var ctx context.Context
 
 		BeforeEach(func() {
 			fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
 			fakeCreatingVolume = new(dbfakes.FakeCreatingVolume)
 			fakeBaggageclaimClient.CreateVolumeReturns(fakeBaggageclaimVolume, nil)
 			fakeDBVolumeRepository.CreateContainerVolumeReturns(fakeCreatingVolume, nil)
+			ctx = context.Background()
 
 			volumeStrategy = baggageclaim.ImportStrategy{
 				Path: "/some/path",
@@ -86,6 +102,7 @@ var _ = Describe("VolumeClient", func() {
 		JustBeforeEach(func() {
 			container = new(dbfakes.FakeCreatingContainer)
 			foundOrCreatedVolume, foundOrCreatedErr = volumeClient.FindOrCreateVolumeForContainer(
+				ctx,
 				testLogger,
 				worker.VolumeSpec{
 					Strategy: volumeStrategy,
@@ -262,6 +279,54 @@ var _ = Describe("VolumeClient", func() {
 					Expect(fakeCreatingVolume.FailedCallCount()).To(Equal(1))
 				})
 			})
+
+			Context("when volume creations are limited", func() {
+				var fakeCreationLimiter *workerfakes.FakeCreationLimiter
+				var cancel context.CancelFunc
+
+				BeforeEach(func() {
+					ctx, cancel = context.WithCancel(context.Background())
+
+					fakeCreationLimiter = new(workerfakes.FakeCreationLimiter)
+					fakeCreationLimiter.AcquireReturns(func() {}, nil)
+
+					volumeClient = worker.NewVolumeClient(
+						fakeBaggageclaimClient,
+						dbWorker,
+						fakeClock,
+
+						fakeLockFactory,
+						fakeDBVolumeRepository,
+						fakeWorkerBaseResourceTypeFactory,
+						fakeTaskCacheFactory,
+						fakeWorkerTaskCacheFactory,
+						fakeResourceCacheStore,
+						fakeCreationLimiter,
+					)
+				})
+
+				AfterEach(func() {
+					cancel()
+				})
+
+				It("waits for a creation slot with the given context", func() {
+					Expect(fakeCreationLimiter.AcquireCallCount()).To(Equal(1))
+					actualCtx, _, workerName := fakeCreationLimiter.AcquireArgsForCall(0)
+					Expect(actualCtx).To(Equal(ctx))
+					Expect(workerName).To(Equal(dbWorker.Name()))
+				})
+
+				Context("when the context is done before a slot is free", func() {
+					BeforeEach(func() {
+						fakeCreationLimiter.AcquireReturns(nil, context.Canceled)
+					})
+
+					It("returns the error without creating the volume", func() {
+						Expect(foundOrCreatedErr).To(Equal(context.Canceled))
+						Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(BeZero())
+					})
+				})
+			})
 		})
 	})
 
@@ -299,6 +364,7 @@ var _ = Describe("VolumeClient", func() {
 		JustBeforeEach(func() {
 			container = new(dbfakes.FakeCreatingContainer)
 			foundOrCreatedVolume, foundOrCreatedErr = volumeClient.FindOrCreateCOWVolumeForContainer(
+				context.Background(),
 				testLogger,
 				worker.VolumeSpec{
 					Strategy: volumeStrategy,
@@ -470,7 +536,7 @@ var _ = Describe("VolumeClient", func() {
 		})
 
 		JustBeforeEach(func() {
-			volume, found, err = volumeClient.FindOrCreateVolumeForResourceCerts(testLogger)
+			volume, found, err = volumeClient.FindOrCreateVolumeForResourceCerts(context.Background(), testLogger)
 		})
 
 		Context("when the worker resource certs entry does not exist", func() {
@@ -567,6 +633,637 @@ var _ = Describe("VolumeClient", func() {
 		})
 	})
 
+	Describe("FindVolumeForResourceCache", func() {
+		var fakeResourceCache *dbfakes.FakeUsedResourceCache
+
+		var volume worker.Volume
+		var found bool
+		var findErr error
+
+		BeforeEach(func() {
+			fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+			fakeResourceCache.IDReturns(42)
+		})
+
+		JustBeforeEach(func() {
+			volume, found, findErr = volumeClient.FindVolumeForResourceCache(testLogger, fakeResourceCache)
+		})
+
+		Context("when the resource cache volume exists on the worker", func() {
+			var fakeCreatedVolume *dbfakes.FakeCreatedVolume
+			var fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+
+			BeforeEach(func() {
+				fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+				fakeCreatedVolume.HandleReturns("some-handle")
+				fakeDBVolumeRepository.FindResourceCacheVolumeReturns(fakeCreatedVolume, true, nil)
+
+				fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+				fakeBaggageclaimClient.LookupVolumeReturns(fakeBaggageclaimVolume, true, nil)
+			})
+
+			It("returns the volume", func() {
+				Expect(findErr).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+			})
+
+			It("does not consult the resource cache store", func() {
+				Expect(fakeResourceCacheStore.GetCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the resource cache volume does not exist on the worker", func() {
+			BeforeEach(func() {
+				fakeDBVolumeRepository.FindResourceCacheVolumeReturns(nil, false, nil)
+				fakeResourceCacheStore.GetReturns(ioutil.NopCloser(strings.NewReader("some-tar")), true, nil)
+			})
+
+			It("returns false", func() {
+				Expect(findErr).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("does not hydrate it from the resource cache store", func() {
+				Expect(fakeResourceCacheStore.GetCallCount()).To(BeZero())
+				Expect(fakeDBVolumeRepository.CreateResourceCacheVolumeCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("HydrateVolumeForResourceCache", func() {
+		var fakeResourceCache *dbfakes.FakeUsedResourceCache
+
+		var volume worker.Volume
+		var found bool
+		var hydrateErr error
+
+		BeforeEach(func() {
+			fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+			fakeResourceCache.IDReturns(42)
+		})
+
+		JustBeforeEach(func() {
+			volume, found, hydrateErr = volumeClient.HydrateVolumeForResourceCache(context.Background(), testLogger, fakeResourceCache)
+		})
+
+		Context("when the resource cache store does not have the cache", func() {
+			BeforeEach(func() {
+				fakeResourceCacheStore.GetReturns(nil, false, nil)
+			})
+
+			It("returns false", func() {
+				Expect(hydrateErr).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("does not create a volume", func() {
+				Expect(fakeDBVolumeRepository.CreateResourceCacheVolumeCallCount()).To(BeZero())
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the resource cache store has the cache", func() {
+			var fakeCreatingVolume *dbfakes.FakeCreatingVolume
+			var fakeCreatedVolume *dbfakes.FakeCreatedVolume
+			var fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+
+			BeforeEach(func() {
+				fakeResourceCacheStore.GetReturns(ioutil.NopCloser(strings.NewReader("some-tar")), true, nil)
+
+				fakeCreatingVolume = new(dbfakes.FakeCreatingVolume)
+				fakeCreatingVolume.HandleReturns("some-handle")
+				fakeDBVolumeRepository.CreateResourceCacheVolumeReturns(fakeCreatingVolume, nil)
+
+				fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+				fakeCreatingVolume.CreatedReturns(fakeCreatedVolume, nil)
+
+				fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+				fakeBaggageclaimClient.CreateVolumeReturns(fakeBaggageclaimVolume, nil)
+			})
+
+			It("creates a volume for the resource cache on the worker", func() {
+				Expect(fakeDBVolumeRepository.CreateResourceCacheVolumeCallCount()).To(Equal(1))
+				workerName, resourceCache := fakeDBVolumeRepository.CreateResourceCacheVolumeArgsForCall(0)
+				Expect(workerName).To(Equal("some-worker"))
+				Expect(resourceCache).To(Equal(fakeResourceCache))
+
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
+				_, handle, spec := fakeBaggageclaimClient.CreateVolumeArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(spec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
+			})
+
+			It("streams the stored cache into the volume", func() {
+				Expect(fakeBaggageclaimVolume.StreamInCallCount()).To(Equal(1))
+				_, path, encoding, tarStream := fakeBaggageclaimVolume.StreamInArgsForCall(0)
+				Expect(path).To(Equal("."))
+				Expect(encoding).To(Equal(baggageclaim.ZstdEncoding))
+				Expect(ioutil.ReadAll(tarStream)).To(Equal([]byte("some-tar")))
+			})
+
+			It("returns the hydrated volume once it has been marked created", func() {
+				Expect(hydrateErr).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(fakeCreatingVolume.CreatedCallCount()).To(Equal(1))
+				Expect(volume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+			})
+
+			Context("when another volume was already created for the resource cache", func() {
+				BeforeEach(func() {
+					fakeDBVolumeRepository.CreateResourceCacheVolumeReturns(nil, db.ErrResourceCacheVolumeExists)
+				})
+
+				It("returns false", func() {
+					Expect(hydrateErr).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+
+			Context("when streaming in fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBaggageclaimVolume.StreamInReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(hydrateErr).To(Equal(disaster))
+				})
+
+				It("destroys the volume and marks it as failed", func() {
+					Expect(fakeBaggageclaimVolume.DestroyCallCount()).To(Equal(1))
+					Expect(fakeCreatingVolume.FailedCallCount()).To(Equal(1))
+					Expect(fakeCreatingVolume.CreatedCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the worker streams volumes with gzip", func() {
+				BeforeEach(func() {
+					dbWorker.StreamEncodingReturns("gzip")
+
+					compressed := new(bytes.Buffer)
+					zstdWriter := zstd.NewWriter(compressed)
+					_, err := zstdWriter.Write([]byte("some-tar"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(zstdWriter.Close()).To(Succeed())
+
+					fakeResourceCacheStore.GetReturns(ioutil.NopCloser(compressed), true, nil)
+
+					fakeBaggageclaimVolume.StreamInStub = func(_ context.Context, _ string, _ baggageclaim.Encoding, tarStream io.Reader) error {
+						gzReader, err := gzip.NewReader(tarStream)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(ioutil.ReadAll(gzReader)).To(Equal([]byte("some-tar")))
+						return nil
+					}
+				})
+
+				It("streams the stored cache into the volume transcoded to gzip", func() {
+					Expect(hydrateErr).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(fakeBaggageclaimVolume.StreamInCallCount()).To(Equal(1))
+					_, _, encoding, _ := fakeBaggageclaimVolume.StreamInArgsForCall(0)
+					Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+				})
+			})
+		})
+	})
+
+	Describe("StreamEncoding", func() {
+		It("defaults to zstd", func() {
+			Expect(volumeClient.StreamEncoding()).To(Equal(baggageclaim.ZstdEncoding))
+		})
+
+		Context("when the worker negotiated its capabilities", func() {
+			BeforeEach(func() {
+				dbWorker.ProtocolVersionReturns(atc.WorkerProtocolVersion)
+				dbWorker.CapabilitiesReturns([]atc.WorkerCapability{atc.WorkerCapabilityZstdStreaming})
+			})
+
+			It("returns zstd", func() {
+				Expect(volumeClient.StreamEncoding()).To(Equal(baggageclaim.ZstdEncoding))
+			})
+
+			Context("without zstd streaming", func() {
+				BeforeEach(func() {
+					dbWorker.CapabilitiesReturns(nil)
+				})
+
+				It("returns gzip", func() {
+					Expect(volumeClient.StreamEncoding()).To(Equal(baggageclaim.GzipEncoding))
+				})
+
+				Context("when the worker streams volumes with zstd", func() {
+					BeforeEach(func() {
+						dbWorker.StreamEncodingReturns("zstd")
+					})
+
+					It("returns zstd", func() {
+						Expect(volumeClient.StreamEncoding()).To(Equal(baggageclaim.ZstdEncoding))
+					})
+				})
+			})
+		})
+
+		Context("when the worker streams volumes with gzip", func() {
+			BeforeEach(func() {
+				dbWorker.StreamEncodingReturns("gzip")
+			})
+
+			It("returns gzip", func() {
+				Expect(volumeClient.StreamEncoding()).To(Equal(baggageclaim.GzipEncoding))
+			})
+
+			Describe("streaming in and out of its volumes", func() {
+				var (
+					fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+					fakeCreatedVolume      *dbfakes.FakeCreatedVolume
+					volume                 worker.Volume
+				)
+
+				BeforeEach(func() {
+					fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+					fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+					fakeCreatedVolume.LeaseReturns(new(dbfakes.FakeVolumeLease), true, nil)
+
+					volume = worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)
+				})
+
+				It("transcodes zstd streams in to gzip", func() {
+					compressed := new(bytes.Buffer)
+					zstdWriter := zstd.NewWriter(compressed)
+					_, err := zstdWriter.Write([]byte("some-tar"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(zstdWriter.Close()).To(Succeed())
+
+					var streamedIn []byte
+					fakeBaggageclaimVolume.StreamInStub = func(_ context.Context, _ string, encoding baggageclaim.Encoding, tarStream io.Reader) error {
+						Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+
+						gzReader, err := gzip.NewReader(tarStream)
+						Expect(err).ToNot(HaveOccurred())
+
+						streamedIn, err = ioutil.ReadAll(gzReader)
+						return err
+					}
+
+					Expect(volume.StreamIn(context.Background(), ".", compressed)).To(Succeed())
+					Expect(streamedIn).To(Equal([]byte("some-tar")))
+				})
+
+				It("transcodes gzip streams out to zstd", func() {
+					compressed := new(bytes.Buffer)
+					gzWriter := gzip.NewWriter(compressed)
+					_, err := gzWriter.Write([]byte("some-tar"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(gzWriter.Close()).To(Succeed())
+
+					fakeBaggageclaimVolume.StreamOutReturns(ioutil.NopCloser(compressed), nil)
+
+					out, err := volume.StreamOut(context.Background(), ".")
+					Expect(err).ToNot(HaveOccurred())
+
+					_, _, encoding := fakeBaggageclaimVolume.StreamOutArgsForCall(0)
+					Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+
+					Expect(ioutil.ReadAll(zstd.NewReader(out))).To(Equal([]byte("some-tar")))
+					Expect(out.Close()).To(Succeed())
+				})
+
+				It("passes gzip streams out of one of its volumes into another unchanged", func() {
+					compressed := new(bytes.Buffer)
+					gzWriter := gzip.NewWriter(compressed)
+					gzWriter.Comment = "not kept by transcoding"
+					_, err := gzWriter.Write([]byte("some-tar"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(gzWriter.Close()).To(Succeed())
+
+					original := compressed.Bytes()
+					fakeBaggageclaimVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewReader(original)), nil)
+
+					var streamedIn []byte
+					otherBaggageclaimVolume := new(baggageclaimfakes.FakeVolume)
+					otherBaggageclaimVolume.StreamInStub = func(_ context.Context, _ string, encoding baggageclaim.Encoding, tarStream io.Reader) error {
+						Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+
+						streamedIn, err = ioutil.ReadAll(tarStream)
+						return err
+					}
+
+					otherVolume := worker.NewVolume(otherBaggageclaimVolume, new(dbfakes.FakeCreatedVolume), volumeClient)
+
+					out, err := volume.StreamOut(context.Background(), ".")
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(otherVolume.StreamIn(context.Background(), ".", out)).To(Succeed())
+					Expect(out.Close()).To(Succeed())
+
+					Expect(streamedIn).To(Equal(original))
+				})
+			})
+		})
+	})
+
+	Describe("FindVolumeForImageLayer", func() {
+		var volume worker.Volume
+		var found bool
+		var findErr error
+
+		JustBeforeEach(func() {
+			volume, found, findErr = volumeClient.FindVolumeForImageLayer(testLogger, "sha256:some-digest")
+		})
+
+		Context("when the layer is cached on the worker", func() {
+			var fakeCreatedVolume *dbfakes.FakeCreatedVolume
+			var fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+
+			BeforeEach(func() {
+				fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+				fakeCreatedVolume.HandleReturns("some-handle")
+				fakeDBVolumeRepository.FindImageLayerVolumeReturns(fakeCreatedVolume, true, nil)
+
+				fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+				fakeBaggageclaimClient.LookupVolumeReturns(fakeBaggageclaimVolume, true, nil)
+			})
+
+			It("returns the volume", func() {
+				Expect(findErr).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+
+				workerName, digest := fakeDBVolumeRepository.FindImageLayerVolumeArgsForCall(0)
+				Expect(workerName).To(Equal("some-worker"))
+				Expect(digest).To(Equal("sha256:some-digest"))
+			})
+		})
+
+		Context("when the volume is gone from baggageclaim", func() {
+			BeforeEach(func() {
+				fakeDBVolumeRepository.FindImageLayerVolumeReturns(new(dbfakes.FakeCreatedVolume), true, nil)
+				fakeBaggageclaimClient.LookupVolumeReturns(nil, false, nil)
+			})
+
+			It("returns false", func() {
+				Expect(findErr).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("CreateVolumeForImageLayer", func() {
+		var (
+			digest string
+
+			fakeCreatingVolume     *dbfakes.FakeCreatingVolume
+			fakeCreatedVolume      *dbfakes.FakeCreatedVolume
+			fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+
+			volume    worker.Volume
+			found     bool
+			createErr error
+		)
+
+		BeforeEach(func() {
+			digest = "sha256:some-other-digest"
+
+			fakeCreatingVolume = new(dbfakes.FakeCreatingVolume)
+			fakeCreatingVolume.HandleReturns("some-handle")
+			fakeDBVolumeRepository.CreateImageLayerVolumeReturns(fakeCreatingVolume, nil)
+
+			fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+			fakeCreatingVolume.CreatedReturns(fakeCreatedVolume, nil)
+
+			fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+			fakeBaggageclaimVolume.StreamInStub = func(_ context.Context, _ string, _ baggageclaim.Encoding, tarStream io.Reader) error {
+				_, err := ioutil.ReadAll(tarStream)
+				return err
+			}
+			fakeBaggageclaimClient.CreateVolumeReturns(fakeBaggageclaimVolume, nil)
+		})
+
+		JustBeforeEach(func() {
+			volume, found, createErr = volumeClient.CreateVolumeForImageLayer(context.Background(), testLogger, digest, strings.NewReader("some-layer"))
+		})
+
+		Context("when the layer matches its digest", func() {
+			BeforeEach(func() {
+				sum := sha256.Sum256([]byte("some-layer"))
+				digest = "sha256:" + hex.EncodeToString(sum[:])
+			})
+
+			It("creates a volume for the layer on the worker", func() {
+				Expect(fakeDBVolumeRepository.CreateImageLayerVolumeCallCount()).To(Equal(1))
+				workerName, layerDigest := fakeDBVolumeRepository.CreateImageLayerVolumeArgsForCall(0)
+				Expect(workerName).To(Equal("some-worker"))
+				Expect(layerDigest).To(Equal(digest))
+
+				_, handle, spec := fakeBaggageclaimClient.CreateVolumeArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(spec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
+			})
+
+			It("extracts the compressed layer into the volume", func() {
+				Expect(fakeBaggageclaimVolume.StreamInCallCount()).To(Equal(1))
+				_, path, encoding, _ := fakeBaggageclaimVolume.StreamInArgsForCall(0)
+				Expect(path).To(Equal("."))
+				Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+			})
+
+			It("returns the volume once it has been marked created", func() {
+				Expect(createErr).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+			})
+		})
+
+		Context("when the layer does not match its digest", func() {
+			It("returns an integrity error", func() {
+				Expect(createErr).To(BeAssignableToTypeOf(worker.LayerIntegrityError{}))
+			})
+
+			It("destroys the volume and marks it as failed", func() {
+				Expect(fakeBaggageclaimVolume.DestroyCallCount()).To(Equal(1))
+				Expect(fakeCreatingVolume.FailedCallCount()).To(Equal(1))
+				Expect(fakeCreatingVolume.CreatedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the digest does not use sha256", func() {
+			BeforeEach(func() {
+				digest = "sha512:some-digest"
+			})
+
+			It("does not create a volume", func() {
+				Expect(createErr).To(Equal(worker.ErrUnsupportedLayerDigest))
+				Expect(fakeDBVolumeRepository.CreateImageLayerVolumeCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the layer is already being cached", func() {
+			BeforeEach(func() {
+				fakeDBVolumeRepository.CreateImageLayerVolumeReturns(nil, db.ErrImageLayerVolumeExists)
+			})
+
+			It("returns false", func() {
+				Expect(createErr).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("CreateVolumeForUnpackedImage", func() {
+		var (
+			fakeCreatingVolume     *dbfakes.FakeCreatingVolume
+			fakeCreatedVolume      *dbfakes.FakeCreatedVolume
+			fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+
+			volume    worker.Volume
+			found     bool
+			createErr error
+		)
+
+		BeforeEach(func() {
+			fakeCreatingVolume = new(dbfakes.FakeCreatingVolume)
+			fakeCreatingVolume.HandleReturns("some-handle")
+			fakeDBVolumeRepository.CreateImageLayerVolumeReturns(fakeCreatingVolume, nil)
+
+			fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+			fakeCreatingVolume.CreatedReturns(fakeCreatedVolume, nil)
+
+			fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+			fakeBaggageclaimVolume.StreamInStub = func(_ context.Context, _ string, _ baggageclaim.Encoding, tarStream io.Reader) error {
+				_, err := ioutil.ReadAll(tarStream)
+				return err
+			}
+			fakeBaggageclaimClient.CreateVolumeReturns(fakeBaggageclaimVolume, nil)
+		})
+
+		JustBeforeEach(func() {
+			volume, found, createErr = volumeClient.CreateVolumeForUnpackedImage(context.Background(), testLogger, "sha256:some-manifest-digest", strings.NewReader("some-image"))
+		})
+
+		It("creates a volume keyed by the image's manifest digest", func() {
+			Expect(fakeDBVolumeRepository.CreateImageLayerVolumeCallCount()).To(Equal(1))
+			workerName, digest := fakeDBVolumeRepository.CreateImageLayerVolumeArgsForCall(0)
+			Expect(workerName).To(Equal("some-worker"))
+			Expect(digest).To(Equal("sha256:some-manifest-digest"))
+
+			_, handle, spec := fakeBaggageclaimClient.CreateVolumeArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(spec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
+		})
+
+		It("streams the image into the volume before marking it created", func() {
+			Expect(fakeBaggageclaimVolume.StreamInCallCount()).To(Equal(1))
+			_, path, encoding, _ := fakeBaggageclaimVolume.StreamInArgsForCall(0)
+			Expect(path).To(Equal("."))
+			Expect(encoding).To(Equal(baggageclaim.ZstdEncoding))
+
+			Expect(createErr).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(volume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+		})
+
+		Context("when streaming the image in fails", func() {
+			BeforeEach(func() {
+				fakeBaggageclaimVolume.StreamInReturns(errors.New("nope"))
+				fakeBaggageclaimVolume.StreamInStub = nil
+			})
+
+			It("destroys the volume and marks it as failed", func() {
+				Expect(createErr).To(HaveOccurred())
+				Expect(fakeBaggageclaimVolume.DestroyCallCount()).To(Equal(1))
+				Expect(fakeCreatingVolume.FailedCallCount()).To(Equal(1))
+				Expect(fakeCreatingVolume.CreatedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the image is already being unpacked", func() {
+			BeforeEach(func() {
+				fakeDBVolumeRepository.CreateImageLayerVolumeReturns(nil, db.ErrImageLayerVolumeExists)
+			})
+
+			It("returns false", func() {
+				Expect(createErr).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("StoreVolumeForResourceCache", func() {
+		var fakeResourceCache *dbfakes.FakeUsedResourceCache
+		var fakeVolume *workerfakes.FakeVolume
+
+		var storeErr error
+
+		BeforeEach(func() {
+			fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+			fakeVolume = new(workerfakes.FakeVolume)
+			fakeVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-tar")), nil)
+		})
+
+		JustBeforeEach(func() {
+			storeErr = volumeClient.StoreVolumeForResourceCache(context.TODO(), testLogger, fakeVolume, fakeResourceCache)
+		})
+
+		Context("when the cache is not yet stored", func() {
+			BeforeEach(func() {
+				fakeResourceCacheStore.ContainsReturns(false, nil)
+			})
+
+			It("streams the volume out to the store", func() {
+				Expect(storeErr).ToNot(HaveOccurred())
+
+				Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+				_, path := fakeVolume.StreamOutArgsForCall(0)
+				Expect(path).To(Equal("."))
+
+				Expect(fakeResourceCacheStore.PutCallCount()).To(Equal(1))
+				_, resourceCache, tarStream := fakeResourceCacheStore.PutArgsForCall(0)
+				Expect(resourceCache).To(Equal(fakeResourceCache))
+				Expect(ioutil.ReadAll(tarStream)).To(Equal([]byte("some-tar")))
+			})
+		})
+
+		Context("when the cache is already stored", func() {
+			BeforeEach(func() {
+				fakeResourceCacheStore.ContainsReturns(true, nil)
+			})
+
+			It("does not store it again", func() {
+				Expect(storeErr).ToNot(HaveOccurred())
+				Expect(fakeVolume.StreamOutCallCount()).To(BeZero())
+				Expect(fakeResourceCacheStore.PutCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when no store is configured", func() {
+			BeforeEach(func() {
+				volumeClient = worker.NewVolumeClient(
+					fakeBaggageclaimClient,
+					dbWorker,
+					fakeClock,
+
+					fakeLockFactory,
+					fakeDBVolumeRepository,
+					fakeWorkerBaseResourceTypeFactory,
+					fakeTaskCacheFactory,
+					fakeWorkerTaskCacheFactory,
+					nil,
+					nil,
+				)
+			})
+
+			It("does nothing", func() {
+				Expect(storeErr).ToNot(HaveOccurred())
+				Expect(fakeVolume.StreamOutCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("FindVolumeForTaskCache", func() {
 		Context("when worker task cache does not exist", func() {
 			BeforeEach(func() {
@@ -653,7 +1350,7 @@ var _ = Describe("VolumeClient", func() {
 		})
 
 		JustBeforeEach(func() {
-			workerVolume, err = volumeClient.CreateVolume(testLogger, worker.VolumeSpec{}, 42, "some-mount", db.VolumeTypeArtifact)
+			workerVolume, err = volumeClient.CreateVolume(context.Background(), testLogger, worker.VolumeSpec{}, 42, "some-mount", db.VolumeTypeArtifact)
 		})
 
 		Context("when trying to create a new volume", func() {
@@ -771,6 +1468,8 @@ var _ = Describe("VolumeClient", func() {
 				fakeWorkerBaseResourceTypeFactory,
 				fakeTaskCacheFactory,
 				fakeWorkerTaskCacheFactory,
+				fakeResourceCacheStore,
+				nil,
 			).LookupVolume(testLogger, handle)
 		})
 
@@ -0,0 +1,229 @@
+package worker_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type interruptedReader struct {
+	io.Reader
+}
+
+func (r interruptedReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset by peer")
+	}
+
+	return n, err
+}
+
+var _ = Describe("Volume", func() {
+	var (
+		fakeBCVolume     *baggageclaimfakes.FakeVolume
+		fakeDBVolume     *dbfakes.FakeCreatedVolume
+		fakeVolumeClient *workerfakes.FakeVolumeClient
+
+		volume worker.Volume
+
+		originalInterval time.Duration
+	)
+
+	BeforeEach(func() {
+		fakeBCVolume = new(baggageclaimfakes.FakeVolume)
+		fakeBCVolume.HandleReturns("some-handle")
+
+		fakeDBVolume = new(dbfakes.FakeCreatedVolume)
+		fakeDBVolume.WorkerNameReturns("some-worker")
+		fakeDBVolume.LeaseReturns(new(dbfakes.FakeVolumeLease), true, nil)
+
+		fakeVolumeClient = new(workerfakes.FakeVolumeClient)
+		fakeVolumeClient.StreamEncodingReturns(baggageclaim.ZstdEncoding)
+
+		volume = worker.NewVolume(fakeBCVolume, fakeDBVolume, fakeVolumeClient)
+
+		originalInterval = worker.StreamResumeInterval
+		worker.StreamResumeInterval = 0
+	})
+
+	AfterEach(func() {
+		worker.StreamResumeInterval = originalInterval
+	})
+
+	Describe("StreamOut", func() {
+		var (
+			ctx     context.Context
+			retries []worker.StreamRetry
+			content []byte
+		)
+
+		BeforeEach(func() {
+			retries = nil
+			ctx = worker.WithStreamRetryNotifier(context.Background(), func(retry worker.StreamRetry) {
+				retries = append(retries, retry)
+			})
+
+			content = make([]byte, 3*1024*1024)
+			for i := range content {
+				content[i] = byte(i % 251)
+			}
+		})
+
+		Context("when the stream is interrupted", func() {
+			BeforeEach(func() {
+				fakeBCVolume.StreamOutReturnsOnCall(0, ioutil.NopCloser(interruptedReader{bytes.NewReader(content[:1536*1024])}), nil)
+				fakeBCVolume.StreamOutReturnsOnCall(1, ioutil.NopCloser(bytes.NewReader(content)), nil)
+			})
+
+			It("resumes it from where it left off", func() {
+				out, err := volume.StreamOut(ctx, ".")
+				Expect(err).ToNot(HaveOccurred())
+
+				streamed, err := ioutil.ReadAll(out)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(streamed).To(Equal(content))
+
+				Expect(fakeBCVolume.StreamOutCallCount()).To(Equal(2))
+			})
+
+			It("notifies the context's retry notifier", func() {
+				out, err := volume.StreamOut(ctx, ".")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = ioutil.ReadAll(out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(retries).To(HaveLen(1))
+				Expect(retries[0].Volume).To(Equal("some-handle"))
+				Expect(retries[0].Worker).To(Equal("some-worker"))
+				Expect(retries[0].Offset).To(Equal(int64(1536 * 1024)))
+				Expect(retries[0].Attempt).To(Equal(1))
+				Expect(retries[0].Err).To(MatchError("connection reset by peer"))
+			})
+		})
+
+		Context("when the resumed stream has different content", func() {
+			BeforeEach(func() {
+				changed := make([]byte, len(content))
+				copy(changed, content)
+				changed[1024] = changed[1024] + 1
+
+				fakeBCVolume.StreamOutReturnsOnCall(0, ioutil.NopCloser(interruptedReader{bytes.NewReader(content[:1536*1024])}), nil)
+				fakeBCVolume.StreamOutReturnsOnCall(1, ioutil.NopCloser(bytes.NewReader(changed)), nil)
+			})
+
+			It("fails", func() {
+				out, err := volume.StreamOut(ctx, ".")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = ioutil.ReadAll(out)
+				Expect(err).To(Equal(worker.ErrStreamChanged))
+			})
+		})
+
+		Context("when the stream keeps being interrupted", func() {
+			BeforeEach(func() {
+				fakeBCVolume.StreamOutStub = func(context.Context, string, baggageclaim.Encoding) (io.ReadCloser, error) {
+					return ioutil.NopCloser(interruptedReader{bytes.NewReader(content[:1024])}), nil
+				}
+			})
+
+			It("gives up after the resume attempts run out", func() {
+				out, err := volume.StreamOut(ctx, ".")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = ioutil.ReadAll(out)
+				Expect(err).To(MatchError("connection reset by peer"))
+
+				Expect(retries).To(HaveLen(worker.StreamResumeAttempts))
+				Expect(fakeBCVolume.StreamOutCallCount()).To(Equal(worker.StreamResumeAttempts + 1))
+			})
+		})
+
+		Context("when the stream is not interrupted", func() {
+			BeforeEach(func() {
+				fakeBCVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewReader(content)), nil)
+			})
+
+			It("streams it once", func() {
+				out, err := volume.StreamOut(ctx, ".")
+				Expect(err).ToNot(HaveOccurred())
+
+				streamed, err := ioutil.ReadAll(out)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(streamed).To(Equal(content))
+
+				Expect(fakeBCVolume.StreamOutCallCount()).To(Equal(1))
+				Expect(retries).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("InitializeResourceCache", func() {
+		var (
+			fakeResourceCache *dbfakes.FakeUsedResourceCache
+			storing           chan struct{}
+			initErr           error
+		)
+
+		BeforeEach(func() {
+			fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+
+			storing = make(chan struct{})
+			fakeVolumeClient.StoreVolumeForResourceCacheStub = func(ctx context.Context, _ lager.Logger, _ worker.Volume, _ db.UsedResourceCache) error {
+				<-storing
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			initErr = volume.InitializeResourceCache(lagertest.NewTestLogger("test"), fakeResourceCache)
+		})
+
+		AfterEach(func() {
+			close(storing)
+		})
+
+		It("initializes the volume in the database", func() {
+			Expect(initErr).ToNot(HaveOccurred())
+			Expect(fakeDBVolume.InitializeResourceCacheCallCount()).To(Equal(1))
+			Expect(fakeDBVolume.InitializeResourceCacheArgsForCall(0)).To(Equal(fakeResourceCache))
+		})
+
+		It("stores the cache in the background, with a deadline", func() {
+			Eventually(fakeVolumeClient.StoreVolumeForResourceCacheCallCount).Should(Equal(1))
+
+			ctx, _, storedVolume, resourceCache := fakeVolumeClient.StoreVolumeForResourceCacheArgsForCall(0)
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeTrue())
+			Expect(storedVolume).To(Equal(volume))
+			Expect(resourceCache).To(Equal(fakeResourceCache))
+		})
+
+		Context("when initializing it in the database fails", func() {
+			BeforeEach(func() {
+				fakeDBVolume.InitializeResourceCacheReturns(errors.New("nope"))
+			})
+
+			It("does not store it", func() {
+				Expect(initErr).To(HaveOccurred())
+				Consistently(fakeVolumeClient.StoreVolumeForResourceCacheCallCount).Should(BeZero())
+			})
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -34,8 +35,10 @@ type Worker interface {
 	Name() string
 	ResourceTypes() []atc.WorkerResourceType
 	Tags() atc.Tags
+	Labels() atc.Labels
 	Uptime() time.Duration
 	IsOwnedByTeam() bool
+	TeamID() int
 	Ephemeral() bool
 	IsVersionCompatible(lager.Logger, version.Version) bool
 	Satisfies(lager.Logger, WorkerSpec) bool
@@ -52,11 +55,16 @@ type Worker interface {
 	) (Container, error)
 
 	FindVolumeForResourceCache(logger lager.Logger, resourceCache db.UsedResourceCache) (Volume, bool, error)
+	HydrateVolumeForResourceCache(ctx context.Context, logger lager.Logger, resourceCache db.UsedResourceCache) (Volume, bool, error)
+	CreateVolumeForResourceCache(ctx context.Context, logger lager.Logger, resourceCache db.UsedResourceCache, tarStream io.Reader) (Volume, bool, error)
+	FindVolumeForImageLayer(logger lager.Logger, digest string) (Volume, bool, error)
+	CreateVolumeForImageLayer(ctx context.Context, logger lager.Logger, digest string, layer io.Reader) (Volume, bool, error)
+	CreateVolumeForUnpackedImage(ctx context.Context, logger lager.Logger, digest string, image io.Reader) (Volume, bool, error)
 	FindVolumeForTaskCache(lager.Logger, int, int, string, string) (Volume, bool, error)
 
-	CertsVolume(lager.Logger) (volume Volume, found bool, err error)
+	CertsVolume(context.Context, lager.Logger) (volume Volume, found bool, err error)
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
-	CreateVolume(logger lager.Logger, spec VolumeSpec, teamID int, volumeType db.VolumeType) (Volume, error)
+	CreateVolume(ctx context.Context, logger lager.Logger, spec VolumeSpec, teamID int, volumeType db.VolumeType) (Volume, error)
 
 	GardenClient() gclient.Client
 	ActiveTasks() (int, error)
@@ -71,6 +79,7 @@ type gardenWorker struct {
 	dbWorker        db.Worker
 	buildContainers int
 	helper          workerHelper
+	creationLimiter CreationLimiter
 }
 
 // NewGardenWorker constructs a Worker using the gardenWorker runtime implementation and allows container and volume
@@ -87,6 +96,7 @@ func NewGardenWorker(
 	// TODO: numBuildContainers is only needed for placement strategy but this
 	// method is called in ContainerProvider.FindOrCreateContainer as well and
 	// hence we pass in 0 values for numBuildContainers everywhere.
+	creationLimiter CreationLimiter,
 ) Worker {
 	workerHelper := workerHelper{
 		gardenClient:  gardenClient,
@@ -103,6 +113,7 @@ func NewGardenWorker(
 		dbWorker:        dbWorker,
 		buildContainers: numBuildContainers,
 		helper:          workerHelper,
+		creationLimiter: creationLimiter,
 	}
 }
 
@@ -128,17 +139,17 @@ func (worker *gardenWorker) IsVersionCompatible(logger lager.Logger, comparedVer
 		return false
 	}
 
+	sameMajor := v.Release.Components[0].Compare(comparedVersion.Release.Components[0]) == 0
+
 	switch v.Release.Compare(comparedVersion.Release) {
 	case 0:
 		return true
 	case -1:
-		return false
+		// a worker which negotiated its capabilities is only relied on for
+		// what it supports, so it may lag behind by a minor version
+		return sameMajor && negotiatedProtocol(worker.dbWorker)
 	default:
-		if v.Release.Components[0].Compare(comparedVersion.Release.Components[0]) == 0 {
-			return true
-		}
-
-		return false
+		return sameMajor
 	}
 }
 
@@ -156,22 +167,68 @@ func (worker *gardenWorker) FindVolumeForResourceCache(logger lager.Logger, reso
 	return worker.volumeClient.FindVolumeForResourceCache(logger, resourceCache)
 }
 
+func (worker *gardenWorker) HydrateVolumeForResourceCache(ctx context.Context, logger lager.Logger, resourceCache db.UsedResourceCache) (Volume, bool, error) {
+	return worker.volumeClient.HydrateVolumeForResourceCache(ctx, logger, resourceCache)
+}
+
+func (worker *gardenWorker) CreateVolumeForResourceCache(ctx context.Context, logger lager.Logger, resourceCache db.UsedResourceCache, tarStream io.Reader) (Volume, bool, error) {
+	return worker.volumeClient.CreateVolumeForResourceCache(ctx, logger, resourceCache, tarStream)
+}
+
+func (worker *gardenWorker) FindVolumeForImageLayer(logger lager.Logger, digest string) (Volume, bool, error) {
+	return worker.volumeClient.FindVolumeForImageLayer(logger, digest)
+}
+
+func (worker *gardenWorker) CreateVolumeForImageLayer(ctx context.Context, logger lager.Logger, digest string, layer io.Reader) (Volume, bool, error) {
+	return worker.volumeClient.CreateVolumeForImageLayer(ctx, logger, digest, layer)
+}
+
+func (worker *gardenWorker) CreateVolumeForUnpackedImage(ctx context.Context, logger lager.Logger, digest string, image io.Reader) (Volume, bool, error) {
+	return worker.volumeClient.CreateVolumeForUnpackedImage(ctx, logger, digest, image)
+}
+
 func (worker *gardenWorker) FindVolumeForTaskCache(logger lager.Logger, teamID int, jobID int, stepName string, path string) (Volume, bool, error) {
 	return worker.volumeClient.FindVolumeForTaskCache(logger, teamID, jobID, stepName, path)
 }
 
-func (worker *gardenWorker) CertsVolume(logger lager.Logger) (Volume, bool, error) {
-	return worker.volumeClient.FindOrCreateVolumeForResourceCerts(logger.Session("find-or-create"))
+func (worker *gardenWorker) CertsVolume(ctx context.Context, logger lager.Logger) (Volume, bool, error) {
+	return worker.volumeClient.FindOrCreateVolumeForResourceCerts(ctx, logger.Session("find-or-create"))
 }
 
-func (worker *gardenWorker) CreateVolume(logger lager.Logger, spec VolumeSpec, teamID int, volumeType db.VolumeType) (Volume, error) {
-	return worker.volumeClient.CreateVolume(logger.Session("find-or-create"), spec, teamID, worker.dbWorker.Name(), volumeType)
+func (worker *gardenWorker) CreateVolume(ctx context.Context, logger lager.Logger, spec VolumeSpec, teamID int, volumeType db.VolumeType) (Volume, error) {
+	return worker.volumeClient.CreateVolume(ctx, logger.Session("find-or-create"), spec, teamID, worker.dbWorker.Name(), volumeType)
 }
 
 func (worker *gardenWorker) LookupVolume(logger lager.Logger, handle string) (Volume, bool, error) {
 	return worker.volumeClient.LookupVolume(logger, handle)
 }
 
+// checkPrivilegedPolicy refuses privileged containers for builds which their
+// team's privileged policy does not allow. The plan is checked before the
+// build runs, but images and resource types configured at runtime are only
+// known to be privileged once they have been fetched.
+func (worker *gardenWorker) checkPrivilegedPolicy(teamID int, metadata db.ContainerMetadata) error {
+	if teamID == 0 {
+		return nil
+	}
+
+	team, found, err := worker.helper.dbTeamFactory.FindTeamByID(teamID)
+	if err != nil {
+		return err
+	}
+
+	if !found || team.PrivilegedPolicy().Allows(metadata.PipelineName, metadata.JobName) {
+		return nil
+	}
+
+	return atc.PrivilegedNotAllowedError{
+		Team:     team.Name(),
+		Pipeline: metadata.PipelineName,
+		Job:      metadata.JobName,
+		Step:     metadata.StepName,
+	}
+}
+
 func (worker *gardenWorker) FindOrCreateContainer(
 	ctx context.Context,
 	logger lager.Logger,
@@ -246,10 +303,33 @@ func (worker *gardenWorker) FindOrCreateContainer(
 	// will create one. If it does exist, we will transition the creatingContainer
 	// to created and return a worker.Container
 	if gardenContainer == nil {
+		var gpus []int
+		if containerSpec.GPUs > 0 {
+			gpus, err = creatingContainer.AllocateGPUs(containerSpec.GPUs)
+			if err != nil {
+				creatingContainer.Failed()
+				logger.Error("failed-to-allocate-gpus", err)
+				return nil, err
+			}
+
+			logger.Debug("allocated-gpus", lager.Data{"gpus": gpus})
+		}
+
+		// tell the step about any of its image or inputs' streams which were
+		// interrupted and resumed, since they'd otherwise just seem slow
+		ctx = WithStreamRetryNotifier(ctx, func(retry StreamRetry) {
+			fmt.Fprintln(delegate.Stderr(), retry)
+		})
+
+		fetchingImage := time.Now()
+
+		imageSpec := containerSpec.ImageSpec
+		imageSpec.Tags = containerSpec.Tags
+
 		fetchedImage, err := worker.fetchImageForContainer(
 			ctx,
 			logger,
-			containerSpec.ImageSpec,
+			imageSpec,
 			containerSpec.TeamID,
 			delegate,
 			resourceTypes,
@@ -261,22 +341,45 @@ func (worker *gardenWorker) FindOrCreateContainer(
 			return nil, err
 		}
 
+		imageFetchDuration := time.Since(fetchingImage)
+
+		if fetchedImage.Privileged {
+			err = worker.checkPrivilegedPolicy(containerSpec.TeamID, metadata)
+			if err != nil {
+				creatingContainer.Failed()
+				logger.Error("failed-to-check-privileged-policy", err)
+				return nil, err
+			}
+		}
+
+		creatingVolumes := time.Now()
+
 		volumeMounts, err := worker.createVolumes(ctx, logger, fetchedImage.Privileged, creatingContainer, containerSpec)
 		if err != nil {
 			creatingContainer.Failed()
 			logger.Error("failed-to-create-volume-mounts-for-container", err)
 			return nil, err
 		}
-		bindMounts, err := worker.getBindMounts(volumeMounts, containerSpec.BindMounts)
+
+		inputStreamDuration := time.Since(creatingVolumes)
+		bindMounts, err := worker.getBindMounts(ctx, volumeMounts, containerSpec.BindMounts)
 		if err != nil {
 			creatingContainer.Failed()
 			logger.Error("failed-to-create-bind-mounts-for-container", err)
 			return nil, err
 		}
 
+		release, err := acquireCreationSlot(ctx, logger, worker.creationLimiter, worker.Name())
+		if err != nil {
+			creatingContainer.Failed()
+			logger.Error("failed-to-acquire-creation-slot", err)
+			return nil, err
+		}
+
 		logger.Debug("creating-garden-container")
 
-		gardenContainer, err = worker.helper.createGardenContainer(containerSpec, fetchedImage, creatingContainer.Handle(), bindMounts)
+		gardenContainer, err = worker.helper.createGardenContainer(containerSpec, metadata, fetchedImage, creatingContainer.Handle(), bindMounts, gpus)
+		release()
 		if err != nil {
 			_, failedErr := creatingContainer.Failed()
 			if failedErr != nil {
@@ -288,6 +391,10 @@ func (worker *gardenWorker) FindOrCreateContainer(
 			return nil, err
 		}
 
+		err = delegate.ContainerPrepared(imageFetchDuration, inputStreamDuration)
+		if err != nil {
+			logger.Error("failed-to-record-container-preparation", err)
+		}
 	}
 
 	logger.Debug("created-container-in-garden")
@@ -311,11 +418,11 @@ func (worker *gardenWorker) FindOrCreateContainer(
 	)
 }
 
-func (worker *gardenWorker) getBindMounts(volumeMounts []VolumeMount, bindMountSources []BindMountSource) ([]garden.BindMount, error) {
+func (worker *gardenWorker) getBindMounts(ctx context.Context, volumeMounts []VolumeMount, bindMountSources []BindMountSource) ([]garden.BindMount, error) {
 	bindMounts := []garden.BindMount{}
 
 	for _, mount := range bindMountSources {
-		bindMount, found, mountErr := mount.VolumeOn(worker)
+		bindMount, found, mountErr := mount.VolumeOn(ctx, worker)
 		if mountErr != nil {
 			return nil, mountErr
 		}
@@ -386,6 +493,7 @@ func (worker *gardenWorker) createVolumes(
 	var ioVolumeMounts []VolumeMount
 
 	scratchVolume, err := worker.volumeClient.FindOrCreateVolumeForContainer(
+		ctx,
 		logger,
 		VolumeSpec{
 			Strategy:   baggageclaim.EmptyStrategy{},
@@ -411,6 +519,7 @@ func (worker *gardenWorker) createVolumes(
 
 	if spec.Dir != "" && !hasSpecDirInOutputs && !hasSpecDirInInputs {
 		workdirVolume, volumeErr := worker.volumeClient.FindOrCreateVolumeForContainer(
+			ctx,
 			logger,
 			VolumeSpec{
 				Strategy:   baggageclaim.EmptyStrategy{},
@@ -444,6 +553,22 @@ func (worker *gardenWorker) createVolumes(
 		inputDestinationPaths[cleanedInputPath] = true
 
 		if found {
+			// lease the volume until its copy-on-write child exists, which
+			// then keeps it from being destroyed
+			release, leased, err := inputSourceVolume.Lease(logger)
+			if err != nil {
+				return nil, err
+			}
+
+			if !leased {
+				return nil, ErrCreatedVolumeNotFound{
+					Handle:     inputSourceVolume.Handle(),
+					WorkerName: inputSourceVolume.WorkerName(),
+				}
+			}
+
+			defer release()
+
 			localInputs = append(localInputs, mountableLocalInput{
 				desiredCOWParent: inputSourceVolume,
 				desiredMountPath: cleanedInputPath,
@@ -457,6 +582,7 @@ func (worker *gardenWorker) createVolumes(
 	}
 
 	cowMounts, err := worker.cloneLocalVolumes(
+		ctx,
 		logger,
 		spec.TeamID,
 		isPrivileged,
@@ -491,6 +617,7 @@ func (worker *gardenWorker) createVolumes(
 		}
 
 		outVolume, volumeErr := worker.volumeClient.FindOrCreateVolumeForContainer(
+			ctx,
 			logger,
 			VolumeSpec{
 				Strategy:   baggageclaim.EmptyStrategy{},
@@ -517,6 +644,7 @@ func (worker *gardenWorker) createVolumes(
 }
 
 func (worker *gardenWorker) cloneLocalVolumes(
+	ctx context.Context,
 	logger lager.Logger,
 	teamID int,
 	privileged bool,
@@ -527,6 +655,7 @@ func (worker *gardenWorker) cloneLocalVolumes(
 
 	for i, localInput := range locals {
 		inputVolume, err := worker.volumeClient.FindOrCreateCOWVolumeForContainer(
+			ctx,
 			logger,
 			VolumeSpec{
 				Strategy:   localInput.desiredCOWParent.COWStrategy(),
@@ -565,6 +694,7 @@ func (worker *gardenWorker) cloneRemoteVolumes(
 		// this is to ensure each go func gets its own non changing copy of the iterator
 		i, nonLocalInput := i, nonLocalInput
 		inputVolume, err := worker.volumeClient.FindOrCreateVolumeForContainer(
+			ctx,
 			logger,
 			VolumeSpec{
 				Strategy:   baggageclaim.EmptyStrategy{},
@@ -645,6 +775,10 @@ func (worker *gardenWorker) Tags() atc.Tags {
 	return worker.dbWorker.Tags()
 }
 
+func (worker *gardenWorker) Labels() atc.Labels {
+	return worker.dbWorker.Labels()
+}
+
 func (worker *gardenWorker) Ephemeral() bool {
 	return worker.dbWorker.Ephemeral()
 }
@@ -661,6 +795,10 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		return false
 	}
 
+	if spec.WorkerName != "" && spec.WorkerName != worker.Name() {
+		return false
+	}
+
 	if spec.ResourceType != "" {
 		underlyingType := determineUnderlyingTypeName(spec.ResourceType, spec.ResourceTypes)
 
@@ -687,6 +825,30 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		return false
 	}
 
+	if !worker.dbWorker.Labels().Matches(spec.Labels) {
+		return false
+	}
+
+	if spec.GPUs > 0 {
+		if lacksCapability(worker.dbWorker, atc.WorkerCapabilityGPUs) {
+			return false
+		}
+
+		if worker.dbWorker.GPUs() < spec.GPUs {
+			return false
+		}
+
+		available, err := worker.dbWorker.AvailableGPUs()
+		if err != nil {
+			logger.Error("failed-to-get-available-gpus", err)
+			return false
+		}
+
+		if available < spec.GPUs {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -714,6 +876,12 @@ func (worker *gardenWorker) Description() string {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	messages = append(messages, labelDescriptions(worker.dbWorker.Labels())...)
+
+	if worker.dbWorker.GPUs() > 0 {
+		messages = append(messages, fmt.Sprintf("%d gpus", worker.dbWorker.GPUs()))
+	}
+
 	return strings.Join(messages, ", ")
 }
 
@@ -721,6 +889,10 @@ func (worker *gardenWorker) IsOwnedByTeam() bool {
 	return worker.dbWorker.TeamID() != 0
 }
 
+func (worker *gardenWorker) TeamID() int {
+	return worker.dbWorker.TeamID()
+}
+
 func (worker *gardenWorker) Uptime() time.Duration {
 	return time.Since(worker.dbWorker.StartTime())
 }
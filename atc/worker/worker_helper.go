@@ -1,11 +1,18 @@
 package worker
 
 import (
+	"archive/tar"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker/gclient"
 )
@@ -20,9 +27,11 @@ type workerHelper struct {
 
 func (w workerHelper) createGardenContainer(
 	containerSpec ContainerSpec,
+	metadata db.ContainerMetadata,
 	fetchedImage FetchedImage,
 	handleToCreate string,
 	bindMounts []garden.BindMount,
+	gpus []int,
 ) (gclient.Container, error) {
 
 	gardenProperties := garden.Properties{}
@@ -33,6 +42,10 @@ func (w workerHelper) createGardenContainer(
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
+	if metadata.BuildID != 0 {
+		gardenProperties[atc.ContainerBuildIDProperty] = strconv.Itoa(metadata.BuildID)
+	}
+
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
 	if w.dbWorker.HTTPProxyURL() != "" {
@@ -47,7 +60,18 @@ func (w workerHelper) createGardenContainer(
 		env = append(env, fmt.Sprintf("no_proxy=%s", w.dbWorker.NoProxy()))
 	}
 
-	return w.gardenClient.Create(
+	// a GPU worker's containers are run by the NVIDIA runtime, which gives
+	// them the device nodes, and access to them, of the GPUs they're
+	// allocated and of no others
+	if w.dbWorker.GPUs() > 0 {
+		env = append(env, gpuEnv(gpus)...)
+	}
+
+	if containerSpec.CACerts != "" && !anyEnv("SSL_CERT_FILE", env) {
+		env = append(env, "SSL_CERT_FILE="+filepath.Join(caCertsDir, caCertsFile))
+	}
+
+	container, err := w.gardenClient.Create(
 		garden.ContainerSpec{
 			Handle:     handleToCreate,
 			RootFSPath: fetchedImage.URL,
@@ -57,6 +81,214 @@ func (w workerHelper) createGardenContainer(
 			Env:        env,
 			Properties: gardenProperties,
 		})
+	if err != nil {
+		return nil, err
+	}
+
+	if containerSpec.CACerts != "" {
+		err = streamInFile(container, caCertsDir, caCertsFile, caBundle(container, containerSpec.CACerts), "root", 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if containerSpec.DNS != nil {
+		err = streamInFile(container, "/etc", "resolv.conf", resolvConf(container, containerSpec.DNS), "root", 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// files are kept on the container's /dev/shm tmpfs, so that they're never
+	// written to the worker's disk, and linked to from their destinations.
+	// They're written as the container's user, so that they needn't be
+	// readable by anyone else.
+	fileUser := gardenProperties[userPropertyName]
+	if fileUser == "" {
+		fileUser = "root"
+	}
+
+	for i, path := range sortedFilePaths(containerSpec.Files) {
+		dest := path
+		if !filepath.IsAbs(dest) {
+			dest = filepath.Join(containerSpec.Dir, dest)
+		}
+
+		name := fmt.Sprintf("%d-%s", i, filepath.Base(dest))
+
+		err = streamInFile(container, filesDir, name, containerSpec.Files[path], fileUser, 0400)
+		if err != nil {
+			return nil, err
+		}
+
+		err = streamInSymlink(container, filepath.Dir(dest), filepath.Base(dest), filepath.Join(filesDir, name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return container, nil
+}
+
+// filesDir is where the files given to containers are kept. /dev/shm is a
+// tmpfs in every Linux container, writable by any user.
+const filesDir = "/dev/shm/concourse-files"
+
+// caCertsDir is where configured CA certificates are placed in containers.
+// It is kept apart from /etc/ssl/certs, which may be a read-only mount of
+// the worker's own certificates.
+const caCertsDir = "/etc/concourse/certs"
+const caCertsFile = "ca-certificates.crt"
+
+// systemCABundle is where most images keep their CA bundle.
+const systemCABundle = "/etc/ssl/certs/ca-certificates.crt"
+
+// caBundle returns the image's own CA bundle, if it has one, followed by the
+// configured certificates. SSL_CERT_FILE replaces rather than extends the
+// system bundle, so both must be in the one file.
+func caBundle(container gclient.Container, caCerts string) string {
+	systemCerts, err := readFile(container, systemCABundle)
+	if err != nil || systemCerts == "" {
+		return caCerts
+	}
+
+	if !strings.HasSuffix(systemCerts, "\n") {
+		systemCerts += "\n"
+	}
+
+	return systemCerts + caCerts
+}
+
+// resolvConf returns a resolv.conf for the given DNS config. If no servers are
+// given, the name servers the container was created with are kept.
+func resolvConf(container gclient.Container, dns *atc.ContainerDNS) string {
+	conf := ""
+
+	if len(dns.Search) > 0 {
+		conf += "search " + strings.Join(dns.Search, " ") + "\n"
+	}
+
+	for _, server := range dns.Servers {
+		conf += "nameserver " + server + "\n"
+	}
+
+	if len(dns.Servers) == 0 {
+		existing, err := readFile(container, "/etc/resolv.conf")
+		if err == nil {
+			for _, line := range strings.Split(existing, "\n") {
+				if strings.HasPrefix(strings.TrimSpace(line), "nameserver") {
+					conf += strings.TrimSpace(line) + "\n"
+				}
+			}
+		}
+	}
+
+	return conf
+}
+
+func sortedFilePaths(files map[string]string) []string {
+	paths := []string{}
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func streamInFile(container gclient.Container, dir string, name string, content string, user string, mode int64) error {
+	buf := new(bytes.Buffer)
+
+	tarWriter := tar.NewWriter(buf)
+
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: mode,
+		Size: int64(len(content)),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tarWriter.Write([]byte(content))
+	if err != nil {
+		return err
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return container.StreamIn(garden.StreamInSpec{
+		Path:      dir,
+		User:      user,
+		TarStream: buf,
+	})
+}
+
+func streamInSymlink(container gclient.Container, dir string, name string, target string) error {
+	buf := new(bytes.Buffer)
+
+	tarWriter := tar.NewWriter(buf)
+
+	err := tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeSymlink,
+		Name:     name,
+		Linkname: target,
+		Mode:     0777,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return container.StreamIn(garden.StreamInSpec{
+		Path:      dir,
+		User:      "root",
+		TarStream: buf,
+	})
+}
+
+func readFile(container gclient.Container, path string) (string, error) {
+	stream, err := container.StreamOut(garden.StreamOutSpec{
+		Path: path,
+		User: "root",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	defer stream.Close()
+
+	tarReader := tar.NewReader(stream)
+
+	_, err = tarReader.Next()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := ioutil.ReadAll(tarReader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+func anyEnv(name string, env []string) bool {
+	for _, e := range env {
+		if strings.HasPrefix(e, name+"=") {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (w workerHelper) constructGardenWorkerContainer(
@@ -111,3 +343,21 @@ func getDestinationPathsFromOutputs(outputs OutputPaths) []string {
 
 	return destinationPaths
 }
+
+// gpuEnv tells the NVIDIA runtime which of the worker's GPUs, by index, to
+// give a container.
+func gpuEnv(gpus []int) []string {
+	if len(gpus) == 0 {
+		return []string{"NVIDIA_VISIBLE_DEVICES=void"}
+	}
+
+	indices := make([]string, len(gpus))
+	for i, gpu := range gpus {
+		indices[i] = strconv.Itoa(gpu)
+	}
+
+	return []string{
+		"NVIDIA_VISIBLE_DEVICES=" + strings.Join(indices, ","),
+		"NVIDIA_DRIVER_CAPABILITIES=compute,utility",
+	}
+}
@@ -1,6 +1,7 @@
 package worker_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"errors"
@@ -49,6 +50,7 @@ var _ = Describe("Worker", func() {
 		fakeGardenContainer       *gclientfakes.FakeContainer
 		fakeImageFetchingDelegate *workerfakes.FakeImageFetchingDelegate
 		fakeBaggageclaimClient    *baggageclaimfakes.FakeClient
+		fakeCreationLimiter       *workerfakes.FakeCreationLimiter
 
 		fakeLocalInput    *workerfakes.FakeInputSource
 		fakeRemoteInput   *workerfakes.FakeInputSource
@@ -58,6 +60,7 @@ var _ = Describe("Worker", func() {
 
 		fakeRemoteInputContainerVolume *workerfakes.FakeVolume
 		fakeLocalVolume                *workerfakes.FakeVolume
+		localVolumeReleased            bool
 		fakeOutputVolume               *workerfakes.FakeVolume
 		fakeLocalCOWVolume             *workerfakes.FakeVolume
 
@@ -77,6 +80,8 @@ var _ = Describe("Worker", func() {
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeVolumeClient = new(workerfakes.FakeVolumeClient)
+		fakeCreationLimiter = new(workerfakes.FakeCreationLimiter)
+		fakeCreationLimiter.AcquireReturns(func() {}, nil)
 		activeContainers = 42
 		resourceTypes = []atc.WorkerResourceType{
 			{
@@ -121,6 +126,8 @@ var _ = Describe("Worker", func() {
 		fakeLocalVolume.COWStrategyReturns(baggageclaim.COWStrategy{
 			Parent: new(baggageclaimfakes.FakeVolume),
 		})
+		localVolumeReleased = false
+		fakeLocalVolume.LeaseReturns(func() { localVolumeReleased = true }, true, nil)
 		fakeLocalInputAS.VolumeOnReturns(fakeLocalVolume, true, nil)
 		fakeLocalInput.SourceReturns(fakeLocalInputAS)
 
@@ -162,7 +169,7 @@ var _ = Describe("Worker", func() {
 
 		volumeSpecs = map[string]VolumeSpec{}
 
-		fakeVolumeClient.FindOrCreateCOWVolumeForContainerStub = func(logger lager.Logger, volumeSpec VolumeSpec, creatingContainer db.CreatingContainer, volume Volume, teamID int, mountPath string) (Volume, error) {
+		fakeVolumeClient.FindOrCreateCOWVolumeForContainerStub = func(_ context.Context, logger lager.Logger, volumeSpec VolumeSpec, creatingContainer db.CreatingContainer, volume Volume, teamID int, mountPath string) (Volume, error) {
 			Expect(volume).To(Equal(fakeLocalVolume))
 
 			volume, found := stubbedVolumes[mountPath]
@@ -175,7 +182,7 @@ var _ = Describe("Worker", func() {
 			return volume, nil
 		}
 
-		fakeVolumeClient.FindOrCreateVolumeForContainerStub = func(logger lager.Logger, volumeSpec VolumeSpec, creatingContainer db.CreatingContainer, teamID int, mountPath string) (Volume, error) {
+		fakeVolumeClient.FindOrCreateVolumeForContainerStub = func(_ context.Context, logger lager.Logger, volumeSpec VolumeSpec, creatingContainer db.CreatingContainer, teamID int, mountPath string) (Volume, error) {
 			volume, found := stubbedVolumes[mountPath]
 			if !found {
 				panic("unknown container volume: " + mountPath)
@@ -269,6 +276,7 @@ var _ = Describe("Worker", func() {
 			fakeDBTeamFactory,
 			fakeDBWorker,
 			0,
+			fakeCreationLimiter,
 		)
 	})
 
@@ -301,6 +309,26 @@ var _ = Describe("Worker", func() {
 			).To(BeFalse())
 		})
 
+		Context("when the worker negotiated its protocol", func() {
+			BeforeEach(func() {
+				fakeDBWorker.ProtocolVersionReturns(atc.WorkerProtocolVersion)
+			})
+
+			It("is compatible when worker minor version is older", func() {
+				requiredVersion := version.MustNewVersionFromString("1.3.3")
+				Expect(
+					gardenWorker.IsVersionCompatible(logger, requiredVersion),
+				).To(BeTrue())
+			})
+
+			It("is not compatible when versions are different in major version", func() {
+				requiredVersion := version.MustNewVersionFromString("2.2.3")
+				Expect(
+					gardenWorker.IsVersionCompatible(logger, requiredVersion),
+				).To(BeFalse())
+			})
+		})
+
 		Context("when worker version is empty", func() {
 			BeforeEach(func() {
 				workerVersion = ""
@@ -563,7 +591,7 @@ var _ = Describe("Worker", func() {
 		})
 
 		JustBeforeEach(func() {
-			volume, err = gardenWorker.CreateVolume(logger, VolumeSpec{}, 42, db.VolumeTypeArtifact)
+			volume, err = gardenWorker.CreateVolume(context.Background(), logger, VolumeSpec{}, 42, db.VolumeTypeArtifact)
 		})
 
 		It("calls the volume client", func() {
@@ -696,6 +724,121 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when labels are requested", func() {
+			BeforeEach(func() {
+				fakeDBWorker.LabelsReturns(atc.Labels{"zone": "us-east-1", "gpu": "true"})
+			})
+
+			Context("when the worker has the labels", func() {
+				BeforeEach(func() {
+					spec.Labels = atc.Labels{"zone": "us-east-1"}
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker has a label with a different value", func() {
+				BeforeEach(func() {
+					spec.Labels = atc.Labels{"zone": "us-west-1"}
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the worker does not have a label", func() {
+				BeforeEach(func() {
+					spec.Labels = atc.Labels{"instance-type": "m5.large"}
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when a worker is named", func() {
+			Context("when it is the worker", func() {
+				BeforeEach(func() {
+					spec.WorkerName = "some-worker"
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when it is another worker", func() {
+				BeforeEach(func() {
+					spec.WorkerName = "some-other-worker"
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when gpus are requested", func() {
+			BeforeEach(func() {
+				spec.GPUs = 2
+				fakeDBWorker.GPUsReturns(4)
+				fakeDBWorker.AvailableGPUsReturns(2, nil)
+			})
+
+			Context("when enough of the worker's gpus are available", func() {
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when too few of the worker's gpus are available", func() {
+				BeforeEach(func() {
+					fakeDBWorker.AvailableGPUsReturns(1, nil)
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the worker has too few gpus", func() {
+				BeforeEach(func() {
+					fakeDBWorker.GPUsReturns(1)
+				})
+
+				It("returns false without checking their allocations", func() {
+					Expect(satisfies).To(BeFalse())
+					Expect(fakeDBWorker.AvailableGPUsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the worker negotiated its protocol without gpus", func() {
+				BeforeEach(func() {
+					fakeDBWorker.ProtocolVersionReturns(atc.WorkerProtocolVersion)
+					fakeDBWorker.CapabilitiesReturns([]atc.WorkerCapability{atc.WorkerCapabilityZstdStreaming})
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the worker negotiated its protocol with gpus", func() {
+				BeforeEach(func() {
+					fakeDBWorker.ProtocolVersionReturns(atc.WorkerProtocolVersion)
+					fakeDBWorker.CapabilitiesReturns([]atc.WorkerCapability{atc.WorkerCapabilityGPUs})
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+		})
+
 		Context("when the platform is incompatible", func() {
 			BeforeEach(func() {
 				spec.Platform = "some-bogus-platform"
@@ -922,6 +1065,10 @@ var _ = Describe("Worker", func() {
 				It("returns worker container", func() {
 					Expect(findOrCreateContainer).ToNot(BeNil())
 				})
+
+				It("does not tell the delegate the container was prepared", func() {
+					Expect(fakeImageFetchingDelegate.ContainerPreparedCallCount()).To(BeZero())
+				})
 			})
 
 			Context("when container does not exist in garden", func() {
@@ -942,6 +1089,10 @@ var _ = Describe("Worker", func() {
 					Expect(findOrCreateContainer).ToNot(BeNil())
 				})
 
+				It("tells the delegate the container was prepared", func() {
+					Expect(fakeImageFetchingDelegate.ContainerPreparedCallCount()).To(Equal(1))
+				})
+
 				It("creates the container in garden with the input and output volumes in alphabetical order", func() {
 					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
 
@@ -1361,6 +1512,28 @@ var _ = Describe("Worker", func() {
 					}))
 				})
 
+				It("leases local inputs until their copy-on-write volumes are created", func() {
+					Expect(fakeLocalVolume.LeaseCallCount()).To(Equal(1))
+					Expect(localVolumeReleased).To(BeTrue())
+				})
+
+				Context("when a local input is being destroyed", func() {
+					BeforeEach(func() {
+						fakeLocalVolume.HandleReturns("some-local-volume")
+						fakeLocalVolume.WorkerNameReturns("some-worker")
+						fakeLocalVolume.LeaseReturns(nil, false, nil)
+					})
+
+					It("returns an error without creating a volume from it", func() {
+						Expect(findOrCreateErr).To(Equal(ErrCreatedVolumeNotFound{
+							Handle:     "some-local-volume",
+							WorkerName: "some-worker",
+						}))
+
+						Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(BeZero())
+					})
+				})
+
 				It("streams remote inputs into newly created container volumes", func() {
 					Expect(fakeRemoteInputAS.StreamToCallCount()).To(Equal(1))
 					_, _, ad := fakeRemoteInputAS.StreamToArgsForCall(0)
@@ -1379,6 +1552,222 @@ var _ = Describe("Worker", func() {
 					Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
 				})
 
+				It("does not stream anything into the container", func() {
+					Expect(fakeGardenContainer.StreamInCallCount()).To(BeZero())
+				})
+
+				Context("when CA certs are given", func() {
+					var streamedInBundle = func() []byte {
+						Expect(fakeGardenContainer.StreamInCallCount()).To(Equal(1))
+
+						spec := fakeGardenContainer.StreamInArgsForCall(0)
+						Expect(spec.Path).To(Equal("/etc/concourse/certs"))
+
+						tarReader := tar.NewReader(spec.TarStream)
+
+						header, err := tarReader.Next()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(header.Name).To(Equal("ca-certificates.crt"))
+
+						bundle, err := ioutil.ReadAll(tarReader)
+						Expect(err).ToNot(HaveOccurred())
+						return bundle
+					}
+
+					BeforeEach(func() {
+						containerSpec.CACerts = "some-ca-cert\n"
+						fakeGardenContainer.StreamOutReturns(nil, errors.New("no such file"))
+					})
+
+					It("points the container at them", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Env).To(ContainElement("SSL_CERT_FILE=/etc/concourse/certs/ca-certificates.crt"))
+					})
+
+					It("streams them into the container", func() {
+						Expect(streamedInBundle()).To(Equal([]byte("some-ca-cert\n")))
+					})
+
+					Context("when the image has a CA bundle", func() {
+						BeforeEach(func() {
+							buf := new(bytes.Buffer)
+							tarWriter := tar.NewWriter(buf)
+							err := tarWriter.WriteHeader(&tar.Header{Name: "ca-certificates.crt", Mode: 0644, Size: 11})
+							Expect(err).ToNot(HaveOccurred())
+							_, err = tarWriter.Write([]byte("system-cert"))
+							Expect(err).ToNot(HaveOccurred())
+							Expect(tarWriter.Close()).To(Succeed())
+
+							fakeGardenContainer.StreamOutReturns(ioutil.NopCloser(buf), nil)
+						})
+
+						It("reads it from the image", func() {
+							Expect(fakeGardenContainer.StreamOutCallCount()).To(Equal(1))
+							Expect(fakeGardenContainer.StreamOutArgsForCall(0).Path).To(Equal("/etc/ssl/certs/ca-certificates.crt"))
+						})
+
+						It("streams in a bundle of the image's certs followed by them", func() {
+							Expect(streamedInBundle()).To(Equal([]byte("system-cert\nsome-ca-cert\n")))
+						})
+					})
+
+					Context("when streaming them in fails", func() {
+						BeforeEach(func() {
+							fakeGardenContainer.StreamInReturns(errors.New("nope"))
+						})
+
+						It("returns the error", func() {
+							Expect(findOrCreateErr).To(MatchError("nope"))
+						})
+					})
+				})
+
+				Context("when DNS config is given", func() {
+					BeforeEach(func() {
+						containerSpec.DNS = &atc.ContainerDNS{
+							Servers: []string{"10.0.0.2", "10.0.0.3"},
+							Search:  []string{"corp.example.com", "example.com"},
+						}
+					})
+
+					It("streams a resolv.conf into the container", func() {
+						Expect(fakeGardenContainer.StreamInCallCount()).To(Equal(1))
+
+						spec := fakeGardenContainer.StreamInArgsForCall(0)
+						Expect(spec.Path).To(Equal("/etc"))
+
+						tarReader := tar.NewReader(spec.TarStream)
+
+						header, err := tarReader.Next()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(header.Name).To(Equal("resolv.conf"))
+						Expect(ioutil.ReadAll(tarReader)).To(Equal([]byte(
+							"search corp.example.com example.com\n" +
+								"nameserver 10.0.0.2\n" +
+								"nameserver 10.0.0.3\n",
+						)))
+					})
+
+					It("does not read the container's resolv.conf", func() {
+						Expect(fakeGardenContainer.StreamOutCallCount()).To(BeZero())
+					})
+
+					Context("when only search domains are given", func() {
+						BeforeEach(func() {
+							containerSpec.DNS = &atc.ContainerDNS{
+								Search: []string{"corp.example.com"},
+							}
+
+							existing := "search worker.example.com\nnameserver 10.0.0.1\noptions ndots:1\n"
+
+							buf := new(bytes.Buffer)
+							tarWriter := tar.NewWriter(buf)
+							err := tarWriter.WriteHeader(&tar.Header{Name: "resolv.conf", Mode: 0644, Size: int64(len(existing))})
+							Expect(err).ToNot(HaveOccurred())
+							_, err = tarWriter.Write([]byte(existing))
+							Expect(err).ToNot(HaveOccurred())
+							Expect(tarWriter.Close()).To(Succeed())
+
+							fakeGardenContainer.StreamOutReturns(ioutil.NopCloser(buf), nil)
+						})
+
+						It("keeps the container's name servers", func() {
+							Expect(fakeGardenContainer.StreamOutCallCount()).To(Equal(1))
+							Expect(fakeGardenContainer.StreamOutArgsForCall(0).Path).To(Equal("/etc/resolv.conf"))
+
+							spec := fakeGardenContainer.StreamInArgsForCall(0)
+							tarReader := tar.NewReader(spec.TarStream)
+
+							_, err := tarReader.Next()
+							Expect(err).ToNot(HaveOccurred())
+							Expect(ioutil.ReadAll(tarReader)).To(Equal([]byte(
+								"search corp.example.com\n" +
+									"nameserver 10.0.0.1\n",
+							)))
+						})
+					})
+				})
+
+				Context("when files are given", func() {
+					BeforeEach(func() {
+						containerSpec.Files = map[string]string{
+							"secrets/token":  "some-token",
+							"/etc/some-cert": "some-cert",
+						}
+					})
+
+					It("streams them onto the container's tmpfs as its user, readable only by it", func() {
+						Expect(fakeGardenContainer.StreamInCallCount()).To(Equal(4))
+
+						spec := fakeGardenContainer.StreamInArgsForCall(0)
+						Expect(spec.Path).To(Equal("/dev/shm/concourse-files"))
+						Expect(spec.User).To(Equal("some-user"))
+
+						tarReader := tar.NewReader(spec.TarStream)
+
+						header, err := tarReader.Next()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(header.Name).To(Equal("0-some-cert"))
+						Expect(header.Mode).To(Equal(int64(0400)))
+						Expect(ioutil.ReadAll(tarReader)).To(Equal([]byte("some-cert")))
+
+						spec = fakeGardenContainer.StreamInArgsForCall(2)
+						Expect(spec.Path).To(Equal("/dev/shm/concourse-files"))
+						Expect(spec.User).To(Equal("some-user"))
+
+						tarReader = tar.NewReader(spec.TarStream)
+
+						header, err = tarReader.Next()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(header.Name).To(Equal("1-token"))
+						Expect(header.Mode).To(Equal(int64(0400)))
+						Expect(ioutil.ReadAll(tarReader)).To(Equal([]byte("some-token")))
+					})
+
+					It("links to them from their destinations", func() {
+						spec := fakeGardenContainer.StreamInArgsForCall(1)
+						Expect(spec.Path).To(Equal("/etc"))
+
+						header, err := tar.NewReader(spec.TarStream).Next()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(header.Typeflag).To(Equal(byte(tar.TypeSymlink)))
+						Expect(header.Name).To(Equal("some-cert"))
+						Expect(header.Linkname).To(Equal("/dev/shm/concourse-files/0-some-cert"))
+
+						spec = fakeGardenContainer.StreamInArgsForCall(3)
+						Expect(spec.Path).To(Equal("/some/work-dir/secrets"))
+
+						header, err = tar.NewReader(spec.TarStream).Next()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(header.Typeflag).To(Equal(byte(tar.TypeSymlink)))
+						Expect(header.Name).To(Equal("token"))
+						Expect(header.Linkname).To(Equal("/dev/shm/concourse-files/1-token"))
+					})
+
+					It("does not set them in the container's environment", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Env).ToNot(ContainElement(ContainSubstring("some-token")))
+					})
+				})
+
+				Context("when the container is for a build", func() {
+					BeforeEach(func() {
+						containerMetadata.BuildID = 42
+					})
+
+					It("labels the garden container with the build", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":                       "some-user",
+							atc.ContainerBuildIDProperty: "42",
+						}))
+					})
+				})
+
 				Context("when the fetched image was privileged", func() {
 					BeforeEach(func() {
 						fakeImage.FetchForContainerReturns(FetchedImage{
@@ -1407,6 +1796,55 @@ var _ = Describe("Worker", func() {
 						}))
 					})
 
+					Context("when the team's privileged policy does not allow the job", func() {
+						BeforeEach(func() {
+							containerMetadata.PipelineName = "some-pipeline"
+							containerMetadata.JobName = "some-job"
+
+							fakeDBTeam.NameReturns("some-team")
+							fakeDBTeam.PrivilegedPolicyReturns(&atc.PrivilegedPolicy{
+								Allow: []string{"other-pipeline"},
+							})
+							fakeDBTeamFactory.FindTeamByIDReturns(fakeDBTeam, true, nil)
+						})
+
+						It("looks up the container's team", func() {
+							Expect(fakeDBTeamFactory.FindTeamByIDCallCount()).To(Equal(1))
+							Expect(fakeDBTeamFactory.FindTeamByIDArgsForCall(0)).To(Equal(73410))
+						})
+
+						It("returns an error without creating the container", func() {
+							Expect(findOrCreateErr).To(Equal(atc.PrivilegedNotAllowedError{
+								Team:     "some-team",
+								Pipeline: "some-pipeline",
+								Job:      "some-job",
+								Step:     "some-step",
+							}))
+							Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+						})
+
+						It("marks the container as failed", func() {
+							Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when the team's privileged policy allows the job", func() {
+						BeforeEach(func() {
+							containerMetadata.PipelineName = "some-pipeline"
+							containerMetadata.JobName = "some-job"
+
+							fakeDBTeam.PrivilegedPolicyReturns(&atc.PrivilegedPolicy{
+								Allow: []string{"some-pipeline/some-*"},
+							})
+							fakeDBTeamFactory.FindTeamByIDReturns(fakeDBTeam, true, nil)
+						})
+
+						It("creates the container privileged", func() {
+							Expect(findOrCreateErr).ToNot(HaveOccurred())
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+							Expect(fakeGardenClient.CreateArgsForCall(0).Privileged).To(BeTrue())
+						})
+					})
 				})
 
 				Context("when an input has the path set to the workdir itself", func() {
@@ -1450,6 +1888,52 @@ var _ = Describe("Worker", func() {
 					})
 				})
 
+				Context("when the worker has gpus", func() {
+					BeforeEach(func() {
+						fakeDBWorker.GPUsReturns(4)
+					})
+
+					It("gives the container none of them", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Env).To(ContainElement("NVIDIA_VISIBLE_DEVICES=void"))
+					})
+				})
+
+				Context("when the container requests gpus", func() {
+					BeforeEach(func() {
+						fakeDBWorker.GPUsReturns(4)
+						containerSpec.GPUs = 2
+						fakeCreatingContainer.AllocateGPUsReturns([]int{1, 3}, nil)
+					})
+
+					It("allocates them to the container", func() {
+						Expect(fakeCreatingContainer.AllocateGPUsCallCount()).To(Equal(1))
+						Expect(fakeCreatingContainer.AllocateGPUsArgsForCall(0)).To(Equal(2))
+					})
+
+					It("gives the container their devices through the nvidia runtime", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Env).To(ContainElement("NVIDIA_VISIBLE_DEVICES=1,3"))
+						Expect(actualSpec.Env).To(ContainElement("NVIDIA_DRIVER_CAPABILITIES=compute,utility"))
+					})
+
+					Context("when not enough gpus are available", func() {
+						BeforeEach(func() {
+							fakeCreatingContainer.AllocateGPUsReturns(nil, db.ErrNotEnoughGPUs)
+						})
+
+						It("marks the container as failed without creating it", func() {
+							Expect(findOrCreateErr).To(Equal(db.ErrNotEnoughGPUs))
+							Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+							Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("when failing to create container in garden", func() {
 					BeforeEach(func() {
 						fakeGardenClient.CreateReturns(nil, disasterErr)
@@ -1481,6 +1965,27 @@ var _ = Describe("Worker", func() {
 						Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(0))
 					})
 				})
+
+				It("creates the container in garden while holding a creation slot on the worker", func() {
+					Expect(fakeCreationLimiter.AcquireCallCount()).To(Equal(1))
+					_, _, name := fakeCreationLimiter.AcquireArgsForCall(0)
+					Expect(name).To(Equal(workerName))
+				})
+
+				Context("when acquiring a creation slot fails", func() {
+					BeforeEach(func() {
+						fakeCreationLimiter.AcquireReturns(nil, context.Canceled)
+					})
+
+					It("returns the error without creating the container", func() {
+						Expect(findOrCreateErr).To(Equal(context.Canceled))
+						Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+					})
+
+					It("marks the container as failed", func() {
+						Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+					})
+				})
 			})
 
 		})
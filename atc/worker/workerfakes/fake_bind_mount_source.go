@@ -2,6 +2,7 @@
 package workerfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/garden"
@@ -9,10 +10,11 @@ import (
 )
 
 type FakeBindMountSource struct {
-	VolumeOnStub        func(worker.Worker) (garden.BindMount, bool, error)
+	VolumeOnStub        func(context.Context, worker.Worker) (garden.BindMount, bool, error)
 	volumeOnMutex       sync.RWMutex
 	volumeOnArgsForCall []struct {
-		arg1 worker.Worker
+		arg1 context.Context
+		arg2 worker.Worker
 	}
 	volumeOnReturns struct {
 		result1 garden.BindMount
@@ -28,16 +30,17 @@ type FakeBindMountSource struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBindMountSource) VolumeOn(arg1 worker.Worker) (garden.BindMount, bool, error) {
+func (fake *FakeBindMountSource) VolumeOn(arg1 context.Context, arg2 worker.Worker) (garden.BindMount, bool, error) {
 	fake.volumeOnMutex.Lock()
 	ret, specificReturn := fake.volumeOnReturnsOnCall[len(fake.volumeOnArgsForCall)]
 	fake.volumeOnArgsForCall = append(fake.volumeOnArgsForCall, struct {
-		arg1 worker.Worker
-	}{arg1})
-	fake.recordInvocation("VolumeOn", []interface{}{arg1})
+		arg1 context.Context
+		arg2 worker.Worker
+	}{arg1, arg2})
+	fake.recordInvocation("VolumeOn", []interface{}{arg1, arg2})
 	fake.volumeOnMutex.Unlock()
 	if fake.VolumeOnStub != nil {
-		return fake.VolumeOnStub(arg1)
+		return fake.VolumeOnStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -52,17 +55,17 @@ func (fake *FakeBindMountSource) VolumeOnCallCount() int {
 	return len(fake.volumeOnArgsForCall)
 }
 
-func (fake *FakeBindMountSource) VolumeOnCalls(stub func(worker.Worker) (garden.BindMount, bool, error)) {
+func (fake *FakeBindMountSource) VolumeOnCalls(stub func(context.Context, worker.Worker) (garden.BindMount, bool, error)) {
 	fake.volumeOnMutex.Lock()
 	defer fake.volumeOnMutex.Unlock()
 	fake.VolumeOnStub = stub
 }
 
-func (fake *FakeBindMountSource) VolumeOnArgsForCall(i int) worker.Worker {
+func (fake *FakeBindMountSource) VolumeOnArgsForCall(i int) (context.Context, worker.Worker) {
 	fake.volumeOnMutex.RLock()
 	defer fake.volumeOnMutex.RUnlock()
 	argsForCall := fake.volumeOnArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeBindMountSource) VolumeOnReturns(result1 garden.BindMount, result2 bool, result3 error) {
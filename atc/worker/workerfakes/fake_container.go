@@ -192,6 +192,16 @@ type FakeContainer struct {
 		result1 string
 		result2 error
 	}
+	ReleaseGPUsStub        func() error
+	releaseGPUsMutex       sync.RWMutex
+	releaseGPUsArgsForCall []struct {
+	}
+	releaseGPUsReturns struct {
+		result1 error
+	}
+	releaseGPUsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemovePropertyStub        func(string) error
 	removePropertyMutex       sync.RWMutex
 	removePropertyArgsForCall []struct {
@@ -1161,6 +1171,58 @@ func (fake *FakeContainer) PropertyReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
+func (fake *FakeContainer) ReleaseGPUs() error {
+	fake.releaseGPUsMutex.Lock()
+	ret, specificReturn := fake.releaseGPUsReturnsOnCall[len(fake.releaseGPUsArgsForCall)]
+	fake.releaseGPUsArgsForCall = append(fake.releaseGPUsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReleaseGPUs", []interface{}{})
+	fake.releaseGPUsMutex.Unlock()
+	if fake.ReleaseGPUsStub != nil {
+		return fake.ReleaseGPUsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.releaseGPUsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainer) ReleaseGPUsCallCount() int {
+	fake.releaseGPUsMutex.RLock()
+	defer fake.releaseGPUsMutex.RUnlock()
+	return len(fake.releaseGPUsArgsForCall)
+}
+
+func (fake *FakeContainer) ReleaseGPUsCalls(stub func() error) {
+	fake.releaseGPUsMutex.Lock()
+	defer fake.releaseGPUsMutex.Unlock()
+	fake.ReleaseGPUsStub = stub
+}
+
+func (fake *FakeContainer) ReleaseGPUsReturns(result1 error) {
+	fake.releaseGPUsMutex.Lock()
+	defer fake.releaseGPUsMutex.Unlock()
+	fake.ReleaseGPUsStub = nil
+	fake.releaseGPUsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainer) ReleaseGPUsReturnsOnCall(i int, result1 error) {
+	fake.releaseGPUsMutex.Lock()
+	defer fake.releaseGPUsMutex.Unlock()
+	fake.ReleaseGPUsStub = nil
+	if fake.releaseGPUsReturnsOnCall == nil {
+		fake.releaseGPUsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseGPUsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainer) RemoveProperty(arg1 string) error {
 	fake.removePropertyMutex.Lock()
 	ret, specificReturn := fake.removePropertyReturnsOnCall[len(fake.removePropertyArgsForCall)]
@@ -1727,6 +1789,8 @@ func (fake *FakeContainer) Invocations() map[string][][]interface{} {
 	defer fake.propertiesMutex.RUnlock()
 	fake.propertyMutex.RLock()
 	defer fake.propertyMutex.RUnlock()
+	fake.releaseGPUsMutex.RLock()
+	defer fake.releaseGPUsMutex.RUnlock()
 	fake.removePropertyMutex.RLock()
 	defer fake.removePropertyMutex.RUnlock()
 	fake.runMutex.RLock()
@@ -71,10 +71,11 @@ type FakeVolume struct {
 		result1 db.WorkerArtifact
 		result2 error
 	}
-	InitializeResourceCacheStub        func(db.UsedResourceCache) error
+	InitializeResourceCacheStub        func(lager.Logger, db.UsedResourceCache) error
 	initializeResourceCacheMutex       sync.RWMutex
 	initializeResourceCacheArgsForCall []struct {
-		arg1 db.UsedResourceCache
+		arg1 lager.Logger
+		arg2 db.UsedResourceCache
 	}
 	initializeResourceCacheReturns struct {
 		result1 error
@@ -82,14 +83,15 @@ type FakeVolume struct {
 	initializeResourceCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InitializeTaskCacheStub        func(lager.Logger, int, string, string, bool) error
+	InitializeTaskCacheStub        func(context.Context, lager.Logger, int, string, string, bool) error
 	initializeTaskCacheMutex       sync.RWMutex
 	initializeTaskCacheArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 int
-		arg3 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int
 		arg4 string
-		arg5 bool
+		arg5 string
+		arg6 bool
 	}
 	initializeTaskCacheReturns struct {
 		result1 error
@@ -97,6 +99,21 @@ type FakeVolume struct {
 	initializeTaskCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	LeaseStub        func(lager.Logger) (func(), bool, error)
+	leaseMutex       sync.RWMutex
+	leaseArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	leaseReturns struct {
+		result1 func()
+		result2 bool
+		result3 error
+	}
+	leaseReturnsOnCall map[int]struct {
+		result1 func()
+		result2 bool
+		result3 error
+	}
 	PathStub        func() string
 	pathMutex       sync.RWMutex
 	pathArgsForCall []struct {
@@ -467,16 +484,17 @@ func (fake *FakeVolume) InitializeArtifactReturnsOnCall(i int, result1 db.Worker
 	}{result1, result2}
 }
 
-func (fake *FakeVolume) InitializeResourceCache(arg1 db.UsedResourceCache) error {
+func (fake *FakeVolume) InitializeResourceCache(arg1 lager.Logger, arg2 db.UsedResourceCache) error {
 	fake.initializeResourceCacheMutex.Lock()
 	ret, specificReturn := fake.initializeResourceCacheReturnsOnCall[len(fake.initializeResourceCacheArgsForCall)]
 	fake.initializeResourceCacheArgsForCall = append(fake.initializeResourceCacheArgsForCall, struct {
-		arg1 db.UsedResourceCache
-	}{arg1})
-	fake.recordInvocation("InitializeResourceCache", []interface{}{arg1})
+		arg1 lager.Logger
+		arg2 db.UsedResourceCache
+	}{arg1, arg2})
+	fake.recordInvocation("InitializeResourceCache", []interface{}{arg1, arg2})
 	fake.initializeResourceCacheMutex.Unlock()
 	if fake.InitializeResourceCacheStub != nil {
-		return fake.InitializeResourceCacheStub(arg1)
+		return fake.InitializeResourceCacheStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -491,17 +509,17 @@ func (fake *FakeVolume) InitializeResourceCacheCallCount() int {
 	return len(fake.initializeResourceCacheArgsForCall)
 }
 
-func (fake *FakeVolume) InitializeResourceCacheCalls(stub func(db.UsedResourceCache) error) {
+func (fake *FakeVolume) InitializeResourceCacheCalls(stub func(lager.Logger, db.UsedResourceCache) error) {
 	fake.initializeResourceCacheMutex.Lock()
 	defer fake.initializeResourceCacheMutex.Unlock()
 	fake.InitializeResourceCacheStub = stub
 }
 
-func (fake *FakeVolume) InitializeResourceCacheArgsForCall(i int) db.UsedResourceCache {
+func (fake *FakeVolume) InitializeResourceCacheArgsForCall(i int) (lager.Logger, db.UsedResourceCache) {
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	argsForCall := fake.initializeResourceCacheArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeVolume) InitializeResourceCacheReturns(result1 error) {
@@ -527,20 +545,21 @@ func (fake *FakeVolume) InitializeResourceCacheReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
-func (fake *FakeVolume) InitializeTaskCache(arg1 lager.Logger, arg2 int, arg3 string, arg4 string, arg5 bool) error {
+func (fake *FakeVolume) InitializeTaskCache(arg1 context.Context, arg2 lager.Logger, arg3 int, arg4 string, arg5 string, arg6 bool) error {
 	fake.initializeTaskCacheMutex.Lock()
 	ret, specificReturn := fake.initializeTaskCacheReturnsOnCall[len(fake.initializeTaskCacheArgsForCall)]
 	fake.initializeTaskCacheArgsForCall = append(fake.initializeTaskCacheArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 int
-		arg3 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int
 		arg4 string
-		arg5 bool
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("InitializeTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg5 string
+		arg6 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("InitializeTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.initializeTaskCacheMutex.Unlock()
 	if fake.InitializeTaskCacheStub != nil {
-		return fake.InitializeTaskCacheStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.InitializeTaskCacheStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -555,17 +574,17 @@ func (fake *FakeVolume) InitializeTaskCacheCallCount() int {
 	return len(fake.initializeTaskCacheArgsForCall)
 }
 
-func (fake *FakeVolume) InitializeTaskCacheCalls(stub func(lager.Logger, int, string, string, bool) error) {
+func (fake *FakeVolume) InitializeTaskCacheCalls(stub func(context.Context, lager.Logger, int, string, string, bool) error) {
 	fake.initializeTaskCacheMutex.Lock()
 	defer fake.initializeTaskCacheMutex.Unlock()
 	fake.InitializeTaskCacheStub = stub
 }
 
-func (fake *FakeVolume) InitializeTaskCacheArgsForCall(i int) (lager.Logger, int, string, string, bool) {
+func (fake *FakeVolume) InitializeTaskCacheArgsForCall(i int) (context.Context, lager.Logger, int, string, string, bool) {
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
 	argsForCall := fake.initializeTaskCacheArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeVolume) InitializeTaskCacheReturns(result1 error) {
@@ -591,6 +610,72 @@ func (fake *FakeVolume) InitializeTaskCacheReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) Lease(arg1 lager.Logger) (func(), bool, error) {
+	fake.leaseMutex.Lock()
+	ret, specificReturn := fake.leaseReturnsOnCall[len(fake.leaseArgsForCall)]
+	fake.leaseArgsForCall = append(fake.leaseArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("Lease", []interface{}{arg1})
+	fake.leaseMutex.Unlock()
+	if fake.LeaseStub != nil {
+		return fake.LeaseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.leaseReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolume) LeaseCallCount() int {
+	fake.leaseMutex.RLock()
+	defer fake.leaseMutex.RUnlock()
+	return len(fake.leaseArgsForCall)
+}
+
+func (fake *FakeVolume) LeaseCalls(stub func(lager.Logger) (func(), bool, error)) {
+	fake.leaseMutex.Lock()
+	defer fake.leaseMutex.Unlock()
+	fake.LeaseStub = stub
+}
+
+func (fake *FakeVolume) LeaseArgsForCall(i int) lager.Logger {
+	fake.leaseMutex.RLock()
+	defer fake.leaseMutex.RUnlock()
+	argsForCall := fake.leaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolume) LeaseReturns(result1 func(), result2 bool, result3 error) {
+	fake.leaseMutex.Lock()
+	defer fake.leaseMutex.Unlock()
+	fake.LeaseStub = nil
+	fake.leaseReturns = struct {
+		result1 func()
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolume) LeaseReturnsOnCall(i int, result1 func(), result2 bool, result3 error) {
+	fake.leaseMutex.Lock()
+	defer fake.leaseMutex.Unlock()
+	fake.LeaseStub = nil
+	if fake.leaseReturnsOnCall == nil {
+		fake.leaseReturnsOnCall = make(map[int]struct {
+			result1 func()
+			result2 bool
+			result3 error
+		})
+	}
+	fake.leaseReturnsOnCall[i] = struct {
+		result1 func()
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolume) Path() string {
 	fake.pathMutex.Lock()
 	ret, specificReturn := fake.pathReturnsOnCall[len(fake.pathArgsForCall)]
@@ -1014,6 +1099,8 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
+	fake.leaseMutex.RLock()
+	defer fake.leaseMutex.RUnlock()
 	fake.pathMutex.RLock()
 	defer fake.pathMutex.RUnlock()
 	fake.propertiesMutex.RLock()
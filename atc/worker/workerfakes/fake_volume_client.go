@@ -2,22 +2,26 @@
 package workerfakes
 
 import (
+	"context"
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
 )
 
 type FakeVolumeClient struct {
-	CreateVolumeStub        func(lager.Logger, worker.VolumeSpec, int, string, db.VolumeType) (worker.Volume, error)
+	CreateVolumeStub        func(context.Context, lager.Logger, worker.VolumeSpec, int, string, db.VolumeType) (worker.Volume, error)
 	createVolumeMutex       sync.RWMutex
 	createVolumeArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
-		arg4 string
-		arg5 db.VolumeType
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 int
+		arg5 string
+		arg6 db.VolumeType
 	}
 	createVolumeReturns struct {
 		result1 worker.Volume
@@ -27,15 +31,52 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
-	CreateVolumeForTaskCacheStub        func(lager.Logger, worker.VolumeSpec, int, int, string, string) (worker.Volume, error)
+	CreateVolumeForImageLayerStub        func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)
+	createVolumeForImageLayerMutex       sync.RWMutex
+	createVolumeForImageLayerArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}
+	createVolumeForImageLayerReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	createVolumeForImageLayerReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	CreateVolumeForResourceCacheStub        func(context.Context, lager.Logger, db.UsedResourceCache, io.Reader) (worker.Volume, bool, error)
+	createVolumeForResourceCacheMutex       sync.RWMutex
+	createVolumeForResourceCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+		arg4 io.Reader
+	}
+	createVolumeForResourceCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	createVolumeForResourceCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	CreateVolumeForTaskCacheStub        func(context.Context, lager.Logger, worker.VolumeSpec, int, int, string, string) (worker.Volume, error)
 	createVolumeForTaskCacheMutex       sync.RWMutex
 	createVolumeForTaskCacheArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
 		arg4 int
-		arg5 string
+		arg5 int
 		arg6 string
+		arg7 string
 	}
 	createVolumeForTaskCacheReturns struct {
 		result1 worker.Volume
@@ -45,15 +86,34 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
-	FindOrCreateCOWVolumeForContainerStub        func(lager.Logger, worker.VolumeSpec, db.CreatingContainer, worker.Volume, int, string) (worker.Volume, error)
+	CreateVolumeForUnpackedImageStub        func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)
+	createVolumeForUnpackedImageMutex       sync.RWMutex
+	createVolumeForUnpackedImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}
+	createVolumeForUnpackedImageReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	createVolumeForUnpackedImageReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	FindOrCreateCOWVolumeForContainerStub        func(context.Context, lager.Logger, worker.VolumeSpec, db.CreatingContainer, worker.Volume, int, string) (worker.Volume, error)
 	findOrCreateCOWVolumeForContainerMutex       sync.RWMutex
 	findOrCreateCOWVolumeForContainerArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 db.CreatingContainer
-		arg4 worker.Volume
-		arg5 int
-		arg6 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 db.CreatingContainer
+		arg5 worker.Volume
+		arg6 int
+		arg7 string
 	}
 	findOrCreateCOWVolumeForContainerReturns struct {
 		result1 worker.Volume
@@ -63,13 +123,14 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
-	FindOrCreateVolumeForBaseResourceTypeStub        func(lager.Logger, worker.VolumeSpec, int, string) (worker.Volume, error)
+	FindOrCreateVolumeForBaseResourceTypeStub        func(context.Context, lager.Logger, worker.VolumeSpec, int, string) (worker.Volume, error)
 	findOrCreateVolumeForBaseResourceTypeMutex       sync.RWMutex
 	findOrCreateVolumeForBaseResourceTypeArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
-		arg4 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 int
+		arg5 string
 	}
 	findOrCreateVolumeForBaseResourceTypeReturns struct {
 		result1 worker.Volume
@@ -79,14 +140,15 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
-	FindOrCreateVolumeForContainerStub        func(lager.Logger, worker.VolumeSpec, db.CreatingContainer, int, string) (worker.Volume, error)
+	FindOrCreateVolumeForContainerStub        func(context.Context, lager.Logger, worker.VolumeSpec, db.CreatingContainer, int, string) (worker.Volume, error)
 	findOrCreateVolumeForContainerMutex       sync.RWMutex
 	findOrCreateVolumeForContainerArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 db.CreatingContainer
-		arg4 int
-		arg5 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 db.CreatingContainer
+		arg5 int
+		arg6 string
 	}
 	findOrCreateVolumeForContainerReturns struct {
 		result1 worker.Volume
@@ -96,10 +158,11 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
-	FindOrCreateVolumeForResourceCertsStub        func(lager.Logger) (worker.Volume, bool, error)
+	FindOrCreateVolumeForResourceCertsStub        func(context.Context, lager.Logger) (worker.Volume, bool, error)
 	findOrCreateVolumeForResourceCertsMutex       sync.RWMutex
 	findOrCreateVolumeForResourceCertsArgsForCall []struct {
-		arg1 lager.Logger
+		arg1 context.Context
+		arg2 lager.Logger
 	}
 	findOrCreateVolumeForResourceCertsReturns struct {
 		result1 worker.Volume
@@ -111,6 +174,22 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	FindVolumeForImageLayerStub        func(lager.Logger, string) (worker.Volume, bool, error)
+	findVolumeForImageLayerMutex       sync.RWMutex
+	findVolumeForImageLayerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	findVolumeForImageLayerReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findVolumeForImageLayerReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	FindVolumeForResourceCacheStub        func(lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)
 	findVolumeForResourceCacheMutex       sync.RWMutex
 	findVolumeForResourceCacheArgsForCall []struct {
@@ -146,6 +225,23 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	HydrateVolumeForResourceCacheStub        func(context.Context, lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)
+	hydrateVolumeForResourceCacheMutex       sync.RWMutex
+	hydrateVolumeForResourceCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+	}
+	hydrateVolumeForResourceCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	hydrateVolumeForResourceCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	LookupVolumeStub        func(lager.Logger, string) (worker.Volume, bool, error)
 	lookupVolumeMutex       sync.RWMutex
 	lookupVolumeArgsForCall []struct {
@@ -162,24 +258,49 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	StoreVolumeForResourceCacheStub        func(context.Context, lager.Logger, worker.Volume, db.UsedResourceCache) error
+	storeVolumeForResourceCacheMutex       sync.RWMutex
+	storeVolumeForResourceCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.Volume
+		arg4 db.UsedResourceCache
+	}
+	storeVolumeForResourceCacheReturns struct {
+		result1 error
+	}
+	storeVolumeForResourceCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamEncodingStub        func() baggageclaim.Encoding
+	streamEncodingMutex       sync.RWMutex
+	streamEncodingArgsForCall []struct {
+	}
+	streamEncodingReturns struct {
+		result1 baggageclaim.Encoding
+	}
+	streamEncodingReturnsOnCall map[int]struct {
+		result1 baggageclaim.Encoding
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeVolumeClient) CreateVolume(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 string, arg5 db.VolumeType) (worker.Volume, error) {
+func (fake *FakeVolumeClient) CreateVolume(arg1 context.Context, arg2 lager.Logger, arg3 worker.VolumeSpec, arg4 int, arg5 string, arg6 db.VolumeType) (worker.Volume, error) {
 	fake.createVolumeMutex.Lock()
 	ret, specificReturn := fake.createVolumeReturnsOnCall[len(fake.createVolumeArgsForCall)]
 	fake.createVolumeArgsForCall = append(fake.createVolumeArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
-		arg4 string
-		arg5 db.VolumeType
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("CreateVolume", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 int
+		arg5 string
+		arg6 db.VolumeType
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("CreateVolume", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.createVolumeMutex.Unlock()
 	if fake.CreateVolumeStub != nil {
-		return fake.CreateVolumeStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.CreateVolumeStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -194,17 +315,17 @@ func (fake *FakeVolumeClient) CreateVolumeCallCount() int {
 	return len(fake.createVolumeArgsForCall)
 }
 
-func (fake *FakeVolumeClient) CreateVolumeCalls(stub func(lager.Logger, worker.VolumeSpec, int, string, db.VolumeType) (worker.Volume, error)) {
+func (fake *FakeVolumeClient) CreateVolumeCalls(stub func(context.Context, lager.Logger, worker.VolumeSpec, int, string, db.VolumeType) (worker.Volume, error)) {
 	fake.createVolumeMutex.Lock()
 	defer fake.createVolumeMutex.Unlock()
 	fake.CreateVolumeStub = stub
 }
 
-func (fake *FakeVolumeClient) CreateVolumeArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, string, db.VolumeType) {
+func (fake *FakeVolumeClient) CreateVolumeArgsForCall(i int) (context.Context, lager.Logger, worker.VolumeSpec, int, string, db.VolumeType) {
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
 	argsForCall := fake.createVolumeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeVolumeClient) CreateVolumeReturns(result1 worker.Volume, result2 error) {
@@ -233,21 +354,160 @@ func (fake *FakeVolumeClient) CreateVolumeReturnsOnCall(i int, result1 worker.Vo
 	}{result1, result2}
 }
 
-func (fake *FakeVolumeClient) CreateVolumeForTaskCache(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 int, arg5 string, arg6 string) (worker.Volume, error) {
+func (fake *FakeVolumeClient) CreateVolumeForImageLayer(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 io.Reader) (worker.Volume, bool, error) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	ret, specificReturn := fake.createVolumeForImageLayerReturnsOnCall[len(fake.createVolumeForImageLayerArgsForCall)]
+	fake.createVolumeForImageLayerArgsForCall = append(fake.createVolumeForImageLayerArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateVolumeForImageLayer", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createVolumeForImageLayerMutex.Unlock()
+	if fake.CreateVolumeForImageLayerStub != nil {
+		return fake.CreateVolumeForImageLayerStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createVolumeForImageLayerReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForImageLayerCallCount() int {
+	fake.createVolumeForImageLayerMutex.RLock()
+	defer fake.createVolumeForImageLayerMutex.RUnlock()
+	return len(fake.createVolumeForImageLayerArgsForCall)
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForImageLayerCalls(stub func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	defer fake.createVolumeForImageLayerMutex.Unlock()
+	fake.CreateVolumeForImageLayerStub = stub
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForImageLayerArgsForCall(i int) (context.Context, lager.Logger, string, io.Reader) {
+	fake.createVolumeForImageLayerMutex.RLock()
+	defer fake.createVolumeForImageLayerMutex.RUnlock()
+	argsForCall := fake.createVolumeForImageLayerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForImageLayerReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	defer fake.createVolumeForImageLayerMutex.Unlock()
+	fake.CreateVolumeForImageLayerStub = nil
+	fake.createVolumeForImageLayerReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForImageLayerReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	defer fake.createVolumeForImageLayerMutex.Unlock()
+	fake.CreateVolumeForImageLayerStub = nil
+	if fake.createVolumeForImageLayerReturnsOnCall == nil {
+		fake.createVolumeForImageLayerReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createVolumeForImageLayerReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCache(arg1 context.Context, arg2 lager.Logger, arg3 db.UsedResourceCache, arg4 io.Reader) (worker.Volume, bool, error) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	ret, specificReturn := fake.createVolumeForResourceCacheReturnsOnCall[len(fake.createVolumeForResourceCacheArgsForCall)]
+	fake.createVolumeForResourceCacheArgsForCall = append(fake.createVolumeForResourceCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateVolumeForResourceCache", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createVolumeForResourceCacheMutex.Unlock()
+	if fake.CreateVolumeForResourceCacheStub != nil {
+		return fake.CreateVolumeForResourceCacheStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createVolumeForResourceCacheReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheCallCount() int {
+	fake.createVolumeForResourceCacheMutex.RLock()
+	defer fake.createVolumeForResourceCacheMutex.RUnlock()
+	return len(fake.createVolumeForResourceCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheCalls(stub func(context.Context, lager.Logger, db.UsedResourceCache, io.Reader) (worker.Volume, bool, error)) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	defer fake.createVolumeForResourceCacheMutex.Unlock()
+	fake.CreateVolumeForResourceCacheStub = stub
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheArgsForCall(i int) (context.Context, lager.Logger, db.UsedResourceCache, io.Reader) {
+	fake.createVolumeForResourceCacheMutex.RLock()
+	defer fake.createVolumeForResourceCacheMutex.RUnlock()
+	argsForCall := fake.createVolumeForResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	defer fake.createVolumeForResourceCacheMutex.Unlock()
+	fake.CreateVolumeForResourceCacheStub = nil
+	fake.createVolumeForResourceCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	defer fake.createVolumeForResourceCacheMutex.Unlock()
+	fake.CreateVolumeForResourceCacheStub = nil
+	if fake.createVolumeForResourceCacheReturnsOnCall == nil {
+		fake.createVolumeForResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createVolumeForResourceCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForTaskCache(arg1 context.Context, arg2 lager.Logger, arg3 worker.VolumeSpec, arg4 int, arg5 int, arg6 string, arg7 string) (worker.Volume, error) {
 	fake.createVolumeForTaskCacheMutex.Lock()
 	ret, specificReturn := fake.createVolumeForTaskCacheReturnsOnCall[len(fake.createVolumeForTaskCacheArgsForCall)]
 	fake.createVolumeForTaskCacheArgsForCall = append(fake.createVolumeForTaskCacheArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
 		arg4 int
-		arg5 string
+		arg5 int
 		arg6 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("CreateVolumeForTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg7 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("CreateVolumeForTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.createVolumeForTaskCacheMutex.Unlock()
 	if fake.CreateVolumeForTaskCacheStub != nil {
-		return fake.CreateVolumeForTaskCacheStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.CreateVolumeForTaskCacheStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -262,17 +522,17 @@ func (fake *FakeVolumeClient) CreateVolumeForTaskCacheCallCount() int {
 	return len(fake.createVolumeForTaskCacheArgsForCall)
 }
 
-func (fake *FakeVolumeClient) CreateVolumeForTaskCacheCalls(stub func(lager.Logger, worker.VolumeSpec, int, int, string, string) (worker.Volume, error)) {
+func (fake *FakeVolumeClient) CreateVolumeForTaskCacheCalls(stub func(context.Context, lager.Logger, worker.VolumeSpec, int, int, string, string) (worker.Volume, error)) {
 	fake.createVolumeForTaskCacheMutex.Lock()
 	defer fake.createVolumeForTaskCacheMutex.Unlock()
 	fake.CreateVolumeForTaskCacheStub = stub
 }
 
-func (fake *FakeVolumeClient) CreateVolumeForTaskCacheArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, int, string, string) {
+func (fake *FakeVolumeClient) CreateVolumeForTaskCacheArgsForCall(i int) (context.Context, lager.Logger, worker.VolumeSpec, int, int, string, string) {
 	fake.createVolumeForTaskCacheMutex.RLock()
 	defer fake.createVolumeForTaskCacheMutex.RUnlock()
 	argsForCall := fake.createVolumeForTaskCacheArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
 }
 
 func (fake *FakeVolumeClient) CreateVolumeForTaskCacheReturns(result1 worker.Volume, result2 error) {
@@ -301,21 +561,91 @@ func (fake *FakeVolumeClient) CreateVolumeForTaskCacheReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
-func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainer(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 db.CreatingContainer, arg4 worker.Volume, arg5 int, arg6 string) (worker.Volume, error) {
+func (fake *FakeVolumeClient) CreateVolumeForUnpackedImage(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 io.Reader) (worker.Volume, bool, error) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	ret, specificReturn := fake.createVolumeForUnpackedImageReturnsOnCall[len(fake.createVolumeForUnpackedImageArgsForCall)]
+	fake.createVolumeForUnpackedImageArgsForCall = append(fake.createVolumeForUnpackedImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateVolumeForUnpackedImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createVolumeForUnpackedImageMutex.Unlock()
+	if fake.CreateVolumeForUnpackedImageStub != nil {
+		return fake.CreateVolumeForUnpackedImageStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createVolumeForUnpackedImageReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForUnpackedImageCallCount() int {
+	fake.createVolumeForUnpackedImageMutex.RLock()
+	defer fake.createVolumeForUnpackedImageMutex.RUnlock()
+	return len(fake.createVolumeForUnpackedImageArgsForCall)
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForUnpackedImageCalls(stub func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	defer fake.createVolumeForUnpackedImageMutex.Unlock()
+	fake.CreateVolumeForUnpackedImageStub = stub
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForUnpackedImageArgsForCall(i int) (context.Context, lager.Logger, string, io.Reader) {
+	fake.createVolumeForUnpackedImageMutex.RLock()
+	defer fake.createVolumeForUnpackedImageMutex.RUnlock()
+	argsForCall := fake.createVolumeForUnpackedImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForUnpackedImageReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	defer fake.createVolumeForUnpackedImageMutex.Unlock()
+	fake.CreateVolumeForUnpackedImageStub = nil
+	fake.createVolumeForUnpackedImageReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForUnpackedImageReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	defer fake.createVolumeForUnpackedImageMutex.Unlock()
+	fake.CreateVolumeForUnpackedImageStub = nil
+	if fake.createVolumeForUnpackedImageReturnsOnCall == nil {
+		fake.createVolumeForUnpackedImageReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createVolumeForUnpackedImageReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainer(arg1 context.Context, arg2 lager.Logger, arg3 worker.VolumeSpec, arg4 db.CreatingContainer, arg5 worker.Volume, arg6 int, arg7 string) (worker.Volume, error) {
 	fake.findOrCreateCOWVolumeForContainerMutex.Lock()
 	ret, specificReturn := fake.findOrCreateCOWVolumeForContainerReturnsOnCall[len(fake.findOrCreateCOWVolumeForContainerArgsForCall)]
 	fake.findOrCreateCOWVolumeForContainerArgsForCall = append(fake.findOrCreateCOWVolumeForContainerArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 db.CreatingContainer
-		arg4 worker.Volume
-		arg5 int
-		arg6 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("FindOrCreateCOWVolumeForContainer", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 db.CreatingContainer
+		arg5 worker.Volume
+		arg6 int
+		arg7 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("FindOrCreateCOWVolumeForContainer", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.findOrCreateCOWVolumeForContainerMutex.Unlock()
 	if fake.FindOrCreateCOWVolumeForContainerStub != nil {
-		return fake.FindOrCreateCOWVolumeForContainerStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.FindOrCreateCOWVolumeForContainerStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -330,17 +660,17 @@ func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainerCallCount() int {
 	return len(fake.findOrCreateCOWVolumeForContainerArgsForCall)
 }
 
-func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainerCalls(stub func(lager.Logger, worker.VolumeSpec, db.CreatingContainer, worker.Volume, int, string) (worker.Volume, error)) {
+func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainerCalls(stub func(context.Context, lager.Logger, worker.VolumeSpec, db.CreatingContainer, worker.Volume, int, string) (worker.Volume, error)) {
 	fake.findOrCreateCOWVolumeForContainerMutex.Lock()
 	defer fake.findOrCreateCOWVolumeForContainerMutex.Unlock()
 	fake.FindOrCreateCOWVolumeForContainerStub = stub
 }
 
-func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainerArgsForCall(i int) (lager.Logger, worker.VolumeSpec, db.CreatingContainer, worker.Volume, int, string) {
+func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainerArgsForCall(i int) (context.Context, lager.Logger, worker.VolumeSpec, db.CreatingContainer, worker.Volume, int, string) {
 	fake.findOrCreateCOWVolumeForContainerMutex.RLock()
 	defer fake.findOrCreateCOWVolumeForContainerMutex.RUnlock()
 	argsForCall := fake.findOrCreateCOWVolumeForContainerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
 }
 
 func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainerReturns(result1 worker.Volume, result2 error) {
@@ -369,19 +699,20 @@ func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainerReturnsOnCall(i i
 	}{result1, result2}
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceType(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 string) (worker.Volume, error) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceType(arg1 context.Context, arg2 lager.Logger, arg3 worker.VolumeSpec, arg4 int, arg5 string) (worker.Volume, error) {
 	fake.findOrCreateVolumeForBaseResourceTypeMutex.Lock()
 	ret, specificReturn := fake.findOrCreateVolumeForBaseResourceTypeReturnsOnCall[len(fake.findOrCreateVolumeForBaseResourceTypeArgsForCall)]
 	fake.findOrCreateVolumeForBaseResourceTypeArgsForCall = append(fake.findOrCreateVolumeForBaseResourceTypeArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
-		arg4 string
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("FindOrCreateVolumeForBaseResourceType", []interface{}{arg1, arg2, arg3, arg4})
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 int
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("FindOrCreateVolumeForBaseResourceType", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.findOrCreateVolumeForBaseResourceTypeMutex.Unlock()
 	if fake.FindOrCreateVolumeForBaseResourceTypeStub != nil {
-		return fake.FindOrCreateVolumeForBaseResourceTypeStub(arg1, arg2, arg3, arg4)
+		return fake.FindOrCreateVolumeForBaseResourceTypeStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -396,17 +727,17 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeCallCount() i
 	return len(fake.findOrCreateVolumeForBaseResourceTypeArgsForCall)
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeCalls(stub func(lager.Logger, worker.VolumeSpec, int, string) (worker.Volume, error)) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeCalls(stub func(context.Context, lager.Logger, worker.VolumeSpec, int, string) (worker.Volume, error)) {
 	fake.findOrCreateVolumeForBaseResourceTypeMutex.Lock()
 	defer fake.findOrCreateVolumeForBaseResourceTypeMutex.Unlock()
 	fake.FindOrCreateVolumeForBaseResourceTypeStub = stub
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, string) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeArgsForCall(i int) (context.Context, lager.Logger, worker.VolumeSpec, int, string) {
 	fake.findOrCreateVolumeForBaseResourceTypeMutex.RLock()
 	defer fake.findOrCreateVolumeForBaseResourceTypeMutex.RUnlock()
 	argsForCall := fake.findOrCreateVolumeForBaseResourceTypeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeReturns(result1 worker.Volume, result2 error) {
@@ -435,20 +766,21 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeReturnsOnCall
 	}{result1, result2}
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForContainer(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 db.CreatingContainer, arg4 int, arg5 string) (worker.Volume, error) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForContainer(arg1 context.Context, arg2 lager.Logger, arg3 worker.VolumeSpec, arg4 db.CreatingContainer, arg5 int, arg6 string) (worker.Volume, error) {
 	fake.findOrCreateVolumeForContainerMutex.Lock()
 	ret, specificReturn := fake.findOrCreateVolumeForContainerReturnsOnCall[len(fake.findOrCreateVolumeForContainerArgsForCall)]
 	fake.findOrCreateVolumeForContainerArgsForCall = append(fake.findOrCreateVolumeForContainerArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 db.CreatingContainer
-		arg4 int
-		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("FindOrCreateVolumeForContainer", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 db.CreatingContainer
+		arg5 int
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("FindOrCreateVolumeForContainer", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.findOrCreateVolumeForContainerMutex.Unlock()
 	if fake.FindOrCreateVolumeForContainerStub != nil {
-		return fake.FindOrCreateVolumeForContainerStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.FindOrCreateVolumeForContainerStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -463,17 +795,17 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerCallCount() int {
 	return len(fake.findOrCreateVolumeForContainerArgsForCall)
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerCalls(stub func(lager.Logger, worker.VolumeSpec, db.CreatingContainer, int, string) (worker.Volume, error)) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerCalls(stub func(context.Context, lager.Logger, worker.VolumeSpec, db.CreatingContainer, int, string) (worker.Volume, error)) {
 	fake.findOrCreateVolumeForContainerMutex.Lock()
 	defer fake.findOrCreateVolumeForContainerMutex.Unlock()
 	fake.FindOrCreateVolumeForContainerStub = stub
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerArgsForCall(i int) (lager.Logger, worker.VolumeSpec, db.CreatingContainer, int, string) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerArgsForCall(i int) (context.Context, lager.Logger, worker.VolumeSpec, db.CreatingContainer, int, string) {
 	fake.findOrCreateVolumeForContainerMutex.RLock()
 	defer fake.findOrCreateVolumeForContainerMutex.RUnlock()
 	argsForCall := fake.findOrCreateVolumeForContainerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerReturns(result1 worker.Volume, result2 error) {
@@ -502,16 +834,17 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerReturnsOnCall(i int,
 	}{result1, result2}
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCerts(arg1 lager.Logger) (worker.Volume, bool, error) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCerts(arg1 context.Context, arg2 lager.Logger) (worker.Volume, bool, error) {
 	fake.findOrCreateVolumeForResourceCertsMutex.Lock()
 	ret, specificReturn := fake.findOrCreateVolumeForResourceCertsReturnsOnCall[len(fake.findOrCreateVolumeForResourceCertsArgsForCall)]
 	fake.findOrCreateVolumeForResourceCertsArgsForCall = append(fake.findOrCreateVolumeForResourceCertsArgsForCall, struct {
-		arg1 lager.Logger
-	}{arg1})
-	fake.recordInvocation("FindOrCreateVolumeForResourceCerts", []interface{}{arg1})
+		arg1 context.Context
+		arg2 lager.Logger
+	}{arg1, arg2})
+	fake.recordInvocation("FindOrCreateVolumeForResourceCerts", []interface{}{arg1, arg2})
 	fake.findOrCreateVolumeForResourceCertsMutex.Unlock()
 	if fake.FindOrCreateVolumeForResourceCertsStub != nil {
-		return fake.FindOrCreateVolumeForResourceCertsStub(arg1)
+		return fake.FindOrCreateVolumeForResourceCertsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -526,17 +859,17 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsCallCount() int
 	return len(fake.findOrCreateVolumeForResourceCertsArgsForCall)
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsCalls(stub func(lager.Logger) (worker.Volume, bool, error)) {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsCalls(stub func(context.Context, lager.Logger) (worker.Volume, bool, error)) {
 	fake.findOrCreateVolumeForResourceCertsMutex.Lock()
 	defer fake.findOrCreateVolumeForResourceCertsMutex.Unlock()
 	fake.FindOrCreateVolumeForResourceCertsStub = stub
 }
 
-func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsArgsForCall(i int) lager.Logger {
+func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsArgsForCall(i int) (context.Context, lager.Logger) {
 	fake.findOrCreateVolumeForResourceCertsMutex.RLock()
 	defer fake.findOrCreateVolumeForResourceCertsMutex.RUnlock()
 	argsForCall := fake.findOrCreateVolumeForResourceCertsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsReturns(result1 worker.Volume, result2 bool, result3 error) {
@@ -568,6 +901,73 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsReturnsOnCall(i
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) FindVolumeForImageLayer(arg1 lager.Logger, arg2 string) (worker.Volume, bool, error) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	ret, specificReturn := fake.findVolumeForImageLayerReturnsOnCall[len(fake.findVolumeForImageLayerArgsForCall)]
+	fake.findVolumeForImageLayerArgsForCall = append(fake.findVolumeForImageLayerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("FindVolumeForImageLayer", []interface{}{arg1, arg2})
+	fake.findVolumeForImageLayerMutex.Unlock()
+	if fake.FindVolumeForImageLayerStub != nil {
+		return fake.FindVolumeForImageLayerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.findVolumeForImageLayerReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) FindVolumeForImageLayerCallCount() int {
+	fake.findVolumeForImageLayerMutex.RLock()
+	defer fake.findVolumeForImageLayerMutex.RUnlock()
+	return len(fake.findVolumeForImageLayerArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindVolumeForImageLayerCalls(stub func(lager.Logger, string) (worker.Volume, bool, error)) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	defer fake.findVolumeForImageLayerMutex.Unlock()
+	fake.FindVolumeForImageLayerStub = stub
+}
+
+func (fake *FakeVolumeClient) FindVolumeForImageLayerArgsForCall(i int) (lager.Logger, string) {
+	fake.findVolumeForImageLayerMutex.RLock()
+	defer fake.findVolumeForImageLayerMutex.RUnlock()
+	argsForCall := fake.findVolumeForImageLayerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeClient) FindVolumeForImageLayerReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	defer fake.findVolumeForImageLayerMutex.Unlock()
+	fake.FindVolumeForImageLayerStub = nil
+	fake.findVolumeForImageLayerReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) FindVolumeForImageLayerReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	defer fake.findVolumeForImageLayerMutex.Unlock()
+	fake.FindVolumeForImageLayerStub = nil
+	if fake.findVolumeForImageLayerReturnsOnCall == nil {
+		fake.findVolumeForImageLayerReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findVolumeForImageLayerReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeClient) FindVolumeForResourceCache(arg1 lager.Logger, arg2 db.UsedResourceCache) (worker.Volume, bool, error) {
 	fake.findVolumeForResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findVolumeForResourceCacheReturnsOnCall[len(fake.findVolumeForResourceCacheArgsForCall)]
@@ -705,6 +1105,74 @@ func (fake *FakeVolumeClient) FindVolumeForTaskCacheReturnsOnCall(i int, result1
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) HydrateVolumeForResourceCache(arg1 context.Context, arg2 lager.Logger, arg3 db.UsedResourceCache) (worker.Volume, bool, error) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	ret, specificReturn := fake.hydrateVolumeForResourceCacheReturnsOnCall[len(fake.hydrateVolumeForResourceCacheArgsForCall)]
+	fake.hydrateVolumeForResourceCacheArgsForCall = append(fake.hydrateVolumeForResourceCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("HydrateVolumeForResourceCache", []interface{}{arg1, arg2, arg3})
+	fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	if fake.HydrateVolumeForResourceCacheStub != nil {
+		return fake.HydrateVolumeForResourceCacheStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.hydrateVolumeForResourceCacheReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) HydrateVolumeForResourceCacheCallCount() int {
+	fake.hydrateVolumeForResourceCacheMutex.RLock()
+	defer fake.hydrateVolumeForResourceCacheMutex.RUnlock()
+	return len(fake.hydrateVolumeForResourceCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) HydrateVolumeForResourceCacheCalls(stub func(context.Context, lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	defer fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	fake.HydrateVolumeForResourceCacheStub = stub
+}
+
+func (fake *FakeVolumeClient) HydrateVolumeForResourceCacheArgsForCall(i int) (context.Context, lager.Logger, db.UsedResourceCache) {
+	fake.hydrateVolumeForResourceCacheMutex.RLock()
+	defer fake.hydrateVolumeForResourceCacheMutex.RUnlock()
+	argsForCall := fake.hydrateVolumeForResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolumeClient) HydrateVolumeForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	defer fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	fake.HydrateVolumeForResourceCacheStub = nil
+	fake.hydrateVolumeForResourceCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) HydrateVolumeForResourceCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	defer fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	fake.HydrateVolumeForResourceCacheStub = nil
+	if fake.hydrateVolumeForResourceCacheReturnsOnCall == nil {
+		fake.hydrateVolumeForResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.hydrateVolumeForResourceCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeClient) LookupVolume(arg1 lager.Logger, arg2 string) (worker.Volume, bool, error) {
 	fake.lookupVolumeMutex.Lock()
 	ret, specificReturn := fake.lookupVolumeReturnsOnCall[len(fake.lookupVolumeArgsForCall)]
@@ -772,13 +1240,134 @@ func (fake *FakeVolumeClient) LookupVolumeReturnsOnCall(i int, result1 worker.Vo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) StoreVolumeForResourceCache(arg1 context.Context, arg2 lager.Logger, arg3 worker.Volume, arg4 db.UsedResourceCache) error {
+	fake.storeVolumeForResourceCacheMutex.Lock()
+	ret, specificReturn := fake.storeVolumeForResourceCacheReturnsOnCall[len(fake.storeVolumeForResourceCacheArgsForCall)]
+	fake.storeVolumeForResourceCacheArgsForCall = append(fake.storeVolumeForResourceCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.Volume
+		arg4 db.UsedResourceCache
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("StoreVolumeForResourceCache", []interface{}{arg1, arg2, arg3, arg4})
+	fake.storeVolumeForResourceCacheMutex.Unlock()
+	if fake.StoreVolumeForResourceCacheStub != nil {
+		return fake.StoreVolumeForResourceCacheStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.storeVolumeForResourceCacheReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolumeClient) StoreVolumeForResourceCacheCallCount() int {
+	fake.storeVolumeForResourceCacheMutex.RLock()
+	defer fake.storeVolumeForResourceCacheMutex.RUnlock()
+	return len(fake.storeVolumeForResourceCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) StoreVolumeForResourceCacheCalls(stub func(context.Context, lager.Logger, worker.Volume, db.UsedResourceCache) error) {
+	fake.storeVolumeForResourceCacheMutex.Lock()
+	defer fake.storeVolumeForResourceCacheMutex.Unlock()
+	fake.StoreVolumeForResourceCacheStub = stub
+}
+
+func (fake *FakeVolumeClient) StoreVolumeForResourceCacheArgsForCall(i int) (context.Context, lager.Logger, worker.Volume, db.UsedResourceCache) {
+	fake.storeVolumeForResourceCacheMutex.RLock()
+	defer fake.storeVolumeForResourceCacheMutex.RUnlock()
+	argsForCall := fake.storeVolumeForResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolumeClient) StoreVolumeForResourceCacheReturns(result1 error) {
+	fake.storeVolumeForResourceCacheMutex.Lock()
+	defer fake.storeVolumeForResourceCacheMutex.Unlock()
+	fake.StoreVolumeForResourceCacheStub = nil
+	fake.storeVolumeForResourceCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolumeClient) StoreVolumeForResourceCacheReturnsOnCall(i int, result1 error) {
+	fake.storeVolumeForResourceCacheMutex.Lock()
+	defer fake.storeVolumeForResourceCacheMutex.Unlock()
+	fake.StoreVolumeForResourceCacheStub = nil
+	if fake.storeVolumeForResourceCacheReturnsOnCall == nil {
+		fake.storeVolumeForResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeVolumeForResourceCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolumeClient) StreamEncoding() baggageclaim.Encoding {
+	fake.streamEncodingMutex.Lock()
+	ret, specificReturn := fake.streamEncodingReturnsOnCall[len(fake.streamEncodingArgsForCall)]
+	fake.streamEncodingArgsForCall = append(fake.streamEncodingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("StreamEncoding", []interface{}{})
+	fake.streamEncodingMutex.Unlock()
+	if fake.StreamEncodingStub != nil {
+		return fake.StreamEncodingStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.streamEncodingReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolumeClient) StreamEncodingCallCount() int {
+	fake.streamEncodingMutex.RLock()
+	defer fake.streamEncodingMutex.RUnlock()
+	return len(fake.streamEncodingArgsForCall)
+}
+
+func (fake *FakeVolumeClient) StreamEncodingCalls(stub func() baggageclaim.Encoding) {
+	fake.streamEncodingMutex.Lock()
+	defer fake.streamEncodingMutex.Unlock()
+	fake.StreamEncodingStub = stub
+}
+
+func (fake *FakeVolumeClient) StreamEncodingReturns(result1 baggageclaim.Encoding) {
+	fake.streamEncodingMutex.Lock()
+	defer fake.streamEncodingMutex.Unlock()
+	fake.StreamEncodingStub = nil
+	fake.streamEncodingReturns = struct {
+		result1 baggageclaim.Encoding
+	}{result1}
+}
+
+func (fake *FakeVolumeClient) StreamEncodingReturnsOnCall(i int, result1 baggageclaim.Encoding) {
+	fake.streamEncodingMutex.Lock()
+	defer fake.streamEncodingMutex.Unlock()
+	fake.StreamEncodingStub = nil
+	if fake.streamEncodingReturnsOnCall == nil {
+		fake.streamEncodingReturnsOnCall = make(map[int]struct {
+			result1 baggageclaim.Encoding
+		})
+	}
+	fake.streamEncodingReturnsOnCall[i] = struct {
+		result1 baggageclaim.Encoding
+	}{result1}
+}
+
 func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
+	fake.createVolumeForImageLayerMutex.RLock()
+	defer fake.createVolumeForImageLayerMutex.RUnlock()
+	fake.createVolumeForResourceCacheMutex.RLock()
+	defer fake.createVolumeForResourceCacheMutex.RUnlock()
 	fake.createVolumeForTaskCacheMutex.RLock()
 	defer fake.createVolumeForTaskCacheMutex.RUnlock()
+	fake.createVolumeForUnpackedImageMutex.RLock()
+	defer fake.createVolumeForUnpackedImageMutex.RUnlock()
 	fake.findOrCreateCOWVolumeForContainerMutex.RLock()
 	defer fake.findOrCreateCOWVolumeForContainerMutex.RUnlock()
 	fake.findOrCreateVolumeForBaseResourceTypeMutex.RLock()
@@ -787,12 +1376,20 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateVolumeForContainerMutex.RUnlock()
 	fake.findOrCreateVolumeForResourceCertsMutex.RLock()
 	defer fake.findOrCreateVolumeForResourceCertsMutex.RUnlock()
+	fake.findVolumeForImageLayerMutex.RLock()
+	defer fake.findVolumeForImageLayerMutex.RUnlock()
 	fake.findVolumeForResourceCacheMutex.RLock()
 	defer fake.findVolumeForResourceCacheMutex.RUnlock()
 	fake.findVolumeForTaskCacheMutex.RLock()
 	defer fake.findVolumeForTaskCacheMutex.RUnlock()
+	fake.hydrateVolumeForResourceCacheMutex.RLock()
+	defer fake.hydrateVolumeForResourceCacheMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.storeVolumeForResourceCacheMutex.RLock()
+	defer fake.storeVolumeForResourceCacheMutex.RUnlock()
+	fake.streamEncodingMutex.RLock()
+	defer fake.streamEncodingMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,6 +3,7 @@ package workerfakes
 
 import (
 	"context"
+	"io"
 	"sync"
 	"time"
 
@@ -37,10 +38,11 @@ type FakeWorker struct {
 	buildContainersReturnsOnCall map[int]struct {
 		result1 int
 	}
-	CertsVolumeStub        func(lager.Logger) (worker.Volume, bool, error)
+	CertsVolumeStub        func(context.Context, lager.Logger) (worker.Volume, bool, error)
 	certsVolumeMutex       sync.RWMutex
 	certsVolumeArgsForCall []struct {
-		arg1 lager.Logger
+		arg1 context.Context
+		arg2 lager.Logger
 	}
 	certsVolumeReturns struct {
 		result1 worker.Volume
@@ -52,13 +54,14 @@ type FakeWorker struct {
 		result2 bool
 		result3 error
 	}
-	CreateVolumeStub        func(lager.Logger, worker.VolumeSpec, int, db.VolumeType) (worker.Volume, error)
+	CreateVolumeStub        func(context.Context, lager.Logger, worker.VolumeSpec, int, db.VolumeType) (worker.Volume, error)
 	createVolumeMutex       sync.RWMutex
 	createVolumeArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
-		arg4 db.VolumeType
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 int
+		arg5 db.VolumeType
 	}
 	createVolumeReturns struct {
 		result1 worker.Volume
@@ -68,6 +71,60 @@ type FakeWorker struct {
 		result1 worker.Volume
 		result2 error
 	}
+	CreateVolumeForImageLayerStub        func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)
+	createVolumeForImageLayerMutex       sync.RWMutex
+	createVolumeForImageLayerArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}
+	createVolumeForImageLayerReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	createVolumeForImageLayerReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	CreateVolumeForResourceCacheStub        func(context.Context, lager.Logger, db.UsedResourceCache, io.Reader) (worker.Volume, bool, error)
+	createVolumeForResourceCacheMutex       sync.RWMutex
+	createVolumeForResourceCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+		arg4 io.Reader
+	}
+	createVolumeForResourceCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	createVolumeForResourceCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	CreateVolumeForUnpackedImageStub        func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)
+	createVolumeForUnpackedImageMutex       sync.RWMutex
+	createVolumeForUnpackedImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}
+	createVolumeForUnpackedImageReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	createVolumeForUnpackedImageReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	DecreaseActiveTasksStub        func() error
 	decreaseActiveTasksMutex       sync.RWMutex
 	decreaseActiveTasksArgsForCall []struct {
@@ -148,6 +205,22 @@ type FakeWorker struct {
 		result1 worker.Container
 		result2 error
 	}
+	FindVolumeForImageLayerStub        func(lager.Logger, string) (worker.Volume, bool, error)
+	findVolumeForImageLayerMutex       sync.RWMutex
+	findVolumeForImageLayerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	findVolumeForImageLayerReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findVolumeForImageLayerReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	FindVolumeForResourceCacheStub        func(lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)
 	findVolumeForResourceCacheMutex       sync.RWMutex
 	findVolumeForResourceCacheArgsForCall []struct {
@@ -193,6 +266,23 @@ type FakeWorker struct {
 	gardenClientReturnsOnCall map[int]struct {
 		result1 gclient.Client
 	}
+	HydrateVolumeForResourceCacheStub        func(context.Context, lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)
+	hydrateVolumeForResourceCacheMutex       sync.RWMutex
+	hydrateVolumeForResourceCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+	}
+	hydrateVolumeForResourceCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	hydrateVolumeForResourceCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	IncreaseActiveTasksStub        func() error
 	increaseActiveTasksMutex       sync.RWMutex
 	increaseActiveTasksArgsForCall []struct {
@@ -225,6 +315,16 @@ type FakeWorker struct {
 	isVersionCompatibleReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	LabelsStub        func() atc.Labels
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct {
+	}
+	labelsReturns struct {
+		result1 atc.Labels
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 atc.Labels
+	}
 	LookupVolumeStub        func(lager.Logger, string) (worker.Volume, bool, error)
 	lookupVolumeMutex       sync.RWMutex
 	lookupVolumeArgsForCall []struct {
@@ -283,6 +383,16 @@ type FakeWorker struct {
 	tagsReturnsOnCall map[int]struct {
 		result1 atc.Tags
 	}
+	TeamIDStub        func() int
+	teamIDMutex       sync.RWMutex
+	teamIDArgsForCall []struct {
+	}
+	teamIDReturns struct {
+		result1 int
+	}
+	teamIDReturnsOnCall map[int]struct {
+		result1 int
+	}
 	UptimeStub        func() time.Duration
 	uptimeMutex       sync.RWMutex
 	uptimeArgsForCall []struct {
@@ -404,16 +514,17 @@ func (fake *FakeWorker) BuildContainersReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
-func (fake *FakeWorker) CertsVolume(arg1 lager.Logger) (worker.Volume, bool, error) {
+func (fake *FakeWorker) CertsVolume(arg1 context.Context, arg2 lager.Logger) (worker.Volume, bool, error) {
 	fake.certsVolumeMutex.Lock()
 	ret, specificReturn := fake.certsVolumeReturnsOnCall[len(fake.certsVolumeArgsForCall)]
 	fake.certsVolumeArgsForCall = append(fake.certsVolumeArgsForCall, struct {
-		arg1 lager.Logger
-	}{arg1})
-	fake.recordInvocation("CertsVolume", []interface{}{arg1})
+		arg1 context.Context
+		arg2 lager.Logger
+	}{arg1, arg2})
+	fake.recordInvocation("CertsVolume", []interface{}{arg1, arg2})
 	fake.certsVolumeMutex.Unlock()
 	if fake.CertsVolumeStub != nil {
-		return fake.CertsVolumeStub(arg1)
+		return fake.CertsVolumeStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -428,17 +539,17 @@ func (fake *FakeWorker) CertsVolumeCallCount() int {
 	return len(fake.certsVolumeArgsForCall)
 }
 
-func (fake *FakeWorker) CertsVolumeCalls(stub func(lager.Logger) (worker.Volume, bool, error)) {
+func (fake *FakeWorker) CertsVolumeCalls(stub func(context.Context, lager.Logger) (worker.Volume, bool, error)) {
 	fake.certsVolumeMutex.Lock()
 	defer fake.certsVolumeMutex.Unlock()
 	fake.CertsVolumeStub = stub
 }
 
-func (fake *FakeWorker) CertsVolumeArgsForCall(i int) lager.Logger {
+func (fake *FakeWorker) CertsVolumeArgsForCall(i int) (context.Context, lager.Logger) {
 	fake.certsVolumeMutex.RLock()
 	defer fake.certsVolumeMutex.RUnlock()
 	argsForCall := fake.certsVolumeArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeWorker) CertsVolumeReturns(result1 worker.Volume, result2 bool, result3 error) {
@@ -470,19 +581,20 @@ func (fake *FakeWorker) CertsVolumeReturnsOnCall(i int, result1 worker.Volume, r
 	}{result1, result2, result3}
 }
 
-func (fake *FakeWorker) CreateVolume(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 db.VolumeType) (worker.Volume, error) {
+func (fake *FakeWorker) CreateVolume(arg1 context.Context, arg2 lager.Logger, arg3 worker.VolumeSpec, arg4 int, arg5 db.VolumeType) (worker.Volume, error) {
 	fake.createVolumeMutex.Lock()
 	ret, specificReturn := fake.createVolumeReturnsOnCall[len(fake.createVolumeArgsForCall)]
 	fake.createVolumeArgsForCall = append(fake.createVolumeArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 worker.VolumeSpec
-		arg3 int
-		arg4 db.VolumeType
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("CreateVolume", []interface{}{arg1, arg2, arg3, arg4})
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 worker.VolumeSpec
+		arg4 int
+		arg5 db.VolumeType
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("CreateVolume", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.createVolumeMutex.Unlock()
 	if fake.CreateVolumeStub != nil {
-		return fake.CreateVolumeStub(arg1, arg2, arg3, arg4)
+		return fake.CreateVolumeStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -497,17 +609,17 @@ func (fake *FakeWorker) CreateVolumeCallCount() int {
 	return len(fake.createVolumeArgsForCall)
 }
 
-func (fake *FakeWorker) CreateVolumeCalls(stub func(lager.Logger, worker.VolumeSpec, int, db.VolumeType) (worker.Volume, error)) {
+func (fake *FakeWorker) CreateVolumeCalls(stub func(context.Context, lager.Logger, worker.VolumeSpec, int, db.VolumeType) (worker.Volume, error)) {
 	fake.createVolumeMutex.Lock()
 	defer fake.createVolumeMutex.Unlock()
 	fake.CreateVolumeStub = stub
 }
 
-func (fake *FakeWorker) CreateVolumeArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, db.VolumeType) {
+func (fake *FakeWorker) CreateVolumeArgsForCall(i int) (context.Context, lager.Logger, worker.VolumeSpec, int, db.VolumeType) {
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
 	argsForCall := fake.createVolumeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeWorker) CreateVolumeReturns(result1 worker.Volume, result2 error) {
@@ -536,6 +648,213 @@ func (fake *FakeWorker) CreateVolumeReturnsOnCall(i int, result1 worker.Volume,
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) CreateVolumeForImageLayer(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 io.Reader) (worker.Volume, bool, error) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	ret, specificReturn := fake.createVolumeForImageLayerReturnsOnCall[len(fake.createVolumeForImageLayerArgsForCall)]
+	fake.createVolumeForImageLayerArgsForCall = append(fake.createVolumeForImageLayerArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateVolumeForImageLayer", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createVolumeForImageLayerMutex.Unlock()
+	if fake.CreateVolumeForImageLayerStub != nil {
+		return fake.CreateVolumeForImageLayerStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createVolumeForImageLayerReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) CreateVolumeForImageLayerCallCount() int {
+	fake.createVolumeForImageLayerMutex.RLock()
+	defer fake.createVolumeForImageLayerMutex.RUnlock()
+	return len(fake.createVolumeForImageLayerArgsForCall)
+}
+
+func (fake *FakeWorker) CreateVolumeForImageLayerCalls(stub func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	defer fake.createVolumeForImageLayerMutex.Unlock()
+	fake.CreateVolumeForImageLayerStub = stub
+}
+
+func (fake *FakeWorker) CreateVolumeForImageLayerArgsForCall(i int) (context.Context, lager.Logger, string, io.Reader) {
+	fake.createVolumeForImageLayerMutex.RLock()
+	defer fake.createVolumeForImageLayerMutex.RUnlock()
+	argsForCall := fake.createVolumeForImageLayerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWorker) CreateVolumeForImageLayerReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	defer fake.createVolumeForImageLayerMutex.Unlock()
+	fake.CreateVolumeForImageLayerStub = nil
+	fake.createVolumeForImageLayerReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) CreateVolumeForImageLayerReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForImageLayerMutex.Lock()
+	defer fake.createVolumeForImageLayerMutex.Unlock()
+	fake.CreateVolumeForImageLayerStub = nil
+	if fake.createVolumeForImageLayerReturnsOnCall == nil {
+		fake.createVolumeForImageLayerReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createVolumeForImageLayerReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCache(arg1 context.Context, arg2 lager.Logger, arg3 db.UsedResourceCache, arg4 io.Reader) (worker.Volume, bool, error) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	ret, specificReturn := fake.createVolumeForResourceCacheReturnsOnCall[len(fake.createVolumeForResourceCacheArgsForCall)]
+	fake.createVolumeForResourceCacheArgsForCall = append(fake.createVolumeForResourceCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateVolumeForResourceCache", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createVolumeForResourceCacheMutex.Unlock()
+	if fake.CreateVolumeForResourceCacheStub != nil {
+		return fake.CreateVolumeForResourceCacheStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createVolumeForResourceCacheReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheCallCount() int {
+	fake.createVolumeForResourceCacheMutex.RLock()
+	defer fake.createVolumeForResourceCacheMutex.RUnlock()
+	return len(fake.createVolumeForResourceCacheArgsForCall)
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheCalls(stub func(context.Context, lager.Logger, db.UsedResourceCache, io.Reader) (worker.Volume, bool, error)) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	defer fake.createVolumeForResourceCacheMutex.Unlock()
+	fake.CreateVolumeForResourceCacheStub = stub
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheArgsForCall(i int) (context.Context, lager.Logger, db.UsedResourceCache, io.Reader) {
+	fake.createVolumeForResourceCacheMutex.RLock()
+	defer fake.createVolumeForResourceCacheMutex.RUnlock()
+	argsForCall := fake.createVolumeForResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	defer fake.createVolumeForResourceCacheMutex.Unlock()
+	fake.CreateVolumeForResourceCacheStub = nil
+	fake.createVolumeForResourceCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForResourceCacheMutex.Lock()
+	defer fake.createVolumeForResourceCacheMutex.Unlock()
+	fake.CreateVolumeForResourceCacheStub = nil
+	if fake.createVolumeForResourceCacheReturnsOnCall == nil {
+		fake.createVolumeForResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createVolumeForResourceCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) CreateVolumeForUnpackedImage(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 io.Reader) (worker.Volume, bool, error) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	ret, specificReturn := fake.createVolumeForUnpackedImageReturnsOnCall[len(fake.createVolumeForUnpackedImageArgsForCall)]
+	fake.createVolumeForUnpackedImageArgsForCall = append(fake.createVolumeForUnpackedImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateVolumeForUnpackedImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createVolumeForUnpackedImageMutex.Unlock()
+	if fake.CreateVolumeForUnpackedImageStub != nil {
+		return fake.CreateVolumeForUnpackedImageStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createVolumeForUnpackedImageReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) CreateVolumeForUnpackedImageCallCount() int {
+	fake.createVolumeForUnpackedImageMutex.RLock()
+	defer fake.createVolumeForUnpackedImageMutex.RUnlock()
+	return len(fake.createVolumeForUnpackedImageArgsForCall)
+}
+
+func (fake *FakeWorker) CreateVolumeForUnpackedImageCalls(stub func(context.Context, lager.Logger, string, io.Reader) (worker.Volume, bool, error)) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	defer fake.createVolumeForUnpackedImageMutex.Unlock()
+	fake.CreateVolumeForUnpackedImageStub = stub
+}
+
+func (fake *FakeWorker) CreateVolumeForUnpackedImageArgsForCall(i int) (context.Context, lager.Logger, string, io.Reader) {
+	fake.createVolumeForUnpackedImageMutex.RLock()
+	defer fake.createVolumeForUnpackedImageMutex.RUnlock()
+	argsForCall := fake.createVolumeForUnpackedImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWorker) CreateVolumeForUnpackedImageReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	defer fake.createVolumeForUnpackedImageMutex.Unlock()
+	fake.CreateVolumeForUnpackedImageStub = nil
+	fake.createVolumeForUnpackedImageReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) CreateVolumeForUnpackedImageReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.createVolumeForUnpackedImageMutex.Lock()
+	defer fake.createVolumeForUnpackedImageMutex.Unlock()
+	fake.CreateVolumeForUnpackedImageStub = nil
+	if fake.createVolumeForUnpackedImageReturnsOnCall == nil {
+		fake.createVolumeForUnpackedImageReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createVolumeForUnpackedImageReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) DecreaseActiveTasks() error {
 	fake.decreaseActiveTasksMutex.Lock()
 	ret, specificReturn := fake.decreaseActiveTasksReturnsOnCall[len(fake.decreaseActiveTasksArgsForCall)]
@@ -892,6 +1211,73 @@ func (fake *FakeWorker) FindOrCreateContainerReturnsOnCall(i int, result1 worker
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) FindVolumeForImageLayer(arg1 lager.Logger, arg2 string) (worker.Volume, bool, error) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	ret, specificReturn := fake.findVolumeForImageLayerReturnsOnCall[len(fake.findVolumeForImageLayerArgsForCall)]
+	fake.findVolumeForImageLayerArgsForCall = append(fake.findVolumeForImageLayerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("FindVolumeForImageLayer", []interface{}{arg1, arg2})
+	fake.findVolumeForImageLayerMutex.Unlock()
+	if fake.FindVolumeForImageLayerStub != nil {
+		return fake.FindVolumeForImageLayerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.findVolumeForImageLayerReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) FindVolumeForImageLayerCallCount() int {
+	fake.findVolumeForImageLayerMutex.RLock()
+	defer fake.findVolumeForImageLayerMutex.RUnlock()
+	return len(fake.findVolumeForImageLayerArgsForCall)
+}
+
+func (fake *FakeWorker) FindVolumeForImageLayerCalls(stub func(lager.Logger, string) (worker.Volume, bool, error)) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	defer fake.findVolumeForImageLayerMutex.Unlock()
+	fake.FindVolumeForImageLayerStub = stub
+}
+
+func (fake *FakeWorker) FindVolumeForImageLayerArgsForCall(i int) (lager.Logger, string) {
+	fake.findVolumeForImageLayerMutex.RLock()
+	defer fake.findVolumeForImageLayerMutex.RUnlock()
+	argsForCall := fake.findVolumeForImageLayerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) FindVolumeForImageLayerReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	defer fake.findVolumeForImageLayerMutex.Unlock()
+	fake.FindVolumeForImageLayerStub = nil
+	fake.findVolumeForImageLayerReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) FindVolumeForImageLayerReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.findVolumeForImageLayerMutex.Lock()
+	defer fake.findVolumeForImageLayerMutex.Unlock()
+	fake.FindVolumeForImageLayerStub = nil
+	if fake.findVolumeForImageLayerReturnsOnCall == nil {
+		fake.findVolumeForImageLayerReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findVolumeForImageLayerReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) FindVolumeForResourceCache(arg1 lager.Logger, arg2 db.UsedResourceCache) (worker.Volume, bool, error) {
 	fake.findVolumeForResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findVolumeForResourceCacheReturnsOnCall[len(fake.findVolumeForResourceCacheArgsForCall)]
@@ -1081,6 +1467,74 @@ func (fake *FakeWorker) GardenClientReturnsOnCall(i int, result1 gclient.Client)
 	}{result1}
 }
 
+func (fake *FakeWorker) HydrateVolumeForResourceCache(arg1 context.Context, arg2 lager.Logger, arg3 db.UsedResourceCache) (worker.Volume, bool, error) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	ret, specificReturn := fake.hydrateVolumeForResourceCacheReturnsOnCall[len(fake.hydrateVolumeForResourceCacheArgsForCall)]
+	fake.hydrateVolumeForResourceCacheArgsForCall = append(fake.hydrateVolumeForResourceCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 db.UsedResourceCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("HydrateVolumeForResourceCache", []interface{}{arg1, arg2, arg3})
+	fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	if fake.HydrateVolumeForResourceCacheStub != nil {
+		return fake.HydrateVolumeForResourceCacheStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.hydrateVolumeForResourceCacheReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) HydrateVolumeForResourceCacheCallCount() int {
+	fake.hydrateVolumeForResourceCacheMutex.RLock()
+	defer fake.hydrateVolumeForResourceCacheMutex.RUnlock()
+	return len(fake.hydrateVolumeForResourceCacheArgsForCall)
+}
+
+func (fake *FakeWorker) HydrateVolumeForResourceCacheCalls(stub func(context.Context, lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	defer fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	fake.HydrateVolumeForResourceCacheStub = stub
+}
+
+func (fake *FakeWorker) HydrateVolumeForResourceCacheArgsForCall(i int) (context.Context, lager.Logger, db.UsedResourceCache) {
+	fake.hydrateVolumeForResourceCacheMutex.RLock()
+	defer fake.hydrateVolumeForResourceCacheMutex.RUnlock()
+	argsForCall := fake.hydrateVolumeForResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorker) HydrateVolumeForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	defer fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	fake.HydrateVolumeForResourceCacheStub = nil
+	fake.hydrateVolumeForResourceCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) HydrateVolumeForResourceCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.hydrateVolumeForResourceCacheMutex.Lock()
+	defer fake.hydrateVolumeForResourceCacheMutex.Unlock()
+	fake.HydrateVolumeForResourceCacheStub = nil
+	if fake.hydrateVolumeForResourceCacheReturnsOnCall == nil {
+		fake.hydrateVolumeForResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.hydrateVolumeForResourceCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) IncreaseActiveTasks() error {
 	fake.increaseActiveTasksMutex.Lock()
 	ret, specificReturn := fake.increaseActiveTasksReturnsOnCall[len(fake.increaseActiveTasksArgsForCall)]
@@ -1246,6 +1700,58 @@ func (fake *FakeWorker) IsVersionCompatibleReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Labels() atc.Labels {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.labelsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakeWorker) LabelsCalls(stub func() atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = stub
+}
+
+func (fake *FakeWorker) LabelsReturns(result1 atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
+func (fake *FakeWorker) LabelsReturnsOnCall(i int, result1 atc.Labels) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 atc.Labels
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
 func (fake *FakeWorker) LookupVolume(arg1 lager.Logger, arg2 string) (worker.Volume, bool, error) {
 	fake.lookupVolumeMutex.Lock()
 	ret, specificReturn := fake.lookupVolumeReturnsOnCall[len(fake.lookupVolumeArgsForCall)]
@@ -1530,6 +2036,58 @@ func (fake *FakeWorker) TagsReturnsOnCall(i int, result1 atc.Tags) {
 	}{result1}
 }
 
+func (fake *FakeWorker) TeamID() int {
+	fake.teamIDMutex.Lock()
+	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
+	fake.teamIDArgsForCall = append(fake.teamIDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("TeamID", []interface{}{})
+	fake.teamIDMutex.Unlock()
+	if fake.TeamIDStub != nil {
+		return fake.TeamIDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.teamIDReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) TeamIDCallCount() int {
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
+	return len(fake.teamIDArgsForCall)
+}
+
+func (fake *FakeWorker) TeamIDCalls(stub func() int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = stub
+}
+
+func (fake *FakeWorker) TeamIDReturns(result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	fake.teamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) TeamIDReturnsOnCall(i int, result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	if fake.teamIDReturnsOnCall == nil {
+		fake.teamIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.teamIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) Uptime() time.Duration {
 	fake.uptimeMutex.Lock()
 	ret, specificReturn := fake.uptimeReturnsOnCall[len(fake.uptimeArgsForCall)]
@@ -1593,6 +2151,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.certsVolumeMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
+	fake.createVolumeForImageLayerMutex.RLock()
+	defer fake.createVolumeForImageLayerMutex.RUnlock()
+	fake.createVolumeForResourceCacheMutex.RLock()
+	defer fake.createVolumeForResourceCacheMutex.RUnlock()
+	fake.createVolumeForUnpackedImageMutex.RLock()
+	defer fake.createVolumeForUnpackedImageMutex.RUnlock()
 	fake.decreaseActiveTasksMutex.RLock()
 	defer fake.decreaseActiveTasksMutex.RUnlock()
 	fake.descriptionMutex.RLock()
@@ -1605,18 +2169,24 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.findContainerByHandleMutex.RUnlock()
 	fake.findOrCreateContainerMutex.RLock()
 	defer fake.findOrCreateContainerMutex.RUnlock()
+	fake.findVolumeForImageLayerMutex.RLock()
+	defer fake.findVolumeForImageLayerMutex.RUnlock()
 	fake.findVolumeForResourceCacheMutex.RLock()
 	defer fake.findVolumeForResourceCacheMutex.RUnlock()
 	fake.findVolumeForTaskCacheMutex.RLock()
 	defer fake.findVolumeForTaskCacheMutex.RUnlock()
 	fake.gardenClientMutex.RLock()
 	defer fake.gardenClientMutex.RUnlock()
+	fake.hydrateVolumeForResourceCacheMutex.RLock()
+	defer fake.hydrateVolumeForResourceCacheMutex.RUnlock()
 	fake.increaseActiveTasksMutex.RLock()
 	defer fake.increaseActiveTasksMutex.RUnlock()
 	fake.isOwnedByTeamMutex.RLock()
 	defer fake.isOwnedByTeamMutex.RUnlock()
 	fake.isVersionCompatibleMutex.RLock()
 	defer fake.isVersionCompatibleMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -1627,6 +2197,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.satisfiesMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
 	fake.uptimeMutex.RLock()
 	defer fake.uptimeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
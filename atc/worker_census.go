@@ -0,0 +1,46 @@
+package atc
+
+// ContainerBuildIDProperty is the garden property set on containers created
+// for a build, so that workers can report which build each container is for.
+const ContainerBuildIDProperty = "concourse:build-id"
+
+// WorkerCensus lists the containers and volumes actually present on a
+// worker. It is reported with each heartbeat and reconciled against the
+// containers and volumes the database thinks the worker has.
+type WorkerCensus struct {
+	Containers []CensusContainer `json:"containers"`
+	Volumes    []string          `json:"volumes"`
+}
+
+type CensusContainer struct {
+	Handle  string `json:"handle"`
+	BuildID int    `json:"build_id,omitempty"`
+}
+
+const (
+	// DiscrepancyMissingOnWorker is a container or volume which the database
+	// thinks has been created on the worker, but which the worker doesn't
+	// have.
+	DiscrepancyMissingOnWorker = "missing_on_worker"
+
+	// DiscrepancyUnknownToDB is a container or volume which the worker has,
+	// but which the database knows nothing about.
+	DiscrepancyUnknownToDB = "unknown_to_db"
+)
+
+// WorkerDiscrepancy is a container or volume on which a worker and the
+// database disagree. Heartbeats counts the consecutive censuses it has been
+// found in; discrepancies found in only one may just be racing with a
+// container or volume being created.
+type WorkerDiscrepancy struct {
+	Type       string `json:"type"`
+	Handle     string `json:"handle"`
+	Problem    string `json:"problem"`
+	BuildID    int    `json:"build_id,omitempty"`
+	Since      int64  `json:"since"`
+	Heartbeats int    `json:"heartbeats"`
+}
+
+type ResolveWorkerDiscrepanciesResponseBody struct {
+	Resolved int `json:"resolved"`
+}
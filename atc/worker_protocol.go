@@ -0,0 +1,114 @@
+package atc
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WorkerProtocolVersion is the newest version of the protocol spoken between
+// the ATC and workers. Each version may introduce capabilities, which the ATC
+// only relies on for workers that negotiated them, so that a fleet of mixed
+// versions keeps working during a rolling upgrade.
+const WorkerProtocolVersion = 2
+
+// LegacyWorkerProtocolVersion is the version spoken by workers which predate
+// protocol negotiation and don't report a protocol version.
+const LegacyWorkerProtocolVersion = 1
+
+// MinWorkerProtocolVersion is the oldest version of the protocol the ATC
+// still speaks. Workers speaking an older one are refused.
+const MinWorkerProtocolVersion = LegacyWorkerProtocolVersion
+
+type WorkerCapability string
+
+const (
+	// WorkerCapabilityZstdStreaming is whether the worker's baggageclaim can
+	// stream volumes compressed with zstd.
+	WorkerCapabilityZstdStreaming WorkerCapability = "zstd-streaming"
+
+	// WorkerCapabilityGPUs is whether the worker can share its GPUs with
+	// containers.
+	WorkerCapabilityGPUs WorkerCapability = "gpus"
+)
+
+// workerCapabilityVersions is the protocol version which introduced each
+// capability known to the ATC.
+var workerCapabilityVersions = map[WorkerCapability]int{
+	WorkerCapabilityZstdStreaming: 2,
+	WorkerCapabilityGPUs:          2,
+}
+
+// WorkerCapabilities returns every capability known to the ATC.
+func WorkerCapabilities() []WorkerCapability {
+	capabilities := []WorkerCapability{}
+	for capability := range workerCapabilityVersions {
+		capabilities = append(capabilities, capability)
+	}
+
+	sort.Slice(capabilities, func(i, j int) bool {
+		return capabilities[i] < capabilities[j]
+	})
+
+	return capabilities
+}
+
+type UnsupportedWorkerProtocolError struct {
+	Version int
+}
+
+func (err UnsupportedWorkerProtocolError) Error() string {
+	return fmt.Sprintf("worker protocol version %d is not supported, the oldest supported version is %d", err.Version, MinWorkerProtocolVersion)
+}
+
+// NegotiateProtocol settles the protocol version and capabilities the ATC
+// uses with the worker: the older of the worker's and the ATC's protocol
+// versions, and the capabilities the worker offers which the ATC knows of
+// and which that version includes. Capabilities the worker leaves out are
+// never used, even if its version includes them.
+func (w *Worker) NegotiateProtocol() error {
+	version := w.ProtocolVersion
+	if version == 0 {
+		version = LegacyWorkerProtocolVersion
+	}
+
+	if version < MinWorkerProtocolVersion {
+		return UnsupportedWorkerProtocolError{Version: version}
+	}
+
+	if version > WorkerProtocolVersion {
+		version = WorkerProtocolVersion
+	}
+
+	var capabilities []WorkerCapability
+	for _, capability := range w.Capabilities {
+		introduced, known := workerCapabilityVersions[capability]
+		if !known || introduced > version {
+			continue
+		}
+
+		if containsCapability(capabilities, capability) {
+			continue
+		}
+
+		capabilities = append(capabilities, capability)
+	}
+
+	sort.Slice(capabilities, func(i, j int) bool {
+		return capabilities[i] < capabilities[j]
+	})
+
+	w.ProtocolVersion = version
+	w.Capabilities = capabilities
+
+	return nil
+}
+
+func containsCapability(capabilities []WorkerCapability, capability WorkerCapability) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+
+	return false
+}
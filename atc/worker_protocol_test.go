@@ -0,0 +1,88 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Worker protocol", func() {
+	Describe("NegotiateProtocol", func() {
+		var worker atc.Worker
+
+		BeforeEach(func() {
+			worker = atc.Worker{
+				GardenAddr: "127.7.7.7",
+			}
+		})
+
+		Context("when the worker does not report a protocol version", func() {
+			BeforeEach(func() {
+				worker.Capabilities = []atc.WorkerCapability{atc.WorkerCapabilityGPUs}
+			})
+
+			It("negotiates the legacy protocol without capabilities", func() {
+				Expect(worker.NegotiateProtocol()).To(Succeed())
+				Expect(worker.ProtocolVersion).To(Equal(atc.LegacyWorkerProtocolVersion))
+				Expect(worker.Capabilities).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker speaks the current protocol", func() {
+			BeforeEach(func() {
+				worker.ProtocolVersion = atc.WorkerProtocolVersion
+				worker.Capabilities = []atc.WorkerCapability{
+					atc.WorkerCapabilityZstdStreaming,
+					atc.WorkerCapabilityGPUs,
+					atc.WorkerCapabilityGPUs,
+				}
+			})
+
+			It("negotiates the capabilities it offers", func() {
+				Expect(worker.NegotiateProtocol()).To(Succeed())
+				Expect(worker.ProtocolVersion).To(Equal(atc.WorkerProtocolVersion))
+				Expect(worker.Capabilities).To(Equal([]atc.WorkerCapability{
+					atc.WorkerCapabilityGPUs,
+					atc.WorkerCapabilityZstdStreaming,
+				}))
+			})
+
+			Context("when it leaves a capability out", func() {
+				BeforeEach(func() {
+					worker.Capabilities = []atc.WorkerCapability{atc.WorkerCapabilityZstdStreaming}
+				})
+
+				It("does not negotiate it", func() {
+					Expect(worker.NegotiateProtocol()).To(Succeed())
+					Expect(worker.Capabilities).To(Equal([]atc.WorkerCapability{atc.WorkerCapabilityZstdStreaming}))
+				})
+			})
+		})
+
+		Context("when the worker speaks a newer protocol", func() {
+			BeforeEach(func() {
+				worker.ProtocolVersion = atc.WorkerProtocolVersion + 1
+				worker.Capabilities = []atc.WorkerCapability{"some-future-capability", atc.WorkerCapabilityGPUs}
+			})
+
+			It("negotiates the ATC's protocol and leaves out unknown capabilities", func() {
+				Expect(worker.NegotiateProtocol()).To(Succeed())
+				Expect(worker.ProtocolVersion).To(Equal(atc.WorkerProtocolVersion))
+				Expect(worker.Capabilities).To(Equal([]atc.WorkerCapability{atc.WorkerCapabilityGPUs}))
+			})
+		})
+
+		Context("when the worker speaks a protocol older than the oldest supported", func() {
+			BeforeEach(func() {
+				worker.ProtocolVersion = -1
+			})
+
+			It("returns an error", func() {
+				Expect(worker.NegotiateProtocol()).To(Equal(atc.UnsupportedWorkerProtocolError{
+					Version: -1,
+				}))
+			})
+		})
+	})
+})
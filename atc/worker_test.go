@@ -60,5 +60,79 @@ var _ = Describe("Worker", func() {
 				Expect(err.Error()).To(ContainSubstring("missing garden address"))
 			})
 		})
+
+		Context("when gpus is negative", func() {
+			BeforeEach(func() {
+				worker.GPUs = -1
+			})
+
+			It("returns errors", func() {
+				Expect(worker.Validate()).To(Equal(atc.ErrInvalidWorkerGPUs))
+			})
+		})
+
+		Context("when stream encoding is gzip", func() {
+			BeforeEach(func() {
+				worker.StreamEncoding = "gzip"
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+		})
+
+		Context("when stream encoding is not supported", func() {
+			BeforeEach(func() {
+				worker.StreamEncoding = "lz4"
+			})
+
+			It("returns errors", func() {
+				Expect(worker.Validate()).To(Equal(atc.ErrInvalidWorkerStreamEncoding))
+			})
+		})
+
+		Context("when labels have valid names", func() {
+			BeforeEach(func() {
+				worker.Labels = atc.Labels{"zone": "us-east-1", "example.com/gpu": ""}
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+		})
+
+		Context("when a label has an invalid name", func() {
+			BeforeEach(func() {
+				worker.Labels = atc.Labels{"instance type": "m5.large"}
+			})
+
+			It("returns errors", func() {
+				Expect(worker.Validate()).To(Equal(atc.ErrInvalidWorkerLabel))
+			})
+		})
+	})
+})
+
+var _ = Describe("Labels", func() {
+	Describe("Matches", func() {
+		labels := atc.Labels{"zone": "us-east-1", "gpu": "true"}
+
+		It("matches an empty selector", func() {
+			Expect(labels.Matches(nil)).To(BeTrue())
+		})
+
+		It("matches when every label has the same value", func() {
+			Expect(labels.Matches(atc.Labels{"zone": "us-east-1", "gpu": "true"})).To(BeTrue())
+			Expect(labels.Matches(atc.Labels{"gpu": "true"})).To(BeTrue())
+		})
+
+		It("does not match when a label has a different value", func() {
+			Expect(labels.Matches(atc.Labels{"zone": "us-west-1"})).To(BeFalse())
+		})
+
+		It("does not match when a label is missing", func() {
+			Expect(labels.Matches(atc.Labels{"instance-type": "m5.large"})).To(BeFalse())
+			Expect(atc.Labels(nil).Matches(atc.Labels{"gpu": "true"})).To(BeFalse())
+		})
 	})
 })
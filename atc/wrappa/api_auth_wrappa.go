@@ -45,11 +45,20 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
+			atc.GetBuildArtifactRegistry,
+			atc.ExplainBuild,
+			atc.GetBuildTimeline,
+			atc.GetBuildPlanGraph,
 			atc.ListBuildArtifacts:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.PauseBuild,
+			atc.ResumeBuild,
+			atc.RerunBuild,
+			atc.GetBuildSnapshot,
+			atc.DownloadBuildArtifact:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -59,14 +68,20 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListDestroyingVolumes,
 			atc.ListDestroyingContainers,
 			atc.ReportWorkerContainers,
-			atc.ReportWorkerVolumes:
+			atc.ReportWorkerVolumes,
+			atc.ListWorkerDiscrepancies,
+			atc.ResolveWorkerDiscrepancies:
 			newHandler = wrappa.checkWorkerTeamAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
 		case atc.GetPipeline,
+			atc.GetPipelineGraph,
 			atc.GetJobBuild,
 			atc.PipelineBadge,
+			atc.PipelineStatus,
 			atc.JobBadge,
+			atc.JobStatus,
+			atc.GetJobStatistics,
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
@@ -75,6 +90,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
 			atc.GetResourceCausality,
+			atc.ListResourceVersionSpan,
 			atc.GetResourceVersion,
 			atc.ListResources,
 			atc.ListResourceTypes,
@@ -85,6 +101,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.CreateBuild,
 			atc.GetContainer,
 			atc.HijackContainer,
+			atc.HijackCheckContainer,
 			atc.ListContainers,
 			atc.ListWorkers,
 			atc.RegisterWorker,
@@ -95,6 +112,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListTeamBuilds,
 			atc.RenameTeam,
 			atc.DestroyTeam,
+			atc.RestoreTeam,
 			atc.ListVolumes:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
@@ -109,11 +127,17 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllJobs,
 			atc.ListAllResources,
 			atc.ListBuilds,
+			atc.MultiplexBuildEvents,
+			atc.AnnotateBuild,
 			atc.MainJobBadge:
 			newHandler = auth.CheckAuthenticationIfProvidedHandler(handler, rejector)
 
 		case atc.GetLogLevel,
 			atc.ListActiveUsersSince,
+			atc.ListLocks,
+			atc.GetCacheEfficiency,
+			atc.GetMigrationStatus,
+			atc.GetDBHealth,
 			atc.SetLogLevel,
 			atc.GetInfoCreds:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
@@ -121,18 +145,23 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// authorized (requested team matches resource team)
 		case atc.CheckResource,
 			atc.CheckResourceType,
+			atc.ListResourceChecks,
 			atc.CreateJobBuild,
 			atc.CreatePipelineBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.PinResourceVersion,
+			atc.UpdateResourceVersionMetadata,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.GetConfig,
 			atc.GetCC,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.DryRunJobSchedule,
+			atc.GetPipelineScheduling,
+			atc.GetJobPlanGraph,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
@@ -141,10 +170,18 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpausePipeline,
 			atc.ExposePipeline,
 			atc.HidePipeline,
+			atc.SharePipeline,
+			atc.UnsharePipeline,
 			atc.SaveConfig,
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
-			atc.GetArtifact:
+			atc.GetArtifact,
+			atc.GetPipelinesRepoStatus,
+			atc.GetResourceTypeMappings,
+			atc.GetResourceTypeHealth,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
@@ -148,31 +148,46 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
 
 				// authorized or public pipeline and public job
-				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
-				atc.ListBuildArtifacts:  checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
-				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
-				atc.GetBuildPlan:        checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.BuildEvents:              checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
+				atc.ListBuildArtifacts:       checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
+				atc.GetBuildPreparation:      checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.GetBuildPlan:             checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.GetBuildArtifactRegistry: checksIfPrivateJob(inputHandlers[atc.GetBuildArtifactRegistry]),
+				atc.ExplainBuild:             checksIfPrivateJob(inputHandlers[atc.ExplainBuild]),
+				atc.GetBuildTimeline:         checksIfPrivateJob(inputHandlers[atc.GetBuildTimeline]),
+				atc.GetBuildPlanGraph:        checksIfPrivateJob(inputHandlers[atc.GetBuildPlanGraph]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:            checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.PauseBuild:            checkWritePermissionForBuild(inputHandlers[atc.PauseBuild]),
+				atc.ResumeBuild:           checkWritePermissionForBuild(inputHandlers[atc.ResumeBuild]),
+				atc.RerunBuild:            checkWritePermissionForBuild(inputHandlers[atc.RerunBuild]),
+				atc.GetBuildSnapshot:      checkWritePermissionForBuild(inputHandlers[atc.GetBuildSnapshot]),
+				atc.DownloadBuildArtifact: checkWritePermissionForBuild(inputHandlers[atc.DownloadBuildArtifact]),
 
 				// resource belongs to authorized team
-				atc.PruneWorker:              checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
-				atc.LandWorker:               checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
-				atc.ReportWorkerContainers:   checkTeamAccessForWorker(inputHandlers[atc.ReportWorkerContainers]),
-				atc.ReportWorkerVolumes:      checkTeamAccessForWorker(inputHandlers[atc.ReportWorkerVolumes]),
-				atc.RetireWorker:             checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
-				atc.ListDestroyingContainers: checkTeamAccessForWorker(inputHandlers[atc.ListDestroyingContainers]),
-				atc.ListDestroyingVolumes:    checkTeamAccessForWorker(inputHandlers[atc.ListDestroyingVolumes]),
+				atc.PruneWorker:                checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
+				atc.LandWorker:                 checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
+				atc.ReportWorkerContainers:     checkTeamAccessForWorker(inputHandlers[atc.ReportWorkerContainers]),
+				atc.ReportWorkerVolumes:        checkTeamAccessForWorker(inputHandlers[atc.ReportWorkerVolumes]),
+				atc.RetireWorker:               checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.ListDestroyingContainers:   checkTeamAccessForWorker(inputHandlers[atc.ListDestroyingContainers]),
+				atc.ListDestroyingVolumes:      checkTeamAccessForWorker(inputHandlers[atc.ListDestroyingVolumes]),
+				atc.ListWorkerDiscrepancies:    checkTeamAccessForWorker(inputHandlers[atc.ListWorkerDiscrepancies]),
+				atc.ResolveWorkerDiscrepancies: checkTeamAccessForWorker(inputHandlers[atc.ResolveWorkerDiscrepancies]),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
+				atc.GetPipelineGraph:              openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipelineGraph]),
 				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobBuild]),
 				atc.PipelineBadge:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.PipelineBadge]),
 				atc.JobBadge:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.JobBadge]),
+				atc.JobStatus:                     openForPublicPipelineOrAuthorized(inputHandlers[atc.JobStatus]),
+				atc.PipelineStatus:                openForPublicPipelineOrAuthorized(inputHandlers[atc.PipelineStatus]),
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
+				atc.GetJobStatistics:              openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobStatistics]),
 				atc.ListPipelineBuilds:            openForPublicPipelineOrAuthorized(inputHandlers[atc.ListPipelineBuilds]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
@@ -181,23 +196,26 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListResourceTypes:             openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceTypes]),
 				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
 				atc.GetResourceCausality:          openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceCausality]),
+				atc.ListResourceVersionSpan:       openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersionSpan]),
 				atc.GetResourceVersion:            openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceVersion]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
-				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
-				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
-				atc.ListTeamBuilds:  authenticated(inputHandlers[atc.ListTeamBuilds]),
-				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
-				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
-				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
-				atc.GetTeam:         authenticated(inputHandlers[atc.GetTeam]),
-				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
-				atc.RenameTeam:      authenticated(inputHandlers[atc.RenameTeam]),
-				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
+				atc.CreateBuild:          authenticated(inputHandlers[atc.CreateBuild]),
+				atc.GetContainer:         authenticated(inputHandlers[atc.GetContainer]),
+				atc.HijackContainer:      authenticated(inputHandlers[atc.HijackContainer]),
+				atc.HijackCheckContainer: authenticated(inputHandlers[atc.HijackCheckContainer]),
+				atc.ListContainers:       authenticated(inputHandlers[atc.ListContainers]),
+				atc.ListVolumes:          authenticated(inputHandlers[atc.ListVolumes]),
+				atc.ListTeamBuilds:       authenticated(inputHandlers[atc.ListTeamBuilds]),
+				atc.ListWorkers:          authenticated(inputHandlers[atc.ListWorkers]),
+				atc.RegisterWorker:       authenticated(inputHandlers[atc.RegisterWorker]),
+				atc.HeartbeatWorker:      authenticated(inputHandlers[atc.HeartbeatWorker]),
+				atc.DeleteWorker:         authenticated(inputHandlers[atc.DeleteWorker]),
+				atc.GetTeam:              authenticated(inputHandlers[atc.GetTeam]),
+				atc.SetTeam:              authenticated(inputHandlers[atc.SetTeam]),
+				atc.RenameTeam:           authenticated(inputHandlers[atc.RenameTeam]),
+				atc.DestroyTeam:          authenticated(inputHandlers[atc.DestroyTeam]),
+				atc.RestoreTeam:          authenticated(inputHandlers[atc.RestoreTeam]),
 
 				//authenticateIfTokenProvided / delegating to handler
 				atc.GetInfo:              authenticateIfTokenProvided(inputHandlers[atc.GetInfo]),
@@ -206,6 +224,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.CheckResourceWebHook: authenticateIfTokenProvided(inputHandlers[atc.CheckResourceWebHook]),
 				atc.ListAllPipelines:     authenticateIfTokenProvided(inputHandlers[atc.ListAllPipelines]),
 				atc.ListBuilds:           authenticateIfTokenProvided(inputHandlers[atc.ListBuilds]),
+				atc.MultiplexBuildEvents: authenticateIfTokenProvided(inputHandlers[atc.MultiplexBuildEvents]),
+				atc.AnnotateBuild:        authenticateIfTokenProvided(inputHandlers[atc.AnnotateBuild]),
 				atc.ListPipelines:        authenticateIfTokenProvided(inputHandlers[atc.ListPipelines]),
 				atc.ListAllJobs:          authenticateIfTokenProvided(inputHandlers[atc.ListAllJobs]),
 				atc.ListAllResources:     authenticateIfTokenProvided(inputHandlers[atc.ListAllResources]),
@@ -217,34 +237,51 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.SetLogLevel:          authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 				atc.GetInfoCreds:         authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
 				atc.ListActiveUsersSince: authenticatedAndAdmin(inputHandlers[atc.ListActiveUsersSince]),
+				atc.ListLocks:            authenticatedAndAdmin(inputHandlers[atc.ListLocks]),
+				atc.GetCacheEfficiency:   authenticatedAndAdmin(inputHandlers[atc.GetCacheEfficiency]),
+				atc.GetMigrationStatus:   authenticatedAndAdmin(inputHandlers[atc.GetMigrationStatus]),
+				atc.GetDBHealth:          authenticatedAndAdmin(inputHandlers[atc.GetDBHealth]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
-				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
-				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:   authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.PinResourceVersion:      authorized(inputHandlers[atc.PinResourceVersion]),
-				atc.UnpinResource:           authorized(inputHandlers[atc.UnpinResource]),
-				atc.SetPinCommentOnResource: authorized(inputHandlers[atc.SetPinCommentOnResource]),
-				atc.GetConfig:               authorized(inputHandlers[atc.GetConfig]),
-				atc.GetCC:                   authorized(inputHandlers[atc.GetCC]),
-				atc.GetVersionsDB:           authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:           authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:          authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:                authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:           authorized(inputHandlers[atc.PausePipeline]),
-				atc.RenamePipeline:          authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:              authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:              authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:         authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.ExposePipeline:          authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:            authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:     authorized(inputHandlers[atc.CreatePipelineBuild]),
-				atc.ClearTaskCache:          authorized(inputHandlers[atc.ClearTaskCache]),
-				atc.CreateArtifact:          authorized(inputHandlers[atc.CreateArtifact]),
-				atc.GetArtifact:             authorized(inputHandlers[atc.GetArtifact]),
+				atc.CheckResource:                 authorized(inputHandlers[atc.CheckResource]),
+				atc.CheckResourceType:             authorized(inputHandlers[atc.CheckResourceType]),
+				atc.ListResourceChecks:            authorized(inputHandlers[atc.ListResourceChecks]),
+				atc.CreateJobBuild:                authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.DeletePipeline:                authorized(inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion:        authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:         authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.PinResourceVersion:            authorized(inputHandlers[atc.PinResourceVersion]),
+				atc.UpdateResourceVersionMetadata: authorized(inputHandlers[atc.UpdateResourceVersionMetadata]),
+				atc.UnpinResource:                 authorized(inputHandlers[atc.UnpinResource]),
+				atc.SetPinCommentOnResource:       authorized(inputHandlers[atc.SetPinCommentOnResource]),
+				atc.GetConfig:                     authorized(inputHandlers[atc.GetConfig]),
+				atc.GetCC:                         authorized(inputHandlers[atc.GetCC]),
+				atc.GetVersionsDB:                 authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.GetPipelineScheduling:         authorized(inputHandlers[atc.GetPipelineScheduling]),
+				atc.ListJobInputs:                 authorized(inputHandlers[atc.ListJobInputs]),
+				atc.DryRunJobSchedule:             authorized(inputHandlers[atc.DryRunJobSchedule]),
+				atc.GetJobPlanGraph:               authorized(inputHandlers[atc.GetJobPlanGraph]),
+				atc.OrderPipelines:                authorized(inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:                      authorized(inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:                 authorized(inputHandlers[atc.PausePipeline]),
+				atc.RenamePipeline:                authorized(inputHandlers[atc.RenamePipeline]),
+				atc.SaveConfig:                    authorized(inputHandlers[atc.SaveConfig]),
+				atc.UnpauseJob:                    authorized(inputHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:               authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.ExposePipeline:                authorized(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:                  authorized(inputHandlers[atc.HidePipeline]),
+				atc.SharePipeline:                 authorized(inputHandlers[atc.SharePipeline]),
+				atc.UnsharePipeline:               authorized(inputHandlers[atc.UnsharePipeline]),
+				atc.CreatePipelineBuild:           authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.ClearTaskCache:                authorized(inputHandlers[atc.ClearTaskCache]),
+				atc.CreateArtifact:                authorized(inputHandlers[atc.CreateArtifact]),
+				atc.GetArtifact:                   authorized(inputHandlers[atc.GetArtifact]),
+				atc.GetPipelinesRepoStatus:        authorized(inputHandlers[atc.GetPipelinesRepoStatus]),
+				atc.GetResourceTypeMappings:       authorized(inputHandlers[atc.GetResourceTypeMappings]),
+				atc.GetResourceTypeHealth:         authorized(inputHandlers[atc.GetResourceTypeHealth]),
+				atc.ListAPITokens:                 authorized(inputHandlers[atc.ListAPITokens]),
+				atc.CreateAPIToken:                authorized(inputHandlers[atc.CreateAPIToken]),
+				atc.RevokeAPIToken:                authorized(inputHandlers[atc.RevokeAPIToken]),
 			}
 		})
 
@@ -29,7 +29,8 @@ func (command *DestroyTeamCommand) Execute([]string) error {
 	}
 
 	teamName := command.Team.Name()
-	fmt.Printf("!!! this will remove all data for team `%s`\n\n", teamName)
+	fmt.Printf("!!! this will delete team `%s`, pausing its pipelines and unregistering its workers\n", teamName)
+	fmt.Printf("!!! its data will be removed once the deleted team grace period has passed\n\n")
 
 	if !command.SkipInteractive {
 		var confirm string
@@ -48,6 +49,7 @@ func (command *DestroyTeamCommand) Execute([]string) error {
 	case nil:
 		fmt.Println()
 		fmt.Printf("`%s` deleted\n", teamName)
+		fmt.Printf("run `fly -t %s restore-team -n %s` to undo this\n", Fly.Target, teamName)
 		return nil
 	case concourse.ErrDestroyRefused:
 		fmt.Println()
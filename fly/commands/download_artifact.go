@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/DataDog/zstd"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/go-archive/tarfs"
+)
+
+type DownloadArtifactCommand struct {
+	Job      flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to download an artifact of"`
+	Build    string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to download the artifact of. If job not specified: build id"`
+	Artifact string              `short:"a" long:"artifact" required:"true" value-name:"NAME" description:"Name of the artifact to download"`
+	Output   string              `short:"o" long:"output" default:"." value-name:"DIR" description:"Directory to extract the artifact into"`
+}
+
+func (command *DownloadArtifactCommand) Execute([]string) error {
+	target, build, err := findBuild(command.Job, command.Build)
+	if err != nil {
+		return err
+	}
+
+	out, err := target.Client().DownloadBuildArtifact(strconv.Itoa(build.ID), command.Artifact)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	err = tarfs.Extract(zstd.NewReader(out), command.Output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("downloaded %s from #%s into %s\n", command.Artifact, build.Name, command.Output)
+	return nil
+}
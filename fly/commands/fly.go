@@ -29,6 +29,7 @@ type FlyCommand struct {
 	SetTeam     SetTeamCommand     `command:"set-team"  alias:"st" description:"Create or modify a team to have the given credentials"`
 	RenameTeam  RenameTeamCommand  `command:"rename-team"   alias:"rt" description:"Rename a team"`
 	DestroyTeam DestroyTeamCommand `command:"destroy-team"  alias:"dt" description:"Destroy a team and delete all of its data"`
+	RestoreTeam RestoreTeamCommand `command:"restore-team"  alias:"rest" description:"Restore a team which was destroyed, unless its data has been removed already"`
 
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
@@ -50,6 +51,8 @@ type FlyCommand struct {
 	UnpausePipeline  UnpausePipelineCommand  `command:"unpause-pipeline"    alias:"up"   description:"Un-pause a pipeline"`
 	ExposePipeline   ExposePipelineCommand   `command:"expose-pipeline"     alias:"ep"   description:"Make a pipeline publicly viewable"`
 	HidePipeline     HidePipelineCommand     `command:"hide-pipeline"       alias:"hp"   description:"Hide a pipeline from the public"`
+	SharePipeline    SharePipelineCommand    `command:"share-pipeline"      alias:"shp"  description:"Make a pipeline viewable by anyone with a share url"`
+	UnsharePipeline  UnsharePipelineCommand  `command:"unshare-pipeline"    alias:"ushp" description:"Revoke a pipeline's share url"`
 	RenamePipeline   RenamePipelineCommand   `command:"rename-pipeline"     alias:"rp"   description:"Rename a pipeline"`
 	ValidatePipeline ValidatePipelineCommand `command:"validate-pipeline"   alias:"vp"   description:"Validate a pipeline config"`
 	FormatPipeline   FormatPipelineCommand   `command:"format-pipeline"     alias:"fp"   description:"Format a pipeline config"`
@@ -58,6 +61,7 @@ type FlyCommand struct {
 	Resources        ResourcesCommand        `command:"resources"               alias:"rs"   description:"List the resources in the pipeline"`
 	ResourceVersions ResourceVersionsCommand `command:"resource-versions"       alias:"rvs"  description:"List the versions of a resource"`
 	CheckResource    CheckResourceCommand    `command:"check-resource"          alias:"cr"   description:"Check a resource"`
+	ResourceChecks   ResourceChecksCommand   `command:"resource-checks"         alias:"rcs"  description:"List the recent checks of a resource"`
 	PinResource      PinResourceCommand      `command:"pin-resource"    alias:"pr"  description:"Pin a version to a resource"`
 	UnpinResource    UnpinResourceCommand    `command:"unpin-resource"          alias:"ur"  description:"Unpin a resource"`
 
@@ -65,8 +69,13 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
+	Builds      BuildsCommand      `command:"builds"       alias:"bs" description:"List builds data"`
+	AbortBuild  AbortBuildCommand  `command:"abort-build"  alias:"ab" description:"Abort a build"`
+	PauseBuild  PauseBuildCommand  `command:"pause-build"  alias:"pb" description:"Pause a build once its current step finishes"`
+	ResumeBuild ResumeBuildCommand `command:"resume-build" alias:"rb" description:"Resume a paused build"`
+	RerunBuild  RerunBuildCommand  `command:"rerun-build"  alias:"rrb" description:"Re-run a failed build of a job from the step it failed at"`
+
+	DownloadArtifact DownloadArtifactCommand `command:"download-artifact" alias:"da" description:"Download an artifact kept by a finished build"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
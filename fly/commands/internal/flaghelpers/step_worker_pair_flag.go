@@ -0,0 +1,23 @@
+package flaghelpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+type StepWorkerPairFlag struct {
+	Step   string
+	Worker string
+}
+
+func (pair *StepWorkerPairFlag) UnmarshalFlag(value string) error {
+	vs := strings.SplitN(value, "=", 2)
+	if len(vs) != 2 || vs[0] == "" || vs[1] == "" {
+		return fmt.Errorf("invalid step worker pair '%s' (must be step=worker)", value)
+	}
+
+	pair.Step = vs[0]
+	pair.Worker = vs[1]
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"reflect"
 
 	"github.com/concourse/concourse/fly/rc"
 
@@ -156,6 +157,77 @@ func diff(existingConfig atc.Config, newConfig atc.Config) bool {
 		}
 	}
 
+	if !reflect.DeepEqual(existingConfig.ResourceDefaults, newConfig.ResourceDefaults) {
+		diffExists = true
+		fmt.Println("resource defaults:")
+
+		payloadA, _ := yaml.Marshal(existingConfig.ResourceDefaults)
+		payloadB, _ := yaml.Marshal(newConfig.ResourceDefaults)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
+	if !reflect.DeepEqual(existingConfig.ContainerDNS, newConfig.ContainerDNS) {
+		diffExists = true
+		fmt.Println("container dns:")
+
+		payloadA, _ := yaml.Marshal(existingConfig.ContainerDNS)
+		payloadB, _ := yaml.Marshal(newConfig.ContainerDNS)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
+	if !reflect.DeepEqual(existingConfig.Branches, newConfig.Branches) {
+		diffExists = true
+		fmt.Println("branches:")
+
+		payloadA, _ := yaml.Marshal(existingConfig.Branches)
+		payloadB, _ := yaml.Marshal(newConfig.Branches)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
+	if !reflect.DeepEqual(existingConfig.SchedulingWindows, newConfig.SchedulingWindows) {
+		diffExists = true
+		fmt.Println("scheduling windows:")
+
+		payloadA, _ := yaml.Marshal(existingConfig.SchedulingWindows)
+		payloadB, _ := yaml.Marshal(newConfig.SchedulingWindows)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
+	if !reflect.DeepEqual(existingConfig.Labels, newConfig.Labels) {
+		diffExists = true
+		fmt.Println("labels:")
+
+		payloadA, _ := yaml.Marshal(existingConfig.Labels)
+		payloadB, _ := yaml.Marshal(newConfig.Labels)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
+	if existingConfig.TimeZone != newConfig.TimeZone {
+		diffExists = true
+		fmt.Println("timezone:")
+
+		renderDiff(indent, fmt.Sprintf("%s\n", existingConfig.TimeZone), fmt.Sprintf("%s\n", newConfig.TimeZone))
+	}
+
+	if existingConfig.Locale != newConfig.Locale {
+		diffExists = true
+		fmt.Println("locale:")
+
+		renderDiff(indent, fmt.Sprintf("%s\n", existingConfig.Locale), fmt.Sprintf("%s\n", newConfig.Locale))
+	}
+
+	if existingConfig.IgnoreTeamContainerEnv != newConfig.IgnoreTeamContainerEnv {
+		diffExists = true
+		fmt.Println("ignore team container env:")
+
+		renderDiff(indent, fmt.Sprintf("%t\n", existingConfig.IgnoreTeamContainerEnv), fmt.Sprintf("%t\n", newConfig.IgnoreTeamContainerEnv))
+	}
+
 	jobDiffs := diffIndices(JobIndex(existingConfig.Jobs), JobIndex(newConfig.Jobs))
 	if len(jobDiffs) > 0 {
 		diffExists = true
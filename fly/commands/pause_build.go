@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type PauseBuildCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to pause a build of"`
+	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to pause. If job not specified: build id"`
+}
+
+func (command *PauseBuildCommand) Execute([]string) error {
+	target, build, err := findBuild(command.Job, command.Build)
+	if err != nil {
+		return err
+	}
+
+	if err := target.Client().PauseBuild(strconv.Itoa(build.ID)); err != nil {
+		return err
+	}
+
+	fmt.Println("build paused; it will hold once its current step finishes")
+	return nil
+}
+
+// findBuild finds a build by its number within the job if one is given, or
+// by its id otherwise.
+func findBuild(job flaghelpers.JobFlag, buildName string) (rc.Target, atc.Build, error) {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return nil, atc.Build{}, err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return nil, atc.Build{}, err
+	}
+
+	var build atc.Build
+	var exists bool
+	if job.PipelineName == "" && job.JobName == "" {
+		build, exists, err = target.Client().Build(buildName)
+	} else {
+		build, exists, err = target.Team().JobBuild(job.PipelineName, job.JobName, buildName)
+	}
+	if err != nil {
+		return nil, atc.Build{}, err
+	}
+
+	if !exists {
+		return nil, atc.Build{}, fmt.Errorf("build does not exist")
+	}
+
+	return target, build, nil
+}
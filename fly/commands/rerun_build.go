@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+type RerunBuildCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to re-run a build of"`
+	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to re-run. If job not specified: build id"`
+}
+
+func (command *RerunBuildCommand) Execute([]string) error {
+	target, build, err := findBuild(command.Job, command.Build)
+	if err != nil {
+		return err
+	}
+
+	rerun, err := target.Client().RerunBuild(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("started %s/%s #%s, re-running #%s from the step it failed at\n", rerun.PipelineName, rerun.JobName, rerun.Name, build.Name)
+	return nil
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ResourceChecksCommand struct {
+	Count    int                      `short:"c" long:"count" default:"50" description:"Number of checks you want to limit the return to"`
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of a resource to get checks for"`
+	Json     bool                     `long:"json" description:"Print command result as JSON, including the output of each check"`
+}
+
+func (command *ResourceChecksCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	checks, found, err := target.Team().ResourceChecks(command.Resource.PipelineName, command.Resource.ResourceName, command.Count)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("resource '%s' not found", command.Resource.ResourceName)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(checks)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "start", Color: color.New(color.Bold)},
+			{Contents: "end", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+			{Contents: "error", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, check := range checks {
+		startTimeCell, endTimeCell, durationCell := populateTimeCells(time.Unix(check.StartTime, 0), time.Unix(check.EndTime, 0))
+
+		statusCell := ui.TableCell{Contents: check.Status}
+		switch check.Status {
+		case "started":
+			statusCell.Color = ui.StartedColor
+		case "succeeded":
+			statusCell.Color = ui.SucceededColor
+		case "errored":
+			statusCell.Color = ui.ErroredColor
+		}
+
+		workerCell := ui.TableCell{Contents: check.WorkerName}
+		if check.WorkerName == "" {
+			workerCell.Contents = "n/a"
+			workerCell.Color = ui.OffColor
+		}
+
+		// only the first line fits in the table; the rest is in --json
+		errorCell := ui.TableCell{Contents: strings.SplitN(check.CheckError, "\n", 2)[0]}
+		if check.CheckError == "" {
+			errorCell.Contents = "n/a"
+			errorCell.Color = ui.OffColor
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: strconv.Itoa(check.ID)},
+			statusCell,
+			workerCell,
+			startTimeCell,
+			endTimeCell,
+			durationCell,
+			errorCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type RestoreTeamCommand struct {
+	Team string `short:"n" long:"team-name" required:"true" description:"The destroyed team to restore"`
+}
+
+func (command *RestoreTeamCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Team().RestoreTeam(command.Team)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("Destroyed team '%s' not found\n", command.Team)
+	}
+
+	fmt.Printf("`%s` restored\n", command.Team)
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+type ResumeBuildCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to resume a build of"`
+	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to resume. If job not specified: build id"`
+}
+
+func (command *ResumeBuildCommand) Execute([]string) error {
+	target, build, err := findBuild(command.Job, command.Build)
+	if err != nil {
+		return err
+	}
+
+	if err := target.Client().ResumeBuild(strconv.Itoa(build.ID)); err != nil {
+		return err
+	}
+
+	fmt.Println("build resumed")
+	return nil
+}
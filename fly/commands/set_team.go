@@ -2,8 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
@@ -13,6 +16,7 @@ import (
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/jessevdk/go-flags"
 	"github.com/vito/go-interact/interact"
+	"sigs.k8s.io/yaml"
 )
 
 func WireTeamConnectors(command *flags.Command) {
@@ -25,9 +29,32 @@ func WireTeamConnectors(command *flags.Command) {
 }
 
 type SetTeamCommand struct {
-	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
-	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
-	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+	Team                    flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
+	SkipInteractive         bool                 `long:"non-interactive" description:"Force apply configuration"`
+	MaxBuildLogSize         int64                `long:"max-build-log-size" description:"Maximum number of bytes of log output to save per build. Output past this is truncated, keeping the beginning and end. 0 means unlimited (admin only)"`
+	MaxBuildStartsPerMinute int                  `long:"max-build-starts-per-minute" description:"Maximum number of the team's builds to start per minute, enforced by each web node separately. Builds past this stay pending until the limit allows them. 0 means unlimited (admin only)"`
+	ResourceDefaults        atc.PathFlag         `long:"resource-defaults" description:"YAML file mapping resource types to source fields applied to every resource of that type in the team's pipelines"`
+	ContainerEnv            []string             `long:"container-env" value-name:"NAME=VALUE" description:"Environment variable to set in every task and resource container run by the team's builds (can be specified multiple times)"`
+	CACerts                 []atc.PathFlag       `long:"ca-cert" description:"PEM-encoded CA cert to trust in every task and resource container run by the team's builds (can be specified multiple times)"`
+	DNSServers              []string             `long:"dns-server" description:"DNS server for the team's build containers to use in place of the installation's (can be specified multiple times)"`
+	DNSSearch               []string             `long:"dns-search" description:"Search domain for the team's build containers to use in place of the installation's (can be specified multiple times)"`
+	DefaultCheckEvery       string               `long:"default-check-every" description:"Interval to check the team's resources which don't configure check_every, in place of the installation's (admin only)"`
+	MinCheckEvery           string               `long:"min-check-every" description:"Shortest interval at which any of the team's resources may be checked (admin only)"`
+	MaxCheckEvery           string               `long:"max-check-every" description:"Longest interval at which any of the team's resources may be checked (admin only)"`
+	MaxConcurrentChecks     int                  `long:"max-concurrent-checks" description:"Maximum number of the team's checks to run at once. 0 means unlimited (admin only)"`
+	DefaultTaskImage        atc.PathFlag         `long:"default-task-image" description:"YAML file with the type and source of the image used by the team's tasks which configure neither an image nor an image_resource"`
+	PipelinesRepoURI        string               `long:"pipelines-repo-uri" description:"URI of a git repo whose pipeline configs the team's pipelines are reconciled with"`
+	PipelinesRepoBranch     string               `long:"pipelines-repo-branch" description:"Branch of the pipelines repo to reconcile with (default: the repo's default branch)"`
+	PipelinesRepoPath       string               `long:"pipelines-repo-path" description:"Directory in the pipelines repo containing one config file per pipeline"`
+	ConcurrencyPools        []string             `long:"concurrency-pool" value-name:"NAME:LIMIT" description:"Named pool which at most LIMIT of the team's builds may run in at once. Jobs and steps join pools with concurrency_pools (can be specified multiple times)"`
+	ContentScanPolicy       string               `long:"content-scan-policy" choice:"warn" choice:"block" description:"Whether findings by the content scanner only warn in the team's builds or error their get and put steps (admin only)"`
+	ResourceTypeMappings    atc.PathFlag         `long:"resource-type-mappings" description:"YAML file mapping base resource types to the images which implement them for the team, overriding the installation's mapping of each type (admin only)"`
+	RestrictPrivileged      bool                 `long:"restrict-privileged" description:"Only allow the pipelines and jobs given by --privileged-allow to run privileged tasks and resource types (admin only)"`
+	PrivilegedAllow         []string             `long:"privileged-allow" value-name:"PIPELINE[/JOB]" description:"Pipeline, or job of a pipeline, which may run privileged containers. Globs are allowed (can be specified multiple times, admin only)"`
+	PrivilegedOneOffBuilds  bool                 `long:"privileged-one-off-builds" description:"Allow one-off builds to run privileged containers when privileged containers are restricted (admin only)"`
+	RequireImageDigests     bool                 `long:"require-image-digests" description:"Require the image_resource of the team's tasks to be pinned by digest (admin only)"`
+	Clear                   []string             `long:"clear" value-name:"SETTING" choice:"max_build_log_size" choice:"max_build_starts_per_minute" choice:"resource_defaults" choice:"container_env" choice:"ca_certs" choice:"container_dns" choice:"check_policy" choice:"default_task_image" choice:"pipelines_repo" choice:"concurrency_pools" choice:"content_scan_policy" choice:"resource_type_mappings" choice:"privileged_policy" choice:"require_image_digests" description:"Setting to reset to its default, e.g. to lift the team's max_build_log_size (can be specified multiple times)"`
+	AuthFlags               skycmd.AuthTeamFlags `group:"Authentication"`
 }
 
 func (command *SetTeamCommand) Execute([]string) error {
@@ -53,6 +80,158 @@ func (command *SetTeamCommand) Execute([]string) error {
 	}
 	sort.Strings(roles)
 
+	var resourceDefaults atc.ResourceDefaults
+	if command.ResourceDefaults != "" {
+		payload, err := ioutil.ReadFile(string(command.ResourceDefaults))
+		if err != nil {
+			displayhelpers.FailWithErrorf("could not read resource defaults file", err)
+		}
+
+		err = yaml.Unmarshal(payload, &resourceDefaults)
+		if err != nil {
+			displayhelpers.FailWithErrorf("could not unmarshal resource defaults", err)
+		}
+	}
+
+	containerEnv := map[string]string{}
+	for _, e := range command.ContainerEnv {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			displayhelpers.Failf("invalid container env '%s': expected NAME=VALUE", e)
+		}
+
+		containerEnv[parts[0]] = parts[1]
+	}
+
+	var caCerts string
+	for _, path := range command.CACerts {
+		cert, err := ioutil.ReadFile(string(path))
+		if err != nil {
+			displayhelpers.FailWithErrorf("could not read CA cert file", err)
+		}
+
+		caCerts += strings.TrimSpace(string(cert)) + "\n"
+	}
+
+	var containerDNS *atc.ContainerDNS
+	if len(command.DNSServers) > 0 || len(command.DNSSearch) > 0 {
+		containerDNS = &atc.ContainerDNS{
+			Servers: command.DNSServers,
+			Search:  command.DNSSearch,
+		}
+
+		err = containerDNS.Validate()
+		if err != nil {
+			displayhelpers.FailWithErrorf("invalid container dns", err)
+		}
+	}
+
+	var checkPolicy *atc.CheckPolicy
+	if command.DefaultCheckEvery != "" || command.MinCheckEvery != "" || command.MaxCheckEvery != "" || command.MaxConcurrentChecks != 0 {
+		checkPolicy = &atc.CheckPolicy{
+			DefaultEvery:  command.DefaultCheckEvery,
+			MinEvery:      command.MinCheckEvery,
+			MaxEvery:      command.MaxCheckEvery,
+			MaxConcurrent: command.MaxConcurrentChecks,
+		}
+
+		err = checkPolicy.Validate()
+		if err != nil {
+			displayhelpers.FailWithErrorf("invalid check policy", err)
+		}
+	}
+
+	var defaultTaskImage *atc.ImageResource
+	if command.DefaultTaskImage != "" {
+		payload, err := ioutil.ReadFile(string(command.DefaultTaskImage))
+		if err != nil {
+			displayhelpers.FailWithErrorf("could not read default task image file", err)
+		}
+
+		err = yaml.Unmarshal(payload, &defaultTaskImage)
+		if err != nil {
+			displayhelpers.FailWithErrorf("could not unmarshal default task image", err)
+		}
+
+		if defaultTaskImage == nil {
+			defaultTaskImage = &atc.ImageResource{}
+		}
+
+		err = defaultTaskImage.Validate()
+		if err != nil {
+			displayhelpers.FailWithErrorf("invalid default task image", err)
+		}
+	}
+
+	var pipelinesRepo *atc.PipelinesRepo
+	if command.PipelinesRepoURI != "" {
+		pipelinesRepo = &atc.PipelinesRepo{
+			URI:    command.PipelinesRepoURI,
+			Branch: command.PipelinesRepoBranch,
+			Path:   command.PipelinesRepoPath,
+		}
+
+		err = pipelinesRepo.Validate()
+		if err != nil {
+			displayhelpers.FailWithErrorf("invalid pipelines repo", err)
+		}
+	}
+
+	var concurrencyPools atc.ConcurrencyPools
+	for _, p := range command.ConcurrencyPools {
+		parts := strings.SplitN(p, ":", 2)
+		if len(parts) != 2 {
+			displayhelpers.Failf("invalid concurrency pool '%s': expected NAME:LIMIT", p)
+		}
+
+		limit, err := strconv.Atoi(parts[1])
+		if err != nil {
+			displayhelpers.Failf("invalid concurrency pool '%s': expected NAME:LIMIT", p)
+		}
+
+		if concurrencyPools == nil {
+			concurrencyPools = atc.ConcurrencyPools{}
+		}
+
+		concurrencyPools[parts[0]] = limit
+	}
+
+	err = concurrencyPools.Validate()
+	if err != nil {
+		displayhelpers.FailWithErrorf("invalid concurrency pools", err)
+	}
+
+	var resourceTypeMappings atc.ResourceTypeMappings
+	if command.ResourceTypeMappings != "" {
+		payload, err := ioutil.ReadFile(string(command.ResourceTypeMappings))
+		if err != nil {
+			displayhelpers.FailWithErrorf("could not read resource type mappings file", err)
+		}
+
+		err = yaml.Unmarshal(payload, &resourceTypeMappings)
+		if err != nil {
+			displayhelpers.FailWithErrorf("could not unmarshal resource type mappings", err)
+		}
+
+		err = resourceTypeMappings.Validate()
+		if err != nil {
+			displayhelpers.FailWithErrorf("invalid resource type mappings", err)
+		}
+	}
+
+	var privilegedPolicy *atc.PrivilegedPolicy
+	if command.RestrictPrivileged || len(command.PrivilegedAllow) > 0 || command.PrivilegedOneOffBuilds {
+		privilegedPolicy = &atc.PrivilegedPolicy{
+			Allow:        command.PrivilegedAllow,
+			OneOffBuilds: command.PrivilegedOneOffBuilds,
+		}
+
+		err = privilegedPolicy.Validate()
+		if err != nil {
+			displayhelpers.FailWithErrorf("invalid privileged policy", err)
+		}
+	}
+
 	teamName := command.Team.Name()
 	fmt.Println("setting team:", ui.Embolden("%s", teamName))
 
@@ -82,6 +261,150 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if command.MaxBuildLogSize > 0 {
+		fmt.Println()
+		fmt.Printf("max build log size: %d bytes\n", command.MaxBuildLogSize)
+	}
+
+	if command.MaxBuildStartsPerMinute > 0 {
+		fmt.Println()
+		fmt.Printf("max build starts per minute: %d (per web node)\n", command.MaxBuildStartsPerMinute)
+	}
+
+	if len(resourceDefaults) > 0 {
+		resourceTypes := []string{}
+		for resourceType := range resourceDefaults {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+		sort.Strings(resourceTypes)
+
+		fmt.Println()
+		fmt.Printf("resource defaults:\n")
+		for _, resourceType := range resourceTypes {
+			fmt.Printf("- %s\n", resourceType)
+		}
+	}
+
+	if len(containerEnv) > 0 {
+		names := []string{}
+		for name := range containerEnv {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println()
+		fmt.Printf("container env:\n")
+		for _, name := range names {
+			fmt.Printf("- %s\n", name)
+		}
+	}
+
+	if len(command.CACerts) > 0 {
+		fmt.Println()
+		fmt.Printf("ca certs:\n")
+		for _, path := range command.CACerts {
+			fmt.Printf("- %s\n", path)
+		}
+	}
+
+	if containerDNS != nil {
+		fmt.Println()
+		fmt.Printf("container dns:\n")
+		for _, server := range containerDNS.Servers {
+			fmt.Printf("- nameserver %s\n", server)
+		}
+		for _, domain := range containerDNS.Search {
+			fmt.Printf("- search %s\n", domain)
+		}
+	}
+
+	if checkPolicy != nil {
+		fmt.Println()
+		fmt.Printf("check policy:\n")
+		if checkPolicy.DefaultEvery != "" {
+			fmt.Printf("- default every %s\n", checkPolicy.DefaultEvery)
+		}
+		if checkPolicy.MinEvery != "" {
+			fmt.Printf("- at most every %s\n", checkPolicy.MinEvery)
+		}
+		if checkPolicy.MaxEvery != "" {
+			fmt.Printf("- at least every %s\n", checkPolicy.MaxEvery)
+		}
+		if checkPolicy.MaxConcurrent != 0 {
+			fmt.Printf("- at most %d at once\n", checkPolicy.MaxConcurrent)
+		}
+	}
+
+	if defaultTaskImage != nil {
+		fmt.Println()
+		fmt.Printf("default task image: %s\n", defaultTaskImage.Type)
+	}
+
+	if pipelinesRepo != nil {
+		fmt.Println()
+		fmt.Printf("pipelines repo: %s\n", pipelinesRepo.URI)
+	}
+
+	if len(concurrencyPools) > 0 {
+		names := []string{}
+		for name := range concurrencyPools {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println()
+		fmt.Printf("concurrency pools:\n")
+		for _, name := range names {
+			fmt.Printf("- %s: %d\n", name, concurrencyPools[name])
+		}
+	}
+
+	if command.ContentScanPolicy != "" {
+		fmt.Println()
+		fmt.Printf("content scan policy: %s\n", command.ContentScanPolicy)
+	}
+
+	if len(resourceTypeMappings) > 0 {
+		names := []string{}
+		for name := range resourceTypeMappings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println()
+		fmt.Printf("resource type mappings:\n")
+		for _, name := range names {
+			fmt.Printf("- %s: %s\n", name, resourceTypeMappings[name].Type)
+		}
+	}
+
+	if privilegedPolicy != nil {
+		fmt.Println()
+		fmt.Printf("privileged containers allowed for:\n")
+		for _, pattern := range privilegedPolicy.Allow {
+			fmt.Printf("- %s\n", pattern)
+		}
+		if privilegedPolicy.OneOffBuilds {
+			fmt.Printf("- one-off builds\n")
+		}
+		if len(privilegedPolicy.Allow) == 0 && !privilegedPolicy.OneOffBuilds {
+			fmt.Printf("  %s\n", ui.OffColor.Sprint("none"))
+		}
+	}
+
+	if command.RequireImageDigests {
+		fmt.Println()
+		fmt.Printf("task images must be pinned by digest\n")
+	}
+
+	if len(command.Clear) > 0 {
+		fmt.Println()
+		fmt.Println("clearing:")
+		for _, setting := range command.Clear {
+			fmt.Printf("  %s\n", setting)
+		}
+	}
+
 	confirm := true
 	if !command.SkipInteractive {
 		confirm = false
@@ -95,7 +418,24 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: atc.TeamAuth(authRoles)}
+	team := atc.Team{
+		Auth:                    atc.TeamAuth(authRoles),
+		MaxBuildLogSize:         command.MaxBuildLogSize,
+		MaxBuildStartsPerMinute: command.MaxBuildStartsPerMinute,
+		ResourceDefaults:        resourceDefaults,
+		ContainerEnv:            containerEnv,
+		CACerts:                 caCerts,
+		ContainerDNS:            containerDNS,
+		CheckPolicy:             checkPolicy,
+		DefaultTaskImage:        defaultTaskImage,
+		PipelinesRepo:           pipelinesRepo,
+		ConcurrencyPools:        concurrencyPools,
+		ContentScanPolicy:       atc.ContentScanPolicy(command.ContentScanPolicy),
+		ResourceTypeMappings:    resourceTypeMappings,
+		PrivilegedPolicy:        privilegedPolicy,
+		RequireImageDigests:     command.RequireImageDigests,
+		Clear:                   command.Clear,
+	}
 
 	_, created, updated, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type SharePipelineCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to share"`
+}
+
+func (command *SharePipelineCommand) Validate() error {
+	return command.Pipeline.Validate()
+}
+
+func (command *SharePipelineCommand) Execute(args []string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	pipelineName := string(command.Pipeline)
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	token, found, err := target.Team().SharePipeline(pipelineName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline '%s' not found\n", pipelineName)
+	}
+
+	fmt.Printf("shared '%s'\n\n", pipelineName)
+	fmt.Println("anyone with this url can view the pipeline; it will not be shown again:")
+	fmt.Printf(
+		"%s/teams/%s/pipelines/%s?%s=%s\n",
+		target.URL(),
+		url.PathEscape(target.Team().Name()),
+		url.PathEscape(pipelineName),
+		atc.ShareTokenQuery,
+		url.QueryEscape(token),
+	)
+
+	return nil
+}
@@ -5,7 +5,10 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/executehelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
@@ -15,6 +18,11 @@ import (
 type TriggerJobCommand struct {
 	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
 	Watch bool                `short:"w" long:"watch" description:"Start watching the build output"`
+
+	Inputs         []flaghelpers.InputPairFlag `short:"i" long:"input" value-name:"NAME=PATH" description:"An input of the job to upload from a local directory instead of fetching it. Can be specified multiple times."`
+	IncludeIgnored bool                        `long:"include-ignored" description:"Including .gitignored paths in uploaded inputs. Disregards .gitignore entries and uploads everything"`
+
+	Workers []flaghelpers.StepWorkerPairFlag `long:"worker" value-name:"STEP=WORKER" description:"A get, put, or task step of the job to run on the named worker, e.g. to reproduce a failure which only happens there. Can be specified multiple times."`
 }
 
 func (command *TriggerJobCommand) Execute(args []string) error {
@@ -30,7 +38,34 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 		return err
 	}
 
-	build, err := target.Team().CreateJobBuild(pipelineName, jobName)
+	var build atc.Build
+	if len(command.Inputs) > 0 || len(command.Workers) > 0 {
+		request := atc.CreateJobBuildRequest{}
+
+		if len(command.Inputs) > 0 {
+			inputs, err := executehelpers.GenerateLocalInputs(atc.NewPlanFactory(time.Now().Unix()), target.Team(), command.Inputs, command.IncludeIgnored, "")
+			if err != nil {
+				return err
+			}
+
+			request.InputArtifacts = atc.InputArtifacts{}
+			for name, input := range inputs {
+				request.InputArtifacts[name] = input.Plan.ArtifactInput.ArtifactID
+			}
+		}
+
+		if len(command.Workers) > 0 {
+			request.WorkerOverrides = atc.StepWorkerOverrides{}
+			for _, pair := range command.Workers {
+				request.WorkerOverrides[pair.Step] = pair.Worker
+			}
+		}
+
+		build, err = target.Team().CreateJobBuildWithRequest(pipelineName, jobName, request)
+	} else {
+		build, err = target.Team().CreateJobBuild(pipelineName, jobName)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -37,6 +37,14 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "%s", e.Payload)
 
+		case event.LogTruncated:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\n%s\n", ui.OffColor.Sprintf("(%d bytes of output truncated)", e.Size))
+
+		case event.Warning:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", ui.StartedColor.Sprintf("WARNING: %s", e.Message))
+
 		case event.InitializeTask:
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1minitializing\x1b[0m\n")
@@ -75,6 +75,33 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a LogTruncated event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.LogTruncated{
+				Size: 1024,
+				Time: time.Now().Unix(),
+			}
+		})
+
+		It("prints how much output was truncated", func() {
+			Expect(out).To(gbytes.Say(`\(1024 bytes of output truncated\)`))
+		})
+	})
+
+	Context("when a Warning event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.Warning{
+				Time:    time.Now().Unix(),
+				Type:    "pipeline",
+				Message: "some-step fetches an artifact which no step uses",
+			}
+		})
+
+		It("prints its message", func() {
+			Expect(out).To(gbytes.Say(`WARNING: some-step fetches an artifact which no step uses`))
+		})
+	})
+
 	Context("when an Error event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.Error{
@@ -53,7 +53,7 @@ var _ = Describe("Fly CLI", func() {
 			}
 
 			It("reminds the user this is a destructive operation", func() {
-				Eventually(sess).Should(gbytes.Say("!!! this will remove all data for team `some-team`"))
+				Eventually(sess).Should(gbytes.Say("!!! this will delete team `some-team`, pausing its pipelines and unregistering its workers"))
 			})
 
 			It("asks the user to type in the team name again", func() {
@@ -0,0 +1,92 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("DownloadArtifact", func() {
+	var outputDir string
+
+	BeforeEach(func() {
+		var err error
+		outputDir, err = ioutil.TempDir("", "fly-download-artifact")
+		Expect(err).NotTo(HaveOccurred())
+
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/my-pipeline/jobs/my-job/builds/42"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{
+					ID:           23,
+					Name:         "42",
+					Status:       "succeeded",
+					JobName:      "my-job",
+					PipelineName: "my-pipeline",
+					APIURL:       "api/v1/builds/23",
+				}),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputDir)
+	})
+
+	Context("when the build kept the artifact", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/artifacts/some-output"),
+					tarHandler,
+				),
+			)
+		})
+
+		It("extracts the artifact into the output directory", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "download-artifact", "-j", "my-pipeline/my-job", "-b", "42", "-a", "some-output", "-o", outputDir)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say(`downloaded some-output from #42`))
+
+			data, err := ioutil.ReadFile(filepath.Join(outputDir, "some-file"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal([]byte("tar-contents")))
+		})
+	})
+
+	Context("when the build didn't keep the artifact", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/artifacts/some-output"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("fails", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "download-artifact", "-j", "my-pipeline/my-job", "-b", "42", "-a", "some-output", "-o", outputDir)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+		})
+	})
+})
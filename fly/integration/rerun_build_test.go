@@ -0,0 +1,91 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("RerunBuild", func() {
+	var (
+		failedBuild = atc.Build{
+			ID:           23,
+			Name:         "42",
+			Status:       "failed",
+			JobName:      "my-job",
+			PipelineName: "my-pipeline",
+			APIURL:       "api/v1/builds/23",
+		}
+
+		rerunBuild = atc.Build{
+			ID:           24,
+			Name:         "43",
+			Status:       "started",
+			JobName:      "my-job",
+			PipelineName: "my-pipeline",
+			APIURL:       "api/v1/builds/24",
+			RerunOf:      23,
+		}
+	)
+
+	BeforeEach(func() {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/my-pipeline/jobs/my-job/builds/42"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, failedBuild),
+			),
+		)
+	})
+
+	Context("when the build can be re-run", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/builds/23/rerun"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, rerunBuild),
+				),
+			)
+		})
+
+		It("starts a build re-running it", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-j", "my-pipeline/my-job", "-b", "42")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say(`started my-pipeline/my-job #43, re-running #42`))
+		})
+	})
+
+	Context("when the build can't be re-run", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/builds/23/rerun"),
+					ghttp.RespondWith(http.StatusConflict, "only failed or errored builds can be re-run"),
+				),
+			)
+		})
+
+		It("fails with the error", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-j", "my-pipeline/my-job", "-b", "42")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("only failed or errored builds can be re-run"))
+		})
+	})
+})
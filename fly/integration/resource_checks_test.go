@@ -0,0 +1,151 @@
+package integration_test
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("resource-checks", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			checkStartTime time.Time
+			checkEndTime   time.Time
+		)
+
+		BeforeEach(func() {
+			checkStartTime = time.Date(2019, time.November, 7, 10, 30, 15, 0, time.UTC)
+			checkEndTime = time.Date(2019, time.November, 7, 10, 30, 20, 0, time.UTC)
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "resource-checks", "-r", "pipeline/foo", "-c", "2")
+		})
+
+		Context("when checks are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources/foo/checks", "limit=2"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Check{
+							{
+								ID:         2,
+								Status:     "errored",
+								StartTime:  checkStartTime.Unix(),
+								EndTime:    checkEndTime.Unix(),
+								WorkerName: "some-worker",
+								CheckError: "failed to check\nsome more detail",
+								Output:     "some-output",
+							},
+							{
+								ID:        1,
+								Status:    "succeeded",
+								StartTime: checkStartTime.Unix(),
+								EndTime:   checkEndTime.Unix(),
+							},
+						}),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints the checks, including their output, in json", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(ContainSubstring(`"output": "some-output"`))
+					Expect(sess.Out.Contents()).To(ContainSubstring(`"worker_name": "some-worker"`))
+				})
+			})
+
+			It("lists the checks", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "worker", Color: color.New(color.Bold)},
+						{Contents: "start", Color: color.New(color.Bold)},
+						{Contents: "end", Color: color.New(color.Bold)},
+						{Contents: "duration", Color: color.New(color.Bold)},
+						{Contents: "error", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "2"},
+							{Contents: "errored", Color: color.New(color.FgRed, color.Bold)},
+							{Contents: "some-worker"},
+							{Contents: checkStartTime.Local().Format(timeDateLayout)},
+							{Contents: checkEndTime.Local().Format(timeDateLayout)},
+							{Contents: "5s"},
+							{Contents: "failed to check"},
+						},
+						{
+							{Contents: "1"},
+							{Contents: "succeeded", Color: color.New(color.FgGreen)},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: checkStartTime.Local().Format(timeDateLayout)},
+							{Contents: checkEndTime.Local().Format(timeDateLayout)},
+							{Contents: "5s"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the resource is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources/foo/checks"),
+						ghttp.RespondWith(404, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("resource 'foo' not found"))
+			})
+		})
+
+		Context("and the api returns an internal server error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources/foo/checks"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("RestoreTeam", func() {
+	BeforeEach(func() {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v1/teams/a-team/restore"),
+				ghttp.RespondWith(http.StatusNoContent, ""),
+			),
+		)
+	})
+
+	Context("when not specifying a team name", func() {
+		It("fails and says you should provide a team name", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "restore-team")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("n", "team-name") + "' was not specified"))
+		})
+	})
+
+	Context("when the team name is provided", func() {
+		It("restores the team", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "restore-team", "-n", "a-team")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(4))
+			Expect(sess.Out).To(gbytes.Say("`a-team` restored"))
+		})
+
+		Context("when the destroyed team is not found", func() {
+			BeforeEach(func() {
+				atcServer.SetHandler(3, ghttp.RespondWith(http.StatusNotFound, ""))
+			})
+
+			It("returns an error", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "restore-team", "-n", "a-team")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Destroyed team 'a-team' not found"))
+			})
+		})
+	})
+})
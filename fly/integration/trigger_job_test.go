@@ -3,9 +3,11 @@ package integration_test
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -130,6 +132,77 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("when inputs are given to upload", func() {
+				var inputDir string
+
+				BeforeEach(func() {
+					inputDir, err = ioutil.TempDir("", "fly-trigger-input")
+					Expect(err).NotTo(HaveOccurred())
+
+					err = ioutil.WriteFile(filepath.Join(inputDir, "hotfix"), []byte("patched"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("POST", "/api/v1/teams/main/artifacts",
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.WorkerArtifact{ID: 17}),
+					)
+
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", path),
+							ghttp.VerifyJSONRepresenting(atc.CreateJobBuildRequest{
+								InputArtifacts: atc.InputArtifacts{"some-input": 17},
+							}),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "42"}),
+						),
+					)
+				})
+
+				AfterEach(func() {
+					os.RemoveAll(inputDir)
+				})
+
+				It("uploads them and starts the build with them", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job", "-i", "some-input="+inputDir)
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
+			Context("when steps are pinned to workers", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", path),
+							ghttp.VerifyJSONRepresenting(atc.CreateJobBuildRequest{
+								WorkerOverrides: atc.StepWorkerOverrides{
+									"some-task":  "some-worker",
+									"some-input": "other-worker",
+								},
+							}),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "42"}),
+						),
+					)
+				})
+
+				It("starts the build with the steps pinned", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job", "--worker", "some-task=some-worker", "--worker", "some-input=other-worker")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
 			Context("when the pipeline/job doesn't exist", func() {
 				BeforeEach(func() {
 					atcServer.AppendHandlers(
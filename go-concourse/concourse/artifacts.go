@@ -37,16 +37,19 @@ func (team *team) GetArtifact(artifactID int) (io.ReadCloser, error) {
 		"artifact_id": strconv.Itoa(artifactID),
 	}
 
-	response := internal.Response{}
-	err := team.connection.Send(internal.Request{
-		RequestName:        atc.GetArtifact,
-		Params:             params,
-		ReturnResponseBody: true,
-	}, &response)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return response.Result.(io.ReadCloser), nil
+	return openResumableDownload(func(header http.Header) (io.ReadCloser, http.Header, error) {
+		headers := http.Header{}
+		response := internal.Response{Headers: &headers}
+		err := team.connection.Send(internal.Request{
+			RequestName:        atc.GetArtifact,
+			Params:             params,
+			Header:             header,
+			ReturnResponseBody: true,
+		}, &response)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return response.Result.(io.ReadCloser), headers, nil
+	})
 }
@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildSnapshot(buildID int) (atc.BuildSnapshot, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var snapshot atc.BuildSnapshot
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildSnapshot,
+		Params:      params,
+	}, &internal.Response{
+		Result: &snapshot,
+	})
+
+	switch err.(type) {
+	case nil:
+		return snapshot, true, nil
+	case internal.ResourceNotFoundError:
+		return snapshot, false, nil
+	default:
+		return snapshot, false, err
+	}
+}
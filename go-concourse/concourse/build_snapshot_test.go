@@ -0,0 +1,61 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Snapshots", func() {
+	Describe("BuildSnapshot", func() {
+		expectedURL := "/api/v1/builds/1234/snapshot"
+
+		Context("when the build exists", func() {
+			expectedSnapshot := atc.BuildSnapshot{
+				Build: atc.Build{ID: 1234, JobName: "some-job"},
+				Inputs: []atc.SnapshotInput{
+					{Name: "source", Resource: "some-repo", Type: "git", Version: atc.Version{"ref": "abc"}},
+				},
+				Tasks: []atc.SnapshotTask{
+					{Name: "unit", ConfigPath: "source/ci/unit.yml"},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSnapshot),
+					),
+				)
+			})
+
+			It("returns the build's snapshot", func() {
+				snapshot, found, err := client.BuildSnapshot(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(snapshot).To(Equal(expectedSnapshot))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildSnapshot(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
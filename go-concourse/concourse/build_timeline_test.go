@@ -0,0 +1,61 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Timelines", func() {
+	Describe("BuildTimeline", func() {
+		expectedURL := "/api/v1/builds/1234/timeline"
+
+		Context("when the build exists", func() {
+			expectedTimeline := atc.BuildTimeline{
+				Build:          atc.Build{ID: 1234, JobName: "some-job"},
+				QueuedDuration: 10,
+				Steps: []atc.TimelineStep{
+					{PlanID: "2", Type: "task", Name: "unit", InitializedAt: 100, StartedAt: 105, FinishedAt: 160, RunningDuration: 55, OnCriticalPath: true},
+				},
+				CriticalPath:         []atc.PlanID{"2"},
+				CriticalPathDuration: 60,
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedTimeline),
+					),
+				)
+			})
+
+			It("returns the build's timeline", func() {
+				timeline, found, err := client.BuildTimeline(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(timeline).To(Equal(expectedTimeline))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildTimeline(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
@@ -53,6 +54,35 @@ func (team *team) CreateJobBuild(pipelineName string, jobName string) (atc.Build
 	return build, err
 }
 
+// CreateJobBuildWithRequest triggers a job with the overrides and uploaded
+// input artifacts of the request.
+func (team *team) CreateJobBuildWithRequest(pipelineName string, jobName string, request atc.CreateJobBuildRequest) (atc.Build, error) {
+	var build atc.Build
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(request)
+	if err != nil {
+		return build, fmt.Errorf("Unable to marshal request: %s", err)
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.CreateJobBuild,
+		Body:        buffer,
+		Params: rata.Params{
+			"job_name":      jobName,
+			"pipeline_name": pipelineName,
+			"team_name":     team.name,
+		},
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
 func (team *team) JobBuild(pipelineName, jobName, buildName string) (atc.Build, bool, error) {
 	params := rata.Params{
 		"job_name":      jobName,
@@ -138,6 +168,44 @@ func (client *client) AbortBuild(buildID string) error {
 	}, nil)
 }
 
+func (client *client) PauseBuild(buildID string) error {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	return client.connection.Send(internal.Request{
+		RequestName: atc.PauseBuild,
+		Params:      params,
+	}, nil)
+}
+
+func (client *client) ResumeBuild(buildID string) error {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	return client.connection.Send(internal.Request{
+		RequestName: atc.ResumeBuild,
+		Params:      params,
+	}, nil)
+}
+
+func (client *client) RerunBuild(buildID string) (atc.Build, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var build atc.Build
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.RerunBuild,
+		Params:      params,
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
 func (team *team) Builds(page Page) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
@@ -185,3 +253,29 @@ func (client *client) ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact,
 
 	return artifacts, err
 }
+
+// DownloadBuildArtifact streams the named artifact kept by a finished build
+// as a zstd-compressed tarball. If the download is interrupted, it's resumed
+// from where it left off.
+func (client *client) DownloadBuildArtifact(buildID string, name string) (io.ReadCloser, error) {
+	params := rata.Params{
+		"build_id":      buildID,
+		"artifact_name": name,
+	}
+
+	return openResumableDownload(func(header http.Header) (io.ReadCloser, http.Header, error) {
+		headers := http.Header{}
+		response := internal.Response{Headers: &headers}
+		err := client.connection.Send(internal.Request{
+			RequestName:        atc.DownloadBuildArtifact,
+			Params:             params,
+			Header:             header,
+			ReturnResponseBody: true,
+		}, &response)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return response.Result.(io.ReadCloser), headers, nil
+	})
+}
@@ -2,7 +2,9 @@ package concourse_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -96,6 +98,39 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("CreateJobBuildWithRequest", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:             123,
+				Name:           "mybuild",
+				Status:         "pending",
+				JobName:        "myjob",
+				APIURL:         "api/v1/builds/123",
+				InputArtifacts: atc.InputArtifacts{"some-input": 17},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds"),
+					ghttp.VerifyJSONRepresenting(atc.CreateJobBuildRequest{
+						InputArtifacts: atc.InputArtifacts{"some-input": 17},
+					}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+			)
+		})
+
+		It("triggers the job with the request", func() {
+			build, err := team.CreateJobBuildWithRequest("mypipeline", "myjob", atc.CreateJobBuildRequest{
+				InputArtifacts: atc.InputArtifacts{"some-input": 17},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
 	Describe("JobBuild", func() {
 		var (
 			expectedBuild atc.Build
@@ -423,6 +458,144 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("PauseBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/pause"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends a pause request to ATC", func() {
+			Expect(func() {
+				err := client.PauseBuild("123")
+				Expect(err).NotTo(HaveOccurred())
+			}).To(Change(func() int {
+				return len(atcServer.ReceivedRequests())
+			}).By(1))
+		})
+	})
+
+	Describe("ResumeBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/resume"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends a resume request to ATC", func() {
+			Expect(func() {
+				err := client.ResumeBuild("123")
+				Expect(err).NotTo(HaveOccurred())
+			}).To(Change(func() int {
+				return len(atcServer.ReceivedRequests())
+			}).By(1))
+		})
+	})
+
+	Describe("DownloadBuildArtifact", func() {
+		Context("when the artifact exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/artifacts/some-output"),
+						ghttp.RespondWith(http.StatusOK, "some-tarball"),
+					),
+				)
+			})
+
+			It("returns the contents", func() {
+				contents, err := client.DownloadBuildArtifact("123", "some-output")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-tarball")))
+			})
+		})
+
+		Context("when the download is interrupted", func() {
+			BeforeEach(func() {
+				concourse.DownloadResumeInterval = 0
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/artifacts/some-output"),
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.Header.Get("Range")).To(BeEmpty())
+
+							w.Header().Set("Accept-Ranges", "bytes")
+							w.Header().Set("Content-Length", "12")
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte("some-"))
+						},
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/artifacts/some-output"),
+						ghttp.VerifyHeaderKV("Range", "bytes=5-"),
+						ghttp.RespondWith(http.StatusPartialContent, "tarball"),
+					),
+				)
+			})
+
+			AfterEach(func() {
+				concourse.DownloadResumeInterval = time.Second
+			})
+
+			It("resumes it from where it left off", func() {
+				contents, err := client.DownloadBuildArtifact("123", "some-output")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-tarball")))
+			})
+		})
+
+		Context("when the artifact doesn't exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/artifacts/some-output"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.DownloadBuildArtifact("123", "some-output")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("RerunBuild", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:      124,
+				Name:    "2",
+				Status:  "started",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/124",
+				RerunOf: 123,
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/builds/123/rerun"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("returns the build re-running the given build", func() {
+			build, err := client.RerunBuild("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
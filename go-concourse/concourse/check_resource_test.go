@@ -108,3 +108,61 @@ var _ = Describe("CheckResource", func() {
 		})
 	})
 })
+
+var _ = Describe("ResourceChecks", func() {
+	expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/checks"
+
+	Context("when ATC request succeeds", func() {
+		var expectedChecks []atc.Check
+
+		BeforeEach(func() {
+			expectedChecks = []atc.Check{
+				{
+					ID:         2,
+					Status:     "errored",
+					StartTime:  100000000000,
+					EndTime:    100000000010,
+					CheckError: "some-error",
+					WorkerName: "some-worker",
+					Output:     "some-output",
+				},
+				{
+					ID:         1,
+					Status:     "succeeded",
+					WorkerName: "other-worker",
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, "limit=5"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedChecks),
+				),
+			)
+		})
+
+		It("returns the checks of the resource", func() {
+			checks, found, err := team.ResourceChecks("mypipeline", "myresource", 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(checks).To(Equal(expectedChecks))
+		})
+	})
+
+	Context("when the resource does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("returns not found", func() {
+			_, found, err := team.ResourceChecks("mypipeline", "myresource", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -19,12 +19,20 @@ type Client interface {
 	BuildEvents(buildID string) (Events, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
+	DownloadBuildArtifact(buildID string, name string) (io.ReadCloser, error)
 	AbortBuild(buildID string) error
+	PauseBuild(buildID string) error
+	ResumeBuild(buildID string) error
+	RerunBuild(buildID string) (atc.Build, error)
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildSnapshot(buildID int) (atc.BuildSnapshot, bool, error)
+	BuildTimeline(buildID int) (atc.BuildTimeline, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
 	LandWorker(workerName string) error
+	ListWorkerDiscrepancies(workerName string) ([]atc.WorkerDiscrepancy, error)
+	ResolveWorkerDiscrepancies(workerName string) (int, error)
 	GetInfo() (atc.Info, error)
 	GetCLIReader(arch, platform string) (io.ReadCloser, http.Header, error)
 	ListPipelines() ([]atc.Pipeline, error)
@@ -81,6 +81,36 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildSnapshotStub        func(int) (atc.BuildSnapshot, bool, error)
+	buildSnapshotMutex       sync.RWMutex
+	buildSnapshotArgsForCall []struct {
+		arg1 int
+	}
+	buildSnapshotReturns struct {
+		result1 atc.BuildSnapshot
+		result2 bool
+		result3 error
+	}
+	buildSnapshotReturnsOnCall map[int]struct {
+		result1 atc.BuildSnapshot
+		result2 bool
+		result3 error
+	}
+	BuildTimelineStub        func(int) (atc.BuildTimeline, bool, error)
+	buildTimelineMutex       sync.RWMutex
+	buildTimelineArgsForCall []struct {
+		arg1 int
+	}
+	buildTimelineReturns struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}
+	buildTimelineReturnsOnCall map[int]struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -111,6 +141,20 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	DownloadBuildArtifactStub        func(string, string) (io.ReadCloser, error)
+	downloadBuildArtifactMutex       sync.RWMutex
+	downloadBuildArtifactArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	downloadBuildArtifactReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	downloadBuildArtifactReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	GetCLIReaderStub        func(string, string) (io.ReadCloser, http.Header, error)
 	getCLIReaderMutex       sync.RWMutex
 	getCLIReaderArgsForCall []struct {
@@ -210,6 +254,19 @@ type FakeClient struct {
 		result1 []atc.Team
 		result2 error
 	}
+	ListWorkerDiscrepanciesStub        func(string) ([]atc.WorkerDiscrepancy, error)
+	listWorkerDiscrepanciesMutex       sync.RWMutex
+	listWorkerDiscrepanciesArgsForCall []struct {
+		arg1 string
+	}
+	listWorkerDiscrepanciesReturns struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}
+	listWorkerDiscrepanciesReturnsOnCall map[int]struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}
 	ListWorkersStub        func() ([]atc.Worker, error)
 	listWorkersMutex       sync.RWMutex
 	listWorkersArgsForCall []struct {
@@ -222,6 +279,17 @@ type FakeClient struct {
 		result1 []atc.Worker
 		result2 error
 	}
+	PauseBuildStub        func(string) error
+	pauseBuildMutex       sync.RWMutex
+	pauseBuildArgsForCall []struct {
+		arg1 string
+	}
+	pauseBuildReturns struct {
+		result1 error
+	}
+	pauseBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PruneWorkerStub        func(string) error
 	pruneWorkerMutex       sync.RWMutex
 	pruneWorkerArgsForCall []struct {
@@ -233,6 +301,43 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RerunBuildStub        func(string) (atc.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		arg1 string
+	}
+	rerunBuildReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	rerunBuildReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
+	ResolveWorkerDiscrepanciesStub        func(string) (int, error)
+	resolveWorkerDiscrepanciesMutex       sync.RWMutex
+	resolveWorkerDiscrepanciesArgsForCall []struct {
+		arg1 string
+	}
+	resolveWorkerDiscrepanciesReturns struct {
+		result1 int
+		result2 error
+	}
+	resolveWorkerDiscrepanciesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	ResumeBuildStub        func(string) error
+	resumeBuildMutex       sync.RWMutex
+	resumeBuildArgsForCall []struct {
+		arg1 string
+	}
+	resumeBuildReturns struct {
+		result1 error
+	}
+	resumeBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -605,6 +710,138 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildSnapshot(arg1 int) (atc.BuildSnapshot, bool, error) {
+	fake.buildSnapshotMutex.Lock()
+	ret, specificReturn := fake.buildSnapshotReturnsOnCall[len(fake.buildSnapshotArgsForCall)]
+	fake.buildSnapshotArgsForCall = append(fake.buildSnapshotArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("BuildSnapshot", []interface{}{arg1})
+	fake.buildSnapshotMutex.Unlock()
+	if fake.BuildSnapshotStub != nil {
+		return fake.BuildSnapshotStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildSnapshotReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildSnapshotCallCount() int {
+	fake.buildSnapshotMutex.RLock()
+	defer fake.buildSnapshotMutex.RUnlock()
+	return len(fake.buildSnapshotArgsForCall)
+}
+
+func (fake *FakeClient) BuildSnapshotCalls(stub func(int) (atc.BuildSnapshot, bool, error)) {
+	fake.buildSnapshotMutex.Lock()
+	defer fake.buildSnapshotMutex.Unlock()
+	fake.BuildSnapshotStub = stub
+}
+
+func (fake *FakeClient) BuildSnapshotArgsForCall(i int) int {
+	fake.buildSnapshotMutex.RLock()
+	defer fake.buildSnapshotMutex.RUnlock()
+	argsForCall := fake.buildSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildSnapshotReturns(result1 atc.BuildSnapshot, result2 bool, result3 error) {
+	fake.buildSnapshotMutex.Lock()
+	defer fake.buildSnapshotMutex.Unlock()
+	fake.BuildSnapshotStub = nil
+	fake.buildSnapshotReturns = struct {
+		result1 atc.BuildSnapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildSnapshotReturnsOnCall(i int, result1 atc.BuildSnapshot, result2 bool, result3 error) {
+	fake.buildSnapshotMutex.Lock()
+	defer fake.buildSnapshotMutex.Unlock()
+	fake.BuildSnapshotStub = nil
+	if fake.buildSnapshotReturnsOnCall == nil {
+		fake.buildSnapshotReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildSnapshot
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildSnapshotReturnsOnCall[i] = struct {
+		result1 atc.BuildSnapshot
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildTimeline(arg1 int) (atc.BuildTimeline, bool, error) {
+	fake.buildTimelineMutex.Lock()
+	ret, specificReturn := fake.buildTimelineReturnsOnCall[len(fake.buildTimelineArgsForCall)]
+	fake.buildTimelineArgsForCall = append(fake.buildTimelineArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("BuildTimeline", []interface{}{arg1})
+	fake.buildTimelineMutex.Unlock()
+	if fake.BuildTimelineStub != nil {
+		return fake.BuildTimelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildTimelineReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildTimelineCallCount() int {
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
+	return len(fake.buildTimelineArgsForCall)
+}
+
+func (fake *FakeClient) BuildTimelineCalls(stub func(int) (atc.BuildTimeline, bool, error)) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = stub
+}
+
+func (fake *FakeClient) BuildTimelineArgsForCall(i int) int {
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
+	argsForCall := fake.buildTimelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildTimelineReturns(result1 atc.BuildTimeline, result2 bool, result3 error) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = nil
+	fake.buildTimelineReturns = struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildTimelineReturnsOnCall(i int, result1 atc.BuildTimeline, result2 bool, result3 error) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = nil
+	if fake.buildTimelineReturnsOnCall == nil {
+		fake.buildTimelineReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildTimeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildTimelineReturnsOnCall[i] = struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -737,6 +974,70 @@ func (fake *FakeClient) CheckReturnsOnCall(i int, result1 atc.Check, result2 boo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) DownloadBuildArtifact(arg1 string, arg2 string) (io.ReadCloser, error) {
+	fake.downloadBuildArtifactMutex.Lock()
+	ret, specificReturn := fake.downloadBuildArtifactReturnsOnCall[len(fake.downloadBuildArtifactArgsForCall)]
+	fake.downloadBuildArtifactArgsForCall = append(fake.downloadBuildArtifactArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DownloadBuildArtifact", []interface{}{arg1, arg2})
+	fake.downloadBuildArtifactMutex.Unlock()
+	if fake.DownloadBuildArtifactStub != nil {
+		return fake.DownloadBuildArtifactStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.downloadBuildArtifactReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) DownloadBuildArtifactCallCount() int {
+	fake.downloadBuildArtifactMutex.RLock()
+	defer fake.downloadBuildArtifactMutex.RUnlock()
+	return len(fake.downloadBuildArtifactArgsForCall)
+}
+
+func (fake *FakeClient) DownloadBuildArtifactCalls(stub func(string, string) (io.ReadCloser, error)) {
+	fake.downloadBuildArtifactMutex.Lock()
+	defer fake.downloadBuildArtifactMutex.Unlock()
+	fake.DownloadBuildArtifactStub = stub
+}
+
+func (fake *FakeClient) DownloadBuildArtifactArgsForCall(i int) (string, string) {
+	fake.downloadBuildArtifactMutex.RLock()
+	defer fake.downloadBuildArtifactMutex.RUnlock()
+	argsForCall := fake.downloadBuildArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) DownloadBuildArtifactReturns(result1 io.ReadCloser, result2 error) {
+	fake.downloadBuildArtifactMutex.Lock()
+	defer fake.downloadBuildArtifactMutex.Unlock()
+	fake.DownloadBuildArtifactStub = nil
+	fake.downloadBuildArtifactReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DownloadBuildArtifactReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.downloadBuildArtifactMutex.Lock()
+	defer fake.downloadBuildArtifactMutex.Unlock()
+	fake.DownloadBuildArtifactStub = nil
+	if fake.downloadBuildArtifactReturnsOnCall == nil {
+		fake.downloadBuildArtifactReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.downloadBuildArtifactReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) GetCLIReader(arg1 string, arg2 string) (io.ReadCloser, http.Header, error) {
 	fake.getCLIReaderMutex.Lock()
 	ret, specificReturn := fake.getCLIReaderReturnsOnCall[len(fake.getCLIReaderArgsForCall)]
@@ -1207,6 +1508,69 @@ func (fake *FakeClient) ListTeamsReturnsOnCall(i int, result1 []atc.Team, result
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListWorkerDiscrepancies(arg1 string) ([]atc.WorkerDiscrepancy, error) {
+	fake.listWorkerDiscrepanciesMutex.Lock()
+	ret, specificReturn := fake.listWorkerDiscrepanciesReturnsOnCall[len(fake.listWorkerDiscrepanciesArgsForCall)]
+	fake.listWorkerDiscrepanciesArgsForCall = append(fake.listWorkerDiscrepanciesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListWorkerDiscrepancies", []interface{}{arg1})
+	fake.listWorkerDiscrepanciesMutex.Unlock()
+	if fake.ListWorkerDiscrepanciesStub != nil {
+		return fake.ListWorkerDiscrepanciesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listWorkerDiscrepanciesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListWorkerDiscrepanciesCallCount() int {
+	fake.listWorkerDiscrepanciesMutex.RLock()
+	defer fake.listWorkerDiscrepanciesMutex.RUnlock()
+	return len(fake.listWorkerDiscrepanciesArgsForCall)
+}
+
+func (fake *FakeClient) ListWorkerDiscrepanciesCalls(stub func(string) ([]atc.WorkerDiscrepancy, error)) {
+	fake.listWorkerDiscrepanciesMutex.Lock()
+	defer fake.listWorkerDiscrepanciesMutex.Unlock()
+	fake.ListWorkerDiscrepanciesStub = stub
+}
+
+func (fake *FakeClient) ListWorkerDiscrepanciesArgsForCall(i int) string {
+	fake.listWorkerDiscrepanciesMutex.RLock()
+	defer fake.listWorkerDiscrepanciesMutex.RUnlock()
+	argsForCall := fake.listWorkerDiscrepanciesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListWorkerDiscrepanciesReturns(result1 []atc.WorkerDiscrepancy, result2 error) {
+	fake.listWorkerDiscrepanciesMutex.Lock()
+	defer fake.listWorkerDiscrepanciesMutex.Unlock()
+	fake.ListWorkerDiscrepanciesStub = nil
+	fake.listWorkerDiscrepanciesReturns = struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListWorkerDiscrepanciesReturnsOnCall(i int, result1 []atc.WorkerDiscrepancy, result2 error) {
+	fake.listWorkerDiscrepanciesMutex.Lock()
+	defer fake.listWorkerDiscrepanciesMutex.Unlock()
+	fake.ListWorkerDiscrepanciesStub = nil
+	if fake.listWorkerDiscrepanciesReturnsOnCall == nil {
+		fake.listWorkerDiscrepanciesReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerDiscrepancy
+			result2 error
+		})
+	}
+	fake.listWorkerDiscrepanciesReturnsOnCall[i] = struct {
+		result1 []atc.WorkerDiscrepancy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListWorkers() ([]atc.Worker, error) {
 	fake.listWorkersMutex.Lock()
 	ret, specificReturn := fake.listWorkersReturnsOnCall[len(fake.listWorkersArgsForCall)]
@@ -1262,6 +1626,66 @@ func (fake *FakeClient) ListWorkersReturnsOnCall(i int, result1 []atc.Worker, re
 	}{result1, result2}
 }
 
+func (fake *FakeClient) PauseBuild(arg1 string) error {
+	fake.pauseBuildMutex.Lock()
+	ret, specificReturn := fake.pauseBuildReturnsOnCall[len(fake.pauseBuildArgsForCall)]
+	fake.pauseBuildArgsForCall = append(fake.pauseBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("PauseBuild", []interface{}{arg1})
+	fake.pauseBuildMutex.Unlock()
+	if fake.PauseBuildStub != nil {
+		return fake.PauseBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pauseBuildReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) PauseBuildCallCount() int {
+	fake.pauseBuildMutex.RLock()
+	defer fake.pauseBuildMutex.RUnlock()
+	return len(fake.pauseBuildArgsForCall)
+}
+
+func (fake *FakeClient) PauseBuildCalls(stub func(string) error) {
+	fake.pauseBuildMutex.Lock()
+	defer fake.pauseBuildMutex.Unlock()
+	fake.PauseBuildStub = stub
+}
+
+func (fake *FakeClient) PauseBuildArgsForCall(i int) string {
+	fake.pauseBuildMutex.RLock()
+	defer fake.pauseBuildMutex.RUnlock()
+	argsForCall := fake.pauseBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) PauseBuildReturns(result1 error) {
+	fake.pauseBuildMutex.Lock()
+	defer fake.pauseBuildMutex.Unlock()
+	fake.PauseBuildStub = nil
+	fake.pauseBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) PauseBuildReturnsOnCall(i int, result1 error) {
+	fake.pauseBuildMutex.Lock()
+	defer fake.pauseBuildMutex.Unlock()
+	fake.PauseBuildStub = nil
+	if fake.pauseBuildReturnsOnCall == nil {
+		fake.pauseBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) PruneWorker(arg1 string) error {
 	fake.pruneWorkerMutex.Lock()
 	ret, specificReturn := fake.pruneWorkerReturnsOnCall[len(fake.pruneWorkerArgsForCall)]
@@ -1322,6 +1746,192 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RerunBuild(arg1 string) (atc.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	ret, specificReturn := fake.rerunBuildReturnsOnCall[len(fake.rerunBuildArgsForCall)]
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RerunBuild", []interface{}{arg1})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.rerunBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeClient) RerunBuildCalls(stub func(string) (atc.Build, error)) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = stub
+}
+
+func (fake *FakeClient) RerunBuildArgsForCall(i int) string {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	argsForCall := fake.rerunBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RerunBuildReturns(result1 atc.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RerunBuildReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	if fake.rerunBuildReturnsOnCall == nil {
+		fake.rerunBuildReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ResolveWorkerDiscrepancies(arg1 string) (int, error) {
+	fake.resolveWorkerDiscrepanciesMutex.Lock()
+	ret, specificReturn := fake.resolveWorkerDiscrepanciesReturnsOnCall[len(fake.resolveWorkerDiscrepanciesArgsForCall)]
+	fake.resolveWorkerDiscrepanciesArgsForCall = append(fake.resolveWorkerDiscrepanciesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ResolveWorkerDiscrepancies", []interface{}{arg1})
+	fake.resolveWorkerDiscrepanciesMutex.Unlock()
+	if fake.ResolveWorkerDiscrepanciesStub != nil {
+		return fake.ResolveWorkerDiscrepanciesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resolveWorkerDiscrepanciesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ResolveWorkerDiscrepanciesCallCount() int {
+	fake.resolveWorkerDiscrepanciesMutex.RLock()
+	defer fake.resolveWorkerDiscrepanciesMutex.RUnlock()
+	return len(fake.resolveWorkerDiscrepanciesArgsForCall)
+}
+
+func (fake *FakeClient) ResolveWorkerDiscrepanciesCalls(stub func(string) (int, error)) {
+	fake.resolveWorkerDiscrepanciesMutex.Lock()
+	defer fake.resolveWorkerDiscrepanciesMutex.Unlock()
+	fake.ResolveWorkerDiscrepanciesStub = stub
+}
+
+func (fake *FakeClient) ResolveWorkerDiscrepanciesArgsForCall(i int) string {
+	fake.resolveWorkerDiscrepanciesMutex.RLock()
+	defer fake.resolveWorkerDiscrepanciesMutex.RUnlock()
+	argsForCall := fake.resolveWorkerDiscrepanciesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ResolveWorkerDiscrepanciesReturns(result1 int, result2 error) {
+	fake.resolveWorkerDiscrepanciesMutex.Lock()
+	defer fake.resolveWorkerDiscrepanciesMutex.Unlock()
+	fake.ResolveWorkerDiscrepanciesStub = nil
+	fake.resolveWorkerDiscrepanciesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ResolveWorkerDiscrepanciesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.resolveWorkerDiscrepanciesMutex.Lock()
+	defer fake.resolveWorkerDiscrepanciesMutex.Unlock()
+	fake.ResolveWorkerDiscrepanciesStub = nil
+	if fake.resolveWorkerDiscrepanciesReturnsOnCall == nil {
+		fake.resolveWorkerDiscrepanciesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.resolveWorkerDiscrepanciesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ResumeBuild(arg1 string) error {
+	fake.resumeBuildMutex.Lock()
+	ret, specificReturn := fake.resumeBuildReturnsOnCall[len(fake.resumeBuildArgsForCall)]
+	fake.resumeBuildArgsForCall = append(fake.resumeBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ResumeBuild", []interface{}{arg1})
+	fake.resumeBuildMutex.Unlock()
+	if fake.ResumeBuildStub != nil {
+		return fake.ResumeBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resumeBuildReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ResumeBuildCallCount() int {
+	fake.resumeBuildMutex.RLock()
+	defer fake.resumeBuildMutex.RUnlock()
+	return len(fake.resumeBuildArgsForCall)
+}
+
+func (fake *FakeClient) ResumeBuildCalls(stub func(string) error) {
+	fake.resumeBuildMutex.Lock()
+	defer fake.resumeBuildMutex.Unlock()
+	fake.ResumeBuildStub = stub
+}
+
+func (fake *FakeClient) ResumeBuildArgsForCall(i int) string {
+	fake.resumeBuildMutex.RLock()
+	defer fake.resumeBuildMutex.RUnlock()
+	argsForCall := fake.resumeBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ResumeBuildReturns(result1 error) {
+	fake.resumeBuildMutex.Lock()
+	defer fake.resumeBuildMutex.Unlock()
+	fake.ResumeBuildStub = nil
+	fake.resumeBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ResumeBuildReturnsOnCall(i int, result1 error) {
+	fake.resumeBuildMutex.Lock()
+	defer fake.resumeBuildMutex.Unlock()
+	fake.ResumeBuildStub = nil
+	if fake.resumeBuildReturnsOnCall == nil {
+		fake.resumeBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1566,10 +2176,16 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildSnapshotMutex.RLock()
+	defer fake.buildSnapshotMutex.RUnlock()
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.checkMutex.RLock()
 	defer fake.checkMutex.RUnlock()
+	fake.downloadBuildArtifactMutex.RLock()
+	defer fake.downloadBuildArtifactMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
 	defer fake.getCLIReaderMutex.RUnlock()
 	fake.getInfoMutex.RLock()
@@ -1586,10 +2202,20 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listPipelinesMutex.RUnlock()
 	fake.listTeamsMutex.RLock()
 	defer fake.listTeamsMutex.RUnlock()
+	fake.listWorkerDiscrepanciesMutex.RLock()
+	defer fake.listWorkerDiscrepanciesMutex.RUnlock()
 	fake.listWorkersMutex.RLock()
 	defer fake.listWorkersMutex.RUnlock()
+	fake.pauseBuildMutex.RLock()
+	defer fake.pauseBuildMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	fake.resolveWorkerDiscrepanciesMutex.RLock()
+	defer fake.resolveWorkerDiscrepanciesMutex.RUnlock()
+	fake.resumeBuildMutex.RLock()
+	defer fake.resumeBuildMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
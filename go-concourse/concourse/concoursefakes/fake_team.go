@@ -166,6 +166,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateJobBuildWithRequestStub        func(string, string, atc.CreateJobBuildRequest) (atc.Build, error)
+	createJobBuildWithRequestMutex       sync.RWMutex
+	createJobBuildWithRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.CreateJobBuildRequest
+	}
+	createJobBuildWithRequestReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	createJobBuildWithRequestReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	CreateOrUpdateStub        func(atc.Team) (atc.Team, bool, bool, error)
 	createOrUpdateMutex       sync.RWMutex
 	createOrUpdateArgsForCall []struct {
@@ -595,6 +610,40 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	ResourceChecksStub        func(string, string, int) ([]atc.Check, bool, error)
+	resourceChecksMutex       sync.RWMutex
+	resourceChecksArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	resourceChecksReturns struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}
+	resourceChecksReturnsOnCall map[int]struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}
+	ResourceVersionSpanStub        func(string, string, concourse.VersionSpan) ([]atc.SpannedResourceVersion, bool, error)
+	resourceVersionSpanMutex       sync.RWMutex
+	resourceVersionSpanArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 concourse.VersionSpan
+	}
+	resourceVersionSpanReturns struct {
+		result1 []atc.SpannedResourceVersion
+		result2 bool
+		result3 error
+	}
+	resourceVersionSpanReturnsOnCall map[int]struct {
+		result1 []atc.SpannedResourceVersion
+		result2 bool
+		result3 error
+	}
 	ResourceVersionsStub        func(string, string, concourse.Page, atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error)
 	resourceVersionsMutex       sync.RWMutex
 	resourceVersionsArgsForCall []struct {
@@ -615,6 +664,19 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	RestoreTeamStub        func(string) (bool, error)
+	restoreTeamMutex       sync.RWMutex
+	restoreTeamArgsForCall []struct {
+		arg1 string
+	}
+	restoreTeamReturns struct {
+		result1 bool
+		result2 error
+	}
+	restoreTeamReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SetPinCommentStub        func(string, string, string) (bool, error)
 	setPinCommentMutex       sync.RWMutex
 	setPinCommentArgsForCall []struct {
@@ -630,6 +692,21 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SharePipelineStub        func(string) (string, bool, error)
+	sharePipelineMutex       sync.RWMutex
+	sharePipelineArgsForCall []struct {
+		arg1 string
+	}
+	sharePipelineReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	sharePipelineReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
 	TeamStub        func(string) (atc.Team, bool, error)
 	teamMutex       sync.RWMutex
 	teamArgsForCall []struct {
@@ -686,6 +763,19 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	UnsharePipelineStub        func(string) (bool, error)
+	unsharePipelineMutex       sync.RWMutex
+	unsharePipelineArgsForCall []struct {
+		arg1 string
+	}
+	unsharePipelineReturns struct {
+		result1 bool
+		result2 error
+	}
+	unsharePipelineReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	VersionedResourceTypesStub        func(string) (atc.VersionedResourceTypes, bool, error)
 	versionedResourceTypesMutex       sync.RWMutex
 	versionedResourceTypesArgsForCall []struct {
@@ -1367,6 +1457,71 @@ func (fake *FakeTeam) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateJobBuildWithRequest(arg1 string, arg2 string, arg3 atc.CreateJobBuildRequest) (atc.Build, error) {
+	fake.createJobBuildWithRequestMutex.Lock()
+	ret, specificReturn := fake.createJobBuildWithRequestReturnsOnCall[len(fake.createJobBuildWithRequestArgsForCall)]
+	fake.createJobBuildWithRequestArgsForCall = append(fake.createJobBuildWithRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.CreateJobBuildRequest
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateJobBuildWithRequest", []interface{}{arg1, arg2, arg3})
+	fake.createJobBuildWithRequestMutex.Unlock()
+	if fake.CreateJobBuildWithRequestStub != nil {
+		return fake.CreateJobBuildWithRequestStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createJobBuildWithRequestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateJobBuildWithRequestCallCount() int {
+	fake.createJobBuildWithRequestMutex.RLock()
+	defer fake.createJobBuildWithRequestMutex.RUnlock()
+	return len(fake.createJobBuildWithRequestArgsForCall)
+}
+
+func (fake *FakeTeam) CreateJobBuildWithRequestCalls(stub func(string, string, atc.CreateJobBuildRequest) (atc.Build, error)) {
+	fake.createJobBuildWithRequestMutex.Lock()
+	defer fake.createJobBuildWithRequestMutex.Unlock()
+	fake.CreateJobBuildWithRequestStub = stub
+}
+
+func (fake *FakeTeam) CreateJobBuildWithRequestArgsForCall(i int) (string, string, atc.CreateJobBuildRequest) {
+	fake.createJobBuildWithRequestMutex.RLock()
+	defer fake.createJobBuildWithRequestMutex.RUnlock()
+	argsForCall := fake.createJobBuildWithRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CreateJobBuildWithRequestReturns(result1 atc.Build, result2 error) {
+	fake.createJobBuildWithRequestMutex.Lock()
+	defer fake.createJobBuildWithRequestMutex.Unlock()
+	fake.CreateJobBuildWithRequestStub = nil
+	fake.createJobBuildWithRequestReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateJobBuildWithRequestReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.createJobBuildWithRequestMutex.Lock()
+	defer fake.createJobBuildWithRequestMutex.Unlock()
+	fake.CreateJobBuildWithRequestStub = nil
+	if fake.createJobBuildWithRequestReturnsOnCall == nil {
+		fake.createJobBuildWithRequestReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.createJobBuildWithRequestReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateOrUpdate(arg1 atc.Team) (atc.Team, bool, bool, error) {
 	fake.createOrUpdateMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateReturnsOnCall[len(fake.createOrUpdateArgsForCall)]
@@ -3296,6 +3451,142 @@ func (fake *FakeTeam) ResourceReturnsOnCall(i int, result1 atc.Resource, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ResourceChecks(arg1 string, arg2 string, arg3 int) ([]atc.Check, bool, error) {
+	fake.resourceChecksMutex.Lock()
+	ret, specificReturn := fake.resourceChecksReturnsOnCall[len(fake.resourceChecksArgsForCall)]
+	fake.resourceChecksArgsForCall = append(fake.resourceChecksArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ResourceChecks", []interface{}{arg1, arg2, arg3})
+	fake.resourceChecksMutex.Unlock()
+	if fake.ResourceChecksStub != nil {
+		return fake.ResourceChecksStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.resourceChecksReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ResourceChecksCallCount() int {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	return len(fake.resourceChecksArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceChecksCalls(stub func(string, string, int) ([]atc.Check, bool, error)) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = stub
+}
+
+func (fake *FakeTeam) ResourceChecksArgsForCall(i int) (string, string, int) {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	argsForCall := fake.resourceChecksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) ResourceChecksReturns(result1 []atc.Check, result2 bool, result3 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	fake.resourceChecksReturns = struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ResourceChecksReturnsOnCall(i int, result1 []atc.Check, result2 bool, result3 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	if fake.resourceChecksReturnsOnCall == nil {
+		fake.resourceChecksReturnsOnCall = make(map[int]struct {
+			result1 []atc.Check
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resourceChecksReturnsOnCall[i] = struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ResourceVersionSpan(arg1 string, arg2 string, arg3 concourse.VersionSpan) ([]atc.SpannedResourceVersion, bool, error) {
+	fake.resourceVersionSpanMutex.Lock()
+	ret, specificReturn := fake.resourceVersionSpanReturnsOnCall[len(fake.resourceVersionSpanArgsForCall)]
+	fake.resourceVersionSpanArgsForCall = append(fake.resourceVersionSpanArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 concourse.VersionSpan
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ResourceVersionSpan", []interface{}{arg1, arg2, arg3})
+	fake.resourceVersionSpanMutex.Unlock()
+	if fake.ResourceVersionSpanStub != nil {
+		return fake.ResourceVersionSpanStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.resourceVersionSpanReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ResourceVersionSpanCallCount() int {
+	fake.resourceVersionSpanMutex.RLock()
+	defer fake.resourceVersionSpanMutex.RUnlock()
+	return len(fake.resourceVersionSpanArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceVersionSpanCalls(stub func(string, string, concourse.VersionSpan) ([]atc.SpannedResourceVersion, bool, error)) {
+	fake.resourceVersionSpanMutex.Lock()
+	defer fake.resourceVersionSpanMutex.Unlock()
+	fake.ResourceVersionSpanStub = stub
+}
+
+func (fake *FakeTeam) ResourceVersionSpanArgsForCall(i int) (string, string, concourse.VersionSpan) {
+	fake.resourceVersionSpanMutex.RLock()
+	defer fake.resourceVersionSpanMutex.RUnlock()
+	argsForCall := fake.resourceVersionSpanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) ResourceVersionSpanReturns(result1 []atc.SpannedResourceVersion, result2 bool, result3 error) {
+	fake.resourceVersionSpanMutex.Lock()
+	defer fake.resourceVersionSpanMutex.Unlock()
+	fake.ResourceVersionSpanStub = nil
+	fake.resourceVersionSpanReturns = struct {
+		result1 []atc.SpannedResourceVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ResourceVersionSpanReturnsOnCall(i int, result1 []atc.SpannedResourceVersion, result2 bool, result3 error) {
+	fake.resourceVersionSpanMutex.Lock()
+	defer fake.resourceVersionSpanMutex.Unlock()
+	fake.ResourceVersionSpanStub = nil
+	if fake.resourceVersionSpanReturnsOnCall == nil {
+		fake.resourceVersionSpanReturnsOnCall = make(map[int]struct {
+			result1 []atc.SpannedResourceVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resourceVersionSpanReturnsOnCall[i] = struct {
+		result1 []atc.SpannedResourceVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ResourceVersions(arg1 string, arg2 string, arg3 concourse.Page, arg4 atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
 	fake.resourceVersionsMutex.Lock()
 	ret, specificReturn := fake.resourceVersionsReturnsOnCall[len(fake.resourceVersionsArgsForCall)]
@@ -3368,6 +3659,69 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) RestoreTeam(arg1 string) (bool, error) {
+	fake.restoreTeamMutex.Lock()
+	ret, specificReturn := fake.restoreTeamReturnsOnCall[len(fake.restoreTeamArgsForCall)]
+	fake.restoreTeamArgsForCall = append(fake.restoreTeamArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RestoreTeam", []interface{}{arg1})
+	fake.restoreTeamMutex.Unlock()
+	if fake.RestoreTeamStub != nil {
+		return fake.RestoreTeamStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.restoreTeamReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RestoreTeamCallCount() int {
+	fake.restoreTeamMutex.RLock()
+	defer fake.restoreTeamMutex.RUnlock()
+	return len(fake.restoreTeamArgsForCall)
+}
+
+func (fake *FakeTeam) RestoreTeamCalls(stub func(string) (bool, error)) {
+	fake.restoreTeamMutex.Lock()
+	defer fake.restoreTeamMutex.Unlock()
+	fake.RestoreTeamStub = stub
+}
+
+func (fake *FakeTeam) RestoreTeamArgsForCall(i int) string {
+	fake.restoreTeamMutex.RLock()
+	defer fake.restoreTeamMutex.RUnlock()
+	argsForCall := fake.restoreTeamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) RestoreTeamReturns(result1 bool, result2 error) {
+	fake.restoreTeamMutex.Lock()
+	defer fake.restoreTeamMutex.Unlock()
+	fake.RestoreTeamStub = nil
+	fake.restoreTeamReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RestoreTeamReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.restoreTeamMutex.Lock()
+	defer fake.restoreTeamMutex.Unlock()
+	fake.RestoreTeamStub = nil
+	if fake.restoreTeamReturnsOnCall == nil {
+		fake.restoreTeamReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.restoreTeamReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) SetPinComment(arg1 string, arg2 string, arg3 string) (bool, error) {
 	fake.setPinCommentMutex.Lock()
 	ret, specificReturn := fake.setPinCommentReturnsOnCall[len(fake.setPinCommentArgsForCall)]
@@ -3433,6 +3787,72 @@ func (fake *FakeTeam) SetPinCommentReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SharePipeline(arg1 string) (string, bool, error) {
+	fake.sharePipelineMutex.Lock()
+	ret, specificReturn := fake.sharePipelineReturnsOnCall[len(fake.sharePipelineArgsForCall)]
+	fake.sharePipelineArgsForCall = append(fake.sharePipelineArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SharePipeline", []interface{}{arg1})
+	fake.sharePipelineMutex.Unlock()
+	if fake.SharePipelineStub != nil {
+		return fake.SharePipelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.sharePipelineReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SharePipelineCallCount() int {
+	fake.sharePipelineMutex.RLock()
+	defer fake.sharePipelineMutex.RUnlock()
+	return len(fake.sharePipelineArgsForCall)
+}
+
+func (fake *FakeTeam) SharePipelineCalls(stub func(string) (string, bool, error)) {
+	fake.sharePipelineMutex.Lock()
+	defer fake.sharePipelineMutex.Unlock()
+	fake.SharePipelineStub = stub
+}
+
+func (fake *FakeTeam) SharePipelineArgsForCall(i int) string {
+	fake.sharePipelineMutex.RLock()
+	defer fake.sharePipelineMutex.RUnlock()
+	argsForCall := fake.sharePipelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SharePipelineReturns(result1 string, result2 bool, result3 error) {
+	fake.sharePipelineMutex.Lock()
+	defer fake.sharePipelineMutex.Unlock()
+	fake.SharePipelineStub = nil
+	fake.sharePipelineReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SharePipelineReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.sharePipelineMutex.Lock()
+	defer fake.sharePipelineMutex.Unlock()
+	fake.SharePipelineStub = nil
+	if fake.sharePipelineReturnsOnCall == nil {
+		fake.sharePipelineReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.sharePipelineReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) Team(arg1 string) (atc.Team, bool, error) {
 	fake.teamMutex.Lock()
 	ret, specificReturn := fake.teamReturnsOnCall[len(fake.teamArgsForCall)]
@@ -3690,6 +4110,69 @@ func (fake *FakeTeam) UnpinResourceReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UnsharePipeline(arg1 string) (bool, error) {
+	fake.unsharePipelineMutex.Lock()
+	ret, specificReturn := fake.unsharePipelineReturnsOnCall[len(fake.unsharePipelineArgsForCall)]
+	fake.unsharePipelineArgsForCall = append(fake.unsharePipelineArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("UnsharePipeline", []interface{}{arg1})
+	fake.unsharePipelineMutex.Unlock()
+	if fake.UnsharePipelineStub != nil {
+		return fake.UnsharePipelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.unsharePipelineReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) UnsharePipelineCallCount() int {
+	fake.unsharePipelineMutex.RLock()
+	defer fake.unsharePipelineMutex.RUnlock()
+	return len(fake.unsharePipelineArgsForCall)
+}
+
+func (fake *FakeTeam) UnsharePipelineCalls(stub func(string) (bool, error)) {
+	fake.unsharePipelineMutex.Lock()
+	defer fake.unsharePipelineMutex.Unlock()
+	fake.UnsharePipelineStub = stub
+}
+
+func (fake *FakeTeam) UnsharePipelineArgsForCall(i int) string {
+	fake.unsharePipelineMutex.RLock()
+	defer fake.unsharePipelineMutex.RUnlock()
+	argsForCall := fake.unsharePipelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UnsharePipelineReturns(result1 bool, result2 error) {
+	fake.unsharePipelineMutex.Lock()
+	defer fake.unsharePipelineMutex.Unlock()
+	fake.UnsharePipelineStub = nil
+	fake.unsharePipelineReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnsharePipelineReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unsharePipelineMutex.Lock()
+	defer fake.unsharePipelineMutex.Unlock()
+	fake.UnsharePipelineStub = nil
+	if fake.unsharePipelineReturnsOnCall == nil {
+		fake.unsharePipelineReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unsharePipelineReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) VersionedResourceTypes(arg1 string) (atc.VersionedResourceTypes, bool, error) {
 	fake.versionedResourceTypesMutex.Lock()
 	ret, specificReturn := fake.versionedResourceTypesReturnsOnCall[len(fake.versionedResourceTypesArgsForCall)]
@@ -3779,6 +4262,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createJobBuildWithRequestMutex.RLock()
+	defer fake.createJobBuildWithRequestMutex.RUnlock()
 	fake.createOrUpdateMutex.RLock()
 	defer fake.createOrUpdateMutex.RUnlock()
 	fake.createOrUpdatePipelineConfigMutex.RLock()
@@ -3839,10 +4324,18 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renameTeamMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	fake.resourceVersionSpanMutex.RLock()
+	defer fake.resourceVersionSpanMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.restoreTeamMutex.RLock()
+	defer fake.restoreTeamMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
+	fake.sharePipelineMutex.RLock()
+	defer fake.sharePipelineMutex.RUnlock()
 	fake.teamMutex.RLock()
 	defer fake.teamMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
@@ -3851,6 +4344,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.unpausePipelineMutex.RUnlock()
 	fake.unpinResourceMutex.RLock()
 	defer fake.unpinResourceMutex.RUnlock()
+	fake.unsharePipelineMutex.RLock()
+	defer fake.unsharePipelineMutex.RUnlock()
 	fake.versionedResourceTypesMutex.RLock()
 	defer fake.versionedResourceTypesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -95,6 +95,22 @@ func (team *team) CreateOrUpdatePipelineConfig(pipelineName string, configVersio
 					Errors: validationErr.Errors,
 				}
 			}
+
+			if unexpectedResponseError.StatusCode == http.StatusConflict {
+				var conflictErr atc.SaveConfigResponse
+				err = json.Unmarshal([]byte(unexpectedResponseError.Body), &conflictErr)
+				if err != nil {
+					return false, false, []ConfigWarning{}, err
+				}
+
+				if conflictErr.Conflict != nil {
+					return false, false, []ConfigWarning{}, ConfigConflictError{
+						Errors:  conflictErr.Errors,
+						Version: conflictErr.Conflict.Version,
+						Diff:    conflictErr.Conflict.Diff,
+					}
+				}
+			}
 		}
 
 		return false, false, []ConfigWarning{}, err
@@ -317,5 +317,21 @@ var _ = Describe("ATC Handler Configs", func() {
 				})
 			})
 		})
+
+		Context("when setting config returns a conflict", func() {
+			BeforeEach(func() {
+				returnHeader = http.StatusConflict
+				returnBody = []byte(`{"errors":["fake-error"],"conflict":{"version":"43","diff":"+ groups:\n"}}`)
+			})
+
+			It("returns a conflict error with the current version and diff", func() {
+				_, _, _, err := team.CreateOrUpdatePipelineConfig(expectedPipelineName, expectedVersion, expectedConfig, checkCredentials)
+				Expect(err).To(Equal(concourse.ConfigConflictError{
+					Errors:  []string{"fake-error"},
+					Version: "43",
+					Diff:    "+ groups:\n",
+				}))
+			})
+		})
 	})
 })
@@ -34,3 +34,16 @@ type InvalidConfigError struct {
 func (c InvalidConfigError) Error() string {
 	return fmt.Sprintf("invalid pipeline config:\n%s", strings.Join(c.Errors, "\n"))
 }
+
+// ConfigConflictError is returned when saving a pipeline's config fails
+// because it changed since the version the config was based on. Diff shows
+// what the config would have overwritten.
+type ConfigConflictError struct {
+	Errors  []string
+	Version string
+	Diff    string
+}
+
+func (c ConfigConflictError) Error() string {
+	return fmt.Sprintf("pipeline config conflict:\n%s", strings.Join(c.Errors, "\n"))
+}
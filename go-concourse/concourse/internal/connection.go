@@ -197,10 +197,14 @@ func (connection *connection) populateResponse(response *http.Response, returnRe
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
 		body, _ := ioutil.ReadAll(response.Body)
 
+		var errorResponse atc.ErrorResponse
+		_ = json.Unmarshal(body, &errorResponse)
+
 		return UnexpectedResponseError{
 			StatusCode: response.StatusCode,
 			Status:     response.Status,
 			Body:       string(body),
+			Code:       errorResponse.Code,
 		}
 	}
 
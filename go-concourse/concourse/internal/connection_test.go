@@ -321,6 +321,38 @@ var _ = Describe("ATC Connection", func() {
 					Expect(ok).To(BeTrue())
 					Expect(ure.StatusCode).To(Equal(http.StatusInternalServerError))
 					Expect(ure.Body).To(Equal("problem"))
+					Expect(ure.Code).To(BeEmpty())
+				})
+			})
+
+			Describe("Non-2XX response with an error code", func() {
+				BeforeEach(func() {
+					atcServer = ghttp.NewServer()
+
+					connection = NewConnection(atcServer.URL(), nil, tracing)
+
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/builds/1/annotations"),
+							ghttp.RespondWithJSONEncoded(http.StatusBadRequest, atc.ErrorResponse{
+								Code:    atc.ErrorCodeQuotaExceeded,
+								Message: "builds may have at most 50 annotations",
+							}),
+						),
+					)
+				})
+
+				It("returns back UnexpectedResponseError with the code", func() {
+					err := connection.Send(Request{
+						RequestName: atc.AnnotateBuild,
+						Params:      rata.Params{"build_id": "1"},
+					}, nil)
+
+					Expect(err).To(HaveOccurred())
+					ure, ok := err.(UnexpectedResponseError)
+					Expect(ok).To(BeTrue())
+					Expect(ure.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ure.Code).To(Equal(atc.ErrorCodeQuotaExceeded))
 				})
 			})
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/google/jsonapi"
 )
 
@@ -12,6 +13,9 @@ type UnexpectedResponseError struct {
 	StatusCode int
 	Status     string
 	Body       string
+
+	// Code is set when the response is an atc.ErrorResponse.
+	Code atc.ErrorCode
 }
 
 func (e UnexpectedResponseError) Error() string {
@@ -84,3 +84,34 @@ func (p Page) QueryParams() url.Values {
 
 	return queryParams
 }
+
+// VersionSpan bounds a span of a resource's versions by version ID. Either
+// Since (exclusive) or From (inclusive) must be given; without Until
+// (exclusive) or To (inclusive) the span runs to the latest version.
+type VersionSpan struct {
+	Since int
+	From  int
+	Until int
+	To    int
+}
+
+func (s VersionSpan) QueryParams() url.Values {
+	queryParams := url.Values{}
+	if s.Since > 0 {
+		queryParams.Add("since", strconv.Itoa(s.Since))
+	}
+
+	if s.From > 0 {
+		queryParams.Add("from", strconv.Itoa(s.From))
+	}
+
+	if s.Until > 0 {
+		queryParams.Add("until", strconv.Itoa(s.Until))
+	}
+
+	if s.To > 0 {
+		queryParams.Add("to", strconv.Itoa(s.To))
+	}
+
+	return queryParams
+}
@@ -129,6 +129,34 @@ func (team *team) HidePipeline(pipelineName string) (bool, error) {
 	return team.managePipeline(pipelineName, atc.HidePipeline)
 }
 
+func (team *team) SharePipeline(pipelineName string) (string, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"team_name":     team.name,
+	}
+
+	var shared atc.SharedPipeline
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SharePipeline,
+		Params:      params,
+	}, &internal.Response{
+		Result: &shared,
+	})
+
+	switch err.(type) {
+	case nil:
+		return shared.Token, true, nil
+	case internal.ResourceNotFoundError:
+		return "", false, nil
+	default:
+		return "", false, err
+	}
+}
+
+func (team *team) UnsharePipeline(pipelineName string) (bool, error) {
+	return team.managePipeline(pipelineName, atc.UnsharePipeline)
+}
+
 func (team *team) managePipeline(pipelineName string, endpoint string) (bool, error) {
 	params := rata.Params{
 		"pipeline_name": pipelineName,
@@ -50,6 +50,32 @@ func (team *team) ResourceVersions(pipelineName string, resourceName string, pag
 	}
 }
 
+func (team *team) ResourceVersionSpan(pipelineName string, resourceName string, span VersionSpan) ([]atc.SpannedResourceVersion, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"resource_name": resourceName,
+		"team_name":     team.name,
+	}
+
+	var versions []atc.SpannedResourceVersion
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListResourceVersionSpan,
+		Params:      params,
+		Query:       span.QueryParams(),
+	}, &internal.Response{
+		Result: &versions,
+	})
+
+	switch err.(type) {
+	case nil:
+		return versions, true, nil
+	case internal.ResourceNotFoundError:
+		return versions, false, nil
+	default:
+		return versions, false, err
+	}
+}
+
 func (team *team) DisableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error) {
 	return team.sendResourceVersion(pipelineName, resourceName, resourceVersionID, atc.DisableResourceVersion)
 }
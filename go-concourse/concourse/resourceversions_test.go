@@ -250,6 +250,60 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 		})
 	})
 
+	Describe("ResourceVersionSpan", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/span"
+
+		var expectedVersions []atc.SpannedResourceVersion
+
+		BeforeEach(func() {
+			expectedVersions = []atc.SpannedResourceVersion{
+				{
+					ResourceVersion: atc.ResourceVersion{ID: 2, Version: atc.Version{"version": "v2"}},
+					InputTo:         []atc.Build{{ID: 1, Name: "1", JobName: "some-job"}},
+				},
+				{
+					ResourceVersion: atc.ResourceVersion{ID: 1, Version: atc.Version{"version": "v1"}},
+					InputTo:         []atc.Build{},
+				},
+			}
+		})
+
+		Context("when the versions are found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "since=1&to=2"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVersions),
+					),
+				)
+			})
+
+			It("returns the versions in the span with their builds", func() {
+				versions, found, err := team.ResourceVersionSpan("mypipeline", "myresource", concourse.VersionSpan{Since: 1, To: 2})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(versions).To(Equal(expectedVersions))
+			})
+		})
+
+		Context("when the resource or a bound of the span is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "from=1"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.ResourceVersionSpan("mypipeline", "myresource", concourse.VersionSpan{From: 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("DisableResourceVersion", func() {
 		var (
 			expectedStatus    int
@@ -0,0 +1,93 @@
+package concourse
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DownloadResumeAttempts is how many times an interrupted artifact download
+// is resumed before giving up.
+var DownloadResumeAttempts = 5
+
+// DownloadResumeInterval is how long to wait before resuming an interrupted
+// artifact download.
+var DownloadResumeInterval = time.Second
+
+// resumableDownload reads an artifact download, asking for the rest of it
+// from where it left off if it's interrupted, rather than starting it over.
+type resumableDownload struct {
+	open func(http.Header) (io.ReadCloser, http.Header, error)
+
+	current  io.ReadCloser
+	offset   int64
+	attempts int
+}
+
+// openResumableDownload starts a download with open, which is passed the
+// headers to send and returns the body and headers of the response. It's
+// only resumed if the ATC says it accepts ranges of it.
+func openResumableDownload(open func(http.Header) (io.ReadCloser, http.Header, error)) (io.ReadCloser, error) {
+	current, headers, err := open(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if headers.Get("Accept-Ranges") != "bytes" {
+		return current, nil
+	}
+
+	return &resumableDownload{
+		open:    open,
+		current: current,
+	}, nil
+}
+
+func (d *resumableDownload) Read(p []byte) (int, error) {
+	for {
+		n, err := d.current.Read(p)
+		d.offset += int64(n)
+
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+
+		resumeErr := d.resume(err)
+		if resumeErr != nil {
+			return n, resumeErr
+		}
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (d *resumableDownload) Close() error {
+	return d.current.Close()
+}
+
+func (d *resumableDownload) resume(cause error) error {
+	for d.attempts < DownloadResumeAttempts {
+		d.attempts++
+
+		_ = d.current.Close()
+
+		time.Sleep(DownloadResumeInterval)
+
+		next, _, err := d.open(http.Header{
+			"Range": {fmt.Sprintf("bytes=%d-", d.offset)},
+		})
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		d.current = next
+
+		return nil
+	}
+
+	return cause
+}
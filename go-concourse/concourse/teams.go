@@ -117,6 +117,25 @@ func (team *team) RenameTeam(teamName, name string) (bool, error) {
 	}
 }
 
+// RestoreTeam restores the deleted team with the name given as argument. It
+// returns false if there is no such deleted team, e.g. because it has been
+// purged already.
+func (team *team) RestoreTeam(teamName string) (bool, error) {
+	params := rata.Params{"team_name": teamName}
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.RestoreTeam,
+		Params:      params,
+	}, nil)
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
 func (client *client) ListTeams() ([]atc.Team, error) {
 	var teams []atc.Team
 	err := client.connection.Send(internal.Request{
@@ -154,6 +154,53 @@ var _ = Describe("ATC Handler Teams", func() {
 		})
 	})
 
+	Describe("Restore", func() {
+		var (
+			restored bool
+			err      error
+		)
+
+		BeforeEach(func() {
+			team = client.Team("not-super-important")
+		})
+
+		JustBeforeEach(func() {
+			restored, err = team.RestoreTeam("enron")
+		})
+
+		Context("when the server restores the team", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/enron/restore"),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restored).To(BeTrue())
+			})
+		})
+
+		Context("when the deleted team is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/enron/restore"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restored).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Destroy", func() {
 		var (
 			expectedURL string
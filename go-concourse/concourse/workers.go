@@ -92,3 +92,25 @@ func (client *client) LandWorker(workerName string) error {
 
 	return err
 }
+
+func (client *client) ListWorkerDiscrepancies(workerName string) ([]atc.WorkerDiscrepancy, error) {
+	var discrepancies []atc.WorkerDiscrepancy
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListWorkerDiscrepancies,
+		Params:      rata.Params{"worker_name": workerName},
+	}, &internal.Response{
+		Result: &discrepancies,
+	})
+	return discrepancies, err
+}
+
+func (client *client) ResolveWorkerDiscrepancies(workerName string) (int, error) {
+	var response atc.ResolveWorkerDiscrepanciesResponseBody
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ResolveWorkerDiscrepancies,
+		Params:      rata.Params{"worker_name": workerName},
+	}, &internal.Response{
+		Result: &response,
+	})
+	return response.Resolved, err
+}
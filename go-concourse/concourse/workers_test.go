@@ -127,6 +127,62 @@ var _ = Describe("ATC Handler Workers", func() {
 		})
 	})
 
+	Describe("ListWorkerDiscrepancies", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/workers/some-worker/discrepancies"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.WorkerDiscrepancy{
+						{Type: "volume", Handle: "some-handle", Problem: atc.DiscrepancyUnknownToDB, Heartbeats: 2},
+					}),
+				),
+			)
+		})
+
+		It("returns the worker's discrepancies", func() {
+			discrepancies, err := client.ListWorkerDiscrepancies("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(discrepancies).To(Equal([]atc.WorkerDiscrepancy{
+				{Type: "volume", Handle: "some-handle", Problem: atc.DiscrepancyUnknownToDB, Heartbeats: 2},
+			}))
+		})
+	})
+
+	Describe("ResolveWorkerDiscrepancies", func() {
+		Context("when succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/discrepancies/resolve"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ResolveWorkerDiscrepanciesResponseBody{Resolved: 3}),
+					),
+				)
+			})
+
+			It("returns the number of discrepancies resolved", func() {
+				resolved, err := client.ResolveWorkerDiscrepancies("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resolved).To(Equal(3))
+			})
+		})
+
+		Context("when the worker is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/discrepancies/resolve"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.ResolveWorkerDiscrepancies("some-worker")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("LandWorker", func() {
 		Context("when succeeds", func() {
 			BeforeEach(func() {
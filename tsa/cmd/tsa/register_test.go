@@ -136,16 +136,16 @@ var _ = Describe("Register", func() {
 
 			gardenStubs <- func() ([]garden.Container, error) {
 				return []garden.Container{
-					new(gfakes.FakeContainer),
-					new(gfakes.FakeContainer),
-					new(gfakes.FakeContainer),
+					fakeContainer("container-a"),
+					fakeContainer("container-b"),
+					fakeContainer("container-c"),
 				}, nil
 			}
 
 			gardenStubs <- func() ([]garden.Container, error) {
 				return []garden.Container{
-					new(gfakes.FakeContainer),
-					new(gfakes.FakeContainer),
+					fakeContainer("container-a"),
+					fakeContainer("container-b"),
 				}, nil
 			}
 
@@ -155,7 +155,7 @@ var _ = Describe("Register", func() {
 
 			gardenStubs <- func() ([]garden.Container, error) {
 				return []garden.Container{
-					new(gfakes.FakeContainer),
+					fakeContainer("container-a"),
 				}, nil
 			}
 
@@ -278,6 +278,13 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 2
 			expectedWorkerPayload.ActiveVolumes = 1
+			expectedWorkerPayload.Census = &atc.WorkerCensus{
+				Containers: []atc.CensusContainer{
+					{Handle: "container-a"},
+					{Handle: "container-b"},
+				},
+				Volumes: []string{"handle-a"},
+			}
 			Expect(registration.worker).To(Equal(expectedWorkerPayload))
 
 			By("heartbeating a forwarded garden address")
@@ -309,6 +316,12 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 1
 			expectedWorkerPayload.ActiveVolumes = 0
+			expectedWorkerPayload.Census = &atc.WorkerCensus{
+				Containers: []atc.CensusContainer{
+					{Handle: "container-a"},
+				},
+				Volumes: []string{},
+			}
 			Expect(registration.worker).To(Equal(expectedWorkerPayload))
 
 			By("having heartbeated after another interval passed")
@@ -581,3 +594,9 @@ var _ = Describe("Register", func() {
 		})
 	})
 })
+
+func fakeContainer(handle string) garden.Container {
+	container := new(gfakes.FakeContainer)
+	container.HandleReturns(handle)
+	return container
+}
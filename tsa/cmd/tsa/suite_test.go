@@ -21,6 +21,7 @@ import (
 	"code.cloudfoundry.org/localip"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/tsa"
 	jwt "github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
@@ -144,7 +145,7 @@ var _ = BeforeEach(func() {
 	signingKey, err := jwt.ParseRSAPrivateKeyFromPEM(rsaKeyBlob)
 	Expect(err).NotTo(HaveOccurred())
 
-	accessFactory = accessor.NewAccessFactory(&signingKey.PublicKey)
+	accessFactory = accessor.NewAccessFactory(&signingKey.PublicKey, new(accessorfakes.FakeTokenRevocations))
 
 	tsaCommand := exec.Command(
 		tsaPath,
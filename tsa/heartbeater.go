@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -118,6 +119,9 @@ func (heartbeater *Heartbeater) register(logger lager.Logger) bool {
 		return false
 	}
 
+	// the census is only reconciled on heartbeats
+	registration.Census = nil
+
 	payload, err := json.Marshal(registration)
 	if err != nil {
 		logger.Error("failed-to-marshal-registration", err)
@@ -297,10 +301,50 @@ func (heartbeater *Heartbeater) pingWorker(logger lager.Logger) (atc.Worker, boo
 
 	registration.ActiveContainers = len(containers)
 	registration.ActiveVolumes = len(volumes)
+	registration.Census = heartbeater.census(logger, containers, volumes)
 
 	return registration, true
 }
 
+// census lists the handles of the worker's containers and volumes, along with
+// the build each container was created for, if any.
+func (heartbeater *Heartbeater) census(logger lager.Logger, containers []garden.Container, volumes []baggageclaim.Volume) *atc.WorkerCensus {
+	census := &atc.WorkerCensus{
+		Containers: []atc.CensusContainer{},
+		Volumes:    []string{},
+	}
+
+	handles := []string{}
+	for _, container := range containers {
+		handles = append(handles, container.Handle())
+	}
+
+	var infos map[string]garden.ContainerInfoEntry
+	if len(handles) > 0 {
+		var err error
+		infos, err = heartbeater.gardenClient.BulkInfo(handles)
+		if err != nil {
+			logger.Error("failed-to-get-container-info", err)
+		}
+	}
+
+	for _, handle := range handles {
+		container := atc.CensusContainer{Handle: handle}
+
+		if info, found := infos[handle]; found && info.Err == nil {
+			container.BuildID, _ = strconv.Atoi(info.Info.Properties[atc.ContainerBuildIDProperty])
+		}
+
+		census.Containers = append(census.Containers, container)
+	}
+
+	for _, volume := range volumes {
+		census.Volumes = append(census.Volumes, volume.Handle())
+	}
+
+	return census
+}
+
 func (heartbeater *Heartbeater) ttl() time.Duration {
 	return heartbeater.interval * 2
 }
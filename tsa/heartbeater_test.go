@@ -195,17 +195,29 @@ var _ = Describe("Heartbeater", func() {
 				new(baggageclaimfakes.FakeVolume),
 			}
 
+			buildContainer := new(gardenfakes.FakeContainer)
+			buildContainer.HandleReturns("build-container")
+
+			checkContainer := new(gardenfakes.FakeContainer)
+			checkContainer.HandleReturns("check-container")
+
+			someVolume := new(baggageclaimfakes.FakeVolume)
+			someVolume.HandleReturns("some-volume")
+
+			otherVolume := new(baggageclaimfakes.FakeVolume)
+			otherVolume.HandleReturns("other-volume")
+
 			containers <- []garden.Container{
-				new(gardenfakes.FakeContainer),
-				new(gardenfakes.FakeContainer),
+				buildContainer,
+				checkContainer,
 				new(gardenfakes.FakeContainer),
 				new(gardenfakes.FakeContainer),
 				new(gardenfakes.FakeContainer),
 			}
 
 			volumes <- []baggageclaim.Volume{
-				new(baggageclaimfakes.FakeVolume),
-				new(baggageclaimfakes.FakeVolume),
+				someVolume,
+				otherVolume,
 			}
 
 			containers <- []garden.Container{
@@ -237,6 +249,19 @@ var _ = Describe("Heartbeater", func() {
 			fakeBaggageclaimClient.ListVolumesStub = func(lager.Logger, baggageclaim.VolumeProperties) (baggageclaim.Volumes, error) {
 				return <-volumes, nil
 			}
+
+			fakeGardenClient.BulkInfoReturns(map[string]garden.ContainerInfoEntry{
+				"build-container": {
+					Info: garden.ContainerInfo{
+						Properties: garden.Properties{atc.ContainerBuildIDProperty: "42"},
+					},
+				},
+				"check-container": {
+					Info: garden.ContainerInfo{
+						Properties: garden.Properties{},
+					},
+				},
+			}, nil)
 		})
 
 		Context("when the ATC responds to registration requests", func() {
@@ -258,9 +283,29 @@ var _ = Describe("Heartbeater", func() {
 					fakeClock.WaitForWatcherAndIncrement(interval)
 					expectedWorker.ActiveContainers = 5
 					expectedWorker.ActiveVolumes = 2
+					expectedWorker.Census = &atc.WorkerCensus{
+						Containers: []atc.CensusContainer{
+							{Handle: "build-container", BuildID: 42},
+							{Handle: "check-container"},
+							{Handle: ""},
+							{Handle: ""},
+							{Handle: ""},
+						},
+						Volumes: []string{"some-volume", "other-volume"},
+					}
 					Eventually(heartbeats).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
 				})
 
+				It("looks up the build of each container for the census", func() {
+					Eventually(registrations).Should(Receive())
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+					Eventually(heartbeats).Should(Receive())
+
+					Expect(fakeGardenClient.BulkInfoCallCount()).To(BeNumerically(">=", 2))
+					Expect(fakeGardenClient.BulkInfoArgsForCall(1)).To(Equal([]string{"build-container", "check-container", "", "", ""}))
+				})
+
 				It("emits events", func() {
 					Eventually(registrations).Should(Receive())
 
@@ -334,6 +379,10 @@ var _ = Describe("Heartbeater", func() {
 				fakeClock.WaitForWatcherAndIncrement(cprInterval)
 				expectedWorker.ActiveContainers = 4
 				expectedWorker.ActiveVolumes = 1
+				expectedWorker.Census = &atc.WorkerCensus{
+					Containers: []atc.CensusContainer{{}, {}, {}, {}},
+					Volumes:    []string{""},
+				}
 				Eventually(heartbeats).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
 			})
 
@@ -352,6 +401,10 @@ var _ = Describe("Heartbeater", func() {
 				fakeClock.WaitForWatcherAndIncrement(interval - cprInterval)
 				expectedWorker.ActiveContainers = 3
 				expectedWorker.ActiveVolumes = 0
+				expectedWorker.Census = &atc.WorkerCensus{
+					Containers: []atc.CensusContainer{{}, {}, {}},
+					Volumes:    []string{},
+				}
 				Eventually(heartbeats).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
 			})
 		})
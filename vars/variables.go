@@ -10,3 +10,23 @@ type VariableDefinition struct {
 	Type    string
 	Options interface{}
 }
+
+// SecretIdentity identifies the secret a var was read from.
+type SecretIdentity struct {
+	// Path is the path of the secret in the credential manager.
+	Path string
+
+	// Version is the version of the secret reported by the credential
+	// manager, if it reports versions.
+	Version string
+}
+
+// SecretVariables are Variables read from a credential manager, which can
+// tell which secret each var was read from.
+type SecretVariables interface {
+	Variables
+
+	// GetSecret is like Get, but also identifies the secret the var was read
+	// from.
+	GetSecret(VariableDefinition) (interface{}, SecretIdentity, bool, error)
+}
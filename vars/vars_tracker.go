@@ -20,7 +20,7 @@ type CredVarsTracker interface {
 
 func NewCredVarsTracker(credVars Variables, on bool) CredVarsTracker {
 	if on {
-		return credVarsTracker{
+		return &credVarsTracker{
 			credVars:          credVars,
 			interpolatedCreds: map[string]string{},
 			lock:              sync.RWMutex{},
@@ -38,7 +38,7 @@ type credVarsTracker struct {
 	lock sync.RWMutex
 }
 
-func (t credVarsTracker) Get(varDef VariableDefinition) (interface{}, bool, error) {
+func (t *credVarsTracker) Get(varDef VariableDefinition) (interface{}, bool, error) {
 	val, found, err := t.credVars.Get(varDef)
 	if found {
 		t.lock.Lock()
@@ -49,7 +49,18 @@ func (t credVarsTracker) Get(varDef VariableDefinition) (interface{}, bool, erro
 	return val, found, err
 }
 
-func (t credVarsTracker) track(name string, val interface{}) {
+func (t *credVarsTracker) GetSecret(varDef VariableDefinition) (interface{}, SecretIdentity, bool, error) {
+	val, identity, found, err := getSecret(t.credVars, varDef)
+	if found {
+		t.lock.Lock()
+		t.track(varDef.Name, val)
+		t.lock.Unlock()
+	}
+
+	return val, identity, found, err
+}
+
+func (t *credVarsTracker) track(name string, val interface{}) {
 	switch v := val.(type) {
 	case map[interface{}]interface{}:
 		for kk, vv := range v {
@@ -68,11 +79,11 @@ func (t credVarsTracker) track(name string, val interface{}) {
 	}
 }
 
-func (t credVarsTracker) List() ([]VariableDefinition, error) {
+func (t *credVarsTracker) List() ([]VariableDefinition, error) {
 	return t.credVars.List()
 }
 
-func (t credVarsTracker) IterateInterpolatedCreds(iter CredVarsTrackerIterator) {
+func (t *credVarsTracker) IterateInterpolatedCreds(iter CredVarsTrackerIterator) {
 	t.lock.RLock()
 	for k, v := range t.interpolatedCreds {
 		iter.YieldCred(k, v)
@@ -90,6 +101,10 @@ func (t dummyCredVarsTracker) Get(varDef VariableDefinition) (interface{}, bool,
 	return t.credVars.Get(varDef)
 }
 
+func (t dummyCredVarsTracker) GetSecret(varDef VariableDefinition) (interface{}, SecretIdentity, bool, error) {
+	return getSecret(t.credVars, varDef)
+}
+
 func (t dummyCredVarsTracker) List() ([]VariableDefinition, error) {
 	return t.credVars.List()
 }
@@ -98,6 +113,17 @@ func (t dummyCredVarsTracker) IterateInterpolatedCreds(iter CredVarsTrackerItera
 	// do nothing
 }
 
+// getSecret reads the var from the secret it comes from if the variables know
+// which one that is, and otherwise identifies it by the var's name.
+func getSecret(credVars Variables, varDef VariableDefinition) (interface{}, SecretIdentity, bool, error) {
+	if secrets, ok := credVars.(SecretVariables); ok {
+		return secrets.GetSecret(varDef)
+	}
+
+	val, found, err := credVars.Get(varDef)
+	return val, SecretIdentity{Path: varDef.Name}, found, err
+}
+
 // MapCredVarsTrackerIterator implements a simple CredVarsTrackerIterator which just
 // populate interpolated secrets into a map. This could be useful in unit test.
 
@@ -40,6 +40,23 @@ var _ = Describe("vars_tracker", func() {
 			})
 		})
 
+		Describe("GetSecret", func() {
+			It("identifies vars not read from secrets by their name", func() {
+				val, identity, found, err := tracker.(SecretVariables).GetSecret(VariableDefinition{Name: "k1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("v1"))
+				Expect(identity).To(Equal(SecretIdentity{Path: "k1"}))
+			})
+
+			It("tracks the fetched variables", func() {
+				tracker.(SecretVariables).GetSecret(VariableDefinition{Name: "k2"})
+				mapit := NewMapCredVarsTrackerIterator()
+				tracker.IterateInterpolatedCreds(mapit)
+				Expect(mapit.Data["k2"]).To(Equal("v2"))
+			})
+		})
+
 		Describe("List", func() {
 			It("returns list of names from multiple vars with duplicates", func() {
 				defs, err := tracker.List()
@@ -7,9 +7,10 @@ import (
 )
 
 type WorkerConfig struct {
-	Name     string   `long:"name"  description:"The name to set for the worker during registration. If not specified, the hostname will be used."`
-	Tags     []string `long:"tag"   description:"A tag to set during registration. Can be specified multiple times."`
-	TeamName string   `long:"team"  description:"The name of the team that this worker will be assigned to."`
+	Name     string            `long:"name"  description:"The name to set for the worker during registration. If not specified, the hostname will be used."`
+	Tags     []string          `long:"tag"   description:"A tag to set during registration. Can be specified multiple times."`
+	Labels   map[string]string `long:"label" description:"A label to set during registration, which steps can select the worker by. Can be specified multiple times." value-name:"NAME:VALUE"`
+	TeamName string            `long:"team"  description:"The name of the team that this worker will be assigned to."`
 
 	HTTPProxy  string `long:"http-proxy"  env:"http_proxy"                  description:"HTTP proxy endpoint to use for containers."`
 	HTTPSProxy string `long:"https-proxy" env:"https_proxy"                 description:"HTTPS proxy endpoint to use for containers."`
@@ -17,12 +18,19 @@ type WorkerConfig struct {
 
 	Ephemeral bool `long:"ephemeral" description:"If set, the worker will be immediately removed upon stalling."`
 
+	GPUs int `long:"gpus" description:"Number of NVIDIA GPUs to advertise. Tasks requesting GPUs are allocated devices /dev/nvidia0 through /dev/nvidia<N-1>, which are given to their containers by the --garden-gpu-runtime."`
+
+	StreamEncoding string `long:"stream-encoding" default:"zstd" choice:"zstd" choice:"gzip" description:"Compression used for volumes streamed to and from this worker. Use gzip if the worker's baggageclaim cannot stream zstd."`
+
+	DisableCapabilities []string `long:"disable-capability" value-name:"CAPABILITY" description:"Capability which the ATC should not rely on this worker for, e.g. zstd-streaming or gpus. Can be specified multiple times."`
+
 	Version string `long:"version" hidden:"true" description:"Version of the worker. This is normally baked in to the binary, so this flag is hidden."`
 }
 
 func (c WorkerConfig) Worker() atc.Worker {
 	return atc.Worker{
 		Tags:          c.Tags,
+		Labels:        c.Labels,
 		Team:          c.TeamName,
 		Name:          c.Name,
 		StartTime:     time.Now().Unix(),
@@ -31,5 +39,31 @@ func (c WorkerConfig) Worker() atc.Worker {
 		HTTPSProxyURL: c.HTTPSProxy,
 		NoProxy:       c.NoProxy,
 		Ephemeral:     c.Ephemeral,
+		GPUs:          c.GPUs,
+
+		StreamEncoding: c.StreamEncoding,
+
+		ProtocolVersion: atc.WorkerProtocolVersion,
+		Capabilities:    c.capabilities(),
 	}
 }
+
+func (c WorkerConfig) capabilities() []atc.WorkerCapability {
+	capabilities := []atc.WorkerCapability{}
+
+	for _, capability := range atc.WorkerCapabilities() {
+		disabled := false
+		for _, name := range c.DisableCapabilities {
+			if name == string(capability) {
+				disabled = true
+				break
+			}
+		}
+
+		if !disabled {
+			capabilities = append(capabilities, capability)
+		}
+	}
+
+	return capabilities
+}
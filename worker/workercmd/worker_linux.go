@@ -32,6 +32,8 @@ type GardenBackend struct {
 	GDN          string    `long:"bin"    default:"gdn" description:"Path to 'gdn' executable (or leave as 'gdn' to find it in $PATH)."`
 	GardenConfig flag.File `long:"config"               description:"Path to a config file to use for Garden. You can also specify Garden flags as env vars, e.g. 'CONCOURSE_GARDEN_FOO_BAR=a,b' for '--foo-bar a --foo-bar b'."`
 
+	GPURuntime string `long:"gpu-runtime" default:"nvidia-container-runtime" description:"Path to the OCI runtime to run containers with when the worker advertises GPUs. It must give containers the devices of the GPUs listed in their NVIDIA_VISIBLE_DEVICES env var."`
+
 	DNS DNSConfig `group:"DNS Proxy Configuration" namespace:"dns-proxy"`
 }
 
@@ -118,6 +120,10 @@ func (cmd *WorkerCommand) gdnRunner(logger lager.Logger) (ifrit.Runner, error) {
 
 	gdnServerFlags = append(gdnServerFlags, detectGardenFlags(logger)...)
 
+	if cmd.Worker.GPUs > 0 {
+		gdnServerFlags = append(gdnServerFlags, "--runtime-plugin", cmd.Garden.GPURuntime)
+	}
+
 	if cmd.Garden.DNS.Enable {
 		dnsProxyRunner, err := cmd.dnsProxyRunner(logger.Session("dns-proxy"))
 		if err != nil {